@@ -10,6 +10,10 @@ class FightClubAdmin {
         this.streamStats = null;
         this.reconnectAttempts = 0;
         this.maxReconnectAttempts = 5;
+        this.events = null;
+        this.liveHistory = [];
+        this.maxLiveHistory = 60;
+        this.errors = [];
 
         this.init();
     }
@@ -17,9 +21,61 @@ class FightClubAdmin {
     init() {
         this.bindEvents();
         this.connectWebSocket();
+        this.connectEvents();
+        this.startPreview();
         this.startPolling();
     }
 
+    // Live thumbnail of the rendered stream (MJPEG from the streamer debug
+    // server, localhost:6062 by default; override with localStorage.previewUrl)
+    startPreview() {
+        const img = document.getElementById('stream-preview');
+        if (!img) return;
+
+        const url = localStorage.getItem('previewUrl') ||
+            `http://${location.hostname || 'localhost'}:6062/api/preview.mjpeg`;
+        img.onload = () => { img.hidden = false; };
+        img.onerror = () => {
+            img.hidden = true;
+            setTimeout(() => { img.src = `${url}?t=${Date.now()}`; }, 10000); // Streamer restarted?
+        };
+        img.src = url;
+    }
+
+    // Live metrics via server-sent events (EventSource reconnects on its own)
+    connectEvents() {
+        if (!window.EventSource) return;
+
+        this.events = new EventSource('/api/admin/events');
+        this.events.onopen = () => this.updateLiveStatus(true);
+        this.events.onerror = () => this.updateLiveStatus(false);
+
+        this.events.addEventListener('stats', (event) => {
+            try {
+                this.updateLiveStats(JSON.parse(event.data));
+            } catch (e) {
+                console.error('Failed to parse live stats:', e);
+            }
+        });
+
+        this.events.addEventListener('error', (event) => {
+            // Connection errors fire 'error' too, without data
+            if (!event.data) return;
+            try {
+                this.addError(JSON.parse(event.data));
+            } catch (e) {
+                console.error('Failed to parse error event:', e);
+            }
+        });
+    }
+
+    updateLiveStatus(connected) {
+        const status = document.getElementById('live-status');
+        if (status) {
+            status.textContent = connected ? '🟢 live' : '🔴 reconnecting...';
+        }
+    }
+
     // WebSocket connection
     connectWebSocket() {
         const protocol = window.location.protocol === 'https:' ? 'wss:' : 'ws:';
@@ -89,8 +145,10 @@ class FightClubAdmin {
     startPolling() {
         this.fetchStats();
         this.fetchKickStatus();
+        this.fetchBans();
         setInterval(() => this.fetchStats(), 5000);
         setInterval(() => this.fetchKickStatus(), 5000);
+        setInterval(() => this.fetchBans(), 15000);
     }
 
     async fetchKickStatus() {
@@ -136,6 +194,151 @@ class FightClubAdmin {
         }
     }
 
+    async fetchBans() {
+        try {
+            const response = await fetch('/api/admin/bans');
+            if (!response.ok) return; // Ban list disabled
+            const data = await response.json();
+            this.renderBans(data.bans || []);
+        } catch (e) {
+            console.error('Failed to fetch bans:', e);
+        }
+    }
+
+    renderBans(bans) {
+        const container = document.getElementById('ban-list');
+        if (!container) return;
+
+        if (bans.length === 0) {
+            container.innerHTML = '<p style="color: #666; text-align: center;">No bans</p>';
+            return;
+        }
+        container.innerHTML = bans.map(b => `
+            <div class="player-item">
+                <span class="name">${this.escapeHtml(b.username)} <span style="color: #666;">#${b.userId}</span></span>
+                <span style="color: #aaa; flex: 1;">${this.escapeHtml(b.reason || 'No reason')}</span>
+                <span style="color: #888;">${b.expiresAt ? 'until ' + new Date(b.expiresAt).toLocaleString() : 'permanent'}</span>
+                <button class="unban-btn" data-user="${b.userId}">Unban</button>
+            </div>
+        `).join('');
+    }
+
+    // Parses 30m / 2h / 7d into seconds (0 = permanent, null = invalid)
+    parseBanDuration(text) {
+        if (!text) return 0;
+        const match = text.match(/^(\d+)\s*([smhd])$/);
+        if (!match) return null;
+        return parseInt(match[1]) * { s: 1, m: 60, h: 3600, d: 86400 }[match[2]];
+    }
+
+    updateLiveStats(data) {
+        const engine = data.engine || {};
+        const tick = engine.tick || {};
+        const queue = data.queue;
+        const stream = data.stream || {};
+
+        this.updateStats({ ...engine, streaming: data.streaming });
+
+        const tickAvg = document.getElementById('tick-avg');
+        const queuePending = document.getElementById('queue-pending');
+        const streamFps = document.getElementById('stream-fps');
+
+        if (tickAvg) {
+            tickAvg.textContent = tick.ticks ? `${tick.avgMs.toFixed(1)} / ${tick.maxMs.toFixed(1)}` : '-';
+            const shed = tick.loadShed && tick.loadShed.level !== 'none' ? tick.loadShed.level : '';
+            tickAvg.style.color = tick.maxMs > tick.budgetMs ? '#ff6b6b' : shed ? '#ffd93d' : '#4ecdc4';
+            tickAvg.title = tick.tickRate
+                ? `${tick.tickRate} TPS, ${tick.dropped || 0} ticks dropped` + (shed ? `, shedding: ${shed}` : '')
+                : '';
+        }
+        if (queuePending) {
+            queuePending.textContent = queue ? `${queue.pending}/${queue.buffer_size}` : '-';
+        }
+        if (streamFps) {
+            streamFps.textContent = data.streaming && stream.fps ? Number(stream.fps).toFixed(0) : '-';
+        }
+
+        this.liveHistory.push({
+            avg: tick.avgMs || 0,
+            max: tick.maxMs || 0,
+            budget: tick.budgetMs || 0,
+            players: engine.playerCount || 0,
+        });
+        if (this.liveHistory.length > this.maxLiveHistory) {
+            this.liveHistory.shift();
+        }
+        this.renderLiveGraph();
+    }
+
+    renderLiveGraph() {
+        const canvas = document.getElementById('live-graph');
+        if (!canvas) return;
+
+        canvas.width = canvas.clientWidth;
+        const ctx = canvas.getContext('2d');
+        const w = canvas.width;
+        const h = canvas.height;
+        const history = this.liveHistory;
+        ctx.clearRect(0, 0, w, h);
+        if (history.length < 2) return;
+
+        // Tick times share a ms scale (at least the tick budget); players have their own
+        const msScale = Math.max(...history.map(p => Math.max(p.max, p.budget)), 1);
+        const playerScale = Math.max(...history.map(p => p.players), 10);
+        const step = w / (this.maxLiveHistory - 1);
+        const offset = (this.maxLiveHistory - history.length) * step;
+
+        const line = (color, value, scale) => {
+            ctx.strokeStyle = color;
+            ctx.lineWidth = 2;
+            ctx.beginPath();
+            history.forEach((p, i) => {
+                const x = offset + i * step;
+                const y = h - 5 - (value(p) / scale) * (h - 10);
+                if (i === 0) ctx.moveTo(x, y); else ctx.lineTo(x, y);
+            });
+            ctx.stroke();
+        };
+
+        // Tick budget (dashed)
+        const budget = history[history.length - 1].budget;
+        if (budget) {
+            const y = h - 5 - (budget / msScale) * (h - 10);
+            ctx.strokeStyle = 'rgba(255, 255, 255, 0.2)';
+            ctx.setLineDash([4, 4]);
+            ctx.beginPath();
+            ctx.moveTo(0, y);
+            ctx.lineTo(w, y);
+            ctx.stroke();
+            ctx.setLineDash([]);
+        }
+
+        line('#ffd93d', p => p.players, playerScale);
+        line('#ff6b6b', p => p.max, msScale);
+        line('#4ecdc4', p => p.avg, msScale);
+    }
+
+    addError(entry) {
+        this.errors.unshift(entry);
+        this.errors = this.errors.slice(0, 50);
+
+        const container = document.getElementById('error-list');
+        if (!container) return;
+
+        container.innerHTML = this.errors.map(e => `
+            <div style="padding: 4px 0; border-bottom: 1px solid rgba(255,255,255,0.05);">
+                <span style="color: #888;">${new Date(e.time).toLocaleTimeString()}</span>
+                <span style="color: #ff6b6b;">${this.escapeHtml(e.message)}</span>
+            </div>
+        `).join('');
+    }
+
+    escapeHtml(text) {
+        const div = document.createElement('div');
+        div.textContent = text;
+        return div.innerHTML;
+    }
+
     // UI Updates
     updateGameState(data) {
         this.players = data.players || [];
@@ -188,7 +391,7 @@ class FightClubAdmin {
         container.innerHTML = sorted.slice(0, 20).map((p, i) => `
             <div class="player-item ${p.isDead ? 'dead' : 'alive'}">
                 <span class="rank">#${i + 1}</span>
-                <span class="name" style="color: ${p.color}">${p.name}</span>
+                <span class="name" style="color: ${p.color}">${this.escapeHtml(p.displayName || p.name)}</span>
                 <span class="kills">⚔️ ${p.kills}</span>
                 <span class="money">💰 $${p.money}</span>
                 <span class="hp">❤️ ${p.hp}/${p.maxHp}</span>
@@ -275,6 +478,49 @@ class FightClubAdmin {
                 }
             }
 
+            // Ban a viewer
+            if (e.target.id === 'ban-btn') {
+                const user = document.getElementById('ban-user').value.trim();
+                const durationSeconds = this.parseBanDuration(document.getElementById('ban-duration').value.trim());
+                const reason = document.getElementById('ban-reason').value.trim();
+                if (!user) return;
+                if (durationSeconds === null) {
+                    alert('Duration must look like 30m, 2h or 7d');
+                    return;
+                }
+
+                const body = /^\d+$/.test(user)
+                    ? { userId: parseInt(user), reason, durationSeconds }
+                    : { username: user, reason, durationSeconds };
+                try {
+                    const response = await fetch('/api/admin/ban', {
+                        method: 'POST',
+                        headers: { 'Content-Type': 'application/json' },
+                        body: JSON.stringify(body)
+                    });
+                    const data = await response.json();
+                    if (!response.ok) {
+                        alert('Failed: ' + (data.error || 'Unknown error'));
+                        return;
+                    }
+                    document.getElementById('ban-user').value = '';
+                    document.getElementById('ban-reason').value = '';
+                    this.fetchBans();
+                } catch (err) {
+                    console.error('Failed to ban:', err);
+                }
+            }
+
+            // Lift a ban
+            if (e.target.classList.contains('unban-btn')) {
+                try {
+                    await fetch(`/api/admin/ban/${encodeURIComponent(e.target.dataset.user)}`, { method: 'DELETE' });
+                    this.fetchBans();
+                } catch (err) {
+                    console.error('Failed to unban:', err);
+                }
+            }
+
             // Batch Add Players
             if (e.target.classList.contains('batch-btn')) {
                 const count = parseInt(e.target.getAttribute('data-count'));
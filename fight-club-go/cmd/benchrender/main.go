@@ -0,0 +1,242 @@
+// =============================================================================
+// FIGHT CLUB - RENDER BENCHMARK
+// =============================================================================
+// Sizes a machine before going live: runs a local engine with N synthetic
+// fighters, renders M frames through the real StreamManager pipeline (same
+// renderer, encoder settings and FFmpeg process as the streamer) into FFmpeg's
+// null muxer, then prints frame-time percentiles and the encoder speed.
+//
+// USAGE:
+//
+//	go run ./cmd/benchrender                           # 50 players, 600 frames, stream settings from .env
+//	go run ./cmd/benchrender -players 200 -frames 1800
+//	go run ./cmd/benchrender -renderer atlas -profile 1080p60-quality
+//	go run ./cmd/benchrender -nvenc                    # NVIDIA hardware encoding
+//
+// =============================================================================
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"fight-club/internal/config"
+	"fight-club/internal/game"
+	"fight-club/internal/profiling"
+	"fight-club/internal/streaming"
+
+	"github.com/joho/godotenv"
+)
+
+func main() {
+	// Load environment (stream size, renderer, theme) like the streamer
+	if err := godotenv.Load("../.env"); err != nil {
+		_ = godotenv.Load(".env")
+	}
+	if _, err := config.LoadFile(); err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+	videoCfg := config.VideoFromEnv()
+
+	players := flag.Int("players", 50, "synthetic fighters kept in the arena")
+	frames := flag.Int("frames", 600, "frames to measure")
+	warmup := flag.Int("warmup", 0, "frames rendered before measuring (default one second of frames)")
+	width := flag.Int("width", videoCfg.Width, "stream width")
+	height := flag.Int("height", videoCfg.Height, "stream height")
+	fps := flag.Int("fps", videoCfg.FPS, "stream frames per second")
+	bitrate := flag.Int("bitrate", videoCfg.Bitrate, "stream bitrate (kbps)")
+	renderer := flag.String("renderer", getEnvWithDefault("STREAM_RENDERER", streaming.RendererGG), "frame renderer (gg or atlas)")
+	profile := flag.String("profile", os.Getenv("FFMPEG_PROFILE"), "named FFmpeg profile: "+strings.Join(streaming.FFmpegProfileNames(), ", "))
+	nvenc := flag.Bool("nvenc", false, "encode with NVIDIA NVENC instead of libx264")
+	flag.Parse()
+
+	if *players < 1 || *frames < 1 || *fps < 1 {
+		log.Fatal("❌ -players, -frames and -fps must be positive")
+	}
+	if *warmup <= 0 {
+		*warmup = *fps
+	}
+
+	streamConfig := streaming.StreamConfig{
+		Width:      *width,
+		Height:     *height,
+		FPS:        *fps,
+		Bitrate:    *bitrate,
+		RTMPURL:    "null",
+		UseNVENC:   *nvenc,
+		Renderer:   *renderer,
+		NullOutput: true,
+		LOD:        os.Getenv("RENDER_LOD") != "false",
+		Theme: streaming.ThemeConfig{
+			Minimap:     os.Getenv("THEME_MINIMAP") != "false",
+			Font:        os.Getenv("THEME_FONT"),
+			EmojiFont:   os.Getenv("THEME_EMOJI_FONT"),
+			Leaderboard: os.Getenv("THEME_LEADERBOARD"),
+		},
+	}
+	if *profile != "" {
+		p, ok := streaming.GetFFmpegProfile(*profile)
+		if !ok {
+			log.Fatalf("❌ Unknown FFmpeg profile %q (available: %s)", *profile, strings.Join(streaming.FFmpegProfileNames(), ", "))
+		}
+		streamConfig = p.Apply(streamConfig)
+		streamConfig.Profile = *profile
+	}
+
+	// In-memory engine: no wallet, leaderboard or history files are touched
+	engineCfg := game.DefaultEngineConfig()
+	engineCfg.WorldWidth, engineCfg.WorldHeight = streamConfig.Width, streamConfig.Height
+	engineCfg.Economy.WalletFile = ""
+	engineCfg.Leaderboard.File = ""
+	engineCfg.History.File = ""
+	engineCfg.Rating.File = ""
+	engineCfg.Career.File = ""
+	engineCfg.Quest.File = ""
+	engineCfg.Skins.File = ""
+	engineCfg.Analytics.Dir = ""
+	engine := game.NewEngine(engineCfg)
+	fillArena(engine, *players)
+	engine.Start()
+	defer engine.Stop()
+
+	// Frame times arrive on the render loop; measure after the warmup
+	var mu sync.Mutex
+	samples := make([]time.Duration, 0, *frames)
+	seen := 0
+	var measureStart, measureEnd time.Time
+	done := make(chan struct{})
+	streamer := streaming.NewStreamManager(engine, streamConfig)
+	streamer.OnFrameRendered(func(frameTime time.Duration) {
+		mu.Lock()
+		defer mu.Unlock()
+		seen++
+		if seen <= *warmup || len(samples) == *frames {
+			return
+		}
+		if len(samples) == 0 {
+			measureStart = time.Now().Add(-frameTime)
+		}
+		samples = append(samples, frameTime)
+		measureEnd = time.Now()
+		if len(samples) == *frames {
+			close(done)
+		}
+	})
+
+	log.Printf("⏱️ Benchmark: %d players, %d frames (+%d warmup) at %dx%d @ %d FPS, %s renderer",
+		*players, *frames, *warmup, streamConfig.Width, streamConfig.Height, streamConfig.FPS, streamConfig.Renderer)
+	if err := streamer.Start(); err != nil {
+		log.Fatalf("❌ Failed to start the pipeline: %v", err)
+	}
+
+	// Keep the arena full and sample the encoder while frames render
+	var speeds []float64
+	ticker := time.NewTicker(time.Second)
+	expected := time.Duration(*warmup+*frames) * time.Second / time.Duration(*fps)
+	timeout := time.After(2*expected + 30*time.Second)
+wait:
+	for {
+		select {
+		case <-done:
+			break wait
+		case <-ticker.C:
+			fillArena(engine, *players)
+			if speed, ok := streamer.GetStats()["encoderSpeed"].(float64); ok {
+				speeds = append(speeds, speed)
+			}
+		case <-timeout:
+			log.Println("⚠️ Timed out waiting for frames (is FFmpeg installed?)")
+			break wait
+		}
+	}
+	ticker.Stop()
+	telemetry := streamer.Telemetry()
+	streamer.Stop()
+
+	mu.Lock()
+	measured := append([]time.Duration(nil), samples...)
+	elapsed := measureEnd.Sub(measureStart)
+	mu.Unlock()
+	if len(measured) == 0 {
+		log.Fatal("❌ No frames rendered")
+	}
+	printReport(measured, speeds, telemetry.FramesDropped, elapsed, streamConfig)
+}
+
+// fillArena adds the synthetic fighters and respawns the dead ones, so the
+// player count stays at n for the whole run
+func fillArena(engine *game.Engine, n int) {
+	for i := 0; i < n; i++ {
+		p := engine.AddPlayer(fmt.Sprintf("bench-%03d", i), game.PlayerOptions{})
+		if p != nil && p.IsDead {
+			p.Respawn()
+		}
+	}
+}
+
+// printReport prints frame-time percentiles against the frame budget, the
+// encoder speed and the slowest render phases
+func printReport(samples []time.Duration, speeds []float64, dropped int64, elapsed time.Duration, cfg streaming.StreamConfig) {
+	sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+	budget := time.Second / time.Duration(cfg.FPS)
+	over := len(samples) - sort.Search(len(samples), func(i int) bool { return samples[i] > budget })
+	var total time.Duration
+	for _, d := range samples {
+		total += d
+	}
+
+	fmt.Println()
+	fmt.Printf("Frames:      %d in %s (%.1f FPS rendered, %d dropped before FFmpeg)\n",
+		len(samples), elapsed.Round(time.Millisecond), float64(len(samples))/elapsed.Seconds(), dropped)
+	fmt.Printf("Frame time:  avg %s  p50 %s  p90 %s  p99 %s  max %s\n",
+		ms(total/time.Duration(len(samples))), ms(percentile(samples, 50)), ms(percentile(samples, 90)),
+		ms(percentile(samples, 99)), ms(samples[len(samples)-1]))
+	fmt.Printf("Budget:      %s per frame at %d FPS, %d frames over (%.1f%%)\n",
+		ms(budget), cfg.FPS, over, 100*float64(over)/float64(len(samples)))
+
+	if len(speeds) == 0 {
+		fmt.Println("Encoder:     no speed reported by FFmpeg")
+	} else {
+		low, sum := math.Inf(1), 0.0
+		for _, s := range speeds {
+			low = math.Min(low, s)
+			sum += s
+		}
+		fmt.Printf("Encoder:     %.2fx avg, %.2fx min (below 1.0x cannot keep up live)\n", sum/float64(len(speeds)), low)
+	}
+
+	for _, group := range profiling.Default.Report().Groups {
+		if group.Name != "render" {
+			continue
+		}
+		fmt.Println("Render phases (slowest first):")
+		for _, p := range group.Phases {
+			fmt.Printf("  %-18s avg %6.2f ms  %5.1f%%\n", p.Name, p.AvgMs, p.SharePct)
+		}
+	}
+}
+
+// percentile returns the p-th percentile of sorted durations (nearest rank)
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p/100*float64(len(sorted)))) - 1
+	return sorted[max(0, min(rank, len(sorted)-1))]
+}
+
+// ms formats a duration in milliseconds
+func ms(d time.Duration) string {
+	return fmt.Sprintf("%.2fms", float64(d)/float64(time.Millisecond))
+}
+
+func getEnvWithDefault(key, defaultVal string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return defaultVal
+}
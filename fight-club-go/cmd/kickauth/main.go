@@ -0,0 +1,212 @@
+// =============================================================================
+// FIGHT CLUB - KICK LOGIN FOR HEADLESS SERVERS
+// =============================================================================
+// Logging in at /api/kick/auth needs a browser that can reach the server's
+// OAuth callback, which a remote VPS doesn't have. This helper runs the same
+// OAuth (PKCE) login on your own machine, with the callback on localhost,
+// then uploads the tokens to the server - authorized by the one-time pairing
+// code the server prints in its log - or writes them to a token file to copy
+// over yourself. No ports or tunnels have to be opened for Kick.
+//
+// The redirect URI (http://localhost:3000/api/kick/callback by default) must
+// be registered in your Kick app, like for a local server.
+//
+// USAGE (on your laptop, with CLIENT_ID_KICK / CLIENT_SECRET_KICK in .env):
+//
+//	go run ./cmd/kickauth -server https://vps.example.com -code ABCD-EFGH
+//	go run ./cmd/kickauth -out .kick-tokens-go.json     # then scp it to the server
+//	ssh -L 3000:localhost:3000 vps                      # server port not public? tunnel it and use
+//	go run ./cmd/kickauth -server http://localhost:3000 -listen localhost:3001 -code ABCD-EFGH
+//
+// =============================================================================
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"fight-club/internal/config"
+	"fight-club/internal/kick"
+
+	"github.com/joho/godotenv"
+)
+
+// loginTimeout is how long to wait for the browser login
+const loginTimeout = 5 * time.Minute
+
+// memoryTokenStore holds the tokens only until they are uploaded
+type memoryTokenStore struct {
+	tokens *kick.TokenData
+}
+
+func (m *memoryTokenStore) Load() (kick.TokenData, error) {
+	if m.tokens == nil {
+		return kick.TokenData{}, kick.ErrNoTokens
+	}
+	return *m.tokens, nil
+}
+
+func (m *memoryTokenStore) Save(tokens kick.TokenData) error {
+	m.tokens = &tokens
+	return nil
+}
+
+func main() {
+	// Load environment (Kick app credentials, KICK_TOKEN_KEY)
+	if err := godotenv.Load("../.env"); err != nil {
+		_ = godotenv.Load(".env")
+	}
+	if _, err := config.LoadFile(); err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+
+	server := flag.String("server", "", "game server URL to upload the tokens to")
+	code := flag.String("code", "", "pairing code from the game server log")
+	out := flag.String("out", "", "write the tokens to this token file instead of uploading (encrypted with KICK_TOKEN_KEY when set)")
+	listen := flag.String("listen", fmt.Sprintf("localhost:%d", config.DefaultServer().Port), "local address for the OAuth callback")
+	redirect := flag.String("redirect", "", "OAuth redirect URI registered in the Kick app (default http://<listen>/api/kick/callback)")
+	flag.Parse()
+
+	clientID, clientSecret := os.Getenv("CLIENT_ID_KICK"), os.Getenv("CLIENT_SECRET_KICK")
+	if clientID == "" || clientSecret == "" {
+		log.Fatal("❌ CLIENT_ID_KICK and CLIENT_SECRET_KICK must be set")
+	}
+	if (*server == "") == (*out == "") {
+		log.Fatal("❌ Pass either -server (with -code) or -out")
+	}
+	if *server != "" && *code == "" {
+		log.Fatal("❌ -code is required with -server (it's in the game server log)")
+	}
+	if *redirect == "" {
+		*redirect = "http://" + *listen + "/api/kick/callback"
+	}
+
+	service := kick.NewServiceWithTokenStore(clientID, clientSecret, &memoryTokenStore{})
+	tokens, err := login(service, *listen, *redirect)
+	if err != nil {
+		log.Fatalf("❌ Kick login failed: %v", err)
+	}
+
+	if *out != "" {
+		key, err := kick.ParseTokenKey(os.Getenv("KICK_TOKEN_KEY"))
+		if err != nil {
+			log.Fatalf("❌ %v", err)
+		}
+		store := kick.NewFileTokenStore(*out, key)
+		if err := store.Save(tokens); err != nil {
+			log.Fatalf("❌ Failed to write %s: %v", *out, err)
+		}
+		log.Printf("💾 Tokens written to %s - copy it to the game server's KICK_TOKEN_FILE and restart it", *out)
+		return
+	}
+
+	if err := upload(*server, *code, tokens); err != nil {
+		log.Fatalf("❌ Upload failed: %v", err)
+	}
+	log.Printf("✅ %s is now logged in to Kick", *server)
+}
+
+// login runs the OAuth PKCE flow with a local callback server and returns the tokens
+func login(service *kick.Service, listen, redirect string) (kick.TokenData, error) {
+	ln, err := net.Listen("tcp", listen)
+	if err != nil {
+		return kick.TokenData{}, fmt.Errorf("cannot listen for the callback (is a local game server running?): %w", err)
+	}
+
+	callback, err := url.Parse(redirect)
+	if err != nil {
+		ln.Close()
+		return kick.TokenData{}, fmt.Errorf("invalid redirect URI: %w", err)
+	}
+	path := callback.Path
+	if path == "" {
+		path = "/"
+	}
+
+	result := make(chan error, 1)
+	mux := http.NewServeMux()
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		code := r.URL.Query().Get("code")
+		if code == "" {
+			http.Error(w, "Missing authorization code", http.StatusBadRequest)
+			return
+		}
+		err := service.ExchangeCode(code, redirect, r.URL.Query().Get("state"))
+		if err != nil {
+			http.Error(w, "Authentication failed: "+err.Error(), http.StatusInternalServerError)
+		} else {
+			fmt.Fprintln(w, "Kick login complete - you can close this window.")
+		}
+		select {
+		case result <- err:
+		default:
+		}
+	})
+	srv := &http.Server{Handler: mux}
+	go srv.Serve(ln)
+	defer srv.Shutdown(context.Background())
+
+	authURL := service.GetAuthURL(redirect)
+	log.Println("🔑 Open this URL and log in with the broadcaster (or bot) account:")
+	fmt.Println(authURL)
+	openBrowser(authURL)
+
+	select {
+	case err := <-result:
+		if err != nil {
+			return kick.TokenData{}, err
+		}
+	case <-time.After(loginTimeout):
+		return kick.TokenData{}, errors.New("timed out waiting for the login")
+	}
+	return service.Tokens(), nil
+}
+
+// upload sends the tokens to the game server's /api/kick/tokens
+func upload(server, code string, tokens kick.TokenData) error {
+	body, err := json.Marshal(map[string]interface{}{"code": code, "tokens": tokens})
+	if err != nil {
+		return err
+	}
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Post(strings.TrimSuffix(server, "/")+"/api/kick/tokens", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+// openBrowser opens a URL in the default browser (best effort; the URL is printed anyway)
+func openBrowser(target string) {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", target)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", target)
+	default:
+		cmd = exec.Command("xdg-open", target)
+	}
+	_ = cmd.Start()
+}
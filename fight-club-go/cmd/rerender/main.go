@@ -0,0 +1,166 @@
+// =============================================================================
+// FIGHT CLUB - OFFLINE RE-RENDER
+// =============================================================================
+// Turns a snapshot recording (SNAPSHOT_RECORDING_DIR on the game server) into
+// a VOD file: the recorded ticks are played back through the same
+// StreamManager pipeline as the live streamer, at any output size, frame rate
+// and bitrate, without the live stream's latency-first encoder settings.
+// Playback runs in real time (the renderer is paced like a live stream).
+//
+// USAGE:
+//
+//	go run ./cmd/rerender -in recordings/snapshots-20261015-200000.fcrec.gz
+//	go run ./cmd/rerender -in rec.fcrec.gz -out vod.mp4 -width 1920 -height 1080 -fps 60
+//	go run ./cmd/rerender -in rec.fcrec.gz -from 1h10m -to 1h25m -out highlight.mp4
+//	go run ./cmd/rerender -in rec.fcrec.gz -profile nvenc-p4   # NVIDIA hardware encoding
+//
+// =============================================================================
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"fight-club/internal/config"
+	"fight-club/internal/ipc"
+	"fight-club/internal/streaming"
+
+	"github.com/joho/godotenv"
+)
+
+func main() {
+	// Load environment (renderer, theme, music and sounds) like the streamer
+	if err := godotenv.Load("../.env"); err != nil {
+		_ = godotenv.Load(".env")
+	}
+	if _, err := config.LoadFile(); err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+
+	in := flag.String("in", "", "snapshot recording to render ("+ipc.RecordingExt+")")
+	out := flag.String("out", "", "output video; the container follows the extension (default: the recording name as .mp4)")
+	width := flag.Int("width", 0, "output width (default: the recorded arena size)")
+	height := flag.Int("height", 0, "output height (default: the recorded arena size)")
+	fps := flag.Int("fps", 60, "output frames per second")
+	bitrate := flag.Int("bitrate", 8000, "output bitrate (kbps)")
+	profile := flag.String("profile", streaming.ProfileQuality1080p, "FFmpeg encoder settings: "+strings.Join(streaming.FFmpegProfileNames(), ", "))
+	renderer := flag.String("renderer", getEnvWithDefault("STREAM_RENDERER", streaming.RendererGG), "frame renderer (gg or atlas)")
+	from := flag.Duration("from", 0, "start this far into the recording")
+	to := flag.Duration("to", 0, "stop this far into the recording (0 = the end)")
+	flag.Parse()
+
+	if *in == "" {
+		log.Fatal("❌ -in is required")
+	}
+	if *out == "" {
+		*out = strings.TrimSuffix(*in, ipc.RecordingExt) + ".mp4"
+	}
+	if *fps < 1 || *bitrate < 1 {
+		log.Fatal("❌ -fps and -bitrate must be positive")
+	}
+	if _, ok := streaming.GetFFmpegProfile(*profile); !ok {
+		log.Fatalf("❌ Unknown FFmpeg profile %q (available: %s)", *profile, strings.Join(streaming.FFmpegProfileNames(), ", "))
+	}
+
+	reader, err := ipc.OpenSnapshotRecording(*in)
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+	defer reader.Close()
+
+	// Frames are rendered at the arena size the game ran at; FFmpeg scales
+	// them to the output size
+	arena := reader.Config()
+	if arena.Width <= 0 || arena.Height <= 0 {
+		video := config.VideoFromEnv()
+		arena.Width, arena.Height = video.Width, video.Height
+	}
+	streamConfig := streaming.StreamConfig{
+		Width:         arena.Width,
+		Height:        arena.Height,
+		FPS:           *fps,
+		Bitrate:       *bitrate,
+		Profile:       *profile,
+		Renderer:      *renderer,
+		OutputFile:    *out,
+		OutputWidth:   *width,
+		OutputHeight:  *height,
+		Interpolation: true,
+		MusicEnabled:  os.Getenv("MUSIC_ENABLED") != "false",
+		MusicVolume:   getEnvFloat("MUSIC_VOLUME", 0.15),
+		MusicPath:     getEnvWithDefault("MUSIC_PATH", "assets/music/digital_fight_arena.ogg"),
+		Sounds:        config.SoundsFromEnv(),
+		Theme: streaming.ThemeConfig{
+			Minimap:     os.Getenv("THEME_MINIMAP") != "false",
+			Font:        os.Getenv("THEME_FONT"),
+			EmojiFont:   os.Getenv("THEME_EMOJI_FONT"),
+			Leaderboard: os.Getenv("THEME_LEADERBOARD"),
+		},
+	}
+
+	source := streaming.NewReplaySource()
+	streamer := streaming.NewStreamManagerWithSource(source, streamConfig)
+
+	log.Printf("🎞️ Re-rendering %s → %s (arena %dx%d @ %d FPS, %dk)", *in, *out, arena.Width, arena.Height, *fps, *bitrate)
+	if err := streamer.Start(); err != nil {
+		log.Fatalf("❌ Failed to start the pipeline: %v", err)
+	}
+
+	// Ctrl+C ends the video early; the file is still finished properly
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-sigChan
+		log.Println("⏹️ Stopping early...")
+		source.Stop()
+	}()
+
+	// Progress every 30s of recording time
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(30 * time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				log.Printf("⏩ %s of the recording rendered", source.Played().Round(time.Second))
+			}
+		}
+	}()
+
+	err = source.Play(reader.Next, *from, *to)
+	close(done)
+	if err != nil {
+		log.Printf("⚠️ Recording ended early: %v", err)
+	}
+	if next := reader.Config(); next.Width != arena.Width || next.Height != arena.Height {
+		log.Printf("⚠️ The arena changed to %dx%d during the recording; it was rendered at %dx%d", next.Width, next.Height, arena.Width, arena.Height)
+	}
+
+	// Let the last snapshot reach the encoder before closing its input
+	time.Sleep(2 * time.Second / time.Duration(*fps))
+	streamer.Stop()
+	log.Printf("✅ %s of the recording rendered to %s", (source.Played() - *from).Round(time.Second), *out)
+}
+
+func getEnvWithDefault(key, defaultVal string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return defaultVal
+}
+
+func getEnvFloat(key string, defaultVal float64) float64 {
+	if v, err := strconv.ParseFloat(os.Getenv(key), 64); err == nil {
+		return v
+	}
+	return defaultVal
+}
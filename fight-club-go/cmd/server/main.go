@@ -1,22 +1,34 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"strconv"
+	"strings"
 	"syscall"
+	"time"
 
 	"fight-club/internal/api"
 	"fight-club/internal/chat"
 	"fight-club/internal/config"
 	"fight-club/internal/game"
+	"fight-club/internal/grpcapi"
+	"fight-club/internal/highlights"
 	"fight-club/internal/ipc"
 	"fight-club/internal/kick"
+	"fight-club/internal/notify"
+	"fight-club/internal/restart"
 	"fight-club/internal/streaming"
 
 	"github.com/joho/godotenv"
+	"google.golang.org/grpc/credentials"
 )
 
 // =============================================================================
@@ -33,6 +45,10 @@ import (
 // =============================================================================
 
 func main() {
+	// Keep recent warnings/errors for the admin panel's live event stream
+	errorLog := api.NewErrorLog(50)
+	log.SetOutput(io.MultiWriter(os.Stderr, errorLog))
+
 	// Load .env file from parent directory
 	if err := godotenv.Load("../.env"); err != nil {
 		// Try current directory as fallback
@@ -48,11 +64,40 @@ func main() {
 	log.Println("  (Streaming handled separately)")
 	log.Println("================================")
 
+	// Optional fight-club.yaml (environment variables take precedence)
+	fileConfig, err := config.LoadFile()
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+	if fileConfig != nil {
+		if err := game.ApplyWeaponOverrides(fileConfig.Weapons); err != nil {
+			log.Fatalf("❌ %s: %v", fileConfig.Path(), err)
+		}
+	}
+
+	// Data-driven weapons (weapons.yaml), validated at startup and hot
+	// reloaded from the admin panel
+	weaponRegistry, err := game.NewWeaponRegistry(getEnvWithDefault("WEAPONS_FILE", "weapons.yaml"))
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+
 	// Load centralized configuration (SSOT - Single Source of Truth)
 	appConfig := config.Load()
 	videoCfg := appConfig.Video
 	serverCfg := appConfig.Server
 
+	// Named arena preset overrides the world size and tick rate
+	if name := appConfig.Simulation.ArenaPreset; name != "" {
+		preset, ok := game.GetArenaPreset(name)
+		if !ok {
+			log.Fatalf("❌ Unknown ARENA_PRESET %q (available: %s)", name, strings.Join(game.ArenaPresetNames(), ", "))
+		}
+		videoCfg.Width, videoCfg.Height = preset.Width, preset.Height
+		appConfig.Simulation.TickRate = preset.TickRate
+		log.Printf("Arena preset: %s (%s)", preset.Name, preset.Label)
+	}
+
 	// Load environment variables for external services
 	clientID := os.Getenv("CLIENT_ID_KICK")
 	clientSecret := os.Getenv("CLIENT_SECRET_KICK")
@@ -63,7 +108,7 @@ func main() {
 	port := strconv.Itoa(serverCfg.Port)
 
 	// Log configuration
-	log.Printf("Config: %d TPS, %dx%d world", videoCfg.FPS, videoCfg.Width, videoCfg.Height)
+	log.Printf("Config: %d TPS, %dx%d world", appConfig.Simulation.TickRate, videoCfg.Width, videoCfg.Height)
 	if clientID != "" {
 		log.Printf("Client ID: %s...", clientID[:min(10, len(clientID))])
 	}
@@ -77,11 +122,56 @@ func main() {
 
 	// Create game engine with centralized config
 	engine := game.NewEngine(game.EngineConfig{
-		TickRate:    videoCfg.FPS, // Use FPS as tick rate for consistency
+		TickRate:    appConfig.Simulation.TickRate, // Independent of the stream FPS
 		WorldWidth:  videoCfg.Width,
 		WorldHeight: videoCfg.Height,
 		Limits:      appConfig.Limits,
+		Economy:     appConfig.Economy,
+		Voting:      appConfig.Voting,
+		Duel:        appConfig.Duel,
+		Chaos:       appConfig.Chaos,
+		Scene:       appConfig.Scene,
+		Airstrike:   appConfig.Airstrike,
+		Shop:        appConfig.Shop,
+		RingOut:     appConfig.RingOut,
+		Replay:      appConfig.Replay,
+		Objective:   appConfig.Objective,
+		Leaderboard: appConfig.Leaderboard,
+		History:     appConfig.History,
+		Rating:      appConfig.Rating,
+		Career:      appConfig.Career,
+		Quest:       appConfig.Quest,
+		Skins:       appConfig.Skins,
+		LagComp:     appConfig.LagComp,
+		Loot:        appConfig.Loot,
+		Gift:        appConfig.Gift,
+		Analytics:   appConfig.Analytics,
+		BotFill:     appConfig.BotFill,
+		JoinQueue:   appConfig.JoinQueue,
+
+		MaxCatchUpTicks: appConfig.Simulation.MaxCatchUpTicks,
+
+		AutoStream: appConfig.AutoStream,
 	})
+	if appConfig.Chaos.Interval > 0 {
+		log.Printf("Chaos events: every %.0fs (%.0fs warning, %.0fs long)", appConfig.Chaos.Interval, appConfig.Chaos.Warning, appConfig.Chaos.Duration)
+	}
+	if appConfig.Objective.ScoreLimit > 0 {
+		log.Printf("Team objective: first to %d points (%.0fs capture)", appConfig.Objective.ScoreLimit, appConfig.Objective.CaptureTime)
+	}
+	if appConfig.BotFill.MinPlayers > 0 {
+		log.Printf("Bot fill: keeping %d fighters in the arena", appConfig.BotFill.MinPlayers)
+	}
+	// Sound packs the admin panel can switch the stream to (played by the streamer)
+	soundPacks := engine.GetSoundPacks()
+	soundPacks.SetAvailable(streaming.ListSoundPacks(appConfig.Sounds.Dir))
+	if err := soundPacks.Set(appConfig.Sounds.Pack); err != nil {
+		log.Printf("⚠️ %v, using the default sounds", err)
+	}
+	log.Printf("Sound pack: %s (available: %s)", soundPacks.Current(), strings.Join(soundPacks.Available(), ", "))
+	if appConfig.AutoStream.Enabled {
+		log.Printf("Auto-stream: live with %d+ players, ends after %.0f min of empty arena", appConfig.AutoStream.MinPlayers, appConfig.AutoStream.IdleMinutes)
+	}
 	limits := engine.GetLimits()
 	log.Printf("Resource limits: %d players, %d particles, %d effects, %d texts",
 		limits.MaxPlayers, limits.MaxParticles, limits.MaxEffects, limits.MaxTexts)
@@ -92,10 +182,27 @@ func main() {
 	ipcSocketPath := getEnvWithDefault("IPC_SOCKET", ipc.DefaultSocketPath)
 	log.Println("Starting IPC publisher for external streamer...")
 
+	ipcCodec, err := ipc.CodecByName(getEnvWithDefault("IPC_CODEC", "gob"))
+	if err != nil {
+		log.Fatalf("❌ %v (available: %s)", err, strings.Join(ipc.CodecNames(), ", "))
+	}
 	ipcPublisher := ipc.NewPublisher(ipcSocketPath)
+	ipcPublisher.SetCodec(ipcCodec)
 	ipcPublisher.SetConfig(videoCfg.Width, videoCfg.Height, videoCfg.FPS, videoCfg.Bitrate)
+	ipcPublisher.SetWeapons(game.WeaponDefinitions())
+	weaponRegistry.OnReload = ipcPublisher.SetWeapons
 
-	if err := ipcPublisher.Start(); err != nil {
+	// Listeners survive graceful restarts (SIGUSR2): a new process inherits them
+	listeners := restart.New()
+	arenaStateFile := getEnvWithDefault("ARENA_STATE_FILE", "arena-state.json")
+
+	ipcListener, err := listeners.Listen("ipc", func() (net.Listener, error) {
+		return ipc.CreatePlatformListener(ipcSocketPath)
+	})
+	if err == nil {
+		err = ipcPublisher.StartWithListener(ipcListener)
+	}
+	if err != nil {
 		log.Printf("WARNING: Failed to start IPC publisher: %v", err)
 		log.Println("External streamer will not be able to connect!")
 	} else {
@@ -103,52 +210,189 @@ func main() {
 		engine.OnSnapshot = func(snapshot *game.GameSnapshot) {
 			ipcPublisher.PublishSnapshot(snapshot)
 		}
-		log.Printf("IPC Publisher started on %s", ipcSocketPath)
+		// Streamers follow the arena size when an admin switches presets
+		engine.OnArenaChange = func(preset game.ArenaPreset) {
+			ipcPublisher.SetConfig(preset.Width, preset.Height, videoCfg.FPS, videoCfg.Bitrate)
+		}
+		log.Printf("IPC Publisher started on %s (%s snapshots)", ipcSocketPath, ipcCodec.Name())
 		log.Println("")
 		log.Println(">>> To start streaming, run in another terminal:")
 		log.Println(">>> go run ./cmd/streamer")
 		log.Println("")
 	}
 
+	// Snapshot recording: every tick to disk, re-rendered offline with cmd/rerender
+	var snapshotRecorder *ipc.SnapshotRecorder
+	if dir := os.Getenv("SNAPSHOT_RECORDING_DIR"); dir != "" && ipcPublisher.IsRunning() {
+		snapshotRecorder, err = ipc.NewSnapshotRecorder(dir)
+		if err != nil {
+			log.Printf("WARNING: Snapshot recording disabled: %v", err)
+		} else {
+			ipcPublisher.SetRecorder(snapshotRecorder)
+			log.Printf("🎞️ Recording snapshots to %s (re-render with: go run ./cmd/rerender -in %s)",
+				snapshotRecorder.Path(), snapshotRecorder.Path())
+		}
+	}
+
 	// ==========================================================================
-	// NO-OP STREAMER - API expects a streamer, but we don't stream from server
+	// REMOTE STREAMER - API expects a streamer, but we don't stream from server
 	// ==========================================================================
-	// The API server needs a streamer interface for endpoints like /api/stats
-	// We use a NoOpStreamer that returns "streaming handled externally" status
-	noopStreamer := streaming.NewNoOpStreamer()
+	// The API server needs a streamer interface for endpoints like /api/stats.
+	// /api/stream/start and /stop are sent to the streamer process over IPC,
+	// and it reports its stats back; until it does (or once it goes quiet)
+	// this returns "streaming handled externally" status
+	remoteStreamer := streaming.NewRemoteStreamer(ipcPublisher)
 
 	// Start event log
-	eventLogPath := getEnvWithDefault("EVENT_LOG_PATH", "events.jsonl")
-	if err := engine.StartEventLog(eventLogPath); err != nil {
+	if err := engine.StartEventLog(appConfig.EventLog); err != nil {
 		log.Printf("Event log disabled: %v", err)
 	} else {
-		log.Printf("Event log: %s", eventLogPath)
+		log.Printf("Event log: %s (rotate at %d MB / %.0fh, keep %.0fh)", appConfig.EventLog.Path,
+			appConfig.EventLog.MaxSizeMB, appConfig.EventLog.RotateHours, appConfig.EventLog.RetainHours)
 	}
 
-	// Start debug server
-	debugCfg := api.DefaultObservabilityConfig()
-	if os.Getenv("DISABLE_DEBUG_SERVER") != "true" {
-		if err := api.StartDebugServer(debugCfg); err != nil {
-			log.Printf("Debug server disabled: %v", err)
-		}
+	// Discord notifications (new all-time kill records, Kick auth expiry)
+	notifier, err := notify.New(appConfig.Notify)
+	if err != nil {
+		log.Fatalf("Invalid Discord notification config: %v", err)
+	}
+	notifier.Start()
+	engine.GetLeaderboardStore().OnNewRecord = func(username string, kills int, previousHolder string, previousKills int) {
+		notifier.Notify(notify.EventKillRecord, map[string]interface{}{
+			"Username":      username,
+			"Kills":         kills,
+			"Previous":      previousHolder,
+			"PreviousKills": previousKills,
+		})
 	}
 
 	// Initialize Kick service for OAuth webhooks
 	var kickService *kick.Service
 	var kickBot *kick.Bot
+	var viewerPoller *kick.ViewerCountPoller
+	var titleUpdater *kick.TitleUpdater
 	var profileCache *kick.ProfileURLCache
+	var feedback *kick.FeedbackBatcher
+	var clips *highlights.Recorder
 	chatHandler := chat.NewHandler(engine)
+	chatHandler.SetRateLimits(chat.RateLimitConfigFrom(appConfig.RateLimit))
+	if n := len(appConfig.RateLimit.Commands); n > 0 {
+		log.Printf("⏱️ Chat rate limits: %d commands/%.0fs per viewer, %d per-command limits (subs x%g, mods x%g)",
+			appConfig.RateLimit.MaxPerWindow, appConfig.RateLimit.Window, n,
+			appConfig.RateLimit.SubscriberMultiplier, appConfig.RateLimit.ModeratorMultiplier)
+	}
+
+	// Chat command languages (English plus !unirse, !entrar...); set before aliases load
+	if err := chat.SetCommandLocales(appConfig.Locale); err != nil {
+		log.Printf("⚠️ Chat command locales: %v (keeping the defaults)", err)
+	} else {
+		log.Printf("🌐 Chat command locales: en %s (+%d extra words)",
+			strings.Join(appConfig.Locale.Locales, " "), len(appConfig.Locale.Extras))
+	}
+
+	// Custom chat command aliases (e.g. !pelear → !join), hot-reloaded when the file is edited
+	aliasesFile := os.Getenv("CHAT_ALIASES_FILE")
+	if aliasesFile == "" {
+		aliasesFile = "chat-aliases.json"
+	}
+	chatAliases, err := chat.NewAliasStore(aliasesFile)
+	if err != nil {
+		log.Printf("⚠️ Chat aliases disabled: %v", err)
+	} else {
+		chatHandler.SetAliases(chatAliases)
+		chatAliases.Start()
+	}
+
+	// Broadcaster game scripts (e.g. a boss at 10 kills), hot-reloaded when the file is edited
+	gameScripts, err := game.NewScriptRunner(getEnvWithDefault("GAME_SCRIPTS_FILE", "game-scripts.yaml"))
+	if err != nil {
+		log.Printf("⚠️ Game scripts disabled: %v", err)
+	} else {
+		engine.SetScripts(gameScripts)
+		gameScripts.Start()
+	}
+
+	// Auto-timeout viewers who keep spamming commands past the rate limit
+	// (dry-run until a Kick moderator is connected and MODERATION_DRY_RUN=false)
+	abuseGuard := chat.NewAbuseGuard(appConfig.Moderation, nil)
+	chatHandler.SetAbuseGuard(abuseGuard)
+	if appConfig.Moderation.Enabled {
+		log.Printf("🛡️ Chat moderation enabled (dryRun=%v, %d violations/%.0fs → %dm timeout)",
+			appConfig.Moderation.DryRun, appConfig.Moderation.Threshold, appConfig.Moderation.Window, appConfig.Moderation.TimeoutMinutes)
+	}
+
+	// Ban list: banned viewers' commands are ignored; persisted across restarts
+	banBackend := appConfig.Bans.Backend
+	banStore, err := chat.NewBanStore(appConfig.Bans)
+	if err != nil && banBackend != "file" && appConfig.Bans.FileFallback {
+		log.Printf("⚠️ Ban store %s unavailable, falling back to %s: %v", banBackend, appConfig.Bans.File, err)
+		banBackend = "file"
+		banStore, err = chat.NewFileBanStore(appConfig.Bans.File), nil
+	}
+	if err != nil {
+		log.Fatalf("❌ Ban store: %v", err)
+	}
+	banList, err := chat.NewBanList(banStore)
+	if err != nil {
+		log.Fatalf("❌ Failed to load the ban list: %v", err)
+	}
+	banList.OnBan(func(b chat.Ban) {
+		engine.RemovePlayer(b.Username)
+	})
+	chatHandler.SetBans(banList)
+	log.Printf("🔨 Ban list: %d active bans (%s store)", len(banList.List()), banBackend)
 
 	// Create command queue with worker pool for non-blocking command processing
 	// This decouples webhook handlers from game engine, eliminating latency
 	commandQueue := chat.NewCommandQueue(chatHandler, chat.DefaultQueueConfig())
 	commandQueue.Start()
 
+	// Demo mode: synthetic viewers send commands through the same queue as Kick chat
+	var demoTraffic *chat.DemoTraffic
+	if appConfig.Demo.Enabled {
+		demoTraffic = chat.NewDemoTraffic(appConfig.Demo, commandQueue.Enqueue)
+		demoTraffic.Start()
+	}
+
+	// Start debug server (pprof, metrics, command queue depth, rate limit hits)
+	debugCfg := api.DefaultObservabilityConfig()
+	debugCfg.CommandQueue = commandQueue
+	debugCfg.RateLimiter = chatHandler.RateLimiter()
+	debugCfg.Listen = func(addr string) (net.Listener, error) {
+		return listeners.Listen("debug", func() (net.Listener, error) { return net.Listen("tcp", addr) })
+	}
+	if os.Getenv("DISABLE_DEBUG_SERVER") != "true" {
+		if err := api.StartDebugServer(debugCfg); err != nil {
+			log.Printf("Debug server disabled: %v", err)
+		}
+	}
+
 	if clientID != "" && clientSecret != "" {
-		kickService = kick.NewService(clientID, clientSecret)
+		// OAuth tokens: file or SQL store, AES-GCM encrypted when KICK_TOKEN_KEY is set
+		tokenStore, err := kick.NewTokenStore(appConfig.KickTokens)
+		if err != nil && appConfig.KickTokens.Backend != "file" {
+			log.Printf("Kick token store unavailable, using %s: %v", appConfig.KickTokens.File, err)
+			fileCfg := appConfig.KickTokens
+			fileCfg.Backend = "file"
+			tokenStore, err = kick.NewTokenStore(fileCfg)
+		}
+		if err != nil {
+			log.Fatalf("❌ Kick token store: %v", err)
+		}
+		if appConfig.KickTokens.EncryptionKey == "" {
+			log.Println("KICK_TOKEN_KEY not set - Kick tokens are stored unencrypted")
+		}
+		kickService = kick.NewServiceWithTokenStore(clientID, clientSecret, tokenStore)
+
+		// Outbound HTTP: proxy, custom root CAs, request timeout
+		if err := kickService.SetHTTPConfig(appConfig.KickHTTP); err != nil {
+			log.Printf("Invalid Kick HTTP config, using defaults: %v", err)
+		}
 
-		// Create profile URL cache for lazy-loading avatars (non-blocking)
-		profileCache = kick.NewProfileURLCache(kickService, kick.DefaultProfileCacheConfig())
+		// Create profile URL cache for lazy-loading avatars (non-blocking),
+		// persisted next to the avatar cache so restarts don't refetch every URL
+		profileCache = kick.NewProfileURLCache(kickService, kick.ProfileCacheConfigFromAvatar(appConfig.AvatarCache))
+		profileCache.Start()
 
 		// Enable async webhook handling to prevent backpressure
 		kickService.SetAsyncHandler(true)
@@ -159,10 +403,35 @@ func main() {
 			kickService.SetBroadcasterID(bid)
 		}
 
+		// Co-op streams: collab partners' chats join too, names prefixed per channel
+		kickService.SetGuestChannels(appConfig.KickGuests.Guests)
+
 		if publicURL != "" {
 			kickService.SetWebhookURL(publicURL + "/api/kick/webhook")
 		}
 
+		// Re-delivered webhooks (same message ID) are only processed once
+		kickService.SetDedupConfig(appConfig.KickDedup)
+
+		// Webhook signature verification: off | log (default) | enforce
+		kickService.SetSignatureMode(kick.ParseSignatureMode(os.Getenv("KICK_WEBHOOK_SIGNATURE_MODE")))
+		if pemKey := os.Getenv("KICK_WEBHOOK_PUBLIC_KEY"); pemKey != "" {
+			if err := kickService.SetWebhookPublicKeyPEM(pemKey); err != nil {
+				log.Printf("Invalid KICK_WEBHOOK_PUBLIC_KEY: %v", err)
+			}
+		} else {
+			go func() {
+				if err := kickService.FetchWebhookPublicKey(); err != nil {
+					log.Printf("Failed to fetch Kick webhook public key: %v", err)
+				}
+			}()
+		}
+
+		kickService.OnAuthError(func(err error) {
+			notifier.Notify(notify.EventAuthExpired, map[string]interface{}{"Error": err.Error()})
+			log.Printf("Kick login expired - headless servers: go run ./cmd/kickauth -server <url> -code %s", kickService.PairingCode())
+		})
+
 		// Register chat message handler - NOW NON-BLOCKING
 		// Commands are enqueued and processed by worker pool
 		kickService.OnChatMessage(func(msg kick.ChatMessage) {
@@ -178,11 +447,15 @@ func main() {
 				}
 
 				cmd := chat.ChatCommand{
-					Command:    msg.Command,
-					Args:       msg.Args,
-					Username:   msg.Username,
-					UserID:     msg.UserID,
-					ProfilePic: profilePic,
+					Command:       msg.Command,
+					Args:          msg.Args,
+					Username:      msg.PlayerName(),
+					UserID:        msg.UserID,
+					ProfilePic:    profilePic,
+					ReceivedAt:    msg.ReceivedAt,
+					IsBroadcaster: msg.ChannelPrefix == "" && msg.UserID != 0 && msg.UserID == msg.BroadcasterID,
+					IsModerator:   msg.ChannelPrefix == "" && msg.IsModerator,
+					IsSubscriber:  msg.ChannelPrefix == "" && msg.IsSubscriber,
 				}
 
 				// Non-blocking enqueue - returns immediately
@@ -192,15 +465,194 @@ func main() {
 			}
 		})
 
+		// Anti-abuse timeouts go through the broadcaster's Kick account
+		abuseGuard.SetModerator(kickService)
+
 		// Wire up OnKill event to sending chat messages
 		// Initialize Kick Bot for Kill Feed
 		kickBot = kick.NewBot(kickService)
 		kickBot.Start()
 
+		// Tell viewers why their commands failed, batched into one message per interval
+		if appConfig.Feedback.Enabled {
+			feedback = kick.NewFeedbackBatcher(appConfig.Feedback, kickBot.QueueMessage)
+			feedback.Start()
+			chatHandler.SetReplier(feedback)
+		}
+
 		engine.OnKill = func(killer, victim *game.Player) {
+			if killer.IsBot || victim.IsBot {
+				return // Bot fill fights would flood the chat
+			}
 			kickBot.QueueKill(killer.Name, victim.Name, killer.Weapon, killer.Kills)
 		}
 
+		// Announce arena modifier votes in chat
+		votes := engine.GetVoteManager()
+		votes.OnVoteStart = func(options []game.ArenaModifier) {
+			if votes.Snapshot().Poll {
+				kickBot.QueueMessage("🗳️ ARENA VOTE! Pick the next modifier in the channel poll")
+				return
+			}
+			msg := "🗳️ ARENA VOTE! Type"
+			for i, mod := range options {
+				msg += fmt.Sprintf(" !vote %d %s %s |", i+1, mod.Emoji, mod.Name)
+			}
+			kickBot.QueueMessage(strings.TrimSuffix(msg, " |"))
+		}
+		if appConfig.Voting.KickPoll {
+			votes.SetPoll(kickService)
+			log.Println("🗳️ Arena votes run as Kick channel polls")
+		}
+		votes.OnVoteEnd = func(winner game.ArenaModifier, count int) {
+			kickBot.QueueMessage(fmt.Sprintf("%s %s wins with %d votes! %s", winner.Emoji, winner.Name, count, winner.Description))
+		}
+
+		// Announce duels in chat
+		duels := engine.GetDuelManager()
+		duels.OnChallenge = func(challenger, target string, timeout float64) {
+			kickBot.QueueMessage(fmt.Sprintf("🤺 @%s challenges @%s to a duel! Type !accept within %.0fs", challenger, target, timeout))
+		}
+		duels.OnDuelStart = func(challenger, opponent string, duration float64) {
+			kickBot.QueueMessage(fmt.Sprintf("🤺 DUEL! %s vs %s in the ring - %.0fs, no interference!", challenger, opponent, duration))
+		}
+		duels.OnDuelEnd = func(result game.DuelResult) {
+			switch {
+			case result.Winner != "":
+				kickBot.QueueMessage(fmt.Sprintf("🏆 %s wins the duel against %s! +$%d", result.Winner, result.Loser, result.Bonus))
+			case result.Reason == "left":
+				kickBot.QueueMessage(fmt.Sprintf("🤺 Duel %s vs %s cancelled - a fighter left", result.Players[0], result.Players[1]))
+			default:
+				kickBot.QueueMessage(fmt.Sprintf("🤺 Duel %s vs %s ends in a draw!", result.Players[0], result.Players[1]))
+			}
+		}
+
+		// Chaos event warnings
+		chaos := engine.GetChaosManager()
+		chaos.OnWarning = func(event game.ChaosEvent, seconds float64) {
+			kickBot.QueueMessage(fmt.Sprintf("%s CHAOS in %.0fs: %s! %s", event.Emoji, seconds, event.Name, event.Description))
+		}
+
+		// Team objective rounds, captures and victories
+		objective := engine.GetObjectiveManager()
+		objective.OnRoundStart = func(teams [2]string, scoreLimit int) {
+			kickBot.QueueMessage(fmt.Sprintf("🚩 TEAM OBJECTIVE! %s vs %s - hold the center point, first to %d wins!", teams[0], teams[1], scoreLimit))
+		}
+		objective.OnCapture = func(team string) {
+			kickBot.QueueMessage(fmt.Sprintf("🚩 %s captured the center point!", team))
+		}
+		objective.OnRoundEnd = func(result game.ObjectiveResult) {
+			if result.Winner == "" {
+				kickBot.QueueMessage(fmt.Sprintf("🚩 Objective %s vs %s abandoned - a team left the arena", result.Teams[0], result.Teams[1]))
+				return
+			}
+			a, b := result.Scores[0], result.Scores[1]
+			msg := fmt.Sprintf("🏆 %s wins the objective %d-%d against %s!", result.Winner, max(a, b), min(a, b), result.Loser)
+			if result.Paid > 0 {
+				msg += fmt.Sprintf(" +$%d each", result.Bonus)
+			}
+			kickBot.QueueMessage(msg)
+		}
+
+		// Game script announcements
+		if gameScripts != nil {
+			gameScripts.OnAnnounce = func(text string) {
+				kickBot.QueueMessage("📜 " + text)
+			}
+		}
+
+		// Seasonal rank promotions
+		engine.GetRatingStore().OnRankUp = func(username string, rank game.RankTier, rating int) {
+			kickBot.QueueMessage(fmt.Sprintf("🎖️ @%s ranked up to %s! (%d rating)", username, rank.Name, rating))
+		}
+
+		// Queued viewers entering a full arena
+		engine.OnJoinQueueAdmit = func(username string) {
+			kickBot.QueueMessage(fmt.Sprintf("⚔️ @%s a slot opened up - you're in the arena!", username))
+		}
+
+		// Clip big moments (3+ kill streaks, boss kills) once Kick is authorized
+		// and share the links in chat and on Discord
+		if appConfig.KickClips.Enabled {
+			clips = highlights.New(appConfig.KickClips, kickService)
+			clips.OnClip = func(clip highlights.Clip) {
+				kickBot.QueueMessage(fmt.Sprintf("🎬 CLIP: %s → %s", clip.Title, clip.URL))
+				notifier.Notify(notify.EventHighlight, map[string]interface{}{
+					"Title":   clip.Title,
+					"ClipURL": clip.URL,
+					"Player":  clip.Player,
+				})
+			}
+			engine.OnHighlight = func(h game.Highlight) {
+				if kickService.IsConnected() {
+					clips.Capture(h)
+				}
+			}
+			log.Printf("🎬 Highlight clips: %ds, at most one every %.0fs", appConfig.KickClips.Duration, appConfig.KickClips.Cooldown)
+		}
+
+		// !quests replies with today's progress
+		engine.GetQuestStore().OnProgress = func(username string, quests []game.QuestProgress) {
+			msg := fmt.Sprintf("📜 @%s daily quests:", username)
+			for _, q := range quests {
+				status := q.Quest.FormatProgress(q.Progress)
+				if q.Done {
+					status = "✅"
+				}
+				msg += fmt.Sprintf(" %s %s %s |", q.Quest.Emoji, q.Quest.Name, status)
+			}
+			kickBot.QueueMessage(strings.TrimSuffix(msg, " |"))
+		}
+
+		// Live viewer count for the "watching" badge next to LIVE
+		if interval := appConfig.KickViewers.PollInterval; interval > 0 {
+			viewerPoller = kick.NewViewerCountPoller(kickService, time.Duration(interval*float64(time.Second)), func(count int, live bool) {
+				engine.SetViewerCount(count)
+			})
+			viewerPoller.Start()
+		}
+
+		// Auto-stream: switch the Kick title/category with the stream
+		autoStream := engine.GetAutoStream()
+		autoStream.OnGoLive = func(reason string) {
+			cfg := autoStream.Config()
+			if err := kickService.UpdateChannel(cfg.LiveCategory, cfg.LiveTitle); err != nil {
+				log.Printf("Failed to update channel for live stream: %v", err)
+			}
+			if titleUpdater != nil {
+				titleUpdater.Reset() // Replace the live title with stats on the next update
+			}
+			kickBot.QueueMessage("📡 The arena is LIVE! Type !join to fight")
+		}
+		autoStream.OnGoOffline = func(reason string, uptime time.Duration) {
+			cfg := autoStream.Config()
+			if err := kickService.UpdateChannel(cfg.OfflineCategory, cfg.OfflineTitle); err != nil {
+				log.Printf("Failed to update channel for offline stream: %v", err)
+			}
+			kickBot.QueueMessage(fmt.Sprintf("💤 Arena closed after %s - type !join to bring it back", uptime.Round(time.Minute)))
+		}
+
+		// Channel title with live stats ("FIGHT CLUB — 42 players, top killer: Foo (17)")
+		if appConfig.KickTitle.Interval > 0 {
+			titleUpdater = kick.NewTitleUpdater(kickService, appConfig.KickTitle, func() (kick.TitleStats, bool) {
+				if status := engine.GetAutoStreamStatus(); status.Enabled && !status.Live {
+					return kick.TitleStats{}, false // Keep the offline title
+				}
+				state := engine.GetState()
+				stats := kick.TitleStats{
+					Players: state.PlayerCount,
+					Alive:   state.AliveCount,
+					Kills:   state.TotalKills,
+				}
+				if len(state.Players) > 0 && state.Players[0].Kills > 0 {
+					stats.TopKiller = state.Players[0].Name
+					stats.TopKills = state.Players[0].Kills
+				}
+				return stats, true
+			})
+			titleUpdater.Start()
+		}
+
 		log.Println("Kick OAuth service initialized")
 
 		// Try to auto-subscribe if already authenticated
@@ -216,14 +668,29 @@ func main() {
 					log.Printf("Auto-subscribe failed: %v", err)
 				}
 
+				if cfg := engine.GetAutoStream().Config(); cfg.Enabled {
+					// Stream starts offline until players join
+					if err := kickService.UpdateChannel(cfg.OfflineCategory, cfg.OfflineTitle); err != nil {
+						log.Printf("Failed to update channel: %v", err)
+					}
+					return
+				}
+
 				log.Println("Updating category to 'Just Chatting'...")
 				if err := kickService.SetCategory("Just Chatting"); err != nil {
 					log.Printf("Failed to update category: %v", err)
 				}
 			}()
+		} else {
+			// No browser on a VPS: log in from a laptop and upload the tokens
+			log.Println("Not authenticated with Kick: visit /api/kick/auth, or on a headless server run")
+			log.Printf("  go run ./cmd/kickauth -server <this server's URL> -code %s", kickService.PairingCode())
 		}
 	} else {
 		log.Println("CLIENT_ID_KICK or CLIENT_SECRET_KICK not set - OAuth disabled")
+		if !appConfig.Demo.Enabled {
+			log.Println("ℹ️ Set DEMO_MODE=true to run with synthetic chat traffic")
+		}
 	}
 
 	// Setup Kick routes on separate mux BEFORE creating API server
@@ -273,16 +740,113 @@ func main() {
 		log.Printf("Kick routes mounted at /api/kick (OAuth: localhost:%d, Webhook: %s/api/kick/webhook)", portInt, baseURL)
 	}
 
+	// API keys for third-party integrations (Discord bots, mobile admin apps)
+	apiKeysFile := os.Getenv("API_KEYS_FILE")
+	if apiKeysFile == "" {
+		apiKeysFile = ".api-keys-go.json"
+	}
+	apiKeyRequired := os.Getenv("API_KEYS_REQUIRED") == "true"
+	apiKeys, err := api.NewAPIKeyStore(apiKeysFile)
+	if err != nil {
+		log.Printf("⚠️ API keys disabled: %v", err)
+	} else if !adminAuthEnabled {
+		log.Println("API key management requires ADMIN_AUTH_ENABLED=true")
+	}
+
 	// Create API server with NoOp streamer (streaming is external)
-	server := api.NewServerWithKickAndAuth(engine, noopStreamer, kickMux, sessionManager, adminAuthEnabled)
+	server := api.NewServerWithConfig(engine, api.RouterConfig{
+		Streamer:           remoteStreamer,
+		KickWebhookHandler: kickMux,
+		SessionManager:     sessionManager,
+		EnableAdminAuth:    adminAuthEnabled,
+		APIKeys:            apiKeys,
+		RequireAPIKey:      apiKeyRequired,
+		Aliases:            chatAliases,
+		Moderation:         abuseGuard,
+		Bans:               banList,
+		Weapons:            weaponRegistry,
+		Highlights:         clips,
+		CommandQueue:       commandQueue,
+		Errors:             errorLog,
+	})
+
+	// Readiness checks for /readyz (engine liveness is always checked)
+	server.Health().Register(api.HealthCheck{
+		Name:     "ipc",
+		Critical: true,
+		Check: func() error {
+			if !ipcPublisher.IsRunning() {
+				return errors.New("IPC publisher not running")
+			}
+			return nil
+		},
+	})
+	server.Health().Register(api.HealthCheck{
+		Name: "streamer",
+		Check: func() error {
+			if clients, _, _ := ipcPublisher.GetStats(); clients == 0 {
+				return errors.New("no streamer connected")
+			}
+			return nil
+		},
+	})
+	if kickService != nil {
+		server.Health().Register(api.HealthCheck{
+			Name:  "kick_auth",
+			Check: kickService.CheckAuth,
+		})
+	}
 
 	// Start game engine
 	engine.Start()
 	log.Println("Game Engine started")
 
+	// Start passive income for viewer wallets (loads persisted balances)
+	engine.GetWalletManager().Start()
+
+	// Load persistent daily/weekly/all-time leaderboards
+	engine.GetLeaderboardStore().Start()
+	engine.GetHistory().Start()
+	engine.GetRatingStore().Start()
+	engine.GetCareerStore().Start()
+	engine.GetQuestStore().Start()
+	engine.GetSkinStore().Start()
+	engine.GetAnalytics().Start()
+
+	// The arena saved by the last process (shutdown, restart or the periodic
+	// save before a crash) resumes: fighters, teams, round and scene
+	if restored, err := engine.RestoreArena(arenaStateFile); err != nil {
+		log.Printf("⚠️ Failed to restore arena: %v", err)
+	} else if restored > 0 {
+		log.Printf("🔄 Restored %d fighters from %s", restored, arenaStateFile)
+	}
+	arenaSaveStop := make(chan struct{})
+	arenaSaveDone := make(chan struct{})
+	go func() {
+		defer close(arenaSaveDone)
+		ticker := time.NewTicker(game.ArenaStateSaveInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-arenaSaveStop:
+				return
+			case <-ticker.C:
+				if err := engine.SaveArena(arenaStateFile); err != nil {
+					log.Printf("⚠️ Failed to save arena: %v", err)
+				}
+			}
+		}
+	}()
+
 	// Start API server in goroutine
+	addr := ":" + port
+	httpListener, err := listeners.Listen("http", func() (net.Listener, error) {
+		return net.Listen("tcp", addr)
+	})
+	if err != nil {
+		log.Fatalf("Failed to start server: %v", err)
+	}
 	go func() {
-		addr := ":" + port
 		log.Printf("API server on http://localhost%s", addr)
 		log.Printf("Admin Panel: http://localhost%s/admin", addr)
 
@@ -291,11 +855,49 @@ func main() {
 			log.Printf("Webhook URL: %s/api/kick/webhook", baseURL)
 		}
 
-		if err := server.Start(addr); err != nil {
+		if err := server.Serve(httpListener); err != nil && !errors.Is(err, http.ErrServerClosed) {
 			log.Fatalf("Failed to start server: %v", err)
 		}
 	}()
 
+	// gRPC admin/control API for external tools (every call needs an API key;
+	// plaintext only on loopback, TLS with GRPC_TLS_CERT/GRPC_TLS_KEY)
+	var grpcServer *grpcapi.Server
+	if grpcAddr := os.Getenv("GRPC_ADDR"); grpcAddr != "" && apiKeys != nil {
+		var grpcTLS credentials.TransportCredentials
+		if cert, key := os.Getenv("GRPC_TLS_CERT"), os.Getenv("GRPC_TLS_KEY"); cert != "" || key != "" {
+			if grpcTLS, err = credentials.NewServerTLSFromFile(cert, key); err != nil {
+				log.Fatalf("Failed to load gRPC TLS certificate: %v", err)
+			}
+		}
+		grpcAddr, err := grpcapi.ListenAddr(grpcAddr, grpcTLS != nil)
+		if err != nil {
+			log.Fatalf("Invalid GRPC_ADDR: %v", err)
+		}
+		grpcListener, err := listeners.Listen("grpc", func() (net.Listener, error) {
+			return net.Listen("tcp", grpcAddr)
+		})
+		if err != nil {
+			log.Fatalf("Failed to start gRPC server: %v", err)
+		}
+		grpcServer = grpcapi.NewServer(grpcapi.Config{
+			Engine:   engine,
+			Streamer: remoteStreamer,
+			APIKeys:  apiKeys,
+			TLS:      grpcTLS,
+		})
+		go func() {
+			if err := grpcServer.Serve(grpcListener); err != nil {
+				log.Fatalf("Failed to start gRPC server: %v", err)
+			}
+		}()
+	} else if grpcAddr != "" {
+		log.Println("⚠️ gRPC admin API disabled: it needs API keys")
+	}
+
+	// Tell the previous process (graceful restart) it can exit
+	listeners.Ready()
+
 	log.Println("")
 	log.Println("To enable chat commands:")
 	log.Println("   1. Set PUBLIC_URL in .env to your ngrok URL")
@@ -307,27 +909,115 @@ func main() {
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 
+	// Graceful restart: kill -USR2 <pid> after deploying a new binary
+	upgradeSig := make(chan os.Signal, 1)
+	restart.Notify(upgradeSig)
+
 	log.Println("Server ready! Press Ctrl+C to stop.")
-	<-quit
+	var upgrade *restart.Upgrade
+wait:
+	for {
+		select {
+		case <-quit:
+			break wait
+		case <-upgradeSig:
+			if upgrade, err = listeners.Prepare(); err != nil {
+				log.Printf("⚠️ Graceful restart unavailable: %v", err)
+				continue
+			}
+			break wait
+		}
+	}
 
-	log.Println("Shutting down...")
+	if upgrade != nil {
+		// Freeze the arena and let in-flight requests finish; the listeners
+		// stay open, new connections wait for the new process
+		log.Println("🔄 Graceful restart: draining before handing over...")
+		engine.Pause()
+		ctx, cancel := context.WithTimeout(context.Background(), restart.DrainTimeout)
+		if err := server.Shutdown(ctx); err != nil {
+			log.Printf("⚠️ Drain cut short: %v", err)
+		}
+		cancel()
+	} else {
+		log.Println("Shutting down...")
+	}
+	if grpcServer != nil {
+		grpcServer.Stop()
+	}
 
-	// Stop command queue first (drain pending commands)
+	// Stop demo traffic, then the command queue (drain pending commands)
+	if demoTraffic != nil {
+		demoTraffic.Stop()
+	}
 	commandQueue.Stop()
+	close(arenaSaveStop)
+	<-arenaSaveDone
+	if err := engine.SaveArena(arenaStateFile); err != nil {
+		log.Printf("⚠️ Failed to save arena: %v", err)
+	}
 
+	if feedback != nil {
+		feedback.Stop()
+	}
+	if clips != nil {
+		clips.Stop() // Post in-flight clips before the bot stops
+	}
 	if kickBot != nil {
 		kickBot.Stop()
 	}
+	if viewerPoller != nil {
+		viewerPoller.Stop()
+	}
+	if titleUpdater != nil {
+		titleUpdater.Stop()
+	}
+	if profileCache != nil {
+		profileCache.Stop()
+	}
 
-	// Stop IPC publisher
-	if ipcPublisher != nil {
+	// Stop IPC publisher (streamers reconnect to the new process after a handoff)
+	if upgrade != nil {
+		ipcPublisher.Handoff()
+	} else {
 		ipcPublisher.Stop()
 	}
+	if snapshotRecorder != nil {
+		ipcPublisher.SetRecorder(nil)
+		if err := snapshotRecorder.Close(); err != nil {
+			log.Printf("Snapshot recording: %v", err)
+		}
+		log.Printf("🎞️ Recorded %d snapshots to %s", snapshotRecorder.Snapshots(), snapshotRecorder.Path())
+	}
 
 	// Note: No streamer.Stop() - streaming is handled by external process
 
+	engine.GetWalletManager().Stop()
+	engine.GetLeaderboardStore().Stop()
+	engine.GetHistory().Stop()
+	engine.GetRatingStore().Stop()
+	engine.GetCareerStore().Stop()
+	engine.GetQuestStore().Stop()
+	engine.GetSkinStore().Stop()
+	engine.GetAnalytics().Stop()
+	engine.GetBalance().Save()
+	if chatAliases != nil {
+		chatAliases.Stop()
+	}
+	if gameScripts != nil {
+		gameScripts.Stop()
+	}
+	notifier.Stop()
 	engine.StopEventLog()
 	engine.Stop()
+
+	if upgrade != nil {
+		if err := upgrade.Start(); err != nil {
+			log.Fatalf("❌ Graceful restart failed: %v (the arena in %s is restored on the next start)", err, arenaStateFile)
+		}
+		log.Println("🔄 New process is serving the arena. Goodbye!")
+		return
+	}
 	log.Println("Goodbye!")
 }
 
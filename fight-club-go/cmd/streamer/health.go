@@ -0,0 +1,112 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"fight-club/internal/ipc"
+	"fight-club/internal/streaming"
+)
+
+// snapshotStaleAfter is how old the last IPC snapshot may be before
+// the streamer is considered disconnected from the game server.
+const snapshotStaleAfter = 5 * time.Second
+
+// healthState is shared between main and the health server
+type healthState struct {
+	streamer   *streaming.StreamManager
+	subscriber *ipc.Subscriber
+	source     *streaming.IPCSnapshotSource
+	booted     atomic.Bool // Set once the first stream start was attempted
+	suspended  atomic.Bool // Auto-stream: FFmpeg stopped on purpose (arena idle)
+}
+
+// probeResult is the JSON body returned by /healthz and /readyz
+type probeResult struct {
+	Status string `json:"status"` // "ok", "starting" or "fail"
+	FFmpeg string `json:"ffmpeg"` // "running", "reconnecting", "suspended" or "stopped"
+	IPC    string `json:"ipc"`    // "connected" or "disconnected"
+	Detail string `json:"detail,omitempty"`
+}
+
+func (hs *healthState) probe() (ffmpegOK, ffmpegAlive, ipcOK bool, res probeResult) {
+	res.FFmpeg = "stopped"
+	switch {
+	case hs.streamer.IsStreaming():
+		res.FFmpeg = "running"
+		ffmpegOK, ffmpegAlive = true, true
+	case hs.streamer.IsReconnecting():
+		res.FFmpeg = "reconnecting"
+		ffmpegAlive = true
+	case hs.suspended.Load():
+		// Offline until players join - healthy, nothing to restart
+		res.FFmpeg = "suspended"
+		ffmpegOK, ffmpegAlive = true, true
+	}
+
+	res.IPC = "disconnected"
+	if hs.subscriber.IsConnected() {
+		res.IPC = "connected"
+		ipcOK = true
+	}
+	if snap := hs.source.GetSnapshot(); snap != nil {
+		age := time.Since(snap.Timestamp)
+		res.Detail = fmt.Sprintf("last snapshot %s ago", age.Round(time.Millisecond))
+		if age > snapshotStaleAfter {
+			ipcOK = false
+		}
+	} else {
+		ipcOK = false
+		res.Detail = "no snapshot received yet"
+	}
+	return
+}
+
+// startHealthServer serves /healthz (FFmpeg liveness) and /readyz
+// (FFmpeg streaming + fresh IPC snapshots) for container orchestration
+func startHealthServer(addr string, hs *healthState) {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		_, ffmpegAlive, _, res := hs.probe()
+		code := http.StatusOK
+		res.Status = "ok"
+		if !hs.booted.Load() {
+			res.Status = "starting"
+		} else if !ffmpegAlive {
+			// FFmpeg died and auto-reconnect gave up - restart the process
+			res.Status = "fail"
+			code = http.StatusServiceUnavailable
+		}
+		writeProbe(w, res, code)
+	})
+
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		ffmpegOK, _, ipcOK, res := hs.probe()
+		code := http.StatusOK
+		res.Status = "ok"
+		if !ffmpegOK || !ipcOK {
+			res.Status = "fail"
+			code = http.StatusServiceUnavailable
+		}
+		writeProbe(w, res, code)
+	})
+
+	go func() {
+		log.Printf("Health server on %s (/healthz, /readyz)", addr)
+		if err := http.ListenAndServe(addr, mux); err != nil {
+			log.Printf("WARNING: Health server error: %v", err)
+		}
+	}()
+}
+
+func writeProbe(w http.ResponseWriter, res probeResult, code int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(res)
+}
@@ -16,14 +16,22 @@
 package main
 
 import (
+	"fmt"
 	"log"
 	"os"
 	"os/signal"
 	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
+	"fight-club/internal/api"
+	"fight-club/internal/config"
+	"fight-club/internal/game"
 	"fight-club/internal/ipc"
+	"fight-club/internal/notify"
 	"fight-club/internal/streaming"
 
 	"github.com/joho/godotenv"
@@ -37,6 +45,11 @@ func main() {
 		}
 	}
 
+	// Optional fight-club.yaml (environment variables take precedence)
+	if _, err := config.LoadFile(); err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+
 	log.Println("================================")
 	log.Println("  FIGHT CLUB - STREAMER")
 	log.Println("  GPU Encoding (NVENC)")
@@ -53,28 +66,92 @@ func main() {
 	// Video config - will be overridden by server config when received
 	width := getEnvInt("STREAM_WIDTH", 1280)
 	height := getEnvInt("STREAM_HEIGHT", 720)
+	if preset, ok := game.GetArenaPreset(os.Getenv("ARENA_PRESET")); ok {
+		width, height = preset.Width, preset.Height
+	}
 	fps := getEnvInt("STREAM_FPS", 24)
 	bitrate := getEnvInt("STREAM_BITRATE", 4000)
+	renderer := getEnvWithDefault("STREAM_RENDERER", streaming.RendererGG)
+
+	// Local VOD recording (empty dir = disabled)
+	recording := streaming.RecordingConfig{
+		Dir:            os.Getenv("RECORDING_DIR"),
+		Format:         getEnvWithDefault("RECORDING_FORMAT", streaming.RecordingFormatMKV),
+		SegmentSeconds: getEnvInt("RECORDING_SEGMENT_SECONDS", 600),
+		RetainHours:    getEnvFloat("RECORDING_RETAIN_HOURS", 72),
+		MaxSizeMB:      int64(getEnvInt("RECORDING_MAX_SIZE_MB", 20000)),
+	}
+
+	// Dynamic resolution scaling under encoder pressure
+	adaptiveResolution := streaming.AdaptiveResolutionConfig{
+		Enabled:  os.Getenv("ADAPTIVE_RESOLUTION") == "true",
+		MinScale: getEnvFloat("ADAPTIVE_RESOLUTION_MIN_SCALE", 0.5),
+	}
+
+	// Smooth motion between game ticks (blends the last two snapshots)
+	interpolation := os.Getenv("RENDER_INTERPOLATION") != "false"
+
+	// Shed particles, the minimap, then every other frame when rendering falls behind
+	frameSkip := os.Getenv("RENDER_FRAME_SKIP") != "false"
+
+	// Draw only the kill leaders and the fighters around them in full past 100 players
+	lod := os.Getenv("RENDER_LOD") != "false"
+
+	// 9:16 portrait simulcast (empty URL = disabled)
+	portrait := streaming.PortraitConfig{
+		RTMPURL: os.Getenv("PORTRAIT_RTMP_URL"),
+		Width:   getEnvInt("PORTRAIT_WIDTH", streaming.DefaultPortraitWidth),
+		Height:  getEnvInt("PORTRAIT_HEIGHT", streaming.DefaultPortraitHeight),
+		Bitrate: getEnvInt("PORTRAIT_BITRATE", streaming.DefaultPortraitBitrate),
+	}
+
+	// Optional widgets
+	theme := streaming.ThemeConfig{
+		Minimap:   os.Getenv("THEME_MINIMAP") != "false",
+		Font:      os.Getenv("THEME_FONT"),
+		EmojiFont: os.Getenv("THEME_EMOJI_FONT"),
+
+		Leaderboard:            os.Getenv("THEME_LEADERBOARD"),
+		LeaderboardPageSeconds: getEnvFloat("THEME_LEADERBOARD_PAGE_SECONDS", streaming.DefaultLeaderboardPageSeconds),
+
+		AutoFrame:           os.Getenv("THEME_AUTO_FRAME") == "true",
+		AutoFrameMaxPlayers: getEnvInt("THEME_AUTO_FRAME_MAX_PLAYERS", streaming.DefaultAutoFrameMaxPlayers),
+		AutoFrameMaxZoom:    getEnvFloat("THEME_AUTO_FRAME_MAX_ZOOM", streaming.DefaultAutoFrameMaxZoom),
+	}
 
 	// Audio config
+	// SRT output for OBS instead of RTMP (empty URL = stream to Kick)
+	srt := streaming.SRTConfig{
+		URL:        os.Getenv("SRT_OUTPUT_URL"),
+		LatencyMs:  getEnvInt("SRT_LATENCY_MS", streaming.DefaultSRTLatencyMs),
+		Passphrase: os.Getenv("SRT_PASSPHRASE"),
+	}
+
 	musicEnabled := os.Getenv("MUSIC_ENABLED") != "false"
 	musicVolume := getEnvFloat("MUSIC_VOLUME", 0.15)
 	musicPath := getEnvWithDefault("MUSIC_PATH", "assets/music/digital_fight_arena.ogg")
 
-	if streamKey == "" {
+	if streamKey == "" && !srt.Enabled() {
 		log.Println("ERROR: STREAM_KEY_KICK not set!")
-		log.Println("Set STREAM_KEY_KICK in your .env file")
+		log.Println("Set STREAM_KEY_KICK in your .env file (or SRT_OUTPUT_URL to feed OBS)")
 		os.Exit(1)
 	}
 
 	log.Printf("IPC Socket: %s", socketPath)
 	log.Printf("Video: %dx%d @ %d FPS, %dk bitrate", width, height, fps, bitrate)
 	log.Println("")
-	log.Println("DIRECT STREAMING (no ngrok/tunnel):")
-	log.Printf("  RTMP Endpoint: %s", rtmpURL)
-	log.Printf("  Stream Key: %s...", streamKey[:min(15, len(streamKey))])
-	log.Println("  -> Video data goes directly to Kick's ingest servers")
-	log.Println("  -> No proxy or tunnel overhead = minimal latency")
+	if srt.Enabled() {
+		log.Println("SRT OUTPUT (composite in OBS):")
+		log.Printf("  Listening on: %s", srt.URL)
+		log.Println("  -> In OBS: Media Source, input srt://<this host>:<port>?mode=caller")
+		log.Println("  -> Uncheck \"Local File\" and \"Restart playback when source becomes active\"")
+	} else {
+		log.Println("DIRECT STREAMING (no ngrok/tunnel):")
+		log.Printf("  RTMP Endpoint: %s", rtmpURL)
+		log.Printf("  Stream Key: %s...", streamKey[:min(15, len(streamKey))])
+		log.Println("  -> Video data goes directly to Kick's ingest servers")
+		log.Println("  -> No proxy or tunnel overhead = minimal latency")
+	}
 
 	// =========================================================================
 	// HARDWARE ENCODING - NVENC by default
@@ -104,16 +181,119 @@ func main() {
 		MusicEnabled: musicEnabled,
 		MusicVolume:  musicVolume,
 		MusicPath:    musicPath,
+		Sounds:       config.SoundsFromEnv(),
 		UseNVENC:     useNVENC,
 		ForceNVENC:   forceNVENC,
+		Renderer:     renderer,
+		Recording:    recording,
+		SRT:          srt,
+
+		AdaptiveResolution: adaptiveResolution,
+		Interpolation:      interpolation,
+		FrameSkip:          frameSkip,
+		LOD:                lod,
+		Theme:              theme,
+		Portrait:           portrait,
+		AvatarCache:        config.AvatarCacheFromEnv(),
+	}
+
+	// Named encoder profile overrides the size, frame rate, bitrate and encoder
+	if name := os.Getenv("FFMPEG_PROFILE"); name != "" {
+		if profile, ok := streaming.GetFFmpegProfile(name); ok {
+			streamConfig = profile.Apply(streamConfig)
+			streamConfig.Profile = name
+			log.Printf("FFmpeg profile: %s (%dx%d @ %d FPS, %dk bitrate)",
+				name, streamConfig.Width, streamConfig.Height, streamConfig.FPS, streamConfig.Bitrate)
+		} else {
+			log.Printf("Unknown FFMPEG_PROFILE %q (available: %s)", name, strings.Join(streaming.FFmpegProfileNames(), ", "))
+		}
 	}
 
 	// Create stream manager with IPC source
 	streamer := streaming.NewStreamManagerWithSource(snapshotSource, streamConfig)
 
+	// Health probes for container deployment (must be reachable by the orchestrator)
+	health := &healthState{
+		streamer:   streamer,
+		subscriber: subscriber,
+		source:     snapshotSource,
+	}
+	if addr := getEnvWithDefault("STREAMER_HEALTH_ADDR", ":6061"); addr != "off" {
+		startHealthServer(addr, health)
+	}
+
+	// Debug server (pprof, metrics, render stage budget, preview) - localhost only,
+	// next to the game server's on 6060
+	if addr := getEnvWithDefault("STREAMER_DEBUG_ADDR", "127.0.0.1:6062"); addr != "off" && os.Getenv("DISABLE_DEBUG_SERVER") != "true" {
+		debugCfg := api.DefaultObservabilityConfig()
+		debugCfg.ListenAddr = addr
+		debugCfg.Preview = streamer
+		if err := api.StartDebugServer(debugCfg); err != nil {
+			log.Printf("Debug server disabled: %v", err)
+		}
+	}
+
+	// Discord notifications (go-live, stream errors, stream ended)
+	notifier, err := notify.New(config.NotifyFromEnv())
+	if err != nil {
+		log.Fatalf("Invalid Discord notification config: %v", err)
+	}
+	notifier.Start()
+
+	// Reconnections restart the stream, only announce going live once
+	// (per auto-stream session: suspending resets it)
+	var announced atomic.Bool
+	streamer.OnStreamStart(func() {
+		if announced.CompareAndSwap(false, true) {
+			notifier.Notify(notify.EventStreamStart, nil)
+		}
+	})
+	streamer.OnStreamError(func(err error) {
+		notifier.Notify(notify.EventStreamError, map[string]interface{}{"Error": err.Error()})
+	})
+
 	// Track connection state
 	connected := false
+	var streamMu sync.Mutex // Guards startedStream/liveSince (auto-stream follower)
 	var startedStream bool
+	var liveSince time.Time
+
+	startStream := func() error {
+		streamMu.Lock()
+		defer streamMu.Unlock()
+
+		log.Println("Starting stream to Kick...")
+		if err := streamer.Start(); err != nil {
+			return err
+		}
+		startedStream = true
+		liveSince = time.Now()
+		health.suspended.Store(false)
+		log.Println("Stream started successfully!")
+		return nil
+	}
+
+	// stopStream ends the broadcast: suspend keeps the process ready to go
+	// live again (auto-stream), otherwise the stream is shut down for good
+	stopStream := func(suspend bool) {
+		streamMu.Lock()
+		defer streamMu.Unlock()
+
+		if !startedStream {
+			return
+		}
+		if suspend {
+			streamer.Suspend()
+			health.suspended.Store(true)
+			announced.Store(false)
+		} else {
+			streamer.Stop()
+		}
+		startedStream = false
+		notifier.Notify(notify.EventStreamStop, map[string]interface{}{
+			"Uptime": time.Since(liveSince).Round(time.Second).String(),
+		})
+	}
 
 	// Set up connection callbacks
 	subscriber.OnConnect(func() {
@@ -127,10 +307,73 @@ func main() {
 		// Don't stop streaming immediately - IPC will reconnect
 	})
 
+	// telemetry samples the stream for the game server's /api/stats
+	telemetry := func() ipc.TelemetryMessage {
+		msg := streamer.Telemetry()
+		msg.SnapshotsReceived, _, _ = subscriber.GetStats()
+		return msg
+	}
+
+	// Stream control from the admin panel (/api/stream/start and /stop). A
+	// remote stop holds the stream off, auto-stream included, until a remote
+	// start.
+	var held atomic.Bool
+	subscriber.OnControl(func(cmd ipc.ControlCommand) error {
+		// Report the new state right away (before the answer reaches the server)
+		defer func() { subscriber.SendTelemetry(telemetry()) }()
+
+		switch cmd {
+		case ipc.ControlStart:
+			log.Println("Stream start requested by the game server")
+			held.Store(false)
+			streamMu.Lock()
+			live := startedStream
+			streamMu.Unlock()
+			if live {
+				return nil
+			}
+			return startStream()
+		case ipc.ControlStop:
+			log.Println("Stream stop requested by the game server")
+			held.Store(true)
+			stopStream(true)
+			return nil
+		case ipc.ControlKeyframe:
+			log.Println("Keyframe requested by the game server")
+			return streamer.ForceKeyframe()
+		}
+		return fmt.Errorf("unknown control command %q", cmd)
+	})
+
+	// The server's size is its arena: follow it when it differs from the one
+	// this streamer was started for (arena preset at startup or from the admin
+	// panel). FPS and bitrate stay local (FFMPEG_PROFILE, STREAM_*).
+	var arenaMu sync.Mutex
+	arenaWidth, arenaHeight := width, height
 	subscriber.OnConfig(func(cfg *ipc.ConfigMessage) {
 		log.Printf("Received config from server: %dx%d @ %d FPS, %dk bitrate",
 			cfg.Width, cfg.Height, cfg.FPS, cfg.Bitrate)
-		// Note: To apply new config, would need to restart stream
+
+		// Weapons from the server's weapons file (renderers and sounds look them up)
+		if err := game.InstallWeaponDefinitions(cfg.Weapons); err != nil {
+			log.Printf("ERROR: Failed to install weapons from server: %v", err)
+		}
+
+		arenaMu.Lock()
+		resized := cfg.Width > 0 && cfg.Height > 0 && (cfg.Width != arenaWidth || cfg.Height != arenaHeight)
+		arenaWidth, arenaHeight = cfg.Width, cfg.Height
+		arenaMu.Unlock()
+		if !resized {
+			return
+		}
+
+		// Off the IPC read loop: restarting FFmpeg takes a moment
+		go func() {
+			log.Printf("Arena resized to %dx%d, restarting the stream at that size", cfg.Width, cfg.Height)
+			if err := streamer.Resize(cfg.Width, cfg.Height); err != nil {
+				log.Printf("ERROR: Failed to resize stream: %v", err)
+			}
+		}()
 	})
 
 	// Start IPC subscriber
@@ -168,15 +411,56 @@ func main() {
 		log.Println("WARNING: No snapshot received yet, starting stream anyway")
 	}
 
-	// Start streaming
-	log.Println("Starting stream to Kick...")
-	if err := streamer.Start(); err != nil {
+	// Start streaming (with auto-stream, only once the arena is live)
+	if snap := snapshotSource.GetSnapshot(); snap != nil && snap.AutoStream && !snap.StreamLive {
+		log.Println("Auto-stream: arena idle, waiting for players before going live")
+		health.suspended.Store(true)
+	} else if err := startStream(); err != nil {
 		log.Printf("ERROR: Failed to start stream: %v", err)
 		log.Println("Check that your STREAM_KEY_KICK is valid")
-	} else {
-		startedStream = true
-		log.Println("Stream started successfully!")
 	}
+	health.booted.Store(true)
+
+	// Auto-stream follower: start/suspend FFmpeg as the game server decides
+	go func() {
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+
+		var retryAt time.Time
+		for range ticker.C {
+			snap := snapshotSource.GetSnapshot()
+			if snap == nil || !snap.AutoStream || held.Load() {
+				continue
+			}
+
+			streamMu.Lock()
+			live := startedStream
+			streamMu.Unlock()
+
+			switch {
+			case snap.StreamLive && !live && time.Now().After(retryAt):
+				log.Println("Auto-stream: players joined, going live")
+				if err := startStream(); err != nil {
+					log.Printf("ERROR: Failed to start stream: %v (retrying in 30s)", err)
+					retryAt = time.Now().Add(30 * time.Second)
+				}
+			case !snap.StreamLive && live:
+				log.Println("Auto-stream: arena idle, suspending stream")
+				stopStream(true)
+			}
+		}
+	}()
+
+	// Telemetry: the game server's /api/stats shows this process's stream stats
+	go func() {
+		ticker := time.NewTicker(ipc.TelemetryInterval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			// Not connected: the server learns the stream state on reconnect
+			subscriber.SendTelemetry(telemetry())
+		}
+	}()
 
 	// Stats logging goroutine
 	go func() {
@@ -190,8 +474,9 @@ func main() {
 				received, seq, reconnects, errors, connected)
 
 			stats := streamer.GetStats()
-			log.Printf("Stream: frames=%v, uptime=%v, streaming=%v",
-				stats["framesSent"], stats["uptime"], stats["streaming"])
+			avSync, _ := stats["avSync"].(map[string]interface{})
+			log.Printf("Stream: frames=%v, uptime=%v, streaming=%v, avOffset=%vms",
+				stats["framesSent"], stats["uptime"], stats["streaming"], avSync["offsetMs"])
 		}
 	}()
 
@@ -206,10 +491,9 @@ func main() {
 
 	log.Println("Shutting down streamer...")
 
-	if startedStream {
-		streamer.Stop()
-	}
+	stopStream(false)
 	subscriber.Stop()
+	notifier.Stop() // Delivers the stream-ended message
 
 	log.Println("Streamer stopped!")
 }
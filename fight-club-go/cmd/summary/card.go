@@ -0,0 +1,137 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"log"
+	"strings"
+
+	"fight-club/internal/game"
+	"fight-club/internal/streaming"
+
+	"github.com/fogleman/gg"
+)
+
+// Recap card size (16:9, the size social sites preview)
+const (
+	cardWidth  = 1200
+	cardHeight = 675
+	cardMargin = 56.0
+	cardRows   = 5 // Killers and weapons listed
+)
+
+var (
+	cardBackground = color.RGBA{18, 18, 24, 255}
+	cardPanel      = color.RGBA{32, 32, 42, 255}
+	cardText       = color.RGBA{255, 255, 255, 255}
+	cardMuted      = color.RGBA{160, 160, 175, 255}
+	cardGold       = color.RGBA{255, 215, 0, 255}
+	cardBar        = color.RGBA{231, 76, 60, 255}
+)
+
+// renderCard draws the recap card: header, champion, totals, top killers and weapons
+func renderCard(recap game.StreamRecap, fontPath string) *gg.Context {
+	fonts := streaming.NewFontRegistry(fontPath, "")
+	dc := gg.NewContext(cardWidth, cardHeight)
+	setFont := func(size float64) {
+		face, err := fonts.Face(size)
+		if err != nil {
+			log.Printf("⚠️ Failed to load recap font: %v", err)
+			return
+		}
+		dc.SetFontFace(face)
+	}
+
+	dc.SetColor(cardBackground)
+	dc.Clear()
+	dc.SetColor(cardBar)
+	dc.DrawRectangle(0, 0, cardWidth, 8)
+	dc.Fill()
+
+	// Header
+	setFont(40)
+	dc.SetColor(cardText)
+	dc.DrawString("FIGHT CLUB · STREAM RECAP", cardMargin, 82)
+	setFont(22)
+	dc.SetColor(cardMuted)
+	dc.DrawString(fmt.Sprintf("%s  ·  %s", recap.Start.Format("Mon Jan 2, 2006"), recap.Length()), cardMargin, 120)
+
+	// Champion
+	y := 190.0
+	if recap.Champion != "" {
+		setFont(22)
+		dc.SetColor(cardMuted)
+		dc.DrawString("CHAMPION", cardMargin, y)
+		setFont(48)
+		dc.SetColor(cardGold)
+		dc.DrawString(recap.Champion, cardMargin, y+54)
+		setFont(22)
+		dc.SetColor(cardMuted)
+		dc.DrawString(fmt.Sprintf("%d of %d rounds won", recap.RoundWins[0].Kills, recap.Rounds), cardMargin, y+90)
+	}
+
+	// Totals, right-aligned on the champion row
+	totals := []struct {
+		label string
+		value int
+	}{{"ROUNDS", recap.Rounds}, {"KILLS", recap.Kills}, {"PEAK FIGHTERS", recap.PeakFighters}}
+	for i, t := range totals {
+		x := float64(cardWidth) - cardMargin - float64(len(totals)-1-i)*170
+		setFont(44)
+		dc.SetColor(cardText)
+		dc.DrawStringAnchored(fmt.Sprint(t.value), x, y+44, 1, 0)
+		setFont(16)
+		dc.SetColor(cardMuted)
+		dc.DrawStringAnchored(t.label, x, y+74, 1, 0)
+	}
+
+	// Top killers and weapons panels
+	panelY := 330.0
+	panelW := (float64(cardWidth) - cardMargin*2 - 32) / 2
+	panelH := float64(cardHeight) - panelY - cardMargin
+	for i := 0; i < 2; i++ {
+		dc.SetColor(cardPanel)
+		dc.DrawRoundedRectangle(cardMargin+float64(i)*(panelW+32), panelY, panelW, panelH, 12)
+		dc.Fill()
+	}
+
+	left := cardMargin + 24
+	setFont(20)
+	dc.SetColor(cardMuted)
+	dc.DrawString("TOP KILLERS", left, panelY+40)
+	for i, k := range recap.TopKillers {
+		if i >= cardRows {
+			break
+		}
+		rowY := panelY + 80 + float64(i)*44
+		setFont(24)
+		dc.SetColor(cardText)
+		if i == 0 {
+			dc.SetColor(cardGold)
+		}
+		dc.DrawString(fmt.Sprintf("%d. %s", i+1, k.Name), left, rowY)
+		dc.DrawStringAnchored(fmt.Sprint(k.Kills), cardMargin+panelW-24, rowY, 1, 0)
+	}
+
+	right := cardMargin + panelW + 32 + 24
+	barW := panelW - 48
+	setFont(20)
+	dc.SetColor(cardMuted)
+	dc.DrawString("WEAPONS", right, panelY+40)
+	for i, w := range recap.Weapons {
+		if i >= cardRows {
+			break
+		}
+		rowY := panelY + 80 + float64(i)*44
+		dc.SetColor(cardBar)
+		dc.DrawRoundedRectangle(right, rowY+8, barW*w.Share, 6, 3)
+		dc.Fill()
+		setFont(22)
+		dc.SetColor(cardText)
+		dc.DrawString(strings.ToUpper(w.Weapon), right, rowY)
+		dc.SetColor(cardMuted)
+		dc.DrawStringAnchored(fmt.Sprintf("%d kills · %.0f%%", w.Kills, w.Share*100), right+barW, rowY, 1, 0)
+	}
+
+	return dc
+}
@@ -0,0 +1,78 @@
+// =============================================================================
+// FIGHT CLUB - STREAM RECAP
+// =============================================================================
+// Builds a shareable recap of a stream from the match history the game
+// server persists (HISTORY_FILE): a markdown summary and a PNG card with the
+// champion, top killers and weapons used.
+//
+// USAGE (after the stream ends):
+//
+//	go run ./cmd/summary                    # Latest stream -> recap-<date>.{md,png}
+//	go run ./cmd/summary -since 6h -out recap
+//	go run ./cmd/summary -file .history-go.json -gap 1h
+//
+// =============================================================================
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"time"
+
+	"fight-club/internal/config"
+	"fight-club/internal/game"
+
+	"github.com/joho/godotenv"
+)
+
+func main() {
+	// Load environment (for HISTORY_FILE and THEME_FONT)
+	if err := godotenv.Load("../.env"); err != nil {
+		_ = godotenv.Load(".env")
+	}
+	if _, err := config.LoadFile(); err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+
+	file := flag.String("file", config.HistoryFromEnv().File, "match history file written by the game server")
+	out := flag.String("out", "", "output path without extension (default recap-<stream date>)")
+	since := flag.Duration("since", 0, "recap rounds that ended within this long ago instead of the latest stream")
+	gap := flag.Duration("gap", 30*time.Minute, "pause between rounds that starts a new stream")
+	flag.Parse()
+
+	rounds, err := game.LoadHistory(*file)
+	if err != nil {
+		log.Fatalf("❌ Failed to read match history: %v", err)
+	}
+
+	if *since > 0 {
+		cutoff := time.Now().Add(-*since)
+		recent := rounds[:0]
+		for _, r := range rounds {
+			if r.End.After(cutoff) {
+				recent = append(recent, r)
+			}
+		}
+		rounds = recent
+	} else {
+		rounds = game.LatestSession(rounds, *gap)
+	}
+	if len(rounds) == 0 {
+		log.Fatalf("❌ No rounds to recap in %s", *file)
+	}
+
+	recap := game.RecapRounds(rounds)
+	prefix := *out
+	if prefix == "" {
+		prefix = "recap-" + recap.Start.Format("2006-01-02")
+	}
+
+	if err := os.WriteFile(prefix+".md", []byte(recap.Markdown()), 0o644); err != nil {
+		log.Fatalf("❌ Failed to write recap: %v", err)
+	}
+	if err := renderCard(recap, os.Getenv("THEME_FONT")).SavePNG(prefix + ".png"); err != nil {
+		log.Fatalf("❌ Failed to write recap card: %v", err)
+	}
+	log.Printf("📜 Recap of %d rounds (%d kills) saved to %s.{md,png}", recap.Rounds, recap.Kills, prefix)
+}
@@ -0,0 +1,66 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"fight-club/internal/chat"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// aliasHandlers exposes chat command alias management to the admin API
+type aliasHandlers struct {
+	aliases *chat.AliasStore
+}
+
+// handleList returns all chat aliases
+func (h *aliasHandlers) handleList(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, h.aliases.List())
+}
+
+// handleSet creates or replaces a chat alias ({"trigger": "pelear", "target": "join"})
+func (h *aliasHandlers) handleSet(w http.ResponseWriter, r *http.Request) {
+	var req chat.CommandAlias
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, "Invalid request", http.StatusBadRequest)
+		return
+	}
+
+	alias, err := h.aliases.Set(req.Trigger, req.Target)
+	switch {
+	case errors.Is(err, chat.ErrAliasCollision):
+		writeError(w, err.Error(), http.StatusConflict)
+		return
+	case errors.Is(err, chat.ErrInvalidAlias):
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	case err != nil:
+		writeError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, alias)
+}
+
+// handleDelete removes a chat alias by trigger
+func (h *aliasHandlers) handleDelete(w http.ResponseWriter, r *http.Request) {
+	found, err := h.aliases.Delete(chi.URLParam(r, "trigger"))
+	if err != nil {
+		writeError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !found {
+		writeError(w, "Alias not found", http.StatusNotFound)
+		return
+	}
+	writeJSON(w, map[string]interface{}{"success": true})
+}
+
+// mountAliasRoutes registers alias management under the given router
+func mountAliasRoutes(r chi.Router, aliases *chat.AliasStore) {
+	h := &aliasHandlers{aliases: aliases}
+	r.Get("/aliases", h.handleList)
+	r.Put("/aliases", h.handleSet)
+	r.Delete("/aliases/{trigger}", h.handleDelete)
+}
@@ -0,0 +1,374 @@
+package api
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// APIKeyHeader carries the key for third-party integrations
+	// ("Authorization: Bearer <key>" is also accepted)
+	APIKeyHeader = "X-API-Key"
+
+	// APIKeyPrefix marks generated keys (easy to spot in leaked configs)
+	APIKeyPrefix = "fc_"
+
+	// Default per-key limits
+	DefaultAPIKeyRequestsPerMinute = 60
+	DefaultAPIKeyBurst             = 10
+
+	// APIKeyScopeAdmin lets a key make the gRPC calls that change the game or
+	// the stream (pause, arena, moderation, stream control). Keys without it
+	// only reach what integrations get on REST: join, heal and status.
+	APIKeyScopeAdmin = "admin"
+)
+
+// APIKey is a third-party integration key (Discord bot, mobile admin app).
+// Only the SHA-256 hash of the key is stored; the key itself is shown once on creation.
+type APIKey struct {
+	ID                string    `json:"id"`
+	Name              string    `json:"name"`
+	Hint              string    `json:"hint"` // First characters of the key, for identification
+	Hash              string    `json:"hash"`
+	RequestsPerMinute float64   `json:"requestsPerMinute"`
+	Burst             int       `json:"burst"`
+	Scopes            []string  `json:"scopes,omitempty"` // APIKeyScopeAdmin
+	CreatedAt         time.Time `json:"createdAt"`
+}
+
+// HasScope reports whether the key was granted scope
+func (k APIKey) HasScope(scope string) bool {
+	return slices.Contains(k.Scopes, scope)
+}
+
+// apiKeyEntry is a loaded key with its rate limiter and usage stats
+type apiKeyEntry struct {
+	key      APIKey
+	limiter  *rate.Limiter
+	requests uint64
+	rejected uint64
+	lastUsed time.Time
+}
+
+// APIKeyStore manages API keys with per-key rate limits, persisted to a JSON file
+type APIKeyStore struct {
+	mu   sync.RWMutex
+	keys map[string]*apiKeyEntry // hash -> entry
+	path string                  // "" = in-memory only
+}
+
+// NewAPIKeyStore creates a key store, loading existing keys from path if it exists
+func NewAPIKeyStore(path string) (*APIKeyStore, error) {
+	ks := &APIKeyStore{
+		keys: make(map[string]*apiKeyEntry),
+		path: path,
+	}
+	if path == "" {
+		return ks, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return ks, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read API keys: %w", err)
+	}
+
+	var keys []APIKey
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return nil, fmt.Errorf("failed to parse API keys: %w", err)
+	}
+	for _, k := range keys {
+		ks.keys[k.Hash] = newAPIKeyEntry(k)
+	}
+	log.Printf("🔑 Loaded %d API key(s)", len(keys))
+	return ks, nil
+}
+
+func newAPIKeyEntry(k APIKey) *apiKeyEntry {
+	return &apiKeyEntry{
+		key:     k,
+		limiter: rate.NewLimiter(rate.Limit(k.RequestsPerMinute/60), k.Burst),
+	}
+}
+
+// hashAPIKey returns the hex SHA-256 of a key
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// Create generates a new key with optional scopes (APIKeyScopeAdmin).
+// The plaintext key is returned once and never stored.
+func (ks *APIKeyStore) Create(name string, requestsPerMinute float64, burst int, scopes ...string) (string, APIKey, error) {
+	if name == "" {
+		return "", APIKey{}, errors.New("name is required")
+	}
+	for _, scope := range scopes {
+		if scope != APIKeyScopeAdmin {
+			return "", APIKey{}, fmt.Errorf("unknown scope %q (use %s)", scope, APIKeyScopeAdmin)
+		}
+	}
+	if requestsPerMinute <= 0 {
+		requestsPerMinute = DefaultAPIKeyRequestsPerMinute
+	}
+	if burst <= 0 {
+		burst = DefaultAPIKeyBurst
+	}
+
+	secret := make([]byte, 24)
+	idBytes := make([]byte, 4)
+	if _, err := rand.Read(secret); err != nil {
+		return "", APIKey{}, err
+	}
+	if _, err := rand.Read(idBytes); err != nil {
+		return "", APIKey{}, err
+	}
+
+	plaintext := APIKeyPrefix + hex.EncodeToString(secret)
+	k := APIKey{
+		ID:                hex.EncodeToString(idBytes),
+		Name:              name,
+		Hint:              plaintext[:len(APIKeyPrefix)+6],
+		Hash:              hashAPIKey(plaintext),
+		RequestsPerMinute: requestsPerMinute,
+		Burst:             burst,
+		Scopes:            scopes,
+		CreatedAt:         time.Now(),
+	}
+
+	ks.mu.Lock()
+	ks.keys[k.Hash] = newAPIKeyEntry(k)
+	err := ks.saveLocked()
+	ks.mu.Unlock()
+	if err != nil {
+		return "", APIKey{}, err
+	}
+
+	log.Printf("🔑 API key created: %s (%s, %.0f req/min, scopes %v)", k.Name, k.ID, k.RequestsPerMinute, k.Scopes)
+	return plaintext, k, nil
+}
+
+// Revoke deletes a key by ID
+func (ks *APIKeyStore) Revoke(id string) (bool, error) {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	for hash, entry := range ks.keys {
+		if entry.key.ID == id {
+			delete(ks.keys, hash)
+			log.Printf("🔑 API key revoked: %s (%s)", entry.key.Name, id)
+			return true, ks.saveLocked()
+		}
+	}
+	return false, nil
+}
+
+// List returns all keys (without secrets) with usage stats, oldest first
+func (ks *APIKeyStore) List() []map[string]interface{} {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	entries := make([]*apiKeyEntry, 0, len(ks.keys))
+	for _, entry := range ks.keys {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].key.CreatedAt.Before(entries[j].key.CreatedAt)
+	})
+
+	result := make([]map[string]interface{}, 0, len(entries))
+	for _, e := range entries {
+		item := map[string]interface{}{
+			"id":                e.key.ID,
+			"name":              e.key.Name,
+			"hint":              e.key.Hint,
+			"requestsPerMinute": e.key.RequestsPerMinute,
+			"burst":             e.key.Burst,
+			"scopes":            e.key.Scopes,
+			"createdAt":         e.key.CreatedAt,
+			"requests":          e.requests,
+			"rejected":          e.rejected,
+		}
+		if !e.lastUsed.IsZero() {
+			item["lastUsed"] = e.lastUsed
+		}
+		result = append(result, item)
+	}
+	return result
+}
+
+// authenticate looks up a key and applies its rate limit.
+// Returns the entry (nil if the key is unknown) and whether the request is allowed.
+func (ks *APIKeyStore) authenticate(key string) (*apiKeyEntry, bool) {
+	hash := hashAPIKey(key)
+
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	entry, ok := ks.keys[hash]
+	if !ok {
+		return nil, false
+	}
+	entry.lastUsed = time.Now()
+	if !entry.limiter.Allow() {
+		entry.rejected++
+		return entry, false
+	}
+	entry.requests++
+	return entry, true
+}
+
+// API key check errors (see Authenticate)
+var (
+	ErrAPIKeyInvalid     = errors.New("invalid API key")
+	ErrAPIKeyRateLimited = errors.New("API key rate limit exceeded")
+)
+
+// Authenticate checks a key and applies its rate limit for callers outside
+// the HTTP router (the gRPC API). Returns the key (name and scopes), or
+// ErrAPIKeyInvalid / ErrAPIKeyRateLimited.
+func (ks *APIKeyStore) Authenticate(key string) (APIKey, error) {
+	entry, allowed := ks.authenticate(key)
+	if entry == nil {
+		return APIKey{}, ErrAPIKeyInvalid
+	}
+	if !allowed {
+		RecordConnectionRejected("api_key_rate_limit")
+		return entry.key, ErrAPIKeyRateLimited
+	}
+	return entry.key, nil
+}
+
+// saveLocked persists keys (hashes only) to disk (caller holds lock)
+func (ks *APIKeyStore) saveLocked() error {
+	if ks.path == "" {
+		return nil
+	}
+
+	keys := make([]APIKey, 0, len(ks.keys))
+	for _, entry := range ks.keys {
+		keys = append(keys, entry.key)
+	}
+	data, err := json.MarshalIndent(keys, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// Write atomically so a crash can't leave a truncated key file
+	tmp := ks.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to save API keys: %w", err)
+	}
+	return os.Rename(tmp, ks.path)
+}
+
+// requestAPIKey extracts the key from X-API-Key or "Authorization: Bearer"
+func requestAPIKey(r *http.Request) string {
+	if key := r.Header.Get(APIKeyHeader); key != "" {
+		return strings.TrimSpace(key)
+	}
+	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		return strings.TrimSpace(strings.TrimPrefix(auth, "Bearer "))
+	}
+	return ""
+}
+
+// Middleware authenticates API keys and applies per-key rate limits.
+// A request without a key passes through unless required is set; then only an
+// admin session (the admin panel) is accepted instead.
+func (ks *APIKeyStore) Middleware(required bool, sessions *SessionManager) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := requestAPIKey(r)
+			if key == "" {
+				if required && (sessions == nil || sessions.ValidateSession(r) == nil) {
+					writeError(w, "API key required", http.StatusUnauthorized)
+					return
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			entry, allowed := ks.authenticate(key)
+			if entry == nil {
+				writeError(w, "Invalid API key", http.StatusUnauthorized)
+				return
+			}
+			if !allowed {
+				RecordConnectionRejected("api_key_rate_limit")
+				w.Header().Set("Retry-After", fmt.Sprintf("%.0f", 60/entry.key.RequestsPerMinute+1))
+				writeError(w, "API key rate limit exceeded", http.StatusTooManyRequests)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// HandleList returns all API keys (admin)
+func (ks *APIKeyStore) HandleList(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, ks.List())
+}
+
+// HandleCreate creates an API key and returns it once (admin)
+func (ks *APIKeyStore) HandleCreate(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Name              string   `json:"name"`
+		RequestsPerMinute float64  `json:"requestsPerMinute"`
+		Burst             int      `json:"burst"`
+		Scopes            []string `json:"scopes"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, "Invalid request", http.StatusBadRequest)
+		return
+	}
+
+	plaintext, k, err := ks.Create(strings.TrimSpace(req.Name), req.RequestsPerMinute, req.Burst, req.Scopes...)
+	if err != nil {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"id":                k.ID,
+		"name":              k.Name,
+		"key":               plaintext, // Only time the key is ever returned
+		"requestsPerMinute": k.RequestsPerMinute,
+		"burst":             k.Burst,
+		"scopes":            k.Scopes,
+	})
+}
+
+// HandleRevoke deletes an API key by ID (admin)
+func (ks *APIKeyStore) HandleRevoke(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	found, err := ks.Revoke(id)
+	if err != nil {
+		writeError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !found {
+		writeError(w, "API key not found", http.StatusNotFound)
+		return
+	}
+	writeJSON(w, map[string]interface{}{"success": true})
+}
@@ -0,0 +1,55 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"fight-club/internal/game"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// ArenaInterface switches the arena between presets (world size and tick rate)
+type ArenaInterface interface {
+	// ArenaPreset returns the current arena
+	ArenaPreset() game.ArenaPreset
+	// SetArenaPreset resizes the running arena to a preset
+	SetArenaPreset(name string) error
+}
+
+// arenaHandlers lets admins switch the arena preset
+type arenaHandlers struct {
+	arena ArenaInterface
+}
+
+// handleGet returns the current arena and the available presets
+func (h *arenaHandlers) handleGet(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, map[string]interface{}{
+		"arena":   h.arena.ArenaPreset(),
+		"presets": game.ArenaPresets,
+	})
+}
+
+// handleSet switches the arena preset; the streamer follows the new size
+func (h *arenaHandlers) handleSet(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Preset string `json:"preset"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Preset == "" {
+		writeError(w, "Invalid request", http.StatusBadRequest)
+		return
+	}
+	if err := h.arena.SetArenaPreset(req.Preset); err != nil {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	h.handleGet(w, r)
+}
+
+// mountArenaRoutes registers arena preset switching under the given router
+func mountArenaRoutes(r chi.Router, arena ArenaInterface) {
+	h := &arenaHandlers{arena: arena}
+	r.Get("/arena", h.handleGet)
+	r.Post("/arena", h.handleSet)
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"fight-club/internal/chat"
+	"fight-club/internal/game"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// banHandlers exposes the chat ban list to the admin API
+type banHandlers struct {
+	bans *chat.BanList
+}
+
+// handleList returns the active bans, newest first
+func (h *banHandlers) handleList(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, map[string]interface{}{"bans": h.bans.List()})
+}
+
+// handleBan bans a Kick user by ID, or by a username seen in chat.
+// durationSeconds 0 (or omitted) bans permanently.
+func (h *banHandlers) handleBan(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		UserID          int64  `json:"userId"`
+		Username        string `json:"username"`
+		Reason          string `json:"reason"`
+		DurationSeconds int64  `json:"durationSeconds"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, "Invalid request", http.StatusBadRequest)
+		return
+	}
+	req.Username = game.SanitizeName(strings.TrimPrefix(req.Username, "@"))
+	if req.UserID < 0 || (req.UserID == 0 && req.Username == "") {
+		writeError(w, "userId or username is required", http.StatusBadRequest)
+		return
+	}
+	if req.DurationSeconds < 0 {
+		writeError(w, "durationSeconds must not be negative", http.StatusBadRequest)
+		return
+	}
+
+	ban, err := h.bans.Ban(req.UserID, req.Username, strings.TrimSpace(req.Reason), "admin",
+		time.Duration(req.DurationSeconds)*time.Second)
+	if errors.Is(err, chat.ErrUnknownUser) {
+		writeError(w, err.Error()+" (pass userId)", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		writeError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, map[string]interface{}{"success": true, "ban": ban})
+}
+
+// handleUnban lifts a ban; {user} is a Kick user ID or a username
+func (h *banHandlers) handleUnban(w http.ResponseWriter, r *http.Request) {
+	user := chi.URLParam(r, "user")
+	var userID int64
+	if id, err := strconv.ParseInt(user, 10, 64); err == nil {
+		userID, user = id, ""
+	} else {
+		user = game.SanitizeName(user)
+	}
+
+	ok, err := h.bans.Unban(userID, user)
+	if err != nil {
+		writeError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !ok {
+		writeError(w, "Not banned", http.StatusNotFound)
+		return
+	}
+	writeJSON(w, map[string]bool{"success": true})
+}
+
+// mountBanRoutes registers ban list management under the given router
+func mountBanRoutes(r chi.Router, bans *chat.BanList) {
+	h := &banHandlers{bans: bans}
+	r.Get("/bans", h.handleList)
+	r.Post("/ban", h.handleBan)
+	r.Delete("/ban/{user}", h.handleUnban)
+}
@@ -0,0 +1,78 @@
+package api
+
+import (
+	"encoding/json"
+	"html/template"
+	"log"
+	"net/http"
+
+	"fight-club/internal/profiling"
+)
+
+// handleBudget serves the per-subsystem timing and memory breakdown as JSON
+func handleBudget(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(profiling.Default.Report())
+}
+
+// handleBudgetHTML serves the breakdown as a flame-style page: one bar per
+// subsystem, each phase a segment as wide as its share of the budget
+func handleBudgetHTML(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	if err := budgetPage.Execute(w, profiling.Default.Report()); err != nil {
+		log.Printf("⚠️ Budget page error: %v", err)
+	}
+}
+
+// budgetPage renders a profiling.Report (refreshes every 2s)
+var budgetPage = template.Must(template.New("budget").Funcs(template.FuncMap{
+	// width is a segment width in % of its bar: share of the budget, or of
+	// the measured time when the group has no budget (capped at 100)
+	"width": func(p profiling.PhaseStats) float64 {
+		if p.BudgetPct > 0 {
+			return min(p.BudgetPct, 100)
+		}
+		return p.SharePct
+	},
+	// hue colors segments from green (cheap) to red (most of the budget)
+	"hue": func(pct float64) int {
+		return 120 - int(min(pct, 100)*1.2)
+	},
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta http-equiv="refresh" content="2">
+<title>Fight Club - budget</title>
+<style>
+body { font: 13px monospace; background: #111; color: #ddd; margin: 24px; }
+h2 { margin: 24px 0 6px; font-size: 15px; }
+.bar { display: flex; height: 28px; background: #222; border: 1px solid #444; }
+.seg { overflow: hidden; white-space: nowrap; padding: 6px 4px; box-sizing: border-box; color: #111; border-right: 1px solid #111; }
+.over { color: #f55; }
+table { border-collapse: collapse; margin-top: 8px; }
+td, th { padding: 2px 12px 2px 0; text-align: right; }
+td:first-child, th:first-child { text-align: left; }
+</style>
+</head>
+<body>
+<div>uptime {{printf "%.0f" .UptimeSeconds}}s · heap {{printf "%.1f" .Memory.HeapAllocMB}} MB ({{.Memory.HeapObjects}} objects) ·
+alloc {{printf "%.1f" .Memory.AllocMBPerSec}} MB/s ({{printf "%.0f" .Memory.AllocsPerSec}} objects/s) ·
+GC {{printf "%.1f" .Memory.GCPerMin}}/min, last pause {{printf "%.2f" .Memory.LastGCPauseMs}} ms ·
+{{.Memory.Goroutines}} goroutines</div>
+{{range .Groups}}
+<h2>{{.Name}} — {{printf "%.2f" .AvgMs}} ms{{if .BudgetMs}} of {{printf "%.1f" .BudgetMs}} ms
+<span{{if gt .BudgetPct 100.0}} class="over"{{end}}>({{printf "%.0f" .BudgetPct}}%)</span>{{end}}</h2>
+<div class="bar">{{range .Phases}}<div class="seg" style="width: {{width .}}%; background: hsl({{hue .BudgetPct}}, 60%, 55%)" title="{{.Name}} {{printf "%.2f" .AvgMs}} ms">{{.Name}}</div>{{end}}</div>
+<table>
+<tr><th>phase</th><th>avg ms</th><th>max ms</th><th>last ms</th><th>share</th><th>budget</th><th>count</th></tr>
+{{range .Phases}}<tr><td>{{.Name}}</td><td>{{printf "%.3f" .AvgMs}}</td><td>{{printf "%.3f" .MaxMs}}</td><td>{{printf "%.3f" .LastMs}}</td><td>{{printf "%.0f" .SharePct}}%</td><td>{{printf "%.0f" .BudgetPct}}%</td><td>{{.Count}}</td></tr>
+{{end}}</table>
+{{else}}
+<p>No phases recorded yet (is the game loop or stream running in this process?)</p>
+{{end}}
+</body>
+</html>
+`))
@@ -0,0 +1,200 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"fight-club/internal/game"
+)
+
+// Admin event stream limits
+const (
+	defaultEventInterval = time.Second
+	minEventInterval     = 250 * time.Millisecond
+	maxEventInterval     = 30 * time.Second
+	maxEventStreams      = 16 // Concurrent /api/admin/events clients
+)
+
+// tickStatsProvider is implemented by engines that time their ticks (*game.Engine)
+type tickStatsProvider interface {
+	GetTickStats() game.TickStats
+}
+
+// LogError is one captured warning/error log line
+type LogError struct {
+	Seq     uint64    `json:"seq"`
+	Time    time.Time `json:"time"`
+	Message string    `json:"message"`
+}
+
+// ErrorLog keeps the most recent warning and error log lines for the admin
+// panel. Install it as a tee on the standard logger:
+//
+//	log.SetOutput(io.MultiWriter(os.Stderr, errorLog))
+type ErrorLog struct {
+	mu      sync.Mutex
+	entries []LogError
+	max     int
+	seq     uint64
+}
+
+// NewErrorLog creates an error log keeping the last max entries
+func NewErrorLog(max int) *ErrorLog {
+	if max <= 0 {
+		max = 50
+	}
+	return &ErrorLog{max: max}
+}
+
+// isErrorLine matches the log register used across the codebase (⚠️ / ❌ prefixes)
+func isErrorLine(line string) bool {
+	return strings.Contains(line, "❌") || strings.Contains(line, "⚠️") ||
+		strings.Contains(line, "ERROR") || strings.Contains(line, "panic")
+}
+
+// Write implements io.Writer; non-error lines are ignored
+func (l *ErrorLog) Write(p []byte) (int, error) {
+	for _, line := range bytes.Split(p, []byte("\n")) {
+		msg := string(bytes.TrimSpace(line))
+		if msg == "" || !isErrorLine(msg) {
+			continue
+		}
+		// Strip the standard logger's "2006/01/02 15:04:05 " prefix
+		if len(msg) > 20 && msg[4] == '/' && msg[7] == '/' && msg[13] == ':' {
+			msg = msg[20:]
+		}
+
+		l.mu.Lock()
+		l.seq++
+		l.entries = append(l.entries, LogError{Seq: l.seq, Time: time.Now(), Message: msg})
+		if len(l.entries) > l.max {
+			l.entries = l.entries[len(l.entries)-l.max:]
+		}
+		l.mu.Unlock()
+	}
+	return len(p), nil
+}
+
+// Since returns entries with a sequence number greater than seq (oldest first)
+func (l *ErrorLog) Since(seq uint64) []LogError {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var out []LogError
+	for _, e := range l.entries {
+		if e.Seq > seq {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// handleAdminEvents streams live stats to the admin panel as server-sent events.
+// Every interval (?interval=seconds, default 1) a "stats" event carries engine,
+// stream and chat queue stats; new warning/error log lines arrive as "error" events.
+func (h *routerHandlers) handleAdminEvents(w http.ResponseWriter, r *http.Request) {
+	interval := defaultEventInterval
+	if v := r.URL.Query().Get("interval"); v != "" {
+		secs, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			writeError(w, "interval must be a number of seconds", http.StatusBadRequest)
+			return
+		}
+		interval = min(max(time.Duration(secs*float64(time.Second)), minEventInterval), maxEventInterval)
+	}
+
+	if h.eventStreams.Add(1) > maxEventStreams {
+		h.eventStreams.Add(-1)
+		writeError(w, "too many event streams", http.StatusServiceUnavailable)
+		return
+	}
+	defer h.eventStreams.Add(-1)
+
+	rc := http.NewResponseController(w)
+	_ = rc.SetWriteDeadline(time.Time{}) // Long-lived response
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no") // Disable proxy buffering (nginx)
+	w.WriteHeader(http.StatusOK)
+
+	// Reconnect delay for the browser's EventSource, then replay recent errors
+	fmt.Fprintf(w, "retry: %d\n\n", (3 * time.Second).Milliseconds())
+	var lastErr uint64
+	sendErrors := func() error {
+		if h.errors == nil {
+			return nil
+		}
+		for _, e := range h.errors.Since(lastErr) {
+			if err := writeEvent(w, "error", e); err != nil {
+				return err
+			}
+			lastErr = e.Seq
+		}
+		return nil
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := sendErrors(); err != nil {
+			return
+		}
+		if err := writeEvent(w, "stats", h.liveStats()); err != nil {
+			return
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// liveStats collects the admin dashboard stats
+func (h *routerHandlers) liveStats() map[string]interface{} {
+	engine := map[string]interface{}{}
+	if snapshot := h.engine.GetSnapshot(); snapshot != nil {
+		engine["playerCount"] = snapshot.PlayerCount
+		engine["aliveCount"] = snapshot.AliveCount
+		engine["totalKills"] = snapshot.TotalKills
+		engine["paused"] = snapshot.Paused
+		engine["viewerCount"] = snapshot.ViewerCount
+		engine["projectiles"] = len(snapshot.Projectiles)
+	}
+	if ts, ok := h.engine.(tickStatsProvider); ok {
+		engine["tick"] = ts.GetTickStats()
+	}
+
+	stats := map[string]interface{}{
+		"time":      time.Now().UnixMilli(),
+		"engine":    engine,
+		"streaming": h.streamer.IsStreaming(),
+		"stream":    h.streamer.GetStats(),
+	}
+	if h.queue != nil {
+		stats["queue"] = h.queue.Stats()
+	}
+	return stats
+}
+
+// writeEvent writes one server-sent event with a JSON payload
+func writeEvent(w http.ResponseWriter, event string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, payload)
+	return err
+}
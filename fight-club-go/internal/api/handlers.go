@@ -5,26 +5,41 @@ import (
 	"fmt"
 	"log"
 	"net/http"
-	"sort"
+	"strconv"
+	"time"
 
 	"fight-club/internal/game"
+	"fight-club/internal/highlights"
+
+	"github.com/go-chi/chi/v5"
 )
 
 // Handler methods for routerHandlers
 // These are used by both the standalone router (for testing) and the full Server.
 
+// handleGetState returns all players (kills first) with optional paging,
+// sorting, field selection and team filtering (see parsePlayerQuery).
+// Overlays polling it can extrapolate positions between polls for up to
+// predictionWindow seconds: x + vx*tickRate*t (vx/vy are px per tick).
 func (h *routerHandlers) handleGetState(w http.ResponseWriter, r *http.Request) {
-	state := h.engine.GetState()
-
-	players := make([]map[string]interface{}, 0)
-	for _, p := range state.Players {
-		players = append(players, p.ToJSON())
+	query, err := parsePlayerQuery(r.URL.Query(), "-kills", 0)
+	if err != nil {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
 	}
 
-	writeJSON(w, map[string]interface{}{
+	state := h.engine.GetState()
+	players, total := query.apply(state.Players)
+
+	writeJSONWithETag(w, r, map[string]interface{}{
 		"players":     players,
 		"playerCount": state.PlayerCount,
 		"aliveCount":  state.AliveCount,
+		"total":       total,
+		"offset":      query.offset,
+
+		"tickRate":         h.engine.GetSnapshot().TickRate,
+		"predictionWindow": game.PredictionWindow,
 	})
 }
 
@@ -36,33 +51,98 @@ func (h *routerHandlers) handleGetStats(w http.ResponseWriter, r *http.Request)
 		"playerCount": snapshot.PlayerCount,
 		"aliveCount":  snapshot.AliveCount,
 		"totalKills":  snapshot.TotalKills,
+		"paused":      snapshot.Paused,
+		"viewerCount": snapshot.ViewerCount,
 		"streaming":   h.streamer.IsStreaming(),
 		"streamStats": h.streamer.GetStats(),
 	}
 	writeJSON(w, stats)
 }
 
+// handleGetLeaderboard returns the top 10 players by kills. Accepts the same
+// parameters as /api/state; the matching count is in X-Total-Count.
 func (h *routerHandlers) handleGetLeaderboard(w http.ResponseWriter, r *http.Request) {
-	state := h.engine.GetState()
+	query, err := parsePlayerQuery(r.URL.Query(), "-kills", 10)
+	if err != nil {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 
-	// Sort by kills using O(n log n) sort instead of O(n²) bubble sort
-	players := state.Players
-	sort.Slice(players, func(i, j int) bool {
-		return players[i].Kills > players[j].Kills
-	})
+	players, total := query.apply(h.engine.GetState().Players)
+	w.Header().Set("X-Total-Count", strconv.Itoa(total))
+	writeJSONWithETag(w, r, players)
+}
+
+// handleGetPeriodLeaderboard returns the persistent leaderboard for today, this week or all time
+func (h *routerHandlers) handleGetPeriodLeaderboard(w http.ResponseWriter, r *http.Request) {
+	period, ok := game.ParseLeaderboardPeriod(chi.URLParam(r, "period"))
+	if !ok {
+		writeError(w, "Unknown period (use today, week or alltime)", http.StatusNotFound)
+		return
+	}
 
-	// Top 10
 	limit := 10
-	if len(players) < limit {
-		limit = len(players)
+	if l, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && l > 0 && l <= 100 {
+		limit = l
+	}
+
+	writeJSON(w, map[string]interface{}{
+		"period":  period,
+		"title":   period.Title(),
+		"entries": h.leaderboards.Top(period, time.Now(), limit),
+	})
+}
+
+// handleGetHistory returns the round in progress and the latest finished
+// rounds, most recent first (?limit=, default 20)
+func (h *routerHandlers) handleGetHistory(w http.ResponseWriter, r *http.Request) {
+	limit := 20
+	if l, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && l > 0 && l <= 100 {
+		limit = l
 	}
 
-	result := make([]map[string]interface{}, 0)
-	for i := 0; i < limit; i++ {
-		result = append(result, players[i].ToJSON())
+	writeJSON(w, map[string]interface{}{
+		"current": h.history.Current(),
+		"rounds":  h.history.Rounds(limit),
+	})
+}
+
+// handleGetHighlights returns the newest highlight clips (?limit=, default 20)
+func (h *routerHandlers) handleGetHighlights(w http.ResponseWriter, r *http.Request) {
+	limit := 20
+	if l, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && l > 0 && l <= highlights.MaxClips {
+		limit = l
+	}
+
+	writeJSON(w, map[string]interface{}{
+		"clips": h.highlights.Clips(limit),
+	})
+}
+
+// handleGetAnalytics returns session combat stats (fight duration, lethal zones, weapon winrates)
+func (h *routerHandlers) handleGetAnalytics(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, h.analytics.Stats())
+}
+
+// handleGetHeatmap returns the combat damage heatmap as a PNG
+func (h *routerHandlers) handleGetHeatmap(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "image/png")
+	w.Header().Set("Cache-Control", "no-store")
+	if err := h.analytics.WriteHeatmapPNG(w); err != nil {
+		log.Printf("⚠️ Failed to render heatmap: %v", err)
 	}
+}
 
-	writeJSON(w, result)
+// handleGetBalance returns the per-weapon balance report (pick rate, kill
+// rate, time-to-kill, combo usage); ?format=md renders it as markdown
+func (h *routerHandlers) handleGetBalance(w http.ResponseWriter, r *http.Request) {
+	report := h.balance.Report()
+	if r.URL.Query().Get("format") == "md" {
+		w.Header().Set("Content-Type", "text/markdown; charset=utf-8")
+		w.Write([]byte(report.Markdown()))
+		return
+	}
+	writeJSON(w, report)
 }
 
 func (h *routerHandlers) handlePlayerJoin(w http.ResponseWriter, r *http.Request) {
@@ -76,6 +156,7 @@ func (h *routerHandlers) handlePlayerJoin(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	req.Name = game.SanitizeName(req.Name)
 	if req.Name == "" {
 		writeError(w, "Name is required", http.StatusBadRequest)
 		return
@@ -185,14 +266,61 @@ func (h *routerHandlers) handleStreamStop(w http.ResponseWriter, r *http.Request
 	writeJSON(w, map[string]bool{"success": true})
 }
 
+// handleStreamKeyframe refreshes a corrupted picture on viewers' players
+func (h *routerHandlers) handleStreamKeyframe(w http.ResponseWriter, r *http.Request) {
+	forcer, ok := h.streamer.(KeyframeInterface)
+	if !ok {
+		writeError(w, "Streamer can't force keyframes", http.StatusNotImplemented)
+		return
+	}
+	log.Println("🔑 Keyframe requested via API")
+	if err := forcer.ForceKeyframe(); err != nil {
+		log.Printf("❌ Forcing a keyframe failed: %v", err)
+		writeError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, map[string]bool{"success": true})
+}
+
+// handleReplay plays the last seconds of the arena back in slow motion on stream
+func (h *routerHandlers) handleReplay(w http.ResponseWriter, r *http.Request) {
+	replayer, ok := h.engine.(ReplayInterface)
+	if !ok {
+		writeError(w, "Engine can't play replays", http.StatusNotImplemented)
+		return
+	}
+	log.Println("🎞️ Replay requested via API")
+	if err := replayer.RequestReplay(); err != nil {
+		writeError(w, err.Error(), http.StatusConflict)
+		return
+	}
+	writeJSON(w, map[string]bool{"success": true})
+}
+
 func (h *routerHandlers) handleStreamStatus(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, h.streamer.GetStats())
 }
 
+func (h *routerHandlers) handlePause(w http.ResponseWriter, r *http.Request) {
+	log.Println("⏸️ Pause requested via API")
+	changed := h.engine.Pause()
+	writeJSON(w, map[string]bool{"success": true, "paused": true, "changed": changed})
+}
+
+func (h *routerHandlers) handleResume(w http.ResponseWriter, r *http.Request) {
+	log.Println("▶️ Resume requested via API")
+	changed := h.engine.Resume()
+	writeJSON(w, map[string]bool{"success": true, "paused": false, "changed": changed})
+}
+
 func (h *routerHandlers) handleGetWeapons(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, game.GetAllWeapons())
 }
 
+func (h *routerHandlers) handleGetSkins(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, game.GetAllSkins())
+}
+
 // Helper functions (package-level for reuse)
 
 func writeJSON(w http.ResponseWriter, data interface{}) {
@@ -0,0 +1,145 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DefaultTickStaleAfter is how long the engine may go without producing
+// a snapshot before the liveness probe reports it as hung.
+const DefaultTickStaleAfter = 5 * time.Second
+
+// HealthCheck is a named readiness check used by /readyz.
+// Critical checks fail readiness (503); non-critical ones only mark it degraded.
+type HealthCheck struct {
+	Name     string
+	Critical bool
+	Check    func() error
+}
+
+// HealthRegistry holds the readiness checks registered by the process wiring
+// (IPC publisher, Kick auth, ...). Engine liveness is always checked.
+type HealthRegistry struct {
+	mu             sync.RWMutex
+	checks         []HealthCheck
+	tickStaleAfter time.Duration
+}
+
+// NewHealthRegistry creates an empty health registry
+func NewHealthRegistry() *HealthRegistry {
+	return &HealthRegistry{tickStaleAfter: DefaultTickStaleAfter}
+}
+
+// Register adds a readiness check
+func (hr *HealthRegistry) Register(check HealthCheck) {
+	hr.mu.Lock()
+	hr.checks = append(hr.checks, check)
+	hr.mu.Unlock()
+}
+
+// healthCheckResult is the JSON form of a single check
+type healthCheckResult struct {
+	OK       bool   `json:"ok"`
+	Critical bool   `json:"critical"`
+	Detail   string `json:"detail,omitempty"`
+}
+
+// healthResponse is the JSON body returned by /healthz and /readyz
+type healthResponse struct {
+	Status string                       `json:"status"` // "ok", "degraded", "starting" or "fail"
+	Checks map[string]healthCheckResult `json:"checks"`
+}
+
+// engineLiveness checks that the game loop is still producing snapshots.
+// Returns started=false before the first tick.
+func (h *routerHandlers) engineLiveness() (result healthCheckResult, started bool) {
+	snap := h.engine.GetSnapshot()
+	if snap == nil || snap.Timestamp.IsZero() {
+		return healthCheckResult{OK: false, Critical: true, Detail: "engine has not ticked yet"}, false
+	}
+
+	age := time.Since(snap.Timestamp)
+	if age > h.health.tickStaleAfter {
+		return healthCheckResult{
+			OK:       false,
+			Critical: true,
+			Detail:   fmt.Sprintf("last tick %d was %s ago", snap.TickNumber, age.Round(time.Millisecond)),
+		}, true
+	}
+	return healthCheckResult{
+		OK:       true,
+		Critical: true,
+		Detail:   fmt.Sprintf("tick %d, %s ago", snap.TickNumber, age.Round(time.Millisecond)),
+	}, true
+}
+
+// handleHealthz is the liveness probe - fails only if the engine tick loop is hung
+func (h *routerHandlers) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	engine, started := h.engineLiveness()
+
+	resp := healthResponse{
+		Status: "ok",
+		Checks: map[string]healthCheckResult{"engine": engine},
+	}
+	code := http.StatusOK
+
+	if !started {
+		// Not started yet is not dead - don't get the pod restarted during boot
+		resp.Status = "starting"
+	} else if !engine.OK {
+		resp.Status = "fail"
+		code = http.StatusServiceUnavailable
+	}
+
+	writeHealth(w, resp, code)
+}
+
+// handleReadyz is the readiness probe - engine liveness plus all registered checks
+func (h *routerHandlers) handleReadyz(w http.ResponseWriter, r *http.Request) {
+	engine, _ := h.engineLiveness()
+
+	resp := healthResponse{
+		Status: "ok",
+		Checks: map[string]healthCheckResult{"engine": engine},
+	}
+	failed, degraded := !engine.OK, false
+
+	h.health.mu.RLock()
+	checks := h.health.checks
+	h.health.mu.RUnlock()
+
+	for _, c := range checks {
+		res := healthCheckResult{OK: true, Critical: c.Critical}
+		if err := c.Check(); err != nil {
+			res.OK = false
+			res.Detail = err.Error()
+			if c.Critical {
+				failed = true
+			} else {
+				degraded = true
+			}
+		}
+		resp.Checks[c.Name] = res
+	}
+
+	code := http.StatusOK
+	if failed {
+		resp.Status = "fail"
+		code = http.StatusServiceUnavailable
+	} else if degraded {
+		resp.Status = "degraded"
+	}
+
+	writeHealth(w, resp, code)
+}
+
+// writeHealth writes a health response with the given status code
+func writeHealth(w http.ResponseWriter, resp healthResponse, code int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,103 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"fight-club/internal/chat"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// moderationHandlers exposes the chat anti-abuse policy to the admin API
+type moderationHandlers struct {
+	guard *chat.AbuseGuard
+}
+
+// moderationPolicyJSON is the API shape of config.ModerationConfig
+type moderationPolicyJSON struct {
+	Enabled        bool     `json:"enabled"`
+	DryRun         bool     `json:"dryRun"`
+	Threshold      int      `json:"threshold"`
+	WindowSeconds  float64  `json:"windowSeconds"`
+	TimeoutMinutes int      `json:"timeoutMinutes"`
+	Reason         string   `json:"reason"`
+	Exempt         []string `json:"exempt"`
+}
+
+// handleGet returns the moderation policy and recent actions
+func (h *moderationHandlers) handleGet(w http.ResponseWriter, r *http.Request) {
+	p := h.guard.Policy()
+	exempt := p.Exempt
+	if exempt == nil {
+		exempt = []string{}
+	}
+	writeJSON(w, map[string]interface{}{
+		"policy": moderationPolicyJSON{
+			Enabled:        p.Enabled,
+			DryRun:         p.DryRun,
+			Threshold:      p.Threshold,
+			WindowSeconds:  p.Window,
+			TimeoutMinutes: p.TimeoutMinutes,
+			Reason:         p.Reason,
+			Exempt:         exempt,
+		},
+		"actions": h.guard.Actions(),
+	})
+}
+
+// handleUpdate changes the moderation policy; omitted fields are kept
+func (h *moderationHandlers) handleUpdate(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Enabled        *bool     `json:"enabled"`
+		DryRun         *bool     `json:"dryRun"`
+		Threshold      *int      `json:"threshold"`
+		WindowSeconds  *float64  `json:"windowSeconds"`
+		TimeoutMinutes *int      `json:"timeoutMinutes"`
+		Reason         *string   `json:"reason"`
+		Exempt         *[]string `json:"exempt"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, "Invalid request", http.StatusBadRequest)
+		return
+	}
+	if (req.Threshold != nil && *req.Threshold < 1) ||
+		(req.WindowSeconds != nil && *req.WindowSeconds <= 0) ||
+		(req.TimeoutMinutes != nil && (*req.TimeoutMinutes < 1 || *req.TimeoutMinutes > 10080)) {
+		writeError(w, "threshold and windowSeconds must be positive, timeoutMinutes 1-10080", http.StatusBadRequest)
+		return
+	}
+
+	p := h.guard.Policy()
+	if req.Enabled != nil {
+		p.Enabled = *req.Enabled
+	}
+	if req.DryRun != nil {
+		p.DryRun = *req.DryRun
+	}
+	if req.Threshold != nil {
+		p.Threshold = *req.Threshold
+	}
+	if req.WindowSeconds != nil {
+		p.Window = *req.WindowSeconds
+	}
+	if req.TimeoutMinutes != nil {
+		p.TimeoutMinutes = *req.TimeoutMinutes
+	}
+	if req.Reason != nil {
+		p.Reason = *req.Reason
+	}
+	if req.Exempt != nil {
+		p.Exempt = *req.Exempt
+	}
+	h.guard.SetPolicy(p)
+
+	h.handleGet(w, r)
+}
+
+// mountModerationRoutes registers moderation policy management under the given router
+func mountModerationRoutes(r chi.Router, guard *chat.AbuseGuard) {
+	h := &moderationHandlers{guard: guard}
+	r.Get("/moderation", h.handleGet)
+	r.Put("/moderation", h.handleUpdate)
+}
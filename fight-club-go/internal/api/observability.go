@@ -1,12 +1,17 @@
 package api
 
 import (
+	"encoding/json"
+	"errors"
 	"log"
+	"net"
 	"net/http"
 	"net/http/pprof"
 	"os"
 	"time"
 
+	"fight-club/internal/chat"
+
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -81,9 +86,25 @@ var (
 // ObservabilityConfig configures the debug server
 type ObservabilityConfig struct {
 	Enabled       bool
-	ListenAddr    string // MUST be "127.0.0.1:6060" in production
+	ListenAddr    string // MUST be a loopback address in production (game server 6060, streamer 6061)
 	BasicAuthUser string // Optional basic auth
 	BasicAuthPass string
+
+	// CommandQueue is optional - if set, its depth and drops are exported
+	// as metrics and as JSON on /debug/queue
+	CommandQueue *chat.CommandQueue
+
+	// RateLimiter is optional - if set, its limit hits (per command and
+	// viewer tier) are served as JSON on /debug/ratelimit
+	RateLimiter *chat.RateLimiter
+
+	// Preview is optional - if set, the latest rendered frame is served on
+	// /api/preview.jpg and /api/preview.mjpeg (admin panel thumbnail)
+	Preview PreviewSource
+
+	// Listen is optional - if set, it opens the listener (e.g. one inherited
+	// from the previous process in a graceful restart)
+	Listen func(addr string) (net.Listener, error)
 }
 
 // DefaultObservabilityConfig returns safe defaults
@@ -103,11 +124,15 @@ func StartDebugServer(cfg ObservabilityConfig) error {
 	}
 
 	// SECURITY: Validate address is localhost
-	if cfg.ListenAddr != "127.0.0.1:6060" && cfg.ListenAddr != "localhost:6060" {
+	if !isLoopbackAddr(cfg.ListenAddr) {
 		// Only allow external binding if explicitly enabled via env
 		if os.Getenv("ALLOW_DEBUG_EXTERNAL") != "true" {
 			log.Println("⚠️ Debug server forced to localhost for security")
-			cfg.ListenAddr = "127.0.0.1:6060"
+			_, port, err := net.SplitHostPort(cfg.ListenAddr)
+			if err != nil {
+				port = "6060"
+			}
+			cfg.ListenAddr = net.JoinHostPort("127.0.0.1", port)
 		}
 	}
 
@@ -123,6 +148,33 @@ func StartDebugServer(cfg ObservabilityConfig) error {
 	// Prometheus metrics endpoint
 	mux.Handle("/metrics", promhttp.Handler())
 
+	// Chat command queue (per-priority depth, drops by command)
+	if cfg.CommandQueue != nil {
+		registerCommandQueueMetrics(cfg.CommandQueue)
+		mux.HandleFunc("/debug/queue", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(cfg.CommandQueue.Stats())
+		})
+	}
+
+	// Chat command rate limit hits
+	if cfg.RateLimiter != nil {
+		mux.HandleFunc("/debug/ratelimit", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(cfg.RateLimiter.Stats())
+		})
+	}
+
+	// Per-subsystem timing budget (tick phases, render stages) and allocation rates
+	mux.HandleFunc("/debug/budget", handleBudget)
+	mux.HandleFunc("/debug/budget.html", handleBudgetHTML)
+
+	// Live thumbnail of what is being sent to Kick
+	if cfg.Preview != nil {
+		mux.HandleFunc("/api/preview.jpg", handlePreviewJPEG(cfg.Preview))
+		mux.HandleFunc("/api/preview.mjpeg", handlePreviewMJPEG(cfg.Preview))
+	}
+
 	// Health check
 	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -135,12 +187,31 @@ func StartDebugServer(cfg ObservabilityConfig) error {
 		handler = basicAuthMiddleware(cfg.BasicAuthUser, cfg.BasicAuthPass, mux)
 	}
 
+	listen := cfg.Listen
+	if listen == nil {
+		listen = func(addr string) (net.Listener, error) { return net.Listen("tcp", addr) }
+	}
+	listener, err := listen(cfg.ListenAddr)
+	if err != nil {
+		return err
+	}
+
 	go func() {
 		log.Printf("📊 Debug server starting on %s", cfg.ListenAddr)
 		log.Printf("   - pprof:   http://%s/debug/pprof/", cfg.ListenAddr)
 		log.Printf("   - metrics: http://%s/metrics", cfg.ListenAddr)
+		log.Printf("   - budget:  http://%s/debug/budget.html", cfg.ListenAddr)
+		if cfg.CommandQueue != nil {
+			log.Printf("   - queue:   http://%s/debug/queue", cfg.ListenAddr)
+		}
+		if cfg.RateLimiter != nil {
+			log.Printf("   - limits:  http://%s/debug/ratelimit", cfg.ListenAddr)
+		}
+		if cfg.Preview != nil {
+			log.Printf("   - preview: http://%s/api/preview.mjpeg", cfg.ListenAddr)
+		}
 
-		if err := http.ListenAndServe(cfg.ListenAddr, handler); err != nil {
+		if err := http.Serve(listener, handler); err != nil {
 			log.Printf("⚠️ Debug server error: %v", err)
 		}
 	}()
@@ -148,6 +219,71 @@ func StartDebugServer(cfg ObservabilityConfig) error {
 	return nil
 }
 
+// isLoopbackAddr reports whether a listen address binds to localhost only
+func isLoopbackAddr(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// commandQueueCollector exports chat command queue stats at scrape time.
+// Labels are bounded: priorities and canonical command names.
+type commandQueueCollector struct {
+	queue     *chat.CommandQueue
+	depth     *prometheus.Desc
+	dropped   *prometheus.Desc
+	evicted   *prometheus.Desc
+	users     *prometheus.Desc
+	processed *prometheus.Desc
+}
+
+// registerCommandQueueMetrics registers the queue collector (once per process)
+func registerCommandQueueMetrics(queue *chat.CommandQueue) {
+	c := &commandQueueCollector{
+		queue:     queue,
+		depth:     prometheus.NewDesc("chat_queue_depth", "Pending chat commands", []string{"priority"}, nil),
+		dropped:   prometheus.NewDesc("chat_queue_dropped_total", "Chat commands dropped (user limit, full queue or evicted)", []string{"command"}, nil),
+		evicted:   prometheus.NewDesc("chat_queue_evicted_total", "Queued chat commands evicted for higher priority ones", nil, nil),
+		users:     prometheus.NewDesc("chat_queue_users", "Viewers with pending chat commands", nil, nil),
+		processed: prometheus.NewDesc("chat_queue_processed_total", "Chat commands processed", nil, nil),
+	}
+	if err := prometheus.Register(c); err != nil {
+		var already prometheus.AlreadyRegisteredError
+		if !errors.As(err, &already) {
+			log.Printf("⚠️ Command queue metrics disabled: %v", err)
+		}
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *commandQueueCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.depth
+	ch <- c.dropped
+	ch <- c.evicted
+	ch <- c.users
+	ch <- c.processed
+}
+
+// Collect implements prometheus.Collector
+func (c *commandQueueCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.queue.Stats()
+	for priority, n := range stats.PendingByPriority {
+		ch <- prometheus.MustNewConstMetric(c.depth, prometheus.GaugeValue, float64(n), priority)
+	}
+	for command, n := range stats.DroppedByCommand {
+		ch <- prometheus.MustNewConstMetric(c.dropped, prometheus.CounterValue, float64(n), command)
+	}
+	ch <- prometheus.MustNewConstMetric(c.evicted, prometheus.CounterValue, float64(stats.Evicted))
+	ch <- prometheus.MustNewConstMetric(c.users, prometheus.GaugeValue, float64(stats.Users))
+	ch <- prometheus.MustNewConstMetric(c.processed, prometheus.CounterValue, float64(stats.Processed))
+}
+
 // basicAuthMiddleware adds basic authentication to the handler
 func basicAuthMiddleware(user, pass string, next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,107 @@
+package api
+
+import (
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// Preview limits
+const (
+	previewFrameInterval = 250 * time.Millisecond // MJPEG poll rate (the streamer captures at most 4 FPS)
+	previewFirstWait     = 2 * time.Second        // Wait for the first capture after the preview wakes up
+	maxPreviewStreams    = 4                      // Concurrent /api/preview.mjpeg clients
+)
+
+// PreviewSource provides JPEG thumbnails of the rendered stream
+// (implemented by streaming.StreamManager)
+type PreviewSource interface {
+	// PreviewJPEG returns the latest frame and its number (0 = none yet)
+	PreviewJPEG() ([]byte, uint64)
+}
+
+// waitPreview returns the latest frame, waiting for the first capture
+// (the streamer only captures while previews are being requested)
+func waitPreview(r *http.Request, src PreviewSource) ([]byte, uint64) {
+	deadline := time.Now().Add(previewFirstWait)
+	for {
+		frame, seq := src.PreviewJPEG()
+		if seq != 0 || time.Now().After(deadline) {
+			return frame, seq
+		}
+		select {
+		case <-r.Context().Done():
+			return nil, 0
+		case <-time.After(previewFrameInterval / 5):
+		}
+	}
+}
+
+// handlePreviewJPEG serves the most recently rendered frame as a JPEG thumbnail
+func handlePreviewJPEG(src PreviewSource) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		frame, seq := waitPreview(r, src)
+		if seq == 0 {
+			http.Error(w, "no frame rendered yet", http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "image/jpeg")
+		w.Header().Set("Content-Length", strconv.Itoa(len(frame)))
+		w.Header().Set("Cache-Control", "no-store")
+		w.Write(frame)
+	}
+}
+
+// handlePreviewMJPEG streams rendered frames as MJPEG (multipart/x-mixed-replace),
+// which browsers play directly in an <img> tag
+func handlePreviewMJPEG(src PreviewSource) http.HandlerFunc {
+	var streams atomic.Int32
+	return func(w http.ResponseWriter, r *http.Request) {
+		if streams.Add(1) > maxPreviewStreams {
+			streams.Add(-1)
+			http.Error(w, "too many preview streams", http.StatusServiceUnavailable)
+			return
+		}
+		defer streams.Add(-1)
+
+		rc := http.NewResponseController(w)
+		_ = rc.SetWriteDeadline(time.Time{}) // Long-lived response
+
+		mw := multipart.NewWriter(w)
+		w.Header().Set("Content-Type", "multipart/x-mixed-replace; boundary="+mw.Boundary())
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(http.StatusOK)
+
+		ticker := time.NewTicker(previewFrameInterval)
+		defer ticker.Stop()
+		var last uint64
+		for {
+			frame, seq := src.PreviewJPEG()
+			if seq != last && seq != 0 {
+				part, err := mw.CreatePart(textproto.MIMEHeader{
+					"Content-Type":   {"image/jpeg"},
+					"Content-Length": {strconv.Itoa(len(frame))},
+				})
+				if err != nil {
+					return
+				}
+				if _, err := part.Write(frame); err != nil {
+					return
+				}
+				if err := rc.Flush(); err != nil {
+					return
+				}
+				last = seq
+			}
+
+			select {
+			case <-r.Context().Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package api
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"fight-club/internal/game"
+)
+
+// maxPageLimit caps ?limit= on player lists
+const maxPageLimit = 500
+
+// playerSortKeys are the ?sort= keys for player lists ("-" prefix = descending).
+// Ties are broken by name so pages are stable between polls.
+var playerSortKeys = map[string]func(a, b *game.Player) int{
+	"kills":  func(a, b *game.Player) int { return a.Kills - b.Kills },
+	"deaths": func(a, b *game.Player) int { return a.Deaths - b.Deaths },
+	"money":  func(a, b *game.Player) int { return a.Money - b.Money },
+	"hp":     func(a, b *game.Player) int { return a.HP - b.HP },
+	"name":   func(a, b *game.Player) int { return strings.Compare(a.Name, b.Name) },
+}
+
+// playerFields are the keys of Player.ToJSON, valid in ?fields=
+var playerFields = func() map[string]bool {
+	fields := make(map[string]bool)
+	for k := range (&game.Player{}).ToJSON() {
+		fields[k] = true
+	}
+	return fields
+}()
+
+// playerQuery is a parsed player list query:
+// ?limit=&offset=&sort=[-]key&fields=a,b&team=id
+type playerQuery struct {
+	limit  int // 0 = all
+	offset int
+	sort   string
+	desc   bool
+	fields []string // nil = all
+	team   string   // "" = any team
+}
+
+// parsePlayerQuery parses list parameters, using defaultSort ("-kills") and
+// defaultLimit when they are not given
+func parsePlayerQuery(q url.Values, defaultSort string, defaultLimit int) (playerQuery, error) {
+	pq := playerQuery{limit: defaultLimit, team: q.Get("team")}
+
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxPageLimit {
+			return pq, fmt.Errorf("limit must be between 1 and %d", maxPageLimit)
+		}
+		pq.limit = n
+	}
+	if v := q.Get("offset"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return pq, fmt.Errorf("offset must be a non-negative integer")
+		}
+		pq.offset = n
+	}
+
+	sortKey := defaultSort
+	if v := q.Get("sort"); v != "" {
+		sortKey = v
+	}
+	pq.desc = strings.HasPrefix(sortKey, "-")
+	pq.sort = strings.TrimPrefix(sortKey, "-")
+	if _, ok := playerSortKeys[pq.sort]; !ok {
+		return pq, fmt.Errorf("unknown sort key %q (use kills, deaths, money, hp or name)", pq.sort)
+	}
+
+	if v := q.Get("fields"); v != "" {
+		for _, f := range strings.Split(v, ",") {
+			f = strings.TrimSpace(f)
+			if !playerFields[f] {
+				return pq, fmt.Errorf("unknown field %q", f)
+			}
+			pq.fields = append(pq.fields, f)
+		}
+	}
+
+	return pq, nil
+}
+
+// apply filters, sorts and pages players. Returns the page as JSON objects
+// and the number of players matching the filter.
+func (pq playerQuery) apply(players []*game.Player) ([]map[string]interface{}, int) {
+	filtered := make([]*game.Player, 0, len(players))
+	for _, p := range players {
+		if pq.team == "" || p.TeamID == pq.team {
+			filtered = append(filtered, p)
+		}
+	}
+
+	cmp := playerSortKeys[pq.sort]
+	sort.SliceStable(filtered, func(i, j int) bool {
+		c := cmp(filtered[i], filtered[j])
+		if c == 0 {
+			return filtered[i].Name < filtered[j].Name
+		}
+		if pq.desc {
+			return c > 0
+		}
+		return c < 0
+	})
+
+	total := len(filtered)
+	start := min(pq.offset, total)
+	end := total
+	if pq.limit > 0 {
+		end = min(start+pq.limit, total)
+	}
+
+	page := make([]map[string]interface{}, 0, end-start)
+	for _, p := range filtered[start:end] {
+		obj := p.ToJSON()
+		if pq.fields != nil {
+			selected := make(map[string]interface{}, len(pq.fields))
+			for _, f := range pq.fields {
+				selected[f] = obj[f]
+			}
+			obj = selected
+		}
+		page = append(page, obj)
+	}
+	return page, total
+}
+
+// writeJSONWithETag writes data with a content ETag and answers 304 Not Modified
+// when the client already has it (If-None-Match), so pollers skip unchanged bodies
+func writeJSONWithETag(w http.ResponseWriter, r *http.Request, data interface{}) {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(data); err != nil {
+		writeError(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+	sum := sha1.Sum(buf.Bytes())
+	etag := `"` + hex.EncodeToString(sum[:10]) + `"`
+
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "no-cache") // Revalidate every poll
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(buf.Bytes())
+}
+
+// etagMatches reports whether an If-None-Match header lists etag (weak comparison)
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == etag || candidate == "*" {
+			return true
+		}
+	}
+	return false
+}
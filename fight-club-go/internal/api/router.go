@@ -2,8 +2,11 @@ package api
 
 import (
 	"net/http"
+	"sync/atomic"
 
+	"fight-club/internal/chat"
 	"fight-club/internal/game"
+	"fight-club/internal/highlights"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
@@ -24,6 +27,10 @@ type EngineInterface interface {
 	HealPlayer(name string, amount int) bool
 	// GetPlayer returns a player by name (may be nil)
 	GetPlayer(name string) *game.Player
+	// Pause freezes the simulation (returns false if already paused)
+	Pause() bool
+	// Resume continues a paused simulation (returns false if not paused)
+	Resume() bool
 }
 
 // StreamerInterface defines the streamer methods used by the API.
@@ -39,6 +46,20 @@ type StreamerInterface interface {
 	GetStats() map[string]interface{}
 }
 
+// KeyframeInterface is implemented by streamers that can send a keyframe on
+// demand (StreamManager, RemoteStreamer), served at /api/admin/stream/keyframe
+type KeyframeInterface interface {
+	// ForceKeyframe makes the encoder send a keyframe now
+	ForceKeyframe() error
+}
+
+// ReplayInterface is implemented by engines that can cue a slow-motion replay
+// of the last seconds on stream (game.Engine), served at /api/admin/replay
+type ReplayInterface interface {
+	// RequestReplay starts a replay (fails while one is playing or replays are off)
+	RequestReplay() error
+}
+
 // RouterConfig contains all dependencies needed to construct the HTTP router.
 // This struct is designed for dependency injection and testability.
 //
@@ -92,13 +113,91 @@ type RouterConfig struct {
 	// LoginPagePath is the path to the login HTML file
 	// If empty, a default embedded login page will be used
 	LoginPagePath string
+
+	// Health is an optional registry of readiness checks for /readyz.
+	// If nil, only engine liveness is checked.
+	Health *HealthRegistry
+
+	// APIKeys is optional - if provided, /api/player/join and /api/player/heal
+	// accept API keys with per-key rate limits, and admins can manage keys
+	// at /api/admin/keys (requires EnableAdminAuth)
+	APIKeys *APIKeyStore
+
+	// RequireAPIKey rejects join/heal requests without an API key or admin session
+	RequireAPIKey bool
+
+	// Aliases is optional - if provided, admins can manage custom chat command
+	// triggers at /api/admin/aliases
+	Aliases *chat.AliasStore
+
+	// Moderation is optional - if provided, admins can view and change the
+	// chat anti-abuse policy and recent timeouts at /api/admin/moderation
+	Moderation *chat.AbuseGuard
+
+	// Bans is optional - if provided, admins can list, ban and unban chat
+	// users at /api/admin/bans and /api/admin/ban
+	Bans *chat.BanList
+
+	// SoundPacks is optional - if provided, admins can switch the stream's
+	// sound effects pack at /api/admin/soundpack
+	SoundPacks *game.SoundPackSelector
+
+	// Arena is optional - if provided, admins can switch the arena preset
+	// (size and tick rate) at /api/admin/arena
+	Arena ArenaInterface
+
+	// Scene is optional - if provided, admins can switch the arena's time of
+	// day and weather at /api/admin/scene
+	Scene SceneInterface
+
+	// Weapons is optional - if provided, admins can inspect the weapons file
+	// at /api/admin/weapons and hot reload it at /api/admin/weapons/reload
+	Weapons *game.WeaponRegistry
+
+	// Leaderboards is optional - if provided, persistent rankings are served
+	// at /api/leaderboard/{today|week|alltime}
+	Leaderboards *game.LeaderboardStore
+
+	// History is optional - if provided, per-round summaries are served at
+	// /api/history
+	History *game.MatchHistory
+
+	// Highlights is optional - if provided, the Kick clips of big moments are
+	// served at /api/highlights
+	Highlights *highlights.Recorder
+
+	// Analytics is optional - if provided, combat stats and the damage heatmap
+	// are served at /api/analytics and /api/analytics/heatmap.png
+	Analytics *game.CombatAnalytics
+
+	// Balance is optional - if provided, the weapon balance report is served
+	// at /api/balance (?format=md for a markdown table)
+	Balance *game.BalanceTelemetry
+
+	// CommandQueue is optional - if provided, its depth is included in the
+	// live stats streamed at /api/admin/events
+	CommandQueue *chat.CommandQueue
+
+	// Errors is optional - if provided, recent warning/error log lines are
+	// streamed at /api/admin/events
+	Errors *ErrorLog
 }
 
 // routerHandlers holds the handler functions for the router.
 // This is used internally to pass handlers to route setup.
 type routerHandlers struct {
-	engine   EngineInterface
-	streamer StreamerInterface
+	engine       EngineInterface
+	streamer     StreamerInterface
+	health       *HealthRegistry
+	leaderboards *game.LeaderboardStore
+	history      *game.MatchHistory
+	highlights   *highlights.Recorder
+	analytics    *game.CombatAnalytics
+	balance      *game.BalanceTelemetry
+	queue        *chat.CommandQueue
+	errors       *ErrorLog
+	eventStreams atomic.Int32 // Open /api/admin/events connections
+	snapStreams  atomic.Int32 // Open /api/snapshots/stream connections
 }
 
 // NewRouter constructs the HTTP router with all middleware and routes.
@@ -150,26 +249,66 @@ func NewRouter(cfg RouterConfig) *chi.Mux {
 		AllowedOrigins:   corsOrigins,
 		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
 		AllowedHeaders:   []string{"*"},
+		ExposedHeaders:   []string{"ETag", "X-Total-Count"}, // Overlay pollers (see query.go)
 		AllowCredentials: true,
 	}))
 
 	// Create handlers struct
+	health := cfg.Health
+	if health == nil {
+		health = NewHealthRegistry()
+	}
 	h := &routerHandlers{
-		engine:   cfg.Engine,
-		streamer: cfg.Streamer,
+		engine:       cfg.Engine,
+		streamer:     cfg.Streamer,
+		health:       health,
+		leaderboards: cfg.Leaderboards,
+		history:      cfg.History,
+		highlights:   cfg.Highlights,
+		analytics:    cfg.Analytics,
+		balance:      cfg.Balance,
+		queue:        cfg.CommandQueue,
+		errors:       cfg.Errors,
+	}
+
+	// API key auth for third-party integrations (no-op without a key store)
+	apiKeyAuth := func(next http.Handler) http.Handler { return next }
+	if cfg.APIKeys != nil {
+		apiKeyAuth = cfg.APIKeys.Middleware(cfg.RequireAPIKey, cfg.SessionManager)
 	}
 
+	// Container probes (Kubernetes liveness/readiness)
+	r.Get("/healthz", h.handleHealthz)
+	r.Get("/readyz", h.handleReadyz)
+
 	// API routes
 	r.Route("/api", func(r chi.Router) {
 		// Game state
 		r.Get("/state", h.handleGetState)
 		r.Get("/stats", h.handleGetStats)
+		r.Get("/snapshots/stream", h.handleSnapshotStream)
 		r.Get("/leaderboard", h.handleGetLeaderboard)
+		if cfg.Leaderboards != nil {
+			r.Get("/leaderboard/{period}", h.handleGetPeriodLeaderboard)
+		}
+		if cfg.History != nil {
+			r.Get("/history", h.handleGetHistory)
+		}
+		if cfg.Highlights != nil {
+			r.Get("/highlights", h.handleGetHighlights)
+		}
+		if cfg.Analytics != nil {
+			r.Get("/analytics", h.handleGetAnalytics)
+			r.Get("/analytics/heatmap.png", h.handleGetHeatmap)
+		}
+		if cfg.Balance != nil {
+			r.Get("/balance", h.handleGetBalance)
+		}
 
 		// Player management
-		r.Post("/player/join", h.handlePlayerJoin)
+		r.With(apiKeyAuth).Post("/player/join", h.handlePlayerJoin)
 		r.Post("/player/batch", h.handlePlayerBatchJoin)
-		r.Post("/player/heal", h.handlePlayerHeal)
+		r.With(apiKeyAuth).Post("/player/heal", h.handlePlayerHeal)
 		r.Post("/player/weapon", h.handlePlayerWeapon)
 
 		// Stream control
@@ -179,6 +318,7 @@ func NewRouter(cfg RouterConfig) *chi.Mux {
 
 		// Admin
 		r.Get("/weapons", h.handleGetWeapons)
+		r.Get("/skins", h.handleGetSkins)
 
 		// Kick routes (OAuth callback, webhook) - if handler provided
 		// Use chi Route group with catch-all that modifies path for http.ServeMux
@@ -248,7 +388,54 @@ func NewRouter(cfg RouterConfig) *chi.Mux {
 			r.Use(cfg.SessionManager.AdminAuthMiddleware)
 			r.Post("/stream/start", h.handleStreamStart)
 			r.Post("/stream/stop", h.handleStreamStop)
+			r.Post("/stream/keyframe", h.handleStreamKeyframe)
+			r.Post("/replay", h.handleReplay)
 			r.Post("/player/batch", h.handlePlayerBatchJoin)
+			r.Post("/pause", h.handlePause)
+			r.Post("/resume", h.handleResume)
+			r.Get("/events", h.handleAdminEvents)
+
+			// API key management
+			if cfg.APIKeys != nil {
+				r.Get("/keys", cfg.APIKeys.HandleList)
+				r.Post("/keys", cfg.APIKeys.HandleCreate)
+				r.Delete("/keys/{id}", cfg.APIKeys.HandleRevoke)
+			}
+
+			// Chat command aliases
+			if cfg.Aliases != nil {
+				mountAliasRoutes(r, cfg.Aliases)
+			}
+
+			// Chat anti-abuse policy
+			if cfg.Moderation != nil {
+				mountModerationRoutes(r, cfg.Moderation)
+			}
+
+			// Chat ban list
+			if cfg.Bans != nil {
+				mountBanRoutes(r, cfg.Bans)
+			}
+
+			// Stream sound effects pack
+			if cfg.SoundPacks != nil {
+				mountSoundPackRoutes(r, cfg.SoundPacks)
+			}
+
+			// Arena size and tick rate presets
+			if cfg.Arena != nil {
+				mountArenaRoutes(r, cfg.Arena)
+			}
+
+			// Background time of day and weather
+			if cfg.Scene != nil {
+				mountSceneRoutes(r, cfg.Scene)
+			}
+
+			// Data-driven weapons
+			if cfg.Weapons != nil {
+				mountWeaponRoutes(r, cfg.Weapons)
+			}
 		})
 	} else {
 		// Unprotected admin routes (default behavior)
@@ -256,6 +443,34 @@ func NewRouter(cfg RouterConfig) *chi.Mux {
 		r.Get("/admin", func(w http.ResponseWriter, req *http.Request) {
 			http.Redirect(w, req, "/admin/", http.StatusMovedPermanently)
 		})
+		r.Route("/api/admin", func(r chi.Router) {
+			r.Post("/stream/keyframe", h.handleStreamKeyframe)
+			r.Post("/replay", h.handleReplay)
+			r.Post("/pause", h.handlePause)
+			r.Post("/resume", h.handleResume)
+			r.Get("/events", h.handleAdminEvents)
+			if cfg.Aliases != nil {
+				mountAliasRoutes(r, cfg.Aliases)
+			}
+			if cfg.Moderation != nil {
+				mountModerationRoutes(r, cfg.Moderation)
+			}
+			if cfg.Bans != nil {
+				mountBanRoutes(r, cfg.Bans)
+			}
+			if cfg.SoundPacks != nil {
+				mountSoundPackRoutes(r, cfg.SoundPacks)
+			}
+			if cfg.Arena != nil {
+				mountArenaRoutes(r, cfg.Arena)
+			}
+			if cfg.Scene != nil {
+				mountSceneRoutes(r, cfg.Scene)
+			}
+			if cfg.Weapons != nil {
+				mountWeaponRoutes(r, cfg.Weapons)
+			}
+		})
 	}
 
 	// Default route
@@ -0,0 +1,57 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"fight-club/internal/game"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// SceneInterface switches the arena's time of day and weather
+type SceneInterface interface {
+	// Scene returns the current time of day and weather
+	Scene() game.SceneSnapshot
+	// SetScene switches the time of day and/or weather ("" keeps the current one)
+	SetScene(timeOfDay, weather string) error
+}
+
+// sceneHandlers lets admins change the arena background theme
+type sceneHandlers struct {
+	scene SceneInterface
+}
+
+// handleGet returns the current scene and the available times of day and weathers
+func (h *sceneHandlers) handleGet(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, map[string]interface{}{
+		"scene":    h.scene.Scene(),
+		"times":    game.SceneTimes,
+		"weathers": game.SceneWeathers,
+	})
+}
+
+// handleSet switches the time of day and/or weather; the cycle continues from there
+func (h *sceneHandlers) handleSet(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Time    string `json:"time"`
+		Weather string `json:"weather"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || (req.Time == "" && req.Weather == "") {
+		writeError(w, "Invalid request", http.StatusBadRequest)
+		return
+	}
+	if err := h.scene.SetScene(req.Time, req.Weather); err != nil {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	h.handleGet(w, r)
+}
+
+// mountSceneRoutes registers time of day and weather switching under the given router
+func mountSceneRoutes(r chi.Router, scene SceneInterface) {
+	h := &sceneHandlers{scene: scene}
+	r.Get("/scene", h.handleGet)
+	r.Post("/scene", h.handleSet)
+}
@@ -1,7 +1,9 @@
 package api
 
 import (
+	"context"
 	"log"
+	"net"
 	"net/http"
 
 	"fight-club/internal/game"
@@ -18,6 +20,8 @@ type Server struct {
 	wsHub       *WebSocketHub
 	rateLimiter *IPRateLimiter
 	kickHandler http.Handler
+	health      *HealthRegistry
+	httpServer  *http.Server
 }
 
 // NewServer creates a new API server with default production configuration.
@@ -38,25 +42,49 @@ func NewServerWithKick(engine *game.Engine, streamer StreamerInterface, kickHand
 
 // NewServerWithKickAndAuth creates a new API server with Kick OAuth and admin authentication support.
 func NewServerWithKickAndAuth(engine *game.Engine, streamer StreamerInterface, kickHandler http.Handler, sessionMgr *SessionManager, enableAuth bool) *Server {
+	return NewServerWithAPIKeys(engine, streamer, kickHandler, sessionMgr, enableAuth, nil, false)
+}
+
+// NewServerWithAPIKeys creates a new API server that also accepts third-party API keys
+// on join/heal (see RouterConfig.APIKeys).
+func NewServerWithAPIKeys(engine *game.Engine, streamer StreamerInterface, kickHandler http.Handler, sessionMgr *SessionManager, enableAuth bool, apiKeys *APIKeyStore, requireAPIKey bool) *Server {
+	return NewServerWithConfig(engine, RouterConfig{
+		Streamer:           streamer,
+		KickWebhookHandler: kickHandler,
+		SessionManager:     sessionMgr,
+		EnableAdminAuth:    enableAuth,
+		APIKeys:            apiKeys,
+		RequireAPIKey:      requireAPIKey,
+	})
+}
+
+// NewServerWithConfig creates a new API server from a router configuration.
+// Engine, RateLimiter, Health, Leaderboards, History, Analytics, Balance, SoundPacks, Arena and Scene are filled in by the server.
+func NewServerWithConfig(engine *game.Engine, cfg RouterConfig) *Server {
 	s := &Server{
 		engine:      engine,
-		streamer:    streamer,
+		streamer:    cfg.Streamer,
 		wsHub:       NewWebSocketHub(),
-		kickHandler: kickHandler,
+		kickHandler: cfg.KickWebhookHandler,
+		health:      NewHealthRegistry(),
 	}
 
 	// Create rate limiter (we track it for potential cleanup)
 	s.rateLimiter = NewIPRateLimiter(DefaultRateLimitConfig)
 
 	// Build router using the factory
-	s.router = NewRouter(RouterConfig{
-		Engine:             engine,
-		Streamer:           streamer,
-		RateLimiter:        s.rateLimiter,
-		KickWebhookHandler: kickHandler,
-		SessionManager:     sessionMgr,
-		EnableAdminAuth:    enableAuth,
-	})
+	cfg.Engine = engine
+	cfg.RateLimiter = s.rateLimiter
+	cfg.Health = s.health
+	cfg.Leaderboards = engine.GetLeaderboardStore()
+	cfg.History = engine.GetHistory()
+	cfg.Analytics = engine.GetAnalytics()
+	cfg.Balance = engine.GetBalance()
+	cfg.SoundPacks = engine.GetSoundPacks()
+	cfg.Arena = engine
+	cfg.Scene = engine
+	s.router = NewRouter(cfg)
+	s.httpServer = &http.Server{Handler: s.router}
 
 	// Add WebSocket routes (these need the wsHub instance)
 	s.setupWebSocketRoutes()
@@ -78,16 +106,35 @@ func (s *Server) setupWebSocketRoutes() {
 //
 // Call this method only once. To stop the server, signal the process.
 func (s *Server) Start(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	return s.Serve(listener)
+}
+
+// Serve is Start on an existing listener (e.g. one inherited from the
+// previous process in a graceful restart). Returns http.ErrServerClosed
+// after Shutdown.
+func (s *Server) Serve(listener net.Listener) error {
 	// Start background workers NOW, not in constructor
 	// This is critical for testability - tests can construct the server
 	// and use Router() without these workers running.
 	go s.wsHub.Run()
 	s.wsHub.StartBroadcastLoop(s.engine, s.streamer)
 
-	log.Printf("🌐 API server starting on %s", addr)
-	log.Printf("🎮 Admin Panel: http://localhost%s/admin", addr)
+	log.Printf("🌐 API server starting on %s", listener.Addr())
+	if _, port, err := net.SplitHostPort(listener.Addr().String()); err == nil {
+		log.Printf("🎮 Admin Panel: http://localhost:%s/admin", port)
+	}
+
+	return s.httpServer.Serve(listener)
+}
 
-	return http.ListenAndServe(addr, s.router)
+// Shutdown stops accepting connections and waits for in-flight requests
+// (long-lived streams are cut when ctx expires)
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.httpServer.Shutdown(ctx)
 }
 
 // Router returns the HTTP handler for use with httptest.
@@ -103,6 +150,12 @@ func (s *Server) Router() http.Handler {
 	return s.router
 }
 
+// Health returns the readiness check registry served at /readyz.
+// Register process-level checks (IPC, Kick auth) after construction.
+func (s *Server) Health() *HealthRegistry {
+	return s.health
+}
+
 // Stop performs graceful shutdown of background workers.
 // Call this before process exit to ensure clean cleanup.
 func (s *Server) Stop() {
@@ -0,0 +1,259 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"fight-club/internal/game"
+)
+
+// Snapshot stream limits
+const (
+	defaultSnapshotRate = 10 // Snapshots per second
+	maxSnapshotRate     = 30
+	maxSnapshotStreams  = 16               // Concurrent /api/snapshots/stream clients
+	snapshotHeartbeat   = 10 * time.Second // Keep-alive while no new tick is produced (paused)
+)
+
+// StreamFrame is one NDJSON line of /api/snapshots/stream.
+// Type is "snapshot", "heartbeat" (no new tick, connection alive) or
+// "reset" (the requested ?since tick is ahead of the engine: it restarted).
+type StreamFrame struct {
+	Type        string                    `json:"type"`
+	Tick        uint64                    `json:"tick"`
+	Seq         uint64                    `json:"seq,omitempty"`
+	Time        int64                     `json:"time,omitempty"` // Unix ms the snapshot was produced
+	TickRate    int                       `json:"tickRate,omitempty"`
+	Predict     float64                   `json:"predictionWindow,omitempty"` // Seconds positions may be extrapolated (game.PredictionWindow)
+	Paused      bool                      `json:"paused,omitempty"`
+	PlayerCount int                       `json:"playerCount,omitempty"`
+	AliveCount  int                       `json:"aliveCount,omitempty"`
+	TotalKills  int                       `json:"totalKills,omitempty"`
+	Players     []StreamPlayer            `json:"players,omitempty"`
+	Projectiles []StreamProjectile        `json:"projectiles,omitempty"`
+	Loot        []game.LootSnapshot       `json:"loot,omitempty"`
+	WeaponDrops []game.WeaponDropSnapshot `json:"weaponDrops,omitempty"`
+	Vote        *game.VoteSnapshot        `json:"vote,omitempty"`
+	Duel        *game.DuelSnapshot        `json:"duel,omitempty"`
+	Chaos       *StreamChaos              `json:"chaos,omitempty"`
+}
+
+// StreamPlayer is a fighter in a snapshot frame
+type StreamPlayer struct {
+	ID          string           `json:"id"`
+	Name        string           `json:"name"`
+	X           float64          `json:"x"`
+	Y           float64          `json:"y"`
+	VX          float64          `json:"vx"`
+	VY          float64          `json:"vy"`
+	HP          int              `json:"hp"`
+	MaxHP       int              `json:"maxHp"`
+	Money       int              `json:"money"`
+	Kills       int              `json:"kills"`
+	Deaths      int              `json:"deaths"`
+	Weapon      string           `json:"weapon"`
+	Color       string           `json:"color"`
+	AttackAngle float64          `json:"attackAngle"`
+	Attacking   bool             `json:"attacking,omitempty"`
+	AttackPhase string           `json:"attackPhase"`     // idle, windup, active or recovery
+	PhaseLeft   float64          `json:"attackPhaseLeft"` // Seconds left in the phase
+	Cooldown    float64          `json:"attackCooldown"`  // Seconds until the next attack
+	Dodging     bool             `json:"dodging,omitempty"`
+	Dead        bool             `json:"dead,omitempty"`
+	Protected   bool             `json:"protected,omitempty"`
+	Stamina     float64          `json:"stamina"`
+	Sprinting   bool             `json:"sprinting,omitempty"`
+	Exhausted   bool             `json:"exhausted,omitempty"`
+	Combo       int              `json:"combo,omitempty"`
+	Personality string           `json:"personality,omitempty"`
+	Rank        string           `json:"rank,omitempty"`
+	TeamColor   string           `json:"teamColor,omitempty"`
+	Skins       game.PlayerSkins `json:"skins"`
+}
+
+// StreamProjectile is an arrow or thrown weapon in a snapshot frame
+type StreamProjectile struct {
+	ID       string  `json:"id"`
+	X        float64 `json:"x"`
+	Y        float64 `json:"y"`
+	Rotation float64 `json:"rotation"`
+}
+
+// StreamChaos is the active chaos event in a snapshot frame
+type StreamChaos struct {
+	Event      string  `json:"event"`
+	Warning    bool    `json:"warning,omitempty"`
+	Remaining  float64 `json:"remaining"`
+	ZoneX      float64 `json:"zoneX,omitempty"`
+	ZoneY      float64 `json:"zoneY,omitempty"`
+	ZoneRadius float64 `json:"zoneRadius,omitempty"`
+}
+
+// newStreamFrame converts a snapshot to a frame. Called right after
+// GetSnapshot: the snapshot is a pooled buffer the engine will reuse.
+func newStreamFrame(s *game.GameSnapshot) StreamFrame {
+	f := StreamFrame{
+		Type:        "snapshot",
+		Tick:        s.TickNumber,
+		Seq:         s.Sequence,
+		Time:        s.Timestamp.UnixMilli(),
+		TickRate:    s.TickRate,
+		Predict:     game.PredictionWindow,
+		Paused:      s.Paused,
+		PlayerCount: s.PlayerCount,
+		AliveCount:  s.AliveCount,
+		TotalKills:  s.TotalKills,
+		Players:     make([]StreamPlayer, len(s.Players)),
+		Loot:        append([]game.LootSnapshot(nil), s.Loot...),
+		WeaponDrops: append([]game.WeaponDropSnapshot(nil), s.WeaponDrops...),
+	}
+	for i, p := range s.Players {
+		f.Players[i] = StreamPlayer{
+			ID:          p.ID,
+			Name:        p.Name,
+			X:           p.X,
+			Y:           p.Y,
+			VX:          p.VX,
+			VY:          p.VY,
+			HP:          p.HP,
+			MaxHP:       p.MaxHP,
+			Money:       p.Money,
+			Kills:       p.Kills,
+			Deaths:      p.Deaths,
+			Weapon:      p.Weapon,
+			Color:       p.Color,
+			AttackAngle: p.AttackAngle,
+			Attacking:   p.IsAttacking,
+			AttackPhase: p.AttackPhase.String(),
+			PhaseLeft:   p.AttackPhaseLeft,
+			Cooldown:    p.AttackCooldown,
+			Dodging:     p.IsDodging,
+			Dead:        p.IsDead,
+			Protected:   p.SpawnProtection,
+			Stamina:     p.Stamina,
+			Sprinting:   p.IsSprinting,
+			Exhausted:   p.IsExhausted,
+			Combo:       p.ComboCount,
+			Personality: p.Personality,
+			Rank:        p.Rank,
+			TeamColor:   p.TeamColor,
+			Skins:       p.Skins,
+		}
+	}
+	if len(s.Projectiles) > 0 {
+		f.Projectiles = make([]StreamProjectile, len(s.Projectiles))
+		for i, p := range s.Projectiles {
+			f.Projectiles[i] = StreamProjectile{ID: p.ID, X: p.X, Y: p.Y, Rotation: p.Rotation}
+		}
+	}
+	if s.Vote.Voting || s.Vote.Modifier != "" {
+		vote := s.Vote
+		f.Vote = &vote
+	}
+	if s.Duel.Active {
+		duel := s.Duel
+		f.Duel = &duel
+	}
+	if s.Chaos.Event != "" {
+		f.Chaos = &StreamChaos{
+			Event:      s.Chaos.Event,
+			Warning:    s.Chaos.Warning,
+			Remaining:  s.Chaos.Remaining,
+			ZoneX:      s.Chaos.ZoneX,
+			ZoneY:      s.Chaos.ZoneY,
+			ZoneRadius: s.Chaos.ZoneRadius,
+		}
+	}
+	return f
+}
+
+// handleSnapshotStream streams game snapshots as NDJSON (one JSON object per
+// line) for headless clients: external renderers, bots and ML agents.
+// ?rate=N caps snapshots per second (default 10, max 30 or the tick rate);
+// ?since=T resumes after tick T, so a reconnecting client skips what it saw.
+func (h *routerHandlers) handleSnapshotStream(w http.ResponseWriter, r *http.Request) {
+	rate := defaultSnapshotRate
+	if v := r.URL.Query().Get("rate"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			writeError(w, "rate must be a positive number of snapshots per second", http.StatusBadRequest)
+			return
+		}
+		rate = min(n, maxSnapshotRate)
+	}
+	// last is the newest tick the client has; resume=false sends the current one
+	var last uint64
+	resume := false
+	if v := r.URL.Query().Get("since"); v != "" {
+		n, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			writeError(w, "since must be a tick number", http.StatusBadRequest)
+			return
+		}
+		last, resume = n, true
+	}
+
+	if h.snapStreams.Add(1) > maxSnapshotStreams {
+		h.snapStreams.Add(-1)
+		writeError(w, "too many snapshot streams", http.StatusServiceUnavailable)
+		return
+	}
+	defer h.snapStreams.Add(-1)
+
+	rc := http.NewResponseController(w)
+	_ = rc.SetWriteDeadline(time.Time{}) // Long-lived response
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no") // Disable proxy buffering (nginx)
+	w.WriteHeader(http.StatusOK)
+
+	enc := json.NewEncoder(w)
+	lastWrite := time.Now()
+	write := func(frame StreamFrame) error {
+		lastWrite = time.Now()
+		return enc.Encode(frame)
+	}
+
+	ticker := time.NewTicker(time.Second / time.Duration(rate))
+	defer ticker.Stop()
+	for first := true; ; first = false {
+		wrote := false
+		if snapshot := h.engine.GetSnapshot(); snapshot != nil {
+			if first && snapshot.TickRate > 0 && rate > snapshot.TickRate {
+				// No point polling faster than the engine produces ticks
+				ticker.Reset(time.Second / time.Duration(snapshot.TickRate))
+			}
+			if first && resume && last > snapshot.TickNumber {
+				// Resuming past the engine's tick: it restarted, start over
+				if err := write(StreamFrame{Type: "reset", Tick: snapshot.TickNumber}); err != nil {
+					return
+				}
+				resume = false
+			}
+			if snapshot.TickNumber > last || (first && !resume) {
+				frame := newStreamFrame(snapshot)
+				if err := write(frame); err != nil {
+					return
+				}
+				last, wrote = frame.Tick, true
+			}
+		}
+		if !wrote && time.Since(lastWrite) >= snapshotHeartbeat {
+			if err := write(StreamFrame{Type: "heartbeat", Tick: last}); err != nil {
+				return
+			}
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package api
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"fight-club/internal/game"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// soundPackHandlers lets admins switch the stream's sound effects pack
+type soundPackHandlers struct {
+	packs *game.SoundPackSelector
+}
+
+// handleGet returns the active sound pack and the available ones
+func (h *soundPackHandlers) handleGet(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, map[string]interface{}{
+		"pack":  h.packs.Current(),
+		"packs": h.packs.Available(),
+	})
+}
+
+// handleSet switches the sound pack; the streamer follows on the next snapshot
+func (h *soundPackHandlers) handleSet(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Pack string `json:"pack"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Pack == "" {
+		writeError(w, "Invalid request", http.StatusBadRequest)
+		return
+	}
+	if err := h.packs.Set(req.Pack); err != nil {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	log.Printf("🔊 Sound pack switched to %s", req.Pack)
+
+	h.handleGet(w, r)
+}
+
+// mountSoundPackRoutes registers sound pack switching under the given router
+func mountSoundPackRoutes(r chi.Router, packs *game.SoundPackSelector) {
+	h := &soundPackHandlers{packs: packs}
+	r.Get("/soundpack", h.handleGet)
+	r.Post("/soundpack", h.handleSet)
+}
@@ -0,0 +1,36 @@
+package api
+
+import (
+	"net/http"
+
+	"fight-club/internal/game"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// weaponHandlers lets admins inspect and hot reload the weapons file
+type weaponHandlers struct {
+	registry *game.WeaponRegistry
+}
+
+// handleGet returns the weapons file status and the weapons in play
+func (h *weaponHandlers) handleGet(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, h.registry.Status())
+}
+
+// handleReload re-reads the weapons file. An invalid file keeps the current
+// weapons and returns the validation error.
+func (h *weaponHandlers) handleReload(w http.ResponseWriter, r *http.Request) {
+	if _, err := h.registry.Reload(); err != nil {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	h.handleGet(w, r)
+}
+
+// mountWeaponRoutes registers the weapon registry routes under the given router
+func mountWeaponRoutes(r chi.Router, registry *game.WeaponRegistry) {
+	h := &weaponHandlers{registry: registry}
+	r.Get("/weapons", h.handleGet)
+	r.Post("/weapons/reload", h.handleReload)
+}
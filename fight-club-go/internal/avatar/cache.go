@@ -1,26 +1,41 @@
 package avatar
 
 import (
+	"bytes"
 	"image"
 	"image/draw"
 	_ "image/gif"  // Support GIF format
 	_ "image/jpeg" // Support JPEG format
 	_ "image/png"  // Support PNG format
+	"io"
 	"log"
 	"net/http"
+	"path/filepath"
 	"sync"
 	"time"
 
+	"fight-club/internal/config"
+
 	_ "golang.org/x/image/webp" // Support WebP format (Kick profile pictures)
 )
 
-// Cache stores decoded avatar images with LRU eviction
+// Config is an alias for config.AvatarCacheConfig (SSOT)
+type Config = config.AvatarCacheConfig
+
+// Cache stores decoded avatar images in memory (LRU eviction) backed by an
+// optional disk tier, so restarts reuse avatars instead of refetching them.
+// Stale avatars keep being served while they are revalidated with the CDN
+// (ETag / Last-Modified), and URLs without a usable image are negative-cached.
 type Cache struct {
 	mu      sync.RWMutex
 	images  map[string]*CachedAvatar
 	order   []string // LRU order (oldest first)
 	maxSize int
 
+	ttl         time.Duration // Fresh period of a fetched avatar
+	negativeTTL time.Duration // Fresh period of a negative entry
+	disk        *diskStore    // nil = memory only
+
 	// Pending fetches
 	pending map[string]bool
 	client  *http.Client
@@ -29,84 +44,115 @@ type Cache struct {
 
 // CachedAvatar holds a decoded image and metadata
 type CachedAvatar struct {
-	Image     image.Image
-	FetchedAt time.Time
+	Image        image.Image // nil: no usable avatar at this URL (negative entry)
+	FetchedAt    time.Time   // Last download or successful revalidation
+	ETag         string
+	LastModified string
+
+	expires time.Time // Revalidate after this
 }
 
 const (
 	DefaultMaxAvatars    = 200
-	AvatarTTL            = 30 * time.Minute
+	AvatarTTL            = 30 * time.Minute // Memory-only caches (no config)
+	NegativeTTL          = 10 * time.Minute // Memory-only caches (no config)
+	RetryBackoff         = time.Minute      // Wait after a failed fetch (CDN down, rate limited)
 	MaxConcurrentFetches = 3
 	FetchTimeout         = 5 * time.Second
+	MaxAvatarBytes       = 4 << 20
 )
 
-// NewCache creates a new avatar cache
+// NewCache creates a memory-only avatar cache
 func NewCache(maxSize int) *Cache {
+	return NewCacheWithConfig(maxSize, Config{})
+}
+
+// NewCacheWithConfig creates an avatar cache with TTLs and an optional disk tier
+func NewCacheWithConfig(maxSize int, cfg Config) *Cache {
 	if maxSize <= 0 {
 		maxSize = DefaultMaxAvatars
 	}
-	return &Cache{
-		images:  make(map[string]*CachedAvatar),
-		order:   make([]string, 0, maxSize),
-		maxSize: maxSize,
-		pending: make(map[string]bool),
+	c := &Cache{
+		images:      make(map[string]*CachedAvatar),
+		order:       make([]string, 0, maxSize),
+		maxSize:     maxSize,
+		ttl:         AvatarTTL,
+		negativeTTL: NegativeTTL,
+		pending:     make(map[string]bool),
 		client: &http.Client{
 			Timeout: FetchTimeout,
 		},
 		sem: make(chan struct{}, MaxConcurrentFetches),
 	}
+	if cfg.TTL > 0 {
+		c.ttl = time.Duration(cfg.TTL * float64(time.Second))
+	}
+	if cfg.NegativeTTL > 0 {
+		c.negativeTTL = time.Duration(cfg.NegativeTTL * float64(time.Second))
+	}
+	if cfg.Dir != "" {
+		disk, err := newDiskStore(filepath.Join(cfg.Dir, "avatars"))
+		if err != nil {
+			log.Printf("⚠️ Avatar disk cache disabled: %v", err)
+		} else {
+			c.disk = disk
+		}
+	}
+	return c
 }
 
-// Get returns a cached avatar or nil
+// Get returns a cached avatar or nil. Stale avatars are returned until replaced.
 func (c *Cache) Get(url string) image.Image {
 	if url == "" {
 		return nil
 	}
 
 	c.mu.RLock()
-	cached, exists := c.images[url]
-	c.mu.RUnlock()
-
-	if !exists {
-		return nil
-	}
-
-	// Check TTL
-	if time.Since(cached.FetchedAt) > AvatarTTL {
-		c.mu.Lock()
-		delete(c.images, url)
-		c.mu.Unlock()
-		return nil
+	defer c.mu.RUnlock()
+	if cached, exists := c.images[url]; exists {
+		return cached.Image
 	}
-
-	return cached.Image
+	return nil
 }
 
-// GetOrFetch returns cached avatar or starts async fetch
+// GetOrFetch returns the cached avatar and starts an async fetch (or
+// revalidation) when it is missing or stale.
 // Never blocks - returns nil immediately if not cached
 func (c *Cache) GetOrFetch(url string) image.Image {
 	if url == "" {
 		return nil
 	}
 
-	// Try cache first
-	if img := c.Get(url); img != nil {
-		return img
+	now := time.Now()
+	c.mu.RLock()
+	cached, exists := c.images[url]
+	c.mu.RUnlock()
+	if exists && now.Before(cached.expires) {
+		return cached.Image
 	}
 
 	// Start async fetch if not pending
 	c.mu.Lock()
 	if !c.pending[url] {
 		c.pending[url] = true
-		go c.fetchAsync(url)
+		var prev *CachedAvatar
+		if exists {
+			copied := *cached
+			prev = &copied
+		}
+		go c.fetchAsync(url, prev)
 	}
 	c.mu.Unlock()
 
+	if exists {
+		return cached.Image
+	}
 	return nil
 }
 
-// fetchAsync downloads and caches an avatar
-func (c *Cache) fetchAsync(url string) {
+// fetchAsync loads an avatar from disk or downloads it. With a cached copy
+// (memory or disk) the request is conditional, so unchanged avatars cost a 304.
+func (c *Cache) fetchAsync(url string, cached *CachedAvatar) {
 	// Acquire semaphore
 	c.sem <- struct{}{}
 	defer func() { <-c.sem }()
@@ -117,44 +163,178 @@ func (c *Cache) fetchAsync(url string) {
 		c.mu.Unlock()
 	}()
 
-	resp, err := c.client.Get(url)
+	entry := diskEntry{URL: url}
+	var stored []byte // Image bytes from disk (conditional request without a memory copy)
+	if cached != nil {
+		entry.ETag, entry.LastModified, entry.FetchedAt = cached.ETag, cached.LastModified, cached.FetchedAt
+	} else if c.disk != nil {
+		if saved, data, ok := c.disk.load(url); ok {
+			entry, stored = saved, data
+			if c.restore(entry, data) {
+				return // Fresh on disk: no network
+			}
+		}
+	}
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		c.storeNegative(url, time.Now())
+		return
+	}
+	revalidate := (cached != nil && cached.Image != nil) || stored != nil
+	if revalidate {
+		if entry.ETag != "" {
+			req.Header.Set("If-None-Match", entry.ETag)
+		}
+		if entry.LastModified != "" {
+			req.Header.Set("If-Modified-Since", entry.LastModified)
+		}
+	}
+
+	resp, err := c.client.Do(req)
 	if err != nil {
 		log.Printf("⚠️ Avatar fetch failed for %s: %v", url[:min(50, len(url))], err)
+		c.backoff(url, cached)
 		return
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
+	now := time.Now()
+	switch {
+	case resp.StatusCode == http.StatusNotModified && revalidate:
+		entry.FetchedAt = now
+		img := cachedImage(cached)
+		if img == nil {
+			img = c.decode(url, bytes.NewReader(stored), "")
+		}
+		if img == nil {
+			c.storeNegative(url, now)
+			return
+		}
+		c.store(entry, img)
+		if c.disk != nil {
+			c.disk.save(entry, nil)
+		}
+
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusGone:
+		log.Printf("⚠️ Avatar fetch returned %d for %s (cached as missing)", resp.StatusCode, url[:min(50, len(url))])
+		c.storeNegative(url, now)
+
+	case resp.StatusCode != http.StatusOK:
 		log.Printf("⚠️ Avatar fetch returned %d for %s", resp.StatusCode, url[:min(50, len(url))])
-		return
+		c.backoff(url, cached)
+
+	default:
+		data, err := io.ReadAll(io.LimitReader(resp.Body, MaxAvatarBytes))
+		if err != nil {
+			log.Printf("⚠️ Avatar download failed for %s: %v", url[:min(50, len(url))], err)
+			c.backoff(url, cached)
+			return
+		}
+		entry = diskEntry{
+			URL:          url,
+			ETag:         resp.Header.Get("ETag"),
+			LastModified: resp.Header.Get("Last-Modified"),
+			FetchedAt:    now,
+		}
+		img := c.decode(url, bytes.NewReader(data), resp.Header.Get("Content-Type"))
+		if img == nil {
+			c.storeNegative(url, now) // Unsupported or corrupt image: don't retry every frame
+			return
+		}
+		c.store(entry, img)
+		if c.disk != nil {
+			c.disk.save(entry, data)
+		}
+		log.Printf("✅ Avatar cached for %s", url[:min(40, len(url))])
+	}
+}
+
+// restore loads a disk entry into memory if it is still fresh
+func (c *Cache) restore(entry diskEntry, data []byte) bool {
+	age := time.Since(entry.FetchedAt)
+	if entry.Missing {
+		if age >= c.negativeTTL {
+			return false
+		}
+		c.put(entry.URL, &CachedAvatar{FetchedAt: entry.FetchedAt, expires: entry.FetchedAt.Add(c.negativeTTL)})
+		return true
+	}
+	if age >= c.ttl {
+		return false
 	}
+	img := c.decode(entry.URL, bytes.NewReader(data), "")
+	if img == nil {
+		return false
+	}
+	c.store(entry, img)
+	return true
+}
 
-	img, format, err := image.Decode(resp.Body)
+// decode decodes and crops an avatar (nil if the image can't be decoded)
+func (c *Cache) decode(url string, r io.Reader, contentType string) image.Image {
+	img, format, err := image.Decode(r)
 	if err != nil {
 		log.Printf("⚠️ Avatar decode failed for %s: %v (Content-Type: %s)",
-			url[:min(60, len(url))], err, resp.Header.Get("Content-Type"))
-		return
+			url[:min(60, len(url))], err, contentType)
+		return nil
 	}
 	log.Printf("🖼️ Avatar decoded (format: %s) for %s", format, url[:min(40, len(url))])
 
 	// Make circular
-	circleImg := c.makeCircular(img)
+	return c.makeCircular(img)
+}
+
+// store caches a decoded avatar in memory
+func (c *Cache) store(entry diskEntry, img image.Image) {
+	c.put(entry.URL, &CachedAvatar{
+		Image:        img,
+		FetchedAt:    entry.FetchedAt,
+		ETag:         entry.ETag,
+		LastModified: entry.LastModified,
+		expires:      entry.FetchedAt.Add(c.ttl),
+	})
+}
+
+// storeNegative remembers that a URL has no usable avatar (memory and disk)
+func (c *Cache) storeNegative(url string, now time.Time) {
+	c.put(url, &CachedAvatar{FetchedAt: now, expires: now.Add(c.negativeTTL)})
+	if c.disk != nil {
+		c.disk.save(diskEntry{URL: url, FetchedAt: now, Missing: true}, nil)
+	}
+}
 
+// backoff delays the next attempt after a transient failure, keeping any stale image
+func (c *Cache) backoff(url string, cached *CachedAvatar) {
+	retry := &CachedAvatar{}
+	if cached != nil {
+		*retry = *cached
+	}
+	retry.expires = time.Now().Add(RetryBackoff)
+	c.put(url, retry)
+}
+
+// put inserts or replaces a memory entry, evicting the oldest at capacity
+func (c *Cache) put(url string, avatar *CachedAvatar) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	// Evict if at capacity
-	if len(c.images) >= c.maxSize {
-		c.evict()
+	if _, exists := c.images[url]; !exists {
+		// Evict if at capacity
+		if len(c.images) >= c.maxSize {
+			c.evict()
+		}
+		c.order = append(c.order, url)
 	}
+	c.images[url] = avatar
+}
 
-	c.images[url] = &CachedAvatar{
-		Image:     circleImg,
-		FetchedAt: time.Now(),
+// cachedImage returns the image of a memory entry (nil-safe)
+func cachedImage(cached *CachedAvatar) image.Image {
+	if cached == nil {
+		return nil
 	}
-	c.order = append(c.order, url)
-
-	log.Printf("✅ Avatar cached for %s", url[:min(40, len(url))])
+	return cached.Image
 }
 
 // makeCircular creates a circular crop of the image
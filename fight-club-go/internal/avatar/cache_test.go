@@ -0,0 +1,128 @@
+package avatar
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// avatarServer serves a PNG with an ETag, answering 304 to If-None-Match
+type avatarServer struct {
+	*httptest.Server
+	requests    atomic.Int32
+	notModified atomic.Int32
+}
+
+func newAvatarServer(t *testing.T, status int) *avatarServer {
+	img := image.NewRGBA(image.Rect(0, 0, 8, 8))
+	img.Set(4, 4, color.RGBA{255, 0, 0, 255})
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+
+	s := &avatarServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.requests.Add(1)
+		if status != http.StatusOK {
+			w.WriteHeader(status)
+			return
+		}
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			s.notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Content-Type", "image/png")
+		w.Write(buf.Bytes())
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// waitFor polls cond until it holds or the test times out
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// idle reports whether no fetch is in flight
+func (c *Cache) idle() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.pending) == 0
+}
+
+// TestCacheRevalidatesWithETag verifies stale avatars stay visible and are
+// revalidated with a conditional request instead of downloaded again
+func TestCacheRevalidatesWithETag(t *testing.T) {
+	srv := newAvatarServer(t, http.StatusOK)
+	c := NewCacheWithConfig(10, Config{Dir: t.TempDir(), TTL: 0.05})
+	url := srv.URL + "/a.png"
+
+	waitFor(t, "download", func() bool { return c.GetOrFetch(url) != nil && c.idle() })
+
+	time.Sleep(60 * time.Millisecond) // Let the avatar go stale
+	if c.GetOrFetch(url) == nil {
+		t.Fatal("Expected the stale avatar while revalidating")
+	}
+	waitFor(t, "revalidation", func() bool { return srv.notModified.Load() == 1 && c.idle() })
+
+	if got := srv.requests.Load(); got != 2 {
+		t.Errorf("Expected 1 download + 1 revalidation, got %d requests", got)
+	}
+	if c.Get(url) == nil {
+		t.Error("Expected the avatar to stay cached after a 304")
+	}
+}
+
+// TestCacheNegativeEntry verifies missing avatars are not refetched every frame
+func TestCacheNegativeEntry(t *testing.T) {
+	srv := newAvatarServer(t, http.StatusNotFound)
+	c := NewCache(10)
+	url := srv.URL + "/missing.png"
+
+	c.GetOrFetch(url)
+	waitFor(t, "404", func() bool { return srv.requests.Load() == 1 && c.idle() })
+
+	for i := 0; i < 5; i++ {
+		if img := c.GetOrFetch(url); img != nil {
+			t.Fatal("Expected no avatar for a 404")
+		}
+	}
+	time.Sleep(20 * time.Millisecond)
+	if got := srv.requests.Load(); got != 1 {
+		t.Errorf("Expected the 404 to be cached, got %d requests", got)
+	}
+}
+
+// TestCacheRestoresFromDisk verifies a new cache (restart) reuses avatars
+// from disk without touching the network
+func TestCacheRestoresFromDisk(t *testing.T) {
+	srv := newAvatarServer(t, http.StatusOK)
+	dir := t.TempDir()
+	url := srv.URL + "/a.png"
+
+	first := NewCacheWithConfig(10, Config{Dir: dir})
+	waitFor(t, "download", func() bool { return first.GetOrFetch(url) != nil && first.idle() })
+
+	second := NewCacheWithConfig(10, Config{Dir: dir})
+	waitFor(t, "disk restore", func() bool { return second.GetOrFetch(url) != nil })
+
+	if got := srv.requests.Load(); got != 1 {
+		t.Errorf("Expected the restart to reuse the disk copy, got %d requests", got)
+	}
+}
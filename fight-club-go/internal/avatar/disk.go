@@ -0,0 +1,123 @@
+package avatar
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// diskRetention is how long an avatar nobody has shown stays on disk
+const diskRetention = 30 * 24 * time.Hour
+
+// diskEntry is the metadata stored next to each avatar on disk
+type diskEntry struct {
+	URL          string    `json:"url"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"lastModified,omitempty"`
+	FetchedAt    time.Time `json:"fetchedAt"`         // Last download or successful revalidation
+	Missing      bool      `json:"missing,omitempty"` // No usable avatar at this URL (negative entry)
+}
+
+// diskStore is the persistent tier: <sha1(url)>.json metadata plus
+// <sha1(url)>.img with the original (undecoded) image bytes
+type diskStore struct {
+	dir string
+}
+
+// newDiskStore creates the cache directory and prunes stale entries
+func newDiskStore(dir string) (*diskStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	d := &diskStore{dir: dir}
+	go d.prune(diskRetention)
+	return d, nil
+}
+
+// paths returns the metadata and image files of a URL
+func (d *diskStore) paths(url string) (meta, img string) {
+	sum := sha1.Sum([]byte(url))
+	base := filepath.Join(d.dir, hex.EncodeToString(sum[:]))
+	return base + ".json", base + ".img"
+}
+
+// load returns the stored entry and image bytes (nil for negative entries)
+func (d *diskStore) load(url string) (diskEntry, []byte, bool) {
+	metaPath, imgPath := d.paths(url)
+	raw, err := os.ReadFile(metaPath)
+	if err != nil {
+		return diskEntry{}, nil, false
+	}
+	var entry diskEntry
+	if err := json.Unmarshal(raw, &entry); err != nil || entry.URL != url {
+		return diskEntry{}, nil, false
+	}
+	if entry.Missing {
+		return entry, nil, true
+	}
+	data, err := os.ReadFile(imgPath)
+	if err != nil {
+		return diskEntry{}, nil, false
+	}
+	return entry, data, true
+}
+
+// save writes an entry; data nil keeps the stored image (revalidation) or,
+// for negative entries, removes it
+func (d *diskStore) save(entry diskEntry, data []byte) {
+	metaPath, imgPath := d.paths(entry.URL)
+	if entry.Missing {
+		os.Remove(imgPath)
+	} else if data != nil {
+		if err := writeFileAtomic(imgPath, data); err != nil {
+			log.Printf("⚠️ Failed to save avatar to disk: %v", err)
+			return
+		}
+	}
+	raw, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	if err := writeFileAtomic(metaPath, raw); err != nil {
+		log.Printf("⚠️ Failed to save avatar metadata: %v", err)
+	}
+}
+
+// prune removes entries not written for longer than maxAge
+func (d *diskStore) prune(maxAge time.Duration) {
+	files, err := os.ReadDir(d.dir)
+	if err != nil {
+		return
+	}
+	removed := 0
+	for _, f := range files {
+		if !strings.HasSuffix(f.Name(), ".json") {
+			continue
+		}
+		info, err := f.Info()
+		if err != nil || time.Since(info.ModTime()) < maxAge {
+			continue
+		}
+		base := filepath.Join(d.dir, strings.TrimSuffix(f.Name(), ".json"))
+		os.Remove(base + ".img")
+		os.Remove(base + ".json")
+		removed++
+	}
+	if removed > 0 {
+		log.Printf("🧹 Pruned %d stale avatars from %s", removed, d.dir)
+	}
+}
+
+// writeFileAtomic writes via a temp file so readers never see a partial file
+func writeFileAtomic(path string, data []byte) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
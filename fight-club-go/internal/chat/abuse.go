@@ -0,0 +1,195 @@
+package chat
+
+import (
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"fight-club/internal/config"
+)
+
+// maxModerationActions caps the recent actions kept for the admin API
+const maxModerationActions = 50
+
+// maxTimeoutMinutes is Kick's longest timeout (7 days)
+const maxTimeoutMinutes = 10080
+
+// Moderator performs chat moderation on the streaming platform (kick.Service)
+type Moderator interface {
+	TimeoutUser(userID int64, minutes int, reason string) error
+}
+
+// ModerationAction is a timeout issued (or, in dry-run, simulated) by the AbuseGuard
+type ModerationAction struct {
+	Username string    `json:"username"`
+	UserID   int64     `json:"userId"`
+	Minutes  int       `json:"minutes"`
+	Strikes  int       `json:"strikes"` // Timeouts this user has received this session, including this one
+	DryRun   bool      `json:"dryRun"`
+	Error    string    `json:"error,omitempty"`
+	At       time.Time `json:"at"`
+}
+
+// abuseRecord tracks rate-limit violations for one user
+type abuseRecord struct {
+	violations []time.Time
+	strikes    int
+}
+
+// AbuseGuard times out viewers who keep spamming commands past the rate
+// limit. Each rate-limited command is a violation; reaching the policy
+// threshold within the window issues a timeout via the Moderator, doubling
+// for repeat offenders. In dry-run mode actions are only logged.
+type AbuseGuard struct {
+	mu        sync.Mutex
+	policy    config.ModerationConfig
+	moderator Moderator
+	records   map[string]*abuseRecord // lowercase username -> record
+	actions   []ModerationAction
+}
+
+// NewAbuseGuard creates an abuse guard. moderator may be nil (then every action is a dry run).
+func NewAbuseGuard(policy config.ModerationConfig, moderator Moderator) *AbuseGuard {
+	return &AbuseGuard{
+		policy:    policy,
+		moderator: moderator,
+		records:   make(map[string]*abuseRecord),
+	}
+}
+
+// SetModerator sets the platform moderator (once Kick is connected)
+func (g *AbuseGuard) SetModerator(moderator Moderator) {
+	g.mu.Lock()
+	g.moderator = moderator
+	g.mu.Unlock()
+}
+
+// Policy returns the current moderation policy
+func (g *AbuseGuard) Policy() config.ModerationConfig {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	policy := g.policy
+	policy.Exempt = append([]string(nil), g.policy.Exempt...)
+	return policy
+}
+
+// SetPolicy replaces the moderation policy (from the admin API)
+func (g *AbuseGuard) SetPolicy(policy config.ModerationConfig) {
+	g.mu.Lock()
+	g.policy = policy
+	g.mu.Unlock()
+	log.Printf("🛡️ Moderation policy updated: enabled=%v dryRun=%v threshold=%d/%.0fs timeout=%dm",
+		policy.Enabled, policy.DryRun, policy.Threshold, policy.Window, policy.TimeoutMinutes)
+}
+
+// Actions returns recent moderation actions, newest first
+func (g *AbuseGuard) Actions() []ModerationAction {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	out := make([]ModerationAction, len(g.actions))
+	for i, a := range g.actions {
+		out[len(g.actions)-1-i] = a
+	}
+	return out
+}
+
+// RecordViolation counts a rate-limited command and times the user out when
+// the policy threshold is reached. Returns the action taken, if any.
+func (g *AbuseGuard) RecordViolation(cmd ChatCommand) *ModerationAction {
+	g.mu.Lock()
+	if !g.policy.Enabled || cmd.IsBroadcaster || cmd.UserID == 0 || g.isExempt(cmd.Username) {
+		g.mu.Unlock()
+		return nil
+	}
+
+	now := time.Now()
+	key := strings.ToLower(cmd.Username)
+	rec, ok := g.records[key]
+	if !ok {
+		rec = &abuseRecord{}
+		g.records[key] = rec
+	}
+
+	// Drop violations outside the window
+	cutoff := now.Add(-time.Duration(g.policy.Window * float64(time.Second)))
+	kept := rec.violations[:0]
+	for _, t := range rec.violations {
+		if t.After(cutoff) {
+			kept = append(kept, t)
+		}
+	}
+	rec.violations = append(kept, now)
+
+	threshold := max(g.policy.Threshold, 1)
+	if len(rec.violations) < threshold {
+		g.mu.Unlock()
+		return nil
+	}
+
+	// Threshold reached: escalate and start counting again
+	rec.violations = rec.violations[:0]
+	rec.strikes++
+	minutes := max(g.policy.TimeoutMinutes, 1)
+	for i := 1; i < rec.strikes && minutes < maxTimeoutMinutes; i++ {
+		minutes *= 2
+	}
+	minutes = min(minutes, maxTimeoutMinutes)
+
+	moderator := g.moderator
+	action := ModerationAction{
+		Username: cmd.Username,
+		UserID:   cmd.UserID,
+		Minutes:  minutes,
+		Strikes:  rec.strikes,
+		DryRun:   g.policy.DryRun || moderator == nil,
+		At:       now,
+	}
+	reason := g.policy.Reason
+	g.appendAction(action)
+	g.mu.Unlock()
+
+	if action.DryRun {
+		log.Printf("🛡️ [dry-run] Would time out %s for %d min (strike %d)", cmd.Username, minutes, action.Strikes)
+		return &action
+	}
+
+	log.Printf("🛡️ Timing out %s for %d min (strike %d)", cmd.Username, minutes, action.Strikes)
+	go func() {
+		if err := moderator.TimeoutUser(cmd.UserID, minutes, reason); err != nil {
+			log.Printf("⚠️ Timeout for %s failed: %v", cmd.Username, err)
+			g.markFailed(action, err)
+		}
+	}()
+	return &action
+}
+
+// appendAction stores an action, keeping the most recent ones. Caller holds g.mu.
+func (g *AbuseGuard) appendAction(action ModerationAction) {
+	g.actions = append(g.actions, action)
+	if len(g.actions) > maxModerationActions {
+		g.actions = g.actions[len(g.actions)-maxModerationActions:]
+	}
+}
+
+// markFailed records a Kick API error on a stored action
+func (g *AbuseGuard) markFailed(action ModerationAction, err error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for i := len(g.actions) - 1; i >= 0; i-- {
+		if g.actions[i].UserID == action.UserID && g.actions[i].At.Equal(action.At) {
+			g.actions[i].Error = err.Error()
+			return
+		}
+	}
+}
+
+// isExempt reports whether a user is on the exempt list. Caller holds g.mu.
+func (g *AbuseGuard) isExempt(username string) bool {
+	for _, name := range g.policy.Exempt {
+		if strings.EqualFold(name, username) {
+			return true
+		}
+	}
+	return false
+}
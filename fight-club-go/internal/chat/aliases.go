@@ -0,0 +1,284 @@
+package chat
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// aliasReloadInterval is how often the alias file is checked for edits
+const aliasReloadInterval = 2 * time.Second
+
+// maxAliasLength caps custom trigger length
+const maxAliasLength = 32
+
+var (
+	// ErrInvalidAlias is returned for malformed triggers or targets
+	ErrInvalidAlias = errors.New("invalid alias")
+
+	// ErrAliasCollision is returned when a trigger shadows a built-in command
+	ErrAliasCollision = errors.New("alias collides with a built-in command")
+)
+
+// CommandAlias is a custom trigger mapped to a built-in command with optional preset args
+type CommandAlias struct {
+	Trigger string `json:"trigger"` // "pelear" (typed as !pelear)
+	Target  string `json:"target"`  // "join", "buy sword"
+}
+
+// AliasStore maps broadcaster-defined triggers to built-in commands
+// (e.g. !pelear → !join, !espada → !buy sword). Aliases are persisted to a
+// JSON file ({"pelear": "join"}) that is hot-reloaded when edited by hand,
+// and can be managed from the admin API.
+type AliasStore struct {
+	mu      sync.RWMutex
+	aliases map[string]string // trigger -> target
+	path    string            // "" = in-memory only
+	modTime time.Time         // Alias file mtime at last load/save
+
+	running bool
+	stopCh  chan struct{}
+	wg      sync.WaitGroup
+}
+
+// NewAliasStore creates an alias store, loading existing aliases from path if it exists
+func NewAliasStore(path string) (*AliasStore, error) {
+	as := &AliasStore{
+		aliases: make(map[string]string),
+		path:    path,
+		stopCh:  make(chan struct{}),
+	}
+	if path == "" {
+		return as, nil
+	}
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		return as, nil
+	}
+	if err := as.reload(); err != nil {
+		return nil, err
+	}
+	return as, nil
+}
+
+// Start begins watching the alias file for edits (hot reload)
+func (as *AliasStore) Start() {
+	as.mu.Lock()
+	if as.running || as.path == "" {
+		as.mu.Unlock()
+		return
+	}
+	as.running = true
+	as.mu.Unlock()
+
+	as.wg.Add(1)
+	go as.watchLoop()
+}
+
+// Stop stops watching the alias file
+func (as *AliasStore) Stop() {
+	as.mu.Lock()
+	if !as.running {
+		as.mu.Unlock()
+		return
+	}
+	as.running = false
+	as.mu.Unlock()
+
+	close(as.stopCh)
+	as.wg.Wait()
+}
+
+// normalizeTrigger lowercases a trigger and strips a leading "!"
+func normalizeTrigger(trigger string) string {
+	return strings.TrimPrefix(strings.ToLower(strings.TrimSpace(trigger)), "!")
+}
+
+// validateAlias normalizes and checks an alias.
+// Triggers must be a single word that doesn't shadow a built-in command (in
+// any enabled locale) or weapon shortcut; targets must start with a built-in command (no alias chains).
+func validateAlias(trigger, target string) (string, string, error) {
+	trigger = normalizeTrigger(trigger)
+	if trigger == "" || len(trigger) > maxAliasLength || strings.ContainsAny(trigger, " \t!") {
+		return "", "", fmt.Errorf("%w: trigger must be a single word up to %d characters", ErrInvalidAlias, maxAliasLength)
+	}
+	if GetCommandType(trigger) != CmdUnknown {
+		return "", "", fmt.Errorf("%w: !%s", ErrAliasCollision, trigger)
+	}
+	if _, ok := GetWeaponID(trigger); ok {
+		return "", "", fmt.Errorf("%w: !%s (weapon shortcut)", ErrAliasCollision, trigger)
+	}
+
+	parts := strings.Fields(target)
+	if len(parts) == 0 {
+		return "", "", fmt.Errorf("%w: target is required", ErrInvalidAlias)
+	}
+	parts[0] = normalizeTrigger(parts[0])
+	builtin := GetCommandType(parts[0]) != CmdUnknown
+	_, weapon := GetWeaponID(parts[0])
+	if !builtin && !weapon {
+		return "", "", fmt.Errorf("%w: !%s is not a built-in command", ErrInvalidAlias, parts[0])
+	}
+	return trigger, strings.Join(parts, " "), nil
+}
+
+// Set creates or replaces an alias and persists it
+func (as *AliasStore) Set(trigger, target string) (CommandAlias, error) {
+	trigger, target, err := validateAlias(trigger, target)
+	if err != nil {
+		return CommandAlias{}, err
+	}
+
+	as.mu.Lock()
+	defer as.mu.Unlock()
+
+	as.aliases[trigger] = target
+	if err := as.saveLocked(); err != nil {
+		return CommandAlias{}, err
+	}
+	log.Printf("🔀 Chat alias set: !%s → !%s", trigger, target)
+	return CommandAlias{Trigger: trigger, Target: target}, nil
+}
+
+// Delete removes an alias, returns false if it didn't exist
+func (as *AliasStore) Delete(trigger string) (bool, error) {
+	trigger = normalizeTrigger(trigger)
+
+	as.mu.Lock()
+	defer as.mu.Unlock()
+
+	if _, ok := as.aliases[trigger]; !ok {
+		return false, nil
+	}
+	delete(as.aliases, trigger)
+	log.Printf("🔀 Chat alias removed: !%s", trigger)
+	return true, as.saveLocked()
+}
+
+// List returns all aliases sorted by trigger
+func (as *AliasStore) List() []CommandAlias {
+	as.mu.RLock()
+	defer as.mu.RUnlock()
+
+	result := make([]CommandAlias, 0, len(as.aliases))
+	for trigger, target := range as.aliases {
+		result = append(result, CommandAlias{Trigger: trigger, Target: target})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Trigger < result[j].Trigger
+	})
+	return result
+}
+
+// Resolve rewrites an aliased command into its built-in command.
+// Preset args come first, then whatever the viewer typed (!espada → !buy sword).
+func (as *AliasStore) Resolve(cmd ChatCommand) ChatCommand {
+	as.mu.RLock()
+	target, ok := as.aliases[cmd.Command]
+	as.mu.RUnlock()
+	if !ok {
+		return cmd
+	}
+
+	parts := strings.Fields(target)
+	cmd.Command = parts[0]
+	cmd.Args = append(parts[1:], cmd.Args...)
+	return cmd
+}
+
+// watchLoop reloads the alias file when its modification time changes
+func (as *AliasStore) watchLoop() {
+	defer as.wg.Done()
+
+	ticker := time.NewTicker(aliasReloadInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-as.stopCh:
+			return
+		case <-ticker.C:
+			info, err := os.Stat(as.path)
+			if err != nil {
+				continue // Deleted or not created yet, keep current aliases
+			}
+			as.mu.RLock()
+			changed := !info.ModTime().Equal(as.modTime)
+			as.mu.RUnlock()
+			if !changed {
+				continue
+			}
+			if err := as.reload(); err != nil {
+				log.Printf("⚠️ Failed to reload chat aliases (keeping previous): %v", err)
+			}
+		}
+	}
+}
+
+// reload replaces all aliases with the file contents.
+// Invalid or colliding entries are skipped with a warning.
+func (as *AliasStore) reload() error {
+	info, err := os.Stat(as.path)
+	if err != nil {
+		return fmt.Errorf("failed to read chat aliases: %w", err)
+	}
+	data, err := os.ReadFile(as.path)
+	if err != nil {
+		return fmt.Errorf("failed to read chat aliases: %w", err)
+	}
+
+	var raw map[string]string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("failed to parse chat aliases: %w", err)
+	}
+
+	aliases := make(map[string]string, len(raw))
+	for trigger, target := range raw {
+		t, cmd, err := validateAlias(trigger, target)
+		if err != nil {
+			log.Printf("⚠️ Skipping chat alias !%s: %v", trigger, err)
+			continue
+		}
+		aliases[t] = cmd
+	}
+
+	as.mu.Lock()
+	as.aliases = aliases
+	as.modTime = info.ModTime()
+	as.mu.Unlock()
+
+	log.Printf("🔀 Loaded %d chat alias(es) from %s", len(aliases), as.path)
+	return nil
+}
+
+// saveLocked persists aliases to disk (caller holds lock)
+func (as *AliasStore) saveLocked() error {
+	if as.path == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(as.aliases, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// Write atomically so the watcher never reads a truncated file
+	tmp := as.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("failed to save chat aliases: %w", err)
+	}
+	if err := os.Rename(tmp, as.path); err != nil {
+		return fmt.Errorf("failed to save chat aliases: %w", err)
+	}
+
+	// Our own write must not trigger a reload
+	if info, err := os.Stat(as.path); err == nil {
+		as.modTime = info.ModTime()
+	}
+	return nil
+}
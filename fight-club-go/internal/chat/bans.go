@@ -0,0 +1,449 @@
+package chat
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"fight-club/internal/config"
+
+	_ "modernc.org/sqlite" // Registers the "sqlite" database/sql driver
+)
+
+// BanConfig is an alias for config.BanConfig (SSOT)
+type BanConfig = config.BanConfig
+
+// maxKnownUsers caps the username -> Kick user ID index built from chat senders
+const maxKnownUsers = 10000
+
+// ErrUnknownUser is returned when a ban names a user who hasn't chatted yet
+// (their Kick user ID is unknown)
+var ErrUnknownUser = errors.New("user not seen in chat yet")
+
+// Ban keeps a Kick user out of the game: their commands are ignored
+type Ban struct {
+	UserID    int64     `json:"userId"`
+	Username  string    `json:"username"`
+	Reason    string    `json:"reason,omitempty"`
+	BannedBy  string    `json:"bannedBy"`
+	CreatedAt time.Time `json:"createdAt"`
+	ExpiresAt time.Time `json:"expiresAt,omitempty"` // Zero = permanent
+}
+
+// Permanent reports whether the ban never expires
+func (b Ban) Permanent() bool {
+	return b.ExpiresAt.IsZero()
+}
+
+// expired reports whether a temp-ban is over
+func (b Ban) expired(now time.Time) bool {
+	return !b.ExpiresAt.IsZero() && !now.Before(b.ExpiresAt)
+}
+
+// BanStore persists bans between restarts
+type BanStore interface {
+	Load() ([]Ban, error)
+	Put(ban Ban) error
+	Delete(userID int64) error
+}
+
+// NewBanStore creates the configured ban store
+func NewBanStore(cfg BanConfig) (BanStore, error) {
+	switch cfg.Backend {
+	case "", "file":
+		return NewFileBanStore(cfg.File), nil
+	case "sql", "sqlite":
+		return OpenSQLBanStore(cfg.DBDriver, cfg.DBDSN)
+	default:
+		return nil, fmt.Errorf("unknown ban store %q (use file or sql)", cfg.Backend)
+	}
+}
+
+// =============================================================================
+// BAN LIST
+// =============================================================================
+
+// BanList holds the active bans, keyed by Kick user ID so renamed accounts
+// stay banned. Chat senders are remembered by name, which lets !ban and the
+// admin API target a username. Temp-bans expire lazily.
+type BanList struct {
+	mu    sync.Mutex
+	bans  map[int64]Ban
+	known map[string]int64 // lowercase username -> Kick user ID
+	store BanStore         // nil = in-memory only
+	onBan func(Ban)
+}
+
+// NewBanList creates a ban list, loading existing bans from store (may be nil)
+func NewBanList(store BanStore) (*BanList, error) {
+	bl := &BanList{
+		bans:  make(map[int64]Ban),
+		known: make(map[string]int64),
+		store: store,
+	}
+	if store == nil {
+		return bl, nil
+	}
+
+	bans, err := store.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load bans: %w", err)
+	}
+	now := time.Now()
+	for _, b := range bans {
+		if b.expired(now) {
+			store.Delete(b.UserID)
+			continue
+		}
+		bl.bans[b.UserID] = b
+		bl.known[strings.ToLower(b.Username)] = b.UserID
+	}
+	return bl, nil
+}
+
+// OnBan sets a callback run after each new ban (e.g. remove the player from the arena)
+func (bl *BanList) OnBan(fn func(Ban)) {
+	bl.mu.Lock()
+	bl.onBan = fn
+	bl.mu.Unlock()
+}
+
+// Observe remembers a chat sender's user ID so they can be banned by name
+func (bl *BanList) Observe(username string, userID int64) {
+	if userID == 0 || username == "" {
+		return
+	}
+	key := strings.ToLower(username)
+	bl.mu.Lock()
+	defer bl.mu.Unlock()
+	if _, ok := bl.known[key]; !ok && len(bl.known) >= maxKnownUsers {
+		bl.known = make(map[string]int64) // Start over rather than grow without bound
+	}
+	bl.known[key] = userID
+}
+
+// Lookup returns the Kick user ID of a username seen in chat
+func (bl *BanList) Lookup(username string) (int64, bool) {
+	bl.mu.Lock()
+	defer bl.mu.Unlock()
+	id, ok := bl.known[strings.ToLower(username)]
+	return id, ok
+}
+
+// IsBanned reports whether a user is banned. Senders without a user ID
+// (API, demo viewers) are matched by name.
+func (bl *BanList) IsBanned(userID int64, username string) bool {
+	bl.mu.Lock()
+	defer bl.mu.Unlock()
+
+	if userID == 0 {
+		var ok bool
+		if userID, ok = bl.known[strings.ToLower(username)]; !ok {
+			return false
+		}
+	}
+	b, ok := bl.bans[userID]
+	if !ok {
+		return false
+	}
+	if b.expired(time.Now()) {
+		bl.removeLocked(userID)
+		return false
+	}
+	return true
+}
+
+// Ban bans a user; a zero duration is permanent. userID 0 resolves the
+// username from chat senders (ErrUnknownUser if never seen). Banning an
+// already banned user replaces their ban.
+func (bl *BanList) Ban(userID int64, username, reason, bannedBy string, duration time.Duration) (Ban, error) {
+	if duration < 0 {
+		return Ban{}, errors.New("ban duration must be positive")
+	}
+
+	bl.mu.Lock()
+	if userID == 0 {
+		var ok bool
+		if userID, ok = bl.known[strings.ToLower(username)]; !ok {
+			bl.mu.Unlock()
+			return Ban{}, fmt.Errorf("%s: %w", username, ErrUnknownUser)
+		}
+	}
+	if username == "" {
+		username = strconv.FormatInt(userID, 10)
+		for name, id := range bl.known {
+			if id == userID {
+				username = name
+				break
+			}
+		}
+	}
+
+	now := time.Now()
+	b := Ban{
+		UserID:    userID,
+		Username:  username,
+		Reason:    reason,
+		BannedBy:  bannedBy,
+		CreatedAt: now,
+	}
+	if duration > 0 {
+		b.ExpiresAt = now.Add(duration)
+	}
+	if bl.store != nil {
+		if err := bl.store.Put(b); err != nil {
+			bl.mu.Unlock()
+			return Ban{}, fmt.Errorf("failed to save ban: %w", err)
+		}
+	}
+	bl.bans[userID] = b
+	bl.known[strings.ToLower(username)] = userID
+	onBan := bl.onBan
+	bl.mu.Unlock()
+
+	if b.Permanent() {
+		log.Printf("🔨 %s banned %s (%d) permanently: %s", bannedBy, username, userID, reason)
+	} else {
+		log.Printf("🔨 %s banned %s (%d) for %s: %s", bannedBy, username, userID, duration, reason)
+	}
+	if onBan != nil {
+		onBan(b)
+	}
+	return b, nil
+}
+
+// Unban lifts a ban by user ID, or by username when userID is 0.
+// Returns false if the user wasn't banned.
+func (bl *BanList) Unban(userID int64, username string) (bool, error) {
+	bl.mu.Lock()
+	defer bl.mu.Unlock()
+
+	if userID == 0 {
+		var ok bool
+		if userID, ok = bl.known[strings.ToLower(username)]; !ok {
+			return false, nil
+		}
+	}
+	if _, ok := bl.bans[userID]; !ok {
+		return false, nil
+	}
+	if err := bl.removeLocked(userID); err != nil {
+		return false, err
+	}
+	log.Printf("🔓 Unbanned %d (%s)", userID, username)
+	return true, nil
+}
+
+// List returns the active bans, newest first
+func (bl *BanList) List() []Ban {
+	bl.mu.Lock()
+	defer bl.mu.Unlock()
+
+	now := time.Now()
+	out := make([]Ban, 0, len(bl.bans))
+	for id, b := range bl.bans {
+		if b.expired(now) {
+			bl.removeLocked(id)
+			continue
+		}
+		out = append(out, b)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].CreatedAt.After(out[j].CreatedAt) })
+	return out
+}
+
+// removeLocked drops a ban from memory and the store (bl.mu held)
+func (bl *BanList) removeLocked(userID int64) error {
+	if bl.store != nil {
+		if err := bl.store.Delete(userID); err != nil {
+			return fmt.Errorf("failed to delete ban: %w", err)
+		}
+	}
+	delete(bl.bans, userID)
+	return nil
+}
+
+// ParseBanDuration parses a ban length: Go durations ("90m", "2h") plus days ("7d")
+func ParseBanDuration(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("invalid ban duration %q", s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid ban duration %q", s)
+	}
+	return d, nil
+}
+
+// =============================================================================
+// FILE STORE
+// =============================================================================
+
+// FileBanStore keeps bans in a JSON file, rewritten atomically on each change
+type FileBanStore struct {
+	mu   sync.Mutex
+	path string
+	bans map[int64]Ban
+}
+
+// NewFileBanStore creates a file ban store
+func NewFileBanStore(path string) *FileBanStore {
+	return &FileBanStore{path: path, bans: make(map[int64]Ban)}
+}
+
+// Load reads bans from the file (none if it doesn't exist yet)
+func (fs *FileBanStore) Load() ([]Ban, error) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	data, err := os.ReadFile(fs.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var bans []Ban
+	if err := json.Unmarshal(data, &bans); err != nil {
+		return nil, fmt.Errorf("%s: %w", fs.path, err)
+	}
+	for _, b := range bans {
+		fs.bans[b.UserID] = b
+	}
+	return bans, nil
+}
+
+// Put adds or replaces a ban
+func (fs *FileBanStore) Put(ban Ban) error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	fs.bans[ban.UserID] = ban
+	return fs.save()
+}
+
+// Delete removes a ban
+func (fs *FileBanStore) Delete(userID int64) error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	if _, ok := fs.bans[userID]; !ok {
+		return nil
+	}
+	delete(fs.bans, userID)
+	return fs.save()
+}
+
+// save writes all bans atomically (tmp + rename); fs.mu held
+func (fs *FileBanStore) save() error {
+	bans := make([]Ban, 0, len(fs.bans))
+	for _, b := range fs.bans {
+		bans = append(bans, b)
+	}
+	sort.Slice(bans, func(i, j int) bool { return bans[i].UserID < bans[j].UserID })
+
+	data, err := json.MarshalIndent(bans, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := fs.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, fs.path)
+}
+
+// =============================================================================
+// SQL STORE
+// =============================================================================
+
+// SQLBanStore keeps bans in a table of a database/sql database.
+// Built for SQLite (the pure-Go modernc.org/sqlite driver, registered as "sqlite").
+type SQLBanStore struct {
+	db *sql.DB
+}
+
+// OpenSQLBanStore opens the database and creates the ban table if needed
+func OpenSQLBanStore(driver, dsn string) (*SQLBanStore, error) {
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open ban database: %w", err)
+	}
+	store, err := NewSQLBanStore(db)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return store, nil
+}
+
+// NewSQLBanStore uses an open database, creating the ban table if needed
+func NewSQLBanStore(db *sql.DB) (*SQLBanStore, error) {
+	_, err := db.Exec(`CREATE TABLE IF NOT EXISTS chat_bans (
+		user_id INTEGER PRIMARY KEY,
+		username TEXT NOT NULL,
+		reason TEXT NOT NULL,
+		banned_by TEXT NOT NULL,
+		created_at TIMESTAMP NOT NULL,
+		expires_at TIMESTAMP
+	)`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ban table: %w", err)
+	}
+	return &SQLBanStore{db: db}, nil
+}
+
+// Load reads all stored bans
+func (ss *SQLBanStore) Load() ([]Ban, error) {
+	rows, err := ss.db.Query(`SELECT user_id, username, reason, banned_by, created_at, expires_at FROM chat_bans`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var bans []Ban
+	for rows.Next() {
+		var b Ban
+		var expires sql.NullTime
+		if err := rows.Scan(&b.UserID, &b.Username, &b.Reason, &b.BannedBy, &b.CreatedAt, &expires); err != nil {
+			return nil, err
+		}
+		if expires.Valid {
+			b.ExpiresAt = expires.Time
+		}
+		bans = append(bans, b)
+	}
+	return bans, rows.Err()
+}
+
+// Put adds or replaces a ban
+func (ss *SQLBanStore) Put(ban Ban) error {
+	expires := sql.NullTime{Time: ban.ExpiresAt.UTC(), Valid: !ban.Permanent()}
+	_, err := ss.db.Exec(`INSERT INTO chat_bans (user_id, username, reason, banned_by, created_at, expires_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(user_id) DO UPDATE SET username = excluded.username, reason = excluded.reason,
+			banned_by = excluded.banned_by, created_at = excluded.created_at, expires_at = excluded.expires_at`,
+		ban.UserID, ban.Username, ban.Reason, ban.BannedBy, ban.CreatedAt.UTC(), expires)
+	return err
+}
+
+// Delete removes a ban
+func (ss *SQLBanStore) Delete(userID int64) error {
+	_, err := ss.db.Exec(`DELETE FROM chat_bans WHERE user_id = ?`, userID)
+	return err
+}
+
+// Close closes the database
+func (ss *SQLBanStore) Close() error {
+	return ss.db.Close()
+}
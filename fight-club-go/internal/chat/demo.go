@@ -0,0 +1,176 @@
+package chat
+
+import (
+	"fmt"
+	"log"
+	"math/rand"
+	"sync"
+	"time"
+
+	"fight-club/internal/config"
+)
+
+// DemoConfig is an alias for config.DemoConfig (SSOT)
+type DemoConfig = config.DemoConfig
+
+// demoNames are the synthetic viewers' base names (numbered past the list)
+var demoNames = []string{
+	"demo_ana", "demo_leo", "demo_mia", "demo_kai", "demo_zoe", "demo_max",
+	"demo_ivy", "demo_rex", "demo_sol", "demo_eva", "demo_tom", "demo_lua",
+}
+
+// demoWeapons, demoEmotes and demoStyles are the arguments synthetic viewers use
+var (
+	demoWeapons = []string{"sword", "spear", "axe", "bow", "scythe", "hammer"}
+	demoEmotes  = []string{"jump", "spin", "flex", "laugh", "dance"}
+	demoStyles  = []string{"berserker", "coward", "sniper", "defender"}
+)
+
+// demoCommand is a synthetic command with its relative frequency
+type demoCommand struct {
+	weight int
+	build  func(d *DemoTraffic, viewer string) ChatCommand
+}
+
+// demoCommands is the synthetic traffic mix: mostly joins (respawns), buys and focus
+var demoCommands = []demoCommand{
+	{25, func(d *DemoTraffic, viewer string) ChatCommand { return demoCmd(viewer, "join") }},
+	{20, func(d *DemoTraffic, viewer string) ChatCommand {
+		return demoCmd(viewer, "buy", demoWeapons[d.rng.Intn(len(demoWeapons))])
+	}},
+	{20, func(d *DemoTraffic, viewer string) ChatCommand {
+		return demoCmd(viewer, "focus", d.otherViewer(viewer))
+	}},
+	{10, func(d *DemoTraffic, viewer string) ChatCommand { return demoCmd(viewer, "heal") }},
+	{10, func(d *DemoTraffic, viewer string) ChatCommand {
+		return demoCmd(viewer, "emote", demoEmotes[d.rng.Intn(len(demoEmotes))])
+	}},
+	{5, func(d *DemoTraffic, viewer string) ChatCommand {
+		return demoCmd(viewer, "style", demoStyles[d.rng.Intn(len(demoStyles))])
+	}},
+	{5, func(d *DemoTraffic, viewer string) ChatCommand {
+		return demoCmd(viewer, "vote", fmt.Sprint(1+d.rng.Intn(3)))
+	}},
+	{5, func(d *DemoTraffic, viewer string) ChatCommand {
+		return demoCmd(viewer, "cheer", d.otherViewer(viewer))
+	}},
+}
+
+// demoCmd builds a synthetic chat command
+func demoCmd(viewer, command string, args ...string) ChatCommand {
+	return ChatCommand{Command: command, Args: args, Username: viewer}
+}
+
+// DemoTraffic sends synthetic chat commands from made-up viewers through the
+// command queue, exercising the same path as Kick webhooks (rate limits,
+// priorities, handler) without credentials. Every viewer joins first.
+type DemoTraffic struct {
+	cfg     DemoConfig
+	enqueue func(ChatCommand) bool // CommandQueue.Enqueue
+
+	mu      sync.Mutex
+	rng     *rand.Rand
+	viewers []string
+	joined  int // Viewers that have sent their first !join
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewDemoTraffic creates a synthetic traffic generator feeding enqueue
+func NewDemoTraffic(cfg DemoConfig, enqueue func(ChatCommand) bool) *DemoTraffic {
+	defaults := config.DefaultDemo()
+	if cfg.Viewers <= 0 {
+		cfg.Viewers = defaults.Viewers
+	}
+	if cfg.Interval <= 0 {
+		cfg.Interval = defaults.Interval
+	}
+	seed := cfg.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	viewers := make([]string, cfg.Viewers)
+	for i := range viewers {
+		viewers[i] = demoNames[i%len(demoNames)]
+		if i >= len(demoNames) {
+			viewers[i] = fmt.Sprintf("%s%d", viewers[i], i/len(demoNames)+1)
+		}
+	}
+
+	return &DemoTraffic{
+		cfg:     cfg,
+		enqueue: enqueue,
+		rng:     rand.New(rand.NewSource(seed)),
+		viewers: viewers,
+		stop:    make(chan struct{}),
+	}
+}
+
+// Next returns the next synthetic command: the next viewer's !join until
+// everyone has joined, then a random viewer and command
+func (d *DemoTraffic) Next() ChatCommand {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.joined < len(d.viewers) {
+		d.joined++
+		return demoCmd(d.viewers[d.joined-1], "join")
+	}
+
+	viewer := d.viewers[d.rng.Intn(len(d.viewers))]
+	total := 0
+	for _, c := range demoCommands {
+		total += c.weight
+	}
+	pick := d.rng.Intn(total)
+	for _, c := range demoCommands {
+		if pick < c.weight {
+			return c.build(d, viewer)
+		}
+		pick -= c.weight
+	}
+	return demoCmd(viewer, "join")
+}
+
+// otherViewer picks a viewer other than viewer (caller holds d.mu)
+func (d *DemoTraffic) otherViewer(viewer string) string {
+	if len(d.viewers) == 1 {
+		return viewer
+	}
+	for {
+		if other := d.viewers[d.rng.Intn(len(d.viewers))]; other != viewer {
+			return other
+		}
+	}
+}
+
+// Start begins sending a command every Interval
+func (d *DemoTraffic) Start() {
+	log.Printf("🎭 Demo mode: %d synthetic viewers, a command every %.2fs", len(d.viewers), d.cfg.Interval)
+
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		ticker := time.NewTicker(time.Duration(d.cfg.Interval * float64(time.Second)))
+		defer ticker.Stop()
+		for {
+			select {
+			case <-d.stop:
+				return
+			case <-ticker.C:
+				cmd := d.Next()
+				if !d.enqueue(cmd) {
+					log.Printf("🎭 Demo command dropped: !%s from %s", cmd.Command, cmd.Username)
+				}
+			}
+		}
+	}()
+}
+
+// Stop stops the synthetic traffic
+func (d *DemoTraffic) Stop() {
+	close(d.stop)
+	d.wg.Wait()
+}
@@ -1,8 +1,11 @@
 package chat
 
 import (
+	"fmt"
 	"log"
+	"strconv"
 	"strings"
+	"time"
 
 	"fight-club/internal/game"
 )
@@ -11,6 +14,16 @@ import (
 type Handler struct {
 	engine      *game.Engine
 	rateLimiter *RateLimiter
+	aliases     *AliasStore // Custom triggers (optional)
+	abuse       *AbuseGuard // Auto-timeout for spammers (optional)
+	bans        *BanList    // Banned users are ignored (optional)
+	replier     Replier     // Tells viewers why a command failed (optional)
+}
+
+// Replier sends short feedback to a viewer in chat. Implementations batch and
+// rate limit replies (see kick.FeedbackBatcher), so calls must not block.
+type Replier interface {
+	Reply(username, message string)
 }
 
 // NewHandler creates a new command handler
@@ -21,16 +34,95 @@ func NewHandler(engine *game.Engine) *Handler {
 	}
 }
 
+// SetAliases enables broadcaster-defined command aliases (e.g. !pelear → !join)
+func (h *Handler) SetAliases(aliases *AliasStore) {
+	h.aliases = aliases
+}
+
+// SetAbuseGuard enables automatic timeouts for users who keep hitting the rate limit
+func (h *Handler) SetAbuseGuard(guard *AbuseGuard) {
+	h.abuse = guard
+}
+
+// SetBans enables the ban list: banned users' commands and chat are ignored
+func (h *Handler) SetBans(bans *BanList) {
+	h.bans = bans
+}
+
+// SetReplier enables chat feedback for failed commands (not enough money, dead, ...)
+func (h *Handler) SetReplier(replier Replier) {
+	h.replier = replier
+}
+
+// reply sends feedback to the viewer when a replier is set
+func (h *Handler) reply(username, format string, args ...interface{}) {
+	if h.replier != nil {
+		h.replier.Reply(username, fmt.Sprintf(format, args...))
+	}
+}
+
+// SetRateLimits replaces the command rate limits (see RateLimitConfigFrom)
+func (h *Handler) SetRateLimits(cfg RateLimitConfig) {
+	h.rateLimiter.SetConfig(cfg)
+}
+
+// RateLimiter returns the command rate limiter (for the debug endpoint)
+func (h *Handler) RateLimiter() *RateLimiter {
+	return h.rateLimiter
+}
+
+// rateLimitName returns the name a command is rate limited under: its
+// canonical name, or "buy" for direct weapon commands (!sword)
+func rateLimitName(cmdType CommandType, command string) string {
+	if cmdType == CmdUnknown {
+		if _, ok := GetWeaponID(command); ok {
+			return CmdBuy.String()
+		}
+	}
+	return cmdType.String()
+}
+
 // ProcessCommand handles a single command
 func (h *Handler) ProcessCommand(cmd ChatCommand) {
-	// Rate limit check
-	if !h.rateLimiter.Allow(cmd.Username) {
-		log.Printf("🚫 Rate limited: %s", cmd.Username)
+	// Usernames are the players' identity: the same sanitized form everywhere
+	cmd.Username = game.SanitizeName(cmd.Username)
+	if cmd.Username == "" {
+		log.Printf("⚠️ Dropped !%s from a sender with no printable name", cmd.Command)
 		return
 	}
 
+	// Banned users can't play (or !join); the broadcaster can't ban themselves out
+	if h.bans != nil && !cmd.IsBroadcaster {
+		h.bans.Observe(cmd.Username, cmd.UserID)
+		if h.bans.IsBanned(cmd.UserID, cmd.Username) {
+			return
+		}
+	}
+
+	// Rewrite custom triggers into built-in commands
+	if h.aliases != nil {
+		cmd = h.aliases.Resolve(cmd)
+	}
+
 	cmdType := GetCommandType(cmd.Command)
 
+	// Rate limit check (per user, then the command's own limit)
+	if ok, limit := h.rateLimiter.AllowCommand(cmd.Username, rateLimitName(cmdType, cmd.Command), TierOf(cmd)); !ok {
+		log.Printf("🚫 Rate limited: %s (!%s)", cmd.Username, cmd.Command)
+		if limit.Max > 0 {
+			h.reply(cmd.Username, "!%s is limited to %d every %s", cmd.Command, limit.Max, limit.Window)
+		} else {
+			h.reply(cmd.Username, "slow down, you're sending commands too fast")
+		}
+		if h.abuse != nil {
+			h.abuse.RecordViolation(cmd)
+		}
+		return
+	}
+
+	// Any chat activity counts as watching (passive income presence)
+	h.engine.GetWalletManager().Touch(cmd.Username)
+
 	switch cmdType {
 	case CmdJoin:
 		h.handleJoin(cmd)
@@ -48,6 +140,52 @@ func (h *Handler) ProcessCommand(cmd ChatCommand) {
 		h.handleFocus(cmd)
 	case CmdTeam:
 		h.handleTeam(cmd)
+	case CmdEmote:
+		h.handleEmote(cmd)
+	case CmdTaunt:
+		h.triggerEmote(cmd, game.TauntEmoteID)
+	case CmdStyle:
+		h.handleStyle(cmd)
+	case CmdVote:
+		h.handleVote(cmd)
+	case CmdCheer:
+		h.handleSpectator(cmd, game.EffectCheer)
+	case CmdCurse:
+		h.handleSpectator(cmd, game.EffectCurse)
+	case CmdPause:
+		h.handlePause(cmd, true)
+	case CmdResume:
+		h.handlePause(cmd, false)
+	case CmdDuel:
+		h.handleDuel(cmd)
+	case CmdAccept:
+		h.handleAccept(cmd)
+	case CmdQuests:
+		h.handleQuests(cmd)
+	case CmdRecord:
+		h.handleRecord(cmd)
+	case CmdLast:
+		h.handleLast(cmd)
+	case CmdSkin:
+		h.handleSkin(cmd)
+	case CmdGift:
+		h.handleGift(cmd)
+	case CmdStrike:
+		h.handleAirstrike(cmd)
+	case CmdKick:
+		h.handleModerate(cmd, game.ModKick)
+	case CmdFreeze:
+		h.handleModerate(cmd, game.ModFreeze)
+	case CmdStrip:
+		h.handleModerate(cmd, game.ModStrip)
+	case CmdBan:
+		h.handleBan(cmd)
+	case CmdUnban:
+		h.handleUnban(cmd)
+	case CmdGoLive:
+		h.handleAutoStream(cmd, true)
+	case CmdOffAir:
+		h.handleAutoStream(cmd, false)
 	default:
 		// Check if it's a direct weapon command (e.g., !sword)
 		if weaponID, ok := GetWeaponID(cmd.Command); ok {
@@ -59,6 +197,11 @@ func (h *Handler) ProcessCommand(cmd ChatCommand) {
 
 // ProcessChatMessage handles non-command chat messages for chat bubbles
 func (h *Handler) ProcessChatMessage(username, message string) {
+	username = game.SanitizeName(username)
+	if username == "" || (h.bans != nil && h.bans.IsBanned(0, username)) {
+		return
+	}
+	h.engine.GetWalletManager().Touch(username)
 	h.engine.SetChatBubble(username, message)
 }
 
@@ -68,7 +211,11 @@ func (h *Handler) handleJoin(cmd ChatCommand) {
 		ProfilePic: cmd.ProfilePic,
 	}
 
-	player := h.engine.AddPlayer(cmd.Username, opts)
+	player, position := h.engine.JoinOrQueue(cmd.Username, opts)
+	if position > 0 {
+		h.reply(cmd.Username, "the arena is full, you're #%d in line", position)
+		return
+	}
 	if player == nil {
 		log.Printf("⚠️ Failed to add player: %s (limit reached?)", cmd.Username)
 		return
@@ -83,16 +230,13 @@ func (h *Handler) handleJoin(cmd ChatCommand) {
 	}
 }
 
-// handleHeal heals the player (costs money)
+// handleHeal heals the player (costs money).
+// A heal sent just before a killing blow still lands (see game.HealPlayerAt).
 func (h *Handler) handleHeal(cmd ChatCommand) {
 	player := h.engine.GetPlayer(cmd.Username)
 	if player == nil {
 		log.Printf("⚠️ %s not in game (tried !heal)", cmd.Username)
-		return
-	}
-
-	if player.IsDead {
-		log.Printf("⚠️ %s is dead (tried !heal)", cmd.Username)
+		h.reply(cmd.Username, "type !join to enter the arena first")
 		return
 	}
 
@@ -101,19 +245,26 @@ func (h *Handler) handleHeal(cmd ChatCommand) {
 
 	if player.Money < healCost {
 		log.Printf("💰 %s needs $%d to heal (has $%d)", cmd.Username, healCost, player.Money)
+		h.reply(cmd.Username, "you need $%d more to heal", healCost-player.Money)
 		return
 	}
 
-	if player.HP >= player.MaxHP {
+	if !player.IsDead && player.HP >= player.MaxHP {
 		log.Printf("💚 %s already at full HP", cmd.Username)
 		return
 	}
 
-	// Charge and heal
-	player.Money -= healCost
-	healed := h.engine.HealPlayer(cmd.Username, healAmount)
-	if healed {
+	// Heal, then charge only if it landed
+	switch h.engine.HealPlayerAt(cmd.Username, healAmount, cmd.ReceivedAt) {
+	case game.HealApplied:
+		player.Money -= healCost
 		log.Printf("💚 %s healed for %d HP (cost: $%d)", cmd.Username, healAmount, healCost)
+	case game.HealRetroactive:
+		player.Money -= healCost
+		log.Printf("💚 %s healed retroactively for %d HP (cost: $%d)", cmd.Username, healAmount, healCost)
+	default:
+		log.Printf("⚠️ %s is dead (tried !heal)", cmd.Username)
+		h.reply(cmd.Username, "you're dead, type !join to respawn")
 	}
 }
 
@@ -128,6 +279,7 @@ func (h *Handler) handleBuy(cmd ChatCommand) {
 	weaponID, ok := GetWeaponID(weaponName)
 	if !ok {
 		log.Printf("⚠️ %s: Unknown weapon '%s'", cmd.Username, weaponName)
+		h.reply(cmd.Username, "unknown weapon '%s', see !shop", weaponName)
 		return
 	}
 
@@ -139,11 +291,13 @@ func (h *Handler) handleBuyWeapon(cmd ChatCommand, weaponID string) {
 	player := h.engine.GetPlayer(cmd.Username)
 	if player == nil {
 		log.Printf("⚠️ %s not in game (tried !buy)", cmd.Username)
+		h.reply(cmd.Username, "type !join to enter the arena first")
 		return
 	}
 
 	if player.IsDead {
 		log.Printf("⚠️ %s is dead (tried !buy)", cmd.Username)
+		h.reply(cmd.Username, "you're dead, type !join to respawn")
 		return
 	}
 
@@ -160,14 +314,37 @@ func (h *Handler) handleBuyWeapon(cmd ChatCommand, weaponID string) {
 		return
 	}
 
-	// Check money
-	if player.Money < weapon.Price {
-		log.Printf("💰 %s needs $%d for %s (has $%d)", cmd.Username, weapon.Price, weapon.Name, player.Money)
+	// Check money (in-arena money + persistent wallet)
+	wallets := h.engine.GetWalletManager()
+	funds := player.Money + wallets.Balance(cmd.Username)
+	if funds < weapon.Price {
+		log.Printf("💰 %s needs $%d for %s (has $%d)", cmd.Username, weapon.Price, weapon.Name, funds)
+		h.reply(cmd.Username, "you need $%d more for %s", weapon.Price-funds, strings.ToLower(weapon.Name))
 		return
 	}
 
-	// Purchase
-	player.Money -= weapon.Price
+	// Shops on the map: the fighter walks over and pays there
+	shop, err := h.engine.OrderWeapon(cmd.Username, weaponID)
+	if err != nil {
+		log.Printf("⚠️ %s can't order %s: %v", cmd.Username, weapon.Name, err)
+		h.reply(cmd.Username, "can't buy %s: %v", strings.ToLower(weapon.Name), err)
+		return
+	}
+	if shop != "" {
+		h.reply(cmd.Username, "head to the %s to pick up your %s", shop, strings.ToLower(weapon.Name))
+		return
+	}
+
+	// Purchase - spend in-arena money first, then the wallet covers the rest
+	if player.Money >= weapon.Price {
+		player.Money -= weapon.Price
+	} else {
+		if !wallets.Spend(cmd.Username, weapon.Price-player.Money) {
+			log.Printf("💰 %s wallet changed during purchase, try again", cmd.Username)
+			return
+		}
+		player.Money = 0
+	}
 	player.Weapon = weaponID
 	log.Printf("🗡️ %s bought %s for $%d!", cmd.Username, weapon.Name, weapon.Price)
 }
@@ -176,7 +353,7 @@ func (h *Handler) handleBuyWeapon(cmd ChatCommand, weaponID string) {
 func (h *Handler) handleStats(cmd ChatCommand) {
 	targetName := cmd.Username
 	if len(cmd.Args) > 0 {
-		targetName = cmd.Args[0]
+		targetName = targetArg(cmd.Args[0])
 	}
 
 	player := h.engine.GetPlayer(targetName)
@@ -193,19 +370,27 @@ func (h *Handler) handleStats(cmd ChatCommand) {
 			teamInfo = " | Team: " + team.Name
 		}
 	}
-	log.Printf("📊 %s: HP %d/%d | $%d | K:%d D:%d | %s%s",
+	style := game.GetPersonality(player.Personality)
+	log.Printf("📊 %s: HP %d/%d | $%d (wallet $%d) | K:%d D:%d | %s | %s%s",
 		player.Name, player.HP, player.MaxHP, player.Money,
-		player.Kills, player.Deaths, weapon.Name, teamInfo)
+		h.engine.GetWalletManager().Balance(player.Name),
+		player.Kills, player.Deaths, weapon.Name, style.Name, teamInfo)
 }
 
 // handleShop shows available weapons
 func (h *Handler) handleShop(cmd ChatCommand) {
-	log.Printf("🏪 Shop: sword $100 | spear $200 | axe $300 | bow $400 | scythe $500")
+	shop := "sword $100 | spear $200 | axe $300 | bow $400 | scythe $500"
+	for _, d := range game.WeaponDefinitions() {
+		if _, builtin := game.Weapons[d.ID]; !builtin {
+			shop += fmt.Sprintf(" | %s $%d", d.ID, d.Price)
+		}
+	}
+	log.Printf("🏪 Shop: %s", shop)
 }
 
 // handleHelp shows available commands
 func (h *Handler) handleHelp(cmd ChatCommand) {
-	log.Printf("📜 Commands: !join | !heal ($20) | !buy <weapon> | !stats | !shop | !focus <user> | !team <cmd>")
+	log.Printf("📜 Commands: !join | !heal ($20) | !buy <weapon> | !stats | !shop | !focus <user> | !team <cmd> | !emote <name> | !taunt | !style <profile> | !vote <1-3> | !cheer/!curse <user> (dead) | !duel <user> | !accept | !quests | !record | !last | !skin <name> | !gift <user> <amount> | !airstrike <x,y|user>")
 }
 
 // handleFocus sets a combat focus target
@@ -213,11 +398,13 @@ func (h *Handler) handleFocus(cmd ChatCommand) {
 	player := h.engine.GetPlayer(cmd.Username)
 	if player == nil {
 		log.Printf("⚠️ %s not in game (tried !focus)", cmd.Username)
+		h.reply(cmd.Username, "type !join to enter the arena first")
 		return
 	}
 
 	if player.IsDead {
 		log.Printf("⚠️ %s is dead (tried !focus)", cmd.Username)
+		h.reply(cmd.Username, "you're dead, type !join to respawn")
 		return
 	}
 
@@ -228,7 +415,7 @@ func (h *Handler) handleFocus(cmd ChatCommand) {
 		return
 	}
 
-	targetName := cmd.Args[0]
+	targetName := targetArg(cmd.Args[0])
 
 	// Can't focus yourself
 	if targetName == cmd.Username {
@@ -240,7 +427,358 @@ func (h *Handler) handleFocus(cmd ChatCommand) {
 		log.Printf("🎯 %s is now focusing %s", cmd.Username, targetName)
 	} else {
 		log.Printf("⚠️ %s: Cannot focus %s (not found or teammate)", cmd.Username, targetName)
+		h.reply(cmd.Username, "can't focus %s (not in the arena or a teammate)", targetName)
+	}
+}
+
+// handleEmote plays a named emote (jump, spin, flex, ...)
+func (h *Handler) handleEmote(cmd ChatCommand) {
+	if len(cmd.Args) == 0 {
+		log.Printf("ℹ️ %s: Usage: !emote <jump|spin|flex|laugh|dance|taunt>", cmd.Username)
+		return
+	}
+
+	emoteName := strings.ToLower(cmd.Args[0])
+	emoteID, ok := GetEmoteID(emoteName)
+	if !ok {
+		log.Printf("⚠️ %s: Unknown emote '%s'", cmd.Username, emoteName)
+		return
 	}
+
+	h.triggerEmote(cmd, emoteID)
+}
+
+// triggerEmote starts an emote animation on the player
+func (h *Handler) triggerEmote(cmd ChatCommand, emoteID string) {
+	if err := h.engine.TriggerEmote(cmd.Username, emoteID); err != nil {
+		log.Printf("⚠️ %s: Cannot emote: %v", cmd.Username, err)
+		h.reply(cmd.Username, "can't emote: %v", err)
+		return
+	}
+
+	emote, _ := game.GetEmote(emoteID)
+	log.Printf("%s %s used emote %s", emote.Emoji, cmd.Username, emote.Name)
+}
+
+// handleStyle sets the player's AI personality (berserker, coward, sniper, defender)
+func (h *Handler) handleStyle(cmd ChatCommand) {
+	if len(cmd.Args) == 0 {
+		log.Printf("ℹ️ %s: Usage: !style <berserker|coward|sniper|defender>", cmd.Username)
+		return
+	}
+
+	styleName := strings.ToLower(cmd.Args[0])
+	personalityID, ok := GetPersonalityID(styleName)
+	if !ok {
+		log.Printf("⚠️ %s: Unknown style '%s'", cmd.Username, styleName)
+		return
+	}
+
+	if err := h.engine.SetPersonality(cmd.Username, personalityID); err != nil {
+		log.Printf("⚠️ %s: Cannot change style: %v", cmd.Username, err)
+		h.reply(cmd.Username, "can't change style: %v", err)
+		return
+	}
+
+	style := game.GetPersonality(personalityID)
+	log.Printf("%s %s is now a %s", style.Emoji, cmd.Username, style.Name)
+}
+
+// handleSkin buys or equips a cosmetic skin, or lists the skin shop.
+// Owned skins are re-equipped for free.
+func (h *Handler) handleSkin(cmd ChatCommand) {
+	if len(cmd.Args) == 0 {
+		shop := make([]string, 0, len(game.Skins))
+		for _, s := range game.GetAllSkins() {
+			shop = append(shop, fmt.Sprintf("%s %s $%d", s.Emoji, s.ID, s.Price))
+		}
+		log.Printf("🎨 Skins: %s", strings.Join(shop, " | "))
+		return
+	}
+
+	skinName := strings.ToLower(cmd.Args[0])
+	skinID, ok := GetSkinID(skinName)
+	if !ok {
+		log.Printf("⚠️ %s: Unknown skin '%s'", cmd.Username, skinName)
+		h.reply(cmd.Username, "unknown skin '%s', see !skin", skinName)
+		return
+	}
+
+	result, err := h.engine.BuySkin(cmd.Username, skinID)
+	if err != nil {
+		log.Printf("⚠️ %s: Cannot get skin %s: %v", cmd.Username, skinID, err)
+		h.reply(cmd.Username, "can't get skin %s: %v", skinID, err)
+		return
+	}
+
+	skin, _ := game.GetSkin(skinID)
+	if result == game.SkinBought {
+		log.Printf("%s %s bought the %s skin for $%d!", skin.Emoji, cmd.Username, skin.Name, skin.Price)
+	} else {
+		log.Printf("%s %s equipped the %s skin", skin.Emoji, cmd.Username, skin.Name)
+	}
+}
+
+// handleGift sends in-arena money to another fighter
+func (h *Handler) handleGift(cmd ChatCommand) {
+	if len(cmd.Args) < 2 {
+		log.Printf("ℹ️ %s: Usage: !gift <player> <amount>", cmd.Username)
+		return
+	}
+
+	targetName := targetArg(cmd.Args[0])
+	amount, err := strconv.Atoi(strings.TrimPrefix(cmd.Args[1], "$"))
+	if err != nil {
+		log.Printf("⚠️ %s: Invalid gift amount '%s'", cmd.Username, cmd.Args[1])
+		h.reply(cmd.Username, "usage: !gift <player> <amount>")
+		return
+	}
+
+	if err := h.engine.Gift(cmd.Username, targetName, amount); err != nil {
+		log.Printf("⚠️ %s: Cannot gift %s: %v", cmd.Username, targetName, err)
+		h.reply(cmd.Username, "can't gift %s: %v", targetName, err)
+	}
+}
+
+// handleAirstrike calls a wallet-paid airstrike on a spot ("x,y") or a player
+// Anyone in chat can call one, not just players in the arena
+func (h *Handler) handleAirstrike(cmd ChatCommand) {
+	if len(cmd.Args) == 0 {
+		log.Printf("ℹ️ %s: Usage: !airstrike <x,y|player>", cmd.Username)
+		h.reply(cmd.Username, "usage: !airstrike <x,y|player> (costs $%d)", h.engine.AirstrikeCost())
+		return
+	}
+
+	// Coordinates may be typed as "x,y", "x, y" or "x y"
+	var target string
+	var x, y float64
+	coords := strings.FieldsFunc(strings.Join(cmd.Args, ","), func(r rune) bool { return r == ',' })
+	var errX, errY error
+	if len(coords) == 2 {
+		x, errX = strconv.ParseFloat(strings.TrimSpace(coords[0]), 64)
+		y, errY = strconv.ParseFloat(strings.TrimSpace(coords[1]), 64)
+	}
+	if len(coords) != 2 || errX != nil || errY != nil {
+		target = targetArg(cmd.Args[0])
+	}
+
+	cost, err := h.engine.Airstrike(cmd.Username, target, x, y)
+	if err != nil {
+		log.Printf("⚠️ %s: Cannot call an airstrike: %v", cmd.Username, err)
+		h.reply(cmd.Username, "can't call an airstrike: %v", err)
+		return
+	}
+	h.reply(cmd.Username, "airstrike incoming for $%d (next one costs $%d)", cost, h.engine.AirstrikeCost())
+}
+
+// handleVote casts a ballot in the current arena modifier vote
+// Anyone in chat can vote, not just players in the arena
+func (h *Handler) handleVote(cmd ChatCommand) {
+	if len(cmd.Args) == 0 {
+		log.Printf("ℹ️ %s: Usage: !vote <1|2|3>", cmd.Username)
+		return
+	}
+
+	option, err := strconv.Atoi(strings.TrimPrefix(cmd.Args[0], "#"))
+	if err != nil {
+		log.Printf("⚠️ %s: Invalid vote '%s'", cmd.Username, cmd.Args[0])
+		return
+	}
+
+	if err := h.engine.GetVoteManager().Vote(cmd.Username, option); err != nil {
+		log.Printf("⚠️ %s: Cannot vote: %v", cmd.Username, err)
+		h.reply(cmd.Username, "can't vote: %v", err)
+		return
+	}
+	log.Printf("🗳️ %s voted for option %d", cmd.Username, option)
+}
+
+// handleSpectator lets dead players cheer (speed up) or curse (slow) a fighter
+func (h *Handler) handleSpectator(cmd ChatCommand, effect game.SpectatorEffect) {
+	if len(cmd.Args) == 0 {
+		log.Printf("ℹ️ %s: Usage: !%s <player>", cmd.Username, effect)
+		return
+	}
+
+	targetName := targetArg(cmd.Args[0])
+
+	var err error
+	if effect == game.EffectCheer {
+		err = h.engine.Cheer(cmd.Username, targetName)
+	} else {
+		err = h.engine.Curse(cmd.Username, targetName)
+	}
+	if err != nil {
+		log.Printf("⚠️ %s: Cannot %s %s: %v", cmd.Username, effect, targetName, err)
+		h.reply(cmd.Username, "can't %s %s: %v", effect, targetName, err)
+	}
+}
+
+// handleDuel challenges another fighter to a 1v1 in the duel ring
+func (h *Handler) handleDuel(cmd ChatCommand) {
+	if len(cmd.Args) == 0 {
+		log.Printf("ℹ️ %s: Usage: !duel <player>", cmd.Username)
+		return
+	}
+
+	targetName := targetArg(cmd.Args[0])
+	if err := h.engine.ChallengeDuel(cmd.Username, targetName); err != nil {
+		log.Printf("⚠️ %s: Cannot duel %s: %v", cmd.Username, targetName, err)
+		h.reply(cmd.Username, "can't duel %s: %v", targetName, err)
+	}
+}
+
+// handleAccept accepts an open duel challenge
+func (h *Handler) handleAccept(cmd ChatCommand) {
+	if err := h.engine.AcceptDuel(cmd.Username); err != nil {
+		log.Printf("⚠️ %s: Cannot accept duel: %v", cmd.Username, err)
+		h.reply(cmd.Username, "can't accept: %v", err)
+	}
+}
+
+// handleQuests shows the viewer's daily quest progress (announced by the chat bot)
+func (h *Handler) handleQuests(cmd ChatCommand) {
+	h.engine.GetQuestStore().ShowProgress(cmd.Username, time.Now())
+}
+
+// handleRecord replies with the viewer's lifetime K/D and best killstreak
+func (h *Handler) handleRecord(cmd ChatCommand) {
+	career, ok := h.engine.GetCareerStore().Career(cmd.Username)
+	if !ok {
+		h.reply(cmd.Username, "no record yet, type !join and get fighting")
+		return
+	}
+	h.reply(cmd.Username, "career %d kills / %d deaths (K/D %.2f) | best streak %d",
+		career.Kills, career.Deaths, career.KD(), career.BestStreak)
+}
+
+// handleLast replies with a summary of the viewer's previous life
+func (h *Handler) handleLast(cmd ChatCommand) {
+	career, _ := h.engine.GetCareerStore().Career(cmd.Username)
+	last := career.Last
+	if last == nil {
+		h.reply(cmd.Username, "you haven't died yet")
+		return
+	}
+
+	killedBy := "the arena"
+	if last.KilledBy != "" {
+		killedBy = last.KilledBy
+	}
+	h.reply(cmd.Username, "last life: survived %s | %d damage | %d kills with %s | killed by %s",
+		time.Duration(last.Seconds)*time.Second, last.Damage, last.Kills,
+		strings.ToLower(game.GetWeapon(last.Weapon).Name), killedBy)
+}
+
+// handlePause freezes or resumes the match for IRL interruptions (broadcaster only)
+func (h *Handler) handlePause(cmd ChatCommand, pause bool) {
+	if !cmd.IsBroadcaster {
+		log.Printf("⚠️ %s: Only the broadcaster can pause/resume the game", cmd.Username)
+		return
+	}
+
+	if pause && !h.engine.Pause() {
+		log.Printf("ℹ️ %s: Game is already paused", cmd.Username)
+	} else if !pause && !h.engine.Resume() {
+		log.Printf("ℹ️ %s: Game is not paused", cmd.Username)
+	}
+}
+
+// handleModerate removes, freezes or disarms a griefer (moderators and broadcaster only)
+func (h *Handler) handleModerate(cmd ChatCommand, action game.ModAction) {
+	if !cmd.IsModerator && !cmd.IsBroadcaster {
+		log.Printf("⚠️ %s: Only moderators can use !%s", cmd.Username, action)
+		return
+	}
+	if len(cmd.Args) == 0 {
+		log.Printf("ℹ️ %s: Usage: !%s <player>", cmd.Username, action)
+		return
+	}
+
+	targetName := targetArg(cmd.Args[0])
+	if err := h.engine.Moderate(cmd.Username, targetName, action); err != nil {
+		log.Printf("⚠️ %s: Cannot %s %s: %v", cmd.Username, action, targetName, err)
+		h.reply(cmd.Username, "can't %s %s: %v", action, targetName, err)
+	}
+}
+
+// handleBan bans a viewer from the game (broadcaster only):
+// !ban <user> [duration like 30m, 2h or 7d] [reason...]
+func (h *Handler) handleBan(cmd ChatCommand) {
+	if !cmd.IsBroadcaster {
+		log.Printf("⚠️ %s: Only the broadcaster can ban", cmd.Username)
+		return
+	}
+	if h.bans == nil {
+		h.reply(cmd.Username, "the ban list is disabled")
+		return
+	}
+	if len(cmd.Args) == 0 {
+		h.reply(cmd.Username, "usage: !ban <user> [30m|2h|7d] [reason]")
+		return
+	}
+
+	target := targetArg(cmd.Args[0])
+	rest := cmd.Args[1:]
+	var duration time.Duration
+	if len(rest) > 0 {
+		if d, err := ParseBanDuration(rest[0]); err == nil {
+			duration, rest = d, rest[1:]
+		}
+	}
+
+	if _, err := h.bans.Ban(0, target, strings.Join(rest, " "), cmd.Username, duration); err != nil {
+		log.Printf("⚠️ %s: Cannot ban %s: %v", cmd.Username, target, err)
+		h.reply(cmd.Username, "can't ban %s: %v", target, err)
+	}
+}
+
+// handleUnban lifts a ban (broadcaster only)
+func (h *Handler) handleUnban(cmd ChatCommand) {
+	if !cmd.IsBroadcaster {
+		log.Printf("⚠️ %s: Only the broadcaster can unban", cmd.Username)
+		return
+	}
+	if h.bans == nil || len(cmd.Args) == 0 {
+		return
+	}
+
+	target := targetArg(cmd.Args[0])
+	if ok, err := h.bans.Unban(0, target); err != nil {
+		log.Printf("⚠️ %s: Cannot unban %s: %v", cmd.Username, target, err)
+	} else if !ok {
+		h.reply(cmd.Username, "%s is not banned", target)
+	}
+}
+
+// handleAutoStream starts or ends the stream by hand (broadcaster only).
+// Only meaningful with AUTO_STREAM_ENABLED; otherwise the stream always runs.
+func (h *Handler) handleAutoStream(cmd ChatCommand, live bool) {
+	if !cmd.IsBroadcaster {
+		log.Printf("⚠️ %s: Only the broadcaster can start/end the stream", cmd.Username)
+		return
+	}
+	if !h.engine.GetAutoStream().Enabled() {
+		h.reply(cmd.Username, "auto-stream is disabled, the stream is always on")
+		return
+	}
+
+	if live && !h.engine.GoLive() {
+		log.Printf("ℹ️ %s: Stream is already live", cmd.Username)
+	} else if !live && !h.engine.GoOffline() {
+		log.Printf("ℹ️ %s: Stream is already offline", cmd.Username)
+	}
+}
+
+// targetArg reads a player named in a command ("@name" or "name") the way
+// usernames are sanitized, so look-alike spellings reach the same player
+func targetArg(arg string) string {
+	return game.SanitizeName(strings.TrimPrefix(arg, "@"))
+}
+
+// teamNameArg reads a team name typed in chat (sanitized, display length)
+func teamNameArg(args []string) string {
+	return game.TruncateGraphemes(game.SanitizeName(strings.Join(args, " ")), game.MaxDisplayNameLength)
 }
 
 // handleTeam handles team commands
@@ -273,8 +811,12 @@ func (h *Handler) handleTeam(cmd ChatCommand) {
 		h.handleTeamRename(cmd, tm)
 	case "color":
 		h.handleTeamColor(cmd, tm)
+	case "rally", "arenga":
+		h.handleTeamOrder(cmd, game.OrderRally)
+	case "regroup", "reagrupar":
+		h.handleTeamOrder(cmd, game.OrderRegroup)
 	default:
-		log.Printf("ℹ️ Team commands: create/invite/join/leave/rename/color")
+		log.Printf("ℹ️ Team commands: create/invite/join/leave/rename/color/rally/regroup")
 	}
 }
 
@@ -303,7 +845,9 @@ func (h *Handler) handleTeamCreate(cmd ChatCommand, tm *game.TeamManager) {
 
 	teamName := cmd.Username + "'s Team"
 	if len(cmd.Args) > 1 {
-		teamName = strings.Join(cmd.Args[1:], " ")
+		if name := teamNameArg(cmd.Args[1:]); name != "" {
+			teamName = name
+		}
 	}
 
 	team, err := tm.CreateTeam(cmd.Username, teamName)
@@ -329,7 +873,7 @@ func (h *Handler) handleTeamInvite(cmd ChatCommand, tm *game.TeamManager) {
 		return
 	}
 
-	targetName := cmd.Args[1]
+	targetName := targetArg(cmd.Args[1])
 
 	// Check target exists
 	target := h.engine.GetPlayer(targetName)
@@ -366,7 +910,7 @@ func (h *Handler) handleTeamJoin(cmd ChatCommand, tm *game.TeamManager) {
 		return
 	}
 
-	leaderName := cmd.Args[1]
+	leaderName := targetArg(cmd.Args[1])
 	team, err := tm.AcceptInvite(cmd.Username, leaderName)
 	if err != nil {
 		log.Printf("⚠️ %s: %v", cmd.Username, err)
@@ -394,7 +938,11 @@ func (h *Handler) handleTeamRename(cmd ChatCommand, tm *game.TeamManager) {
 		return
 	}
 
-	newName := strings.Join(cmd.Args[1:], " ")
+	newName := teamNameArg(cmd.Args[1:])
+	if newName == "" {
+		log.Printf("ℹ️ Usage: !team rename <new_name>")
+		return
+	}
 	err := tm.RenameTeam(cmd.Username, newName)
 	if err != nil {
 		log.Printf("⚠️ %s: %v", cmd.Username, err)
@@ -420,6 +968,20 @@ func (h *Handler) handleTeamColor(cmd ChatCommand, tm *game.TeamManager) {
 	log.Printf("👥 %s set team color to %s", cmd.Username, color)
 }
 
+// handleTeamOrder gives a team leader's rally or regroup order
+func (h *Handler) handleTeamOrder(cmd ChatCommand, order game.TeamOrder) {
+	var err error
+	if order == game.OrderRally {
+		_, err = h.engine.TeamRally(cmd.Username)
+	} else {
+		_, err = h.engine.TeamRegroup(cmd.Username)
+	}
+	if err != nil {
+		log.Printf("⚠️ %s: Cannot %s: %v", cmd.Username, order, err)
+		h.reply(cmd.Username, "can't %s: %v", order, err)
+	}
+}
+
 // Run starts processing commands from a channel (call in goroutine)
 func (h *Handler) Run(commands <-chan ChatCommand) {
 	for cmd := range commands {
@@ -67,6 +67,9 @@ type KickChatMessageData struct {
 		Slug     string `json:"slug"`
 		Identity struct {
 			ProfilePic string `json:"profile_pic"`
+			Badges     []struct {
+				Type string `json:"type"`
+			} `json:"badges"`
 		} `json:"identity"`
 		ProfilePic string `json:"profile_pic"` // Alternative location
 	} `json:"sender"`
@@ -238,6 +241,16 @@ func (l *Listener) handleChatMessage(data string) {
 			ProfilePic: profilePic,
 			ReceivedAt: time.Now(),
 		}
+		for _, badge := range chatData.Sender.Identity.Badges {
+			switch badge.Type {
+			case "broadcaster":
+				cmd.IsBroadcaster = true
+			case "moderator":
+				cmd.IsModerator = true
+			case "subscriber", "founder":
+				cmd.IsSubscriber = true
+			}
+		}
 
 		// Non-blocking send to command channel
 		select {
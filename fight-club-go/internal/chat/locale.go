@@ -0,0 +1,200 @@
+package chat
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"unicode"
+
+	"fight-club/internal/config"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// CommandLocaleConfig is an alias for config.CommandLocaleConfig (SSOT)
+type CommandLocaleConfig = config.CommandLocaleConfig
+
+// CommandLocales are the built-in command translations, enabled per locale
+// (CHAT_COMMAND_LOCALES). Words are stored without accents: "!misiones" and
+// "!missões" both match (see lookupFolded).
+var CommandLocales = map[string]map[string]CommandType{
+	"es": {
+		"unirse":    CmdJoin,
+		"entrar":    CmdJoin,
+		"vida":      CmdHeal,
+		"curar":     CmdHeal,
+		"comprar":   CmdBuy,
+		"tienda":    CmdShop,
+		"ayuda":     CmdHelp,
+		"comandos":  CmdHelp,
+		"enfocar":   CmdFocus,
+		"objetivo":  CmdFocus,
+		"equipo":    CmdTeam,
+		"gesto":     CmdEmote,
+		"burla":     CmdTaunt,
+		"provocar":  CmdTaunt,
+		"estilo":    CmdStyle,
+		"votar":     CmdVote,
+		"voto":      CmdVote,
+		"animar":    CmdCheer,
+		"apoyar":    CmdCheer,
+		"maldecir":  CmdCurse,
+		"maldicion": CmdCurse,
+		"pausa":     CmdPause,
+		"reanudar":  CmdResume,
+		"continuar": CmdResume,
+		"duelo":     CmdDuel,
+		"retar":     CmdDuel,
+		"aceptar":   CmdAccept,
+		"misiones":  CmdQuests,
+		"historial": CmdRecord,
+		"ultima":    CmdLast,
+		"aspecto":   CmdSkin,
+		"regalar":   CmdGift,
+		"regalo":    CmdGift,
+		"bombardeo": CmdStrike,
+		"expulsar":  CmdKick,
+		"congelar":  CmdFreeze,
+		"desarmar":  CmdStrip,
+		"banear":    CmdBan,
+		"desbanear": CmdUnban,
+		"envivo":    CmdGoLive,
+	},
+	"pt": {
+		"entrar":     CmdJoin,
+		"jogar":      CmdJoin,
+		"curar":      CmdHeal,
+		"vida":       CmdHeal,
+		"comprar":    CmdBuy,
+		"status":     CmdStats,
+		"loja":       CmdShop,
+		"ajuda":      CmdHelp,
+		"comandos":   CmdHelp,
+		"focar":      CmdFocus,
+		"alvo":       CmdFocus,
+		"equipe":     CmdTeam,
+		"time":       CmdTeam,
+		"gesto":      CmdEmote,
+		"provocar":   CmdTaunt,
+		"zoar":       CmdTaunt,
+		"estilo":     CmdStyle,
+		"votar":      CmdVote,
+		"voto":       CmdVote,
+		"torcer":     CmdCheer,
+		"apoiar":     CmdCheer,
+		"amaldicoar": CmdCurse,
+		"maldicao":   CmdCurse,
+		"pausar":     CmdPause,
+		"retomar":    CmdResume,
+		"continuar":  CmdResume,
+		"duelo":      CmdDuel,
+		"desafiar":   CmdDuel,
+		"aceitar":    CmdAccept,
+		"missoes":    CmdQuests,
+		"historico":  CmdRecord,
+		"ultima":     CmdLast,
+		"visual":     CmdSkin,
+		"presentear": CmdGift,
+		"presente":   CmdGift,
+		"bombardeio": CmdStrike,
+		"expulsar":   CmdKick,
+		"congelar":   CmdFreeze,
+		"desarmar":   CmdStrip,
+		"banir":      CmdBan,
+		"desbanir":   CmdUnban,
+		"aovivo":     CmdGoLive,
+	},
+}
+
+// activeCommands is the command table GetCommandType reads: English plus the
+// enabled locales and extras (all locales until SetCommandLocales is called)
+var activeCommands atomic.Pointer[map[string]CommandType]
+
+func init() {
+	table, err := buildCommandTable(config.DefaultCommandLocale())
+	if err != nil {
+		panic(err)
+	}
+	activeCommands.Store(&table)
+}
+
+// SetCommandLocales switches the languages chat commands are understood in.
+// Extras map words to a built-in command ("pelear" -> "join"); they can't
+// shadow an English command. On error the current table is kept.
+func SetCommandLocales(cfg CommandLocaleConfig) error {
+	table, err := buildCommandTable(cfg)
+	if err != nil {
+		return err
+	}
+	activeCommands.Store(&table)
+	return nil
+}
+
+// buildCommandTable merges English, the locales (in order) and the extras
+func buildCommandTable(cfg CommandLocaleConfig) (map[string]CommandType, error) {
+	table := make(map[string]CommandType, len(SupportedCommands))
+	for word, t := range SupportedCommands {
+		table[word] = t
+	}
+
+	for _, locale := range cfg.Locales {
+		words, ok := CommandLocales[locale]
+		if !ok {
+			return nil, fmt.Errorf("unknown command locale %q (available: %s)", locale, strings.Join(CommandLocaleNames(), ", "))
+		}
+		for word, t := range words {
+			if _, english := SupportedCommands[word]; !english {
+				table[word] = t
+			}
+		}
+	}
+
+	for word, command := range cfg.Extras {
+		word = foldAccents(strings.ToLower(word))
+		t, ok := SupportedCommands[command]
+		if !ok {
+			return nil, fmt.Errorf("!%s: unknown command !%s", word, command)
+		}
+		if _, english := SupportedCommands[word]; english {
+			return nil, fmt.Errorf("!%s shadows a built-in command", word)
+		}
+		table[word] = t
+	}
+	return table, nil
+}
+
+// CommandLocaleNames returns the built-in locales, sorted
+func CommandLocaleNames() []string {
+	names := make([]string, 0, len(CommandLocales))
+	for name := range CommandLocales {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// lookupFolded looks a word up as typed, then without accents ("missões" → "missoes")
+func lookupFolded[V any](table map[string]V, word string) (V, bool) {
+	if v, ok := table[word]; ok {
+		return v, true
+	}
+	v, ok := table[foldAccents(word)]
+	return v, ok
+}
+
+// foldAccents strips diacritics: decomposes (NFD) and drops the combining marks
+func foldAccents(s string) string {
+	for _, r := range s {
+		if r >= 0x80 {
+			var b strings.Builder
+			for _, r := range norm.NFD.String(s) {
+				if !unicode.Is(unicode.Mn, r) {
+					b.WriteRune(r)
+				}
+			}
+			return b.String()
+		}
+	}
+	return s // ASCII: nothing to fold
+}
@@ -7,35 +7,97 @@ import (
 	"time"
 )
 
+// CommandPriority orders queued commands: lower values are processed first
+type CommandPriority int
+
+const (
+	PriorityJoin     CommandPriority = iota // !join and broadcaster controls
+	PriorityGameplay                        // Commands that change the fight (heal, buy, focus, vote...)
+	PriorityCosmetic                        // Emotes, styles and info replies
+	numPriorities
+)
+
+// String returns the priority name used in metrics
+func (p CommandPriority) String() string {
+	switch p {
+	case PriorityJoin:
+		return "join"
+	case PriorityGameplay:
+		return "gameplay"
+	default:
+		return "cosmetic"
+	}
+}
+
+// GetCommandPriority returns the queue priority of a command type
+func GetCommandPriority(t CommandType) CommandPriority {
+	switch t {
+	case CmdJoin, CmdPause, CmdResume, CmdGoLive, CmdOffAir:
+		return PriorityJoin
+	case CmdEmote, CmdTaunt, CmdStyle, CmdStats, CmdShop, CmdHelp, CmdQuests, CmdRecord, CmdLast, CmdUnknown:
+		return PriorityCosmetic
+	default:
+		return PriorityGameplay
+	}
+}
+
+// queuedCommand is a pending command with its routing info
+type queuedCommand struct {
+	cmd      ChatCommand
+	kind     string // Command type name (drop metrics)
+	priority CommandPriority
+}
+
+// userQueue holds one viewer's pending commands per priority
+type userQueue struct {
+	pending [numPriorities][]queuedCommand
+	total   int
+}
+
 // CommandQueue provides a non-blocking queue for chat commands with worker pool processing.
 // This decouples webhook handlers from game engine operations, eliminating latency caused
 // by synchronous command processing.
+//
+// Each viewer gets their own queue and viewers are drained round-robin, highest priority
+// first, so one spammer only fills their own slots and !join is never stuck behind emotes.
 type CommandQueue struct {
-	commands    chan ChatCommand
-	handler     *Handler
-	workers     int
-	wg          sync.WaitGroup
-	running     atomic.Bool
-	stopChan    chan struct{}
+	mu       sync.Mutex
+	users    map[string]*userQueue
+	rotation [numPriorities][]string // Round-robin order of users with pending commands
+	pending  int
+
+	bufferSize   int
+	perUserLimit int
+	ready        chan struct{} // Wakes idle workers
+
+	handler  *Handler
+	workers  int
+	wg       sync.WaitGroup
+	running  atomic.Bool
+	stopChan chan struct{}
 
 	// Metrics
 	enqueued    atomic.Uint64
 	processed   atomic.Uint64
 	dropped     atomic.Uint64
+	evicted     atomic.Uint64
 	avgWaitTime atomic.Int64 // nanoseconds, exponential moving average
+	dropsByType map[string]uint64
 }
 
 // QueueConfig holds configuration for the command queue
 type QueueConfig struct {
-	BufferSize int // Number of commands to buffer (default: 256)
-	Workers    int // Number of worker goroutines (default: 4)
+	BufferSize   int // Number of commands to buffer (default: 256)
+	Workers      int // Number of worker goroutines (default: 4)
+	PerUserLimit int // Pending commands per viewer (default: 8)
 }
 
 // DefaultQueueConfig returns sensible defaults for production
 func DefaultQueueConfig() QueueConfig {
 	return QueueConfig{
-		BufferSize: 256, // ~10 seconds of commands at peak load
-		Workers:    4,   // Enough parallelism without lock contention
+		BufferSize:   256, // ~10 seconds of commands at peak load
+		Workers:      4,   // Enough parallelism without lock contention
+		PerUserLimit: 8,   // Above the rate limiter burst, so only floods are dropped
 	}
 }
 
@@ -47,12 +109,19 @@ func NewCommandQueue(handler *Handler, config QueueConfig) *CommandQueue {
 	if config.Workers <= 0 {
 		config.Workers = 4
 	}
+	if config.PerUserLimit <= 0 {
+		config.PerUserLimit = 8
+	}
 
 	return &CommandQueue{
-		commands: make(chan ChatCommand, config.BufferSize),
-		handler:  handler,
-		workers:  config.Workers,
-		stopChan: make(chan struct{}),
+		users:        make(map[string]*userQueue),
+		bufferSize:   config.BufferSize,
+		perUserLimit: config.PerUserLimit,
+		ready:        make(chan struct{}, config.BufferSize),
+		handler:      handler,
+		workers:      config.Workers,
+		stopChan:     make(chan struct{}),
+		dropsByType:  make(map[string]uint64),
 	}
 }
 
@@ -62,7 +131,7 @@ func (q *CommandQueue) Start() {
 		return // Already running
 	}
 
-	log.Printf("🚀 CommandQueue starting with %d workers, buffer size %d", q.workers, cap(q.commands))
+	log.Printf("🚀 CommandQueue starting with %d workers, buffer size %d (%d per user)", q.workers, q.bufferSize, q.perUserLimit)
 
 	for i := 0; i < q.workers; i++ {
 		q.wg.Add(1)
@@ -83,24 +152,147 @@ func (q *CommandQueue) Stop() {
 		q.enqueued.Load(), q.processed.Load(), q.dropped.Load())
 }
 
-// Enqueue adds a command to the queue (non-blocking)
-// Returns true if enqueued, false if queue is full (command dropped)
+// classify returns a command's type name and priority (custom aliases resolved first)
+func (q *CommandQueue) classify(cmd ChatCommand) (string, CommandPriority) {
+	if q.handler != nil && q.handler.aliases != nil {
+		cmd = q.handler.aliases.Resolve(cmd)
+	}
+	cmdType := GetCommandType(cmd.Command)
+	if cmdType == CmdUnknown {
+		if _, ok := GetWeaponID(cmd.Command); ok {
+			cmdType = CmdBuy // Direct weapon command (!sword)
+		}
+	}
+	return cmdType.String(), GetCommandPriority(cmdType)
+}
+
+// Enqueue adds a command to the sender's queue (non-blocking).
+// Returns false if it was dropped: the sender has too many pending commands,
+// or the queue is full of commands at the same or higher priority.
 func (q *CommandQueue) Enqueue(cmd ChatCommand) bool {
-	// Set receive time for latency tracking
-	cmd.ReceivedAt = time.Now()
+	// Set receive time for latency tracking (keep the webhook arrival time if known)
+	if cmd.ReceivedAt.IsZero() {
+		cmd.ReceivedAt = time.Now()
+	}
+	kind, priority := q.classify(cmd)
+	qc := queuedCommand{cmd: cmd, kind: kind, priority: priority}
 
+	q.mu.Lock()
+	uq := q.users[cmd.Username]
+	switch {
+	case uq != nil && uq.total >= q.perUserLimit:
+		q.recordDropLocked(kind)
+		q.mu.Unlock()
+		q.logDrop(cmd, "user limit")
+		return false
+	case q.pending >= q.bufferSize && !q.evictLocked(priority):
+		q.recordDropLocked(kind)
+		q.mu.Unlock()
+		q.logDrop(cmd, "queue full")
+		return false
+	}
+
+	if uq == nil {
+		uq = &userQueue{}
+		q.users[cmd.Username] = uq
+	}
+	if len(uq.pending[priority]) == 0 {
+		q.rotation[priority] = append(q.rotation[priority], cmd.Username)
+	}
+	uq.pending[priority] = append(uq.pending[priority], qc)
+	uq.total++
+	q.pending++
+	q.mu.Unlock()
+
+	q.enqueued.Add(1)
 	select {
-	case q.commands <- cmd:
-		q.enqueued.Add(1)
+	case q.ready <- struct{}{}:
+	default: // Workers are already draining
+	}
+	return true
+}
+
+// evictLocked makes room for a command by dropping the newest pending command of a
+// lower priority from the viewer with the most of them. Returns false if nothing is lower.
+func (q *CommandQueue) evictLocked(priority CommandPriority) bool {
+	for p := numPriorities - 1; p > priority; p-- {
+		if len(q.rotation[p]) == 0 {
+			continue
+		}
+
+		victim := q.rotation[p][0]
+		for _, username := range q.rotation[p][1:] {
+			if len(q.users[username].pending[p]) > len(q.users[victim].pending[p]) {
+				victim = username
+			}
+		}
+
+		uq := q.users[victim]
+		last := len(uq.pending[p]) - 1
+		q.recordDropLocked(uq.pending[p][last].kind)
+		q.evicted.Add(1)
+		uq.pending[p] = uq.pending[p][:last]
+		q.removeLocked(victim, uq, p)
 		return true
-	default:
-		// Queue full - drop command to prevent backpressure
-		q.dropped.Add(1)
-		if q.dropped.Load()%100 == 1 {
-			log.Printf("⚠️ CommandQueue full, dropped command from %s (total dropped: %d)",
-				cmd.Username, q.dropped.Load())
+	}
+	return false
+}
+
+// removeLocked updates bookkeeping after a command left a user's priority queue
+func (q *CommandQueue) removeLocked(username string, uq *userQueue, p CommandPriority) {
+	uq.total--
+	q.pending--
+	if len(uq.pending[p]) == 0 {
+		for i, name := range q.rotation[p] {
+			if name == username {
+				q.rotation[p] = append(q.rotation[p][:i], q.rotation[p][i+1:]...)
+				break
+			}
 		}
-		return false
+	}
+	if uq.total == 0 {
+		delete(q.users, username)
+	}
+}
+
+// Next removes the next command to process: highest priority first,
+// round-robin across viewers within a priority
+func (q *CommandQueue) Next() (ChatCommand, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for p := PriorityJoin; p < numPriorities; p++ {
+		if len(q.rotation[p]) == 0 {
+			continue
+		}
+
+		username := q.rotation[p][0]
+		uq := q.users[username]
+		qc := uq.pending[p][0]
+		uq.pending[p] = uq.pending[p][1:]
+
+		// Rotate the viewer to the back if they still have commands at this priority
+		q.rotation[p] = q.rotation[p][1:]
+		if len(uq.pending[p]) > 0 {
+			q.rotation[p] = append(q.rotation[p], username)
+		}
+		q.removeLocked(username, uq, p)
+		return qc.cmd, true
+	}
+	return ChatCommand{}, false
+}
+
+// recordDropLocked counts a dropped command by type
+func (q *CommandQueue) recordDropLocked(kind string) {
+	q.dropped.Add(1)
+	q.dropsByType[kind]++
+}
+
+// logDrop logs every 100th drop to avoid log floods
+func (q *CommandQueue) logDrop(cmd ChatCommand, reason string) {
+	if dropped := q.dropped.Load(); dropped%100 == 1 {
+		log.Printf("⚠️ CommandQueue %s, dropped !%s from %s (total dropped: %d)",
+			reason, cmd.Command, cmd.Username, dropped)
 	}
 }
 
@@ -112,9 +304,19 @@ func (q *CommandQueue) worker(id int) {
 		select {
 		case <-q.stopChan:
 			return
-		case cmd, ok := <-q.commands:
-			if !ok {
+		case <-q.ready:
+		}
+
+		for {
+			select {
+			case <-q.stopChan:
 				return
+			default:
+			}
+
+			cmd, ok := q.Next()
+			if !ok {
+				break
 			}
 
 			// Track wait time
@@ -144,24 +346,50 @@ func (q *CommandQueue) updateAvgWaitTime(waitTime time.Duration) {
 
 // Stats returns current queue statistics
 func (q *CommandQueue) Stats() QueueStats {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	pendingByPriority := make(map[string]uint64, numPriorities)
+	for p := PriorityJoin; p < numPriorities; p++ {
+		var n uint64
+		for _, username := range q.rotation[p] {
+			n += uint64(len(q.users[username].pending[p]))
+		}
+		pendingByPriority[p.String()] = n
+	}
+	dropsByType := make(map[string]uint64, len(q.dropsByType))
+	for kind, n := range q.dropsByType {
+		dropsByType[kind] = n
+	}
+
 	return QueueStats{
-		Enqueued:       q.enqueued.Load(),
-		Processed:      q.processed.Load(),
-		Dropped:        q.dropped.Load(),
-		Pending:        uint64(len(q.commands)),
-		BufferSize:     uint64(cap(q.commands)),
-		AvgWaitTimeMs:  float64(q.avgWaitTime.Load()) / 1e6,
-		BufferUsagePct: float64(len(q.commands)) / float64(cap(q.commands)) * 100,
+		Enqueued:          q.enqueued.Load(),
+		Processed:         q.processed.Load(),
+		Dropped:           q.dropped.Load(),
+		Evicted:           q.evicted.Load(),
+		Pending:           uint64(q.pending),
+		PendingByPriority: pendingByPriority,
+		Users:             len(q.users),
+		BufferSize:        uint64(q.bufferSize),
+		PerUserLimit:      q.perUserLimit,
+		AvgWaitTimeMs:     float64(q.avgWaitTime.Load()) / 1e6,
+		BufferUsagePct:    float64(q.pending) / float64(q.bufferSize) * 100,
+		DroppedByCommand:  dropsByType,
 	}
 }
 
 // QueueStats holds queue metrics
 type QueueStats struct {
-	Enqueued       uint64  `json:"enqueued"`
-	Processed      uint64  `json:"processed"`
-	Dropped        uint64  `json:"dropped"`
-	Pending        uint64  `json:"pending"`
-	BufferSize     uint64  `json:"buffer_size"`
-	AvgWaitTimeMs  float64 `json:"avg_wait_time_ms"`
-	BufferUsagePct float64 `json:"buffer_usage_pct"`
+	Enqueued          uint64            `json:"enqueued"`
+	Processed         uint64            `json:"processed"`
+	Dropped           uint64            `json:"dropped"`
+	Evicted           uint64            `json:"evicted"` // Dropped after queuing to make room for higher priority
+	Pending           uint64            `json:"pending"`
+	PendingByPriority map[string]uint64 `json:"pending_by_priority"`
+	Users             int               `json:"users"` // Viewers with pending commands
+	BufferSize        uint64            `json:"buffer_size"`
+	PerUserLimit      int               `json:"per_user_limit"`
+	AvgWaitTimeMs     float64           `json:"avg_wait_time_ms"`
+	BufferUsagePct    float64           `json:"buffer_usage_pct"`
+	DroppedByCommand  map[string]uint64 `json:"dropped_by_command"`
 }
@@ -1,21 +1,44 @@
 package chat
 
 import (
+	"math"
 	"sync"
 	"time"
+
+	"fight-club/internal/config"
 )
 
-// RateLimiter implements per-user command rate limiting
+// RateLimiter implements per-user command rate limiting, with optional
+// per-command limits and higher limits for subscribers and moderators
 type RateLimiter struct {
 	mu         sync.RWMutex
 	userCounts map[string]*userLimit
 	config     RateLimitConfig
+
+	// Limit-hit statistics (debug endpoint)
+	allowed          uint64
+	limitedUser      uint64
+	limitedByCommand map[string]uint64
+	limitedByTier    map[Tier]uint64
 }
 
 type userLimit struct {
 	count     int
 	windowEnd time.Time
 	lastCmd   time.Time
+	commands  map[string]*commandWindow // Per-command windows (commands with their own limit)
+}
+
+// commandWindow counts one user's uses of one command
+type commandWindow struct {
+	count     int
+	windowEnd time.Time
+}
+
+// CommandLimit allows Max uses of one command per Window
+type CommandLimit struct {
+	Max    int
+	Window time.Duration
 }
 
 // RateLimitConfig configures rate limiting behavior
@@ -26,20 +49,80 @@ type RateLimitConfig struct {
 	WindowDuration time.Duration
 	// CooldownDuration is minimum time between commands
 	CooldownDuration time.Duration
+	// Commands limits single commands by canonical name (e.g. "join": 1 per 30s),
+	// on top of the per-user limit
+	Commands map[string]CommandLimit
+	// SubscriberMultiplier and ModeratorMultiplier scale every limit for those
+	// viewers (2 = twice the commands, half the cooldown); 0 means 1
+	SubscriberMultiplier float64
+	ModeratorMultiplier  float64
 }
 
 // DefaultRateLimitConfig for chat commands
-var DefaultRateLimitConfig = RateLimitConfig{
-	MaxPerWindow:     5,                      // 5 commands
-	WindowDuration:   5 * time.Second,        // per 5 seconds
-	CooldownDuration: 500 * time.Millisecond, // 500ms between commands
+var DefaultRateLimitConfig = RateLimitConfigFrom(config.DefaultChatRateLimit())
+
+// RateLimitConfigFrom converts the configured limits (seconds) to a RateLimitConfig
+func RateLimitConfigFrom(cfg config.ChatRateLimitConfig) RateLimitConfig {
+	seconds := func(s float64) time.Duration { return time.Duration(s * float64(time.Second)) }
+	rl := RateLimitConfig{
+		MaxPerWindow:         cfg.MaxPerWindow,
+		WindowDuration:       seconds(cfg.Window),
+		CooldownDuration:     seconds(cfg.Cooldown),
+		Commands:             make(map[string]CommandLimit, len(cfg.Commands)),
+		SubscriberMultiplier: cfg.SubscriberMultiplier,
+		ModeratorMultiplier:  cfg.ModeratorMultiplier,
+	}
+	for name, l := range cfg.Commands {
+		rl.Commands[name] = CommandLimit{Max: l.Max, Window: seconds(l.Window)}
+	}
+	return rl
+}
+
+// Tier is a viewer's rate limit tier
+type Tier int
+
+const (
+	TierViewer Tier = iota
+	TierSubscriber
+	TierModerator
+	TierBroadcaster // Never limited
+)
+
+// String returns the tier name used in stats
+func (t Tier) String() string {
+	switch t {
+	case TierSubscriber:
+		return "subscriber"
+	case TierModerator:
+		return "moderator"
+	case TierBroadcaster:
+		return "broadcaster"
+	default:
+		return "viewer"
+	}
+}
+
+// TierOf returns the highest tier of the command's sender
+func TierOf(cmd ChatCommand) Tier {
+	switch {
+	case cmd.IsBroadcaster:
+		return TierBroadcaster
+	case cmd.IsModerator:
+		return TierModerator
+	case cmd.IsSubscriber:
+		return TierSubscriber
+	default:
+		return TierViewer
+	}
 }
 
 // NewRateLimiter creates a new rate limiter
 func NewRateLimiter(cfg RateLimitConfig) *RateLimiter {
 	rl := &RateLimiter{
-		userCounts: make(map[string]*userLimit),
-		config:     cfg,
+		userCounts:       make(map[string]*userLimit),
+		config:           cfg,
+		limitedByCommand: make(map[string]uint64),
+		limitedByTier:    make(map[Tier]uint64),
 	}
 
 	// Start cleanup goroutine
@@ -48,45 +131,147 @@ func NewRateLimiter(cfg RateLimitConfig) *RateLimiter {
 	return rl
 }
 
-// Allow checks if a user can execute a command
+// SetConfig replaces the limits (usage counted so far is kept)
+func (rl *RateLimiter) SetConfig(cfg RateLimitConfig) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	rl.config = cfg
+}
+
+// Allow checks if a user can execute a command (per-user limit only)
 func (rl *RateLimiter) Allow(username string) bool {
+	ok, _ := rl.AllowCommand(username, "", TierViewer)
+	return ok
+}
+
+// AllowCommand checks if a user of the given tier can execute command now.
+// When the command's own limit was hit, that limit (scaled for the tier) is
+// returned; a zero CommandLimit means the per-user limit was hit.
+func (rl *RateLimiter) AllowCommand(username, command string, tier Tier) (bool, CommandLimit) {
 	rl.mu.Lock()
 	defer rl.mu.Unlock()
 
+	if tier == TierBroadcaster {
+		rl.allowed++
+		return true, CommandLimit{}
+	}
+
 	now := time.Now()
-	key := username
+	mult := rl.multiplier(tier)
 
-	limit, exists := rl.userCounts[key]
+	limit, exists := rl.userCounts[username]
 	if !exists {
-		rl.userCounts[key] = &userLimit{
-			count:     1,
-			windowEnd: now.Add(rl.config.WindowDuration),
-			lastCmd:   now,
-		}
-		return true
+		limit = &userLimit{}
+		rl.userCounts[username] = limit
 	}
 
 	// Check cooldown
-	if now.Sub(limit.lastCmd) < rl.config.CooldownDuration {
-		return false
+	if exists && now.Sub(limit.lastCmd) < time.Duration(float64(rl.config.CooldownDuration)/mult) {
+		rl.limited(tier, "")
+		return false, CommandLimit{}
 	}
 
-	// Check/reset window
+	// Check/reset window, then count
 	if now.After(limit.windowEnd) {
-		limit.count = 1
+		limit.count = 0
 		limit.windowEnd = now.Add(rl.config.WindowDuration)
-		limit.lastCmd = now
-		return true
+	}
+	if limit.count >= scaleMax(rl.config.MaxPerWindow, mult) {
+		rl.limited(tier, "")
+		return false, CommandLimit{}
 	}
 
-	// Check count
-	if limit.count >= rl.config.MaxPerWindow {
-		return false
+	// Command's own limit
+	var window *commandWindow
+	if cl, ok := rl.config.Commands[command]; ok && cl.Max > 0 {
+		if limit.commands == nil {
+			limit.commands = make(map[string]*commandWindow)
+		}
+		window = limit.commands[command]
+		if window == nil || now.After(window.windowEnd) {
+			window = &commandWindow{windowEnd: now.Add(cl.Window)}
+			limit.commands[command] = window
+		}
+		if scaled := scaleMax(cl.Max, mult); window.count >= scaled {
+			rl.limited(tier, command)
+			return false, CommandLimit{Max: scaled, Window: cl.Window}
+		}
 	}
 
 	limit.count++
 	limit.lastCmd = now
-	return true
+	if window != nil {
+		window.count++
+	}
+	rl.allowed++
+	return true, CommandLimit{}
+}
+
+// multiplier returns the limit multiplier for a tier. Caller holds rl.mu.
+func (rl *RateLimiter) multiplier(tier Tier) float64 {
+	m := 1.0
+	switch tier {
+	case TierSubscriber:
+		m = rl.config.SubscriberMultiplier
+	case TierModerator:
+		m = rl.config.ModeratorMultiplier
+	}
+	if m < 1 {
+		return 1
+	}
+	return m
+}
+
+// scaleMax multiplies a count limit, rounding down but never below the base limit
+func scaleMax(max int, mult float64) int {
+	return int(math.Max(float64(max), math.Floor(float64(max)*mult)))
+}
+
+// limited records a limit hit (command "" = the per-user limit). Caller holds rl.mu.
+func (rl *RateLimiter) limited(tier Tier, command string) {
+	rl.limitedByTier[tier]++
+	if command == "" {
+		rl.limitedUser++
+	} else {
+		rl.limitedByCommand[command]++
+	}
+}
+
+// RateLimitStats is the limiter's activity since start (debug endpoint)
+type RateLimitStats struct {
+	Allowed          uint64            `json:"allowed"`
+	Limited          uint64            `json:"limited"`
+	LimitedUser      uint64            `json:"limited_user"`       // Per-user limit or cooldown hits
+	LimitedByCommand map[string]uint64 `json:"limited_by_command"` // Command limit hits
+	LimitedByTier    map[string]uint64 `json:"limited_by_tier"`    // All hits by viewer tier
+	Users            int               `json:"users"`              // Viewers currently tracked
+	CommandLimits    map[string]string `json:"command_limits"`     // Configured viewer limits ("1/30s")
+}
+
+// Stats returns limit-hit statistics
+func (rl *RateLimiter) Stats() RateLimitStats {
+	rl.mu.RLock()
+	defer rl.mu.RUnlock()
+
+	stats := RateLimitStats{
+		Allowed:          rl.allowed,
+		LimitedUser:      rl.limitedUser,
+		LimitedByCommand: make(map[string]uint64, len(rl.limitedByCommand)),
+		LimitedByTier:    make(map[string]uint64, len(rl.limitedByTier)),
+		Users:            len(rl.userCounts),
+		CommandLimits:    make(map[string]string, len(rl.config.Commands)),
+	}
+	for command, n := range rl.limitedByCommand {
+		stats.LimitedByCommand[command] = n
+	}
+	for tier, n := range rl.limitedByTier {
+		stats.LimitedByTier[tier.String()] = n
+		stats.Limited += n
+	}
+	for name, l := range rl.config.Commands {
+		stats.CommandLimits[name] = config.CommandRateLimit{Max: l.Max, Window: l.Window.Seconds()}.String()
+	}
+	return stats
 }
 
 // cleanup removes old entries every minute
@@ -97,7 +282,15 @@ func (rl *RateLimiter) cleanup() {
 	for range ticker.C {
 		rl.mu.Lock()
 		now := time.Now()
-		cutoff := now.Add(-5 * time.Minute)
+
+		// Keep users until their longest command window has run out
+		keep := 5 * time.Minute
+		for _, l := range rl.config.Commands {
+			if l.Window > keep {
+				keep = l.Window
+			}
+		}
+		cutoff := now.Add(-keep)
 
 		for key, limit := range rl.userCounts {
 			if limit.lastCmd.Before(cutoff) {
@@ -1,6 +1,10 @@
 package chat
 
-import "time"
+import (
+	"time"
+
+	"fight-club/internal/game"
+)
 
 // ChatMessage represents a raw message from Kick chat
 type ChatMessage struct {
@@ -21,6 +25,14 @@ type ChatCommand struct {
 	UserID     int64
 	ProfilePic string
 	ReceivedAt time.Time
+
+	// IsBroadcaster is set when the channel owner sent the command
+	// (required for !pause / !resume)
+	IsBroadcaster bool
+	// IsModerator / IsSubscriber come from the sender's chat badges
+	// (higher command rate limits)
+	IsModerator  bool
+	IsSubscriber bool
 }
 
 // CommandType for routing
@@ -33,49 +45,117 @@ const (
 	CmdStats
 	CmdShop
 	CmdHelp
-	CmdFocus // !focus <username>
-	CmdTeam  // !team <subcommand>
+	CmdFocus  // !focus <username>
+	CmdTeam   // !team <subcommand>
+	CmdEmote  // !emote <name>
+	CmdTaunt  // !taunt
+	CmdStyle  // !style <profile>
+	CmdVote   // !vote <1|2|3>
+	CmdCheer  // !cheer <username> (dead spectators)
+	CmdCurse  // !curse <username> (dead spectators)
+	CmdPause  // !pause (broadcaster only)
+	CmdResume // !resume (broadcaster only)
+	CmdDuel   // !duel <username>
+	CmdAccept // !accept (open duel challenge)
+	CmdQuests // !quests (daily quest progress)
+	CmdSkin   // !skin <name> (cosmetics shop)
+	CmdGoLive // !golive (broadcaster only, auto-stream)
+	CmdOffAir // !offline (broadcaster only, auto-stream)
+	CmdGift   // !gift <username> <amount>
+	CmdKick   // !kick <username> (moderators)
+	CmdFreeze // !freeze <username> (moderators)
+	CmdStrip  // !strip <username> (moderators)
+	CmdBan    // !ban <username> [duration] [reason] (broadcaster only)
+	CmdUnban  // !unban <username> (broadcaster only)
+	CmdRecord // !record (lifetime K/D and best streak)
+	CmdLast   // !last (summary of the previous life)
+	CmdStrike // !airstrike <x,y|username> (wallet-paid)
 	CmdUnknown
 )
 
-// SupportedCommands maps command strings to types
+// commandTypeNames are the canonical command names (metrics labels)
+var commandTypeNames = map[CommandType]string{
+	CmdJoin:   "join",
+	CmdHeal:   "heal",
+	CmdBuy:    "buy",
+	CmdStats:  "stats",
+	CmdShop:   "shop",
+	CmdHelp:   "help",
+	CmdFocus:  "focus",
+	CmdTeam:   "team",
+	CmdEmote:  "emote",
+	CmdTaunt:  "taunt",
+	CmdStyle:  "style",
+	CmdVote:   "vote",
+	CmdCheer:  "cheer",
+	CmdCurse:  "curse",
+	CmdPause:  "pause",
+	CmdResume: "resume",
+	CmdDuel:   "duel",
+	CmdAccept: "accept",
+	CmdQuests: "quests",
+	CmdSkin:   "skin",
+	CmdGoLive: "golive",
+	CmdOffAir: "offline",
+	CmdGift:   "gift",
+	CmdKick:   "kick",
+	CmdFreeze: "freeze",
+	CmdStrip:  "strip",
+	CmdBan:    "ban",
+	CmdUnban:  "unban",
+	CmdRecord: "record",
+	CmdLast:   "last",
+	CmdStrike: "airstrike",
+}
+
+// String returns the canonical command name ("unknown" for unsupported commands)
+func (t CommandType) String() string {
+	if name, ok := commandTypeNames[t]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// SupportedCommands maps the English command strings to types. They always
+// work; other languages come from CommandLocales (see locale.go).
 var SupportedCommands = map[string]CommandType{
-	// Join variants
-	"join":   CmdJoin,
-	"unirse": CmdJoin,
-	"entrar": CmdJoin,
-
-	// Heal variants
-	"heal":  CmdHeal,
-	"vida":  CmdHeal,
-	"curar": CmdHeal,
-
-	// Buy variants
-	"buy":     CmdBuy,
-	"comprar": CmdBuy,
-
-	// Stats variants
-	"stats": CmdStats,
-	"score": CmdStats,
-
-	// Shop variants
-	"shop":   CmdShop,
-	"tienda": CmdShop,
-
-	// Help variants
-	"help":     CmdHelp,
-	"ayuda":    CmdHelp,
-	"commands": CmdHelp,
-	"comandos": CmdHelp,
-
-	// Focus variants
-	"focus":    CmdFocus,
-	"enfocar":  CmdFocus,
-	"objetivo": CmdFocus,
-
-	// Team variants
-	"team":   CmdTeam,
-	"equipo": CmdTeam,
+	"join":      CmdJoin,
+	"heal":      CmdHeal,
+	"buy":       CmdBuy,
+	"stats":     CmdStats,
+	"score":     CmdStats,
+	"shop":      CmdShop,
+	"help":      CmdHelp,
+	"commands":  CmdHelp,
+	"focus":     CmdFocus,
+	"team":      CmdTeam,
+	"emote":     CmdEmote,
+	"taunt":     CmdTaunt,
+	"style":     CmdStyle,
+	"vote":      CmdVote,
+	"cheer":     CmdCheer, // Spectators (dead players only)
+	"curse":     CmdCurse,
+	"pause":     CmdPause, // Broadcaster only
+	"resume":    CmdResume,
+	"duel":      CmdDuel,
+	"accept":    CmdAccept,
+	"quests":    CmdQuests,
+	"quest":     CmdQuests,
+	"skin":      CmdSkin,
+	"skins":     CmdSkin,
+	"gift":      CmdGift,
+	"kick":      CmdKick, // Moderators and broadcaster only
+	"freeze":    CmdFreeze,
+	"strip":     CmdStrip,
+	"ban":       CmdBan, // Broadcaster only
+	"unban":     CmdUnban,
+	"record":    CmdRecord,
+	"career":    CmdRecord,
+	"last":      CmdLast,
+	"airstrike": CmdStrike,
+	"golive":    CmdGoLive, // Broadcaster only (auto-stream)
+	"offline":   CmdOffAir,
+	"endstream": CmdOffAir,
 }
 
 // WeaponAliases maps weapon names to canonical IDs
@@ -92,20 +172,107 @@ var WeaponAliases = map[string]string{
 	"guadana":  "scythe",
 	"hammer":   "hammer",
 	"martillo": "hammer",
+
+	// Portuguese (espada and arco are shared with Spanish)
+	"lanca":   "spear",
+	"machado": "axe",
+	"foice":   "scythe",
+	"martelo": "hammer",
+}
+
+// EmoteAliases maps emote names to canonical emote IDs
+var EmoteAliases = map[string]string{
+	"jump":    "jump",
+	"saltar":  "jump",
+	"spin":    "spin",
+	"girar":   "spin",
+	"flex":    "flex",
+	"musculo": "flex",
+	"laugh":   "laugh",
+	"reir":    "laugh",
+	"dance":   "dance",
+	"bailar":  "dance",
+	"taunt":   "taunt",
+	"burla":   "taunt",
+
+	// Portuguese
+	"pular":  "jump",
+	"rir":    "laugh",
+	"dancar": "dance",
+	"zoar":   "taunt",
 }
 
-// GetCommandType returns the command type for a string (case-insensitive)
+// PersonalityAliases maps style names to canonical AI personality IDs
+var PersonalityAliases = map[string]string{
+	"berserker":     "berserker",
+	"berserk":       "berserker",
+	"furia":         "berserker",
+	"coward":        "coward",
+	"cobarde":       "coward",
+	"sniper":        "sniper",
+	"francotirador": "sniper",
+	"defender":      "defender",
+	"defensor":      "defender",
+
+	// Portuguese (defensor is shared with Spanish)
+	"covarde":  "coward",
+	"atirador": "sniper",
+}
+
+// SkinAliases maps skin names to canonical skin IDs
+var SkinAliases = map[string]string{
+	"gold":     "gold",
+	"oro":      "gold",
+	"frost":    "frost",
+	"hielo":    "frost",
+	"flame":    "flame",
+	"fire":     "flame",
+	"fuego":    "flame",
+	"crimson":  "crimson",
+	"carmesi":  "crimson",
+	"toxic":    "toxic",
+	"toxico":   "toxic",
+	"golden":   "golden",
+	"dorado":   "golden",
+	"rainbow":  "rainbow",
+	"arcoiris": "rainbow",
+
+	// Portuguese (toxico and arcoiris are shared with Spanish)
+	"ouro":    "gold",
+	"gelo":    "frost",
+	"fogo":    "flame",
+	"dourado": "golden",
+}
+
+// GetCommandType returns the command type for a lowercase command string in
+// English or an enabled locale ("unirse", "missões" and "missoes" alike)
 func GetCommandType(cmd string) CommandType {
-	if t, ok := SupportedCommands[cmd]; ok {
+	if t, ok := lookupFolded(*activeCommands.Load(), cmd); ok {
 		return t
 	}
 	return CmdUnknown
 }
 
-// GetWeaponID normalizes weapon name to canonical ID
+// GetEmoteID normalizes emote name to canonical ID
+func GetEmoteID(name string) (string, bool) {
+	return lookupFolded(EmoteAliases, name)
+}
+
+// GetPersonalityID normalizes style name to canonical personality ID
+func GetPersonalityID(name string) (string, bool) {
+	return lookupFolded(PersonalityAliases, name)
+}
+
+// GetSkinID normalizes skin name to canonical skin ID
+func GetSkinID(name string) (string, bool) {
+	return lookupFolded(SkinAliases, name)
+}
+
+// GetWeaponID normalizes weapon name to canonical ID, including weapons and
+// aliases from the weapons file
 func GetWeaponID(name string) (string, bool) {
-	if id, ok := WeaponAliases[name]; ok {
+	if id, ok := lookupFolded(WeaponAliases, name); ok {
 		return id, true
 	}
-	return "", false
+	return lookupFolded(game.WeaponAliases(), name)
 }
@@ -6,8 +6,11 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 )
 
 // =============================================================================
@@ -19,7 +22,7 @@ import (
 type VideoConfig struct {
 	Width   int // Canvas/stream width in pixels
 	Height  int // Canvas/stream height in pixels
-	FPS     int // Frames per second (also used for game tick rate)
+	FPS     int // Stream frames per second (the game ticks at SimulationConfig.TickRate)
 	Bitrate int // Stream bitrate in kbps
 }
 
@@ -55,6 +58,42 @@ func VideoFromEnv() VideoConfig {
 	return cfg
 }
 
+// =============================================================================
+// SIMULATION CONFIGURATION
+// =============================================================================
+
+// SimulationConfig holds the game loop timing, independent of the stream FPS.
+// The engine advances in fixed steps of 1/TickRate seconds; the streamer
+// interpolates between snapshots when it renders more frames than that.
+type SimulationConfig struct {
+	TickRate        int    // Game ticks per second
+	MaxCatchUpTicks int    // Ticks run back-to-back after a stall before the rest are dropped
+	ArenaPreset     string // small | standard | large: overrides the world size and tick rate ("" = off)
+}
+
+// DefaultSimulation returns the default simulation configuration.
+func DefaultSimulation() SimulationConfig {
+	return SimulationConfig{
+		TickRate:        30,
+		MaxCatchUpTicks: 5,
+	}
+}
+
+// SimulationFromEnv returns simulation configuration with environment variable overrides.
+func SimulationFromEnv() SimulationConfig {
+	cfg := DefaultSimulation()
+
+	if t := getEnvInt("GAME_TICK_RATE", 0); t > 0 {
+		cfg.TickRate = t
+	}
+	if m := getEnvInt("GAME_MAX_CATCHUP_TICKS", 0); m > 0 {
+		cfg.MaxCatchUpTicks = m
+	}
+	cfg.ArenaPreset = os.Getenv("ARENA_PRESET")
+
+	return cfg
+}
+
 // =============================================================================
 // GAME RESOURCE LIMITS
 // =============================================================================
@@ -86,6 +125,31 @@ func DefaultLimits() ResourceLimits {
 	}
 }
 
+// LimitsFromEnv returns resource limits with environment variable overrides.
+func LimitsFromEnv() ResourceLimits {
+	cfg := DefaultLimits()
+
+	for _, limit := range []struct {
+		env   string
+		value *int
+	}{
+		{"LIMIT_MAX_TOTAL_PLAYERS", &cfg.MaxTotalPlayers},
+		{"LIMIT_MAX_PLAYERS", &cfg.MaxPlayers},
+		{"LIMIT_MAX_PARTICLES", &cfg.MaxParticles},
+		{"LIMIT_MAX_EFFECTS", &cfg.MaxEffects},
+		{"LIMIT_MAX_TEXTS", &cfg.MaxTexts},
+		{"LIMIT_MAX_TRAILS", &cfg.MaxTrails},
+		{"LIMIT_MAX_FLASHES", &cfg.MaxFlashes},
+		{"LIMIT_MAX_PROJECTILES", &cfg.MaxProjectiles},
+	} {
+		if v := getEnvInt(limit.env, 0); v > 0 {
+			*limit.value = v
+		}
+	}
+
+	return cfg
+}
+
 // =============================================================================
 // AUDIO CONFIGURATION
 // =============================================================================
@@ -122,6 +186,41 @@ func AudioFromEnv() AudioConfig {
 	return cfg
 }
 
+// SoundConfig selects the stream's sound effects. The sounds directory is the
+// "default" pack; named packs (retro, cinematic, meme, ...) live in
+// <dir>/packs/<name> and override any of its sounds. Sounds are decoded on
+// first use and kept in memory up to CacheMB.
+type SoundConfig struct {
+	Dir     string // Sounds directory ("" = assets/sounds, or ../assets/sounds)
+	Pack    string // Pack at startup (switchable at /api/admin/soundpack)
+	CacheMB int    // Decoded sounds kept in memory, least recently played dropped first
+}
+
+// DefaultSounds returns the default sound configuration.
+func DefaultSounds() SoundConfig {
+	return SoundConfig{
+		Pack:    "default",
+		CacheMB: 32,
+	}
+}
+
+// SoundsFromEnv returns sound configuration with environment variable overrides.
+func SoundsFromEnv() SoundConfig {
+	cfg := DefaultSounds()
+
+	if d := os.Getenv("SOUNDS_DIR"); d != "" {
+		cfg.Dir = d
+	}
+	if p := os.Getenv("SOUND_PACK"); p != "" {
+		cfg.Pack = p
+	}
+	if mb := getEnvInt("SOUND_CACHE_MB", 0); mb > 0 {
+		cfg.CacheMB = mb
+	}
+
+	return cfg
+}
+
 // =============================================================================
 // SERVER CONFIGURATION
 // =============================================================================
@@ -172,27 +271,1743 @@ func DefaultSpatial() SpatialConfig {
 	}
 }
 
+// =============================================================================
+// ECONOMY CONFIGURATION
+// =============================================================================
+
+// EconomyConfig holds passive viewer income settings.
+type EconomyConfig struct {
+	PassiveEarnAmount   int     // Money credited to each present viewer per payout
+	PassiveEarnInterval float64 // Seconds between payouts
+	PresenceTimeout     float64 // Seconds since last chat activity to still count as watching
+	WalletFile          string  // JSON file wallets are persisted to
+}
+
+// DefaultEconomy returns the default economy configuration.
+func DefaultEconomy() EconomyConfig {
+	return EconomyConfig{
+		PassiveEarnAmount:   5,
+		PassiveEarnInterval: 60,  // 1 payout per minute
+		PresenceTimeout:     900, // 15 minutes without chatting = not watching
+		WalletFile:          ".wallets-go.json",
+	}
+}
+
+// EconomyFromEnv returns economy configuration with environment variable overrides.
+func EconomyFromEnv() EconomyConfig {
+	cfg := DefaultEconomy()
+
+	if a := getEnvInt("PASSIVE_EARN_AMOUNT", -1); a >= 0 {
+		cfg.PassiveEarnAmount = a
+	}
+	if i := getEnvFloat("PASSIVE_EARN_INTERVAL", 0); i > 0 {
+		cfg.PassiveEarnInterval = i
+	}
+	if t := getEnvFloat("PRESENCE_TIMEOUT", 0); t > 0 {
+		cfg.PresenceTimeout = t
+	}
+	if f := os.Getenv("WALLET_FILE"); f != "" {
+		cfg.WalletFile = f
+	}
+
+	return cfg
+}
+
+// =============================================================================
+// ARENA VOTING CONFIGURATION
+// =============================================================================
+
+// VotingConfig holds crowd-controlled arena modifier vote settings.
+type VotingConfig struct {
+	Interval float64 // Seconds between votes (also how long the winning modifier lasts); 0 disables
+	Duration float64 // Seconds a vote stays open
+	KickPoll bool    // Run votes as Kick channel polls (falls back to !vote while unavailable)
+}
+
+// DefaultVoting returns the default voting configuration.
+func DefaultVoting() VotingConfig {
+	return VotingConfig{
+		Interval: 300, // New vote every 5 minutes
+		Duration: 30,
+	}
+}
+
+// VotingFromEnv returns voting configuration with environment variable overrides.
+func VotingFromEnv() VotingConfig {
+	cfg := DefaultVoting()
+
+	if i := getEnvFloat("ARENA_VOTE_INTERVAL", -1); i >= 0 {
+		cfg.Interval = i
+	}
+	if d := getEnvFloat("ARENA_VOTE_DURATION", 0); d > 0 {
+		cfg.Duration = d
+	}
+	if v := os.Getenv("ARENA_VOTE_KICK_POLL"); v != "" {
+		cfg.KickPoll = v == "true"
+	}
+
+	return cfg
+}
+
+// =============================================================================
+// DUEL CONFIGURATION
+// =============================================================================
+
+// DuelConfig holds chat-initiated 1v1 duel (!duel / !accept) settings.
+type DuelConfig struct {
+	Duration         float64 // Seconds a duel lasts before it ends in a draw
+	ChallengeTimeout float64 // Seconds a challenge stays open for !accept
+	Bonus            int     // Wallet payout to the winner
+	RingRadius       float64 // Radius of the duel ring at the arena center (px)
+}
+
+// DefaultDuel returns the default duel configuration.
+func DefaultDuel() DuelConfig {
+	return DuelConfig{
+		Duration:         60,
+		ChallengeTimeout: 30,
+		Bonus:            250,
+		RingRadius:       160,
+	}
+}
+
+// DuelFromEnv returns duel configuration with environment variable overrides.
+func DuelFromEnv() DuelConfig {
+	cfg := DefaultDuel()
+
+	if d := getEnvFloat("DUEL_DURATION", 0); d > 0 {
+		cfg.Duration = d
+	}
+	if t := getEnvFloat("DUEL_CHALLENGE_TIMEOUT", 0); t > 0 {
+		cfg.ChallengeTimeout = t
+	}
+	if b := getEnvInt("DUEL_BONUS", -1); b >= 0 {
+		cfg.Bonus = b
+	}
+	if r := getEnvFloat("DUEL_RING_RADIUS", 0); r > 0 {
+		cfg.RingRadius = r
+	}
+
+	return cfg
+}
+
+// =============================================================================
+// CHAOS EVENTS CONFIGURATION
+// =============================================================================
+
+// ChaosConfig holds scheduled world event (meteor shower, shrinking zone, gravity flip) settings.
+type ChaosConfig struct {
+	Interval float64 // Seconds between chaos events (0 disables)
+	Warning  float64 // Seconds the upcoming event is announced on screen before it starts
+	Duration float64 // Seconds each event lasts
+}
+
+// DefaultChaos returns the default chaos events configuration.
+func DefaultChaos() ChaosConfig {
+	return ChaosConfig{
+		Interval: 600, // Every 10 minutes
+		Warning:  10,
+		Duration: 30,
+	}
+}
+
+// ChaosFromEnv returns chaos events configuration with environment variable overrides.
+func ChaosFromEnv() ChaosConfig {
+	cfg := DefaultChaos()
+
+	if i := getEnvFloat("CHAOS_INTERVAL", -1); i >= 0 {
+		cfg.Interval = i
+	}
+	if w := getEnvFloat("CHAOS_WARNING", -1); w >= 0 {
+		cfg.Warning = w
+	}
+	if d := getEnvFloat("CHAOS_DURATION", 0); d > 0 {
+		cfg.Duration = d
+	}
+
+	return cfg
+}
+
+// =============================================================================
+// ARENA SCENE (TIME OF DAY AND WEATHER) CONFIGURATION
+// =============================================================================
+
+// SceneConfig holds the arena background theme scheduler settings.
+type SceneConfig struct {
+	PhaseDuration float64 // Seconds of each time of day (day -> dusk -> night; 0 keeps the current one)
+	WeatherChance float64 // Chance a new time of day brings rain or snow (0-1)
+}
+
+// DefaultScene returns the default scene configuration.
+func DefaultScene() SceneConfig {
+	return SceneConfig{
+		PhaseDuration: 900, // A full day every 45 minutes
+		WeatherChance: 0.3,
+	}
+}
+
+// SceneFromEnv returns scene configuration with environment variable overrides.
+func SceneFromEnv() SceneConfig {
+	cfg := DefaultScene()
+
+	if d := getEnvFloat("SCENE_PHASE_DURATION", -1); d >= 0 {
+		cfg.PhaseDuration = d
+	}
+	if c := getEnvFloat("SCENE_WEATHER_CHANCE", -1); c >= 0 {
+		cfg.WeatherChance = min(c, 1)
+	}
+
+	return cfg
+}
+
+// =============================================================================
+// SHOP CONFIGURATION
+// =============================================================================
+
+// MaxShops is the most shops an arena can have
+const MaxShops = 6
+
+// ShopConfig holds settings for the weapon shops placed on the map. A !buy is
+// completed when the fighter walks into a shop that stocks the weapon.
+type ShopConfig struct {
+	Count        int     // Shops in the arena (the weapons are split between them); 0 = instant !buy
+	Radius       float64 // Shop circle radius (px)
+	OrderTimeout float64 // Seconds to reach the shop before the order is cancelled
+}
+
+// DefaultShop returns the default shop configuration.
+func DefaultShop() ShopConfig {
+	return ShopConfig{
+		Count:        2,
+		Radius:       60,
+		OrderTimeout: 30,
+	}
+}
+
+// ShopFromEnv returns shop configuration with environment variable overrides.
+func ShopFromEnv() ShopConfig {
+	cfg := DefaultShop()
+
+	if n := getEnvInt("SHOP_COUNT", -1); n >= 0 {
+		cfg.Count = n
+	}
+	if r := getEnvFloat("SHOP_RADIUS", 0); r > 0 {
+		cfg.Radius = r
+	}
+	if t := getEnvFloat("SHOP_ORDER_TIMEOUT", 0); t > 0 {
+		cfg.OrderTimeout = t
+	}
+
+	return cfg
+}
+
+// =============================================================================
+// RING-OUT CONFIGURATION
+// =============================================================================
+
+// RingOutConfig holds settings for ring-outs: a heavy weapon's knockback near
+// the arena edge throws the fighter into the pit around it, killing them.
+type RingOutConfig struct {
+	MinKnockback float64 // Weapon knockback force needed (axe 25, hammer 30, scythe 20)
+	Reach        float64 // Pixels a hit carries the fighter per knockback unit; 0 disables ring-outs
+}
+
+// DefaultRingOut returns the default ring-out configuration.
+func DefaultRingOut() RingOutConfig {
+	return RingOutConfig{
+		MinKnockback: 20,
+		Reach:        4,
+	}
+}
+
+// RingOutFromEnv returns ring-out configuration with environment variable overrides.
+func RingOutFromEnv() RingOutConfig {
+	cfg := DefaultRingOut()
+
+	if k := getEnvFloat("RING_OUT_MIN_KNOCKBACK", 0); k > 0 {
+		cfg.MinKnockback = k
+	}
+	if r := getEnvFloat("RING_OUT_REACH", -1); r >= 0 {
+		cfg.Reach = r
+	}
+
+	return cfg
+}
+
+// =============================================================================
+// REPLAY CONFIGURATION
+// =============================================================================
+
+// ReplayConfig holds settings for slow-motion kill replays: the stream plays
+// the last seconds of the arena back slowed down, then returns to live.
+type ReplayConfig struct {
+	Seconds  float64 // Arena time kept and replayed; 0 disables replays
+	Speed    float64 // Playback speed (0.3 = a 3s replay lasts 10s)
+	AutoDuel bool    // Replay the kill that wins a duel
+}
+
+// DefaultReplay returns the default replay configuration.
+func DefaultReplay() ReplayConfig {
+	return ReplayConfig{
+		Seconds:  3,
+		Speed:    0.3,
+		AutoDuel: true,
+	}
+}
+
+// ReplayFromEnv returns replay configuration with environment variable overrides.
+func ReplayFromEnv() ReplayConfig {
+	cfg := DefaultReplay()
+
+	if s := getEnvFloat("REPLAY_SECONDS", -1); s >= 0 {
+		cfg.Seconds = s
+	}
+	if s := getEnvFloat("REPLAY_SPEED", 0); s > 0 {
+		cfg.Speed = s
+	}
+	if v := os.Getenv("REPLAY_AUTO_DUEL"); v != "" {
+		cfg.AutoDuel = v != "false"
+	}
+
+	return cfg
+}
+
+// =============================================================================
+// AIRSTRIKE CONFIGURATION
+// =============================================================================
+
+// AirstrikeConfig holds the premium !airstrike settings (paid from the viewer wallet).
+type AirstrikeConfig struct {
+	Cost       int     // Price of an airstrike when none was called recently (0 disables)
+	CostGrowth float64 // Price multiplier per airstrike called in the last CostWindow seconds
+	CostWindow float64 // Seconds an airstrike keeps raising the price
+	Cooldown   float64 // Seconds between airstrikes per viewer
+	Delay      float64 // Seconds the danger zone is shown before the impact
+	Radius     float64 // Blast radius (px)
+	Damage     int     // Damage at the center (half at the edge)
+}
+
+// DefaultAirstrike returns the default airstrike configuration.
+func DefaultAirstrike() AirstrikeConfig {
+	return AirstrikeConfig{
+		Cost:       2000,
+		CostGrowth: 1.5, // 2000, 3000, 4500, ... while strikes keep coming
+		CostWindow: 600,
+		Cooldown:   120,
+		Delay:      3,
+		Radius:     140,
+		Damage:     60,
+	}
+}
+
+// AirstrikeFromEnv returns airstrike configuration with environment variable overrides.
+func AirstrikeFromEnv() AirstrikeConfig {
+	cfg := DefaultAirstrike()
+
+	if c := getEnvInt("AIRSTRIKE_COST", -1); c >= 0 {
+		cfg.Cost = c
+	}
+	if g := getEnvFloat("AIRSTRIKE_COST_GROWTH", 0); g >= 1 {
+		cfg.CostGrowth = g
+	}
+	if w := getEnvFloat("AIRSTRIKE_COST_WINDOW", -1); w >= 0 {
+		cfg.CostWindow = w
+	}
+	if c := getEnvFloat("AIRSTRIKE_COOLDOWN", -1); c >= 0 {
+		cfg.Cooldown = c
+	}
+	if d := getEnvFloat("AIRSTRIKE_DELAY", 0); d > 0 {
+		cfg.Delay = d
+	}
+	if r := getEnvFloat("AIRSTRIKE_RADIUS", 0); r > 0 {
+		cfg.Radius = r
+	}
+	if d := getEnvInt("AIRSTRIKE_DAMAGE", 0); d > 0 {
+		cfg.Damage = d
+	}
+
+	return cfg
+}
+
+// =============================================================================
+// TEAM OBJECTIVE CONFIGURATION
+// =============================================================================
+
+// ObjectiveConfig holds the team objective mode (bases and a central capture point) settings.
+type ObjectiveConfig struct {
+	ScoreLimit  int     // Points a team needs to win a round (0 disables the mode)
+	CaptureTime float64 // Seconds a team must hold the point uncontested to take it
+	PointRadius float64 // Radius of the capture point at the arena center (px)
+	BaseRadius  float64 // Radius of each team base at the left/right arena edge (px)
+	Bonus       int     // Wallet payout to each member of the winning team
+}
+
+// DefaultObjective returns the default team objective configuration.
+func DefaultObjective() ObjectiveConfig {
+	return ObjectiveConfig{
+		ScoreLimit:  0, // Opt-in
+		CaptureTime: 5,
+		PointRadius: 90,
+		BaseRadius:  110,
+		Bonus:       100,
+	}
+}
+
+// ObjectiveFromEnv returns team objective configuration with environment variable overrides.
+func ObjectiveFromEnv() ObjectiveConfig {
+	cfg := DefaultObjective()
+
+	if l := getEnvInt("OBJECTIVE_SCORE_LIMIT", -1); l >= 0 {
+		cfg.ScoreLimit = l
+	}
+	if t := getEnvFloat("OBJECTIVE_CAPTURE_TIME", 0); t > 0 {
+		cfg.CaptureTime = t
+	}
+	if r := getEnvFloat("OBJECTIVE_POINT_RADIUS", 0); r > 0 {
+		cfg.PointRadius = r
+	}
+	if r := getEnvFloat("OBJECTIVE_BASE_RADIUS", 0); r > 0 {
+		cfg.BaseRadius = r
+	}
+	if b := getEnvInt("OBJECTIVE_BONUS", -1); b >= 0 {
+		cfg.Bonus = b
+	}
+
+	return cfg
+}
+
+// =============================================================================
+// PERSISTENT LEADERBOARD CONFIGURATION
+// =============================================================================
+
+// LeaderboardConfig holds persistent (daily/weekly/all-time) leaderboard settings.
+type LeaderboardConfig struct {
+	File           string  // JSON file kills per (user, day) are persisted to
+	RotateInterval float64 // Seconds each view (today/week/all time) stays on stream; 0 hides the rotator
+}
+
+// DefaultLeaderboard returns the default leaderboard configuration.
+func DefaultLeaderboard() LeaderboardConfig {
+	return LeaderboardConfig{
+		File:           ".leaderboard-go.json",
+		RotateInterval: 30,
+	}
+}
+
+// LeaderboardFromEnv returns leaderboard configuration with environment variable overrides.
+func LeaderboardFromEnv() LeaderboardConfig {
+	cfg := DefaultLeaderboard()
+
+	if f := os.Getenv("LEADERBOARD_FILE"); f != "" {
+		cfg.File = f
+	}
+	if i := getEnvFloat("LEADERBOARD_ROTATE_INTERVAL", -1); i >= 0 {
+		cfg.RotateInterval = i
+	}
+
+	return cfg
+}
+
+// =============================================================================
+// MATCH HISTORY CONFIGURATION
+// =============================================================================
+
+// HistoryConfig holds the match history settings. The continuous arena is
+// split into rounds of RoundLength seconds; each finished round's summary is
+// persisted for /api/history and the post-stream recap (cmd/summary).
+type HistoryConfig struct {
+	File        string  // JSON file round summaries are persisted to
+	RoundLength float64 // Seconds per round
+	MaxRounds   int     // Oldest rounds are dropped past this many
+}
+
+// DefaultHistory returns the default match history configuration.
+func DefaultHistory() HistoryConfig {
+	return HistoryConfig{
+		File:        ".history-go.json",
+		RoundLength: 600,
+		MaxRounds:   1000,
+	}
+}
+
+// HistoryFromEnv returns match history configuration with environment variable overrides.
+func HistoryFromEnv() HistoryConfig {
+	cfg := DefaultHistory()
+
+	if f := os.Getenv("HISTORY_FILE"); f != "" {
+		cfg.File = f
+	}
+	if l := getEnvFloat("HISTORY_ROUND_LENGTH", 0); l > 0 {
+		cfg.RoundLength = l
+	}
+	if m := getEnvInt("HISTORY_MAX_ROUNDS", 0); m > 0 {
+		cfg.MaxRounds = m
+	}
+
+	return cfg
+}
+
+// =============================================================================
+// SEASONAL RATING CONFIGURATION
+// =============================================================================
+
+// RatingConfig holds the persistent ELO rating (ranks Bronze to Champion) settings.
+type RatingConfig struct {
+	File        string  // JSON file ratings are persisted to
+	Initial     int     // Rating of new viewers (and of bots, which are never rated)
+	KFactor     float64 // Max rating change per kill
+	SeasonReset bool    // Reset ratings at the start of each calendar month
+}
+
+// DefaultRating returns the default rating configuration.
+func DefaultRating() RatingConfig {
+	return RatingConfig{
+		File:        ".ratings-go.json",
+		Initial:     1000,
+		KFactor:     32,
+		SeasonReset: true,
+	}
+}
+
+// RatingFromEnv returns rating configuration with environment variable overrides.
+func RatingFromEnv() RatingConfig {
+	cfg := DefaultRating()
+
+	if f := os.Getenv("RATING_FILE"); f != "" {
+		cfg.File = f
+	}
+	if i := getEnvInt("RATING_INITIAL", 0); i > 0 {
+		cfg.Initial = i
+	}
+	if k := getEnvFloat("RATING_K_FACTOR", 0); k > 0 {
+		cfg.KFactor = k
+	}
+	if v := os.Getenv("RATING_SEASON_RESET"); v != "" {
+		cfg.SeasonReset = v != "false"
+	}
+
+	return cfg
+}
+
+// =============================================================================
+// CAREER STATS CONFIGURATION
+// =============================================================================
+
+// CareerConfig holds the persistent per-viewer career stats (!record, !last) settings.
+type CareerConfig struct {
+	File string // JSON file lifetime stats are persisted to
+}
+
+// DefaultCareer returns the default career stats configuration.
+func DefaultCareer() CareerConfig {
+	return CareerConfig{
+		File: ".career-go.json",
+	}
+}
+
+// CareerFromEnv returns career stats configuration with environment variable overrides.
+func CareerFromEnv() CareerConfig {
+	cfg := DefaultCareer()
+
+	if f := os.Getenv("CAREER_FILE"); f != "" {
+		cfg.File = f
+	}
+
+	return cfg
+}
+
+// =============================================================================
+// DAILY QUEST CONFIGURATION
+// =============================================================================
+
+// QuestConfig holds the daily viewer quest settings.
+type QuestConfig struct {
+	File   string // JSON file quest progress is persisted to
+	Reward int    // Wallet money granted per completed quest
+}
+
+// DefaultQuest returns the default quest configuration.
+func DefaultQuest() QuestConfig {
+	return QuestConfig{
+		File:   ".quests-go.json",
+		Reward: 250,
+	}
+}
+
+// QuestFromEnv returns quest configuration with environment variable overrides.
+func QuestFromEnv() QuestConfig {
+	cfg := DefaultQuest()
+
+	if f := os.Getenv("QUEST_FILE"); f != "" {
+		cfg.File = f
+	}
+	if r := getEnvInt("QUEST_REWARD", -1); r >= 0 {
+		cfg.Reward = r
+	}
+
+	return cfg
+}
+
+// =============================================================================
+// COSMETIC SKIN CONFIGURATION
+// =============================================================================
+
+// SkinConfig holds the cosmetic skin shop settings.
+type SkinConfig struct {
+	File string // JSON file owned and equipped skins are persisted to
+}
+
+// DefaultSkin returns the default skin configuration.
+func DefaultSkin() SkinConfig {
+	return SkinConfig{
+		File: ".skins-go.json",
+	}
+}
+
+// SkinFromEnv returns skin configuration with environment variable overrides.
+func SkinFromEnv() SkinConfig {
+	cfg := DefaultSkin()
+
+	if f := os.Getenv("SKIN_FILE"); f != "" {
+		cfg.File = f
+	}
+
+	return cfg
+}
+
+// =============================================================================
+// LAG COMPENSATION CONFIGURATION
+// =============================================================================
+
+// LagCompensationConfig holds the grace window for late chat commands.
+// Kick webhooks arrive hundreds of milliseconds after the viewer typed,
+// so a !heal sent just before a killing blow is applied retroactively.
+type LagCompensationConfig struct {
+	GraceWindow float64 // Seconds a death can be undone by an earlier !heal (0 = off)
+}
+
+// DefaultLagCompensation returns the default lag compensation configuration.
+func DefaultLagCompensation() LagCompensationConfig {
+	return LagCompensationConfig{
+		GraceWindow: 0.5,
+	}
+}
+
+// LagCompensationFromEnv returns lag compensation configuration with environment variable overrides.
+func LagCompensationFromEnv() LagCompensationConfig {
+	cfg := DefaultLagCompensation()
+
+	if g := getEnvFloat("LAG_GRACE_WINDOW", -1); g >= 0 {
+		cfg.GraceWindow = g
+	}
+
+	return cfg
+}
+
+// =============================================================================
+// LOOT CONFIGURATION
+// =============================================================================
+
+// LootConfig holds the drop settings: a dead fighter drops part of their
+// money and their weapon where they fell, for anyone to walk over and pick up.
+type LootConfig struct {
+	DropPercent   float64 // Percent of a dead fighter's money dropped (0 = off)
+	Despawn       float64 // Seconds dropped coins stay on the ground
+	PickupRadius  float64 // Distance from the coins or weapon to pick them up (px)
+	WeaponDespawn float64 // Seconds a dropped weapon stays on the ground (0 = weapons aren't dropped)
+}
+
+// DefaultLoot returns the default loot configuration.
+func DefaultLoot() LootConfig {
+	return LootConfig{
+		DropPercent:   50,
+		Despawn:       20,
+		PickupRadius:  40,
+		WeaponDespawn: 15,
+	}
+}
+
+// LootFromEnv returns loot configuration with environment variable overrides.
+func LootFromEnv() LootConfig {
+	cfg := DefaultLoot()
+
+	if p := getEnvFloat("LOOT_DROP_PERCENT", -1); p >= 0 && p <= 100 {
+		cfg.DropPercent = p
+	}
+	if d := getEnvFloat("LOOT_DESPAWN", 0); d > 0 {
+		cfg.Despawn = d
+	}
+	if r := getEnvFloat("LOOT_PICKUP_RADIUS", 0); r > 0 {
+		cfg.PickupRadius = r
+	}
+	if d := getEnvFloat("LOOT_WEAPON_DESPAWN", -1); d >= 0 {
+		cfg.WeaponDespawn = d
+	}
+
+	return cfg
+}
+
+// =============================================================================
+// GIFT CONFIGURATION
+// =============================================================================
+
+// GiftConfig holds the limits for !gift money transfers between viewers.
+type GiftConfig struct {
+	Min      int     // Smallest gift
+	Max      int     // Largest gift
+	Cooldown float64 // Seconds between gifts from the same viewer
+}
+
+// DefaultGift returns the default gift configuration.
+func DefaultGift() GiftConfig {
+	return GiftConfig{
+		Min:      10,
+		Max:      500,
+		Cooldown: 60,
+	}
+}
+
+// GiftFromEnv returns gift configuration with environment variable overrides.
+func GiftFromEnv() GiftConfig {
+	cfg := DefaultGift()
+
+	if m := getEnvInt("GIFT_MIN", 0); m > 0 {
+		cfg.Min = m
+	}
+	if m := getEnvInt("GIFT_MAX", 0); m > 0 {
+		cfg.Max = m
+	}
+	if c := getEnvFloat("GIFT_COOLDOWN", -1); c >= 0 {
+		cfg.Cooldown = c
+	}
+	if cfg.Max < cfg.Min {
+		cfg.Max = cfg.Min
+	}
+
+	return cfg
+}
+
+// =============================================================================
+// COMBAT ANALYTICS CONFIGURATION
+// =============================================================================
+
+// AnalyticsConfig holds settings for the combat heatmap and stats dumps.
+type AnalyticsConfig struct {
+	Dir      string  // Directory heatmap PNGs and stats JSON are written to ("" = API only)
+	Interval float64 // Seconds between dumps (a final dump is always written on shutdown)
+	CellSize float64 // Heatmap grid cell size in world pixels
+}
+
+// DefaultAnalytics returns the default analytics configuration.
+func DefaultAnalytics() AnalyticsConfig {
+	return AnalyticsConfig{
+		Dir:      "analytics",
+		Interval: 300,
+		CellSize: 40,
+	}
+}
+
+// AnalyticsFromEnv returns analytics configuration with environment variable overrides.
+func AnalyticsFromEnv() AnalyticsConfig {
+	cfg := DefaultAnalytics()
+
+	if d, ok := os.LookupEnv("ANALYTICS_DIR"); ok {
+		cfg.Dir = d
+	}
+	if i := getEnvFloat("ANALYTICS_INTERVAL", 0); i > 0 {
+		cfg.Interval = i
+	}
+	if c := getEnvFloat("ANALYTICS_CELL_SIZE", 0); c > 0 {
+		cfg.CellSize = c
+	}
+
+	return cfg
+}
+
+// =============================================================================
+// EVENT LOG CONFIGURATION
+// =============================================================================
+
+// EventLogConfig holds the event log file and its rotation policy. Rotated
+// segments are gzip-compressed and listed in a manifest next to the log.
+type EventLogConfig struct {
+	Path        string  // Active log file (newline-delimited JSON)
+	MaxSizeMB   int     // Rotate when the active file reaches this size (0 = no size limit)
+	RotateHours float64 // Rotate when the active file is this old (0 = no time limit)
+	RetainHours float64 // Delete segments older than this (0 = keep forever)
+	MaxSegments int     // Keep at most this many rotated segments (0 = unlimited)
+}
+
+// DefaultEventLog returns the default event log configuration.
+func DefaultEventLog() EventLogConfig {
+	return EventLogConfig{
+		Path:        "events.jsonl",
+		MaxSizeMB:   100,
+		RotateHours: 24,
+		RetainHours: 168,
+	}
+}
+
+// EventLogFromEnv returns event log configuration with environment variable overrides.
+func EventLogFromEnv() EventLogConfig {
+	cfg := DefaultEventLog()
+
+	if p := os.Getenv("EVENT_LOG_PATH"); p != "" {
+		cfg.Path = p
+	}
+	if m := getEnvInt("EVENT_LOG_MAX_SIZE_MB", -1); m >= 0 {
+		cfg.MaxSizeMB = m
+	}
+	if r := getEnvFloat("EVENT_LOG_ROTATE_HOURS", -1); r >= 0 {
+		cfg.RotateHours = r
+	}
+	if r := getEnvFloat("EVENT_LOG_RETAIN_HOURS", -1); r >= 0 {
+		cfg.RetainHours = r
+	}
+	if m := getEnvInt("EVENT_LOG_MAX_SEGMENTS", -1); m >= 0 {
+		cfg.MaxSegments = m
+	}
+
+	return cfg
+}
+
+// =============================================================================
+// BOT FILL CONFIGURATION
+// =============================================================================
+
+// BotFillConfig holds settings for AI bots that keep a quiet arena populated.
+type BotFillConfig struct {
+	MinPlayers   int     // Bots fill the arena up to this many fighters; 0 disables bot fill
+	SpawnDelay   float64 // Seconds between bot joins (avoids a burst of bots at startup)
+	RespawnDelay float64 // Seconds before a killed bot re-enters (if still needed)
+}
+
+// DefaultBotFill returns the default bot fill configuration.
+func DefaultBotFill() BotFillConfig {
+	return BotFillConfig{
+		MinPlayers:   0,
+		SpawnDelay:   1.5,
+		RespawnDelay: 5,
+	}
+}
+
+// BotFillFromEnv returns bot fill configuration with environment variable overrides.
+func BotFillFromEnv() BotFillConfig {
+	cfg := DefaultBotFill()
+
+	if n := getEnvInt("BOT_FILL_MIN_PLAYERS", -1); n >= 0 {
+		cfg.MinPlayers = n
+	}
+	if d := getEnvFloat("BOT_FILL_SPAWN_DELAY", -1); d >= 0 {
+		cfg.SpawnDelay = d
+	}
+	if d := getEnvFloat("BOT_FILL_RESPAWN_DELAY", -1); d >= 0 {
+		cfg.RespawnDelay = d
+	}
+
+	return cfg
+}
+
+// JoinQueueConfig holds settings for the waiting line used when the arena is full.
+type JoinQueueConfig struct {
+	MaxSize     int     // Viewers who can wait for a slot; 0 disables the queue (!join is rejected when full)
+	DeadTimeout float64 // Seconds a dead viewer keeps their slot while others are queued
+}
+
+// DefaultJoinQueue returns the default join queue configuration.
+func DefaultJoinQueue() JoinQueueConfig {
+	return JoinQueueConfig{
+		MaxSize:     50,
+		DeadTimeout: 30,
+	}
+}
+
+// JoinQueueFromEnv returns join queue configuration with environment variable overrides.
+func JoinQueueFromEnv() JoinQueueConfig {
+	cfg := DefaultJoinQueue()
+
+	if n := getEnvInt("JOIN_QUEUE_SIZE", -1); n >= 0 {
+		cfg.MaxSize = n
+	}
+	if d := getEnvFloat("JOIN_QUEUE_DEAD_TIMEOUT", -1); d >= 0 {
+		cfg.DeadTimeout = d
+	}
+
+	return cfg
+}
+
+// =============================================================================
+// AUTO-STREAM CONFIGURATION
+// =============================================================================
+
+// AutoStreamConfig controls the auto-stream controller: the stream goes live
+// when viewers join (or the broadcaster types !golive) and ends after the
+// arena has been empty for IdleMinutes, saving GPU hours on rented machines.
+type AutoStreamConfig struct {
+	Enabled         bool    // Off: the streamer goes live at startup and stays live
+	IdleMinutes     float64 // Minutes without viewers in the arena before the stream ends
+	MinPlayers      int     // Viewers (not bots) in the arena that start the stream
+	LiveTitle       string  // Kick stream title set when going live ("" = unchanged)
+	LiveCategory    string  // Kick category set when going live ("" = unchanged)
+	OfflineTitle    string  // Kick stream title set when the stream ends ("" = unchanged)
+	OfflineCategory string  // Kick category set when the stream ends ("" = unchanged)
+}
+
+// DefaultAutoStream returns the default auto-stream configuration (disabled).
+func DefaultAutoStream() AutoStreamConfig {
+	return AutoStreamConfig{
+		Enabled:     false,
+		IdleMinutes: 10,
+		MinPlayers:  1,
+	}
+}
+
+// AutoStreamFromEnv returns auto-stream configuration with environment variable overrides.
+func AutoStreamFromEnv() AutoStreamConfig {
+	cfg := DefaultAutoStream()
+
+	cfg.Enabled = os.Getenv("AUTO_STREAM_ENABLED") == "true"
+	if m := getEnvFloat("AUTO_STREAM_IDLE_MINUTES", 0); m > 0 {
+		cfg.IdleMinutes = m
+	}
+	if n := getEnvInt("AUTO_STREAM_MIN_PLAYERS", 0); n > 0 {
+		cfg.MinPlayers = n
+	}
+	cfg.LiveTitle = os.Getenv("AUTO_STREAM_LIVE_TITLE")
+	cfg.LiveCategory = os.Getenv("AUTO_STREAM_LIVE_CATEGORY")
+	cfg.OfflineTitle = os.Getenv("AUTO_STREAM_OFFLINE_TITLE")
+	cfg.OfflineCategory = os.Getenv("AUTO_STREAM_OFFLINE_CATEGORY")
+
+	return cfg
+}
+
+// =============================================================================
+// DISCORD NOTIFICATION CONFIGURATION
+// =============================================================================
+
+// NotifyConfig holds Discord webhook notification settings.
+type NotifyConfig struct {
+	DiscordWebhookURL string   // Discord webhook URL; "" disables notifications
+	Username          string   // Display name of the webhook messages
+	StreamURL         string   // Channel link included in stream start messages
+	Events            []string // Enabled events (empty = all)
+	TemplatesFile     string   // JSON file of {"event": "template"} overriding the defaults
+	RatePerMinute     float64  // Max webhook posts per minute (Discord allows ~30)
+	Cooldown          float64  // Min seconds between two posts of the same event
+}
+
+// DefaultNotify returns the default notification configuration.
+func DefaultNotify() NotifyConfig {
+	return NotifyConfig{
+		Username:      "Fight Club",
+		RatePerMinute: 20,
+		Cooldown:      60,
+	}
+}
+
+// NotifyFromEnv returns notification configuration with environment variable overrides.
+func NotifyFromEnv() NotifyConfig {
+	cfg := DefaultNotify()
+
+	cfg.DiscordWebhookURL = os.Getenv("DISCORD_WEBHOOK_URL")
+	if u := os.Getenv("DISCORD_USERNAME"); u != "" {
+		cfg.Username = u
+	}
+	cfg.StreamURL = os.Getenv("DISCORD_STREAM_URL")
+	if e := os.Getenv("DISCORD_NOTIFY_EVENTS"); e != "" {
+		for _, event := range strings.Split(e, ",") {
+			if event = strings.TrimSpace(event); event != "" {
+				cfg.Events = append(cfg.Events, event)
+			}
+		}
+	}
+	cfg.TemplatesFile = os.Getenv("DISCORD_TEMPLATES_FILE")
+	if r := getEnvFloat("DISCORD_RATE_PER_MINUTE", 0); r > 0 {
+		cfg.RatePerMinute = r
+	}
+	if c := getEnvFloat("DISCORD_EVENT_COOLDOWN", -1); c >= 0 {
+		cfg.Cooldown = c
+	}
+
+	return cfg
+}
+
+// =============================================================================
+// CHAT MODERATION CONFIGURATION
+// =============================================================================
+
+// ModerationConfig is the broadcaster's anti-abuse policy: viewers who keep
+// hitting the command rate limit are timed out on Kick.
+type ModerationConfig struct {
+	Enabled        bool     // Act on abuse at all
+	DryRun         bool     // Log (and list in the admin API) what would happen without calling Kick
+	Threshold      int      // Rate-limited commands within Window that trigger a timeout
+	Window         float64  // Seconds violations are counted over
+	TimeoutMinutes int      // First timeout; repeat offenders get double each time (Kick max 7 days)
+	Reason         string   // Shown to the user by Kick
+	Exempt         []string // Usernames never timed out (moderators, friends); the broadcaster always is
+}
+
+// DefaultModeration returns the default moderation policy (off, dry-run when enabled).
+func DefaultModeration() ModerationConfig {
+	return ModerationConfig{
+		Enabled:        false,
+		DryRun:         true,
+		Threshold:      10,
+		Window:         60,
+		TimeoutMinutes: 5,
+		Reason:         "Spamming game commands",
+	}
+}
+
+// ModerationFromEnv returns the moderation policy with environment variable overrides.
+func ModerationFromEnv() ModerationConfig {
+	cfg := DefaultModeration()
+
+	cfg.Enabled = os.Getenv("MODERATION_ENABLED") == "true"
+	if os.Getenv("MODERATION_DRY_RUN") == "false" {
+		cfg.DryRun = false
+	}
+	if t := getEnvInt("MODERATION_ABUSE_THRESHOLD", 0); t > 0 {
+		cfg.Threshold = t
+	}
+	if w := getEnvFloat("MODERATION_ABUSE_WINDOW", 0); w > 0 {
+		cfg.Window = w
+	}
+	if m := getEnvInt("MODERATION_TIMEOUT_MINUTES", 0); m > 0 {
+		cfg.TimeoutMinutes = m
+	}
+	if r := os.Getenv("MODERATION_REASON"); r != "" {
+		cfg.Reason = r
+	}
+	if e := os.Getenv("MODERATION_EXEMPT"); e != "" {
+		for _, name := range strings.Split(e, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				cfg.Exempt = append(cfg.Exempt, name)
+			}
+		}
+	}
+
+	return cfg
+}
+
+// =============================================================================
+// CHAT BAN LIST CONFIGURATION
+// =============================================================================
+
+// BanConfig holds where the ban list (!ban, /api/admin/ban) is persisted.
+type BanConfig struct {
+	Backend  string // "file" or "sql"
+	File     string // Ban list file (file backend)
+	DBDriver string // database/sql driver name (sql backend), e.g. "sqlite"
+	DBDSN    string // Database DSN (sql backend)
+
+	// FileFallback uses File when the sql backend can't be opened, instead of
+	// refusing to start (off by default: bans shouldn't silently change store)
+	FileFallback bool
+}
+
+// DefaultBans returns the default ban list storage configuration.
+func DefaultBans() BanConfig {
+	return BanConfig{
+		Backend:  "file",
+		File:     "chat-bans.json",
+		DBDriver: "sqlite",
+		DBDSN:    "chat-bans.db",
+	}
+}
+
+// BansFromEnv returns ban list storage configuration with environment variable overrides.
+func BansFromEnv() BanConfig {
+	cfg := DefaultBans()
+
+	if b := os.Getenv("BAN_STORE"); b != "" {
+		cfg.Backend = b
+	}
+	if f := os.Getenv("BAN_FILE"); f != "" {
+		cfg.File = f
+	}
+	if d := os.Getenv("BAN_DB_DRIVER"); d != "" {
+		cfg.DBDriver = d
+	}
+	if d := os.Getenv("BAN_DB_DSN"); d != "" {
+		cfg.DBDSN = d
+	}
+	cfg.FileFallback = os.Getenv("BAN_FILE_FALLBACK") == "true"
+
+	return cfg
+}
+
+// =============================================================================
+// CHAT RATE LIMIT CONFIGURATION
+// =============================================================================
+
+// CommandRateLimit allows Max uses of one command per Window seconds
+type CommandRateLimit struct {
+	Max    int
+	Window float64
+}
+
+// String formats the limit the way ParseCommandLimits reads it ("1/30s")
+func (l CommandRateLimit) String() string {
+	return strconv.Itoa(l.Max) + "/" + strconv.FormatFloat(l.Window, 'f', -1, 64) + "s"
+}
+
+// ChatRateLimitConfig limits how fast each viewer can send commands. Every
+// command counts against the per-viewer limit; commands listed in Commands
+// also have their own limit. Subscribers and moderators get their limits
+// multiplied (2 = twice the commands, half the cooldown); the broadcaster is
+// never limited.
+type ChatRateLimitConfig struct {
+	MaxPerWindow int                         // Commands per Window for each viewer
+	Window       float64                     // Seconds
+	Cooldown     float64                     // Minimum seconds between two commands
+	Commands     map[string]CommandRateLimit // Canonical command name ("join", "emote") -> its own limit
+
+	SubscriberMultiplier float64
+	ModeratorMultiplier  float64
+}
+
+// DefaultChatRateLimit returns the default limits: 5 commands per 5s with a
+// 0.5s cooldown, one !join per 30s and one !emote / !taunt per 5s.
+func DefaultChatRateLimit() ChatRateLimitConfig {
+	return ChatRateLimitConfig{
+		MaxPerWindow: 5,
+		Window:       5,
+		Cooldown:     0.5,
+		Commands: map[string]CommandRateLimit{
+			"join":  {Max: 1, Window: 30},
+			"emote": {Max: 1, Window: 5},
+			"taunt": {Max: 1, Window: 5},
+		},
+		SubscriberMultiplier: 2,
+		ModeratorMultiplier:  4,
+	}
+}
+
+// ChatRateLimitFromEnv returns the chat rate limits with environment variable overrides.
+// CHAT_COMMAND_LIMITS replaces the default command limits ("join=1/30s,emote=1/5s";
+// "none" removes them); an invalid list is ignored.
+func ChatRateLimitFromEnv() ChatRateLimitConfig {
+	cfg := DefaultChatRateLimit()
+
+	if m := getEnvInt("CHAT_RATE_LIMIT_MAX", 0); m > 0 {
+		cfg.MaxPerWindow = m
+	}
+	if w := getEnvFloat("CHAT_RATE_LIMIT_WINDOW", 0); w > 0 {
+		cfg.Window = w
+	}
+	if c := getEnvFloat("CHAT_RATE_LIMIT_COOLDOWN", -1); c >= 0 {
+		cfg.Cooldown = c
+	}
+	if s := os.Getenv("CHAT_COMMAND_LIMITS"); s != "" {
+		if limits, err := ParseCommandLimits(s); err == nil {
+			cfg.Commands = limits
+		}
+	}
+	if m := getEnvFloat("CHAT_RATE_SUBSCRIBER_MULTIPLIER", 0); m >= 1 {
+		cfg.SubscriberMultiplier = m
+	}
+	if m := getEnvFloat("CHAT_RATE_MODERATOR_MULTIPLIER", 0); m >= 1 {
+		cfg.ModeratorMultiplier = m
+	}
+
+	return cfg
+}
+
+// ParseCommandLimits parses a command limit list: "join=1/30s, emote=1/5s"
+// (uses per Go duration, or per seconds without a unit). "none" is an empty list.
+func ParseCommandLimits(s string) (map[string]CommandRateLimit, error) {
+	limits := make(map[string]CommandRateLimit)
+	if strings.TrimSpace(s) == "none" {
+		return limits, nil
+	}
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, limit, ok := strings.Cut(entry, "=")
+		name = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(name), "!"))
+		countStr, windowStr, ok2 := strings.Cut(strings.TrimSpace(limit), "/")
+		if !ok || !ok2 || name == "" {
+			return nil, fmt.Errorf("%q is not command=count/window", entry)
+		}
+		count, err := strconv.Atoi(strings.TrimSpace(countStr))
+		if err != nil || count < 1 {
+			return nil, fmt.Errorf("%q: count must be a positive integer", entry)
+		}
+		windowStr = strings.TrimSpace(windowStr)
+		window, err := strconv.ParseFloat(windowStr, 64)
+		if err != nil {
+			d, derr := time.ParseDuration(windowStr)
+			if derr != nil {
+				return nil, fmt.Errorf("%q: window must be a duration like 30s or 2m", entry)
+			}
+			window = d.Seconds()
+		}
+		if window <= 0 {
+			return nil, fmt.Errorf("%q: window must be positive", entry)
+		}
+		limits[name] = CommandRateLimit{Max: count, Window: window}
+	}
+	return limits, nil
+}
+
+// =============================================================================
+// CHAT COMMAND LOCALES CONFIGURATION
+// =============================================================================
+
+// CommandLocaleConfig selects the languages chat commands are understood in.
+// English commands always work; each locale adds its built-in translations
+// (es: !unirse, !curar, !comprar; pt: !entrar, !curar, !comprar). Extras map
+// more words to built-in commands without the custom alias file.
+type CommandLocaleConfig struct {
+	Locales []string          // "es", "pt"
+	Extras  map[string]string // Word -> built-in command ("pelear" -> "join")
+}
+
+// DefaultCommandLocale returns the default locales (Spanish and Portuguese).
+func DefaultCommandLocale() CommandLocaleConfig {
+	return CommandLocaleConfig{
+		Locales: []string{"es", "pt"},
+		Extras:  map[string]string{},
+	}
+}
+
+// CommandLocaleFromEnv returns the command locales with environment variable overrides.
+// CHAT_COMMAND_LOCALES is a list ("es,pt"; "none" = English only);
+// CHAT_COMMAND_ALIASES adds words ("pelear=join,sanar=heal"), an invalid list is ignored.
+func CommandLocaleFromEnv() CommandLocaleConfig {
+	cfg := DefaultCommandLocale()
+
+	if s := os.Getenv("CHAT_COMMAND_LOCALES"); s != "" {
+		cfg.Locales = ParseCommandLocales(s)
+	}
+	if s := os.Getenv("CHAT_COMMAND_ALIASES"); s != "" {
+		if extras, err := ParseCommandExtras(s); err == nil {
+			cfg.Extras = extras
+		}
+	}
+
+	return cfg
+}
+
+// ParseCommandLocales parses a locale list: "es, pt" ("none" is an empty list)
+func ParseCommandLocales(s string) []string {
+	locales := []string{}
+	if strings.TrimSpace(s) == "none" {
+		return locales
+	}
+	for _, locale := range strings.Split(s, ",") {
+		if locale = strings.ToLower(strings.TrimSpace(locale)); locale != "" {
+			locales = append(locales, locale)
+		}
+	}
+	return locales
+}
+
+// ParseCommandExtras parses extra command words: "pelear=join, sanar=heal"
+// (a leading "!" is optional on both sides)
+func ParseCommandExtras(s string) (map[string]string, error) {
+	extras := make(map[string]string)
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		word, command, ok := strings.Cut(entry, "=")
+		word = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(word), "!"))
+		command = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(command), "!"))
+		if !ok || word == "" || command == "" || strings.ContainsAny(word+command, " \t") {
+			return nil, fmt.Errorf("%q is not word=command", entry)
+		}
+		extras[word] = command
+	}
+	return extras, nil
+}
+
+// =============================================================================
+// CHAT FEEDBACK CONFIGURATION
+// =============================================================================
+
+// ChatFeedbackConfig controls replies to failed commands ("@ana you need $80
+// more for Sword"). Replies are batched into one chat message per interval
+// and each viewer gets at most one per cooldown, so they never flood the chat.
+type ChatFeedbackConfig struct {
+	Enabled      bool    // Off by default: failures are only logged
+	Interval     float64 // Seconds between batched feedback messages
+	UserCooldown float64 // Seconds before the same viewer gets another reply
+	MaxBatch     int     // Replies per chat message; the rest are dropped
+}
+
+// DefaultChatFeedback returns the default feedback settings (disabled).
+func DefaultChatFeedback() ChatFeedbackConfig {
+	return ChatFeedbackConfig{
+		Enabled:      false,
+		Interval:     10,
+		UserCooldown: 60,
+		MaxBatch:     4,
+	}
+}
+
+// ChatFeedbackFromEnv returns the feedback settings with environment variable overrides.
+func ChatFeedbackFromEnv() ChatFeedbackConfig {
+	cfg := DefaultChatFeedback()
+
+	cfg.Enabled = os.Getenv("CHAT_FEEDBACK_ENABLED") == "true"
+	if v := getEnvFloat("CHAT_FEEDBACK_INTERVAL", 0); v > 0 {
+		cfg.Interval = v
+	}
+	if v := getEnvFloat("CHAT_FEEDBACK_USER_COOLDOWN", 0); v > 0 {
+		cfg.UserCooldown = v
+	}
+	if v := getEnvInt("CHAT_FEEDBACK_MAX_BATCH", 0); v > 0 {
+		cfg.MaxBatch = v
+	}
+
+	return cfg
+}
+
+// =============================================================================
+// DEMO MODE CONFIGURATION
+// =============================================================================
+
+// DemoConfig holds the offline demo mode: synthetic viewers send chat
+// commands through the real command queue, so the whole pipeline runs
+// without Kick credentials.
+type DemoConfig struct {
+	Enabled  bool
+	Viewers  int     // Synthetic viewers sending commands
+	Interval float64 // Seconds between synthetic commands
+	Seed     int64   // Random seed (0 = different traffic every run)
+}
+
+// DefaultDemo returns the default demo settings (disabled).
+func DefaultDemo() DemoConfig {
+	return DemoConfig{
+		Enabled:  false,
+		Viewers:  12,
+		Interval: 0.5,
+	}
+}
+
+// DemoFromEnv returns the demo settings with environment variable overrides.
+func DemoFromEnv() DemoConfig {
+	cfg := DefaultDemo()
+
+	cfg.Enabled = os.Getenv("DEMO_MODE") == "true"
+	if v := getEnvInt("DEMO_VIEWERS", 0); v > 0 {
+		cfg.Viewers = v
+	}
+	if v := getEnvFloat("DEMO_COMMAND_INTERVAL", 0); v > 0 {
+		cfg.Interval = v
+	}
+	cfg.Seed = int64(getEnvInt("DEMO_SEED", 0))
+
+	return cfg
+}
+
+// =============================================================================
+// KICK API HTTP CLIENT CONFIGURATION
+// =============================================================================
+
+// KickHTTPConfig holds outbound HTTP settings for the Kick API client.
+// Needed on hosts with restricted egress (corporate proxy, TLS interception).
+type KickHTTPConfig struct {
+	ProxyURL string  // http://, https://, socks5:// or socks5h:// proxy; "" uses HTTPS_PROXY/HTTP_PROXY
+	CAFile   string  // PEM bundle of extra root CAs (added to the system pool)
+	Timeout  float64 // Per-request timeout in seconds
+}
+
+// DefaultKickHTTP returns the default Kick API HTTP configuration.
+func DefaultKickHTTP() KickHTTPConfig {
+	return KickHTTPConfig{
+		Timeout: 10,
+	}
+}
+
+// KickHTTPFromEnv returns Kick API HTTP configuration with environment variable overrides.
+func KickHTTPFromEnv() KickHTTPConfig {
+	cfg := DefaultKickHTTP()
+
+	if p := os.Getenv("KICK_HTTP_PROXY"); p != "" {
+		cfg.ProxyURL = p
+	}
+	if f := os.Getenv("KICK_CA_FILE"); f != "" {
+		cfg.CAFile = f
+	}
+	if t := getEnvFloat("KICK_HTTP_TIMEOUT", 0); t > 0 {
+		cfg.Timeout = t
+	}
+
+	return cfg
+}
+
+// =============================================================================
+// KICK WEBHOOK DEDUPLICATION CONFIGURATION
+// =============================================================================
+
+// KickDedupConfig controls webhook replay protection. Kick re-delivers
+// webhooks it considers unacknowledged; a message ID seen within Window
+// seconds is acknowledged but not processed again (no double !buy charges).
+type KickDedupConfig struct {
+	Window float64 // Seconds a message ID is remembered (0 = no deduplication)
+	MaxIDs int     // Most recent message IDs remembered, oldest forgotten first
+}
+
+// DefaultKickDedup returns the default webhook deduplication configuration.
+func DefaultKickDedup() KickDedupConfig {
+	return KickDedupConfig{
+		Window: 600,
+		MaxIDs: 10000,
+	}
+}
+
+// KickDedupFromEnv returns webhook deduplication configuration with environment variable overrides.
+func KickDedupFromEnv() KickDedupConfig {
+	cfg := DefaultKickDedup()
+
+	if w := getEnvFloat("KICK_DEDUP_WINDOW", -1); w >= 0 {
+		cfg.Window = w
+	}
+	if m := getEnvInt("KICK_DEDUP_MAX_IDS", 0); m > 0 {
+		cfg.MaxIDs = m
+	}
+
+	return cfg
+}
+
+// =============================================================================
+// KICK TOKEN STORAGE CONFIGURATION
+// =============================================================================
+
+// KickTokenStoreConfig holds where and how Kick OAuth tokens are persisted.
+type KickTokenStoreConfig struct {
+	Backend       string // "file" or "sql"
+	File          string // Token file (file backend)
+	DBDriver      string // database/sql driver name (sql backend), e.g. "sqlite"
+	DBDSN         string // Database DSN (sql backend)
+	EncryptionKey string // 32-byte AES-256-GCM key as hex or base64; "" stores plaintext
+}
+
+// DefaultKickTokenStore returns the default token storage configuration.
+func DefaultKickTokenStore() KickTokenStoreConfig {
+	return KickTokenStoreConfig{
+		Backend:  "file",
+		File:     ".kick-tokens-go.json",
+		DBDriver: "sqlite",
+		DBDSN:    "kick-tokens.db",
+	}
+}
+
+// KickTokenStoreFromEnv returns token storage configuration with environment variable overrides.
+func KickTokenStoreFromEnv() KickTokenStoreConfig {
+	cfg := DefaultKickTokenStore()
+
+	if b := os.Getenv("KICK_TOKEN_STORE"); b != "" {
+		cfg.Backend = b
+	}
+	if f := os.Getenv("KICK_TOKEN_FILE"); f != "" {
+		cfg.File = f
+	}
+	if d := os.Getenv("KICK_TOKEN_DB_DRIVER"); d != "" {
+		cfg.DBDriver = d
+	}
+	if d := os.Getenv("KICK_TOKEN_DB_DSN"); d != "" {
+		cfg.DBDSN = d
+	}
+	cfg.EncryptionKey = os.Getenv("KICK_TOKEN_KEY")
+
+	return cfg
+}
+
+// =============================================================================
+// KICK VIEWER COUNT CONFIGURATION
+// =============================================================================
+
+// KickViewersConfig controls polling of the live viewer count shown on stream.
+type KickViewersConfig struct {
+	PollInterval float64 // Seconds between livestream API polls (0 = disabled)
+}
+
+// DefaultKickViewers returns the default viewer count configuration.
+func DefaultKickViewers() KickViewersConfig {
+	return KickViewersConfig{
+		PollInterval: 30,
+	}
+}
+
+// KickViewersFromEnv returns viewer count configuration with environment variable overrides.
+func KickViewersFromEnv() KickViewersConfig {
+	cfg := DefaultKickViewers()
+
+	if i := getEnvFloat("KICK_VIEWER_POLL_INTERVAL", -1); i >= 0 {
+		cfg.PollInterval = i
+	}
+
+	return cfg
+}
+
+// =============================================================================
+// KICK TITLE CONFIGURATION
+// =============================================================================
+
+// KickTitleConfig controls the channel title updated with live game stats.
+// Template placeholders: {players}, {alive}, {kills} (total), {top} and
+// {topkills} (the arena's top killer and their kills).
+type KickTitleConfig struct {
+	Template string
+	Interval float64 // Seconds between title updates (0 = disabled)
+}
+
+// DefaultKickTitle returns the default title updater configuration.
+func DefaultKickTitle() KickTitleConfig {
+	return KickTitleConfig{
+		Template: "FIGHT CLUB — {players} players, top killer: {top} ({topkills})",
+		Interval: 0,
+	}
+}
+
+// KickTitleFromEnv returns title updater configuration with environment variable overrides.
+func KickTitleFromEnv() KickTitleConfig {
+	cfg := DefaultKickTitle()
+
+	if t := os.Getenv("KICK_TITLE_TEMPLATE"); t != "" {
+		cfg.Template = t
+	}
+	if i := getEnvFloat("KICK_TITLE_INTERVAL", -1); i >= 0 {
+		cfg.Interval = i
+	}
+
+	return cfg
+}
+
+// =============================================================================
+// KICK HIGHLIGHT CLIPS CONFIGURATION
+// =============================================================================
+
+// KickClipsConfig controls highlight clips: big moments (3+ multikills, boss
+// kills) are clipped on Kick and the clip links posted to chat and Discord.
+type KickClipsConfig struct {
+	Enabled  bool
+	Duration int     // Clip length in seconds (ending at the moment)
+	Cooldown float64 // Min seconds between two clips
+	File     string  // JSON file the clip links are kept in
+}
+
+// DefaultKickClips returns the default highlight clips configuration.
+func DefaultKickClips() KickClipsConfig {
+	return KickClipsConfig{
+		Duration: 30,
+		Cooldown: 60,
+		File:     ".highlights-go.json",
+	}
+}
+
+// KickClipsFromEnv returns highlight clips configuration with environment variable overrides.
+func KickClipsFromEnv() KickClipsConfig {
+	cfg := DefaultKickClips()
+
+	cfg.Enabled = os.Getenv("KICK_CLIPS_ENABLED") == "true"
+	if d := getEnvInt("KICK_CLIPS_DURATION", 0); d > 0 {
+		cfg.Duration = d
+	}
+	if c := getEnvFloat("KICK_CLIPS_COOLDOWN", -1); c >= 0 {
+		cfg.Cooldown = c
+	}
+	if f := os.Getenv("KICK_CLIPS_FILE"); f != "" {
+		cfg.File = f
+	}
+
+	return cfg
+}
+
+// =============================================================================
+// KICK GUEST CHANNELS CONFIGURATION
+// =============================================================================
+
+// KickGuestChannel is a collab partner's channel whose chat also plays.
+type KickGuestChannel struct {
+	BroadcasterID int64
+	Prefix        string // Prepended to player names from this chat ("ana" -> "ana:viewer")
+}
+
+// KickGuestsConfig lists guest channels read alongside the broadcaster's own chat.
+type KickGuestsConfig struct {
+	Guests []KickGuestChannel
+}
+
+// KickGuestsFromEnv parses KICK_GUEST_CHANNELS ("id:prefix,id:prefix").
+// A missing prefix defaults to the broadcaster ID; invalid IDs are skipped.
+func KickGuestsFromEnv() KickGuestsConfig {
+	var cfg KickGuestsConfig
+
+	for _, entry := range strings.Split(os.Getenv("KICK_GUEST_CHANNELS"), ",") {
+		idStr, prefix, _ := strings.Cut(strings.TrimSpace(entry), ":")
+		id, err := strconv.ParseInt(strings.TrimSpace(idStr), 10, 64)
+		if err != nil || id <= 0 {
+			continue
+		}
+		prefix = strings.TrimSpace(prefix)
+		if prefix == "" {
+			prefix = strconv.FormatInt(id, 10)
+		}
+		cfg.Guests = append(cfg.Guests, KickGuestChannel{BroadcasterID: id, Prefix: prefix})
+	}
+
+	return cfg
+}
+
+// =============================================================================
+// AVATAR CACHE CONFIGURATION
+// =============================================================================
+
+// AvatarCacheConfig holds the profile picture cache settings. Avatars and
+// profile URLs are kept in memory and on disk, so restarts don't refetch
+// every viewer's picture from Kick's CDN.
+type AvatarCacheConfig struct {
+	Dir         string  // Disk tier directory ("" = memory only)
+	TTL         float64 // Seconds before a cached avatar is revalidated (ETag / Last-Modified)
+	URLTTL      float64 // Seconds a user's profile picture URL is trusted before asking Kick again
+	NegativeTTL float64 // Seconds users without an avatar aren't looked up again
+}
+
+// DefaultAvatarCache returns the default avatar cache configuration.
+func DefaultAvatarCache() AvatarCacheConfig {
+	return AvatarCacheConfig{
+		Dir:         ".avatar-cache",
+		TTL:         6 * 3600,
+		URLTTL:      24 * 3600,
+		NegativeTTL: 3600,
+	}
+}
+
+// AvatarCacheFromEnv returns avatar cache configuration with environment variable overrides.
+func AvatarCacheFromEnv() AvatarCacheConfig {
+	cfg := DefaultAvatarCache()
+
+	if d := os.Getenv("AVATAR_CACHE_DIR"); d == "off" {
+		cfg.Dir = ""
+	} else if d != "" {
+		cfg.Dir = d
+	}
+	if t := getEnvFloat("AVATAR_CACHE_TTL", 0); t > 0 {
+		cfg.TTL = t
+	}
+	if t := getEnvFloat("AVATAR_URL_TTL", 0); t > 0 {
+		cfg.URLTTL = t
+	}
+	if t := getEnvFloat("AVATAR_NEGATIVE_TTL", 0); t > 0 {
+		cfg.NegativeTTL = t
+	}
+
+	return cfg
+}
+
 // =============================================================================
 // COMPLETE APP CONFIGURATION
 // =============================================================================
 
 // AppConfig holds the complete application configuration.
 type AppConfig struct {
-	Video    VideoConfig
-	Audio    AudioConfig
-	Server   ServerConfig
-	Limits   ResourceLimits
-	Spatial  SpatialConfig
+	Video       VideoConfig
+	Simulation  SimulationConfig
+	Audio       AudioConfig
+	Sounds      SoundConfig
+	Server      ServerConfig
+	Limits      ResourceLimits
+	Spatial     SpatialConfig
+	Economy     EconomyConfig
+	Voting      VotingConfig
+	Duel        DuelConfig
+	Chaos       ChaosConfig
+	Scene       SceneConfig
+	Airstrike   AirstrikeConfig
+	Shop        ShopConfig
+	RingOut     RingOutConfig
+	Replay      ReplayConfig
+	Objective   ObjectiveConfig
+	Leaderboard LeaderboardConfig
+	History     HistoryConfig
+	Rating      RatingConfig
+	Career      CareerConfig
+	Quest       QuestConfig
+	Skins       SkinConfig
+	LagComp     LagCompensationConfig
+	Loot        LootConfig
+	Gift        GiftConfig
+	Analytics   AnalyticsConfig
+	EventLog    EventLogConfig
+	BotFill     BotFillConfig
+	JoinQueue   JoinQueueConfig
+	AutoStream  AutoStreamConfig
+	Notify      NotifyConfig
+	Moderation  ModerationConfig
+	Bans        BanConfig
+	RateLimit   ChatRateLimitConfig
+	Locale      CommandLocaleConfig
+	Feedback    ChatFeedbackConfig
+	Demo        DemoConfig
+	KickHTTP    KickHTTPConfig
+	KickDedup   KickDedupConfig
+	KickTokens  KickTokenStoreConfig
+	KickViewers KickViewersConfig
+	KickTitle   KickTitleConfig
+	KickClips   KickClipsConfig
+	KickGuests  KickGuestsConfig
+	AvatarCache AvatarCacheConfig
 }
 
 // Load returns the complete configuration with environment overrides.
 func Load() AppConfig {
 	return AppConfig{
-		Video:   VideoFromEnv(),
-		Audio:   AudioFromEnv(),
-		Server:  ServerFromEnv(),
-		Limits:  DefaultLimits(),
-		Spatial: DefaultSpatial(),
+		Video:       VideoFromEnv(),
+		Simulation:  SimulationFromEnv(),
+		Audio:       AudioFromEnv(),
+		Sounds:      SoundsFromEnv(),
+		Server:      ServerFromEnv(),
+		Limits:      LimitsFromEnv(),
+		Spatial:     DefaultSpatial(),
+		Economy:     EconomyFromEnv(),
+		Voting:      VotingFromEnv(),
+		Duel:        DuelFromEnv(),
+		Chaos:       ChaosFromEnv(),
+		Scene:       SceneFromEnv(),
+		Airstrike:   AirstrikeFromEnv(),
+		Shop:        ShopFromEnv(),
+		RingOut:     RingOutFromEnv(),
+		Replay:      ReplayFromEnv(),
+		Objective:   ObjectiveFromEnv(),
+		Leaderboard: LeaderboardFromEnv(),
+		History:     HistoryFromEnv(),
+		Rating:      RatingFromEnv(),
+		Career:      CareerFromEnv(),
+		Quest:       QuestFromEnv(),
+		Skins:       SkinFromEnv(),
+		LagComp:     LagCompensationFromEnv(),
+		Loot:        LootFromEnv(),
+		Gift:        GiftFromEnv(),
+		Analytics:   AnalyticsFromEnv(),
+		EventLog:    EventLogFromEnv(),
+		BotFill:     BotFillFromEnv(),
+		JoinQueue:   JoinQueueFromEnv(),
+		AutoStream:  AutoStreamFromEnv(),
+		Notify:      NotifyFromEnv(),
+		Moderation:  ModerationFromEnv(),
+		Bans:        BansFromEnv(),
+		RateLimit:   ChatRateLimitFromEnv(),
+		Locale:      CommandLocaleFromEnv(),
+		Feedback:    ChatFeedbackFromEnv(),
+		Demo:        DemoFromEnv(),
+		KickHTTP:    KickHTTPFromEnv(),
+		KickDedup:   KickDedupFromEnv(),
+		KickTokens:  KickTokenStoreFromEnv(),
+		KickViewers: KickViewersFromEnv(),
+		KickTitle:   KickTitleFromEnv(),
+		KickClips:   KickClipsFromEnv(),
+		KickGuests:  KickGuestsFromEnv(),
+		AvatarCache: AvatarCacheFromEnv(),
 	}
 }
 
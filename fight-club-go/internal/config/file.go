@@ -0,0 +1,520 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// =============================================================================
+// YAML CONFIGURATION FILE (fight-club.yaml)
+// =============================================================================
+//
+// Every setting in the file maps to an environment variable (see the `env`
+// tags). The file only fills in variables that are not already set, exactly
+// like .env files, so precedence is:
+//
+//	environment > .env > fight-club.yaml > defaults
+//
+// Secrets (stream key, Kick client secret, token key) stay in the environment.
+
+// DefaultConfigFile is the YAML configuration file looked up when
+// FIGHT_CLUB_CONFIG is not set (working directory, then parent directory)
+const DefaultConfigFile = "fight-club.yaml"
+
+// FileConfig is the schema of fight-club.yaml. Omitted keys keep their defaults.
+type FileConfig struct {
+	Video      *VideoSection             `yaml:"video"`
+	Simulation *SimulationSection        `yaml:"simulation"`
+	Limits     *LimitsSection            `yaml:"limits"`
+	Weapons    map[string]WeaponOverride `yaml:"weapons"`
+	Arena      *ArenaSection             `yaml:"arena"`
+	Chat       *ChatSection              `yaml:"chat"`
+	Streaming  *StreamingSection         `yaml:"streaming"`
+
+	path string // File the config was loaded from
+}
+
+// VideoSection is the `video:` section (shared by server and streamer)
+type VideoSection struct {
+	Width   *int `yaml:"width" env:"STREAM_WIDTH"`
+	Height  *int `yaml:"height" env:"STREAM_HEIGHT"`
+	FPS     *int `yaml:"fps" env:"STREAM_FPS"`
+	Bitrate *int `yaml:"bitrate" env:"STREAM_BITRATE"` // kbps
+}
+
+// SimulationSection is the `simulation:` section (game server tick rate, arena preset)
+type SimulationSection struct {
+	TickRate        *int    `yaml:"tick_rate" env:"GAME_TICK_RATE"`
+	MaxCatchUpTicks *int    `yaml:"max_catchup_ticks" env:"GAME_MAX_CATCHUP_TICKS"`
+	ArenaPreset     *string `yaml:"arena_preset" env:"ARENA_PRESET"`
+}
+
+// LimitsSection is the `limits:` section (see ResourceLimits)
+type LimitsSection struct {
+	MaxTotalPlayers *int `yaml:"max_total_players" env:"LIMIT_MAX_TOTAL_PLAYERS"`
+	MaxPlayers      *int `yaml:"max_players" env:"LIMIT_MAX_PLAYERS"`
+	MaxParticles    *int `yaml:"max_particles" env:"LIMIT_MAX_PARTICLES"`
+	MaxEffects      *int `yaml:"max_effects" env:"LIMIT_MAX_EFFECTS"`
+	MaxTexts        *int `yaml:"max_texts" env:"LIMIT_MAX_TEXTS"`
+	MaxTrails       *int `yaml:"max_trails" env:"LIMIT_MAX_TRAILS"`
+	MaxFlashes      *int `yaml:"max_flashes" env:"LIMIT_MAX_FLASHES"`
+	MaxProjectiles  *int `yaml:"max_projectiles" env:"LIMIT_MAX_PROJECTILES"`
+}
+
+// WeaponOverride changes the stats of a built-in weapon (`weapons.<id>:`).
+// Weapon IDs are checked by the game package when the overrides are applied.
+type WeaponOverride struct {
+	MinDamage *int     `yaml:"min_damage"`
+	MaxDamage *int     `yaml:"max_damage"`
+	Range     *float64 `yaml:"range"`
+	Cooldown  *float64 `yaml:"cooldown"` // Seconds
+	Price     *int     `yaml:"price"`
+}
+
+// ArenaSection is the `arena:` section (voting, duels, bot fill, leaderboard rotator)
+type ArenaSection struct {
+	VoteInterval        *float64 `yaml:"vote_interval" env:"ARENA_VOTE_INTERVAL"`
+	VoteDuration        *float64 `yaml:"vote_duration" env:"ARENA_VOTE_DURATION"`
+	VoteKickPoll        *bool    `yaml:"vote_kick_poll" env:"ARENA_VOTE_KICK_POLL"`
+	DuelDuration        *float64 `yaml:"duel_duration" env:"DUEL_DURATION"`
+	DuelTimeout         *float64 `yaml:"duel_challenge_timeout" env:"DUEL_CHALLENGE_TIMEOUT"`
+	DuelBonus           *int     `yaml:"duel_bonus" env:"DUEL_BONUS"`
+	DuelRingRadius      *float64 `yaml:"duel_ring_radius" env:"DUEL_RING_RADIUS"`
+	ChaosInterval       *float64 `yaml:"chaos_interval" env:"CHAOS_INTERVAL"`
+	ChaosWarning        *float64 `yaml:"chaos_warning" env:"CHAOS_WARNING"`
+	ChaosDuration       *float64 `yaml:"chaos_duration" env:"CHAOS_DURATION"`
+	ScenePhaseDuration  *float64 `yaml:"scene_phase_duration" env:"SCENE_PHASE_DURATION"`
+	SceneWeatherChance  *float64 `yaml:"scene_weather_chance" env:"SCENE_WEATHER_CHANCE"`
+	AirstrikeCost       *int     `yaml:"airstrike_cost" env:"AIRSTRIKE_COST"`
+	AirstrikeCostGrowth *float64 `yaml:"airstrike_cost_growth" env:"AIRSTRIKE_COST_GROWTH"`
+	AirstrikeCostWindow *float64 `yaml:"airstrike_cost_window" env:"AIRSTRIKE_COST_WINDOW"`
+	AirstrikeCooldown   *float64 `yaml:"airstrike_cooldown" env:"AIRSTRIKE_COOLDOWN"`
+	AirstrikeDelay      *float64 `yaml:"airstrike_delay" env:"AIRSTRIKE_DELAY"`
+	AirstrikeRadius     *float64 `yaml:"airstrike_radius" env:"AIRSTRIKE_RADIUS"`
+	AirstrikeDamage     *int     `yaml:"airstrike_damage" env:"AIRSTRIKE_DAMAGE"`
+	ShopCount           *int     `yaml:"shop_count" env:"SHOP_COUNT"`
+	ShopRadius          *float64 `yaml:"shop_radius" env:"SHOP_RADIUS"`
+	ShopOrderTimeout    *float64 `yaml:"shop_order_timeout" env:"SHOP_ORDER_TIMEOUT"`
+	RingOutMinKnockback *float64 `yaml:"ring_out_min_knockback" env:"RING_OUT_MIN_KNOCKBACK"`
+	RingOutReach        *float64 `yaml:"ring_out_reach" env:"RING_OUT_REACH"`
+	ReplaySeconds       *float64 `yaml:"replay_seconds" env:"REPLAY_SECONDS"`
+	ReplaySpeed         *float64 `yaml:"replay_speed" env:"REPLAY_SPEED"`
+	ReplayAutoDuel      *bool    `yaml:"replay_auto_duel" env:"REPLAY_AUTO_DUEL"`
+	ObjectiveScoreLimit *int     `yaml:"objective_score_limit" env:"OBJECTIVE_SCORE_LIMIT"`
+	ObjectiveCapture    *float64 `yaml:"objective_capture_time" env:"OBJECTIVE_CAPTURE_TIME"`
+	ObjectivePoint      *float64 `yaml:"objective_point_radius" env:"OBJECTIVE_POINT_RADIUS"`
+	ObjectiveBase       *float64 `yaml:"objective_base_radius" env:"OBJECTIVE_BASE_RADIUS"`
+	ObjectiveBonus      *int     `yaml:"objective_bonus" env:"OBJECTIVE_BONUS"`
+	BotFillMinPlayers   *int     `yaml:"bot_fill_min_players" env:"BOT_FILL_MIN_PLAYERS"`
+	BotFillSpawnDelay   *float64 `yaml:"bot_fill_spawn_delay" env:"BOT_FILL_SPAWN_DELAY"`
+	BotFillRespawnDelay *float64 `yaml:"bot_fill_respawn_delay" env:"BOT_FILL_RESPAWN_DELAY"`
+	JoinQueueSize       *int     `yaml:"join_queue_size" env:"JOIN_QUEUE_SIZE"`
+	JoinQueueDead       *float64 `yaml:"join_queue_dead_timeout" env:"JOIN_QUEUE_DEAD_TIMEOUT"`
+	LeaderboardRotate   *float64 `yaml:"leaderboard_rotate_interval" env:"LEADERBOARD_ROTATE_INTERVAL"`
+	HistoryRoundLength  *float64 `yaml:"history_round_length" env:"HISTORY_ROUND_LENGTH"`
+	HistoryMaxRounds    *int     `yaml:"history_max_rounds" env:"HISTORY_MAX_ROUNDS"`
+	RatingInitial       *int     `yaml:"rating_initial" env:"RATING_INITIAL"`
+	RatingKFactor       *float64 `yaml:"rating_k_factor" env:"RATING_K_FACTOR"`
+	RatingSeasonReset   *bool    `yaml:"rating_season_reset" env:"RATING_SEASON_RESET"`
+	LootDropPercent     *float64 `yaml:"loot_drop_percent" env:"LOOT_DROP_PERCENT"`
+	LootDespawn         *float64 `yaml:"loot_despawn" env:"LOOT_DESPAWN"`
+	LootPickupRadius    *float64 `yaml:"loot_pickup_radius" env:"LOOT_PICKUP_RADIUS"`
+	LootWeaponDespawn   *float64 `yaml:"loot_weapon_despawn" env:"LOOT_WEAPON_DESPAWN"`
+	ScriptsFile         *string  `yaml:"scripts_file" env:"GAME_SCRIPTS_FILE"`
+}
+
+// ChatSection is the `chat:` section (commands, webhooks, viewer economy)
+type ChatSection struct {
+	AliasesFile         *string  `yaml:"aliases_file" env:"CHAT_ALIASES_FILE"`
+	SignatureMode       *string  `yaml:"signature_mode" env:"KICK_WEBHOOK_SIGNATURE_MODE"`
+	DedupWindow         *float64 `yaml:"dedup_window" env:"KICK_DEDUP_WINDOW"`
+	DedupMaxIDs         *int     `yaml:"dedup_max_ids" env:"KICK_DEDUP_MAX_IDS"`
+	PassiveEarnAmount   *int     `yaml:"passive_earn_amount" env:"PASSIVE_EARN_AMOUNT"`
+	PassiveEarnInterval *float64 `yaml:"passive_earn_interval" env:"PASSIVE_EARN_INTERVAL"`
+	PresenceTimeout     *float64 `yaml:"presence_timeout" env:"PRESENCE_TIMEOUT"`
+	QuestReward         *int     `yaml:"quest_reward" env:"QUEST_REWARD"`
+	LagGraceWindow      *float64 `yaml:"lag_grace_window" env:"LAG_GRACE_WINDOW"`
+	GiftMin             *int     `yaml:"gift_min" env:"GIFT_MIN"`
+	GiftMax             *int     `yaml:"gift_max" env:"GIFT_MAX"`
+	GiftCooldown        *float64 `yaml:"gift_cooldown" env:"GIFT_COOLDOWN"`
+
+	RateLimitMax         *int     `yaml:"rate_limit_max" env:"CHAT_RATE_LIMIT_MAX"`
+	RateLimitWindow      *float64 `yaml:"rate_limit_window" env:"CHAT_RATE_LIMIT_WINDOW"`
+	RateLimitCooldown    *float64 `yaml:"rate_limit_cooldown" env:"CHAT_RATE_LIMIT_COOLDOWN"`
+	CommandLimits        *string  `yaml:"command_limits" env:"CHAT_COMMAND_LIMITS"`
+	SubscriberMultiplier *float64 `yaml:"subscriber_multiplier" env:"CHAT_RATE_SUBSCRIBER_MULTIPLIER"`
+	ModeratorMultiplier  *float64 `yaml:"moderator_multiplier" env:"CHAT_RATE_MODERATOR_MULTIPLIER"`
+
+	CommandLocales *string `yaml:"command_locales" env:"CHAT_COMMAND_LOCALES"`
+	CommandAliases *string `yaml:"command_aliases" env:"CHAT_COMMAND_ALIASES"`
+
+	DemoMode            *bool    `yaml:"demo_mode" env:"DEMO_MODE"`
+	DemoViewers         *int     `yaml:"demo_viewers" env:"DEMO_VIEWERS"`
+	DemoCommandInterval *float64 `yaml:"demo_command_interval" env:"DEMO_COMMAND_INTERVAL"`
+}
+
+// StreamingSection is the `streaming:` section (streamer process)
+type StreamingSection struct {
+	RTMPURL      *string           `yaml:"rtmp_url" env:"RTMP_URL"`
+	Renderer     *string           `yaml:"renderer" env:"STREAM_RENDERER"`
+	Profile      *string           `yaml:"ffmpeg_profile" env:"FFMPEG_PROFILE"`
+	IPCSocket    *string           `yaml:"ipc_socket" env:"IPC_SOCKET"`
+	IPCCodec     *string           `yaml:"ipc_codec" env:"IPC_CODEC"`
+	MusicEnabled *bool             `yaml:"music_enabled" env:"MUSIC_ENABLED"`
+	MusicVolume  *float64          `yaml:"music_volume" env:"MUSIC_VOLUME"`
+	MusicPath    *string           `yaml:"music_path" env:"MUSIC_PATH"`
+	SoundPack    *string           `yaml:"sound_pack" env:"SOUND_PACK"`
+	SoundsDir    *string           `yaml:"sounds_dir" env:"SOUNDS_DIR"`
+	SoundCacheMB *int              `yaml:"sound_cache_mb" env:"SOUND_CACHE_MB"`
+	Recording    *RecordingSection `yaml:"recording"`
+	Theme        *ThemeSection     `yaml:"theme"`
+	Portrait     *PortraitSection  `yaml:"portrait"`
+
+	AdaptiveResolution *bool    `yaml:"adaptive_resolution" env:"ADAPTIVE_RESOLUTION"`
+	AdaptiveMinScale   *float64 `yaml:"adaptive_min_scale" env:"ADAPTIVE_RESOLUTION_MIN_SCALE"`
+	Interpolation      *bool    `yaml:"interpolation" env:"RENDER_INTERPOLATION"`
+	FrameSkip          *bool    `yaml:"frame_skip" env:"RENDER_FRAME_SKIP"`
+	LOD                *bool    `yaml:"lod" env:"RENDER_LOD"`
+}
+
+// RecordingSection is the `streaming.recording:` section (local VOD recording)
+type RecordingSection struct {
+	Dir            *string  `yaml:"dir" env:"RECORDING_DIR"`
+	Format         *string  `yaml:"format" env:"RECORDING_FORMAT"`
+	SegmentSeconds *int     `yaml:"segment_seconds" env:"RECORDING_SEGMENT_SECONDS"`
+	RetainHours    *float64 `yaml:"retain_hours" env:"RECORDING_RETAIN_HOURS"`
+	MaxSizeMB      *int     `yaml:"max_size_mb" env:"RECORDING_MAX_SIZE_MB"`
+}
+
+// ThemeSection is the `streaming.theme:` section (optional on-stream widgets)
+type ThemeSection struct {
+	Minimap   *bool   `yaml:"minimap" env:"THEME_MINIMAP"`
+	Font      *string `yaml:"font" env:"THEME_FONT"`
+	EmojiFont *string `yaml:"emoji_font" env:"THEME_EMOJI_FONT"`
+
+	Leaderboard            *string  `yaml:"leaderboard" env:"THEME_LEADERBOARD"`
+	LeaderboardPageSeconds *float64 `yaml:"leaderboard_page_seconds" env:"THEME_LEADERBOARD_PAGE_SECONDS"`
+
+	AutoFrame           *bool    `yaml:"auto_frame" env:"THEME_AUTO_FRAME"`
+	AutoFrameMaxPlayers *int     `yaml:"auto_frame_max_players" env:"THEME_AUTO_FRAME_MAX_PLAYERS"`
+	AutoFrameMaxZoom    *float64 `yaml:"auto_frame_max_zoom" env:"THEME_AUTO_FRAME_MAX_ZOOM"`
+}
+
+// PortraitSection is the `streaming.portrait:` section (9:16 simulcast output)
+type PortraitSection struct {
+	RTMPURL *string `yaml:"rtmp_url" env:"PORTRAIT_RTMP_URL"`
+	Width   *int    `yaml:"width" env:"PORTRAIT_WIDTH"`
+	Height  *int    `yaml:"height" env:"PORTRAIT_HEIGHT"`
+	Bitrate *int    `yaml:"bitrate" env:"PORTRAIT_BITRATE"`
+}
+
+// LoadFile finds, validates and applies the YAML configuration file
+// (FIGHT_CLUB_CONFIG, or fight-club.yaml in the working or parent directory).
+// Returns nil without error when there is no file to load.
+func LoadFile() (*FileConfig, error) {
+	path := os.Getenv("FIGHT_CLUB_CONFIG")
+	if path == "" {
+		for _, candidate := range []string{DefaultConfigFile, filepath.Join("..", DefaultConfigFile)} {
+			if _, err := os.Stat(candidate); err == nil {
+				path = candidate
+				break
+			}
+		}
+		if path == "" {
+			return nil, nil
+		}
+	}
+
+	fc, err := LoadYAML(path)
+	if err != nil {
+		return nil, err
+	}
+	applied := fc.ApplyEnv()
+	log.Printf("📄 Loaded %s (%d settings, environment overrides the rest)", path, applied)
+	return fc, nil
+}
+
+// LoadYAML parses and validates a configuration file.
+// Unknown keys are rejected so typos don't silently fall back to defaults.
+func LoadYAML(path string) (*FileConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	fc := &FileConfig{path: path}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(fc); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%s: %s", path, strings.ReplaceAll(err.Error(), "yaml: unmarshal errors:\n ", ""))
+	}
+
+	if err := fc.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return fc, nil
+}
+
+// Validate checks value ranges, reporting every problem with its YAML path
+func (fc *FileConfig) Validate() error {
+	var problems []string
+	check := func(ok bool, key, format string, args ...interface{}) {
+		if !ok {
+			problems = append(problems, key+": "+fmt.Sprintf(format, args...))
+		}
+	}
+	intRange := func(v *int, key string, min, max int) {
+		if v != nil {
+			check(*v >= min && *v <= max, key, "must be between %d and %d (got %d)", min, max, *v)
+		}
+	}
+	floatRange := func(v *float64, key string, min, max float64) {
+		if v != nil {
+			check(*v >= min && *v <= max, key, "must be between %g and %g (got %g)", min, max, *v)
+		}
+	}
+	oneOf := func(v *string, key string, allowed ...string) {
+		if v == nil {
+			return
+		}
+		for _, a := range allowed {
+			if *v == a {
+				return
+			}
+		}
+		check(false, key, "must be one of %s (got %q)", strings.Join(allowed, ", "), *v)
+	}
+
+	if v := fc.Video; v != nil {
+		intRange(v.Width, "video.width", 320, 3840)
+		intRange(v.Height, "video.height", 240, 2160)
+		intRange(v.FPS, "video.fps", 1, 120)
+		intRange(v.Bitrate, "video.bitrate", 500, 50000)
+	}
+
+	if s := fc.Simulation; s != nil {
+		intRange(s.TickRate, "simulation.tick_rate", 10, 120)
+		intRange(s.MaxCatchUpTicks, "simulation.max_catchup_ticks", 1, 60)
+		oneOf(s.ArenaPreset, "simulation.arena_preset", "small", "standard", "large")
+	}
+
+	if l := fc.Limits; l != nil {
+		intRange(l.MaxTotalPlayers, "limits.max_total_players", 1, 10_000_000)
+		intRange(l.MaxPlayers, "limits.max_players", 1, 10_000)
+		intRange(l.MaxParticles, "limits.max_particles", 1, 100_000)
+		intRange(l.MaxEffects, "limits.max_effects", 1, 10_000)
+		intRange(l.MaxTexts, "limits.max_texts", 1, 10_000)
+		intRange(l.MaxTrails, "limits.max_trails", 1, 10_000)
+		intRange(l.MaxFlashes, "limits.max_flashes", 1, 10_000)
+		intRange(l.MaxProjectiles, "limits.max_projectiles", 1, 10_000)
+	}
+
+	for id, w := range fc.Weapons {
+		key := "weapons." + id
+		intRange(w.MinDamage, key+".min_damage", 0, 1000)
+		intRange(w.MaxDamage, key+".max_damage", 0, 1000)
+		floatRange(w.Range, key+".range", 61, 2000) // Must exceed two player radii (60) to hit
+		floatRange(w.Cooldown, key+".cooldown", 0.05, 60)
+		intRange(w.Price, key+".price", 0, 1_000_000)
+		if w.MinDamage != nil && w.MaxDamage != nil {
+			check(*w.MinDamage <= *w.MaxDamage, key, "min_damage (%d) is greater than max_damage (%d)", *w.MinDamage, *w.MaxDamage)
+		}
+	}
+
+	if a := fc.Arena; a != nil {
+		floatRange(a.VoteInterval, "arena.vote_interval", 0, 86400)
+		floatRange(a.VoteDuration, "arena.vote_duration", 1, 3600)
+		floatRange(a.DuelDuration, "arena.duel_duration", 5, 3600)
+		floatRange(a.DuelTimeout, "arena.duel_challenge_timeout", 5, 600)
+		intRange(a.DuelBonus, "arena.duel_bonus", 0, 1_000_000)
+		floatRange(a.DuelRingRadius, "arena.duel_ring_radius", 60, 1000) // Room for two fighters
+		floatRange(a.ChaosInterval, "arena.chaos_interval", 0, 86400)
+		floatRange(a.ChaosWarning, "arena.chaos_warning", 0, 120)
+		floatRange(a.ChaosDuration, "arena.chaos_duration", 5, 600)
+		floatRange(a.ScenePhaseDuration, "arena.scene_phase_duration", 0, 86400)
+		floatRange(a.SceneWeatherChance, "arena.scene_weather_chance", 0, 1)
+		intRange(a.AirstrikeCost, "arena.airstrike_cost", 0, 100_000_000)
+		floatRange(a.AirstrikeCostGrowth, "arena.airstrike_cost_growth", 1, 10)
+		floatRange(a.AirstrikeCostWindow, "arena.airstrike_cost_window", 0, 86400)
+		floatRange(a.AirstrikeCooldown, "arena.airstrike_cooldown", 0, 86400)
+		floatRange(a.AirstrikeDelay, "arena.airstrike_delay", 0.5, 30) // Time to get out of the zone
+		floatRange(a.AirstrikeRadius, "arena.airstrike_radius", 30, 1000)
+		intRange(a.AirstrikeDamage, "arena.airstrike_damage", 1, 1000)
+		intRange(a.ShopCount, "arena.shop_count", 0, MaxShops)
+		floatRange(a.ShopRadius, "arena.shop_radius", 20, 300)
+		floatRange(a.ShopOrderTimeout, "arena.shop_order_timeout", 5, 600)
+		floatRange(a.RingOutMinKnockback, "arena.ring_out_min_knockback", 1, 100)
+		floatRange(a.RingOutReach, "arena.ring_out_reach", 0, 20)
+		floatRange(a.ReplaySeconds, "arena.replay_seconds", 0, 10)
+		floatRange(a.ReplaySpeed, "arena.replay_speed", 0.1, 1)
+		intRange(a.ObjectiveScoreLimit, "arena.objective_score_limit", 0, 100_000)
+		floatRange(a.ObjectiveCapture, "arena.objective_capture_time", 0.5, 120)
+		floatRange(a.ObjectivePoint, "arena.objective_point_radius", 30, 1000) // Room for one fighter
+		floatRange(a.ObjectiveBase, "arena.objective_base_radius", 30, 1000)
+		intRange(a.ObjectiveBonus, "arena.objective_bonus", 0, 1_000_000)
+		intRange(a.BotFillMinPlayers, "arena.bot_fill_min_players", 0, 100)
+		floatRange(a.BotFillSpawnDelay, "arena.bot_fill_spawn_delay", 0, 600)
+		floatRange(a.BotFillRespawnDelay, "arena.bot_fill_respawn_delay", 0, 600)
+		intRange(a.JoinQueueSize, "arena.join_queue_size", 0, 10000)
+		floatRange(a.JoinQueueDead, "arena.join_queue_dead_timeout", 0, 3600)
+		floatRange(a.LeaderboardRotate, "arena.leaderboard_rotate_interval", 0, 3600)
+		floatRange(a.HistoryRoundLength, "arena.history_round_length", 30, 86400)
+		intRange(a.HistoryMaxRounds, "arena.history_max_rounds", 1, 1_000_000)
+		intRange(a.RatingInitial, "arena.rating_initial", 1, 5000)
+		floatRange(a.RatingKFactor, "arena.rating_k_factor", 1, 200)
+		floatRange(a.LootDropPercent, "arena.loot_drop_percent", 0, 100)
+		floatRange(a.LootDespawn, "arena.loot_despawn", 1, 600)
+		floatRange(a.LootPickupRadius, "arena.loot_pickup_radius", 5, 500)
+		floatRange(a.LootWeaponDespawn, "arena.loot_weapon_despawn", 0, 600)
+	}
+
+	if c := fc.Chat; c != nil {
+		oneOf(c.SignatureMode, "chat.signature_mode", "off", "log", "enforce")
+		floatRange(c.DedupWindow, "chat.dedup_window", 0, 86400)
+		intRange(c.DedupMaxIDs, "chat.dedup_max_ids", 1, 10_000_000)
+		intRange(c.PassiveEarnAmount, "chat.passive_earn_amount", 0, 1_000_000)
+		floatRange(c.PassiveEarnInterval, "chat.passive_earn_interval", 1, 86400)
+		floatRange(c.PresenceTimeout, "chat.presence_timeout", 1, 86400)
+		intRange(c.QuestReward, "chat.quest_reward", 0, 1_000_000)
+		floatRange(c.LagGraceWindow, "chat.lag_grace_window", 0, 2)
+		intRange(c.GiftMin, "chat.gift_min", 1, 1_000_000)
+		intRange(c.GiftMax, "chat.gift_max", 1, 1_000_000)
+		floatRange(c.GiftCooldown, "chat.gift_cooldown", 0, 86400)
+		intRange(c.RateLimitMax, "chat.rate_limit_max", 1, 1000)
+		floatRange(c.RateLimitWindow, "chat.rate_limit_window", 0.1, 3600)
+		floatRange(c.RateLimitCooldown, "chat.rate_limit_cooldown", 0, 60)
+		if c.CommandLimits != nil {
+			_, err := ParseCommandLimits(*c.CommandLimits)
+			check(err == nil, "chat.command_limits", "%v", err)
+		}
+		floatRange(c.SubscriberMultiplier, "chat.subscriber_multiplier", 1, 100)
+		floatRange(c.ModeratorMultiplier, "chat.moderator_multiplier", 1, 100)
+		if c.CommandLocales != nil {
+			for _, locale := range ParseCommandLocales(*c.CommandLocales) {
+				oneOf(&locale, "chat.command_locales", "es", "pt")
+			}
+		}
+		if c.CommandAliases != nil {
+			_, err := ParseCommandExtras(*c.CommandAliases)
+			check(err == nil, "chat.command_aliases", "%v", err)
+		}
+		intRange(c.DemoViewers, "chat.demo_viewers", 1, 500)
+		floatRange(c.DemoCommandInterval, "chat.demo_command_interval", 0.01, 60)
+	}
+
+	if s := fc.Streaming; s != nil {
+		if s.RTMPURL != nil {
+			check(strings.HasPrefix(*s.RTMPURL, "rtmp://") || strings.HasPrefix(*s.RTMPURL, "rtmps://"),
+				"streaming.rtmp_url", "must start with rtmp:// or rtmps:// (got %q)", *s.RTMPURL)
+		}
+		oneOf(s.Renderer, "streaming.renderer", "gg", "atlas")
+		oneOf(s.Profile, "streaming.ffmpeg_profile", "720p30-low-latency", "1080p60-quality", "nvenc-p4", "test-null-output")
+		oneOf(s.IPCCodec, "streaming.ipc_codec", "gob", "protobuf")
+		floatRange(s.MusicVolume, "streaming.music_volume", 0, 1)
+		intRange(s.SoundCacheMB, "streaming.sound_cache_mb", 1, 4096)
+		floatRange(s.AdaptiveMinScale, "streaming.adaptive_min_scale", 0.5, 1)
+		if r := s.Recording; r != nil {
+			oneOf(r.Format, "streaming.recording.format", "mkv", "mp4")
+			intRange(r.SegmentSeconds, "streaming.recording.segment_seconds", 10, 86400)
+			floatRange(r.RetainHours, "streaming.recording.retain_hours", 0, 24*365)
+			intRange(r.MaxSizeMB, "streaming.recording.max_size_mb", 0, 10_000_000)
+		}
+		if t := s.Theme; t != nil {
+			oneOf(t.Leaderboard, "streaming.theme.leaderboard", "top", "pages", "columns")
+			floatRange(t.LeaderboardPageSeconds, "streaming.theme.leaderboard_page_seconds", 1, 60)
+			intRange(t.AutoFrameMaxPlayers, "streaming.theme.auto_frame_max_players", 1, 16)
+			floatRange(t.AutoFrameMaxZoom, "streaming.theme.auto_frame_max_zoom", 1, 4)
+		}
+		if p := s.Portrait; p != nil {
+			if p.RTMPURL != nil && *p.RTMPURL != "" {
+				check(strings.HasPrefix(*p.RTMPURL, "rtmp://") || strings.HasPrefix(*p.RTMPURL, "rtmps://"),
+					"streaming.portrait.rtmp_url", "must start with rtmp:// or rtmps:// (got %q)", *p.RTMPURL)
+			}
+			intRange(p.Width, "streaming.portrait.width", 360, 2160)
+			intRange(p.Height, "streaming.portrait.height", 640, 3840)
+			intRange(p.Bitrate, "streaming.portrait.bitrate", 500, 20000)
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid configuration:\n  %s", strings.Join(problems, "\n  "))
+	}
+	return nil
+}
+
+// ApplyEnv sets the environment variable of every setting in the file that
+// isn't already set in the environment. Returns the number of settings applied.
+func (fc *FileConfig) ApplyEnv() int {
+	applied := 0
+	var walk func(v reflect.Value)
+	walk = func(v reflect.Value) {
+		if v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return
+			}
+			v = v.Elem()
+		}
+		if v.Kind() != reflect.Struct {
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			field, value := v.Type().Field(i), v.Field(i)
+			env := field.Tag.Get("env")
+			if env == "" {
+				walk(value) // Nested section
+				continue
+			}
+			if value.IsNil() {
+				continue
+			}
+			if _, set := os.LookupEnv(env); set {
+				continue // Environment wins
+			}
+			os.Setenv(env, formatEnvValue(value.Elem()))
+			applied++
+		}
+	}
+
+	walk(reflect.ValueOf(fc.Video))
+	walk(reflect.ValueOf(fc.Limits))
+	walk(reflect.ValueOf(fc.Arena))
+	walk(reflect.ValueOf(fc.Chat))
+	walk(reflect.ValueOf(fc.Streaming))
+
+	// Weapon overrides have no environment variables
+	for _, w := range fc.Weapons {
+		if w != (WeaponOverride{}) {
+			applied++
+		}
+	}
+	return applied
+}
+
+// Path returns the file the configuration was loaded from
+func (fc *FileConfig) Path() string {
+	return fc.path
+}
+
+// formatEnvValue renders a setting the way the *FromEnv functions parse it
+func formatEnvValue(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.Int:
+		return strconv.Itoa(int(v.Int()))
+	case reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64)
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	default:
+		return v.String()
+	}
+}
@@ -0,0 +1,178 @@
+package game
+
+import (
+	"fmt"
+	"log"
+	"math"
+
+	"fight-club/internal/config"
+)
+
+// AirstrikeConfig is an alias for config.AirstrikeConfig (SSOT)
+type AirstrikeConfig = config.AirstrikeConfig
+
+// MaxAirstrikes is how many airstrikes can be incoming at once
+const MaxAirstrikes = 3
+
+// AirstrikeSnapshot is an incoming airstrike for rendering
+type AirstrikeSnapshot struct {
+	X, Y     float64 // Impact point
+	Radius   float64
+	Progress float64 // 0 (called) .. 1 (impact)
+	Caller   string
+}
+
+// airstrike is a called airstrike waiting for its impact
+type airstrike struct {
+	x, y    float64
+	elapsed float64
+	caller  string
+}
+
+// AirstrikeCost returns the current price of an airstrike: the base cost
+// grows by CostGrowth for every airstrike called in the last CostWindow
+// seconds. 0 means airstrikes are disabled.
+func (e *Engine) AirstrikeCost() int {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.airstrikeCostLocked()
+}
+
+// airstrikeCostLocked returns the current price (caller holds e.mu)
+func (e *Engine) airstrikeCostLocked() int {
+	cfg := e.airstrikeCfg
+	if cfg.Cost <= 0 {
+		return 0
+	}
+	windowStart := e.tickCount - int64(cfg.CostWindow*float64(e.tickRate))
+	recent := 0
+	for _, t := range e.airstrikeTicks {
+		if t > windowStart {
+			recent++
+		}
+	}
+	return int(math.Round(float64(cfg.Cost) * math.Pow(max(cfg.CostGrowth, 1), float64(recent))))
+}
+
+// Airstrike lets a viewer pay from their wallet to bomb a spot (!airstrike).
+// The target is a fighter's current position (target != "") or x, y. The
+// danger zone shows for Delay seconds, so fighters can get out, then deals
+// up to Damage to everyone inside. The caller doesn't need to be fighting.
+// Returns the price paid.
+func (e *Engine) Airstrike(caller, target string, x, y float64) (int, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	cfg := e.airstrikeCfg
+	cost := e.airstrikeCostLocked()
+	if cost == 0 {
+		return 0, fmt.Errorf("airstrikes are disabled")
+	}
+	if e.tickCount < e.airstrikeReady[caller] {
+		remaining := float64(e.airstrikeReady[caller]-e.tickCount) / float64(e.tickRate)
+		return 0, fmt.Errorf("on cooldown (%.0fs)", remaining)
+	}
+	if len(e.airstrikes) >= MaxAirstrikes {
+		return 0, fmt.Errorf("the sky is busy, try again in a few seconds")
+	}
+
+	if target != "" {
+		p, ok := e.players[target]
+		if !ok || p.IsDead {
+			return 0, fmt.Errorf("'%s' is not fighting", target)
+		}
+		if p.InDuel {
+			return 0, fmt.Errorf("'%s' is in a duel", target)
+		}
+		x, y = p.X, p.Y
+	} else if x < 0 || y < 0 || x > e.worldWidth || y > e.worldHeight {
+		return 0, fmt.Errorf("target must be within 0,0 and %.0f,%.0f", e.worldWidth, e.worldHeight)
+	}
+
+	if !e.wallets.Spend(caller, cost) {
+		return 0, fmt.Errorf("an airstrike costs $%d, you have $%d", cost, e.wallets.Balance(caller))
+	}
+
+	e.airstrikes = append(e.airstrikes, airstrike{x: x, y: y, caller: caller})
+	e.airstrikeTicks = append(e.airstrikeTicks, e.tickCount)
+	e.airstrikeReady[caller] = e.tickCount + int64(cfg.Cooldown*float64(e.tickRate))
+
+	if len(e.texts) < e.limits.MaxTexts {
+		e.texts = append(e.texts, &FloatingText{
+			X:     x,
+			Y:     y - cfg.Radius - 10,
+			Text:  "AIRSTRIKE INCOMING",
+			Color: "#ff3b1f",
+			Alpha: 1.0,
+			VY:    -0.5,
+		})
+	}
+
+	e.eventLog.EmitSimple(EventTypeAirstrike, uint64(e.tickCount), caller,
+		AirstrikePayload{Caller: caller, Phase: "called", X: x, Y: y, Radius: cfg.Radius, Cost: cost})
+
+	log.Printf("✈️ %s called an airstrike on (%.0f, %.0f) for $%d", caller, x, y, cost)
+	return cost, nil
+}
+
+// updateAirstrikes resolves airstrikes whose delay ran out and forgets
+// strikes that left the cost window. Caller holds e.mu.
+func (e *Engine) updateAirstrikes(deltaTime float64) {
+	incoming := e.airstrikes[:0]
+	for _, s := range e.airstrikes {
+		s.elapsed += deltaTime
+		if s.elapsed < e.airstrikeCfg.Delay {
+			incoming = append(incoming, s)
+			continue
+		}
+		e.airstrikeImpact(s)
+	}
+	e.airstrikes = incoming
+
+	// Compact in place so the backing array is reused instead of growing
+	windowStart := e.tickCount - int64(e.airstrikeCfg.CostWindow*float64(e.tickRate))
+	expired := 0
+	for expired < len(e.airstrikeTicks) && e.airstrikeTicks[expired] <= windowStart {
+		expired++
+	}
+	if expired > 0 {
+		e.airstrikeTicks = append(e.airstrikeTicks[:0], e.airstrikeTicks[expired:]...)
+	}
+}
+
+// airstrikeImpact damages fighters in the blast, falling off to half at the
+// edge. Duelists are left alone. Caller holds e.mu.
+func (e *Engine) airstrikeImpact(s airstrike) {
+	cfg := e.airstrikeCfg
+	hits := 0
+	for _, p := range e.playerSlice {
+		dist := math.Hypot(p.X-s.x, p.Y-s.y)
+		if p.IsDead || p.InDuel || dist > cfg.Radius+PlayerRadius {
+			continue
+		}
+		damage := int(math.Round(float64(cfg.Damage) * (1 - 0.5*min(dist/cfg.Radius, 1))))
+		if e.hazardDamage(p, damage, s.caller+"'s airstrike") {
+			hits++
+		}
+	}
+
+	e.emitParticles("airstrike", s.x, s.y, "#ff3b1f", 0)
+	e.emitParticles("debris", s.x, s.y, "#5a4a3a", 12)
+	e.CreateFlash(s.x, s.y, "#ff3b1f", 3.0)
+	e.AddShake(10.0)
+	e.eventLog.EmitSimple(EventTypeAirstrike, uint64(e.tickCount), s.caller,
+		AirstrikePayload{Caller: s.caller, Phase: "impact", X: s.x, Y: s.y, Radius: cfg.Radius, Hits: hits})
+}
+
+// airstrikeSnapshot copies the incoming airstrikes into the snapshot. Caller holds e.mu.
+func (e *Engine) airstrikeSnapshot(snap *GameSnapshot) {
+	for _, s := range e.airstrikes {
+		snap.Airstrikes = append(snap.Airstrikes, AirstrikeSnapshot{
+			X:        s.x,
+			Y:        s.y,
+			Radius:   e.airstrikeCfg.Radius,
+			Progress: min(s.elapsed/e.airstrikeCfg.Delay, 1),
+			Caller:   s.caller,
+		})
+	}
+}
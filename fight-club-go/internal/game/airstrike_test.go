@@ -0,0 +1,114 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+// testAirstrike makes $1000 airstrikes doubling in price
+var testAirstrike = AirstrikeConfig{
+	Cost: 1000, CostGrowth: 2, CostWindow: 60,
+	Cooldown: 10, Delay: 2, Radius: 100, Damage: 60,
+}
+
+// fundWallet gives a viewer a wallet with balance
+func fundWallet(e *Engine, username string, balance int) {
+	e.wallets.Register(username)
+	e.wallets.Credit(username, balance)
+}
+
+// TestAirstrikeCost verifies the wallet charge, price scaling, cooldown and window
+func TestAirstrikeCost(t *testing.T) {
+	engine := newTestEngineWith(func(cfg *EngineConfig) { cfg.Airstrike = testAirstrike })
+	fundWallet(engine, "ana", 1500)
+	fundWallet(engine, "leo", 5000)
+
+	if _, err := engine.Airstrike("nobody", "", 100, 100); err == nil {
+		t.Error("Expected an airstrike without a wallet to fail")
+	}
+	if _, err := engine.Airstrike("ana", "", 2000, 100); err == nil {
+		t.Error("Expected an airstrike outside the arena to fail")
+	}
+	if _, err := engine.Airstrike("ana", "ghost", 0, 0); err == nil {
+		t.Error("Expected an airstrike on a missing fighter to fail")
+	}
+
+	cost, err := engine.Airstrike("ana", "", 300, 300)
+	if err != nil || cost != 1000 {
+		t.Fatalf("Expected a $1000 airstrike, got $%d (%v)", cost, err)
+	}
+	if engine.wallets.Balance("ana") != 500 {
+		t.Errorf("Expected $500 left, got $%d", engine.wallets.Balance("ana"))
+	}
+	if _, err := engine.Airstrike("ana", "", 300, 300); err == nil || !strings.Contains(err.Error(), "cooldown") {
+		t.Errorf("Expected cooldown error, got %v", err)
+	}
+
+	// Each recent airstrike doubles the price
+	if got := engine.AirstrikeCost(); got != 2000 {
+		t.Errorf("Expected the next airstrike at $2000, got $%d", got)
+	}
+	if cost, err := engine.Airstrike("leo", "", 600, 300); err != nil || cost != 2000 {
+		t.Errorf("Expected a $2000 airstrike, got $%d (%v)", cost, err)
+	}
+
+	// Broke after the cooldown; the price drops back once the window passes
+	stepEngine(engine, 11, engine.updateAirstrikes)
+	if _, err := engine.Airstrike("ana", "", 300, 300); err == nil || !strings.Contains(err.Error(), "costs $4000") {
+		t.Errorf("Expected insufficient funds error, got %v", err)
+	}
+	stepEngine(engine, 50, engine.updateAirstrikes)
+	if got := engine.AirstrikeCost(); got != 1000 || len(engine.airstrikeTicks) != 0 {
+		t.Errorf("Expected the price back at $1000, got $%d (%d strikes in the window)", got, len(engine.airstrikeTicks))
+	}
+}
+
+// TestAirstrikeImpact verifies the telegraph delay, falloff damage and duel immunity
+func TestAirstrikeImpact(t *testing.T) {
+	engine := newTestEngineWith(func(cfg *EngineConfig) { cfg.Airstrike = testAirstrike })
+	fundWallet(engine, "viewer", 10000)
+	center := engine.AddPlayer("center", PlayerOptions{})
+	edge := engine.AddPlayer("edge", PlayerOptions{})
+	far := engine.AddPlayer("far", PlayerOptions{})
+	duelist := engine.AddPlayer("duelist", PlayerOptions{})
+	for _, p := range []*Player{center, edge, far, duelist} {
+		p.SpawnProtection = false
+		p.HP, p.MaxHP = 200, 200
+	}
+	center.X, center.Y = 400, 400
+	edge.X, edge.Y = 500, 400
+	far.X, far.Y = 1000, 100
+	duelist.X, duelist.Y, duelist.InDuel = 410, 400, true
+
+	if _, err := engine.Airstrike("viewer", "duelist", 0, 0); err == nil {
+		t.Error("Expected an airstrike on a duelist to fail")
+	}
+	if _, err := engine.Airstrike("viewer", "center", 0, 0); err != nil {
+		t.Fatalf("Airstrike failed: %v", err)
+	}
+	center.X += 5 // Moving after the call doesn't move the strike
+
+	var snap GameSnapshot
+	engine.airstrikeSnapshot(&snap)
+	if snaps := snap.Airstrikes; len(snaps) != 1 || snaps[0].X != 400 {
+		t.Fatalf("Expected one incoming airstrike at x=400, got %+v", snaps)
+	}
+	stepEngine(engine, 1.5, engine.updateAirstrikes)
+	if center.HP != center.MaxHP {
+		t.Error("Expected no damage before the delay runs out")
+	}
+
+	stepEngine(engine, 1, engine.updateAirstrikes)
+	if len(engine.airstrikes) != 0 {
+		t.Error("Expected the airstrike resolved")
+	}
+	if center.HP >= center.MaxHP-55 {
+		t.Errorf("Expected the center fighter hit for about 60, at %d HP", center.HP)
+	}
+	if lost := edge.MaxHP - edge.HP; lost != 30 {
+		t.Errorf("Expected the edge fighter hit for half damage (30), lost %d", lost)
+	}
+	if far.HP != far.MaxHP || duelist.HP != duelist.MaxHP {
+		t.Errorf("Expected the distant fighter and the duelist untouched, at %d/%d HP", far.HP, duelist.HP)
+	}
+}
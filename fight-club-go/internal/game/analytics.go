@@ -0,0 +1,389 @@
+package game
+
+import (
+	"bytes"
+	"encoding/json"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"fight-club/internal/config"
+)
+
+// AnalyticsConfig is an alias for config.AnalyticsConfig (SSOT)
+type AnalyticsConfig = config.AnalyticsConfig
+
+const (
+	analyticsFightGap   = 5.0 // Seconds without damage before a victim's fight is over
+	analyticsTopZones   = 5   // Most lethal zones reported
+	analyticsPixelsCell = 16  // Heatmap PNG pixels per grid cell
+)
+
+// AnalyticsZone is one heatmap cell, located by its center in world pixels
+type AnalyticsZone struct {
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+	Kills  int     `json:"kills"`
+	Damage int     `json:"damage"`
+}
+
+// WeaponStats is the combat record of one weapon.
+// WinRate = kills / (kills + deaths while holding it).
+type WeaponStats struct {
+	Weapon  string  `json:"weapon"`
+	Kills   int     `json:"kills"`
+	Deaths  int     `json:"deaths"`
+	Damage  int     `json:"damage"`
+	WinRate float64 `json:"winRate"`
+}
+
+// AnalyticsStats is the post-stream combat summary (/api/analytics)
+type AnalyticsStats struct {
+	Since           time.Time       `json:"since"`
+	Kills           int             `json:"kills"`
+	Damage          int             `json:"damage"`
+	Fights          int             `json:"fights"` // Fights that ended in a kill
+	AvgFightSeconds float64         `json:"avgFightSeconds"`
+	LethalZones     []AnalyticsZone `json:"lethalZones"`
+	Weapons         []WeaponStats   `json:"weapons"`
+	CellSize        float64         `json:"cellSize"`
+	Cols            int             `json:"cols"`
+	Rows            int             `json:"rows"`
+}
+
+// analyticsFight tracks damage a victim has taken in the current fight (in ticks)
+type analyticsFight struct {
+	start, last int64
+}
+
+// CombatAnalytics aggregates damage and kill positions into a grid and keeps
+// fight and weapon stats for the session. Stats are served by the API and
+// periodically dumped as a heatmap PNG plus JSON for post-stream review.
+type CombatAnalytics struct {
+	mu         sync.RWMutex
+	cfg        AnalyticsConfig
+	since      time.Time
+	cols, rows int
+	damage     []int // Per cell, row-major
+	kills      []int // Per cell, row-major
+	weapons    map[string]*WeaponStats
+	fights     map[string]analyticsFight // Victim ID -> current fight
+	tickRate   float64
+
+	totalKills      int
+	totalDamage     int
+	fightCount      int
+	fightSecondsSum float64
+	dirty           bool
+
+	running bool
+	stopCh  chan struct{}
+	wg      sync.WaitGroup
+}
+
+// NewCombatAnalytics creates an analytics grid covering the world
+func NewCombatAnalytics(cfg AnalyticsConfig, worldWidth, worldHeight float64, tickRate int) *CombatAnalytics {
+	if cfg.CellSize <= 0 {
+		cfg.CellSize = DefaultAnalytics.CellSize
+	}
+	if tickRate <= 0 {
+		tickRate = 30
+	}
+	cols := int(math.Ceil(worldWidth / cfg.CellSize))
+	rows := int(math.Ceil(worldHeight / cfg.CellSize))
+	return &CombatAnalytics{
+		cfg:      cfg,
+		since:    time.Now(),
+		cols:     cols,
+		rows:     rows,
+		damage:   make([]int, cols*rows),
+		kills:    make([]int, cols*rows),
+		weapons:  make(map[string]*WeaponStats),
+		fights:   make(map[string]analyticsFight),
+		tickRate: float64(tickRate),
+		stopCh:   make(chan struct{}),
+	}
+}
+
+// resize re-grids the heatmap for a new world size and tick rate (arena
+// preset change); heat recorded on the old grid is dropped
+func (ca *CombatAnalytics) resize(worldWidth, worldHeight float64, tickRate int) {
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
+
+	ca.cols = int(math.Ceil(worldWidth / ca.cfg.CellSize))
+	ca.rows = int(math.Ceil(worldHeight / ca.cfg.CellSize))
+	ca.damage = make([]int, ca.cols*ca.rows)
+	ca.kills = make([]int, ca.cols*ca.rows)
+	ca.tickRate = float64(max(1, tickRate))
+}
+
+// cell returns the grid index for a world position (clamped to the grid)
+func (ca *CombatAnalytics) cell(x, y float64) int {
+	col := int(x / ca.cfg.CellSize)
+	row := int(y / ca.cfg.CellSize)
+	col = max(0, min(ca.cols-1, col))
+	row = max(0, min(ca.rows-1, row))
+	return row*ca.cols + col
+}
+
+// weaponLocked returns the stats entry for a weapon (caller holds lock)
+func (ca *CombatAnalytics) weaponLocked(id string) *WeaponStats {
+	ws, ok := ca.weapons[id]
+	if !ok {
+		ws = &WeaponStats{Weapon: id}
+		ca.weapons[id] = ws
+	}
+	return ws
+}
+
+// RecordDamage records a hit landing on a victim at (x, y)
+func (ca *CombatAnalytics) RecordDamage(victimID, weapon string, x, y float64, damage int, tick int64) {
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
+
+	ca.damage[ca.cell(x, y)] += damage
+	ca.totalDamage += damage
+	ca.weaponLocked(weapon).Damage += damage
+
+	// Forget fights of victims that escaped (or left the arena)
+	if len(ca.fights) > 256 {
+		for id, f := range ca.fights {
+			if float64(tick-f.last)/ca.tickRate > analyticsFightGap {
+				delete(ca.fights, id)
+			}
+		}
+	}
+
+	fight, ok := ca.fights[victimID]
+	if !ok || float64(tick-fight.last)/ca.tickRate > analyticsFightGap {
+		fight.start = tick
+	}
+	fight.last = tick
+	ca.fights[victimID] = fight
+	ca.dirty = true
+}
+
+// RecordKill records a kill at the victim's position (x, y)
+func (ca *CombatAnalytics) RecordKill(victimID, killerWeapon, victimWeapon string, x, y float64, tick int64) {
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
+
+	ca.kills[ca.cell(x, y)]++
+	ca.totalKills++
+	ca.weaponLocked(killerWeapon).Kills++
+	ca.weaponLocked(victimWeapon).Deaths++
+
+	if fight, ok := ca.fights[victimID]; ok {
+		ca.fightCount++
+		ca.fightSecondsSum += float64(tick-fight.start) / ca.tickRate
+		delete(ca.fights, victimID)
+	}
+	ca.dirty = true
+}
+
+// Stats returns the session combat summary
+func (ca *CombatAnalytics) Stats() AnalyticsStats {
+	ca.mu.RLock()
+	defer ca.mu.RUnlock()
+
+	stats := AnalyticsStats{
+		Since:       ca.since,
+		Kills:       ca.totalKills,
+		Damage:      ca.totalDamage,
+		Fights:      ca.fightCount,
+		CellSize:    ca.cfg.CellSize,
+		Cols:        ca.cols,
+		Rows:        ca.rows,
+		LethalZones: []AnalyticsZone{},
+		Weapons:     make([]WeaponStats, 0, len(ca.weapons)),
+	}
+	if ca.fightCount > 0 {
+		stats.AvgFightSeconds = math.Round(ca.fightSecondsSum/float64(ca.fightCount)*100) / 100
+	}
+
+	// Most lethal zones: kills first, damage breaks ties
+	cells := make([]int, 0, len(ca.kills))
+	for i := range ca.kills {
+		if ca.kills[i] > 0 || ca.damage[i] > 0 {
+			cells = append(cells, i)
+		}
+	}
+	sort.Slice(cells, func(a, b int) bool {
+		ia, ib := cells[a], cells[b]
+		if ca.kills[ia] != ca.kills[ib] {
+			return ca.kills[ia] > ca.kills[ib]
+		}
+		if ca.damage[ia] != ca.damage[ib] {
+			return ca.damage[ia] > ca.damage[ib]
+		}
+		return ia < ib
+	})
+	for _, i := range cells[:min(len(cells), analyticsTopZones)] {
+		stats.LethalZones = append(stats.LethalZones, AnalyticsZone{
+			X:      (float64(i%ca.cols) + 0.5) * ca.cfg.CellSize,
+			Y:      (float64(i/ca.cols) + 0.5) * ca.cfg.CellSize,
+			Kills:  ca.kills[i],
+			Damage: ca.damage[i],
+		})
+	}
+
+	for _, ws := range ca.weapons {
+		entry := *ws
+		if total := entry.Kills + entry.Deaths; total > 0 {
+			entry.WinRate = math.Round(float64(entry.Kills)/float64(total)*1000) / 1000
+		}
+		stats.Weapons = append(stats.Weapons, entry)
+	}
+	sort.Slice(stats.Weapons, func(a, b int) bool {
+		if stats.Weapons[a].Kills != stats.Weapons[b].Kills {
+			return stats.Weapons[a].Kills > stats.Weapons[b].Kills
+		}
+		return stats.Weapons[a].Weapon < stats.Weapons[b].Weapon
+	})
+
+	return stats
+}
+
+// Heatmap renders damage intensity per cell (dark -> red -> yellow), with
+// cells where kills happened outlined in white
+func (ca *CombatAnalytics) Heatmap() *image.RGBA {
+	ca.mu.RLock()
+	defer ca.mu.RUnlock()
+
+	img := image.NewRGBA(image.Rect(0, 0, ca.cols*analyticsPixelsCell, ca.rows*analyticsPixelsCell))
+	maxDamage := 0
+	for _, d := range ca.damage {
+		maxDamage = max(maxDamage, d)
+	}
+
+	for i := range ca.damage {
+		heat := 0.0
+		if maxDamage > 0 {
+			heat = math.Sqrt(float64(ca.damage[i]) / float64(maxDamage)) // sqrt keeps quiet zones visible
+		}
+		fill := heatColor(heat)
+		x0, y0 := (i%ca.cols)*analyticsPixelsCell, (i/ca.cols)*analyticsPixelsCell
+		for y := 0; y < analyticsPixelsCell; y++ {
+			for x := 0; x < analyticsPixelsCell; x++ {
+				c := fill
+				if ca.kills[i] > 0 && (x == 0 || y == 0 || x == analyticsPixelsCell-1 || y == analyticsPixelsCell-1) {
+					c = color.RGBA{255, 255, 255, 255}
+				}
+				img.SetRGBA(x0+x, y0+y, c)
+			}
+		}
+	}
+	return img
+}
+
+// heatColor maps 0..1 to dark blue-gray -> red -> yellow
+func heatColor(t float64) color.RGBA {
+	if t <= 0 {
+		return color.RGBA{18, 18, 24, 255}
+	}
+	if t < 0.5 {
+		f := t / 0.5
+		return color.RGBA{uint8(40 + 215*f), uint8(20 * (1 - f)), uint8(60 * (1 - f)), 255}
+	}
+	f := (t - 0.5) / 0.5
+	return color.RGBA{255, uint8(220 * f), 0, 255}
+}
+
+// WriteHeatmapPNG encodes the heatmap as PNG
+func (ca *CombatAnalytics) WriteHeatmapPNG(w io.Writer) error {
+	return png.Encode(w, ca.Heatmap())
+}
+
+// Start begins periodic dumps to cfg.Dir (no-op without a directory)
+func (ca *CombatAnalytics) Start() {
+	ca.mu.Lock()
+	if ca.running || ca.cfg.Dir == "" {
+		ca.mu.Unlock()
+		return
+	}
+	ca.running = true
+	ca.mu.Unlock()
+
+	interval := time.Duration(ca.cfg.Interval * float64(time.Second))
+	if interval <= 0 {
+		interval = time.Duration(DefaultAnalytics.Interval * float64(time.Second))
+	}
+
+	ca.wg.Add(1)
+	go func() {
+		defer ca.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ca.stopCh:
+				return
+			case <-ticker.C:
+				ca.Dump()
+			}
+		}
+	}()
+}
+
+// Stop stops periodic dumps and writes a final dump
+func (ca *CombatAnalytics) Stop() {
+	ca.mu.Lock()
+	if !ca.running {
+		ca.mu.Unlock()
+		return
+	}
+	ca.running = false
+	ca.mu.Unlock()
+
+	close(ca.stopCh)
+	ca.wg.Wait()
+	ca.Dump()
+}
+
+// Dump writes combat-<session>-heatmap.png and combat-<session>-stats.json
+// to cfg.Dir if anything changed since the last dump
+func (ca *CombatAnalytics) Dump() {
+	ca.mu.Lock()
+	if !ca.dirty || ca.cfg.Dir == "" {
+		ca.mu.Unlock()
+		return
+	}
+	ca.dirty = false
+	ca.mu.Unlock()
+
+	if err := os.MkdirAll(ca.cfg.Dir, 0o755); err != nil {
+		log.Printf("⚠️ Failed to create analytics dir: %v", err)
+		return
+	}
+	prefix := filepath.Join(ca.cfg.Dir, "combat-"+ca.since.Format("20060102-150405"))
+
+	stats, err := json.MarshalIndent(ca.Stats(), "", "  ")
+	if err != nil {
+		log.Printf("⚠️ Failed to marshal analytics: %v", err)
+		return
+	}
+	if err := os.WriteFile(prefix+"-stats.json", stats, 0o644); err != nil {
+		log.Printf("⚠️ Failed to save analytics stats: %v", err)
+		return
+	}
+
+	var heatmap bytes.Buffer
+	if err := ca.WriteHeatmapPNG(&heatmap); err != nil {
+		log.Printf("⚠️ Failed to render heatmap: %v", err)
+		return
+	}
+	if err := os.WriteFile(prefix+"-heatmap.png", heatmap.Bytes(), 0o644); err != nil {
+		log.Printf("⚠️ Failed to save heatmap: %v", err)
+		return
+	}
+	log.Printf("📊 Combat analytics saved to %s-*", prefix)
+}
@@ -0,0 +1,74 @@
+package game
+
+import (
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestCombatAnalyticsStats verifies fight durations, lethal zones and weapon winrates
+func TestCombatAnalyticsStats(t *testing.T) {
+	ca := NewCombatAnalytics(AnalyticsConfig{CellSize: 100}, 1000, 500, 20)
+
+	// A 3 second sword fight ending in a kill at (150, 250)
+	ca.RecordDamage("bob", "sword", 140, 240, 30, 100)
+	ca.RecordDamage("bob", "sword", 150, 250, 40, 160)
+	ca.RecordKill("bob", "sword", "fists", 150, 250, 160)
+
+	// A 1 second fight; the earlier hit is too old to belong to it
+	ca.RecordDamage("amy", "bow", 900, 50, 20, 0)
+	ca.RecordDamage("amy", "bow", 900, 50, 20, 300)
+	ca.RecordKill("amy", "bow", "sword", 900, 50, 320)
+
+	stats := ca.Stats()
+	if stats.Kills != 2 || stats.Damage != 110 || stats.Fights != 2 {
+		t.Fatalf("Unexpected totals: %+v", stats)
+	}
+	if stats.AvgFightSeconds != 2 {
+		t.Errorf("Expected average fight of 2s, got %v", stats.AvgFightSeconds)
+	}
+	if top := stats.LethalZones[0]; top.X != 150 || top.Y != 250 || top.Damage != 70 {
+		t.Errorf("Expected the sword fight cell as most lethal zone, got %+v", top)
+	}
+
+	winRates := make(map[string]float64)
+	for _, w := range stats.Weapons {
+		winRates[w.Weapon] = w.WinRate
+	}
+	if winRates["sword"] != 0.5 || winRates["bow"] != 1 || winRates["fists"] != 0 {
+		t.Errorf("Unexpected weapon winrates: %v", winRates)
+	}
+}
+
+// TestCombatAnalyticsDump verifies the heatmap PNG and stats JSON are written
+func TestCombatAnalyticsDump(t *testing.T) {
+	dir := t.TempDir()
+	ca := NewCombatAnalytics(AnalyticsConfig{Dir: dir, CellSize: 40}, 1280, 720, 30)
+	ca.Dump()
+	if files, _ := os.ReadDir(dir); len(files) != 0 {
+		t.Fatalf("Expected nothing written without combat, got %d files", len(files))
+	}
+
+	ca.RecordDamage("bob", "axe", 5000, -20, 45, 1) // Off-world positions clamp to the edge
+	ca.Dump()
+
+	pngs, _ := filepath.Glob(filepath.Join(dir, "combat-*-heatmap.png"))
+	jsons, _ := filepath.Glob(filepath.Join(dir, "combat-*-stats.json"))
+	if len(pngs) != 1 || len(jsons) != 1 {
+		t.Fatalf("Expected one heatmap and one stats file, got %v %v", pngs, jsons)
+	}
+
+	f, err := os.Open(pngs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	img, err := png.Decode(f)
+	if err != nil {
+		t.Fatalf("Invalid heatmap PNG: %v", err)
+	}
+	if b := img.Bounds(); b.Dx() != 32*analyticsPixelsCell || b.Dy() != 18*analyticsPixelsCell {
+		t.Errorf("Unexpected heatmap size %v", b)
+	}
+}
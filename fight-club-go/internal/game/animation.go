@@ -26,41 +26,48 @@ const (
 // WeaponAnimationConfig defines visual and timing properties per weapon
 // This separates animation/feel from damage/balance in weapons.go
 type WeaponAnimationConfig struct {
-	WeaponID string
+	WeaponID string `yaml:"-"`
 
 	// Attack Timing (in ticks at 20 TPS)
-	WindUpTicks   int // Anticipation duration
-	ActiveTicks   int // Damage window
-	RecoveryTicks int // Follow-through duration
+	WindUpTicks   int `yaml:"wind_up_ticks"`  // Anticipation duration
+	ActiveTicks   int `yaml:"active_ticks"`   // Damage window
+	RecoveryTicks int `yaml:"recovery_ticks"` // Follow-through duration
 
 	// Motion
-	LungeDistance  float64 // Forward movement on attack (pixels)
-	RecoilDistance float64 // Backward movement after attack (pixels)
+	LungeDistance  float64 `yaml:"lunge_distance"`  // Forward movement on attack (pixels)
+	RecoilDistance float64 `yaml:"recoil_distance"` // Backward movement after attack (pixels)
 
 	// Visual Effect Type
-	TrailType  TrailType // None, Arc, Line, Radial, Projectile
-	TrailColor string    // Override weapon color if set (empty = use weapon color)
-	TrailWidth float64   // Arc width in radians, or line width in pixels
-	TrailCount int       // Number of trail segments to render
+	TrailType  TrailType `yaml:"trail_type"`  // None, Arc, Line, Radial, Projectile
+	TrailColor string    `yaml:"trail_color"` // Override weapon color if set (empty = use weapon color)
+	TrailWidth float64   `yaml:"trail_width"` // Arc width in radians, or line width in pixels
+	TrailCount int       `yaml:"trail_count"` // Number of trail segments to render
 
 	// Impact Effects
-	ShakeIntensity float64 // Screen shake on hit (0-8 scale, capped by MaxShakeIntensity)
-	FlashRadius    float64 // Impact flash size (pixels)
-	ParticleCount  int     // Particles spawned on hit
+	ShakeIntensity float64 `yaml:"shake_intensity"` // Screen shake on hit (0-8 scale, capped by MaxShakeIntensity)
+	FlashRadius    float64 `yaml:"flash_radius"`    // Impact flash size (pixels)
+	ParticleCount  int     `yaml:"particle_count"`  // Particles spawned on hit
+	ParticleStyle  string  `yaml:"particle_style"`  // Hit particle look (ParticleStyles key, empty = "hit")
 
 	// Movement Control (Hit Reactions)
-	KnockbackForce   float64 // How far victim is pushed (pixels)
-	KnockbackAngle   float64 // Override angle (0 = use attack direction)
-	AttackerPushback float64 // How far attacker slides back after hit (pixels)
+	KnockbackForce   float64 `yaml:"knockback_force"`   // How far victim is pushed (pixels)
+	KnockbackAngle   float64 `yaml:"knockback_angle"`   // Override angle (0 = use attack direction)
+	AttackerPushback float64 `yaml:"attacker_pushback"` // How far attacker slides back after hit (pixels)
 
 	// Stun/Control
-	StunDuration   float64 // Seconds victim is stunned (cannot act)
-	SlowMultiplier float64 // Speed reduction during slow (1.0 = no slow, 0.5 = half speed)
-	SlowDuration   float64 // How long slow lasts (seconds)
+	StunDuration   float64 `yaml:"stun_duration"`   // Seconds victim is stunned (cannot act)
+	SlowMultiplier float64 `yaml:"slow_multiplier"` // Speed reduction during slow (1.0 = no slow, 0.5 = half speed)
+	SlowDuration   float64 `yaml:"slow_duration"`   // How long slow lasts (seconds)
 
 	// Projectile (bow only)
-	IsProjectile    bool    // True = spawns projectile instead of instant hit
-	ProjectileSpeed float64 // Pixels per second
+	IsProjectile    bool    `yaml:"projectile"`       // True = spawns projectile instead of instant hit
+	ProjectileSpeed float64 `yaml:"projectile_speed"` // Pixels per second
+
+	// Projectile physics (all optional, zero = straight arrow that stops at the first hit)
+	ProjectileGravity float64 `yaml:"projectile_gravity"` // Downward pull in pixels/s² - lobbed weapons arc onto the target
+	ProjectileBounces int     `yaml:"projectile_bounces"` // Times the projectile rebounds off the world bounds
+	ProjectileBounce  float64 `yaml:"projectile_bounce"`  // Fraction of speed kept per bounce (0 = 0.7)
+	ProjectilePierce  int     `yaml:"projectile_pierce"`  // Extra players the projectile passes through after the first hit
 }
 
 // cachedWeaponAnimations stores animation configurations to avoid map allocation on every call.
@@ -119,6 +126,7 @@ func initWeaponAnimations() map[string]WeaponAnimationConfig {
 			ShakeIntensity:   1.5,
 			FlashRadius:      10,
 			ParticleCount:    2,
+			ParticleStyle:    "spark",
 			KnockbackForce:   6,
 			AttackerPushback: 3,
 			StunDuration:     0.08,
@@ -142,6 +150,7 @@ func initWeaponAnimations() map[string]WeaponAnimationConfig {
 			ShakeIntensity:   2.5,
 			FlashRadius:      15,
 			ParticleCount:    3,
+			ParticleStyle:    "spark",
 			KnockbackForce:   12, // Medium knockback
 			AttackerPushback: 5,
 			StunDuration:     0.1, // Brief stun
@@ -165,6 +174,7 @@ func initWeaponAnimations() map[string]WeaponAnimationConfig {
 			ShakeIntensity:   2.0,
 			FlashRadius:      12,
 			ParticleCount:    2,
+			ParticleStyle:    "spark",
 			KnockbackForce:   8,    // Moderate push - spacing control
 			AttackerPushback: 8,    // Attacker retreats to maintain distance
 			StunDuration:     0.15, // Longer stun - poking interrupts
@@ -188,6 +198,7 @@ func initWeaponAnimations() map[string]WeaponAnimationConfig {
 			ShakeIntensity:   5.0, // BIG shake
 			FlashRadius:      25,
 			ParticleCount:    5,
+			ParticleStyle:    "debris",
 			KnockbackForce:   25, // MASSIVE knockback
 			AttackerPushback: 8,
 			StunDuration:     0.25, // Long stun - hit stagger
@@ -210,11 +221,13 @@ func initWeaponAnimations() map[string]WeaponAnimationConfig {
 			ShakeIntensity:   2.0, // On arrow hit
 			FlashRadius:      15,  // Impact flash
 			ParticleCount:    3,
+			ParticleStyle:    "arrow",
 			KnockbackForce:   18,   // Strong push - distance control
 			AttackerPushback: 0,    // No pushback for shooter
 			StunDuration:     0,    // No stun - distance not control
 			IsProjectile:     true, // Uses projectile system
 			ProjectileSpeed:  500,  // Pixels per second
+			ProjectilePierce: 1,    // Arrows pass through the first target
 		},
 
 		// ==========================================================================
@@ -258,6 +271,7 @@ func initWeaponAnimations() map[string]WeaponAnimationConfig {
 			ShakeIntensity:   2.0,
 			FlashRadius:      12,
 			ParticleCount:    2,
+			ParticleStyle:    "spark",
 			KnockbackForce:   10,
 			AttackerPushback: 4,
 			StunDuration:     0.08,
@@ -281,6 +295,7 @@ func initWeaponAnimations() map[string]WeaponAnimationConfig {
 			ShakeIntensity:   6.0, // MASSIVE shake
 			FlashRadius:      30,
 			ParticleCount:    6,
+			ParticleStyle:    "debris",
 			KnockbackForce:   30, // HUGE knockback
 			AttackerPushback: 10,
 			StunDuration:     0.3, // Long stun - stagger
@@ -296,11 +311,22 @@ func DefaultWeaponAnimations() map[string]WeaponAnimationConfig {
 
 // GetWeaponAnimation returns the animation config for a weapon ID.
 // Uses cached map lookup - O(1) with no allocation.
-// Defaults to fists if weapon not found.
+// Defaults to fists if weapon not found. Weapons from the weapons file win.
 func GetWeaponAnimation(weaponID string) WeaponAnimationConfig {
+	r := installedWeapons.Load()
+	if r != nil {
+		if d, ok := r.defs[weaponID]; ok {
+			return d.Animation
+		}
+	}
 	if anim, ok := cachedWeaponAnimations[weaponID]; ok {
 		return anim
 	}
+	if r != nil {
+		if d, ok := r.defs["fists"]; ok {
+			return d.Animation
+		}
+	}
 	return defaultFistsAnimation
 }
 
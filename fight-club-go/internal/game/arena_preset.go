@@ -0,0 +1,142 @@
+package game
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"strings"
+
+	"fight-club/internal/game/spatial"
+)
+
+// ArenaPreset is a named arena size and simulation rate
+type ArenaPreset struct {
+	Name     string `json:"name"`
+	Label    string `json:"label"`
+	Width    int    `json:"width"`
+	Height   int    `json:"height"`
+	TickRate int    `json:"tickRate"`
+}
+
+// CustomArenaPreset names an arena that matches no preset (VIDEO_WIDTH/HEIGHT, GAME_TICK_RATE)
+const CustomArenaPreset = "custom"
+
+// ArenaPresets are the arenas selectable at startup (ARENA_PRESET) or from the
+// admin panel. Smaller arenas tick faster for snappier close-quarters fights;
+// the battle royale ticks slower to keep crowded rounds within budget.
+var ArenaPresets = []ArenaPreset{
+	{Name: "small", Label: "Brawl", Width: 800, Height: 600, TickRate: 40},
+	{Name: "standard", Label: "Standard", Width: 1280, Height: 720, TickRate: 30},
+	{Name: "large", Label: "Battle Royale", Width: 1920, Height: 1080, TickRate: 20},
+}
+
+// GetArenaPreset returns a preset by name (case-insensitive)
+func GetArenaPreset(name string) (ArenaPreset, bool) {
+	for _, p := range ArenaPresets {
+		if strings.EqualFold(p.Name, name) {
+			return p, true
+		}
+	}
+	return ArenaPreset{}, false
+}
+
+// ArenaPresetNames returns the preset names in order
+func ArenaPresetNames() []string {
+	names := make([]string, len(ArenaPresets))
+	for i, p := range ArenaPresets {
+		names[i] = p.Name
+	}
+	return names
+}
+
+// ArenaPreset returns the current arena (CustomArenaPreset when it matches no preset)
+func (e *Engine) ArenaPreset() ArenaPreset {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	current := ArenaPreset{
+		Name:     CustomArenaPreset,
+		Label:    "Custom",
+		Width:    int(e.worldWidth),
+		Height:   int(e.worldHeight),
+		TickRate: e.tickRate,
+	}
+	for _, p := range ArenaPresets {
+		if p.Width == current.Width && p.Height == current.Height && p.TickRate == current.TickRate {
+			return p
+		}
+	}
+	return current
+}
+
+// SetArenaPreset switches the running arena to a preset: world bounds, the
+// spatial grid and flow fields, the duel ring, chaos zone and objective
+// bases, and the tick rate. Fighters stay in the arena, pulled inside the new
+// bounds. OnArenaChange is called afterwards (the streamer follows the size).
+func (e *Engine) SetArenaPreset(name string) error {
+	preset, ok := GetArenaPreset(name)
+	if !ok {
+		return fmt.Errorf("unknown arena preset %q (available: %s)", name, strings.Join(ArenaPresetNames(), ", "))
+	}
+
+	e.mu.Lock()
+	e.resizeArena(float64(preset.Width), float64(preset.Height))
+	rateChanged := e.tickRate != preset.TickRate
+	e.tickRate = preset.TickRate
+	e.analytics.resize(e.worldWidth, e.worldHeight, preset.TickRate)
+	e.balance.setTickRate(preset.TickRate)
+	onChange := e.OnArenaChange
+	e.mu.Unlock()
+
+	// The game loop picks up the new rate on its next wakeup
+	if rateChanged {
+		select {
+		case e.tickRateChanged <- struct{}{}:
+		default:
+		}
+	}
+
+	log.Printf("🏟️ Arena preset: %s (%dx%d @ %d TPS)", preset.Name, preset.Width, preset.Height, preset.TickRate)
+	if onChange != nil {
+		onChange(preset)
+	}
+	return nil
+}
+
+// resizeArena rebuilds everything sized by the world bounds. Caller holds e.mu.
+func (e *Engine) resizeArena(width, height float64) {
+	e.worldWidth, e.worldHeight = width, height
+
+	e.discardBroadphase()
+	e.spatialGrid = spatial.NewSpatialGrid(width, height, 100, e.limits.MaxPlayers)
+	e.broadphase = newBroadphase(width, height, e.limits.MaxPlayers)
+	e.flowFieldManager = spatial.NewFlowFieldManager(width, height, 50)
+
+	e.duels.x, e.duels.y = width/2, height/2
+	e.chaos.zoneX, e.chaos.zoneY = width/2, height/2
+	e.chaos.zoneStartRadius = math.Hypot(width, height) / 2
+	e.chaos.zoneRadius = min(e.chaos.zoneRadius, e.chaos.zoneStartRadius)
+
+	om := e.objective
+	om.pointX, om.pointY = width/2, height/2
+	om.baseX = [2]float64{om.cfg.BaseRadius, width - om.cfg.BaseRadius}
+	om.baseY = height / 2
+	e.shops.layout(width, height)
+
+	for _, p := range e.players {
+		p.worldWidth, p.worldHeight = width, height
+		p.X = max(PlayerRadius, min(width-PlayerRadius, p.X))
+		p.Y = max(PlayerRadius, min(height-PlayerRadius, p.Y))
+	}
+	for _, proj := range e.projectiles {
+		proj.BoundsW, proj.BoundsH = width, height
+	}
+	for _, l := range e.lootPiles {
+		l.x = max(0, min(width, l.x))
+		l.y = max(0, min(height, l.y))
+	}
+	for _, w := range e.weaponDrops {
+		w.x = max(0, min(width, w.x))
+		w.y = max(0, min(height, w.y))
+	}
+}
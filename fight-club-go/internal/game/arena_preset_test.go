@@ -0,0 +1,55 @@
+package game
+
+import (
+	"testing"
+)
+
+// TestSetArenaPreset verifies switching presets resizes the arena consistently and keeps fighters inside
+func TestSetArenaPreset(t *testing.T) {
+	engine := newTestEngine(30)
+	if p := engine.ArenaPreset(); p.Name != "standard" {
+		t.Fatalf("Expected a 1280x720 @ 30 TPS engine to be the standard preset, got %+v", p)
+	}
+
+	p := engine.AddPlayer("edge", PlayerOptions{})
+	p.X, p.Y = 1250, 700
+
+	var changed ArenaPreset
+	engine.OnArenaChange = func(preset ArenaPreset) { changed = preset }
+	if err := engine.SetArenaPreset("small"); err != nil {
+		t.Fatalf("SetArenaPreset failed: %v", err)
+	}
+
+	small, _ := GetArenaPreset("small")
+	if changed != small || engine.ArenaPreset() != small {
+		t.Errorf("Expected the small preset applied and announced, got %+v (callback %+v)", engine.ArenaPreset(), changed)
+	}
+	if engine.tickRate != small.TickRate {
+		t.Errorf("Expected %d TPS, got %d", small.TickRate, engine.tickRate)
+	}
+	if p.X > 800-PlayerRadius || p.Y > 600-PlayerRadius || p.worldWidth != 800 || p.worldHeight != 600 {
+		t.Errorf("Expected the fighter pulled inside 800x600, got (%.0f, %.0f) in %.0fx%.0f", p.X, p.Y, p.worldWidth, p.worldHeight)
+	}
+	if engine.duels.x != 400 || engine.objective.pointY != 300 || engine.chaos.zoneX != 400 {
+		t.Errorf("Expected the duel ring, capture point and chaos zone recentered, got %.0f / %.0f / %.0f",
+			engine.duels.x, engine.objective.pointY, engine.chaos.zoneX)
+	}
+	if stats := engine.analytics.Stats(); stats.Cols*int(engine.analytics.cfg.CellSize) < 800 {
+		t.Errorf("Expected the heatmap to cover the new arena, got %d columns", stats.Cols)
+	}
+
+	// The simulation keeps running on the new grid
+	for i := 0; i < 60; i++ {
+		engine.tick()
+	}
+	if p.X < 0 || p.X > 800 || p.Y < 0 || p.Y > 600 {
+		t.Errorf("Fighter left the small arena: (%.0f, %.0f)", p.X, p.Y)
+	}
+
+	if err := engine.SetArenaPreset("colosseum"); err == nil {
+		t.Error("Expected an unknown preset to fail")
+	}
+	if engine.ArenaPreset() != small {
+		t.Errorf("Unknown preset changed the arena: %+v", engine.ArenaPreset())
+	}
+}
@@ -0,0 +1,367 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// ArenaStateMaxAge is how old a saved arena may be and still be restored
+// (a crashed handoff shouldn't resurrect yesterday's fighters)
+const ArenaStateMaxAge = 5 * time.Minute
+
+// ArenaStateSaveInterval is how often the server saves the arena while
+// running, so a crash resumes from at most this far back
+const ArenaStateSaveInterval = 30 * time.Second
+
+// ArenaFighter is a viewer's in-arena state carried across a restart
+type ArenaFighter struct {
+	Name        string  `json:"name"`
+	X           float64 `json:"x"`
+	Y           float64 `json:"y"`
+	HP          int     `json:"hp"`
+	MaxHP       int     `json:"maxHp"`
+	Money       int     `json:"money"`
+	Kills       int     `json:"kills"`
+	Deaths      int     `json:"deaths"`
+	Weapon      string  `json:"weapon"`
+	Color       string  `json:"color"`
+	Avatar      string  `json:"avatar"`
+	ProfilePic  string  `json:"profilePic"`
+	Personality string  `json:"personality"`
+	IsDead      bool    `json:"isDead"`
+	TeamID      string  `json:"teamId,omitempty"`
+}
+
+// ArenaTeam is a team carried across a restart (pending invites are dropped)
+type ArenaTeam struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Color     string    `json:"color"`
+	Leader    string    `json:"leader"`
+	Members   []string  `json:"members"`
+	Kills     int       `json:"kills"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// ArenaRound is the match history round in progress
+type ArenaRound struct {
+	Start   time.Time      `json:"start"`
+	Elapsed float64        `json:"elapsed"` // Seconds of game time
+	Kills   map[string]int `json:"kills"`   // Viewer -> kills
+	Weapons map[string]int `json:"weapons"` // Weapon -> kills
+	Total   int            `json:"total"`
+	Peak    int            `json:"peak"`
+}
+
+// ArenaScene is the time of day and weather (a clearing weather is saved as clear)
+type ArenaScene struct {
+	Time      string  `json:"time"`
+	Weather   string  `json:"weather"`
+	Intensity float64 `json:"intensity"`
+	Remaining float64 `json:"remaining"`
+}
+
+// ArenaState is the arena saved on shutdown (and periodically, for crashes)
+// and restored by the next process: fighters, teams, the round in progress,
+// the scene, the game clock and the RNG seed. Bots are left out (bot fill
+// spawns new ones); wallets, ratings and the other stores persist on their
+// own. Events in flight (chaos, bounties, objective rounds) start over.
+type ArenaState struct {
+	SavedAt    time.Time      `json:"savedAt"`
+	Tick       int64          `json:"tick"`
+	RNGSeed    int64          `json:"rngSeed"`
+	TotalKills int            `json:"totalKills"`
+	Fighters   []ArenaFighter `json:"fighters"`
+	Teams      []ArenaTeam    `json:"teams,omitempty"`
+	Round      *ArenaRound    `json:"round,omitempty"`
+	Scene      *ArenaScene    `json:"scene,omitempty"`
+}
+
+// ExportArena captures the arena
+func (e *Engine) ExportArena() ArenaState {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	sm := e.scene
+	scene := &ArenaScene{
+		Time:      SceneTimes[sm.time],
+		Weather:   sm.target(),
+		Remaining: sm.remaining,
+	}
+	if !sm.fading {
+		scene.Intensity = sm.intensity
+	}
+	round := e.history.exportRound()
+
+	state := ArenaState{
+		SavedAt:    time.Now(),
+		Tick:       e.tickCount,
+		RNGSeed:    e.rngSeed,
+		TotalKills: e.totalKills,
+		Fighters:   make([]ArenaFighter, 0, len(e.players)),
+		Teams:      e.teamManager.exportTeams(),
+		Round:      &round,
+		Scene:      scene,
+	}
+	for _, p := range e.players {
+		if p.IsBot || p.Name == e.arenaBotName {
+			continue
+		}
+		state.Fighters = append(state.Fighters, ArenaFighter{
+			Name:        p.Name,
+			X:           p.X,
+			Y:           p.Y,
+			HP:          p.HP,
+			MaxHP:       p.MaxHP,
+			Money:       p.Money,
+			Kills:       p.Kills,
+			Deaths:      p.Deaths,
+			Weapon:      p.Weapon,
+			Color:       p.Color,
+			Avatar:      p.Avatar,
+			ProfilePic:  p.ProfilePic,
+			Personality: p.Personality,
+			IsDead:      p.IsDead,
+			TeamID:      p.TeamID,
+		})
+	}
+	return state
+}
+
+// ImportArena puts a saved arena back (quietly: no join announcements).
+// Fighters and teams already in the arena are kept as they are. Returns how
+// many fighters were restored.
+func (e *Engine) ImportArena(state ArenaState) int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.totalKills = max(e.totalKills, state.TotalKills)
+	e.tickCount = max(e.tickCount, state.Tick)
+	if state.RNGSeed != 0 {
+		e.rngSeed = state.RNGSeed
+		e.rng.Seed(e.rngSeed)
+	}
+	e.teamManager.importTeams(state.Teams)
+	if state.Round != nil {
+		e.history.resumeRound(*state.Round)
+	}
+	if state.Scene != nil {
+		e.scene.restore(*state.Scene)
+	}
+
+	restored := 0
+	for _, f := range state.Fighters {
+		if _, ok := e.players[f.Name]; ok || f.Name == "" {
+			continue
+		}
+		if len(e.players) >= e.limits.MaxTotalPlayers && !e.evictBotLocked() {
+			log.Printf("⚠️ Player limit reached, %d saved fighters not restored", len(state.Fighters)-restored)
+			break
+		}
+
+		p := NewPlayer(f.Name, PlayerOptions{
+			ProfilePic:  f.ProfilePic,
+			Color:       f.Color,
+			WorldWidth:  e.worldWidth,
+			WorldHeight: e.worldHeight,
+		})
+		p.X = max(0, min(e.worldWidth, f.X))
+		p.Y = max(0, min(e.worldHeight, f.Y))
+		p.MaxHP = max(f.MaxHP, 1)
+		p.HP = max(0, min(p.MaxHP, f.HP))
+		p.Money = f.Money
+		p.Kills = f.Kills
+		p.Deaths = f.Deaths
+		if IsWeapon(f.Weapon) {
+			p.Weapon = f.Weapon
+		}
+		if f.Avatar != "" {
+			p.Avatar = f.Avatar
+		}
+		if f.Personality != "" {
+			p.SetPersonality(f.Personality)
+		}
+		if f.IsDead || p.HP == 0 {
+			p.IsDead = true
+			p.HP = 0
+			p.State = StateDead
+			p.SpawnProtection = false
+		}
+		_, rank := e.ratings.Rating(f.Name)
+		p.Rank = rank.ID
+		p.Skins = e.skins.Equipped(f.Name)
+		if team := e.teamManager.GetTeamByMember(f.Name); team != nil {
+			p.TeamID = team.ID
+		}
+
+		e.players[f.Name] = p
+		restored++
+	}
+	e.rosterVersion++
+	return restored
+}
+
+// SaveState writes the arena as JSON
+func (e *Engine) SaveState(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(e.ExportArena())
+}
+
+// LoadState restores an arena written by SaveState. A stale arena (older
+// than ArenaStateMaxAge) restores nothing. Returns how many fighters were
+// restored.
+func (e *Engine) LoadState(r io.Reader) (int, error) {
+	var state ArenaState
+	if err := json.NewDecoder(r).Decode(&state); err != nil {
+		return 0, err
+	}
+	if age := time.Since(state.SavedAt); age > ArenaStateMaxAge {
+		log.Printf("🔄 Ignoring arena state saved %s ago", age.Round(time.Second))
+		return 0, nil
+	}
+	return e.ImportArena(state), nil
+}
+
+// SaveArena writes the arena to path for the next process. The file is
+// replaced atomically, so a crash mid-save keeps the previous one.
+func (e *Engine) SaveArena(path string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := e.SaveState(tmp); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// RestoreArena loads an arena saved by SaveArena and removes the file, so
+// it is only restored once. A missing or stale file restores nothing.
+func (e *Engine) RestoreArena(path string) (int, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	if err := os.Remove(path); err != nil {
+		log.Printf("⚠️ Failed to remove arena state %s: %v", path, err)
+	}
+
+	restored, err := e.LoadState(f)
+	if err != nil {
+		return 0, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return restored, nil
+}
+
+// exportTeams captures the teams (members sorted for a stable file)
+func (tm *TeamManager) exportTeams() []ArenaTeam {
+	tm.mu.RLock()
+	defer tm.mu.RUnlock()
+
+	teams := make([]ArenaTeam, 0, len(tm.teams))
+	for _, t := range tm.teams {
+		members := make([]string, 0, len(t.Members))
+		for name := range t.Members {
+			members = append(members, name)
+		}
+		sort.Strings(members)
+		teams = append(teams, ArenaTeam{
+			ID:        t.ID,
+			Name:      t.Name,
+			Color:     t.Color,
+			Leader:    t.LeaderID,
+			Members:   members,
+			Kills:     t.Kills,
+			CreatedAt: t.CreatedAt,
+		})
+	}
+	sort.Slice(teams, func(i, j int) bool { return teams[i].ID < teams[j].ID })
+	return teams
+}
+
+// importTeams adds saved teams, skipping ones that already exist and anyone
+// already in a team
+func (tm *TeamManager) importTeams(saved []ArenaTeam) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	inTeam := make(map[string]bool)
+	for _, t := range tm.teams {
+		for name := range t.Members {
+			inTeam[name] = true
+		}
+	}
+
+	for _, st := range saved {
+		if _, ok := tm.teams[st.ID]; ok || st.ID == "" || len(tm.teams) >= MaxTeams {
+			continue
+		}
+		team := &Team{
+			ID:        st.ID,
+			Name:      st.Name,
+			Color:     st.Color,
+			LeaderID:  st.Leader,
+			Members:   make(map[string]bool),
+			Kills:     st.Kills,
+			CreatedAt: st.CreatedAt,
+			Invites:   make(map[string]time.Time),
+		}
+		for _, name := range st.Members {
+			if !inTeam[name] && len(team.Members) < MaxTeamSize {
+				team.Members[name] = true
+				inTeam[name] = true
+			}
+		}
+		if len(team.Members) == 0 {
+			continue
+		}
+		if !team.Members[team.LeaderID] {
+			for _, name := range st.Members {
+				if team.Members[name] {
+					team.LeaderID = name
+					break
+				}
+			}
+		}
+		tm.teams[team.ID] = team
+	}
+}
+
+// restore puts a saved scene back (unknown values keep the current one)
+func (sm *SceneManager) restore(saved ArenaScene) {
+	for i, t := range SceneTimes {
+		if t == saved.Time {
+			sm.time = i
+			if saved.Remaining > 0 {
+				sm.remaining = saved.Remaining
+			}
+		}
+	}
+	for _, w := range SceneWeathers {
+		if w == saved.Weather {
+			sm.weather, sm.fading = w, false
+			sm.intensity = max(0, min(1, saved.Intensity))
+			if w == WeatherClear {
+				sm.intensity = 0
+			}
+		}
+	}
+}
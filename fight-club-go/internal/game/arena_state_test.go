@@ -0,0 +1,155 @@
+package game
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestArenaStateRoundTrip verifies viewers survive a save/restore while bots don't
+func TestArenaStateRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "arena-state.json")
+
+	old := newTestEngine(30)
+	alice := old.AddPlayer("alice", PlayerOptions{})
+	alice.HP, alice.Money, alice.Kills, alice.Weapon = 42, 300, 7, "spear"
+	dead := old.AddPlayer("bob", PlayerOptions{})
+	dead.IsDead, dead.HP, dead.State = true, 0, StateDead
+	bot := old.AddPlayer("Bot-1", PlayerOptions{})
+	bot.IsBot = true
+	old.totalKills = 12
+
+	if err := old.SaveArena(path); err != nil {
+		t.Fatalf("SaveArena: %v", err)
+	}
+
+	next := newTestEngine(30)
+	restored, err := next.RestoreArena(path)
+	if err != nil || restored != 2 {
+		t.Fatalf("Expected 2 fighters restored, got %d (%v)", restored, err)
+	}
+	got := next.players["alice"]
+	if got == nil || got.HP != 42 || got.Money != 300 || got.Kills != 7 || got.Weapon != "spear" || got.Personality != alice.Personality {
+		t.Errorf("Expected alice's state to carry over, got %+v", got)
+	}
+	if b := next.players["bob"]; b == nil || !b.IsDead || b.State != StateDead {
+		t.Errorf("Expected bob to stay dead, got %+v", b)
+	}
+	if next.players["Bot-1"] != nil {
+		t.Error("Expected bots to be left out")
+	}
+	if next.totalKills != 12 {
+		t.Errorf("Expected 12 session kills, got %d", next.totalKills)
+	}
+
+	// The file is consumed: a second start restores nothing
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("Expected the arena state file to be removed")
+	}
+	if n, err := newTestEngine(30).RestoreArena(path); n != 0 || err != nil {
+		t.Errorf("Expected nothing without a file, got %d (%v)", n, err)
+	}
+}
+
+// TestArenaStateStale verifies an old save is ignored
+func TestArenaStateStale(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "arena-state.json")
+	data, _ := json.Marshal(ArenaState{
+		SavedAt:  time.Now().Add(-ArenaStateMaxAge - time.Minute),
+		Fighters: []ArenaFighter{{Name: "alice", HP: 100, MaxHP: 100}},
+	})
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	engine := newTestEngine(30)
+	if n, err := engine.RestoreArena(path); n != 0 || err != nil {
+		t.Errorf("Expected a stale arena to be ignored, got %d (%v)", n, err)
+	}
+	if engine.players["alice"] != nil {
+		t.Error("Expected no fighters from a stale arena")
+	}
+}
+
+// TestArenaStateFull verifies teams, the round in progress, the scene, the
+// game clock and the RNG seed survive SaveState/LoadState
+func TestArenaStateFull(t *testing.T) {
+	old := newTestEngine(30)
+	alice := old.AddPlayer("alice", PlayerOptions{})
+	bob := old.AddPlayer("bob", PlayerOptions{})
+	team, err := old.teamManager.CreateTeam("alice", "Wolves")
+	if err != nil {
+		t.Fatal(err)
+	}
+	team.Members["bob"] = true
+	team.Kills = 4
+	alice.TeamID, bob.TeamID = team.ID, team.ID
+
+	old.history.RecordKill(alice)
+	old.history.Advance(12, 2)
+	old.tickCount = 5000
+	old.rngSeed = 424242
+	if err := old.SetScene(SceneNight, WeatherSnow); err != nil {
+		t.Fatal(err)
+	}
+	old.scene.intensity = 0.6
+
+	var buf bytes.Buffer
+	if err := old.SaveState(&buf); err != nil {
+		t.Fatalf("SaveState: %v", err)
+	}
+
+	next := newTestEngine(30)
+	if n, err := next.LoadState(&buf); n != 2 || err != nil {
+		t.Fatalf("Expected 2 fighters restored, got %d (%v)", n, err)
+	}
+
+	got := next.teamManager.GetTeam(team.ID)
+	if got == nil || got.Name != "Wolves" || got.LeaderID != "alice" || !got.Members["bob"] || got.Kills != 4 {
+		t.Errorf("Expected the team to carry over, got %+v", got)
+	}
+	if next.players["alice"].TeamID != team.ID || next.players["bob"].TeamID != team.ID {
+		t.Error("Expected the fighters back in their team")
+	}
+	if round := next.history.Current(); round.Kills != 1 || round.Seconds != 12 || round.Winner != "alice" {
+		t.Errorf("Expected the round to resume, got %+v", round)
+	}
+	if scene := next.Scene(); scene.Time != SceneNight || scene.Weather != WeatherSnow || scene.WeatherIntensity != 0.6 {
+		t.Errorf("Expected the scene to carry over, got %+v", scene)
+	}
+	if next.tickCount != 5000 || next.rngSeed != 424242 {
+		t.Errorf("Expected tick 5000 and seed 424242, got %d and %d", next.tickCount, next.rngSeed)
+	}
+	if a, b := next.rng.Int63(), rand.New(rand.NewSource(424242)).Int63(); a != b {
+		t.Error("Expected the RNG to be reseeded")
+	}
+}
+
+// TestArenaStateResumedRound verifies the round the old process closed on
+// shutdown is replaced by the resumed one rather than counted twice
+func TestArenaStateResumedRound(t *testing.T) {
+	old := newTestEngine(30)
+	old.history.RecordKill(old.AddPlayer("alice", PlayerOptions{}))
+	state := old.ExportArena()
+	old.history.Start()
+	old.history.Stop() // Closes the round
+	rounds := old.history.Rounds(0)
+	if len(rounds) != 1 {
+		t.Fatalf("Expected the round closed on stop, got %d", len(rounds))
+	}
+
+	next := newTestEngine(30)
+	next.history.rounds = rounds
+	next.history.nextID = rounds[0].ID + 1
+	next.ImportArena(state)
+	if got := next.history.Rounds(0); len(got) != 0 {
+		t.Errorf("Expected the closed round replaced, got %+v", got)
+	}
+	if round := next.history.Current(); round.ID != rounds[0].ID || round.Kills != 1 {
+		t.Errorf("Expected round %d to resume, got %+v", rounds[0].ID, round)
+	}
+}
@@ -0,0 +1,164 @@
+package game
+
+import (
+	"log"
+	"time"
+
+	"fight-club/internal/config"
+)
+
+// AutoStreamConfig is an alias for config.AutoStreamConfig (SSOT)
+type AutoStreamConfig = config.AutoStreamConfig
+
+// Reasons passed to the auto-stream callbacks
+const (
+	AutoStreamPlayers = "players" // Viewers joined the arena
+	AutoStreamCommand = "command" // Broadcaster typed !golive / !offline
+	AutoStreamIdle    = "idle"    // Arena empty for IdleMinutes
+)
+
+// AutoStreamStatus is the controller state for the admin API
+type AutoStreamStatus struct {
+	Enabled     bool    `json:"enabled"`
+	Live        bool    `json:"live"`
+	IdleSeconds float64 `json:"idleSeconds"` // Time the live arena has had no viewers fighting
+	IdleLimit   float64 `json:"idleLimit"`   // Seconds before the stream ends
+	Since       string  `json:"since"`       // Last change (RFC 3339)
+}
+
+// AutoStreamController decides whether the stream should be live. The game
+// server only publishes the decision (GameSnapshot.StreamLive); the streamer
+// process starts or suspends FFmpeg to follow it. State is guarded by the
+// engine lock (see Engine.updateAutoStream).
+type AutoStreamController struct {
+	cfg   AutoStreamConfig
+	live  bool
+	idle  float64
+	since time.Time
+
+	// Callbacks (called in a new goroutine, e.g. to update the Kick title)
+	OnGoLive    func(reason string)
+	OnGoOffline func(reason string, uptime time.Duration)
+}
+
+// NewAutoStreamController creates a controller; the stream starts offline
+func NewAutoStreamController(cfg AutoStreamConfig) *AutoStreamController {
+	defaults := config.DefaultAutoStream()
+	if cfg.IdleMinutes <= 0 {
+		cfg.IdleMinutes = defaults.IdleMinutes
+	}
+	if cfg.MinPlayers <= 0 {
+		cfg.MinPlayers = defaults.MinPlayers
+	}
+	return &AutoStreamController{cfg: cfg, since: time.Now()}
+}
+
+// Enabled reports whether the stream follows arena activity
+func (a *AutoStreamController) Enabled() bool {
+	return a.cfg.Enabled
+}
+
+// Config returns the controller settings (Kick titles and categories)
+func (a *AutoStreamController) Config() AutoStreamConfig {
+	return a.cfg
+}
+
+// setLive switches the stream state and fires the callback
+func (a *AutoStreamController) setLive(live bool, reason string) {
+	uptime := time.Since(a.since)
+	a.live = live
+	a.idle = 0
+	a.since = time.Now()
+
+	if live {
+		log.Printf("📡 Auto-stream: going live (%s)", reason)
+		if a.OnGoLive != nil {
+			go a.OnGoLive(reason)
+		}
+		return
+	}
+	log.Printf("💤 Auto-stream: ending stream (%s) after %s", reason, uptime.Round(time.Second))
+	if a.OnGoOffline != nil {
+		go a.OnGoOffline(reason, uptime)
+	}
+}
+
+// updateAutoStream goes live when enough viewers are fighting and ends the
+// stream once the arena has had none for IdleMinutes. Bots don't count.
+// Caller holds e.mu.
+func (e *Engine) updateAutoStream(deltaTime float64) {
+	a := e.autoStream
+	if !a.cfg.Enabled {
+		return
+	}
+
+	fighters := 0
+	for _, p := range e.players {
+		if !p.IsDead && !p.IsBot && p.Name != e.arenaBotName {
+			fighters++
+		}
+	}
+
+	if !a.live {
+		if fighters >= a.cfg.MinPlayers {
+			a.setLive(true, AutoStreamPlayers)
+		}
+		return
+	}
+
+	if fighters > 0 {
+		a.idle = 0
+		return
+	}
+	a.idle += deltaTime
+	if a.idle >= a.cfg.IdleMinutes*60 {
+		a.setLive(false, AutoStreamIdle)
+	}
+}
+
+// GoLive starts the stream regardless of arena activity (broadcaster command).
+// It still ends after IdleMinutes without viewers. Returns false if the
+// controller is disabled or already live.
+func (e *Engine) GoLive() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if !e.autoStream.cfg.Enabled || e.autoStream.live {
+		return false
+	}
+	e.autoStream.setLive(true, AutoStreamCommand)
+	return true
+}
+
+// GoOffline ends the stream now (broadcaster command); it goes live again
+// when viewers join. Returns false if the controller is disabled or offline.
+func (e *Engine) GoOffline() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if !e.autoStream.cfg.Enabled || !e.autoStream.live {
+		return false
+	}
+	e.autoStream.setLive(false, AutoStreamCommand)
+	return true
+}
+
+// GetAutoStream returns the auto-stream controller (to set callbacks)
+func (e *Engine) GetAutoStream() *AutoStreamController {
+	return e.autoStream
+}
+
+// GetAutoStreamStatus returns the controller state
+func (e *Engine) GetAutoStreamStatus() AutoStreamStatus {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	a := e.autoStream
+	return AutoStreamStatus{
+		Enabled:     a.cfg.Enabled,
+		Live:        a.live,
+		IdleSeconds: a.idle,
+		IdleLimit:   a.cfg.IdleMinutes * 60,
+		Since:       a.since.Format(time.RFC3339),
+	}
+}
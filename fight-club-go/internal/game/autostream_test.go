@@ -0,0 +1,96 @@
+package game
+
+import (
+	"testing"
+	"time"
+)
+
+// TestAutoStreamFollowsArena verifies the stream goes live on join and ends after the idle timeout
+func TestAutoStreamFollowsArena(t *testing.T) {
+	engine := newTestEngineWith(func(cfg *EngineConfig) {
+		cfg.AutoStream = AutoStreamConfig{Enabled: true, IdleMinutes: 1, MinPlayers: 1}
+	})
+	engine.arenaBotEnabled = false
+	live := make(chan string, 1)
+	offline := make(chan string, 1)
+	engine.autoStream.OnGoLive = func(reason string) { live <- reason }
+	engine.autoStream.OnGoOffline = func(reason string, uptime time.Duration) { offline <- reason }
+
+	engine.updateAutoStream(1)
+	if engine.autoStream.live {
+		t.Fatal("Expected stream offline with an empty arena")
+	}
+
+	engine.AddPlayer("viewer1", PlayerOptions{})
+	engine.updateAutoStream(1)
+	if !engine.autoStream.live {
+		t.Fatal("Expected stream live after a viewer joined")
+	}
+	if reason := <-live; reason != AutoStreamPlayers {
+		t.Errorf("Expected reason %q, got %q", AutoStreamPlayers, reason)
+	}
+
+	engine.RemovePlayer("viewer1")
+	engine.updateAutoStream(30)
+	if !engine.autoStream.live {
+		t.Fatal("Expected stream still live before the idle timeout")
+	}
+	engine.updateAutoStream(30)
+	if engine.autoStream.live {
+		t.Fatal("Expected stream offline after 1 minute of empty arena")
+	}
+	if reason := <-offline; reason != AutoStreamIdle {
+		t.Errorf("Expected reason %q, got %q", AutoStreamIdle, reason)
+	}
+
+	engine.ProduceSnapshot()
+	snap := engine.GetSnapshot()
+	if !snap.AutoStream || snap.StreamLive {
+		t.Errorf("Expected snapshot AutoStream=true StreamLive=false, got %v %v", snap.AutoStream, snap.StreamLive)
+	}
+}
+
+// TestAutoStreamIgnoresBots verifies bots and dead fighters neither start nor keep the stream
+func TestAutoStreamIgnoresBots(t *testing.T) {
+	engine := newTestEngineWith(func(cfg *EngineConfig) {
+		cfg.AutoStream = AutoStreamConfig{Enabled: true, IdleMinutes: 1, MinPlayers: 1}
+	})
+	engine.arenaBotEnabled = false
+	engine.AddPlayer("[BOT] Rex", PlayerOptions{})
+	engine.players["[BOT] Rex"].IsBot = true
+	engine.updateAutoStream(1)
+	if engine.autoStream.live {
+		t.Fatal("Expected bots not to start the stream")
+	}
+
+	engine.AddPlayer("viewer1", PlayerOptions{})
+	engine.updateAutoStream(1)
+	engine.players["viewer1"].IsDead = true
+	engine.updateAutoStream(61)
+	if engine.autoStream.live {
+		t.Error("Expected stream offline with only bots and dead viewers left")
+	}
+}
+
+// TestAutoStreamCommands verifies broadcaster !golive / !offline
+func TestAutoStreamCommands(t *testing.T) {
+	engine := newTestEngineWith(func(cfg *EngineConfig) {
+		cfg.AutoStream = AutoStreamConfig{Enabled: true, IdleMinutes: 10, MinPlayers: 1}
+	})
+	engine.arenaBotEnabled = false
+
+	if !engine.GoLive() || engine.GoLive() {
+		t.Fatal("Expected GoLive to succeed once")
+	}
+	if status := engine.GetAutoStreamStatus(); !status.Live || status.IdleLimit != 600 {
+		t.Errorf("Unexpected status after GoLive: %+v", status)
+	}
+	if !engine.GoOffline() || engine.GoOffline() {
+		t.Fatal("Expected GoOffline to succeed once")
+	}
+
+	disabled := newTestEngine(30)
+	if disabled.GoLive() || disabled.GetAutoStream().Enabled() {
+		t.Error("Expected auto-stream commands to do nothing when disabled")
+	}
+}
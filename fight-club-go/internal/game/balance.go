@@ -0,0 +1,293 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// BalanceWeaponStats is one weapon's row in the balance report.
+// Picks only count viewers switching weapons (bot loadouts are random);
+// held time and kills count every fighter, so KillsPerMinute compares
+// weapons per minute actually spent holding them.
+type BalanceWeaponStats struct {
+	Weapon         string  `json:"weapon"`
+	Picks          int     `json:"picks"`
+	PickRate       float64 `json:"pickRate"`    // Share of all viewer picks
+	HeldSeconds    float64 `json:"heldSeconds"` // Fighter-seconds alive holding it
+	UsageShare     float64 `json:"usageShare"`  // Share of all held time
+	Kills          int     `json:"kills"`
+	KillsPerMinute float64 `json:"killsPerMinute"`
+	AvgTimeToKill  float64 `json:"avgTimeToKill"` // Seconds from a victim's first hit to the kill
+	Hits           int     `json:"hits"`
+	ComboHits      int     `json:"comboHits"` // Hits landed as combo follow-ups (x2 and up)
+	ComboRate      float64 `json:"comboRate"`
+	MaxCombo       int     `json:"maxCombo"`
+}
+
+// BalanceReport is the session weapon balance summary (/api/balance)
+type BalanceReport struct {
+	Since   time.Time            `json:"since"`
+	Seconds float64              `json:"seconds"`
+	Picks   int                  `json:"picks"`
+	Kills   int                  `json:"kills"`
+	Weapons []BalanceWeaponStats `json:"weapons"`
+}
+
+// balanceWeapon accumulates one weapon's raw counters
+type balanceWeapon struct {
+	picks     int
+	heldTicks int64
+	kills     int
+	ttkTicks  int64
+	ttkCount  int
+	hits      int
+	comboHits int
+	maxCombo  int
+}
+
+// BalanceTelemetry collects per-weapon pick, kill, time-to-kill and combo
+// stats for the session, so weapons.go can be tuned with real data. The
+// report is served at /api/balance and saved as JSON + markdown next to the
+// combat analytics at shutdown.
+type BalanceTelemetry struct {
+	mu       sync.RWMutex
+	dir      string
+	since    time.Time
+	tickRate int64
+
+	weapons map[string]*balanceWeapon
+	held    map[string]string         // Viewer ID -> weapon at the last sample
+	fights  map[string]analyticsFight // Victim ID -> current fight (in ticks)
+	picks   int
+	kills   int
+}
+
+// NewBalanceTelemetry creates a collector that saves its report to dir
+// (no file without a directory)
+func NewBalanceTelemetry(dir string, tickRate int) *BalanceTelemetry {
+	if tickRate <= 0 {
+		tickRate = 30
+	}
+	return &BalanceTelemetry{
+		dir:      dir,
+		since:    time.Now(),
+		tickRate: int64(tickRate),
+		weapons:  make(map[string]*balanceWeapon),
+		held:     make(map[string]string),
+		fights:   make(map[string]analyticsFight),
+	}
+}
+
+// setTickRate changes the tick rate held time and fight lengths are measured in
+func (bt *BalanceTelemetry) setTickRate(tickRate int) {
+	bt.mu.Lock()
+	defer bt.mu.Unlock()
+	bt.tickRate = int64(max(1, tickRate))
+}
+
+// weaponLocked returns the counters for a weapon (caller holds lock)
+func (bt *BalanceTelemetry) weaponLocked(id string) *balanceWeapon {
+	w, ok := bt.weapons[id]
+	if !ok {
+		w = &balanceWeapon{}
+		bt.weapons[id] = w
+	}
+	return w
+}
+
+// Sample records held time and weapon switches. Called every tick, it only
+// looks at the fighters once per second.
+func (bt *BalanceTelemetry) Sample(players []*Player, tick int64) {
+	if tick%bt.tickRate != 0 {
+		return
+	}
+	bt.mu.Lock()
+	defer bt.mu.Unlock()
+
+	held := make(map[string]string, len(bt.held))
+	for _, p := range players {
+		if p.IsDead || p.IsRagdoll {
+			continue
+		}
+		bt.weaponLocked(p.Weapon).heldTicks += bt.tickRate
+		if p.IsBot {
+			continue
+		}
+		// The first sighting is the join loadout, not a choice
+		if prev, ok := bt.held[p.ID]; ok && prev != p.Weapon {
+			bt.weaponLocked(p.Weapon).picks++
+			bt.picks++
+		}
+		held[p.ID] = p.Weapon
+	}
+	bt.held = held
+}
+
+// RecordHit records a hit; combo is the attacker's combo count after it
+func (bt *BalanceTelemetry) RecordHit(weapon, victimID string, combo int, tick int64) {
+	bt.mu.Lock()
+	defer bt.mu.Unlock()
+
+	w := bt.weaponLocked(weapon)
+	w.hits++
+	if combo > 1 {
+		w.comboHits++
+	}
+	w.maxCombo = max(w.maxCombo, combo)
+
+	// Forget fights of victims that escaped (or left the arena)
+	if len(bt.fights) > 256 {
+		for id, f := range bt.fights {
+			if float64(tick-f.last)/float64(bt.tickRate) > analyticsFightGap {
+				delete(bt.fights, id)
+			}
+		}
+	}
+
+	fight, ok := bt.fights[victimID]
+	if !ok || float64(tick-fight.last)/float64(bt.tickRate) > analyticsFightGap {
+		fight.start = tick
+	}
+	fight.last = tick
+	bt.fights[victimID] = fight
+}
+
+// RecordKill records a kill with the killer's weapon
+func (bt *BalanceTelemetry) RecordKill(weapon, victimID string, tick int64) {
+	bt.mu.Lock()
+	defer bt.mu.Unlock()
+
+	w := bt.weaponLocked(weapon)
+	w.kills++
+	bt.kills++
+	if fight, ok := bt.fights[victimID]; ok {
+		w.ttkTicks += tick - fight.start
+		w.ttkCount++
+		delete(bt.fights, victimID)
+	}
+}
+
+// round2 rounds to 2 decimals for the report
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// Report returns the session balance summary, one row per weapon (unused
+// weapons included), most kills first
+func (bt *BalanceTelemetry) Report() BalanceReport {
+	bt.mu.RLock()
+	defer bt.mu.RUnlock()
+
+	report := BalanceReport{
+		Since:   bt.since,
+		Seconds: round2(time.Since(bt.since).Seconds()),
+		Picks:   bt.picks,
+		Kills:   bt.kills,
+		Weapons: make([]BalanceWeaponStats, 0, len(Weapons)),
+	}
+
+	weapons := GetAllWeapons()
+	ids := make(map[string]bool, len(weapons)+len(bt.weapons))
+	var totalHeld int64
+	for _, w := range weapons {
+		ids[w.ID] = true
+	}
+	for id, w := range bt.weapons {
+		ids[id] = true
+		totalHeld += w.heldTicks
+	}
+
+	tickRate := float64(bt.tickRate)
+	for id := range ids {
+		w := bt.weapons[id]
+		if w == nil {
+			w = &balanceWeapon{}
+		}
+		row := BalanceWeaponStats{
+			Weapon:      id,
+			Picks:       w.picks,
+			HeldSeconds: round2(float64(w.heldTicks) / tickRate),
+			Kills:       w.kills,
+			Hits:        w.hits,
+			ComboHits:   w.comboHits,
+			MaxCombo:    w.maxCombo,
+		}
+		if bt.picks > 0 {
+			row.PickRate = round2(float64(w.picks) / float64(bt.picks))
+		}
+		if totalHeld > 0 {
+			row.UsageShare = round2(float64(w.heldTicks) / float64(totalHeld))
+		}
+		if w.heldTicks > 0 {
+			row.KillsPerMinute = round2(float64(w.kills) / (float64(w.heldTicks) / tickRate / 60))
+		}
+		if w.ttkCount > 0 {
+			row.AvgTimeToKill = round2(float64(w.ttkTicks) / float64(w.ttkCount) / tickRate)
+		}
+		if w.hits > 0 {
+			row.ComboRate = round2(float64(w.comboHits) / float64(w.hits))
+		}
+		report.Weapons = append(report.Weapons, row)
+	}
+	sort.Slice(report.Weapons, func(a, b int) bool {
+		if report.Weapons[a].Kills != report.Weapons[b].Kills {
+			return report.Weapons[a].Kills > report.Weapons[b].Kills
+		}
+		return report.Weapons[a].Weapon < report.Weapons[b].Weapon
+	})
+	return report
+}
+
+// Markdown renders the report as a markdown table
+func (r BalanceReport) Markdown() string {
+	var b strings.Builder
+	b.WriteString("# Weapon balance report\n\n")
+	fmt.Fprintf(&b, "Session started %s, %.0f minutes, %d kills, %d viewer picks.\n\n",
+		r.Since.Format("2006-01-02 15:04"), r.Seconds/60, r.Kills, r.Picks)
+	b.WriteString("| Weapon | Picks | Pick rate | Usage | Kills | Kills/min | Avg TTK | Hits | Combo rate | Max combo |\n")
+	b.WriteString("|---|---:|---:|---:|---:|---:|---:|---:|---:|---:|\n")
+	for _, w := range r.Weapons {
+		fmt.Fprintf(&b, "| %s | %d | %.0f%% | %.0f%% | %d | %.2f | %.2fs | %d | %.0f%% | %d |\n",
+			w.Weapon, w.Picks, w.PickRate*100, w.UsageShare*100, w.Kills, w.KillsPerMinute,
+			w.AvgTimeToKill, w.Hits, w.ComboRate*100, w.MaxCombo)
+	}
+	return b.String()
+}
+
+// Save writes balance-<session>.json and balance-<session>.md to the
+// directory (skipped when nothing was recorded)
+func (bt *BalanceTelemetry) Save() {
+	report := bt.Report()
+	if bt.dir == "" || (report.Kills == 0 && report.Picks == 0) {
+		return
+	}
+
+	if err := os.MkdirAll(bt.dir, 0o755); err != nil {
+		log.Printf("⚠️ Failed to create balance report dir: %v", err)
+		return
+	}
+	prefix := filepath.Join(bt.dir, "balance-"+bt.since.Format("20060102-150405"))
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		log.Printf("⚠️ Failed to marshal balance report: %v", err)
+		return
+	}
+	if err := os.WriteFile(prefix+".json", data, 0o644); err != nil {
+		log.Printf("⚠️ Failed to save balance report: %v", err)
+		return
+	}
+	if err := os.WriteFile(prefix+".md", []byte(report.Markdown()), 0o644); err != nil {
+		log.Printf("⚠️ Failed to save balance report: %v", err)
+		return
+	}
+	log.Printf("⚖️ Weapon balance report saved to %s.{json,md}", prefix)
+}
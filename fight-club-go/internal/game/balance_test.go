@@ -0,0 +1,84 @@
+package game
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestBalanceTelemetryReport verifies picks, kill rate, time-to-kill and combo stats
+func TestBalanceTelemetryReport(t *testing.T) {
+	bt := NewBalanceTelemetry("", 20)
+	viewer := &Player{ID: "v1", Weapon: "fists"}
+	bot := &Player{ID: "b1", Weapon: "axe", IsBot: true}
+	players := []*Player{viewer, bot}
+
+	// Join loadout is not a pick; switching to a sword is
+	bt.Sample(players, 20)
+	viewer.Weapon = "sword"
+	bt.Sample(players, 40)
+	bt.Sample(players, 41) // Off-second ticks are ignored
+	bt.Sample(players, 60)
+
+	// A 2 second sword fight with a x2 combo follow-up
+	bt.RecordHit("sword", "b1", 1, 100)
+	bt.RecordHit("sword", "b1", 2, 140)
+	bt.RecordKill("sword", "b1", 140)
+
+	report := bt.Report()
+	if report.Picks != 1 || report.Kills != 1 {
+		t.Fatalf("Expected 1 pick and 1 kill, got %+v", report)
+	}
+	if report.Weapons[0].Weapon != "sword" {
+		t.Fatalf("Expected the sword first (most kills), got %s", report.Weapons[0].Weapon)
+	}
+
+	rows := make(map[string]BalanceWeaponStats)
+	for _, w := range report.Weapons {
+		rows[w.Weapon] = w
+	}
+	sword := rows["sword"]
+	if sword.Picks != 1 || sword.PickRate != 1 || sword.HeldSeconds != 2 {
+		t.Errorf("Unexpected sword picks/held time: %+v", sword)
+	}
+	if sword.KillsPerMinute != 30 || sword.AvgTimeToKill != 2 {
+		t.Errorf("Expected 30 kills/min and 2s TTK, got %+v", sword)
+	}
+	if sword.Hits != 2 || sword.ComboHits != 1 || sword.ComboRate != 0.5 || sword.MaxCombo != 2 {
+		t.Errorf("Unexpected sword combo stats: %+v", sword)
+	}
+	if rows["axe"].Picks != 0 || rows["axe"].HeldSeconds != 3 || rows["axe"].UsageShare != 0.5 {
+		t.Errorf("Expected bot axe time without picks, got %+v", rows["axe"])
+	}
+	if _, ok := rows["bow"]; !ok {
+		t.Error("Expected unused weapons in the report")
+	}
+}
+
+// TestBalanceTelemetrySave verifies the JSON and markdown reports are written
+func TestBalanceTelemetrySave(t *testing.T) {
+	dir := t.TempDir()
+	bt := NewBalanceTelemetry(dir, 30)
+	bt.Save()
+	if files, _ := os.ReadDir(dir); len(files) != 0 {
+		t.Fatalf("Expected nothing written without kills, got %d files", len(files))
+	}
+
+	bt.RecordHit("bow", "amy", 1, 10)
+	bt.RecordKill("bow", "amy", 40)
+	bt.Save()
+
+	jsons, _ := filepath.Glob(filepath.Join(dir, "balance-*.json"))
+	mds, _ := filepath.Glob(filepath.Join(dir, "balance-*.md"))
+	if len(jsons) != 1 || len(mds) != 1 {
+		t.Fatalf("Expected one JSON and one markdown report, got %v %v", jsons, mds)
+	}
+	md, err := os.ReadFile(mds[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(md), "| bow | 0 | 0% | 0% | 1 |") {
+		t.Errorf("Expected a bow row in the markdown report:\n%s", md)
+	}
+}
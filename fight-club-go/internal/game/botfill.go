@@ -0,0 +1,173 @@
+package game
+
+import (
+	"log"
+	"sort"
+
+	"fight-club/internal/config"
+)
+
+// BotFillConfig is an alias for config.BotFillConfig (SSOT)
+type BotFillConfig = config.BotFillConfig
+
+// BotTag prefixes bot names so viewers can tell them apart on stream
+const BotTag = "[BOT]"
+
+// botNames are the bot fill roster (shown as "[BOT] Rusty")
+var botNames = []string{
+	"Rusty", "Bolt", "Sprocket", "Gizmo", "Cog", "Piston",
+	"Widget", "Ratchet", "Tinker", "Dynamo", "Servo", "Rivet",
+	"Clank", "Socket", "Turbo", "Volt",
+}
+
+// botColors are muted metallic tones, distinct from the bright viewer palette
+var botColors = []string{
+	"#95a5a6", "#7f8c8d", "#a4b0be", "#747d8c",
+	"#b2bec3", "#636e72", "#8395a7", "#576574",
+}
+
+// botWeapons are the starting weapons bots pick from (viewers start with fists)
+var botWeapons = []string{"fists", "knife", "sword", "spear", "axe"}
+
+// updateBotFill keeps at least MinPlayers fighters in the arena by adding AI
+// bots while chat is quiet. Bots leave as soon as viewers take their place,
+// and killed bots re-enter after RespawnDelay if they are still needed.
+// Caller must hold e.mu.
+func (e *Engine) updateBotFill(deltaTime float64) {
+	fighters := 0
+	var bots []*Player
+	for _, p := range e.players {
+		if p.IsBoss {
+			continue // Script bosses come and go on their own
+		}
+		if p.IsBot {
+			bots = append(bots, p)
+		} else if !p.IsDead {
+			fighters++
+		}
+	}
+	if len(bots) == 0 && e.botFill.MinPlayers <= 0 {
+		return
+	}
+
+	needed := e.botFill.MinPlayers - fighters
+	if needed < 0 {
+		needed = 0
+	}
+
+	// Viewers joined: dead bots leave first, then the weakest
+	if len(bots) > needed {
+		sort.Slice(bots, func(i, j int) bool {
+			if bots[i].IsDead != bots[j].IsDead {
+				return bots[i].IsDead
+			}
+			return bots[i].HP < bots[j].HP
+		})
+		for _, bot := range bots[:len(bots)-needed] {
+			e.removeBot(bot)
+		}
+		bots = bots[len(bots)-needed:]
+	}
+
+	// Respawn killed bots
+	for _, bot := range bots {
+		if !bot.IsDead {
+			continue
+		}
+		e.botRespawnTimers[bot.Name] += deltaTime
+		if e.botRespawnTimers[bot.Name] >= e.botFill.RespawnDelay {
+			delete(e.botRespawnTimers, bot.Name)
+			bot.Respawn()
+		}
+	}
+
+	// Chat is quiet: add one bot every SpawnDelay seconds
+	if len(bots) < needed {
+		e.botSpawnTimer -= deltaTime
+		if e.botSpawnTimer <= 0 {
+			e.botSpawnTimer = e.botFill.SpawnDelay
+			e.spawnBot()
+		}
+	}
+}
+
+// spawnBot adds a bot with an unused roster name (caller holds e.mu)
+func (e *Engine) spawnBot() {
+	if len(e.players) >= e.limits.MaxTotalPlayers {
+		return
+	}
+
+	name := ""
+	for _, i := range e.rng.Perm(len(botNames)) {
+		candidate := BotTag + " " + botNames[i]
+		if _, taken := e.players[candidate]; !taken {
+			name = candidate
+			break
+		}
+	}
+	if name == "" {
+		return // Roster exhausted
+	}
+
+	bot := NewPlayer(name, PlayerOptions{
+		Color:       botColors[e.rng.Intn(len(botColors))],
+		WorldWidth:  e.worldWidth,
+		WorldHeight: e.worldHeight,
+	})
+	bot.IsBot = true
+	bot.Avatar = "🤖"
+	bot.Weapon = botWeapons[e.rng.Intn(len(botWeapons))]
+	bot.X = e.rng.Float64()*e.worldWidth*0.8 + e.worldWidth*0.1
+	bot.Y = e.rng.Float64()*e.worldHeight*0.8 + e.worldHeight*0.1
+
+	e.players[name] = bot
+	e.rosterVersion++
+	log.Printf("🤖 Bot joined: %s", name)
+}
+
+// removeBot takes a bot out of the arena (caller holds e.mu)
+func (e *Engine) removeBot(bot *Player) {
+	delete(e.players, bot.Name)
+	e.rosterVersion++
+	delete(e.botRespawnTimers, bot.Name)
+	log.Printf("🤖 Bot left: %s", bot.Name)
+}
+
+// evictBotLocked frees a player slot for a viewer, returns false if there are no bots (caller holds e.mu)
+func (e *Engine) evictBotLocked() bool {
+	var victim *Player
+	for _, p := range e.players {
+		if p.IsBot && (victim == nil || (p.IsDead && !victim.IsDead)) {
+			victim = p
+		}
+	}
+	if victim == nil {
+		return false
+	}
+	e.removeBot(victim)
+	return true
+}
+
+// SetBotFillMinPlayers changes the bot fill target population (0 removes all bots)
+func (e *Engine) SetBotFillMinPlayers(n int) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if n < 0 {
+		n = 0
+	}
+	e.botFill.MinPlayers = n
+}
+
+// GetBotCount returns the number of bots in the arena
+func (e *Engine) GetBotCount() int {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	count := 0
+	for _, p := range e.players {
+		if p.IsBot {
+			count++
+		}
+	}
+	return count
+}
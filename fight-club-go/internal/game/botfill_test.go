@@ -0,0 +1,76 @@
+package game
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestBotFillMaintainsPopulation verifies bots fill the arena and leave as viewers join
+func TestBotFillMaintainsPopulation(t *testing.T) {
+	engine := newTestEngineWith(func(cfg *EngineConfig) {
+		cfg.BotFill = BotFillConfig{MinPlayers: 4, SpawnDelay: 0, RespawnDelay: 1}
+	})
+	engine.arenaBotEnabled = false
+	stepEngine(engine, 0.3, engine.updateBotFill)
+
+	if got := engine.GetBotCount(); got != 4 {
+		t.Fatalf("Expected 4 bots in an empty arena, got %d", got)
+	}
+	for name, p := range engine.players {
+		if !p.IsBot || !strings.HasPrefix(name, BotTag+" ") {
+			t.Errorf("Expected tagged bot, got %q (IsBot=%v)", name, p.IsBot)
+		}
+	}
+
+	engine.AddPlayer("viewer1", PlayerOptions{})
+	engine.AddPlayer("viewer2", PlayerOptions{})
+	stepEngine(engine, 0.1, engine.updateBotFill)
+	if got := engine.GetBotCount(); got != 2 {
+		t.Errorf("Expected 2 bots after 2 viewers joined, got %d", got)
+	}
+
+	engine.SetBotFillMinPlayers(0)
+	stepEngine(engine, 0.1, engine.updateBotFill)
+	if got := engine.GetBotCount(); got != 0 {
+		t.Errorf("Expected all bots gone when bot fill is disabled, got %d", got)
+	}
+}
+
+// TestBotFillRespawnAndLimits verifies dead bots return, and viewers take bot slots at the player cap
+func TestBotFillRespawnAndLimits(t *testing.T) {
+	engine := newTestEngineWith(func(cfg *EngineConfig) {
+		cfg.BotFill = BotFillConfig{MinPlayers: 2, SpawnDelay: 0, RespawnDelay: 1}
+	})
+	engine.arenaBotEnabled = false
+	stepEngine(engine, 0.2, engine.updateBotFill)
+
+	var bot *Player
+	for _, p := range engine.players {
+		bot = p
+	}
+	bot.IsDead = true
+	bot.State = StateDead
+	stepEngine(engine, 0.5, engine.updateBotFill)
+	if !bot.IsDead {
+		t.Error("Expected bot to wait for RespawnDelay")
+	}
+	stepEngine(engine, 1, engine.updateBotFill)
+	if bot.IsDead {
+		t.Error("Expected bot to respawn after RespawnDelay")
+	}
+
+	engine.limits.MaxTotalPlayers = 2
+	if p := engine.AddPlayer("viewer", PlayerOptions{}); p == nil {
+		t.Fatal("Expected viewer to take a bot's slot at the player cap")
+	}
+	if got := engine.GetBotCount(); got != 1 {
+		t.Errorf("Expected one bot evicted, got %d bots", got)
+	}
+
+	// Bot kills never reach the persistent leaderboard
+	engine.recordLeaderboardKill(bot)
+	if top := engine.GetLeaderboardStore().Top(PeriodAllTime, time.Now(), 10); len(top) != 0 {
+		t.Errorf("Expected no bot kills on the leaderboard, got %v", top)
+	}
+}
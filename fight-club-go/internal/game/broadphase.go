@@ -0,0 +1,94 @@
+package game
+
+import "fight-club/internal/game/spatial"
+
+// BroadphaseParallelMin is the player count from which the next tick's
+// spatial grid is built on a worker; below it the synchronous rebuild is
+// cheaper than handing the positions over
+const BroadphaseParallelMin = 128
+
+// broadphase double-buffers the spatial grid. Once a tick has moved and
+// separated everyone, it copies the positions and a worker fills the back grid
+// while the tick runs its systems, projectiles and snapshot. The next tick
+// swaps the back grid in instead of rebuilding it, unless fighters joined or
+// left in between.
+//
+// The prebuilt grid is one tick phase old: systems and chat commands may still
+// move, kill or respawn fighters after the copy. Every grid user checks the
+// live player state (dead, distance), so this only shifts candidates at cell
+// borders for a tick; the grid is rebuilt from scratch whenever the roster changes.
+type broadphase struct {
+	next    *spatial.SpatialGrid // Back buffer the worker fills
+	order   []*Player            // Player order the back grid indexes
+	xs, ys  []float64            // Position copy the worker reads
+	skip    []bool               // Dead or ragdolled at copy time (not inserted)
+	roster  uint64               // e.rosterVersion at copy time
+	pending chan struct{}        // Closed when the back grid is ready (nil = no build)
+}
+
+// newBroadphase creates the back buffer for a world
+func newBroadphase(width, height float64, maxPlayers int) broadphase {
+	return broadphase{
+		next:  spatial.NewSpatialGrid(width, height, 100, maxPlayers),
+		order: make([]*Player, 0, maxPlayers),
+	}
+}
+
+// prebuildBroadphase starts building the next tick's grid from the current
+// positions, if there are enough players for it to pay off. Caller holds e.mu.
+func (e *Engine) prebuildBroadphase() {
+	bp := &e.broadphase
+	e.discardBroadphase()
+	if len(e.playerSlice) < BroadphaseParallelMin || bp.next == nil {
+		return
+	}
+
+	bp.order = append(bp.order[:0], e.playerSlice...)
+	bp.xs, bp.ys, bp.skip = bp.xs[:0], bp.ys[:0], bp.skip[:0]
+	for _, p := range bp.order {
+		bp.xs = append(bp.xs, p.X)
+		bp.ys = append(bp.ys, p.Y)
+		bp.skip = append(bp.skip, p.IsDead || p.IsRagdoll)
+	}
+	bp.roster = e.rosterVersion
+
+	done := make(chan struct{})
+	bp.pending = done
+	grid, xs, ys, skip := bp.next, bp.xs, bp.ys, bp.skip
+	go func() {
+		defer close(done)
+		grid.Clear()
+		for i := range xs {
+			if !skip[i] {
+				grid.Insert(uint32(i), xs[i], ys[i])
+			}
+		}
+	}()
+}
+
+// swapBroadphase waits for the worker and, if the roster is unchanged, makes
+// its grid and player order current. Returns false when the caller has to
+// rebuild both. Caller holds e.mu.
+func (e *Engine) swapBroadphase() bool {
+	bp := &e.broadphase
+	if bp.pending == nil {
+		return false
+	}
+	<-bp.pending
+	bp.pending = nil
+	if bp.roster != e.rosterVersion {
+		return false
+	}
+
+	e.spatialGrid, bp.next = bp.next, e.spatialGrid
+	e.playerSlice, bp.order = bp.order, e.playerSlice
+	return true
+}
+
+// discardBroadphase waits for a pending build and drops it. Caller holds e.mu.
+func (e *Engine) discardBroadphase() {
+	if bp := &e.broadphase; bp.pending != nil {
+		<-bp.pending
+		bp.pending = nil
+	}
+}
@@ -0,0 +1,61 @@
+package game
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+// TestBroadphasePrebuilt verifies the worker's grid is swapped in when the
+// roster is unchanged and indexes the same players as the tick's player list
+func TestBroadphasePrebuilt(t *testing.T) {
+	engine := newTestEngine(30)
+	for i := 0; i < BroadphaseParallelMin+10; i++ {
+		engine.AddPlayer(fmt.Sprintf("p%d", i), PlayerOptions{})
+	}
+
+	engine.tick() // Spawns the arena bot, so the first prebuilt grid is dropped
+	engine.tick()
+	if engine.broadphase.pending == nil {
+		t.Fatal("Expected the next grid prebuilt after the tick")
+	}
+	if !engine.swapBroadphase() {
+		t.Fatal("Expected the prebuilt grid swapped in")
+	}
+	for i, p := range engine.playerSlice {
+		if p.IsDead || p.IsRagdoll {
+			continue
+		}
+		// Positions are those copied after collisions, which the systems barely move
+		if !slices.Contains(engine.spatialGrid.QueryRadius(p.X, p.Y, 50), uint32(i)) {
+			t.Fatalf("Expected %s (index %d) in the prebuilt grid", p.Name, i)
+		}
+	}
+
+	// A join between ticks drops the prebuilt grid
+	engine.prebuildBroadphase()
+	engine.AddPlayer("late", PlayerOptions{})
+	if engine.swapBroadphase() {
+		t.Error("Expected a roster change to force a rebuild")
+	}
+	engine.tick()
+	found := false
+	for _, p := range engine.playerSlice {
+		found = found || p.Name == "late"
+	}
+	if !found {
+		t.Error("Expected the late joiner in the rebuilt player list")
+	}
+}
+
+// TestBroadphaseSmallArena verifies small arenas keep the synchronous rebuild
+func TestBroadphaseSmallArena(t *testing.T) {
+	engine := newTestEngine(30)
+	for i := 0; i < 10; i++ {
+		engine.AddPlayer(fmt.Sprintf("p%d", i), PlayerOptions{})
+	}
+	engine.tick()
+	if engine.broadphase.pending != nil {
+		t.Error("Expected no worker below BroadphaseParallelMin players")
+	}
+}
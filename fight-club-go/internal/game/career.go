@@ -0,0 +1,243 @@
+package game
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"fight-club/internal/config"
+)
+
+// CareerConfig is an alias for config.CareerConfig (SSOT)
+type CareerConfig = config.CareerConfig
+
+// careerSaveInterval is how often changed careers are flushed to disk
+const careerSaveInterval = 30 * time.Second
+
+// LifeSummary describes one life, from (re)spawn to death
+type LifeSummary struct {
+	Seconds  int       `json:"seconds"` // Survival time
+	Damage   int       `json:"damage"`  // Damage dealt
+	Kills    int       `json:"kills"`
+	Weapon   string    `json:"weapon"`
+	KilledBy string    `json:"killedBy,omitempty"` // Empty for arena hazards
+	DiedAt   time.Time `json:"diedAt"`
+}
+
+// PlayerCareer is one viewer's lifetime stats (never reset)
+type PlayerCareer struct {
+	Kills      int          `json:"kills"`
+	Deaths     int          `json:"deaths"`
+	BestStreak int          `json:"bestStreak"` // Most kills in a single life
+	Last       *LifeSummary `json:"last,omitempty"`
+}
+
+// KD returns the kill/death ratio (kills when the viewer never died)
+func (c PlayerCareer) KD() float64 {
+	if c.Deaths == 0 {
+		return float64(c.Kills)
+	}
+	return float64(c.Kills) / float64(c.Deaths)
+}
+
+// CareerStore keeps persistent lifetime stats per viewer for !record and !last.
+// Bots have no career; kills on bots still count for the viewer.
+type CareerStore struct {
+	mu          sync.RWMutex
+	players     map[string]*PlayerCareer
+	cfg         CareerConfig
+	dirty       bool
+	saveBlocked bool // The file didn't parse and couldn't be moved aside
+
+	running bool
+	stopCh  chan struct{}
+	wg      sync.WaitGroup
+}
+
+// NewCareerStore creates a career store (File "" = in-memory only)
+func NewCareerStore(cfg CareerConfig) *CareerStore {
+	return &CareerStore{
+		players: make(map[string]*PlayerCareer),
+		cfg:     cfg,
+		stopCh:  make(chan struct{}),
+	}
+}
+
+// Start loads persisted careers and begins periodic saving
+func (cs *CareerStore) Start() {
+	cs.mu.Lock()
+	if cs.running {
+		cs.mu.Unlock()
+		return
+	}
+	cs.running = true
+	cs.mu.Unlock()
+
+	cs.load()
+
+	cs.wg.Add(1)
+	go cs.saveLoop()
+}
+
+// Stop stops periodic saving and flushes careers to disk
+func (cs *CareerStore) Stop() {
+	cs.mu.Lock()
+	if !cs.running {
+		cs.mu.Unlock()
+		return
+	}
+	cs.running = false
+	cs.mu.Unlock()
+
+	close(cs.stopCh)
+	cs.wg.Wait()
+	cs.save()
+}
+
+// Career returns a copy of a viewer's lifetime stats (false if they never fought)
+func (cs *CareerStore) Career(username string) (PlayerCareer, bool) {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+
+	c, ok := cs.players[username]
+	if !ok {
+		return PlayerCareer{}, false
+	}
+	career := *c
+	if c.Last != nil {
+		last := *c.Last
+		career.Last = &last
+	}
+	return career, true
+}
+
+// RecordKill credits a kill; streak is the killer's kills this life, including this one
+func (cs *CareerStore) RecordKill(username string, streak int) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	c := cs.entryLocked(username)
+	c.Kills++
+	c.BestStreak = max(c.BestStreak, streak)
+	cs.dirty = true
+}
+
+// RecordDeath counts a death and remembers the life that just ended
+func (cs *CareerStore) RecordDeath(username string, life LifeSummary) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	c := cs.entryLocked(username)
+	c.Deaths++
+	c.Last = &life
+	cs.dirty = true
+}
+
+// entryLocked returns a viewer's career, creating it (caller holds lock)
+func (cs *CareerStore) entryLocked(username string) *PlayerCareer {
+	c, ok := cs.players[username]
+	if !ok {
+		c = &PlayerCareer{}
+		cs.players[username] = c
+	}
+	return c
+}
+
+// saveLoop flushes careers to disk every careerSaveInterval
+func (cs *CareerStore) saveLoop() {
+	defer cs.wg.Done()
+
+	ticker := time.NewTicker(careerSaveInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-cs.stopCh:
+			return
+		case <-ticker.C:
+			cs.save()
+		}
+	}
+}
+
+// save persists careers to disk if anything changed
+func (cs *CareerStore) save() {
+	cs.mu.Lock()
+	if !cs.dirty || cs.cfg.File == "" || cs.saveBlocked {
+		cs.mu.Unlock()
+		return
+	}
+	data, err := json.MarshalIndent(cs.players, "", "  ")
+	cs.dirty = false
+	cs.mu.Unlock()
+
+	if err != nil {
+		log.Printf("⚠️ Failed to marshal careers: %v", err)
+		return
+	}
+
+	if err := WriteFileAtomic(cs.cfg.File, data, 0600); err != nil {
+		log.Printf("⚠️ Failed to save careers: %v", err)
+	}
+}
+
+// load restores persisted careers from disk
+func (cs *CareerStore) load() {
+	if cs.cfg.File == "" {
+		return
+	}
+
+	data, err := os.ReadFile(cs.cfg.File)
+	if err != nil {
+		return // No saved careers
+	}
+
+	var players map[string]*PlayerCareer
+	if err := json.Unmarshal(data, &players); err != nil {
+		if !setAsideCorrupt(cs.cfg.File, "careers", err) {
+			cs.mu.Lock()
+			cs.saveBlocked = true
+			cs.mu.Unlock()
+		}
+		return
+	}
+
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	for name, c := range players {
+		if c != nil {
+			cs.players[name] = c
+		}
+	}
+	log.Printf("📂 Loaded careers: %d viewers", len(players))
+}
+
+// recordCareerDeath updates lifetime stats for a death (killer nil = arena
+// hazard). Bots have no career: a viewer killing a bot still gets the kill.
+func (e *Engine) recordCareerDeath(victim, killer *Player) {
+	isViewer := func(p *Player) bool {
+		return !p.IsBot && p.Name != e.arenaBotName
+	}
+
+	killedBy := ""
+	if killer != nil {
+		killer.lifeKills++
+		killedBy = killer.Name
+		if isViewer(killer) {
+			e.careers.RecordKill(killer.Name, killer.lifeKills)
+		}
+	}
+	if isViewer(victim) {
+		e.careers.RecordDeath(victim.Name, LifeSummary{
+			Seconds:  int(victim.AliveTime),
+			Damage:   victim.lifeDamage,
+			Kills:    victim.lifeKills,
+			Weapon:   victim.Weapon,
+			KilledBy: killedBy,
+			DiedAt:   time.Now(),
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package game
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+// TestCareerRecordDeath verifies kills, streaks and the last life of viewers,
+// with bots giving kills but keeping no career
+func TestCareerRecordDeath(t *testing.T) {
+	e := NewEngine(DefaultEngineConfig())
+	ana := e.AddPlayer("ana", PlayerOptions{})
+	leo := e.AddPlayer("leo", PlayerOptions{})
+	bot := e.AddPlayer("bot", PlayerOptions{})
+	bot.IsBot = true
+
+	ana.lifeDamage, ana.AliveTime = 120, 42.7
+	e.recordCareerDeath(bot, ana)
+	e.recordCareerDeath(leo, ana)
+	e.recordCareerDeath(ana, leo)
+
+	a, ok := e.careers.Career("ana")
+	if !ok || a.Kills != 2 || a.Deaths != 1 || a.BestStreak != 2 {
+		t.Fatalf("Unexpected career for ana: %+v", a)
+	}
+	if a.Last == nil || a.Last.Seconds != 42 || a.Last.Damage != 120 || a.Last.Kills != 2 || a.Last.KilledBy != "leo" {
+		t.Errorf("Unexpected last life for ana: %+v", a.Last)
+	}
+	if _, ok := e.careers.Career("bot"); ok {
+		t.Error("Expected no career for bots")
+	}
+
+	// A new life starts a new streak; the best one is kept
+	ana.Respawn()
+	e.recordCareerDeath(leo, ana)
+	e.recordCareerDeath(ana, nil)
+	a, _ = e.careers.Career("ana")
+	if a.Kills != 3 || a.BestStreak != 2 || a.Last.Kills != 1 || a.Last.KilledBy != "" {
+		t.Errorf("Unexpected career after the second life: %+v, %+v", a, a.Last)
+	}
+	if a.KD() != 1.5 {
+		t.Errorf("Expected K/D 1.5, got %.2f", a.KD())
+	}
+}
+
+// TestCareerDamageDealt verifies only damage that lands counts, without overkill
+func TestCareerDamageDealt(t *testing.T) {
+	e := NewEngine(DefaultEngineConfig())
+	ana := e.AddPlayer("ana", PlayerOptions{})
+	leo := e.AddPlayer("leo", PlayerOptions{})
+	leo.SpawnProtection = false
+
+	leo.HP = 30
+	leo.TakeDamage(20, ana)
+	leo.TakeDamage(50, ana)
+	if ana.lifeDamage != 30 {
+		t.Errorf("Expected 30 damage dealt, got %d", ana.lifeDamage)
+	}
+}
+
+// TestCareerPersistence verifies careers survive restarts
+func TestCareerPersistence(t *testing.T) {
+	cfg := DefaultCareer
+	cfg.File = filepath.Join(t.TempDir(), "career.json")
+	cs := NewCareerStore(cfg)
+	cs.Start()
+	cs.RecordKill("ana", 3)
+	cs.RecordDeath("ana", LifeSummary{Seconds: 90, Damage: 240, Kills: 3, Weapon: "axe"})
+	cs.Stop()
+
+	restored := NewCareerStore(cfg)
+	restored.Start()
+	defer restored.Stop()
+	a, ok := restored.Career("ana")
+	if !ok || a.Kills != 1 || a.Deaths != 1 || a.BestStreak != 3 || a.Last == nil || a.Last.Damage != 240 {
+		t.Errorf("Expected ana's career restored, got %+v", a)
+	}
+}
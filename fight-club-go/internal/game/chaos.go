@@ -0,0 +1,397 @@
+package game
+
+import (
+	"fmt"
+	"log"
+	"math"
+
+	"fight-club/internal/config"
+)
+
+// ChaosConfig is an alias for config.ChaosConfig (SSOT)
+type ChaosConfig = config.ChaosConfig
+
+// Chaos event IDs
+const (
+	ChaosMeteorShower  = "meteor_shower"
+	ChaosShrinkingZone = "shrinking_zone"
+	ChaosGravityFlip   = "gravity_flip"
+)
+
+// Chaos event tuning
+const (
+	MeteorSpawnInterval = 0.6  // Seconds between meteor targets during a shower
+	MeteorFallTime      = 1.5  // Seconds from the ground marker to impact (dodge window)
+	MeteorRadius        = 70.0 // Impact area radius
+	MeteorDamage        = 35
+	MaxMeteors          = 8 // Meteors falling at once
+
+	ZoneMinRadius      = 150.0 // Safe zone radius once fully shrunk
+	ZoneShrinkFraction = 0.7   // Share of the event spent shrinking (the rest holds)
+	ZoneDamage         = 6     // Damage per ZoneDamageInterval outside the zone
+	ZoneDamageInterval = 0.5
+
+	GravityFlipPull   = 0.9 // Velocity added per tick towards the top/bottom edge
+	GravityFlipPeriod = 4.0 // Seconds between flips
+)
+
+// ChaosEvent is a scheduled world event
+type ChaosEvent struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Emoji       string `json:"emoji"`
+	Description string `json:"description"`
+}
+
+// ChaosEvents is the registry of all chaos events
+var ChaosEvents = map[string]ChaosEvent{
+	ChaosMeteorShower: {
+		ID:          ChaosMeteorShower,
+		Name:        "Meteor Shower",
+		Emoji:       "☄️",
+		Description: "Meteors rain down - get out of the red circles",
+	},
+	ChaosShrinkingZone: {
+		ID:          ChaosShrinkingZone,
+		Name:        "Shrinking Zone",
+		Emoji:       "🔥",
+		Description: "The safe zone closes in - stay inside or burn",
+	},
+	ChaosGravityFlip: {
+		ID:          ChaosGravityFlip,
+		Name:        "Gravity Flip",
+		Emoji:       "🙃",
+		Description: "Gravity pulls everyone up, then down",
+	},
+}
+
+// chaosEventOrder fixes the pick order so the engine RNG alone decides the event
+var chaosEventOrder = []string{ChaosMeteorShower, ChaosShrinkingZone, ChaosGravityFlip}
+
+// GetChaosEvent returns a chaos event by ID and whether it exists
+func GetChaosEvent(id string) (ChaosEvent, bool) {
+	ev, ok := ChaosEvents[id]
+	return ev, ok
+}
+
+// MeteorSnapshot is a falling meteor for rendering
+type MeteorSnapshot struct {
+	X, Y     float64 // Impact point
+	Radius   float64
+	Progress float64 // 0 (marker appears) .. 1 (impact)
+}
+
+// ChaosSnapshot is the chaos event state for the stream overlay
+type ChaosSnapshot struct {
+	Event     string  // Chaos event ID ("" = none)
+	Warning   bool    // Announced but not started yet
+	Remaining float64 // Seconds until it starts (warning) or ends
+
+	// Shrinking zone
+	ZoneX, ZoneY, ZoneRadius float64
+
+	// Gravity flip pull: -1 up, 1 down (0 otherwise)
+	Gravity float64
+
+	// Meteor shower
+	Meteors     [MaxMeteors]MeteorSnapshot
+	MeteorCount int
+}
+
+// meteor is a meteor on its way down
+type meteor struct {
+	x, y    float64
+	elapsed float64
+}
+
+// ChaosManager schedules chaos events: every Interval seconds a random event
+// is announced for Warning seconds, then runs for Duration seconds. All
+// randomness comes from the engine RNG so replays pick the same events and
+// meteor targets. State is guarded by the engine lock (see Engine.updateChaos).
+type ChaosManager struct {
+	cfg ChaosConfig
+
+	untilNext float64 // Seconds until the next warning
+	event     string  // Current event ID ("" = none)
+	warning   bool
+	remaining float64
+
+	meteors     []meteor
+	meteorTimer float64
+
+	zoneX, zoneY     float64
+	zoneRadius       float64
+	zoneStartRadius  float64
+	zoneDamageTimer  float64
+	gravity          float64
+	gravityFlipTimer float64
+
+	// Callbacks (called in a new goroutine, e.g. to post to Kick chat)
+	OnWarning func(event ChaosEvent, seconds float64)
+	OnStart   func(event ChaosEvent)
+	OnEnd     func(event ChaosEvent)
+}
+
+// NewChaosManager creates a chaos manager for an arena of the given size;
+// the first event is announced after one interval
+func NewChaosManager(cfg ChaosConfig, width, height float64) *ChaosManager {
+	if cfg.Duration <= 0 {
+		cfg.Duration = config.DefaultChaos().Duration
+	}
+	return &ChaosManager{
+		cfg:             cfg,
+		untilNext:       cfg.Interval,
+		zoneX:           width / 2,
+		zoneY:           height / 2,
+		zoneStartRadius: math.Hypot(width, height) / 2,
+	}
+}
+
+// Enabled reports whether chaos events are scheduled
+func (cm *ChaosManager) Enabled() bool {
+	return cm.cfg.Interval > 0
+}
+
+// Snapshot returns the chaos state for rendering
+func (cm *ChaosManager) Snapshot() ChaosSnapshot {
+	snap := ChaosSnapshot{Event: cm.event, Warning: cm.warning, Remaining: cm.remaining}
+	if cm.event == "" || cm.warning {
+		return snap
+	}
+	switch cm.event {
+	case ChaosShrinkingZone:
+		snap.ZoneX, snap.ZoneY, snap.ZoneRadius = cm.zoneX, cm.zoneY, cm.zoneRadius
+	case ChaosGravityFlip:
+		snap.Gravity = cm.gravity
+	case ChaosMeteorShower:
+		for _, m := range cm.meteors {
+			snap.Meteors[snap.MeteorCount] = MeteorSnapshot{
+				X: m.x, Y: m.y, Radius: MeteorRadius, Progress: m.elapsed / MeteorFallTime,
+			}
+			snap.MeteorCount++
+		}
+	}
+	return snap
+}
+
+// updateChaos advances the chaos schedule and the running event. Caller holds e.mu.
+func (e *Engine) updateChaos(deltaTime float64) {
+	cm := e.chaos
+
+	if cm.event == "" {
+		if !cm.Enabled() {
+			return
+		}
+		cm.untilNext -= deltaTime
+		if cm.untilNext <= 0 {
+			e.announceChaos(chaosEventOrder[e.rng.Intn(len(chaosEventOrder))])
+		}
+		return
+	}
+
+	cm.remaining -= deltaTime
+	if cm.warning {
+		if cm.remaining <= 0 {
+			e.startChaos()
+		}
+		return
+	}
+	if cm.remaining <= 0 {
+		e.endChaos()
+		return
+	}
+
+	switch cm.event {
+	case ChaosMeteorShower:
+		e.updateMeteors(deltaTime)
+	case ChaosShrinkingZone:
+		e.updateZone(deltaTime)
+	case ChaosGravityFlip:
+		e.updateGravityFlip(deltaTime)
+	}
+}
+
+// announceChaos puts an event on screen for the warning period. Caller holds e.mu.
+func (e *Engine) announceChaos(id string) {
+	cm := e.chaos
+	ev := ChaosEvents[id]
+	cm.event = id
+	cm.warning = true
+	cm.remaining = cm.cfg.Warning
+	cm.untilNext = cm.cfg.Interval
+
+	log.Printf("%s Chaos event incoming: %s (in %.0fs)", ev.Emoji, ev.Name, cm.cfg.Warning)
+	e.eventLog.EmitSimple(EventTypeChaos, uint64(e.tickCount), "",
+		ChaosPayload{Event: id, Phase: "warning"})
+	if cm.OnWarning != nil {
+		go cm.OnWarning(ev, cm.cfg.Warning)
+	}
+	if cm.remaining <= 0 {
+		e.startChaos()
+	}
+}
+
+// startChaos begins the announced event. Caller holds e.mu.
+func (e *Engine) startChaos() {
+	cm := e.chaos
+	ev := ChaosEvents[cm.event]
+	cm.warning = false
+	cm.remaining = cm.cfg.Duration
+
+	cm.meteors = cm.meteors[:0]
+	cm.meteorTimer = 0
+	cm.zoneRadius = cm.zoneStartRadius
+	cm.zoneDamageTimer = ZoneDamageInterval
+	cm.gravity = -1 // Up first
+	cm.gravityFlipTimer = GravityFlipPeriod
+
+	log.Printf("%s Chaos event started: %s", ev.Emoji, ev.Name)
+	e.eventLog.EmitSimple(EventTypeChaos, uint64(e.tickCount), "",
+		ChaosPayload{Event: cm.event, Phase: "start"})
+	e.AddShake(8.0)
+	if cm.OnStart != nil {
+		go cm.OnStart(ev)
+	}
+}
+
+// endChaos clears the running event and schedules the next. Caller holds e.mu.
+func (e *Engine) endChaos() {
+	cm := e.chaos
+	ev := ChaosEvents[cm.event]
+
+	log.Printf("%s Chaos event over: %s", ev.Emoji, ev.Name)
+	e.eventLog.EmitSimple(EventTypeChaos, uint64(e.tickCount), "",
+		ChaosPayload{Event: cm.event, Phase: "end"})
+
+	cm.event = ""
+	cm.warning = false
+	cm.remaining = 0
+	cm.meteors = cm.meteors[:0]
+	cm.gravity = 0
+	if cm.OnEnd != nil {
+		go cm.OnEnd(ev)
+	}
+}
+
+// updateMeteors drops new meteors and resolves impacts. Caller holds e.mu.
+func (e *Engine) updateMeteors(deltaTime float64) {
+	cm := e.chaos
+
+	// Resolve impacts first so a meteor is shown at Progress 1 for at most one tick
+	landed := cm.meteors[:0]
+	for _, m := range cm.meteors {
+		m.elapsed += deltaTime
+		if m.elapsed < MeteorFallTime {
+			landed = append(landed, m)
+			continue
+		}
+		e.meteorImpact(m.x, m.y)
+	}
+	cm.meteors = landed
+
+	cm.meteorTimer -= deltaTime
+	if cm.meteorTimer <= 0 && len(cm.meteors) < MaxMeteors {
+		cm.meteorTimer = MeteorSpawnInterval
+		x := MeteorRadius + e.rng.Float64()*(e.worldWidth-2*MeteorRadius)
+		y := MeteorRadius + e.rng.Float64()*(e.worldHeight-2*MeteorRadius)
+		cm.meteors = append(cm.meteors, meteor{x: x, y: y})
+	}
+}
+
+// meteorImpact deals area damage around (x, y). Duelists are left alone. Caller holds e.mu.
+func (e *Engine) meteorImpact(x, y float64) {
+	hits := 0
+	for _, p := range e.playerSlice {
+		if p.IsDead || p.InDuel || math.Hypot(p.X-x, p.Y-y) > MeteorRadius+PlayerRadius {
+			continue
+		}
+		if e.chaosDamage(p, MeteorDamage) {
+			hits++
+		}
+	}
+
+	e.emitParticles("meteor", x, y, "#ff6b00", 0)
+	e.CreateFlash(x, y, "#ffb300", 2.0)
+	e.AddShake(4.0)
+	e.eventLog.EmitSimple(EventTypeChaos, uint64(e.tickCount), "",
+		ChaosPayload{Event: ChaosMeteorShower, Phase: "impact", X: x, Y: y, Radius: MeteorRadius, Hits: hits})
+}
+
+// updateZone shrinks the safe zone and burns fighters outside it. Caller holds e.mu.
+func (e *Engine) updateZone(deltaTime float64) {
+	cm := e.chaos
+
+	elapsed := cm.cfg.Duration - cm.remaining
+	t := min(elapsed/(cm.cfg.Duration*ZoneShrinkFraction), 1)
+	cm.zoneRadius = cm.zoneStartRadius + (ZoneMinRadius-cm.zoneStartRadius)*t
+
+	cm.zoneDamageTimer -= deltaTime
+	if cm.zoneDamageTimer > 0 {
+		return
+	}
+	cm.zoneDamageTimer = ZoneDamageInterval
+	for _, p := range e.playerSlice {
+		if p.IsDead || p.InDuel || math.Hypot(p.X-cm.zoneX, p.Y-cm.zoneY) <= cm.zoneRadius {
+			continue
+		}
+		e.chaosDamage(p, ZoneDamage)
+	}
+}
+
+// updateGravityFlip pulls fighters towards the top or bottom edge, flipping periodically. Caller holds e.mu.
+func (e *Engine) updateGravityFlip(deltaTime float64) {
+	cm := e.chaos
+
+	cm.gravityFlipTimer -= deltaTime
+	if cm.gravityFlipTimer <= 0 {
+		cm.gravityFlipTimer = GravityFlipPeriod
+		cm.gravity = -cm.gravity
+		e.AddShake(3.0)
+	}
+	for _, p := range e.playerSlice {
+		if p.IsDead && !p.IsRagdoll {
+			continue
+		}
+		p.VY += cm.gravity * GravityFlipPull
+	}
+}
+
+// chaosDamage hurts a fighter with the running chaos event; returns true if damage landed. Caller holds e.mu.
+func (e *Engine) chaosDamage(p *Player, damage int) bool {
+	return e.hazardDamage(p, damage, "the "+ChaosEvents[e.chaos.event].Name)
+}
+
+// hazardDamage hurts a fighter without an attacker (cause names the hazard
+// in the log); returns true if damage landed. Caller holds e.mu.
+func (e *Engine) hazardDamage(p *Player, damage int, cause string) bool {
+	before := p.HP
+	p.TakeDamage(damage, nil)
+	if p.HP == before {
+		return false // Spawn protection or invulnerable
+	}
+
+	if len(e.texts) < e.limits.MaxTexts {
+		e.texts = append(e.texts, &FloatingText{
+			X:     p.X,
+			Y:     p.Y - 30,
+			Text:  fmt.Sprintf("-%d", damage),
+			Color: "#ff6b00",
+			Alpha: 1.0,
+			VY:    -2,
+		})
+	}
+	if p.IsDead {
+		e.recordDeath(p, before, nil)
+		e.recordCareerDeath(p, nil)
+		e.dropLoot(p)
+		e.dropWeapon(p)
+		log.Printf("💀 %s was killed by %s", p.Name, cause)
+		e.emitParticles("death", p.X, p.Y, p.Color, 0)
+	}
+	return true
+}
+
+// GetChaosManager returns the chaos event scheduler
+func (e *Engine) GetChaosManager() *ChaosManager {
+	return e.chaos
+}
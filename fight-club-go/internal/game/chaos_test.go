@@ -0,0 +1,112 @@
+package game
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// testChaos schedules a chaos event every 10s
+var testChaos = ChaosConfig{Interval: 10, Warning: 2, Duration: 5}
+
+// TestChaosSchedule verifies the warning -> running -> over cycle and callbacks
+func TestChaosSchedule(t *testing.T) {
+	engine := newTestEngineWith(func(cfg *EngineConfig) { cfg.Chaos = testChaos })
+	engine.rng = rand.New(rand.NewSource(1))
+	warnings := make(chan ChaosEvent, 1)
+	engine.chaos.OnWarning = func(ev ChaosEvent, seconds float64) { warnings <- ev }
+
+	stepEngine(engine, 9.9, engine.updateChaos)
+	if snap := engine.chaos.Snapshot(); snap.Event != "" {
+		t.Fatalf("Expected no event before the interval, got %+v", snap)
+	}
+
+	stepEngine(engine, 0.2, engine.updateChaos)
+	snap := engine.chaos.Snapshot()
+	if snap.Event == "" || !snap.Warning {
+		t.Fatalf("Expected a warning after the interval, got %+v", snap)
+	}
+	if ev := <-warnings; ev.ID != snap.Event {
+		t.Errorf("Expected warning callback for %s, got %s", snap.Event, ev.ID)
+	}
+
+	stepEngine(engine, 2, engine.updateChaos)
+	if snap := engine.chaos.Snapshot(); snap.Warning || snap.Event == "" {
+		t.Fatalf("Expected the event running after the warning, got %+v", snap)
+	}
+
+	stepEngine(engine, 5, engine.updateChaos)
+	if snap := engine.chaos.Snapshot(); snap.Event != "" {
+		t.Fatalf("Expected the event over after its duration, got %+v", snap)
+	}
+}
+
+// TestChaosDeterministic verifies the same RNG seed picks the same events and meteor targets
+func TestChaosDeterministic(t *testing.T) {
+	run := func() ChaosSnapshot {
+		engine := newTestEngineWith(func(cfg *EngineConfig) { cfg.Chaos = testChaos })
+		engine.rng = rand.New(rand.NewSource(42))
+		engine.announceChaos(ChaosMeteorShower)
+		stepEngine(engine, 3, engine.updateChaos)
+		return engine.chaos.Snapshot()
+	}
+	a, b := run(), run()
+	if a.MeteorCount == 0 || a != b {
+		t.Errorf("Expected identical meteor showers, got %+v and %+v", a, b)
+	}
+
+	picks := func() []string {
+		engine := newTestEngineWith(func(cfg *EngineConfig) { cfg.Chaos = testChaos })
+		engine.rng = rand.New(rand.NewSource(7))
+		var events []string
+		for i := 0; i < 5; i++ {
+			stepEngine(engine, 10.1, engine.updateChaos)
+			events = append(events, engine.chaos.event)
+			stepEngine(engine, 7, engine.updateChaos)
+		}
+		return events
+	}
+	first, second := picks(), picks()
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("Expected the same event sequence, got %v and %v", first, second)
+		}
+	}
+}
+
+// TestChaosDamage verifies meteors hit only nearby fighters and the zone burns those outside it
+func TestChaosDamage(t *testing.T) {
+	engine := newTestEngineWith(func(cfg *EngineConfig) { cfg.Chaos = testChaos })
+	engine.rng = rand.New(rand.NewSource(3))
+	near := engine.AddPlayer("near", PlayerOptions{})
+	far := engine.AddPlayer("far", PlayerOptions{})
+	near.SpawnProtection, far.SpawnProtection = false, false
+	near.X, near.Y = 400, 400
+	far.X, far.Y = 1000, 100
+
+	engine.playerSlice = []*Player{near, far}
+	engine.announceChaos(ChaosMeteorShower)
+	engine.startChaos()
+	engine.meteorImpact(420, 400)
+	if near.HP != near.MaxHP-MeteorDamage {
+		t.Errorf("Expected the nearby fighter hit for %d, at %d HP", MeteorDamage, near.HP)
+	}
+	if far.HP != far.MaxHP {
+		t.Errorf("Expected the distant fighter untouched, at %d HP", far.HP)
+	}
+	engine.endChaos()
+
+	// Fully shrunk zone: the center is safe, the corner burns
+	near.HP, near.X, near.Y = near.MaxHP, 640, 360
+	engine.announceChaos(ChaosShrinkingZone)
+	engine.startChaos()
+	stepEngine(engine, 4.5, engine.updateChaos)
+	if r := engine.chaos.Snapshot().ZoneRadius; r != ZoneMinRadius {
+		t.Errorf("Expected the zone fully shrunk to %.0f, got %.1f", ZoneMinRadius, r)
+	}
+	if near.HP != near.MaxHP {
+		t.Errorf("Expected the fighter inside the zone unharmed, at %d HP", near.HP)
+	}
+	if far.HP >= far.MaxHP {
+		t.Error("Expected the fighter outside the zone to burn")
+	}
+}
@@ -0,0 +1,313 @@
+package game
+
+import (
+	"fmt"
+	"log"
+	"math"
+
+	"fight-club/internal/config"
+)
+
+// DuelConfig is an alias for config.DuelConfig (SSOT)
+type DuelConfig = config.DuelConfig
+
+// DuelSnapshot is the active duel for the stream overlay (ring + countdown)
+type DuelSnapshot struct {
+	Active    bool
+	X, Y      float64   // Ring center
+	Radius    float64   // Ring radius
+	Players   [2]string // Challenger, opponent
+	Remaining float64   // Seconds until the duel ends in a draw
+}
+
+// DuelResult describes how a duel ended
+type DuelResult struct {
+	Players [2]string // Challenger, opponent
+	Winner  string    // "" on a draw or cancel
+	Loser   string
+	Draw    bool   // Time ran out (or both fell)
+	Reason  string // "kill", "timeout" or "left"
+	Bonus   int    // Wallet payout credited to the winner
+}
+
+// duelChallenge is an open !duel waiting for the target's !accept
+type duelChallenge struct {
+	challenger string
+	remaining  float64 // Seconds until it expires
+}
+
+// DuelManager holds chat-initiated 1v1 duels. One duel runs at a time inside
+// a ring at the arena center; duelists can only hit each other and nobody
+// else can hit them. State is guarded by the engine lock (see Engine.ChallengeDuel).
+type DuelManager struct {
+	cfg DuelConfig
+
+	challenges map[string]duelChallenge // target -> open challenge
+
+	active    bool
+	players   [2]string // Challenger, opponent
+	labels    [2]string // Their display names (rendered)
+	remaining float64
+	x, y      float64 // Ring center
+
+	// Callbacks (called in a new goroutine, e.g. to post to Kick chat)
+	OnChallenge func(challenger, target string, timeout float64)
+	OnDuelStart func(challenger, opponent string, duration float64)
+	OnDuelEnd   func(result DuelResult)
+}
+
+// NewDuelManager creates a duel manager with its ring at (x, y)
+func NewDuelManager(cfg DuelConfig, x, y float64) *DuelManager {
+	defaults := config.DefaultDuel()
+	if cfg.Duration <= 0 {
+		cfg.Duration = defaults.Duration
+	}
+	if cfg.ChallengeTimeout <= 0 {
+		cfg.ChallengeTimeout = defaults.ChallengeTimeout
+	}
+	if cfg.RingRadius <= 0 {
+		cfg.RingRadius = defaults.RingRadius
+	}
+	return &DuelManager{
+		cfg:        cfg,
+		challenges: make(map[string]duelChallenge),
+		x:          x,
+		y:          y,
+	}
+}
+
+// Snapshot returns the duel state for rendering
+func (dm *DuelManager) Snapshot() DuelSnapshot {
+	if !dm.active {
+		return DuelSnapshot{}
+	}
+	return DuelSnapshot{
+		Active:    true,
+		X:         dm.x,
+		Y:         dm.y,
+		Radius:    dm.cfg.RingRadius,
+		Players:   dm.labels,
+		Remaining: math.Max(0, dm.remaining),
+	}
+}
+
+// ChallengeDuel opens a challenge from one fighter to another; the target has
+// ChallengeTimeout seconds to !accept. A new challenge replaces the challenger's previous one.
+func (e *Engine) ChallengeDuel(challengerName, targetName string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	dm := e.duels
+	if challengerName == targetName {
+		return fmt.Errorf("cannot duel yourself")
+	}
+	challenger, ok := e.players[challengerName]
+	if !ok {
+		return fmt.Errorf("not in arena (type !join first)")
+	}
+	target, ok := e.players[targetName]
+	if !ok {
+		return fmt.Errorf("'%s' is not in the arena", targetName)
+	}
+	if target.IsBot {
+		return fmt.Errorf("bots don't accept duels")
+	}
+	if err := e.duelReady(challenger, target); err != nil {
+		return err
+	}
+	if open, ok := dm.challenges[targetName]; ok && open.challenger != challengerName {
+		return fmt.Errorf("'%s' already has an open challenge", targetName)
+	}
+
+	for t, c := range dm.challenges {
+		if c.challenger == challengerName {
+			delete(dm.challenges, t)
+		}
+	}
+	dm.challenges[targetName] = duelChallenge{challenger: challengerName, remaining: dm.cfg.ChallengeTimeout}
+
+	log.Printf("🤺 %s challenged %s to a duel (%.0fs to !accept)", challengerName, targetName, dm.cfg.ChallengeTimeout)
+	if dm.OnChallenge != nil {
+		go dm.OnChallenge(challengerName, targetName, dm.cfg.ChallengeTimeout)
+	}
+	return nil
+}
+
+// AcceptDuel accepts the open challenge to username and starts the duel:
+// both fighters are healed and teleported into the ring
+func (e *Engine) AcceptDuel(username string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	dm := e.duels
+	open, ok := dm.challenges[username]
+	if !ok {
+		return fmt.Errorf("no open challenge")
+	}
+	challenger, ok := e.players[open.challenger]
+	if !ok {
+		delete(dm.challenges, username)
+		return fmt.Errorf("'%s' left the arena", open.challenger)
+	}
+	opponent, ok := e.players[username]
+	if !ok {
+		return fmt.Errorf("not in arena (type !join first)")
+	}
+	if err := e.duelReady(challenger, opponent); err != nil {
+		return err
+	}
+	delete(dm.challenges, username)
+
+	dm.active = true
+	dm.players = [2]string{challenger.Name, opponent.Name}
+	dm.labels = [2]string{challenger.DisplayName, opponent.DisplayName}
+	dm.remaining = dm.cfg.Duration
+
+	// Face off on either side of the center
+	offset := dm.cfg.RingRadius * 0.5
+	for i, p := range []*Player{challenger, opponent} {
+		p.InDuel = true
+		p.X = dm.x + offset*float64(2*i-1)
+		p.Y = dm.y
+		p.VX, p.VY = 0, 0
+		p.HP = p.MaxHP
+		p.Target = nil
+		p.FocusTarget = ""
+		p.FocusTTL = 0
+		p.lastAttacker = nil
+		p.lastAttackedTimer = 0
+	}
+
+	log.Printf("🤺 Duel started: %s vs %s (%.0fs)", challenger.Name, opponent.Name, dm.cfg.Duration)
+	if dm.OnDuelStart != nil {
+		go dm.OnDuelStart(challenger.Name, opponent.Name, dm.cfg.Duration)
+	}
+	return nil
+}
+
+// duelReady checks two fighters can start a duel. Caller holds e.mu.
+func (e *Engine) duelReady(a, b *Player) error {
+	if e.duels.active {
+		return fmt.Errorf("a duel is already in progress")
+	}
+	for _, p := range []*Player{a, b} {
+		if p.IsDead || p.State != StateAlive {
+			return fmt.Errorf("'%s' is not fighting", p.Name)
+		}
+	}
+	if a.TeamID != "" && a.TeamID == b.TeamID {
+		return fmt.Errorf("teammates cannot duel")
+	}
+	return nil
+}
+
+// updateDuel expires challenges, keeps the ring separated and settles the
+// active duel. Called from tick with e.mu held.
+func (e *Engine) updateDuel(deltaTime float64) {
+	dm := e.duels
+	for target, c := range dm.challenges {
+		c.remaining -= deltaTime
+		if c.remaining <= 0 {
+			log.Printf("🤺 %s's duel challenge to %s expired", c.challenger, target)
+			delete(dm.challenges, target)
+			continue
+		}
+		dm.challenges[target] = c
+	}
+
+	if !dm.active {
+		return
+	}
+	dm.remaining -= deltaTime
+
+	a, aOK := e.players[dm.players[0]]
+	b, bOK := e.players[dm.players[1]]
+	switch {
+	case !aOK || !bOK:
+		e.endDuel(DuelResult{Reason: "left"})
+		return
+	case a.IsDead && b.IsDead:
+		e.endDuel(DuelResult{Draw: true, Reason: "kill"})
+		return
+	case a.IsDead:
+		e.endDuel(DuelResult{Winner: b.Name, Loser: a.Name, Reason: "kill"})
+		return
+	case b.IsDead:
+		e.endDuel(DuelResult{Winner: a.Name, Loser: b.Name, Reason: "kill"})
+		return
+	case dm.remaining <= 0:
+		e.endDuel(DuelResult{Draw: true, Reason: "timeout"})
+		return
+	}
+
+	// Duelists stay inside the ring, everyone else stays out
+	for _, p := range e.playerSlice {
+		if p.IsDead {
+			continue
+		}
+		dx, dy := p.X-dm.x, p.Y-dm.y
+		dist := math.Sqrt(dx*dx + dy*dy)
+		if dist == 0 {
+			dx, dist = 1, 1
+		}
+		if p.InDuel {
+			if limit := dm.cfg.RingRadius - PlayerRadius; dist > limit {
+				p.X, p.Y = dm.x+dx/dist*limit, dm.y+dy/dist*limit
+			}
+		} else if limit := dm.cfg.RingRadius + PlayerRadius; dist < limit {
+			p.X, p.Y = dm.x+dx/dist*limit, dm.y+dy/dist*limit
+		}
+	}
+}
+
+// endDuel releases the duelists, pays the winner and announces the result. Caller holds e.mu.
+func (e *Engine) endDuel(result DuelResult) {
+	dm := e.duels
+	for _, name := range dm.players {
+		if p, ok := e.players[name]; ok {
+			p.InDuel = false
+		}
+	}
+	dm.active = false
+	result.Players = dm.players
+
+	if result.Winner != "" {
+		if dm.cfg.Bonus > 0 && e.wallets.Credit(result.Winner, dm.cfg.Bonus) {
+			result.Bonus = dm.cfg.Bonus
+		}
+		if winner, ok := e.players[result.Winner]; ok && len(e.texts) < e.limits.MaxTexts {
+			e.texts = append(e.texts, &FloatingText{
+				X:     winner.X,
+				Y:     winner.Y - 40,
+				Text:  "DUEL WON!",
+				Color: "#ffd700",
+				Alpha: 1.0,
+				VY:    -1.5,
+			})
+		}
+		log.Printf("🏆 %s won the duel against %s (+$%d)", result.Winner, result.Loser, result.Bonus)
+		if winner, ok := e.players[result.Winner]; ok {
+			e.recordQuest(winner, QuestDuelWin, 1)
+		}
+		if result.Reason == "kill" && e.replayCfg.AutoDuel {
+			e.requestReplayLocked(ReplayDuel) // No replay when off or one is playing
+		}
+	} else {
+		log.Printf("🤺 Duel %s vs %s ended without a winner (%s)", dm.players[0], dm.players[1], result.Reason)
+	}
+
+	if dm.OnDuelEnd != nil {
+		go dm.OnDuelEnd(result)
+	}
+}
+
+// duelSeparated reports whether a duel keeps two players from fighting
+// (exactly one of them is in the ring)
+func duelSeparated(a, b *Player) bool {
+	return a.InDuel != b.InDuel
+}
+
+// GetDuelManager returns the duel manager (for wiring chat announcements)
+func (e *Engine) GetDuelManager() *DuelManager {
+	return e.duels
+}
@@ -0,0 +1,137 @@
+package game
+
+import (
+	"math"
+	"testing"
+)
+
+// testDuel is a 10s duel with a 100 bonus
+var testDuel = DuelConfig{Duration: 10, ChallengeTimeout: 5, Bonus: 100, RingRadius: 150}
+
+// TestDuelChallengeAndAccept verifies challenges validate, expire, and that
+// accepting teleports both fighters into the ring
+func TestDuelChallengeAndAccept(t *testing.T) {
+	engine := newTestEngineWith(func(cfg *EngineConfig) { cfg.Duel = testDuel })
+	alice := engine.AddPlayer("alice", PlayerOptions{})
+	bob := engine.AddPlayer("bob", PlayerOptions{})
+	engine.AddPlayer("carol", PlayerOptions{})
+
+	if err := engine.ChallengeDuel("alice", "alice"); err == nil {
+		t.Error("Expected error when challenging yourself")
+	}
+	if err := engine.ChallengeDuel("alice", "nobody"); err == nil {
+		t.Error("Expected error for unknown target")
+	}
+	if err := engine.AcceptDuel("bob"); err == nil {
+		t.Error("Expected error accepting without a challenge")
+	}
+
+	// Challenges expire
+	if err := engine.ChallengeDuel("alice", "bob"); err != nil {
+		t.Fatalf("Challenge failed: %v", err)
+	}
+	if err := engine.ChallengeDuel("carol", "bob"); err == nil {
+		t.Error("Expected error for a target with an open challenge")
+	}
+	engine.updateDuel(6)
+	if err := engine.AcceptDuel("bob"); err == nil {
+		t.Fatal("Expected the challenge to have expired")
+	}
+
+	engine.ChallengeDuel("alice", "bob")
+	if err := engine.AcceptDuel("bob"); err != nil {
+		t.Fatalf("Accept failed: %v", err)
+	}
+	if !alice.InDuel || !bob.InDuel {
+		t.Fatal("Expected both fighters in the duel")
+	}
+	duel := engine.duels.Snapshot()
+	if !duel.Active || duel.Players != [2]string{"alice", "bob"} || duel.Remaining != 10 {
+		t.Fatalf("Unexpected duel snapshot %+v", duel)
+	}
+	for _, p := range []*Player{alice, bob} {
+		if math.Hypot(p.X-duel.X, p.Y-duel.Y) > duel.Radius {
+			t.Errorf("Expected %s teleported into the ring, at (%.0f, %.0f)", p.Name, p.X, p.Y)
+		}
+	}
+
+	if err := engine.ChallengeDuel("carol", "alice"); err == nil {
+		t.Error("Expected error while a duel is in progress")
+	}
+}
+
+// TestDuelNoInterference verifies outsiders can't hit or target duelists and are pushed out of the ring
+func TestDuelNoInterference(t *testing.T) {
+	engine := newTestEngineWith(func(cfg *EngineConfig) { cfg.Duel = testDuel })
+	engine.AddPlayer("alice", PlayerOptions{})
+	bob := engine.AddPlayer("bob", PlayerOptions{})
+	carol := engine.AddPlayer("carol", PlayerOptions{})
+	engine.ChallengeDuel("alice", "bob")
+	engine.AcceptDuel("bob")
+
+	bob.SpawnProtection, carol.SpawnProtection = false, false
+	carol.X, carol.Y = bob.X-20, bob.Y
+	carol.AttackAngle = 0
+	engine.ProcessAttack(carol, bob, 10)
+	if bob.HP != bob.MaxHP {
+		t.Errorf("Expected outsider's hit blocked, bob at %d HP", bob.HP)
+	}
+
+	engine.playerSlice = []*Player{carol}
+	engine.updateDuel(1.0 / 30)
+	if d := engine.duels.Snapshot(); math.Hypot(carol.X-d.X, carol.Y-d.Y) < d.Radius {
+		t.Error("Expected outsider pushed out of the ring")
+	}
+
+	grid := engine.GetSpatialGrid()
+	grid.Clear()
+	grid.Insert(0, carol.X, carol.Y)
+	grid.Insert(1, bob.X, bob.Y)
+	carol.findTarget([]*Player{carol, bob}, 0, grid)
+	if carol.Target == bob {
+		t.Error("Expected outsider not to target a duelist")
+	}
+
+	// After the duel, the same hit lands
+	engine.endDuel(DuelResult{Draw: true, Reason: "timeout"})
+	carol.X, carol.Y = bob.X-20, bob.Y
+	engine.ProcessAttack(carol, bob, 10)
+	if bob.HP == bob.MaxHP || bob.InDuel {
+		t.Errorf("Expected the duel released, bob at %d HP (inDuel=%v)", bob.HP, bob.InDuel)
+	}
+}
+
+// TestDuelWinnerPayout verifies the winner is paid and announced, and a timeout is a draw
+func TestDuelWinnerPayout(t *testing.T) {
+	engine := newTestEngineWith(func(cfg *EngineConfig) { cfg.Duel = testDuel })
+	engine.AddPlayer("alice", PlayerOptions{})
+	bob := engine.AddPlayer("bob", PlayerOptions{})
+
+	results := make(chan DuelResult, 2)
+	engine.duels.OnDuelEnd = func(r DuelResult) { results <- r }
+
+	engine.ChallengeDuel("alice", "bob")
+	engine.AcceptDuel("bob")
+	bob.die(nil)
+	engine.updateDuel(1.0 / 30)
+
+	r := <-results
+	if r.Winner != "alice" || r.Loser != "bob" || r.Bonus != 100 {
+		t.Fatalf("Unexpected result %+v", r)
+	}
+	if got := engine.GetWalletManager().Balance("alice"); got != 100 {
+		t.Errorf("Expected winner's wallet credited 100, got %d", got)
+	}
+	if engine.duels.Snapshot().Active {
+		t.Error("Expected the duel over")
+	}
+
+	// Timeout ends in a draw without payout
+	bob.Respawn()
+	engine.ChallengeDuel("alice", "bob")
+	engine.AcceptDuel("bob")
+	engine.updateDuel(11)
+	if r := <-results; !r.Draw || r.Bonus != 0 {
+		t.Errorf("Expected a draw, got %+v", r)
+	}
+}
@@ -0,0 +1,126 @@
+package game
+
+// EmoteAnimation is the character animation played while an emote is active
+type EmoteAnimation string
+
+const (
+	EmoteAnimJump EmoteAnimation = "jump" // Hop up and land
+	EmoteAnimSpin EmoteAnimation = "spin" // Full 360° body rotation
+	EmoteAnimFlex EmoteAnimation = "flex" // Pulse/scale the body
+)
+
+// Emote timing (seconds)
+const (
+	EmoteCooldown = 5.0 // Per-player cooldown between emotes (anti-spam)
+	TauntEmoteID  = "taunt"
+)
+
+// Emote represents a chat-triggered emote/taunt
+type Emote struct {
+	ID        string         `json:"id"`
+	Name      string         `json:"name"`
+	Emoji     string         `json:"emoji"`     // Floating emoji drawn above the player
+	Animation EmoteAnimation `json:"animation"` // Character animation
+	Duration  float64        `json:"duration"`  // seconds
+}
+
+// Emotes is the registry of all available emotes
+var Emotes = map[string]Emote{
+	"jump": {
+		ID:        "jump",
+		Name:      "Jump",
+		Emoji:     "⬆️",
+		Animation: EmoteAnimJump,
+		Duration:  0.8,
+	},
+	"spin": {
+		ID:        "spin",
+		Name:      "Spin",
+		Emoji:     "🌀",
+		Animation: EmoteAnimSpin,
+		Duration:  1.0,
+	},
+	"flex": {
+		ID:        "flex",
+		Name:      "Flex",
+		Emoji:     "💪",
+		Animation: EmoteAnimFlex,
+		Duration:  1.5,
+	},
+	"laugh": {
+		ID:        "laugh",
+		Name:      "Laugh",
+		Emoji:     "😂",
+		Animation: EmoteAnimJump,
+		Duration:  1.2,
+	},
+	"dance": {
+		ID:        "dance",
+		Name:      "Dance",
+		Emoji:     "💃",
+		Animation: EmoteAnimSpin,
+		Duration:  1.5,
+	},
+	TauntEmoteID: {
+		ID:        TauntEmoteID,
+		Name:      "Taunt",
+		Emoji:     "😜",
+		Animation: EmoteAnimFlex,
+		Duration:  1.2,
+	},
+}
+
+// GetEmote returns an emote by ID and whether it exists
+func GetEmote(id string) (Emote, bool) {
+	e, ok := Emotes[id]
+	return e, ok
+}
+
+// GetAllEmotes returns all emotes as a slice
+func GetAllEmotes() []Emote {
+	emotes := make([]Emote, 0, len(Emotes))
+	for _, e := range Emotes {
+		emotes = append(emotes, e)
+	}
+	return emotes
+}
+
+// EmoteProgress returns how far through the emote animation a player is (0..1)
+// Returns 0 when no emote is playing
+func (p *Player) EmoteProgress() float64 {
+	if p.Emote == "" {
+		return 0
+	}
+	e, ok := Emotes[p.Emote]
+	if !ok || e.Duration <= 0 {
+		return 0
+	}
+	progress := 1.0 - p.EmoteTimer/e.Duration
+	if progress < 0 {
+		return 0
+	}
+	if progress > 1 {
+		return 1
+	}
+	return progress
+}
+
+// updateEmote advances emote animation and cooldown timers
+func (p *Player) updateEmote(deltaTime float64) {
+	if p.EmoteCooldown > 0 {
+		p.EmoteCooldown -= deltaTime
+	}
+	if p.EmoteTimer > 0 {
+		p.EmoteTimer -= deltaTime
+		if p.EmoteTimer <= 0 {
+			p.Emote = ""
+			p.EmoteTimer = 0
+		}
+	}
+}
+
+// clearEmote stops any playing emote (death/respawn)
+func (p *Player) clearEmote() {
+	p.Emote = ""
+	p.EmoteTimer = 0
+}
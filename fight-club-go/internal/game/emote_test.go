@@ -0,0 +1,59 @@
+package game
+
+import (
+	"testing"
+)
+
+// TestGetEmote tests emote registry lookup
+func TestGetEmote(t *testing.T) {
+	for _, id := range []string{"jump", "spin", "flex", TauntEmoteID} {
+		e, ok := GetEmote(id)
+		if !ok {
+			t.Errorf("Expected emote '%s' to exist", id)
+			continue
+		}
+		if e.Emoji == "" || e.Duration <= 0 {
+			t.Errorf("Emote %s should have emoji and positive duration", id)
+		}
+	}
+
+	if _, ok := GetEmote("moonwalk"); ok {
+		t.Error("Unknown emote should not be found")
+	}
+}
+
+// TestTriggerEmote verifies emote start, cooldown and expiry
+func TestTriggerEmote(t *testing.T) {
+	engine := newTestEngine(30)
+	player := engine.AddPlayer("emoter", PlayerOptions{})
+
+	if err := engine.TriggerEmote("emoter", "spin"); err != nil {
+		t.Fatalf("TriggerEmote failed: %v", err)
+	}
+	if player.Emote != "spin" {
+		t.Errorf("Expected emote 'spin', got '%s'", player.Emote)
+	}
+
+	// Second emote should hit the cooldown
+	if err := engine.TriggerEmote("emoter", "flex"); err == nil {
+		t.Error("Expected cooldown error on second emote")
+	}
+
+	// Unknown emote and unknown player are rejected
+	if err := engine.TriggerEmote("emoter", "moonwalk"); err == nil {
+		t.Error("Expected error for unknown emote")
+	}
+	if err := engine.TriggerEmote("ghost", "spin"); err == nil {
+		t.Error("Expected error for unknown player")
+	}
+
+	// Animation finishes after its duration, cooldown after EmoteCooldown
+	player.updateEmote(Emotes["spin"].Duration + 0.01)
+	if player.Emote != "" {
+		t.Errorf("Emote should have expired, got '%s'", player.Emote)
+	}
+	player.updateEmote(EmoteCooldown)
+	if err := engine.TriggerEmote("emoter", "flex"); err != nil {
+		t.Errorf("Emote should be allowed after cooldown: %v", err)
+	}
+}
@@ -3,27 +3,33 @@ package game
 import (
 	"fmt"
 	"log"
-	"math"
 	"math/rand"
+	"slices"
 	"sort"
 	"sync"
 	"time"
 
 	"fight-club/internal/game/spatial"
+	"fight-club/internal/profiling"
 )
 
 // Engine is the main game engine handling the game loop and physics
 type Engine struct {
-	mu        sync.RWMutex
-	players   map[string]*Player
-	particles []*Particle
-	effects   []*AttackEffect
-	texts     []*FloatingText
+	mu           sync.RWMutex
+	players      map[string]*Player
+	particles    []*Particle
+	particlePool particlePool // Recycled dead particles (see particles.go)
+	effects      []*AttackEffect
+	texts        []*FloatingText
 
 	// Spatial indexing for O(1) neighbor queries (replaces O(n²) scans)
 	spatialGrid *spatial.SpatialGrid
 	playerSlice []*Player // Cached slice for index-based access
 
+	// Next tick's spatial grid, prebuilt on a worker (see broadphase.go)
+	broadphase    broadphase
+	rosterVersion uint64 // Bumped whenever e.players gains or loses a fighter
+
 	// Phase 2: Sweep-and-Prune for broad-phase collision detection
 	// Uses temporal coherence - nearly O(n) when entities move little
 	sap *spatial.SweepAndPrune
@@ -44,14 +50,29 @@ type Engine struct {
 	// Combat configuration
 	comboDefinitions map[string]ComboDefinition
 
-	tickRate int
-	running  bool
-	ticker   *time.Ticker
-	stopChan chan struct{}
+	tickRate        int
+	tickRateChanged chan struct{} // Signals the game loop after SetArenaPreset
+	running         bool
+	ticker          *time.Ticker
+	stopChan        chan struct{}
+
+	maxCatchUpTicks int // Missed ticks replayed after a stall (see timestep.go)
+
+	// Pause freezes the simulation while snapshots keep flowing (see pause.go)
+	paused   bool
+	pausedAt time.Time
+
+	// Live viewer count from the Kick API (0 = unknown/offline)
+	viewerCount int
 
 	// Stats
 	totalKills int
 	tickCount  int64
+	tickTimes  tickTimer // Tick durations for the admin dashboard (see tick_stats.go)
+
+	// Load shedding under overload (see load_shedding.go)
+	shedder   loadShedder
+	shedLevel int // Level for the current tick
 
 	// Event callbacks
 	onDamage   func(attacker, victim *Player, damage int)
@@ -60,6 +81,15 @@ type Engine struct {
 	onRespawn  func(player *Player)
 	OnSnapshot func(snapshot *GameSnapshot) // Called after each snapshot is produced (for IPC)
 
+	// Called after SetArenaPreset resized the arena (the streamer follows the size)
+	OnArenaChange func(preset ArenaPreset)
+
+	// Called on big moments worth a clip (see highlight.go)
+	OnHighlight func(h Highlight)
+
+	// Called when a queued viewer gets into the arena (see join_queue.go)
+	OnJoinQueueAdmit func(name string)
+
 	// World bounds
 	worldWidth  float64
 	worldHeight float64
@@ -80,18 +110,145 @@ type Engine struct {
 	// Team management
 	teamManager *TeamManager
 
+	// Persistent viewer wallets (passive income, survives death)
+	wallets *WalletManager
+
+	// Persistent daily/weekly/all-time kill leaderboards
+	leaderboards *LeaderboardStore
+
+	// Per-round summaries for /api/history and the stream recap (see history.go)
+	history *MatchHistory
+
+	// Seasonal ELO ratings and ranks (see rating.go)
+	ratings *RatingStore
+
+	// Lifetime K/D, best streak and last life per viewer (see career.go)
+	careers *CareerStore
+
+	// Owned and equipped cosmetic skins (see skin.go)
+	skins *SkinStore
+
+	// Daily viewer quests and completion toasts (see quest.go)
+	quests      *QuestStore
+	questTimer  float64 // Seconds since survival progress was last recorded
+	questToasts []questToast
+
+	// Combat heatmap and fight/weapon stats (see analytics.go)
+	analytics *CombatAnalytics
+
+	// Per-weapon pick/kill/TTK/combo telemetry (see balance.go)
+	balance *BalanceTelemetry
+
+	// Late !heal grace window (see lag_compensation.go)
+	lagComp      LagCompensationConfig
+	recentDeaths map[string]recentDeath
+
+	// Coins and weapons dropped by dead fighters (see loot.go, weapon_drop.go)
+	loot        LootConfig
+	lootPiles   []*lootPile
+	weaponDrops []*weaponDrop
+
+	// Money transfers between viewers (see gift.go)
+	gift      GiftConfig
+	giftReady map[string]int64 // Sender name -> tick their next gift is allowed
+
+	// Team leader !team rally / regroup cooldowns (see team_orders.go)
+	teamOrders map[string]*teamOrderState
+
+	// Crowd-voted arena modifiers (see vote.go / modifier.go)
+	votes    *VoteManager
+	modifier string // Active modifier ID, refreshed each tick
+
+	// Chat-initiated 1v1 duels (see duel.go)
+	duels *DuelManager
+
+	// Scheduled chaos events: meteors, shrinking zone, gravity flip (see chaos.go)
+	chaos *ChaosManager
+
+	// Time of day and weather of the arena background (see scene.go)
+	scene *SceneManager
+
+	// Viewer-paid airstrikes (see airstrike.go)
+	airstrikeCfg   AirstrikeConfig
+	airstrikes     []airstrike
+	airstrikeTicks []int64          // Ticks of recent airstrikes (price scaling), oldest first
+	airstrikeReady map[string]int64 // Caller name -> tick their next airstrike is allowed
+
+	// Weapon shops where !buy orders are picked up (see shop.go)
+	shops *ShopManager
+
+	// Heavy knockback near the edge throws fighters into the pit (see ring_out.go)
+	ringOutCfg RingOutConfig
+
+	// Slow-motion replays of the last seconds (see replay.go)
+	replayCfg    ReplayConfig
+	replayCue    ReplayCue
+	replayEnd    time.Time // When the replay on stream ends
+	replayFrames *ReplayBuffer
+
+	// Team bases and a central capture point (see objective.go)
+	objective *ObjectiveManager
+
 	// Arena bot system - always keeps at least one bot in the arena
 	arenaBotEnabled     bool
 	arenaBotRespawnTime float64 // Time until arena bot respawns (seconds)
 	arenaBotName        string
+
+	// Bot fill - AI bots keep a quiet arena populated (see botfill.go)
+	botFill          BotFillConfig
+	botSpawnTimer    float64            // Seconds until the next bot may join
+	botRespawnTimers map[string]float64 // Bot name -> seconds spent dead
+
+	// Viewers waiting for a slot when the arena is full, first in line first (see join_queue.go)
+	joinQueueCfg JoinQueueConfig
+	joinQueue    []queuedJoin
+
+	// Viewers kicked by a moderator -> tick they may !join again (see moderation.go)
+	modRejoinTicks map[string]int64
+
+	// Stream goes live with viewers and ends when the arena empties (see autostream.go)
+	autoStream *AutoStreamController
+
+	// Sound effects pack the streamer plays, switched from the admin panel (see soundpack.go)
+	soundPacks *SoundPackSelector
+
+	// Broadcaster rules from the hot-reloaded cue file (see script.go)
+	scripts     *ScriptRunner
+	scriptState map[string]*scriptState // Rule name -> what it already fired for
 }
 
 // EngineConfig holds configuration for the game engine
 type EngineConfig struct {
-	TickRate    int
+	TickRate    int // Simulation ticks per second (independent of the stream FPS)
 	WorldWidth  int
 	WorldHeight int
 	Limits      ResourceLimits
+	Economy     EconomyConfig
+	Voting      VotingConfig
+	Duel        DuelConfig
+	Chaos       ChaosConfig
+	Scene       SceneConfig
+	Airstrike   AirstrikeConfig
+	Shop        ShopConfig
+	RingOut     RingOutConfig
+	Replay      ReplayConfig
+	Objective   ObjectiveConfig
+	Leaderboard LeaderboardConfig
+	History     HistoryConfig
+	Rating      RatingConfig
+	Career      CareerConfig
+	Quest       QuestConfig
+	Skins       SkinConfig
+	LagComp     LagCompensationConfig
+	Loot        LootConfig
+	Gift        GiftConfig
+	Analytics   AnalyticsConfig
+	BotFill     BotFillConfig
+	JoinQueue   JoinQueueConfig
+
+	MaxCatchUpTicks int // Missed ticks replayed after a stall (see timestep.go)
+
+	AutoStream AutoStreamConfig // Start/stop the stream with arena activity
 }
 
 // NewEngine creates a new game engine with the provided configuration.
@@ -107,9 +264,15 @@ func NewEngine(cfg EngineConfig) *Engine {
 	if cfg.TickRate == 0 {
 		cfg.TickRate = 30
 	}
+	if cfg.MaxCatchUpTicks == 0 {
+		cfg.MaxCatchUpTicks = 5
+	}
 	if cfg.Limits.MaxPlayers == 0 {
 		cfg.Limits = DefaultLimits
 	}
+	if cfg.Gift.Max == 0 {
+		cfg.Gift = DefaultGift
+	}
 
 	limits := cfg.Limits
 	seed := time.Now().UnixNano()
@@ -127,11 +290,14 @@ func NewEngine(cfg EngineConfig) *Engine {
 		flashes:          make([]*ImpactFlash, 0, limits.MaxFlashes),
 		projectiles:      make([]*Projectile, 0, MaxProjectiles),
 		spatialGrid:      grid,
+		broadphase:       newBroadphase(float64(cfg.WorldWidth), float64(cfg.WorldHeight), limits.MaxPlayers),
 		playerSlice:      make([]*Player, 0, limits.MaxPlayers),
 		sap:              spatial.NewSweepAndPrune(limits.MaxPlayers),
 		flowFieldManager: spatial.NewFlowFieldManager(float64(cfg.WorldWidth), float64(cfg.WorldHeight), 50), // 50px cells for smoother nav
 		comboDefinitions: DefaultComboDefinitions(),
 		tickRate:         cfg.TickRate,
+		tickRateChanged:  make(chan struct{}, 1),
+		maxCatchUpTicks:  cfg.MaxCatchUpTicks,
 		stopChan:         make(chan struct{}),
 		worldWidth:       float64(cfg.WorldWidth),
 		worldHeight:      float64(cfg.WorldHeight),
@@ -141,8 +307,42 @@ func NewEngine(cfg EngineConfig) *Engine {
 		rng:              rand.New(rand.NewSource(seed)),
 		rngSeed:          seed,
 		teamManager:      NewTeamManager(),
+		wallets:          NewWalletManager(cfg.Economy),
+		leaderboards:     NewLeaderboardStore(cfg.Leaderboard),
+		history:          NewMatchHistory(cfg.History),
+		ratings:          NewRatingStore(cfg.Rating),
+		careers:          NewCareerStore(cfg.Career),
+		quests:           NewQuestStore(cfg.Quest),
+		skins:            NewSkinStore(cfg.Skins),
+		lagComp:          cfg.LagComp,
+		recentDeaths:     make(map[string]recentDeath),
+		loot:             cfg.Loot,
+		lootPiles:        make([]*lootPile, 0, MaxLootPiles),
+		weaponDrops:      make([]*weaponDrop, 0, MaxWeaponDrops),
+		gift:             cfg.Gift,
+		giftReady:        make(map[string]int64),
+		teamOrders:       make(map[string]*teamOrderState),
+		analytics:        NewCombatAnalytics(cfg.Analytics, float64(cfg.WorldWidth), float64(cfg.WorldHeight), cfg.TickRate),
+		balance:          NewBalanceTelemetry(cfg.Analytics.Dir, cfg.TickRate),
+		votes:            NewVoteManager(cfg.Voting),
+		duels:            NewDuelManager(cfg.Duel, float64(cfg.WorldWidth)/2, float64(cfg.WorldHeight)/2),
+		chaos:            NewChaosManager(cfg.Chaos, float64(cfg.WorldWidth), float64(cfg.WorldHeight)),
+		scene:            NewSceneManager(cfg.Scene),
+		airstrikeCfg:     cfg.Airstrike,
+		airstrikeReady:   make(map[string]int64),
+		shops:            NewShopManager(cfg.Shop, float64(cfg.WorldWidth), float64(cfg.WorldHeight)),
+		ringOutCfg:       cfg.RingOut,
+		replayCfg:        cfg.Replay,
+		replayFrames:     NewReplayBuffer(),
+		objective:        NewObjectiveManager(cfg.Objective, float64(cfg.WorldWidth), float64(cfg.WorldHeight)),
 		arenaBotEnabled:  true,
 		arenaBotName:     "Arena-Bot",
+		botFill:          cfg.BotFill,
+		botRespawnTimers: make(map[string]float64),
+		joinQueueCfg:     cfg.JoinQueue,
+		modRejoinTicks:   make(map[string]int64),
+		autoStream:       NewAutoStreamController(cfg.AutoStream),
+		soundPacks:       NewSoundPackSelector(DefaultSoundPack),
 	}
 }
 
@@ -153,6 +353,30 @@ func DefaultEngineConfig() EngineConfig {
 		WorldWidth:  1280,
 		WorldHeight: 720,
 		Limits:      DefaultLimits,
+		Economy:     DefaultEconomy,
+		Voting:      DefaultVoting,
+		Duel:        DefaultDuel,
+		Chaos:       DefaultChaos,
+		Scene:       DefaultScene,
+		Airstrike:   DefaultAirstrike,
+		Shop:        DefaultShop,
+		RingOut:     DefaultRingOut,
+		Replay:      DefaultReplay,
+		Objective:   DefaultObjective,
+		Leaderboard: DefaultLeaderboard,
+		History:     DefaultHistory,
+		Rating:      DefaultRating,
+		Career:      DefaultCareer,
+		Quest:       DefaultQuest,
+		Skins:       DefaultSkin,
+		LagComp:     DefaultLagCompensation,
+		Loot:        DefaultLoot,
+		Gift:        DefaultGift,
+		Analytics:   DefaultAnalytics,
+		BotFill:     DefaultBotFill,
+		JoinQueue:   DefaultJoinQueue,
+
+		MaxCatchUpTicks: 5,
 	}
 }
 
@@ -167,17 +391,8 @@ func (e *Engine) Start() {
 	e.mu.Unlock()
 
 	e.ticker = time.NewTicker(time.Second / time.Duration(e.tickRate))
-
-	go func() {
-		for {
-			select {
-			case <-e.ticker.C:
-				e.tick()
-			case <-e.stopChan:
-				return
-			}
-		}
-	}()
+	profiling.SetBudget("tick", 1000/float64(e.tickRate))
+	go e.run()
 
 	log.Printf("🎮 Game engine started at %d TPS", e.tickRate)
 }
@@ -199,13 +414,29 @@ func (e *Engine) Stop() {
 	log.Println("🛑 Game engine stopped")
 }
 
-// tick is called at tickRate times per second
+// tick advances the simulation by one fixed step (1/tickRate seconds)
 func (e *Engine) tick() {
 	e.mu.Lock()
 	defer e.mu.Unlock()
 
+	start := time.Now()
+	defer func() {
+		d := time.Since(start)
+		e.tickTimes.record(d, e.tickRate)
+		if !e.paused {
+			e.observeLoad(d)
+		}
+	}()
+
+	// Paused: keep publishing the frozen state so the stream stays alive
+	if e.paused {
+		e.ProduceSnapshot()
+		return
+	}
+
 	e.tickCount++
 	deltaTime := 1.0 / float64(e.tickRate)
+	e.shedLevel = e.shedder.Level()
 
 	// Log tick event with RNG seed for deterministic replay
 	e.eventLog.EmitSimple(EventTypeTick, uint64(e.tickCount), "",
@@ -213,26 +444,28 @@ func (e *Engine) tick() {
 			RNGSeed:     e.rngSeed,
 			PlayerCount: len(e.players),
 			DeltaTimeNs: int64(deltaTime * 1e9),
+			ShedLevel:   e.shedLevel,
 		})
 
 	// Advance RNG seed deterministically for next tick
 	e.rngSeed = e.rng.Int63()
 	e.rng.Seed(e.rngSeed)
 
-	// Build player list and spatial grid for O(1) neighbor queries
-	// Reuse playerSlice to avoid allocation
-	e.playerSlice = e.playerSlice[:0]
-	for _, p := range e.players {
-		e.playerSlice = append(e.playerSlice, p)
+	// Phase timings for the debug server budget (see tick_stats.go)
+	phaseStart := time.Now()
+
+	// Build player list and spatial grid for O(1) neighbor queries, or take
+	// both from the broadphase worker if the roster didn't change since
+	if !e.swapBroadphase() {
+		e.indexPlayers()
 	}
+	phaseStart = tickPhaseSpatial.Since(phaseStart)
 	playerList := e.playerSlice
 
-	// Rebuild spatial grid (O(n) - much faster than O(n²) scans)
-	e.spatialGrid.Clear()
-	for i, p := range playerList {
-		if !p.IsDead && !p.IsRagdoll {
-			e.spatialGrid.Insert(uint32(i), p.X, p.Y)
-		}
+	// Advance the arena vote and apply the winning modifier to everyone
+	e.modifier = e.votes.Update(deltaTime)
+	for _, p := range playerList {
+		p.modifier = e.modifier
 	}
 
 	for i, player := range playerList {
@@ -250,12 +483,54 @@ func (e *Engine) tick() {
 		player.Update(playerList, uint32(i), e.spatialGrid, deltaTime, e, e.players)
 	}
 
+	// Follow moved chase targets (shed first under load)
+	e.refreshFlowFields()
+	phaseStart = tickPhaseAI.Since(phaseStart)
+
 	// Resolve collisions using spatial grid
 	for i, player := range playerList {
 		if !player.IsDead && !player.IsRagdoll {
 			player.ResolveCollisions(playerList, uint32(i), e.spatialGrid)
 		}
 	}
+	phaseStart = tickPhaseCollision.Since(phaseStart)
+
+	// Movement is done: build next tick's grid while the rest of the tick runs
+	e.prebuildBroadphase()
+
+	// Settle the duel and keep its ring separated
+	e.updateDuel(deltaTime)
+
+	// Scheduled chaos events (meteors, shrinking zone, gravity flip)
+	e.updateChaos(deltaTime)
+	e.updateAirstrikes(deltaTime)
+
+	// Time of day and weather of the arena background
+	e.updateScene(deltaTime)
+
+	// Team objective: capture point, scores and base healing
+	e.updateObjective(deltaTime)
+
+	// Coin and weapon pickups and despawns
+	e.updateLoot(deltaTime)
+	e.updateWeaponDrops(deltaTime)
+
+	// Weapon shop orders picked up or expired
+	e.updateShops(deltaTime)
+
+	// Daily quest survival progress and completion toasts
+	e.updateQuests(deltaTime)
+
+	// Broadcaster script rules (bosses, sudden death, announcements)
+	e.updateScripts()
+
+	// Weapon held time and switches for the balance report
+	e.balance.Sample(playerList, e.tickCount)
+
+	// Round clock for the match history
+	e.history.Advance(deltaTime, len(playerList))
+
+	phaseStart = tickPhaseSystems.Since(phaseStart)
 
 	// Update particles
 	e.updateParticles()
@@ -276,25 +551,42 @@ func (e *Engine) tick() {
 	// Update arena bot (respawn if dead)
 	e.updateArenaBot(deltaTime)
 
+	// Let queued viewers into freed slots
+	e.updateJoinQueue(deltaTime)
+
+	// Fill a quiet arena with bots (they leave as viewers join)
+	e.updateBotFill(deltaTime)
+
+	// Go live with viewers, end the stream once the arena stays empty
+	e.updateAutoStream(deltaTime)
+	phaseStart = tickPhaseEffects.Since(phaseStart)
+
 	// Produce immutable snapshot for lock-free render access
 	e.ProduceSnapshot()
+	tickPhaseSnapshot.Since(phaseStart)
 }
 
 // AddPlayer adds a new player to the game
 func (e *Engine) AddPlayer(name string, opts PlayerOptions) *Player {
 	e.mu.Lock()
 	defer e.mu.Unlock()
+	return e.addPlayerLocked(name, opts)
+}
 
-	// HARD CAP: Prevent DoS via player flooding
-	if len(e.players) >= e.limits.MaxTotalPlayers {
-		log.Printf("⚠️ Player limit reached (%d), rejecting: %s", e.limits.MaxTotalPlayers, name)
+// addPlayerLocked adds or respawns a player, nil if rejected (caller holds e.mu)
+func (e *Engine) addPlayerLocked(name string, opts PlayerOptions) *Player {
+	// Kicked by a moderator: sit out the rejoin delay
+	if e.rejoinBlocked(name) {
+		log.Printf("🛡️ %s was kicked by a moderator, rejecting join", name)
 		return nil
 	}
 
-	// Check if player already exists
+	// Check if player already exists (their slot is taken, so the cap doesn't apply)
 	if existing, ok := e.players[name]; ok {
+		e.wallets.Register(name)
 		if existing.IsDead {
 			existing.Respawn()
+			e.spawnAtBase(existing)
 			// Log respawn event
 			e.eventLog.EmitSimple(EventTypeRespawn, uint64(e.tickCount), existing.ID,
 				RespawnPayload{PlayerID: existing.ID, SpawnX: existing.X, SpawnY: existing.Y})
@@ -305,6 +597,15 @@ func (e *Engine) AddPlayer(name string, opts PlayerOptions) *Player {
 		return existing
 	}
 
+	// HARD CAP: Prevent DoS via player flooding (bots give up their slot to viewers)
+	if len(e.players) >= e.limits.MaxTotalPlayers && !e.evictBotLocked() {
+		log.Printf("⚠️ Player limit reached (%d), rejecting: %s", e.limits.MaxTotalPlayers, name)
+		return nil
+	}
+
+	// Viewers who have joined at least once earn passive income
+	e.wallets.Register(name)
+
 	// Create new player with deterministic spawn position
 	// Pass world bounds so player can use them for movement/respawn
 	opts.WorldWidth = e.worldWidth
@@ -312,8 +613,12 @@ func (e *Engine) AddPlayer(name string, opts PlayerOptions) *Player {
 	player := NewPlayer(name, opts)
 	player.X = e.rng.Float64()*e.worldWidth*0.8 + e.worldWidth*0.1
 	player.Y = e.rng.Float64()*e.worldHeight*0.8 + e.worldHeight*0.1
+	_, rank := e.ratings.Rating(name)
+	player.Rank = rank.ID
+	player.Skins = e.skins.Equipped(name)
 
 	e.players[name] = player
+	e.rosterVersion++
 
 	// Log join event for audit trail
 	e.eventLog.EmitSimple(EventTypePlayerJoin, uint64(e.tickCount), player.ID,
@@ -333,11 +638,15 @@ func (e *Engine) AddPlayer(name string, opts PlayerOptions) *Player {
 	return player
 }
 
-// RemovePlayer removes a player from the game
+// RemovePlayer removes a player from the game (and the join queue)
 func (e *Engine) RemovePlayer(name string) {
 	e.mu.Lock()
 	defer e.mu.Unlock()
 	delete(e.players, name)
+	e.rosterVersion++
+	if pos := e.joinQueuePositionLocked(name); pos > 0 {
+		e.joinQueue = slices.Delete(e.joinQueue, pos-1, pos)
+	}
 }
 
 // GetPlayer returns a player by name
@@ -366,9 +675,11 @@ func (e *Engine) ProcessAttack(attacker, victim *Player, damage int) {
 	// Get animation config for weapon-specific behavior
 	anim := GetWeaponAnimation(attacker.Weapon)
 
-	// PROJECTILE WEAPONS: Spawn projectile instead of instant damage
+	// PROJECTILE WEAPONS: Spawn projectile instead of instant damage,
+	// led to where a moving victim will be when it arrives
 	if anim.IsProjectile {
-		e.CreateProjectile(attacker, victim.X, victim.Y, damage)
+		aimX, aimY := attacker.aimAt(victim, e.rng)
+		e.CreateProjectile(attacker, aimX, aimY, damage)
 		return // Damage will be applied when projectile hits
 	}
 
@@ -382,6 +693,11 @@ func (e *Engine) ProcessAttack(attacker, victim *Player, damage int) {
 		return
 	}
 
+	// No interference with (or from) a duel
+	if duelSeparated(attacker, victim) {
+		return
+	}
+
 	// Validate shaped hitbox collision
 	hitbox := GetHitbox(attacker.Weapon)
 	if !hitbox.CheckHit(attacker.X, attacker.Y, victim.X, victim.Y, attacker.AttackAngle) {
@@ -394,13 +710,18 @@ func (e *Engine) ProcessAttack(attacker, victim *Player, damage int) {
 		combo = ComboDefinition{MaxHits: 1, DamageScale: []float64{1.0}}
 	}
 	comboMultiplier := attacker.Combat.RegisterHit(uint64(e.tickCount), combo)
-	damage = int(float64(damage) * comboMultiplier)
+	damage = int(float64(damage)*comboMultiplier) * e.damageMultiplier()
+	damage = victim.exhaustedDamage(attacker.rallyDamage(damage))
 
 	// Log the attack for debugging
 	log.Printf("⚔️ %s attacks %s for %d damage (HP: %d -> %d) [combo x%.1f]",
 		attacker.Name, victim.Name, damage, victim.HP, victim.HP-damage, comboMultiplier)
 
+	hpBefore := victim.HP
 	victim.TakeDamage(damage, attacker)
+	e.ringOut(attacker, victim, hpBefore)
+	e.analytics.RecordDamage(victim.ID, attacker.Weapon, victim.X, victim.Y, damage, e.tickCount)
+	e.balance.RecordHit(attacker.Weapon, victim.ID, attacker.Combat.ComboCount, e.tickCount)
 
 	// Log damage event for audit trail
 	e.eventLog.EmitSimple(EventTypeDamage, uint64(e.tickCount), attacker.ID,
@@ -416,14 +737,12 @@ func (e *Engine) ProcessAttack(attacker, victim *Player, damage int) {
 		go e.onDamage(attacker, victim, damage)
 	}
 
-	// Create particles (use weapon-specific count from animation config)
+	// Create particles (use weapon-specific count and look from animation config)
 	particleCount := anim.ParticleCount
 	if particleCount < 2 {
 		particleCount = 2 // Minimum particles
 	}
-	for i := 0; i < particleCount; i++ {
-		e.createParticle(victim.X, victim.Y, "#ff0000")
-	}
+	e.emitParticles(anim.ParticleStyle, victim.X, victim.Y, "#ff0000", particleCount)
 
 	// Create arc swing effect (now created here only when hit connects)
 	weapon := GetWeapon(attacker.Weapon)
@@ -461,6 +780,8 @@ func (e *Engine) ProcessAttack(attacker, victim *Player, damage int) {
 	}
 
 	if victim.IsDead {
+		e.recordDeath(victim, hpBefore, attacker)
+		e.dropLoot(victim)
 		e.totalKills++
 		attacker.Kills++
 		attacker.Money += 50
@@ -469,6 +790,17 @@ func (e *Engine) ProcessAttack(attacker, victim *Player, damage int) {
 		if attacker.TeamID != "" {
 			e.teamManager.AddKill(attacker.TeamID)
 		}
+		e.recordLeaderboardKill(attacker)
+		e.recordRatingKill(attacker, victim)
+		e.recordCareerDeath(victim, attacker)
+		e.recordHighlight(attacker, victim)
+		if attacker.Weapon == "spear" {
+			e.recordQuest(attacker, QuestSpearKills, 1)
+		}
+		e.analytics.RecordKill(victim.ID, attacker.Weapon, victim.Weapon, victim.X, victim.Y, e.tickCount)
+		e.balance.RecordKill(attacker.Weapon, victim.ID, e.tickCount)
+		e.dropWeapon(victim)
+		e.history.RecordKill(attacker)
 
 		log.Printf("💀 %s killed by %s! (Kills: %d)", victim.Name, attacker.Name, attacker.Kills)
 
@@ -479,61 +811,21 @@ func (e *Engine) ProcessAttack(attacker, victim *Player, damage int) {
 				VictimID:     victim.ID,
 				KillerKills:  attacker.Kills,
 				VictimDeaths: victim.Deaths,
+				RingOut:      victim.RingOut,
 			})
 
 		if e.OnKill != nil {
 			go e.OnKill(attacker, victim)
 		}
 
-		// Death particles (already capped in createParticle)
-		for i := 0; i < 20; i++ {
-			e.createParticle(victim.X, victim.Y, victim.Color)
-		}
+		// Death particles (already capped in emitParticles)
+		e.emitParticles("death", victim.X, victim.Y, victim.Color, 0)
 
 		// Extra shake for kills
 		e.AddShake(6.0)
 	}
 }
 
-func (e *Engine) createParticle(x, y float64, color string) {
-	// HARD CAP: Prevent DoS via particle flooding
-	if len(e.particles) >= e.limits.MaxParticles {
-		return // Silently drop - this is intentional under attack
-	}
-
-	// Use deterministic RNG for replay consistency
-	angle := e.rng.Float64() * math.Pi * 2
-	speed := e.rng.Float64()*3 + 1
-
-	e.particles = append(e.particles, &Particle{
-		X:     x,
-		Y:     y,
-		VX:    math.Cos(angle) * speed,
-		VY:    math.Sin(angle) * speed,
-		Color: color,
-		Alpha: 1.0,
-		Life:  1.0,
-	})
-}
-
-func (e *Engine) updateParticles() {
-	// REAL-TIME FIX: Zero-allocation in-place filtering
-	// Avoids creating new slice each tick, reduces GC pressure
-	n := 0
-	for _, p := range e.particles {
-		p.X += p.VX
-		p.Y += p.VY
-		p.Life -= 0.02
-		p.Alpha = p.Life
-
-		if p.Life > 0 {
-			e.particles[n] = p
-			n++
-		}
-	}
-	e.particles = e.particles[:n]
-}
-
 func (e *Engine) updateFloatingTexts() {
 	// REAL-TIME FIX: Zero-allocation in-place filtering
 	n := 0
@@ -615,12 +907,14 @@ func (e *Engine) updateProjectiles() {
 			if proj.CheckHit(target) {
 				// Apply damage and effects
 				e.processProjectileHit(proj, target)
-				hit = true
-				break
+				if !proj.RegisterHit(target) {
+					hit = true
+					break
+				}
 			}
 		}
 
-		// Remove if hit something or expired/out of bounds
+		// Remove if hit something (and didn't pierce) or expired/out of bounds
 		if hit || !proj.Update(deltaTime) {
 			continue // Don't keep this projectile
 		}
@@ -647,16 +941,21 @@ func (e *Engine) processProjectileHit(proj *Projectile, victim *Player) {
 	// Get animation config for weapon-specific effects
 	anim := GetWeaponAnimation(attacker.Weapon)
 
-	// Apply damage
-	victim.TakeDamage(proj.Damage, attacker)
+	// Apply damage (arena modifier applies on hit, not on fire)
+	damage := victim.exhaustedDamage(attacker.rallyDamage(proj.Damage * e.damageMultiplier()))
+	hpBefore := victim.HP
+	victim.TakeDamage(damage, attacker)
+	e.ringOut(attacker, victim, hpBefore)
+	e.analytics.RecordDamage(victim.ID, attacker.Weapon, victim.X, victim.Y, damage, e.tickCount)
+	e.balance.RecordHit(attacker.Weapon, victim.ID, 1, e.tickCount)
 
 	// Create impact effects
 	e.CreateFlash(victim.X, victim.Y, proj.Color, 1.5)
 	e.AddShake(anim.ShakeIntensity)
 
-	// Create particles
-	for i := 0; i < anim.ParticleCount; i++ {
-		e.createParticle(victim.X, victim.Y, proj.Color)
+	// Create particles along the last few ticks of the flight path
+	if anim.ParticleCount > 0 {
+		e.emitParticleTrail(anim.ParticleStyle, proj.X-proj.VX*3, proj.Y-proj.VY*3, victim.X, victim.Y, proj.Color, anim.ParticleCount)
 	}
 
 	// Create damage text
@@ -664,7 +963,7 @@ func (e *Engine) processProjectileHit(proj *Projectile, victim *Player) {
 		e.texts = append(e.texts, &FloatingText{
 			X:     victim.X,
 			Y:     victim.Y - 30,
-			Text:  fmt.Sprintf("-%d", proj.Damage),
+			Text:  fmt.Sprintf("-%d", damage),
 			Color: "#ff3e3e",
 			Alpha: 1.0,
 			VY:    -2,
@@ -676,17 +975,19 @@ func (e *Engine) processProjectileHit(proj *Projectile, victim *Player) {
 		DamagePayload{
 			AttackerID: attacker.ID,
 			VictimID:   victim.ID,
-			Damage:     proj.Damage,
+			Damage:     damage,
 			VictimHP:   victim.HP,
 			WeaponID:   attacker.Weapon,
 		})
 
 	if e.onDamage != nil {
-		go e.onDamage(attacker, victim, proj.Damage)
+		go e.onDamage(attacker, victim, damage)
 	}
 
 	// Handle kill
 	if victim.IsDead {
+		e.recordDeath(victim, hpBefore, attacker)
+		e.dropLoot(victim)
 		e.totalKills++
 		attacker.Kills++
 		attacker.Money += 50
@@ -694,6 +995,17 @@ func (e *Engine) processProjectileHit(proj *Projectile, victim *Player) {
 		if attacker.TeamID != "" {
 			e.teamManager.AddKill(attacker.TeamID)
 		}
+		e.recordLeaderboardKill(attacker)
+		e.recordRatingKill(attacker, victim)
+		e.recordCareerDeath(victim, attacker)
+		e.recordHighlight(attacker, victim)
+		if attacker.Weapon == "spear" {
+			e.recordQuest(attacker, QuestSpearKills, 1)
+		}
+		e.analytics.RecordKill(victim.ID, attacker.Weapon, victim.Weapon, victim.X, victim.Y, e.tickCount)
+		e.balance.RecordKill(attacker.Weapon, victim.ID, e.tickCount)
+		e.dropWeapon(victim)
+		e.history.RecordKill(attacker)
 
 		log.Printf("🏹💀 %s killed by %s's arrow! (Kills: %d)", victim.Name, attacker.Name, attacker.Kills)
 
@@ -703,6 +1015,7 @@ func (e *Engine) processProjectileHit(proj *Projectile, victim *Player) {
 				VictimID:     victim.ID,
 				KillerKills:  attacker.Kills,
 				VictimDeaths: victim.Deaths,
+				RingOut:      victim.RingOut,
 			})
 
 		if e.OnKill != nil {
@@ -710,9 +1023,7 @@ func (e *Engine) processProjectileHit(proj *Projectile, victim *Player) {
 		}
 
 		// Death particles
-		for i := 0; i < 20; i++ {
-			e.createParticle(victim.X, victim.Y, victim.Color)
-		}
+		e.emitParticles("death", victim.X, victim.Y, victim.Color, 0)
 
 		e.AddShake(6.0)
 	}
@@ -725,6 +1036,7 @@ func (e *Engine) CreateProjectile(owner *Player, targetX, targetY float64, damag
 	}
 
 	proj := NewProjectile(owner, targetX, targetY, damage, e.tickCount)
+	proj.BoundsW, proj.BoundsH = e.worldWidth, e.worldHeight
 	e.projectiles = append(e.projectiles, proj)
 
 	log.Printf("🏹 %s fires arrow toward (%.0f, %.0f)", owner.Name, targetX, targetY)
@@ -817,13 +1129,19 @@ type GameState struct {
 	TotalKills  int
 }
 
-// Particle represents a visual particle
+// Particle represents a visual particle (spawned by emitters, see particles.go)
 type Particle struct {
 	X, Y   float64
 	VX, VY float64
 	Color  string
 	Alpha  float64
-	Life   float64
+	Life   float64 // 1 at spawn, removed at 0
+	Size   float64 // Radius in pixels (0 = 2)
+
+	// Physics copied from the ParticleStyle at spawn
+	decay              float64
+	gravity, drag      float64
+	startSize, endSize float64
 }
 
 // AttackEffect represents an attack visual effect
@@ -849,11 +1167,36 @@ func (e *Engine) GetSnapshot() *GameSnapshot {
 	return e.snapshotPool.AcquireRead()
 }
 
+// SetViewerCount updates the live viewer count shown next to the LIVE badge (0 hides it)
+func (e *Engine) SetViewerCount(count int) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if count < 0 {
+		count = 0
+	}
+	e.viewerCount = count
+}
+
+// indexPlayers rebuilds the player list and the spatial grid of living
+// fighters (O(n) - much faster than O(n²) scans). Reuses playerSlice to avoid
+// allocation. Caller holds e.mu.
+func (e *Engine) indexPlayers() {
+	e.playerSlice = e.playerSlice[:0]
+	e.spatialGrid.Clear()
+	for _, p := range e.players {
+		if !p.IsDead && !p.IsRagdoll {
+			e.spatialGrid.Insert(uint32(len(e.playerSlice)), p.X, p.Y)
+		}
+		e.playerSlice = append(e.playerSlice, p)
+	}
+}
+
 // ProduceSnapshot creates an immutable snapshot of the current game state
 // Called at the end of each tick
 func (e *Engine) ProduceSnapshot() {
 	snap := e.snapshotPool.AcquireWrite()
 	snap.TickNumber = uint64(e.tickCount)
+	snap.TickRate = e.tickRate
 	snap.RNGSeed = e.rngSeed
 	snap.TotalKills = e.totalKills
 
@@ -891,9 +1234,10 @@ func (e *Engine) ProduceSnapshot() {
 			}
 			continue
 		}
+		phase, phaseLeft := p.AttackPhase()
 		snap.Players = append(snap.Players, PlayerSnapshot{
 			ID:              p.ID,
-			Name:            p.Name,
+			Name:            p.DisplayName,
 			X:               p.X,
 			Y:               p.Y,
 			VX:              p.VX,
@@ -910,6 +1254,8 @@ func (e *Engine) ProduceSnapshot() {
 			IsDead:          p.IsDead,
 			IsRagdoll:       p.IsRagdoll,
 			RagdollRotation: p.RagdollRotation,
+			RingOut:         p.RingOut,
+			FallProgress:    p.fallProgress(),
 			SpawnProtection: p.SpawnProtection,
 			IsAttacking:     p.IsAttacking,
 			ProfilePic:      p.ProfilePic,
@@ -917,6 +1263,25 @@ func (e *Engine) ProduceSnapshot() {
 			DodgeDirection:  p.Combat.DodgeDirection,
 			ComboCount:      p.Combat.ComboCount,
 			Stamina:         p.Stamina,
+			IsSprinting:     p.IsSprinting,
+			IsExhausted:     p.IsExhausted,
+			Emote:           p.Emote,
+			EmoteProgress:   p.EmoteProgress(),
+			ChatBubble:      p.ChatBubble,
+			ChatBubbleTTL:   p.ChatBubbleTTL,
+			Personality:     p.Personality,
+			Rank:            p.Rank,
+			TeamColor:       e.teamManager.TeamColor(p.TeamID),
+			Skins:           p.Skins,
+			IsCheered:       p.CheerTimer > 0,
+			IsCursed:        p.CurseTimer > 0,
+			IsRallied:       p.RallyTimer > 0,
+			IsRegrouping:    p.RegroupTimer > 0,
+			RegroupX:        p.RegroupX,
+			RegroupY:        p.RegroupY,
+			AttackPhase:     phase,
+			AttackPhaseLeft: phaseLeft,
+			AttackCooldown:  max(p.AttackCooldown, 0),
 		})
 		if !p.IsDead {
 			aliveCount++
@@ -935,6 +1300,7 @@ func (e *Engine) ProduceSnapshot() {
 			Y:     p.Y,
 			Color: p.Color,
 			Alpha: p.Alpha,
+			Size:  p.Size,
 		})
 	}
 
@@ -1015,6 +1381,11 @@ func (e *Engine) ProduceSnapshot() {
 		snap.Projectiles = append(snap.Projectiles, proj.ToSnapshot())
 	}
 
+	// Copy coin piles and dropped weapons
+	e.lootSnapshot(snap)
+	e.weaponDropSnapshot(snap)
+	e.shopSnapshot(snap)
+
 	// Copy screen shake
 	if e.shake != nil && e.shake.Intensity > 0.5 {
 		snap.Shake = ShakeSnapshot{
@@ -1026,7 +1397,25 @@ func (e *Engine) ProduceSnapshot() {
 
 	snap.PlayerCount = len(snap.Players)
 	snap.AliveCount = aliveCount
-
+	snap.Framing = framePlayers(snap.Players)
+	snap.Vote = e.votes.Snapshot()
+	snap.Duel = e.duels.Snapshot()
+	snap.Chaos = e.chaos.Snapshot()
+	snap.Scene = e.scene.Snapshot()
+	e.airstrikeSnapshot(snap)
+	snap.Objective = e.objectiveSnapshot()
+	snap.QuestToast = e.questToastSnapshot()
+	snap.JoinQueue = e.joinQueueSnapshot()
+	snap.PitBand = e.pitBand()
+	snap.Replay = e.replaySnapshot()
+	snap.Leaderboard = e.leaderboards.Rotation(time.Now())
+	snap.Paused = e.paused
+	snap.ViewerCount = e.viewerCount
+	snap.AutoStream = e.autoStream.cfg.Enabled
+	snap.StreamLive = e.autoStream.live
+	snap.SoundPack = e.soundPacks.Current()
+
+	e.replayFrames.Record(snap)
 	e.snapshotPool.PublishWrite()
 
 	// Call OnSnapshot callback if set (used for IPC publishing)
@@ -1036,8 +1425,8 @@ func (e *Engine) ProduceSnapshot() {
 }
 
 // StartEventLog initializes the event logging system
-func (e *Engine) StartEventLog(filePath string) error {
-	return e.eventLog.Start(filePath)
+func (e *Engine) StartEventLog(cfg EventLogConfig) error {
+	return e.eventLog.Start(cfg)
 }
 
 // StopEventLog gracefully stops the event logging system
@@ -1065,6 +1454,78 @@ func (e *Engine) GetTeamManager() *TeamManager {
 	return e.teamManager
 }
 
+// GetWalletManager returns the persistent viewer wallet manager
+func (e *Engine) GetWalletManager() *WalletManager {
+	return e.wallets
+}
+
+// GetLeaderboardStore returns the persistent daily/weekly/all-time leaderboard store
+func (e *Engine) GetLeaderboardStore() *LeaderboardStore {
+	return e.leaderboards
+}
+
+// GetHistory returns the per-round match history
+func (e *Engine) GetHistory() *MatchHistory {
+	return e.history
+}
+
+// GetSoundPacks returns the stream's sound pack selection
+func (e *Engine) GetSoundPacks() *SoundPackSelector {
+	return e.soundPacks
+}
+
+// GetRatingStore returns the seasonal ELO rating store
+func (e *Engine) GetRatingStore() *RatingStore {
+	return e.ratings
+}
+
+// GetCareerStore returns the lifetime career stats store
+func (e *Engine) GetCareerStore() *CareerStore {
+	return e.careers
+}
+
+// GetAnalytics returns the combat analytics (heatmap and fight stats)
+func (e *Engine) GetAnalytics() *CombatAnalytics {
+	return e.analytics
+}
+
+// GetBalance returns the weapon balance telemetry
+func (e *Engine) GetBalance() *BalanceTelemetry {
+	return e.balance
+}
+
+// recordLeaderboardKill credits a kill to the persistent leaderboards (viewers only)
+func (e *Engine) recordLeaderboardKill(attacker *Player) {
+	if attacker.IsBot || attacker.Name == e.arenaBotName {
+		return
+	}
+	e.leaderboards.RecordKill(attacker.Name, time.Now())
+}
+
+// recordRatingKill updates ELO ratings for a kill and refreshes rank badges.
+// Bots are fixed-rating opponents; a bot killing a bot changes nothing.
+func (e *Engine) recordRatingKill(attacker, victim *Player) {
+	ratedName := func(p *Player) string {
+		if p.IsBot || p.Name == e.arenaBotName {
+			return ""
+		}
+		return p.Name
+	}
+	killer, killed := ratedName(attacker), ratedName(victim)
+	killerRank, victimRank := e.ratings.RecordKill(killer, killed, time.Now())
+	if killer != "" {
+		attacker.Rank = killerRank.ID
+	}
+	if killed != "" {
+		victim.Rank = victimRank.ID
+	}
+}
+
+// GetVoteManager returns the arena modifier vote manager
+func (e *Engine) GetVoteManager() *VoteManager {
+	return e.votes
+}
+
 // GetFlowFieldManager returns the flow field manager for AI navigation
 func (e *Engine) GetFlowFieldManager() *spatial.FlowFieldManager {
 	return e.flowFieldManager
@@ -1117,16 +1578,55 @@ func (e *Engine) SetChatBubble(playerName, message string) bool {
 		return false // Only show bubbles for alive, joined players
 	}
 
-	// Truncate to 50 chars
-	if len(message) > 50 {
-		message = message[:50]
-	}
-
-	player.ChatBubble = message
+	// Truncate to 50 characters (viewer text: strip what breaks rendering first)
+	player.ChatBubble = TruncateGraphemes(SanitizeText(message), 50)
 	player.ChatBubbleTTL = 5.0 // 5 seconds TTL
 	return true
 }
 
+// TriggerEmote starts an emote animation on a player, respecting the per-player cooldown
+func (e *Engine) TriggerEmote(playerName, emoteID string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	emote, ok := Emotes[emoteID]
+	if !ok {
+		return fmt.Errorf("unknown emote '%s'", emoteID)
+	}
+
+	player, ok := e.players[playerName]
+	if !ok || player.IsDead || player.State != StateAlive {
+		return fmt.Errorf("player not in arena")
+	}
+
+	if player.EmoteCooldown > 0 {
+		return fmt.Errorf("emote on cooldown (%.1fs)", player.EmoteCooldown)
+	}
+
+	player.Emote = emote.ID
+	player.EmoteTimer = emote.Duration
+	player.EmoteCooldown = EmoteCooldown
+	return nil
+}
+
+// SetPersonality changes a player's AI personality profile
+func (e *Engine) SetPersonality(playerName, personalityID string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if _, ok := Personalities[personalityID]; !ok {
+		return fmt.Errorf("unknown style '%s'", personalityID)
+	}
+
+	player, ok := e.players[playerName]
+	if !ok {
+		return fmt.Errorf("player not in arena")
+	}
+
+	player.SetPersonality(personalityID)
+	return nil
+}
+
 // SetPlayerTeam updates a player's team ID
 func (e *Engine) SetPlayerTeam(playerName, teamID string) {
 	e.mu.Lock()
@@ -1134,6 +1634,7 @@ func (e *Engine) SetPlayerTeam(playerName, teamID string) {
 
 	if player, ok := e.players[playerName]; ok {
 		player.TeamID = teamID
+		player.clearTeamOrders()
 	}
 }
 
@@ -1179,9 +1680,11 @@ func (e *Engine) spawnArenaBot() {
 	})
 	bot.X = e.rng.Float64()*e.worldWidth*0.8 + e.worldWidth*0.1
 	bot.Y = e.rng.Float64()*e.worldHeight*0.8 + e.worldHeight*0.1
+	bot.SetPersonality("berserker")
 	bot.Aggression = 1.0 // Maximum aggression
 
 	e.players[e.arenaBotName] = bot
+	e.rosterVersion++
 	log.Printf("🤖 Arena bot spawned: %s", e.arenaBotName)
 }
 
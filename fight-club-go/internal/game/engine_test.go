@@ -9,12 +9,30 @@ import (
 
 // helper to create a test engine with defaults
 func newTestEngine(tickRate int) *Engine {
-	return NewEngine(EngineConfig{
-		TickRate:    tickRate,
+	return newTestEngineWith(func(cfg *EngineConfig) { cfg.TickRate = tickRate })
+}
+
+// newTestEngineWith creates a 30 TPS test engine with defaults, adjusted by configure
+func newTestEngineWith(configure func(*EngineConfig)) *Engine {
+	cfg := EngineConfig{
+		TickRate:    30,
 		WorldWidth:  1280,
 		WorldHeight: 720,
 		Limits:      config.DefaultLimits(),
-	})
+	}
+	configure(&cfg)
+	return NewEngine(cfg)
+}
+
+// stepEngine advances the engine by seconds at its tick rate, running update
+// each tick after the tick count and player index are set up as in tick
+func stepEngine(e *Engine, seconds float64, update func(dt float64)) {
+	dt := 1.0 / float64(e.tickRate)
+	for t := 0.0; t < seconds; t += dt {
+		e.tickCount++
+		e.indexPlayers()
+		update(dt)
+	}
 }
 
 // TestNewEngine verifies engine creation with correct defaults
@@ -18,6 +18,16 @@ const (
 	EventTypeHeal
 	EventTypeRespawn
 	EventTypeAttack
+	EventTypeCheer     // Spectator speed buff
+	EventTypeCurse     // Spectator slow
+	EventTypePause     // Simulation frozen (broadcaster IRL break)
+	EventTypeResume    // Simulation continued
+	EventTypeChaos     // Chaos event warning/start/end and meteor impacts
+	EventTypeLoot      // Coins or a weapon dropped, picked up, reclaimed or despawned
+	EventTypeGift      // Money sent from one viewer to another
+	EventTypeMod       // Moderator !kick, !freeze or !strip
+	EventTypeTeamOrder // Team leader !team rally or regroup
+	EventTypeAirstrike // Viewer-paid !airstrike called or landed
 )
 
 // EventVersion for backwards compatibility in replay
@@ -53,6 +63,26 @@ func (t EventType) String() string {
 		return "respawn"
 	case EventTypeAttack:
 		return "attack"
+	case EventTypeCheer:
+		return "cheer"
+	case EventTypeCurse:
+		return "curse"
+	case EventTypePause:
+		return "pause"
+	case EventTypeResume:
+		return "resume"
+	case EventTypeChaos:
+		return "chaos"
+	case EventTypeLoot:
+		return "loot"
+	case EventTypeGift:
+		return "gift"
+	case EventTypeMod:
+		return "moderation"
+	case EventTypeTeamOrder:
+		return "team_order"
+	case EventTypeAirstrike:
+		return "airstrike"
 	default:
 		return "unknown"
 	}
@@ -65,6 +95,7 @@ type TickPayload struct {
 	RNGSeed     int64 `json:"rngSeed"`
 	PlayerCount int   `json:"playerCount"`
 	DeltaTimeNs int64 `json:"deltaTimeNs"`
+	ShedLevel   int   `json:"shedLevel,omitempty"` // Load shedding level (see load_shedding.go)
 }
 
 // DamagePayload contains damage event details
@@ -82,6 +113,7 @@ type KillPayload struct {
 	VictimID     string `json:"victimId"`
 	KillerKills  int    `json:"killerKills"`
 	VictimDeaths int    `json:"victimDeaths"`
+	RingOut      bool   `json:"ringOut,omitempty"` // Knocked into the pit
 }
 
 // PlayerJoinPayload contains player join details
@@ -95,9 +127,11 @@ type PlayerJoinPayload struct {
 
 // HealPayload contains heal event details
 type HealPayload struct {
-	PlayerID  string `json:"playerId"`
-	Amount    int    `json:"amount"`
-	CurrentHP int    `json:"currentHp"`
+	PlayerID    string `json:"playerId"`
+	Amount      int    `json:"amount"`
+	CurrentHP   int    `json:"currentHp"`
+	Retroactive bool   `json:"retroactive,omitempty"` // Lag compensation undid a death
+	LatencyMs   int64  `json:"latencyMs,omitempty"`   // Command sent this long before the killing blow
 }
 
 // RespawnPayload contains respawn event details
@@ -107,6 +141,81 @@ type RespawnPayload struct {
 	SpawnY   float64 `json:"spawnY"`
 }
 
+// PausePayload contains pause/resume details
+type PausePayload struct {
+	Paused bool `json:"paused"`
+}
+
+// ChaosPayload contains chaos event details (Phase: warning, start, end or impact)
+type ChaosPayload struct {
+	Event  string  `json:"event"`
+	Phase  string  `json:"phase"`
+	X      float64 `json:"x,omitempty"` // Meteor impact point
+	Y      float64 `json:"y,omitempty"`
+	Radius float64 `json:"radius,omitempty"`
+	Hits   int     `json:"hits,omitempty"` // Fighters damaged by the impact
+}
+
+// LootPayload contains coin pile or dropped weapon details (Phase: drop,
+// pickup, reclaim or despawn)
+type LootPayload struct {
+	Phase    string  `json:"phase"`
+	OwnerID  string  `json:"ownerId"`            // Fighter who dropped the coins
+	PlayerID string  `json:"playerId,omitempty"` // Fighter who picked them up (or reclaimed them)
+	Amount   int     `json:"amount"`
+	Weapon   string  `json:"weapon,omitempty"` // Weapon ID for weapon drops (Amount is 0)
+	X        float64 `json:"x"`
+	Y        float64 `json:"y"`
+	Age      float64 `json:"age"` // Seconds the coins were on the ground
+}
+
+// GiftPayload contains !gift transfer details
+type GiftPayload struct {
+	FromID string `json:"fromId"`
+	ToID   string `json:"toId"`
+	Amount int    `json:"amount"`
+}
+
+// ModerationPayload contains moderator action details (Duration: freeze or
+// rejoin delay in seconds)
+type ModerationPayload struct {
+	Moderator string  `json:"moderator"`
+	Action    string  `json:"action"`
+	TargetID  string  `json:"targetId"`
+	Target    string  `json:"target"`
+	Duration  float64 `json:"duration,omitempty"`
+}
+
+// TeamOrderPayload contains team leader order details (X, Y: leader position,
+// the regroup goal)
+type TeamOrderPayload struct {
+	TeamID   string  `json:"teamId"`
+	LeaderID string  `json:"leaderId"`
+	Order    string  `json:"order"`
+	Members  int     `json:"members"` // Fighters rallied or sent to regroup
+	X        float64 `json:"x"`
+	Y        float64 `json:"y"`
+}
+
+// AirstrikePayload contains !airstrike details (Phase: called or impact)
+type AirstrikePayload struct {
+	Caller string  `json:"caller"` // Viewer who paid (may not be fighting)
+	Phase  string  `json:"phase"`
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+	Radius float64 `json:"radius"`
+	Cost   int     `json:"cost,omitempty"` // Price paid (called)
+	Hits   int     `json:"hits,omitempty"` // Fighters damaged (impact)
+}
+
+// SpectatorPayload contains spectator cheer/curse details
+type SpectatorPayload struct {
+	SpectatorID string  `json:"spectatorId"`
+	TargetID    string  `json:"targetId"`
+	Effect      string  `json:"effect"`
+	Duration    float64 `json:"duration"`
+}
+
 // EncodePayload marshals a payload to JSON bytes
 func EncodePayload(payload interface{}) []byte {
 	data, err := json.Marshal(payload)
@@ -40,6 +40,14 @@ type EventLog struct {
 	filePath string
 	file     *os.File
 	fileMu   sync.Mutex
+	fileSize int64     // Bytes in the active file (rotation)
+	openedAt time.Time // When the active file was started (rotation)
+
+	// Rotation: segments are compressed in the background (see event_log_rotation.go)
+	cfg        EventLogConfig
+	compressCh chan string
+	compressWg sync.WaitGroup
+	manifestMu sync.Mutex
 
 	// Stats for DoS detection and monitoring
 	droppedCount uint64 // atomic
@@ -61,21 +69,41 @@ func NewEventLog() *EventLog {
 	return el
 }
 
-// Start begins the async writer goroutine
-func (el *EventLog) Start(filePath string) error {
+// Start begins the async writer goroutine, appending to cfg.Path and
+// rotating it according to the configured size/age limits
+func (el *EventLog) Start(cfg EventLogConfig) error {
 	if el.running.Load() {
 		return nil
 	}
 
-	el.filePath = filePath
+	el.cfg = cfg
+	el.filePath = cfg.Path
 
 	// Open file for append
-	if filePath != "" {
-		file, err := os.OpenFile(filePath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if el.filePath != "" {
+		file, err := os.OpenFile(el.filePath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
 		if err != nil {
 			return err
 		}
 		el.file = file
+		if info, err := file.Stat(); err == nil {
+			el.fileSize = info.Size()
+		}
+		el.openedAt = time.Now()
+	}
+
+	if el.rotationEnabled() {
+		el.compressCh = make(chan string, 16)
+		el.compressWg.Add(1)
+		go el.compressLoop()
+
+		// Segments a crash left uncompressed
+		for _, segment := range uncompressedSegments(el.filePath) {
+			select {
+			case el.compressCh <- segment:
+			default:
+			}
+		}
 	}
 
 	el.running.Store(true)
@@ -98,6 +126,12 @@ func (el *EventLog) Stop() {
 			el.file.Close()
 		}
 		el.fileMu.Unlock()
+
+		// Finish compressing rotated segments
+		if el.compressCh != nil {
+			close(el.compressCh)
+			el.compressWg.Wait()
+		}
 	})
 }
 
@@ -255,8 +289,13 @@ func (el *EventLog) flushBatch(batch []Event) {
 		if err != nil {
 			continue
 		}
-		el.file.Write(data)
-		el.file.Write([]byte("\n"))
+		data = append(data, '\n')
+		n, _ := el.file.Write(data)
+		el.fileSize += int64(n)
+	}
+
+	if el.shouldRotate() {
+		el.rotate()
 	}
 }
 
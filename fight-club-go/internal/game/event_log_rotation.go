@@ -0,0 +1,329 @@
+package game
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"fight-club/internal/config"
+)
+
+// EventLogConfig is an alias for config.EventLogConfig (SSOT)
+type EventLogConfig = config.EventLogConfig
+
+// EventManifestVersion is the schema version of the event log manifest
+const EventManifestVersion = 1
+
+// EventSegment describes a rotated, gzip-compressed event log segment.
+// Sequence numbers restart with every server run, so segments are ordered
+// by their position in the manifest, not by sequence.
+type EventSegment struct {
+	File          string    `json:"file"` // Relative to the manifest's directory
+	FirstSequence uint64    `json:"firstSequence"`
+	LastSequence  uint64    `json:"lastSequence"`
+	Start         time.Time `json:"start"` // First event
+	End           time.Time `json:"end"`   // Last event
+	Events        int       `json:"events"`
+	Bytes         int64     `json:"bytes"` // Compressed size
+}
+
+// EventLogManifest lists an event log's rotated segments (oldest first) and
+// the active file, so a replay can stitch the full history back together
+type EventLogManifest struct {
+	Version  int            `json:"version"`
+	Active   string         `json:"active"`
+	Segments []EventSegment `json:"segments"`
+}
+
+// EventManifestPath returns the manifest path for an event log (events.jsonl → events.manifest.json)
+func EventManifestPath(logPath string) string {
+	return strings.TrimSuffix(logPath, filepath.Ext(logPath)) + ".manifest.json"
+}
+
+// LoadEventLogManifest reads an event log's manifest. A log that was never
+// rotated has no manifest and returns one with just the active file.
+func LoadEventLogManifest(logPath string) (*EventLogManifest, error) {
+	m := &EventLogManifest{Version: EventManifestVersion, Active: filepath.Base(logPath)}
+	data, err := os.ReadFile(EventManifestPath(logPath))
+	if errors.Is(err, os.ErrNotExist) {
+		return m, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf("invalid event log manifest: %w", err)
+	}
+	return m, nil
+}
+
+// writeEventLogManifest atomically replaces the manifest
+func writeEventLogManifest(logPath string, m *EventLogManifest) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := EventManifestPath(logPath)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// ReadEventLog replays an event log in order: every rotated segment listed
+// in the manifest, then the active file. fn returning an error stops the read.
+func ReadEventLog(logPath string, fn func(Event) error) error {
+	m, err := LoadEventLogManifest(logPath)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(logPath)
+	for _, seg := range m.Segments {
+		if err := readEventFile(filepath.Join(dir, seg.File), fn); err != nil {
+			return fmt.Errorf("segment %s: %w", seg.File, err)
+		}
+	}
+
+	err = readEventFile(logPath, fn)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil // Just rotated, nothing written since
+	}
+	return err
+}
+
+// readEventFile reads newline-delimited events from a plain or .gz file
+func readEventFile(path string, fn func(Event) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if strings.HasSuffix(path, ".gz") {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	br := bufio.NewReader(r)
+	for {
+		line, err := br.ReadBytes('\n')
+		if len(line) > 1 {
+			var event Event
+			if jerr := json.Unmarshal(line, &event); jerr == nil {
+				if ferr := fn(event); ferr != nil {
+					return ferr
+				}
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// rotationEnabled reports whether the active file is ever rotated
+func (el *EventLog) rotationEnabled() bool {
+	return el.filePath != "" && (el.cfg.MaxSizeMB > 0 || el.cfg.RotateHours > 0)
+}
+
+// shouldRotate checks the size and age limits. Caller holds fileMu.
+func (el *EventLog) shouldRotate() bool {
+	if !el.rotationEnabled() || el.fileSize == 0 {
+		return false
+	}
+	if el.cfg.MaxSizeMB > 0 && el.fileSize >= int64(el.cfg.MaxSizeMB)<<20 {
+		return true
+	}
+	return el.cfg.RotateHours > 0 && time.Since(el.openedAt) >= time.Duration(el.cfg.RotateHours*float64(time.Hour))
+}
+
+// rotate renames the active file to a timestamped segment, reopens a fresh
+// active file and queues the segment for compression. Caller holds fileMu.
+func (el *EventLog) rotate() {
+	el.file.Close()
+	el.file = nil
+
+	segment := rotatedEventLogName(el.filePath, time.Now())
+	if err := os.Rename(el.filePath, segment); err != nil {
+		log.Printf("⚠️ Event log rotation failed: %v", err)
+		segment = ""
+	}
+
+	file, err := os.OpenFile(el.filePath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		log.Printf("⚠️ Event log disabled, cannot reopen %s: %v", el.filePath, err)
+	} else {
+		el.file = file
+		el.fileSize = 0
+		if info, err := file.Stat(); err == nil {
+			el.fileSize = info.Size() // Rename failed: still the old file
+		}
+	}
+	el.openedAt = time.Now()
+
+	if segment != "" {
+		el.compressCh <- segment
+	}
+}
+
+// rotatedEventLogName returns a free segment name (events.jsonl → events-20250101-120000.jsonl)
+func rotatedEventLogName(logPath string, t time.Time) string {
+	ext := filepath.Ext(logPath)
+	base := strings.TrimSuffix(logPath, ext) + "-" + t.Format("20060102-150405")
+	name := base + ext
+	for i := 2; ; i++ {
+		_, errPlain := os.Stat(name)
+		_, errGz := os.Stat(name + ".gz")
+		if errors.Is(errPlain, os.ErrNotExist) && errors.Is(errGz, os.ErrNotExist) {
+			return name
+		}
+		name = fmt.Sprintf("%s-%d%s", base, i, ext)
+	}
+}
+
+// uncompressedSegments finds rotated segments left uncompressed by a crash
+func uncompressedSegments(logPath string) []string {
+	ext := filepath.Ext(logPath)
+	matches, _ := filepath.Glob(strings.TrimSuffix(logPath, ext) + "-*" + ext)
+	return matches // Glob returns them sorted, i.e. oldest first
+}
+
+// compressLoop gzips rotated segments in rotation order and records them in the manifest
+func (el *EventLog) compressLoop() {
+	defer el.compressWg.Done()
+
+	for segment := range el.compressCh {
+		seg, err := compressEventSegment(segment)
+		if err != nil {
+			log.Printf("⚠️ Event log segment %s not compressed: %v", segment, err)
+			continue
+		}
+		if seg.Events == 0 {
+			os.Remove(filepath.Join(filepath.Dir(el.filePath), seg.File))
+			continue
+		}
+		if err := el.addSegment(seg); err != nil {
+			log.Printf("⚠️ Event log manifest not updated: %v", err)
+			continue
+		}
+		log.Printf("🗜️ Event log rotated: %s (%d events, %d KB)", seg.File, seg.Events, seg.Bytes/1024)
+	}
+}
+
+// compressEventSegment writes path.gz, collecting the segment's sequence and
+// time range on the way, then removes the uncompressed file
+func compressEventSegment(path string) (EventSegment, error) {
+	seg := EventSegment{File: filepath.Base(path) + ".gz"}
+
+	src, err := os.Open(path)
+	if err != nil {
+		return seg, err
+	}
+	defer src.Close()
+
+	tmp := path + ".gz.tmp"
+	dst, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return seg, err
+	}
+	gz := gzip.NewWriter(dst)
+
+	br := bufio.NewReader(src)
+	for {
+		line, rerr := br.ReadBytes('\n')
+		if len(line) > 0 {
+			if _, err := gz.Write(line); err != nil {
+				dst.Close()
+				os.Remove(tmp)
+				return seg, err
+			}
+			var header struct {
+				Sequence  uint64 `json:"sequence"`
+				Timestamp int64  `json:"timestamp"`
+			}
+			if json.Unmarshal(line, &header) == nil {
+				at := time.Unix(0, header.Timestamp)
+				if seg.Events == 0 {
+					seg.FirstSequence, seg.Start = header.Sequence, at
+				}
+				seg.LastSequence, seg.End = header.Sequence, at
+				seg.Events++
+			}
+		}
+		if rerr == io.EOF {
+			break
+		}
+		if rerr != nil {
+			dst.Close()
+			os.Remove(tmp)
+			return seg, rerr
+		}
+	}
+
+	if err := gz.Close(); err != nil {
+		dst.Close()
+		os.Remove(tmp)
+		return seg, err
+	}
+	if err := dst.Close(); err != nil {
+		os.Remove(tmp)
+		return seg, err
+	}
+	if err := os.Rename(tmp, path+".gz"); err != nil {
+		return seg, err
+	}
+	if info, err := os.Stat(path + ".gz"); err == nil {
+		seg.Bytes = info.Size()
+	}
+	src.Close()
+	return seg, os.Remove(path)
+}
+
+// addSegment appends a segment to the manifest and applies the retention policy
+func (el *EventLog) addSegment(seg EventSegment) error {
+	el.manifestMu.Lock()
+	defer el.manifestMu.Unlock()
+
+	m, err := LoadEventLogManifest(el.filePath)
+	if err != nil {
+		return err
+	}
+	m.Version = EventManifestVersion
+	m.Active = filepath.Base(el.filePath)
+	m.Segments = append(m.Segments, seg)
+
+	// Retention: drop segments past the age limit, then the oldest above the count limit
+	dir := filepath.Dir(el.filePath)
+	keep := m.Segments[:0]
+	cutoff := time.Now().Add(-time.Duration(el.cfg.RetainHours * float64(time.Hour)))
+	for i, s := range m.Segments {
+		expired := el.cfg.RetainHours > 0 && s.End.Before(cutoff)
+		excess := el.cfg.MaxSegments > 0 && len(m.Segments)-i > el.cfg.MaxSegments
+		if expired || excess {
+			os.Remove(filepath.Join(dir, s.File))
+			continue
+		}
+		keep = append(keep, s)
+	}
+	m.Segments = keep
+
+	return writeEventLogManifest(el.filePath, m)
+}
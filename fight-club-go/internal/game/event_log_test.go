@@ -0,0 +1,95 @@
+package game
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// emitFlushed emits events and waits for the writer loop to flush them
+func emitFlushed(el *EventLog, tick uint64, count int) {
+	for i := 0; i < count; i++ {
+		el.EmitSimple(EventTypeTick, tick, "", map[string]int{"i": i})
+	}
+	time.Sleep(3 * BatchFlushInterval)
+}
+
+// TestEventLogRotationStitchesSegments verifies rotated segments are gzipped,
+// listed in the manifest and replayed in order together with the active file
+func TestEventLogRotationStitchesSegments(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	el := NewEventLog()
+	// Any age is too old: every flush rotates
+	if err := el.Start(EventLogConfig{Path: path, RotateHours: 1e-12}); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	for tick := uint64(1); tick <= 3; tick++ {
+		emitFlushed(el, tick, 5)
+	}
+	el.Stop()
+
+	m, err := LoadEventLogManifest(path)
+	if err != nil {
+		t.Fatalf("Manifest unreadable: %v", err)
+	}
+	if len(m.Segments) != 3 || m.Active != "events.jsonl" {
+		t.Fatalf("Expected 3 segments, got %+v", m)
+	}
+	for _, seg := range m.Segments {
+		if filepath.Ext(seg.File) != ".gz" || seg.Events != 5 || seg.LastSequence-seg.FirstSequence != 4 {
+			t.Errorf("Unexpected segment %+v", seg)
+		}
+	}
+
+	var ticks []uint64
+	err = ReadEventLog(path, func(e Event) error {
+		ticks = append(ticks, e.TickNum)
+		return nil
+	})
+	if err != nil || len(ticks) != 15 {
+		t.Fatalf("Expected 15 replayed events, got %d (%v)", len(ticks), err)
+	}
+	for i := 1; i < len(ticks); i++ {
+		if ticks[i] < ticks[i-1] {
+			t.Fatalf("Expected events in order, got %v", ticks)
+		}
+	}
+}
+
+// TestEventLogRetentionAndRecovery verifies leftover segments are compressed
+// on start and the segment count limit deletes the oldest
+func TestEventLogRetentionAndRecovery(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "events.jsonl")
+	leftover := filepath.Join(dir, "events-20200101-000000.jsonl")
+	os.WriteFile(leftover, []byte(`{"version":1,"type":1,"timestamp":1,"sequence":7,"tickNum":1}`+"\n"), 0644)
+
+	el := NewEventLog()
+	if err := el.Start(EventLogConfig{Path: path, MaxSizeMB: 100, MaxSegments: 1}); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	el.Stop()
+
+	m, _ := LoadEventLogManifest(path)
+	if len(m.Segments) != 1 || m.Segments[0].FirstSequence != 7 {
+		t.Fatalf("Expected the leftover segment recovered, got %+v", m.Segments)
+	}
+	if _, err := os.Stat(leftover); !os.IsNotExist(err) {
+		t.Error("Expected the uncompressed leftover removed")
+	}
+
+	// A second rotation pushes the first one out
+	el = NewEventLog()
+	el.Start(EventLogConfig{Path: path, RotateHours: 1e-12, MaxSegments: 1})
+	emitFlushed(el, 1, 3)
+	el.Stop()
+
+	m, _ = LoadEventLogManifest(path)
+	if len(m.Segments) != 1 || m.Segments[0].Events != 3 {
+		t.Fatalf("Expected only the newest segment kept, got %+v", m.Segments)
+	}
+	if _, err := os.Stat(leftover + ".gz"); !os.IsNotExist(err) {
+		t.Error("Expected the pruned segment deleted")
+	}
+}
@@ -0,0 +1,33 @@
+package game
+
+// FramingSnapshot is the bounding box of the alive fighters, for renderers
+// that zoom in when only a few are left (see streaming autoFramer)
+type FramingSnapshot struct {
+	Count                  int     // Alive fighters in the box (0 = nobody, box unset)
+	MinX, MinY, MaxX, MaxY float64 // Fighter bodies included (PlayerRadius)
+}
+
+// framePlayers returns the bounding box of the alive players in a snapshot
+func framePlayers(players []PlayerSnapshot) FramingSnapshot {
+	var f FramingSnapshot
+	for i := range players {
+		p := &players[i]
+		if p.IsDead {
+			continue
+		}
+		if f.Count == 0 {
+			f.MinX, f.MaxX = p.X, p.X
+			f.MinY, f.MaxY = p.Y, p.Y
+		}
+		f.MinX, f.MaxX = min(f.MinX, p.X), max(f.MaxX, p.X)
+		f.MinY, f.MaxY = min(f.MinY, p.Y), max(f.MaxY, p.Y)
+		f.Count++
+	}
+	if f.Count > 0 {
+		f.MinX -= PlayerRadius
+		f.MinY -= PlayerRadius
+		f.MaxX += PlayerRadius
+		f.MaxY += PlayerRadius
+	}
+	return f
+}
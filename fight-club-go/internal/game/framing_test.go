@@ -0,0 +1,30 @@
+package game
+
+import (
+	"testing"
+)
+
+// TestFramingBoundsAlivePlayers verifies the snapshot frames alive fighters only, bodies included
+func TestFramingBoundsAlivePlayers(t *testing.T) {
+	engine := newTestEngine(30)
+	a := engine.AddPlayer("left", PlayerOptions{})
+	b := engine.AddPlayer("right", PlayerOptions{})
+	dead := engine.AddPlayer("ghost", PlayerOptions{})
+	a.X, a.Y = 300, 400
+	b.X, b.Y = 500, 250
+	dead.X, dead.Y = 1200, 700
+	dead.IsDead = true
+
+	engine.ProduceSnapshot()
+	f := engine.GetSnapshot().Framing
+	if f.Count != 2 {
+		t.Fatalf("Expected 2 framed fighters, got %d", f.Count)
+	}
+	if f.MinX != 300-PlayerRadius || f.MaxX != 500+PlayerRadius || f.MinY != 250-PlayerRadius || f.MaxY != 400+PlayerRadius {
+		t.Errorf("Unexpected framing box (%.0f, %.0f)-(%.0f, %.0f)", f.MinX, f.MinY, f.MaxX, f.MaxY)
+	}
+
+	if f := framePlayers([]PlayerSnapshot{{IsDead: true, X: 10}}); f != (FramingSnapshot{}) {
+		t.Errorf("Expected an empty box without alive fighters, got %+v", f)
+	}
+}
@@ -13,11 +13,77 @@ type ResourceLimits = config.ResourceLimits
 // DefaultLimits provides production-safe default limits (SSOT from config)
 var DefaultLimits = config.DefaultLimits()
 
+// DefaultEconomy provides default passive income settings (SSOT from config)
+var DefaultEconomy = config.DefaultEconomy()
+
+// DefaultVoting provides default arena vote settings (SSOT from config)
+var DefaultVoting = config.DefaultVoting()
+
+// DefaultDuel provides default duel settings (SSOT from config)
+var DefaultDuel = config.DefaultDuel()
+
+// DefaultChaos provides default chaos event settings (SSOT from config)
+var DefaultChaos = config.DefaultChaos()
+
+// DefaultScene provides default time of day and weather settings (SSOT from config)
+var DefaultScene = config.DefaultScene()
+
+// DefaultAirstrike provides default !airstrike settings (SSOT from config)
+var DefaultAirstrike = config.DefaultAirstrike()
+
+// DefaultShop provides default weapon shop settings (SSOT from config)
+var DefaultShop = config.DefaultShop()
+
+// DefaultRingOut provides default ring-out settings (SSOT from config)
+var DefaultRingOut = config.DefaultRingOut()
+
+// DefaultReplay provides default slow-motion replay settings (SSOT from config)
+var DefaultReplay = config.DefaultReplay()
+
+// DefaultObjective provides default team objective settings (SSOT from config)
+var DefaultObjective = config.DefaultObjective()
+
+// DefaultLeaderboard provides default persistent leaderboard settings (SSOT from config)
+var DefaultLeaderboard = config.DefaultLeaderboard()
+
+// DefaultHistory provides default match history settings (SSOT from config)
+var DefaultHistory = config.DefaultHistory()
+
+// DefaultRating provides default seasonal ELO rating settings (SSOT from config)
+var DefaultRating = config.DefaultRating()
+
+// DefaultCareer provides default career stats settings (SSOT from config)
+var DefaultCareer = config.DefaultCareer()
+
+// DefaultQuest provides default daily quest settings (SSOT from config)
+var DefaultQuest = config.DefaultQuest()
+
+// DefaultSkin provides default cosmetic skin settings (SSOT from config)
+var DefaultSkin = config.DefaultSkin()
+
+// DefaultLagCompensation provides default late command grace settings (SSOT from config)
+var DefaultLagCompensation = config.DefaultLagCompensation()
+
+// DefaultLoot provides default coin drop settings (SSOT from config)
+var DefaultLoot = config.DefaultLoot()
+
+// DefaultGift provides default !gift limits (SSOT from config)
+var DefaultGift = config.DefaultGift()
+
+// DefaultAnalytics provides default combat analytics settings (SSOT from config)
+var DefaultAnalytics = config.DefaultAnalytics()
+
+// DefaultBotFill provides default bot fill settings (SSOT from config)
+var DefaultBotFill = config.DefaultBotFill()
+
+// DefaultJoinQueue provides default join queue settings (SSOT from config)
+var DefaultJoinQueue = config.DefaultJoinQueue()
+
 // PlayerSnapshot is an immutable copy of player state for rendering
 // Uses value types (not pointers) to ensure immutability
 type PlayerSnapshot struct {
 	ID              string
-	Name            string
+	Name            string // Display name (see DisplayName), not the identity key
 	X, Y            float64
 	VX, VY          float64
 	HP, MaxHP       int
@@ -31,6 +97,8 @@ type PlayerSnapshot struct {
 	IsDead          bool
 	IsRagdoll       bool
 	RagdollRotation float64
+	RingOut         bool    // Knocked into the pit: the ragdoll falls out of sight
+	FallProgress    float64 // 0..1 through the ring-out fall
 	SpawnProtection bool
 	IsAttacking     bool
 	ProfilePic      string
@@ -40,6 +108,42 @@ type PlayerSnapshot struct {
 	DodgeDirection float64
 	ComboCount     int
 	Stamina        float64
+	IsSprinting    bool
+	IsExhausted    bool // Out of stamina: slower, takes bonus damage
+
+	// Emote/taunt animation (empty when none playing)
+	Emote         string
+	EmoteProgress float64 // 0..1 through the animation
+
+	// Chat bubble above the player (empty when none showing)
+	ChatBubble    string
+	ChatBubbleTTL float64 // Seconds left; the bubble fades out in the last second
+
+	// AI personality profile ID (shown on the player card)
+	Personality string
+
+	// Seasonal rank tier ID (badge next to the name; empty for bots)
+	Rank string
+
+	// Team color (minimap dot; empty when solo)
+	TeamColor string
+
+	// Equipped cosmetic skins (border ring, swing color, nameplate)
+	Skins PlayerSkins
+
+	// Spectator effects (on-screen indicator)
+	IsCheered bool
+	IsCursed  bool
+
+	// Team leader orders (indicator around team members; see team_orders.go)
+	IsRallied          bool
+	IsRegrouping       bool
+	RegroupX, RegroupY float64 // Regroup goal the indicator points at
+
+	// Swing timing for client-side prediction (see PredictionWindow)
+	AttackPhase     AttackPhase
+	AttackPhaseLeft float64 // Seconds left in the phase
+	AttackCooldown  float64 // Seconds until the next attack
 }
 
 // ParticleSnapshot is an immutable particle for rendering
@@ -47,6 +151,7 @@ type ParticleSnapshot struct {
 	X, Y  float64
 	Color string
 	Alpha float64
+	Size  float64 // Radius in pixels (0 = 2)
 }
 
 // EffectSnapshot is an immutable attack effect
@@ -101,6 +206,7 @@ type GameSnapshot struct {
 	Sequence   uint64    // Monotonic sequence for ordering
 	Timestamp  time.Time // When snapshot was created
 	TickNumber uint64    // Game tick this represents
+	TickRate   int       // Simulation ticks per second (interpolation hint: TickNumber deltas are 1/TickRate s apart)
 	RNGSeed    int64     // Seed for deterministic replay
 
 	// Pre-allocated capped slices (never grows beyond limits)
@@ -113,7 +219,28 @@ type GameSnapshot struct {
 	Trails      []TrailSnapshot
 	Flashes     []FlashSnapshot
 	Projectiles []ProjectileSnapshot // Bow arrows and thrown weapons
+	Loot        []LootSnapshot       // Coins dropped by dead fighters
+	WeaponDrops []WeaponDropSnapshot // Weapons dropped by dead fighters
+	Shops       []ShopSnapshot       // Weapon shops where !buy orders are picked up
+	PitBand     float64              // Width of the ring-out pit around the arena (0 = no ring-outs)
 	Shake       ShakeSnapshot        // Single global shake state
+	Vote        VoteSnapshot         // Arena modifier vote / active modifier
+	Duel        DuelSnapshot         // Active duel ring
+	Chaos       ChaosSnapshot        // Chaos event warning, safe zone and meteors
+	Scene       SceneSnapshot        // Background time of day and weather overlay
+	Airstrikes  []AirstrikeSnapshot  // Incoming viewer airstrikes
+	Objective   ObjectiveSnapshot    // Team bases, capture point and scores
+	Leaderboard LeaderboardSnapshot  // Persistent leaderboard view on the rotator
+	QuestToast  QuestToastSnapshot   // Quest completion toast (Username "" = hidden)
+	JoinQueue   JoinQueueSnapshot    // Viewers waiting for a slot ("Up next")
+	Paused      bool                 // Simulation frozen (render PAUSED overlay)
+	ViewerCount int                  // Live Kick viewers (0 = unknown/offline, hidden)
+	AutoStream  bool                 // Streamer follows StreamLive instead of streaming always
+	StreamLive  bool                 // Auto-stream decision: FFmpeg should be running
+	SoundPack   string               // Sound effects pack the streamer plays
+	Framing     FramingSnapshot      // Alive fighters' bounding box (auto-framing camera)
+	Replay      ReplayCue            // Slow-motion replay requests
+	Replaying   bool                 // Render-side: a frame of a replay being played (never set by the engine)
 
 	// Aggregate stats
 	PlayerCount int
@@ -145,6 +272,10 @@ func NewSnapshotPool(limits ResourceLimits) *SnapshotPool {
 			Trails:      make([]TrailSnapshot, 0, limits.MaxTrails),
 			Flashes:     make([]FlashSnapshot, 0, limits.MaxFlashes),
 			Projectiles: make([]ProjectileSnapshot, 0, MaxProjectiles),
+			Loot:        make([]LootSnapshot, 0, MaxLootPiles),
+			WeaponDrops: make([]WeaponDropSnapshot, 0, MaxWeaponDrops),
+			Shops:       make([]ShopSnapshot, 0, config.MaxShops),
+			Airstrikes:  make([]AirstrikeSnapshot, 0, MaxAirstrikes),
 		}
 	}
 
@@ -165,6 +296,10 @@ func (p *SnapshotPool) AcquireWrite() *GameSnapshot {
 	snap.Trails = snap.Trails[:0]           // BUGFIX: Was missing, caused stale trails
 	snap.Flashes = snap.Flashes[:0]         // BUGFIX: Was missing, caused stale flashes
 	snap.Projectiles = snap.Projectiles[:0] // Reset projectiles
+	snap.Loot = snap.Loot[:0]
+	snap.WeaponDrops = snap.WeaponDrops[:0]
+	snap.Shops = snap.Shops[:0]
+	snap.Airstrikes = snap.Airstrikes[:0]
 
 	// Reset shake state
 	snap.Shake = ShakeSnapshot{} // Zero out shake
@@ -176,6 +311,27 @@ func (p *SnapshotPool) AcquireWrite() *GameSnapshot {
 	return snap
 }
 
+// copyInto copies the snapshot into dst, reusing dst's slices. Only the
+// slices the pool rewrites are copied: the rest are built fresh each tick.
+func (s *GameSnapshot) copyInto(dst *GameSnapshot) {
+	players, particles, effects, texts := dst.Players[:0], dst.Particles[:0], dst.Effects[:0], dst.Texts[:0]
+	trails, flashes, projectiles := dst.Trails[:0], dst.Flashes[:0], dst.Projectiles[:0]
+	loot, drops, shops, airstrikes := dst.Loot[:0], dst.WeaponDrops[:0], dst.Shops[:0], dst.Airstrikes[:0]
+
+	*dst = *s
+	dst.Players = append(players, s.Players...)
+	dst.Particles = append(particles, s.Particles...)
+	dst.Effects = append(effects, s.Effects...)
+	dst.Texts = append(texts, s.Texts...)
+	dst.Trails = append(trails, s.Trails...)
+	dst.Flashes = append(flashes, s.Flashes...)
+	dst.Projectiles = append(projectiles, s.Projectiles...)
+	dst.Loot = append(loot, s.Loot...)
+	dst.WeaponDrops = append(drops, s.WeaponDrops...)
+	dst.Shops = append(shops, s.Shops...)
+	dst.Airstrikes = append(airstrikes, s.Airstrikes...)
+}
+
 // PublishWrite marks write complete and advances read pointer
 // Called after snapshot is fully populated
 func (p *SnapshotPool) PublishWrite() {
@@ -0,0 +1,62 @@
+package game
+
+import (
+	"fmt"
+	"log"
+
+	"fight-club/internal/config"
+)
+
+// GiftConfig is an alias for config.GiftConfig (SSOT)
+type GiftConfig = config.GiftConfig
+
+// Gift moves in-arena money from one fighter to another (!gift). Both must be
+// viewers in the arena; amounts are bounded by the gift config and each
+// sender has a cooldown, so alts can't funnel money around freely.
+func (e *Engine) Gift(fromName, toName string, amount int) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	from, ok := e.players[fromName]
+	if !ok {
+		return fmt.Errorf("not in arena (type !join first)")
+	}
+	if fromName == toName {
+		return fmt.Errorf("cannot gift yourself")
+	}
+	to, ok := e.players[toName]
+	if !ok || to.IsBot || toName == e.arenaBotName {
+		return fmt.Errorf("'%s' is not in the arena", toName)
+	}
+	if amount < e.gift.Min || amount > e.gift.Max {
+		return fmt.Errorf("gifts must be $%d-$%d", e.gift.Min, e.gift.Max)
+	}
+	if e.tickCount < e.giftReady[fromName] {
+		remaining := float64(e.giftReady[fromName]-e.tickCount) / float64(e.tickRate)
+		return fmt.Errorf("on cooldown (%.0fs)", remaining)
+	}
+	if from.Money < amount {
+		return fmt.Errorf("you only have $%d", from.Money)
+	}
+
+	from.Money -= amount
+	to.Money += amount
+	e.giftReady[fromName] = e.tickCount + int64(e.gift.Cooldown*float64(e.tickRate))
+
+	if len(e.texts) < e.limits.MaxTexts {
+		e.texts = append(e.texts, &FloatingText{
+			X:     to.X,
+			Y:     to.Y - 40,
+			Text:  fmt.Sprintf("+$%d from %s", amount, from.DisplayName),
+			Color: "#ffd700",
+			Alpha: 1.0,
+			VY:    -1.5,
+		})
+	}
+
+	e.eventLog.EmitSimple(EventTypeGift, uint64(e.tickCount), from.ID,
+		GiftPayload{FromID: from.ID, ToID: to.ID, Amount: amount})
+
+	log.Printf("🎁 %s gifted $%d to %s", fromName, amount, toName)
+	return nil
+}
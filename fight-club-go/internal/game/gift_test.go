@@ -0,0 +1,66 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestGift verifies money moves between viewers within the limits and cooldown
+func TestGift(t *testing.T) {
+	engine := newTestEngine(30)
+	ana := engine.AddPlayer("ana", PlayerOptions{})
+	leo := engine.AddPlayer("leo", PlayerOptions{})
+	ana.Money, leo.Money = 300, 0
+
+	for _, tc := range []struct {
+		from, to string
+		amount   int
+	}{
+		{"nobody", "leo", 100}, // Sender not in the arena
+		{"ana", "ana", 100},    // Self gift
+		{"ana", "nobody", 100}, // Unknown recipient
+		{"ana", "leo", DefaultGift.Min - 1},
+		{"ana", "leo", DefaultGift.Max + 1},
+	} {
+		if err := engine.Gift(tc.from, tc.to, tc.amount); err == nil {
+			t.Errorf("Expected gift %s -> %s $%d to fail", tc.from, tc.to, tc.amount)
+		}
+	}
+
+	if err := engine.Gift("ana", "leo", 100); err != nil {
+		t.Fatalf("Gift failed: %v", err)
+	}
+	if ana.Money != 200 || leo.Money != 100 {
+		t.Errorf("Expected $200/$100 after the gift, got $%d/$%d", ana.Money, leo.Money)
+	}
+	found := false
+	for _, text := range engine.texts {
+		found = found || text.Text == "+$100 from ana"
+	}
+	if !found {
+		t.Error("Expected a floating text over the recipient")
+	}
+
+	// Per-sender cooldown, then insufficient funds
+	if err := engine.Gift("ana", "leo", 100); err == nil || !strings.Contains(err.Error(), "cooldown") {
+		t.Errorf("Expected cooldown error, got %v", err)
+	}
+	engine.tickCount += int64(DefaultGift.Cooldown * 30)
+	if err := engine.Gift("ana", "leo", 300); err == nil {
+		t.Error("Expected gift larger than the balance to fail")
+	}
+	if err := engine.Gift("ana", "leo", 200); err != nil {
+		t.Errorf("Gift after cooldown failed: %v", err)
+	}
+
+	// Bots can't receive gifts
+	engine.botFill = BotFillConfig{MinPlayers: 3}
+	engine.spawnBot()
+	for name, p := range engine.players {
+		if p.IsBot {
+			if err := engine.Gift("leo", name, 50); err == nil {
+				t.Errorf("Expected gift to bot %s to fail", name)
+			}
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package game
+
+import "log"
+
+// Highlight kinds
+const (
+	HighlightMultiKill = "multikill" // MultiKillCount kills in a quick chain
+	HighlightBossKill  = "boss_kill" // A script boss went down
+)
+
+// Multikill detection
+const (
+	MultiKillCount  = 3    // Chained kills that make a highlight
+	MultiKillWindow = 10.0 // Max seconds between two kills of a chain
+)
+
+// Highlight is a big arena moment worth clipping
+type Highlight struct {
+	Kind   string `json:"kind"`
+	Player string `json:"player"`           // Who made the moment
+	Victim string `json:"victim,omitempty"` // Boss killed (boss kills)
+	Kills  int    `json:"kills,omitempty"`  // Kills in the chain (multikills)
+}
+
+// recordHighlight tracks the killer's multikill chain and reports highlights
+// to OnHighlight. Bot kills don't count. Caller holds e.mu.
+func (e *Engine) recordHighlight(killer, victim *Player) {
+	if killer.IsBot {
+		return
+	}
+
+	if killer.chainKills > 0 && e.tickCount-killer.chainKillTick <= int64(MultiKillWindow*float64(e.tickRate)) {
+		killer.chainKills++
+	} else {
+		killer.chainKills = 1
+	}
+	killer.chainKillTick = e.tickCount
+
+	var h Highlight
+	switch {
+	case victim.IsBoss:
+		h = Highlight{Kind: HighlightBossKill, Player: killer.Name, Victim: victim.Name}
+	case killer.chainKills == MultiKillCount:
+		h = Highlight{Kind: HighlightMultiKill, Player: killer.Name, Kills: killer.chainKills}
+	default:
+		return
+	}
+
+	log.Printf("🎬 Highlight: %s (%s)", h.Kind, h.Player)
+	if e.OnHighlight != nil {
+		go e.OnHighlight(h)
+	}
+}
@@ -0,0 +1,56 @@
+package game
+
+import (
+	"testing"
+	"time"
+)
+
+// TestHighlights verifies quick kill chains and boss kills are reported once
+func TestHighlights(t *testing.T) {
+	engine := newTestEngine(30)
+	highlights := make(chan Highlight, 10)
+	engine.OnHighlight = func(h Highlight) { highlights <- h }
+
+	ana := engine.AddPlayer("ana", PlayerOptions{})
+	victim := engine.AddPlayer("victim", PlayerOptions{})
+
+	// Two quick kills, a pause that breaks the chain, then three quick kills
+	for _, gap := range []float64{0, 2, MultiKillWindow + 1, 3, 3, 3} {
+		engine.tickCount += int64(gap * 30)
+		engine.recordHighlight(ana, victim)
+	}
+
+	select {
+	case h := <-highlights:
+		if h.Kind != HighlightMultiKill || h.Player != "ana" || h.Kills != MultiKillCount {
+			t.Errorf("Expected ana's %d-kill multikill, got %+v", MultiKillCount, h)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected a multikill highlight")
+	}
+
+	boss := engine.AddPlayer("[BOSS] Titan", PlayerOptions{})
+	boss.IsBoss = true
+	engine.tickCount += int64(MultiKillWindow*30) + 1
+	engine.recordHighlight(ana, boss)
+	select {
+	case h := <-highlights:
+		if h.Kind != HighlightBossKill || h.Victim != "[BOSS] Titan" {
+			t.Errorf("Expected the boss kill, got %+v", h)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected a boss kill highlight")
+	}
+
+	// Bots don't make highlights
+	bot := engine.AddPlayer("[BOT] Rex", PlayerOptions{})
+	bot.IsBot = true
+	for i := 0; i < MultiKillCount; i++ {
+		engine.recordHighlight(bot, victim)
+	}
+	select {
+	case h := <-highlights:
+		t.Errorf("Expected no more highlights, got %+v", h)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
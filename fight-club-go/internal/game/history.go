@@ -0,0 +1,369 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"log"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"fight-club/internal/config"
+)
+
+// HistoryConfig is an alias for config.HistoryConfig (SSOT)
+type HistoryConfig = config.HistoryConfig
+
+const (
+	historyTopKillers   = 5                // Killers kept per round summary
+	historySaveInterval = 30 * time.Second // How often finished rounds are flushed to disk
+)
+
+// RoundKiller is one viewer's kills in a round
+type RoundKiller struct {
+	Name  string `json:"name"`
+	Kills int    `json:"kills"`
+}
+
+// RoundSummary is one round of the match history (/api/history)
+type RoundSummary struct {
+	ID           int            `json:"id"`
+	Start        time.Time      `json:"start"`
+	End          time.Time      `json:"end"`
+	Seconds      float64        `json:"seconds"`
+	Winner       string         `json:"winner"` // Viewer with the most kills ("" = no viewer scored)
+	TopKillers   []RoundKiller  `json:"topKillers"`
+	Kills        int            `json:"kills"`   // All kills, bots included
+	Weapons      map[string]int `json:"weapons"` // Kills per weapon
+	PeakFighters int            `json:"peakFighters"`
+}
+
+// historyFile is the persisted JSON layout
+type historyFile struct {
+	Rounds []RoundSummary `json:"rounds"`
+}
+
+// MatchHistory splits the continuous arena into rounds of RoundLength
+// seconds of game time and keeps a summary of each: winner, top killers,
+// kills and weapons used. Rounds without kills aren't kept. Finished rounds
+// are persisted (the last MaxRounds) for /api/history and cmd/summary.
+type MatchHistory struct {
+	mu          sync.RWMutex
+	cfg         HistoryConfig
+	rounds      []RoundSummary // Oldest first
+	nextID      int
+	dirty       bool
+	saveBlocked bool // The file couldn't be read, or didn't parse and couldn't be moved aside
+
+	// Current round
+	start   time.Time
+	elapsed float64
+	kills   map[string]int // Viewer -> kills
+	weapons map[string]int // Weapon -> kills
+	total   int
+	peak    int
+
+	running bool
+	stopCh  chan struct{}
+	wg      sync.WaitGroup
+}
+
+// NewMatchHistory creates a match history (File "" = in-memory only)
+func NewMatchHistory(cfg HistoryConfig) *MatchHistory {
+	defaults := config.DefaultHistory()
+	if cfg.RoundLength <= 0 {
+		cfg.RoundLength = defaults.RoundLength
+	}
+	if cfg.MaxRounds <= 0 {
+		cfg.MaxRounds = defaults.MaxRounds
+	}
+	mh := &MatchHistory{
+		cfg:    cfg,
+		nextID: 1,
+		stopCh: make(chan struct{}),
+	}
+	mh.resetRound(time.Now())
+	return mh
+}
+
+// Start loads persisted rounds and begins periodic saving
+func (mh *MatchHistory) Start() {
+	mh.mu.Lock()
+	if mh.running {
+		mh.mu.Unlock()
+		return
+	}
+	mh.running = true
+	mh.mu.Unlock()
+
+	mh.load()
+
+	mh.wg.Add(1)
+	go mh.saveLoop()
+}
+
+// Stop ends the round in progress (the stream is over) and flushes rounds to disk
+func (mh *MatchHistory) Stop() {
+	mh.mu.Lock()
+	if !mh.running {
+		mh.mu.Unlock()
+		return
+	}
+	mh.running = false
+	mh.endRoundLocked(time.Now())
+	mh.mu.Unlock()
+
+	close(mh.stopCh)
+	mh.wg.Wait()
+	mh.save()
+}
+
+// resetRound starts a new round (caller holds lock)
+func (mh *MatchHistory) resetRound(now time.Time) {
+	mh.start = now
+	mh.elapsed = 0
+	mh.kills = make(map[string]int)
+	mh.weapons = make(map[string]int)
+	mh.total = 0
+	mh.peak = 0
+}
+
+// RecordKill counts a kill in the current round. Bot kills count toward the
+// totals and weapons but bots never top the round.
+func (mh *MatchHistory) RecordKill(killer *Player) {
+	mh.mu.Lock()
+	defer mh.mu.Unlock()
+
+	mh.total++
+	mh.weapons[killer.Weapon]++
+	if !killer.IsBot {
+		mh.kills[killer.Name]++
+	}
+}
+
+// Advance moves the round clock and ends the round after RoundLength seconds.
+// Called every tick with the number of fighters in the arena.
+func (mh *MatchHistory) Advance(deltaTime float64, fighters int) {
+	mh.mu.Lock()
+	defer mh.mu.Unlock()
+
+	mh.peak = max(mh.peak, fighters)
+	mh.elapsed += deltaTime
+	if mh.elapsed >= mh.cfg.RoundLength {
+		mh.endRoundLocked(time.Now())
+	}
+}
+
+// currentLocked summarizes the round in progress (caller holds lock)
+func (mh *MatchHistory) currentLocked(now time.Time) RoundSummary {
+	round := RoundSummary{
+		ID:           mh.nextID,
+		Start:        mh.start,
+		End:          now,
+		Seconds:      round2(mh.elapsed),
+		Kills:        mh.total,
+		Weapons:      make(map[string]int, len(mh.weapons)),
+		PeakFighters: mh.peak,
+	}
+	for weapon, n := range mh.weapons {
+		round.Weapons[weapon] = n
+	}
+	round.TopKillers = topKillers(mh.kills, historyTopKillers)
+	if len(round.TopKillers) > 0 {
+		round.Winner = round.TopKillers[0].Name
+	}
+	return round
+}
+
+// topKillers ranks viewers by kills (ties by name)
+func topKillers(kills map[string]int, limit int) []RoundKiller {
+	killers := make([]RoundKiller, 0, len(kills))
+	for name, k := range kills {
+		killers = append(killers, RoundKiller{Name: name, Kills: k})
+	}
+	sort.Slice(killers, func(i, j int) bool {
+		if killers[i].Kills != killers[j].Kills {
+			return killers[i].Kills > killers[j].Kills
+		}
+		return killers[i].Name < killers[j].Name
+	})
+	if limit > 0 && len(killers) > limit {
+		killers = killers[:limit]
+	}
+	return killers
+}
+
+// endRoundLocked records the round in progress (if anyone died) and starts
+// the next one (caller holds lock)
+func (mh *MatchHistory) endRoundLocked(now time.Time) {
+	if mh.total > 0 {
+		round := mh.currentLocked(now)
+		mh.rounds = append(mh.rounds, round)
+		if len(mh.rounds) > mh.cfg.MaxRounds {
+			mh.rounds = mh.rounds[len(mh.rounds)-mh.cfg.MaxRounds:]
+		}
+		mh.nextID++
+		mh.dirty = true
+		log.Printf("📜 Round %d over: %d kills, winner %q", round.ID, round.Kills, round.Winner)
+	}
+	mh.resetRound(now)
+}
+
+// Current returns the summary of the round in progress
+func (mh *MatchHistory) Current() RoundSummary {
+	mh.mu.RLock()
+	defer mh.mu.RUnlock()
+	return mh.currentLocked(time.Now())
+}
+
+// exportRound captures the round in progress
+func (mh *MatchHistory) exportRound() ArenaRound {
+	mh.mu.RLock()
+	defer mh.mu.RUnlock()
+
+	round := ArenaRound{
+		Start:   mh.start,
+		Elapsed: mh.elapsed,
+		Kills:   make(map[string]int, len(mh.kills)),
+		Weapons: make(map[string]int, len(mh.weapons)),
+		Total:   mh.total,
+		Peak:    mh.peak,
+	}
+	for name, n := range mh.kills {
+		round.Kills[name] = n
+	}
+	for weapon, n := range mh.weapons {
+		round.Weapons[weapon] = n
+	}
+	return round
+}
+
+// resumeRound continues a round saved by the previous process. The old
+// process closed that round when it stopped; the resumed round replaces it.
+func (mh *MatchHistory) resumeRound(saved ArenaRound) {
+	mh.mu.Lock()
+	defer mh.mu.Unlock()
+
+	if n := len(mh.rounds); n > 0 && mh.rounds[n-1].Start.Equal(saved.Start) {
+		mh.nextID = mh.rounds[n-1].ID
+		mh.rounds = mh.rounds[:n-1]
+		mh.dirty = true
+	}
+
+	mh.start = saved.Start
+	mh.elapsed = saved.Elapsed
+	mh.kills = make(map[string]int, len(saved.Kills))
+	for name, n := range saved.Kills {
+		mh.kills[name] = n
+	}
+	mh.weapons = make(map[string]int, len(saved.Weapons))
+	for weapon, n := range saved.Weapons {
+		mh.weapons[weapon] = n
+	}
+	mh.total = saved.Total
+	mh.peak = max(mh.peak, saved.Peak)
+}
+
+// Rounds returns finished rounds, most recent first (limit 0 = all)
+func (mh *MatchHistory) Rounds(limit int) []RoundSummary {
+	mh.mu.RLock()
+	defer mh.mu.RUnlock()
+
+	n := len(mh.rounds)
+	if limit > 0 && limit < n {
+		n = limit
+	}
+	rounds := make([]RoundSummary, n)
+	for i := range rounds {
+		rounds[i] = mh.rounds[len(mh.rounds)-1-i]
+	}
+	return rounds
+}
+
+// saveLoop flushes finished rounds to disk every historySaveInterval
+func (mh *MatchHistory) saveLoop() {
+	defer mh.wg.Done()
+
+	ticker := time.NewTicker(historySaveInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-mh.stopCh:
+			return
+		case <-ticker.C:
+			mh.save()
+		}
+	}
+}
+
+// save persists finished rounds to disk if any were added
+func (mh *MatchHistory) save() {
+	mh.mu.Lock()
+	if !mh.dirty || mh.cfg.File == "" || mh.saveBlocked {
+		mh.mu.Unlock()
+		return
+	}
+	data, err := json.MarshalIndent(historyFile{Rounds: mh.rounds}, "", "  ")
+	mh.dirty = false
+	mh.mu.Unlock()
+
+	if err != nil {
+		log.Printf("⚠️ Failed to marshal match history: %v", err)
+		return
+	}
+
+	if err := WriteFileAtomic(mh.cfg.File, data, 0600); err != nil {
+		log.Printf("⚠️ Failed to save match history: %v", err)
+	}
+}
+
+// load restores persisted rounds from disk
+func (mh *MatchHistory) load() {
+	if mh.cfg.File == "" {
+		return
+	}
+	rounds, err := LoadHistory(mh.cfg.File)
+	if os.IsNotExist(err) {
+		return // No saved history
+	}
+	if err != nil {
+		var pathErr *fs.PathError
+		if errors.As(err, &pathErr) { // Unreadable rather than corrupt
+			log.Printf("⚠️ Failed to read saved match history, not saving over it: %v", err)
+		} else if setAsideCorrupt(mh.cfg.File, "match history", err) {
+			return
+		}
+		mh.mu.Lock()
+		mh.saveBlocked = true
+		mh.mu.Unlock()
+		return
+	}
+
+	mh.mu.Lock()
+	mh.rounds = append(rounds, mh.rounds...)
+	if len(mh.rounds) > mh.cfg.MaxRounds {
+		mh.rounds = mh.rounds[len(mh.rounds)-mh.cfg.MaxRounds:]
+	}
+	for _, r := range mh.rounds {
+		mh.nextID = max(mh.nextID, r.ID+1)
+	}
+	mh.mu.Unlock()
+
+	log.Printf("📂 Loaded match history: %d rounds", len(rounds))
+}
+
+// LoadHistory reads the rounds persisted in a match history file, oldest first
+func LoadHistory(path string) ([]RoundSummary, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var file historyFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+	return file.Rounds, nil
+}
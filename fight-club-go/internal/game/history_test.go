@@ -0,0 +1,106 @@
+package game
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestMatchHistoryRounds verifies rounds end after RoundLength with their
+// winner, top killers and weapons, and that empty rounds aren't kept
+func TestMatchHistoryRounds(t *testing.T) {
+	mh := NewMatchHistory(HistoryConfig{RoundLength: 10, MaxRounds: 2})
+	ana := &Player{Name: "ana", Weapon: "bow"}
+	leo := &Player{Name: "leo", Weapon: "sword"}
+	bot := &Player{Name: "bot", Weapon: "sword", IsBot: true}
+
+	mh.RecordKill(ana)
+	mh.RecordKill(ana)
+	mh.RecordKill(leo)
+	for i := 0; i < 3; i++ {
+		mh.RecordKill(bot)
+	}
+	mh.Advance(5, 4)
+	if len(mh.Rounds(0)) != 0 || mh.Current().Kills != 6 {
+		t.Fatalf("Expected the round still running with 6 kills, got %+v", mh.Current())
+	}
+
+	mh.Advance(5, 6)
+	rounds := mh.Rounds(0)
+	if len(rounds) != 1 {
+		t.Fatalf("Expected one finished round, got %d", len(rounds))
+	}
+	r := rounds[0]
+	if r.Winner != "ana" || len(r.TopKillers) != 2 || r.TopKillers[1] != (RoundKiller{"leo", 1}) {
+		t.Errorf("Expected ana to win ahead of leo (bots never rank), got %+v", r)
+	}
+	if r.Kills != 6 || r.Weapons["sword"] != 4 || r.Weapons["bow"] != 2 || r.PeakFighters != 6 {
+		t.Errorf("Unexpected round totals %+v", r)
+	}
+
+	// A round without kills isn't recorded
+	mh.Advance(10, 2)
+	if len(mh.Rounds(0)) != 1 {
+		t.Error("Expected an empty round to be dropped")
+	}
+
+	// Only the last MaxRounds are kept, newest first
+	for i := 0; i < 2; i++ {
+		mh.RecordKill(leo)
+		mh.Advance(10, 2)
+	}
+	if rounds := mh.Rounds(0); len(rounds) != 2 || rounds[0].ID != 3 || rounds[0].Winner != "leo" {
+		t.Errorf("Expected rounds 3 and 2 kept, got %+v", rounds)
+	}
+}
+
+// TestMatchHistoryPersists verifies Stop saves the round in progress and a new
+// history continues the round IDs
+func TestMatchHistoryPersists(t *testing.T) {
+	cfg := HistoryConfig{File: filepath.Join(t.TempDir(), "history.json"), RoundLength: 600}
+	mh := NewMatchHistory(cfg)
+	mh.Start()
+	mh.RecordKill(&Player{Name: "ana", Weapon: "axe"})
+	mh.Stop()
+
+	rounds, err := LoadHistory(cfg.File)
+	if err != nil || len(rounds) != 1 || rounds[0].Winner != "ana" {
+		t.Fatalf("Expected the stopped round saved, got %+v (%v)", rounds, err)
+	}
+
+	restored := NewMatchHistory(cfg)
+	restored.Start()
+	defer restored.Stop()
+	if got := restored.Rounds(0); len(got) != 1 || restored.Current().ID != 2 {
+		t.Errorf("Expected 1 restored round and round 2 next, got %d rounds, next %d", len(got), restored.Current().ID)
+	}
+}
+
+// TestStreamRecap verifies the latest stream is split off by the gap and recapped
+func TestStreamRecap(t *testing.T) {
+	at := func(h, m int) time.Time { return time.Date(2026, 10, 14, h, m, 0, 0, time.UTC) }
+	rounds := []RoundSummary{
+		{ID: 1, Start: at(12, 0), End: at(12, 10), Winner: "old", TopKillers: []RoundKiller{{"old", 9}}, Kills: 9},
+		{ID: 2, Start: at(20, 0), End: at(20, 10), Winner: "ana", TopKillers: []RoundKiller{{"ana", 5}, {"leo", 4}},
+			Kills: 10, Weapons: map[string]int{"bow": 6, "sword": 4}, PeakFighters: 12},
+		{ID: 3, Start: at(20, 10), End: at(20, 20), Winner: "leo", TopKillers: []RoundKiller{{"leo", 3}},
+			Kills: 5, Weapons: map[string]int{"sword": 5}, PeakFighters: 8},
+	}
+
+	session := LatestSession(rounds, 30*time.Minute)
+	if len(session) != 2 || session[0].ID != 2 {
+		t.Fatalf("Expected rounds 2-3 as the latest stream, got %+v", session)
+	}
+
+	recap := RecapRounds(session)
+	if recap.Rounds != 2 || recap.Kills != 15 || recap.PeakFighters != 12 || recap.Length() != "20m" {
+		t.Errorf("Unexpected recap totals %+v", recap)
+	}
+	// One round each: leo's 7 kills beat ana's 5
+	if recap.Champion != "leo" || recap.TopKillers[0] != (RoundKiller{"leo", 7}) {
+		t.Errorf("Expected leo as champion and top killer, got %+v", recap)
+	}
+	if recap.Weapons[0].Weapon != "sword" || recap.Weapons[0].Share != 0.6 {
+		t.Errorf("Expected sword first with 60%% of kills, got %+v", recap.Weapons)
+	}
+}
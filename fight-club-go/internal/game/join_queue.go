@@ -0,0 +1,127 @@
+package game
+
+import (
+	"log"
+
+	"fight-club/internal/config"
+)
+
+// JoinQueueConfig is an alias for config.JoinQueueConfig (SSOT)
+type JoinQueueConfig = config.JoinQueueConfig
+
+// JoinQueueShown is how many queued viewers the "Up next" card names
+const JoinQueueShown = 3
+
+// queuedJoin is a viewer waiting for a free slot
+type queuedJoin struct {
+	name string
+	opts PlayerOptions
+}
+
+// JoinQueueSnapshot is the "Up next" line shown on stream (Total 0 = hidden)
+type JoinQueueSnapshot struct {
+	Names [JoinQueueShown]string // Next viewers in, first in line first
+	Count int                    // Valid entries in Names
+	Total int                    // Viewers waiting
+}
+
+// JoinOrQueue adds a player like AddPlayer, or puts a new viewer in the join
+// queue when the arena is full. Returns the player, or nil and the viewer's
+// place in line (0 = rejected: kicked, queue disabled or full).
+func (e *Engine) JoinOrQueue(name string, opts PlayerOptions) (*Player, int) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if pos := e.joinQueuePositionLocked(name); pos > 0 {
+		return nil, pos
+	}
+	// Viewers in line go first: newcomers queue behind them even if a slot
+	// freed up since the last tick
+	if _, exists := e.players[name]; exists || len(e.joinQueue) == 0 {
+		if player := e.addPlayerLocked(name, opts); player != nil || e.rejoinBlocked(name) {
+			return player, 0
+		}
+	}
+
+	if len(e.joinQueue) >= e.joinQueueCfg.MaxSize {
+		log.Printf("⚠️ Join queue full (%d), rejecting: %s", len(e.joinQueue), name)
+		return nil, 0
+	}
+	e.joinQueue = append(e.joinQueue, queuedJoin{name: name, opts: opts})
+	log.Printf("⏳ %s queued to join (#%d)", name, len(e.joinQueue))
+	return nil, len(e.joinQueue)
+}
+
+// JoinQueuePosition returns a viewer's place in the join queue (0 = not queued)
+func (e *Engine) JoinQueuePosition(name string) int {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.joinQueuePositionLocked(name)
+}
+
+// joinQueuePositionLocked returns a viewer's place in line. Caller holds e.mu.
+func (e *Engine) joinQueuePositionLocked(name string) int {
+	for i, q := range e.joinQueue {
+		if q.name == name {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// updateJoinQueue admits queued viewers in order as slots free up: empty
+// slots first, then bots' slots, then those of viewers who stayed dead past
+// the timeout. Caller holds e.mu.
+func (e *Engine) updateJoinQueue(deltaTime float64) {
+	for _, p := range e.players {
+		if p.IsDead {
+			p.deadTime += deltaTime
+		}
+	}
+
+	for len(e.joinQueue) > 0 {
+		if len(e.players) >= e.limits.MaxTotalPlayers && !e.evictBotLocked() && !e.evictDeadViewerLocked() {
+			return
+		}
+
+		next := e.joinQueue[0]
+		e.joinQueue = e.joinQueue[1:]
+		if e.addPlayerLocked(next.name, next.opts) == nil {
+			continue // Kicked by a moderator while waiting
+		}
+		if e.OnJoinQueueAdmit != nil {
+			go e.OnJoinQueueAdmit(next.name)
+		}
+	}
+}
+
+// evictDeadViewerLocked frees the slot of the viewer dead the longest, if past
+// the timeout. Returns false if nobody qualifies. Caller holds e.mu.
+func (e *Engine) evictDeadViewerLocked() bool {
+	var victim *Player
+	for _, p := range e.players {
+		if !p.IsDead || p.IsBot || p.IsBoss || p.deadTime < e.joinQueueCfg.DeadTimeout {
+			continue
+		}
+		if victim == nil || p.deadTime > victim.deadTime {
+			victim = p
+		}
+	}
+	if victim == nil {
+		return false
+	}
+	delete(e.players, victim.Name)
+	e.rosterVersion++
+	log.Printf("⏳ %s was dead for %.0fs, freeing their slot for the join queue", victim.Name, victim.deadTime)
+	return true
+}
+
+// joinQueueSnapshot returns the "Up next" line. Caller holds e.mu.
+func (e *Engine) joinQueueSnapshot() JoinQueueSnapshot {
+	snap := JoinQueueSnapshot{Total: len(e.joinQueue)}
+	for i := 0; i < len(e.joinQueue) && i < JoinQueueShown; i++ {
+		snap.Names[i] = DisplayName(e.joinQueue[i].name)
+		snap.Count++
+	}
+	return snap
+}
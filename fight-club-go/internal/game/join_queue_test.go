@@ -0,0 +1,87 @@
+package game
+
+import (
+	"testing"
+	"time"
+)
+
+// TestJoinQueue verifies viewers wait in order when the arena is full and get
+// in as slots free up
+func TestJoinQueue(t *testing.T) {
+	engine := newTestEngine(30)
+	engine.arenaBotEnabled = false
+	engine.limits.MaxTotalPlayers = 2
+	engine.joinQueueCfg = JoinQueueConfig{MaxSize: 2, DeadTimeout: 5}
+	admitted := make(chan string, 10)
+	engine.OnJoinQueueAdmit = func(name string) { admitted <- name }
+
+	engine.AddPlayer("ana", PlayerOptions{})
+	leo := engine.AddPlayer("leo", PlayerOptions{})
+	for i, name := range []string{"mia", "zoe"} {
+		if p, pos := engine.JoinOrQueue(name, PlayerOptions{}); p != nil || pos != i+1 {
+			t.Fatalf("Expected %s queued at #%d, got player %v at #%d", name, i+1, p, pos)
+		}
+	}
+	if _, pos := engine.JoinOrQueue("mia", PlayerOptions{}); pos != 1 {
+		t.Errorf("Expected a repeated !join to keep mia's place, got #%d", pos)
+	}
+	if p, pos := engine.JoinOrQueue("max", PlayerOptions{}); p != nil || pos != 0 {
+		t.Errorf("Expected a full queue to reject max, got player %v at #%d", p, pos)
+	}
+	if q := engine.joinQueueSnapshot(); q.Total != 2 || q.Count != 2 || q.Names[0] != "mia" {
+		t.Errorf("Expected mia then zoe up next, got %+v", q)
+	}
+
+	// A dead fighter can still respawn into their own slot
+	leo.die(nil)
+	if p, _ := engine.JoinOrQueue("leo", PlayerOptions{}); p != leo {
+		t.Fatal("Expected leo to keep their slot")
+	}
+	if leo.IsDead {
+		t.Error("Expected leo respawned")
+	}
+
+	// Once dead past the timeout, the first viewer in line takes the slot
+	leo.die(nil)
+	engine.mu.Lock()
+	engine.updateJoinQueue(4)
+	if engine.players["mia"] != nil {
+		t.Error("Expected leo's slot kept before the timeout")
+	}
+	engine.updateJoinQueue(2)
+	engine.mu.Unlock()
+	if engine.GetPlayer("mia") == nil || engine.GetPlayer("leo") != nil {
+		t.Fatal("Expected mia in leo's slot")
+	}
+	if pos := engine.JoinQueuePosition("zoe"); pos != 1 {
+		t.Errorf("Expected zoe first in line, got #%d", pos)
+	}
+	select {
+	case name := <-admitted:
+		if name != "mia" {
+			t.Errorf("Expected mia notified, got %s", name)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected mia notified")
+	}
+
+	// Newcomers queue behind zoe even when a slot is free
+	engine.RemovePlayer("ana")
+	if _, pos := engine.JoinOrQueue("max", PlayerOptions{}); pos != 2 {
+		t.Errorf("Expected max behind zoe, got #%d", pos)
+	}
+	engine.tick()
+	if engine.GetPlayer("zoe") == nil || engine.GetPlayer("max") != nil {
+		t.Error("Expected zoe admitted before max")
+	}
+}
+
+// TestJoinQueueDisabled verifies a full arena rejects joins without a queue
+func TestJoinQueueDisabled(t *testing.T) {
+	engine := newTestEngine(30)
+	engine.limits.MaxTotalPlayers = 1
+	engine.AddPlayer("ana", PlayerOptions{})
+	if p, pos := engine.JoinOrQueue("leo", PlayerOptions{}); p != nil || pos != 0 {
+		t.Errorf("Expected leo rejected, got player %v at #%d", p, pos)
+	}
+}
@@ -0,0 +1,122 @@
+package game
+
+import (
+	"log"
+	"time"
+
+	"fight-club/internal/config"
+)
+
+// LagCompensationConfig is an alias for config.LagCompensationConfig (SSOT)
+type LagCompensationConfig = config.LagCompensationConfig
+
+// HealResult is the outcome of a timestamped heal command
+type HealResult int
+
+const (
+	HealRejected    HealResult = iota // Not in game, or dead for good
+	HealApplied                       // Alive: healed now
+	HealRetroactive                   // Died after the command was sent: the heal outlasted the killing blow
+)
+
+// lagRevivalProtection is the spawn protection given to a fighter saved by a late heal (seconds)
+const lagRevivalProtection = 0.5
+
+// recentDeath is the killing blow of a fighter who died within the grace window
+type recentDeath struct {
+	At       time.Time
+	HPBefore int // HP right before the killing blow
+	Damage   int // Damage of the killing blow
+	Killer   string
+}
+
+// recordDeath remembers a killing blow so a late !heal can revive the victim. Caller holds e.mu.
+// Duel deaths and ring-outs are final (the duel resolves on the next tick; no
+// heal pulls a fighter out of the pit).
+func (e *Engine) recordDeath(victim *Player, hpBefore int, killer *Player) {
+	if e.lagComp.GraceWindow <= 0 || victim.InDuel || victim.RingOut {
+		return
+	}
+
+	now := time.Now()
+	grace := time.Duration(e.lagComp.GraceWindow * float64(time.Second))
+	for name, d := range e.recentDeaths {
+		if now.Sub(d.At) > grace {
+			delete(e.recentDeaths, name)
+		}
+	}
+
+	d := recentDeath{At: now, HPBefore: hpBefore, Damage: hpBefore - victim.HP}
+	if killer != nil {
+		d.Killer = killer.Name
+	}
+	e.recentDeaths[victim.Name] = d
+}
+
+// HealPlayerAt heals a player for a command sent at sentAt (webhook receipt).
+// A fighter who died after sentAt, within the grace window, is revived with the
+// HP they would have had if the heal landed before the killing blow. The kill
+// and the death stand: both were already announced and recorded (career
+// stats, rating, history, quests), so the revival only restores the fighter.
+func (e *Engine) HealPlayerAt(name string, amount int, sentAt time.Time) HealResult {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	player, ok := e.players[name]
+	if !ok {
+		return HealRejected
+	}
+
+	if !player.IsDead {
+		player.Heal(amount)
+		e.eventLog.EmitSimple(EventTypeHeal, uint64(e.tickCount), player.ID,
+			HealPayload{PlayerID: player.ID, Amount: amount, CurrentHP: player.HP})
+		return HealApplied
+	}
+
+	death, ok := e.recentDeaths[name]
+	if !ok || sentAt.IsZero() || sentAt.After(death.At) {
+		return HealRejected
+	}
+	if time.Since(death.At) > time.Duration(e.lagComp.GraceWindow*float64(time.Second)) {
+		return HealRejected
+	}
+
+	hp := min(player.MaxHP, death.HPBefore+amount) - death.Damage
+	if hp <= 0 {
+		return HealRejected // The heal wouldn't have survived the hit either
+	}
+
+	delete(e.recentDeaths, name)
+	player.revive(hp)
+	e.reclaimLoot(player)
+	e.reclaimWeapon(player)
+
+	latency := death.At.Sub(sentAt)
+	log.Printf("⏪ %s saved by a late !heal (sent %.0fms before %s's killing blow, HP %d)",
+		name, float64(latency.Microseconds())/1000, death.Killer, hp)
+	e.eventLog.EmitSimple(EventTypeHeal, uint64(e.tickCount), player.ID,
+		HealPayload{
+			PlayerID:    player.ID,
+			Amount:      amount,
+			CurrentHP:   hp,
+			Retroactive: true,
+			LatencyMs:   latency.Milliseconds(),
+		})
+	return HealRetroactive
+}
+
+// revive brings a fighter back in place (no respawn position, brief protection).
+// The death stays counted, like everywhere else it was recorded.
+func (p *Player) revive(hp int) {
+	p.IsDead = false
+	p.IsRagdoll = false
+	p.State = StateAlive
+	p.HP = hp
+	p.RagdollRotation = 0
+	p.RagdollTimer = 0
+	p.VX = 0
+	p.VY = 0
+	p.SpawnProtection = true
+	p.SpawnTimer = lagRevivalProtection
+}
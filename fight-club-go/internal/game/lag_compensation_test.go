@@ -0,0 +1,112 @@
+package game
+
+import (
+	"testing"
+	"time"
+)
+
+// newLagCompEngine creates a test engine with a 0.5s heal grace window
+func newLagCompEngine() *Engine {
+	return newTestEngineWith(func(cfg *EngineConfig) {
+		cfg.LagComp = LagCompensationConfig{GraceWindow: 0.5}
+	})
+}
+
+// killWith lands a killing blow of the given damage on victim, as the combat code does
+func killWith(t *testing.T, e *Engine, victim, killer *Player, damage int) {
+	t.Helper()
+	victim.SpawnProtection = false
+	hpBefore := victim.HP
+	victim.TakeDamage(damage, killer)
+	if !victim.IsDead {
+		t.Fatalf("Expected a killing blow, %s has %d HP left", victim.Name, victim.HP)
+	}
+	e.recordDeath(victim, hpBefore, killer)
+}
+
+// TestLateHealRevives verifies a heal sent before the killing blow revives the fighter
+func TestLateHealRevives(t *testing.T) {
+	engine := newLagCompEngine()
+	alice := engine.AddPlayer("alice", PlayerOptions{})
+	bob := engine.AddPlayer("bob", PlayerOptions{})
+
+	sentAt := time.Now()
+	alice.HP = 15
+	killWith(t, engine, alice, bob, 25)
+	if alice.Deaths != 1 {
+		t.Fatalf("Expected a recorded death, got %d", alice.Deaths)
+	}
+
+	// 15 HP + 20 heal - 25 damage = 10 HP
+	if res := engine.HealPlayerAt("alice", 20, sentAt); res != HealRetroactive {
+		t.Fatalf("Expected a retroactive heal, got %v", res)
+	}
+	if alice.IsDead || alice.HP != 10 || alice.Deaths != 1 || !alice.SpawnProtection {
+		t.Errorf("Expected alice alive at 10 HP with her death still counted, got dead=%v hp=%d deaths=%d",
+			alice.IsDead, alice.HP, alice.Deaths)
+	}
+	if _, ok := engine.recentDeaths["alice"]; ok {
+		t.Error("Expected the death consumed by the save")
+	}
+}
+
+// TestLateHealRejected covers heals that must not revive a fighter
+func TestLateHealRejected(t *testing.T) {
+	engine := newLagCompEngine()
+	bob := engine.AddPlayer("bob", PlayerOptions{})
+
+	t.Run("sent after death", func(t *testing.T) {
+		p := engine.AddPlayer("after", PlayerOptions{})
+		p.HP = 10
+		killWith(t, engine, p, bob, 15)
+		if res := engine.HealPlayerAt("after", 20, time.Now().Add(time.Millisecond)); res != HealRejected || !p.IsDead {
+			t.Errorf("Expected rejection, got %v", res)
+		}
+	})
+
+	t.Run("grace expired", func(t *testing.T) {
+		p := engine.AddPlayer("late", PlayerOptions{})
+		sentAt := time.Now().Add(-time.Second)
+		p.HP = 10
+		killWith(t, engine, p, bob, 15)
+		d := engine.recentDeaths["late"]
+		d.At = time.Now().Add(-600 * time.Millisecond)
+		engine.recentDeaths["late"] = d
+		if res := engine.HealPlayerAt("late", 20, sentAt); res != HealRejected || !p.IsDead {
+			t.Errorf("Expected rejection, got %v", res)
+		}
+	})
+
+	t.Run("heal too small", func(t *testing.T) {
+		p := engine.AddPlayer("overkill", PlayerOptions{})
+		sentAt := time.Now()
+		p.HP = 10
+		killWith(t, engine, p, bob, 40)
+		if res := engine.HealPlayerAt("overkill", 20, sentAt); res != HealRejected || !p.IsDead {
+			t.Errorf("Expected rejection, got %v", res)
+		}
+	})
+
+	t.Run("duel death", func(t *testing.T) {
+		p := engine.AddPlayer("duelist", PlayerOptions{})
+		sentAt := time.Now()
+		p.InDuel = true
+		p.HP = 10
+		killWith(t, engine, p, bob, 15)
+		if res := engine.HealPlayerAt("duelist", 20, sentAt); res != HealRejected || !p.IsDead {
+			t.Errorf("Expected rejection, got %v", res)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		engine := newTestEngine(30)
+		bob := engine.AddPlayer("bob", PlayerOptions{})
+		p := engine.AddPlayer("alice", PlayerOptions{})
+		sentAt := time.Now()
+		p.HP = 10
+		killWith(t, engine, p, bob, 15)
+		if res := engine.HealPlayerAt("alice", 20, sentAt); res != HealRejected {
+			t.Errorf("Expected rejection with no grace window, got %v", res)
+		}
+	})
+}
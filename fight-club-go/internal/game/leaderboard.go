@@ -1,189 +1,340 @@
 package game
 
 import (
-	"fight-club/internal/game/spatial"
+	"encoding/json"
+	"log"
+	"os"
+	"sort"
+	"strings"
 	"sync"
+	"time"
+
+	"fight-club/internal/config"
 )
 
-// Leaderboard provides O(log n) leaderboard operations using a skip list
-// Supports real-time updates, rank queries, and range queries
-//
-// Operations:
-//   - UpdateScore: O(log n)
-//   - GetRank: O(log n)
-//   - GetTop: O(log n + k)
-//   - GetAroundPlayer: O(log n + k)
-type Leaderboard struct {
-	skipList *spatial.SkipList
-	mu       sync.RWMutex // For batch operations
+// LeaderboardConfig is an alias for config.LeaderboardConfig (SSOT)
+type LeaderboardConfig = config.LeaderboardConfig
+
+// LeaderboardPeriod is a persistent leaderboard time window
+type LeaderboardPeriod string
+
+const (
+	PeriodToday   LeaderboardPeriod = "today"
+	PeriodWeek    LeaderboardPeriod = "week" // Since Monday (local time)
+	PeriodAllTime LeaderboardPeriod = "alltime"
+)
+
+// LeaderboardPeriods is the on-stream rotation order
+var LeaderboardPeriods = []LeaderboardPeriod{PeriodToday, PeriodWeek, PeriodAllTime}
+
+// Title returns the on-stream heading for a period
+func (p LeaderboardPeriod) Title() string {
+	switch p {
+	case PeriodToday:
+		return "TODAY"
+	case PeriodWeek:
+		return "THIS WEEK"
+	case PeriodAllTime:
+		return "ALL TIME"
+	}
+	return strings.ToUpper(string(p))
+}
+
+// ParseLeaderboardPeriod validates a period name
+func ParseLeaderboardPeriod(s string) (LeaderboardPeriod, bool) {
+	for _, p := range LeaderboardPeriods {
+		if string(p) == s {
+			return p, true
+		}
+	}
+	return "", false
 }
 
-// LeaderboardEntry represents a player in the leaderboard
+// LeaderboardEntry is one ranked viewer in a persistent leaderboard
 type LeaderboardEntry struct {
-	PlayerID string
-	Kills    int
-	Deaths   int
-	Score    float64 // Computed score for ranking
-	Rank     int
+	Rank     int    `json:"rank"`
+	Username string `json:"username"`
+	Kills    int    `json:"kills"`
 }
 
-// NewLeaderboard creates a new leaderboard
-func NewLeaderboard() *Leaderboard {
-	return &Leaderboard{
-		skipList: spatial.NewSkipList(),
-	}
+// LeaderboardRotatorSize is the number of entries shown by the on-stream rotator
+const LeaderboardRotatorSize = 5
+
+// LeaderboardSnapshot is the persistent leaderboard view currently on stream
+type LeaderboardSnapshot struct {
+	Period LeaderboardPeriod // "" = rotator hidden
+	Names  [LeaderboardRotatorSize]string
+	Kills  [LeaderboardRotatorSize]int
+	Count  int
 }
 
-// UpdatePlayer updates a player's score in the leaderboard
-// Score is computed as: kills * 100 - deaths * 10 (can be customized)
-// O(log n) time complexity
-func (lb *Leaderboard) UpdatePlayer(playerID string, kills, deaths int) {
-	// Compute score (higher is better)
-	// Using kills as primary factor, deaths as penalty
-	score := float64(kills)*100.0 - float64(deaths)*10.0
+// leaderboardDayFormat keys persisted kills by local calendar day
+const leaderboardDayFormat = "2006-01-02"
+
+// leaderboardSaveInterval is how often dirty kill counts are flushed to disk
+const leaderboardSaveInterval = 30 * time.Second
 
-	lb.skipList.Insert(playerID, score)
+// leaderboardFile is the persisted JSON layout
+type leaderboardFile struct {
+	Days map[string]map[string]int `json:"days"` // Day -> username -> kills
 }
 
-// UpdateScore updates a player's score directly
-// O(log n) time complexity
-func (lb *Leaderboard) UpdateScore(playerID string, score float64) {
-	lb.skipList.Insert(playerID, score)
+// LeaderboardStore persists kills per (viewer, day) so leaderboards survive
+// restarts, and serves today / this week / all-time rankings from them.
+type LeaderboardStore struct {
+	mu          sync.RWMutex
+	days        map[string]map[string]int // Day -> username -> kills
+	allTime     map[string]int            // Username -> kills (derived from days)
+	cfg         LeaderboardConfig
+	dirty       bool
+	saveBlocked bool // The file didn't parse and couldn't be moved aside
+
+	// Cached on-stream view (rankings only change on kills, the period every RotateInterval)
+	rotation   LeaderboardSnapshot
+	rotationAt time.Time
+
+	// All-time kill record
+	recordHolder string
+	recordKills  int
+
+	// OnNewRecord is called when a viewer takes the all-time kill lead from someone else
+	OnNewRecord func(username string, kills int, previousHolder string, previousKills int)
+
+	running bool
+	stopCh  chan struct{}
+	wg      sync.WaitGroup
 }
 
-// RemovePlayer removes a player from the leaderboard
-// O(log n) time complexity
-func (lb *Leaderboard) RemovePlayer(playerID string) {
-	lb.skipList.Remove(playerID)
+// NewLeaderboardStore creates a leaderboard store (File "" = in-memory only)
+func NewLeaderboardStore(cfg LeaderboardConfig) *LeaderboardStore {
+	return &LeaderboardStore{
+		days:    make(map[string]map[string]int),
+		allTime: make(map[string]int),
+		cfg:     cfg,
+		stopCh:  make(chan struct{}),
+	}
 }
 
-// GetRank returns a player's rank (1-indexed, 1 = top)
-// Returns 0 if player not found
-// O(log n) time complexity
-func (lb *Leaderboard) GetRank(playerID string) int {
-	return lb.skipList.GetRank(playerID)
+// Start loads persisted kills and begins periodic saving
+func (ls *LeaderboardStore) Start() {
+	ls.mu.Lock()
+	if ls.running {
+		ls.mu.Unlock()
+		return
+	}
+	ls.running = true
+	ls.mu.Unlock()
+
+	ls.load()
+
+	ls.wg.Add(1)
+	go ls.saveLoop()
 }
 
-// GetScore returns a player's score
-// Returns (score, true) if found, (0, false) if not
-// O(log n) time complexity
-func (lb *Leaderboard) GetScore(playerID string) (float64, bool) {
-	return lb.skipList.GetScore(playerID)
+// Stop stops periodic saving and flushes kills to disk
+func (ls *LeaderboardStore) Stop() {
+	ls.mu.Lock()
+	if !ls.running {
+		ls.mu.Unlock()
+		return
+	}
+	ls.running = false
+	ls.mu.Unlock()
+
+	close(ls.stopCh)
+	ls.wg.Wait()
+	ls.save()
 }
 
-// GetTop returns the top N players
-// O(log n + k) where k is the number of players to return
-func (lb *Leaderboard) GetTop(n int) []LeaderboardEntry {
-	entries := lb.skipList.GetRange(1, n)
-	result := make([]LeaderboardEntry, len(entries))
+// RecordKill credits a kill to a viewer on the given day
+func (ls *LeaderboardStore) RecordKill(username string, now time.Time) {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
 
-	for i, e := range entries {
-		result[i] = LeaderboardEntry{
-			PlayerID: e.Key,
-			Score:    e.Score,
-			Rank:     i + 1,
+	day := now.Format(leaderboardDayFormat)
+	kills, ok := ls.days[day]
+	if !ok {
+		kills = make(map[string]int)
+		ls.days[day] = kills
+	}
+	kills[username]++
+	ls.allTime[username]++
+	ls.dirty = true
+	ls.rotationAt = time.Time{} // Invalidate the on-stream view
+
+	// New all-time leader (a holder extending their own record isn't news)
+	if total := ls.allTime[username]; total > ls.recordKills {
+		previousHolder, previousKills := ls.recordHolder, ls.recordKills
+		ls.recordHolder, ls.recordKills = username, total
+		if previousHolder != username && previousKills > 0 && ls.OnNewRecord != nil {
+			go ls.OnNewRecord(username, total, previousHolder, previousKills)
 		}
 	}
+}
 
-	return result
+// Top returns the best viewers for a period, ranked by kills (ties by name)
+func (ls *LeaderboardStore) Top(period LeaderboardPeriod, now time.Time, limit int) []LeaderboardEntry {
+	ls.mu.RLock()
+	defer ls.mu.RUnlock()
+	return ls.topLocked(period, now, limit)
 }
 
-// GetAtRank returns the player at a specific rank
-// O(log n) time complexity
-func (lb *Leaderboard) GetAtRank(rank int) *LeaderboardEntry {
-	entry := lb.skipList.GetByRank(rank)
-	if entry == nil {
-		return nil
+// topLocked ranks viewers for a period (caller holds lock)
+func (ls *LeaderboardStore) topLocked(period LeaderboardPeriod, now time.Time, limit int) []LeaderboardEntry {
+	totals := make(map[string]int)
+	switch period {
+	case PeriodToday:
+		for name, k := range ls.days[now.Format(leaderboardDayFormat)] {
+			totals[name] += k
+		}
+	case PeriodWeek:
+		// ISO week: Monday through today
+		sinceMonday := (int(now.Weekday()) + 6) % 7
+		for d := 0; d <= sinceMonday; d++ {
+			day := now.AddDate(0, 0, -d).Format(leaderboardDayFormat)
+			for name, k := range ls.days[day] {
+				totals[name] += k
+			}
+		}
+	case PeriodAllTime:
+		totals = ls.allTime
 	}
 
-	return &LeaderboardEntry{
-		PlayerID: entry.Key,
-		Score:    entry.Score,
-		Rank:     rank,
+	entries := make([]LeaderboardEntry, 0, len(totals))
+	for name, k := range totals {
+		if k > 0 {
+			entries = append(entries, LeaderboardEntry{Username: name, Kills: k})
+		}
 	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Kills != entries[j].Kills {
+			return entries[i].Kills > entries[j].Kills
+		}
+		return entries[i].Username < entries[j].Username
+	})
+	if limit > 0 && len(entries) > limit {
+		entries = entries[:limit]
+	}
+	for i := range entries {
+		entries[i].Rank = i + 1
+	}
+	return entries
 }
 
-// GetAroundPlayer returns players around a specific player
-// Returns `above` players ranked higher, the player, and `below` players ranked lower
-// O(log n + k) time complexity
-func (lb *Leaderboard) GetAroundPlayer(playerID string, above, below int) []LeaderboardEntry {
-	rank := lb.skipList.GetRank(playerID)
-	if rank == 0 {
-		return nil // Player not found
+// RotationPeriod returns the period on stream at a given time.
+// Views cycle every RotateInterval seconds; "" if the rotator is disabled.
+func (ls *LeaderboardStore) RotationPeriod(now time.Time) LeaderboardPeriod {
+	if ls.cfg.RotateInterval <= 0 {
+		return ""
 	}
+	slot := int64(float64(now.UnixMilli()) / (ls.cfg.RotateInterval * 1000))
+	return LeaderboardPeriods[slot%int64(len(LeaderboardPeriods))]
+}
 
-	start := rank - above
-	if start < 1 {
-		start = 1
+// Rotation returns the on-stream leaderboard view.
+// Called every snapshot, so rankings are recomputed at most once per second.
+func (ls *LeaderboardStore) Rotation(now time.Time) LeaderboardSnapshot {
+	period := ls.RotationPeriod(now)
+	if period == "" {
+		return LeaderboardSnapshot{}
 	}
-	end := rank + below
 
-	entries := lb.skipList.GetRange(start, end)
-	result := make([]LeaderboardEntry, len(entries))
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
 
-	currentRank := start
-	for i, e := range entries {
-		result[i] = LeaderboardEntry{
-			PlayerID: e.Key,
-			Score:    e.Score,
-			Rank:     currentRank,
-		}
-		currentRank++
+	if !ls.rotationAt.IsZero() && ls.rotation.Period == period && now.Sub(ls.rotationAt) < time.Second {
+		return ls.rotation
 	}
 
-	return result
+	view := LeaderboardSnapshot{Period: period}
+	for i, entry := range ls.topLocked(period, now, LeaderboardRotatorSize) {
+		view.Names[i] = DisplayName(entry.Username)
+		view.Kills[i] = entry.Kills
+		view.Count++
+	}
+	ls.rotation = view
+	ls.rotationAt = now
+	return view
 }
 
-// GetRange returns players in the specified rank range (1-indexed, inclusive)
-// O(log n + k) time complexity
-func (lb *Leaderboard) GetRange(start, end int) []LeaderboardEntry {
-	entries := lb.skipList.GetRange(start, end)
-	result := make([]LeaderboardEntry, len(entries))
+// saveLoop flushes kills to disk every leaderboardSaveInterval
+func (ls *LeaderboardStore) saveLoop() {
+	defer ls.wg.Done()
 
-	currentRank := start
-	for i, e := range entries {
-		result[i] = LeaderboardEntry{
-			PlayerID: e.Key,
-			Score:    e.Score,
-			Rank:     currentRank,
+	ticker := time.NewTicker(leaderboardSaveInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ls.stopCh:
+			return
+		case <-ticker.C:
+			ls.save()
 		}
-		currentRank++
 	}
-
-	return result
 }
 
-// Length returns the number of players in the leaderboard
-// O(1) time complexity
-func (lb *Leaderboard) Length() int {
-	return lb.skipList.Length()
-}
+// save persists kills to disk if anything changed
+func (ls *LeaderboardStore) save() {
+	ls.mu.Lock()
+	if !ls.dirty || ls.cfg.File == "" || ls.saveBlocked {
+		ls.mu.Unlock()
+		return
+	}
+	data, err := json.MarshalIndent(leaderboardFile{Days: ls.days}, "", "  ")
+	ls.dirty = false
+	ls.mu.Unlock()
+
+	if err != nil {
+		log.Printf("⚠️ Failed to marshal leaderboard: %v", err)
+		return
+	}
 
-// Clear removes all players from the leaderboard
-func (lb *Leaderboard) Clear() {
-	lb.skipList.Clear()
+	if err := WriteFileAtomic(ls.cfg.File, data, 0600); err != nil {
+		log.Printf("⚠️ Failed to save leaderboard: %v", err)
+	}
 }
 
-// BatchUpdate updates multiple players efficiently
-// Holds lock during entire batch to ensure consistency
-func (lb *Leaderboard) BatchUpdate(updates map[string]float64) {
-	lb.mu.Lock()
-	defer lb.mu.Unlock()
+// load restores persisted kills from disk
+func (ls *LeaderboardStore) load() {
+	if ls.cfg.File == "" {
+		return
+	}
 
-	for playerID, score := range updates {
-		lb.skipList.Insert(playerID, score)
+	data, err := os.ReadFile(ls.cfg.File)
+	if err != nil {
+		return // No saved leaderboard
 	}
-}
 
-// ForEach iterates over all players in rank order
-// The callback receives rank (1-indexed) and entry
-// Return false from callback to stop iteration
-func (lb *Leaderboard) ForEach(fn func(rank int, entry LeaderboardEntry) bool) {
-	lb.skipList.ForEach(func(rank int, e spatial.SkipListEntry) bool {
-		return fn(rank, LeaderboardEntry{
-			PlayerID: e.Key,
-			Score:    e.Score,
-			Rank:     rank,
-		})
-	})
+	var file leaderboardFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		if !setAsideCorrupt(ls.cfg.File, "leaderboard", err) {
+			ls.mu.Lock()
+			ls.saveBlocked = true
+			ls.mu.Unlock()
+		}
+		return
+	}
+
+	ls.mu.Lock()
+	for day, kills := range file.Days {
+		merged, ok := ls.days[day]
+		if !ok {
+			merged = make(map[string]int)
+			ls.days[day] = merged
+		}
+		for name, k := range kills {
+			merged[name] += k
+			ls.allTime[name] += k
+		}
+	}
+	for name, k := range ls.allTime {
+		if k > ls.recordKills || (k == ls.recordKills && name < ls.recordHolder) {
+			ls.recordHolder, ls.recordKills = name, k
+		}
+	}
+	ls.rotationAt = time.Time{}
+	ls.mu.Unlock()
+
+	log.Printf("📂 Loaded leaderboard: %d days of kills", len(file.Days))
 }
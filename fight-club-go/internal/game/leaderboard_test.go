@@ -0,0 +1,148 @@
+package game
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTestLeaderboardStore(t *testing.T) *LeaderboardStore {
+	cfg := DefaultLeaderboard
+	cfg.File = filepath.Join(t.TempDir(), "leaderboard.json")
+	return NewLeaderboardStore(cfg)
+}
+
+// TestLeaderboardPeriods verifies today/week/all-time windows over per-day kills
+func TestLeaderboardPeriods(t *testing.T) {
+	ls := newTestLeaderboardStore(t)
+
+	// Wednesday 2026-10-14; the week started Monday 2026-10-12
+	now := time.Date(2026, 10, 14, 20, 0, 0, 0, time.Local)
+	lastWeek := now.AddDate(0, 0, -3) // Sunday
+	monday := now.AddDate(0, 0, -2)
+
+	for i := 0; i < 5; i++ {
+		ls.RecordKill("veteran", lastWeek)
+	}
+	ls.RecordKill("weekly", monday)
+	ls.RecordKill("weekly", monday)
+	ls.RecordKill("daily", now)
+
+	top := func(p LeaderboardPeriod) []LeaderboardEntry { return ls.Top(p, now, 10) }
+
+	if today := top(PeriodToday); len(today) != 1 || today[0].Username != "daily" {
+		t.Errorf("Expected only today's killer, got %v", today)
+	}
+	week := top(PeriodWeek)
+	if len(week) != 2 || week[0].Username != "weekly" || week[0].Kills != 2 || week[1].Rank != 2 {
+		t.Errorf("Expected weekly then daily (Monday start), got %v", week)
+	}
+	if all := top(PeriodAllTime); len(all) != 3 || all[0].Username != "veteran" || all[0].Kills != 5 {
+		t.Errorf("Expected veteran on top all time, got %v", all)
+	}
+	if limited := ls.Top(PeriodAllTime, now, 1); len(limited) != 1 {
+		t.Errorf("Expected limit to apply, got %d entries", len(limited))
+	}
+}
+
+// TestLeaderboardRotation verifies the on-stream view cycles every RotateInterval
+func TestLeaderboardRotation(t *testing.T) {
+	ls := newTestLeaderboardStore(t)
+	start := time.Unix(0, 0)
+	ls.RecordKill("fighter", start)
+
+	for i, want := range []LeaderboardPeriod{PeriodToday, PeriodWeek, PeriodAllTime, PeriodToday} {
+		now := start.Add(time.Duration(float64(i)*ls.cfg.RotateInterval) * time.Second)
+		if got := ls.Rotation(now).Period; got != want {
+			t.Errorf("Slot %d: expected %s, got %s", i, want, got)
+		}
+	}
+
+	view := ls.Rotation(start)
+	if view.Count != 1 || view.Names[0] != "fighter" || view.Kills[0] != 1 {
+		t.Errorf("Expected fighter in the rotator, got %+v", view)
+	}
+
+	// New kills show up right away
+	ls.RecordKill("fighter", start)
+	if view := ls.Rotation(start); view.Kills[0] != 2 {
+		t.Errorf("Expected rotator to refresh after a kill, got %d", view.Kills[0])
+	}
+
+	ls.cfg.RotateInterval = 0
+	if view := ls.Rotation(start); view.Period != "" {
+		t.Errorf("Expected rotator hidden when disabled, got %s", view.Period)
+	}
+}
+
+// TestLeaderboardPersistence verifies kills survive a save/load round trip
+func TestLeaderboardPersistence(t *testing.T) {
+	ls := newTestLeaderboardStore(t)
+	now := time.Now()
+	ls.RecordKill("saver", now)
+	ls.RecordKill("saver", now.AddDate(0, 0, -30))
+	ls.save()
+
+	restored := NewLeaderboardStore(ls.cfg)
+	restored.load()
+	if all := restored.Top(PeriodAllTime, now, 10); len(all) != 1 || all[0].Kills != 2 {
+		t.Errorf("Expected 2 restored all-time kills, got %v", all)
+	}
+	if today := restored.Top(PeriodToday, now, 10); len(today) != 1 || today[0].Kills != 1 {
+		t.Errorf("Expected 1 restored kill today, got %v", today)
+	}
+}
+
+// TestLeaderboardNewRecord verifies the record callback fires only when the all-time lead changes hands
+func TestLeaderboardNewRecord(t *testing.T) {
+	ls := newTestLeaderboardStore(t)
+	ls.RecordKill("champ", time.Now())
+	ls.RecordKill("champ", time.Now())
+	ls.save()
+
+	// Record holder is restored from disk
+	restored := NewLeaderboardStore(ls.cfg)
+	restored.load()
+
+	records := make(chan string, 4)
+	restored.OnNewRecord = func(username string, kills int, previousHolder string, previousKills int) {
+		records <- fmt.Sprintf("%s:%d>%s:%d", username, kills, previousHolder, previousKills)
+	}
+
+	now := time.Now()
+	restored.RecordKill("champ", now) // Extending own record
+	for i := 0; i < 3; i++ {
+		restored.RecordKill("rival", now) // Ties at 3, no record yet
+	}
+	restored.RecordKill("rival", now) // 4 > 3
+
+	select {
+	case got := <-records:
+		if got != "rival:4>champ:3" {
+			t.Errorf("Expected rival to pass champ, got %s", got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected a new record callback")
+	}
+	select {
+	case got := <-records:
+		t.Errorf("Expected a single record callback, also got %s", got)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+// TestEngineKillRecordsLeaderboard verifies viewer kills are persisted and arena bot kills are not
+func TestEngineKillRecordsLeaderboard(t *testing.T) {
+	engine := newTestEngine(30)
+	killer := engine.AddPlayer("killer", PlayerOptions{})
+	bot := engine.AddPlayer(engine.arenaBotName, PlayerOptions{})
+
+	engine.recordLeaderboardKill(killer)
+	engine.recordLeaderboardKill(bot)
+
+	all := engine.GetLeaderboardStore().Top(PeriodAllTime, time.Now(), 10)
+	if len(all) != 1 || all[0].Username != "killer" {
+		t.Errorf("Expected only the viewer on the leaderboard, got %v", all)
+	}
+}
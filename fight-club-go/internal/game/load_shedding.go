@@ -0,0 +1,188 @@
+package game
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+// Load shedding levels: tick work is shed in this order as ticks approach
+// the tick budget, and restored in reverse once they fit again
+const (
+	ShedNone       = iota // Full simulation
+	ShedFlowFields        // Skip flow field refreshes (far chasers follow the last field)
+	ShedParticles         // Also halve particle spawns
+	ShedFarAI             // Also re-decide targets of far-away fighters every few ticks
+)
+
+// shedLevelNames are the stats/log names of the load shedding levels
+var shedLevelNames = [...]string{"none", "flowfields", "particles", "farai"}
+
+// Load shedding tuning
+const (
+	loadShedSmoothing = 0.1 // EWMA weight of the newest tick time
+	loadShedOver      = 0.8 // Average above this share of the budget is near overload
+	loadShedUnder     = 0.5 // Average below this share of the budget has headroom
+	loadShedDownAfter = 1.0 // Seconds of sustained load before shedding the next tier
+	loadShedUpAfter   = 5.0 // Seconds of sustained headroom before restoring a tier
+
+	farAIDistance = 400.0 // Fighters further than this from their target are "far away" (flow field range)
+	farAIInterval = 3     // Far-away fighters re-decide every this many ticks under ShedFarAI
+
+	flowFieldRefreshBudget = 8 // Flow fields regenerated per tick at most
+)
+
+// LoadShedStats summarizes the load shedding controller (admin dashboard)
+type LoadShedStats struct {
+	Level   string           `json:"level"`
+	Changes int64            `json:"changes"` // Level changes since start
+	Ticks   map[string]int64 `json:"ticks"`   // Ticks run at each level
+}
+
+// loadShedder is a hysteresis controller shedding tick work when ticks take
+// too much of the tick budget, so 200+ fighters slow the simulation down
+// gracefully instead of dropping ticks. Hysteresis counts ticks, so the
+// same tick times always shed the same way. It has its own lock so stats
+// can be read without the engine lock.
+type loadShedder struct {
+	mu         sync.Mutex
+	avg        float64 // Smoothed tick time (ms)
+	level      int
+	overTicks  int
+	underTicks int
+	changes    int64
+	ticks      [len(shedLevelNames)]int64
+}
+
+// Level returns the current load shedding level
+func (l *loadShedder) Level() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.level
+}
+
+// observe records a tick's duration against the budget.
+// Returns the new level and true when it changed.
+func (l *loadShedder) observe(d time.Duration, tickRate int) (int, bool) {
+	ms := float64(d) / float64(time.Millisecond)
+	budgetMs := 1000 / float64(tickRate)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.ticks[l.level]++
+	if l.avg == 0 {
+		l.avg = ms
+	} else {
+		l.avg += loadShedSmoothing * (ms - l.avg)
+	}
+
+	switch {
+	case l.avg > loadShedOver*budgetMs:
+		l.underTicks = 0
+		l.overTicks++
+		if float64(l.overTicks) >= loadShedDownAfter*float64(tickRate) && l.level < ShedFarAI {
+			l.level++
+			l.overTicks = 0
+			l.changes++
+			return l.level, true
+		}
+
+	case l.avg < loadShedUnder*budgetMs:
+		l.overTicks = 0
+		l.underTicks++
+		if float64(l.underTicks) >= loadShedUpAfter*float64(tickRate) && l.level > ShedNone {
+			l.level--
+			l.underTicks = 0
+			l.changes++
+			return l.level, true
+		}
+
+	default:
+		// Between the thresholds: no trend either way
+		l.overTicks = 0
+		l.underTicks = 0
+	}
+	return l.level, false
+}
+
+// stats returns the controller state for the tick stats
+func (l *loadShedder) stats() LoadShedStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	ticks := make(map[string]int64, len(shedLevelNames))
+	for i, name := range shedLevelNames {
+		ticks[name] = l.ticks[i]
+	}
+	return LoadShedStats{Level: shedLevelNames[l.level], Changes: l.changes, Ticks: ticks}
+}
+
+// observeLoad feeds a tick's duration to the load shedder and logs level changes
+func (e *Engine) observeLoad(d time.Duration) {
+	level, changed := e.shedder.observe(d, e.tickRate)
+	if !changed {
+		return
+	}
+	budget := time.Second / time.Duration(e.tickRate)
+	if level == ShedNone {
+		log.Printf("⏩ Game tick caught up (%s budget), running the full simulation", budget)
+		return
+	}
+	log.Printf("⏭️ Game tick near its %s budget with %d fighters, load shedding level: %s",
+		budget, len(e.players), shedLevelNames[level])
+}
+
+// shedParticles scales a particle spawn count down under ShedParticles
+// (at least one particle is kept). Caller holds e.mu.
+func (e *Engine) shedParticles(count int) int {
+	if e.shedLevel >= ShedParticles {
+		return (count + 1) / 2
+	}
+	return count
+}
+
+// deferRetarget reports whether a far-away fighter keeps its current target
+// this tick instead of searching again (ShedFarAI). Each fighter still
+// re-decides every farAIInterval ticks, staggered by its slot; fighters
+// close to the action, focused by chat or whose target died always do.
+// Caller holds e.mu.
+func (e *Engine) deferRetarget(p *Player, slot uint32) bool {
+	if e == nil || e.shedLevel < ShedFarAI || p.Target == nil || p.FocusTarget != "" {
+		return false
+	}
+	if t := p.Target; t.IsDead || t.IsRagdoll || p.distanceTo(t) < farAIDistance {
+		return false
+	}
+	return (e.tickCount+int64(slot))%farAIInterval != 0
+}
+
+// refreshFlowFields regenerates flow fields whose target moved a cell or
+// more (a few per tick) and, once a second, drops the fields of fighters who
+// died or left and of expired team regroups. Skipped under ShedFlowFields. Caller holds e.mu.
+func (e *Engine) refreshFlowFields() {
+	if e.shedLevel >= ShedFlowFields || e.flowFieldManager.Len() == 0 {
+		return
+	}
+
+	budget := flowFieldRefreshBudget
+	for _, p := range e.playerSlice {
+		if budget == 0 {
+			break
+		}
+		if !p.IsDead && e.flowFieldManager.Stale(p.ID, p.X, p.Y) {
+			e.flowFieldManager.Regenerate(p.ID, p.X, p.Y)
+			budget--
+		}
+	}
+
+	if e.tickCount%int64(e.tickRate) == 0 {
+		alive := make(map[string]bool, len(e.playerSlice))
+		for _, p := range e.playerSlice {
+			if !p.IsDead {
+				alive[p.ID] = true
+			}
+		}
+		e.flowFieldManager.Retain(func(key string) bool { return alive[key] || e.keepRegroupField(key) || e.keepShopField(key) })
+	}
+}
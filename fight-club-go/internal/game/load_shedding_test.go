@@ -0,0 +1,154 @@
+package game
+
+import (
+	"testing"
+	"time"
+)
+
+// TestLoadShedderHysteresis verifies tiers are shed one per second of
+// overload and restored one per five seconds of headroom
+func TestLoadShedderHysteresis(t *testing.T) {
+	var l loadShedder
+	const tickRate = 30 // 33.3ms budget
+	run := func(d time.Duration, ticks int) (changes int) {
+		for i := 0; i < ticks; i++ {
+			if _, changed := l.observe(d, tickRate); changed {
+				changes++
+			}
+		}
+		return changes
+	}
+
+	if run(20*time.Millisecond, 300) != 0 || l.Level() != ShedNone {
+		t.Fatalf("Expected no shedding at 60%% of the budget, got %s", shedLevelNames[l.Level()])
+	}
+	if run(30*time.Millisecond, 2*tickRate) != 1 || l.Level() != ShedFlowFields {
+		t.Fatalf("Expected flow fields shed once the average spent a second near the budget, got %s", shedLevelNames[l.Level()])
+	}
+	run(30*time.Millisecond, 10*tickRate)
+	if l.Level() != ShedFarAI {
+		t.Fatalf("Expected every tier shed under sustained load, got %s", shedLevelNames[l.Level()])
+	}
+
+	// Brief headroom restores nothing, five seconds of it restores one tier
+	run(5*time.Millisecond, 2*tickRate)
+	if l.Level() != ShedFarAI {
+		t.Fatalf("Expected brief headroom to keep shedding, got %s", shedLevelNames[l.Level()])
+	}
+	run(5*time.Millisecond, 4*tickRate)
+	if l.Level() != ShedParticles {
+		t.Fatalf("Expected one tier restored after 5s of headroom, got %s", shedLevelNames[l.Level()])
+	}
+	run(5*time.Millisecond, 15*tickRate)
+	if l.Level() != ShedNone {
+		t.Fatalf("Expected the full simulation back, got %s", shedLevelNames[l.Level()])
+	}
+
+	stats := l.stats()
+	if stats.Level != "none" || stats.Changes != 6 || stats.Ticks["farai"] == 0 {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+}
+
+// TestShedParticles verifies particle bursts are halved under ShedParticles
+func TestShedParticles(t *testing.T) {
+	engine := newTestEngineWith(func(cfg *EngineConfig) { cfg.Limits.MaxParticles = 100 })
+	engine.shedLevel = ShedFlowFields
+	engine.emitParticles("spark", 100, 100, "", 5)
+	if len(engine.particles) != 5 {
+		t.Fatalf("Expected 5 sparks before particles are shed, got %d", len(engine.particles))
+	}
+
+	engine.particles = engine.particles[:0]
+	engine.shedLevel = ShedParticles
+	engine.emitParticles("spark", 100, 100, "", 5)
+	engine.emitParticles("spark", 100, 100, "", 1)
+	if len(engine.particles) != 4 {
+		t.Errorf("Expected 3 + 1 sparks when shedding particles, got %d", len(engine.particles))
+	}
+}
+
+// TestDeferRetarget verifies only far-away fighters keep their target, and
+// still re-decide every farAIInterval ticks
+func TestDeferRetarget(t *testing.T) {
+	engine := newTestEngine(30)
+	hunter := engine.AddPlayer("hunter", PlayerOptions{})
+	prey := engine.AddPlayer("prey", PlayerOptions{})
+	hunter.X, hunter.Y = 100, 100
+	prey.X, prey.Y = 900, 100
+	hunter.Target = prey
+
+	if engine.deferRetarget(hunter, 0) {
+		t.Fatal("Expected targets re-decided every tick without shedding")
+	}
+
+	engine.shedLevel = ShedFarAI
+	deferred := 0
+	for tick := int64(1); tick <= 30; tick++ {
+		engine.tickCount = tick
+		if engine.deferRetarget(hunter, 7) {
+			deferred++
+		}
+	}
+	if deferred != 20 {
+		t.Errorf("Expected the far fighter to re-decide every %d ticks (20 of 30 deferred), got %d deferred", farAIInterval, deferred)
+	}
+
+	engine.tickCount = 1
+	prey.X = 300
+	if engine.deferRetarget(hunter, 7) {
+		t.Error("Expected fighters near their target to re-decide every tick")
+	}
+	prey.X = 900
+	hunter.FocusTarget = "prey"
+	if engine.deferRetarget(hunter, 7) {
+		t.Error("Expected chat-focused fighters to re-decide every tick")
+	}
+	hunter.FocusTarget = ""
+	prey.IsDead = true
+	if engine.deferRetarget(hunter, 7) {
+		t.Error("Expected fighters whose target died to re-decide right away")
+	}
+	if (*Engine)(nil).deferRetarget(hunter, 7) {
+		t.Error("Expected fighters updated without an engine never to defer")
+	}
+}
+
+// TestRefreshFlowFields verifies fields follow moved targets, are pruned once
+// their target is gone and are left alone while shed
+func TestRefreshFlowFields(t *testing.T) {
+	engine := newTestEngine(30)
+	prey := engine.AddPlayer("prey", PlayerOptions{})
+	gone := engine.AddPlayer("gone", PlayerOptions{})
+	prey.X, prey.Y = 600, 300
+	engine.playerSlice = []*Player{prey, gone}
+
+	flows := engine.flowFieldManager
+	flows.GetOrCreate(prey.ID, prey.X, prey.Y)
+	flows.GetOrCreate(gone.ID, gone.X, gone.Y)
+	gone.IsDead = true
+
+	prey.X = 900
+	engine.shedLevel = ShedFlowFields
+	engine.tickCount = 30
+	engine.refreshFlowFields()
+	if !flows.Stale(prey.ID, prey.X, prey.Y) || flows.Len() != 2 {
+		t.Fatal("Expected flow fields left alone while shed")
+	}
+
+	engine.shedLevel = ShedNone
+	engine.tickCount = 31
+	engine.refreshFlowFields()
+	if flows.Stale(prey.ID, prey.X, prey.Y) {
+		t.Error("Expected the field regenerated toward the moved target")
+	}
+	if flows.Len() != 2 {
+		t.Error("Expected fields pruned only once a second")
+	}
+
+	engine.tickCount = 60
+	engine.refreshFlowFields()
+	if flows.Len() != 1 || flows.Stale(prey.ID, prey.X+10, prey.Y) {
+		t.Errorf("Expected only the living target's field kept, got %d fields", flows.Len())
+	}
+}
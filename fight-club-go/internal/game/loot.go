@@ -0,0 +1,141 @@
+package game
+
+import (
+	"fmt"
+	"log"
+	"math"
+
+	"fight-club/internal/config"
+)
+
+// LootConfig is an alias for config.LootConfig (SSOT)
+type LootConfig = config.LootConfig
+
+// MaxLootPiles caps the coin piles on the ground (the oldest vanishes first)
+const MaxLootPiles = 32
+
+// LootSnapshot is a coin pile for rendering
+type LootSnapshot struct {
+	X, Y      float64
+	Amount    int
+	Remaining float64 // Seconds until it despawns
+}
+
+// lootPile is money dropped by a dead fighter
+type lootPile struct {
+	x, y    float64
+	amount  int
+	owner   string // Name of the fighter who dropped it
+	ownerID string
+	age     float64
+}
+
+// dropLoot drops part of a dead fighter's money where they fell. Caller holds e.mu.
+func (e *Engine) dropLoot(victim *Player) {
+	amount := int(float64(victim.Money) * e.loot.DropPercent / 100)
+	if amount <= 0 {
+		return
+	}
+	victim.Money -= amount
+
+	if len(e.lootPiles) >= MaxLootPiles {
+		e.removeLoot(0, "despawn", "")
+	}
+	pile := &lootPile{x: victim.X, y: victim.Y, amount: amount, owner: victim.Name, ownerID: victim.ID}
+	e.lootPiles = append(e.lootPiles, pile)
+	e.emitLoot("drop", pile, victim.ID)
+}
+
+// updateLoot ages coin piles and lets fighters walking over them pick them up.
+// Uses the spatial grid built this tick, so only nearby fighters are checked.
+// Caller holds e.mu.
+func (e *Engine) updateLoot(deltaTime float64) {
+	playerList := e.playerSlice
+	queryRange := e.loot.PickupRadius + PlayerRadius // Grid positions are from the start of the tick
+
+	for i := 0; i < len(e.lootPiles); i++ {
+		pile := e.lootPiles[i]
+		pile.age += deltaTime
+		if pile.age >= e.loot.Despawn {
+			e.removeLoot(i, "despawn", "")
+			i--
+			continue
+		}
+
+		var picker *Player
+		best := e.loot.PickupRadius
+		for _, idx := range e.spatialGrid.QueryRadius(pile.x, pile.y, queryRange) {
+			p := playerList[idx]
+			if p.IsDead || p.IsRagdoll {
+				continue
+			}
+			if d := math.Hypot(p.X-pile.x, p.Y-pile.y); d <= best {
+				picker, best = p, d
+			}
+		}
+		if picker == nil {
+			continue
+		}
+
+		picker.Money += pile.amount
+		log.Printf("🪙 %s picked up $%d dropped by %s", picker.Name, pile.amount, pile.owner)
+		if len(e.texts) < e.limits.MaxTexts {
+			e.texts = append(e.texts, &FloatingText{
+				X:     picker.X,
+				Y:     picker.Y - 30,
+				Text:  fmt.Sprintf("+$%d", pile.amount),
+				Color: "#ffd700",
+				Alpha: 1.0,
+				VY:    -1.5,
+			})
+		}
+		e.removeLoot(i, "pickup", picker.ID)
+		i--
+	}
+}
+
+// reclaimLoot returns a fighter's coins still on the ground (their death was undone)
+func (e *Engine) reclaimLoot(p *Player) {
+	for i := 0; i < len(e.lootPiles); i++ {
+		if pile := e.lootPiles[i]; pile.owner == p.Name {
+			p.Money += pile.amount
+			e.removeLoot(i, "reclaim", p.ID)
+			i--
+		}
+	}
+}
+
+// removeLoot removes pile i (keeping drop order) and logs why
+func (e *Engine) removeLoot(i int, phase, playerID string) {
+	pile := e.lootPiles[i]
+	copy(e.lootPiles[i:], e.lootPiles[i+1:])
+	e.lootPiles[len(e.lootPiles)-1] = nil
+	e.lootPiles = e.lootPiles[:len(e.lootPiles)-1]
+	e.emitLoot(phase, pile, playerID)
+}
+
+// emitLoot logs a loot event for economy balance analysis
+func (e *Engine) emitLoot(phase string, pile *lootPile, playerID string) {
+	e.eventLog.EmitSimple(EventTypeLoot, uint64(e.tickCount), playerID,
+		LootPayload{
+			Phase:    phase,
+			OwnerID:  pile.ownerID,
+			PlayerID: playerID,
+			Amount:   pile.amount,
+			X:        pile.x,
+			Y:        pile.y,
+			Age:      pile.age,
+		})
+}
+
+// lootSnapshot copies the coin piles into the snapshot
+func (e *Engine) lootSnapshot(snap *GameSnapshot) {
+	for _, pile := range e.lootPiles {
+		snap.Loot = append(snap.Loot, LootSnapshot{
+			X:         pile.x,
+			Y:         pile.y,
+			Amount:    pile.amount,
+			Remaining: e.loot.Despawn - pile.age,
+		})
+	}
+}
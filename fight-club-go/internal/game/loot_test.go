@@ -0,0 +1,200 @@
+package game
+
+import (
+	"testing"
+	"time"
+)
+
+// testLoot drops half the money, with 10s despawn, 40px pickup and weapons that stay 10s
+var testLoot = LootConfig{DropPercent: 50, Despawn: 10, PickupRadius: 40, WeaponDespawn: 10}
+
+// TestLootDropAndPickup verifies coins drop where the fighter fell and go to whoever walks over them
+func TestLootDropAndPickup(t *testing.T) {
+	engine := newTestEngineWith(func(cfg *EngineConfig) {
+		cfg.Loot = testLoot
+		cfg.LagComp = LagCompensationConfig{GraceWindow: 0.5}
+	})
+	alice := engine.AddPlayer("alice", PlayerOptions{})
+	bob := engine.AddPlayer("bob", PlayerOptions{})
+	alice.Money = 101
+	alice.X, alice.Y = 400, 300
+	bob.X, bob.Y = 900, 300
+
+	alice.HP = 10
+	killWith(t, engine, alice, bob, 20)
+	engine.dropLoot(alice)
+	if alice.Money != 51 || len(engine.lootPiles) != 1 || engine.lootPiles[0].amount != 50 {
+		t.Fatalf("Expected a $50 pile and $51 left, got $%d and %d piles", alice.Money, len(engine.lootPiles))
+	}
+
+	// Dead fighters can't pick up (alice lies on the coins), bob is out of reach
+	stepEngine(engine, 1, engine.updateLoot)
+	if len(engine.lootPiles) != 1 || bob.Money != 0 || alice.Money != 51 {
+		t.Fatal("Expected the coins to stay on the ground")
+	}
+
+	bob.X, bob.Y = 430, 300
+	stepEngine(engine, 0.1, engine.updateLoot)
+	if len(engine.lootPiles) != 0 || bob.Money != 50 {
+		t.Errorf("Expected bob to pick up $50, got $%d (%d piles left)", bob.Money, len(engine.lootPiles))
+	}
+
+	// Nothing to drop when broke
+	bob.Money = 1
+	bob.IsDead = true
+	engine.dropLoot(bob)
+	if len(engine.lootPiles) != 0 || bob.Money != 1 {
+		t.Errorf("Expected no pile for $0, got %d", len(engine.lootPiles))
+	}
+}
+
+// TestLootDespawn verifies unclaimed coins vanish and the pile cap drops the oldest
+func TestLootDespawn(t *testing.T) {
+	engine := newTestEngineWith(func(cfg *EngineConfig) {
+		cfg.Loot = testLoot
+		cfg.LagComp = LagCompensationConfig{GraceWindow: 0.5}
+	})
+	alice := engine.AddPlayer("alice", PlayerOptions{})
+	alice.IsDead = true
+
+	alice.Money = 100
+	engine.dropLoot(alice)
+	stepEngine(engine, 5, engine.updateLoot)
+	var snap GameSnapshot
+	engine.lootSnapshot(&snap)
+	if len(snap.Loot) != 1 || snap.Loot[0].Remaining > 5.1 || snap.Loot[0].Remaining < 4.9 {
+		t.Fatalf("Expected one pile with ~5s left, got %+v", snap.Loot)
+	}
+	stepEngine(engine, 5.1, engine.updateLoot)
+	if len(engine.lootPiles) != 0 {
+		t.Fatalf("Expected the pile to despawn, got %d", len(engine.lootPiles))
+	}
+
+	for i := 0; i < MaxLootPiles+1; i++ {
+		alice.Money = 2 * (i + 1)
+		engine.dropLoot(alice)
+	}
+	if len(engine.lootPiles) != MaxLootPiles || engine.lootPiles[0].amount != 2 {
+		t.Errorf("Expected %d piles without the oldest, got %d (first $%d)",
+			MaxLootPiles, len(engine.lootPiles), engine.lootPiles[0].amount)
+	}
+}
+
+// TestLootReclaimedOnLateHeal verifies a fighter revived by lag compensation gets back the dropped coins
+func TestLootReclaimedOnLateHeal(t *testing.T) {
+	engine := newTestEngineWith(func(cfg *EngineConfig) {
+		cfg.Loot = testLoot
+		cfg.LagComp = LagCompensationConfig{GraceWindow: 0.5}
+	})
+	alice := engine.AddPlayer("alice", PlayerOptions{})
+	bob := engine.AddPlayer("bob", PlayerOptions{})
+	alice.Money = 100
+
+	sentAt := time.Now()
+	alice.HP = 15
+	killWith(t, engine, alice, bob, 25)
+	engine.dropLoot(alice)
+
+	if res := engine.HealPlayerAt("alice", 20, sentAt); res != HealRetroactive {
+		t.Fatalf("Expected a retroactive heal, got %v", res)
+	}
+	if alice.Money != 100 || len(engine.lootPiles) != 0 {
+		t.Errorf("Expected the coins back, got $%d (%d piles)", alice.Money, len(engine.lootPiles))
+	}
+}
+
+// TestWeaponDropAndPickup verifies a dead fighter's weapon drops where they fell
+// and only goes to fighters it's an upgrade for
+func TestWeaponDropAndPickup(t *testing.T) {
+	engine := newTestEngineWith(func(cfg *EngineConfig) {
+		cfg.Loot = testLoot
+		cfg.LagComp = LagCompensationConfig{GraceWindow: 0.5}
+	})
+	alice := engine.AddPlayer("alice", PlayerOptions{})
+	bob := engine.AddPlayer("bob", PlayerOptions{})
+	carl := engine.AddPlayer("carl", PlayerOptions{})
+	alice.Weapon, bob.Weapon = "sword", "axe"
+	alice.X, alice.Y = 400, 300
+	bob.X, bob.Y = 420, 300
+	carl.X, carl.Y = 900, 300
+
+	alice.HP = 10
+	killWith(t, engine, alice, bob, 20)
+	engine.dropWeapon(alice)
+	if alice.Weapon != "fists" || len(engine.weaponDrops) != 1 || engine.weaponDrops[0].weapon != "sword" {
+		t.Fatalf("Expected alice's sword on the ground, got weapon %q and %d drops", alice.Weapon, len(engine.weaponDrops))
+	}
+
+	// bob stands on it but his axe is better; carl is out of reach
+	stepEngine(engine, 1, engine.updateWeaponDrops)
+	if len(engine.weaponDrops) != 1 || bob.Weapon != "axe" || carl.Weapon != "fists" {
+		t.Fatal("Expected the sword to stay on the ground")
+	}
+
+	carl.X, carl.Y = 380, 300
+	stepEngine(engine, 0.1, engine.updateWeaponDrops)
+	if len(engine.weaponDrops) != 0 || carl.Weapon != "sword" {
+		t.Errorf("Expected carl to pick up the sword, got %q (%d drops left)", carl.Weapon, len(engine.weaponDrops))
+	}
+
+	// Fists don't drop
+	carl.Weapon = "fists"
+	carl.IsDead = true
+	engine.dropWeapon(carl)
+	if len(engine.weaponDrops) != 0 {
+		t.Errorf("Expected no drop for fists, got %d", len(engine.weaponDrops))
+	}
+}
+
+// TestWeaponDropDespawn verifies unclaimed weapons vanish and drops can be turned off
+func TestWeaponDropDespawn(t *testing.T) {
+	engine := newTestEngineWith(func(cfg *EngineConfig) {
+		cfg.Loot = testLoot
+		cfg.LagComp = LagCompensationConfig{GraceWindow: 0.5}
+	})
+	alice := engine.AddPlayer("alice", PlayerOptions{})
+	alice.IsDead = true
+
+	alice.Weapon = "spear"
+	engine.dropWeapon(alice)
+	stepEngine(engine, 5, engine.updateWeaponDrops)
+	var snap GameSnapshot
+	engine.weaponDropSnapshot(&snap)
+	if len(snap.WeaponDrops) != 1 || snap.WeaponDrops[0].Weapon != "spear" || snap.WeaponDrops[0].Remaining > 5.1 || snap.WeaponDrops[0].Remaining < 4.9 {
+		t.Fatalf("Expected one spear with ~5s left, got %+v", snap.WeaponDrops)
+	}
+	stepEngine(engine, 5.1, engine.updateWeaponDrops)
+	if len(engine.weaponDrops) != 0 {
+		t.Fatalf("Expected the spear to despawn, got %d", len(engine.weaponDrops))
+	}
+
+	engine.loot.WeaponDespawn = 0
+	alice.Weapon = "axe"
+	engine.dropWeapon(alice)
+	if len(engine.weaponDrops) != 0 || alice.Weapon != "axe" {
+		t.Errorf("Expected alice to keep the axe with weapon drops off, got %q", alice.Weapon)
+	}
+}
+
+// TestWeaponReclaimedOnLateHeal verifies a fighter revived by lag compensation gets back the dropped weapon
+func TestWeaponReclaimedOnLateHeal(t *testing.T) {
+	engine := newTestEngineWith(func(cfg *EngineConfig) {
+		cfg.Loot = testLoot
+		cfg.LagComp = LagCompensationConfig{GraceWindow: 0.5}
+	})
+	alice := engine.AddPlayer("alice", PlayerOptions{})
+	bob := engine.AddPlayer("bob", PlayerOptions{})
+	alice.Weapon = "katana"
+
+	sentAt := time.Now()
+	alice.HP = 15
+	killWith(t, engine, alice, bob, 25)
+	engine.dropWeapon(alice)
+
+	if res := engine.HealPlayerAt("alice", 20, sentAt); res != HealRetroactive {
+		t.Fatalf("Expected a retroactive heal, got %v", res)
+	}
+	if alice.Weapon != "katana" || len(engine.weaponDrops) != 0 {
+		t.Errorf("Expected the katana back, got %q (%d drops)", alice.Weapon, len(engine.weaponDrops))
+	}
+}
@@ -0,0 +1,105 @@
+package game
+
+import (
+	"fmt"
+	"log"
+)
+
+// ModAction is a channel moderator's chat action against a fighter
+type ModAction string
+
+const (
+	ModKick   ModAction = "kick"   // Remove from the arena
+	ModFreeze ModAction = "freeze" // Can't move or attack for FreezeDuration
+	ModStrip  ModAction = "strip"  // Back to fists
+)
+
+// Moderation tuning
+const (
+	FreezeDuration     = 10.0  // seconds
+	ModKickRejoinDelay = 300.0 // Seconds a kicked viewer can't !join again
+)
+
+// updateFreeze counts down a moderator freeze, reporting whether the player
+// is still frozen (no movement or attacks)
+func (p *Player) updateFreeze(deltaTime float64) bool {
+	if p.FreezeTimer <= 0 {
+		return false
+	}
+	p.FreezeTimer -= deltaTime
+	p.VX, p.VY = 0, 0
+	return true
+}
+
+// Moderate applies a moderator action to a fighter and records it in the event log
+func (e *Engine) Moderate(moderatorName, targetName string, action ModAction) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	target, ok := e.players[targetName]
+	if !ok {
+		return fmt.Errorf("'%s' is not in the arena", targetName)
+	}
+
+	var duration float64
+	var text, textColor string
+	switch action {
+	case ModKick:
+		delete(e.players, targetName)
+		e.rosterVersion++
+		delete(e.botRespawnTimers, targetName)
+		e.modRejoinTicks[targetName] = e.tickCount + int64(ModKickRejoinDelay*float64(e.tickRate))
+		duration = ModKickRejoinDelay
+	case ModFreeze:
+		if target.IsDead {
+			return fmt.Errorf("'%s' is not fighting", targetName)
+		}
+		target.FreezeTimer = FreezeDuration
+		target.VX, target.VY = 0, 0
+		duration, text, textColor = FreezeDuration, "FROZEN!", "#7dd3fc"
+	case ModStrip:
+		if target.Weapon == "fists" {
+			return fmt.Errorf("'%s' is already unarmed", targetName)
+		}
+		target.Weapon = "fists"
+		text, textColor = "DISARMED!", "#ff4757"
+	default:
+		return fmt.Errorf("unknown moderator action '%s'", action)
+	}
+
+	if text != "" && len(e.texts) < e.limits.MaxTexts {
+		e.texts = append(e.texts, &FloatingText{
+			X:     target.X,
+			Y:     target.Y - 40,
+			Text:  text,
+			Color: textColor,
+			Alpha: 1.0,
+			VY:    -1.5,
+		})
+	}
+
+	e.eventLog.EmitSimple(EventTypeMod, uint64(e.tickCount), target.ID,
+		ModerationPayload{
+			Moderator: moderatorName,
+			Action:    string(action),
+			TargetID:  target.ID,
+			Target:    targetName,
+			Duration:  duration,
+		})
+
+	log.Printf("🛡️ %s used %s on %s", moderatorName, action, targetName)
+	return nil
+}
+
+// rejoinBlocked reports whether a kicked viewer is still barred from joining. Caller holds e.mu.
+func (e *Engine) rejoinBlocked(name string) bool {
+	until, ok := e.modRejoinTicks[name]
+	if !ok {
+		return false
+	}
+	if e.tickCount >= until {
+		delete(e.modRejoinTicks, name)
+		return false
+	}
+	return true
+}
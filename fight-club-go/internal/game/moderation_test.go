@@ -0,0 +1,41 @@
+package game
+
+import (
+	"testing"
+)
+
+// TestModerateFreezeAndKick verifies frozen fighters stand still and kicked viewers sit out the rejoin delay
+func TestModerateFreezeAndKick(t *testing.T) {
+	engine := newTestEngine(30)
+	engine.arenaBotEnabled = false // Nobody to push the frozen fighter around
+	p := engine.AddPlayer("griefer", PlayerOptions{})
+
+	if err := engine.Moderate("mod", "griefer", ModFreeze); err != nil {
+		t.Fatalf("Freeze failed: %v", err)
+	}
+	x, y := p.X, p.Y
+	for i := 0; i < 30; i++ {
+		engine.tick()
+	}
+	if p.X != x || p.Y != y {
+		t.Errorf("Frozen fighter moved from (%.0f, %.0f) to (%.0f, %.0f)", x, y, p.X, p.Y)
+	}
+	if p.FreezeTimer <= 0 || p.FreezeTimer >= FreezeDuration {
+		t.Errorf("Expected the freeze counting down, got %.2fs", p.FreezeTimer)
+	}
+
+	if err := engine.Moderate("mod", "griefer", ModStrip); err == nil {
+		t.Error("Expected stripping a fighter with fists to fail")
+	}
+
+	if err := engine.Moderate("mod", "griefer", ModKick); err != nil {
+		t.Fatalf("Kick failed: %v", err)
+	}
+	if engine.AddPlayer("griefer", PlayerOptions{}) != nil {
+		t.Error("Kicked viewer rejoined during the rejoin delay")
+	}
+	engine.tickCount += int64(ModKickRejoinDelay * float64(engine.tickRate))
+	if engine.AddPlayer("griefer", PlayerOptions{}) == nil {
+		t.Error("Kicked viewer should rejoin after the delay")
+	}
+}
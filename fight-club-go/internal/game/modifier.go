@@ -0,0 +1,103 @@
+package game
+
+// Arena modifier IDs
+const (
+	ModLowGravity   = "low_gravity"
+	ModDoubleDamage = "double_damage"
+	ModTinyPlayers  = "tiny_players"
+	ModFog          = "fog"
+)
+
+// Modifier tuning
+const (
+	LowGravityFriction = 0.94 // Velocity retained per tick (normal: 0.85) - floaty movement
+	LowGravityRagdoll  = 0.97 // Ragdoll velocity retained per tick (normal: 0.92)
+	TinyPlayerScale    = 0.6  // Body/collision radius multiplier
+	FogDetectionRange  = 150.0
+)
+
+// ArenaModifier is a crowd-voted rule change applied to the whole arena
+type ArenaModifier struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Emoji       string `json:"emoji"`
+	Description string `json:"description"`
+}
+
+// Modifiers is the registry of all arena modifiers
+var Modifiers = map[string]ArenaModifier{
+	ModLowGravity: {
+		ID:          ModLowGravity,
+		Name:        "Low Gravity",
+		Emoji:       "🪐",
+		Description: "Floaty movement, bodies fly further",
+	},
+	ModDoubleDamage: {
+		ID:          ModDoubleDamage,
+		Name:        "Double Damage",
+		Emoji:       "💥",
+		Description: "Every hit deals 2x damage",
+	},
+	ModTinyPlayers: {
+		ID:          ModTinyPlayers,
+		Name:        "Tiny Players",
+		Emoji:       "🐜",
+		Description: "Everyone shrinks",
+	},
+	ModFog: {
+		ID:          ModFog,
+		Name:        "Fog",
+		Emoji:       "🌫️",
+		Description: "Fighters only see nearby enemies",
+	},
+}
+
+// GetModifier returns a modifier by ID and whether it exists
+func GetModifier(id string) (ArenaModifier, bool) {
+	m, ok := Modifiers[id]
+	return m, ok
+}
+
+// friction returns the per-tick velocity retention under the active modifier
+func (p *Player) friction() float64 {
+	if p.modifier == ModLowGravity {
+		return LowGravityFriction
+	}
+	return 0.85
+}
+
+// ragdollFriction returns the per-tick ragdoll velocity retention under the active modifier
+func (p *Player) ragdollFriction() float64 {
+	if p.modifier == ModLowGravity {
+		return LowGravityRagdoll
+	}
+	return 0.92
+}
+
+// sizeScale returns the body/collision size multiplier under the active modifier
+func (p *Player) sizeScale() float64 {
+	return ModifierPlayerScale(p.modifier)
+}
+
+// ModifierPlayerScale returns the body size multiplier for a modifier (used by renderers)
+func ModifierPlayerScale(modifier string) float64 {
+	if modifier == ModTinyPlayers {
+		return TinyPlayerScale
+	}
+	return 1.0
+}
+
+// damageMultiplier returns the damage scale for the active modifier
+func (e *Engine) damageMultiplier() int {
+	if e.modifier == ModDoubleDamage {
+		return 2
+	}
+	return 1
+}
+
+// GetActiveModifier returns the arena modifier currently in effect ("" if none)
+func (e *Engine) GetActiveModifier() string {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.modifier
+}
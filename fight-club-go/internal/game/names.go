@@ -0,0 +1,174 @@
+package game
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+	"golang.org/x/text/width"
+)
+
+// MaxDisplayNameLength caps HUD names in graphemes (what viewers count as
+// characters: "é", "👍🏽" and "👨‍👩‍👧" are one each), not bytes or runes
+const MaxDisplayNameLength = 20
+
+// maxMarksPerGrapheme caps stacked combining marks ("zalgo" text towering
+// over the nameplates); real scripts rarely need more than two
+const maxMarksPerGrapheme = 2
+
+const zeroWidthJoiner = '\u200d'
+
+// SanitizeName cleans a username at the boundary where it enters the game
+// (chat, API): control and invisible format characters, bidi overrides and
+// stacked combining marks are dropped, fullwidth letters folded ("ＢＯＢ" → "BOB"), the result
+// NFC-normalized and whitespace collapsed. Ordinary Kick usernames pass
+// through unchanged, so it's safe as the player's identity key. Returns ""
+// when nothing printable is left.
+func SanitizeName(name string) string {
+	text := strings.Join(splitGraphemes(SanitizeText(name)), "")
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// SanitizeText drops characters that break rendering or logs from viewer
+// text (names, chat bubbles) and normalizes width and composition
+func SanitizeText(s string) string {
+	s = norm.NFC.String(width.Fold.String(s))
+
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range s {
+		switch {
+		case r == zeroWidthJoiner:
+			b.WriteRune(r) // Emoji sequences (👨‍👩‍👧); stray ones are dropped by graphemes
+		case isBidiControl(r):
+			// RTL/LTR overrides and isolates flip the rest of the HUD line
+		case unicode.IsSpace(r):
+			b.WriteByte(' ')
+		case unicode.In(r, unicode.L, unicode.M, unicode.N, unicode.P, unicode.S):
+			b.WriteRune(r)
+		case unicode.Is(unicode.Variation_Selector, r) || isEmojiTag(r):
+			b.WriteRune(r) // Emoji presentation and flag tags (Cf, kept)
+		}
+		// Everything else: control (Cc), other format (Cf), private use,
+		// surrogates, unassigned and line/paragraph separators
+	}
+	return b.String()
+}
+
+// DisplayName returns the name as shown on stream: sanitized and capped at
+// MaxDisplayNameLength graphemes
+// ("…" marks the cut). A name with nothing printable gets a stable
+// placeholder derived from it ("viewer-3f2a"), so the same viewer always
+// shows the same way.
+func DisplayName(name string) string {
+	clean := SanitizeName(name)
+	graphemes := splitGraphemes(clean)
+	if len(graphemes) == 0 {
+		return placeholderName(name)
+	}
+
+	if len(graphemes) <= MaxDisplayNameLength {
+		return clean
+	}
+	return strings.TrimSpace(strings.Join(graphemes[:MaxDisplayNameLength-1], "")) + "…"
+}
+
+// TruncateGraphemes cuts s to at most n graphemes without splitting one
+func TruncateGraphemes(s string, n int) string {
+	graphemes := splitGraphemes(s)
+	if len(graphemes) <= n {
+		return strings.Join(graphemes, "")
+	}
+	return strings.Join(graphemes[:n], "")
+}
+
+// placeholderName names a viewer whose name has nothing printable
+func placeholderName(name string) string {
+	h := fnv.New32a()
+	h.Write([]byte(name))
+	return fmt.Sprintf("viewer-%04x", h.Sum32()&0xffff)
+}
+
+// splitGraphemes splits sanitized text into user-perceived characters: a base
+// rune with its combining marks (capped at maxMarksPerGrapheme), variation
+// selectors, skin tone modifiers, emoji tags, zero-width-joined sequences and
+// flag pairs. It's a practical subset of UAX #29 for names, not all of it.
+func splitGraphemes(s string) []string {
+	var graphemes []string
+	var cur []rune
+	marks := 0
+	joined := false // Previous rune was a zero-width joiner
+	flagHalf := false
+
+	flush := func() {
+		// A trailing joiner has nothing to join
+		for len(cur) > 0 && cur[len(cur)-1] == zeroWidthJoiner {
+			cur = cur[:len(cur)-1]
+		}
+		if len(cur) > 0 {
+			graphemes = append(graphemes, string(cur))
+		}
+		cur, marks, joined, flagHalf = nil, 0, false, false
+	}
+
+	for _, r := range s {
+		switch {
+		case r == zeroWidthJoiner:
+			if len(cur) > 0 {
+				cur = append(cur, r)
+				joined = true
+			}
+			continue
+		case unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc):
+			if len(cur) > 0 && marks < maxMarksPerGrapheme {
+				cur = append(cur, r)
+			}
+			if len(cur) > 0 {
+				marks++
+			}
+			continue
+		case unicode.Is(unicode.Variation_Selector, r) || isSkinTone(r) || isEmojiTag(r):
+			if len(cur) > 0 {
+				cur = append(cur, r)
+			}
+			continue
+		case isRegionalIndicator(r) && flagHalf:
+			cur = append(cur, r) // Second half of a flag
+			flagHalf = false
+			continue
+		case joined:
+			cur = append(cur, r)
+			joined = false
+			continue
+		}
+
+		flush()
+		cur = append(cur, r)
+		flagHalf = isRegionalIndicator(r)
+	}
+	flush()
+	return graphemes
+}
+
+// isBidiControl reports explicit direction marks, embeddings, overrides and isolates
+func isBidiControl(r rune) bool {
+	return r == '\u061c' || r == '\u200e' || r == '\u200f' ||
+		(r >= '\u202a' && r <= '\u202e') || (r >= '\u2066' && r <= '\u2069')
+}
+
+// isSkinTone reports the emoji skin tone modifiers (🏻-🏿)
+func isSkinTone(r rune) bool {
+	return r >= 0x1f3fb && r <= 0x1f3ff
+}
+
+// isEmojiTag reports the tag characters that spell subdivision flags (Scotland, Wales)
+func isEmojiTag(r rune) bool {
+	return r >= 0xe0020 && r <= 0xe007f
+}
+
+// isRegionalIndicator reports the letters that pair into country flags
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1f1e6 && r <= 0x1f1ff
+}
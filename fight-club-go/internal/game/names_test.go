@@ -0,0 +1,104 @@
+package game
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// TestSanitizeName verifies control, invisible and direction characters are
+// dropped and look-alike spellings fold to one identity
+func TestSanitizeName(t *testing.T) {
+	cases := []struct {
+		in, want string
+	}{
+		{"ninja_42", "ninja_42"},
+		{"bob\x00\x1b[31m", "bob[31m"},
+		{"evil\u202egnp.exe", "evilgnp.exe"}, // RTL override
+		{"\u2066iso\u2069late", "isolate"},   // Isolates
+		{"zero\u200bwidth\ufeff", "zerowidth"},
+		{"\uff22\uff2f\uff22", "BOB"}, // Fullwidth
+		{"e\u0301", "é"},              // NFC: the same as the precomposed spelling
+		{"  two \t words\n", "two words"},
+		{"\u200d\u200b\x07", ""},
+	}
+	for _, c := range cases {
+		if got := SanitizeName(c.in); got != c.want {
+			t.Errorf("SanitizeName(%q) = %q, want %q", c.in, got, c.want)
+		}
+	}
+}
+
+// TestDisplayName verifies names are capped in graphemes without splitting
+// one, stacked marks are trimmed and empty names get a stable placeholder
+func TestDisplayName(t *testing.T) {
+	family := "\U0001f468\u200d\U0001f469\u200d\U0001f467"
+	flag := "\U0001f1e7\U0001f1f7"
+	thumb := "\U0001f44d\U0001f3fd"
+	if got := DisplayName(family + flag + thumb); got != family+flag+thumb {
+		t.Errorf("Expected emoji sequences kept whole, got %q", got)
+	}
+	if n := len(splitGraphemes(family + flag + thumb + "e\u0301")); n != 4 {
+		t.Errorf("Expected 4 graphemes, got %d", n)
+	}
+
+	got := DisplayName(strings.Repeat(flag, 30))
+	if got != strings.Repeat(flag, MaxDisplayNameLength-1)+"…" {
+		t.Errorf("Expected %d whole flags and an ellipsis, got %q", MaxDisplayNameLength-1, got)
+	}
+
+	if got := DisplayName("z" + strings.Repeat("\u0336", 40)); utf8.RuneCountInString(got) != 1+maxMarksPerGrapheme {
+		t.Errorf("Expected stacked marks trimmed to %d, got %q", maxMarksPerGrapheme, got)
+	}
+
+	a, b := DisplayName("\u202e\u200b"), DisplayName("\u202e\u200b")
+	if a != b || !strings.HasPrefix(a, "viewer-") || a == DisplayName("\x01") {
+		t.Errorf("Expected a stable per-name placeholder, got %q, %q and %q", a, b, DisplayName("\x01"))
+	}
+
+	p := NewPlayer("\u202eRTL", PlayerOptions{})
+	if p.Name != "\u202eRTL" || p.DisplayName != "RTL" {
+		t.Errorf("Expected the identity kept and a safe display name, got %q / %q", p.Name, p.DisplayName)
+	}
+}
+
+// TestChatBubbleSanitized verifies bubbles drop unsafe characters and are cut by grapheme
+func TestChatBubbleSanitized(t *testing.T) {
+	engine := newTestEngine(30)
+	engine.AddPlayer("talker", PlayerOptions{})
+
+	engine.SetChatBubble("talker", "\u202e"+strings.Repeat("é", 60))
+	bubble := engine.GetPlayer("talker").ChatBubble
+	if utf8.RuneCountInString(bubble) != 50 || strings.ContainsRune(bubble, '\u202e') {
+		t.Errorf("Expected 50 clean characters, got %q", bubble)
+	}
+}
+
+// TestChatBubbleExpires verifies bubbles reach the snapshot and disappear after their TTL
+func TestChatBubbleExpires(t *testing.T) {
+	engine := newTestEngine(30)
+	engine.AddPlayer("talker", PlayerOptions{})
+	engine.SetChatBubble("talker", "hello chat")
+
+	engine.ProduceSnapshot()
+	snap := engine.GetSnapshot()
+	if len(snap.Players) != 1 || snap.Players[0].ChatBubble != "hello chat" || snap.Players[0].ChatBubbleTTL != 5 {
+		t.Fatalf("Expected the bubble in the snapshot, got %+v", snap.Players)
+	}
+
+	player := engine.GetPlayer("talker")
+	player.updateChatBubble(4.9)
+	if player.ChatBubble == "" {
+		t.Error("Bubble expired early")
+	}
+	player.updateChatBubble(0.2)
+	if player.ChatBubble != "" || player.ChatBubbleTTL != 0 {
+		t.Errorf("Expected the bubble gone after 5s, got %q (%.2fs)", player.ChatBubble, player.ChatBubbleTTL)
+	}
+
+	engine.SetChatBubble("talker", "again")
+	player.die(nil)
+	if player.ChatBubble != "" {
+		t.Error("Expected death to clear the bubble")
+	}
+}
@@ -0,0 +1,376 @@
+package game
+
+import (
+	"log"
+	"math"
+	"sort"
+
+	"fight-club/internal/config"
+)
+
+// ObjectiveConfig is an alias for config.ObjectiveConfig (SSOT)
+type ObjectiveConfig = config.ObjectiveConfig
+
+// Team objective tuning
+const (
+	ObjectiveScoreInterval = 1.0  // Seconds the point must be held per team point
+	ObjectiveBaseHeal      = 3    // HP healed per ObjectiveHealInterval inside the own base
+	ObjectiveHealInterval  = 1.0  // Seconds between base heals
+	ObjectiveRoundDelay    = 15.0 // Seconds between the end of a round and the next
+)
+
+// ObjectiveTeamSnapshot is one side of the objective round
+type ObjectiveTeamSnapshot struct {
+	Name         string
+	Color        string // Team color name (see TeamColors)
+	Score        int
+	BaseX, BaseY float64
+}
+
+// ObjectiveSnapshot is the team objective state for the stream overlay
+type ObjectiveSnapshot struct {
+	Active     bool
+	Teams      [2]ObjectiveTeamSnapshot // Left base, right base
+	BaseRadius float64
+	ScoreLimit int
+
+	PointX, PointY, PointRadius float64
+
+	Owner     int     // Side holding the point (-1 = neutral)
+	Capturing int     // Side taking the point (-1 = nobody)
+	Progress  float64 // 0..1 capture progress of Capturing
+	Contested bool    // Both sides stand on the point
+}
+
+// ObjectiveResult describes how an objective round ended
+type ObjectiveResult struct {
+	Teams  [2]string // Team names, left base first
+	Scores [2]int
+	Winner string // "" when the round was abandoned
+	Loser  string
+	Reason string // "score" or "abandoned"
+	Bonus  int    // Wallet payout credited to each winning member in the arena
+	Paid   int    // Winning members paid
+}
+
+// ObjectiveManager runs the team objective mode: the two teams with the most
+// fighters in the arena get a base at the left/right edge and fight over a
+// capture point at the center. Holding the point scores ObjectiveScoreInterval
+// points per second; the first side to ScoreLimit wins the round. Fighters heal
+// in their own base and respawn there. State is guarded by the engine lock
+// (see Engine.updateObjective).
+type ObjectiveManager struct {
+	cfg ObjectiveConfig
+
+	pointX, pointY float64
+	baseX          [2]float64
+	baseY          float64
+
+	active    bool
+	teams     [2]string // Team IDs, left base first
+	scores    [2]int
+	owner     int
+	capturing int
+	progress  float64 // Seconds of uncontested capture by capturing
+	contested bool
+
+	scoreTimer float64
+	healTimer  float64
+	cooldown   float64 // Seconds until the next round may start
+
+	// Callbacks (called in a new goroutine, e.g. to post to Kick chat)
+	OnRoundStart func(teams [2]string, scoreLimit int)
+	OnCapture    func(team string)
+	OnRoundEnd   func(result ObjectiveResult)
+}
+
+// NewObjectiveManager creates an objective manager for an arena of the given size
+func NewObjectiveManager(cfg ObjectiveConfig, width, height float64) *ObjectiveManager {
+	defaults := config.DefaultObjective()
+	if cfg.CaptureTime <= 0 {
+		cfg.CaptureTime = defaults.CaptureTime
+	}
+	if cfg.PointRadius <= 0 {
+		cfg.PointRadius = defaults.PointRadius
+	}
+	if cfg.BaseRadius <= 0 {
+		cfg.BaseRadius = defaults.BaseRadius
+	}
+	return &ObjectiveManager{
+		cfg:       cfg,
+		pointX:    width / 2,
+		pointY:    height / 2,
+		baseX:     [2]float64{cfg.BaseRadius, width - cfg.BaseRadius},
+		baseY:     height / 2,
+		owner:     -1,
+		capturing: -1,
+	}
+}
+
+// Enabled reports whether the objective mode is on
+func (om *ObjectiveManager) Enabled() bool {
+	return om.cfg.ScoreLimit > 0
+}
+
+// side returns which side a team plays on (-1 = not in the round)
+func (om *ObjectiveManager) side(teamID string) int {
+	if !om.active || teamID == "" {
+		return -1
+	}
+	for i, id := range om.teams {
+		if id == teamID {
+			return i
+		}
+	}
+	return -1
+}
+
+// objectiveSnapshot returns the objective state for rendering. Caller holds e.mu.
+func (e *Engine) objectiveSnapshot() ObjectiveSnapshot {
+	om := e.objective
+	if !om.active {
+		return ObjectiveSnapshot{Owner: -1, Capturing: -1}
+	}
+	snap := ObjectiveSnapshot{
+		Active:      true,
+		BaseRadius:  om.cfg.BaseRadius,
+		ScoreLimit:  om.cfg.ScoreLimit,
+		PointX:      om.pointX,
+		PointY:      om.pointY,
+		PointRadius: om.cfg.PointRadius,
+		Owner:       om.owner,
+		Capturing:   om.capturing,
+		Progress:    math.Min(om.progress/om.cfg.CaptureTime, 1),
+		Contested:   om.contested,
+	}
+	for i, id := range om.teams {
+		snap.Teams[i] = ObjectiveTeamSnapshot{
+			Name:  e.objectiveTeamName(id),
+			Color: e.teamManager.TeamColor(id),
+			Score: om.scores[i],
+			BaseX: om.baseX[i],
+			BaseY: om.baseY,
+		}
+	}
+	return snap
+}
+
+// objectiveTeamName returns a team's current name (teams can be renamed mid-round)
+func (e *Engine) objectiveTeamName(teamID string) string {
+	if team := e.teamManager.GetTeam(teamID); team != nil {
+		return team.Name
+	}
+	return "?"
+}
+
+// updateObjective starts rounds, moves the capture point, scores the holder
+// and heals fighters in their base. Called from tick with e.mu held.
+func (e *Engine) updateObjective(deltaTime float64) {
+	om := e.objective
+	if !om.Enabled() {
+		return
+	}
+	if !om.active {
+		if om.cooldown > 0 {
+			om.cooldown -= deltaTime
+			return
+		}
+		e.startObjectiveRound()
+		return
+	}
+
+	om.healTimer -= deltaTime
+	heal := om.healTimer <= 0
+	if heal {
+		om.healTimer = ObjectiveHealInterval
+	}
+
+	var present, onPoint [2]int
+	for _, p := range e.playerSlice {
+		side := om.side(p.TeamID)
+		if side < 0 {
+			continue
+		}
+		present[side]++
+		if p.IsDead || p.InDuel {
+			continue
+		}
+		if math.Hypot(p.X-om.pointX, p.Y-om.pointY) <= om.cfg.PointRadius {
+			onPoint[side]++
+		}
+		if heal && p.HP < p.MaxHP && math.Hypot(p.X-om.baseX[side], p.Y-om.baseY) <= om.cfg.BaseRadius {
+			p.Heal(ObjectiveBaseHeal)
+		}
+	}
+	if present[0] == 0 || present[1] == 0 {
+		e.endObjectiveRound(-1)
+		return
+	}
+
+	e.updateCapture(deltaTime, onPoint)
+
+	if om.owner < 0 {
+		return
+	}
+	om.scoreTimer += deltaTime
+	for om.scoreTimer >= ObjectiveScoreInterval {
+		om.scoreTimer -= ObjectiveScoreInterval
+		om.scores[om.owner]++
+		if om.scores[om.owner] >= om.cfg.ScoreLimit {
+			e.endObjectiveRound(om.owner)
+			return
+		}
+	}
+}
+
+// updateCapture advances the capture point from who stands on it. A lone side
+// first drains the other side's progress, then builds its own; the holder
+// standing on its point drains an attacker's progress. Caller holds e.mu.
+func (e *Engine) updateCapture(deltaTime float64, onPoint [2]int) {
+	om := e.objective
+	om.contested = onPoint[0] > 0 && onPoint[1] > 0
+	if om.contested || onPoint[0]+onPoint[1] == 0 {
+		return // Frozen while contested; an empty point keeps its progress
+	}
+
+	side := 0
+	if onPoint[1] > 0 {
+		side = 1
+	}
+	if side == om.owner || (om.capturing >= 0 && om.capturing != side) {
+		om.progress -= deltaTime
+		if om.progress <= 0 {
+			om.progress = 0
+			om.capturing = -1
+		}
+		return
+	}
+
+	om.capturing = side
+	om.progress += deltaTime
+	if om.progress < om.cfg.CaptureTime {
+		return
+	}
+	om.owner = side
+	om.capturing = -1
+	om.progress = 0
+	om.scoreTimer = 0
+
+	name := e.objectiveTeamName(om.teams[side])
+	log.Printf("🚩 %s captured the point", name)
+	e.CreateFlash(om.pointX, om.pointY, "#ffffff", 2.0)
+	if om.OnCapture != nil {
+		go om.OnCapture(name)
+	}
+}
+
+// startObjectiveRound picks the two teams with the most fighters in the arena
+// and starts a round if there are two. Caller holds e.mu.
+func (e *Engine) startObjectiveRound() {
+	om := e.objective
+
+	counts := make(map[string]int)
+	for _, p := range e.playerSlice {
+		if p.TeamID != "" && e.teamManager.GetTeam(p.TeamID) != nil {
+			counts[p.TeamID]++
+		}
+	}
+	if len(counts) < 2 {
+		return
+	}
+	ids := make([]string, 0, len(counts))
+	for id := range counts {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if counts[ids[i]] != counts[ids[j]] {
+			return counts[ids[i]] > counts[ids[j]]
+		}
+		return ids[i] < ids[j]
+	})
+
+	om.active = true
+	om.teams = [2]string{ids[0], ids[1]}
+	om.scores = [2]int{}
+	om.owner = -1
+	om.capturing = -1
+	om.progress = 0
+	om.contested = false
+	om.scoreTimer = 0
+	om.healTimer = ObjectiveHealInterval
+
+	names := [2]string{e.objectiveTeamName(ids[0]), e.objectiveTeamName(ids[1])}
+	log.Printf("🚩 Objective round: %s vs %s (first to %d)", names[0], names[1], om.cfg.ScoreLimit)
+	if om.OnRoundStart != nil {
+		go om.OnRoundStart(names, om.cfg.ScoreLimit)
+	}
+}
+
+// endObjectiveRound ends the round; winner is the winning side or -1 when a
+// team left the arena. Winning members in the arena are paid. Caller holds e.mu.
+func (e *Engine) endObjectiveRound(winner int) {
+	om := e.objective
+	result := ObjectiveResult{
+		Teams:  [2]string{e.objectiveTeamName(om.teams[0]), e.objectiveTeamName(om.teams[1])},
+		Scores: om.scores,
+		Reason: "abandoned",
+	}
+
+	if winner >= 0 {
+		result.Reason = "score"
+		result.Winner = result.Teams[winner]
+		result.Loser = result.Teams[1-winner]
+		for _, p := range e.playerSlice {
+			if p.TeamID != om.teams[winner] || p.IsBot {
+				continue
+			}
+			if om.cfg.Bonus > 0 && e.wallets.Credit(p.Name, om.cfg.Bonus) {
+				result.Bonus = om.cfg.Bonus
+				result.Paid++
+			}
+		}
+		if len(e.texts) < e.limits.MaxTexts {
+			e.texts = append(e.texts, &FloatingText{
+				X:     om.baseX[winner],
+				Y:     om.baseY - om.cfg.BaseRadius,
+				Text:  "VICTORY!",
+				Color: "#ffd700",
+				Alpha: 1.0,
+				VY:    -1.5,
+			})
+		}
+		e.AddShake(6.0)
+		log.Printf("🏆 %s won the objective round %d-%d (+$%d x%d)", result.Winner, om.scores[winner], om.scores[1-winner], result.Bonus, result.Paid)
+	} else {
+		log.Printf("🚩 Objective round %s vs %s abandoned", result.Teams[0], result.Teams[1])
+	}
+
+	om.active = false
+	om.teams = [2]string{}
+	om.owner = -1
+	om.capturing = -1
+	om.progress = 0
+	om.contested = false
+	om.cooldown = ObjectiveRoundDelay
+	if om.OnRoundEnd != nil {
+		go om.OnRoundEnd(result)
+	}
+}
+
+// spawnAtBase moves a respawned fighter into their team's base. Caller holds e.mu.
+func (e *Engine) spawnAtBase(p *Player) {
+	om := e.objective
+	side := om.side(p.TeamID)
+	if side < 0 {
+		return
+	}
+	angle := e.rng.Float64() * 2 * math.Pi
+	dist := e.rng.Float64() * om.cfg.BaseRadius * 0.6
+	p.X = om.baseX[side] + math.Cos(angle)*dist
+	p.Y = om.baseY + math.Sin(angle)*dist
+}
+
+// GetObjectiveManager returns the team objective mode (for wiring chat announcements)
+func (e *Engine) GetObjectiveManager() *ObjectiveManager {
+	return e.objective
+}
@@ -0,0 +1,162 @@
+package game
+
+import (
+	"testing"
+)
+
+// testObjective is a round to 3 points with a 1s capture
+var testObjective = ObjectiveConfig{ScoreLimit: 3, CaptureTime: 1, PointRadius: 90, BaseRadius: 110, Bonus: 50}
+
+// startObjectiveRound puts two teams of one fighter each in the arena, waits
+// for the round to start and moves both fighters to their own base
+func startObjectiveRound(t *testing.T, engine *Engine) (*Player, *Player) {
+	t.Helper()
+	engine.arenaBotEnabled = false
+
+	players := make([]*Player, 2)
+	for i, name := range []string{"alice", "bob"} {
+		team, err := engine.teamManager.CreateTeam(name, name+"s")
+		if err != nil {
+			t.Fatalf("CreateTeam failed: %v", err)
+		}
+		players[i] = engine.AddPlayer(name, PlayerOptions{})
+		engine.SetPlayerTeam(name, team.ID)
+		players[i].SpawnProtection = false
+		players[i].X, players[i].Y = 100, 100 // Off the capture point
+	}
+	stepEngine(engine, 0.1, engine.updateObjective)
+	if !engine.objective.active {
+		t.Fatal("Expected a round to start with two teams in the arena")
+	}
+
+	// Start everyone in their own base, away from the random spawn
+	for _, p := range players {
+		p.X, p.Y = engine.objective.baseX[sideOf(engine, p)], engine.objective.baseY
+	}
+	return players[0], players[1]
+}
+
+// sideOf returns the side a fighter's team plays on
+func sideOf(e *Engine, p *Player) int {
+	return e.objective.side(p.TeamID)
+}
+
+// TestObjectiveCaptureAndVictory verifies a lone fighter captures the point,
+// scores while holding it and wins the round with a payout
+func TestObjectiveCaptureAndVictory(t *testing.T) {
+	engine := newTestEngineWith(func(cfg *EngineConfig) { cfg.Objective = testObjective })
+	alice, bob := startObjectiveRound(t, engine)
+	ends := make(chan ObjectiveResult, 1)
+	engine.objective.OnRoundEnd = func(r ObjectiveResult) { ends <- r }
+
+	om := engine.objective
+	alice.X, alice.Y = om.pointX, om.pointY
+	bob.X, bob.Y = om.baseX[sideOf(engine, bob)], om.baseY
+
+	stepEngine(engine, 0.5, engine.updateObjective)
+	if snap := engine.objectiveSnapshot(); snap.Capturing != sideOf(engine, alice) || snap.Progress <= 0 {
+		t.Fatalf("Expected alice's team capturing, got %+v", snap)
+	}
+
+	stepEngine(engine, 0.6, engine.updateObjective)
+	if om.owner != sideOf(engine, alice) {
+		t.Fatalf("Expected alice's team to hold the point, owner is %d", om.owner)
+	}
+
+	before := engine.wallets.Balance("alice")
+	stepEngine(engine, 3.1, engine.updateObjective)
+	if om.active {
+		t.Fatal("Expected the round over at the score limit")
+	}
+	r := <-ends
+	if r.Winner != "alices" || r.Loser != "bobs" || r.Reason != "score" {
+		t.Errorf("Expected alices to beat bobs on score, got %+v", r)
+	}
+	if got := engine.wallets.Balance("alice"); got != before+50 || r.Paid != 1 {
+		t.Errorf("Expected alice paid $50 once, balance %d -> %d (paid %d)", before, got, r.Paid)
+	}
+}
+
+// TestObjectiveContested verifies both teams on the point freeze capture progress
+func TestObjectiveContested(t *testing.T) {
+	engine := newTestEngineWith(func(cfg *EngineConfig) { cfg.Objective = testObjective })
+	alice, bob := startObjectiveRound(t, engine)
+	om := engine.objective
+	alice.X, alice.Y = om.pointX, om.pointY
+	stepEngine(engine, 0.5, engine.updateObjective)
+	progress := om.progress
+
+	bob.X, bob.Y = om.pointX+10, om.pointY
+	stepEngine(engine, 1, engine.updateObjective)
+	if !om.contested || om.progress != progress || om.owner != -1 {
+		t.Errorf("Expected contested point frozen at %.2f, got contested=%t progress=%.2f owner=%d",
+			progress, om.contested, om.progress, om.owner)
+	}
+
+	// The defender alone drains the attacker's progress before building its own
+	alice.X = om.baseX[sideOf(engine, alice)]
+	stepEngine(engine, 0.3, engine.updateObjective)
+	if om.capturing != sideOf(engine, alice) || om.progress >= progress {
+		t.Errorf("Expected alice's progress draining, got capturing=%d progress=%.2f", om.capturing, om.progress)
+	}
+}
+
+// TestObjectiveAbandoned verifies the round ends without a winner when a team leaves
+func TestObjectiveAbandoned(t *testing.T) {
+	engine := newTestEngineWith(func(cfg *EngineConfig) { cfg.Objective = testObjective })
+	_, bob := startObjectiveRound(t, engine)
+	ends := make(chan ObjectiveResult, 1)
+	engine.objective.OnRoundEnd = func(r ObjectiveResult) { ends <- r }
+
+	engine.RemovePlayer(bob.Name)
+	stepEngine(engine, 0.1, engine.updateObjective)
+	if r := <-ends; r.Winner != "" || r.Reason != "abandoned" {
+		t.Errorf("Expected an abandoned round, got %+v", r)
+	}
+
+	// No new round during the cooldown, even with two teams back
+	bob = engine.AddPlayer("bob", PlayerOptions{})
+	engine.SetPlayerTeam("bob", engine.teamManager.GetTeamByLeader("bob").ID)
+	stepEngine(engine, 1, engine.updateObjective)
+	if engine.objective.active {
+		t.Error("Expected no round during the cooldown")
+	}
+}
+
+// TestObjectiveBaseHealAndSpawn verifies bases heal their team and respawns land in them
+func TestObjectiveBaseHealAndSpawn(t *testing.T) {
+	engine := newTestEngineWith(func(cfg *EngineConfig) { cfg.Objective = testObjective })
+	alice, bob := startObjectiveRound(t, engine)
+	om := engine.objective
+	side := sideOf(engine, alice)
+
+	alice.X, alice.Y = om.baseX[side], om.baseY
+	alice.HP = 50
+	bob.X, bob.Y = om.baseX[side], om.baseY+20 // Enemies don't heal in another team's base
+	bob.HP = 50
+	stepEngine(engine, 1.05, engine.updateObjective)
+	if alice.HP <= 50 || bob.HP != 50 {
+		t.Errorf("Expected only alice healed in their base, got alice=%d bob=%d", alice.HP, bob.HP)
+	}
+
+	bob.IsDead = true
+	engine.AddPlayer("bob", PlayerOptions{})
+	bobSide := sideOf(engine, bob)
+	if d := bob.distanceTo(&Player{X: om.baseX[bobSide], Y: om.baseY}); d > om.cfg.BaseRadius {
+		t.Errorf("Expected bob to respawn in their base, %.0fpx away", d)
+	}
+}
+
+// TestObjectiveDisabled verifies no round starts with a zero score limit
+func TestObjectiveDisabled(t *testing.T) {
+	engine := NewEngine(DefaultEngineConfig())
+	for _, name := range []string{"alice", "bob"} {
+		team, _ := engine.teamManager.CreateTeam(name, name)
+		engine.AddPlayer(name, PlayerOptions{})
+		engine.SetPlayerTeam(name, team.ID)
+	}
+	stepEngine(engine, 1, engine.updateObjective)
+	if snap := engine.objectiveSnapshot(); snap.Active {
+		t.Errorf("Expected no objective round when disabled, got %+v", snap)
+	}
+}
@@ -0,0 +1,165 @@
+package game
+
+import "math"
+
+// EmitterType defines how an emission places and launches its particles
+type EmitterType int
+
+const (
+	EmitterBurst    EmitterType = iota // All directions from one point (hits, deaths)
+	EmitterFountain                    // Upward cone that falls back under gravity (debris, meteors)
+	EmitterTrail                       // Spread along a line (the path of a projectile)
+)
+
+// ParticleStyle is the look and physics of one kind of particle effect.
+// Speeds and sizes are in pixels, times in ticks.
+type ParticleStyle struct {
+	Emitter EmitterType
+	Count   int    // Particles per emission when the caller passes 0
+	Color   string // Overrides the caller's color if set
+
+	MinSpeed, MaxSpeed float64 // Launch speed (pixels/tick)
+	Spread             float64 // Fountain cone width (radians)
+	Gravity            float64 // Added to VY every tick (pixels/tick²)
+	Drag               float64 // Fraction of velocity lost per tick (0-1)
+
+	Life          float64 // Lifetime in ticks
+	Size, EndSize float64 // Radius at spawn and at death (pixels)
+}
+
+// DefaultParticleStyle is the plain hit burst (the look before emitters existed)
+const DefaultParticleStyle = "hit"
+
+// ParticleStyles are the named particle looks. Weapons pick theirs with
+// WeaponAnimationConfig.ParticleStyle; deaths and chaos events use their own.
+var ParticleStyles = map[string]ParticleStyle{
+	// Plain splash in every direction
+	"hit": {Emitter: EmitterBurst, Count: 2, MinSpeed: 1, MaxSpeed: 4, Drag: 0.03, Life: 45, Size: 2, EndSize: 1},
+	// Blades: fast, short-lived sparks that stop quickly
+	"spark": {Emitter: EmitterBurst, Count: 3, Color: "#ffe08a", MinSpeed: 3, MaxSpeed: 7, Drag: 0.15, Life: 18, Size: 1.5, EndSize: 0.5},
+	// Heavy weapons: chunks thrown up that fall back down
+	"debris": {Emitter: EmitterFountain, Count: 5, MinSpeed: 3, MaxSpeed: 6, Spread: math.Pi / 2, Gravity: 0.35, Drag: 0.02, Life: 40, Size: 3, EndSize: 2},
+	// Arrows: a streak left along the flight path
+	"arrow": {Emitter: EmitterTrail, Count: 4, MinSpeed: 0, MaxSpeed: 1, Drag: 0.1, Life: 25, Size: 1.5, EndSize: 0.5},
+	// Kills: a big slow burst in the victim's color
+	"death": {Emitter: EmitterBurst, Count: 20, MinSpeed: 1, MaxSpeed: 4, Gravity: 0.04, Drag: 0.02, Life: 50, Size: 3, EndSize: 1},
+	// Meteor impacts: embers sprayed upward
+	"meteor": {Emitter: EmitterFountain, Count: 12, Color: "#ff6b00", MinSpeed: 4, MaxSpeed: 8, Spread: math.Pi * 0.6, Gravity: 0.4, Drag: 0.02, Life: 40, Size: 3, EndSize: 1},
+	// Airstrikes: a wide, long-lived fireball
+	"airstrike": {Emitter: EmitterBurst, Count: 40, Color: "#ff3b1f", MinSpeed: 3, MaxSpeed: 10, Gravity: 0.05, Drag: 0.04, Life: 60, Size: 5, EndSize: 1.5},
+}
+
+// GetParticleStyle returns a named style, falling back to the plain hit burst
+func GetParticleStyle(name string) ParticleStyle {
+	if style, ok := ParticleStyles[name]; ok {
+		return style
+	}
+	return ParticleStyles[DefaultParticleStyle]
+}
+
+// particlePool recycles dead particles so combat bursts don't allocate.
+// Only touched under e.mu; never holds more than MaxParticles.
+type particlePool struct {
+	free []*Particle
+}
+
+func (p *particlePool) get() *Particle {
+	if n := len(p.free); n > 0 {
+		particle := p.free[n-1]
+		p.free = p.free[:n-1]
+		return particle
+	}
+	return &Particle{}
+}
+
+func (p *particlePool) put(particle *Particle) {
+	p.free = append(p.free, particle)
+}
+
+// emitParticles spawns count particles (0 = the style's count) of a named
+// style at (x, y). Trail styles are emitted as a point; use emitParticleTrail
+// for a path. Caller holds e.mu.
+func (e *Engine) emitParticles(name string, x, y float64, color string, count int) {
+	e.emitParticleTrail(name, x, y, x, y, color, count)
+}
+
+// emitParticleTrail spawns particles of a named style between (fromX, fromY)
+// and (x, y): spread along the line for trail styles, at (x, y) otherwise.
+// Caller holds e.mu.
+func (e *Engine) emitParticleTrail(name string, fromX, fromY, x, y float64, color string, count int) {
+	style := GetParticleStyle(name)
+	if count <= 0 {
+		count = style.Count
+	}
+	count = e.shedParticles(count)
+	if style.Color != "" {
+		color = style.Color
+	}
+
+	for i := 0; i < count; i++ {
+		// HARD CAP: Prevent DoS via particle flooding
+		if len(e.particles) >= e.limits.MaxParticles {
+			return // Silently drop - this is intentional under attack
+		}
+
+		// Use deterministic RNG for replay consistency
+		px, py := x, y
+		var angle float64
+		switch style.Emitter {
+		case EmitterFountain:
+			angle = -math.Pi/2 + (e.rng.Float64()-0.5)*style.Spread
+		case EmitterTrail:
+			t := e.rng.Float64()
+			px, py = fromX+(x-fromX)*t, fromY+(y-fromY)*t
+			angle = e.rng.Float64() * math.Pi * 2
+		default:
+			angle = e.rng.Float64() * math.Pi * 2
+		}
+		speed := style.MinSpeed + e.rng.Float64()*(style.MaxSpeed-style.MinSpeed)
+
+		p := e.particlePool.get()
+		*p = Particle{
+			X:         px,
+			Y:         py,
+			VX:        math.Cos(angle) * speed,
+			VY:        math.Sin(angle) * speed,
+			Color:     color,
+			Alpha:     1.0,
+			Life:      1.0,
+			Size:      style.Size,
+			decay:     1 / math.Max(style.Life, 1),
+			gravity:   style.Gravity,
+			drag:      style.Drag,
+			startSize: style.Size,
+			endSize:   style.EndSize,
+		}
+		e.particles = append(e.particles, p)
+	}
+}
+
+func (e *Engine) updateParticles() {
+	// REAL-TIME FIX: Zero-allocation in-place filtering
+	// Dead particles go back to the pool for the next burst
+	n := 0
+	for _, p := range e.particles {
+		p.VY += p.gravity
+		p.VX *= 1 - p.drag
+		p.VY *= 1 - p.drag
+		p.X += p.VX
+		p.Y += p.VY
+		p.Life -= p.decay
+		p.Alpha = p.Life
+		p.Size = p.endSize + (p.startSize-p.endSize)*p.Life
+
+		if p.Life > 0 {
+			e.particles[n] = p
+			n++
+		} else {
+			e.particlePool.put(p)
+		}
+	}
+	for i := n; i < len(e.particles); i++ {
+		e.particles[i] = nil
+	}
+	e.particles = e.particles[:n]
+}
@@ -0,0 +1,89 @@
+package game
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// TestEmitParticlesCapped verifies emissions stop at MaxParticles and use the style count
+func TestEmitParticlesCapped(t *testing.T) {
+	engine := newTestEngineWith(func(cfg *EngineConfig) { cfg.Limits.MaxParticles = 30 })
+	engine.rng = rand.New(rand.NewSource(1))
+
+	engine.emitParticles("death", 100, 100, "#ffffff", 0)
+	if got := len(engine.particles); got != ParticleStyles["death"].Count {
+		t.Fatalf("Expected %d death particles, got %d", ParticleStyles["death"].Count, got)
+	}
+	engine.emitParticles("death", 100, 100, "#ffffff", 0)
+	if got := len(engine.particles); got != 30 {
+		t.Errorf("Expected particles capped at 30, got %d", got)
+	}
+}
+
+// TestParticlePoolReuse verifies dead particles are recycled instead of reallocated
+func TestParticlePoolReuse(t *testing.T) {
+	engine := newTestEngineWith(func(cfg *EngineConfig) { cfg.Limits.MaxParticles = 100 })
+	engine.rng = rand.New(rand.NewSource(1))
+	engine.emitParticles("spark", 100, 100, "", 5)
+	first := engine.particles[0]
+
+	for i := 0; i < int(ParticleStyles["spark"].Life)+1; i++ {
+		engine.updateParticles()
+	}
+	if len(engine.particles) != 0 {
+		t.Fatalf("Expected all sparks to expire, %d left", len(engine.particles))
+	}
+	if len(engine.particlePool.free) != 5 {
+		t.Fatalf("Expected 5 pooled particles, got %d", len(engine.particlePool.free))
+	}
+
+	engine.emitParticles("hit", 200, 200, "#ff0000", 5)
+	reused := false
+	for _, p := range engine.particles {
+		if p == first {
+			reused = true
+		}
+		if p.Life != 1 || p.Color != "#ff0000" || p.X != 200 {
+			t.Errorf("Expected recycled particle to be reset, got %+v", *p)
+		}
+	}
+	if !reused {
+		t.Error("Expected a pooled particle to be reused")
+	}
+}
+
+// TestParticleStylePhysics verifies fountains fall back under gravity, trails
+// spread along their path and unknown styles fall back to the hit burst
+func TestParticleStylePhysics(t *testing.T) {
+	engine := newTestEngineWith(func(cfg *EngineConfig) { cfg.Limits.MaxParticles = 100 })
+	engine.rng = rand.New(rand.NewSource(1))
+	engine.emitParticles("meteor", 100, 100, "", 1)
+	p := engine.particles[0]
+	if p.VY >= 0 || p.Color != "#ff6b00" {
+		t.Fatalf("Expected an upward orange meteor particle, got VY=%.2f color=%s", p.VY, p.Color)
+	}
+	for i := 0; i < 30; i++ {
+		engine.updateParticles()
+	}
+	if p.VY <= 0 {
+		t.Errorf("Expected gravity to pull the particle down, VY=%.2f", p.VY)
+	}
+	if p.Size >= ParticleStyles["meteor"].Size {
+		t.Errorf("Expected particle to shrink, size %.2f", p.Size)
+	}
+
+	engine.particles = engine.particles[:0]
+	engine.emitParticleTrail("arrow", 0, 0, 100, 0, "", 20)
+	minX, maxX := math.Inf(1), math.Inf(-1)
+	for _, p := range engine.particles {
+		minX, maxX = math.Min(minX, p.X), math.Max(maxX, p.X)
+	}
+	if maxX-minX < 50 {
+		t.Errorf("Expected trail particles spread along the path, got x %.0f..%.0f", minX, maxX)
+	}
+
+	if GetParticleStyle("nope") != ParticleStyles[DefaultParticleStyle] {
+		t.Error("Expected unknown style to fall back to the hit burst")
+	}
+}
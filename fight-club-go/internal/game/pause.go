@@ -0,0 +1,47 @@
+package game
+
+import (
+	"log"
+	"time"
+)
+
+// Pause freezes the simulation (AI, cooldowns, timers, votes) without stopping the engine.
+// Snapshots keep flowing so the stream stays up and shows a PAUSED overlay.
+// Returns false if the engine was already paused.
+func (e *Engine) Pause() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.paused {
+		return false
+	}
+	e.paused = true
+	e.pausedAt = time.Now()
+
+	e.eventLog.EmitSimple(EventTypePause, uint64(e.tickCount), "", PausePayload{Paused: true})
+	log.Printf("⏸️ Game paused at tick %d", e.tickCount)
+	return true
+}
+
+// Resume continues a paused simulation from the exact tick it was paused at.
+// Returns false if the engine was not paused.
+func (e *Engine) Resume() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if !e.paused {
+		return false
+	}
+	e.paused = false
+
+	e.eventLog.EmitSimple(EventTypeResume, uint64(e.tickCount), "", PausePayload{Paused: false})
+	log.Printf("▶️ Game resumed after %s", time.Since(e.pausedAt).Round(time.Second))
+	return true
+}
+
+// IsPaused returns whether the simulation is paused
+func (e *Engine) IsPaused() bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.paused
+}
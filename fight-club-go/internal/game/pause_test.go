@@ -0,0 +1,53 @@
+package game
+
+import (
+	"testing"
+)
+
+// TestPauseFreezesSimulation verifies ticks, timers and positions freeze while snapshots keep flowing
+func TestPauseFreezesSimulation(t *testing.T) {
+	engine := newTestEngine(30)
+	p := engine.AddPlayer("fighter", PlayerOptions{})
+	engine.AddPlayer("rival", PlayerOptions{})
+	p.CheerTimer = CheerDuration
+
+	if !engine.Pause() {
+		t.Fatal("Pause should succeed")
+	}
+	if engine.Pause() {
+		t.Error("Second pause should be a no-op")
+	}
+
+	tick := engine.tickCount
+	x, y, cheer := p.X, p.Y, p.CheerTimer
+	seq := engine.GetSnapshot().Sequence
+	for i := 0; i < 30; i++ {
+		engine.tick()
+	}
+
+	if engine.tickCount != tick {
+		t.Errorf("Tick count advanced while paused: %d -> %d", tick, engine.tickCount)
+	}
+	if p.X != x || p.Y != y || p.CheerTimer != cheer {
+		t.Error("Player state changed while paused")
+	}
+
+	snap := engine.GetSnapshot()
+	if snap.Sequence == seq {
+		t.Error("Snapshots should keep being produced while paused")
+	}
+	if !snap.Paused {
+		t.Error("Snapshot should be marked paused")
+	}
+
+	if !engine.Resume() || engine.IsPaused() {
+		t.Fatal("Resume should succeed")
+	}
+	engine.tick()
+	if engine.tickCount != tick+1 {
+		t.Errorf("Expected ticks to continue from %d, got %d", tick+1, engine.tickCount)
+	}
+	if engine.GetSnapshot().Paused {
+		t.Error("Snapshot should not be marked paused after resume")
+	}
+}
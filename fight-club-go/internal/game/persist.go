@@ -0,0 +1,60 @@
+package game
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// WriteFileAtomic replaces path with data. It writes a temp file next to path
+// and renames it into place, so a crash mid-save keeps the previous file
+// instead of truncating it.
+func WriteFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), perm); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// QuarantineFile moves a store file that failed to parse aside
+// (<path>.corrupt-<unix time>), so the next save starts a new file instead of
+// overwriting the only copy of the data. Returns where the file went.
+func QuarantineFile(path string) (string, error) {
+	dst := fmt.Sprintf("%s.corrupt-%d", path, time.Now().Unix())
+	if err := os.Rename(path, dst); err != nil {
+		return "", err
+	}
+	return dst, nil
+}
+
+// setAsideCorrupt logs a store file that failed to parse and moves it aside
+// (see QuarantineFile). Returns false if it couldn't be moved: the store must
+// then not save over it.
+func setAsideCorrupt(path, what string, parseErr error) bool {
+	moved, err := QuarantineFile(path)
+	if err != nil {
+		log.Printf("⚠️ Failed to parse saved %s, not saving over it: %v (%v)", what, parseErr, err)
+		return false
+	}
+	log.Printf("⚠️ Failed to parse saved %s (moved to %s): %v", what, moved, parseErr)
+	return true
+}
@@ -0,0 +1,31 @@
+package game
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestWriteFileAtomic verifies the file is replaced whole with its mode and
+// no temp files are left behind
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "store.json")
+	for _, data := range []string{`{"v": 1}`, `{"v": 2}`} {
+		if err := WriteFileAtomic(path, []byte(data), 0600); err != nil {
+			t.Fatalf("WriteFileAtomic failed: %v", err)
+		}
+		if got, _ := os.ReadFile(path); string(got) != data {
+			t.Errorf("Expected %s, got %s", data, got)
+		}
+	}
+	if info, _ := os.Stat(path); info.Mode().Perm() != 0600 {
+		t.Errorf("Expected mode 0600, got %v", info.Mode().Perm())
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("Expected only the store file, got %d entries", len(entries))
+	}
+	if err := WriteFileAtomic(filepath.Join(dir, "missing", "store.json"), nil, 0600); err == nil {
+		t.Error("Expected an error for a missing directory")
+	}
+}
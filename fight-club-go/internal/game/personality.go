@@ -0,0 +1,203 @@
+package game
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// TargetPriority controls how an AI personality picks who to fight
+type TargetPriority string
+
+const (
+	TargetNearest  TargetPriority = "nearest"  // Closest enemy
+	TargetWeakest  TargetPriority = "weakest"  // Lowest HP enemy (picks off the wounded)
+	TargetLeader   TargetPriority = "leader"   // Most kills (goes for the top fighter)
+	TargetAttacker TargetPriority = "attacker" // Whoever hit us last, otherwise nearest
+)
+
+// Personality tuning
+const (
+	DefaultPersonalityID = "berserker"
+	RetaliateWindow      = 3.0 // Seconds a defender remembers its last attacker
+)
+
+// Personality is an AI behavior profile layered on top of weapon stats
+type Personality struct {
+	ID             string         `json:"id"`
+	Name           string         `json:"name"`
+	Emoji          string         `json:"emoji"`
+	Color          string         `json:"color"`          // Player card label color
+	MinAggression  float64        `json:"minAggression"`  // Movement speed multiplier range
+	MaxAggression  float64        `json:"maxAggression"`  //
+	TargetPriority TargetPriority `json:"targetPriority"` // Target selection
+	RetreatHP      float64        `json:"retreatHp"`      // Flee below this HP fraction (0 = never)
+	DodgeChance    float64        `json:"dodgeChance"`    // Per-tick chance to dodge an incoming swing
+	KiteDistance   float64        `json:"kiteDistance"`   // Back off when closer than this fraction of weapon range
+	LeashRange     float64        `json:"leashRange"`     // Only engage within this many px (0 = global search)
+	AimError       float64        `json:"aimError"`       // Max random error of projectile shots (radians)
+}
+
+// Personalities is the registry of all AI personality profiles
+var Personalities = map[string]Personality{
+	"berserker": {
+		ID:             "berserker",
+		Name:           "Berserker",
+		Emoji:          "😡",
+		Color:          "#e74c3c",
+		MinAggression:  0.9,
+		MaxAggression:  1.0,
+		TargetPriority: TargetNearest,
+		RetreatHP:      0,
+		DodgeChance:    0.02,
+		KiteDistance:   0,
+		AimError:       0.15,
+	},
+	"coward": {
+		ID:             "coward",
+		Name:           "Coward",
+		Emoji:          "😱",
+		Color:          "#f1c40f",
+		MinAggression:  0.5,
+		MaxAggression:  0.7,
+		TargetPriority: TargetWeakest,
+		RetreatHP:      0.5,
+		DodgeChance:    0.3,
+		KiteDistance:   0,
+		AimError:       0.2,
+	},
+	"sniper": {
+		ID:             "sniper",
+		Name:           "Sniper",
+		Emoji:          "🎯",
+		Color:          "#3498db",
+		MinAggression:  0.6,
+		MaxAggression:  0.8,
+		TargetPriority: TargetLeader,
+		RetreatHP:      0.25,
+		DodgeChance:    0.15,
+		KiteDistance:   0.6,
+		AimError:       0.03,
+	},
+	"defender": {
+		ID:             "defender",
+		Name:           "Defender",
+		Emoji:          "🛡️",
+		Color:          "#27ae60",
+		MinAggression:  0.6,
+		MaxAggression:  0.9,
+		TargetPriority: TargetAttacker,
+		RetreatHP:      0.2,
+		DodgeChance:    0.1,
+		KiteDistance:   0,
+		LeashRange:     300,
+		AimError:       0.08,
+	},
+}
+
+// personalityIDs is a stable, sorted list for random assignment
+var personalityIDs = func() []string {
+	ids := make([]string, 0, len(Personalities))
+	for id := range Personalities {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}()
+
+// GetPersonality returns a personality by ID, defaulting to berserker
+func GetPersonality(id string) Personality {
+	if p, ok := Personalities[id]; ok {
+		return p
+	}
+	return Personalities[DefaultPersonalityID]
+}
+
+// GetAllPersonalities returns all personalities as a slice
+func GetAllPersonalities() []Personality {
+	personalities := make([]Personality, 0, len(Personalities))
+	for _, id := range personalityIDs {
+		personalities = append(personalities, Personalities[id])
+	}
+	return personalities
+}
+
+// RandomPersonalityID picks a random personality for a new player
+func RandomPersonalityID() string {
+	return personalityIDs[rand.Intn(len(personalityIDs))]
+}
+
+// SetPersonality switches a player's AI profile and re-rolls aggression within its range
+func (p *Player) SetPersonality(id string) {
+	pers := GetPersonality(id)
+	p.Personality = pers.ID
+	p.Aggression = pers.MinAggression + rand.Float64()*(pers.MaxAggression-pers.MinAggression)
+}
+
+// targetScore ranks a candidate target for this player's personality (lower is better)
+func (p *Player) targetScore(pers Personality, other *Player, dist float64) float64 {
+	switch pers.TargetPriority {
+	case TargetWeakest:
+		return float64(other.HP)*10 + dist*0.1
+	case TargetLeader:
+		return -float64(other.Kills)*1000 + dist
+	case TargetAttacker:
+		if p.lastAttackedTimer > 0 && other == p.lastAttacker {
+			return math.Inf(-1)
+		}
+		return dist
+	default:
+		return dist
+	}
+}
+
+// aimAt returns the point a projectile weapon fires at: the victim's
+// intercept point, off by up to the personality's aim error
+func (p *Player) aimAt(victim *Player, rng *rand.Rand) (float64, float64) {
+	x, y := InterceptPoint(p, victim, GetWeaponAnimation(p.Weapon).ProjectileSpeed/20.0)
+	if spread := GetPersonality(p.Personality).AimError; spread > 0 {
+		sin, cos := math.Sincos((rng.Float64()*2 - 1) * spread)
+		dx, dy := x-p.X, y-p.Y
+		x, y = p.X+dx*cos-dy*sin, p.Y+dx*sin+dy*cos
+	}
+	return x, y
+}
+
+// shouldRetreat reports whether the player is too hurt to keep fighting
+func (p *Player) shouldRetreat(pers Personality) bool {
+	return pers.RetreatHP > 0 && float64(p.HP) < float64(p.MaxHP)*pers.RetreatHP
+}
+
+// tryDodge sidesteps an incoming swing based on the personality's dodge chance
+// Returns true if a dodge was started
+func (p *Player) tryDodge(pers Personality, dist float64) bool {
+	if pers.DodgeChance <= 0 || p.Target == nil || !p.Target.IsAttacking {
+		return false
+	}
+	if dist > GetWeapon(p.Target.Weapon).Range*1.2 {
+		return false
+	}
+	if !p.Combat.CanDodge(p.Stamina) || rand.Float64() >= pers.DodgeChance {
+		return false
+	}
+
+	// Dodge perpendicular to the attacker, random side
+	angle := p.AttackAngle + math.Pi/2
+	if rand.Float64() < 0.5 {
+		angle = p.AttackAngle - math.Pi/2
+	}
+	p.Combat.StartDodge(angle)
+	p.Stamina -= DodgeStaminaCost
+	p.IsDodging = true
+	return true
+}
+
+// updateRetaliation ages out the remembered attacker
+func (p *Player) updateRetaliation(deltaTime float64) {
+	if p.lastAttackedTimer > 0 {
+		p.lastAttackedTimer -= deltaTime
+		if p.lastAttackedTimer <= 0 {
+			p.lastAttacker = nil
+		}
+	}
+}
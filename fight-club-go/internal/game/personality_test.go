@@ -0,0 +1,116 @@
+package game
+
+import (
+	"testing"
+)
+
+// TestNewPlayerHasPersonality verifies new players get a valid random profile
+func TestNewPlayerHasPersonality(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		p := NewPlayer("bot", PlayerOptions{})
+		pers, ok := Personalities[p.Personality]
+		if !ok {
+			t.Fatalf("Unknown personality '%s'", p.Personality)
+		}
+		if p.Aggression < pers.MinAggression || p.Aggression > pers.MaxAggression {
+			t.Errorf("%s aggression %.2f outside [%.2f, %.2f]",
+				pers.ID, p.Aggression, pers.MinAggression, pers.MaxAggression)
+		}
+	}
+}
+
+// TestSetPersonality verifies the engine validates and applies styles
+func TestSetPersonality(t *testing.T) {
+	engine := newTestEngine(30)
+	player := engine.AddPlayer("stylist", PlayerOptions{})
+
+	if err := engine.SetPersonality("stylist", "sniper"); err != nil {
+		t.Fatalf("SetPersonality failed: %v", err)
+	}
+	if player.Personality != "sniper" {
+		t.Errorf("Expected 'sniper', got '%s'", player.Personality)
+	}
+	if err := engine.SetPersonality("stylist", "pacifist"); err == nil {
+		t.Error("Expected error for unknown style")
+	}
+	if err := engine.SetPersonality("ghost", "coward"); err == nil {
+		t.Error("Expected error for unknown player")
+	}
+}
+
+// TestPersonalityTargetSelection verifies weakest/leader/attacker priorities
+func TestPersonalityTargetSelection(t *testing.T) {
+	p := NewPlayer("hunter", PlayerOptions{})
+	near := NewPlayer("near", PlayerOptions{})
+	near.HP = 100
+	far := NewPlayer("far", PlayerOptions{})
+	far.HP = 10
+	far.Kills = 5
+
+	pick := func(id string) *Player {
+		pers := Personalities[id]
+		if p.targetScore(pers, near, 50) < p.targetScore(pers, far, 250) {
+			return near
+		}
+		return far
+	}
+
+	if pick("berserker") != near {
+		t.Error("Berserker should pick the nearest target")
+	}
+	if pick("coward") != far {
+		t.Error("Coward should pick the weakest target")
+	}
+	if pick("sniper") != far {
+		t.Error("Sniper should pick the leader")
+	}
+	if pick("defender") != near {
+		t.Error("Defender without an attacker should pick the nearest target")
+	}
+
+	// Defender retaliates against its last attacker
+	p.SpawnProtection = false
+	p.TakeDamage(1, far)
+	if pick("defender") != far {
+		t.Error("Defender should retaliate against its attacker")
+	}
+	p.updateRetaliation(RetaliateWindow + 0.1)
+	if pick("defender") != near {
+		t.Error("Defender should forget its attacker after the window")
+	}
+}
+
+// TestPersonalityRetreatAndDodge verifies retreat thresholds and dodge usage
+func TestPersonalityRetreatAndDodge(t *testing.T) {
+	p := NewPlayer("coward", PlayerOptions{})
+	p.SetPersonality("coward")
+
+	p.HP = p.MaxHP
+	if p.shouldRetreat(GetPersonality("coward")) {
+		t.Error("Healthy coward should not retreat")
+	}
+	p.HP = p.MaxHP / 4
+	if !p.shouldRetreat(GetPersonality("coward")) {
+		t.Error("Hurt coward should retreat")
+	}
+	if p.shouldRetreat(GetPersonality("berserker")) {
+		t.Error("Berserker should never retreat")
+	}
+
+	// Always-dodge profile sidesteps an attacking target in range
+	attacker := NewPlayer("attacker", PlayerOptions{})
+	attacker.IsAttacking = true
+	p.Target = attacker
+	dodger := GetPersonality("coward")
+	dodger.DodgeChance = 1.0
+
+	if !p.tryDodge(dodger, 10) {
+		t.Fatal("Expected dodge against an attacking target")
+	}
+	if p.Stamina != p.MaxStamina-DodgeStaminaCost {
+		t.Errorf("Dodge should cost stamina, got %.1f", p.Stamina)
+	}
+	if p.tryDodge(dodger, 10) {
+		t.Error("Dodge should respect the cooldown")
+	}
+}
@@ -21,7 +21,7 @@ const (
 // Player represents a game player with AI behavior
 type Player struct {
 	ID     string  `json:"id"`
-	Name   string  `json:"name"`
+	Name   string  `json:"name"` // Identity (chat username); never rendered as-is
 	X      float64 `json:"x"`
 	Y      float64 `json:"y"`
 	VX     float64 `json:"vx"`
@@ -35,39 +35,78 @@ type Player struct {
 	Color  string  `json:"color"`
 	Avatar string  `json:"avatar"`
 
+	// HUD-safe name (see DisplayName)
+	DisplayName string `json:"displayName"`
+
 	// Combat state
 	Target         *Player `json:"-"`
 	IsAttacking    bool    `json:"isAttacking"`
 	AttackCooldown float64 `json:"-"`
 	AttackAngle    float64 `json:"attackAngle"`
 
-	// Aggression (personality)
-	Aggression float64 `json:"-"`
+	// Swing animation clock for external overlays (see prediction.go)
+	swingTimer  float64 // Seconds left in the current swing (0 = idle)
+	swingWeapon string  // Weapon the swing started with
+
+	// AI personality (see personality.go)
+	Personality string  `json:"personality"`
+	Aggression  float64 `json:"-"` // Movement speed multiplier, rolled from the personality
+
+	// Seasonal rank tier ID shown as a badge (see rating.go; empty for bots)
+	Rank string `json:"rank"`
+
+	// Equipped cosmetic skins (see skin.go; persisted per viewer)
+	Skins PlayerSkins `json:"skins"`
+
+	// Last attacker (defender retaliation)
+	lastAttacker      *Player
+	lastAttackedTimer float64
 
 	// Death state
 	IsDead          bool    `json:"isDead"`
 	IsRagdoll       bool    `json:"isRagdoll"`
 	RagdollTimer    float64 `json:"-"`
 	RagdollRotation float64 `json:"ragdollRotation"`
+	RingOut         bool    `json:"ringOut"` // Knocked into the pit (see ring_out.go)
+	fallTime        float64 // Seconds into the ring-out fall
+	deadTime        float64 // Seconds dead (a queued viewer may take the slot, see join_queue.go)
 
 	// Protection
 	SpawnProtection bool    `json:"spawnProtection"`
 	SpawnTimer      float64 `json:"-"`
 
+	// Seconds alive since the last (re)spawn (survival quest)
+	AliveTime float64 `json:"-"`
+
+	// Kills and damage dealt since the last (re)spawn (!last, see career.go)
+	lifeKills  int
+	lifeDamage int
+
+	// Kills in the current multikill chain and the tick of the last one (see highlight.go)
+	chainKills    int
+	chainKillTick int64
+
 	// Stun state
 	IsStunned bool    `json:"isStunned"`
 	StunTimer float64 `json:"-"`
 
+	// Moderator !freeze (see moderation.go)
+	FreezeTimer float64 `json:"-"`
+
 	// Profile
 	ProfilePic string `json:"profilePic"`
 
 	// Advanced combat state (combos, dodge)
 	Combat CombatState `json:"-"`
 
-	// Stamina for dodge (regenerates over time)
+	// Stamina for dodge and sprint (regenerates over time)
 	Stamina    float64 `json:"stamina"`
 	MaxStamina float64 `json:"-"`
 
+	// Sprint state (see sprint.go)
+	IsSprinting bool `json:"isSprinting"`
+	IsExhausted bool `json:"isExhausted"`
+
 	// Dodge state (for rendering)
 	IsDodging bool `json:"isDodging"`
 
@@ -81,10 +120,45 @@ type Player struct {
 	// Team membership
 	TeamID string `json:"teamId"`
 
+	// Bot fill AI (see botfill.go) - not a viewer
+	IsBot bool `json:"isBot"`
+
+	// Spawned by a game script (see script.go) - a bot that leaves when killed
+	IsBoss bool `json:"isBoss"`
+
+	// Fighting in the duel ring (see duel.go) - only duelists can hit each other
+	InDuel bool `json:"inDuel"`
+
 	// Chat bubble (visible above player)
 	ChatBubble    string  `json:"chatBubble"`
 	ChatBubbleTTL float64 `json:"-"`
 
+	// Emote/taunt state (see emote.go)
+	Emote         string  `json:"emote"`
+	EmoteTimer    float64 `json:"-"`
+	EmoteCooldown float64 `json:"-"`
+
+	// Spectator buffs/debuffs (see spectator.go)
+	CheerTimer float64 `json:"-"`
+	CurseTimer float64 `json:"-"`
+
+	// Spectator rate limiting (engine ticks)
+	spectateReadyTick    int64   // Next tick this player may !cheer/!curse while dead
+	spectatorEffectTicks []int64 // Ticks this player received cheer/curse (per-target limit)
+
+	// Team leader orders (see team_orders.go)
+	RallyTimer         float64 `json:"-"` // Damage buff
+	RegroupTimer       float64 `json:"-"` // Heading for the regroup goal
+	RegroupX, RegroupY float64 `json:"-"`
+
+	// Weapon shop order (see shop.go)
+	ShopOrder string  `json:"-"` // Weapon ID to pick up ("" = none)
+	ShopIndex int     `json:"-"` // Shop stocking it
+	ShopTimer float64 `json:"-"` // Seconds left to reach the shop
+
+	// Active arena modifier (set by the engine each tick, see modifier.go)
+	modifier string
+
 	// World bounds (stored for consistent bounds clamping)
 	worldWidth  float64
 	worldHeight float64
@@ -125,9 +199,10 @@ func NewPlayer(name string, opts PlayerOptions) *Player {
 		worldHeight = 720
 	}
 
-	return &Player{
+	player := &Player{
 		ID:              id,
 		Name:            name,
+		DisplayName:     DisplayName(name),
 		X:               rand.Float64() * worldWidth,
 		Y:               rand.Float64() * worldHeight,
 		HP:              100,
@@ -138,7 +213,6 @@ func NewPlayer(name string, opts PlayerOptions) *Player {
 		Avatar:          avatars[rand.Intn(len(avatars))],
 		SpawnProtection: true,
 		SpawnTimer:      0.3, // Reduced to 0.3s for instant combat (was 1.5)
-		Aggression:      0.5 + rand.Float64()*0.5, // 0.5 to 1.0
 		ProfilePic:      opts.ProfilePic,
 		Stamina:         MaxStamina,
 		MaxStamina:      MaxStamina,
@@ -146,6 +220,8 @@ func NewPlayer(name string, opts PlayerOptions) *Player {
 		worldWidth:      worldWidth,
 		worldHeight:     worldHeight,
 	}
+	player.SetPersonality(RandomPersonalityID())
+	return player
 }
 
 // Update updates the player state each tick
@@ -161,13 +237,9 @@ func (p *Player) Update(players []*Player, selfIdx uint32, grid *spatial.Spatial
 	p.Combat.UpdateTimers()
 	p.IsDodging = p.Combat.IsDodging
 
-	// Stamina regeneration
-	if p.Stamina < p.MaxStamina {
-		p.Stamina += StaminaRegenRate * deltaTime
-		if p.Stamina > p.MaxStamina {
-			p.Stamina = p.MaxStamina
-		}
-	}
+	// Stamina drain (last tick's sprint) or regeneration
+	p.updateStamina(deltaTime)
+	p.IsSprinting = false // Re-decided by combatBehavior each tick
 
 	// Apply dodge velocity if dodging
 	if p.Combat.IsDodging {
@@ -177,6 +249,13 @@ func (p *Player) Update(players []*Player, selfIdx uint32, grid *spatial.Spatial
 	}
 
 	// Update timers
+	p.updateEmote(deltaTime)
+	p.updateChatBubble(deltaTime)
+	p.updateRetaliation(deltaTime)
+	p.updateSpectatorEffects(deltaTime)
+	p.updateTeamOrders(deltaTime)
+	p.updateSwing(deltaTime)
+
 	if p.SpawnTimer > 0 {
 		p.SpawnTimer -= deltaTime
 		if p.SpawnTimer <= 0 {
@@ -184,6 +263,10 @@ func (p *Player) Update(players []*Player, selfIdx uint32, grid *spatial.Spatial
 		}
 	}
 
+	if p.updateFreeze(deltaTime) {
+		return // Frozen by a moderator
+	}
+
 	if p.StunTimer > 0 {
 		p.StunTimer -= deltaTime
 		if p.StunTimer <= 0 {
@@ -197,19 +280,24 @@ func (p *Player) Update(players []*Player, selfIdx uint32, grid *spatial.Spatial
 	}
 
 	// Find target using spatial grid (O(k) instead of O(n))
-	// Pass playerMap for O(1) focus target lookup if available
-	p.findTarget(players, selfIdx, grid, playerMap...)
+	// Pass playerMap for O(1) focus target lookup if available.
+	// Far-away fighters keep their target a few ticks under load.
+	if !engine.deferRetarget(p, selfIdx) {
+		p.findTarget(players, selfIdx, grid, playerMap...)
+	}
 
-	// AI behavior
-	if p.Target != nil {
-		p.combatBehavior(deltaTime, engine)
-	} else {
-		p.wander(deltaTime)
+	// AI behavior (a team regroup order, then a shop order, takes precedence over far-away targets)
+	if !p.regroup(deltaTime, engine) && !p.goShopping(deltaTime, engine) {
+		if p.Target != nil {
+			p.combatBehavior(deltaTime, engine)
+		} else {
+			p.wander(deltaTime)
+		}
 	}
 
-	// Apply velocity with speed limit
+	// Apply velocity with speed limit (scaled by cheer/curse)
 	speed := math.Sqrt(p.VX*p.VX + p.VY*p.VY)
-	maxSpeed := 6.0
+	maxSpeed := 6.0 * p.speedMultiplier()
 	if speed > maxSpeed {
 		p.VX = (p.VX / speed) * maxSpeed
 		p.VY = (p.VY / speed) * maxSpeed
@@ -218,12 +306,13 @@ func (p *Player) Update(players []*Player, selfIdx uint32, grid *spatial.Spatial
 	p.X += p.VX
 	p.Y += p.VY
 
-	// Friction
-	p.VX *= 0.85
-	p.VY *= 0.85
+	// Friction (lower under low gravity)
+	friction := p.friction()
+	p.VX *= friction
+	p.VY *= friction
 
 	// World bounds (use stored bounds with margin)
-	margin := 40.0
+	margin := EdgeMargin
 	p.X = math.Max(margin, math.Min(p.worldWidth-margin, p.X))
 	p.Y = math.Max(margin, math.Min(p.worldHeight-margin, p.Y))
 }
@@ -249,7 +338,7 @@ func (p *Player) findTarget(players []*Player, selfIdx uint32, grid *spatial.Spa
 			}
 		}
 
-		if focusedPlayer != nil && !focusedPlayer.IsDead && !focusedPlayer.IsRagdoll {
+		if focusedPlayer != nil && !focusedPlayer.IsDead && !focusedPlayer.IsRagdoll && !duelSeparated(p, focusedPlayer) {
 			// NOTE: Allows targeting spawn-protected - approach immediately
 			// Also check team - can't focus teammates
 			if p.TeamID == "" || p.TeamID != focusedPlayer.TeamID {
@@ -262,13 +351,21 @@ func (p *Player) findTarget(players []*Player, selfIdx uint32, grid *spatial.Spa
 		p.FocusTTL = 0
 	}
 
-	// Priority 2: Closest valid target using spatial grid (O(k) instead of O(n))
+	// Priority 2: Best valid target for our personality using spatial grid (O(k) instead of O(n))
+	pers := GetPersonality(p.Personality)
 	var closest *Player
-	minDist := math.MaxFloat64
+	minScore := math.MaxFloat64
 
 	// First try nearby detection range for immediate combat
-	const combatRange = 300.0 // Immediate combat detection
-	candidates := grid.QueryRadius(p.X, p.Y, combatRange)
+	detectRange := 300.0 // Immediate combat detection
+	if pers.LeashRange > 0 {
+		detectRange = pers.LeashRange
+	}
+	fogged := p.modifier == ModFog
+	if fogged {
+		detectRange = math.Min(detectRange, FogDetectionRange)
+	}
+	candidates := grid.QueryRadius(p.X, p.Y, detectRange)
 
 	for _, idx := range candidates {
 		if idx == selfIdx {
@@ -284,18 +381,24 @@ func (p *Player) findTarget(players []*Player, selfIdx uint32, grid *spatial.Spa
 		if p.TeamID != "" && p.TeamID == other.TeamID {
 			continue
 		}
+		// The duel ring is off limits in both directions
+		if duelSeparated(p, other) {
+			continue
+		}
 
-		dist := p.distanceTo(other)
-		if dist < minDist {
-			minDist = dist
+		score := p.targetScore(pers, other, p.distanceTo(other))
+		if score < minScore {
+			minScore = score
 			closest = other
 		}
 	}
 
 	// If no nearby target found, do GLOBAL search for exploration
 	// This ensures players always find someone to fight
-	if closest == nil {
-		minDist = math.MaxFloat64
+	// Leashed personalities (defender) hold position instead of roaming
+	// Fog hides everyone out of sight range
+	if closest == nil && pers.LeashRange == 0 && !fogged {
+		minScore = math.MaxFloat64
 		for i, other := range players {
 			if uint32(i) == selfIdx {
 				continue
@@ -308,10 +411,13 @@ func (p *Player) findTarget(players []*Player, selfIdx uint32, grid *spatial.Spa
 			if p.TeamID != "" && p.TeamID == other.TeamID {
 				continue
 			}
+			if duelSeparated(p, other) {
+				continue
+			}
 
-			dist := p.distanceTo(other)
-			if dist < minDist {
-				minDist = dist
+			score := p.targetScore(pers, other, p.distanceTo(other))
+			if score < minScore {
+				minScore = score
 				closest = other
 			}
 		}
@@ -344,6 +450,24 @@ func (p *Player) combatBehavior(deltaTime float64, engine *Engine) {
 	// Always face target first
 	p.AttackAngle = math.Atan2(dy, dx)
 
+	pers := GetPersonality(p.Personality)
+
+	// DODGE: Sidestep incoming swings (chance depends on personality)
+	if p.tryDodge(pers, dist) {
+		return
+	}
+
+	// RETREAT: Hurt players of cautious personalities flee, swinging only if cornered
+	if p.shouldRetreat(pers) {
+		if dist <= attackRange && canAttack {
+			p.attack(engine)
+		}
+		moveSpeed := 5.0 * p.Aggression * p.speedMultiplier()
+		p.VX -= dx * moveSpeed * deltaTime * 60
+		p.VY -= dy * moveSpeed * deltaTime * 60
+		return
+	}
+
 	// IMMEDIATE ATTACK: In range and ready - highest priority
 	if dist <= attackRange && canAttack {
 		p.attack(engine)
@@ -353,10 +477,18 @@ func (p *Player) combatBehavior(deltaTime float64, engine *Engine) {
 		return
 	}
 
+	// SPRINT: Close long gaps fast, burning stamina
+	p.IsSprinting = p.canSprint(dist, attackRange)
+
 	// MOVEMENT LOGIC: No dead zones - always moving toward optimal position
-	moveSpeed := 5.0 * p.Aggression
+	moveSpeed := 5.0 * p.Aggression * p.speedMultiplier()
 	minCombatDist := 40.0 // Minimum distance to maintain (avoids clipping)
 
+	// Kiting personalities (sniper) hold the edge of their weapon range
+	if pers.KiteDistance > 0 && attackRange*pers.KiteDistance > minCombatDist {
+		minCombatDist = attackRange * pers.KiteDistance
+	}
+
 	if dist < minCombatDist {
 		// TOO CLOSE - back up slightly while strafing
 		perpX := -dy
@@ -448,6 +580,7 @@ func (p *Player) attack(engine *Engine) {
 
 	p.AttackCooldown = weapon.Cooldown
 	p.IsAttacking = true
+	p.startSwing()
 	p.AttackAngle = math.Atan2(p.Target.Y-p.Y, p.Target.X-p.X)
 
 	// Apply lunge motion toward target (weapon-specific)
@@ -495,8 +628,17 @@ func (p *Player) TakeDamage(amount int, attacker *Player) {
 		return
 	}
 
+	if attacker != nil {
+		attacker.lifeDamage += min(amount, p.HP) // Overkill doesn't count
+	}
 	p.HP -= amount
 
+	// Remember who hit us (defender retaliation)
+	if attacker != nil {
+		p.lastAttacker = attacker
+		p.lastAttackedTimer = RetaliateWindow
+	}
+
 	// Weapon-specific knockback and stun
 	if attacker != nil {
 		anim := GetWeaponAnimation(attacker.Weapon)
@@ -540,10 +682,19 @@ func (p *Player) die(killer *Player) {
 	p.RagdollTimer = 4.0 // 4 seconds ragdoll animation
 	p.Deaths++
 	p.Target = nil
+	p.RingOut = false
+	p.fallTime = 0
 
 	// Clear focus on death
 	p.FocusTarget = ""
 	p.FocusTTL = 0
+	p.clearEmote()
+	p.clearChatBubble()
+	p.clearSpectatorEffects()
+	p.clearTeamOrders()
+	p.clearShopOrder()
+	p.lastAttacker = nil
+	p.lastAttackedTimer = 0
 
 	// Random spin direction
 	p.RagdollRotation = 0
@@ -563,13 +714,17 @@ func (p *Player) UpdateRagdoll(deltaTime float64) {
 	// Apply velocity with friction
 	p.X += p.VX
 	p.Y += p.VY
-	p.VX *= 0.92
-	p.VY *= 0.92
+	p.VX *= p.ragdollFriction()
+	p.VY *= p.ragdollFriction()
 
-	// World bounds (use stored bounds with margin)
-	margin := 40.0
-	p.X = math.Max(margin, math.Min(p.worldWidth-margin, p.X))
-	p.Y = math.Max(margin, math.Min(p.worldHeight-margin, p.Y))
+	// World bounds (use stored bounds with margin); a ring-out falls into the pit past it
+	if p.RingOut {
+		p.updateFall(deltaTime)
+	} else {
+		margin := EdgeMargin
+		p.X = math.Max(margin, math.Min(p.worldWidth-margin, p.X))
+		p.Y = math.Max(margin, math.Min(p.worldHeight-margin, p.Y))
+	}
 
 	// Timer - ragdoll animation complete
 	p.RagdollTimer -= deltaTime
@@ -585,6 +740,9 @@ func (p *Player) UpdateRagdoll(deltaTime float64) {
 func (p *Player) Respawn() {
 	p.IsDead = false
 	p.IsRagdoll = false
+	p.RingOut = false
+	p.fallTime = 0
+	p.deadTime = 0
 	p.State = StateAlive
 	p.HP = p.MaxHP
 	// Spawn within 80% of world bounds (10% margin on each side)
@@ -592,25 +750,51 @@ func (p *Player) Respawn() {
 	p.Y = rand.Float64()*p.worldHeight*0.8 + p.worldHeight*0.1
 	p.VX = 0
 	p.VY = 0
+	p.FreezeTimer = 0
 	p.SpawnProtection = true
 	p.SpawnTimer = 0.5 // Reduced to 0.5s for fast combat (was 3.0)
+	p.AliveTime = 0
+	p.lifeKills = 0
+	p.lifeDamage = 0
 	p.Target = nil
 	p.RagdollRotation = 0
 	p.AttackCooldown = 0
-	p.Stamina = p.MaxStamina
+	p.swingTimer = 0
+	p.resetStamina()
 	p.Combat.Reset()
 	p.IsDodging = false
 	// Clear focus on respawn
 	p.FocusTarget = ""
 	p.FocusTTL = 0
+	p.clearEmote()
+	p.clearChatBubble()
+	p.lastAttacker = nil
+	p.lastAttackedTimer = 0
 }
 
 // ResolveCollisions resolves collisions with nearby players using spatial grid
 // selfIdx: index of this player in the players slice
 // grid: spatial grid for O(1) neighbor queries
+// updateChatBubble counts down the chat bubble and hides it when it expires
+func (p *Player) updateChatBubble(deltaTime float64) {
+	if p.ChatBubbleTTL > 0 {
+		p.ChatBubbleTTL -= deltaTime
+		if p.ChatBubbleTTL <= 0 {
+			p.ChatBubble = ""
+			p.ChatBubbleTTL = 0
+		}
+	}
+}
+
+// clearChatBubble hides the chat bubble (death/respawn)
+func (p *Player) clearChatBubble() {
+	p.ChatBubble = ""
+	p.ChatBubbleTTL = 0
+}
+
 func (p *Player) ResolveCollisions(players []*Player, selfIdx uint32, grid *spatial.SpatialGrid) {
-	const radius = 28.0
-	const collisionRadius = radius * 2 // 56px - diameter for collision detection
+	radius := 28.0 * p.sizeScale()
+	collisionRadius := radius * 2 // 56px - diameter for collision detection
 
 	// Query only nearby entities (collision radius + buffer)
 	candidates := grid.QueryRadius(p.X, p.Y, collisionRadius+10)
@@ -660,9 +844,11 @@ func (p *Player) distanceTo(other *Player) float64 {
 
 // ToJSON returns a map representation for JSON serialization
 func (p *Player) ToJSON() map[string]interface{} {
+	phase, phaseLeft := p.AttackPhase()
 	return map[string]interface{}{
 		"id":              p.ID,
 		"name":            p.Name,
+		"displayName":     p.DisplayName,
 		"x":               p.X,
 		"y":               p.Y,
 		"vx":              p.VX,
@@ -677,6 +863,9 @@ func (p *Player) ToJSON() map[string]interface{} {
 		"avatar":          p.Avatar,
 		"isAttacking":     p.IsAttacking,
 		"attackAngle":     p.AttackAngle,
+		"attackPhase":     phase.String(),
+		"attackPhaseLeft": phaseLeft,
+		"attackCooldown":  max(p.AttackCooldown, 0),
 		"isDead":          p.IsDead,
 		"isRagdoll":       p.IsRagdoll,
 		"ragdollRotation": p.RagdollRotation,
@@ -686,5 +875,12 @@ func (p *Player) ToJSON() map[string]interface{} {
 		"stamina":         p.Stamina,
 		"isDodging":       p.IsDodging,
 		"comboCount":      p.Combat.ComboCount,
+		"personality":     p.Personality,
+		"rank":            p.Rank,
+		"skins":           p.Skins,
+		"cheered":         p.CheerTimer > 0,
+		"cursed":          p.CurseTimer > 0,
+		"isBot":           p.IsBot,
+		"teamId":          p.TeamID,
 	}
 }
@@ -0,0 +1,55 @@
+package game
+
+// PredictionWindow is how far ahead (seconds) external overlays may
+// extrapolate a fighter from its last polled position: x + vx*tickRate*t.
+// VX/VY are pixels per tick; friction and AI steering bend real paths, so
+// clients should stop extrapolating past the window and wait for fresh state.
+const PredictionWindow = 0.25
+
+// animTickRate is the tick rate weapon animation timings are authored at (see animation.go)
+const animTickRate = 20.0
+
+// attackPhaseNames are the API names of the attack phases
+var attackPhaseNames = [...]string{"idle", "windup", "active", "recovery"}
+
+// String returns the API name of an attack phase
+func (ph AttackPhase) String() string {
+	if ph < 0 || int(ph) >= len(attackPhaseNames) {
+		return "idle"
+	}
+	return attackPhaseNames[ph]
+}
+
+// startSwing starts the attack animation clock for the current weapon
+func (p *Player) startSwing() {
+	anim := GetWeaponAnimation(p.Weapon)
+	p.swingWeapon = p.Weapon
+	p.swingTimer = anim.TotalAttackDuration()
+}
+
+// updateSwing advances the attack animation clock
+func (p *Player) updateSwing(deltaTime float64) {
+	if p.swingTimer > 0 {
+		p.swingTimer = max(p.swingTimer-deltaTime, 0)
+	}
+}
+
+// AttackPhase returns the fighter's swing phase and the seconds left in it,
+// so overlays can time swing animations between polls
+func (p *Player) AttackPhase() (AttackPhase, float64) {
+	if p.swingTimer <= 0 {
+		return PhaseIdle, 0
+	}
+	anim := GetWeaponAnimation(p.swingWeapon)
+	windUp := float64(anim.WindUpTicks) / animTickRate
+	active := float64(anim.ActiveTicks) / animTickRate
+	elapsed := anim.TotalAttackDuration() - p.swingTimer
+
+	switch {
+	case elapsed < windUp:
+		return PhaseWindUp, windUp - elapsed
+	case elapsed < windUp+active:
+		return PhaseActive, windUp + active - elapsed
+	}
+	return PhaseRecovery, p.swingTimer
+}
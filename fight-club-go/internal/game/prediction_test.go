@@ -0,0 +1,56 @@
+package game
+
+import (
+	"math"
+	"testing"
+)
+
+// TestAttackPhaseProgression verifies the swing clock walks wind-up, active and recovery
+func TestAttackPhaseProgression(t *testing.T) {
+	p := &Player{Weapon: "sword"}
+	if phase, left := p.AttackPhase(); phase != PhaseIdle || left != 0 {
+		t.Fatalf("Expected idle before swinging, got %s %.2f", phase, left)
+	}
+
+	// Sword: 3 wind-up, 3 active, 4 recovery ticks at 20 TPS
+	p.startSwing()
+	for _, step := range []struct {
+		advance float64
+		phase   AttackPhase
+		left    float64
+	}{
+		{0, PhaseWindUp, 0.15},
+		{0.05, PhaseWindUp, 0.10},
+		{0.15, PhaseActive, 0.10},
+		{0.15, PhaseRecovery, 0.15},
+		{0.20, PhaseIdle, 0},
+	} {
+		p.updateSwing(step.advance)
+		phase, left := p.AttackPhase()
+		if phase != step.phase || math.Abs(left-step.left) > 1e-9 {
+			t.Errorf("Expected %s with %.2fs left, got %s with %.2fs", step.phase, step.left, phase, left)
+		}
+	}
+}
+
+// TestAttackPhaseInSnapshot verifies the swing timing reaches the snapshot
+func TestAttackPhaseInSnapshot(t *testing.T) {
+	engine := newTestEngine(30)
+	p := engine.AddPlayer("viewer1", PlayerOptions{})
+	p.Weapon = "sword"
+	p.startSwing()
+	p.AttackCooldown = 0.5
+
+	engine.ProduceSnapshot()
+	snap := engine.GetSnapshot()
+	if len(snap.Players) != 1 {
+		t.Fatalf("Expected 1 player in snapshot, got %d", len(snap.Players))
+	}
+	got := snap.Players[0]
+	if got.AttackPhase != PhaseWindUp || got.AttackPhaseLeft <= 0 || got.AttackCooldown != 0.5 {
+		t.Errorf("Expected wind-up with 0.5s cooldown, got %s %.2f %.2f", got.AttackPhase, got.AttackPhaseLeft, got.AttackCooldown)
+	}
+	if got.AttackPhase.String() != "windup" {
+		t.Errorf("Expected phase name windup, got %q", got.AttackPhase.String())
+	}
+}
@@ -14,9 +14,17 @@ type Projectile struct {
 	Owner     *Player // Direct reference to owner - avoids O(n) lookup
 
 	// Position and motion
-	X, Y   float64 // Current position
-	VX, VY float64 // Velocity (pixels per tick at 20 TPS)
-	Speed  float64 // Speed magnitude
+	X, Y    float64 // Current position
+	VX, VY  float64 // Velocity (pixels per tick at 20 TPS)
+	Speed   float64 // Speed magnitude
+	Gravity float64 // Added to VY every tick (pixels/tick², 0 = straight line)
+
+	// World interaction
+	BoundsW, BoundsH float64  // World size for bounces and removal
+	Bounces          int      // Remaining rebounds off the world bounds
+	BounceDamping    float64  // Fraction of velocity kept per bounce
+	Pierce           int      // Remaining extra targets after the next hit
+	HitIDs           []string // Players already hit (never hit twice)
 
 	// Combat
 	Damage    int     // Damage dealt on hit
@@ -41,6 +49,14 @@ const (
 	ProjectileLifetime = 60   // 3 seconds at 20 TPS
 	ProjectileRadius   = 8.0  // Collision radius
 	PlayerRadius       = 28.0 // Player hitbox radius
+
+	defaultBounceDamping = 0.7    // Speed kept per bounce when the weapon doesn't set one
+	defaultBoundsW       = 1920.0 // World size until the engine sets the real one
+	defaultBoundsH       = 1080.0
+	boundsMargin         = 50.0 // Non-bouncing projectiles are removed this far outside the world
+
+	projectileMuzzle = 40.0 // Projectiles spawn this far from the shooter's center
+	leadIterations   = 3    // Refinements of the intercept point (each re-times the flight)
 )
 
 // NewProjectile creates a new projectile aimed at a target
@@ -62,30 +78,69 @@ func NewProjectile(owner *Player, targetX, targetY float64, damage int, tickCoun
 
 	// Speed is in pixels/second, convert to pixels/tick at 20 TPS
 	speedPerTick := anim.ProjectileSpeed / 20.0
+	gravityPerTick := anim.ProjectileGravity / (20.0 * 20.0)
 
 	// Start projectile at player's edge (not center) in the direction of fire
-	startX := owner.X + dirX*40
-	startY := owner.Y + dirY*40
+	startX := owner.X + dirX*projectileMuzzle
+	startY := owner.Y + dirY*projectileMuzzle
+
+	vx := dirX * speedPerTick
+	vy := dirY * speedPerTick
+	if gravityPerTick != 0 && speedPerTick > 0 {
+		// Lob: keep the flight time of a straight shot, but launch upward so
+		// gravity brings the projectile down onto the target
+		ticks := math.Max(1, (dist-projectileMuzzle)/speedPerTick)
+		vy = (targetY-startY)/ticks - 0.5*gravityPerTick*(ticks-1)
+	}
+
+	damping := anim.ProjectileBounce
+	if damping <= 0 {
+		damping = defaultBounceDamping
+	}
 
 	return &Projectile{
-		ID:        fmt.Sprintf("proj_%d_%s", tickCount, owner.ID),
-		OwnerID:   owner.ID,
-		OwnerName: owner.Name,
-		Owner:     owner, // Store direct reference - avoids O(n) lookup
-		X:         startX,
-		Y:         startY,
-		VX:        dirX * speedPerTick,
-		VY:        dirY * speedPerTick,
-		Speed:     speedPerTick,
-		Damage:    damage,
-		HitRadius: ProjectileRadius,
-		Color:     weapon.Color,
-		Rotation:  math.Atan2(dy, dx),
-		Timer:     ProjectileLifetime,
-		TrailIdx:  0,
+		ID:            fmt.Sprintf("proj_%d_%s", tickCount, owner.ID),
+		OwnerID:       owner.ID,
+		OwnerName:     owner.Name,
+		Owner:         owner, // Store direct reference - avoids O(n) lookup
+		X:             startX,
+		Y:             startY,
+		VX:            vx,
+		VY:            vy,
+		Speed:         speedPerTick,
+		Gravity:       gravityPerTick,
+		BoundsW:       defaultBoundsW,
+		BoundsH:       defaultBoundsH,
+		Bounces:       anim.ProjectileBounces,
+		BounceDamping: damping,
+		Pierce:        anim.ProjectilePierce,
+		Damage:        damage,
+		HitRadius:     ProjectileRadius,
+		Color:         weapon.Color,
+		Rotation:      math.Atan2(vy, vx),
+		Timer:         ProjectileLifetime,
+		TrailIdx:      0,
 	}
 }
 
+// InterceptPoint returns where a shooter must aim a projectile of speed
+// (pixels/tick) to meet a target that keeps its current velocity. Targets
+// standing still, or too far to reach in a projectile's lifetime, are led no
+// further than the lifetime allows.
+func InterceptPoint(shooter, target *Player, speed float64) (float64, float64) {
+	x, y := target.X, target.Y
+	if speed <= 0 || (target.VX == 0 && target.VY == 0) {
+		return x, y
+	}
+	for i := 0; i < leadIterations; i++ {
+		ticks := math.Max(0, math.Hypot(x-shooter.X, y-shooter.Y)-projectileMuzzle) / speed
+		ticks = math.Min(ticks, ProjectileLifetime)
+		x = target.X + target.VX*ticks
+		y = target.Y + target.VY*ticks
+	}
+	return x, y
+}
+
 // Update moves the projectile and decrements its lifetime
 // Returns false if the projectile should be removed
 func (p *Projectile) Update(deltaTime float64) bool {
@@ -98,11 +153,25 @@ func (p *Projectile) Update(deltaTime float64) bool {
 	p.X += p.VX
 	p.Y += p.VY
 
+	// Gravity curves lobbed projectiles (arrow keeps pointing along its path)
+	if p.Gravity != 0 {
+		p.VY += p.Gravity
+		p.Rotation = math.Atan2(p.VY, p.VX)
+	}
+
 	// Decrease lifetime
 	p.Timer--
 
+	// Rebound off the world edges while bounces remain
+	if p.Bounces > 0 && p.bounce() {
+		p.Bounces--
+		p.VX *= p.BounceDamping
+		p.VY *= p.BounceDamping
+		p.Rotation = math.Atan2(p.VY, p.VX)
+	}
+
 	// Check if out of bounds or expired
-	if p.X < -50 || p.X > 1970 || p.Y < -50 || p.Y > 1130 {
+	if p.X < -boundsMargin || p.X > p.BoundsW+boundsMargin || p.Y < -boundsMargin || p.Y > p.BoundsH+boundsMargin {
 		return false // Remove - out of bounds
 	}
 
@@ -113,6 +182,33 @@ func (p *Projectile) Update(deltaTime float64) bool {
 	return true // Keep alive
 }
 
+// bounce reflects the projectile off any world edge it crossed, returns true if it bounced
+func (p *Projectile) bounce() bool {
+	bounced := false
+	if p.X < 0 || p.X > p.BoundsW {
+		p.X = math.Max(0, math.Min(p.BoundsW, p.X))
+		p.VX = -p.VX
+		bounced = true
+	}
+	if p.Y < 0 || p.Y > p.BoundsH {
+		p.Y = math.Max(0, math.Min(p.BoundsH, p.Y))
+		p.VY = -p.VY
+		bounced = true
+	}
+	return bounced
+}
+
+// RegisterHit records a hit on target and returns true if the projectile
+// pierces through it (keeps flying) instead of being consumed
+func (p *Projectile) RegisterHit(target *Player) bool {
+	p.HitIDs = append(p.HitIDs, target.ID)
+	if p.Pierce <= 0 {
+		return false
+	}
+	p.Pierce--
+	return true
+}
+
 // CheckHit tests if this projectile collides with a player
 // Returns true if collision detected
 func (p *Projectile) CheckHit(target *Player) bool {
@@ -126,6 +222,18 @@ func (p *Projectile) CheckHit(target *Player) bool {
 		return false
 	}
 
+	// Arrows pass through the duel ring (both ways)
+	if p.Owner != nil && duelSeparated(p.Owner, target) {
+		return false
+	}
+
+	// Piercing projectiles hit each player only once
+	for _, id := range p.HitIDs {
+		if id == target.ID {
+			return false
+		}
+	}
+
 	// Check invulnerability (dodge i-frames)
 	if target.Combat.IsInvulnerable() {
 		return false
@@ -153,6 +261,7 @@ func (p *Projectile) GetTrailPoints() (xs, ys [4]float64, count int) {
 
 // ProjectileSnapshot is an immutable copy of projectile state for rendering
 type ProjectileSnapshot struct {
+	ID         string // Matches a projectile across snapshots (render interpolation)
 	X, Y       float64
 	Rotation   float64
 	Color      string
@@ -165,6 +274,7 @@ type ProjectileSnapshot struct {
 func (p *Projectile) ToSnapshot() ProjectileSnapshot {
 	xs, ys, count := p.GetTrailPoints()
 	return ProjectileSnapshot{
+		ID:         p.ID,
 		X:          p.X,
 		Y:          p.Y,
 		Rotation:   p.Rotation,
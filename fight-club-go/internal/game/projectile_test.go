@@ -0,0 +1,115 @@
+package game
+
+import (
+	"math"
+	"testing"
+)
+
+// withTestWeaponAnimation registers a temporary projectile weapon animation
+func withTestWeaponAnimation(t *testing.T, id string, anim WeaponAnimationConfig) {
+	t.Helper()
+	anim.WeaponID = id
+	anim.IsProjectile = true
+	cachedWeaponAnimations[id] = anim
+	t.Cleanup(func() { delete(cachedWeaponAnimations, id) })
+}
+
+func newArcher(weapon string, x, y float64) *Player {
+	p := NewPlayer("Archer", PlayerOptions{WorldWidth: 1280, WorldHeight: 720})
+	p.Weapon = weapon
+	p.X, p.Y = x, y
+	return p
+}
+
+// TestProjectileGravityLandsOnTarget verifies lobbed projectiles arc upward and come down on the target
+func TestProjectileGravityLandsOnTarget(t *testing.T) {
+	withTestWeaponAnimation(t, "test_lob", WeaponAnimationConfig{ProjectileSpeed: 400, ProjectileGravity: 600})
+	proj := NewProjectile(newArcher("test_lob", 100, 400), 500, 400, 20, 1)
+
+	if proj.VY >= 0 {
+		t.Fatalf("Expected lobbed projectile to launch upward, got VY=%.2f", proj.VY)
+	}
+
+	minY := proj.Y
+	for i := 0; i < 20 && proj.X < 500; i++ {
+		proj.Update(0.05)
+		minY = math.Min(minY, proj.Y)
+	}
+	if minY > 350 {
+		t.Errorf("Expected a visible arc above the firing line, peak Y=%.0f", minY)
+	}
+	if math.Abs(proj.X-500) > 1 || math.Abs(proj.Y-400) > 1 {
+		t.Errorf("Expected projectile to land on (500, 400), got (%.1f, %.1f)", proj.X, proj.Y)
+	}
+}
+
+// TestProjectileBouncesOffWorldBounds verifies rebounds lose speed and stop after the bounce budget
+func TestProjectileBouncesOffWorldBounds(t *testing.T) {
+	withTestWeaponAnimation(t, "test_bounce", WeaponAnimationConfig{ProjectileSpeed: 400, ProjectileBounces: 1, ProjectileBounce: 0.5})
+	proj := NewProjectile(newArcher("test_bounce", 1200, 300), 1300, 300, 20, 1)
+	proj.BoundsW, proj.BoundsH = 1280, 720
+
+	speed := proj.VX
+	for i := 0; i < 5 && proj.VX > 0; i++ {
+		if !proj.Update(0.05) {
+			t.Fatal("Expected projectile to bounce instead of leaving the world")
+		}
+	}
+	if proj.VX != -speed*0.5 || proj.X > 1280 || proj.Bounces != 0 {
+		t.Errorf("Expected damped rebound inside the world, got VX=%.1f X=%.1f bounces=%d", proj.VX, proj.X, proj.Bounces)
+	}
+
+	// Out of bounces: the left wall removes it
+	proj.X, proj.VX = 10, -200
+	if proj.Update(0.05) {
+		t.Error("Expected projectile removed after using its bounces")
+	}
+}
+
+// TestProjectilePierce verifies piercing arrows hit several players, each only once
+func TestProjectilePierce(t *testing.T) {
+	proj := NewProjectile(newArcher("bow", 100, 100), 400, 100, 20, 1)
+	first := NewPlayer("First", PlayerOptions{})
+	second := NewPlayer("Second", PlayerOptions{})
+	first.SpawnProtection, second.SpawnProtection = false, false
+	first.X, first.Y = proj.X, proj.Y
+	second.X, second.Y = proj.X, proj.Y
+
+	if !proj.CheckHit(first) || !proj.RegisterHit(first) {
+		t.Fatal("Expected bow arrow to pierce the first target")
+	}
+	if proj.CheckHit(first) {
+		t.Error("Expected piercing arrow not to hit the same player twice")
+	}
+	if !proj.CheckHit(second) || proj.RegisterHit(second) {
+		t.Error("Expected arrow to stop at the second target")
+	}
+}
+
+// TestInterceptPointLeadsMovingTarget verifies a shot at the intercept point
+// hits a strafing target that a shot at its current position misses
+func TestInterceptPointLeadsMovingTarget(t *testing.T) {
+	withTestWeaponAnimation(t, "test_bow", WeaponAnimationConfig{ProjectileSpeed: 500})
+	archer := newArcher("test_bow", 100, 400)
+
+	closest := func(aimX, aimY float64) float64 {
+		target := NewPlayer("Target", PlayerOptions{WorldWidth: 1280, WorldHeight: 720})
+		target.X, target.Y, target.VX, target.VY = 500, 400, 0, 5
+		proj := NewProjectile(archer, aimX, aimY, 20, 1)
+		best := math.Inf(1)
+		for i := 0; i < ProjectileLifetime && proj.Update(0.05); i++ {
+			target.Y += target.VY
+			best = math.Min(best, math.Hypot(proj.X-target.X, proj.Y-target.Y))
+		}
+		return best
+	}
+
+	if d := closest(500, 400); d <= PlayerRadius+ProjectileRadius {
+		t.Fatalf("Expected a shot at the current position to miss, passed %.1fpx away", d)
+	}
+	target := &Player{X: 500, Y: 400, VX: 0, VY: 5}
+	x, y := InterceptPoint(archer, target, 500/20.0)
+	if d := closest(x, y); d > PlayerRadius {
+		t.Errorf("Expected the led shot to hit, passed %.1fpx away (aimed at %.0f, %.0f)", d, x, y)
+	}
+}
@@ -0,0 +1,376 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"fight-club/internal/config"
+)
+
+// QuestConfig is an alias for config.QuestConfig (SSOT)
+type QuestConfig = config.QuestConfig
+
+// Daily quest IDs
+const (
+	QuestSpearKills = "spear_kills"
+	QuestSurvive    = "survive"
+	QuestDuelWin    = "duel_win"
+)
+
+// Quest is a daily objective every viewer receives
+type Quest struct {
+	ID    string
+	Name  string
+	Emoji string
+	Goal  int
+	Timed bool // Goal and progress are seconds
+}
+
+// DailyQuests lists the objectives handed out each day, in !quests order
+var DailyQuests = []Quest{
+	{ID: QuestSpearKills, Name: "Get 3 kills with a spear", Emoji: "🔱", Goal: 3},
+	{ID: QuestSurvive, Name: "Survive 5 minutes", Emoji: "⏱️", Goal: 300, Timed: true},
+	{ID: QuestDuelWin, Name: "Win a duel", Emoji: "🤺", Goal: 1},
+}
+
+// GetQuest returns a daily quest by ID
+func GetQuest(id string) (Quest, bool) {
+	for _, q := range DailyQuests {
+		if q.ID == id {
+			return q, true
+		}
+	}
+	return Quest{}, false
+}
+
+// FormatProgress renders progress toward the goal ("2/3", or "2:10/5:00" for timed quests)
+func (q Quest) FormatProgress(progress int) string {
+	progress = min(progress, q.Goal)
+	if q.Timed {
+		return fmt.Sprintf("%d:%02d/%d:%02d", progress/60, progress%60, q.Goal/60, q.Goal%60)
+	}
+	return fmt.Sprintf("%d/%d", progress, q.Goal)
+}
+
+// QuestProgress is one viewer's progress on a daily quest
+type QuestProgress struct {
+	Quest    Quest
+	Progress int
+	Done     bool
+}
+
+// questDayFormat keys quest progress by local calendar day
+const questDayFormat = "2006-01-02"
+
+// questSaveInterval is how often changed progress is flushed to disk
+const questSaveInterval = 30 * time.Second
+
+// questFile is the persisted JSON layout
+type questFile struct {
+	Day     string                    `json:"day"`
+	Players map[string]map[string]int `json:"players"` // Username -> quest ID -> progress
+}
+
+// QuestStore persists each viewer's daily quest progress. Progress resets
+// at local midnight; a quest completes once per day when it reaches its goal.
+type QuestStore struct {
+	mu          sync.RWMutex
+	players     map[string]map[string]int // Username -> quest ID -> progress
+	day         string
+	cfg         QuestConfig
+	dirty       bool
+	saveBlocked bool // The file didn't parse and couldn't be moved aside
+
+	// OnProgress is called with a viewer's quests when they ask for them (!quests)
+	OnProgress func(username string, quests []QuestProgress)
+
+	running bool
+	stopCh  chan struct{}
+	wg      sync.WaitGroup
+}
+
+// NewQuestStore creates a quest store (File "" = in-memory only)
+func NewQuestStore(cfg QuestConfig) *QuestStore {
+	return &QuestStore{
+		players: make(map[string]map[string]int),
+		day:     time.Now().Format(questDayFormat),
+		cfg:     cfg,
+		stopCh:  make(chan struct{}),
+	}
+}
+
+// Start loads persisted progress and begins periodic saving
+func (qs *QuestStore) Start() {
+	qs.mu.Lock()
+	if qs.running {
+		qs.mu.Unlock()
+		return
+	}
+	qs.running = true
+	qs.mu.Unlock()
+
+	qs.load()
+
+	qs.wg.Add(1)
+	go qs.saveLoop()
+}
+
+// Stop stops periodic saving and flushes progress to disk
+func (qs *QuestStore) Stop() {
+	qs.mu.Lock()
+	if !qs.running {
+		qs.mu.Unlock()
+		return
+	}
+	qs.running = false
+	qs.mu.Unlock()
+
+	close(qs.stopCh)
+	qs.wg.Wait()
+	qs.save()
+}
+
+// Reward returns the wallet money granted per completed quest
+func (qs *QuestStore) Reward() int {
+	return qs.cfg.Reward
+}
+
+// Progress returns a viewer's progress on today's quests
+func (qs *QuestStore) Progress(username string, now time.Time) []QuestProgress {
+	qs.mu.Lock()
+	defer qs.mu.Unlock()
+
+	qs.rolloverLocked(now)
+	progress := make([]QuestProgress, 0, len(DailyQuests))
+	for _, q := range DailyQuests {
+		p := qs.players[username][q.ID]
+		progress = append(progress, QuestProgress{Quest: q, Progress: p, Done: p >= q.Goal})
+	}
+	return progress
+}
+
+// ShowProgress sends a viewer's progress to OnProgress (the chat bot)
+func (qs *QuestStore) ShowProgress(username string, now time.Time) {
+	progress := qs.Progress(username, now)
+	if qs.OnProgress != nil {
+		go qs.OnProgress(username, progress)
+	}
+}
+
+// Add advances a counted quest and reports whether this completed it
+func (qs *QuestStore) Add(username, questID string, amount int, now time.Time) (Quest, bool) {
+	return qs.advance(username, questID, now, func(p int) int { return p + amount })
+}
+
+// Reach records a best-of value (e.g. seconds survived in one life) and reports whether this completed it
+func (qs *QuestStore) Reach(username, questID string, value int, now time.Time) (Quest, bool) {
+	return qs.advance(username, questID, now, func(p int) int { return max(p, value) })
+}
+
+// advance applies a progress update, returning the quest when it crosses its goal
+func (qs *QuestStore) advance(username, questID string, now time.Time, update func(int) int) (Quest, bool) {
+	q, ok := GetQuest(questID)
+	if !ok || username == "" {
+		return Quest{}, false
+	}
+
+	qs.mu.Lock()
+	defer qs.mu.Unlock()
+
+	qs.rolloverLocked(now)
+	progress, ok := qs.players[username]
+	if !ok {
+		progress = make(map[string]int)
+		qs.players[username] = progress
+	}
+
+	before := progress[questID]
+	if before >= q.Goal {
+		return q, false // Already done today
+	}
+	after := min(update(before), q.Goal)
+	if after == before {
+		return q, false
+	}
+	progress[questID] = after
+	qs.dirty = true
+	return q, after >= q.Goal
+}
+
+// rolloverLocked resets all progress when the day changes (caller holds lock)
+func (qs *QuestStore) rolloverLocked(now time.Time) {
+	day := now.Format(questDayFormat)
+	if day == qs.day {
+		return
+	}
+	qs.players = make(map[string]map[string]int)
+	qs.day = day
+	qs.dirty = true
+}
+
+// saveLoop flushes progress to disk every questSaveInterval
+func (qs *QuestStore) saveLoop() {
+	defer qs.wg.Done()
+
+	ticker := time.NewTicker(questSaveInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-qs.stopCh:
+			return
+		case <-ticker.C:
+			qs.save()
+		}
+	}
+}
+
+// save persists progress to disk if anything changed
+func (qs *QuestStore) save() {
+	qs.mu.Lock()
+	if !qs.dirty || qs.cfg.File == "" || qs.saveBlocked {
+		qs.mu.Unlock()
+		return
+	}
+	data, err := json.MarshalIndent(questFile{Day: qs.day, Players: qs.players}, "", "  ")
+	qs.dirty = false
+	qs.mu.Unlock()
+
+	if err != nil {
+		log.Printf("⚠️ Failed to marshal quests: %v", err)
+		return
+	}
+
+	if err := WriteFileAtomic(qs.cfg.File, data, 0600); err != nil {
+		log.Printf("⚠️ Failed to save quests: %v", err)
+	}
+}
+
+// load restores persisted progress from disk (yesterday's progress is dropped)
+func (qs *QuestStore) load() {
+	if qs.cfg.File == "" {
+		return
+	}
+
+	data, err := os.ReadFile(qs.cfg.File)
+	if err != nil {
+		return // No saved quests
+	}
+
+	var file questFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		if !setAsideCorrupt(qs.cfg.File, "quests", err) {
+			qs.mu.Lock()
+			qs.saveBlocked = true
+			qs.mu.Unlock()
+		}
+		return
+	}
+
+	qs.mu.Lock()
+	defer qs.mu.Unlock()
+
+	if file.Day != qs.day {
+		qs.dirty = true
+		return
+	}
+	for name, progress := range file.Players {
+		if progress != nil {
+			qs.players[name] = progress
+		}
+	}
+	log.Printf("📂 Loaded quest progress: %d viewers (%s)", len(file.Players), qs.day)
+}
+
+// questToastDuration is how long a quest completion toast stays on stream (seconds)
+const questToastDuration = 4.0
+
+// maxQueuedQuestToasts caps toasts waiting behind the one on screen
+const maxQueuedQuestToasts = 8
+
+// QuestToastSnapshot is the quest completion toast currently on stream
+type QuestToastSnapshot struct {
+	Username string // "" = hidden
+	Quest    string
+	Reward   int
+}
+
+// questToast is a queued completion toast
+type questToast struct {
+	snap  QuestToastSnapshot
+	timer float64
+}
+
+// recordQuest advances a viewer's quest and pays out on completion. Caller holds e.mu.
+func (e *Engine) recordQuest(p *Player, questID string, amount int) {
+	if p.IsBot || p.Name == e.arenaBotName {
+		return
+	}
+	if q, done := e.quests.Add(p.Name, questID, amount, time.Now()); done {
+		e.completeQuest(p.Name, q)
+	}
+}
+
+// completeQuest credits the reward and queues the on-stream toast. Caller holds e.mu.
+func (e *Engine) completeQuest(username string, q Quest) {
+	reward := 0
+	if r := e.quests.Reward(); r > 0 && e.wallets.Credit(username, r) {
+		reward = r
+	}
+	log.Printf("📜 %s completed quest '%s' (+$%d)", username, q.Name, reward)
+
+	if len(e.questToasts) < maxQueuedQuestToasts {
+		e.questToasts = append(e.questToasts, questToast{
+			snap:  QuestToastSnapshot{Username: DisplayName(username), Quest: q.Name, Reward: reward},
+			timer: questToastDuration,
+		})
+	}
+}
+
+// updateQuests tracks survival time and advances the toast queue. Caller holds e.mu.
+func (e *Engine) updateQuests(deltaTime float64) {
+	for _, p := range e.playerSlice {
+		if !p.IsDead {
+			p.AliveTime += deltaTime
+		}
+	}
+
+	// Survival progress once per second (best single life today)
+	e.questTimer += deltaTime
+	if e.questTimer >= 1 {
+		e.questTimer = 0
+		now := time.Now()
+		for _, p := range e.playerSlice {
+			if p.IsDead || p.IsBot || p.Name == e.arenaBotName {
+				continue
+			}
+			if q, done := e.quests.Reach(p.Name, QuestSurvive, int(p.AliveTime), now); done {
+				e.completeQuest(p.Name, q)
+			}
+		}
+	}
+
+	if len(e.questToasts) > 0 {
+		e.questToasts[0].timer -= deltaTime
+		if e.questToasts[0].timer <= 0 {
+			e.questToasts = e.questToasts[1:]
+		}
+	}
+}
+
+// questToastSnapshot returns the toast on screen (zero value when none). Caller holds e.mu.
+func (e *Engine) questToastSnapshot() QuestToastSnapshot {
+	if len(e.questToasts) == 0 {
+		return QuestToastSnapshot{}
+	}
+	return e.questToasts[0].snap
+}
+
+// GetQuestStore returns the daily quest store
+func (e *Engine) GetQuestStore() *QuestStore {
+	return e.quests
+}
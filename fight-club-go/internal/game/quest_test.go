@@ -0,0 +1,95 @@
+package game
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestQuestProgress verifies quests complete once at their goal and reset the next day
+func TestQuestProgress(t *testing.T) {
+	qs := NewQuestStore(QuestConfig{Reward: 100})
+	now := time.Now()
+
+	for i := 1; i <= 3; i++ {
+		_, done := qs.Add("alice", QuestSpearKills, 1, now)
+		if done != (i == 3) {
+			t.Fatalf("Spear kill %d: expected done=%v", i, i == 3)
+		}
+	}
+	if _, done := qs.Add("alice", QuestSpearKills, 1, now); done {
+		t.Error("Expected a finished quest not to complete twice")
+	}
+
+	// Survival keeps the best single life, not the sum
+	qs.Reach("alice", QuestSurvive, 120, now)
+	qs.Reach("alice", QuestSurvive, 60, now)
+	progress := qs.Progress("alice", now)
+	if progress[1].Progress != 120 || progress[1].Done {
+		t.Errorf("Expected survival at 120s and not done, got %+v", progress[1])
+	}
+	if got := progress[1].Quest.FormatProgress(progress[1].Progress); got != "2:00/5:00" {
+		t.Errorf("Expected 2:00/5:00, got %s", got)
+	}
+	if !progress[0].Done {
+		t.Error("Expected the spear quest done")
+	}
+
+	// Next day starts fresh
+	if p := qs.Progress("alice", now.AddDate(0, 0, 1)); p[0].Progress != 0 || p[1].Progress != 0 {
+		t.Errorf("Expected progress reset the next day, got %+v", p)
+	}
+}
+
+// TestQuestPersistence verifies today's progress survives restarts
+func TestQuestPersistence(t *testing.T) {
+	cfg := QuestConfig{File: filepath.Join(t.TempDir(), "quests.json"), Reward: 100}
+	qs := NewQuestStore(cfg)
+	qs.Start()
+	qs.Add("alice", QuestSpearKills, 2, time.Now())
+	qs.Stop()
+
+	restored := NewQuestStore(cfg)
+	restored.Start()
+	defer restored.Stop()
+	if p := restored.Progress("alice", time.Now()); p[0].Progress != 2 {
+		t.Errorf("Expected 2 spear kills restored, got %d", p[0].Progress)
+	}
+}
+
+// TestQuestReward verifies completion credits the wallet and shows a toast, bots never progress
+func TestQuestReward(t *testing.T) {
+	engine := newTestEngineWith(func(cfg *EngineConfig) {
+		cfg.Quest = QuestConfig{Reward: 150}
+	})
+	alice := engine.AddPlayer("alice", PlayerOptions{})
+	engine.playerSlice = []*Player{alice}
+
+	// Survive 5 minutes in one life
+	alice.AliveTime = 299.5
+	engine.updateQuests(1)
+	if balance := engine.wallets.Balance("alice"); balance != 150 {
+		t.Fatalf("Expected $150 quest reward, got $%d", balance)
+	}
+	toast := engine.questToastSnapshot()
+	if toast.Username != "alice" || toast.Reward != 150 {
+		t.Errorf("Expected alice's quest toast, got %+v", toast)
+	}
+
+	// A second completion queues behind the first toast
+	engine.recordQuest(alice, QuestSpearKills, 3)
+	engine.updateQuests(questToastDuration)
+	if toast := engine.questToastSnapshot(); toast.Quest != "Get 3 kills with a spear" {
+		t.Errorf("Expected the spear toast next, got %+v", toast)
+	}
+	engine.updateQuests(questToastDuration)
+	if toast := engine.questToastSnapshot(); toast.Username != "" {
+		t.Errorf("Expected no toast once the queue drains, got %+v", toast)
+	}
+
+	bot := &Player{Name: "bot", IsBot: true}
+	engine.recordQuest(bot, QuestDuelWin, 1)
+	if p := engine.quests.Progress("bot", time.Now()); p[2].Progress != 0 {
+		t.Error("Expected bots to never progress quests")
+	}
+}
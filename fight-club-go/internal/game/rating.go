@@ -0,0 +1,307 @@
+package game
+
+import (
+	"encoding/json"
+	"log"
+	"math"
+	"os"
+	"sync"
+	"time"
+
+	"fight-club/internal/config"
+)
+
+// RatingConfig is an alias for config.RatingConfig (SSOT)
+type RatingConfig = config.RatingConfig
+
+// RankTier is a named rating band shown as a badge next to player names
+type RankTier struct {
+	ID        string
+	Name      string
+	Badge     string // Single letter drawn inside the badge
+	Color     string
+	MinRating int
+}
+
+// RatingRanks lists the rank tiers from lowest to highest
+var RatingRanks = []RankTier{
+	{ID: "bronze", Name: "Bronze", Badge: "B", Color: "#cd7f32", MinRating: 0},
+	{ID: "silver", Name: "Silver", Badge: "S", Color: "#a8b2bd", MinRating: 1100},
+	{ID: "gold", Name: "Gold", Badge: "G", Color: "#ffc400", MinRating: 1250},
+	{ID: "platinum", Name: "Platinum", Badge: "P", Color: "#2ec4b6", MinRating: 1400},
+	{ID: "diamond", Name: "Diamond", Badge: "D", Color: "#3d8bfd", MinRating: 1600},
+	{ID: "master", Name: "Master", Badge: "M", Color: "#a855f7", MinRating: 1800},
+	{ID: "champion", Name: "Champion", Badge: "C", Color: "#ff3e3e", MinRating: 2000},
+}
+
+// RankForRating returns the highest tier a rating qualifies for
+func RankForRating(rating int) RankTier {
+	tier := RatingRanks[0]
+	for _, r := range RatingRanks {
+		if rating >= r.MinRating {
+			tier = r
+		}
+	}
+	return tier
+}
+
+// GetRank returns a rank tier by ID
+func GetRank(id string) (RankTier, bool) {
+	for _, r := range RatingRanks {
+		if r.ID == id {
+			return r, true
+		}
+	}
+	return RankTier{}, false
+}
+
+// ratingSeasonFormat keys seasons by calendar month
+const ratingSeasonFormat = "2006-01"
+
+// ratingSaveInterval is how often changed ratings are flushed to disk
+const ratingSaveInterval = 30 * time.Second
+
+// PlayerRating is one viewer's rating for the current season
+type PlayerRating struct {
+	Rating int `json:"rating"`
+	Peak   int `json:"peak"` // Highest rating this season (rank-ups are announced once)
+	Kills  int `json:"kills"`
+	Deaths int `json:"deaths"`
+}
+
+// ratingFile is the persisted JSON layout
+type ratingFile struct {
+	Season  string                   `json:"season"`
+	Players map[string]*PlayerRating `json:"players"`
+}
+
+// RatingStore keeps a persistent ELO rating per viewer, updated on every kill.
+// Bots are rated opponents at the initial rating but never gain or lose rating.
+type RatingStore struct {
+	mu          sync.RWMutex
+	players     map[string]*PlayerRating
+	season      string
+	cfg         RatingConfig
+	dirty       bool
+	saveBlocked bool // The file didn't parse and couldn't be moved aside
+
+	// OnRankUp is called when a viewer reaches a tier above their season peak
+	OnRankUp func(username string, rank RankTier, rating int)
+
+	running bool
+	stopCh  chan struct{}
+	wg      sync.WaitGroup
+}
+
+// NewRatingStore creates a rating store (File "" = in-memory only)
+func NewRatingStore(cfg RatingConfig) *RatingStore {
+	if cfg.Initial <= 0 {
+		cfg.Initial = config.DefaultRating().Initial
+	}
+	if cfg.KFactor <= 0 {
+		cfg.KFactor = config.DefaultRating().KFactor
+	}
+	return &RatingStore{
+		players: make(map[string]*PlayerRating),
+		season:  time.Now().Format(ratingSeasonFormat),
+		cfg:     cfg,
+		stopCh:  make(chan struct{}),
+	}
+}
+
+// Start loads persisted ratings and begins periodic saving
+func (rs *RatingStore) Start() {
+	rs.mu.Lock()
+	if rs.running {
+		rs.mu.Unlock()
+		return
+	}
+	rs.running = true
+	rs.mu.Unlock()
+
+	rs.load()
+
+	rs.wg.Add(1)
+	go rs.saveLoop()
+}
+
+// Stop stops periodic saving and flushes ratings to disk
+func (rs *RatingStore) Stop() {
+	rs.mu.Lock()
+	if !rs.running {
+		rs.mu.Unlock()
+		return
+	}
+	rs.running = false
+	rs.mu.Unlock()
+
+	close(rs.stopCh)
+	rs.wg.Wait()
+	rs.save()
+}
+
+// Rating returns a viewer's current rating and rank (unrated viewers start at Initial)
+func (rs *RatingStore) Rating(username string) (int, RankTier) {
+	rs.mu.RLock()
+	defer rs.mu.RUnlock()
+
+	rating := rs.cfg.Initial
+	if p, ok := rs.players[username]; ok {
+		rating = p.Rating
+	}
+	return rating, RankForRating(rating)
+}
+
+// Season returns the current season key ("2006-01")
+func (rs *RatingStore) Season() string {
+	rs.mu.RLock()
+	defer rs.mu.RUnlock()
+	return rs.season
+}
+
+// RecordKill updates the killer's and victim's ratings and returns their new ranks.
+// Pass "" for a bot on either side: it counts as an opponent at the initial
+// rating and is left unchanged (its returned rank is the zero RankTier).
+func (rs *RatingStore) RecordKill(killer, victim string, now time.Time) (RankTier, RankTier) {
+	if killer == "" && victim == "" {
+		return RankTier{}, RankTier{}
+	}
+
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	rs.rolloverLocked(now)
+
+	killerRating, victimRating := rs.cfg.Initial, rs.cfg.Initial
+	var k, v *PlayerRating
+	if killer != "" {
+		k = rs.entryLocked(killer)
+		killerRating = k.Rating
+	}
+	if victim != "" {
+		v = rs.entryLocked(victim)
+		victimRating = v.Rating
+	}
+
+	// Standard ELO: an upset (low-rated killer) moves ratings further
+	expected := 1 / (1 + math.Pow(10, float64(victimRating-killerRating)/400))
+	delta := max(int(math.Round(rs.cfg.KFactor*(1-expected))), 1)
+
+	var killerRank, victimRank RankTier
+	if k != nil {
+		previousPeak := RankForRating(k.Peak)
+		k.Rating += delta
+		k.Kills++
+		k.Peak = max(k.Peak, k.Rating)
+		killerRank = RankForRating(k.Rating)
+		if killerRank.MinRating > previousPeak.MinRating && rs.OnRankUp != nil {
+			go rs.OnRankUp(killer, killerRank, k.Rating)
+		}
+	}
+	if v != nil {
+		v.Rating = max(v.Rating-delta, 0)
+		v.Deaths++
+		victimRank = RankForRating(v.Rating)
+	}
+	rs.dirty = true
+	return killerRank, victimRank
+}
+
+// entryLocked returns a viewer's rating, creating it at Initial (caller holds lock)
+func (rs *RatingStore) entryLocked(username string) *PlayerRating {
+	p, ok := rs.players[username]
+	if !ok {
+		p = &PlayerRating{Rating: rs.cfg.Initial, Peak: rs.cfg.Initial}
+		rs.players[username] = p
+	}
+	return p
+}
+
+// rolloverLocked starts a new season when the month changes (caller holds lock)
+func (rs *RatingStore) rolloverLocked(now time.Time) {
+	season := now.Format(ratingSeasonFormat)
+	if season == rs.season {
+		return
+	}
+	if rs.cfg.SeasonReset {
+		log.Printf("🏁 Rating season %s started (%d ratings from %s reset)", season, len(rs.players), rs.season)
+		rs.players = make(map[string]*PlayerRating)
+	}
+	rs.season = season
+	rs.dirty = true
+}
+
+// saveLoop flushes ratings to disk every ratingSaveInterval
+func (rs *RatingStore) saveLoop() {
+	defer rs.wg.Done()
+
+	ticker := time.NewTicker(ratingSaveInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-rs.stopCh:
+			return
+		case <-ticker.C:
+			rs.save()
+		}
+	}
+}
+
+// save persists ratings to disk if anything changed
+func (rs *RatingStore) save() {
+	rs.mu.Lock()
+	if !rs.dirty || rs.cfg.File == "" || rs.saveBlocked {
+		rs.mu.Unlock()
+		return
+	}
+	data, err := json.MarshalIndent(ratingFile{Season: rs.season, Players: rs.players}, "", "  ")
+	rs.dirty = false
+	rs.mu.Unlock()
+
+	if err != nil {
+		log.Printf("⚠️ Failed to marshal ratings: %v", err)
+		return
+	}
+
+	if err := WriteFileAtomic(rs.cfg.File, data, 0600); err != nil {
+		log.Printf("⚠️ Failed to save ratings: %v", err)
+	}
+}
+
+// load restores persisted ratings from disk (a past season is reset if SeasonReset)
+func (rs *RatingStore) load() {
+	if rs.cfg.File == "" {
+		return
+	}
+
+	data, err := os.ReadFile(rs.cfg.File)
+	if err != nil {
+		return // No saved ratings
+	}
+
+	var file ratingFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		if !setAsideCorrupt(rs.cfg.File, "ratings", err) {
+			rs.mu.Lock()
+			rs.saveBlocked = true
+			rs.mu.Unlock()
+		}
+		return
+	}
+
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	if file.Season != rs.season && rs.cfg.SeasonReset {
+		log.Printf("🏁 Rating season %s started (season %s ended with %d ratings)", rs.season, file.Season, len(file.Players))
+		rs.dirty = true
+		return
+	}
+	for name, p := range file.Players {
+		if p != nil {
+			rs.players[name] = p
+		}
+	}
+	log.Printf("📂 Loaded ratings: %d viewers (season %s)", len(file.Players), rs.season)
+}
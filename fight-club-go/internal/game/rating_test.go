@@ -0,0 +1,101 @@
+package game
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTestRatingStore(t *testing.T) *RatingStore {
+	cfg := DefaultRating
+	cfg.File = filepath.Join(t.TempDir(), "ratings.json")
+	return NewRatingStore(cfg)
+}
+
+// TestRatingELO verifies kills move ratings by the ELO expectation and bots stay unrated
+func TestRatingELO(t *testing.T) {
+	rs := newTestRatingStore(t)
+	now := time.Now()
+
+	// Equal ratings: half the K factor changes hands
+	killerRank, victimRank := rs.RecordKill("alice", "bob", now)
+	if a, _ := rs.Rating("alice"); a != 1016 {
+		t.Errorf("Expected alice at 1016, got %d", a)
+	}
+	if b, _ := rs.Rating("bob"); b != 984 {
+		t.Errorf("Expected bob at 984, got %d", b)
+	}
+	if killerRank.ID != "bronze" || victimRank.ID != "bronze" {
+		t.Errorf("Expected both still bronze, got %s / %s", killerRank.ID, victimRank.ID)
+	}
+
+	// The favourite gains less for beating the underdog than the upset pays
+	rs.RecordKill("alice", "bob", now)
+	favourite, _ := rs.Rating("alice")
+	rs.RecordKill("bob", "alice", now)
+	if upset, _ := rs.Rating("bob"); upset-969 <= favourite-1016 {
+		t.Errorf("Expected the upset to pay more (bob %d, alice %d)", upset, favourite)
+	}
+
+	// Bots are opponents at the initial rating but never rated themselves
+	rs.RecordKill("carol", "", now)
+	if c, _ := rs.Rating("carol"); c != 1016 {
+		t.Errorf("Expected carol at 1016 after a bot kill, got %d", c)
+	}
+	if _, ok := rs.players[""]; ok {
+		t.Error("Expected no rating for bots")
+	}
+}
+
+// TestRatingRankUp verifies promotions are announced once per tier per season
+func TestRatingRankUp(t *testing.T) {
+	rs := newTestRatingStore(t)
+	now := time.Now()
+	promotions := make(chan string, 10)
+	rs.OnRankUp = func(username string, rank RankTier, rating int) { promotions <- rank.ID }
+
+	rs.players["alice"] = &PlayerRating{Rating: 1095, Peak: 1095}
+	if rank, _ := rs.RecordKill("alice", "", now); rank.ID != "silver" {
+		t.Fatalf("Expected promotion to silver, got %s", rank.ID)
+	}
+	if got := <-promotions; got != "silver" {
+		t.Errorf("Expected silver announcement, got %s", got)
+	}
+
+	// Dropping back and climbing again isn't re-announced
+	rs.players["alice"].Rating = 1095
+	rs.RecordKill("alice", "", now)
+	select {
+	case got := <-promotions:
+		t.Errorf("Expected no repeat announcement, got %s", got)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	if rank := RankForRating(2400); rank.ID != "champion" {
+		t.Errorf("Expected champion at 2400, got %s", rank.ID)
+	}
+}
+
+// TestRatingPersistenceAndSeasons verifies ratings survive restarts and reset each season
+func TestRatingPersistenceAndSeasons(t *testing.T) {
+	rs := newTestRatingStore(t)
+	rs.Start()
+	rs.RecordKill("alice", "bob", time.Now())
+	rs.Stop()
+
+	restored := NewRatingStore(rs.cfg)
+	restored.Start()
+	defer restored.Stop()
+	if a, _ := restored.Rating("alice"); a != 1016 {
+		t.Fatalf("Expected alice restored at 1016, got %d", a)
+	}
+
+	// First kill of a new month starts a fresh season
+	restored.RecordKill("carol", "", time.Now().AddDate(0, 1, 0))
+	if a, _ := restored.Rating("alice"); a != DefaultRating.Initial {
+		t.Errorf("Expected alice reset to %d, got %d", DefaultRating.Initial, a)
+	}
+	if restored.Season() != time.Now().AddDate(0, 1, 0).Format(ratingSeasonFormat) {
+		t.Errorf("Expected the new season, got %s", restored.Season())
+	}
+}
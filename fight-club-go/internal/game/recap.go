@@ -0,0 +1,145 @@
+package game
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// recapTopKillers is how many viewers the stream recap ranks
+const recapTopKillers = 5
+
+// RecapWeapon is one weapon's share of a stream's kills
+type RecapWeapon struct {
+	Weapon string  `json:"weapon"`
+	Kills  int     `json:"kills"`
+	Share  float64 `json:"share"`
+}
+
+// StreamRecap is the post-stream summary of a session's rounds (cmd/summary)
+type StreamRecap struct {
+	Start        time.Time     `json:"start"`
+	End          time.Time     `json:"end"`
+	Rounds       int           `json:"rounds"`
+	Kills        int           `json:"kills"`
+	PeakFighters int           `json:"peakFighters"`
+	Champion     string        `json:"champion"`   // Most rounds won (ties by kills)
+	RoundWins    []RoundKiller `json:"roundWins"`  // Kills = rounds won
+	TopKillers   []RoundKiller `json:"topKillers"` // Kills summed over each round's top killers
+	Weapons      []RecapWeapon `json:"weapons"`    // Most kills first
+}
+
+// LatestSession returns the rounds of the most recent stream: the trailing
+// rounds with no more than gap between one round's end and the next start
+func LatestSession(rounds []RoundSummary, gap time.Duration) []RoundSummary {
+	if len(rounds) == 0 {
+		return nil
+	}
+	first := len(rounds) - 1
+	for first > 0 && rounds[first].Start.Sub(rounds[first-1].End) <= gap {
+		first--
+	}
+	return rounds[first:]
+}
+
+// RecapRounds summarizes rounds (oldest first) into a stream recap
+func RecapRounds(rounds []RoundSummary) StreamRecap {
+	recap := StreamRecap{Rounds: len(rounds)}
+	if len(rounds) == 0 {
+		return recap
+	}
+	recap.Start = rounds[0].Start
+	recap.End = rounds[len(rounds)-1].End
+
+	wins := make(map[string]int)
+	kills := make(map[string]int)
+	weapons := make(map[string]int)
+	for _, r := range rounds {
+		recap.Kills += r.Kills
+		recap.PeakFighters = max(recap.PeakFighters, r.PeakFighters)
+		if r.Winner != "" {
+			wins[r.Winner]++
+		}
+		for _, k := range r.TopKillers {
+			kills[k.Name] += k.Kills
+		}
+		for weapon, n := range r.Weapons {
+			weapons[weapon] += n
+		}
+	}
+
+	recap.TopKillers = topKillers(kills, recapTopKillers)
+	recap.RoundWins = topKillers(wins, 0)
+	sort.SliceStable(recap.RoundWins, func(i, j int) bool {
+		a, b := recap.RoundWins[i], recap.RoundWins[j]
+		return a.Kills > b.Kills || (a.Kills == b.Kills && kills[a.Name] > kills[b.Name])
+	})
+	if len(recap.RoundWins) > recapTopKillers {
+		recap.RoundWins = recap.RoundWins[:recapTopKillers]
+	}
+	if len(recap.RoundWins) > 0 {
+		recap.Champion = recap.RoundWins[0].Name
+	}
+
+	for weapon, n := range weapons {
+		w := RecapWeapon{Weapon: weapon, Kills: n}
+		if recap.Kills > 0 {
+			w.Share = round2(float64(n) / float64(recap.Kills))
+		}
+		recap.Weapons = append(recap.Weapons, w)
+	}
+	sort.Slice(recap.Weapons, func(i, j int) bool {
+		if recap.Weapons[i].Kills != recap.Weapons[j].Kills {
+			return recap.Weapons[i].Kills > recap.Weapons[j].Kills
+		}
+		return recap.Weapons[i].Weapon < recap.Weapons[j].Weapon
+	})
+	return recap
+}
+
+// Length returns how long the recapped stream ran ("2h 05m", "45m")
+func (r StreamRecap) Length() string {
+	minutes := int(r.End.Sub(r.Start).Round(time.Minute).Minutes())
+	if minutes < 60 {
+		return fmt.Sprintf("%dm", minutes)
+	}
+	return fmt.Sprintf("%dh %02dm", minutes/60, minutes%60)
+}
+
+// Markdown renders the recap for sharing (Discord, socials)
+func (r StreamRecap) Markdown() string {
+	var b strings.Builder
+	b.WriteString("# Fight Club stream recap\n\n")
+	if r.Rounds == 0 {
+		b.WriteString("No rounds were played.\n")
+		return b.String()
+	}
+	fmt.Fprintf(&b, "%s, %s: %d rounds, %d kills, up to %d fighters.\n\n",
+		r.Start.Format("2006-01-02 15:04"), r.Length(), r.Rounds, r.Kills, r.PeakFighters)
+	if r.Champion != "" {
+		fmt.Fprintf(&b, "🏆 **Champion: %s** (%d rounds won)\n\n", r.Champion, r.RoundWins[0].Kills)
+	}
+
+	if len(r.TopKillers) > 0 {
+		b.WriteString("## Top killers\n\n| # | Viewer | Kills |\n|---:|---|---:|\n")
+		for i, k := range r.TopKillers {
+			fmt.Fprintf(&b, "| %d | %s | %d |\n", i+1, k.Name, k.Kills)
+		}
+		b.WriteString("\n")
+	}
+	if len(r.RoundWins) > 0 {
+		b.WriteString("## Rounds won\n\n| Viewer | Rounds |\n|---|---:|\n")
+		for _, k := range r.RoundWins {
+			fmt.Fprintf(&b, "| %s | %d |\n", k.Name, k.Kills)
+		}
+		b.WriteString("\n")
+	}
+	if len(r.Weapons) > 0 {
+		b.WriteString("## Weapons\n\n| Weapon | Kills | Share |\n|---|---:|---:|\n")
+		for _, w := range r.Weapons {
+			fmt.Fprintf(&b, "| %s | %d | %.0f%% |\n", w.Weapon, w.Kills, w.Share*100)
+		}
+	}
+	return b.String()
+}
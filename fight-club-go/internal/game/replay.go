@@ -0,0 +1,121 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"fight-club/internal/config"
+)
+
+// ReplayConfig is an alias for config.ReplayConfig (SSOT)
+type ReplayConfig = config.ReplayConfig
+
+// Replay reasons
+const (
+	ReplayAdmin = "admin" // Requested from the admin panel
+	ReplayDuel  = "duel"  // The kill that won a duel
+)
+
+// ReplayCue tells the renderer when to play a slow-motion replay. It rides
+// on every snapshot so it reaches a streamer in another process too.
+type ReplayCue struct {
+	Seq     uint64  // Bumped by each replay request (0 = none yet)
+	Reason  string  // ReplayAdmin, ReplayDuel
+	Seconds float64 // Arena time kept for replays (0 = replays off)
+	Speed   float64 // Playback speed
+}
+
+// ReplayBuffer keeps copies of the last cue.Seconds of snapshots for a
+// slow-motion replay. Frames that fall out of the window are reused for new
+// ones, so recording doesn't allocate once warm. Safe for concurrent use.
+type ReplayBuffer struct {
+	mu     sync.Mutex
+	frames []*GameSnapshot // Oldest first
+}
+
+// NewReplayBuffer creates an empty replay buffer
+func NewReplayBuffer() *ReplayBuffer {
+	return &ReplayBuffer{}
+}
+
+// Record keeps a copy of snap (engine snapshots are pooled and rewritten two
+// ticks later) and drops the frames older than snap.Replay.Seconds
+func (b *ReplayBuffer) Record(snap *GameSnapshot) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	window := time.Duration(snap.Replay.Seconds * float64(time.Second))
+	if window <= 0 {
+		b.frames = nil
+		return
+	}
+
+	var slot *GameSnapshot
+	for len(b.frames) > 0 && snap.Timestamp.Sub(b.frames[0].Timestamp) > window {
+		slot = b.frames[0]
+		b.frames = append(b.frames[:0], b.frames[1:]...)
+	}
+	if slot == nil {
+		slot = &GameSnapshot{}
+	}
+	snap.copyInto(slot)
+	b.frames = append(b.frames, slot)
+}
+
+// Take hands the kept frames, oldest first, to a replay and starts over.
+// The frames are never written again.
+func (b *ReplayBuffer) Take() []*GameSnapshot {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	frames := b.frames
+	b.frames = nil
+	return frames
+}
+
+// replayLength returns how long a replay plays on stream
+func replayLength(cfg ReplayConfig) time.Duration {
+	return time.Duration(cfg.Seconds / cfg.Speed * float64(time.Second))
+}
+
+// RequestReplay asks the stream for a slow-motion replay of the last
+// seconds (the admin "replay last kill" button)
+func (e *Engine) RequestReplay() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.requestReplayLocked(ReplayAdmin)
+}
+
+// requestReplayLocked bumps the replay cue. A replay still playing on stream
+// can't be interrupted. Caller holds e.mu.
+func (e *Engine) requestReplayLocked(reason string) error {
+	cfg := e.replayCfg
+	if cfg.Seconds <= 0 || cfg.Speed <= 0 {
+		return errors.New("replays are off")
+	}
+	now := time.Now()
+	if wait := e.replayEnd.Sub(now); wait > 0 {
+		return fmt.Errorf("a replay is playing, try again in %s", wait.Round(time.Second))
+	}
+	e.replayEnd = now.Add(replayLength(cfg))
+	e.replayCue.Seq++
+	e.replayCue.Reason = reason
+	log.Printf("🎞️ Slow-motion replay (%s): last %.1fs at %.1fx", reason, cfg.Seconds, cfg.Speed)
+	return nil
+}
+
+// replaySnapshot returns the replay cue for the snapshot. Caller holds e.mu.
+func (e *Engine) replaySnapshot() ReplayCue {
+	cue := e.replayCue
+	cue.Seconds = e.replayCfg.Seconds
+	cue.Speed = e.replayCfg.Speed
+	return cue
+}
+
+// ReplayFrames hands the last seconds of snapshots to the renderer for a
+// replay (see ReplayBuffer.Take)
+func (e *Engine) ReplayFrames() []*GameSnapshot {
+	return e.replayFrames.Take()
+}
@@ -0,0 +1,75 @@
+package game
+
+import (
+	"testing"
+	"time"
+)
+
+// TestReplayBufferWindow verifies the buffer keeps copies of the last
+// cue.Seconds of snapshots and hands them over once
+func TestReplayBufferWindow(t *testing.T) {
+	b := NewReplayBuffer()
+	t0 := time.Unix(100, 0)
+	snap := &GameSnapshot{Replay: ReplayCue{Seconds: 1}, Players: make([]PlayerSnapshot, 1)}
+	for i := 0; i < 60; i++ { // 2s at 30 TPS
+		snap.TickNumber = uint64(i)
+		snap.Timestamp = t0.Add(time.Duration(i) * time.Second / 30)
+		snap.Players[0].X = float64(i)
+		b.Record(snap)
+	}
+
+	frames := b.Take()
+	if len(frames) != 31 {
+		t.Fatalf("Expected the last second (31 frames), got %d", len(frames))
+	}
+	if first, last := frames[0], frames[len(frames)-1]; first.TickNumber != 29 || last.TickNumber != 59 {
+		t.Errorf("Expected ticks 29..59, got %d..%d", first.TickNumber, last.TickNumber)
+	}
+	if x := frames[len(frames)-1].Players[0].X; x != 59 {
+		t.Errorf("Expected the frames to be copies, got x=%.0f", x)
+	}
+	snap.Players[0].X = -1
+	if frames[len(frames)-1].Players[0].X != 59 {
+		t.Error("Expected the kept frame unchanged by the engine reusing its snapshot")
+	}
+	if len(b.Take()) != 0 {
+		t.Error("Expected the buffer empty after Take")
+	}
+
+	snap.Replay.Seconds = 0
+	b.Record(snap)
+	if len(b.Take()) != 0 {
+		t.Error("Expected nothing kept with replays off")
+	}
+}
+
+// TestRequestReplay verifies replays are cued once at a time, and that the
+// kill that wins a duel is replayed
+func TestRequestReplay(t *testing.T) {
+	engine := newTestEngineWith(func(cfg *EngineConfig) { cfg.Duel = testDuel })
+	if err := engine.RequestReplay(); err == nil {
+		t.Error("Expected an error with replays off")
+	}
+
+	engine.replayCfg = DefaultReplay
+	if err := engine.RequestReplay(); err != nil {
+		t.Fatalf("Expected a replay, got %v", err)
+	}
+	if cue := engine.replaySnapshot(); cue.Seq != 1 || cue.Reason != ReplayAdmin || cue.Speed != DefaultReplay.Speed {
+		t.Errorf("Unexpected cue %+v", cue)
+	}
+	if err := engine.RequestReplay(); err == nil {
+		t.Error("Expected an error while a replay is playing")
+	}
+
+	engine.replayEnd = time.Time{} // The admin replay is over
+	engine.AddPlayer("alice", PlayerOptions{})
+	bob := engine.AddPlayer("bob", PlayerOptions{})
+	engine.ChallengeDuel("alice", "bob")
+	engine.AcceptDuel("bob")
+	bob.die(nil)
+	engine.updateDuel(1.0 / 30)
+	if cue := engine.replaySnapshot(); cue.Seq != 2 || cue.Reason != ReplayDuel {
+		t.Errorf("Expected the duel kill replayed, got %+v", cue)
+	}
+}
@@ -0,0 +1,91 @@
+package game
+
+import (
+	"log"
+	"math"
+
+	"fight-club/internal/config"
+)
+
+// RingOutConfig is an alias for config.RingOutConfig (SSOT)
+type RingOutConfig = config.RingOutConfig
+
+// EdgeMargin is how far inside the world edge fighters are kept. The band
+// outside it is the pit that ring-outs fall into.
+const EdgeMargin = 40.0
+
+// Ring-out fall tuning
+const (
+	RingOutFallTime = 1.0  // Seconds for a thrown fighter to fall out of sight
+	ringOutLaunch   = 10.0 // Ragdoll speed (px/tick) towards the pit
+)
+
+// ringOut throws a fighter just hit by a heavy weapon into the pit when the
+// knockback would carry them past the edge margin. They die on the spot and
+// the attacker gets the kill: the caller's kill bookkeeping sees IsDead.
+// Bosses and duelists stay in. hpBefore tells whether the hit landed.
+// Returns true on a ring-out. Caller holds e.mu.
+func (e *Engine) ringOut(attacker, victim *Player, hpBefore int) bool {
+	cfg := e.ringOutCfg
+	if cfg.Reach <= 0 || attacker == nil || victim.IsDead || victim.IsBoss || victim.InDuel || victim.HP == hpBefore {
+		return false
+	}
+	force := GetWeaponAnimation(attacker.Weapon).KnockbackForce
+	if force < cfg.MinKnockback {
+		return false
+	}
+
+	dx, dy := victim.X-attacker.X, victim.Y-attacker.Y
+	dist := math.Hypot(dx, dy)
+	if dist == 0 {
+		return false
+	}
+	dx, dy = dx/dist, dy/dist
+	x := victim.X + dx*force*cfg.Reach
+	y := victim.Y + dy*force*cfg.Reach
+	if x >= EdgeMargin && x <= e.worldWidth-EdgeMargin && y >= EdgeMargin && y <= e.worldHeight-EdgeMargin {
+		return false // Lands inside the arena
+	}
+
+	victim.HP = 0
+	victim.die(attacker)
+	victim.RingOut = true
+	victim.VX, victim.VY = dx*ringOutLaunch, dy*ringOutLaunch
+
+	log.Printf("🕳️ %s was knocked into the pit by %s's %s!", victim.Name, attacker.Name, GetWeapon(attacker.Weapon).Name)
+	if len(e.texts) < e.limits.MaxTexts {
+		e.texts = append(e.texts, &FloatingText{
+			X:     victim.X,
+			Y:     victim.Y - 30,
+			Text:  "RING OUT!",
+			Color: "#ffd600",
+			Alpha: 1.0,
+			VY:    -1.5,
+		})
+	}
+	e.emitParticles("debris", victim.X, victim.Y, "#5a4a3a", 10)
+	e.AddShake(8.0)
+	return true
+}
+
+// pitBand returns the width of the ring-out pit around the arena (0 = ring-outs off)
+func (e *Engine) pitBand() float64 {
+	if e.ringOutCfg.Reach <= 0 {
+		return 0
+	}
+	return EdgeMargin
+}
+
+// updateFall slides a rung-out fighter into the pit (the renderers shrink and
+// fade them over RingOutFallTime). Called from UpdateRagdoll.
+func (p *Player) updateFall(deltaTime float64) {
+	p.fallTime += deltaTime
+	p.RagdollRotation += 0.25 // Tumbles faster than a ragdoll
+	p.X = math.Max(0, math.Min(p.worldWidth, p.X))
+	p.Y = math.Max(0, math.Min(p.worldHeight, p.Y))
+}
+
+// fallProgress returns how far a rung-out fighter has fallen, 0..1
+func (p *Player) fallProgress() float64 {
+	return min(p.fallTime/RingOutFallTime, 1)
+}
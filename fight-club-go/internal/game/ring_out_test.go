@@ -0,0 +1,85 @@
+package game
+
+import "testing"
+
+// newRingOutFight returns an engine with ring-outs on and two fighters facing
+// right, the victim at x
+func newRingOutFight(weapon string, x float64) (*Engine, *Player, *Player) {
+	engine := newTestEngine(30)
+	engine.ringOutCfg = DefaultRingOut
+
+	attacker := engine.AddPlayer("Attacker", PlayerOptions{})
+	victim := engine.AddPlayer("Victim", PlayerOptions{})
+	attacker.Weapon = weapon
+	attacker.X, attacker.Y = x-50, 360
+	victim.X, victim.Y = x, 360
+	attacker.AttackAngle = 0
+	attacker.SpawnProtection = false
+	victim.SpawnProtection = false
+	return engine, attacker, victim
+}
+
+// TestRingOut verifies a hammer hit near the edge throws the victim into the
+// pit and credits the attacker
+func TestRingOut(t *testing.T) {
+	engine, attacker, victim := newRingOutFight("hammer", 1200)
+	kills := attacker.Kills
+
+	engine.ProcessAttack(attacker, victim, 10)
+
+	if !victim.IsDead || !victim.RingOut {
+		t.Fatalf("Expected a ring-out, got dead=%v ringOut=%v HP %d", victim.IsDead, victim.RingOut, victim.HP)
+	}
+	if attacker.Kills != kills+1 {
+		t.Errorf("Expected the attacker credited with the kill, got %d kills", attacker.Kills)
+	}
+	if victim.VX <= 0 {
+		t.Errorf("Expected the victim launched towards the pit, got VX %.2f", victim.VX)
+	}
+	if engine.pitBand() != EdgeMargin {
+		t.Errorf("Expected a pit band of %.0f, got %.0f", EdgeMargin, engine.pitBand())
+	}
+
+	// The fall ends outside the edge margin, fully faded
+	for i := 0; i < 45; i++ {
+		victim.UpdateRagdoll(1.0 / 30)
+	}
+	if victim.fallProgress() != 1 || victim.X <= 1280-EdgeMargin {
+		t.Errorf("Expected the victim fallen into the pit, got progress %.2f at x %.0f", victim.fallProgress(), victim.X)
+	}
+
+	victim.Respawn()
+	if victim.RingOut || victim.fallProgress() != 0 {
+		t.Error("Expected respawn to clear the ring-out")
+	}
+}
+
+// TestRingOutNeedsHeavyHitNearEdge verifies light weapons, hits in the
+// middle of the arena and a zero reach leave the victim in
+func TestRingOutNeedsHeavyHitNearEdge(t *testing.T) {
+	tests := []struct {
+		name   string
+		weapon string
+		x      float64
+		reach  float64
+	}{
+		{"fists", "fists", 1200, DefaultRingOut.Reach},
+		{"center", "hammer", 640, DefaultRingOut.Reach},
+		{"disabled", "hammer", 1200, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine, attacker, victim := newRingOutFight(tt.weapon, tt.x)
+			engine.ringOutCfg.Reach = tt.reach
+
+			engine.ProcessAttack(attacker, victim, 10)
+
+			if victim.HP == victim.MaxHP {
+				t.Fatal("Expected the hit to land")
+			}
+			if victim.IsDead || victim.RingOut {
+				t.Errorf("Expected no ring-out, got dead=%v ringOut=%v", victim.IsDead, victim.RingOut)
+			}
+		})
+	}
+}
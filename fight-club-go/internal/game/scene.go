@@ -0,0 +1,175 @@
+package game
+
+import (
+	"fmt"
+	"log"
+
+	"fight-club/internal/config"
+)
+
+// SceneConfig is an alias for config.SceneConfig (SSOT)
+type SceneConfig = config.SceneConfig
+
+// Times of day, in cycle order (background palettes, see streaming/scene_render.go)
+const (
+	SceneDay   = "day"
+	SceneDusk  = "dusk"
+	SceneNight = "night"
+)
+
+// Weather overlays
+const (
+	WeatherClear = "clear"
+	WeatherRain  = "rain"
+	WeatherSnow  = "snow"
+)
+
+// WeatherFadeTime is how long rain or snow takes to fully set in or clear (seconds)
+const WeatherFadeTime = 5.0
+
+// SceneTimes is the time-of-day cycle
+var SceneTimes = []string{SceneDay, SceneDusk, SceneNight}
+
+// SceneWeathers lists the weather overlays
+var SceneWeathers = []string{WeatherClear, WeatherRain, WeatherSnow}
+
+// SceneSnapshot is the arena look for the renderers
+type SceneSnapshot struct {
+	Time             string  `json:"time"`             // Time of day (background palette)
+	Weather          string  `json:"weather"`          // Weather overlay (rain, snow or clear)
+	WeatherIntensity float64 `json:"weatherIntensity"` // 0 (clear) .. 1 (full), ramps over WeatherFadeTime
+	Remaining        float64 `json:"remaining"`        // Seconds until the next time of day (0 = not cycling)
+}
+
+// SceneManager cycles the arena through times of day, rolling the weather at
+// each change. Randomness comes from the engine RNG. State is guarded by the
+// engine lock (see Engine.updateScene).
+type SceneManager struct {
+	cfg SceneConfig
+
+	time      int     // Index into SceneTimes
+	weather   string  // Current (or fading out) weather
+	intensity float64 // Weather intensity
+	fading    bool    // Weather is clearing; switches to next once faded
+	next      string  // Weather after the fade out
+	remaining float64 // Seconds until the next time of day
+}
+
+// NewSceneManager creates a scene manager starting at day with clear weather
+func NewSceneManager(cfg SceneConfig) *SceneManager {
+	return &SceneManager{
+		cfg:       cfg,
+		weather:   WeatherClear,
+		remaining: cfg.PhaseDuration,
+	}
+}
+
+// Snapshot returns the scene for rendering
+func (sm *SceneManager) Snapshot() SceneSnapshot {
+	snap := SceneSnapshot{
+		Time:             SceneTimes[sm.time],
+		Weather:          sm.weather,
+		WeatherIntensity: sm.intensity,
+	}
+	if sm.cfg.PhaseDuration > 0 {
+		snap.Remaining = sm.remaining
+	}
+	return snap
+}
+
+// setWeather fades the current weather out (if any) and the new one in
+func (sm *SceneManager) setWeather(weather string) {
+	switch {
+	case weather == sm.weather:
+		sm.fading = false // Keep it (or stop clearing it)
+	case sm.weather == WeatherClear || sm.intensity <= 0:
+		sm.weather, sm.fading = weather, false
+	default:
+		sm.fading, sm.next = true, weather
+	}
+}
+
+// target returns the weather the scene is heading to
+func (sm *SceneManager) target() string {
+	if sm.fading {
+		return sm.next
+	}
+	return sm.weather
+}
+
+// updateScene advances the time of day and the weather fade. Caller holds e.mu.
+func (e *Engine) updateScene(deltaTime float64) {
+	sm := e.scene
+
+	if sm.cfg.PhaseDuration > 0 {
+		sm.remaining -= deltaTime
+		if sm.remaining <= 0 {
+			sm.remaining = sm.cfg.PhaseDuration
+			sm.time = (sm.time + 1) % len(SceneTimes)
+			weather := WeatherClear
+			if e.rng.Float64() < sm.cfg.WeatherChance {
+				weather = SceneWeathers[1+e.rng.Intn(len(SceneWeathers)-1)]
+			}
+			sm.setWeather(weather)
+			log.Printf("🌗 Arena scene: %s, %s", SceneTimes[sm.time], weather)
+		}
+	}
+
+	step := deltaTime / WeatherFadeTime
+	switch {
+	case sm.fading:
+		sm.intensity -= step
+		if sm.intensity <= 0 {
+			sm.intensity = 0
+			sm.weather, sm.fading = sm.next, false
+		}
+	case sm.weather != WeatherClear:
+		sm.intensity = min(sm.intensity+step, 1)
+	}
+}
+
+// Scene returns the current time of day and weather
+func (e *Engine) Scene() SceneSnapshot {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.scene.Snapshot()
+}
+
+// SetScene switches the time of day and/or the weather ("" keeps the current
+// one). A new time of day restarts the cycle timer.
+func (e *Engine) SetScene(timeOfDay, weather string) error {
+	timeIndex := -1
+	if timeOfDay != "" {
+		for i, t := range SceneTimes {
+			if t == timeOfDay {
+				timeIndex = i
+			}
+		}
+		if timeIndex < 0 {
+			return fmt.Errorf("unknown time of day %q", timeOfDay)
+		}
+	}
+	if weather != "" {
+		known := false
+		for _, w := range SceneWeathers {
+			known = known || w == weather
+		}
+		if !known {
+			return fmt.Errorf("unknown weather %q", weather)
+		}
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	sm := e.scene
+	if timeIndex >= 0 {
+		sm.time = timeIndex
+		sm.remaining = sm.cfg.PhaseDuration
+	}
+	if weather != "" {
+		sm.setWeather(weather)
+	}
+	log.Printf("🌗 Arena scene set: %s, %s", SceneTimes[sm.time], sm.target())
+	return nil
+}
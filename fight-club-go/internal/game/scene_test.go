@@ -0,0 +1,68 @@
+package game
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// TestSceneCycle verifies the day -> dusk -> night -> day cycle and the weather rolls
+func TestSceneCycle(t *testing.T) {
+	engine := newTestEngineWith(func(cfg *EngineConfig) { cfg.Scene = SceneConfig{PhaseDuration: 10, WeatherChance: 1} })
+	engine.rng = rand.New(rand.NewSource(1))
+
+	if snap := engine.Scene(); snap.Time != SceneDay || snap.Weather != WeatherClear {
+		t.Fatalf("Expected a clear day to start, got %+v", snap)
+	}
+	for _, want := range []string{SceneDusk, SceneNight, SceneDay} {
+		stepEngine(engine, 10, engine.updateScene)
+		snap := engine.Scene()
+		if snap.Time != want {
+			t.Fatalf("Expected %s, got %+v", want, snap)
+		}
+		if snap.Weather != WeatherRain && snap.Weather != WeatherSnow {
+			t.Errorf("Expected rain or snow with a weather chance of 1, got %+v", snap)
+		}
+	}
+
+	fixed := newTestEngineWith(func(cfg *EngineConfig) { cfg.Scene = SceneConfig{PhaseDuration: 0, WeatherChance: 1} })
+	fixed.rng = rand.New(rand.NewSource(1))
+	stepEngine(fixed, 60, fixed.updateScene)
+	if snap := fixed.Scene(); snap.Time != SceneDay || snap.Weather != WeatherClear || snap.Remaining != 0 {
+		t.Errorf("Expected a fixed clear day without a cycle, got %+v", snap)
+	}
+}
+
+// TestSceneWeatherFade verifies weather ramps in, and fades out before switching
+func TestSceneWeatherFade(t *testing.T) {
+	engine := newTestEngineWith(func(cfg *EngineConfig) { cfg.Scene = SceneConfig{} })
+	engine.rng = rand.New(rand.NewSource(1))
+
+	if err := engine.SetScene(SceneNight, WeatherSnow); err != nil {
+		t.Fatalf("SetScene failed: %v", err)
+	}
+	stepEngine(engine, WeatherFadeTime/2, engine.updateScene)
+	if snap := engine.Scene(); snap.Time != SceneNight || snap.Weather != WeatherSnow || snap.WeatherIntensity < 0.4 || snap.WeatherIntensity > 0.6 {
+		t.Fatalf("Expected snow halfway in, got %+v", snap)
+	}
+	stepEngine(engine, WeatherFadeTime, engine.updateScene)
+	if snap := engine.Scene(); snap.WeatherIntensity != 1 {
+		t.Fatalf("Expected full snow, got %+v", snap)
+	}
+
+	// Snow clears before the rain starts
+	engine.SetScene("", WeatherRain)
+	stepEngine(engine, WeatherFadeTime/2, engine.updateScene)
+	if snap := engine.Scene(); snap.Time != SceneNight || snap.Weather != WeatherSnow || snap.WeatherIntensity >= 1 {
+		t.Fatalf("Expected the snow clearing, got %+v", snap)
+	}
+	stepEngine(engine, WeatherFadeTime, engine.updateScene)
+	if snap := engine.Scene(); snap.Weather != WeatherRain || snap.WeatherIntensity <= 0 {
+		t.Fatalf("Expected the rain setting in, got %+v", snap)
+	}
+
+	for _, bad := range [][2]string{{"noon", ""}, {"", "fog"}} {
+		if err := engine.SetScene(bad[0], bad[1]); err == nil {
+			t.Errorf("Expected an error for %v", bad)
+		}
+	}
+}
@@ -0,0 +1,453 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// scriptReloadInterval is how often the script file is checked for edits
+const scriptReloadInterval = 2 * time.Second
+
+// Script sandbox limits: rules can only use the actions below, within these bounds
+const (
+	maxScriptRules    = 32
+	maxScriptActions  = 8    // Actions per rule
+	maxScriptBosses   = 2    // Bosses alive at once
+	maxBossHP         = 2000 // Boss HP cap (fighters have 100)
+	maxScriptMoney    = 1000 // give_money cap per action
+	maxAnnounceLength = 200
+)
+
+// BossTag prefixes boss names so viewers can tell them apart on stream
+const BossTag = "[BOSS]"
+
+// ErrInvalidScript is returned for malformed script rules
+var ErrInvalidScript = errors.New("invalid script rule")
+
+// ScriptRule is a broadcaster-defined reaction: when the trigger fires, the
+// actions run in order. Rules live in a YAML cue file, e.g.
+//
+//	rules:
+//	  - name: boss at 10 kills
+//	    when: {kills: 10}
+//	    do:
+//	      - announce: "{player} hit 10 kills, a boss enters the arena!"
+//	      - spawn_boss: {name: Titan, hp: 500, weapon: axe}
+type ScriptRule struct {
+	Name string         `yaml:"name"`
+	When ScriptTrigger  `yaml:"when"`
+	Do   []ScriptAction `yaml:"do"`
+}
+
+// ScriptTrigger is when a rule fires. Exactly one field is set.
+type ScriptTrigger struct {
+	Kills   int     `yaml:"kills"`   // A fighter reaches N kills (once per fighter)
+	Minute  float64 `yaml:"minute"`  // Minutes of unpaused arena time (once)
+	Players int     `yaml:"players"` // Fighter count reaches N (again after dropping below)
+}
+
+// ScriptAction is one sandboxed engine action. Exactly one field is set.
+type ScriptAction struct {
+	Announce    string    `yaml:"announce"`     // Chat and on-screen message, {player} = the triggering fighter
+	Chaos       string    `yaml:"chaos"`        // Chaos event ID or "random", skipped while one is running
+	SpawnBoss   *BossSpec `yaml:"spawn_boss"`   // Bot with boosted HP, leaves when killed
+	SuddenDeath bool      `yaml:"sudden_death"` // Every alive fighter drops to 1 HP
+	HealAll     bool      `yaml:"heal_all"`     // Every alive fighter back to full HP
+	GiveMoney   int       `yaml:"give_money"`   // Money for the triggering fighter
+}
+
+// BossSpec describes a boss spawned by a script
+type BossSpec struct {
+	Name   string `yaml:"name"`
+	HP     int    `yaml:"hp"`
+	Weapon string `yaml:"weapon"`
+}
+
+// scriptFile is the cue file layout
+type scriptFile struct {
+	Rules []ScriptRule `yaml:"rules"`
+}
+
+// scriptState is what a rule has already fired for. Kept by rule name, so
+// editing the file doesn't replay rules that already ran.
+type scriptState struct {
+	fired bool            // Minute rules
+	above bool            // Players rules: count is at or above the threshold
+	seen  map[string]bool // Kills rules: fighters that already triggered it
+}
+
+// validateRule checks a rule against the sandbox limits
+func validateRule(r *ScriptRule) error {
+	triggers := 0
+	for _, set := range []bool{r.When.Kills > 0, r.When.Minute > 0, r.When.Players > 0} {
+		if set {
+			triggers++
+		}
+	}
+	if triggers != 1 {
+		return fmt.Errorf("%w: %q needs exactly one of when.kills, when.minute, when.players", ErrInvalidScript, r.Name)
+	}
+	if len(r.Do) == 0 || len(r.Do) > maxScriptActions {
+		return fmt.Errorf("%w: %q needs 1-%d actions", ErrInvalidScript, r.Name, maxScriptActions)
+	}
+
+	for i := range r.Do {
+		a := &r.Do[i]
+		actions := 0
+		for _, set := range []bool{a.Announce != "", a.Chaos != "", a.SpawnBoss != nil, a.SuddenDeath, a.HealAll, a.GiveMoney != 0} {
+			if set {
+				actions++
+			}
+		}
+		if actions != 1 {
+			return fmt.Errorf("%w: %q action %d must set exactly one action", ErrInvalidScript, r.Name, i+1)
+		}
+
+		switch {
+		case len(a.Announce) > maxAnnounceLength:
+			return fmt.Errorf("%w: %q announce is longer than %d characters", ErrInvalidScript, r.Name, maxAnnounceLength)
+		case a.Chaos != "" && a.Chaos != "random":
+			if _, ok := ChaosEvents[a.Chaos]; !ok {
+				return fmt.Errorf("%w: %q unknown chaos event %q", ErrInvalidScript, r.Name, a.Chaos)
+			}
+		case a.GiveMoney < 0 || a.GiveMoney > maxScriptMoney:
+			return fmt.Errorf("%w: %q give_money must be 1-%d", ErrInvalidScript, r.Name, maxScriptMoney)
+		case a.SpawnBoss != nil:
+			b := a.SpawnBoss
+			b.Name = strings.TrimSpace(b.Name)
+			if b.Name == "" {
+				b.Name = "Brute"
+			}
+			if b.HP <= 0 {
+				b.HP = 300
+			}
+			b.HP = min(b.HP, maxBossHP)
+			if b.Weapon == "" {
+				b.Weapon = "axe"
+			}
+			if !IsWeapon(b.Weapon) {
+				return fmt.Errorf("%w: %q unknown boss weapon %q", ErrInvalidScript, r.Name, b.Weapon)
+			}
+		}
+	}
+	return nil
+}
+
+// ScriptRunner loads broadcaster rules from a YAML cue file that is
+// hot-reloaded when edited. Rules only reach the engine through the
+// whitelisted actions in ScriptAction; the engine evaluates them each tick
+// (see Engine.updateScripts).
+type ScriptRunner struct {
+	mu      sync.RWMutex
+	rules   []ScriptRule
+	path    string
+	modTime time.Time // Script file mtime at last load
+
+	running bool
+	stopCh  chan struct{}
+	wg      sync.WaitGroup
+
+	// Callback for announce actions (called in a new goroutine, e.g. to post to Kick chat)
+	OnAnnounce func(text string)
+}
+
+// NewScriptRunner creates a script runner, loading rules from path if it exists
+func NewScriptRunner(path string) (*ScriptRunner, error) {
+	sr := &ScriptRunner{
+		path:   path,
+		stopCh: make(chan struct{}),
+	}
+	if path == "" {
+		return sr, nil
+	}
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		return sr, nil
+	}
+	if err := sr.reload(); err != nil {
+		return nil, err
+	}
+	return sr, nil
+}
+
+// Start begins watching the script file for edits (hot reload)
+func (sr *ScriptRunner) Start() {
+	sr.mu.Lock()
+	if sr.running || sr.path == "" {
+		sr.mu.Unlock()
+		return
+	}
+	sr.running = true
+	sr.mu.Unlock()
+
+	sr.wg.Add(1)
+	go sr.watchLoop()
+}
+
+// Stop stops watching the script file
+func (sr *ScriptRunner) Stop() {
+	sr.mu.Lock()
+	if !sr.running {
+		sr.mu.Unlock()
+		return
+	}
+	sr.running = false
+	sr.mu.Unlock()
+
+	close(sr.stopCh)
+	sr.wg.Wait()
+}
+
+// Rules returns the loaded rules (shared, do not modify)
+func (sr *ScriptRunner) Rules() []ScriptRule {
+	sr.mu.RLock()
+	defer sr.mu.RUnlock()
+	return sr.rules
+}
+
+// watchLoop reloads the script file when its modification time changes
+func (sr *ScriptRunner) watchLoop() {
+	defer sr.wg.Done()
+
+	ticker := time.NewTicker(scriptReloadInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-sr.stopCh:
+			return
+		case <-ticker.C:
+			info, err := os.Stat(sr.path)
+			if err != nil {
+				continue // Deleted or not created yet, keep current rules
+			}
+			sr.mu.RLock()
+			changed := !info.ModTime().Equal(sr.modTime)
+			sr.mu.RUnlock()
+			if !changed {
+				continue
+			}
+			if err := sr.reload(); err != nil {
+				log.Printf("⚠️ Failed to reload game scripts (keeping previous): %v", err)
+			}
+		}
+	}
+}
+
+// reload replaces all rules with the file contents.
+// Invalid rules are skipped with a warning.
+func (sr *ScriptRunner) reload() error {
+	info, err := os.Stat(sr.path)
+	if err != nil {
+		return fmt.Errorf("failed to read game scripts: %w", err)
+	}
+	data, err := os.ReadFile(sr.path)
+	if err != nil {
+		return fmt.Errorf("failed to read game scripts: %w", err)
+	}
+
+	var file scriptFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("failed to parse game scripts: %w", err)
+	}
+
+	rules := make([]ScriptRule, 0, len(file.Rules))
+	names := make(map[string]bool, len(file.Rules))
+	for i, r := range file.Rules {
+		if len(rules) == maxScriptRules {
+			log.Printf("⚠️ Skipping game scripts past the first %d rules", maxScriptRules)
+			break
+		}
+		if r.Name == "" {
+			r.Name = "rule " + strconv.Itoa(i+1)
+		}
+		if names[r.Name] {
+			log.Printf("⚠️ Skipping game script %q: duplicate name", r.Name)
+			continue
+		}
+		if err := validateRule(&r); err != nil {
+			log.Printf("⚠️ Skipping game script: %v", err)
+			continue
+		}
+		names[r.Name] = true
+		rules = append(rules, r)
+	}
+
+	sr.mu.Lock()
+	sr.rules = rules
+	sr.modTime = info.ModTime()
+	sr.mu.Unlock()
+
+	log.Printf("📜 Loaded %d game script rule(s) from %s", len(rules), sr.path)
+	return nil
+}
+
+// SetScripts attaches broadcaster rules to the engine (nil detaches them)
+func (e *Engine) SetScripts(sr *ScriptRunner) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.scripts = sr
+	e.scriptState = make(map[string]*scriptState)
+}
+
+// updateScripts fires rules whose trigger was reached and clears out killed
+// bosses. Caller holds e.mu.
+func (e *Engine) updateScripts() {
+	for name, p := range e.players {
+		if p.IsBoss && p.IsDead && !p.IsRagdoll {
+			delete(e.players, name)
+			e.rosterVersion++
+			log.Printf("👹 Boss defeated: %s", name)
+		}
+	}
+	if e.scripts == nil {
+		return
+	}
+
+	minutes := float64(e.tickCount) / float64(e.tickRate) / 60
+	fighters := 0
+	for _, p := range e.players {
+		if !p.IsDead && !p.IsBoss {
+			fighters++
+		}
+	}
+
+	rules := e.scripts.Rules()
+	for i := range rules {
+		rule := &rules[i]
+		st := e.scriptState[rule.Name]
+		if st == nil {
+			st = &scriptState{seen: make(map[string]bool)}
+			e.scriptState[rule.Name] = st
+		}
+
+		switch {
+		case rule.When.Kills > 0:
+			for _, p := range e.playerSlice {
+				if p.IsBoss || p.Kills < rule.When.Kills || st.seen[p.Name] {
+					continue
+				}
+				st.seen[p.Name] = true
+				e.runScript(rule, p)
+			}
+		case rule.When.Minute > 0:
+			if !st.fired && minutes >= rule.When.Minute {
+				st.fired = true
+				e.runScript(rule, nil)
+			}
+		case rule.When.Players > 0:
+			above := fighters >= rule.When.Players
+			if above && !st.above {
+				e.runScript(rule, nil)
+			}
+			st.above = above
+		}
+	}
+}
+
+// runScript runs a rule's actions; player is the triggering fighter (nil for
+// arena-wide triggers). Caller holds e.mu.
+func (e *Engine) runScript(rule *ScriptRule, player *Player) {
+	who := ""
+	if player != nil {
+		who = player.Name
+	}
+	log.Printf("📜 Game script fired: %s", rule.Name)
+
+	for _, a := range rule.Do {
+		switch {
+		case a.Announce != "":
+			text := strings.ReplaceAll(a.Announce, "{player}", who)
+			if len(e.texts) < e.limits.MaxTexts {
+				e.texts = append(e.texts, &FloatingText{
+					X:     e.worldWidth / 2,
+					Y:     e.worldHeight / 3,
+					Text:  text,
+					Color: "#ffd60a",
+					Alpha: 1.0,
+					VY:    -0.5,
+				})
+			}
+			if e.scripts.OnAnnounce != nil {
+				go e.scripts.OnAnnounce(text)
+			}
+
+		case a.Chaos != "":
+			if e.chaos.event != "" {
+				continue // Don't cut a running event short
+			}
+			id := a.Chaos
+			if id == "random" {
+				id = chaosEventOrder[e.rng.Intn(len(chaosEventOrder))]
+			}
+			e.announceChaos(id)
+
+		case a.SpawnBoss != nil:
+			e.spawnBoss(*a.SpawnBoss)
+
+		case a.SuddenDeath:
+			for _, p := range e.players {
+				if !p.IsDead {
+					p.HP = 1
+				}
+			}
+			e.AddShake(8.0)
+			log.Printf("☠️ Sudden death: every fighter is down to 1 HP")
+
+		case a.HealAll:
+			for _, p := range e.players {
+				if !p.IsDead {
+					p.HP = p.MaxHP
+				}
+			}
+
+		case a.GiveMoney > 0:
+			if player != nil {
+				player.Money += a.GiveMoney
+			}
+		}
+	}
+}
+
+// spawnBoss adds a boss bot unless too many are alive (caller holds e.mu)
+func (e *Engine) spawnBoss(spec BossSpec) {
+	bosses := 0
+	for _, p := range e.players {
+		if p.IsBoss {
+			bosses++
+		}
+	}
+	if bosses >= maxScriptBosses || len(e.players) >= e.limits.MaxTotalPlayers {
+		return
+	}
+	name := BossTag + " " + spec.Name
+	if _, taken := e.players[name]; taken {
+		return
+	}
+
+	boss := NewPlayer(name, PlayerOptions{
+		Color:       "#c0392b",
+		WorldWidth:  e.worldWidth,
+		WorldHeight: e.worldHeight,
+	})
+	boss.IsBot = true
+	boss.IsBoss = true
+	boss.Avatar = "👹"
+	boss.Weapon = spec.Weapon
+	boss.MaxHP = spec.HP
+	boss.HP = spec.HP
+	boss.X = e.worldWidth / 2
+	boss.Y = e.worldHeight / 2
+
+	e.players[name] = boss
+	e.rosterVersion++
+	e.AddShake(10.0)
+	log.Printf("👹 Boss entered the arena: %s (%d HP)", name, spec.HP)
+}
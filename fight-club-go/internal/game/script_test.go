@@ -0,0 +1,197 @@
+package game
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// loadScripts writes the given cue file and loads it
+func loadScripts(t *testing.T, rules string) *ScriptRunner {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "game-scripts.yaml")
+	if err := os.WriteFile(path, []byte(rules), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	sr, err := NewScriptRunner(path)
+	if err != nil {
+		t.Fatalf("NewScriptRunner: %v", err)
+	}
+	return sr
+}
+
+// stepScripts evaluates the script rules once with a fresh player index
+func stepScripts(e *Engine) {
+	e.indexPlayers()
+	e.updateScripts()
+}
+
+// TestScriptValidation verifies invalid rules are skipped and boss specs are clamped
+func TestScriptValidation(t *testing.T) {
+	sr := loadScripts(t, `
+rules:
+  - name: boss
+    when: {kills: 5}
+    do:
+      - spawn_boss: {hp: 99999}
+  - name: two triggers
+    when: {kills: 5, minute: 1}
+    do:
+      - heal_all: true
+  - name: two actions in one entry
+    when: {minute: 1}
+    do:
+      - heal_all: true
+        sudden_death: true
+  - name: unknown chaos
+    when: {minute: 1}
+    do:
+      - chaos: tornado
+  - name: too rich
+    when: {minute: 1}
+    do:
+      - give_money: 1000000
+`)
+
+	rules := sr.Rules()
+	if len(rules) != 1 || rules[0].Name != "boss" {
+		t.Fatalf("Expected only the valid rule to load, got %+v", rules)
+	}
+	boss := rules[0].Do[0].SpawnBoss
+	if boss.HP != maxBossHP || boss.Name == "" || boss.Weapon == "" {
+		t.Errorf("Expected clamped HP and default name/weapon, got %+v", boss)
+	}
+}
+
+// TestScriptKillsSpawnsBoss verifies a kills rule fires once per fighter and the boss leaves when killed
+func TestScriptKillsSpawnsBoss(t *testing.T) {
+	engine := newTestEngine(30)
+	engine.arenaBotEnabled = false
+	engine.SetScripts(loadScripts(t, `
+rules:
+  - name: boss at 3 kills
+    when: {kills: 3}
+    do:
+      - spawn_boss: {name: Titan, hp: 500, weapon: hammer}
+      - give_money: 200
+`))
+	player := engine.AddPlayer("viewer1", PlayerOptions{})
+	money := player.Money
+
+	stepScripts(engine)
+	if len(engine.players) != 1 {
+		t.Fatalf("Expected no boss before 3 kills, got %d players", len(engine.players))
+	}
+
+	player.Kills = 3
+	stepScripts(engine)
+	stepScripts(engine)
+	boss := engine.players[BossTag+" Titan"]
+	if boss == nil || !boss.IsBoss || boss.HP != 500 || boss.MaxHP != 500 || boss.Weapon != "hammer" {
+		t.Fatalf("Expected a 500 HP hammer boss, got %+v", boss)
+	}
+	if player.Money != money+200 {
+		t.Errorf("Expected the rule to pay out once, money %d -> %d", money, player.Money)
+	}
+
+	// Bot fill leaves bosses alone
+	engine.botFill = BotFillConfig{MinPlayers: 0}
+	engine.updateBotFill(1.0 / 30)
+	if engine.players[boss.Name] == nil {
+		t.Fatal("Expected bot fill to keep the boss")
+	}
+
+	boss.IsDead = true
+	boss.IsRagdoll = false
+	stepScripts(engine)
+	if engine.players[boss.Name] != nil {
+		t.Error("Expected the killed boss to leave the arena")
+	}
+}
+
+// TestScriptMinuteSuddenDeath verifies minute rules fire once, also across a reload
+func TestScriptMinuteSuddenDeath(t *testing.T) {
+	rules := `
+rules:
+  - name: sudden death
+    when: {minute: 1}
+    do:
+      - sudden_death: true
+`
+	sr := loadScripts(t, rules)
+	engine := newTestEngine(30)
+	engine.arenaBotEnabled = false
+	engine.SetScripts(sr)
+	announced := make(chan string, 1)
+	sr.OnAnnounce = func(text string) { announced <- text }
+	player := engine.AddPlayer("viewer1", PlayerOptions{})
+
+	engine.tickCount = 59 * 30
+	stepScripts(engine)
+	if player.HP != player.MaxHP {
+		t.Fatalf("Expected full HP before minute 1, got %d", player.HP)
+	}
+
+	engine.tickCount = 60 * 30
+	stepScripts(engine)
+	if player.HP != 1 {
+		t.Fatalf("Expected sudden death to drop HP to 1, got %d", player.HP)
+	}
+
+	// Editing the file keeps the rule's fired state
+	player.HP = player.MaxHP
+	if err := os.WriteFile(sr.path, []byte(rules+`
+  - name: welcome
+    when: {minute: 1}
+    do:
+      - announce: "one minute in"
+`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := sr.reload(); err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	stepScripts(engine)
+	if player.HP != player.MaxHP {
+		t.Error("Expected sudden death not to fire again after a reload")
+	}
+	if got := <-announced; got != "one minute in" {
+		t.Errorf("Expected the new rule to announce, got %q", got)
+	}
+}
+
+// TestScriptPlayersTrigger verifies players rules re-arm after the count drops
+func TestScriptPlayersTrigger(t *testing.T) {
+	engine := newTestEngine(30)
+	engine.arenaBotEnabled = false
+	engine.SetScripts(loadScripts(t, `
+rules:
+  - name: crowd
+    when: {players: 2}
+    do:
+      - give_money: 1
+      - heal_all: true
+`))
+	a := engine.AddPlayer("a", PlayerOptions{})
+	b := engine.AddPlayer("b", PlayerOptions{})
+	a.HP = 10
+
+	stepScripts(engine)
+	if a.HP != a.MaxHP {
+		t.Fatalf("Expected heal_all at 2 fighters, got %d HP", a.HP)
+	}
+
+	a.HP = 10
+	stepScripts(engine)
+	if a.HP != 10 {
+		t.Fatal("Expected no repeat while the count stays at 2")
+	}
+
+	b.IsDead = true
+	stepScripts(engine)
+	b.IsDead = false
+	stepScripts(engine)
+	if a.HP != a.MaxHP {
+		t.Error("Expected the rule to fire again after dropping below 2")
+	}
+}
@@ -0,0 +1,285 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"fight-club/internal/config"
+)
+
+// ShopConfig is an alias for config.ShopConfig (SSOT)
+type ShopConfig = config.ShopConfig
+
+// shopNames are the shops' on-stream names, in placement order
+var shopNames = [config.MaxShops]string{"Armory", "Forge", "Fletcher", "Bazaar", "Smithy", "Outpost"}
+
+const shopFieldPrefix = "shop:"
+
+// shopFieldKey is the flow field key of the path to shop i
+func shopFieldKey(i int) string {
+	return shopFieldPrefix + strconv.Itoa(i)
+}
+
+// ShopSnapshot is a weapon shop on the map
+type ShopSnapshot struct {
+	X, Y   float64
+	Radius float64
+	Name   string
+	Stock  []string // Weapon IDs sold here, cheapest first (shared, read-only)
+	Orders int      // Fighters on their way here
+}
+
+// shop is a weapon shop circle on the map
+type shop struct {
+	x, y float64
+	name string
+}
+
+// ShopManager places the weapon shops and splits the weapons between them.
+// Its state is guarded by the engine mutex.
+type ShopManager struct {
+	cfg   ShopConfig
+	shops []shop
+
+	// Stock per shop, rebuilt when the weapons file is reloaded
+	stock        [][]string
+	stockVersion uint64
+}
+
+// NewShopManager places cfg.Count shops in a world of the given size
+func NewShopManager(cfg ShopConfig, width, height float64) *ShopManager {
+	sm := &ShopManager{cfg: cfg}
+	sm.layout(width, height)
+	return sm
+}
+
+// Enabled reports whether !buy goes through the shops
+func (sm *ShopManager) Enabled() bool {
+	return len(sm.shops) > 0
+}
+
+// layout spreads the shops along the top and bottom of the arena, away from
+// the center (duels, capture point) and the side team bases
+func (sm *ShopManager) layout(width, height float64) {
+	n := min(max(sm.cfg.Count, 0), config.MaxShops)
+	sm.shops = sm.shops[:0]
+	for i := 0; i < n; i++ {
+		y := height * 0.2
+		if i%2 == 1 {
+			y = height * 0.8
+		}
+		x := width * float64(i+1) / float64(n+1)
+		sm.shops = append(sm.shops, shop{x: x, y: y, name: shopNames[i]})
+	}
+	sm.stockVersion = 0 // Rebuild the stock with the new shop count
+}
+
+// stockLocked returns the weapons sold by each shop: the priced weapons,
+// cheapest first, dealt out in turn so every shop has cheap and strong ones
+func (sm *ShopManager) stockLocked() [][]string {
+	version := WeaponRegistryVersion() + 1 // 0 = never built
+	if sm.stockVersion == version || len(sm.shops) == 0 {
+		return sm.stock
+	}
+
+	weapons := GetAllWeapons()
+	slices.SortFunc(weapons, func(a, b Weapon) int {
+		if a.Price != b.Price {
+			return a.Price - b.Price
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	sm.stock = make([][]string, len(sm.shops))
+	i := 0
+	for _, w := range weapons {
+		if w.Price <= 0 {
+			continue // Fists are free everywhere
+		}
+		sm.stock[i%len(sm.shops)] = append(sm.stock[i%len(sm.shops)], w.ID)
+		i++
+	}
+	sm.stockVersion = version
+	return sm.stock
+}
+
+// shopFor returns the shop stocking a weapon closest to (x, y), -1 if none
+func (sm *ShopManager) shopFor(weaponID string, x, y float64) int {
+	best, bestDist := -1, math.Inf(1)
+	for i, stock := range sm.stockLocked() {
+		if !slices.Contains(stock, weaponID) {
+			continue
+		}
+		if d := math.Hypot(sm.shops[i].x-x, sm.shops[i].y-y); d < bestDist {
+			best, bestDist = i, d
+		}
+	}
+	return best
+}
+
+// OrderWeapon sends a fighter to the nearest shop stocking the weapon. The
+// weapon is paid for and equipped when they walk into the shop circle.
+// Returns the shop name, or "" when shops are off (buy instantly instead).
+func (e *Engine) OrderWeapon(username, weaponID string) (string, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	sm := e.shops
+	if !sm.Enabled() {
+		return "", nil
+	}
+	p, ok := e.players[username]
+	if !ok || p.IsDead {
+		return "", errors.New("not in the arena")
+	}
+	i := sm.shopFor(weaponID, p.X, p.Y)
+	if i < 0 {
+		return "", fmt.Errorf("no shop sells %s", strings.ToLower(GetWeapon(weaponID).Name))
+	}
+
+	p.ShopOrder = weaponID
+	p.ShopIndex = i
+	p.ShopTimer = sm.cfg.OrderTimeout
+	log.Printf("🛒 %s is heading to the %s for %s", username, sm.shops[i].name, GetWeapon(weaponID).Name)
+	return sm.shops[i].name, nil
+}
+
+// goShopping moves a fighter with a shop order along the flow field to the
+// shop. Returns false when there is no order or an enemy is in weapon range
+// (fight back first) so the normal AI runs. Caller holds e.mu.
+func (p *Player) goShopping(deltaTime float64, engine *Engine) bool {
+	if p.ShopOrder == "" || engine == nil || p.ShopIndex >= len(engine.shops.shops) {
+		return false
+	}
+	if p.Target != nil && p.distanceTo(p.Target) <= GetWeapon(p.Weapon).Range {
+		return false
+	}
+
+	s := engine.shops.shops[p.ShopIndex]
+	dx, dy := s.x-p.X, s.y-p.Y
+	dist := math.Hypot(dx, dy)
+	if dist < 1 {
+		return false
+	}
+	dx, dy = dx/dist, dy/dist
+	field := engine.flowFieldManager.GetOrCreate(shopFieldKey(p.ShopIndex), s.x, s.y)
+	if flowX, flowY := field.Lookup(p.X, p.Y); flowX != 0 || flowY != 0 {
+		dx, dy = float64(flowX), float64(flowY)
+	}
+	moveSpeed := 5.0 * p.speedMultiplier()
+	p.VX += dx * moveSpeed * deltaTime * 60
+	p.VY += dy * moveSpeed * deltaTime * 60
+	return true
+}
+
+// clearShopOrder cancels a pending shop order (death, timeout, purchase)
+func (p *Player) clearShopOrder() {
+	p.ShopOrder = ""
+	p.ShopTimer = 0
+}
+
+// updateShops completes the orders of fighters inside their shop and cancels
+// expired ones. Payment is taken at the shop: in-arena money first, then the
+// wallet. Caller holds e.mu.
+func (e *Engine) updateShops(deltaTime float64) {
+	sm := e.shops
+	if !sm.Enabled() {
+		return
+	}
+
+	for _, p := range e.playerSlice {
+		if p.ShopOrder == "" || p.IsDead {
+			continue
+		}
+		if p.ShopIndex >= len(sm.shops) {
+			p.clearShopOrder() // The arena changed under the order
+			continue
+		}
+
+		s := sm.shops[p.ShopIndex]
+		if math.Hypot(p.X-s.x, p.Y-s.y) > sm.cfg.Radius {
+			p.ShopTimer -= deltaTime
+			if p.ShopTimer <= 0 {
+				log.Printf("🛒 %s's %s order expired", p.Name, GetWeapon(p.ShopOrder).Name)
+				e.shopText(p, "Order expired", "#9e9e9e")
+				p.clearShopOrder()
+			}
+			continue
+		}
+
+		weapon := GetWeapon(p.ShopOrder)
+		p.clearShopOrder()
+		if !e.chargeLocked(p, weapon.Price) {
+			log.Printf("💰 %s can't afford %s at the %s anymore", p.Name, weapon.Name, s.name)
+			e.shopText(p, "Not enough $", "#ff5252")
+			continue
+		}
+		p.Weapon = weapon.ID
+		log.Printf("🗡️ %s bought %s for $%d at the %s!", p.Name, weapon.Name, weapon.Price, s.name)
+		e.shopText(p, "+"+weapon.Name, weapon.Color)
+		e.emitParticles("hit", p.X, p.Y, weapon.Color, 12)
+	}
+}
+
+// chargeLocked takes price from the fighter's in-arena money, then their
+// wallet. Returns false (charging nothing) if they can't afford it.
+func (e *Engine) chargeLocked(p *Player, price int) bool {
+	if p.Money >= price {
+		p.Money -= price
+		return true
+	}
+	if !e.wallets.Spend(p.Name, price-p.Money) {
+		return false
+	}
+	p.Money = 0
+	return true
+}
+
+// shopText floats a shop message above a fighter. Caller holds e.mu.
+func (e *Engine) shopText(p *Player, text, color string) {
+	if len(e.texts) >= e.limits.MaxTexts {
+		return
+	}
+	e.texts = append(e.texts, &FloatingText{
+		X:     p.X,
+		Y:     p.Y - 30,
+		Text:  text,
+		Color: color,
+		Alpha: 1.0,
+		VY:    -1.5,
+	})
+}
+
+// keepShopField reports whether a flow field key is a path to a shop
+func (e *Engine) keepShopField(key string) bool {
+	index, ok := strings.CutPrefix(key, shopFieldPrefix)
+	if !ok {
+		return false
+	}
+	i, err := strconv.Atoi(index)
+	return err == nil && i < len(e.shops.shops)
+}
+
+// shopSnapshot copies the shops and their stock into the snapshot. Caller holds e.mu.
+func (e *Engine) shopSnapshot(snap *GameSnapshot) {
+	sm := e.shops
+	stock := sm.stockLocked()
+	for i, s := range sm.shops {
+		snap.Shops = append(snap.Shops, ShopSnapshot{
+			X:      s.x,
+			Y:      s.y,
+			Radius: sm.cfg.Radius,
+			Name:   s.name,
+			Stock:  stock[i],
+		})
+	}
+	for _, p := range e.playerSlice {
+		if p.ShopOrder != "" && !p.IsDead && p.ShopIndex < len(snap.Shops) {
+			snap.Shops[p.ShopIndex].Orders++
+		}
+	}
+}
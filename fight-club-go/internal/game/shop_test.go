@@ -0,0 +1,101 @@
+package game
+
+import "testing"
+
+// TestShopStock verifies the priced weapons are split between the shops
+func TestShopStock(t *testing.T) {
+	sm := NewShopManager(ShopConfig{Count: 2, Radius: 60, OrderTimeout: 30}, 1280, 720)
+	stock := sm.stockLocked()
+	if len(stock) != 2 {
+		t.Fatalf("Expected stock for 2 shops, got %d", len(stock))
+	}
+
+	seen := map[string]int{}
+	for i, ids := range stock {
+		for _, id := range ids {
+			seen[id]++
+			if GetWeapon(id).Price <= 0 {
+				t.Errorf("Expected no free weapons in shop %d, got %s", i, id)
+			}
+		}
+	}
+	for _, w := range GetAllWeapons() {
+		if w.Price > 0 && seen[w.ID] != 1 {
+			t.Errorf("Expected %s sold in exactly one shop, got %d", w.ID, seen[w.ID])
+		}
+	}
+
+	if NewShopManager(ShopConfig{Count: 0}, 1280, 720).Enabled() {
+		t.Error("Expected no shops with Count 0")
+	}
+}
+
+// TestShopOrder verifies a !buy is paid and equipped only inside the shop
+func TestShopOrder(t *testing.T) {
+	engine := newTestEngine(30)
+	engine.shops = NewShopManager(ShopConfig{Count: 2, Radius: 60, OrderTimeout: 30}, 1280, 720)
+
+	ana := engine.AddPlayer("ana", PlayerOptions{})
+	ana.Money = 1000
+	shopName, err := engine.OrderWeapon("ana", "sword")
+	if err != nil || shopName == "" {
+		t.Fatalf("Expected the sword ordered, got %q, %v", shopName, err)
+	}
+
+	s := engine.shops.shops[ana.ShopIndex]
+	ana.X, ana.Y = s.x+200, s.y
+	engine.playerSlice = []*Player{ana}
+	engine.mu.Lock()
+	engine.updateShops(1)
+	engine.mu.Unlock()
+	if ana.Weapon == "sword" || ana.Money != 1000 {
+		t.Fatal("Expected nothing bought outside the shop")
+	}
+
+	// The AI heads for the shop
+	ana.VX, ana.VY = 0, 0
+	if !ana.goShopping(1.0/30, engine) || ana.VX >= 0 {
+		t.Errorf("Expected ana steered left toward the shop, got VX %.2f", ana.VX)
+	}
+
+	ana.X = s.x + 10
+	engine.mu.Lock()
+	engine.updateShops(1)
+	engine.mu.Unlock()
+	price := GetWeapon("sword").Price
+	if ana.Weapon != "sword" || ana.Money != 1000-price || ana.ShopOrder != "" {
+		t.Errorf("Expected the sword bought for $%d at the shop, got %s with $%d", price, ana.Weapon, ana.Money)
+	}
+}
+
+// TestShopOrderCancelled verifies orders end on death and after the timeout
+func TestShopOrderCancelled(t *testing.T) {
+	engine := newTestEngine(30)
+	engine.shops = NewShopManager(ShopConfig{Count: 2, Radius: 60, OrderTimeout: 5}, 1280, 720)
+
+	ana := engine.AddPlayer("ana", PlayerOptions{})
+	ana.Money = 1000
+	engine.playerSlice = []*Player{ana}
+	engine.OrderWeapon("ana", "axe")
+	ana.X, ana.Y = 0, 360 // Far from every shop
+
+	engine.mu.Lock()
+	engine.updateShops(3)
+	if ana.ShopOrder != "axe" {
+		t.Error("Expected the order kept before the timeout")
+	}
+	engine.updateShops(3)
+	engine.mu.Unlock()
+	if ana.ShopOrder != "" || ana.Money != 1000 {
+		t.Error("Expected the order cancelled for free after the timeout")
+	}
+
+	engine.OrderWeapon("ana", "axe")
+	ana.die(nil)
+	if ana.ShopOrder != "" {
+		t.Error("Expected the order cancelled on death")
+	}
+	if _, err := engine.OrderWeapon("ana", "axe"); err == nil {
+		t.Error("Expected no order while dead")
+	}
+}
@@ -0,0 +1,423 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"fight-club/internal/config"
+)
+
+// SkinConfig is an alias for config.SkinConfig (SSOT)
+type SkinConfig = config.SkinConfig
+
+// SkinSlot is the part of the fighter a skin changes (one equipped per slot)
+type SkinSlot string
+
+const (
+	SkinSlotBorder    SkinSlot = "border"    // Ring around the body
+	SkinSlotTrail     SkinSlot = "trail"     // Weapon swing color
+	SkinSlotNameplate SkinSlot = "nameplate" // Name under the fighter
+)
+
+// SkinStyle is how the streamer draws a skin
+type SkinStyle string
+
+const (
+	SkinStyleSolid   SkinStyle = "solid"   // Flat Color
+	SkinStyleFlame   SkinStyle = "flame"   // Flickering flame tongues in Color
+	SkinStyleRainbow SkinStyle = "rainbow" // Cycling hues (Color unused)
+)
+
+// skinSaveInterval is how often changed loadouts are flushed to disk
+const skinSaveInterval = 30 * time.Second
+
+// Skin is a cosmetic bought with in-game money (!skin <name>)
+type Skin struct {
+	ID    string    `json:"id"`
+	Name  string    `json:"name"`
+	Emoji string    `json:"emoji"`
+	Slot  SkinSlot  `json:"slot"`
+	Style SkinStyle `json:"style"`
+	Color string    `json:"color"`
+	Price int       `json:"price"`
+}
+
+// Skins is the registry of all cosmetic skins
+var Skins = map[string]Skin{
+	"gold": {
+		ID:    "gold",
+		Name:  "Gold Ring",
+		Emoji: "💍",
+		Slot:  SkinSlotBorder,
+		Style: SkinStyleSolid,
+		Color: "#ffd700",
+		Price: 1000,
+	},
+	"frost": {
+		ID:    "frost",
+		Name:  "Frost Ring",
+		Emoji: "❄️",
+		Slot:  SkinSlotBorder,
+		Style: SkinStyleSolid,
+		Color: "#7fdbff",
+		Price: 1000,
+	},
+	"flame": {
+		ID:    "flame",
+		Name:  "Flame Outline",
+		Emoji: "🔥",
+		Slot:  SkinSlotBorder,
+		Style: SkinStyleFlame,
+		Color: "#ff6a00",
+		Price: 2500,
+	},
+	"crimson": {
+		ID:    "crimson",
+		Name:  "Crimson Trail",
+		Emoji: "🩸",
+		Slot:  SkinSlotTrail,
+		Style: SkinStyleSolid,
+		Color: "#ff1744",
+		Price: 800,
+	},
+	"toxic": {
+		ID:    "toxic",
+		Name:  "Toxic Trail",
+		Emoji: "☢️",
+		Slot:  SkinSlotTrail,
+		Style: SkinStyleSolid,
+		Color: "#39ff14",
+		Price: 800,
+	},
+	"golden": {
+		ID:    "golden",
+		Name:  "Golden Name",
+		Emoji: "👑",
+		Slot:  SkinSlotNameplate,
+		Style: SkinStyleSolid,
+		Color: "#d4a017",
+		Price: 1500,
+	},
+	"rainbow": {
+		ID:    "rainbow",
+		Name:  "Rainbow Name",
+		Emoji: "🌈",
+		Slot:  SkinSlotNameplate,
+		Style: SkinStyleRainbow,
+		Price: 3000,
+	},
+}
+
+// GetSkin returns a skin by ID and whether it exists
+func GetSkin(id string) (Skin, bool) {
+	s, ok := Skins[id]
+	return s, ok
+}
+
+// GetAllSkins returns all skins, cheapest first (shop order)
+func GetAllSkins() []Skin {
+	skins := make([]Skin, 0, len(Skins))
+	for _, s := range Skins {
+		skins = append(skins, s)
+	}
+	sort.Slice(skins, func(i, j int) bool {
+		if skins[i].Price != skins[j].Price {
+			return skins[i].Price < skins[j].Price
+		}
+		return skins[i].ID < skins[j].ID
+	})
+	return skins
+}
+
+// PlayerSkins are the skin IDs a fighter has equipped ("" = default look)
+type PlayerSkins struct {
+	Border    string `json:"border,omitempty"`
+	Trail     string `json:"trail,omitempty"`
+	Nameplate string `json:"nameplate,omitempty"`
+}
+
+// equip puts a skin in its slot
+func (ps *PlayerSkins) equip(s Skin) {
+	switch s.Slot {
+	case SkinSlotBorder:
+		ps.Border = s.ID
+	case SkinSlotTrail:
+		ps.Trail = s.ID
+	case SkinSlotNameplate:
+		ps.Nameplate = s.ID
+	}
+}
+
+// skinLoadout is one viewer's persisted skins
+type skinLoadout struct {
+	Owned    []string    `json:"owned"`
+	Equipped PlayerSkins `json:"equipped"`
+}
+
+// owns reports whether the viewer bought a skin
+func (l *skinLoadout) owns(id string) bool {
+	for _, owned := range l.Owned {
+		if owned == id {
+			return true
+		}
+	}
+	return false
+}
+
+// SkinPurchase is the outcome of Engine.BuySkin
+type SkinPurchase int
+
+const (
+	SkinBought   SkinPurchase = iota // Paid for and equipped
+	SkinEquipped                     // Already owned, equipped for free
+)
+
+// Skin purchase errors
+var (
+	ErrUnknownSkin   = errors.New("unknown skin, see !skin")
+	ErrSkinEquipped  = errors.New("already equipped")
+	ErrSkinNoWallet  = errors.New("type !join first to get a wallet")
+	ErrSkinNotEnough = errors.New("not enough money")
+)
+
+// SkinStore persists the skins each viewer owns and has equipped.
+// Skins survive deaths and restarts, like wallet money.
+type SkinStore struct {
+	mu          sync.RWMutex
+	players     map[string]*skinLoadout
+	cfg         SkinConfig
+	dirty       bool
+	saveBlocked bool // The file didn't parse and couldn't be moved aside
+
+	running bool
+	stopCh  chan struct{}
+	wg      sync.WaitGroup
+}
+
+// NewSkinStore creates a skin store (File "" = in-memory only)
+func NewSkinStore(cfg SkinConfig) *SkinStore {
+	return &SkinStore{
+		players: make(map[string]*skinLoadout),
+		cfg:     cfg,
+		stopCh:  make(chan struct{}),
+	}
+}
+
+// Start loads persisted skins and begins periodic saving
+func (ss *SkinStore) Start() {
+	ss.mu.Lock()
+	if ss.running {
+		ss.mu.Unlock()
+		return
+	}
+	ss.running = true
+	ss.mu.Unlock()
+
+	ss.load()
+
+	ss.wg.Add(1)
+	go ss.saveLoop()
+}
+
+// Stop stops periodic saving and flushes skins to disk
+func (ss *SkinStore) Stop() {
+	ss.mu.Lock()
+	if !ss.running {
+		ss.mu.Unlock()
+		return
+	}
+	ss.running = false
+	ss.mu.Unlock()
+
+	close(ss.stopCh)
+	ss.wg.Wait()
+	ss.save()
+}
+
+// Equipped returns a viewer's equipped skins (zero value if none)
+func (ss *SkinStore) Equipped(username string) PlayerSkins {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+
+	if l, ok := ss.players[username]; ok {
+		return l.Equipped
+	}
+	return PlayerSkins{}
+}
+
+// Owned returns the IDs of the skins a viewer bought
+func (ss *SkinStore) Owned(username string) []string {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+
+	if l, ok := ss.players[username]; ok {
+		return append([]string(nil), l.Owned...)
+	}
+	return nil
+}
+
+// Owns reports whether a viewer bought a skin
+func (ss *SkinStore) Owns(username, skinID string) bool {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+
+	l, ok := ss.players[username]
+	return ok && l.owns(skinID)
+}
+
+// Equip equips a skin, adding it to the owned skins if needed.
+// Returns the viewer's new loadout.
+func (ss *SkinStore) Equip(username string, s Skin) PlayerSkins {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	l, ok := ss.players[username]
+	if !ok {
+		l = &skinLoadout{}
+		ss.players[username] = l
+	}
+	if !l.owns(s.ID) {
+		l.Owned = append(l.Owned, s.ID)
+	}
+	l.Equipped.equip(s)
+	ss.dirty = true
+	return l.Equipped
+}
+
+// saveLoop flushes loadouts to disk every skinSaveInterval
+func (ss *SkinStore) saveLoop() {
+	defer ss.wg.Done()
+
+	ticker := time.NewTicker(skinSaveInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ss.stopCh:
+			return
+		case <-ticker.C:
+			ss.save()
+		}
+	}
+}
+
+// save persists loadouts to disk if anything changed
+func (ss *SkinStore) save() {
+	ss.mu.Lock()
+	if !ss.dirty || ss.cfg.File == "" || ss.saveBlocked {
+		ss.mu.Unlock()
+		return
+	}
+	data, err := json.MarshalIndent(ss.players, "", "  ")
+	ss.dirty = false
+	ss.mu.Unlock()
+
+	if err != nil {
+		log.Printf("⚠️ Failed to marshal skins: %v", err)
+		return
+	}
+
+	if err := WriteFileAtomic(ss.cfg.File, data, 0600); err != nil {
+		log.Printf("⚠️ Failed to save skins: %v", err)
+	}
+}
+
+// load restores persisted loadouts from disk
+func (ss *SkinStore) load() {
+	if ss.cfg.File == "" {
+		return
+	}
+
+	data, err := os.ReadFile(ss.cfg.File)
+	if err != nil {
+		return // No saved skins
+	}
+
+	var players map[string]*skinLoadout
+	if err := json.Unmarshal(data, &players); err != nil {
+		if !setAsideCorrupt(ss.cfg.File, "skins", err) {
+			ss.mu.Lock()
+			ss.saveBlocked = true
+			ss.mu.Unlock()
+		}
+		return
+	}
+
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	for name, l := range players {
+		if l != nil {
+			ss.players[name] = l
+		}
+	}
+	log.Printf("📂 Loaded skins for %d viewers", len(players))
+}
+
+// BuySkin buys and equips a skin for a viewer, or equips it for free if they
+// already own it. Like weapons, in-arena money is spent first and the wallet
+// covers the rest; the viewer doesn't need to be alive or in the arena.
+func (e *Engine) BuySkin(username, skinID string) (SkinPurchase, error) {
+	skin, ok := GetSkin(skinID)
+	if !ok {
+		return 0, ErrUnknownSkin
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	player := e.players[username]
+	result := SkinEquipped
+	if !e.skins.Owns(username, skin.ID) {
+		if _, ok := e.wallets.GetWallet(username); !ok && player == nil {
+			return 0, ErrSkinNoWallet
+		}
+
+		arena := 0
+		if player != nil {
+			arena = player.Money
+		}
+		if funds := arena + e.wallets.Balance(username); funds < skin.Price {
+			return 0, fmt.Errorf("%w: need $%d more", ErrSkinNotEnough, skin.Price-funds)
+		}
+
+		// Spend in-arena money first, then the wallet covers the rest
+		if arena >= skin.Price {
+			player.Money -= skin.Price
+		} else {
+			if !e.wallets.Spend(username, skin.Price-arena) {
+				return 0, ErrSkinNotEnough
+			}
+			if player != nil {
+				player.Money = 0
+			}
+		}
+		result = SkinBought
+	} else if e.skins.Equipped(username) == e.equippedWith(username, skin) {
+		return 0, ErrSkinEquipped
+	}
+
+	equipped := e.skins.Equip(username, skin)
+	if player != nil {
+		player.Skins = equipped
+	}
+	return result, nil
+}
+
+// equippedWith returns the viewer's loadout with skin equipped
+func (e *Engine) equippedWith(username string, skin Skin) PlayerSkins {
+	skins := e.skins.Equipped(username)
+	skins.equip(skin)
+	return skins
+}
+
+// GetSkinStore returns the cosmetic skin store
+func (e *Engine) GetSkinStore() *SkinStore {
+	return e.skins
+}
@@ -0,0 +1,83 @@
+package game
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+// TestBuySkinSpendsArenaThenWallet verifies skins are paid like weapons and equipped on the fighter
+func TestBuySkinSpendsArenaThenWallet(t *testing.T) {
+	engine := newTestEngine(30)
+	player := engine.AddPlayer("buyer", PlayerOptions{})
+	player.Money = 600
+	engine.GetWalletManager().Credit("buyer", 300)
+
+	if _, err := engine.BuySkin("buyer", "gold"); !errors.Is(err, ErrSkinNotEnough) {
+		t.Fatalf("Expected not enough money for a $1000 skin with $900, got %v", err)
+	}
+
+	engine.GetWalletManager().Credit("buyer", 200)
+	result, err := engine.BuySkin("buyer", "gold")
+	if err != nil || result != SkinBought {
+		t.Fatalf("Expected purchase, got %v %v", result, err)
+	}
+	if player.Money != 0 || engine.GetWalletManager().Balance("buyer") != 100 {
+		t.Errorf("Expected $0 arena / $100 wallet left, got $%d / $%d", player.Money, engine.GetWalletManager().Balance("buyer"))
+	}
+	if player.Skins.Border != "gold" {
+		t.Errorf("Expected gold border equipped, got %+v", player.Skins)
+	}
+
+	engine.GetWalletManager().Credit("buyer", 1000)
+	if _, err := engine.BuySkin("buyer", "frost"); err != nil {
+		t.Fatalf("Expected frost purchase, got %v", err)
+	}
+	balance := engine.GetWalletManager().Balance("buyer")
+
+	// Owned skins are re-equipped for free, once
+	if result, err := engine.BuySkin("buyer", "gold"); err != nil || result != SkinEquipped {
+		t.Fatalf("Expected free re-equip, got %v %v", result, err)
+	}
+	if _, err := engine.BuySkin("buyer", "gold"); !errors.Is(err, ErrSkinEquipped) {
+		t.Errorf("Expected already equipped, got %v", err)
+	}
+	if engine.GetWalletManager().Balance("buyer") != balance || player.Skins.Border != "gold" {
+		t.Errorf("Expected re-equip to be free and swap the border, got $%d %+v", engine.GetWalletManager().Balance("buyer"), player.Skins)
+	}
+
+	if _, err := engine.BuySkin("buyer", "cape"); !errors.Is(err, ErrUnknownSkin) {
+		t.Errorf("Expected unknown skin, got %v", err)
+	}
+	if _, err := engine.BuySkin("stranger", "gold"); !errors.Is(err, ErrSkinNoWallet) {
+		t.Errorf("Expected no wallet for a viewer who never joined, got %v", err)
+	}
+}
+
+// TestSkinStorePersists verifies loadouts survive a restart and come back on join
+func TestSkinStorePersists(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "skins.json")
+
+	store := NewSkinStore(SkinConfig{File: file})
+	store.Start()
+	store.Equip("fan", Skins["flame"])
+	store.Equip("fan", Skins["rainbow"])
+	store.Stop()
+
+	engine := NewEngine(EngineConfig{TickRate: 30, Skins: SkinConfig{File: file}})
+	engine.GetSkinStore().Start()
+	defer engine.GetSkinStore().Stop()
+
+	player := engine.AddPlayer("fan", PlayerOptions{})
+	if player.Skins.Border != "flame" || player.Skins.Nameplate != "rainbow" || player.Skins.Trail != "" {
+		t.Errorf("Expected flame border and rainbow name restored, got %+v", player.Skins)
+	}
+	if owned := engine.GetSkinStore().Owned("fan"); len(owned) != 2 {
+		t.Errorf("Expected 2 owned skins, got %v", owned)
+	}
+
+	engine.ProduceSnapshot()
+	if snap := engine.GetSnapshot(); len(snap.Players) == 0 || snap.Players[0].Skins != player.Skins {
+		t.Errorf("Expected skins in the player snapshot, got %+v", snap.Players)
+	}
+}
@@ -0,0 +1,64 @@
+package game
+
+import (
+	"fmt"
+	"sync"
+)
+
+// DefaultSoundPack is the pack of the sounds directory itself
+const DefaultSoundPack = "default"
+
+// SoundPackSelector is the sound pack the stream plays. The game server owns
+// the choice (admin API) and ships it in snapshots; the streamer loads the
+// pack's sounds. The server lists the available packs from the sounds directory.
+type SoundPackSelector struct {
+	mu        sync.RWMutex
+	current   string
+	available []string
+}
+
+// NewSoundPackSelector creates a selector playing pack ("" = default)
+func NewSoundPackSelector(pack string) *SoundPackSelector {
+	if pack == "" {
+		pack = DefaultSoundPack
+	}
+	return &SoundPackSelector{
+		current:   pack,
+		available: []string{DefaultSoundPack},
+	}
+}
+
+// SetAvailable sets the packs admins can switch to
+func (s *SoundPackSelector) SetAvailable(packs []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.available = append([]string(nil), packs...)
+}
+
+// Available returns the packs admins can switch to
+func (s *SoundPackSelector) Available() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]string(nil), s.available...)
+}
+
+// Current returns the pack the stream plays
+func (s *SoundPackSelector) Current() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.current
+}
+
+// Set switches the stream to an available pack
+func (s *SoundPackSelector) Set(pack string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, p := range s.available {
+		if p == pack {
+			s.current = pack
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown sound pack %q", pack)
+}
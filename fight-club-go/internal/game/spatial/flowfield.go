@@ -247,6 +247,7 @@ type FlowFieldManager struct {
 	worldHeight float64
 	cellSize    float64
 	fields      map[string]*FlowField
+	goals       map[string][2]float64 // Goal each field was generated toward
 }
 
 // NewFlowFieldManager creates a manager for multiple flow fields.
@@ -256,6 +257,7 @@ func NewFlowFieldManager(worldWidth, worldHeight, cellSize float64) *FlowFieldMa
 		worldHeight: worldHeight,
 		cellSize:    cellSize,
 		fields:      make(map[string]*FlowField),
+		goals:       make(map[string][2]float64),
 	}
 }
 
@@ -269,24 +271,55 @@ func (m *FlowFieldManager) GetOrCreate(goalKey string, goalX, goalY float64) *Fl
 	field := NewFlowField(m.worldWidth, m.worldHeight, m.cellSize)
 	field.Generate(goalX, goalY)
 	m.fields[goalKey] = field
+	m.goals[goalKey] = [2]float64{goalX, goalY}
 	return field
 }
 
-// Regenerate re-generates the flow field for a goal.
+// Regenerate re-generates the flow field for a goal (in place if it exists).
 // Call when goal position changes or obstacles change.
 func (m *FlowFieldManager) Regenerate(goalKey string, goalX, goalY float64) *FlowField {
-	field := NewFlowField(m.worldWidth, m.worldHeight, m.cellSize)
+	field, ok := m.fields[goalKey]
+	if !ok {
+		field = NewFlowField(m.worldWidth, m.worldHeight, m.cellSize)
+		m.fields[goalKey] = field
+	}
 	field.Generate(goalX, goalY)
-	m.fields[goalKey] = field
+	m.goals[goalKey] = [2]float64{goalX, goalY}
 	return field
 }
 
+// Stale reports whether the field for goalKey exists and its goal has moved
+// to (goalX, goalY), at least one cell away from where it was generated.
+func (m *FlowFieldManager) Stale(goalKey string, goalX, goalY float64) bool {
+	goal, ok := m.goals[goalKey]
+	if !ok {
+		return false
+	}
+	return math.Abs(goalX-goal[0]) >= m.cellSize || math.Abs(goalY-goal[1]) >= m.cellSize
+}
+
+// Retain removes the flow fields whose goal key keep rejects.
+func (m *FlowFieldManager) Retain(keep func(goalKey string) bool) {
+	for key := range m.fields {
+		if !keep(key) {
+			m.Remove(key)
+		}
+	}
+}
+
+// Len returns the number of flow fields.
+func (m *FlowFieldManager) Len() int {
+	return len(m.fields)
+}
+
 // Remove removes a flow field.
 func (m *FlowFieldManager) Remove(goalKey string) {
 	delete(m.fields, goalKey)
+	delete(m.goals, goalKey)
 }
 
 // Clear removes all flow fields.
 func (m *FlowFieldManager) Clear() {
 	m.fields = make(map[string]*FlowField)
+	m.goals = make(map[string][2]float64)
 }
@@ -0,0 +1,143 @@
+package game
+
+import (
+	"fmt"
+	"log"
+)
+
+// SpectatorEffect is a buff/debuff a dead player can apply to a fighter
+type SpectatorEffect string
+
+const (
+	EffectCheer SpectatorEffect = "cheer" // Speed buff
+	EffectCurse SpectatorEffect = "curse" // Slow
+)
+
+// Spectator tuning
+const (
+	CheerSpeedMultiplier = 1.3 // Movement speed while cheered
+	CheerDuration        = 4.0 // seconds
+	CurseSpeedMultiplier = 0.6 // Movement speed while cursed
+	CurseDuration        = 2.5 // seconds
+
+	SpectatorCooldown     = 15.0 // Seconds between !cheer/!curse per spectator
+	SpectatorTargetLimit  = 3    // Max effects one fighter can receive per window (anti-dogpile)
+	SpectatorTargetWindow = 20.0 // seconds
+)
+
+// speedMultiplier returns the movement speed scale from spectator effects
+// and sprinting or exhaustion
+func (p *Player) speedMultiplier() float64 {
+	mult := p.staminaSpeedMultiplier()
+	if p.CheerTimer > 0 {
+		mult *= CheerSpeedMultiplier
+	}
+	if p.CurseTimer > 0 {
+		mult *= CurseSpeedMultiplier
+	}
+	return mult
+}
+
+// updateSpectatorEffects counts down cheer/curse timers
+func (p *Player) updateSpectatorEffects(deltaTime float64) {
+	if p.CheerTimer > 0 {
+		p.CheerTimer -= deltaTime
+	}
+	if p.CurseTimer > 0 {
+		p.CurseTimer -= deltaTime
+	}
+}
+
+// clearSpectatorEffects removes active cheer/curse (on death)
+func (p *Player) clearSpectatorEffects() {
+	p.CheerTimer = 0
+	p.CurseTimer = 0
+}
+
+// Cheer gives a fighter a short speed buff from a dead spectator
+func (e *Engine) Cheer(spectatorName, targetName string) error {
+	return e.applySpectatorEffect(spectatorName, targetName, EffectCheer)
+}
+
+// Curse slows a fighter briefly, applied by a dead spectator
+func (e *Engine) Curse(spectatorName, targetName string) error {
+	return e.applySpectatorEffect(spectatorName, targetName, EffectCurse)
+}
+
+// applySpectatorEffect validates cooldowns/rate limits and applies the effect
+func (e *Engine) applySpectatorEffect(spectatorName, targetName string, effect SpectatorEffect) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	spectator, ok := e.players[spectatorName]
+	if !ok {
+		return fmt.Errorf("not in arena (type !join first)")
+	}
+	if spectator.State != StateDead {
+		return fmt.Errorf("only dead players can %s", effect)
+	}
+	if spectatorName == targetName {
+		return fmt.Errorf("cannot %s yourself", effect)
+	}
+	if e.tickCount < spectator.spectateReadyTick {
+		remaining := float64(spectator.spectateReadyTick-e.tickCount) / float64(e.tickRate)
+		return fmt.Errorf("on cooldown (%.1fs)", remaining)
+	}
+
+	target, ok := e.players[targetName]
+	if !ok || target.IsDead || target.State != StateAlive {
+		return fmt.Errorf("'%s' is not fighting", targetName)
+	}
+
+	// Per-target rate limit: drop effects older than the window, then check
+	windowStart := e.tickCount - int64(SpectatorTargetWindow*float64(e.tickRate))
+	recent := target.spectatorEffectTicks[:0]
+	for _, t := range target.spectatorEffectTicks {
+		if t > windowStart {
+			recent = append(recent, t)
+		}
+	}
+	target.spectatorEffectTicks = recent
+	if len(recent) >= SpectatorTargetLimit {
+		return fmt.Errorf("'%s' has had enough attention, try again soon", targetName)
+	}
+
+	var duration float64
+	var text, textColor string
+	var eventType EventType
+	switch effect {
+	case EffectCheer:
+		duration, text, textColor, eventType = CheerDuration, "CHEERED!", "#53ff45", EventTypeCheer
+		target.CheerTimer = duration
+	case EffectCurse:
+		duration, text, textColor, eventType = CurseDuration, "CURSED!", "#a855f7", EventTypeCurse
+		target.CurseTimer = duration
+	default:
+		return fmt.Errorf("unknown spectator effect '%s'", effect)
+	}
+
+	target.spectatorEffectTicks = append(target.spectatorEffectTicks, e.tickCount)
+	spectator.spectateReadyTick = e.tickCount + int64(SpectatorCooldown*float64(e.tickRate))
+
+	if len(e.texts) < e.limits.MaxTexts {
+		e.texts = append(e.texts, &FloatingText{
+			X:     target.X,
+			Y:     target.Y - 40,
+			Text:  text,
+			Color: textColor,
+			Alpha: 1.0,
+			VY:    -1.5,
+		})
+	}
+
+	e.eventLog.EmitSimple(eventType, uint64(e.tickCount), spectator.ID,
+		SpectatorPayload{
+			SpectatorID: spectator.ID,
+			TargetID:    target.ID,
+			Effect:      string(effect),
+			Duration:    duration,
+		})
+
+	log.Printf("👻 %s used %s on %s (%.1fs)", spectatorName, effect, targetName, duration)
+	return nil
+}
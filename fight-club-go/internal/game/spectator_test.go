@@ -0,0 +1,74 @@
+package game
+
+import (
+	"testing"
+)
+
+// TestSpectatorCheerAndCurse verifies only dead players can cheer/curse and effects scale speed
+func TestSpectatorCheerAndCurse(t *testing.T) {
+	engine := newTestEngine(30)
+	ghost := engine.AddPlayer("ghost", PlayerOptions{})
+	fighter := engine.AddPlayer("fighter", PlayerOptions{})
+
+	if err := engine.Cheer("ghost", "fighter"); err == nil {
+		t.Error("Alive players should not be able to cheer")
+	}
+
+	ghost.die(nil)
+	if err := engine.Cheer("ghost", "nobody"); err == nil {
+		t.Error("Expected error for unknown target")
+	}
+	if err := engine.Curse("ghost", "ghost"); err == nil {
+		t.Error("Expected error when targeting yourself")
+	}
+
+	if err := engine.Cheer("ghost", "fighter"); err != nil {
+		t.Fatalf("Cheer failed: %v", err)
+	}
+	if fighter.speedMultiplier() != CheerSpeedMultiplier {
+		t.Errorf("Expected cheer speed multiplier, got %.2f", fighter.speedMultiplier())
+	}
+
+	// Per-spectator cooldown
+	if err := engine.Curse("ghost", "fighter"); err == nil {
+		t.Error("Expected cooldown error")
+	}
+	engine.tickCount += int64(SpectatorCooldown * 30)
+	if err := engine.Curse("ghost", "fighter"); err != nil {
+		t.Fatalf("Curse after cooldown failed: %v", err)
+	}
+	if got := fighter.speedMultiplier(); got != CheerSpeedMultiplier*CurseSpeedMultiplier {
+		t.Errorf("Expected cheer and curse to stack, got %.2f", got)
+	}
+
+	// Effects expire
+	fighter.updateSpectatorEffects(CheerDuration)
+	if fighter.speedMultiplier() != 1.0 {
+		t.Errorf("Expected effects to expire, got %.2f", fighter.speedMultiplier())
+	}
+}
+
+// TestSpectatorTargetRateLimit verifies a fighter can't be dogpiled by many spectators
+func TestSpectatorTargetRateLimit(t *testing.T) {
+	engine := newTestEngine(30)
+	engine.AddPlayer("fighter", PlayerOptions{})
+
+	names := []string{"a", "b", "c", "d"}
+	for _, name := range names {
+		engine.AddPlayer(name, PlayerOptions{}).die(nil)
+	}
+
+	for _, name := range names[:SpectatorTargetLimit] {
+		if err := engine.Curse(name, "fighter"); err != nil {
+			t.Fatalf("Curse by %s failed: %v", name, err)
+		}
+	}
+	if err := engine.Curse("d", "fighter"); err == nil {
+		t.Error("Expected per-target rate limit")
+	}
+
+	engine.tickCount += int64(SpectatorTargetWindow * 30)
+	if err := engine.Curse("d", "fighter"); err != nil {
+		t.Errorf("Expected rate limit window to reset: %v", err)
+	}
+}
@@ -0,0 +1,71 @@
+package game
+
+import "math"
+
+// Sprint and exhaustion tuning. Fighters sprint to close long gaps, burning
+// stamina; running dry leaves them exhausted (slow and fragile) until they
+// catch their breath, so long chases and positioning matter.
+const (
+	SprintDistance           = 250.0 // Target farther than this (px) triggers a sprint
+	SprintSpeedMultiplier    = 1.4   // Movement speed while sprinting
+	SprintStaminaDrain       = 30.0  // Stamina per second while sprinting (regen paused)
+	ExhaustedSpeedMultiplier = 0.7   // Movement speed while exhausted
+	ExhaustedDamageTaken     = 1.25  // Damage multiplier against exhausted fighters
+	ExhaustionRecovery       = 50.0  // Stamina needed to shake off exhaustion
+)
+
+// updateStamina drains stamina while sprinting, regenerates it otherwise, and
+// moves the fighter in and out of exhaustion
+func (p *Player) updateStamina(deltaTime float64) {
+	if p.IsSprinting {
+		p.Stamina -= SprintStaminaDrain * deltaTime
+		if p.Stamina <= 0 {
+			p.Stamina = 0
+			p.IsSprinting = false
+			p.IsExhausted = true
+		}
+		return
+	}
+
+	if p.Stamina < p.MaxStamina {
+		p.Stamina += StaminaRegenRate * deltaTime
+		if p.Stamina > p.MaxStamina {
+			p.Stamina = p.MaxStamina
+		}
+	}
+	if p.IsExhausted && p.Stamina >= ExhaustionRecovery {
+		p.IsExhausted = false
+	}
+}
+
+// canSprint reports whether the fighter may sprint toward a target dist away
+// (only beyond weapon reach, so kiters don't sprint inside their range)
+func (p *Player) canSprint(dist, reach float64) bool {
+	return dist > math.Max(SprintDistance, reach) && !p.IsExhausted && p.Stamina > 0
+}
+
+// staminaSpeedMultiplier returns the movement speed scale from sprinting or exhaustion
+func (p *Player) staminaSpeedMultiplier() float64 {
+	switch {
+	case p.IsExhausted:
+		return ExhaustedSpeedMultiplier
+	case p.IsSprinting:
+		return SprintSpeedMultiplier
+	}
+	return 1.0
+}
+
+// exhaustedDamage scales incoming damage if the fighter is exhausted
+func (p *Player) exhaustedDamage(damage int) int {
+	if !p.IsExhausted {
+		return damage
+	}
+	return int(math.Round(float64(damage) * ExhaustedDamageTaken))
+}
+
+// resetStamina refills stamina and clears sprint state (respawn)
+func (p *Player) resetStamina() {
+	p.Stamina = p.MaxStamina
+	p.IsSprinting = false
+	p.IsExhausted = false
+}
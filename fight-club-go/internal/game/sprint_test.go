@@ -0,0 +1,77 @@
+package game
+
+import "testing"
+
+// TestSprintDrainsIntoExhaustion verifies sprinting burns stamina down to
+// exhaustion, which slows the fighter until stamina recovers
+func TestSprintDrainsIntoExhaustion(t *testing.T) {
+	p := NewPlayer("runner", PlayerOptions{})
+
+	elapsed := 0.0
+	for !p.IsExhausted && elapsed < 10 {
+		p.IsSprinting = p.canSprint(SprintDistance+1, 0)
+		p.updateStamina(0.1)
+		elapsed += 0.1
+	}
+	if !p.IsExhausted || p.Stamina != 0 {
+		t.Fatalf("Expected exhaustion after sprinting, got stamina %.1f after %.1fs", p.Stamina, elapsed)
+	}
+	if want := MaxStamina / SprintStaminaDrain; elapsed < want-0.2 || elapsed > want+0.2 {
+		t.Errorf("Expected ~%.1fs of sprint, got %.1fs", want, elapsed)
+	}
+	if p.canSprint(SprintDistance+1, 0) {
+		t.Error("Exhausted fighters should not sprint")
+	}
+	if p.speedMultiplier() != ExhaustedSpeedMultiplier {
+		t.Errorf("Expected exhausted speed x%.1f, got x%.2f", ExhaustedSpeedMultiplier, p.speedMultiplier())
+	}
+
+	// Catch breath: exhaustion lifts once stamina reaches the recovery mark
+	for i := 0; p.IsExhausted && i < 100; i++ {
+		p.updateStamina(0.1)
+	}
+	if p.IsExhausted || p.Stamina < ExhaustionRecovery {
+		t.Errorf("Expected recovery at %.0f stamina, got %.1f (exhausted=%v)", ExhaustionRecovery, p.Stamina, p.IsExhausted)
+	}
+}
+
+// TestChaseSprint verifies fighters sprint only toward targets beyond sprint distance
+func TestChaseSprint(t *testing.T) {
+	engine := newTestEngine(30)
+	hunter := engine.AddPlayer("hunter", PlayerOptions{})
+	prey := engine.AddPlayer("prey", PlayerOptions{})
+	hunter.SetPersonality("berserker")
+	hunter.SpawnProtection, prey.SpawnProtection = false, false
+	hunter.AttackCooldown = 10 // Chase, don't swing
+	hunter.Target = prey
+
+	hunter.X, hunter.Y = 300, 360
+	prey.X, prey.Y = 300+SprintDistance+50, 360
+	hunter.combatBehavior(1.0/30, engine)
+	if !hunter.IsSprinting {
+		t.Error("Expected a sprint toward a distant target")
+	}
+
+	prey.X = 300 + SprintDistance/2
+	hunter.combatBehavior(1.0/30, engine)
+	if hunter.IsSprinting {
+		t.Error("Expected no sprint toward a nearby target")
+	}
+}
+
+// TestExhaustedTakesBonusDamage verifies hits land harder on exhausted fighters
+func TestExhaustedTakesBonusDamage(t *testing.T) {
+	p := NewPlayer("tired", PlayerOptions{})
+	if got := p.exhaustedDamage(20); got != 20 {
+		t.Errorf("Expected normal damage while rested, got %d", got)
+	}
+	p.IsExhausted = true
+	if got := p.exhaustedDamage(20); got != 25 {
+		t.Errorf("Expected 25 damage while exhausted, got %d", got)
+	}
+
+	p.Respawn()
+	if p.IsExhausted || p.Stamina != p.MaxStamina {
+		t.Errorf("Expected respawn to restore stamina, got %.1f (exhausted=%v)", p.Stamina, p.IsExhausted)
+	}
+}
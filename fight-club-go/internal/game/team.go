@@ -254,6 +254,17 @@ func (tm *TeamManager) SetTeamColor(leaderName, color string) error {
 	return fmt.Errorf("not a team leader")
 }
 
+// TeamColor returns a team's color ("" for no team)
+func (tm *TeamManager) TeamColor(teamID string) string {
+	tm.mu.RLock()
+	defer tm.mu.RUnlock()
+
+	if team, ok := tm.teams[teamID]; ok {
+		return team.Color
+	}
+	return ""
+}
+
 // AddKill adds a kill to the team counter
 func (tm *TeamManager) AddKill(teamID string) {
 	tm.mu.Lock()
@@ -0,0 +1,231 @@
+package game
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"strings"
+)
+
+// TeamOrder is a team leader's !team order
+type TeamOrder string
+
+const (
+	OrderRally   TeamOrder = "rally"   // Damage buff for teammates near the leader
+	OrderRegroup TeamOrder = "regroup" // Teammates converge on the leader's position
+)
+
+// Team order tuning (costs are paid by the leader)
+const (
+	RallyCost             = 100
+	RallyCooldown         = 30.0  // Seconds between rallies per team
+	RallyRadius           = 300.0 // Teammates this close to the leader are rallied
+	RallyDuration         = 6.0   // seconds
+	RallyDamageMultiplier = 1.25
+
+	RegroupCost         = 50
+	RegroupCooldown     = 20.0 // Seconds between regroups per team
+	RegroupDuration     = 8.0  // Seconds teammates head for the goal
+	RegroupArriveRadius = 80.0 // Teammates this close to the goal are regrouped
+)
+
+// teamOrderState is a team's order cooldowns and regroup goal
+type teamOrderState struct {
+	rallyReady   int64 // Next tick the team may rally
+	regroupReady int64 // Next tick the team may regroup
+	regroupUntil int64 // Tick the regroup goal expires
+}
+
+// regroupFieldPrefix prefixes the flow field keys of team regroup goals
+const regroupFieldPrefix = "regroup:"
+
+// regroupFieldKey is the flow field key of a team's regroup goal
+func regroupFieldKey(teamID string) string {
+	return regroupFieldPrefix + teamID
+}
+
+// rallyDamage scales an attack's damage while the attacker is rallied
+func (p *Player) rallyDamage(damage int) int {
+	if p.RallyTimer > 0 {
+		return int(float64(damage) * RallyDamageMultiplier)
+	}
+	return damage
+}
+
+// updateTeamOrders counts down rally/regroup timers
+func (p *Player) updateTeamOrders(deltaTime float64) {
+	if p.RallyTimer > 0 {
+		p.RallyTimer -= deltaTime
+	}
+	if p.RegroupTimer > 0 {
+		p.RegroupTimer -= deltaTime
+	}
+}
+
+// clearTeamOrders ends an active rally or regroup (death, leaving the team)
+func (p *Player) clearTeamOrders() {
+	p.RallyTimer = 0
+	p.RegroupTimer = 0
+}
+
+// regroup moves a regrouping fighter along the team's regroup flow field.
+// Returns false when not regrouping (arrived, or fighting an enemy in
+// weapon range) so the normal AI runs. Caller holds e.mu.
+func (p *Player) regroup(deltaTime float64, engine *Engine) bool {
+	if p.RegroupTimer <= 0 || engine == nil {
+		return false
+	}
+	dx, dy := p.RegroupX-p.X, p.RegroupY-p.Y
+	dist := math.Hypot(dx, dy)
+	if dist <= RegroupArriveRadius {
+		p.RegroupTimer = 0
+		return false
+	}
+	if p.Target != nil && p.distanceTo(p.Target) <= GetWeapon(p.Weapon).Range {
+		return false // Fight back first
+	}
+
+	dx, dy = dx/dist, dy/dist
+	field := engine.flowFieldManager.GetOrCreate(regroupFieldKey(p.TeamID), p.RegroupX, p.RegroupY)
+	if flowX, flowY := field.Lookup(p.X, p.Y); flowX != 0 || flowY != 0 {
+		dx, dy = float64(flowX), float64(flowY)
+	}
+	moveSpeed := 5.0 * p.speedMultiplier()
+	p.VX += dx * moveSpeed * deltaTime * 60
+	p.VY += dy * moveSpeed * deltaTime * 60
+	return true
+}
+
+// TeamRally buffs the damage of the leader and teammates near them.
+// Returns how many fighters were rallied.
+func (e *Engine) TeamRally(leaderName string) (int, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	leader, team, orders, err := e.teamOrderLeader(leaderName, OrderRally, RallyCost)
+	if err != nil {
+		return 0, err
+	}
+	if e.tickCount < orders.rallyReady {
+		return 0, fmt.Errorf("rally on cooldown (%.0fs)", float64(orders.rallyReady-e.tickCount)/float64(e.tickRate))
+	}
+
+	rallied := 0
+	for _, p := range e.players {
+		if p.TeamID == team.ID && !p.IsDead && p.distanceTo(leader) <= RallyRadius {
+			p.RallyTimer = RallyDuration
+			rallied++
+		}
+	}
+
+	leader.Money -= RallyCost
+	orders.rallyReady = e.tickCount + int64(RallyCooldown*float64(e.tickRate))
+	e.announceTeamOrder(leader, team, OrderRally, rallied, "RALLY!", "#ff9f43")
+	return rallied, nil
+}
+
+// TeamRegroup sends the leader's teammates to the leader's current position
+// along a shared flow field. Returns how many teammates were sent.
+func (e *Engine) TeamRegroup(leaderName string) (int, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	leader, team, orders, err := e.teamOrderLeader(leaderName, OrderRegroup, RegroupCost)
+	if err != nil {
+		return 0, err
+	}
+	if e.tickCount < orders.regroupReady {
+		return 0, fmt.Errorf("regroup on cooldown (%.0fs)", float64(orders.regroupReady-e.tickCount)/float64(e.tickRate))
+	}
+
+	var members []*Player
+	for _, p := range e.players {
+		if p.TeamID == team.ID && p != leader && !p.IsDead && p.State == StateAlive {
+			members = append(members, p)
+		}
+	}
+	if len(members) == 0 {
+		return 0, fmt.Errorf("no teammates in the fight")
+	}
+
+	e.flowFieldManager.Regenerate(regroupFieldKey(team.ID), leader.X, leader.Y)
+	for _, p := range members {
+		p.RegroupTimer = RegroupDuration
+		p.RegroupX, p.RegroupY = leader.X, leader.Y
+	}
+
+	leader.Money -= RegroupCost
+	orders.regroupReady = e.tickCount + int64(RegroupCooldown*float64(e.tickRate))
+	orders.regroupUntil = e.tickCount + int64(RegroupDuration*float64(e.tickRate))
+	e.announceTeamOrder(leader, team, OrderRegroup, len(members), "REGROUP!", "#48dbfb")
+	return len(members), nil
+}
+
+// teamOrderLeader validates that leaderName leads a team, is fighting and
+// can pay for the order. Caller holds e.mu.
+func (e *Engine) teamOrderLeader(leaderName string, order TeamOrder, cost int) (*Player, *Team, *teamOrderState, error) {
+	leader, ok := e.players[leaderName]
+	if !ok {
+		return nil, nil, nil, fmt.Errorf("not in arena (type !join first)")
+	}
+	team := e.teamManager.GetTeamByLeader(leaderName)
+	if team == nil || leader.TeamID != team.ID {
+		return nil, nil, nil, fmt.Errorf("only a team leader can %s", order)
+	}
+	if leader.IsDead || leader.State != StateAlive {
+		return nil, nil, nil, fmt.Errorf("you must be fighting to %s", order)
+	}
+	if leader.Money < cost {
+		return nil, nil, nil, fmt.Errorf("%s costs $%d, you have $%d", order, cost, leader.Money)
+	}
+
+	// Forget teams whose cooldowns all ran out (disbanded teams included)
+	for id, o := range e.teamOrders {
+		if e.tickCount >= max(o.rallyReady, o.regroupReady, o.regroupUntil) {
+			delete(e.teamOrders, id)
+		}
+	}
+	orders, ok := e.teamOrders[team.ID]
+	if !ok {
+		orders = &teamOrderState{}
+		e.teamOrders[team.ID] = orders
+	}
+	return leader, team, orders, nil
+}
+
+// announceTeamOrder shows the order over the leader and logs it
+func (e *Engine) announceTeamOrder(leader *Player, team *Team, order TeamOrder, members int, text, textColor string) {
+	if len(e.texts) < e.limits.MaxTexts {
+		e.texts = append(e.texts, &FloatingText{
+			X:     leader.X,
+			Y:     leader.Y - 40,
+			Text:  text,
+			Color: textColor,
+			Alpha: 1.0,
+			VY:    -1.5,
+		})
+	}
+
+	e.eventLog.EmitSimple(EventTypeTeamOrder, uint64(e.tickCount), leader.ID,
+		TeamOrderPayload{
+			TeamID:   team.ID,
+			LeaderID: leader.ID,
+			Order:    string(order),
+			Members:  members,
+			X:        leader.X,
+			Y:        leader.Y,
+		})
+
+	log.Printf("👥 %s ordered team %s to %s (%d fighters)", leader.Name, team.Name, order, members)
+}
+
+// keepRegroupField reports whether a flow field key is a regroup goal still
+// in use. Caller holds e.mu.
+func (e *Engine) keepRegroupField(key string) bool {
+	teamID, ok := strings.CutPrefix(key, regroupFieldPrefix)
+	if !ok {
+		return false
+	}
+	orders, ok := e.teamOrders[teamID]
+	return ok && e.tickCount < orders.regroupUntil
+}
@@ -0,0 +1,150 @@
+package game
+
+import (
+	"testing"
+)
+
+// newTestTeam creates a team led by leader with the given members
+func newTestTeam(t *testing.T, engine *Engine, leader string, members ...string) *Team {
+	t.Helper()
+	engine.AddPlayer(leader, PlayerOptions{})
+	team, err := engine.teamManager.CreateTeam(leader, leader+"'s Team")
+	if err != nil {
+		t.Fatalf("CreateTeam failed: %v", err)
+	}
+	engine.SetPlayerTeam(leader, team.ID)
+	for _, name := range members {
+		engine.AddPlayer(name, PlayerOptions{})
+		if err := engine.teamManager.InvitePlayer(team.ID, leader, name); err != nil {
+			t.Fatalf("InvitePlayer failed: %v", err)
+		}
+		if _, err := engine.teamManager.AcceptInvite(name, leader); err != nil {
+			t.Fatalf("AcceptInvite failed: %v", err)
+		}
+		engine.SetPlayerTeam(name, team.ID)
+	}
+	return team
+}
+
+// TestTeamRally verifies rally cost, radius, cooldown and the damage buff
+func TestTeamRally(t *testing.T) {
+	engine := newTestEngine(30)
+	newTestTeam(t, engine, "boss", "near", "far")
+	engine.AddPlayer("loner", PlayerOptions{})
+
+	boss, near, far := engine.players["boss"], engine.players["near"], engine.players["far"]
+	boss.X, boss.Y = 400, 400
+	near.X, near.Y = 500, 400
+	far.X, far.Y = 400+RallyRadius+50, 400
+
+	if _, err := engine.TeamRally("near"); err == nil {
+		t.Error("Expected only the leader to rally")
+	}
+	if _, err := engine.TeamRally("loner"); err == nil {
+		t.Error("Expected a fighter without a team to be refused")
+	}
+	if _, err := engine.TeamRally("boss"); err == nil {
+		t.Error("Expected a broke leader to be refused")
+	}
+
+	boss.Money = RallyCost + 10
+	rallied, err := engine.TeamRally("boss")
+	if err != nil {
+		t.Fatalf("TeamRally failed: %v", err)
+	}
+	if rallied != 2 || boss.RallyTimer <= 0 || near.RallyTimer <= 0 || far.RallyTimer > 0 {
+		t.Errorf("Expected the leader and the nearby teammate rallied, got %d (far rallied: %v)", rallied, far.RallyTimer > 0)
+	}
+	if boss.Money != 10 {
+		t.Errorf("Expected the rally to cost $%d, $%d left", RallyCost, boss.Money)
+	}
+	if got := near.rallyDamage(20); got != int(20*RallyDamageMultiplier) {
+		t.Errorf("Expected rallied damage %d, got %d", int(20*RallyDamageMultiplier), got)
+	}
+	if got := far.rallyDamage(20); got != 20 {
+		t.Errorf("Expected unrallied damage unchanged, got %d", got)
+	}
+
+	// Team cooldown
+	boss.Money = RallyCost
+	if _, err := engine.TeamRally("boss"); err == nil {
+		t.Error("Expected rally cooldown error")
+	}
+	engine.tickCount += int64(RallyCooldown * 30)
+	if _, err := engine.TeamRally("boss"); err != nil {
+		t.Errorf("Rally after cooldown failed: %v", err)
+	}
+
+	// The buff wears off and ends on death
+	near.updateTeamOrders(RallyDuration)
+	if near.rallyDamage(20) != 20 {
+		t.Error("Expected the rally to expire")
+	}
+	boss.die(nil)
+	if boss.RallyTimer > 0 {
+		t.Error("Expected death to end the rally")
+	}
+}
+
+// TestTeamRegroup verifies teammates head for the leader until they arrive
+func TestTeamRegroup(t *testing.T) {
+	engine := newTestEngine(30)
+	team := newTestTeam(t, engine, "boss", "mate")
+	boss, mate := engine.players["boss"], engine.players["mate"]
+	boss.X, boss.Y = 200, 200
+	mate.X, mate.Y = 900, 500
+	boss.Money = RegroupCost
+
+	sent, err := engine.TeamRegroup("boss")
+	if err != nil {
+		t.Fatalf("TeamRegroup failed: %v", err)
+	}
+	if sent != 1 || mate.RegroupTimer <= 0 || mate.RegroupX != 200 || mate.RegroupY != 200 {
+		t.Fatalf("Expected mate sent to the leader, got %d (goal %.0f,%.0f)", sent, mate.RegroupX, mate.RegroupY)
+	}
+	if boss.RegroupTimer > 0 {
+		t.Error("Expected the leader to stay put")
+	}
+	if !engine.keepRegroupField(regroupFieldKey(team.ID)) {
+		t.Error("Expected the regroup flow field kept while the order lasts")
+	}
+
+	mate.VX, mate.VY = 0, 0
+	if !mate.regroup(1.0/30, engine) {
+		t.Fatal("Expected mate to be regrouping")
+	}
+	if mate.VX >= 0 || mate.VY >= 0 {
+		t.Errorf("Expected mate to head toward the leader, got velocity %.2f,%.2f", mate.VX, mate.VY)
+	}
+
+	// Arriving ends the order
+	mate.X, mate.Y = 210, 210
+	if mate.regroup(1.0/30, engine) || mate.RegroupTimer > 0 {
+		t.Error("Expected the regroup to end on arrival")
+	}
+
+	// Cooldown, then the field is released once the order expires
+	boss.Money = RegroupCost
+	if _, err := engine.TeamRegroup("boss"); err == nil {
+		t.Error("Expected regroup cooldown error")
+	}
+	engine.tickCount += int64(RegroupCooldown * 30)
+	if engine.keepRegroupField(regroupFieldKey(team.ID)) {
+		t.Error("Expected the regroup flow field released after the order")
+	}
+}
+
+// TestTeamRegroupAlone verifies a leader without fighting teammates can't regroup
+func TestTeamRegroupAlone(t *testing.T) {
+	engine := newTestEngine(30)
+	newTestTeam(t, engine, "boss", "mate")
+	engine.players["boss"].Money = RegroupCost
+	engine.players["mate"].die(nil)
+
+	if _, err := engine.TeamRegroup("boss"); err == nil {
+		t.Error("Expected regroup to fail with no teammates in the fight")
+	}
+	if engine.players["boss"].Money != RegroupCost {
+		t.Error("Expected a failed regroup to cost nothing")
+	}
+}
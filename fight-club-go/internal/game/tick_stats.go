@@ -0,0 +1,90 @@
+package game
+
+import (
+	"sync"
+	"time"
+
+	"fight-club/internal/profiling"
+)
+
+// Tick phases for the debug server budget breakdown (/debug/budget).
+// Vote, modifier and event log work before the spatial rebuild is only in
+// the total tick time.
+var (
+	tickPhaseSpatial   = profiling.Track("tick/spatial")   // Spatial grid rebuild
+	tickPhaseAI        = profiling.Track("tick/ai")        // Player movement, targeting and attacks
+	tickPhaseCollision = profiling.Track("tick/collision") // Collision resolution
+	tickPhaseSystems   = profiling.Track("tick/systems")   // Duel, chaos, objective, loot and quests
+	tickPhaseEffects   = profiling.Track("tick/effects")   // Particles, trails, projectiles and bots
+	tickPhaseSnapshot  = profiling.Track("tick/snapshot")  // Snapshot for the renderer
+)
+
+// TickStats summarizes recent game tick durations (admin dashboard)
+type TickStats struct {
+	TickRate int     `json:"tickRate"`
+	Ticks    int64   `json:"ticks"`    // Ticks measured since start (including paused ones)
+	LastMs   float64 `json:"lastMs"`   // Most recent tick
+	AvgMs    float64 `json:"avgMs"`    // Exponential moving average
+	MaxMs    float64 `json:"maxMs"`    // Slowest tick over the last one to two seconds
+	BudgetMs float64 `json:"budgetMs"` // Time available per tick (1000 / TickRate)
+	Dropped  int64   `json:"dropped"`  // Ticks skipped because the loop fell too far behind
+
+	LoadShed LoadShedStats `json:"loadShed"` // Work shed to stay within the budget
+}
+
+// tickTimer measures tick durations. It has its own lock so stats can be
+// read without waiting on the engine lock held for the whole tick.
+type tickTimer struct {
+	mu      sync.Mutex
+	ticks   int64
+	last    float64
+	avg     float64
+	max     float64 // Current one-second window
+	prevMax float64 // Previous window
+	dropped int64
+}
+
+// record adds one tick duration; windows roll over every tickRate ticks
+func (t *tickTimer) record(d time.Duration, tickRate int) {
+	ms := float64(d) / float64(time.Millisecond)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.ticks++
+	t.last = ms
+	if t.ticks == 1 {
+		t.avg = ms
+	} else {
+		t.avg += (ms - t.avg) * 0.05 // ~20 tick smoothing
+	}
+	t.max = max(t.max, ms)
+	if tickRate > 0 && t.ticks%int64(tickRate) == 0 {
+		t.prevMax, t.max = t.max, 0
+	}
+}
+
+// drop counts ticks skipped by the fixed-timestep loop
+func (t *tickTimer) drop(n int) {
+	t.mu.Lock()
+	t.dropped += int64(n)
+	t.mu.Unlock()
+}
+
+// GetTickStats returns recent tick timing
+func (e *Engine) GetTickStats() TickStats {
+	e.tickTimes.mu.Lock()
+	defer e.tickTimes.mu.Unlock()
+
+	t := &e.tickTimes
+	return TickStats{
+		TickRate: e.tickRate,
+		Ticks:    t.ticks,
+		LastMs:   t.last,
+		AvgMs:    t.avg,
+		MaxMs:    max(t.max, t.prevMax),
+		BudgetMs: 1000 / float64(e.tickRate),
+		Dropped:  t.dropped,
+		LoadShed: e.shedder.stats(),
+	}
+}
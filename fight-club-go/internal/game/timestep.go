@@ -0,0 +1,80 @@
+package game
+
+import (
+	"log"
+	"time"
+
+	"fight-club/internal/profiling"
+)
+
+// fixedStep turns elapsed wall time into whole simulation steps. The engine
+// loop wakes on a ticker, but a late wakeup (GC pause, loaded VPS) runs the
+// missed ticks instead of stretching deltaTime, so the simulation stays
+// deterministic at any stream FPS. More than maxSteps behind, the rest is
+// dropped rather than spiralling further behind.
+type fixedStep struct {
+	step     time.Duration
+	maxSteps int
+	acc      time.Duration
+}
+
+// newFixedStep creates an accumulator for tickRate steps per second
+func newFixedStep(tickRate, maxSteps int) *fixedStep {
+	return &fixedStep{
+		step:     time.Second / time.Duration(tickRate),
+		maxSteps: max(1, maxSteps),
+	}
+}
+
+// advance adds elapsed time and returns how many steps to run now and how
+// many were dropped. The remainder carries over to the next call.
+func (f *fixedStep) advance(elapsed time.Duration) (steps, dropped int) {
+	f.acc += elapsed
+	steps = int(f.acc / f.step)
+	f.acc -= time.Duration(steps) * f.step
+	if steps > f.maxSteps {
+		dropped = steps - f.maxSteps
+		steps = f.maxSteps
+	}
+	return steps, dropped
+}
+
+// run is the game loop: fixed-timestep ticks until Stop
+func (e *Engine) run() {
+	clock := newFixedStep(e.tickRate, e.maxCatchUpTicks)
+	last := time.Now()
+
+	for {
+		select {
+		case <-e.ticker.C:
+			now := time.Now()
+			steps, dropped := clock.advance(now.Sub(last))
+			last = now
+
+			for i := 0; i < steps; i++ {
+				e.tick()
+			}
+			if dropped > 0 {
+				e.tickTimes.drop(dropped)
+				log.Printf("⏱️ Game loop fell behind: dropped %d ticks", dropped)
+			}
+		case <-e.tickRateChanged:
+			clock = e.retick()
+		case <-e.stopChan:
+			return
+		}
+	}
+}
+
+// retick restarts the ticker and step clock at the current tick rate (an
+// arena preset changed it, see SetArenaPreset)
+func (e *Engine) retick() *fixedStep {
+	e.mu.RLock()
+	rate := e.tickRate
+	e.mu.RUnlock()
+
+	e.ticker.Reset(time.Second / time.Duration(rate))
+	profiling.SetBudget("tick", 1000/float64(rate))
+	log.Printf("🎮 Game loop now at %d TPS", rate)
+	return newFixedStep(rate, e.maxCatchUpTicks)
+}
@@ -0,0 +1,51 @@
+package game
+
+import (
+	"testing"
+	"time"
+)
+
+// TestFixedStepAccumulates verifies wall time becomes whole steps with the
+// remainder carried over, and that a long stall is capped
+func TestFixedStepAccumulates(t *testing.T) {
+	clock := newFixedStep(60, 5)
+	step := time.Second / 60
+
+	if steps, dropped := clock.advance(step / 2); steps != 0 || dropped != 0 {
+		t.Errorf("Expected no step for half a tick, got %d (%d dropped)", steps, dropped)
+	}
+	if steps, _ := clock.advance(step / 2); steps != 1 {
+		t.Errorf("Expected the carried remainder to complete a step, got %d", steps)
+	}
+
+	// 30 FPS wakeups of a 60 TPS game: two steps each
+	for i := 0; i < 3; i++ {
+		if steps, _ := clock.advance(2 * step); steps != 2 {
+			t.Errorf("Expected 2 steps per 30 FPS wakeup, got %d", steps)
+		}
+	}
+
+	// A one-second stall runs the cap and drops the rest
+	if steps, dropped := clock.advance(time.Second); steps != 5 || dropped != 55 {
+		t.Errorf("Expected 5 catch-up steps and 55 dropped, got %d and %d", steps, dropped)
+	}
+	if steps, _ := clock.advance(0); steps != 0 {
+		t.Errorf("Expected no backlog after dropping, got %d", steps)
+	}
+}
+
+// TestEngineTickRateHint verifies snapshots carry the simulation tick rate
+func TestEngineTickRateHint(t *testing.T) {
+	cfg := DefaultEngineConfig()
+	cfg.TickRate = 60
+	e := NewEngine(cfg)
+	e.tick()
+
+	snap := e.GetSnapshot()
+	if snap.TickRate != 60 || snap.TickNumber != 1 {
+		t.Errorf("Expected tick 1 at 60 TPS, got tick %d at %d", snap.TickNumber, snap.TickRate)
+	}
+	if stats := e.GetTickStats(); stats.TickRate != 60 || stats.BudgetMs < 16 || stats.BudgetMs > 17 {
+		t.Errorf("Unexpected tick stats %+v", stats)
+	}
+}
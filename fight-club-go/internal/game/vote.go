@@ -0,0 +1,284 @@
+package game
+
+import (
+	"fmt"
+	"log"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"fight-club/internal/config"
+)
+
+// VotingConfig is an alias for config.VotingConfig (SSOT)
+type VotingConfig = config.VotingConfig
+
+// VoteOptionCount is how many modifiers are offered per vote
+const VoteOptionCount = 3
+
+// votePollRefresh is how often the results of a platform poll are read
+var votePollRefresh = 3 * time.Second
+
+// VotePoll runs the arena vote as a platform poll (e.g. a Kick channel poll)
+// instead of counting !vote ballots in chat. Implemented by kick.Service.
+type VotePoll interface {
+	// CreatePoll opens a poll for duration seconds and returns its ID
+	CreatePoll(title string, options []string, duration int) (string, error)
+	// GetPollResults returns the votes per option, in option order
+	GetPollResults(id string) ([]int, error)
+}
+
+// VoteSnapshot is the vote/modifier state for the stream overlay
+type VoteSnapshot struct {
+	Voting    bool                    // A vote is currently open
+	Options   [VoteOptionCount]string // Modifier IDs offered (voting only)
+	Counts    [VoteOptionCount]int    // Votes per option (voting only)
+	Remaining float64                 // Seconds until the vote closes or the modifier expires
+	Poll      bool                    // The vote runs as a channel poll (no !vote)
+
+	Modifier string // Active modifier ID ("" if none)
+}
+
+// VoteManager runs the periodic crowd vote for arena modifiers.
+// Every Interval seconds a vote opens for Duration seconds; the winner
+// is active until the next vote closes. Driven by the engine tick.
+type VoteManager struct {
+	mu  sync.Mutex
+	cfg VotingConfig
+
+	untilNextVote float64 // Seconds until the next vote opens
+	voting        bool
+	voteRemaining float64
+	options       [VoteOptionCount]string
+	ballots       map[string]int // username -> option index
+
+	poll       VotePoll // nil = chat ballots only
+	voteSeq    int      // Increments per vote (stale poll readers stop)
+	polled     bool     // The open vote is counted by the poll
+	pollCounts [VoteOptionCount]int
+
+	active          string
+	activeRemaining float64
+
+	// Callbacks (called in a new goroutine, e.g. to post to Kick chat)
+	OnVoteStart func(options []ArenaModifier)
+	OnVoteEnd   func(winner ArenaModifier, votes int)
+}
+
+// NewVoteManager creates a vote manager; the first vote opens after one interval
+func NewVoteManager(cfg VotingConfig) *VoteManager {
+	if cfg.Duration <= 0 {
+		cfg.Duration = config.DefaultVoting().Duration
+	}
+	return &VoteManager{
+		cfg:           cfg,
+		untilNextVote: cfg.Interval,
+		ballots:       make(map[string]int),
+	}
+}
+
+// SetPoll runs votes as platform polls (nil = chat ballots). A vote whose
+// poll can't be created falls back to !vote.
+func (vm *VoteManager) SetPoll(poll VotePoll) {
+	vm.mu.Lock()
+	defer vm.mu.Unlock()
+	vm.poll = poll
+}
+
+// Enabled reports whether periodic voting is on
+func (vm *VoteManager) Enabled() bool {
+	return vm.cfg.Interval > 0
+}
+
+// Update advances vote and modifier timers, returns the active modifier
+func (vm *VoteManager) Update(deltaTime float64) string {
+	vm.mu.Lock()
+	defer vm.mu.Unlock()
+
+	if vm.activeRemaining > 0 {
+		vm.activeRemaining -= deltaTime
+		if vm.activeRemaining <= 0 {
+			log.Printf("🗳️ Arena modifier expired: %s", vm.active)
+			vm.active = ""
+			vm.activeRemaining = 0
+		}
+	}
+
+	if !vm.Enabled() {
+		return vm.active
+	}
+
+	if vm.voting {
+		vm.voteRemaining -= deltaTime
+		if vm.voteRemaining <= 0 {
+			vm.closeVote()
+		}
+		return vm.active
+	}
+
+	vm.untilNextVote -= deltaTime
+	if vm.untilNextVote <= 0 {
+		vm.openVote()
+	}
+	return vm.active
+}
+
+// openVote picks random modifiers and starts accepting ballots (caller holds lock)
+func (vm *VoteManager) openVote() {
+	ids := make([]string, 0, len(Modifiers))
+	for id := range Modifiers {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids) // Map order is random; sort so the shuffle is the only randomness
+	rand.Shuffle(len(ids), func(i, j int) { ids[i], ids[j] = ids[j], ids[i] })
+
+	options := make([]ArenaModifier, 0, VoteOptionCount)
+	for i := range vm.options {
+		vm.options[i] = ids[i%len(ids)]
+		options = append(options, Modifiers[vm.options[i]])
+	}
+
+	vm.voting = true
+	vm.voteRemaining = vm.cfg.Duration
+	vm.untilNextVote = vm.cfg.Interval
+	vm.ballots = make(map[string]int)
+	vm.voteSeq++
+	vm.polled = false
+	vm.pollCounts = [VoteOptionCount]int{}
+
+	log.Printf("🗳️ Arena vote open: 1) %s 2) %s 3) %s", options[0].Name, options[1].Name, options[2].Name)
+	if vm.poll != nil {
+		// Announced once the poll is up (or fell back to chat)
+		go vm.runPoll(vm.poll, vm.voteSeq, options)
+		return
+	}
+	if vm.OnVoteStart != nil {
+		go vm.OnVoteStart(options)
+	}
+}
+
+// runPoll creates the platform poll for a vote and copies its results into
+// the tally until the vote closes
+func (vm *VoteManager) runPoll(poll VotePoll, seq int, options []ArenaModifier) {
+	names := make([]string, len(options))
+	for i, mod := range options {
+		names[i] = mod.Emoji + " " + mod.Name
+	}
+	id, err := poll.CreatePoll("Next arena modifier?", names, int(vm.cfg.Duration))
+
+	vm.mu.Lock()
+	current := vm.voting && vm.voteSeq == seq
+	if err != nil {
+		log.Printf("⚠️ Arena vote poll unavailable, counting !vote instead: %v", err)
+	} else if current {
+		vm.polled = true
+	}
+	vm.mu.Unlock()
+
+	if current && vm.OnVoteStart != nil {
+		vm.OnVoteStart(options)
+	}
+	if err != nil || !current {
+		return
+	}
+
+	ticker := time.NewTicker(votePollRefresh)
+	defer ticker.Stop()
+	for range ticker.C {
+		results, err := poll.GetPollResults(id)
+
+		vm.mu.Lock()
+		if !vm.voting || vm.voteSeq != seq {
+			vm.mu.Unlock()
+			return
+		}
+		if err != nil {
+			log.Printf("⚠️ Failed to read arena vote poll: %v", err)
+		} else {
+			for i := range vm.pollCounts {
+				if i < len(results) {
+					vm.pollCounts[i] = results[i]
+				}
+			}
+		}
+		vm.mu.Unlock()
+	}
+}
+
+// closeVote tallies ballots and applies the winner (caller holds lock)
+// Ties go to the lower option number; no ballots means no modifier
+func (vm *VoteManager) closeVote() {
+	counts := vm.counts()
+	winner, best := -1, 0
+	for i, c := range counts {
+		if c > best {
+			winner, best = i, c
+		}
+	}
+
+	vm.voting = false
+	vm.voteRemaining = 0
+	vm.untilNextVote = vm.cfg.Interval - vm.cfg.Duration
+
+	if winner < 0 {
+		log.Println("🗳️ Arena vote closed with no votes")
+		return
+	}
+
+	mod := Modifiers[vm.options[winner]]
+	vm.active = mod.ID
+	vm.activeRemaining = vm.cfg.Interval
+	log.Printf("🗳️ Arena vote winner: %s %s (%d votes)", mod.Emoji, mod.Name, best)
+	if vm.OnVoteEnd != nil {
+		go vm.OnVoteEnd(mod, best)
+	}
+}
+
+// counts returns votes per option (caller holds lock)
+func (vm *VoteManager) counts() [VoteOptionCount]int {
+	if vm.polled {
+		return vm.pollCounts
+	}
+	var counts [VoteOptionCount]int
+	for _, idx := range vm.ballots {
+		counts[idx]++
+	}
+	return counts
+}
+
+// Vote records a viewer's ballot (option is 1-based); re-voting changes the ballot
+func (vm *VoteManager) Vote(username string, option int) error {
+	vm.mu.Lock()
+	defer vm.mu.Unlock()
+
+	if !vm.voting {
+		return fmt.Errorf("no vote is open")
+	}
+	if vm.polled {
+		return fmt.Errorf("vote in the channel poll")
+	}
+	if option < 1 || option > VoteOptionCount {
+		return fmt.Errorf("option must be 1-%d", VoteOptionCount)
+	}
+
+	vm.ballots[username] = option - 1
+	return nil
+}
+
+// Snapshot returns the current vote/modifier state for rendering
+func (vm *VoteManager) Snapshot() VoteSnapshot {
+	vm.mu.Lock()
+	defer vm.mu.Unlock()
+
+	snap := VoteSnapshot{Modifier: vm.active, Voting: vm.voting}
+	if vm.voting {
+		snap.Poll = vm.polled
+		snap.Options = vm.options
+		snap.Counts = vm.counts()
+		snap.Remaining = vm.voteRemaining
+	} else {
+		snap.Remaining = vm.activeRemaining
+	}
+	return snap
+}
@@ -0,0 +1,183 @@
+package game
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestVoteAppliesWinner verifies a vote opens, tallies ballots and activates the winner
+func TestVoteAppliesWinner(t *testing.T) {
+	vm := NewVoteManager(VotingConfig{Interval: 10, Duration: 2})
+
+	if err := vm.Vote("alice", 1); err == nil {
+		t.Error("Expected error when no vote is open")
+	}
+
+	vm.Update(10)
+	snap := vm.Snapshot()
+	if !snap.Voting {
+		t.Fatal("Expected vote to open after the interval")
+	}
+	for i, id := range snap.Options {
+		if _, ok := GetModifier(id); !ok {
+			t.Fatalf("Option %d is not a modifier: '%s'", i+1, id)
+		}
+	}
+
+	if err := vm.Vote("alice", 4); err == nil {
+		t.Error("Expected error for out-of-range option")
+	}
+	vm.Vote("alice", 1)
+	vm.Vote("bob", 2)
+	vm.Vote("carol", 2)
+	vm.Vote("alice", 2) // Re-vote changes the ballot
+	if counts := vm.Snapshot().Counts; counts != [VoteOptionCount]int{0, 3, 0} {
+		t.Errorf("Unexpected tally %v", counts)
+	}
+
+	if active := vm.Update(2); active != snap.Options[1] {
+		t.Errorf("Expected winner '%s', got '%s'", snap.Options[1], active)
+	}
+	if snap := vm.Snapshot(); snap.Voting || snap.Remaining != 10 {
+		t.Errorf("Expected closed vote with modifier active for the interval, got %+v", snap)
+	}
+
+	// Modifier expires after the interval
+	if active := vm.Update(10.1); active != "" {
+		t.Errorf("Expected modifier to expire, got '%s'", active)
+	}
+}
+
+// TestVoteNoBallots verifies a vote without ballots applies no modifier
+func TestVoteNoBallots(t *testing.T) {
+	vm := NewVoteManager(VotingConfig{Interval: 10, Duration: 2})
+	vm.Update(10)
+	if active := vm.Update(2); active != "" {
+		t.Errorf("Expected no modifier, got '%s'", active)
+	}
+	if vm.Snapshot().Voting {
+		t.Error("Expected vote to be closed")
+	}
+}
+
+// TestVoteDisabled verifies an interval of 0 never opens a vote
+func TestVoteDisabled(t *testing.T) {
+	vm := NewVoteManager(VotingConfig{Interval: 0, Duration: 2})
+	for i := 0; i < 100; i++ {
+		vm.Update(10)
+	}
+	if vm.Snapshot().Voting {
+		t.Error("Voting should be disabled")
+	}
+}
+
+// TestModifierEffects verifies double damage and tiny players apply to combat/physics
+func TestModifierEffects(t *testing.T) {
+	engine := newTestEngine(30)
+	if engine.damageMultiplier() != 1 {
+		t.Error("Expected normal damage without a modifier")
+	}
+	engine.modifier = ModDoubleDamage
+	if engine.damageMultiplier() != 2 {
+		t.Error("Expected double damage")
+	}
+
+	p := NewPlayer("shrinky", PlayerOptions{})
+	p.modifier = ModTinyPlayers
+	if p.sizeScale() != TinyPlayerScale {
+		t.Errorf("Expected tiny scale, got %.2f", p.sizeScale())
+	}
+	p.modifier = ModLowGravity
+	if p.friction() <= 0.85 {
+		t.Error("Low gravity should retain more velocity")
+	}
+}
+
+// fakeVotePoll is a platform poll with scripted results
+type fakeVotePoll struct {
+	mu      sync.Mutex
+	err     error
+	options []string
+	results []int
+}
+
+func (f *fakeVotePoll) CreatePoll(title string, options []string, duration int) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.options = options
+	return "poll-1", f.err
+}
+
+func (f *fakeVotePoll) GetPollResults(id string) ([]int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.results, nil
+}
+
+// waitVote polls the vote snapshot until cond holds
+func waitVote(t *testing.T, vm *VoteManager, cond func(VoteSnapshot) bool) VoteSnapshot {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		snap := vm.Snapshot()
+		if cond(snap) {
+			return snap
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for the vote, last %+v", snap)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// TestVotePoll verifies a poll-backed vote takes its tally from the poll
+// and ignores !vote
+func TestVotePoll(t *testing.T) {
+	defer func(d time.Duration) { votePollRefresh = d }(votePollRefresh)
+	votePollRefresh = 10 * time.Millisecond
+
+	poll := &fakeVotePoll{results: []int{1, 0, 7}}
+	vm := NewVoteManager(VotingConfig{Interval: 10, Duration: 2})
+	vm.SetPoll(poll)
+	started := make(chan []ArenaModifier, 1)
+	vm.OnVoteStart = func(options []ArenaModifier) { started <- options }
+
+	vm.Update(10)
+	snap := waitVote(t, vm, func(s VoteSnapshot) bool { return s.Counts[2] == 7 })
+	if !snap.Poll {
+		t.Error("Expected the vote to run as a poll")
+	}
+	if options := <-started; len(poll.options) != VoteOptionCount || poll.options[0] != options[0].Emoji+" "+options[0].Name {
+		t.Errorf("Expected the modifiers as poll options, got %v", poll.options)
+	}
+	if err := vm.Vote("alice", 1); err == nil {
+		t.Error("Expected !vote to be refused during a poll")
+	}
+
+	if active := vm.Update(2); active != snap.Options[2] {
+		t.Errorf("Expected the poll winner '%s', got '%s'", snap.Options[2], active)
+	}
+}
+
+// TestVotePollFallback verifies a vote falls back to !vote when the poll
+// can't be created
+func TestVotePollFallback(t *testing.T) {
+	vm := NewVoteManager(VotingConfig{Interval: 10, Duration: 2})
+	vm.SetPoll(&fakeVotePoll{err: errors.New("404")})
+	started := make(chan struct{})
+	vm.OnVoteStart = func([]ArenaModifier) { close(started) }
+
+	vm.Update(10)
+	<-started
+	if vm.Snapshot().Poll {
+		t.Error("Expected chat ballots without a poll")
+	}
+	if err := vm.Vote("alice", 2); err != nil {
+		t.Errorf("Expected !vote to work, got %v", err)
+	}
+	if counts := vm.Snapshot().Counts; counts != [VoteOptionCount]int{0, 1, 0} {
+		t.Errorf("Unexpected tally %v", counts)
+	}
+}
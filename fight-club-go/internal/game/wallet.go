@@ -0,0 +1,257 @@
+package game
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"fight-club/internal/config"
+)
+
+// EconomyConfig is an alias for config.EconomyConfig (SSOT)
+type EconomyConfig = config.EconomyConfig
+
+// Wallet is a viewer's persistent balance, separate from the in-arena Player.
+// Player.Money is lost on death; wallet money survives deaths and restarts.
+type Wallet struct {
+	Username    string    `json:"username"`
+	Balance     int       `json:"balance"`
+	TotalEarned int       `json:"totalEarned"`
+	LastSeen    time.Time `json:"lastSeen"`
+}
+
+// WalletManager tracks viewer presence and pays passive income
+// to viewers who have joined at least once and are still watching.
+type WalletManager struct {
+	mu          sync.RWMutex
+	wallets     map[string]*Wallet
+	cfg         EconomyConfig
+	dirty       bool
+	saveBlocked bool // The wallet file didn't parse and couldn't be moved aside
+
+	running bool
+	stopCh  chan struct{}
+	wg      sync.WaitGroup
+}
+
+// NewWalletManager creates a new wallet manager
+func NewWalletManager(cfg EconomyConfig) *WalletManager {
+	if cfg.PassiveEarnInterval <= 0 {
+		cfg = config.DefaultEconomy()
+	}
+	return &WalletManager{
+		wallets: make(map[string]*Wallet),
+		cfg:     cfg,
+		stopCh:  make(chan struct{}),
+	}
+}
+
+// Start loads persisted wallets and begins paying passive income
+func (wm *WalletManager) Start() {
+	wm.mu.Lock()
+	if wm.running {
+		wm.mu.Unlock()
+		return
+	}
+	wm.running = true
+	wm.mu.Unlock()
+
+	wm.load()
+
+	wm.wg.Add(1)
+	go wm.payoutLoop()
+
+	log.Printf("💰 Passive income: $%d every %.0fs (wallets: %s)",
+		wm.cfg.PassiveEarnAmount, wm.cfg.PassiveEarnInterval, wm.cfg.WalletFile)
+}
+
+// Stop stops payouts and flushes wallets to disk
+func (wm *WalletManager) Stop() {
+	wm.mu.Lock()
+	if !wm.running {
+		wm.mu.Unlock()
+		return
+	}
+	wm.running = false
+	wm.mu.Unlock()
+
+	close(wm.stopCh)
+	wm.wg.Wait()
+	wm.save()
+}
+
+// Register creates a wallet for a viewer on their first join
+func (wm *WalletManager) Register(username string) {
+	wm.mu.Lock()
+	defer wm.mu.Unlock()
+
+	w, ok := wm.wallets[username]
+	if !ok {
+		w = &Wallet{Username: username}
+		wm.wallets[username] = w
+	}
+	w.LastSeen = time.Now()
+	wm.dirty = true
+}
+
+// Touch records chat activity for a registered viewer (presence heartbeat)
+func (wm *WalletManager) Touch(username string) {
+	wm.mu.Lock()
+	defer wm.mu.Unlock()
+
+	if w, ok := wm.wallets[username]; ok {
+		w.LastSeen = time.Now()
+	}
+}
+
+// Balance returns a viewer's wallet balance (0 if unknown)
+func (wm *WalletManager) Balance(username string) int {
+	wm.mu.RLock()
+	defer wm.mu.RUnlock()
+
+	if w, ok := wm.wallets[username]; ok {
+		return w.Balance
+	}
+	return 0
+}
+
+// Spend deducts amount from a viewer's wallet, returns false if insufficient
+func (wm *WalletManager) Spend(username string, amount int) bool {
+	wm.mu.Lock()
+	defer wm.mu.Unlock()
+
+	w, ok := wm.wallets[username]
+	if !ok || w.Balance < amount {
+		return false
+	}
+	w.Balance -= amount
+	wm.dirty = true
+	return true
+}
+
+// Credit adds a prize to a viewer's wallet, returns false if they have none
+func (wm *WalletManager) Credit(username string, amount int) bool {
+	wm.mu.Lock()
+	defer wm.mu.Unlock()
+
+	w, ok := wm.wallets[username]
+	if !ok {
+		return false
+	}
+	w.Balance += amount
+	w.TotalEarned += amount
+	wm.dirty = true
+	return true
+}
+
+// GetWallet returns a copy of a viewer's wallet
+func (wm *WalletManager) GetWallet(username string) (Wallet, bool) {
+	wm.mu.RLock()
+	defer wm.mu.RUnlock()
+
+	if w, ok := wm.wallets[username]; ok {
+		return *w, true
+	}
+	return Wallet{}, false
+}
+
+// payoutLoop credits passive income every PassiveEarnInterval
+func (wm *WalletManager) payoutLoop() {
+	defer wm.wg.Done()
+
+	interval := time.Duration(wm.cfg.PassiveEarnInterval * float64(time.Second))
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-wm.stopCh:
+			return
+		case now := <-ticker.C:
+			if paid := wm.payout(now); paid > 0 {
+				wm.save()
+			}
+		}
+	}
+}
+
+// payout credits every present viewer, returns number of wallets paid
+// Viewers earn whether alive, dead or out of the arena
+func (wm *WalletManager) payout(now time.Time) int {
+	wm.mu.Lock()
+	defer wm.mu.Unlock()
+
+	timeout := time.Duration(wm.cfg.PresenceTimeout * float64(time.Second))
+	paid := 0
+	for _, w := range wm.wallets {
+		if now.Sub(w.LastSeen) > timeout {
+			continue // Not watching anymore
+		}
+		w.Balance += wm.cfg.PassiveEarnAmount
+		w.TotalEarned += wm.cfg.PassiveEarnAmount
+		paid++
+	}
+	if paid > 0 {
+		wm.dirty = true
+	}
+	return paid
+}
+
+// save persists wallets to disk if anything changed
+func (wm *WalletManager) save() {
+	wm.mu.Lock()
+	if !wm.dirty || wm.cfg.WalletFile == "" || wm.saveBlocked {
+		wm.mu.Unlock()
+		return
+	}
+	wallets := make([]Wallet, 0, len(wm.wallets))
+	for _, w := range wm.wallets {
+		wallets = append(wallets, *w)
+	}
+	wm.dirty = false
+	wm.mu.Unlock()
+
+	data, err := json.MarshalIndent(wallets, "", "  ")
+	if err != nil {
+		log.Printf("⚠️ Failed to marshal wallets: %v", err)
+		return
+	}
+
+	if err := WriteFileAtomic(wm.cfg.WalletFile, data, 0600); err != nil {
+		log.Printf("⚠️ Failed to save wallets: %v", err)
+	}
+}
+
+// load restores persisted wallets from disk. A file that doesn't parse is
+// moved aside, or else saving is blocked, so it's never overwritten.
+func (wm *WalletManager) load() {
+	if wm.cfg.WalletFile == "" {
+		return
+	}
+
+	data, err := os.ReadFile(wm.cfg.WalletFile)
+	if err != nil {
+		return // No saved wallets
+	}
+
+	var wallets []Wallet
+	if err := json.Unmarshal(data, &wallets); err != nil {
+		if !setAsideCorrupt(wm.cfg.WalletFile, "wallets", err) {
+			wm.mu.Lock()
+			wm.saveBlocked = true
+			wm.mu.Unlock()
+		}
+		return
+	}
+
+	wm.mu.Lock()
+	for i := range wallets {
+		w := wallets[i]
+		wm.wallets[w.Username] = &w
+	}
+	wm.mu.Unlock()
+
+	log.Printf("📂 Loaded %d wallets from disk", len(wallets))
+}
@@ -0,0 +1,108 @@
+package game
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTestWalletManager(t *testing.T) *WalletManager {
+	cfg := DefaultEconomy
+	cfg.WalletFile = filepath.Join(t.TempDir(), "wallets.json")
+	return NewWalletManager(cfg)
+}
+
+// TestWalletPassivePayout verifies present viewers earn and absent ones don't
+func TestWalletPassivePayout(t *testing.T) {
+	wm := newTestWalletManager(t)
+	wm.Register("watcher")
+	wm.Register("lurker")
+
+	// Touch on unregistered viewers must not create wallets
+	wm.Touch("stranger")
+	if _, ok := wm.GetWallet("stranger"); ok {
+		t.Error("Touch should not create a wallet")
+	}
+
+	// Make lurker absent beyond the presence timeout
+	wm.mu.Lock()
+	wm.wallets["lurker"].LastSeen = time.Now().Add(-2 * time.Hour)
+	wm.mu.Unlock()
+
+	if paid := wm.payout(time.Now()); paid != 1 {
+		t.Errorf("Expected 1 wallet paid, got %d", paid)
+	}
+	if b := wm.Balance("watcher"); b != DefaultEconomy.PassiveEarnAmount {
+		t.Errorf("Expected watcher balance %d, got %d", DefaultEconomy.PassiveEarnAmount, b)
+	}
+	if b := wm.Balance("lurker"); b != 0 {
+		t.Errorf("Absent viewer should not earn, got %d", b)
+	}
+}
+
+// TestWalletSpend verifies spending rejects insufficient balances
+func TestWalletSpend(t *testing.T) {
+	wm := newTestWalletManager(t)
+	wm.Register("buyer")
+	wm.payout(time.Now())
+
+	if wm.Spend("buyer", DefaultEconomy.PassiveEarnAmount+1) {
+		t.Error("Spend should fail with insufficient balance")
+	}
+	if !wm.Spend("buyer", DefaultEconomy.PassiveEarnAmount) {
+		t.Error("Spend should succeed with exact balance")
+	}
+	if wm.Balance("buyer") != 0 {
+		t.Errorf("Expected empty wallet, got %d", wm.Balance("buyer"))
+	}
+}
+
+// TestWalletPersistence verifies wallets survive a save/load round trip
+func TestWalletPersistence(t *testing.T) {
+	wm := newTestWalletManager(t)
+	wm.Register("saver")
+	wm.payout(time.Now())
+	wm.save()
+
+	restored := NewWalletManager(wm.cfg)
+	restored.load()
+	if b := restored.Balance("saver"); b != DefaultEconomy.PassiveEarnAmount {
+		t.Errorf("Expected restored balance %d, got %d", DefaultEconomy.PassiveEarnAmount, b)
+	}
+}
+
+// TestWalletCorruptFileKept verifies a wallet file that doesn't parse is moved
+// aside instead of being overwritten by the next save
+func TestWalletCorruptFileKept(t *testing.T) {
+	wm := newTestWalletManager(t)
+	if err := os.WriteFile(wm.cfg.WalletFile, []byte(`[{"username": "rich", "bal`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	wm.load()
+	wm.Register("newcomer")
+	wm.save()
+
+	moved, _ := filepath.Glob(wm.cfg.WalletFile + ".corrupt-*")
+	if len(moved) != 1 {
+		t.Fatalf("Expected the corrupt wallet file moved aside, got %v", moved)
+	}
+	if data, _ := os.ReadFile(moved[0]); string(data) != `[{"username": "rich", "bal` {
+		t.Errorf("Expected the corrupt wallets kept as they were, got %q", data)
+	}
+	restored := NewWalletManager(wm.cfg)
+	restored.load()
+	if _, ok := restored.GetWallet("newcomer"); !ok {
+		t.Error("Expected new wallets saved to a fresh file")
+	}
+}
+
+// TestEngineJoinRegistersWallet verifies joining creates a persistent wallet
+func TestEngineJoinRegistersWallet(t *testing.T) {
+	engine := newTestEngine(30)
+	engine.AddPlayer("viewer", PlayerOptions{})
+
+	if _, ok := engine.GetWalletManager().GetWallet("viewer"); !ok {
+		t.Error("Joining should register a wallet")
+	}
+}
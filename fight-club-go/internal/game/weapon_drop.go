@@ -0,0 +1,137 @@
+package game
+
+import (
+	"log"
+	"math"
+)
+
+// MaxWeaponDrops caps the weapons on the ground (the oldest vanishes first)
+const MaxWeaponDrops = 16
+
+// WeaponDropSnapshot is a dropped weapon for rendering
+type WeaponDropSnapshot struct {
+	X, Y      float64
+	Weapon    string  // Weapon ID
+	Remaining float64 // Seconds until it despawns
+}
+
+// weaponDrop is the weapon of a dead fighter lying where they fell
+type weaponDrop struct {
+	x, y    float64
+	weapon  string
+	owner   string // Name of the fighter who dropped it
+	ownerID string
+	age     float64
+}
+
+// dropWeapon drops a dead fighter's weapon where they fell; they respawn
+// with fists. Caller holds e.mu.
+func (e *Engine) dropWeapon(victim *Player) {
+	if e.loot.WeaponDespawn <= 0 || victim.Weapon == "" || victim.Weapon == "fists" {
+		return
+	}
+
+	if len(e.weaponDrops) >= MaxWeaponDrops {
+		e.removeWeaponDrop(0, "despawn", "")
+	}
+	drop := &weaponDrop{x: victim.X, y: victim.Y, weapon: victim.Weapon, owner: victim.Name, ownerID: victim.ID}
+	victim.Weapon = "fists"
+	e.weaponDrops = append(e.weaponDrops, drop)
+	e.emitWeaponDrop("drop", drop, victim.ID)
+}
+
+// updateWeaponDrops ages dropped weapons and hands them to fighters walking
+// over them, if the weapon beats what they hold (fists-only newcomers arm up,
+// an axe never gets swapped for a knife). Caller holds e.mu.
+func (e *Engine) updateWeaponDrops(deltaTime float64) {
+	playerList := e.playerSlice
+	queryRange := e.loot.PickupRadius + PlayerRadius // Grid positions are from the start of the tick
+
+	for i := 0; i < len(e.weaponDrops); i++ {
+		drop := e.weaponDrops[i]
+		drop.age += deltaTime
+		if drop.age >= e.loot.WeaponDespawn {
+			e.removeWeaponDrop(i, "despawn", "")
+			i--
+			continue
+		}
+
+		price := GetWeapon(drop.weapon).Price
+		var picker *Player
+		best := e.loot.PickupRadius
+		for _, idx := range e.spatialGrid.QueryRadius(drop.x, drop.y, queryRange) {
+			p := playerList[idx]
+			if p.IsDead || p.IsRagdoll || GetWeapon(p.Weapon).Price >= price {
+				continue
+			}
+			if d := math.Hypot(p.X-drop.x, p.Y-drop.y); d <= best {
+				picker, best = p, d
+			}
+		}
+		if picker == nil {
+			continue
+		}
+
+		picker.Weapon = drop.weapon
+		weapon := GetWeapon(drop.weapon)
+		log.Printf("🗡️ %s picked up %s's %s", picker.Name, drop.owner, weapon.Name)
+		if len(e.texts) < e.limits.MaxTexts {
+			e.texts = append(e.texts, &FloatingText{
+				X:     picker.X,
+				Y:     picker.Y - 30,
+				Text:  "+" + weapon.Name,
+				Color: weapon.Color,
+				Alpha: 1.0,
+				VY:    -1.5,
+			})
+		}
+		e.removeWeaponDrop(i, "pickup", picker.ID)
+		i--
+	}
+}
+
+// reclaimWeapon returns a fighter's weapon still on the ground (their death was undone)
+func (e *Engine) reclaimWeapon(p *Player) {
+	for i := 0; i < len(e.weaponDrops); i++ {
+		if drop := e.weaponDrops[i]; drop.owner == p.Name {
+			p.Weapon = drop.weapon
+			e.removeWeaponDrop(i, "reclaim", p.ID)
+			return
+		}
+	}
+}
+
+// removeWeaponDrop removes drop i (keeping drop order) and logs why
+func (e *Engine) removeWeaponDrop(i int, phase, playerID string) {
+	drop := e.weaponDrops[i]
+	copy(e.weaponDrops[i:], e.weaponDrops[i+1:])
+	e.weaponDrops[len(e.weaponDrops)-1] = nil
+	e.weaponDrops = e.weaponDrops[:len(e.weaponDrops)-1]
+	e.emitWeaponDrop(phase, drop, playerID)
+}
+
+// emitWeaponDrop logs a weapon loot event (weapons changing hands outside the shop)
+func (e *Engine) emitWeaponDrop(phase string, drop *weaponDrop, playerID string) {
+	e.eventLog.EmitSimple(EventTypeLoot, uint64(e.tickCount), playerID,
+		LootPayload{
+			Phase:    phase,
+			OwnerID:  drop.ownerID,
+			PlayerID: playerID,
+			Weapon:   drop.weapon,
+			X:        drop.x,
+			Y:        drop.y,
+			Age:      drop.age,
+		})
+}
+
+// weaponDropSnapshot copies the dropped weapons into the snapshot
+func (e *Engine) weaponDropSnapshot(snap *GameSnapshot) {
+	for _, drop := range e.weaponDrops {
+		snap.WeaponDrops = append(snap.WeaponDrops, WeaponDropSnapshot{
+			X:         drop.x,
+			Y:         drop.y,
+			Weapon:    drop.weapon,
+			Remaining: e.loot.WeaponDespawn - drop.age,
+		})
+	}
+}
@@ -0,0 +1,449 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrInvalidWeapon is returned for malformed weapon definitions
+var ErrInvalidWeapon = errors.New("invalid weapon")
+
+// weaponIDPattern is what a weapon ID may look like (it ends up in chat
+// commands, sound keys and snapshot fields)
+var weaponIDPattern = regexp.MustCompile(`^[a-z0-9_-]{1,24}$`)
+
+// weaponColorPattern is the #rrggbb color the renderers parse
+var weaponColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// WeaponSound is a weapon's swing/hit/kill sound: an audio file in the sounds
+// directory played at a pitch and volume (see the streamer's sound manifest)
+type WeaponSound struct {
+	File   string  `json:"file" yaml:"file"`
+	Volume float64 `json:"volume,omitempty" yaml:"volume"` // Default 1.0
+	Pitch  float64 `json:"pitch,omitempty" yaml:"pitch"`   // Playback rate, default 1.0
+}
+
+// WeaponDefinition is one weapon of the data-driven registry: stats and
+// price, chat aliases, animation/hitbox feel and sounds. Definitions live in
+// a YAML (or JSON) weapons file, e.g.
+//
+//	weapons:
+//	  - id: trident
+//	    base: spear          # Start from the spear, override what differs
+//	    name: Trident
+//	    aliases: [tridente]
+//	    min_damage: 14
+//	    max_damage: 24
+//	    price: 550
+//	    color: "#3fa7d6"
+//	    animation: {lunge_distance: 45, knockback_force: 30}
+//	    sounds:
+//	      hit: {file: hit.wav, pitch: 0.8}
+//
+// A definition with a built-in weapon's ID replaces that weapon.
+type WeaponDefinition struct {
+	Weapon    `yaml:",inline"`
+	Base      string                 `json:"base,omitempty" yaml:"base"`
+	Aliases   []string               `json:"aliases,omitempty" yaml:"aliases"`
+	Animation WeaponAnimationConfig  `json:"animation" yaml:"animation"`
+	Sounds    map[string]WeaponSound `json:"sounds,omitempty" yaml:"sounds"`
+}
+
+// weaponSoundEvents are the sounds a definition may set
+var weaponSoundEvents = map[string]bool{"swing": true, "hit": true, "kill": true}
+
+// weaponRegistryFile is the weapons file layout
+type weaponRegistryFile struct {
+	Weapons []yaml.Node `yaml:"weapons"`
+}
+
+// weaponRegistry is an installed set of definitions. Immutable once
+// installed, so lookups from the tick loop and renderers need no lock.
+type weaponRegistry struct {
+	defs    map[string]WeaponDefinition
+	aliases map[string]string // Lowercase alias or ID -> weapon ID
+	order   []string          // IDs in file order
+}
+
+// installedWeapons is the active registry (nil = built-in weapons only)
+var installedWeapons atomic.Pointer[weaponRegistry]
+
+// weaponRegistryVersion counts installs, so caches keyed by weapon (sounds)
+// know to refresh
+var weaponRegistryVersion atomic.Uint64
+
+// ParseWeaponDefinitions parses and validates a weapons file. Each entry
+// starts from its base (a built-in or an earlier entry), else from the
+// built-in with the same ID, else from fists' animation with no stats.
+func ParseWeaponDefinitions(data []byte) ([]WeaponDefinition, error) {
+	var file weaponRegistryFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse weapons: %w", err)
+	}
+
+	defs := make([]WeaponDefinition, 0, len(file.Weapons))
+	byID := make(map[string]int, len(file.Weapons))
+	for i := range file.Weapons {
+		node := &file.Weapons[i]
+		var head struct {
+			ID   string `yaml:"id"`
+			Base string `yaml:"base"`
+		}
+		if err := node.Decode(&head); err != nil {
+			return nil, fmt.Errorf("%w: entry %d: %v", ErrInvalidWeapon, i+1, err)
+		}
+		if !weaponIDPattern.MatchString(head.ID) {
+			return nil, fmt.Errorf("%w: entry %d: id %q must be 1-24 of a-z, 0-9, _ or -", ErrInvalidWeapon, i+1, head.ID)
+		}
+		if _, dup := byID[head.ID]; dup {
+			return nil, fmt.Errorf("%w: %s: defined twice", ErrInvalidWeapon, head.ID)
+		}
+
+		def, err := weaponTemplate(head.ID, head.Base, defs, byID)
+		if err != nil {
+			return nil, err
+		}
+		if err := node.Decode(&def); err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrInvalidWeapon, head.ID, err)
+		}
+		def.ID = head.ID
+		def.Animation.WeaponID = head.ID
+		if err := validateWeaponDefinition(&def); err != nil {
+			return nil, err
+		}
+
+		byID[def.ID] = len(defs)
+		defs = append(defs, def)
+	}
+
+	if _, err := newWeaponRegistry(defs); err != nil {
+		return nil, err
+	}
+	return defs, nil
+}
+
+// weaponTemplate returns the definition an entry is decoded over
+func weaponTemplate(id, base string, defs []WeaponDefinition, byID map[string]int) (WeaponDefinition, error) {
+	from := base
+	if from == "" {
+		from = id
+	}
+
+	var def WeaponDefinition
+	if i, ok := byID[from]; ok {
+		def = defs[i]
+	} else if w, ok := Weapons[from]; ok {
+		def = WeaponDefinition{Weapon: w, Animation: cachedWeaponAnimations[from]}
+	} else if base != "" {
+		return def, fmt.Errorf("%w: %s: unknown base weapon %q", ErrInvalidWeapon, id, base)
+	} else {
+		def = WeaponDefinition{Animation: defaultFistsAnimation}
+	}
+
+	// Don't share the base's sounds map, and aliases are never inherited
+	sounds := make(map[string]WeaponSound, len(def.Sounds))
+	for event, s := range def.Sounds {
+		sounds[event] = s
+	}
+	def.Sounds = sounds
+	def.Aliases = nil
+	def.Base = base
+	return def, nil
+}
+
+// validateWeaponDefinition checks stats, animation and sounds are playable
+func validateWeaponDefinition(d *WeaponDefinition) error {
+	fail := func(format string, args ...any) error {
+		return fmt.Errorf("%w: %s: %s", ErrInvalidWeapon, d.ID, fmt.Sprintf(format, args...))
+	}
+
+	d.Name = strings.TrimSpace(d.Name)
+	switch {
+	case d.Name == "":
+		return fail("name is required")
+	case d.MinDamage < 0 || d.MinDamage > d.MaxDamage:
+		return fail("damage must be 0 <= min_damage (%d) <= max_damage (%d)", d.MinDamage, d.MaxDamage)
+	case d.Range <= 60:
+		return fail("range must be > 60 to hit (got %g)", d.Range)
+	case d.Cooldown <= 0:
+		return fail("cooldown must be > 0 seconds")
+	case d.Price < 0:
+		return fail("price can't be negative")
+	case !weaponColorPattern.MatchString(d.Color):
+		return fail("color %q must be #rrggbb", d.Color)
+	}
+
+	a := &d.Animation
+	switch {
+	case a.ActiveTicks < 1 || a.WindUpTicks < 0 || a.RecoveryTicks < 0:
+		return fail("animation needs active_ticks >= 1 and no negative ticks")
+	case a.TrailColor != "" && !weaponColorPattern.MatchString(a.TrailColor):
+		return fail("animation trail_color %q must be #rrggbb", a.TrailColor)
+	case a.IsProjectile && a.ProjectileSpeed <= 0:
+		return fail("projectile weapons need projectile_speed > 0")
+	case a.SlowMultiplier < 0 || a.SlowMultiplier > 1:
+		return fail("animation slow_multiplier must be 0-1")
+	}
+	if a.ParticleStyle != "" {
+		if _, ok := ParticleStyles[a.ParticleStyle]; !ok {
+			return fail("unknown particle_style %q", a.ParticleStyle)
+		}
+	}
+
+	for event, s := range d.Sounds {
+		if !weaponSoundEvents[event] {
+			return fail("unknown sound event %q (want swing, hit or kill)", event)
+		}
+		if s.File == "" {
+			return fail("sound %s needs a file", event)
+		}
+	}
+	return nil
+}
+
+// newWeaponRegistry indexes definitions, rejecting aliases that collide with
+// another weapon
+func newWeaponRegistry(defs []WeaponDefinition) (*weaponRegistry, error) {
+	r := &weaponRegistry{
+		defs:    make(map[string]WeaponDefinition, len(defs)),
+		aliases: make(map[string]string),
+		order:   make([]string, 0, len(defs)),
+	}
+	for _, d := range defs {
+		r.defs[d.ID] = d
+		r.order = append(r.order, d.ID)
+		r.aliases[d.ID] = d.ID
+	}
+	for _, d := range defs {
+		for _, alias := range d.Aliases {
+			alias = strings.ToLower(strings.TrimSpace(alias))
+			if alias == "" {
+				continue
+			}
+			if other, ok := r.aliases[alias]; ok && other != d.ID {
+				return nil, fmt.Errorf("%w: %s: alias %q is taken by %s", ErrInvalidWeapon, d.ID, alias, other)
+			}
+			if _, builtin := Weapons[alias]; builtin && alias != d.ID {
+				return nil, fmt.Errorf("%w: %s: alias %q is a built-in weapon", ErrInvalidWeapon, d.ID, alias)
+			}
+			r.aliases[alias] = d.ID
+		}
+	}
+	return r, nil
+}
+
+// InstallWeaponDefinitions makes definitions the active weapons, on top of
+// the built-ins (nil or empty = built-ins only). Definitions must come from
+// ParseWeaponDefinitions or a peer that ran it (the streamer gets them over
+// IPC).
+func InstallWeaponDefinitions(defs []WeaponDefinition) error {
+	if len(defs) == 0 {
+		installedWeapons.Store(nil)
+		weaponRegistryVersion.Add(1)
+		return nil
+	}
+	r, err := newWeaponRegistry(defs)
+	if err != nil {
+		return err
+	}
+	installedWeapons.Store(r)
+	weaponRegistryVersion.Add(1)
+	return nil
+}
+
+// WeaponDefinitions returns the installed definitions in file order
+func WeaponDefinitions() []WeaponDefinition {
+	r := installedWeapons.Load()
+	if r == nil {
+		return nil
+	}
+	defs := make([]WeaponDefinition, 0, len(r.order))
+	for _, id := range r.order {
+		defs = append(defs, r.defs[id])
+	}
+	return defs
+}
+
+// WeaponRegistryVersion changes every time definitions are installed
+func WeaponRegistryVersion() uint64 {
+	return weaponRegistryVersion.Load()
+}
+
+// IsWeapon returns whether id is a built-in or installed weapon
+func IsWeapon(id string) bool {
+	if r := installedWeapons.Load(); r != nil {
+		if _, ok := r.defs[id]; ok {
+			return true
+		}
+	}
+	_, ok := Weapons[id]
+	return ok
+}
+
+// WeaponIDForAlias resolves an installed weapon's ID or alias (case
+// insensitive); ok is false for anything else
+func WeaponIDForAlias(name string) (string, bool) {
+	r := installedWeapons.Load()
+	if r == nil {
+		return "", false
+	}
+	id, ok := r.aliases[strings.ToLower(name)]
+	return id, ok
+}
+
+// WeaponAliases returns the installed weapons' lowercase aliases and IDs,
+// mapped to weapon IDs (shared, do not modify), for chat lookups that fold
+// accents
+func WeaponAliases() map[string]string {
+	r := installedWeapons.Load()
+	if r == nil {
+		return nil
+	}
+	return r.aliases
+}
+
+// WeaponSoundFor returns an installed weapon's sound for an event
+func WeaponSoundFor(weaponID, event string) (WeaponSound, bool) {
+	r := installedWeapons.Load()
+	if r == nil {
+		return WeaponSound{}, false
+	}
+	s, ok := r.defs[weaponID].Sounds[event]
+	return s, ok
+}
+
+// WeaponRegistry loads weapon definitions from a YAML or JSON file. A
+// missing file means the built-in weapons only. Reload (admin API) swaps in
+// the file's current contents; an invalid file keeps the weapons in play.
+type WeaponRegistry struct {
+	mu       sync.Mutex
+	path     string
+	loadedAt time.Time
+	lastErr  string
+
+	// Called after every successful load with the installed definitions
+	// (e.g. to push them to the streamer process)
+	OnReload func(defs []WeaponDefinition)
+}
+
+// WeaponRegistryStatus is the admin view of the registry
+type WeaponRegistryStatus struct {
+	Path      string    `json:"path"`
+	LoadedAt  time.Time `json:"loadedAt,omitempty"`
+	LastError string    `json:"lastError,omitempty"`
+	Custom    []string  `json:"custom"` // Weapon IDs defined by the file
+	Weapons   []Weapon  `json:"weapons"`
+}
+
+// NewWeaponRegistry creates a registry, installing the file's weapons if it
+// exists
+func NewWeaponRegistry(path string) (*WeaponRegistry, error) {
+	wr := &WeaponRegistry{path: path}
+	if path == "" {
+		return wr, nil
+	}
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		return wr, nil
+	}
+	if _, err := wr.Reload(); err != nil {
+		return nil, err
+	}
+	return wr, nil
+}
+
+// Reload re-reads the weapons file and installs it. A missing file goes back
+// to the built-in weapons. Returns the number of weapons defined by the file.
+func (wr *WeaponRegistry) Reload() (int, error) {
+	wr.mu.Lock()
+	defer wr.mu.Unlock()
+
+	if wr.path == "" {
+		return 0, errors.New("no weapons file configured (set WEAPONS_FILE)")
+	}
+
+	var defs []WeaponDefinition
+	data, err := os.ReadFile(wr.path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		// Built-ins only
+	case err != nil:
+		wr.lastErr = err.Error()
+		return 0, fmt.Errorf("failed to read weapons: %w", err)
+	default:
+		if defs, err = ParseWeaponDefinitions(data); err != nil {
+			wr.lastErr = err.Error()
+			return 0, err
+		}
+	}
+
+	if err := InstallWeaponDefinitions(defs); err != nil {
+		wr.lastErr = err.Error()
+		return 0, err
+	}
+	wr.loadedAt = time.Now()
+	wr.lastErr = ""
+	log.Printf("🗡️ Loaded %d weapon definition(s) from %s", len(defs), wr.path)
+
+	if wr.OnReload != nil {
+		wr.OnReload(defs)
+	}
+	return len(defs), nil
+}
+
+// Status returns the file, last load and the weapons now in play
+func (wr *WeaponRegistry) Status() WeaponRegistryStatus {
+	wr.mu.Lock()
+	defer wr.mu.Unlock()
+
+	custom := make([]string, 0)
+	for _, d := range WeaponDefinitions() {
+		custom = append(custom, d.ID)
+	}
+	weapons := GetAllWeapons()
+	sort.Slice(weapons, func(i, j int) bool {
+		if weapons[i].Price != weapons[j].Price {
+			return weapons[i].Price < weapons[j].Price
+		}
+		return weapons[i].ID < weapons[j].ID
+	})
+	return WeaponRegistryStatus{
+		Path:      wr.path,
+		LoadedAt:  wr.loadedAt,
+		LastError: wr.lastErr,
+		Custom:    custom,
+		Weapons:   weapons,
+	}
+}
+
+// UnmarshalYAML accepts trail types by name in weapons files
+func (t *TrailType) UnmarshalYAML(node *yaml.Node) error {
+	var name string
+	if err := node.Decode(&name); err != nil {
+		return err
+	}
+	switch strings.ToLower(name) {
+	case "none", "":
+		*t = TrailNone
+	case "arc":
+		*t = TrailArc
+	case "line":
+		*t = TrailLine
+	case "radial":
+		*t = TrailRadial
+	case "projectile":
+		*t = TrailProjectile
+	default:
+		return fmt.Errorf("unknown trail_type %q (want none, arc, line, radial or projectile)", name)
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package game
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testWeaponsFile = `
+weapons:
+  - id: trident
+    base: spear
+    name: Trident
+    aliases: [Tridente]
+    min_damage: 14
+    max_damage: 24
+    price: 550
+    color: "#3fa7d6"
+    animation: {lunge_distance: 45, trail_type: line}
+    sounds:
+      hit: {file: hit.wav, pitch: 0.8}
+  - id: golden-trident
+    base: trident
+    name: Golden Trident
+    price: 900
+  - id: hammer
+    name: Big Hammer
+`
+
+// TestParseWeaponDefinitions verifies bases, same-ID built-ins and YAML overrides
+func TestParseWeaponDefinitions(t *testing.T) {
+	defs, err := ParseWeaponDefinitions([]byte(testWeaponsFile))
+	if err != nil {
+		t.Fatalf("ParseWeaponDefinitions failed: %v", err)
+	}
+	if len(defs) != 3 {
+		t.Fatalf("Expected 3 definitions, got %d", len(defs))
+	}
+
+	trident := defs[0]
+	spear := Weapons["spear"]
+	if trident.MinDamage != 14 || trident.Range != spear.Range || trident.Cooldown != spear.Cooldown {
+		t.Errorf("Expected trident stats over the spear's, got %+v", trident.Weapon)
+	}
+	if trident.Animation.WeaponID != "trident" || trident.Animation.LungeDistance != 45 || trident.Animation.TrailType != TrailLine {
+		t.Errorf("Unexpected trident animation %+v", trident.Animation)
+	}
+	if trident.Animation.ActiveTicks != cachedWeaponAnimations["spear"].ActiveTicks {
+		t.Error("Expected unset animation fields inherited from the spear")
+	}
+
+	golden := defs[1]
+	if golden.Price != 900 || golden.MinDamage != 14 || golden.Sounds["hit"].File != "hit.wav" || len(golden.Aliases) != 0 {
+		t.Errorf("Expected golden trident based on the trident (sounds, not aliases), got %+v", golden)
+	}
+
+	hammer := defs[2]
+	if hammer.Name != "Big Hammer" || hammer.MaxDamage != Weapons["hammer"].MaxDamage {
+		t.Errorf("Expected the hammer entry to start from the built-in hammer, got %+v", hammer.Weapon)
+	}
+
+	// JSON is YAML too
+	if _, err := ParseWeaponDefinitions([]byte(`{"weapons": [{"id": "dagger", "base": "knife", "name": "Dagger"}]}`)); err != nil {
+		t.Errorf("Expected a JSON weapons file to parse: %v", err)
+	}
+}
+
+// TestParseWeaponDefinitionsInvalid verifies bad definitions fail the whole file
+func TestParseWeaponDefinitionsInvalid(t *testing.T) {
+	for name, file := range map[string]string{
+		"bad id":         `weapons: [{id: "Big Axe", base: axe, name: Big Axe}]`,
+		"duplicate":      `weapons: [{id: club, base: axe, name: Club}, {id: club, base: axe, name: Club}]`,
+		"unknown base":   `weapons: [{id: club, base: mace, name: Club}]`,
+		"no stats":       `weapons: [{id: club, name: Club}]`,
+		"no name":        `weapons: [{id: club, base: axe, name: " "}]`,
+		"damage":         `weapons: [{id: club, base: axe, name: Club, min_damage: 30, max_damage: 10}]`,
+		"short range":    `weapons: [{id: club, base: axe, name: Club, range: 40}]`,
+		"color":          `weapons: [{id: club, base: axe, name: Club, color: red}]`,
+		"trail":          `weapons: [{id: club, base: axe, name: Club, animation: {trail_type: spiral}}]`,
+		"active ticks":   `weapons: [{id: club, base: axe, name: Club, animation: {active_ticks: 0}}]`,
+		"sound event":    `weapons: [{id: club, base: axe, name: Club, sounds: {block: {file: hit.wav}}}]`,
+		"alias taken":    `weapons: [{id: club, base: axe, name: Club, aliases: [bat]}, {id: bat2, base: axe, name: Bat, aliases: [bat]}]`,
+		"builtin alias":  `weapons: [{id: club, base: axe, name: Club, aliases: [sword]}]`,
+		"particle style": `weapons: [{id: club, base: axe, name: Club, animation: {particle_style: confetti}}]`,
+	} {
+		if _, err := ParseWeaponDefinitions([]byte(file)); !errors.Is(err, ErrInvalidWeapon) {
+			t.Errorf("%s: expected ErrInvalidWeapon, got %v", name, err)
+		}
+	}
+}
+
+// TestInstallWeaponDefinitions verifies lookups see installed weapons and
+// fall back to the built-ins once uninstalled
+func TestInstallWeaponDefinitions(t *testing.T) {
+	defs, err := ParseWeaponDefinitions([]byte(testWeaponsFile))
+	if err != nil {
+		t.Fatalf("ParseWeaponDefinitions failed: %v", err)
+	}
+	if err := InstallWeaponDefinitions(defs); err != nil {
+		t.Fatalf("InstallWeaponDefinitions failed: %v", err)
+	}
+	defer InstallWeaponDefinitions(nil)
+
+	if !IsWeapon("trident") || GetWeapon("trident").Name != "Trident" || GetWeaponAnimation("trident").LungeDistance != 45 {
+		t.Error("Expected the trident to be a weapon")
+	}
+	if GetWeapon("hammer").Name != "Big Hammer" || GetWeapon("sword").Name != Weapons["sword"].Name {
+		t.Error("Expected the file to replace the hammer and keep the sword")
+	}
+	if id, ok := WeaponIDForAlias("tridente"); !ok || id != "trident" {
+		t.Errorf("Expected the alias to resolve, got %q", id)
+	}
+	if s, ok := WeaponSoundFor("trident", "hit"); !ok || s.Pitch != 0.8 {
+		t.Errorf("Expected the trident hit sound, got %+v", s)
+	}
+	if n := len(GetAllWeapons()); n != len(Weapons)+2 {
+		t.Errorf("Expected %d weapons, got %d", len(Weapons)+2, n)
+	}
+
+	version := WeaponRegistryVersion()
+	InstallWeaponDefinitions(nil)
+	if IsWeapon("trident") || GetWeapon("hammer").Name != Weapons["hammer"].Name || WeaponRegistryVersion() == version {
+		t.Error("Expected the built-ins back after uninstalling")
+	}
+}
+
+// TestWeaponRegistryReload verifies an invalid file keeps the weapons in play
+func TestWeaponRegistryReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "weapons.yaml")
+	if err := os.WriteFile(path, []byte(testWeaponsFile), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	wr, err := NewWeaponRegistry(path)
+	if err != nil {
+		t.Fatalf("NewWeaponRegistry failed: %v", err)
+	}
+	defer InstallWeaponDefinitions(nil)
+
+	var pushed []WeaponDefinition
+	wr.OnReload = func(defs []WeaponDefinition) { pushed = defs }
+
+	os.WriteFile(path, []byte(`weapons: [{id: club, name: Club}]`), 0o644)
+	if _, err := wr.Reload(); err == nil {
+		t.Fatal("Expected the invalid file to fail")
+	}
+	if !IsWeapon("trident") || pushed != nil || wr.Status().LastError == "" {
+		t.Error("Expected the previous weapons kept and the error reported")
+	}
+
+	os.Remove(path)
+	if n, err := wr.Reload(); err != nil || n != 0 || IsWeapon("trident") {
+		t.Errorf("Expected a removed file to restore the built-ins, got %d, %v", n, err)
+	}
+
+	if wr, err := NewWeaponRegistry(filepath.Join(t.TempDir(), "missing.yaml")); err != nil || len(wr.Status().Custom) != 0 {
+		t.Errorf("Expected a missing file to mean built-ins only, got %v", err)
+	}
+}
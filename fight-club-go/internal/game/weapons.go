@@ -1,16 +1,24 @@
 package game
 
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"fight-club/internal/config"
+)
+
 // Weapon represents a weapon configuration
 type Weapon struct {
-	ID        string  `json:"id"`
-	Name      string  `json:"name"`
-	MinDamage int     `json:"minDamage"`
-	MaxDamage int     `json:"maxDamage"`
-	Range     float64 `json:"range"`
-	Cooldown  float64 `json:"cooldown"` // seconds
-	Price     int     `json:"price"`
-	Color     string  `json:"color"`
-	Emoji     string  `json:"emoji"`
+	ID        string  `json:"id" yaml:"id"`
+	Name      string  `json:"name" yaml:"name"`
+	MinDamage int     `json:"minDamage" yaml:"min_damage"`
+	MaxDamage int     `json:"maxDamage" yaml:"max_damage"`
+	Range     float64 `json:"range" yaml:"range"`       // Hitbox reach (px)
+	Cooldown  float64 `json:"cooldown" yaml:"cooldown"` // seconds
+	Price     int     `json:"price" yaml:"price"`
+	Color     string  `json:"color" yaml:"color"`
+	Emoji     string  `json:"emoji" yaml:"emoji"`
 }
 
 // Weapons is the map of all available weapons
@@ -121,19 +129,85 @@ var Weapons = map[string]Weapon{
 var defaultFistsWeapon = Weapons["fists"]
 
 // GetWeapon returns a weapon by ID, defaults to fists.
+// Weapons from the weapons file win over built-ins.
 // Uses cached map lookup - O(1) with no allocation.
 func GetWeapon(id string) Weapon {
+	r := installedWeapons.Load()
+	if r != nil {
+		if d, ok := r.defs[id]; ok {
+			return d.Weapon
+		}
+	}
 	if w, ok := Weapons[id]; ok {
 		return w
 	}
+	if r != nil {
+		if d, ok := r.defs["fists"]; ok {
+			return d.Weapon
+		}
+	}
 	return defaultFistsWeapon
 }
 
-// GetAllWeapons returns all weapons as a slice
+// GetAllWeapons returns all weapons (built-in and from the weapons file) as a slice
 func GetAllWeapons() []Weapon {
+	r := installedWeapons.Load()
 	weapons := make([]Weapon, 0, len(Weapons))
-	for _, w := range Weapons {
+	for id, w := range Weapons {
+		if r != nil {
+			if _, replaced := r.defs[id]; replaced {
+				continue
+			}
+		}
 		weapons = append(weapons, w)
 	}
+	if r != nil {
+		for _, id := range r.order {
+			weapons = append(weapons, r.defs[id].Weapon)
+		}
+	}
 	return weapons
 }
+
+// ApplyWeaponOverrides changes built-in weapon stats (weapons section of
+// fight-club.yaml). Call once at startup, before the engine starts.
+func ApplyWeaponOverrides(overrides map[string]config.WeaponOverride) error {
+	for id, o := range overrides {
+		w, ok := Weapons[id]
+		if !ok {
+			valid := make([]string, 0, len(Weapons))
+			for known := range Weapons {
+				valid = append(valid, known)
+			}
+			sort.Strings(valid)
+			return fmt.Errorf("weapons.%s: unknown weapon (valid: %s)", id, strings.Join(valid, ", "))
+		}
+
+		if o.MinDamage != nil {
+			w.MinDamage = *o.MinDamage
+		}
+		if o.MaxDamage != nil {
+			w.MaxDamage = *o.MaxDamage
+		}
+		if o.Range != nil {
+			w.Range = *o.Range
+		}
+		if o.Cooldown != nil {
+			w.Cooldown = *o.Cooldown
+		}
+		if o.Price != nil {
+			w.Price = *o.Price
+		}
+
+		if w.MinDamage > w.MaxDamage {
+			return fmt.Errorf("weapons.%s: min_damage (%d) is greater than max_damage (%d)", id, w.MinDamage, w.MaxDamage)
+		}
+		if w.Range <= 60 {
+			return fmt.Errorf("weapons.%s: range must be > 60 to hit (got %g)", id, w.Range)
+		}
+		Weapons[id] = w
+	}
+
+	defaultFistsWeapon = Weapons["fists"]
+	return nil
+}
@@ -0,0 +1,1315 @@
+// Fight Club admin/control API (gRPC). The REST admin API and this service
+// drive the same engine; this one gives external tools and desktop control
+// apps type-safe clients.
+//
+// Every call needs an API key (created at /api/admin/keys) in the
+// "x-api-key" metadata or as "authorization: Bearer <key>". Keys keep their
+// per-key rate limit.
+//
+// Regenerate the Go code after editing (buf, protoc-gen-go and
+// protoc-gen-go-grpc on PATH):
+//
+//	cd internal/grpcapi && go generate
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: adminpb/admin.proto
+
+package adminpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatusRequest) Reset() {
+	*x = GetStatusRequest{}
+	mi := &file_adminpb_admin_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatusRequest) ProtoMessage() {}
+
+func (x *GetStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_adminpb_admin_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetStatusRequest) Descriptor() ([]byte, []int) {
+	return file_adminpb_admin_proto_rawDescGZIP(), []int{0}
+}
+
+type PauseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PauseRequest) Reset() {
+	*x = PauseRequest{}
+	mi := &file_adminpb_admin_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PauseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseRequest) ProtoMessage() {}
+
+func (x *PauseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_adminpb_admin_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PauseRequest.ProtoReflect.Descriptor instead.
+func (*PauseRequest) Descriptor() ([]byte, []int) {
+	return file_adminpb_admin_proto_rawDescGZIP(), []int{1}
+}
+
+type ResumeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResumeRequest) Reset() {
+	*x = ResumeRequest{}
+	mi := &file_adminpb_admin_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResumeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeRequest) ProtoMessage() {}
+
+func (x *ResumeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_adminpb_admin_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeRequest.ProtoReflect.Descriptor instead.
+func (*ResumeRequest) Descriptor() ([]byte, []int) {
+	return file_adminpb_admin_proto_rawDescGZIP(), []int{2}
+}
+
+type SetArenaRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Preset name ("small", "standard", "large")
+	Preset        string `protobuf:"bytes,1,opt,name=preset,proto3" json:"preset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetArenaRequest) Reset() {
+	*x = SetArenaRequest{}
+	mi := &file_adminpb_admin_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetArenaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetArenaRequest) ProtoMessage() {}
+
+func (x *SetArenaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_adminpb_admin_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetArenaRequest.ProtoReflect.Descriptor instead.
+func (*SetArenaRequest) Descriptor() ([]byte, []int) {
+	return file_adminpb_admin_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SetArenaRequest) GetPreset() string {
+	if x != nil {
+		return x.Preset
+	}
+	return ""
+}
+
+type EngineStatus struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Paused      bool                   `protobuf:"varint,1,opt,name=paused,proto3" json:"paused,omitempty"`
+	Tick        uint64                 `protobuf:"varint,2,opt,name=tick,proto3" json:"tick,omitempty"`
+	PlayerCount int32                  `protobuf:"varint,3,opt,name=player_count,json=playerCount,proto3" json:"player_count,omitempty"`
+	AliveCount  int32                  `protobuf:"varint,4,opt,name=alive_count,json=aliveCount,proto3" json:"alive_count,omitempty"`
+	TotalKills  int32                  `protobuf:"varint,5,opt,name=total_kills,json=totalKills,proto3" json:"total_kills,omitempty"`
+	Arena       *Arena                 `protobuf:"bytes,6,opt,name=arena,proto3" json:"arena,omitempty"`
+	TickStats   *TickStats             `protobuf:"bytes,7,opt,name=tick_stats,json=tickStats,proto3" json:"tick_stats,omitempty"`
+	// Whether the call changed the state (Pause/Resume; false when already in it)
+	Changed       bool `protobuf:"varint,8,opt,name=changed,proto3" json:"changed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EngineStatus) Reset() {
+	*x = EngineStatus{}
+	mi := &file_adminpb_admin_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EngineStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EngineStatus) ProtoMessage() {}
+
+func (x *EngineStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_adminpb_admin_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EngineStatus.ProtoReflect.Descriptor instead.
+func (*EngineStatus) Descriptor() ([]byte, []int) {
+	return file_adminpb_admin_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *EngineStatus) GetPaused() bool {
+	if x != nil {
+		return x.Paused
+	}
+	return false
+}
+
+func (x *EngineStatus) GetTick() uint64 {
+	if x != nil {
+		return x.Tick
+	}
+	return 0
+}
+
+func (x *EngineStatus) GetPlayerCount() int32 {
+	if x != nil {
+		return x.PlayerCount
+	}
+	return 0
+}
+
+func (x *EngineStatus) GetAliveCount() int32 {
+	if x != nil {
+		return x.AliveCount
+	}
+	return 0
+}
+
+func (x *EngineStatus) GetTotalKills() int32 {
+	if x != nil {
+		return x.TotalKills
+	}
+	return 0
+}
+
+func (x *EngineStatus) GetArena() *Arena {
+	if x != nil {
+		return x.Arena
+	}
+	return nil
+}
+
+func (x *EngineStatus) GetTickStats() *TickStats {
+	if x != nil {
+		return x.TickStats
+	}
+	return nil
+}
+
+func (x *EngineStatus) GetChanged() bool {
+	if x != nil {
+		return x.Changed
+	}
+	return false
+}
+
+type Arena struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Label         string                 `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	Width         int32                  `protobuf:"varint,3,opt,name=width,proto3" json:"width,omitempty"`
+	Height        int32                  `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`
+	TickRate      int32                  `protobuf:"varint,5,opt,name=tick_rate,json=tickRate,proto3" json:"tick_rate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Arena) Reset() {
+	*x = Arena{}
+	mi := &file_adminpb_admin_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Arena) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Arena) ProtoMessage() {}
+
+func (x *Arena) ProtoReflect() protoreflect.Message {
+	mi := &file_adminpb_admin_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Arena.ProtoReflect.Descriptor instead.
+func (*Arena) Descriptor() ([]byte, []int) {
+	return file_adminpb_admin_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Arena) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Arena) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *Arena) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *Arena) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *Arena) GetTickRate() int32 {
+	if x != nil {
+		return x.TickRate
+	}
+	return 0
+}
+
+type TickStats struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	TickRate int32                  `protobuf:"varint,1,opt,name=tick_rate,json=tickRate,proto3" json:"tick_rate,omitempty"`
+	AvgMs    float64                `protobuf:"fixed64,2,opt,name=avg_ms,json=avgMs,proto3" json:"avg_ms,omitempty"`
+	MaxMs    float64                `protobuf:"fixed64,3,opt,name=max_ms,json=maxMs,proto3" json:"max_ms,omitempty"`
+	BudgetMs float64                `protobuf:"fixed64,4,opt,name=budget_ms,json=budgetMs,proto3" json:"budget_ms,omitempty"`
+	Dropped  int64                  `protobuf:"varint,5,opt,name=dropped,proto3" json:"dropped,omitempty"`
+	// Load shedding level ("none", "flowfields", "particles", "farai")
+	LoadShedLevel string `protobuf:"bytes,6,opt,name=load_shed_level,json=loadShedLevel,proto3" json:"load_shed_level,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TickStats) Reset() {
+	*x = TickStats{}
+	mi := &file_adminpb_admin_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TickStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TickStats) ProtoMessage() {}
+
+func (x *TickStats) ProtoReflect() protoreflect.Message {
+	mi := &file_adminpb_admin_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TickStats.ProtoReflect.Descriptor instead.
+func (*TickStats) Descriptor() ([]byte, []int) {
+	return file_adminpb_admin_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *TickStats) GetTickRate() int32 {
+	if x != nil {
+		return x.TickRate
+	}
+	return 0
+}
+
+func (x *TickStats) GetAvgMs() float64 {
+	if x != nil {
+		return x.AvgMs
+	}
+	return 0
+}
+
+func (x *TickStats) GetMaxMs() float64 {
+	if x != nil {
+		return x.MaxMs
+	}
+	return 0
+}
+
+func (x *TickStats) GetBudgetMs() float64 {
+	if x != nil {
+		return x.BudgetMs
+	}
+	return 0
+}
+
+func (x *TickStats) GetDropped() int64 {
+	if x != nil {
+		return x.Dropped
+	}
+	return 0
+}
+
+func (x *TickStats) GetLoadShedLevel() string {
+	if x != nil {
+		return x.LoadShedLevel
+	}
+	return ""
+}
+
+type Player struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Display name
+	Name          string  `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Hp            int32   `protobuf:"varint,3,opt,name=hp,proto3" json:"hp,omitempty"`
+	MaxHp         int32   `protobuf:"varint,4,opt,name=max_hp,json=maxHp,proto3" json:"max_hp,omitempty"`
+	Money         int32   `protobuf:"varint,5,opt,name=money,proto3" json:"money,omitempty"`
+	Kills         int32   `protobuf:"varint,6,opt,name=kills,proto3" json:"kills,omitempty"`
+	Deaths        int32   `protobuf:"varint,7,opt,name=deaths,proto3" json:"deaths,omitempty"`
+	Weapon        string  `protobuf:"bytes,8,opt,name=weapon,proto3" json:"weapon,omitempty"`
+	Dead          bool    `protobuf:"varint,9,opt,name=dead,proto3" json:"dead,omitempty"`
+	X             float64 `protobuf:"fixed64,10,opt,name=x,proto3" json:"x,omitempty"`
+	Y             float64 `protobuf:"fixed64,11,opt,name=y,proto3" json:"y,omitempty"`
+	Personality   string  `protobuf:"bytes,12,opt,name=personality,proto3" json:"personality,omitempty"`
+	Rank          string  `protobuf:"bytes,13,opt,name=rank,proto3" json:"rank,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Player) Reset() {
+	*x = Player{}
+	mi := &file_adminpb_admin_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Player) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Player) ProtoMessage() {}
+
+func (x *Player) ProtoReflect() protoreflect.Message {
+	mi := &file_adminpb_admin_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Player.ProtoReflect.Descriptor instead.
+func (*Player) Descriptor() ([]byte, []int) {
+	return file_adminpb_admin_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Player) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Player) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Player) GetHp() int32 {
+	if x != nil {
+		return x.Hp
+	}
+	return 0
+}
+
+func (x *Player) GetMaxHp() int32 {
+	if x != nil {
+		return x.MaxHp
+	}
+	return 0
+}
+
+func (x *Player) GetMoney() int32 {
+	if x != nil {
+		return x.Money
+	}
+	return 0
+}
+
+func (x *Player) GetKills() int32 {
+	if x != nil {
+		return x.Kills
+	}
+	return 0
+}
+
+func (x *Player) GetDeaths() int32 {
+	if x != nil {
+		return x.Deaths
+	}
+	return 0
+}
+
+func (x *Player) GetWeapon() string {
+	if x != nil {
+		return x.Weapon
+	}
+	return ""
+}
+
+func (x *Player) GetDead() bool {
+	if x != nil {
+		return x.Dead
+	}
+	return false
+}
+
+func (x *Player) GetX() float64 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *Player) GetY() float64 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+func (x *Player) GetPersonality() string {
+	if x != nil {
+		return x.Personality
+	}
+	return ""
+}
+
+func (x *Player) GetRank() string {
+	if x != nil {
+		return x.Rank
+	}
+	return ""
+}
+
+type ListPlayersRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only fighters who are alive
+	AliveOnly     bool `protobuf:"varint,1,opt,name=alive_only,json=aliveOnly,proto3" json:"alive_only,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPlayersRequest) Reset() {
+	*x = ListPlayersRequest{}
+	mi := &file_adminpb_admin_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPlayersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPlayersRequest) ProtoMessage() {}
+
+func (x *ListPlayersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_adminpb_admin_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPlayersRequest.ProtoReflect.Descriptor instead.
+func (*ListPlayersRequest) Descriptor() ([]byte, []int) {
+	return file_adminpb_admin_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListPlayersRequest) GetAliveOnly() bool {
+	if x != nil {
+		return x.AliveOnly
+	}
+	return false
+}
+
+type ListPlayersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Players       []*Player              `protobuf:"bytes,1,rep,name=players,proto3" json:"players,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPlayersResponse) Reset() {
+	*x = ListPlayersResponse{}
+	mi := &file_adminpb_admin_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPlayersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPlayersResponse) ProtoMessage() {}
+
+func (x *ListPlayersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_adminpb_admin_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPlayersResponse.ProtoReflect.Descriptor instead.
+func (*ListPlayersResponse) Descriptor() ([]byte, []int) {
+	return file_adminpb_admin_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListPlayersResponse) GetPlayers() []*Player {
+	if x != nil {
+		return x.Players
+	}
+	return nil
+}
+
+type GetPlayerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPlayerRequest) Reset() {
+	*x = GetPlayerRequest{}
+	mi := &file_adminpb_admin_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPlayerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPlayerRequest) ProtoMessage() {}
+
+func (x *GetPlayerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_adminpb_admin_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPlayerRequest.ProtoReflect.Descriptor instead.
+func (*GetPlayerRequest) Descriptor() ([]byte, []int) {
+	return file_adminpb_admin_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetPlayerRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+type JoinPlayerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	ProfilePic    string                 `protobuf:"bytes,2,opt,name=profile_pic,json=profilePic,proto3" json:"profile_pic,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JoinPlayerRequest) Reset() {
+	*x = JoinPlayerRequest{}
+	mi := &file_adminpb_admin_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JoinPlayerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinPlayerRequest) ProtoMessage() {}
+
+func (x *JoinPlayerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_adminpb_admin_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinPlayerRequest.ProtoReflect.Descriptor instead.
+func (*JoinPlayerRequest) Descriptor() ([]byte, []int) {
+	return file_adminpb_admin_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *JoinPlayerRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *JoinPlayerRequest) GetProfilePic() string {
+	if x != nil {
+		return x.ProfilePic
+	}
+	return ""
+}
+
+type HealPlayerRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Username string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	// HP to heal (default 20)
+	Amount        int32 `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HealPlayerRequest) Reset() {
+	*x = HealPlayerRequest{}
+	mi := &file_adminpb_admin_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HealPlayerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HealPlayerRequest) ProtoMessage() {}
+
+func (x *HealPlayerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_adminpb_admin_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HealPlayerRequest.ProtoReflect.Descriptor instead.
+func (*HealPlayerRequest) Descriptor() ([]byte, []int) {
+	return file_adminpb_admin_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *HealPlayerRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *HealPlayerRequest) GetAmount() int32 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+type HealPlayerResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Healed        bool                   `protobuf:"varint,1,opt,name=healed,proto3" json:"healed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HealPlayerResponse) Reset() {
+	*x = HealPlayerResponse{}
+	mi := &file_adminpb_admin_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HealPlayerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HealPlayerResponse) ProtoMessage() {}
+
+func (x *HealPlayerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_adminpb_admin_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HealPlayerResponse.ProtoReflect.Descriptor instead.
+func (*HealPlayerResponse) Descriptor() ([]byte, []int) {
+	return file_adminpb_admin_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *HealPlayerResponse) GetHealed() bool {
+	if x != nil {
+		return x.Healed
+	}
+	return false
+}
+
+type ModeratePlayerRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Username string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	// "kick", "freeze" or "strip"
+	Action        string `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModeratePlayerRequest) Reset() {
+	*x = ModeratePlayerRequest{}
+	mi := &file_adminpb_admin_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModeratePlayerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModeratePlayerRequest) ProtoMessage() {}
+
+func (x *ModeratePlayerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_adminpb_admin_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModeratePlayerRequest.ProtoReflect.Descriptor instead.
+func (*ModeratePlayerRequest) Descriptor() ([]byte, []int) {
+	return file_adminpb_admin_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ModeratePlayerRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *ModeratePlayerRequest) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+type ModeratePlayerResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModeratePlayerResponse) Reset() {
+	*x = ModeratePlayerResponse{}
+	mi := &file_adminpb_admin_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModeratePlayerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModeratePlayerResponse) ProtoMessage() {}
+
+func (x *ModeratePlayerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_adminpb_admin_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModeratePlayerResponse.ProtoReflect.Descriptor instead.
+func (*ModeratePlayerResponse) Descriptor() ([]byte, []int) {
+	return file_adminpb_admin_proto_rawDescGZIP(), []int{15}
+}
+
+type StartStreamRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartStreamRequest) Reset() {
+	*x = StartStreamRequest{}
+	mi := &file_adminpb_admin_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartStreamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartStreamRequest) ProtoMessage() {}
+
+func (x *StartStreamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_adminpb_admin_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartStreamRequest.ProtoReflect.Descriptor instead.
+func (*StartStreamRequest) Descriptor() ([]byte, []int) {
+	return file_adminpb_admin_proto_rawDescGZIP(), []int{16}
+}
+
+type StopStreamRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StopStreamRequest) Reset() {
+	*x = StopStreamRequest{}
+	mi := &file_adminpb_admin_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StopStreamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopStreamRequest) ProtoMessage() {}
+
+func (x *StopStreamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_adminpb_admin_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopStreamRequest.ProtoReflect.Descriptor instead.
+func (*StopStreamRequest) Descriptor() ([]byte, []int) {
+	return file_adminpb_admin_proto_rawDescGZIP(), []int{17}
+}
+
+type GetStreamStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStreamStatusRequest) Reset() {
+	*x = GetStreamStatusRequest{}
+	mi := &file_adminpb_admin_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStreamStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStreamStatusRequest) ProtoMessage() {}
+
+func (x *GetStreamStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_adminpb_admin_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStreamStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetStreamStatusRequest) Descriptor() ([]byte, []int) {
+	return file_adminpb_admin_proto_rawDescGZIP(), []int{18}
+}
+
+type StreamStatus struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Streaming bool                   `protobuf:"varint,1,opt,name=streaming,proto3" json:"streaming,omitempty"`
+	// Streamer stats (fps, bitrate, frames...; the same as /api/stream/status)
+	Stats         *structpb.Struct `protobuf:"bytes,2,opt,name=stats,proto3" json:"stats,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamStatus) Reset() {
+	*x = StreamStatus{}
+	mi := &file_adminpb_admin_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamStatus) ProtoMessage() {}
+
+func (x *StreamStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_adminpb_admin_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamStatus.ProtoReflect.Descriptor instead.
+func (*StreamStatus) Descriptor() ([]byte, []int) {
+	return file_adminpb_admin_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *StreamStatus) GetStreaming() bool {
+	if x != nil {
+		return x.Streaming
+	}
+	return false
+}
+
+func (x *StreamStatus) GetStats() *structpb.Struct {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+var File_adminpb_admin_proto protoreflect.FileDescriptor
+
+const file_adminpb_admin_proto_rawDesc = "" +
+	"\n" +
+	"\x13adminpb/admin.proto\x12\x12fightclub.admin.v1\x1a\x1cgoogle/protobuf/struct.proto\"\x12\n" +
+	"\x10GetStatusRequest\"\x0e\n" +
+	"\fPauseRequest\"\x0f\n" +
+	"\rResumeRequest\")\n" +
+	"\x0fSetArenaRequest\x12\x16\n" +
+	"\x06preset\x18\x01 \x01(\tR\x06preset\"\xa8\x02\n" +
+	"\fEngineStatus\x12\x16\n" +
+	"\x06paused\x18\x01 \x01(\bR\x06paused\x12\x12\n" +
+	"\x04tick\x18\x02 \x01(\x04R\x04tick\x12!\n" +
+	"\fplayer_count\x18\x03 \x01(\x05R\vplayerCount\x12\x1f\n" +
+	"\valive_count\x18\x04 \x01(\x05R\n" +
+	"aliveCount\x12\x1f\n" +
+	"\vtotal_kills\x18\x05 \x01(\x05R\n" +
+	"totalKills\x12/\n" +
+	"\x05arena\x18\x06 \x01(\v2\x19.fightclub.admin.v1.ArenaR\x05arena\x12<\n" +
+	"\n" +
+	"tick_stats\x18\a \x01(\v2\x1d.fightclub.admin.v1.TickStatsR\ttickStats\x12\x18\n" +
+	"\achanged\x18\b \x01(\bR\achanged\"|\n" +
+	"\x05Arena\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05label\x18\x02 \x01(\tR\x05label\x12\x14\n" +
+	"\x05width\x18\x03 \x01(\x05R\x05width\x12\x16\n" +
+	"\x06height\x18\x04 \x01(\x05R\x06height\x12\x1b\n" +
+	"\ttick_rate\x18\x05 \x01(\x05R\btickRate\"\xb5\x01\n" +
+	"\tTickStats\x12\x1b\n" +
+	"\ttick_rate\x18\x01 \x01(\x05R\btickRate\x12\x15\n" +
+	"\x06avg_ms\x18\x02 \x01(\x01R\x05avgMs\x12\x15\n" +
+	"\x06max_ms\x18\x03 \x01(\x01R\x05maxMs\x12\x1b\n" +
+	"\tbudget_ms\x18\x04 \x01(\x01R\bbudgetMs\x12\x18\n" +
+	"\adropped\x18\x05 \x01(\x03R\adropped\x12&\n" +
+	"\x0fload_shed_level\x18\x06 \x01(\tR\rloadShedLevel\"\x95\x02\n" +
+	"\x06Player\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x0e\n" +
+	"\x02hp\x18\x03 \x01(\x05R\x02hp\x12\x15\n" +
+	"\x06max_hp\x18\x04 \x01(\x05R\x05maxHp\x12\x14\n" +
+	"\x05money\x18\x05 \x01(\x05R\x05money\x12\x14\n" +
+	"\x05kills\x18\x06 \x01(\x05R\x05kills\x12\x16\n" +
+	"\x06deaths\x18\a \x01(\x05R\x06deaths\x12\x16\n" +
+	"\x06weapon\x18\b \x01(\tR\x06weapon\x12\x12\n" +
+	"\x04dead\x18\t \x01(\bR\x04dead\x12\f\n" +
+	"\x01x\x18\n" +
+	" \x01(\x01R\x01x\x12\f\n" +
+	"\x01y\x18\v \x01(\x01R\x01y\x12 \n" +
+	"\vpersonality\x18\f \x01(\tR\vpersonality\x12\x12\n" +
+	"\x04rank\x18\r \x01(\tR\x04rank\"3\n" +
+	"\x12ListPlayersRequest\x12\x1d\n" +
+	"\n" +
+	"alive_only\x18\x01 \x01(\bR\taliveOnly\"K\n" +
+	"\x13ListPlayersResponse\x124\n" +
+	"\aplayers\x18\x01 \x03(\v2\x1a.fightclub.admin.v1.PlayerR\aplayers\".\n" +
+	"\x10GetPlayerRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\"P\n" +
+	"\x11JoinPlayerRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x1f\n" +
+	"\vprofile_pic\x18\x02 \x01(\tR\n" +
+	"profilePic\"G\n" +
+	"\x11HealPlayerRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x05R\x06amount\",\n" +
+	"\x12HealPlayerResponse\x12\x16\n" +
+	"\x06healed\x18\x01 \x01(\bR\x06healed\"K\n" +
+	"\x15ModeratePlayerRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x16\n" +
+	"\x06action\x18\x02 \x01(\tR\x06action\"\x18\n" +
+	"\x16ModeratePlayerResponse\"\x14\n" +
+	"\x12StartStreamRequest\"\x13\n" +
+	"\x11StopStreamRequest\"\x18\n" +
+	"\x16GetStreamStatusRequest\"[\n" +
+	"\fStreamStatus\x12\x1c\n" +
+	"\tstreaming\x18\x01 \x01(\bR\tstreaming\x12-\n" +
+	"\x05stats\x18\x02 \x01(\v2\x17.google.protobuf.StructR\x05stats2\xd3\x02\n" +
+	"\rEngineService\x12S\n" +
+	"\tGetStatus\x12$.fightclub.admin.v1.GetStatusRequest\x1a .fightclub.admin.v1.EngineStatus\x12K\n" +
+	"\x05Pause\x12 .fightclub.admin.v1.PauseRequest\x1a .fightclub.admin.v1.EngineStatus\x12M\n" +
+	"\x06Resume\x12!.fightclub.admin.v1.ResumeRequest\x1a .fightclub.admin.v1.EngineStatus\x12Q\n" +
+	"\bSetArena\x12#.fightclub.admin.v1.SetArenaRequest\x1a .fightclub.admin.v1.EngineStatus2\xd5\x03\n" +
+	"\rPlayerService\x12^\n" +
+	"\vListPlayers\x12&.fightclub.admin.v1.ListPlayersRequest\x1a'.fightclub.admin.v1.ListPlayersResponse\x12M\n" +
+	"\tGetPlayer\x12$.fightclub.admin.v1.GetPlayerRequest\x1a\x1a.fightclub.admin.v1.Player\x12O\n" +
+	"\n" +
+	"JoinPlayer\x12%.fightclub.admin.v1.JoinPlayerRequest\x1a\x1a.fightclub.admin.v1.Player\x12[\n" +
+	"\n" +
+	"HealPlayer\x12%.fightclub.admin.v1.HealPlayerRequest\x1a&.fightclub.admin.v1.HealPlayerResponse\x12g\n" +
+	"\x0eModeratePlayer\x12).fightclub.admin.v1.ModeratePlayerRequest\x1a*.fightclub.admin.v1.ModeratePlayerResponse2\xa0\x02\n" +
+	"\rStreamService\x12W\n" +
+	"\vStartStream\x12&.fightclub.admin.v1.StartStreamRequest\x1a .fightclub.admin.v1.StreamStatus\x12U\n" +
+	"\n" +
+	"StopStream\x12%.fightclub.admin.v1.StopStreamRequest\x1a .fightclub.admin.v1.StreamStatus\x12_\n" +
+	"\x0fGetStreamStatus\x12*.fightclub.admin.v1.GetStreamStatusRequest\x1a .fightclub.admin.v1.StreamStatusB%Z#fight-club/internal/grpcapi/adminpbb\x06proto3"
+
+var (
+	file_adminpb_admin_proto_rawDescOnce sync.Once
+	file_adminpb_admin_proto_rawDescData []byte
+)
+
+func file_adminpb_admin_proto_rawDescGZIP() []byte {
+	file_adminpb_admin_proto_rawDescOnce.Do(func() {
+		file_adminpb_admin_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_adminpb_admin_proto_rawDesc), len(file_adminpb_admin_proto_rawDesc)))
+	})
+	return file_adminpb_admin_proto_rawDescData
+}
+
+var file_adminpb_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
+var file_adminpb_admin_proto_goTypes = []any{
+	(*GetStatusRequest)(nil),       // 0: fightclub.admin.v1.GetStatusRequest
+	(*PauseRequest)(nil),           // 1: fightclub.admin.v1.PauseRequest
+	(*ResumeRequest)(nil),          // 2: fightclub.admin.v1.ResumeRequest
+	(*SetArenaRequest)(nil),        // 3: fightclub.admin.v1.SetArenaRequest
+	(*EngineStatus)(nil),           // 4: fightclub.admin.v1.EngineStatus
+	(*Arena)(nil),                  // 5: fightclub.admin.v1.Arena
+	(*TickStats)(nil),              // 6: fightclub.admin.v1.TickStats
+	(*Player)(nil),                 // 7: fightclub.admin.v1.Player
+	(*ListPlayersRequest)(nil),     // 8: fightclub.admin.v1.ListPlayersRequest
+	(*ListPlayersResponse)(nil),    // 9: fightclub.admin.v1.ListPlayersResponse
+	(*GetPlayerRequest)(nil),       // 10: fightclub.admin.v1.GetPlayerRequest
+	(*JoinPlayerRequest)(nil),      // 11: fightclub.admin.v1.JoinPlayerRequest
+	(*HealPlayerRequest)(nil),      // 12: fightclub.admin.v1.HealPlayerRequest
+	(*HealPlayerResponse)(nil),     // 13: fightclub.admin.v1.HealPlayerResponse
+	(*ModeratePlayerRequest)(nil),  // 14: fightclub.admin.v1.ModeratePlayerRequest
+	(*ModeratePlayerResponse)(nil), // 15: fightclub.admin.v1.ModeratePlayerResponse
+	(*StartStreamRequest)(nil),     // 16: fightclub.admin.v1.StartStreamRequest
+	(*StopStreamRequest)(nil),      // 17: fightclub.admin.v1.StopStreamRequest
+	(*GetStreamStatusRequest)(nil), // 18: fightclub.admin.v1.GetStreamStatusRequest
+	(*StreamStatus)(nil),           // 19: fightclub.admin.v1.StreamStatus
+	(*structpb.Struct)(nil),        // 20: google.protobuf.Struct
+}
+var file_adminpb_admin_proto_depIdxs = []int32{
+	5,  // 0: fightclub.admin.v1.EngineStatus.arena:type_name -> fightclub.admin.v1.Arena
+	6,  // 1: fightclub.admin.v1.EngineStatus.tick_stats:type_name -> fightclub.admin.v1.TickStats
+	7,  // 2: fightclub.admin.v1.ListPlayersResponse.players:type_name -> fightclub.admin.v1.Player
+	20, // 3: fightclub.admin.v1.StreamStatus.stats:type_name -> google.protobuf.Struct
+	0,  // 4: fightclub.admin.v1.EngineService.GetStatus:input_type -> fightclub.admin.v1.GetStatusRequest
+	1,  // 5: fightclub.admin.v1.EngineService.Pause:input_type -> fightclub.admin.v1.PauseRequest
+	2,  // 6: fightclub.admin.v1.EngineService.Resume:input_type -> fightclub.admin.v1.ResumeRequest
+	3,  // 7: fightclub.admin.v1.EngineService.SetArena:input_type -> fightclub.admin.v1.SetArenaRequest
+	8,  // 8: fightclub.admin.v1.PlayerService.ListPlayers:input_type -> fightclub.admin.v1.ListPlayersRequest
+	10, // 9: fightclub.admin.v1.PlayerService.GetPlayer:input_type -> fightclub.admin.v1.GetPlayerRequest
+	11, // 10: fightclub.admin.v1.PlayerService.JoinPlayer:input_type -> fightclub.admin.v1.JoinPlayerRequest
+	12, // 11: fightclub.admin.v1.PlayerService.HealPlayer:input_type -> fightclub.admin.v1.HealPlayerRequest
+	14, // 12: fightclub.admin.v1.PlayerService.ModeratePlayer:input_type -> fightclub.admin.v1.ModeratePlayerRequest
+	16, // 13: fightclub.admin.v1.StreamService.StartStream:input_type -> fightclub.admin.v1.StartStreamRequest
+	17, // 14: fightclub.admin.v1.StreamService.StopStream:input_type -> fightclub.admin.v1.StopStreamRequest
+	18, // 15: fightclub.admin.v1.StreamService.GetStreamStatus:input_type -> fightclub.admin.v1.GetStreamStatusRequest
+	4,  // 16: fightclub.admin.v1.EngineService.GetStatus:output_type -> fightclub.admin.v1.EngineStatus
+	4,  // 17: fightclub.admin.v1.EngineService.Pause:output_type -> fightclub.admin.v1.EngineStatus
+	4,  // 18: fightclub.admin.v1.EngineService.Resume:output_type -> fightclub.admin.v1.EngineStatus
+	4,  // 19: fightclub.admin.v1.EngineService.SetArena:output_type -> fightclub.admin.v1.EngineStatus
+	9,  // 20: fightclub.admin.v1.PlayerService.ListPlayers:output_type -> fightclub.admin.v1.ListPlayersResponse
+	7,  // 21: fightclub.admin.v1.PlayerService.GetPlayer:output_type -> fightclub.admin.v1.Player
+	7,  // 22: fightclub.admin.v1.PlayerService.JoinPlayer:output_type -> fightclub.admin.v1.Player
+	13, // 23: fightclub.admin.v1.PlayerService.HealPlayer:output_type -> fightclub.admin.v1.HealPlayerResponse
+	15, // 24: fightclub.admin.v1.PlayerService.ModeratePlayer:output_type -> fightclub.admin.v1.ModeratePlayerResponse
+	19, // 25: fightclub.admin.v1.StreamService.StartStream:output_type -> fightclub.admin.v1.StreamStatus
+	19, // 26: fightclub.admin.v1.StreamService.StopStream:output_type -> fightclub.admin.v1.StreamStatus
+	19, // 27: fightclub.admin.v1.StreamService.GetStreamStatus:output_type -> fightclub.admin.v1.StreamStatus
+	16, // [16:28] is the sub-list for method output_type
+	4,  // [4:16] is the sub-list for method input_type
+	4,  // [4:4] is the sub-list for extension type_name
+	4,  // [4:4] is the sub-list for extension extendee
+	0,  // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_adminpb_admin_proto_init() }
+func file_adminpb_admin_proto_init() {
+	if File_adminpb_admin_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_adminpb_admin_proto_rawDesc), len(file_adminpb_admin_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   20,
+			NumExtensions: 0,
+			NumServices:   3,
+		},
+		GoTypes:           file_adminpb_admin_proto_goTypes,
+		DependencyIndexes: file_adminpb_admin_proto_depIdxs,
+		MessageInfos:      file_adminpb_admin_proto_msgTypes,
+	}.Build()
+	File_adminpb_admin_proto = out.File
+	file_adminpb_admin_proto_goTypes = nil
+	file_adminpb_admin_proto_depIdxs = nil
+}
@@ -0,0 +1,180 @@
+// Package grpcapi serves the admin/control API over gRPC alongside the REST
+// API: engine control, player management and stream control for external
+// tools and desktop control apps (see adminpb/admin.proto).
+package grpcapi
+
+//go:generate buf generate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"strings"
+
+	"fight-club/internal/api"
+	"fight-club/internal/game"
+	"fight-club/internal/grpcapi/adminpb"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// EngineInterface defines the game engine methods used by the gRPC API
+// (a superset of api.EngineInterface)
+type EngineInterface interface {
+	GetSnapshot() *game.GameSnapshot
+	GetTickStats() game.TickStats
+	AddPlayer(name string, opts game.PlayerOptions) *game.Player
+	GetPlayer(name string) *game.Player
+	HealPlayer(name string, amount int) bool
+	Moderate(moderatorName, targetName string, action game.ModAction) error
+	IsPaused() bool
+	Pause() bool
+	Resume() bool
+	ArenaPreset() game.ArenaPreset
+	SetArenaPreset(name string) error
+}
+
+// Config contains the dependencies of the gRPC API
+type Config struct {
+	// Engine is the game engine (required)
+	Engine EngineInterface
+
+	// Streamer is the stream manager (required)
+	Streamer api.StreamerInterface
+
+	// APIKeys authenticates every call (required): keys are created at
+	// /api/admin/keys and keep their per-key rate limit. Calls that change
+	// the game or stream need a key with api.APIKeyScopeAdmin.
+	APIKeys *api.APIKeyStore
+
+	// TLS encrypts connections (nil = plaintext, for loopback binds only;
+	// see ListenAddr)
+	TLS credentials.TransportCredentials
+}
+
+// Server is the gRPC admin/control API server
+type Server struct {
+	grpc *grpc.Server
+}
+
+// NewServer creates the gRPC server with every service registered.
+// Nothing listens until Serve is called.
+func NewServer(cfg Config) *Server {
+	auth := &authenticator{keys: cfg.APIKeys}
+	opts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(auth.unary),
+		grpc.ChainStreamInterceptor(auth.stream),
+	}
+	if cfg.TLS != nil {
+		opts = append(opts, grpc.Creds(cfg.TLS))
+	}
+	s := grpc.NewServer(opts...)
+	adminpb.RegisterEngineServiceServer(s, &engineService{engine: cfg.Engine})
+	adminpb.RegisterPlayerServiceServer(s, &playerService{engine: cfg.Engine})
+	adminpb.RegisterStreamServiceServer(s, &streamService{streamer: cfg.Streamer})
+	return &Server{grpc: s}
+}
+
+// Serve accepts gRPC connections on listener until Stop
+func (s *Server) Serve(listener net.Listener) error {
+	log.Printf("🛰️ gRPC admin API on %s", listener.Addr())
+	return s.grpc.Serve(listener)
+}
+
+// Stop waits for in-flight calls to finish, then closes the listener
+func (s *Server) Stop() {
+	s.grpc.GracefulStop()
+}
+
+// ListenAddr returns the address to listen on. API keys travel in the call
+// metadata, so without TLS the API only listens on loopback: a bare port
+// (":50051") binds 127.0.0.1, and any other host is an error.
+func ListenAddr(addr string, tls bool) (string, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "", err
+	}
+	if tls {
+		return addr, nil
+	}
+	if host == "" {
+		return net.JoinHostPort("127.0.0.1", port), nil
+	}
+	if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+		return "", fmt.Errorf("%s is not a loopback address: set GRPC_TLS_CERT and GRPC_TLS_KEY to serve it (API keys would cross the network in clear text)", addr)
+	}
+	return addr, nil
+}
+
+// adminMethods change the game or the stream: they need a key with
+// api.APIKeyScopeAdmin (integration keys only join, heal and read status)
+var adminMethods = map[string]bool{
+	adminpb.EngineService_Pause_FullMethodName:          true,
+	adminpb.EngineService_Resume_FullMethodName:         true,
+	adminpb.EngineService_SetArena_FullMethodName:       true,
+	adminpb.PlayerService_ModeratePlayer_FullMethodName: true,
+	adminpb.StreamService_StartStream_FullMethodName:    true,
+	adminpb.StreamService_StopStream_FullMethodName:     true,
+}
+
+// authenticator checks the API key of every call
+type authenticator struct {
+	keys *api.APIKeyStore
+}
+
+// callerKey is the context key of the authenticated API key's name
+type callerKey struct{}
+
+// caller returns the name of the API key that made the call
+func caller(ctx context.Context) string {
+	name, _ := ctx.Value(callerKey{}).(string)
+	return name
+}
+
+// authenticate checks the key in the call metadata ("x-api-key" or
+// "authorization: Bearer <key>") and its scope for method
+func (a *authenticator) authenticate(ctx context.Context, method string) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	var key string
+	if v := md.Get(strings.ToLower(api.APIKeyHeader)); len(v) > 0 {
+		key = strings.TrimSpace(v[0])
+	} else if v := md.Get("authorization"); len(v) > 0 && strings.HasPrefix(v[0], "Bearer ") {
+		key = strings.TrimSpace(strings.TrimPrefix(v[0], "Bearer "))
+	}
+	if key == "" {
+		return nil, status.Error(codes.Unauthenticated, "API key required")
+	}
+
+	k, err := a.keys.Authenticate(key)
+	switch {
+	case errors.Is(err, api.ErrAPIKeyRateLimited):
+		return nil, status.Error(codes.ResourceExhausted, err.Error())
+	case err != nil:
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if adminMethods[method] && !k.HasScope(api.APIKeyScopeAdmin) {
+		return nil, status.Errorf(codes.PermissionDenied, "API key %q lacks the %s scope", k.Name, api.APIKeyScopeAdmin)
+	}
+	return context.WithValue(ctx, callerKey{}, k.Name), nil
+}
+
+func (a *authenticator) unary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, err := a.authenticate(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (a *authenticator) stream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if _, err := a.authenticate(ss.Context(), info.FullMethod); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
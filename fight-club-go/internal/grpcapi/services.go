@@ -0,0 +1,229 @@
+package grpcapi
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	"fight-club/internal/api"
+	"fight-club/internal/game"
+	"fight-club/internal/grpcapi/adminpb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// engineService implements adminpb.EngineServiceServer
+type engineService struct {
+	adminpb.UnimplementedEngineServiceServer
+	engine EngineInterface
+}
+
+func (s *engineService) GetStatus(ctx context.Context, _ *adminpb.GetStatusRequest) (*adminpb.EngineStatus, error) {
+	return s.status(false), nil
+}
+
+func (s *engineService) Pause(ctx context.Context, _ *adminpb.PauseRequest) (*adminpb.EngineStatus, error) {
+	log.Printf("⏸️ Pause requested via gRPC (%s)", caller(ctx))
+	return s.status(s.engine.Pause()), nil
+}
+
+func (s *engineService) Resume(ctx context.Context, _ *adminpb.ResumeRequest) (*adminpb.EngineStatus, error) {
+	log.Printf("▶️ Resume requested via gRPC (%s)", caller(ctx))
+	return s.status(s.engine.Resume()), nil
+}
+
+func (s *engineService) SetArena(ctx context.Context, req *adminpb.SetArenaRequest) (*adminpb.EngineStatus, error) {
+	if req.GetPreset() == "" {
+		return nil, status.Error(codes.InvalidArgument, "preset is required")
+	}
+	if err := s.engine.SetArenaPreset(req.GetPreset()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return s.status(true), nil
+}
+
+// status builds the engine status from the latest snapshot and tick stats
+func (s *engineService) status(changed bool) *adminpb.EngineStatus {
+	arena := s.engine.ArenaPreset()
+	tick := s.engine.GetTickStats()
+	st := &adminpb.EngineStatus{
+		Paused:  s.engine.IsPaused(),
+		Changed: changed,
+		Arena: &adminpb.Arena{
+			Name:     arena.Name,
+			Label:    arena.Label,
+			Width:    int32(arena.Width),
+			Height:   int32(arena.Height),
+			TickRate: int32(arena.TickRate),
+		},
+		TickStats: &adminpb.TickStats{
+			TickRate:      int32(tick.TickRate),
+			AvgMs:         tick.AvgMs,
+			MaxMs:         tick.MaxMs,
+			BudgetMs:      tick.BudgetMs,
+			Dropped:       tick.Dropped,
+			LoadShedLevel: tick.LoadShed.Level,
+		},
+	}
+	if snap := s.engine.GetSnapshot(); snap != nil {
+		st.Tick = snap.TickNumber
+		st.PlayerCount = int32(snap.PlayerCount)
+		st.AliveCount = int32(snap.AliveCount)
+		st.TotalKills = int32(snap.TotalKills)
+	}
+	return st
+}
+
+// playerService implements adminpb.PlayerServiceServer
+type playerService struct {
+	adminpb.UnimplementedPlayerServiceServer
+	engine EngineInterface
+}
+
+func (s *playerService) ListPlayers(ctx context.Context, req *adminpb.ListPlayersRequest) (*adminpb.ListPlayersResponse, error) {
+	resp := &adminpb.ListPlayersResponse{}
+	snap := s.engine.GetSnapshot()
+	if snap == nil {
+		return resp, nil
+	}
+	for i := range snap.Players {
+		p := &snap.Players[i]
+		if req.GetAliveOnly() && p.IsDead {
+			continue
+		}
+		resp.Players = append(resp.Players, playerFromSnapshot(p))
+	}
+	return resp, nil
+}
+
+func (s *playerService) GetPlayer(ctx context.Context, req *adminpb.GetPlayerRequest) (*adminpb.Player, error) {
+	player := s.engine.GetPlayer(req.GetUsername())
+	if player == nil {
+		return nil, status.Errorf(codes.NotFound, "'%s' is not in the arena", req.GetUsername())
+	}
+	// The snapshot copy is consistent; fighters who joined this tick aren't in it yet
+	if snap := s.engine.GetSnapshot(); snap != nil {
+		for i := range snap.Players {
+			if snap.Players[i].ID == player.ID {
+				return playerFromSnapshot(&snap.Players[i]), nil
+			}
+		}
+	}
+	return playerFromGame(player), nil
+}
+
+func (s *playerService) JoinPlayer(ctx context.Context, req *adminpb.JoinPlayerRequest) (*adminpb.Player, error) {
+	name := game.SanitizeName(req.GetUsername())
+	if name == "" {
+		return nil, status.Error(codes.InvalidArgument, "username is required")
+	}
+	player := s.engine.AddPlayer(name, game.PlayerOptions{ProfilePic: req.GetProfilePic()})
+	if player == nil {
+		return nil, status.Error(codes.ResourceExhausted, "player limit reached")
+	}
+	return playerFromGame(player), nil
+}
+
+func (s *playerService) HealPlayer(ctx context.Context, req *adminpb.HealPlayerRequest) (*adminpb.HealPlayerResponse, error) {
+	amount := int(req.GetAmount())
+	if amount <= 0 {
+		amount = 20
+	}
+	return &adminpb.HealPlayerResponse{Healed: s.engine.HealPlayer(req.GetUsername(), amount)}, nil
+}
+
+func (s *playerService) ModeratePlayer(ctx context.Context, req *adminpb.ModeratePlayerRequest) (*adminpb.ModeratePlayerResponse, error) {
+	action := game.ModAction(req.GetAction())
+	switch action {
+	case game.ModKick, game.ModFreeze, game.ModStrip:
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown action '%s' (kick, freeze or strip)", req.GetAction())
+	}
+	if s.engine.GetPlayer(req.GetUsername()) == nil {
+		return nil, status.Errorf(codes.NotFound, "'%s' is not in the arena", req.GetUsername())
+	}
+	if err := s.engine.Moderate("api:"+caller(ctx), req.GetUsername(), action); err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return &adminpb.ModeratePlayerResponse{}, nil
+}
+
+// playerFromSnapshot converts a snapshot fighter
+func playerFromSnapshot(p *game.PlayerSnapshot) *adminpb.Player {
+	return &adminpb.Player{
+		Id:          p.ID,
+		Name:        p.Name,
+		Hp:          int32(p.HP),
+		MaxHp:       int32(p.MaxHP),
+		Money:       int32(p.Money),
+		Kills:       int32(p.Kills),
+		Deaths:      int32(p.Deaths),
+		Weapon:      p.Weapon,
+		Dead:        p.IsDead,
+		X:           p.X,
+		Y:           p.Y,
+		Personality: p.Personality,
+		Rank:        p.Rank,
+	}
+}
+
+// playerFromGame converts a live fighter (like the REST join response)
+func playerFromGame(p *game.Player) *adminpb.Player {
+	return &adminpb.Player{
+		Id:          p.ID,
+		Name:        p.DisplayName,
+		Hp:          int32(p.HP),
+		MaxHp:       int32(p.MaxHP),
+		Money:       int32(p.Money),
+		Kills:       int32(p.Kills),
+		Deaths:      int32(p.Deaths),
+		Weapon:      p.Weapon,
+		Dead:        p.IsDead,
+		X:           p.X,
+		Y:           p.Y,
+		Personality: p.Personality,
+		Rank:        p.Rank,
+	}
+}
+
+// streamService implements adminpb.StreamServiceServer
+type streamService struct {
+	adminpb.UnimplementedStreamServiceServer
+	streamer api.StreamerInterface
+}
+
+func (s *streamService) StartStream(ctx context.Context, _ *adminpb.StartStreamRequest) (*adminpb.StreamStatus, error) {
+	log.Printf("📡 Stream start requested via gRPC (%s)", caller(ctx))
+	if err := s.streamer.Start(); err != nil {
+		log.Printf("❌ Stream start failed: %v", err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return s.status()
+}
+
+func (s *streamService) StopStream(ctx context.Context, _ *adminpb.StopStreamRequest) (*adminpb.StreamStatus, error) {
+	log.Printf("📡 Stream stop requested via gRPC (%s)", caller(ctx))
+	s.streamer.Stop()
+	return s.status()
+}
+
+func (s *streamService) GetStreamStatus(ctx context.Context, _ *adminpb.GetStreamStatusRequest) (*adminpb.StreamStatus, error) {
+	return s.status()
+}
+
+// status returns the streamer stats (round-tripped through JSON like the
+// REST response, so any value type converts)
+func (s *streamService) status() (*adminpb.StreamStatus, error) {
+	data, err := json.Marshal(s.streamer.GetStats())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	stats := &structpb.Struct{}
+	if err := protojson.Unmarshal(data, stats); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &adminpb.StreamStatus{Streaming: s.streamer.IsStreaming(), Stats: stats}, nil
+}
@@ -0,0 +1,193 @@
+// Package highlights clips big arena moments (multikills, boss kills) on Kick
+// and keeps the clip links.
+package highlights
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"fight-club/internal/config"
+	"fight-club/internal/game"
+)
+
+// Config is an alias for config.KickClipsConfig (SSOT)
+type Config = config.KickClipsConfig
+
+// MaxClips is how many clip links are kept (oldest dropped first)
+const MaxClips = 100
+
+// Clipper creates clips of the live stream (kick.Service)
+type Clipper interface {
+	CreateClip(title string, duration int) (string, error)
+}
+
+// Clip is a captured highlight
+type Clip struct {
+	URL       string    `json:"url"`
+	Title     string    `json:"title"`
+	Kind      string    `json:"kind"`
+	Player    string    `json:"player"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Recorder turns engine highlights into Kick clips, at most one per cooldown,
+// and persists the clip links. All methods are safe for concurrent use.
+type Recorder struct {
+	cfg     Config
+	clipper Clipper
+
+	// Called with each new clip (from the capture goroutine)
+	OnClip func(clip Clip)
+
+	mu          sync.Mutex
+	clips       []Clip // Oldest first
+	lastClip    time.Time
+	saveBlocked bool // The file didn't parse and couldn't be moved aside
+	wg          sync.WaitGroup
+
+	now func() time.Time
+}
+
+// New creates a recorder, loading the clip links saved in cfg.File
+func New(cfg Config, clipper Clipper) *Recorder {
+	r := &Recorder{
+		cfg:     cfg,
+		clipper: clipper,
+		now:     time.Now,
+	}
+	r.load()
+	return r
+}
+
+// Title returns the clip title of a highlight
+func Title(h game.Highlight) string {
+	switch h.Kind {
+	case game.HighlightBossKill:
+		return fmt.Sprintf("%s takes down %s", h.Player, h.Victim)
+	case game.HighlightMultiKill:
+		return fmt.Sprintf("%s %d-kill streak", h.Player, h.Kills)
+	}
+	return h.Player
+}
+
+// Capture clips a highlight in the background unless disabled or on cooldown.
+// Returns whether a clip was started.
+func (r *Recorder) Capture(h game.Highlight) bool {
+	if r == nil || !r.cfg.Enabled || r.clipper == nil {
+		return false
+	}
+
+	r.mu.Lock()
+	now := r.now()
+	if !r.lastClip.IsZero() && now.Sub(r.lastClip) < time.Duration(r.cfg.Cooldown*float64(time.Second)) {
+		r.mu.Unlock()
+		return false
+	}
+	r.lastClip = now
+	r.wg.Add(1)
+	r.mu.Unlock()
+
+	go func() {
+		defer r.wg.Done()
+		r.capture(h, now)
+	}()
+	return true
+}
+
+// capture creates the clip and records its link
+func (r *Recorder) capture(h game.Highlight, at time.Time) {
+	title := Title(h)
+	url, err := r.clipper.CreateClip(title, r.cfg.Duration)
+	if err != nil {
+		log.Printf("⚠️ Failed to clip highlight %q: %v", title, err)
+		return
+	}
+
+	clip := Clip{URL: url, Title: title, Kind: h.Kind, Player: h.Player, CreatedAt: at}
+	r.mu.Lock()
+	r.clips = append(r.clips, clip)
+	if len(r.clips) > MaxClips {
+		r.clips = r.clips[len(r.clips)-MaxClips:]
+	}
+	r.mu.Unlock()
+	r.save()
+
+	log.Printf("🎬 Clipped %q: %s", title, url)
+	if r.OnClip != nil {
+		r.OnClip(clip)
+	}
+}
+
+// Clips returns up to limit clips, newest first (limit <= 0 = all)
+func (r *Recorder) Clips(limit int) []Clip {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if limit <= 0 || limit > len(r.clips) {
+		limit = len(r.clips)
+	}
+	clips := make([]Clip, limit)
+	for i := range clips {
+		clips[i] = r.clips[len(r.clips)-1-i]
+	}
+	return clips
+}
+
+// Stop waits for in-flight clips to finish
+func (r *Recorder) Stop() {
+	if r != nil {
+		r.wg.Wait()
+	}
+}
+
+// save writes the clip links to disk
+func (r *Recorder) save() {
+	if r.cfg.File == "" {
+		return
+	}
+	r.mu.Lock()
+	if r.saveBlocked {
+		r.mu.Unlock()
+		return
+	}
+	data, err := json.MarshalIndent(r.clips, "", "  ")
+	r.mu.Unlock()
+
+	if err != nil {
+		log.Printf("⚠️ Failed to marshal highlight clips: %v", err)
+		return
+	}
+	if err := game.WriteFileAtomic(r.cfg.File, data, 0600); err != nil {
+		log.Printf("⚠️ Failed to save highlight clips: %v", err)
+	}
+}
+
+// load restores saved clip links from disk
+func (r *Recorder) load() {
+	if r.cfg.File == "" {
+		return
+	}
+	data, err := os.ReadFile(r.cfg.File)
+	if err != nil {
+		return // No saved clips
+	}
+
+	var clips []Clip
+	if err := json.Unmarshal(data, &clips); err != nil {
+		if moved, qerr := game.QuarantineFile(r.cfg.File); qerr == nil {
+			log.Printf("⚠️ Failed to parse saved highlight clips (moved to %s): %v", moved, err)
+		} else {
+			log.Printf("⚠️ Failed to parse saved highlight clips, not saving over them: %v (%v)", err, qerr)
+			r.saveBlocked = true
+		}
+		return
+	}
+	r.clips = clips
+	if len(r.clips) > MaxClips {
+		r.clips = r.clips[len(r.clips)-MaxClips:]
+	}
+}
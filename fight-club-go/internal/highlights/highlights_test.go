@@ -0,0 +1,84 @@
+package highlights
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"fight-club/internal/game"
+)
+
+// fakeClipper returns numbered clip URLs, or err
+type fakeClipper struct {
+	mu     sync.Mutex
+	titles []string
+	err    error
+}
+
+func (f *fakeClipper) CreateClip(title string, duration int) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.titles = append(f.titles, title)
+	if f.err != nil {
+		return "", f.err
+	}
+	return fmt.Sprintf("https://kick.com/clip/%d", len(f.titles)), nil
+}
+
+// TestRecorderCapture verifies highlights become clips, throttled by the cooldown
+func TestRecorderCapture(t *testing.T) {
+	clipper := &fakeClipper{}
+	cfg := Config{Enabled: true, Duration: 30, Cooldown: 60, File: filepath.Join(t.TempDir(), "clips.json")}
+	r := New(cfg, clipper)
+	now := time.Unix(1000, 0)
+	r.now = func() time.Time { return now }
+
+	var posted []Clip
+	r.OnClip = func(c Clip) { posted = append(posted, c) }
+
+	if !r.Capture(game.Highlight{Kind: game.HighlightMultiKill, Player: "ana", Kills: 3}) {
+		t.Fatal("Expected the first highlight clipped")
+	}
+	if r.Capture(game.Highlight{Kind: game.HighlightBossKill, Player: "leo", Victim: "[BOSS] Titan"}) {
+		t.Error("Expected a highlight within the cooldown skipped")
+	}
+	r.Stop()
+
+	now = now.Add(61 * time.Second)
+	r.Capture(game.Highlight{Kind: game.HighlightBossKill, Player: "leo", Victim: "[BOSS] Titan"})
+	r.Stop()
+
+	clips := r.Clips(0)
+	if len(clips) != 2 || clips[0].Title != "leo takes down [BOSS] Titan" || clips[1].Title != "ana 3-kill streak" {
+		t.Fatalf("Expected the boss clip then the multikill clip, got %+v", clips)
+	}
+	if len(posted) != 2 || posted[1].URL != "https://kick.com/clip/2" {
+		t.Errorf("Expected both clips posted, got %+v", posted)
+	}
+
+	// Clip links survive a restart
+	if reloaded := New(cfg, clipper).Clips(1); len(reloaded) != 1 || reloaded[0].URL != clips[0].URL {
+		t.Errorf("Expected the newest clip reloaded, got %+v", reloaded)
+	}
+}
+
+// TestRecorderDisabledOrFailing verifies nothing is stored without a working clipper
+func TestRecorderDisabledOrFailing(t *testing.T) {
+	h := game.Highlight{Kind: game.HighlightMultiKill, Player: "ana", Kills: 3}
+	if New(Config{Enabled: false}, &fakeClipper{}).Capture(h) {
+		t.Error("Expected no clip when disabled")
+	}
+	if New(Config{Enabled: true}, nil).Capture(h) {
+		t.Error("Expected no clip without a clipper")
+	}
+
+	r := New(Config{Enabled: true, Duration: 30}, &fakeClipper{err: errors.New("unauthorized")})
+	r.Capture(h)
+	r.Stop()
+	if clips := r.Clips(0); len(clips) != 0 {
+		t.Errorf("Expected no clip stored after a failure, got %+v", clips)
+	}
+}
@@ -13,6 +13,7 @@ func (msg *SnapshotMessage) ToGameSnapshot() *game.GameSnapshot {
 		Sequence:    msg.Sequence,
 		Timestamp:   time.Unix(0, msg.Timestamp),
 		TickNumber:  msg.TickNumber,
+		TickRate:    msg.TickRate,
 		PlayerCount: msg.PlayerCount,
 		AliveCount:  msg.AliveCount,
 		TotalKills:  msg.TotalKills,
@@ -21,6 +22,61 @@ func (msg *SnapshotMessage) ToGameSnapshot() *game.GameSnapshot {
 			OffsetY:   msg.ShakeOffsetY,
 			Intensity: msg.ShakeIntensity,
 		},
+		Vote: game.VoteSnapshot{
+			Voting:    msg.VoteOpen,
+			Options:   msg.VoteOptions,
+			Counts:    msg.VoteCounts,
+			Remaining: msg.VoteRemaining,
+			Poll:      msg.VotePoll,
+			Modifier:  msg.Modifier,
+		},
+		Duel: game.DuelSnapshot{
+			Active:    msg.DuelActive,
+			X:         msg.DuelX,
+			Y:         msg.DuelY,
+			Radius:    msg.DuelRadius,
+			Players:   msg.DuelPlayers,
+			Remaining: msg.DuelRemaining,
+		},
+		Chaos: game.ChaosSnapshot{
+			Event:       msg.ChaosEvent,
+			Warning:     msg.ChaosWarning,
+			Remaining:   msg.ChaosRemaining,
+			ZoneX:       msg.ChaosZoneX,
+			ZoneY:       msg.ChaosZoneY,
+			ZoneRadius:  msg.ChaosZoneRadius,
+			Gravity:     msg.ChaosGravity,
+			MeteorCount: msg.ChaosMeteorCount,
+		},
+		Scene: game.SceneSnapshot{
+			Time:             msg.SceneTime,
+			Weather:          msg.SceneWeather,
+			WeatherIntensity: msg.SceneWeatherIntensity,
+		},
+		Leaderboard: game.LeaderboardSnapshot{
+			Period: game.LeaderboardPeriod(msg.LeaderboardPeriod),
+			Names:  msg.LeaderboardNames,
+			Kills:  msg.LeaderboardKills,
+			Count:  msg.LeaderboardCount,
+		},
+		QuestToast: game.QuestToastSnapshot{
+			Username: msg.QuestToastUser,
+			Quest:    msg.QuestToastQuest,
+			Reward:   msg.QuestToastReward,
+		},
+		JoinQueue: game.JoinQueueSnapshot{
+			Names: msg.JoinQueueNames,
+			Count: msg.JoinQueueCount,
+			Total: msg.JoinQueueTotal,
+		},
+		Paused:      msg.Paused,
+		ViewerCount: msg.ViewerCount,
+		AutoStream:  msg.AutoStream,
+		StreamLive:  msg.StreamLive,
+	}
+
+	for i, m := range msg.ChaosMeteors {
+		snap.Chaos.Meteors[i] = game.MeteorSnapshot{X: m.X, Y: m.Y, Radius: m.Radius, Progress: m.Progress}
 	}
 
 	// Convert players
@@ -45,6 +101,8 @@ func (msg *SnapshotMessage) ToGameSnapshot() *game.GameSnapshot {
 			IsDead:          p.IsDead,
 			IsRagdoll:       p.IsRagdoll,
 			RagdollRotation: p.RagdollRotation,
+			RingOut:         p.RingOut,
+			FallProgress:    p.FallProgress,
 			SpawnProtection: p.SpawnProtection,
 			IsAttacking:     p.IsAttacking,
 			ProfilePic:      p.ProfilePic,
@@ -52,6 +110,27 @@ func (msg *SnapshotMessage) ToGameSnapshot() *game.GameSnapshot {
 			DodgeDirection:  p.DodgeDirection,
 			ComboCount:      p.ComboCount,
 			Stamina:         p.Stamina,
+			IsSprinting:     p.IsSprinting,
+			IsExhausted:     p.IsExhausted,
+			Emote:           p.Emote,
+			EmoteProgress:   p.EmoteProgress,
+			ChatBubble:      p.ChatBubble,
+			ChatBubbleTTL:   p.ChatBubbleTTL,
+			Personality:     p.Personality,
+			Rank:            p.Rank,
+			TeamColor:       p.TeamColor,
+			IsCheered:       p.IsCheered,
+			IsCursed:        p.IsCursed,
+			IsRallied:       p.IsRallied,
+			IsRegrouping:    p.IsRegrouping,
+			RegroupX:        p.RegroupX,
+			RegroupY:        p.RegroupY,
+
+			Skins: game.PlayerSkins{
+				Border:    p.SkinBorder,
+				Trail:     p.SkinTrail,
+				Nameplate: p.SkinNameplate,
+			},
 		}
 	}
 
@@ -63,6 +142,7 @@ func (msg *SnapshotMessage) ToGameSnapshot() *game.GameSnapshot {
 			Y:     p.Y,
 			Color: p.Color,
 			Alpha: p.Alpha,
+			Size:  p.Size,
 		}
 	}
 
@@ -126,6 +206,7 @@ func (msg *SnapshotMessage) ToGameSnapshot() *game.GameSnapshot {
 	snap.Projectiles = make([]game.ProjectileSnapshot, len(msg.Projectiles))
 	for i, p := range msg.Projectiles {
 		snap.Projectiles[i] = game.ProjectileSnapshot{
+			ID:         p.ID,
 			X:          p.X,
 			Y:          p.Y,
 			Rotation:   p.Rotation,
@@ -136,5 +217,26 @@ func (msg *SnapshotMessage) ToGameSnapshot() *game.GameSnapshot {
 		}
 	}
 
+	// Convert coin piles
+	snap.Loot = make([]game.LootSnapshot, len(msg.Loot))
+	for i, l := range msg.Loot {
+		snap.Loot[i] = game.LootSnapshot{X: l.X, Y: l.Y, Amount: l.Amount, Remaining: l.Remaining}
+	}
+	snap.WeaponDrops = make([]game.WeaponDropSnapshot, len(msg.WeaponDrops))
+	for i, w := range msg.WeaponDrops {
+		snap.WeaponDrops[i] = game.WeaponDropSnapshot{X: w.X, Y: w.Y, Weapon: w.Weapon, Remaining: w.Remaining}
+	}
+	snap.Airstrikes = make([]game.AirstrikeSnapshot, len(msg.Airstrikes))
+	for i, a := range msg.Airstrikes {
+		snap.Airstrikes[i] = game.AirstrikeSnapshot{X: a.X, Y: a.Y, Radius: a.Radius, Progress: a.Progress, Caller: a.Caller}
+	}
+
+	snap.Shops = make([]game.ShopSnapshot, len(msg.Shops))
+	for i, sh := range msg.Shops {
+		snap.Shops[i] = game.ShopSnapshot{X: sh.X, Y: sh.Y, Radius: sh.Radius, Name: sh.Name, Stock: sh.Stock, Orders: sh.Orders}
+	}
+	snap.PitBand = msg.PitBand
+	snap.Replay = game.ReplayCue{Seq: msg.ReplaySeq, Reason: msg.ReplayReason, Seconds: msg.ReplaySeconds, Speed: msg.ReplaySpeed}
+
 	return snap
 }
@@ -0,0 +1,77 @@
+package ipc
+
+import (
+	"encoding/gob"
+	"fmt"
+	"strings"
+)
+
+// Snapshot codec IDs, sent in the Codec byte of every snapshot frame header
+const (
+	CodecGob      byte = 0 // Default (frames from before codecs carry 0 too)
+	CodecProtobuf byte = 1 // snapshot.proto, readable outside Go
+)
+
+// SnapshotCodec serializes snapshots for the wire. The publisher encodes with
+// the codec picked by IPC_CODEC; the ID in the frame header tells subscribers
+// and recordings how to decode, so the streamer needs no setting of its own.
+type SnapshotCodec interface {
+	ID() byte
+	Name() string
+
+	// AppendSnapshot appends the encoded snapshot to dst
+	AppendSnapshot(dst []byte, msg *SnapshotMessage) ([]byte, error)
+	DecodeSnapshot(data []byte) (*SnapshotMessage, error)
+}
+
+// codecs by ID
+var codecs = []SnapshotCodec{
+	CodecGob:      gobCodec{},
+	CodecProtobuf: protobufCodec{},
+}
+
+// CodecByName returns the codec called name ("gob", "protobuf")
+func CodecByName(name string) (SnapshotCodec, error) {
+	for _, c := range codecs {
+		if c.Name() == strings.ToLower(name) {
+			return c, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown snapshot codec %q", name)
+}
+
+// CodecByID returns the codec of a frame header
+func CodecByID(id byte) (SnapshotCodec, error) {
+	if int(id) >= len(codecs) {
+		return nil, fmt.Errorf("unknown snapshot codec %d", id)
+	}
+	return codecs[id], nil
+}
+
+// CodecNames lists the codec names, for errors and docs
+func CodecNames() []string {
+	names := make([]string, len(codecs))
+	for i, c := range codecs {
+		names[i] = c.Name()
+	}
+	return names
+}
+
+// gobCodec is the original encoding: simple, but every message carries its
+// type description and is encoded through reflection
+type gobCodec struct{}
+
+func (gobCodec) ID() byte     { return CodecGob }
+func (gobCodec) Name() string { return "gob" }
+
+func (gobCodec) AppendSnapshot(dst []byte, msg *SnapshotMessage) ([]byte, error) {
+	buf := &gobBuffer{buf: dst}
+	if err := gob.NewEncoder(buf).Encode(msg); err != nil {
+		return dst, fmt.Errorf("gob encode: %w", err)
+	}
+	return buf.buf, nil
+}
+
+func (gobCodec) DecodeSnapshot(data []byte) (*SnapshotMessage, error) {
+	return DecodeSnapshot(data)
+}
@@ -0,0 +1,1062 @@
+package ipc
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// protobufCodec encodes snapshots in the protobuf wire format described by
+// snapshot.proto, so tools in other languages can read the snapshot stream.
+// The code is written against protowire rather than generated: it appends
+// straight into the frame buffer, without building a message tree or going
+// through reflection. Keep the field numbers in sync with snapshot.proto.
+type protobufCodec struct{}
+
+func (protobufCodec) ID() byte     { return CodecProtobuf }
+func (protobufCodec) Name() string { return "protobuf" }
+
+func (protobufCodec) AppendSnapshot(dst []byte, msg *SnapshotMessage) ([]byte, error) {
+	return appendSnapshot(dst, msg), nil
+}
+
+func (protobufCodec) DecodeSnapshot(data []byte) (*SnapshotMessage, error) {
+	var msg SnapshotMessage
+	if err := decodeSnapshot(data, &msg); err != nil {
+		return nil, fmt.Errorf("protobuf decode snapshot: %w", err)
+	}
+	return &msg, nil
+}
+
+// Field encoders. Zero scalars are left out, like proto3 does.
+
+func appendDouble(b []byte, num protowire.Number, v float64) []byte {
+	if v == 0 {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.Fixed64Type)
+	return protowire.AppendFixed64(b, math.Float64bits(v))
+}
+
+func appendInt(b []byte, num protowire.Number, v int) []byte {
+	if v == 0 {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.VarintType)
+	return protowire.AppendVarint(b, protowire.EncodeZigZag(int64(v)))
+}
+
+func appendInt64(b []byte, num protowire.Number, v int64) []byte {
+	if v == 0 {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.VarintType)
+	return protowire.AppendVarint(b, uint64(v))
+}
+
+func appendUint64(b []byte, num protowire.Number, v uint64) []byte {
+	if v == 0 {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.VarintType)
+	return protowire.AppendVarint(b, v)
+}
+
+func appendBool(b []byte, num protowire.Number, v bool) []byte {
+	if !v {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.VarintType)
+	return protowire.AppendVarint(b, 1)
+}
+
+func appendString(b []byte, num protowire.Number, v string) []byte {
+	if v == "" {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendString(b, v)
+}
+
+// appendStrings appends a repeated string. Empty strings before the last set
+// one are kept: the fixed-size arrays (vote options, names) are positional.
+func appendStrings(b []byte, num protowire.Number, vs []string) []byte {
+	for _, v := range trimZero(vs) {
+		b = protowire.AppendTag(b, num, protowire.BytesType)
+		b = protowire.AppendString(b, v)
+	}
+	return b
+}
+
+func appendPackedInts(b []byte, num protowire.Number, vs []int) []byte {
+	vs = trimZero(vs)
+	if len(vs) == 0 {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	start := len(b)
+	for _, v := range vs {
+		b = protowire.AppendVarint(b, protowire.EncodeZigZag(int64(v)))
+	}
+	return insertLength(b, start)
+}
+
+func appendPackedDoubles(b []byte, num protowire.Number, vs []float64) []byte {
+	vs = trimZero(vs)
+	if len(vs) == 0 {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	b = protowire.AppendVarint(b, uint64(len(vs)*8))
+	for _, v := range vs {
+		b = protowire.AppendFixed64(b, math.Float64bits(v))
+	}
+	return b
+}
+
+// appendMessage appends v as an embedded message field
+func appendMessage[T any](b []byte, num protowire.Number, appendFields func([]byte, *T) []byte, v *T) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	start := len(b)
+	return insertLength(appendFields(b, v), start)
+}
+
+// insertLength prefixes the value written from start with its length. Writing
+// the value first spares sizing every message before encoding it.
+func insertLength(b []byte, start int) []byte {
+	n := len(b) - start
+	size := protowire.SizeVarint(uint64(n))
+	b = append(b, make([]byte, size)...)
+	copy(b[start+size:], b[start:start+n])
+	protowire.AppendVarint(b[start:start], uint64(n))
+	return b
+}
+
+// trimZero drops the trailing zero values of a fixed-size array
+func trimZero[T comparable](vs []T) []T {
+	var zero T
+	for len(vs) > 0 && vs[len(vs)-1] == zero {
+		vs = vs[:len(vs)-1]
+	}
+	return vs
+}
+
+// Message encoders, one per snapshot.proto message
+
+func appendSnapshot(b []byte, m *SnapshotMessage) []byte {
+	b = appendUint64(b, 1, m.Sequence)
+	b = appendInt64(b, 2, m.Timestamp)
+	b = appendUint64(b, 3, m.TickNumber)
+	b = appendInt(b, 4, m.TickRate)
+	for i := range m.Players {
+		b = appendMessage(b, 5, appendPlayer, &m.Players[i])
+	}
+	for i := range m.Particles {
+		b = appendMessage(b, 6, appendParticle, &m.Particles[i])
+	}
+	for i := range m.Effects {
+		b = appendMessage(b, 7, appendEffect, &m.Effects[i])
+	}
+	for i := range m.Texts {
+		b = appendMessage(b, 8, appendText, &m.Texts[i])
+	}
+	for i := range m.Trails {
+		b = appendMessage(b, 9, appendTrail, &m.Trails[i])
+	}
+	for i := range m.Flashes {
+		b = appendMessage(b, 10, appendFlash, &m.Flashes[i])
+	}
+	for i := range m.Projectiles {
+		b = appendMessage(b, 11, appendProjectile, &m.Projectiles[i])
+	}
+	for i := range m.Loot {
+		b = appendMessage(b, 12, appendLoot, &m.Loot[i])
+	}
+	for i := range m.WeaponDrops {
+		b = appendMessage(b, 13, appendWeaponDrop, &m.WeaponDrops[i])
+	}
+	for i := range m.Airstrikes {
+		b = appendMessage(b, 14, appendAirstrike, &m.Airstrikes[i])
+	}
+	for i := range m.Shops {
+		b = appendMessage(b, 15, appendShop, &m.Shops[i])
+	}
+	b = appendDouble(b, 16, m.ShakeOffsetX)
+	b = appendDouble(b, 17, m.ShakeOffsetY)
+	b = appendDouble(b, 18, m.ShakeIntensity)
+	b = appendBool(b, 19, m.VoteOpen)
+	b = appendStrings(b, 20, m.VoteOptions[:])
+	b = appendPackedInts(b, 21, m.VoteCounts[:])
+	b = appendDouble(b, 22, m.VoteRemaining)
+	b = appendBool(b, 23, m.VotePoll)
+	b = appendString(b, 24, m.Modifier)
+	b = appendBool(b, 25, m.DuelActive)
+	b = appendDouble(b, 26, m.DuelX)
+	b = appendDouble(b, 27, m.DuelY)
+	b = appendDouble(b, 28, m.DuelRadius)
+	b = appendStrings(b, 29, m.DuelPlayers[:])
+	b = appendDouble(b, 30, m.DuelRemaining)
+	b = appendString(b, 31, m.ChaosEvent)
+	b = appendBool(b, 32, m.ChaosWarning)
+	b = appendDouble(b, 33, m.ChaosRemaining)
+	b = appendDouble(b, 34, m.ChaosZoneX)
+	b = appendDouble(b, 35, m.ChaosZoneY)
+	b = appendDouble(b, 36, m.ChaosZoneRadius)
+	b = appendDouble(b, 37, m.ChaosGravity)
+	for i := range trimZero(m.ChaosMeteors[:]) {
+		b = appendMessage(b, 38, appendMeteor, &m.ChaosMeteors[i])
+	}
+	b = appendInt(b, 39, m.ChaosMeteorCount)
+	b = appendString(b, 40, m.SceneTime)
+	b = appendString(b, 41, m.SceneWeather)
+	b = appendDouble(b, 42, m.SceneWeatherIntensity)
+	b = appendString(b, 43, m.LeaderboardPeriod)
+	b = appendStrings(b, 44, m.LeaderboardNames[:])
+	b = appendPackedInts(b, 45, m.LeaderboardKills[:])
+	b = appendInt(b, 46, m.LeaderboardCount)
+	b = appendString(b, 47, m.QuestToastUser)
+	b = appendString(b, 48, m.QuestToastQuest)
+	b = appendInt(b, 49, m.QuestToastReward)
+	b = appendStrings(b, 50, m.JoinQueueNames[:])
+	b = appendInt(b, 51, m.JoinQueueCount)
+	b = appendInt(b, 52, m.JoinQueueTotal)
+	b = appendBool(b, 53, m.Paused)
+	b = appendInt(b, 54, m.ViewerCount)
+	b = appendBool(b, 55, m.AutoStream)
+	b = appendBool(b, 56, m.StreamLive)
+	b = appendInt(b, 57, m.PlayerCount)
+	b = appendInt(b, 58, m.AliveCount)
+	b = appendInt(b, 59, m.TotalKills)
+	b = appendDouble(b, 60, m.PitBand)
+	b = appendUint64(b, 61, m.ReplaySeq)
+	b = appendString(b, 62, m.ReplayReason)
+	b = appendDouble(b, 63, m.ReplaySeconds)
+	b = appendDouble(b, 64, m.ReplaySpeed)
+	return b
+}
+
+func appendPlayer(b []byte, p *PlayerData) []byte {
+	b = appendString(b, 1, p.ID)
+	b = appendString(b, 2, p.Name)
+	b = appendDouble(b, 3, p.X)
+	b = appendDouble(b, 4, p.Y)
+	b = appendDouble(b, 5, p.VX)
+	b = appendDouble(b, 6, p.VY)
+	b = appendInt(b, 7, p.HP)
+	b = appendInt(b, 8, p.MaxHP)
+	b = appendInt(b, 9, p.Money)
+	b = appendInt(b, 10, p.Kills)
+	b = appendInt(b, 11, p.Deaths)
+	b = appendString(b, 12, p.Weapon)
+	b = appendString(b, 13, p.Color)
+	b = appendString(b, 14, p.Avatar)
+	b = appendDouble(b, 15, p.AttackAngle)
+	b = appendBool(b, 16, p.IsDead)
+	b = appendBool(b, 17, p.IsRagdoll)
+	b = appendDouble(b, 18, p.RagdollRotation)
+	b = appendBool(b, 19, p.SpawnProtection)
+	b = appendBool(b, 20, p.IsAttacking)
+	b = appendString(b, 21, p.ProfilePic)
+	b = appendBool(b, 22, p.IsDodging)
+	b = appendDouble(b, 23, p.DodgeDirection)
+	b = appendInt(b, 24, p.ComboCount)
+	b = appendDouble(b, 25, p.Stamina)
+	b = appendBool(b, 26, p.IsSprinting)
+	b = appendBool(b, 27, p.IsExhausted)
+	b = appendString(b, 28, p.Emote)
+	b = appendDouble(b, 29, p.EmoteProgress)
+	b = appendString(b, 30, p.ChatBubble)
+	b = appendDouble(b, 31, p.ChatBubbleTTL)
+	b = appendString(b, 32, p.Personality)
+	b = appendString(b, 33, p.Rank)
+	b = appendString(b, 34, p.TeamColor)
+	b = appendString(b, 35, p.SkinBorder)
+	b = appendString(b, 36, p.SkinTrail)
+	b = appendString(b, 37, p.SkinNameplate)
+	b = appendBool(b, 38, p.IsCheered)
+	b = appendBool(b, 39, p.IsCursed)
+	b = appendBool(b, 40, p.IsRallied)
+	b = appendBool(b, 41, p.IsRegrouping)
+	b = appendDouble(b, 42, p.RegroupX)
+	b = appendDouble(b, 43, p.RegroupY)
+	b = appendBool(b, 44, p.RingOut)
+	b = appendDouble(b, 45, p.FallProgress)
+	return b
+}
+
+func appendParticle(b []byte, v *ParticleData) []byte {
+	b = appendDouble(b, 1, v.X)
+	b = appendDouble(b, 2, v.Y)
+	b = appendString(b, 3, v.Color)
+	b = appendDouble(b, 4, v.Alpha)
+	b = appendDouble(b, 5, v.Size)
+	return b
+}
+
+func appendEffect(b []byte, v *EffectData) []byte {
+	b = appendDouble(b, 1, v.X)
+	b = appendDouble(b, 2, v.Y)
+	b = appendDouble(b, 3, v.TX)
+	b = appendDouble(b, 4, v.TY)
+	b = appendString(b, 5, v.Color)
+	b = appendInt(b, 6, v.Timer)
+	return b
+}
+
+func appendText(b []byte, v *TextData) []byte {
+	b = appendDouble(b, 1, v.X)
+	b = appendDouble(b, 2, v.Y)
+	b = appendString(b, 3, v.Text)
+	b = appendString(b, 4, v.Color)
+	b = appendDouble(b, 5, v.Alpha)
+	return b
+}
+
+func appendTrail(b []byte, v *TrailData) []byte {
+	for i := range trimZero(v.Points[:]) {
+		b = appendMessage(b, 1, appendTrailPoint, &v.Points[i])
+	}
+	b = appendInt(b, 2, v.Count)
+	b = appendString(b, 3, v.Color)
+	b = appendDouble(b, 4, v.Alpha)
+	b = appendString(b, 5, v.PlayerID)
+	return b
+}
+
+func appendTrailPoint(b []byte, v *TrailPointData) []byte {
+	b = appendDouble(b, 1, v.X)
+	b = appendDouble(b, 2, v.Y)
+	b = appendDouble(b, 3, v.Alpha)
+	return b
+}
+
+func appendFlash(b []byte, v *FlashData) []byte {
+	b = appendDouble(b, 1, v.X)
+	b = appendDouble(b, 2, v.Y)
+	b = appendDouble(b, 3, v.Radius)
+	b = appendString(b, 4, v.Color)
+	b = appendDouble(b, 5, v.Intensity)
+	return b
+}
+
+func appendProjectile(b []byte, v *ProjectileData) []byte {
+	b = appendString(b, 1, v.ID)
+	b = appendDouble(b, 2, v.X)
+	b = appendDouble(b, 3, v.Y)
+	b = appendDouble(b, 4, v.Rotation)
+	b = appendString(b, 5, v.Color)
+	b = appendPackedDoubles(b, 6, v.TrailX[:])
+	b = appendPackedDoubles(b, 7, v.TrailY[:])
+	b = appendInt(b, 8, v.TrailCount)
+	return b
+}
+
+func appendLoot(b []byte, v *LootData) []byte {
+	b = appendDouble(b, 1, v.X)
+	b = appendDouble(b, 2, v.Y)
+	b = appendInt(b, 3, v.Amount)
+	b = appendDouble(b, 4, v.Remaining)
+	return b
+}
+
+func appendWeaponDrop(b []byte, v *WeaponDropData) []byte {
+	b = appendDouble(b, 1, v.X)
+	b = appendDouble(b, 2, v.Y)
+	b = appendString(b, 3, v.Weapon)
+	b = appendDouble(b, 4, v.Remaining)
+	return b
+}
+
+func appendAirstrike(b []byte, v *AirstrikeData) []byte {
+	b = appendDouble(b, 1, v.X)
+	b = appendDouble(b, 2, v.Y)
+	b = appendDouble(b, 3, v.Radius)
+	b = appendDouble(b, 4, v.Progress)
+	b = appendString(b, 5, v.Caller)
+	return b
+}
+
+func appendShop(b []byte, v *ShopData) []byte {
+	b = appendDouble(b, 1, v.X)
+	b = appendDouble(b, 2, v.Y)
+	b = appendDouble(b, 3, v.Radius)
+	b = appendString(b, 4, v.Name)
+	b = appendStrings(b, 5, v.Stock)
+	b = appendInt(b, 6, v.Orders)
+	return b
+}
+
+func appendMeteor(b []byte, v *MeteorData) []byte {
+	b = appendDouble(b, 1, v.X)
+	b = appendDouble(b, 2, v.Y)
+	b = appendDouble(b, 3, v.Radius)
+	b = appendDouble(b, 4, v.Progress)
+	return b
+}
+
+var errWireType = errors.New("unexpected wire type")
+
+// wireReader walks the fields of a message:
+//
+//	r := wireReader{b: data}
+//	for r.next() {
+//		switch r.num { ... r.double() ... }
+//	}
+//	return r.err
+//
+// The accessors read the current value as the expected type; a field no case
+// reads (unknown to this version) is skipped.
+type wireReader struct {
+	b   []byte // The current value, followed by the rest of the message
+	num protowire.Number
+	typ protowire.Type
+	n   int // Bytes of the current value read (-1 = not read)
+	err error
+}
+
+// next moves to the next field. Returns false at the end or on an error.
+func (r *wireReader) next() bool {
+	if r.err != nil {
+		return false
+	}
+	if r.num != 0 {
+		if r.n < 0 {
+			r.n = protowire.ConsumeFieldValue(r.num, r.typ, r.b)
+		}
+		if r.n < 0 {
+			r.fail(protowire.ParseError(r.n))
+			return false
+		}
+		r.b = r.b[r.n:]
+	}
+	if len(r.b) == 0 {
+		return false
+	}
+
+	num, typ, n := protowire.ConsumeTag(r.b)
+	if n < 0 {
+		r.fail(protowire.ParseError(n))
+		return false
+	}
+	r.b = r.b[n:]
+	r.num, r.typ, r.n = num, typ, -1
+	return true
+}
+
+// fail records the first error (nil is ignored), with the field it happened in
+func (r *wireReader) fail(err error) {
+	if r.err == nil && err != nil {
+		r.err = fmt.Errorf("field %d: %w", r.num, err)
+	}
+}
+
+func (r *wireReader) varint() uint64 {
+	if r.typ != protowire.VarintType {
+		r.fail(errWireType)
+		return 0
+	}
+	v, n := protowire.ConsumeVarint(r.b)
+	r.n = n
+	return v
+}
+
+func (r *wireReader) int() int       { return int(protowire.DecodeZigZag(r.varint())) }
+func (r *wireReader) int64() int64   { return int64(r.varint()) }
+func (r *wireReader) uint64() uint64 { return r.varint() }
+func (r *wireReader) bool() bool     { return r.varint() != 0 }
+func (r *wireReader) string() string { return string(r.bytes()) }
+
+func (r *wireReader) double() float64 {
+	if r.typ != protowire.Fixed64Type {
+		r.fail(errWireType)
+		return 0
+	}
+	v, n := protowire.ConsumeFixed64(r.b)
+	r.n = n
+	return math.Float64frombits(v)
+}
+
+func (r *wireReader) bytes() []byte {
+	if r.typ != protowire.BytesType {
+		r.fail(errWireType)
+		return nil
+	}
+	v, n := protowire.ConsumeBytes(r.b)
+	r.n = n
+	return v
+}
+
+// packedInts reads a packed repeated sint64 into a fixed-size array
+func (r *wireReader) packedInts(dst []int) {
+	b := r.bytes()
+	for i := 0; len(b) > 0; i++ {
+		v, n := protowire.ConsumeVarint(b)
+		if n < 0 {
+			r.fail(protowire.ParseError(n))
+			return
+		}
+		if i < len(dst) {
+			dst[i] = int(protowire.DecodeZigZag(v))
+		}
+		b = b[n:]
+	}
+}
+
+// packedDoubles reads a packed repeated double into a fixed-size array
+func (r *wireReader) packedDoubles(dst []float64) {
+	b := r.bytes()
+	for i := 0; len(b) > 0; i++ {
+		v, n := protowire.ConsumeFixed64(b)
+		if n < 0 {
+			r.fail(protowire.ParseError(n))
+			return
+		}
+		if i < len(dst) {
+			dst[i] = math.Float64frombits(v)
+		}
+		b = b[n:]
+	}
+}
+
+// countFields counts the occurrences of field numbers below len(counts).
+// Malformed input stops the count; decoding reports it.
+func countFields(b []byte, counts []int) {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return
+		}
+		b = b[n:]
+		if int(num) < len(counts) {
+			counts[num]++
+		}
+		if n = protowire.ConsumeFieldValue(num, typ, b); n < 0 {
+			return
+		}
+		b = b[n:]
+	}
+}
+
+// withCap returns an empty slice with room for n values (nil for none, like
+// an absent field)
+func withCap[T any](n int) []T {
+	if n == 0 {
+		return nil
+	}
+	return make([]T, 0, n)
+}
+
+// Message decoders, one per snapshot.proto message
+
+func decodeSnapshot(b []byte, m *SnapshotMessage) error {
+	// Size the lists up front: appending would copy the fighters several
+	// times over as the slices grow
+	var counts [16]int
+	countFields(b, counts[:])
+	m.Players = withCap[PlayerData](counts[5])
+	m.Particles = withCap[ParticleData](counts[6])
+	m.Effects = withCap[EffectData](counts[7])
+	m.Texts = withCap[TextData](counts[8])
+	m.Trails = withCap[TrailData](counts[9])
+	m.Flashes = withCap[FlashData](counts[10])
+	m.Projectiles = withCap[ProjectileData](counts[11])
+	m.Loot = withCap[LootData](counts[12])
+	m.WeaponDrops = withCap[WeaponDropData](counts[13])
+	m.Airstrikes = withCap[AirstrikeData](counts[14])
+	m.Shops = withCap[ShopData](counts[15])
+
+	var voteOptions, duelPlayers, chaosMeteors, leaderboardNames, joinQueueNames int
+	r := wireReader{b: b}
+	for r.next() {
+		switch r.num {
+		case 1:
+			m.Sequence = r.uint64()
+		case 2:
+			m.Timestamp = r.int64()
+		case 3:
+			m.TickNumber = r.uint64()
+		case 4:
+			m.TickRate = r.int()
+		case 5:
+			m.Players = append(m.Players, PlayerData{})
+			r.fail(decodePlayer(r.bytes(), &m.Players[len(m.Players)-1]))
+		case 6:
+			m.Particles = append(m.Particles, ParticleData{})
+			r.fail(decodeParticle(r.bytes(), &m.Particles[len(m.Particles)-1]))
+		case 7:
+			m.Effects = append(m.Effects, EffectData{})
+			r.fail(decodeEffect(r.bytes(), &m.Effects[len(m.Effects)-1]))
+		case 8:
+			m.Texts = append(m.Texts, TextData{})
+			r.fail(decodeText(r.bytes(), &m.Texts[len(m.Texts)-1]))
+		case 9:
+			m.Trails = append(m.Trails, TrailData{})
+			r.fail(decodeTrail(r.bytes(), &m.Trails[len(m.Trails)-1]))
+		case 10:
+			m.Flashes = append(m.Flashes, FlashData{})
+			r.fail(decodeFlash(r.bytes(), &m.Flashes[len(m.Flashes)-1]))
+		case 11:
+			m.Projectiles = append(m.Projectiles, ProjectileData{})
+			r.fail(decodeProjectile(r.bytes(), &m.Projectiles[len(m.Projectiles)-1]))
+		case 12:
+			m.Loot = append(m.Loot, LootData{})
+			r.fail(decodeLoot(r.bytes(), &m.Loot[len(m.Loot)-1]))
+		case 13:
+			m.WeaponDrops = append(m.WeaponDrops, WeaponDropData{})
+			r.fail(decodeWeaponDrop(r.bytes(), &m.WeaponDrops[len(m.WeaponDrops)-1]))
+		case 14:
+			m.Airstrikes = append(m.Airstrikes, AirstrikeData{})
+			r.fail(decodeAirstrike(r.bytes(), &m.Airstrikes[len(m.Airstrikes)-1]))
+		case 15:
+			m.Shops = append(m.Shops, ShopData{})
+			r.fail(decodeShop(r.bytes(), &m.Shops[len(m.Shops)-1]))
+		case 16:
+			m.ShakeOffsetX = r.double()
+		case 17:
+			m.ShakeOffsetY = r.double()
+		case 18:
+			m.ShakeIntensity = r.double()
+		case 19:
+			m.VoteOpen = r.bool()
+		case 20:
+			if voteOptions < len(m.VoteOptions) {
+				m.VoteOptions[voteOptions] = r.string()
+			}
+			voteOptions++
+		case 21:
+			r.packedInts(m.VoteCounts[:])
+		case 22:
+			m.VoteRemaining = r.double()
+		case 23:
+			m.VotePoll = r.bool()
+		case 24:
+			m.Modifier = r.string()
+		case 25:
+			m.DuelActive = r.bool()
+		case 26:
+			m.DuelX = r.double()
+		case 27:
+			m.DuelY = r.double()
+		case 28:
+			m.DuelRadius = r.double()
+		case 29:
+			if duelPlayers < len(m.DuelPlayers) {
+				m.DuelPlayers[duelPlayers] = r.string()
+			}
+			duelPlayers++
+		case 30:
+			m.DuelRemaining = r.double()
+		case 31:
+			m.ChaosEvent = r.string()
+		case 32:
+			m.ChaosWarning = r.bool()
+		case 33:
+			m.ChaosRemaining = r.double()
+		case 34:
+			m.ChaosZoneX = r.double()
+		case 35:
+			m.ChaosZoneY = r.double()
+		case 36:
+			m.ChaosZoneRadius = r.double()
+		case 37:
+			m.ChaosGravity = r.double()
+		case 38:
+			if chaosMeteors < len(m.ChaosMeteors) {
+				r.fail(decodeMeteor(r.bytes(), &m.ChaosMeteors[chaosMeteors]))
+			}
+			chaosMeteors++
+		case 39:
+			m.ChaosMeteorCount = r.int()
+		case 40:
+			m.SceneTime = r.string()
+		case 41:
+			m.SceneWeather = r.string()
+		case 42:
+			m.SceneWeatherIntensity = r.double()
+		case 43:
+			m.LeaderboardPeriod = r.string()
+		case 44:
+			if leaderboardNames < len(m.LeaderboardNames) {
+				m.LeaderboardNames[leaderboardNames] = r.string()
+			}
+			leaderboardNames++
+		case 45:
+			r.packedInts(m.LeaderboardKills[:])
+		case 46:
+			m.LeaderboardCount = r.int()
+		case 47:
+			m.QuestToastUser = r.string()
+		case 48:
+			m.QuestToastQuest = r.string()
+		case 49:
+			m.QuestToastReward = r.int()
+		case 50:
+			if joinQueueNames < len(m.JoinQueueNames) {
+				m.JoinQueueNames[joinQueueNames] = r.string()
+			}
+			joinQueueNames++
+		case 51:
+			m.JoinQueueCount = r.int()
+		case 52:
+			m.JoinQueueTotal = r.int()
+		case 53:
+			m.Paused = r.bool()
+		case 54:
+			m.ViewerCount = r.int()
+		case 55:
+			m.AutoStream = r.bool()
+		case 56:
+			m.StreamLive = r.bool()
+		case 57:
+			m.PlayerCount = r.int()
+		case 58:
+			m.AliveCount = r.int()
+		case 59:
+			m.TotalKills = r.int()
+		case 60:
+			m.PitBand = r.double()
+		case 61:
+			m.ReplaySeq = r.uint64()
+		case 62:
+			m.ReplayReason = r.string()
+		case 63:
+			m.ReplaySeconds = r.double()
+		case 64:
+			m.ReplaySpeed = r.double()
+		}
+	}
+	return r.err
+}
+
+func decodePlayer(b []byte, p *PlayerData) error {
+	r := wireReader{b: b}
+	for r.next() {
+		switch r.num {
+		case 1:
+			p.ID = r.string()
+		case 2:
+			p.Name = r.string()
+		case 3:
+			p.X = r.double()
+		case 4:
+			p.Y = r.double()
+		case 5:
+			p.VX = r.double()
+		case 6:
+			p.VY = r.double()
+		case 7:
+			p.HP = r.int()
+		case 8:
+			p.MaxHP = r.int()
+		case 9:
+			p.Money = r.int()
+		case 10:
+			p.Kills = r.int()
+		case 11:
+			p.Deaths = r.int()
+		case 12:
+			p.Weapon = r.string()
+		case 13:
+			p.Color = r.string()
+		case 14:
+			p.Avatar = r.string()
+		case 15:
+			p.AttackAngle = r.double()
+		case 16:
+			p.IsDead = r.bool()
+		case 17:
+			p.IsRagdoll = r.bool()
+		case 18:
+			p.RagdollRotation = r.double()
+		case 19:
+			p.SpawnProtection = r.bool()
+		case 20:
+			p.IsAttacking = r.bool()
+		case 21:
+			p.ProfilePic = r.string()
+		case 22:
+			p.IsDodging = r.bool()
+		case 23:
+			p.DodgeDirection = r.double()
+		case 24:
+			p.ComboCount = r.int()
+		case 25:
+			p.Stamina = r.double()
+		case 26:
+			p.IsSprinting = r.bool()
+		case 27:
+			p.IsExhausted = r.bool()
+		case 28:
+			p.Emote = r.string()
+		case 29:
+			p.EmoteProgress = r.double()
+		case 30:
+			p.ChatBubble = r.string()
+		case 31:
+			p.ChatBubbleTTL = r.double()
+		case 32:
+			p.Personality = r.string()
+		case 33:
+			p.Rank = r.string()
+		case 34:
+			p.TeamColor = r.string()
+		case 35:
+			p.SkinBorder = r.string()
+		case 36:
+			p.SkinTrail = r.string()
+		case 37:
+			p.SkinNameplate = r.string()
+		case 38:
+			p.IsCheered = r.bool()
+		case 39:
+			p.IsCursed = r.bool()
+		case 40:
+			p.IsRallied = r.bool()
+		case 41:
+			p.IsRegrouping = r.bool()
+		case 42:
+			p.RegroupX = r.double()
+		case 43:
+			p.RegroupY = r.double()
+		case 44:
+			p.RingOut = r.bool()
+		case 45:
+			p.FallProgress = r.double()
+		}
+	}
+	return r.err
+}
+
+func decodeParticle(b []byte, v *ParticleData) error {
+	r := wireReader{b: b}
+	for r.next() {
+		switch r.num {
+		case 1:
+			v.X = r.double()
+		case 2:
+			v.Y = r.double()
+		case 3:
+			v.Color = r.string()
+		case 4:
+			v.Alpha = r.double()
+		case 5:
+			v.Size = r.double()
+		}
+	}
+	return r.err
+}
+
+func decodeEffect(b []byte, v *EffectData) error {
+	r := wireReader{b: b}
+	for r.next() {
+		switch r.num {
+		case 1:
+			v.X = r.double()
+		case 2:
+			v.Y = r.double()
+		case 3:
+			v.TX = r.double()
+		case 4:
+			v.TY = r.double()
+		case 5:
+			v.Color = r.string()
+		case 6:
+			v.Timer = r.int()
+		}
+	}
+	return r.err
+}
+
+func decodeText(b []byte, v *TextData) error {
+	r := wireReader{b: b}
+	for r.next() {
+		switch r.num {
+		case 1:
+			v.X = r.double()
+		case 2:
+			v.Y = r.double()
+		case 3:
+			v.Text = r.string()
+		case 4:
+			v.Color = r.string()
+		case 5:
+			v.Alpha = r.double()
+		}
+	}
+	return r.err
+}
+
+func decodeTrail(b []byte, v *TrailData) error {
+	var points int
+	r := wireReader{b: b}
+	for r.next() {
+		switch r.num {
+		case 1:
+			if points < len(v.Points) {
+				r.fail(decodeTrailPoint(r.bytes(), &v.Points[points]))
+			}
+			points++
+		case 2:
+			v.Count = r.int()
+		case 3:
+			v.Color = r.string()
+		case 4:
+			v.Alpha = r.double()
+		case 5:
+			v.PlayerID = r.string()
+		}
+	}
+	return r.err
+}
+
+func decodeTrailPoint(b []byte, v *TrailPointData) error {
+	r := wireReader{b: b}
+	for r.next() {
+		switch r.num {
+		case 1:
+			v.X = r.double()
+		case 2:
+			v.Y = r.double()
+		case 3:
+			v.Alpha = r.double()
+		}
+	}
+	return r.err
+}
+
+func decodeFlash(b []byte, v *FlashData) error {
+	r := wireReader{b: b}
+	for r.next() {
+		switch r.num {
+		case 1:
+			v.X = r.double()
+		case 2:
+			v.Y = r.double()
+		case 3:
+			v.Radius = r.double()
+		case 4:
+			v.Color = r.string()
+		case 5:
+			v.Intensity = r.double()
+		}
+	}
+	return r.err
+}
+
+func decodeProjectile(b []byte, v *ProjectileData) error {
+	r := wireReader{b: b}
+	for r.next() {
+		switch r.num {
+		case 1:
+			v.ID = r.string()
+		case 2:
+			v.X = r.double()
+		case 3:
+			v.Y = r.double()
+		case 4:
+			v.Rotation = r.double()
+		case 5:
+			v.Color = r.string()
+		case 6:
+			r.packedDoubles(v.TrailX[:])
+		case 7:
+			r.packedDoubles(v.TrailY[:])
+		case 8:
+			v.TrailCount = r.int()
+		}
+	}
+	return r.err
+}
+
+func decodeLoot(b []byte, v *LootData) error {
+	r := wireReader{b: b}
+	for r.next() {
+		switch r.num {
+		case 1:
+			v.X = r.double()
+		case 2:
+			v.Y = r.double()
+		case 3:
+			v.Amount = r.int()
+		case 4:
+			v.Remaining = r.double()
+		}
+	}
+	return r.err
+}
+
+func decodeWeaponDrop(b []byte, v *WeaponDropData) error {
+	r := wireReader{b: b}
+	for r.next() {
+		switch r.num {
+		case 1:
+			v.X = r.double()
+		case 2:
+			v.Y = r.double()
+		case 3:
+			v.Weapon = r.string()
+		case 4:
+			v.Remaining = r.double()
+		}
+	}
+	return r.err
+}
+
+func decodeAirstrike(b []byte, v *AirstrikeData) error {
+	r := wireReader{b: b}
+	for r.next() {
+		switch r.num {
+		case 1:
+			v.X = r.double()
+		case 2:
+			v.Y = r.double()
+		case 3:
+			v.Radius = r.double()
+		case 4:
+			v.Progress = r.double()
+		case 5:
+			v.Caller = r.string()
+		}
+	}
+	return r.err
+}
+
+func decodeShop(b []byte, v *ShopData) error {
+	r := wireReader{b: b}
+	for r.next() {
+		switch r.num {
+		case 1:
+			v.X = r.double()
+		case 2:
+			v.Y = r.double()
+		case 3:
+			v.Radius = r.double()
+		case 4:
+			v.Name = r.string()
+		case 5:
+			v.Stock = append(v.Stock, r.string())
+		case 6:
+			v.Orders = r.int()
+		}
+	}
+	return r.err
+}
+
+func decodeMeteor(b []byte, v *MeteorData) error {
+	r := wireReader{b: b}
+	for r.next() {
+		switch r.num {
+		case 1:
+			v.X = r.double()
+		case 2:
+			v.Y = r.double()
+		case 3:
+			v.Radius = r.double()
+		case 4:
+			v.Progress = r.double()
+		}
+	}
+	return r.err
+}
@@ -0,0 +1,170 @@
+package ipc
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// fillValue sets every field of v (recursively) to a value derived from seed,
+// so a codec that forgets a field fails the round trip
+func fillValue(v reflect.Value, seed int) {
+	switch v.Kind() {
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			fillValue(v.Field(i), seed*31+i+1)
+		}
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			fillValue(v.Index(i), seed+i+1)
+		}
+	case reflect.Slice:
+		v.Set(reflect.MakeSlice(v.Type(), 2, 2))
+		for i := 0; i < v.Len(); i++ {
+			fillValue(v.Index(i), seed+i+1)
+		}
+	case reflect.String:
+		v.SetString(fmt.Sprintf("s%d", seed))
+	case reflect.Bool:
+		v.SetBool(true)
+	case reflect.Int, reflect.Int64:
+		v.SetInt(int64(-seed)) // Negative values take the zigzag path
+	case reflect.Uint64:
+		v.SetUint(uint64(seed))
+	case reflect.Float64:
+		v.SetFloat(float64(seed) + 0.25)
+	}
+}
+
+// testSnapshot returns a snapshot with every field set
+func testSnapshot() *SnapshotMessage {
+	var msg SnapshotMessage
+	fillValue(reflect.ValueOf(&msg).Elem(), 1)
+
+	// Positional arrays with gaps and a short tail
+	msg.VoteOptions[1] = ""
+	msg.VoteCounts[2] = 0
+	msg.LeaderboardNames[4] = ""
+	msg.ChaosMeteors[7] = MeteorData{}
+	msg.Projectiles[0].TrailX[3] = 0
+	return &msg
+}
+
+// TestCodecRoundTrip verifies every codec decodes its frames to the snapshot
+// it was given
+func TestCodecRoundTrip(t *testing.T) {
+	want := testSnapshot()
+	for _, codec := range codecs {
+		t.Run(codec.Name(), func(t *testing.T) {
+			frame, err := AppendSnapshotFrame(nil, codec, want)
+			if err != nil {
+				t.Fatalf("Encode failed: %v", err)
+			}
+
+			header, body, err := ReadFrame(bytes.NewReader(frame))
+			if err != nil {
+				t.Fatalf("Read failed: %v", err)
+			}
+			if header.Type != MsgTypeSnapshot || header.Codec != codec.ID() {
+				t.Fatalf("Expected a snapshot frame with codec %d, got type %d codec %d", codec.ID(), header.Type, header.Codec)
+			}
+			got, err := DecodeSnapshotFrame(header, body)
+			if err != nil {
+				t.Fatalf("Decode failed: %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Round trip changed the snapshot:\ngot  %+v\nwant %+v", got, want)
+			}
+		})
+	}
+}
+
+// TestCodecByName verifies the IPC_CODEC names and the default
+func TestCodecByName(t *testing.T) {
+	for _, name := range []string{"gob", "protobuf", "Protobuf"} {
+		if _, err := CodecByName(name); err != nil {
+			t.Errorf("Expected codec %q, got %v", name, err)
+		}
+	}
+	if _, err := CodecByName("flatbuffers"); err == nil {
+		t.Error("Expected an error for an unknown codec")
+	}
+	if _, err := CodecByID(0xff); err == nil {
+		t.Error("Expected an error for an unknown codec ID")
+	}
+	if NewPublisher("").codec.ID() != CodecGob {
+		t.Error("Expected publishers to default to gob")
+	}
+}
+
+// TestProtobufUnknownFields verifies fields from a newer server are skipped
+func TestProtobufUnknownFields(t *testing.T) {
+	want := &SnapshotMessage{Sequence: 7, Modifier: "lowgrav"}
+	data, _ := protobufCodec{}.AppendSnapshot(nil, want)
+	data = protowire.AppendTag(data, 999, protowire.BytesType)
+	data = protowire.AppendString(data, "from the future")
+	data = protowire.AppendTag(data, 1000, protowire.Fixed64Type)
+	data = protowire.AppendFixed64(data, 42)
+
+	got, err := protobufCodec{}.DecodeSnapshot(data)
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %+v, got %+v", want, got)
+	}
+
+	if _, err := (protobufCodec{}).DecodeSnapshot(data[:len(data)-3]); err == nil {
+		t.Error("Expected an error for a truncated snapshot")
+	}
+}
+
+// benchmarkSnapshot returns a busy arena snapshot with n fighters
+func benchmarkSnapshot(n int) *SnapshotMessage {
+	msg := testSnapshot()
+	msg.Players = make([]PlayerData, n)
+	for i := range msg.Players {
+		fillValue(reflect.ValueOf(&msg.Players[i]).Elem(), i)
+	}
+	msg.Particles = make([]ParticleData, n*2)
+	for i := range msg.Particles {
+		fillValue(reflect.ValueOf(&msg.Particles[i]).Elem(), i)
+	}
+	return msg
+}
+
+func BenchmarkSnapshotEncode(b *testing.B) {
+	for _, n := range []int{50, 500} {
+		msg := benchmarkSnapshot(n)
+		for _, codec := range codecs {
+			b.Run(fmt.Sprintf("%s/%dPlayers", codec.Name(), n), func(b *testing.B) {
+				var buf []byte
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					buf, _ = AppendSnapshotFrame(buf[:0], codec, msg)
+				}
+				b.ReportMetric(float64(len(buf)), "bytes/frame")
+			})
+		}
+	}
+}
+
+func BenchmarkSnapshotDecode(b *testing.B) {
+	for _, n := range []int{50, 500} {
+		msg := benchmarkSnapshot(n)
+		for _, codec := range codecs {
+			b.Run(fmt.Sprintf("%s/%dPlayers", codec.Name(), n), func(b *testing.B) {
+				data, _ := codec.AppendSnapshot(nil, msg)
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					if _, err := codec.DecodeSnapshot(data); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
@@ -5,12 +5,15 @@ package ipc
 import (
 	"encoding/binary"
 	"encoding/gob"
+	"errors"
 	"fmt"
 	"io"
 	"net"
 	"os"
 	"sync"
 	"time"
+
+	"fight-club/internal/game"
 )
 
 const (
@@ -28,6 +31,15 @@ const (
 	MsgTypePong     byte = 0x03
 	MsgTypeConfig   byte = 0x04
 
+	// MsgTypeTelemetry flows the other way: streamer health, sent by the
+	// Subscriber every TelemetryInterval (see TelemetryMessage)
+	MsgTypeTelemetry byte = 0x05
+
+	// MsgTypeControl asks the streamer to start or stop its pipeline (server →
+	// streamer); the streamer answers with MsgTypeControlResult
+	MsgTypeControl       byte = 0x06
+	MsgTypeControlResult byte = 0x07
+
 	// Protocol version for compatibility checking
 	ProtocolVersion uint16 = 1
 
@@ -37,6 +49,15 @@ const (
 	ReadTimeout    = 100 * time.Millisecond
 	ReconnectDelay = 500 * time.Millisecond
 	MaxReconnects  = 20
+
+	// Streamer telemetry cadence; the server treats reports older than
+	// TelemetryStale as a streamer that went away
+	TelemetryInterval = time.Second
+	TelemetryStale    = 5 * time.Second
+
+	// ControlTimeout bounds a stream control round trip (starting the stream
+	// spawns FFmpeg on the streamer)
+	ControlTimeout = 10 * time.Second
 )
 
 // SnapshotMessage wraps a game snapshot for IPC transmission
@@ -44,6 +65,7 @@ type SnapshotMessage struct {
 	Sequence   uint64
 	Timestamp  int64 // Unix nano
 	TickNumber uint64
+	TickRate   int // Interpolation hint (see game.GameSnapshot.TickRate)
 
 	// Player data
 	Players []PlayerData
@@ -55,12 +77,81 @@ type SnapshotMessage struct {
 	Trails      []TrailData
 	Flashes     []FlashData
 	Projectiles []ProjectileData
+	Loot        []LootData
+	WeaponDrops []WeaponDropData
+	Airstrikes  []AirstrikeData
+	Shops       []ShopData
+	PitBand     float64 // Ring-out pit width around the arena (0 = no ring-outs)
 
 	// Screen shake
 	ShakeOffsetX   float64
 	ShakeOffsetY   float64
 	ShakeIntensity float64
 
+	// Arena vote / active modifier
+	VoteOpen      bool
+	VoteOptions   [3]string
+	VoteCounts    [3]int
+	VoteRemaining float64
+	VotePoll      bool
+	Modifier      string
+
+	// Active duel ring
+	DuelActive    bool
+	DuelX         float64
+	DuelY         float64
+	DuelRadius    float64
+	DuelPlayers   [2]string
+	DuelRemaining float64
+
+	// Chaos event (warning banner, shrinking zone, gravity flip, falling meteors)
+	ChaosEvent       string
+	ChaosWarning     bool
+	ChaosRemaining   float64
+	ChaosZoneX       float64
+	ChaosZoneY       float64
+	ChaosZoneRadius  float64
+	ChaosGravity     float64
+	ChaosMeteors     [8]MeteorData
+	ChaosMeteorCount int
+
+	// Background time of day and weather overlay
+	SceneTime             string
+	SceneWeather          string
+	SceneWeatherIntensity float64
+
+	// Persistent leaderboard rotator (today / this week / all time)
+	LeaderboardPeriod string
+	LeaderboardNames  [5]string
+	LeaderboardKills  [5]int
+	LeaderboardCount  int
+
+	// Quest completion toast (QuestToastUser "" = hidden)
+	QuestToastUser   string
+	QuestToastQuest  string
+	QuestToastReward int
+
+	// "Up next" join queue (JoinQueueTotal 0 = hidden)
+	JoinQueueNames [3]string
+	JoinQueueCount int
+	JoinQueueTotal int
+
+	// Simulation paused by the broadcaster
+	Paused bool
+
+	// Live Kick viewer count (0 = unknown/offline)
+	ViewerCount int
+
+	// Auto-stream: the streamer runs FFmpeg only while StreamLive
+	AutoStream bool
+	StreamLive bool
+
+	// Slow-motion replay cue (see game.ReplayCue)
+	ReplaySeq     uint64
+	ReplayReason  string
+	ReplaySeconds float64
+	ReplaySpeed   float64
+
 	// Aggregate stats
 	PlayerCount int
 	AliveCount  int
@@ -84,6 +175,8 @@ type PlayerData struct {
 	IsDead          bool
 	IsRagdoll       bool
 	RagdollRotation float64
+	RingOut         bool
+	FallProgress    float64
 	SpawnProtection bool
 	IsAttacking     bool
 	ProfilePic      string
@@ -91,6 +184,24 @@ type PlayerData struct {
 	DodgeDirection  float64
 	ComboCount      int
 	Stamina         float64
+	IsSprinting     bool
+	IsExhausted     bool
+	Emote           string
+	EmoteProgress   float64
+	ChatBubble      string
+	ChatBubbleTTL   float64
+	Personality     string
+	Rank            string
+	TeamColor       string
+	SkinBorder      string
+	SkinTrail       string
+	SkinNameplate   string
+	IsCheered       bool
+	IsCursed        bool
+	IsRallied       bool
+	IsRegrouping    bool
+	RegroupX        float64
+	RegroupY        float64
 }
 
 // ParticleData is the IPC representation of a particle
@@ -98,6 +209,7 @@ type ParticleData struct {
 	X, Y  float64
 	Color string
 	Alpha float64
+	Size  float64
 }
 
 // EffectData is the IPC representation of an attack effect
@@ -141,6 +253,7 @@ type FlashData struct {
 
 // ProjectileData is the IPC representation of a projectile
 type ProjectileData struct {
+	ID         string
 	X, Y       float64
 	Rotation   float64
 	Color      string
@@ -149,20 +262,117 @@ type ProjectileData struct {
 	TrailCount int
 }
 
+// LootData is the IPC representation of a coin pile
+type LootData struct {
+	X, Y      float64
+	Amount    int
+	Remaining float64
+}
+
+// WeaponDropData is the IPC representation of a dropped weapon
+type WeaponDropData struct {
+	X, Y      float64
+	Weapon    string
+	Remaining float64
+}
+
+// AirstrikeData is the IPC representation of an incoming airstrike
+type AirstrikeData struct {
+	X, Y     float64
+	Radius   float64
+	Progress float64
+	Caller   string
+}
+
+// ShopData is the IPC representation of a weapon shop
+type ShopData struct {
+	X, Y   float64
+	Radius float64
+	Name   string
+	Stock  []string
+	Orders int
+}
+
+// MeteorData is the IPC representation of a falling meteor
+type MeteorData struct {
+	X, Y     float64
+	Radius   float64
+	Progress float64
+}
+
 // ConfigMessage contains streaming configuration
 type ConfigMessage struct {
 	Width   int
 	Height  int
 	FPS     int
 	Bitrate int
+
+	// Weapons from the server's weapons file (nil = built-ins only), so the
+	// streamer animates and voices them like the server simulates them
+	Weapons []game.WeaponDefinition
+}
+
+// ErrNotConnected is returned when sending while the server is unreachable
+var ErrNotConnected = errors.New("ipc: not connected")
+
+// TelemetryMessage reports streamer health to the game server (streamer →
+// server), so the server's /api/stats shows the real stream
+type TelemetryMessage struct {
+	Timestamp int64 // Unix nano when sampled
+
+	Streaming         bool
+	Reconnecting      bool
+	ReconnectAttempts int32
+	ConnectionLost    bool
+	UptimeSeconds     float64
+
+	FramesSent    int64
+	FramesDropped int64   // Frames the FFmpeg writer couldn't take
+	ActualFPS     float64 // Frames sent per second of uptime
+	AvgFrameMs    float64 // Render time per frame
+	EncoderSpeed  float64 // FFmpeg speed (1.0 = real time, 0 = unknown)
+
+	Width, Height             int // Output resolution
+	EncodeWidth, EncodeHeight int // FFmpeg input (adaptive resolution)
+	FPS                       int
+	Bitrate                   int
+	Renderer                  string
+
+	SnapshotsReceived int64
+	LastError         string
+}
+
+// ErrControlTimeout is returned when the streamer doesn't answer a control
+// command within ControlTimeout
+var ErrControlTimeout = errors.New("ipc: streamer did not answer")
+
+// ControlCommand is a stream control action for the streamer process
+type ControlCommand string
+
+const (
+	ControlStart    ControlCommand = "start"    // Go live
+	ControlStop     ControlCommand = "stop"     // End the broadcast, stay ready to start again
+	ControlKeyframe ControlCommand = "keyframe" // Send a keyframe now (viewers' picture is corrupted)
+)
+
+// ControlMessage asks the streamer to run a command (server → streamer)
+type ControlMessage struct {
+	ID      uint64
+	Command ControlCommand
+}
+
+// ControlResult answers a ControlMessage (streamer → server)
+type ControlResult struct {
+	ID    uint64
+	Error string // Empty on success
 }
 
 // Header is the message header for framing
 type Header struct {
-	Version  uint16
-	Type     byte
-	Reserved byte
-	Length   uint32
+	Version uint16
+	Type    byte
+	Codec   byte // Snapshot codec (see codec.go), 0 = gob
+	Length  uint32
 }
 
 const HeaderSize = 8 // 2 + 1 + 1 + 4
@@ -188,18 +398,11 @@ func WriteMessage(w io.Writer, msgType byte, data interface{}) error {
 	}
 
 	// Write header
-	header := Header{
+	headerBuf := appendHeader(make([]byte, 0, HeaderSize), Header{
 		Version: ProtocolVersion,
 		Type:    msgType,
 		Length:  uint32(len(buf)),
-	}
-
-	headerBuf := make([]byte, HeaderSize)
-	binary.LittleEndian.PutUint16(headerBuf[0:2], header.Version)
-	headerBuf[2] = header.Type
-	headerBuf[3] = header.Reserved
-	binary.LittleEndian.PutUint32(headerBuf[4:8], header.Length)
-
+	})
 	if _, err := w.Write(headerBuf); err != nil {
 		return fmt.Errorf("write header: %w", err)
 	}
@@ -214,26 +417,63 @@ func WriteMessage(w io.Writer, msgType byte, data interface{}) error {
 	return nil
 }
 
+// AppendSnapshotFrame appends a framed snapshot encoded with codec to dst.
+// The publisher encodes each snapshot once and writes the frame to every
+// streamer.
+func AppendSnapshotFrame(dst []byte, codec SnapshotCodec, msg *SnapshotMessage) ([]byte, error) {
+	start := len(dst)
+	frame, err := codec.AppendSnapshot(appendHeader(dst, Header{}), msg)
+	if err != nil {
+		return dst, err
+	}
+	length := len(frame) - start - HeaderSize
+	if length > MaxMessageSize {
+		return dst, fmt.Errorf("message too large: %d > %d", length, MaxMessageSize)
+	}
+
+	appendHeader(frame[start:start], Header{
+		Version: ProtocolVersion,
+		Type:    MsgTypeSnapshot,
+		Codec:   codec.ID(),
+		Length:  uint32(length),
+	})
+	return frame, nil
+}
+
+// appendHeader appends an encoded frame header to dst
+func appendHeader(dst []byte, header Header) []byte {
+	dst = binary.LittleEndian.AppendUint16(dst, header.Version)
+	dst = append(dst, header.Type, header.Codec)
+	return binary.LittleEndian.AppendUint32(dst, header.Length)
+}
+
 // ReadMessage reads a framed message from the connection
 func ReadMessage(r io.Reader) (byte, []byte, error) {
+	header, body, err := ReadFrame(r)
+	return header.Type, body, err
+}
+
+// ReadFrame reads a framed message and its header (for the snapshot codec)
+func ReadFrame(r io.Reader) (Header, []byte, error) {
 	// Read header
 	headerBuf := make([]byte, HeaderSize)
 	if _, err := io.ReadFull(r, headerBuf); err != nil {
-		return 0, nil, fmt.Errorf("read header: %w", err)
+		return Header{}, nil, fmt.Errorf("read header: %w", err)
 	}
 
 	header := Header{
 		Version: binary.LittleEndian.Uint16(headerBuf[0:2]),
 		Type:    headerBuf[2],
+		Codec:   headerBuf[3],
 		Length:  binary.LittleEndian.Uint32(headerBuf[4:8]),
 	}
 
 	if header.Version != ProtocolVersion {
-		return 0, nil, fmt.Errorf("version mismatch: got %d, want %d", header.Version, ProtocolVersion)
+		return Header{}, nil, fmt.Errorf("version mismatch: got %d, want %d", header.Version, ProtocolVersion)
 	}
 
 	if header.Length > MaxMessageSize {
-		return 0, nil, fmt.Errorf("message too large: %d > %d", header.Length, MaxMessageSize)
+		return Header{}, nil, fmt.Errorf("message too large: %d > %d", header.Length, MaxMessageSize)
 	}
 
 	// Read body
@@ -241,11 +481,20 @@ func ReadMessage(r io.Reader) (byte, []byte, error) {
 	if header.Length > 0 {
 		body = make([]byte, header.Length)
 		if _, err := io.ReadFull(r, body); err != nil {
-			return 0, nil, fmt.Errorf("read body: %w", err)
+			return Header{}, nil, fmt.Errorf("read body: %w", err)
 		}
 	}
 
-	return header.Type, body, nil
+	return header, body, nil
+}
+
+// DecodeSnapshotFrame decodes a snapshot with the codec named in its frame header
+func DecodeSnapshotFrame(header Header, data []byte) (*SnapshotMessage, error) {
+	codec, err := CodecByID(header.Codec)
+	if err != nil {
+		return nil, err
+	}
+	return codec.DecodeSnapshot(data)
 }
 
 // DecodeSnapshot decodes a snapshot from gob bytes
@@ -274,6 +523,45 @@ func DecodeConfig(data []byte) (*ConfigMessage, error) {
 	return &msg, nil
 }
 
+// DecodeTelemetry decodes streamer telemetry from gob bytes
+func DecodeTelemetry(data []byte) (*TelemetryMessage, error) {
+	var buf = getBytesBuffer(data)
+	defer putBytesBuffer(buf)
+
+	dec := gob.NewDecoder(buf)
+	var msg TelemetryMessage
+	if err := dec.Decode(&msg); err != nil {
+		return nil, fmt.Errorf("gob decode telemetry: %w", err)
+	}
+	return &msg, nil
+}
+
+// DecodeControl decodes a stream control command from gob bytes
+func DecodeControl(data []byte) (*ControlMessage, error) {
+	var buf = getBytesBuffer(data)
+	defer putBytesBuffer(buf)
+
+	dec := gob.NewDecoder(buf)
+	var msg ControlMessage
+	if err := dec.Decode(&msg); err != nil {
+		return nil, fmt.Errorf("gob decode control: %w", err)
+	}
+	return &msg, nil
+}
+
+// DecodeControlResult decodes a stream control result from gob bytes
+func DecodeControlResult(data []byte) (*ControlResult, error) {
+	var buf = getBytesBuffer(data)
+	defer putBytesBuffer(buf)
+
+	dec := gob.NewDecoder(buf)
+	var msg ControlResult
+	if err := dec.Decode(&msg); err != nil {
+		return nil, fmt.Errorf("gob decode control result: %w", err)
+	}
+	return &msg, nil
+}
+
 // CleanupSocket removes the socket file if it exists
 func CleanupSocket(path string) error {
 	if _, err := os.Stat(path); err == nil {
@@ -1,6 +1,7 @@
 package ipc
 
 import (
+	"errors"
 	"log"
 	"net"
 	"sync"
@@ -22,9 +23,30 @@ type Publisher struct {
 	// Snapshot channel (ring buffer behavior - drop old if full)
 	snapshotCh chan *game.GameSnapshot
 
-	// Config to send to new clients
+	// Snapshot encoding; each snapshot is framed once into frameBuf (owned
+	// by the broadcast loop) and written to every streamer
+	codec    SnapshotCodec
+	frameBuf []byte
+
+	// Config to send to new clients (and to connected ones when it changes)
 	config   ConfigMessage
 	configMu sync.RWMutex
+	configCh chan struct{} // Signals the broadcast loop to resend the config
+
+	// Optional snapshot recording to disk (see recording.go)
+	recorder atomic.Pointer[SnapshotRecorder]
+
+	// Latest streamer telemetry (streamer → server)
+	telemetry   TelemetryMessage
+	telemetryAt time.Time
+	telemetryMu sync.RWMutex
+
+	// Stream control (server → streamer): commands are written by the
+	// broadcast loop, results are matched to callers by ID
+	controlCh   chan ControlMessage
+	controlID   uint64 // atomic
+	controlWait map[uint64]chan ControlResult
+	controlMu   sync.Mutex
 
 	// Stats
 	clientCount   int32 // atomic
@@ -35,6 +57,8 @@ type Publisher struct {
 	running int32 // atomic
 	stopCh  chan struct{}
 	wg      sync.WaitGroup
+
+	keepSocket int32 // atomic: the listener was handed to a new process (see Handoff)
 }
 
 // NewPublisher creates a new IPC publisher
@@ -44,14 +68,24 @@ func NewPublisher(socketPath string) *Publisher {
 	}
 
 	return &Publisher{
-		socketPath: socketPath,
-		clients:    make(map[net.Conn]struct{}),
-		snapshotCh: make(chan *game.GameSnapshot, 8), // Buffer 8 frames
-		stopCh:     make(chan struct{}),
+		socketPath:  socketPath,
+		clients:     make(map[net.Conn]struct{}),
+		snapshotCh:  make(chan *game.GameSnapshot, 8), // Buffer 8 frames
+		codec:       gobCodec{},
+		configCh:    make(chan struct{}, 1),
+		controlCh:   make(chan ControlMessage, 4),
+		controlWait: make(map[uint64]chan ControlResult),
+		stopCh:      make(chan struct{}),
 	}
 }
 
-// SetConfig sets the streaming configuration to send to new clients
+// SetCodec sets the snapshot encoding (gob by default). Call before Start.
+func (p *Publisher) SetCodec(codec SnapshotCodec) {
+	p.codec = codec
+}
+
+// SetConfig sets the streaming configuration to send to new clients.
+// Connected streamers receive it too (e.g. after an arena preset change).
 func (p *Publisher) SetConfig(width, height, fps, bitrate int) {
 	p.configMu.Lock()
 	p.config = ConfigMessage{
@@ -59,22 +93,63 @@ func (p *Publisher) SetConfig(width, height, fps, bitrate int) {
 		Height:  height,
 		FPS:     fps,
 		Bitrate: bitrate,
+		Weapons: p.config.Weapons,
 	}
 	p.configMu.Unlock()
+
+	select {
+	case p.configCh <- struct{}{}:
+	default:
+	}
+}
+
+// SetWeapons sends the weapons file's definitions to the streamer (on
+// connect and now, if connected)
+func (p *Publisher) SetWeapons(defs []game.WeaponDefinition) {
+	p.configMu.Lock()
+	p.config.Weapons = defs
+	p.configMu.Unlock()
+
+	select {
+	case p.configCh <- struct{}{}:
+	default:
+	}
+}
+
+// SetRecorder records every broadcast snapshot and config change to rec
+// (nil stops recording). The current config is recorded first.
+func (p *Publisher) SetRecorder(rec *SnapshotRecorder) {
+	if rec != nil {
+		p.configMu.RLock()
+		config := p.config
+		p.configMu.RUnlock()
+		if err := rec.RecordConfig(config); err != nil {
+			log.Printf("⚠️ Snapshot recording failed: %v", err)
+		}
+	}
+	p.recorder.Store(rec)
 }
 
 // Start starts the publisher server
 func (p *Publisher) Start() error {
-	if !atomic.CompareAndSwapInt32(&p.running, 0, 1) {
+	if atomic.LoadInt32(&p.running) == 1 {
 		return nil // Already running
 	}
 
 	// Use platform-specific listener (Unix socket on Linux/macOS, TCP on Windows)
 	listener, err := CreatePlatformListener(p.socketPath)
 	if err != nil {
-		atomic.StoreInt32(&p.running, 0)
 		return err
 	}
+	return p.StartWithListener(listener)
+}
+
+// StartWithListener starts the publisher on an existing listener
+// (e.g. one inherited from the previous process in a graceful restart)
+func (p *Publisher) StartWithListener(listener net.Listener) error {
+	if !atomic.CompareAndSwapInt32(&p.running, 0, 1) {
+		return nil // Already running
+	}
 	p.listener = listener
 
 	// Start accept loop
@@ -111,10 +186,20 @@ func (p *Publisher) Stop() {
 
 	p.wg.Wait()
 
-	CleanupSocket(p.socketPath)
+	if atomic.LoadInt32(&p.keepSocket) == 0 {
+		CleanupSocket(p.socketPath)
+	}
 	log.Println("📡 IPC Publisher stopped")
 }
 
+// Handoff stops the publisher like Stop but leaves the socket file in place:
+// a new server process inherited the listener and keeps accepting on it.
+// Connected streamers are closed and reconnect to the new process.
+func (p *Publisher) Handoff() {
+	atomic.StoreInt32(&p.keepSocket, 1)
+	p.Stop()
+}
+
 // PublishSnapshot queues a snapshot for broadcast
 // This is non-blocking - drops the oldest snapshot if buffer is full
 func (p *Publisher) PublishSnapshot(snapshot *game.GameSnapshot) {
@@ -140,6 +225,11 @@ func (p *Publisher) PublishSnapshot(snapshot *game.GameSnapshot) {
 	}
 }
 
+// IsRunning returns whether the publisher is accepting streamer connections
+func (p *Publisher) IsRunning() bool {
+	return atomic.LoadInt32(&p.running) == 1
+}
+
 // GetStats returns publisher statistics
 func (p *Publisher) GetStats() (clients int, sent int64, dropped int64) {
 	return int(atomic.LoadInt32(&p.clientCount)),
@@ -185,6 +275,101 @@ func (p *Publisher) addClient(conn net.Conn) {
 			log.Printf("⚠️ Failed to send config to streamer: %v", err)
 		}
 	}()
+
+	p.wg.Add(1)
+	go p.readLoop(conn)
+}
+
+// readLoop reads the streamer's messages (telemetry, control results) until the connection
+// closes. Reads never time out: Stop and removeClient close the connection.
+func (p *Publisher) readLoop(conn net.Conn) {
+	defer p.wg.Done()
+	defer p.removeClient(conn)
+
+	for {
+		msgType, data, err := ReadMessage(conn)
+		if err != nil {
+			return
+		}
+		switch msgType {
+		case MsgTypeTelemetry:
+			msg, err := DecodeTelemetry(data)
+			if err != nil {
+				log.Printf("⚠️ Bad streamer telemetry: %v", err)
+				continue
+			}
+			p.telemetryMu.Lock()
+			p.telemetry = *msg
+			p.telemetryAt = time.Now()
+			p.telemetryMu.Unlock()
+
+		case MsgTypeControlResult:
+			result, err := DecodeControlResult(data)
+			if err != nil {
+				log.Printf("⚠️ Bad stream control result: %v", err)
+				continue
+			}
+			p.controlMu.Lock()
+			if ch, ok := p.controlWait[result.ID]; ok {
+				select {
+				case ch <- *result:
+				default: // Another streamer answered first
+				}
+			}
+			p.controlMu.Unlock()
+		}
+	}
+}
+
+// SendControl asks the connected streamer(s) to run a stream control command
+// and waits for the answer. Returns ErrNotConnected without a streamer,
+// ErrControlTimeout if none answers, or the streamer's error.
+func (p *Publisher) SendControl(cmd ControlCommand) error {
+	if atomic.LoadInt32(&p.running) == 0 || atomic.LoadInt32(&p.clientCount) == 0 {
+		return ErrNotConnected
+	}
+
+	msg := ControlMessage{ID: atomic.AddUint64(&p.controlID, 1), Command: cmd}
+	resultCh := make(chan ControlResult, 1)
+	p.controlMu.Lock()
+	p.controlWait[msg.ID] = resultCh
+	p.controlMu.Unlock()
+	defer func() {
+		p.controlMu.Lock()
+		delete(p.controlWait, msg.ID)
+		p.controlMu.Unlock()
+	}()
+
+	timeout := time.NewTimer(ControlTimeout)
+	defer timeout.Stop()
+
+	select {
+	case p.controlCh <- msg:
+	case <-p.stopCh:
+		return ErrNotConnected
+	case <-timeout.C:
+		return ErrControlTimeout
+	}
+
+	select {
+	case result := <-resultCh:
+		if result.Error != "" {
+			return errors.New(result.Error)
+		}
+		return nil
+	case <-p.stopCh:
+		return ErrNotConnected
+	case <-timeout.C:
+		return ErrControlTimeout
+	}
+}
+
+// LatestTelemetry returns the last streamer telemetry and when it arrived.
+// ok is false until a streamer has reported.
+func (p *Publisher) LatestTelemetry() (msg TelemetryMessage, receivedAt time.Time, ok bool) {
+	p.telemetryMu.RLock()
+	defer p.telemetryMu.RUnlock()
+	return p.telemetry, p.telemetryAt, !p.telemetryAt.IsZero()
 }
 
 // removeClient removes a client connection
@@ -213,6 +398,12 @@ func (p *Publisher) broadcastLoop() {
 
 		case snapshot := <-p.snapshotCh:
 			p.broadcast(snapshot)
+
+		case <-p.configCh:
+			p.broadcastConfig()
+
+		case msg := <-p.controlCh:
+			p.broadcastControl(msg)
 		}
 	}
 }
@@ -220,6 +411,7 @@ func (p *Publisher) broadcastLoop() {
 // broadcast sends a snapshot to all connected clients
 func (p *Publisher) broadcast(snapshot *game.GameSnapshot) {
 	msg := snapshotToMessage(snapshot)
+	p.record(func(rec *SnapshotRecorder) error { return rec.RecordSnapshot(msg) })
 
 	p.clientsMu.RLock()
 	clients := make([]net.Conn, 0, len(p.clients))
@@ -227,11 +419,21 @@ func (p *Publisher) broadcast(snapshot *game.GameSnapshot) {
 		clients = append(clients, conn)
 	}
 	p.clientsMu.RUnlock()
+	if len(clients) == 0 {
+		return
+	}
+
+	frame, err := AppendSnapshotFrame(p.frameBuf[:0], p.codec, msg)
+	if err != nil {
+		log.Printf("⚠️ Failed to encode snapshot: %v", err)
+		return
+	}
+	p.frameBuf = frame
 
 	var failed []net.Conn
 	for _, conn := range clients {
 		conn.SetWriteDeadline(time.Now().Add(WriteTimeout))
-		if err := WriteMessage(conn, MsgTypeSnapshot, msg); err != nil {
+		if _, err := conn.Write(frame); err != nil {
 			failed = append(failed, conn)
 		}
 	}
@@ -246,18 +448,120 @@ func (p *Publisher) broadcast(snapshot *game.GameSnapshot) {
 	}
 }
 
+// broadcastConfig sends the current config to all clients (written by the
+// broadcast loop so it never interleaves with a snapshot)
+func (p *Publisher) broadcastConfig() {
+	p.configMu.RLock()
+	config := p.config
+	p.configMu.RUnlock()
+	p.record(func(rec *SnapshotRecorder) error { return rec.RecordConfig(config) })
+
+	p.clientsMu.RLock()
+	clients := make([]net.Conn, 0, len(p.clients))
+	for conn := range p.clients {
+		clients = append(clients, conn)
+	}
+	p.clientsMu.RUnlock()
+
+	for _, conn := range clients {
+		conn.SetWriteDeadline(time.Now().Add(WriteTimeout))
+		if err := WriteMessage(conn, MsgTypeConfig, config); err != nil {
+			p.removeClient(conn)
+		}
+	}
+}
+
+// broadcastControl sends a stream control command to all clients (not
+// recorded: a rerender has no stream to control)
+func (p *Publisher) broadcastControl(msg ControlMessage) {
+	p.clientsMu.RLock()
+	clients := make([]net.Conn, 0, len(p.clients))
+	for conn := range p.clients {
+		clients = append(clients, conn)
+	}
+	p.clientsMu.RUnlock()
+
+	for _, conn := range clients {
+		conn.SetWriteDeadline(time.Now().Add(WriteTimeout))
+		if err := WriteMessage(conn, MsgTypeControl, msg); err != nil {
+			p.removeClient(conn)
+		}
+	}
+}
+
+// record writes to the recorder, if any. A failed recording (disk full) is
+// dropped after logging once; streamers are unaffected.
+func (p *Publisher) record(write func(*SnapshotRecorder) error) {
+	rec := p.recorder.Load()
+	if rec == nil {
+		return
+	}
+	if err := write(rec); err != nil {
+		log.Printf("⚠️ Snapshot recording stopped: %v", err)
+		p.recorder.CompareAndSwap(rec, nil)
+	}
+}
+
 // snapshotToMessage converts a game snapshot to IPC message
 func snapshotToMessage(s *game.GameSnapshot) *SnapshotMessage {
 	msg := &SnapshotMessage{
 		Sequence:       s.Sequence,
 		Timestamp:      s.Timestamp.UnixNano(),
 		TickNumber:     s.TickNumber,
+		TickRate:       s.TickRate,
 		PlayerCount:    s.PlayerCount,
 		AliveCount:     s.AliveCount,
 		TotalKills:     s.TotalKills,
 		ShakeOffsetX:   s.Shake.OffsetX,
 		ShakeOffsetY:   s.Shake.OffsetY,
 		ShakeIntensity: s.Shake.Intensity,
+		VoteOpen:       s.Vote.Voting,
+		VoteOptions:    s.Vote.Options,
+		VoteCounts:     s.Vote.Counts,
+		VoteRemaining:  s.Vote.Remaining,
+		VotePoll:       s.Vote.Poll,
+		Modifier:       s.Vote.Modifier,
+		Paused:         s.Paused,
+		ViewerCount:    s.ViewerCount,
+		AutoStream:     s.AutoStream,
+		StreamLive:     s.StreamLive,
+
+		DuelActive:    s.Duel.Active,
+		DuelX:         s.Duel.X,
+		DuelY:         s.Duel.Y,
+		DuelRadius:    s.Duel.Radius,
+		DuelPlayers:   s.Duel.Players,
+		DuelRemaining: s.Duel.Remaining,
+
+		ChaosEvent:       s.Chaos.Event,
+		ChaosWarning:     s.Chaos.Warning,
+		ChaosRemaining:   s.Chaos.Remaining,
+		ChaosZoneX:       s.Chaos.ZoneX,
+		ChaosZoneY:       s.Chaos.ZoneY,
+		ChaosZoneRadius:  s.Chaos.ZoneRadius,
+		ChaosGravity:     s.Chaos.Gravity,
+		ChaosMeteorCount: s.Chaos.MeteorCount,
+
+		SceneTime:             s.Scene.Time,
+		SceneWeather:          s.Scene.Weather,
+		SceneWeatherIntensity: s.Scene.WeatherIntensity,
+
+		LeaderboardPeriod: string(s.Leaderboard.Period),
+		LeaderboardNames:  s.Leaderboard.Names,
+		LeaderboardKills:  s.Leaderboard.Kills,
+		LeaderboardCount:  s.Leaderboard.Count,
+
+		QuestToastUser:   s.QuestToast.Username,
+		QuestToastQuest:  s.QuestToast.Quest,
+		QuestToastReward: s.QuestToast.Reward,
+
+		JoinQueueNames: s.JoinQueue.Names,
+		JoinQueueCount: s.JoinQueue.Count,
+		JoinQueueTotal: s.JoinQueue.Total,
+	}
+
+	for i, m := range s.Chaos.Meteors {
+		msg.ChaosMeteors[i] = MeteorData{X: m.X, Y: m.Y, Radius: m.Radius, Progress: m.Progress}
 	}
 
 	// Convert players
@@ -282,6 +586,8 @@ func snapshotToMessage(s *game.GameSnapshot) *SnapshotMessage {
 			IsDead:          p.IsDead,
 			IsRagdoll:       p.IsRagdoll,
 			RagdollRotation: p.RagdollRotation,
+			RingOut:         p.RingOut,
+			FallProgress:    p.FallProgress,
 			SpawnProtection: p.SpawnProtection,
 			IsAttacking:     p.IsAttacking,
 			ProfilePic:      p.ProfilePic,
@@ -289,6 +595,24 @@ func snapshotToMessage(s *game.GameSnapshot) *SnapshotMessage {
 			DodgeDirection:  p.DodgeDirection,
 			ComboCount:      p.ComboCount,
 			Stamina:         p.Stamina,
+			IsSprinting:     p.IsSprinting,
+			IsExhausted:     p.IsExhausted,
+			Emote:           p.Emote,
+			EmoteProgress:   p.EmoteProgress,
+			ChatBubble:      p.ChatBubble,
+			ChatBubbleTTL:   p.ChatBubbleTTL,
+			Personality:     p.Personality,
+			Rank:            p.Rank,
+			TeamColor:       p.TeamColor,
+			SkinBorder:      p.Skins.Border,
+			SkinTrail:       p.Skins.Trail,
+			SkinNameplate:   p.Skins.Nameplate,
+			IsCheered:       p.IsCheered,
+			IsCursed:        p.IsCursed,
+			IsRallied:       p.IsRallied,
+			IsRegrouping:    p.IsRegrouping,
+			RegroupX:        p.RegroupX,
+			RegroupY:        p.RegroupY,
 		}
 	}
 
@@ -300,6 +624,7 @@ func snapshotToMessage(s *game.GameSnapshot) *SnapshotMessage {
 			Y:     p.Y,
 			Color: p.Color,
 			Alpha: p.Alpha,
+			Size:  p.Size,
 		}
 	}
 
@@ -363,6 +688,7 @@ func snapshotToMessage(s *game.GameSnapshot) *SnapshotMessage {
 	msg.Projectiles = make([]ProjectileData, len(s.Projectiles))
 	for i, p := range s.Projectiles {
 		msg.Projectiles[i] = ProjectileData{
+			ID:         p.ID,
 			X:          p.X,
 			Y:          p.Y,
 			Rotation:   p.Rotation,
@@ -373,5 +699,29 @@ func snapshotToMessage(s *game.GameSnapshot) *SnapshotMessage {
 		}
 	}
 
+	// Convert coin piles
+	msg.Loot = make([]LootData, len(s.Loot))
+	for i, l := range s.Loot {
+		msg.Loot[i] = LootData{X: l.X, Y: l.Y, Amount: l.Amount, Remaining: l.Remaining}
+	}
+	msg.WeaponDrops = make([]WeaponDropData, len(s.WeaponDrops))
+	for i, w := range s.WeaponDrops {
+		msg.WeaponDrops[i] = WeaponDropData{X: w.X, Y: w.Y, Weapon: w.Weapon, Remaining: w.Remaining}
+	}
+	msg.Airstrikes = make([]AirstrikeData, len(s.Airstrikes))
+	for i, a := range s.Airstrikes {
+		msg.Airstrikes[i] = AirstrikeData{X: a.X, Y: a.Y, Radius: a.Radius, Progress: a.Progress, Caller: a.Caller}
+	}
+
+	msg.Shops = make([]ShopData, len(s.Shops))
+	for i, sh := range s.Shops {
+		msg.Shops[i] = ShopData{X: sh.X, Y: sh.Y, Radius: sh.Radius, Name: sh.Name, Stock: sh.Stock, Orders: sh.Orders}
+	}
+	msg.PitBand = s.PitBand
+	msg.ReplaySeq = s.Replay.Seq
+	msg.ReplayReason = s.Replay.Reason
+	msg.ReplaySeconds = s.Replay.Seconds
+	msg.ReplaySpeed = s.Replay.Speed
+
 	return msg
 }
@@ -0,0 +1,190 @@
+package ipc
+
+import (
+	"bufio"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// RecordingExt is the file extension of snapshot recordings
+const RecordingExt = ".fcrec.gz"
+
+// SnapshotRecorder writes the snapshot stream to disk in the IPC wire format
+// (framed gob messages, gzip compressed), one file per server run. Config
+// messages are recorded too, so cmd/rerender knows the arena size at every
+// point of the recording.
+type SnapshotRecorder struct {
+	mu   sync.Mutex
+	path string
+	file *os.File
+	gz   *gzip.Writer
+	buf  *bufio.Writer
+
+	snapshots int64
+	err       error // First write error; recording stops after it
+}
+
+// NewSnapshotRecorder creates a new recording file in dir
+func NewSnapshotRecorder(dir string) (*SnapshotRecorder, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create recording dir: %w", err)
+	}
+	path := filepath.Join(dir, "snapshots-"+time.Now().Format("20060102-150405")+RecordingExt)
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("create recording: %w", err)
+	}
+
+	// BestSpeed: recording runs on the broadcast loop, and repeated gob type
+	// info in every message compresses well even at the lowest level
+	gz, _ := gzip.NewWriterLevel(file, gzip.BestSpeed)
+	return &SnapshotRecorder{
+		path: path,
+		file: file,
+		gz:   gz,
+		buf:  bufio.NewWriterSize(gz, 64*1024),
+	}, nil
+}
+
+// Path returns the recording file
+func (r *SnapshotRecorder) Path() string {
+	return r.path
+}
+
+// RecordSnapshot appends a snapshot
+func (r *SnapshotRecorder) RecordSnapshot(msg *SnapshotMessage) error {
+	return r.write(MsgTypeSnapshot, msg)
+}
+
+// RecordConfig appends a config change (arena size)
+func (r *SnapshotRecorder) RecordConfig(msg ConfigMessage) error {
+	return r.write(MsgTypeConfig, msg)
+}
+
+func (r *SnapshotRecorder) write(msgType byte, data interface{}) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.err != nil {
+		return r.err
+	}
+	if r.file == nil {
+		return errors.New("recording closed")
+	}
+	if err := WriteMessage(r.buf, msgType, data); err != nil {
+		r.err = err
+		return err
+	}
+	if msgType == MsgTypeSnapshot {
+		r.snapshots++
+	}
+	return nil
+}
+
+// Snapshots returns how many snapshots were recorded
+func (r *SnapshotRecorder) Snapshots() int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.snapshots
+}
+
+// Close flushes and closes the recording
+func (r *SnapshotRecorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return nil
+	}
+	err := r.buf.Flush()
+	if cerr := r.gz.Close(); err == nil {
+		err = cerr
+	}
+	if cerr := r.file.Close(); err == nil {
+		err = cerr
+	}
+	r.file = nil
+	return err
+}
+
+// SnapshotReader reads a recording made by SnapshotRecorder
+type SnapshotReader struct {
+	file    *os.File
+	gz      *gzip.Reader
+	buf     *bufio.Reader
+	config  ConfigMessage
+	pending *SnapshotMessage // First snapshot, read ahead for its config
+}
+
+// OpenSnapshotRecording opens a recording for reading. The first snapshot is
+// read ahead, so Config is the starting arena size right away.
+func OpenSnapshotRecording(path string) (*SnapshotReader, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("open recording: %w", err)
+	}
+	r := &SnapshotReader{file: file, gz: gz, buf: bufio.NewReaderSize(gz, 64*1024)}
+	if r.pending, err = r.read(); err != nil {
+		r.Close()
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("open recording: %s has no snapshots", path)
+		}
+		return nil, fmt.Errorf("open recording: %w", err)
+	}
+	return r, nil
+}
+
+// Next returns the next snapshot, or io.EOF at the end of the recording.
+// A recording cut short (server crash) ends at its last complete snapshot.
+func (r *SnapshotReader) Next() (*SnapshotMessage, error) {
+	if msg := r.pending; msg != nil {
+		r.pending = nil
+		return msg, nil
+	}
+	return r.read()
+}
+
+func (r *SnapshotReader) read() (*SnapshotMessage, error) {
+	for {
+		header, data, err := ReadFrame(r.buf)
+		if err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return nil, io.EOF
+			}
+			return nil, err
+		}
+
+		switch header.Type {
+		case MsgTypeSnapshot:
+			return DecodeSnapshotFrame(header, data)
+		case MsgTypeConfig:
+			cfg, err := DecodeConfig(data)
+			if err != nil {
+				return nil, err
+			}
+			r.config = *cfg
+		}
+	}
+}
+
+// Config returns the last config read (the arena size of the current snapshot)
+func (r *SnapshotReader) Config() ConfigMessage {
+	return r.config
+}
+
+// Close closes the recording
+func (r *SnapshotReader) Close() error {
+	r.gz.Close()
+	return r.file.Close()
+}
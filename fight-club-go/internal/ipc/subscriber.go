@@ -14,6 +14,7 @@ type Subscriber struct {
 	socketPath string
 	conn       net.Conn
 	connMu     sync.Mutex
+	writeMu    sync.Mutex // Serializes Pong, telemetry and control result writes
 
 	// Latest snapshot (lock-free access)
 	latestSnapshot atomic.Value // *SnapshotMessage
@@ -38,6 +39,7 @@ type Subscriber struct {
 	onConfig     func(*ConfigMessage)
 	onConnect    func()
 	onDisconnect func()
+	onControl    func(ControlCommand) error
 }
 
 // NewSubscriber creates a new IPC subscriber
@@ -73,6 +75,13 @@ func (s *Subscriber) OnDisconnect(fn func()) {
 	s.onDisconnect = fn
 }
 
+// OnControl sets the handler for the server's stream control commands
+// (admin panel start/stop). It runs off the read loop; its error is sent
+// back to the server.
+func (s *Subscriber) OnControl(fn func(ControlCommand) error) {
+	s.onControl = fn
+}
+
 // Start starts the subscriber, connecting to the server
 func (s *Subscriber) Start() error {
 	if !atomic.CompareAndSwapInt32(&s.running, 0, 1) {
@@ -145,6 +154,22 @@ func (s *Subscriber) IsConnected() bool {
 	return s.conn != nil
 }
 
+// SendTelemetry reports streamer health to the server. Returns
+// ErrNotConnected while the server is unreachable.
+func (s *Subscriber) SendTelemetry(msg TelemetryMessage) error {
+	s.connMu.Lock()
+	conn := s.conn
+	s.connMu.Unlock()
+	if conn == nil {
+		return ErrNotConnected
+	}
+
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	conn.SetWriteDeadline(time.Now().Add(WriteTimeout))
+	return WriteMessage(conn, MsgTypeTelemetry, msg)
+}
+
 // connectionLoop maintains the connection to the server
 func (s *Subscriber) connectionLoop() {
 	defer s.wg.Done()
@@ -211,7 +236,7 @@ func (s *Subscriber) readLoop(conn net.Conn) {
 		// Set read deadline
 		conn.SetReadDeadline(time.Now().Add(ReadTimeout))
 
-		msgType, data, err := ReadMessage(conn)
+		header, data, err := ReadFrame(conn)
 		if err != nil {
 			if err == io.EOF {
 				log.Println("🔌 Server closed connection")
@@ -226,24 +251,29 @@ func (s *Subscriber) readLoop(conn net.Conn) {
 			return
 		}
 
-		switch msgType {
+		switch header.Type {
 		case MsgTypeSnapshot:
-			s.handleSnapshot(data)
+			s.handleSnapshot(header, data)
 
 		case MsgTypeConfig:
 			s.handleConfig(data)
 
+		case MsgTypeControl:
+			s.handleControl(conn, data)
+
 		case MsgTypePing:
 			// Respond with pong
+			s.writeMu.Lock()
 			conn.SetWriteDeadline(time.Now().Add(WriteTimeout))
 			WriteMessage(conn, MsgTypePong, nil)
+			s.writeMu.Unlock()
 		}
 	}
 }
 
 // handleSnapshot processes a received snapshot
-func (s *Subscriber) handleSnapshot(data []byte) {
-	snapshot, err := DecodeSnapshot(data)
+func (s *Subscriber) handleSnapshot(header Header, data []byte) {
+	snapshot, err := DecodeSnapshotFrame(header, data)
 	if err != nil {
 		log.Printf("⚠️ Failed to decode snapshot: %v", err)
 		atomic.AddInt64(&s.errors, 1)
@@ -287,3 +317,30 @@ func (s *Subscriber) handleConfig(data []byte) {
 		s.onConfig(config)
 	}
 }
+
+// handleControl runs a stream control command and answers the server.
+// Starting the stream takes a while, so the command runs off the read loop.
+func (s *Subscriber) handleControl(conn net.Conn, data []byte) {
+	msg, err := DecodeControl(data)
+	if err != nil {
+		log.Printf("⚠️ Failed to decode control command: %v", err)
+		atomic.AddInt64(&s.errors, 1)
+		return
+	}
+
+	go func() {
+		result := ControlResult{ID: msg.ID}
+		if s.onControl == nil {
+			result.Error = "streamer does not accept stream control"
+		} else if err := s.onControl(msg.Command); err != nil {
+			result.Error = err.Error()
+		}
+
+		s.writeMu.Lock()
+		defer s.writeMu.Unlock()
+		conn.SetWriteDeadline(time.Now().Add(WriteTimeout))
+		if err := WriteMessage(conn, MsgTypeControlResult, result); err != nil {
+			log.Printf("⚠️ Failed to answer control command: %v", err)
+		}
+	}()
+}
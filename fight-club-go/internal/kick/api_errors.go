@@ -0,0 +1,242 @@
+package kick
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Kick API error classes, matched with errors.Is on errors from apiRequest
+var (
+	ErrRateLimited  = errors.New("rate limited by Kick")
+	ErrUnauthorized = errors.New("unauthorized by Kick")
+	ErrServerError  = errors.New("Kick server error")
+	ErrCircuitOpen  = errors.New("Kick endpoint temporarily disabled after repeated failures")
+)
+
+// APIError is a non-2xx Kick API response
+type APIError struct {
+	Method     string
+	Endpoint   string // Path without the query
+	StatusCode int
+	Body       string
+	RetryAfter time.Duration // Server-requested delay (429 only, 0 = none)
+}
+
+// Error keeps the "API error <status>" format callers log
+func (e *APIError) Error() string {
+	return fmt.Sprintf("API error %d: %s", e.StatusCode, e.Body)
+}
+
+// Unwrap classifies the status as ErrRateLimited, ErrUnauthorized or ErrServerError
+func (e *APIError) Unwrap() error {
+	switch {
+	case e.StatusCode == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case e.StatusCode == http.StatusUnauthorized:
+		return ErrUnauthorized
+	case e.StatusCode >= 500:
+		return ErrServerError
+	}
+	return nil
+}
+
+// newAPIError builds the error for a non-2xx response
+func newAPIError(method, endpoint string, resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		Method:     method,
+		Endpoint:   endpoint,
+		StatusCode: resp.StatusCode,
+		Body:       string(body),
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		if secs, err := strconv.ParseFloat(resp.Header.Get("Retry-After"), 64); err == nil && secs > 0 {
+			apiErr.RetryAfter = time.Duration(secs * float64(time.Second))
+		}
+	}
+	return apiErr
+}
+
+// apiRetryPolicy is how apiRequest retries transient failures. Rate limits are
+// retried for every method (the request wasn't processed); server and network
+// errors only for idempotent methods, so a chat message is never posted twice.
+type apiRetryPolicy struct {
+	Attempts  int           // Tries per request, the first included
+	BaseDelay time.Duration // Backoff before the first retry, doubled per retry
+	MaxDelay  time.Duration // Backoff (and Retry-After) cap
+}
+
+// defaultAPIRetry keeps a request under ~5s of backoff
+var defaultAPIRetry = apiRetryPolicy{
+	Attempts:  3,
+	BaseDelay: 500 * time.Millisecond,
+	MaxDelay:  4 * time.Second,
+}
+
+// retryable reports whether a failed try (err from the transport or the API) may be repeated
+func (p apiRetryPolicy) retryable(method string, err error) bool {
+	if errors.Is(err, ErrRateLimited) {
+		return true
+	}
+	idempotent := method == http.MethodGet || method == http.MethodPut ||
+		method == http.MethodPatch || method == http.MethodDelete
+	return idempotent && (errors.Is(err, ErrServerError) || isNetworkError(err))
+}
+
+// isNetworkError reports whether a request failed before Kick answered
+// (connection, timeout) rather than with a status, a missing token or an open circuit
+func isNetworkError(err error) bool {
+	return err != nil && !errors.As(err, new(*APIError)) &&
+		!errors.Is(err, ErrUnauthorized) && !errors.Is(err, ErrCircuitOpen)
+}
+
+// delay returns the jittered backoff before retry n (1-based)
+func (p apiRetryPolicy) delay(n int, err error) time.Duration {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+		return min(apiErr.RetryAfter, p.MaxDelay)
+	}
+	backoff := min(p.BaseDelay<<(n-1), p.MaxDelay)
+	// Equal jitter: half fixed, half random, so clients don't retry in lockstep
+	return backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+}
+
+// Circuit breaker tuning
+const (
+	breakerThreshold = 5                // Consecutive failures that open an endpoint's circuit
+	breakerCooldown  = 30 * time.Second // Open time before a trial request is let through
+)
+
+// circuitBreaker stops calling an endpoint that keeps failing (server or
+// network errors), letting one trial request through per cooldown
+type circuitBreaker struct {
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+	trial     bool // A trial request is in flight
+}
+
+// allow reports whether a request may be sent
+func (b *circuitBreaker) allow(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < breakerThreshold {
+		return true
+	}
+	if now.Before(b.openUntil) || b.trial {
+		return false
+	}
+	b.trial = true
+	return true
+}
+
+// record counts a request outcome; returns whether the circuit just opened
+func (b *circuitBreaker) record(failed bool, now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.trial = false
+	if !failed {
+		b.failures = 0
+		return false
+	}
+	b.failures++
+	if b.failures >= breakerThreshold {
+		b.openUntil = now.Add(breakerCooldown)
+		return b.failures == breakerThreshold
+	}
+	return false
+}
+
+// open reports whether the circuit currently rejects requests
+func (b *circuitBreaker) open(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.failures >= breakerThreshold && now.Before(b.openUntil)
+}
+
+// breaker returns the circuit breaker of an endpoint
+func (s *Service) breaker(endpoint string) *circuitBreaker {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.breakers == nil {
+		s.breakers = make(map[string]*circuitBreaker)
+	}
+	b, ok := s.breakers[endpoint]
+	if !ok {
+		b = &circuitBreaker{}
+		s.breakers[endpoint] = b
+	}
+	return b
+}
+
+// OpenCircuits lists the endpoints currently rejected by their circuit breaker
+func (s *Service) OpenCircuits() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	now := time.Now()
+	var open []string
+	for endpoint, b := range s.breakers {
+		if b.open(now) {
+			open = append(open, endpoint)
+		}
+	}
+	sort.Strings(open)
+	return open
+}
+
+// endpointPath strips the query (IDs) so endpoints are bounded metric labels
+func endpointPath(endpoint string) string {
+	path, _, _ := strings.Cut(endpoint, "?")
+	return path
+}
+
+// apiResult classifies a request outcome for metrics
+func apiResult(err error) string {
+	switch {
+	case err == nil:
+		return "ok"
+	case errors.Is(err, ErrCircuitOpen):
+		return "circuit_open"
+	case errors.Is(err, ErrRateLimited):
+		return "rate_limited"
+	case errors.Is(err, ErrUnauthorized):
+		return "unauthorized"
+	case errors.Is(err, ErrServerError):
+		return "server_error"
+	case isNetworkError(err):
+		return "network_error"
+	}
+	return "client_error"
+}
+
+// Bounded label values only: endpoint is the API path without query,
+// result is one of apiResult's values
+var (
+	kickAPIRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "kick_api_requests_total",
+		Help: "Kick API requests by endpoint and result (after retries)",
+	}, []string{"endpoint", "result"})
+
+	kickAPIRetriesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "kick_api_retries_total",
+		Help: "Kick API retries after transient failures",
+	}, []string{"endpoint"})
+
+	kickAPICircuitOpen = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kick_api_circuit_open",
+		Help: "1 while an endpoint's circuit breaker rejects requests",
+	}, []string{"endpoint"})
+)
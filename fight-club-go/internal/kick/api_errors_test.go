@@ -0,0 +1,158 @@
+package kick
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeKickAPI answers Kick API and token requests with scripted statuses
+type fakeKickAPI struct {
+	mu       sync.Mutex
+	statuses []int // Per API call; the last one repeats
+	calls    int
+	tokens   []string // Bearer token of each API call
+	header   http.Header
+}
+
+func (f *fakeKickAPI) RoundTrip(req *http.Request) (*http.Response, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if strings.HasSuffix(req.URL.Path, "/oauth/token") {
+		return fakeResponse(http.StatusOK, `{"access_token":"fresh","refresh_token":"r2","expires_in":3600}`, nil), nil
+	}
+	status := f.statuses[min(f.calls, len(f.statuses)-1)]
+	f.calls++
+	f.tokens = append(f.tokens, req.Header.Get("Authorization"))
+	return fakeResponse(status, `{"data":{}}`, f.header), nil
+}
+
+func (f *fakeKickAPI) Calls() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls
+}
+
+func fakeResponse(status int, body string, header http.Header) *http.Response {
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{StatusCode: status, Header: header, Body: io.NopCloser(strings.NewReader(body))}
+}
+
+// newFakeAPIService returns a logged-in service talking to api with fast retries
+func newFakeAPIService(t *testing.T, api *fakeKickAPI) *Service {
+	s := NewServiceWithTokenStore("id", "secret", NewFileTokenStore(filepath.Join(t.TempDir(), "tokens.json"), nil))
+	s.client = &http.Client{Transport: api}
+	s.accessToken, s.refreshToken = "stale", "r1"
+	s.tokenExpiry = time.Now().Add(time.Hour)
+	s.retry = apiRetryPolicy{Attempts: 3, BaseDelay: time.Millisecond, MaxDelay: 2 * time.Millisecond}
+	return s
+}
+
+// TestAPIErrorClasses verifies statuses map to the error taxonomy
+func TestAPIErrorClasses(t *testing.T) {
+	for status, want := range map[int]error{429: ErrRateLimited, 401: ErrUnauthorized, 502: ErrServerError} {
+		if err := error(&APIError{StatusCode: status}); !errors.Is(err, want) {
+			t.Errorf("Expected %d to be %v", status, want)
+		}
+	}
+	err := error(&APIError{StatusCode: 404})
+	if errors.Is(err, ErrServerError) || errors.Is(err, ErrRateLimited) || apiResult(err) != "client_error" {
+		t.Error("Expected 404 to be a plain client error")
+	}
+	if apiResult(errors.New("connection refused")) != "network_error" {
+		t.Error("Expected a transport error to be a network error")
+	}
+}
+
+// TestAPIRequestRetries verifies transient failures are retried, POSTs only on rate limits
+func TestAPIRequestRetries(t *testing.T) {
+	api := &fakeKickAPI{statuses: []int{503, 200}}
+	s := newFakeAPIService(t, api)
+	if _, err := s.apiRequest("GET", "/livestreams?broadcaster_user_id=1", nil); err != nil || api.Calls() != 2 {
+		t.Errorf("Expected a GET to succeed on the retry, got %d calls (%v)", api.Calls(), err)
+	}
+
+	api = &fakeKickAPI{statuses: []int{503, 200}}
+	s = newFakeAPIService(t, api)
+	if _, err := s.apiRequest("POST", "/chat", map[string]string{"content": "hi"}); !errors.Is(err, ErrServerError) || api.Calls() != 1 {
+		t.Errorf("Expected a POST not to be repeated after a 503, got %d calls (%v)", api.Calls(), err)
+	}
+
+	api = &fakeKickAPI{statuses: []int{429}, header: http.Header{"Retry-After": {"1"}}}
+	s = newFakeAPIService(t, api)
+	_, err := s.apiRequest("POST", "/chat", map[string]string{"content": "hi"})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.RetryAfter != time.Second || api.Calls() != 3 {
+		t.Errorf("Expected 3 rate-limited tries with Retry-After, got %d calls (%v)", api.Calls(), err)
+	}
+}
+
+// TestAPIRequestRefreshesOn401 verifies a 401 refreshes the token and retries once
+func TestAPIRequestRefreshesOn401(t *testing.T) {
+	api := &fakeKickAPI{statuses: []int{401, 200}}
+	s := newFakeAPIService(t, api)
+	if _, err := s.apiRequest("POST", "/chat", map[string]string{"content": "hi"}); err != nil {
+		t.Fatalf("Expected success after the refresh, got %v", err)
+	}
+	if len(api.tokens) != 2 || api.tokens[0] != "Bearer stale" || api.tokens[1] != "Bearer fresh" {
+		t.Errorf("Expected the retry with the refreshed token, got %v", api.tokens)
+	}
+
+	api = &fakeKickAPI{statuses: []int{401}}
+	s = newFakeAPIService(t, api)
+	if _, err := s.apiRequest("GET", "/users/me", nil); !errors.Is(err, ErrUnauthorized) || api.Calls() != 2 {
+		t.Errorf("Expected one refresh then ErrUnauthorized, got %d calls (%v)", api.Calls(), err)
+	}
+}
+
+// TestAPICircuitBreaker verifies a failing endpoint is skipped while others still work
+func TestAPICircuitBreaker(t *testing.T) {
+	api := &fakeKickAPI{statuses: []int{500}}
+	s := newFakeAPIService(t, api)
+	s.retry.Attempts = 1
+
+	for i := 0; i < breakerThreshold; i++ {
+		s.apiRequest("GET", "/channels", nil)
+	}
+	if _, err := s.apiRequest("GET", "/channels?slug=x", nil); !errors.Is(err, ErrCircuitOpen) || api.Calls() != breakerThreshold {
+		t.Errorf("Expected the open circuit to skip the request, got %d calls (%v)", api.Calls(), err)
+	}
+	if open := s.OpenCircuits(); len(open) != 1 || open[0] != "/channels" {
+		t.Errorf("Expected /channels open, got %v", open)
+	}
+	if _, err := s.apiRequest("GET", "/users/me", nil); errors.Is(err, ErrCircuitOpen) {
+		t.Error("Expected other endpoints unaffected")
+	}
+}
+
+// TestCircuitBreakerTrial verifies one trial request goes through after the cooldown
+func TestCircuitBreakerTrial(t *testing.T) {
+	var b circuitBreaker
+	now := time.Now()
+	for i := 0; i < breakerThreshold; i++ {
+		b.allow(now)
+		if opened := b.record(true, now); opened != (i == breakerThreshold-1) {
+			t.Fatalf("Unexpected open=%v after %d failures", opened, i+1)
+		}
+	}
+	if b.allow(now) {
+		t.Fatal("Expected the circuit open")
+	}
+
+	later := now.Add(breakerCooldown)
+	if !b.allow(later) || b.allow(later) {
+		t.Fatal("Expected exactly one trial request after the cooldown")
+	}
+	b.record(false, later)
+	if !b.allow(later) || b.open(later) {
+		t.Error("Expected a successful trial to close the circuit")
+	}
+}
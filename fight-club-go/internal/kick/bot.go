@@ -1,6 +1,7 @@
 package kick
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"strings"
@@ -22,10 +23,11 @@ type KillEvent struct {
 // - Queue management (Drop Newest)
 // - Automatic failure recovery (404/ID handling)
 type Bot struct {
-	service *Service
-	queue   chan KillEvent
-	quit    chan struct{}
-	wg      sync.WaitGroup
+	service  *Service
+	queue    chan KillEvent
+	messages chan string // Announcements (arena votes, ...)
+	quit     chan struct{}
+	wg       sync.WaitGroup
 	// Backoff state
 	rateLimit      time.Duration
 	currentBackoff time.Duration
@@ -37,6 +39,7 @@ func NewBot(service *Service) *Bot {
 	return &Bot{
 		service:    service,
 		queue:      make(chan KillEvent, 100), // Buffer size 100 from Architecture
+		messages:   make(chan string, 10),
 		quit:       make(chan struct{}),
 		rateLimit:  2000 * time.Millisecond, // 2.0s per message to avoid spam filters
 		maxBackoff: 60 * time.Second,
@@ -77,6 +80,16 @@ func (b *Bot) QueueKill(killer, victim, weapon string, killerKills int) {
 	}
 }
 
+// QueueMessage queues a plain chat announcement.
+// Shares the kill feed rate limit; dropped if the queue is full.
+func (b *Bot) QueueMessage(msg string) {
+	select {
+	case b.messages <- msg:
+	default:
+		log.Printf("⚠️ Bot message queue full, dropping: %s", msg)
+	}
+}
+
 // dispatcher is the main event loop
 func (b *Bot) dispatcher() {
 	defer b.wg.Done()
@@ -98,6 +111,15 @@ func (b *Bot) dispatcher() {
 			}
 
 			b.processEvent(event)
+
+		case msg := <-b.messages:
+			select {
+			case <-ticker.C:
+			case <-b.quit:
+				return
+			}
+
+			b.send(msg)
 		}
 	}
 }
@@ -138,13 +160,16 @@ func (b *Bot) processEvent(event KillEvent) {
 	// Using SendMessage with broadcaster_user_id - this sends as the streamer account
 	// Note: type "bot" returns 500 error, so we use type "user" instead
 	log.Printf("🎮 Kill event: %s -> %s (weapon: %s)", event.Killer, event.Victim, event.Weapon)
+	b.send(msg)
+}
+
+// send posts a message to chat with 429 backoff handling
+func (b *Bot) send(msg string) {
 	err := b.service.SendMessage(msg)
 
 	// 3. Handle Errors
 	if err != nil {
-		errMsg := err.Error()
-
-		if strings.Contains(errMsg, "429") {
+		if errors.Is(err, ErrRateLimited) {
 			// Exponential Backoff
 			if b.currentBackoff == 0 {
 				b.currentBackoff = 2 * time.Second
@@ -160,7 +185,7 @@ func (b *Bot) processEvent(event KillEvent) {
 			// Other errors (400, 404, 500)
 			// For 400/404 on SendMessage, it usually means Broadcaster ID is wrong or Token is invalid.
 			// We log but don't crash or sleep extensively, just continue to next message.
-			log.Printf("⚠️ Failed to send kill feed: %v", err)
+			log.Printf("⚠️ Failed to send bot message: %v", err)
 		}
 	} else {
 		// Success - reset backoff
@@ -0,0 +1,200 @@
+package kick
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"sort"
+	"time"
+
+	"fight-club/internal/config"
+)
+
+// GuestChannel is an alias for config.KickGuestChannel (SSOT)
+type GuestChannel = config.KickGuestChannel
+
+// ChannelSubscription is the chat event subscription state of one channel
+type ChannelSubscription struct {
+	BroadcasterID  int64     `json:"broadcasterId"`
+	Prefix         string    `json:"prefix,omitempty"` // "" = the broadcaster's own channel
+	SubscriptionID string    `json:"subscriptionId,omitempty"`
+	SubscribedAt   time.Time `json:"subscribedAt,omitempty"`
+	Error          string    `json:"error,omitempty"`
+}
+
+// subscriptionResponse is the POST /events/subscriptions reply
+type subscriptionResponse struct {
+	Data []struct {
+		Name           string `json:"name"`
+		SubscriptionID string `json:"subscription_id"`
+		Error          string `json:"error"`
+	} `json:"data"`
+}
+
+// SetGuestChannels sets the collab partners' channels whose chat also plays.
+// Their viewers' names are prefixed (see ChatMessage.PlayerName).
+func (s *Service) SetGuestChannels(guests []GuestChannel) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.guests = make(map[int64]string, len(guests))
+	for _, g := range guests {
+		if g.BroadcasterID == 0 || g.BroadcasterID == s.broadcasterID {
+			continue
+		}
+		s.guests[g.BroadcasterID] = g.Prefix
+		log.Printf("🤝 Guest channel: %d (players prefixed %q)", g.BroadcasterID, g.Prefix+":")
+	}
+}
+
+// GuestPrefix returns the player name prefix for a guest channel ("" for the own channel)
+func (s *Service) GuestPrefix(broadcasterID int64) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.guests[broadcasterID]
+}
+
+// isGuest reports whether a broadcaster is a configured guest channel
+func (s *Service) isGuest(broadcasterID int64) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	_, ok := s.guests[broadcasterID]
+	return ok
+}
+
+// SubscribeChannel subscribes to chat events of the own or a guest channel
+func (s *Service) SubscribeChannel(broadcasterID int64) error {
+	if broadcasterID == 0 {
+		return errors.New("broadcaster ID not set")
+	}
+
+	// Note: Kick webhooks are registered against your app,
+	// the callback URL is set in the Kick Developer Dashboard
+	body := map[string]interface{}{
+		"events": []map[string]interface{}{
+			{"name": "chat.message.sent", "version": 1},
+		},
+		"method":              "webhook",
+		"broadcaster_user_id": broadcasterID,
+	}
+
+	resp, err := s.apiRequest("POST", "/events/subscriptions", body)
+	if err == nil {
+		var result subscriptionResponse
+		if jsonErr := json.Unmarshal(resp, &result); jsonErr == nil && len(result.Data) > 0 && result.Data[0].Error != "" {
+			err = errors.New(result.Data[0].Error)
+		} else {
+			s.recordSubscription(broadcasterID, func(sub *ChannelSubscription) {
+				if len(result.Data) > 0 {
+					sub.SubscriptionID = result.Data[0].SubscriptionID
+				}
+				sub.SubscribedAt = time.Now()
+				sub.Error = ""
+			})
+			return nil
+		}
+	}
+
+	s.recordSubscription(broadcasterID, func(sub *ChannelSubscription) {
+		sub.Error = err.Error()
+	})
+	return fmt.Errorf("subscription failed: %w", err)
+}
+
+// UnsubscribeChannel removes a channel's chat event subscription
+func (s *Service) UnsubscribeChannel(broadcasterID int64) error {
+	s.mu.RLock()
+	sub, ok := s.subscriptions[broadcasterID]
+	subscriptionID := ""
+	if ok {
+		subscriptionID = sub.SubscriptionID
+	}
+	s.mu.RUnlock()
+
+	if subscriptionID == "" {
+		return fmt.Errorf("channel %d is not subscribed", broadcasterID)
+	}
+
+	if _, err := s.apiRequest("DELETE", "/events/subscriptions?id="+url.QueryEscape(subscriptionID), nil); err != nil {
+		return fmt.Errorf("unsubscribe failed: %w", err)
+	}
+
+	s.mu.Lock()
+	delete(s.subscriptions, broadcasterID)
+	s.mu.Unlock()
+	log.Printf("🔕 Unsubscribed from chat events (broadcaster: %d)", broadcasterID)
+	return nil
+}
+
+// subscribeGuests subscribes every guest channel, logging failures (the own channel still works)
+func (s *Service) subscribeGuests() {
+	s.mu.RLock()
+	ids := make([]int64, 0, len(s.guests))
+	for id := range s.guests {
+		ids = append(ids, id)
+	}
+	s.mu.RUnlock()
+
+	for _, id := range ids {
+		if err := s.SubscribeChannel(id); err != nil {
+			log.Printf("⚠️ Guest channel %d: %v", id, err)
+			continue
+		}
+		log.Printf("✅ Subscribed to guest chat events (broadcaster: %d, prefix: %s)", id, s.GuestPrefix(id))
+	}
+}
+
+// recordSubscription updates a channel's subscription state
+func (s *Service) recordSubscription(broadcasterID int64, update func(*ChannelSubscription)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.subscriptions == nil {
+		s.subscriptions = make(map[int64]*ChannelSubscription)
+	}
+	sub, ok := s.subscriptions[broadcasterID]
+	if !ok {
+		sub = &ChannelSubscription{BroadcasterID: broadcasterID}
+		s.subscriptions[broadcasterID] = sub
+	}
+	sub.Prefix = s.guests[broadcasterID]
+	update(sub)
+}
+
+// ChannelSubscriptions returns the own and guest channels with their subscription state
+func (s *Service) ChannelSubscriptions() []ChannelSubscription {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	subs := make([]ChannelSubscription, 0, len(s.guests)+1)
+	add := func(id int64, prefix string) {
+		sub := ChannelSubscription{BroadcasterID: id, Prefix: prefix}
+		if existing, ok := s.subscriptions[id]; ok {
+			sub = *existing
+		}
+		subs = append(subs, sub)
+	}
+	guestIDs := make([]int64, 0, len(s.guests))
+	for id := range s.guests {
+		guestIDs = append(guestIDs, id)
+	}
+	sort.Slice(guestIDs, func(i, j int) bool { return guestIDs[i] < guestIDs[j] })
+
+	if s.broadcasterID != 0 {
+		add(s.broadcasterID, "")
+	}
+	for _, id := range guestIDs {
+		add(id, s.guests[id])
+	}
+	return subs
+}
+
+// PlayerName returns the in-game name: guest channel viewers get their channel prefix
+func (m ChatMessage) PlayerName() string {
+	if m.ChannelPrefix == "" {
+		return m.Username
+	}
+	return m.ChannelPrefix + ":" + m.Username
+}
@@ -0,0 +1,129 @@
+package kick
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// roundTripFunc fakes the Kick API
+type roundTripFunc func(*http.Request) *http.Response
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r), nil
+}
+
+// newTestGuestService creates an authenticated service with one guest channel
+func newTestGuestService(api roundTripFunc) *Service {
+	s := &Service{
+		client:      &http.Client{Transport: api},
+		accessToken: "token",
+		tokenExpiry: time.Now().Add(time.Hour),
+	}
+	s.SetSignatureMode(SignatureModeOff)
+	s.SetBroadcasterID(1)
+	s.SetGuestChannels([]GuestChannel{{BroadcasterID: 2, Prefix: "ana"}, {BroadcasterID: 1, Prefix: "self"}})
+	return s
+}
+
+// postChat delivers a chat webhook from a channel
+func postChat(s *Service, broadcasterID, chatroomID int, username, content string) {
+	body := `{"message_id":"m","content":"` + content + `","chatroom_id":` + strconv.Itoa(chatroomID) +
+		`,"broadcaster":{"user_id":` + strconv.Itoa(broadcasterID) + `},"sender":{"user_id":99,"username":"` + username + `"}}`
+	req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body))
+	req.Header.Set("Kick-Event-Type", "chat.message.sent")
+	s.HandleWebhook(httptest.NewRecorder(), req)
+}
+
+// TestGuestChannelPrefix verifies guest chat gets prefixed names and never replaces the own chatroom
+func TestGuestChannelPrefix(t *testing.T) {
+	s := newTestGuestService(nil)
+	var got []ChatMessage
+	s.OnChatMessage(func(msg ChatMessage) { got = append(got, msg) })
+
+	postChat(s, 1, 100, "alice", "!join")
+	postChat(s, 2, 200, "bob", "!join")
+
+	if len(got) != 2 {
+		t.Fatalf("Expected 2 messages, got %d", len(got))
+	}
+	if got[0].PlayerName() != "alice" || got[0].ChannelPrefix != "" {
+		t.Errorf("Expected own channel viewer unprefixed, got %q", got[0].PlayerName())
+	}
+	if got[1].PlayerName() != "ana:bob" || !got[1].IsCommand || got[1].Command != "join" {
+		t.Errorf("Expected guest !join from ana:bob, got %+v", got[1])
+	}
+	if s.chatroomID != 100 {
+		t.Errorf("Expected own chatroom 100 kept, got %d", s.chatroomID)
+	}
+	if s.GuestPrefix(1) != "" {
+		t.Error("Expected the own channel never to be a guest")
+	}
+}
+
+// TestGuestChannelSubscriptions verifies each channel is subscribed and unsubscribed separately
+func TestGuestChannelSubscriptions(t *testing.T) {
+	var requests []string
+	s := newTestGuestService(func(r *http.Request) *http.Response {
+		var body []byte
+		if r.Body != nil {
+			body, _ = io.ReadAll(r.Body)
+		}
+		requests = append(requests, r.Method+" "+r.URL.RequestURI()+" "+string(body))
+
+		reply := `{"data":[{"name":"chat.message.sent","subscription_id":"sub-host"}]}`
+		if bytes.Contains(body, []byte(`"broadcaster_user_id":2`)) {
+			reply = `{"data":[{"name":"chat.message.sent","subscription_id":"sub-ana"}]}`
+		}
+		if r.Method == http.MethodDelete {
+			reply = ``
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(reply)), Header: http.Header{}}
+	})
+
+	if err := s.SubscribeToChatEvents(); err != nil {
+		t.Fatalf("SubscribeToChatEvents failed: %v", err)
+	}
+	subs := s.ChannelSubscriptions()
+	if len(subs) != 2 || subs[0].SubscriptionID != "sub-host" || subs[1].SubscriptionID != "sub-ana" || subs[1].Prefix != "ana" {
+		t.Fatalf("Expected host and guest subscriptions, got %+v", subs)
+	}
+
+	if err := s.UnsubscribeChannel(2); err != nil {
+		t.Fatalf("UnsubscribeChannel failed: %v", err)
+	}
+	if last := requests[len(requests)-1]; !strings.HasPrefix(last, "DELETE /public/v1/events/subscriptions?id=sub-ana") {
+		t.Errorf("Expected DELETE of the guest subscription, got %q", last)
+	}
+	if subs := s.ChannelSubscriptions(); subs[1].SubscriptionID != "" || subs[0].SubscriptionID != "sub-host" {
+		t.Errorf("Expected only the guest unsubscribed, got %+v", subs)
+	}
+	if err := s.UnsubscribeChannel(2); err == nil {
+		t.Error("Expected error unsubscribing twice")
+	}
+}
+
+// TestGuestSubscriptionError verifies a rejected guest is recorded without failing the own channel
+func TestGuestSubscriptionError(t *testing.T) {
+	s := newTestGuestService(func(r *http.Request) *http.Response {
+		reply := `{"data":[{"name":"chat.message.sent","subscription_id":"sub-host"}]}`
+		if r.Body != nil {
+			if body, _ := io.ReadAll(r.Body); bytes.Contains(body, []byte(`"broadcaster_user_id":2`)) {
+				reply = `{"data":[{"name":"chat.message.sent","error":"not authorized"}]}`
+			}
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(reply)), Header: http.Header{}}
+	})
+
+	if err := s.SubscribeToChatEvents(); err != nil {
+		t.Fatalf("Expected own channel subscribed despite guest error, got %v", err)
+	}
+	if subs := s.ChannelSubscriptions(); subs[1].Error != "not authorized" {
+		t.Errorf("Expected guest error recorded, got %+v", subs[1])
+	}
+}
@@ -0,0 +1,58 @@
+package kick
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// clipsEndpoint is the channel clips resource. Like polls, Kick's public API
+// doesn't document clip creation yet; until it does, creating a clip fails
+// and highlights are only logged (see highlights.Recorder).
+const clipsEndpoint = "/clips"
+
+// maxClipTitle is the longest clip title sent to Kick (runes)
+const maxClipTitle = 100
+
+// CreateClip clips the last duration seconds of the broadcaster's livestream
+// and returns the clip URL
+func (s *Service) CreateClip(title string, duration int) (string, error) {
+	s.mu.RLock()
+	broadcasterID := s.broadcasterID
+	s.mu.RUnlock()
+
+	if broadcasterID == 0 {
+		return "", errors.New("broadcaster ID not set")
+	}
+	if r := []rune(title); len(r) > maxClipTitle {
+		title = string(r[:maxClipTitle])
+	}
+
+	body := map[string]interface{}{
+		"broadcaster_user_id": broadcasterID,
+		"title":               title,
+		"duration":            duration,
+	}
+	resp, err := s.apiRequest("POST", clipsEndpoint, body)
+	if err != nil {
+		return "", fmt.Errorf("failed to create clip: %w", err)
+	}
+	return parseClipURL(resp)
+}
+
+// parseClipURL reads the clip link from a clips response ({"data": {...}})
+func parseClipURL(body []byte) (string, error) {
+	var result struct {
+		Data struct {
+			ID  string `json:"id"`
+			URL string `json:"url"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return "", fmt.Errorf("invalid clip response: %w", err)
+	}
+	if result.Data.URL == "" {
+		return "", errors.New("clip created without a URL")
+	}
+	return result.Data.URL, nil
+}
@@ -0,0 +1,26 @@
+package kick
+
+import "testing"
+
+// TestParseClipURL verifies clip responses and that a clip without a link is an error
+func TestParseClipURL(t *testing.T) {
+	url, err := parseClipURL([]byte(`{"data":{"id":"c1","url":"https://kick.com/fightclub?clip=c1"},"message":"OK"}`))
+	if err != nil || url != "https://kick.com/fightclub?clip=c1" {
+		t.Errorf("Expected the clip URL, got %q (%v)", url, err)
+	}
+
+	if _, err := parseClipURL([]byte(`{"data":{"id":"c2"}}`)); err == nil {
+		t.Error("Expected error for a clip without a URL")
+	}
+	if _, err := parseClipURL([]byte(`not json`)); err == nil {
+		t.Error("Expected error for invalid JSON")
+	}
+}
+
+// TestCreateClipNeedsBroadcaster verifies clips aren't attempted before login
+func TestCreateClipNeedsBroadcaster(t *testing.T) {
+	s := NewService("id", "secret")
+	if _, err := s.CreateClip("ana triple kill", 30); err == nil {
+		t.Error("Expected error without a broadcaster ID")
+	}
+}
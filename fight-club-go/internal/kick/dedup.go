@@ -0,0 +1,147 @@
+package kick
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"fight-club/internal/config"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// DedupConfig is an alias for config.KickDedupConfig (SSOT)
+type DedupConfig = config.KickDedupConfig
+
+// Bounded label values only: "event" (Kick-Event-Message-Id), "chat" (chat message_id)
+var webhookDuplicatesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "kick_webhook_duplicates_total",
+	Help: "Re-delivered Kick webhooks dropped by message ID",
+}, []string{"kind"})
+
+// MessageDedup remembers recently seen message IDs so re-delivered webhooks
+// are processed once. IDs are kept in arrival order and forgotten after the
+// window or when more than MaxIDs are remembered (LRU by first sighting).
+type MessageDedup struct {
+	mu    sync.Mutex
+	cfg   DedupConfig
+	seen  map[string]*list.Element
+	order *list.List // *seenMessage, newest first
+
+	checked    int64
+	duplicates int64
+}
+
+// seenMessage is one remembered message ID
+type seenMessage struct {
+	id string
+	at time.Time
+}
+
+// DedupStats holds deduplication counters for monitoring
+type DedupStats struct {
+	WindowSeconds float64 `json:"windowSeconds"`
+	Remembered    int     `json:"remembered"`
+	Checked       int64   `json:"checked"`
+	Duplicates    int64   `json:"duplicates"`
+}
+
+// NewMessageDedup creates a message ID deduplicator
+func NewMessageDedup(cfg DedupConfig) *MessageDedup {
+	if cfg.MaxIDs <= 0 {
+		cfg.MaxIDs = config.DefaultKickDedup().MaxIDs
+	}
+	return &MessageDedup{
+		cfg:   cfg,
+		seen:  make(map[string]*list.Element),
+		order: list.New(),
+	}
+}
+
+// Seen records a message ID and reports whether it was already seen within
+// the window. Empty IDs are never duplicates, nor is anything for a nil dedup.
+func (d *MessageDedup) Seen(id string, now time.Time) bool {
+	if d == nil || id == "" {
+		return false
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.cfg.Window <= 0 {
+		return false
+	}
+	d.checked++
+
+	// Forget IDs past the window (oldest at the back)
+	cutoff := now.Add(-time.Duration(d.cfg.Window * float64(time.Second)))
+	for el := d.order.Back(); el != nil && el.Value.(*seenMessage).at.Before(cutoff); el = d.order.Back() {
+		d.forget(el)
+	}
+
+	if _, ok := d.seen[id]; ok {
+		d.duplicates++
+		return true
+	}
+
+	d.seen[id] = d.order.PushFront(&seenMessage{id: id, at: now})
+	for d.order.Len() > d.cfg.MaxIDs {
+		d.forget(d.order.Back())
+	}
+	return false
+}
+
+// forget drops a remembered ID. Caller holds d.mu.
+func (d *MessageDedup) forget(el *list.Element) {
+	delete(d.seen, d.order.Remove(el).(*seenMessage).id)
+}
+
+// Stats returns deduplication counters
+func (d *MessageDedup) Stats() DedupStats {
+	if d == nil {
+		return DedupStats{}
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return DedupStats{
+		WindowSeconds: d.cfg.Window,
+		Remembered:    d.order.Len(),
+		Checked:       d.checked,
+		Duplicates:    d.duplicates,
+	}
+}
+
+// SetDedupConfig replaces the webhook deduplication window and size
+// (remembered IDs are forgotten)
+func (s *Service) SetDedupConfig(cfg DedupConfig) {
+	dedup := NewMessageDedup(cfg)
+	s.mu.Lock()
+	s.dedup = dedup
+	s.mu.Unlock()
+}
+
+// GetDedupStats returns webhook deduplication counters
+func (s *Service) GetDedupStats() DedupStats {
+	s.mu.RLock()
+	dedup := s.dedup
+	s.mu.RUnlock()
+	return dedup.Stats()
+}
+
+// isDuplicate reports whether a webhook delivery or chat message was already
+// processed (kind "event" or "chat", IDs are namespaced by kind)
+func (s *Service) isDuplicate(kind, id string, now time.Time) bool {
+	if id == "" {
+		return false
+	}
+	s.mu.RLock()
+	dedup := s.dedup
+	s.mu.RUnlock()
+
+	if !dedup.Seen(kind+":"+id, now) {
+		return false
+	}
+	webhookDuplicatesTotal.WithLabelValues(kind).Inc()
+	return true
+}
@@ -0,0 +1,78 @@
+package kick
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestMessageDedupWindow verifies IDs are duplicates within the window only
+// and the oldest are forgotten past MaxIDs
+func TestMessageDedupWindow(t *testing.T) {
+	d := NewMessageDedup(DedupConfig{Window: 60, MaxIDs: 2})
+	now := time.Now()
+
+	if d.Seen("a", now) || !d.Seen("a", now.Add(30*time.Second)) {
+		t.Fatal("Expected a re-delivery within the window to be a duplicate")
+	}
+	if d.Seen("a", now.Add(61*time.Second)) {
+		t.Error("Expected the ID to be forgotten after the window")
+	}
+
+	d.Seen("b", now.Add(62*time.Second))
+	d.Seen("c", now.Add(63*time.Second)) // Pushes out a
+	if d.Seen("a", now.Add(64*time.Second)) {
+		t.Error("Expected the oldest ID to be forgotten past MaxIDs")
+	}
+	if d.Seen("", now) || d.Seen("", now) {
+		t.Error("Expected empty IDs never to be duplicates")
+	}
+
+	if stats := d.Stats(); stats.Duplicates != 1 || stats.Remembered != 2 {
+		t.Errorf("Expected 1 duplicate and 2 remembered IDs, got %+v", stats)
+	}
+
+	off := NewMessageDedup(DedupConfig{Window: 0})
+	if off.Seen("a", now) || off.Seen("a", now) {
+		t.Error("Expected a zero window to disable deduplication")
+	}
+}
+
+// TestWebhookDuplicatesIgnored verifies a re-delivered chat webhook is
+// acknowledged but handled once, by delivery ID or chat message ID
+func TestWebhookDuplicatesIgnored(t *testing.T) {
+	s := NewServiceWithTokenStore("id", "secret", NewFileTokenStore(t.TempDir()+"/tokens.json", nil))
+	s.SetSignatureMode(SignatureModeOff)
+	var got []ChatMessage
+	s.OnChatMessage(func(msg ChatMessage) { got = append(got, msg) })
+
+	deliver := func(eventID, messageID string) int {
+		body := `{"message_id":"` + messageID + `","content":"!buy sword","broadcaster":{"user_id":1},"sender":{"user_id":99,"username":"ana"}}`
+		req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body))
+		req.Header.Set("Kick-Event-Type", "chat.message.sent")
+		req.Header.Set(HeaderEventMessageID, eventID)
+		rec := httptest.NewRecorder()
+		s.HandleWebhook(rec, req)
+		return rec.Code
+	}
+
+	codes := []int{
+		deliver("evt-1", "msg-1"),
+		deliver("evt-1", "msg-1"), // Same delivery retried
+		deliver("evt-2", "msg-1"), // Same message, new delivery
+		deliver("evt-3", "msg-2"),
+	}
+	for i, code := range codes {
+		if code != http.StatusOK {
+			t.Errorf("Expected delivery %d acknowledged with 200, got %d", i, code)
+		}
+	}
+	if len(got) != 2 || got[0].MessageID != "msg-1" || got[1].MessageID != "msg-2" {
+		t.Fatalf("Expected msg-1 and msg-2 handled once each, got %+v", got)
+	}
+	if stats := s.GetDedupStats(); stats.Duplicates != 2 {
+		t.Errorf("Expected 2 duplicates counted, got %+v", stats)
+	}
+}
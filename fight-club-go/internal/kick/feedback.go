@@ -0,0 +1,149 @@
+package kick
+
+import (
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"fight-club/internal/config"
+)
+
+// FeedbackConfig is an alias for config.ChatFeedbackConfig (SSOT)
+type FeedbackConfig = config.ChatFeedbackConfig
+
+// maxChatMessageLength is Kick's chat message limit (characters)
+const maxChatMessageLength = 500
+
+// feedbackReply is a queued reply to one viewer
+type feedbackReply struct {
+	username string
+	message  string
+}
+
+// FeedbackBatcher tells viewers why their command failed without spamming
+// chat: replies are collected and posted as one message per interval
+// ("@ana you need $80 more for sword | @leo you're dead, type !join to respawn").
+// Each viewer gets at most one reply per cooldown; extra replies are dropped.
+type FeedbackBatcher struct {
+	config FeedbackConfig
+	send   func(msg string) // Usually Bot.QueueMessage (shares its rate limit)
+
+	mu        sync.Mutex
+	pending   []feedbackReply
+	lastReply map[string]time.Time // Lowercase username -> last queued reply
+	dropped   int
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewFeedbackBatcher creates a batcher posting through send
+func NewFeedbackBatcher(cfg FeedbackConfig, send func(msg string)) *FeedbackBatcher {
+	defaults := config.DefaultChatFeedback()
+	if cfg.Interval <= 0 {
+		cfg.Interval = defaults.Interval
+	}
+	if cfg.UserCooldown < 0 {
+		cfg.UserCooldown = 0
+	}
+	if cfg.MaxBatch <= 0 {
+		cfg.MaxBatch = defaults.MaxBatch
+	}
+	return &FeedbackBatcher{
+		config:    cfg,
+		send:      send,
+		lastReply: make(map[string]time.Time),
+		stopCh:    make(chan struct{}),
+	}
+}
+
+// Start begins flushing batched replies every interval
+func (f *FeedbackBatcher) Start() {
+	f.wg.Add(1)
+	go f.loop()
+	log.Printf("💬 Chat feedback enabled (every %.0fs, %.0fs per-user cooldown, max %d per message)",
+		f.config.Interval, f.config.UserCooldown, f.config.MaxBatch)
+}
+
+// Stop stops the flush loop. Pending replies are discarded (they'd be stale).
+func (f *FeedbackBatcher) Stop() {
+	close(f.stopCh)
+	f.wg.Wait()
+}
+
+// Reply queues feedback for a viewer (implements chat.Replier). Never blocks.
+func (f *FeedbackBatcher) Reply(username, message string) {
+	f.replyAt(username, message, time.Now())
+}
+
+// replyAt queues a reply unless the viewer is on cooldown or the batch is full
+func (f *FeedbackBatcher) replyAt(username, message string, now time.Time) {
+	key := strings.ToLower(username)
+	cooldown := time.Duration(f.config.UserCooldown * float64(time.Second))
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if last, ok := f.lastReply[key]; ok && now.Sub(last) < cooldown {
+		return // Already told them recently
+	}
+	if len(f.pending) >= f.config.MaxBatch {
+		f.dropped++
+		return
+	}
+	f.pending = append(f.pending, feedbackReply{username: username, message: message})
+	f.lastReply[key] = now
+}
+
+// loop flushes replies every interval
+func (f *FeedbackBatcher) loop() {
+	defer f.wg.Done()
+
+	ticker := time.NewTicker(time.Duration(f.config.Interval * float64(time.Second)))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-f.stopCh:
+			return
+		case now := <-ticker.C:
+			if msg := f.flush(now); msg != "" {
+				f.send(msg)
+			}
+		}
+	}
+}
+
+// flush returns the pending replies as one chat message ("" if none) and
+// forgets cooldowns that have expired
+func (f *FeedbackBatcher) flush(now time.Time) string {
+	cooldown := time.Duration(f.config.UserCooldown * float64(time.Second))
+
+	f.mu.Lock()
+	replies := f.pending
+	f.pending = nil
+	if f.dropped > 0 {
+		log.Printf("💬 Dropped %d chat feedback replies (batch full)", f.dropped)
+		f.dropped = 0
+	}
+	for key, last := range f.lastReply {
+		if now.Sub(last) >= cooldown {
+			delete(f.lastReply, key)
+		}
+	}
+	f.mu.Unlock()
+
+	var msg strings.Builder
+	for _, r := range replies {
+		part := "@" + r.username + " " + r.message
+		if msg.Len() > 0 {
+			part = " | " + part
+		}
+		if msg.Len()+len(part) > maxChatMessageLength {
+			break
+		}
+		msg.WriteString(part)
+	}
+	return msg.String()
+}
@@ -0,0 +1,88 @@
+package kick
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"fight-club/internal/config"
+)
+
+func newTestFeedback(maxBatch int) *FeedbackBatcher {
+	return NewFeedbackBatcher(config.ChatFeedbackConfig{
+		Enabled:      true,
+		Interval:     10,
+		UserCooldown: 60,
+		MaxBatch:     maxBatch,
+	}, func(string) {})
+}
+
+// TestFeedbackBatchesReplies verifies replies are joined into one message
+func TestFeedbackBatchesReplies(t *testing.T) {
+	f := newTestFeedback(4)
+	now := time.Now()
+
+	f.replyAt("ana", "you need $80 more for sword", now)
+	f.replyAt("leo", "you're dead, type !join to respawn", now)
+
+	msg := f.flush(now)
+	want := "@ana you need $80 more for sword | @leo you're dead, type !join to respawn"
+	if msg != want {
+		t.Errorf("Expected %q, got %q", want, msg)
+	}
+	if msg := f.flush(now); msg != "" {
+		t.Errorf("Expected nothing after a flush, got %q", msg)
+	}
+}
+
+// TestFeedbackUserCooldown verifies a viewer gets one reply per cooldown
+func TestFeedbackUserCooldown(t *testing.T) {
+	f := newTestFeedback(4)
+	now := time.Now()
+
+	f.replyAt("ana", "first", now)
+	f.replyAt("ANA", "second", now.Add(time.Second))
+	if msg := f.flush(now.Add(10 * time.Second)); msg != "@ana first" {
+		t.Errorf("Expected only the first reply, got %q", msg)
+	}
+
+	f.replyAt("ana", "third", now.Add(30*time.Second))
+	if msg := f.flush(now.Add(40 * time.Second)); msg != "" {
+		t.Errorf("Expected the cooldown to still apply, got %q", msg)
+	}
+
+	f.replyAt("ana", "fourth", now.Add(61*time.Second))
+	if msg := f.flush(now.Add(70 * time.Second)); msg != "@ana fourth" {
+		t.Errorf("Expected a reply after the cooldown, got %q", msg)
+	}
+}
+
+// TestFeedbackBatchLimit verifies overflow replies are dropped, not queued
+func TestFeedbackBatchLimit(t *testing.T) {
+	f := newTestFeedback(2)
+	now := time.Now()
+
+	for _, name := range []string{"a", "b", "c", "d"} {
+		f.replyAt(name, "slow down", now)
+	}
+	msg := f.flush(now)
+	if strings.Count(msg, "@") != 2 {
+		t.Errorf("Expected 2 replies, got %q", msg)
+	}
+	if msg := f.flush(now); msg != "" {
+		t.Errorf("Expected dropped replies not to carry over, got %q", msg)
+	}
+}
+
+// TestFeedbackMessageLength verifies the batch fits in one Kick chat message
+func TestFeedbackMessageLength(t *testing.T) {
+	f := newTestFeedback(10)
+	now := time.Now()
+
+	for i := 0; i < 10; i++ {
+		f.replyAt(string(rune('a'+i)), strings.Repeat("x", 100), now)
+	}
+	if msg := f.flush(now); len(msg) > maxChatMessageLength {
+		t.Errorf("Expected at most %d characters, got %d", maxChatMessageLength, len(msg))
+	}
+}
@@ -0,0 +1,93 @@
+package kick
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"fight-club/internal/config"
+)
+
+// HTTPConfig is an alias for config.KickHTTPConfig (SSOT)
+type HTTPConfig = config.KickHTTPConfig
+
+// NewHTTPClient builds the Kick API HTTP client from config.
+// Without a proxy URL, the standard HTTPS_PROXY/HTTP_PROXY/NO_PROXY
+// environment variables still apply.
+func NewHTTPClient(cfg HTTPConfig) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if cfg.ProxyURL != "" {
+		proxyURL, err := url.Parse(cfg.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		switch proxyURL.Scheme {
+		case "http", "https", "socks5", "socks5h":
+		default:
+			return nil, fmt.Errorf("unsupported proxy scheme %q (use http, https, socks5 or socks5h)", proxyURL.Scheme)
+		}
+		if proxyURL.Host == "" {
+			return nil, fmt.Errorf("proxy URL %q has no host", cfg.ProxyURL)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if cfg.CAFile != "" {
+		pemData, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pemData) {
+			return nil, fmt.Errorf("no PEM certificates found in %s", cfg.CAFile)
+		}
+		transport.TLSClientConfig = &tls.Config{
+			RootCAs:    pool,
+			MinVersion: tls.VersionTLS12,
+		}
+	}
+
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = config.DefaultKickHTTP().Timeout
+	}
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   time.Duration(timeout * float64(time.Second)),
+	}, nil
+}
+
+// SetHTTPConfig replaces the Kick API HTTP client (proxy, custom CAs, timeout).
+// Call during setup, before the service starts making requests.
+// On error the current client is kept.
+func (s *Service) SetHTTPConfig(cfg HTTPConfig) error {
+	client, err := NewHTTPClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.client = client
+	s.mu.Unlock()
+
+	if cfg.ProxyURL != "" {
+		// Don't log proxy credentials
+		if u, err := url.Parse(cfg.ProxyURL); err == nil {
+			log.Printf("🌐 Kick API proxy: %s://%s", u.Scheme, u.Host)
+		}
+	}
+	if cfg.CAFile != "" {
+		log.Printf("🔐 Kick API extra root CAs: %s", cfg.CAFile)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package kick
+
+import (
+	"errors"
+	"fmt"
+	"log"
+)
+
+// MaxTimeoutMinutes is the longest timeout the Kick API accepts (7 days)
+const MaxTimeoutMinutes = 10080
+
+// banRequest is the body of POST/DELETE /moderation/bans
+type banRequest struct {
+	BroadcasterUserID int64  `json:"broadcaster_user_id"`
+	UserID            int64  `json:"user_id"`
+	Duration          int    `json:"duration,omitempty"` // Minutes; omitted = permanent ban
+	Reason            string `json:"reason,omitempty"`
+}
+
+// moderationRequest sends a ban/timeout/unban for a user in the broadcaster's chat
+func (s *Service) moderationRequest(method string, req banRequest) error {
+	s.mu.RLock()
+	req.BroadcasterUserID = s.broadcasterID
+	s.mu.RUnlock()
+
+	if req.BroadcasterUserID == 0 {
+		return errors.New("broadcaster ID not set")
+	}
+	if req.UserID == 0 {
+		return errors.New("user ID not set")
+	}
+
+	if _, err := s.apiRequest(method, "/moderation/bans", req); err != nil {
+		return fmt.Errorf("moderation request failed: %w", err)
+	}
+	return nil
+}
+
+// TimeoutUser temporarily bans a user from chat (1 to MaxTimeoutMinutes minutes).
+// Requires the moderation:ban scope.
+func (s *Service) TimeoutUser(userID int64, minutes int, reason string) error {
+	if minutes < 1 {
+		minutes = 1
+	}
+	if minutes > MaxTimeoutMinutes {
+		minutes = MaxTimeoutMinutes
+	}
+	if err := s.moderationRequest("POST", banRequest{UserID: userID, Duration: minutes, Reason: reason}); err != nil {
+		return err
+	}
+	log.Printf("🔇 Timed out user %d for %d min: %s", userID, minutes, reason)
+	return nil
+}
+
+// BanUser permanently bans a user from chat. Requires the moderation:ban scope.
+func (s *Service) BanUser(userID int64, reason string) error {
+	if err := s.moderationRequest("POST", banRequest{UserID: userID, Reason: reason}); err != nil {
+		return err
+	}
+	log.Printf("🔨 Banned user %d: %s", userID, reason)
+	return nil
+}
+
+// UnbanUser lifts a ban or timeout. Requires the moderation:ban scope.
+func (s *Service) UnbanUser(userID int64) error {
+	if err := s.moderationRequest("DELETE", banRequest{UserID: userID}); err != nil {
+		return err
+	}
+	log.Printf("🔊 Unbanned user %d", userID)
+	return nil
+}
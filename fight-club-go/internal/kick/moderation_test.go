@@ -0,0 +1,32 @@
+package kick
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// TestBanRequestBody verifies timeouts send a duration and permanent bans omit it
+func TestBanRequestBody(t *testing.T) {
+	timeout, _ := json.Marshal(banRequest{BroadcasterUserID: 1, UserID: 42, Duration: 5, Reason: "spam"})
+	if string(timeout) != `{"broadcaster_user_id":1,"user_id":42,"duration":5,"reason":"spam"}` {
+		t.Errorf("Unexpected timeout body: %s", timeout)
+	}
+
+	ban, _ := json.Marshal(banRequest{BroadcasterUserID: 1, UserID: 42})
+	if string(ban) != `{"broadcaster_user_id":1,"user_id":42}` {
+		t.Errorf("Unexpected ban body: %s", ban)
+	}
+}
+
+// TestModerationRequiresIDs verifies no request is made without broadcaster and user IDs
+func TestModerationRequiresIDs(t *testing.T) {
+	s := NewService("id", "secret")
+	if err := s.TimeoutUser(42, 5, "spam"); err == nil {
+		t.Error("Expected error without broadcaster ID")
+	}
+
+	s.SetBroadcasterID(1)
+	if err := s.BanUser(0, "spam"); err == nil {
+		t.Error("Expected error without user ID")
+	}
+}
@@ -0,0 +1,144 @@
+package kick
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+)
+
+// pairingAlphabet leaves out look-alikes (0/O, 1/I) so codes survive being
+// retyped; 32 characters, so each random byte maps without bias
+const pairingAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// maxPairingFailures rotates the code after this many wrong guesses
+const maxPairingFailures = 5
+
+// ErrBadPairingCode is returned for a wrong or already used pairing code
+var ErrBadPairingCode = errors.New("invalid pairing code")
+
+// Pairing authorizes a one-time token upload to /api/kick/tokens from
+// cmd/kickauth, which completes the OAuth login on another machine (headless
+// servers have no browser, and the callback would need a public URL). The
+// code is only shown in the server log; it is replaced after each use and
+// after maxPairingFailures wrong attempts.
+type Pairing struct {
+	mu       sync.Mutex
+	code     string
+	failures int
+}
+
+// NewPairing creates a pairing with a fresh code
+func NewPairing() *Pairing {
+	return &Pairing{code: newPairingCode()}
+}
+
+// Code returns the current pairing code (XXXX-XXXX)
+func (p *Pairing) Code() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.code
+}
+
+// Consume checks a code and, if it matches, replaces it so it can't be reused
+func (p *Pairing) Consume(code string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	want := []byte(normalizePairingCode(p.code))
+	if subtle.ConstantTimeCompare([]byte(normalizePairingCode(code)), want) == 1 {
+		p.code, p.failures = newPairingCode(), 0
+		return nil
+	}
+
+	p.failures++
+	if p.failures >= maxPairingFailures {
+		p.code, p.failures = newPairingCode(), 0
+		log.Printf("🔑 Too many wrong Kick pairing codes, new code: %s", p.code)
+	}
+	return ErrBadPairingCode
+}
+
+// newPairingCode returns 8 random characters as XXXX-XXXX (40 bits)
+func newPairingCode() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	for i := range b {
+		b[i] = pairingAlphabet[int(b[i])%len(pairingAlphabet)]
+	}
+	return fmt.Sprintf("%s-%s", b[:4], b[4:])
+}
+
+// normalizePairingCode makes codes case and dash insensitive
+func normalizePairingCode(code string) string {
+	return strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
+
+// PairingCode returns the code cmd/kickauth needs to upload tokens to this server
+func (s *Service) PairingCode() string {
+	return s.pairing.Code()
+}
+
+// Tokens returns the current OAuth tokens (cmd/kickauth uploads them to a server)
+func (s *Service) Tokens() TokenData {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return TokenData{
+		AccessToken:   s.accessToken,
+		RefreshToken:  s.refreshToken,
+		TokenExpiry:   s.tokenExpiry,
+		UserID:        s.userID,
+		BroadcasterID: s.broadcasterID,
+	}
+}
+
+// ImportTokens replaces the OAuth tokens with ones obtained elsewhere
+// (cmd/kickauth). The tokens are checked against Kick before they are saved;
+// on error the previous tokens are kept.
+func (s *Service) ImportTokens(tokens TokenData) error {
+	if tokens.AccessToken == "" || tokens.RefreshToken == "" {
+		return errors.New("access and refresh tokens are required")
+	}
+
+	s.mu.Lock()
+	previous := TokenData{
+		AccessToken:   s.accessToken,
+		RefreshToken:  s.refreshToken,
+		TokenExpiry:   s.tokenExpiry,
+		UserID:        s.userID,
+		BroadcasterID: s.broadcasterID,
+	}
+	wasConnected := s.isConnected
+	s.accessToken = tokens.AccessToken
+	s.refreshToken = tokens.RefreshToken
+	s.tokenExpiry = tokens.TokenExpiry
+	if s.broadcasterID == 0 {
+		s.broadcasterID = tokens.BroadcasterID // BROADCASTER_ID wins when set
+	}
+	s.mu.Unlock()
+
+	if err := s.getUserInfo(); err != nil {
+		s.mu.Lock()
+		s.accessToken = previous.AccessToken
+		s.refreshToken = previous.RefreshToken
+		s.tokenExpiry = previous.TokenExpiry
+		s.userID = previous.UserID
+		s.broadcasterID = previous.BroadcasterID
+		s.mu.Unlock()
+		return fmt.Errorf("tokens rejected by Kick: %w", err)
+	}
+
+	s.mu.Lock()
+	s.isConnected = true
+	s.mu.Unlock()
+	if !wasConnected {
+		log.Println("✅ Kick tokens imported")
+	} else {
+		log.Println("✅ Kick tokens replaced")
+	}
+	s.saveTokens()
+	return nil
+}
@@ -0,0 +1,66 @@
+package kick
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestPairingConsume verifies codes are one-time, forgiving about formatting,
+// and rotate after too many wrong guesses
+func TestPairingConsume(t *testing.T) {
+	p := NewPairing()
+	code := p.Code()
+	if len(code) != 9 || code[4] != '-' {
+		t.Fatalf("Expected XXXX-XXXX code, got %q", code)
+	}
+
+	if err := p.Consume(strings.ToLower(strings.ReplaceAll(code, "-", ""))); err != nil {
+		t.Fatalf("Expected lowercase code without dash to match, got %v", err)
+	}
+	if p.Code() == code {
+		t.Error("Expected a new code after a successful pairing")
+	}
+	if err := p.Consume(code); !errors.Is(err, ErrBadPairingCode) {
+		t.Errorf("Expected used code to be rejected, got %v", err)
+	}
+
+	// The rejected reuse above already counts as one failure
+	current := p.Code()
+	for i := 2; i < maxPairingFailures; i++ {
+		p.Consume("WRONG-CODE")
+	}
+	if p.Code() != current {
+		t.Fatal("Expected code to survive fewer than the maximum failures")
+	}
+	p.Consume("WRONG-CODE")
+	if p.Code() == current {
+		t.Error("Expected code to rotate after the maximum failures")
+	}
+}
+
+// TestTokenUploadRoute verifies /tokens needs the pairing code and complete tokens
+func TestTokenUploadRoute(t *testing.T) {
+	s := NewServiceWithTokenStore("id", "secret", NewFileTokenStore(t.TempDir()+"/tokens.json", nil))
+	mux := http.NewServeMux()
+	s.SetupRoutes(mux, "http://localhost:3000", 3000)
+
+	post := func(body string) int {
+		req := httptest.NewRequest(http.MethodPost, "/tokens", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	if code := post(`{"code":"AAAA-AAAA","tokens":{"access_token":"a","refresh_token":"r"}}`); code != http.StatusForbidden {
+		t.Errorf("Expected 403 for a wrong pairing code, got %d", code)
+	}
+	if code := post(`{"code":"` + s.PairingCode() + `","tokens":{}}`); code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for missing tokens, got %d", code)
+	}
+	if s.IsConnected() {
+		t.Error("Expected service to stay disconnected after rejected uploads")
+	}
+}
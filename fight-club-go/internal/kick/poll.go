@@ -0,0 +1,101 @@
+package kick
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+)
+
+// pollsEndpoint is the channel polls resource. Kick's public API doesn't
+// document polls yet; until it does, creating a poll fails and the arena
+// vote falls back to !vote in chat (see game.VotePoll).
+const pollsEndpoint = "/chat/polls"
+
+// pollResultDisplay is how long Kick shows the results after a poll ends (seconds)
+const pollResultDisplay = 15
+
+// CreatePoll opens a poll in the broadcaster's chat for duration seconds
+// and returns its ID
+func (s *Service) CreatePoll(title string, options []string, duration int) (string, error) {
+	s.mu.RLock()
+	broadcasterID := s.broadcasterID
+	s.mu.RUnlock()
+
+	if broadcasterID == 0 {
+		return "", errors.New("broadcaster ID not set")
+	}
+	if len(options) < 2 {
+		return "", errors.New("a poll needs at least 2 options")
+	}
+
+	body := map[string]interface{}{
+		"broadcaster_user_id":     broadcasterID,
+		"title":                   title,
+		"options":                 options,
+		"duration":                duration,
+		"result_display_duration": pollResultDisplay,
+	}
+	resp, err := s.apiRequest("POST", pollsEndpoint, body)
+	if err != nil {
+		return "", fmt.Errorf("failed to create poll: %w", err)
+	}
+
+	poll, err := parsePoll(resp)
+	if err != nil {
+		return "", err
+	}
+	if poll.ID == "" {
+		return "", errors.New("poll created without an ID")
+	}
+	return poll.ID, nil
+}
+
+// GetPollResults returns the votes per option of a poll, in option order
+func (s *Service) GetPollResults(id string) ([]int, error) {
+	resp, err := s.apiRequest("GET", pollsEndpoint+"?id="+url.QueryEscape(id), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read poll: %w", err)
+	}
+	poll, err := parsePoll(resp)
+	if err != nil {
+		return nil, err
+	}
+	return poll.votes(), nil
+}
+
+// pollOption is one answer of a poll
+type pollOption struct {
+	ID    int    `json:"id"`
+	Label string `json:"label"`
+	Votes int    `json:"votes"`
+}
+
+// poll is a channel poll as returned by the API
+type poll struct {
+	ID      string       `json:"id"`
+	Options []pollOption `json:"options"`
+}
+
+// votes returns the votes per option, ordered by option ID
+func (p poll) votes() []int {
+	options := append([]pollOption(nil), p.Options...)
+	sort.SliceStable(options, func(i, j int) bool { return options[i].ID < options[j].ID })
+	votes := make([]int, len(options))
+	for i, o := range options {
+		votes[i] = o.Votes
+	}
+	return votes
+}
+
+// parsePoll reads a poll from a polls response ({"data": {...}})
+func parsePoll(body []byte) (poll, error) {
+	var result struct {
+		Data poll `json:"data"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return poll{}, fmt.Errorf("invalid poll response: %w", err)
+	}
+	return result.Data, nil
+}
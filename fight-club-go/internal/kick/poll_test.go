@@ -0,0 +1,29 @@
+package kick
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestParsePoll verifies poll responses and that votes follow option order
+func TestParsePoll(t *testing.T) {
+	p, err := parsePoll([]byte(`{"data":{"id":"p1","options":[{"id":2,"label":"Fog","votes":4},{"id":1,"label":"Giants","votes":9},{"id":3,"label":"Speed","votes":0}]},"message":"OK"}`))
+	if err != nil || p.ID != "p1" {
+		t.Fatalf("Expected poll p1, got %+v (%v)", p, err)
+	}
+	if votes := p.votes(); !reflect.DeepEqual(votes, []int{9, 4, 0}) {
+		t.Errorf("Expected votes [9 4 0], got %v", votes)
+	}
+
+	if _, err := parsePoll([]byte(`not json`)); err == nil {
+		t.Error("Expected error for invalid JSON")
+	}
+}
+
+// TestCreatePollNeedsBroadcaster verifies polls aren't attempted before login
+func TestCreatePollNeedsBroadcaster(t *testing.T) {
+	s := NewService("id", "secret")
+	if _, err := s.CreatePoll("Next?", []string{"a", "b"}, 30); err == nil {
+		t.Error("Expected error without a broadcaster ID")
+	}
+}
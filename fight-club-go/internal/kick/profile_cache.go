@@ -1,22 +1,40 @@
 package kick
 
 import (
+	"encoding/json"
 	"log"
+	"os"
+	"path/filepath"
+	"strconv"
 	"sync"
 	"sync/atomic"
 	"time"
+
+	"fight-club/internal/config"
 )
 
+// profileCacheSaveInterval is how often new profile URLs are flushed to disk
+const profileCacheSaveInterval = time.Minute
+
 // ProfileURLCache provides non-blocking access to user profile picture URLs.
 // It uses lazy loading to fetch URLs in the background without blocking the webhook handler.
+// Users without a profile picture are cached too (URL ""), and with a File
+// the URLs survive restarts instead of being looked up again.
 type ProfileURLCache struct {
 	cache   sync.Map // map[int64]cachedURL
 	pending sync.Map // map[int64]bool - prevents duplicate fetches
 	fetcher ProfileFetcher
 
 	// Concurrency control
-	sem      chan struct{}
-	maxAge   time.Duration
+	sem         chan struct{}
+	maxAge      time.Duration
+	negativeAge time.Duration // How long a user without an avatar stays cached
+
+	// Persistence ("" = memory only)
+	file   string
+	dirty  atomic.Bool
+	stopCh chan struct{}
+	wg     sync.WaitGroup
 
 	// Metrics
 	hits    atomic.Uint64
@@ -30,16 +48,18 @@ type ProfileFetcher interface {
 	GetUserProfilePicture(userID int64) (string, error)
 }
 
-// cachedURL holds a URL with expiry
+// cachedURL holds a URL with expiry (URL "" = the user has no profile picture)
 type cachedURL struct {
-	URL       string
-	FetchedAt time.Time
+	URL       string    `json:"url"`
+	FetchedAt time.Time `json:"fetchedAt"`
 }
 
 // ProfileCacheConfig holds configuration options
 type ProfileCacheConfig struct {
 	MaxConcurrentFetches int           // Max parallel API calls (default: 5)
 	MaxAge               time.Duration // How long to cache URLs (default: 1 hour)
+	NegativeMaxAge       time.Duration // How long to cache users without a picture (default: 10 minutes)
+	File                 string        // JSON file the URLs are persisted to ("" = memory only)
 }
 
 // DefaultProfileCacheConfig returns sensible defaults
@@ -47,7 +67,24 @@ func DefaultProfileCacheConfig() ProfileCacheConfig {
 	return ProfileCacheConfig{
 		MaxConcurrentFetches: 5,
 		MaxAge:               1 * time.Hour,
+		NegativeMaxAge:       10 * time.Minute,
+	}
+}
+
+// ProfileCacheConfigFromAvatar returns the URL cache settings of an avatar
+// cache configuration (URLs persisted in its directory)
+func ProfileCacheConfigFromAvatar(cfg config.AvatarCacheConfig) ProfileCacheConfig {
+	pc := DefaultProfileCacheConfig()
+	if cfg.URLTTL > 0 {
+		pc.MaxAge = time.Duration(cfg.URLTTL * float64(time.Second))
+	}
+	if cfg.NegativeTTL > 0 {
+		pc.NegativeMaxAge = time.Duration(cfg.NegativeTTL * float64(time.Second))
+	}
+	if cfg.Dir != "" {
+		pc.File = filepath.Join(cfg.Dir, "profile-urls.json")
 	}
+	return pc
 }
 
 // NewProfileURLCache creates a new profile URL cache
@@ -58,12 +95,58 @@ func NewProfileURLCache(fetcher ProfileFetcher, config ProfileCacheConfig) *Prof
 	if config.MaxAge <= 0 {
 		config.MaxAge = 1 * time.Hour
 	}
+	if config.NegativeMaxAge <= 0 {
+		config.NegativeMaxAge = 10 * time.Minute
+	}
 
 	return &ProfileURLCache{
-		fetcher: fetcher,
-		sem:     make(chan struct{}, config.MaxConcurrentFetches),
-		maxAge:  config.MaxAge,
+		fetcher:     fetcher,
+		sem:         make(chan struct{}, config.MaxConcurrentFetches),
+		maxAge:      config.MaxAge,
+		negativeAge: config.NegativeMaxAge,
+		file:        config.File,
+		stopCh:      make(chan struct{}),
+	}
+}
+
+// Start loads persisted URLs and begins periodic saving (no-op without a File)
+func (c *ProfileURLCache) Start() {
+	if c.file == "" {
+		return
 	}
+	c.load()
+
+	c.wg.Add(1)
+	go c.saveLoop()
+}
+
+// Stop stops periodic saving and flushes URLs to disk
+func (c *ProfileURLCache) Stop() {
+	if c.file == "" {
+		return
+	}
+	close(c.stopCh)
+	c.wg.Wait()
+	c.save()
+}
+
+// lookup returns a fresh entry, deleting it once expired
+func (c *ProfileURLCache) lookup(userID int64) (cachedURL, bool) {
+	cached, ok := c.cache.Load(userID)
+	if !ok {
+		return cachedURL{}, false
+	}
+	entry := cached.(cachedURL)
+	maxAge := c.maxAge
+	if entry.URL == "" {
+		maxAge = c.negativeAge
+	}
+	if time.Since(entry.FetchedAt) < maxAge {
+		return entry, true
+	}
+	// Expired - delete and trigger refresh
+	c.cache.Delete(userID)
+	return cachedURL{}, false
 }
 
 // Get returns the cached profile URL for a user, or empty string if not cached.
@@ -73,14 +156,9 @@ func (c *ProfileURLCache) Get(userID int64) string {
 		return ""
 	}
 
-	if cached, ok := c.cache.Load(userID); ok {
-		entry := cached.(cachedURL)
-		if time.Since(entry.FetchedAt) < c.maxAge {
-			c.hits.Add(1)
-			return entry.URL
-		}
-		// Expired - delete and trigger refresh
-		c.cache.Delete(userID)
+	if entry, ok := c.lookup(userID); ok {
+		c.hits.Add(1)
+		return entry.URL
 	}
 
 	c.misses.Add(1)
@@ -95,10 +173,12 @@ func (c *ProfileURLCache) GetOrFetchAsync(userID int64) string {
 		return ""
 	}
 
-	// Check cache first
-	if url := c.Get(userID); url != "" {
-		return url
+	// Check cache first (users known to have no picture aren't looked up again)
+	if entry, ok := c.lookup(userID); ok {
+		c.hits.Add(1)
+		return entry.URL
 	}
+	c.misses.Add(1)
 
 	// Trigger async fetch if not already pending
 	if _, alreadyPending := c.pending.LoadOrStore(userID, true); !alreadyPending {
@@ -118,6 +198,7 @@ func (c *ProfileURLCache) Set(userID int64, url string) {
 		URL:       url,
 		FetchedAt: time.Now(),
 	})
+	c.dirty.Store(true)
 }
 
 // fetchAsync downloads the profile URL in the background
@@ -141,12 +222,89 @@ func (c *ProfileURLCache) fetchAsync(userID int64) {
 		return
 	}
 
-	if url != "" {
-		c.cache.Store(userID, cachedURL{
-			URL:       url,
-			FetchedAt: time.Now(),
-		})
+	// Cached even when empty: users without a picture aren't refetched on every message
+	c.cache.Store(userID, cachedURL{
+		URL:       url,
+		FetchedAt: time.Now(),
+	})
+	c.dirty.Store(true)
+}
+
+// saveLoop flushes URLs to disk every profileCacheSaveInterval
+func (c *ProfileURLCache) saveLoop() {
+	defer c.wg.Done()
+
+	ticker := time.NewTicker(profileCacheSaveInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.stopCh:
+			return
+		case <-ticker.C:
+			c.save()
+		}
+	}
+}
+
+// save persists unexpired URLs if anything changed
+func (c *ProfileURLCache) save() {
+	if !c.dirty.Swap(false) {
+		return
+	}
+
+	entries := make(map[string]cachedURL)
+	c.cache.Range(func(key, value interface{}) bool {
+		if _, fresh := c.lookup(key.(int64)); fresh {
+			entries[strconv.FormatInt(key.(int64), 10)] = value.(cachedURL)
+		}
+		return true
+	})
+
+	data, err := json.Marshal(entries)
+	if err != nil {
+		log.Printf("⚠️ Failed to marshal profile URLs: %v", err)
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(c.file), 0o755); err != nil {
+		log.Printf("⚠️ Failed to save profile URLs: %v", err)
+		return
+	}
+	tmp := c.file + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		log.Printf("⚠️ Failed to save profile URLs: %v", err)
+		return
+	}
+	if err := os.Rename(tmp, c.file); err != nil {
+		log.Printf("⚠️ Failed to save profile URLs: %v", err)
+	}
+}
+
+// load restores persisted URLs that haven't expired
+func (c *ProfileURLCache) load() {
+	data, err := os.ReadFile(c.file)
+	if err != nil {
+		return // No saved URLs
+	}
+
+	var entries map[string]cachedURL
+	if err := json.Unmarshal(data, &entries); err != nil {
+		log.Printf("⚠️ Failed to parse saved profile URLs: %v", err)
+		return
+	}
+
+	loaded := 0
+	for key, entry := range entries {
+		userID, err := strconv.ParseInt(key, 10, 64)
+		if err != nil || userID == 0 {
+			continue
+		}
+		c.cache.Store(userID, entry)
+		if _, fresh := c.lookup(userID); fresh {
+			loaded++
+		}
 	}
+	log.Printf("🖼️ Loaded %d profile picture URLs from %s", loaded, c.file)
 }
 
 // Prefetch triggers background fetches for multiple users
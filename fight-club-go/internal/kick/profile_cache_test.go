@@ -0,0 +1,104 @@
+package kick
+
+import (
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"fight-club/internal/config"
+)
+
+type fakeProfileFetcher struct {
+	mu    sync.Mutex
+	urls  map[int64]string
+	calls int
+}
+
+func (f *fakeProfileFetcher) GetUserProfilePicture(userID int64) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	return f.urls[userID], nil
+}
+
+func (f *fakeProfileFetcher) callCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls
+}
+
+// waitForCalls waits until the async fetches have happened
+func waitForCalls(t *testing.T, f *fakeProfileFetcher, n int) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for f.callCount() < n {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected %d fetches, got %d", n, f.callCount())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	time.Sleep(10 * time.Millisecond) // Let the fetch store its result
+}
+
+// TestProfileCacheNegativeEntry verifies users without a picture are looked up once
+func TestProfileCacheNegativeEntry(t *testing.T) {
+	fetcher := &fakeProfileFetcher{urls: map[int64]string{}}
+	cache := NewProfileURLCache(fetcher, DefaultProfileCacheConfig())
+
+	cache.GetOrFetchAsync(7)
+	waitForCalls(t, fetcher, 1)
+
+	for i := 0; i < 5; i++ {
+		if url := cache.GetOrFetchAsync(7); url != "" {
+			t.Fatalf("Expected no URL, got %q", url)
+		}
+	}
+	time.Sleep(20 * time.Millisecond)
+	if calls := fetcher.callCount(); calls != 1 {
+		t.Errorf("Expected the missing picture to be cached, got %d fetches", calls)
+	}
+}
+
+// TestProfileCachePersistence verifies URLs survive a restart
+func TestProfileCachePersistence(t *testing.T) {
+	cfg := DefaultProfileCacheConfig()
+	cfg.File = filepath.Join(t.TempDir(), "profile-urls.json")
+
+	fetcher := &fakeProfileFetcher{urls: map[int64]string{1: "https://cdn.example/1.webp"}}
+	first := NewProfileURLCache(fetcher, cfg)
+	first.Start()
+	first.GetOrFetchAsync(1)
+	first.GetOrFetchAsync(2) // No picture
+	waitForCalls(t, fetcher, 2)
+	first.Set(3, "https://cdn.example/3.webp")
+	first.Stop()
+
+	restarted := &fakeProfileFetcher{urls: map[int64]string{}}
+	second := NewProfileURLCache(restarted, cfg)
+	second.Start()
+	defer second.Stop()
+
+	if url := second.GetOrFetchAsync(1); url != "https://cdn.example/1.webp" {
+		t.Errorf("Expected the fetched URL to be restored, got %q", url)
+	}
+	if url := second.GetOrFetchAsync(3); url != "https://cdn.example/3.webp" {
+		t.Errorf("Expected the webhook URL to be restored, got %q", url)
+	}
+	second.GetOrFetchAsync(2)
+	time.Sleep(20 * time.Millisecond)
+	if calls := restarted.callCount(); calls != 0 {
+		t.Errorf("Expected no fetches after the restart, got %d", calls)
+	}
+}
+
+// TestProfileCacheConfigFromAvatar verifies the avatar config maps to URL cache settings
+func TestProfileCacheConfigFromAvatar(t *testing.T) {
+	cfg := ProfileCacheConfigFromAvatar(config.AvatarCacheConfig{Dir: "cache", URLTTL: 7200, NegativeTTL: 300})
+	if cfg.MaxAge != 2*time.Hour || cfg.NegativeMaxAge != 5*time.Minute {
+		t.Errorf("Unexpected TTLs: %v / %v", cfg.MaxAge, cfg.NegativeMaxAge)
+	}
+	if cfg.File != filepath.Join("cache", "profile-urls.json") {
+		t.Errorf("Unexpected file %q", cfg.File)
+	}
+}
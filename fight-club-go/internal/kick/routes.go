@@ -72,26 +72,7 @@ func (s *Service) SetupRoutesWithOptions(mux *http.ServeMux, baseURL string, loc
 		}
 
 		// Subscribe to chat events in background - don't block the response
-		go func() {
-			defer func() {
-				if r := recover(); r != nil {
-					log.Printf("❌ PANIC in SubscribeToChatEvents: %v", r)
-				}
-			}()
-
-			// Initialize chatroom ID first
-			log.Println("🔄 Fetching chatroom ID...")
-			if err := s.InitializeChatroomID(); err != nil {
-				log.Printf("⚠️ Failed to initialize chatroom ID: %v", err)
-			}
-
-			log.Println("🔄 Starting chat events subscription...")
-			if err := s.SubscribeToChatEvents(); err != nil {
-				log.Printf("⚠️ Failed to subscribe to chat events: %v", err)
-			} else {
-				log.Println("✅ Subscribed to Kick chat events")
-			}
-		}()
+		go s.subscribeAfterAuth()
 
 		log.Println("✅ OAuth callback successful, sending success page")
 
@@ -132,6 +113,44 @@ func (s *Service) SetupRoutesWithOptions(mux *http.ServeMux, baseURL string, loc
 		`, redirectTarget)
 	})
 
+	// Token upload from cmd/kickauth (headless servers), authorized by the
+	// one-time pairing code printed in the server log
+	mux.HandleFunc("/tokens", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		var req struct {
+			Code   string    `json:"code"`
+			Tokens TokenData `json:"tokens"`
+		}
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 64<<10)).Decode(&req); err != nil {
+			http.Error(w, "Invalid JSON", http.StatusBadRequest)
+			return
+		}
+		if err := s.pairing.Consume(req.Code); err != nil {
+			log.Printf("⚠️ Kick token upload rejected from %s: %v", r.RemoteAddr, err)
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		if err := s.ImportTokens(req.Tokens); err != nil {
+			log.Printf("❌ Kick token upload failed: %v (new pairing code: %s)", err, s.PairingCode())
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		go s.subscribeAfterAuth()
+
+		authInfo := s.GetAuthInfo()
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success":       true,
+			"userId":        authInfo.UserID,
+			"broadcasterId": authInfo.BroadcasterID,
+		})
+	})
+
 	// Webhook endpoint for Kick events (this needs the tunnel URL)
 	mux.HandleFunc("/webhook", s.HandleWebhook)
 
@@ -139,8 +158,12 @@ func (s *Service) SetupRoutesWithOptions(mux *http.ServeMux, baseURL string, loc
 	mux.HandleFunc("/status", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(map[string]interface{}{
-			"connected":     s.IsConnected(),
-			"broadcasterID": s.broadcasterID,
+			"connected":         s.IsConnected(),
+			"broadcasterID":     s.broadcasterID,
+			"webhookSignatures": s.GetWebhookSignatureStats(),
+			"webhookDuplicates": s.GetDedupStats(),
+			"channels":          s.ChannelSubscriptions(),
+			"openCircuits":      s.OpenCircuits(),
 		})
 	})
 
@@ -259,3 +282,26 @@ func (s *Service) SetupRoutesWithOptions(mux *http.ServeMux, baseURL string, loc
 	log.Printf("   - Test Bot Message: POST %s/api/kick/test-bot-message", baseURL)
 	log.Printf("   - Update Category: POST %s/api/kick/update-category", baseURL)
 }
+
+// subscribeAfterAuth fetches the chatroom and subscribes to chat events once
+// the broadcaster has logged in (OAuth callback or token upload)
+func (s *Service) subscribeAfterAuth() {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("❌ PANIC in SubscribeToChatEvents: %v", r)
+		}
+	}()
+
+	// Initialize chatroom ID first
+	log.Println("🔄 Fetching chatroom ID...")
+	if err := s.InitializeChatroomID(); err != nil {
+		log.Printf("⚠️ Failed to initialize chatroom ID: %v", err)
+	}
+
+	log.Println("🔄 Starting chat events subscription...")
+	if err := s.SubscribeToChatEvents(); err != nil {
+		log.Printf("⚠️ Failed to subscribe to chat events: %v", err)
+	} else {
+		log.Println("✅ Subscribed to Kick chat events")
+	}
+}
@@ -3,6 +3,7 @@ package kick
 import (
 	"bytes"
 	"crypto/rand"
+	"crypto/rsa"
 	"crypto/sha256"
 	"encoding/base64"
 	"encoding/json"
@@ -13,11 +14,12 @@ import (
 	"net/http"
 	"net/url"
 	"os"
-	"path/filepath"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
+
+	"fight-club/internal/config"
 )
 
 const (
@@ -26,7 +28,7 @@ const (
 	APIBaseV2 = "https://api.kick.com/public/v2"
 	AuthBase  = "https://id.kick.com"
 
-	// Token file for persistence
+	// Legacy plaintext token file (see token_store.go)
 	TokenFileName = ".kick-tokens-go.json"
 )
 
@@ -45,6 +47,10 @@ type Service struct {
 	broadcasterSlug string
 	chatroomID      int // Cached chatroom ID from webhooks
 
+	// Guest channels (co-op streams, see channels.go)
+	guests        map[int64]string // broadcaster ID -> player name prefix
+	subscriptions map[int64]*ChannelSubscription
+
 	// PKCE state
 	codeVerifier string
 	pkceState    string
@@ -57,14 +63,35 @@ type Service struct {
 	// HTTP client
 	client *http.Client
 
+	// Token persistence (file or database, optionally encrypted)
+	tokenStore TokenStore
+
 	// Event handlers
 	onChatMessage func(msg ChatMessage)
+	onAuthError   func(err error) // Token refresh failed (re-login required)
 
 	// Async processing
 	asyncHandler bool // If true, handler is called in goroutine
 
 	// Status
 	isConnected bool
+
+	// Webhook signature verification (see webhook_signature.go)
+	signatureMode    SignatureMode
+	webhookPublicKey *rsa.PublicKey
+	sigValid         int64 // atomic
+	sigInvalid       int64 // atomic
+	sigRejected      int64 // atomic
+
+	// Re-delivered webhooks are processed once (see dedup.go)
+	dedup *MessageDedup
+
+	// One-time code for token uploads from cmd/kickauth (see pairing.go)
+	pairing *Pairing
+
+	// API retry policy and per-endpoint circuit breakers (see api_errors.go)
+	retry    apiRetryPolicy
+	breakers map[string]*circuitBreaker
 }
 
 // TokenData for persistence
@@ -87,7 +114,11 @@ type ChatMessage struct {
 	Command       string
 	Args          []string
 	BroadcasterID int64
+	ChannelPrefix string // Guest channel prefix ("" = own channel)
+	IsModerator   bool   // Sender has the moderator badge in the channel
+	IsSubscriber  bool   // Sender has the subscriber or founder badge
 	CreatedAt     time.Time
+	ReceivedAt    time.Time // Webhook arrival (lag compensation reference)
 }
 
 // WebhookChatPayload matches Kick webhook structure
@@ -106,17 +137,33 @@ type WebhookChatPayload struct {
 		Username       string `json:"username"`
 		ProfilePicture string `json:"profile_picture"`
 		ChannelSlug    string `json:"channel_slug"`
+		Identity       struct {
+			Badges []struct {
+				Type string `json:"type"`
+			} `json:"badges"`
+		} `json:"identity"`
 	} `json:"sender"`
 }
 
-// NewService creates a new Kick service
+// NewService creates a new Kick service with tokens in the plaintext token file
 func NewService(clientID, clientSecret string) *Service {
+	return NewServiceWithTokenStore(clientID, clientSecret, NewFileTokenStore(TokenFileName, nil))
+}
+
+// NewServiceWithTokenStore creates a new Kick service persisting tokens to store
+// (see NewTokenStore for the configured file/SQL store with encryption)
+func NewServiceWithTokenStore(clientID, clientSecret string, store TokenStore) *Service {
 	s := &Service{
 		clientID:     clientID,
 		clientSecret: clientSecret,
 		client: &http.Client{
-			Timeout: 10 * time.Second,
+			Timeout: 10 * time.Second, // See SetHTTPConfig for proxy/CA/timeout overrides
 		},
+		tokenStore:    store,
+		signatureMode: SignatureModeLogOnly,
+		dedup:         NewMessageDedup(config.DefaultKickDedup()),
+		pairing:       NewPairing(),
+		retry:         defaultAPIRetry,
 	}
 
 	// Try to load saved tokens
@@ -146,6 +193,14 @@ func (s *Service) OnChatMessage(handler func(ChatMessage)) {
 	s.mu.Unlock()
 }
 
+// OnAuthError registers a callback for when the access token can no longer be
+// refreshed and the broadcaster must log in again
+func (s *Service) OnAuthError(handler func(error)) {
+	s.mu.Lock()
+	s.onAuthError = handler
+	s.mu.Unlock()
+}
+
 // SetAsyncHandler enables/disables async handler execution.
 // When enabled, chat message handlers are called in a separate goroutine,
 // preventing webhook response latency from affecting game performance.
@@ -188,6 +243,7 @@ func (s *Service) GetAuthURL(redirectURI string) string {
 		"chat:write",
 		"events:subscribe",
 		"channel:write",
+		"moderation:ban", // Anti-abuse timeouts (see moderation.go)
 	}
 
 	params := url.Values{
@@ -422,17 +478,86 @@ func (s *Service) GetUserProfilePicture(userID int64) (string, error) {
 	return "", nil
 }
 
-// apiRequest makes an authenticated API request
+// apiRequest calls the Kick API and returns the response body. Transient
+// failures are retried with jittered backoff (see apiRetryPolicy), a 401
+// refreshes the token once, and an endpoint that keeps failing is skipped by
+// its circuit breaker for a while. Errors match ErrRateLimited,
+// ErrUnauthorized, ErrServerError and ErrCircuitOpen with errors.Is;
+// non-2xx responses are *APIError.
 func (s *Service) apiRequest(method, endpoint string, body interface{}) ([]byte, error) {
+	path := endpointPath(endpoint)
+	breaker := s.breaker(path)
+
+	var jsonBody []byte
+	if body != nil {
+		var err error
+		if jsonBody, err = json.Marshal(body); err != nil {
+			return nil, err
+		}
+	}
+
+	s.mu.RLock()
+	policy := s.retry
+	s.mu.RUnlock()
+	if policy.Attempts <= 0 {
+		policy = defaultAPIRetry
+	}
+
+	var (
+		resp      []byte
+		err       error
+		refreshed bool
+	)
+	for attempt := 1; ; attempt++ {
+		if !breaker.allow(time.Now()) {
+			err = fmt.Errorf("%s %s: %w", method, path, ErrCircuitOpen)
+			break
+		}
+
+		resp, err = s.apiAttempt(method, endpoint, path, jsonBody, refreshed)
+		failed := errors.Is(err, ErrServerError) || isNetworkError(err)
+		if breaker.record(failed, time.Now()) {
+			kickAPICircuitOpen.WithLabelValues(path).Set(1)
+			log.Printf("⚠️ Kick API %s failing, pausing it for %s: %v", path, breakerCooldown, err)
+		} else if !failed {
+			kickAPICircuitOpen.WithLabelValues(path).Set(0)
+		}
+
+		if errors.Is(err, ErrUnauthorized) && !refreshed {
+			// The token was revoked or expired early: refresh and try again
+			refreshed = true
+			attempt--
+			continue
+		}
+		if err == nil || attempt >= policy.Attempts || !policy.retryable(method, err) {
+			break
+		}
+		kickAPIRetriesTotal.WithLabelValues(path).Inc()
+		time.Sleep(policy.delay(attempt, err))
+	}
+
+	kickAPIRequestsTotal.WithLabelValues(path, apiResult(err)).Inc()
+	return resp, err
+}
+
+// apiAttempt sends one API request, refreshing the token first when it is
+// about to expire (or when refresh is set, after a 401)
+func (s *Service) apiAttempt(method, endpoint, path string, jsonBody []byte, refresh bool) ([]byte, error) {
 	s.mu.RLock()
 	token := s.accessToken
 	expiry := s.tokenExpiry
 	s.mu.RUnlock()
 
 	// Refresh if needed
-	if time.Now().Add(time.Minute).After(expiry) {
+	if refresh || time.Now().Add(time.Minute).After(expiry) {
 		if err := s.RefreshToken(); err != nil {
-			return nil, fmt.Errorf("token refresh failed: %w", err)
+			s.mu.RLock()
+			onAuthError := s.onAuthError
+			s.mu.RUnlock()
+			if onAuthError != nil {
+				go onAuthError(err)
+			}
+			return nil, fmt.Errorf("%w: %w", ErrUnauthorized, err)
 		}
 		s.mu.RLock()
 		token = s.accessToken
@@ -440,15 +565,11 @@ func (s *Service) apiRequest(method, endpoint string, body interface{}) ([]byte,
 	}
 
 	if token == "" {
-		return nil, errors.New("not authenticated")
+		return nil, fmt.Errorf("%w: not authenticated", ErrUnauthorized)
 	}
 
 	var bodyReader io.Reader
-	if body != nil {
-		jsonBody, err := json.Marshal(body)
-		if err != nil {
-			return nil, err
-		}
+	if jsonBody != nil {
 		bodyReader = bytes.NewReader(jsonBody)
 	}
 
@@ -459,7 +580,7 @@ func (s *Service) apiRequest(method, endpoint string, body interface{}) ([]byte,
 
 	req.Header.Set("Authorization", "Bearer "+token)
 	req.Header.Set("Accept", "application/json")
-	if body != nil {
+	if jsonBody != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
 
@@ -475,43 +596,28 @@ func (s *Service) apiRequest(method, endpoint string, body interface{}) ([]byte,
 	}
 
 	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("API error %d: %s", resp.StatusCode, string(respBody))
+		return nil, newAPIError(method, path, resp, respBody)
 	}
 
 	return respBody, nil
 }
 
-// SubscribeToChatEvents subscribes to chat message events via webhook
+// SubscribeToChatEvents subscribes to chat message events of the own and guest channels via webhook
 func (s *Service) SubscribeToChatEvents() error {
 	s.mu.RLock()
 	broadcasterID := s.broadcasterID
 	webhookURL := s.webhookURL
 	s.mu.RUnlock()
 
-	if broadcasterID == 0 {
-		return errors.New("broadcaster ID not set")
-	}
-
-	// Note: Kick webhooks are registered against your app,
-	// the callback URL is set in the Kick Developer Dashboard
-	body := map[string]interface{}{
-		"events": []map[string]interface{}{
-			{"name": "chat.message.sent", "version": 1},
-		},
-		"method":              "webhook",
-		"broadcaster_user_id": broadcasterID,
-	}
-
-	resp, err := s.apiRequest("POST", "/events/subscriptions", body)
-	if err != nil {
-		return fmt.Errorf("subscription failed: %w", err)
+	if err := s.SubscribeChannel(broadcasterID); err != nil {
+		return err
 	}
 
 	log.Printf("✅ Subscribed to Kick chat events (broadcaster: %d)", broadcasterID)
 	log.Printf("📡 Webhook URL should be configured in Kick Dashboard: %s", webhookURL)
 
-	var result map[string]interface{}
-	json.Unmarshal(resp, &result)
+	// Guest failures are logged only: the own channel keeps working
+	s.subscribeGuests()
 
 	return nil
 }
@@ -533,6 +639,7 @@ func (s *Service) GetSubscriptions() ([]map[string]interface{}, error) {
 
 // HandleWebhook processes incoming webhook requests
 func (s *Service) HandleWebhook(w http.ResponseWriter, r *http.Request) {
+	receivedAt := time.Now()
 	if r.Method != http.MethodPost {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
@@ -545,8 +652,23 @@ func (s *Service) HandleWebhook(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Verify Kick-Event-Signature before trusting the payload
+	if !s.checkWebhookSignature(r, body) {
+		http.Error(w, "Invalid signature", http.StatusUnauthorized)
+		return
+	}
+
 	// Get event type from header
 	eventType := r.Header.Get("Kick-Event-Type")
+
+	// Kick re-delivers webhooks it considers unacknowledged: acknowledge
+	// again so it stops retrying, but process each delivery once
+	if id := r.Header.Get(HeaderEventMessageID); s.isDuplicate("event", id, receivedAt) {
+		log.Printf("♻️ Duplicate Kick webhook %s (%s) ignored", id, eventType)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("OK"))
+		return
+	}
 	log.Printf("📨 Kick webhook: %s", eventType)
 
 	// Handle chat message
@@ -558,8 +680,17 @@ func (s *Service) HandleWebhook(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		// Capture chatroom ID if present
-		if payload.ChatroomID != 0 {
+		// The same chat message under a new delivery ID is a replay too
+		if s.isDuplicate("chat", payload.MessageID, receivedAt) {
+			log.Printf("♻️ Duplicate chat message %s from %s ignored", payload.MessageID, payload.Sender.Username)
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte("OK"))
+			return
+		}
+
+		// Capture chatroom ID if present (own channel only: the bot replies there)
+		guest := s.isGuest(payload.Broadcaster.UserID)
+		if payload.ChatroomID != 0 && !guest {
 			s.mu.Lock()
 			if s.chatroomID == 0 {
 				log.Printf("✅ Captured Chatroom ID: %d", payload.ChatroomID)
@@ -575,6 +706,18 @@ func (s *Service) HandleWebhook(w http.ResponseWriter, r *http.Request) {
 			UserID:        payload.Sender.UserID,
 			ProfilePic:    payload.Sender.ProfilePicture,
 			BroadcasterID: payload.Broadcaster.UserID,
+			ReceivedAt:    receivedAt,
+		}
+		if guest {
+			msg.ChannelPrefix = s.GuestPrefix(payload.Broadcaster.UserID)
+		}
+		for _, badge := range payload.Sender.Identity.Badges {
+			switch badge.Type {
+			case "moderator":
+				msg.IsModerator = true
+			case "subscriber", "founder":
+				msg.IsSubscriber = true
+			}
 		}
 
 		// Parse command
@@ -590,9 +733,9 @@ func (s *Service) HandleWebhook(w http.ResponseWriter, r *http.Request) {
 		// Debug logging
 		if msg.IsCommand {
 			log.Printf("💬 [%s] COMMAND: !%s (UserID: %d, ProfilePic: %v)",
-				msg.Username, msg.Command, msg.UserID, msg.ProfilePic != "")
+				msg.PlayerName(), msg.Command, msg.UserID, msg.ProfilePic != "")
 		} else {
-			log.Printf("💬 [%s]: %s", msg.Username, msg.Content)
+			log.Printf("💬 [%s]: %s", msg.PlayerName(), msg.Content)
 		}
 
 		// Call handler (async to prevent webhook latency affecting game)
@@ -623,7 +766,22 @@ func (s *Service) IsConnected() bool {
 	return s.isConnected
 }
 
-// saveTokens persists tokens to disk
+// CheckAuth reports whether the Kick credentials are usable (for readiness probes).
+// An expired access token is fine as long as it can be refreshed on the next request.
+func (s *Service) CheckAuth() error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if !s.isConnected || s.accessToken == "" {
+		return errors.New("not authenticated with Kick (visit /api/kick/auth)")
+	}
+	if time.Now().After(s.tokenExpiry) && s.refreshToken == "" {
+		return errors.New("access token expired and no refresh token")
+	}
+	return nil
+}
+
+// saveTokens persists tokens to the token store
 func (s *Service) saveTokens() {
 	s.mu.RLock()
 	data := TokenData{
@@ -635,40 +793,21 @@ func (s *Service) saveTokens() {
 	}
 	s.mu.RUnlock()
 
-	jsonData, err := json.MarshalIndent(data, "", "  ")
-	if err != nil {
-		log.Printf("⚠️ Failed to marshal tokens: %v", err)
+	if err := s.tokenStore.Save(data); err != nil {
+		log.Printf("⚠️ Failed to save tokens: %v", err)
 		return
 	}
 
-	// Save to current directory
-	if err := os.WriteFile(TokenFileName, jsonData, 0600); err != nil {
-		// Try parent directory
-		parentPath := filepath.Join("..", TokenFileName)
-		if err := os.WriteFile(parentPath, jsonData, 0600); err != nil {
-			log.Printf("⚠️ Failed to save tokens: %v", err)
-			return
-		}
-	}
-
 	log.Println("💾 Kick tokens saved")
 }
 
-// loadTokens loads persisted tokens from disk
+// loadTokens loads persisted tokens from the token store
 func (s *Service) loadTokens() {
-	// Try current directory first
-	data, err := os.ReadFile(TokenFileName)
+	tokens, err := s.tokenStore.Load()
 	if err != nil {
-		// Try parent directory
-		data, err = os.ReadFile(filepath.Join("..", TokenFileName))
-		if err != nil {
-			return // No saved tokens
+		if !errors.Is(err, ErrNoTokens) {
+			log.Printf("⚠️ Failed to load saved tokens: %v", err)
 		}
-	}
-
-	var tokens TokenData
-	if err := json.Unmarshal(data, &tokens); err != nil {
-		log.Printf("⚠️ Failed to parse saved tokens: %v", err)
 		return
 	}
 
@@ -860,6 +999,32 @@ func (s *Service) SetCategory(categoryName string) error {
 	return nil
 }
 
+// UpdateChannel sets the stream category and/or title in one request.
+// Empty values are left unchanged.
+func (s *Service) UpdateChannel(categoryName, title string) error {
+	body := map[string]interface{}{}
+	if categoryName != "" {
+		cat, err := s.SearchCategory(categoryName)
+		if err != nil {
+			return fmt.Errorf("could not find category '%s': %w", categoryName, err)
+		}
+		body["category_id"] = cat.ID
+	}
+	if title != "" {
+		body["stream_title"] = title
+	}
+	if len(body) == 0 {
+		return nil
+	}
+
+	if _, err := s.apiRequest("PATCH", "/channels", body); err != nil {
+		return fmt.Errorf("failed to update channel: %w", err)
+	}
+
+	log.Printf("✅ Channel updated (category: %q, title: %q)", categoryName, title)
+	return nil
+}
+
 // getChannelID fetches the channel ID
 func (s *Service) getChannelID() (int64, error) {
 	s.mu.RLock()
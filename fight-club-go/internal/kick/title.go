@@ -0,0 +1,156 @@
+package kick
+
+import (
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"fight-club/internal/config"
+)
+
+// TitleConfig is an alias for config.KickTitleConfig (SSOT)
+type TitleConfig = config.KickTitleConfig
+
+// minTitleInterval keeps title updates well under Kick API rate limits
+const minTitleInterval = 30 * time.Second
+
+// ChannelUpdater is the interface for patching the channel category/title
+type ChannelUpdater interface {
+	UpdateChannel(categoryName, title string) error
+}
+
+// TitleStats are the live game stats rendered into the channel title
+type TitleStats struct {
+	Players   int
+	Alive     int
+	Kills     int    // Total kills this session
+	TopKiller string // "" = nobody has a kill yet
+	TopKills  int
+}
+
+// RenderTitle fills a title template with live stats (see config.KickTitleConfig)
+func RenderTitle(template string, stats TitleStats) string {
+	top := stats.TopKiller
+	if top == "" {
+		top = "nobody yet"
+	}
+	return strings.NewReplacer(
+		"{players}", strconv.Itoa(stats.Players),
+		"{alive}", strconv.Itoa(stats.Alive),
+		"{kills}", strconv.Itoa(stats.Kills),
+		"{top}", top,
+		"{topkills}", strconv.Itoa(stats.TopKills),
+	).Replace(template)
+}
+
+// TitleUpdater periodically patches the channel title with live game stats.
+// The title is only sent when it changed since the last successful update.
+type TitleUpdater struct {
+	updater  ChannelUpdater
+	template string
+	interval time.Duration
+	stats    func() (TitleStats, bool) // false = leave the title alone (e.g. stream offline)
+
+	mu     sync.Mutex
+	last   string // Last title sent
+	failed bool   // Last update failed (log recovery once)
+
+	running bool
+	stopCh  chan struct{}
+	wg      sync.WaitGroup
+}
+
+// NewTitleUpdater creates a title updater reading stats on every update
+func NewTitleUpdater(updater ChannelUpdater, cfg TitleConfig, stats func() (TitleStats, bool)) *TitleUpdater {
+	interval := time.Duration(cfg.Interval * float64(time.Second))
+	if interval < minTitleInterval {
+		interval = minTitleInterval
+	}
+	return &TitleUpdater{
+		updater:  updater,
+		template: cfg.Template,
+		interval: interval,
+		stats:    stats,
+		stopCh:   make(chan struct{}),
+	}
+}
+
+// Start updates the title every interval
+func (t *TitleUpdater) Start() {
+	t.mu.Lock()
+	if t.running {
+		t.mu.Unlock()
+		return
+	}
+	t.running = true
+	t.mu.Unlock()
+
+	t.wg.Add(1)
+	go func() {
+		defer t.wg.Done()
+		ticker := time.NewTicker(t.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				t.Update()
+			case <-t.stopCh:
+				return
+			}
+		}
+	}()
+	log.Printf("📝 Channel title updates every %s", t.interval)
+}
+
+// Stop ends title updates
+func (t *TitleUpdater) Stop() {
+	t.mu.Lock()
+	if !t.running {
+		t.mu.Unlock()
+		return
+	}
+	t.running = false
+	t.mu.Unlock()
+
+	close(t.stopCh)
+	t.wg.Wait()
+}
+
+// Update renders the title and sends it if it changed
+func (t *TitleUpdater) Update() {
+	stats, ok := t.stats()
+	if !ok {
+		return
+	}
+	title := RenderTitle(t.template, stats)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if title == "" || title == t.last {
+		return
+	}
+
+	if err := t.updater.UpdateChannel("", title); err != nil {
+		if !t.failed {
+			log.Printf("⚠️ Channel title update failed: %v", err)
+		}
+		t.failed = true
+		return
+	}
+	if t.failed {
+		log.Printf("📝 Channel title updates working again")
+	}
+	t.failed = false
+	t.last = title
+}
+
+// Reset forgets the last title, so the next update is sent even if unchanged
+// (call after something else changed the title, e.g. auto-stream going live)
+func (t *TitleUpdater) Reset() {
+	t.mu.Lock()
+	t.last = ""
+	t.mu.Unlock()
+}
@@ -0,0 +1,72 @@
+package kick
+
+import (
+	"errors"
+	"testing"
+
+	"fight-club/internal/config"
+)
+
+type fakeChannelUpdater struct {
+	titles []string
+	err    error
+}
+
+func (f *fakeChannelUpdater) UpdateChannel(categoryName, title string) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.titles = append(f.titles, title)
+	return nil
+}
+
+// TestRenderTitle verifies the default template and the no-kills fallback
+func TestRenderTitle(t *testing.T) {
+	template := config.DefaultKickTitle().Template
+	got := RenderTitle(template, TitleStats{Players: 42, TopKiller: "Foo", TopKills: 17})
+	if want := "FIGHT CLUB — 42 players, top killer: Foo (17)"; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+
+	got = RenderTitle("{alive}/{players} alive, {kills} kills, MVP {top}", TitleStats{Players: 3, Alive: 2})
+	if want := "2/3 alive, 0 kills, MVP nobody yet"; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+// TestTitleUpdater verifies titles are sent only on change, skipped on request, and retried after errors
+func TestTitleUpdater(t *testing.T) {
+	channel := &fakeChannelUpdater{}
+	stats, send := TitleStats{Players: 5}, true
+	updater := NewTitleUpdater(channel, TitleConfig{Template: "{players} fighters"}, func() (TitleStats, bool) {
+		return stats, send
+	})
+
+	updater.Update()
+	updater.Update()
+	if len(channel.titles) != 1 || channel.titles[0] != "5 fighters" {
+		t.Fatalf("Expected a single title update, got %v", channel.titles)
+	}
+
+	send = false
+	stats.Players = 6
+	updater.Update()
+	if len(channel.titles) != 1 {
+		t.Errorf("Expected no update while skipped, got %v", channel.titles)
+	}
+
+	send = true
+	channel.err = errors.New("API error 429")
+	updater.Update()
+	channel.err = nil
+	updater.Update()
+	if len(channel.titles) != 2 || channel.titles[1] != "6 fighters" {
+		t.Errorf("Expected the failed title retried, got %v", channel.titles)
+	}
+
+	updater.Reset()
+	updater.Update()
+	if len(channel.titles) != 3 {
+		t.Errorf("Expected an unchanged title resent after Reset, got %v", channel.titles)
+	}
+}
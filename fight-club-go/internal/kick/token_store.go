@@ -0,0 +1,344 @@
+package kick
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"fight-club/internal/config"
+
+	_ "modernc.org/sqlite" // Registers the "sqlite" database/sql driver
+)
+
+// TokenStoreConfig is an alias for config.KickTokenStoreConfig (SSOT)
+type TokenStoreConfig = config.KickTokenStoreConfig
+
+// ErrNoTokens is returned by TokenStore.Load when nothing has been saved yet
+var ErrNoTokens = errors.New("no saved tokens")
+
+// TokenStore persists Kick OAuth tokens between restarts
+type TokenStore interface {
+	Load() (TokenData, error) // ErrNoTokens if empty
+	Save(tokens TokenData) error
+}
+
+// NewTokenStore creates the configured token store and migrates tokens from
+// the legacy plaintext .kick-tokens-go.json (working or parent directory) into it.
+// There is no OS keyring backend: the server runs on headless hosts without a
+// Secret Service/Keychain session, so tokens are encrypted with KICK_TOKEN_KEY instead.
+func NewTokenStore(cfg TokenStoreConfig) (TokenStore, error) {
+	key, err := ParseTokenKey(cfg.EncryptionKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var store TokenStore
+	switch cfg.Backend {
+	case "", "file":
+		store = NewFileTokenStore(cfg.File, key)
+	case "sql", "sqlite":
+		sqlStore, err := OpenSQLTokenStore(cfg.DBDriver, cfg.DBDSN, key)
+		if err != nil {
+			return nil, err
+		}
+		store = sqlStore
+	default:
+		return nil, fmt.Errorf("unknown token store %q (use file or sql)", cfg.Backend)
+	}
+
+	if err := migrateLegacyTokens(store); err != nil {
+		log.Printf("⚠️ Failed to migrate legacy Kick tokens: %v", err)
+	}
+	return store, nil
+}
+
+// =============================================================================
+// ENCRYPTION
+// =============================================================================
+
+// tokenCipher encrypts token data with AES-256-GCM
+type tokenCipher struct {
+	aead cipher.AEAD
+}
+
+// TokenKeySize is the AES-256 key length KICK_TOKEN_KEY must decode to
+const TokenKeySize = 32
+
+// ParseTokenKey decodes a random 32-byte key given as hex or base64
+// (`openssl rand -base64 32`). Returns nil (plaintext) for an empty string.
+// Passphrases are rejected: the key is used as-is, not stretched.
+func ParseTokenKey(s string) ([]byte, error) {
+	if s == "" {
+		return nil, nil
+	}
+	decoders := []func(string) ([]byte, error){
+		hex.DecodeString,
+		base64.StdEncoding.DecodeString,
+		base64.RawStdEncoding.DecodeString,
+		base64.URLEncoding.DecodeString,
+		base64.RawURLEncoding.DecodeString,
+	}
+	for _, decode := range decoders {
+		if key, err := decode(s); err == nil && len(key) == TokenKeySize {
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("KICK_TOKEN_KEY must be %d random bytes as hex or base64 (generate with: openssl rand -base64 32)", TokenKeySize)
+}
+
+// newTokenCipher creates a cipher for a key from ParseTokenKey.
+// Returns nil (plaintext) for a nil key.
+func newTokenCipher(key []byte) *tokenCipher {
+	if key == nil {
+		return nil
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		panic(fmt.Sprintf("kick: invalid token key: %v", err)) // ParseTokenKey guarantees 32 bytes
+	}
+	aead, _ := cipher.NewGCM(block)
+	return &tokenCipher{aead: aead}
+}
+
+// seal returns nonce || ciphertext
+func (tc *tokenCipher) seal(plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, tc.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return tc.aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// open decrypts nonce || ciphertext
+func (tc *tokenCipher) open(sealed []byte) ([]byte, error) {
+	if len(sealed) < tc.aead.NonceSize() {
+		return nil, errors.New("encrypted tokens are truncated")
+	}
+	nonce, ciphertext := sealed[:tc.aead.NonceSize()], sealed[tc.aead.NonceSize():]
+	plaintext, err := tc.aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, errors.New("failed to decrypt tokens (wrong KICK_TOKEN_KEY?)")
+	}
+	return plaintext, nil
+}
+
+// encryptedTokens is the on-disk envelope for encrypted tokens
+type encryptedTokens struct {
+	Version   int    `json:"version"`
+	Encrypted []byte `json:"encrypted"` // nonce || AES-GCM ciphertext (base64 in JSON)
+}
+
+// encodeTokens serializes tokens, encrypted if a cipher is set
+func encodeTokens(tokens TokenData, tc *tokenCipher) ([]byte, error) {
+	plaintext, err := json.MarshalIndent(tokens, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if tc == nil {
+		return plaintext, nil
+	}
+	sealed, err := tc.seal(plaintext)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(encryptedTokens{Version: 1, Encrypted: sealed}, "", "  ")
+}
+
+// decodeTokens parses plaintext or encrypted tokens.
+// Returns plaintext=true for unencrypted data, so callers can upgrade it.
+func decodeTokens(data []byte, tc *tokenCipher) (tokens TokenData, plaintext bool, err error) {
+	var envelope encryptedTokens
+	if err := json.Unmarshal(data, &envelope); err == nil && len(envelope.Encrypted) > 0 {
+		if tc == nil {
+			return TokenData{}, false, errors.New("tokens are encrypted but KICK_TOKEN_KEY is not set")
+		}
+		decrypted, err := tc.open(envelope.Encrypted)
+		if err != nil {
+			return TokenData{}, false, err
+		}
+		err = json.Unmarshal(decrypted, &tokens)
+		return tokens, false, err
+	}
+
+	err = json.Unmarshal(data, &tokens)
+	return tokens, true, err
+}
+
+// =============================================================================
+// FILE STORE
+// =============================================================================
+
+// FileTokenStore keeps tokens in a JSON file (0600), encrypted if a key is set
+type FileTokenStore struct {
+	path   string
+	cipher *tokenCipher
+}
+
+// NewFileTokenStore creates a file token store (key from ParseTokenKey, nil = plaintext)
+func NewFileTokenStore(path string, key []byte) *FileTokenStore {
+	return &FileTokenStore{path: path, cipher: newTokenCipher(key)}
+}
+
+// Load reads tokens from the file. Plaintext tokens are re-saved encrypted
+// when an encryption key is configured.
+func (fs *FileTokenStore) Load() (TokenData, error) {
+	data, err := os.ReadFile(fs.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return TokenData{}, ErrNoTokens
+	}
+	if err != nil {
+		return TokenData{}, err
+	}
+
+	tokens, plaintext, err := decodeTokens(data, fs.cipher)
+	if err != nil {
+		return TokenData{}, err
+	}
+	if plaintext && fs.cipher != nil {
+		if err := fs.Save(tokens); err != nil {
+			return tokens, nil // Still usable, retry encryption on next save
+		}
+		log.Printf("🔐 Encrypted plaintext Kick tokens in %s", fs.path)
+	}
+	return tokens, nil
+}
+
+// Save writes tokens atomically (tmp + rename)
+func (fs *FileTokenStore) Save(tokens TokenData) error {
+	data, err := encodeTokens(tokens, fs.cipher)
+	if err != nil {
+		return err
+	}
+
+	tmp := fs.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, fs.path)
+}
+
+// =============================================================================
+// SQL STORE
+// =============================================================================
+
+// SQLTokenStore keeps tokens in a single-row table of a database/sql database.
+// Built for SQLite (the pure-Go modernc.org/sqlite driver, registered as "sqlite").
+type SQLTokenStore struct {
+	db     *sql.DB
+	cipher *tokenCipher
+}
+
+// OpenSQLTokenStore opens the database and creates the token table if needed
+func OpenSQLTokenStore(driver, dsn string, key []byte) (*SQLTokenStore, error) {
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open token database: %w", err)
+	}
+	store, err := NewSQLTokenStore(db, key)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return store, nil
+}
+
+// NewSQLTokenStore uses an open database, creating the token table if needed
+func NewSQLTokenStore(db *sql.DB, key []byte) (*SQLTokenStore, error) {
+	_, err := db.Exec(`CREATE TABLE IF NOT EXISTS kick_tokens (
+		id INTEGER PRIMARY KEY,
+		data BLOB NOT NULL,
+		updated_at TIMESTAMP NOT NULL
+	)`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token table: %w", err)
+	}
+	return &SQLTokenStore{db: db, cipher: newTokenCipher(key)}, nil
+}
+
+// Load reads the stored tokens
+func (ss *SQLTokenStore) Load() (TokenData, error) {
+	var data []byte
+	err := ss.db.QueryRow(`SELECT data FROM kick_tokens WHERE id = 1`).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return TokenData{}, ErrNoTokens
+	}
+	if err != nil {
+		return TokenData{}, err
+	}
+
+	tokens, plaintext, err := decodeTokens(data, ss.cipher)
+	if err == nil && plaintext && ss.cipher != nil {
+		ss.Save(tokens) // Upgrade to encrypted (best effort)
+	}
+	return tokens, err
+}
+
+// Save replaces the stored tokens
+func (ss *SQLTokenStore) Save(tokens TokenData) error {
+	data, err := encodeTokens(tokens, ss.cipher)
+	if err != nil {
+		return err
+	}
+	_, err = ss.db.Exec(`INSERT INTO kick_tokens (id, data, updated_at) VALUES (1, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET data = excluded.data, updated_at = excluded.updated_at`,
+		data, time.Now().UTC())
+	return err
+}
+
+// Close closes the database
+func (ss *SQLTokenStore) Close() error {
+	return ss.db.Close()
+}
+
+// =============================================================================
+// LEGACY MIGRATION
+// =============================================================================
+
+// migrateLegacyTokens imports the legacy plaintext token file into an empty
+// store and removes it, so tokens don't linger unencrypted on disk.
+// A file store at the legacy path upgrades itself on Load instead.
+func migrateLegacyTokens(store TokenStore) error {
+	if _, err := store.Load(); !errors.Is(err, ErrNoTokens) {
+		return nil // Store already has tokens (or is unreadable - leave legacy file alone)
+	}
+
+	for _, path := range []string{TokenFileName, filepath.Join("..", TokenFileName)} {
+		if fs, ok := store.(*FileTokenStore); ok && sameFile(fs.path, path) {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		tokens, _, err := decodeTokens(data, nil)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		if err := store.Save(tokens); err != nil {
+			return err
+		}
+		if err := os.Remove(path); err != nil {
+			log.Printf("⚠️ Migrated Kick tokens but could not remove %s: %v", path, err)
+		}
+		log.Printf("🔐 Migrated Kick tokens from %s", path)
+		return nil
+	}
+	return nil
+}
+
+// sameFile reports whether two paths refer to the same file location
+func sameFile(a, b string) bool {
+	absA, errA := filepath.Abs(a)
+	absB, errB := filepath.Abs(b)
+	return errA == nil && errB == nil && absA == absB
+}
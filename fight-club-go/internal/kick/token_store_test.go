@@ -0,0 +1,173 @@
+package kick
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Test keys as KICK_TOKEN_KEY would hold them
+const (
+	testKeyBase64 = "q83vASNFZ4mrze8BI0VniavN7wEjRWeJq83vASNFZ4k="
+	testKeyHex    = "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff"
+)
+
+func testKey(t *testing.T, s string) []byte {
+	t.Helper()
+	key, err := ParseTokenKey(s)
+	if err != nil {
+		t.Fatalf("ParseTokenKey failed: %v", err)
+	}
+	return key
+}
+
+func testTokens() TokenData {
+	return TokenData{
+		AccessToken:   "access-secret",
+		RefreshToken:  "refresh-secret",
+		TokenExpiry:   time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC),
+		BroadcasterID: 42,
+	}
+}
+
+// TestFileTokenStoreEncryption verifies tokens are encrypted at rest and need the right key
+func TestFileTokenStoreEncryption(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tokens.json")
+	store := NewFileTokenStore(path, testKey(t, testKeyBase64))
+
+	if _, err := store.Load(); !errors.Is(err, ErrNoTokens) {
+		t.Fatalf("Expected ErrNoTokens for a new store, got %v", err)
+	}
+	if err := store.Save(testTokens()); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	raw, _ := os.ReadFile(path)
+	if bytes.Contains(raw, []byte("refresh-secret")) {
+		t.Error("Expected refresh token to be encrypted on disk")
+	}
+	if info, _ := os.Stat(path); info.Mode().Perm() != 0600 {
+		t.Errorf("Expected 0600 token file, got %v", info.Mode().Perm())
+	}
+
+	got, err := store.Load()
+	if err != nil || got != testTokens() {
+		t.Errorf("Expected tokens to round-trip, got %+v (%v)", got, err)
+	}
+	if _, err := NewFileTokenStore(path, testKey(t, testKeyHex)).Load(); err == nil {
+		t.Error("Expected wrong key to fail")
+	}
+	if _, err := NewFileTokenStore(path, nil).Load(); err == nil {
+		t.Error("Expected missing key to fail for encrypted tokens")
+	}
+}
+
+// TestFileTokenStoreUpgradesPlaintext verifies a plaintext token file is encrypted in place once a key is set
+func TestFileTokenStoreUpgradesPlaintext(t *testing.T) {
+	path := filepath.Join(t.TempDir(), TokenFileName)
+	if err := NewFileTokenStore(path, nil).Save(testTokens()); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := NewFileTokenStore(path, testKey(t, testKeyHex)).Load()
+	if err != nil || got != testTokens() {
+		t.Fatalf("Expected plaintext tokens to load, got %+v (%v)", got, err)
+	}
+	raw, _ := os.ReadFile(path)
+	if bytes.Contains(raw, []byte("access-secret")) {
+		t.Error("Expected plaintext tokens to be re-saved encrypted")
+	}
+}
+
+// TestNewTokenStoreMigratesLegacyFile verifies the legacy token file is imported into a new store and removed
+func TestNewTokenStoreMigratesLegacyFile(t *testing.T) {
+	t.Chdir(t.TempDir())
+	if err := NewFileTokenStore(TokenFileName, nil).Save(testTokens()); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := TokenStoreConfig{Backend: "file", File: "tokens.enc.json", EncryptionKey: testKeyBase64}
+	store, err := NewTokenStore(cfg)
+	if err != nil {
+		t.Fatalf("NewTokenStore failed: %v", err)
+	}
+
+	if got, err := store.Load(); err != nil || got != testTokens() {
+		t.Errorf("Expected migrated tokens, got %+v (%v)", got, err)
+	}
+	if _, err := os.Stat(TokenFileName); !os.IsNotExist(err) {
+		t.Error("Expected legacy plaintext token file to be removed")
+	}
+
+	if _, err := NewTokenStore(TokenStoreConfig{Backend: "redis"}); err == nil {
+		t.Error("Expected error for unknown backend")
+	}
+}
+
+// TestParseTokenKey verifies KICK_TOKEN_KEY must be 32 random bytes as hex or base64
+func TestParseTokenKey(t *testing.T) {
+	if key, err := ParseTokenKey(""); key != nil || err != nil {
+		t.Errorf("Expected no key for an empty string, got %v (%v)", key, err)
+	}
+	for _, s := range []string{testKeyBase64, strings.TrimRight(testKeyBase64, "="), testKeyHex} {
+		if key, err := ParseTokenKey(s); err != nil || len(key) != TokenKeySize {
+			t.Errorf("Expected a %d-byte key from %q, got %d (%v)", TokenKeySize, s, len(key), err)
+		}
+	}
+	for _, s := range []string{"correct horse battery staple", "c2hvcnQ=", testKeyHex[:32]} {
+		if _, err := ParseTokenKey(s); err == nil {
+			t.Errorf("Expected %q to be rejected", s)
+		}
+	}
+	if _, err := NewTokenStore(TokenStoreConfig{Backend: "file", File: filepath.Join(t.TempDir(), "t.json"), EncryptionKey: "passphrase"}); err == nil {
+		t.Error("Expected NewTokenStore to reject a passphrase key")
+	}
+}
+
+// TestSQLTokenStore verifies tokens round-trip through SQLite encrypted, and
+// plaintext rows are upgraded once a key is set
+func TestSQLTokenStore(t *testing.T) {
+	dsn := filepath.Join(t.TempDir(), "tokens.db")
+	plain, err := OpenSQLTokenStore("sqlite", dsn, nil)
+	if err != nil {
+		t.Fatalf("OpenSQLTokenStore failed: %v", err)
+	}
+	if _, err := plain.Load(); !errors.Is(err, ErrNoTokens) {
+		t.Fatalf("Expected ErrNoTokens for a new store, got %v", err)
+	}
+	if err := plain.Save(testTokens()); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	plain.Close()
+
+	store, err := NewTokenStore(TokenStoreConfig{Backend: "sql", DBDriver: "sqlite", DBDSN: dsn, EncryptionKey: testKeyBase64})
+	if err != nil {
+		t.Fatalf("NewTokenStore failed: %v", err)
+	}
+	sqlStore := store.(*SQLTokenStore)
+	defer sqlStore.Close()
+	if got, err := store.Load(); err != nil || got != testTokens() {
+		t.Fatalf("Expected plaintext tokens to load, got %+v (%v)", got, err)
+	}
+
+	var raw []byte
+	if err := sqlStore.db.QueryRow(`SELECT data FROM kick_tokens WHERE id = 1`).Scan(&raw); err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(raw, []byte("refresh-secret")) {
+		t.Error("Expected the stored tokens to be re-saved encrypted")
+	}
+
+	updated := testTokens()
+	updated.AccessToken = "rotated"
+	if err := store.Save(updated); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if got, err := store.Load(); err != nil || got != updated {
+		t.Errorf("Expected the saved tokens replaced, got %+v (%v)", got, err)
+	}
+}
@@ -0,0 +1,156 @@
+package kick
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// ViewerCountFetcher is the interface for reading the live viewer count
+type ViewerCountFetcher interface {
+	GetViewerCount() (count int, live bool, err error)
+}
+
+// GetViewerCount returns the broadcaster's current viewer count from the
+// livestreams endpoint. live is false (count 0) while the channel is offline.
+func (s *Service) GetViewerCount() (int, bool, error) {
+	s.mu.RLock()
+	broadcasterID := s.broadcasterID
+	s.mu.RUnlock()
+
+	if broadcasterID == 0 {
+		return 0, false, errors.New("broadcaster ID not set")
+	}
+
+	resp, err := s.apiRequest("GET", fmt.Sprintf("/livestreams?broadcaster_user_id=%d", broadcasterID), nil)
+	if err != nil {
+		return 0, false, err
+	}
+	return parseViewerCount(resp)
+}
+
+// parseViewerCount reads viewer_count from a livestreams response (empty data = offline)
+func parseViewerCount(body []byte) (int, bool, error) {
+	var result struct {
+		Data []struct {
+			ViewerCount int `json:"viewer_count"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return 0, false, err
+	}
+	if len(result.Data) == 0 {
+		return 0, false, nil
+	}
+	return result.Data[0].ViewerCount, true, nil
+}
+
+// ViewerCountPoller polls the Kick API for the live viewer count in the
+// background and reports changes (for the on-stream "watching" counter)
+type ViewerCountPoller struct {
+	fetcher  ViewerCountFetcher
+	interval time.Duration
+	onUpdate func(count int, live bool)
+
+	mu     sync.RWMutex
+	count  int
+	live   bool
+	failed bool // Last poll failed (log recovery once)
+
+	running bool
+	stopCh  chan struct{}
+	wg      sync.WaitGroup
+}
+
+// NewViewerCountPoller creates a poller calling onUpdate whenever the count changes
+func NewViewerCountPoller(fetcher ViewerCountFetcher, interval time.Duration, onUpdate func(count int, live bool)) *ViewerCountPoller {
+	if interval < 5*time.Second {
+		interval = 5 * time.Second // Stay well under Kick API rate limits
+	}
+	return &ViewerCountPoller{
+		fetcher:  fetcher,
+		interval: interval,
+		onUpdate: onUpdate,
+		stopCh:   make(chan struct{}),
+	}
+}
+
+// Start polls immediately, then every interval
+func (p *ViewerCountPoller) Start() {
+	p.mu.Lock()
+	if p.running {
+		p.mu.Unlock()
+		return
+	}
+	p.running = true
+	p.mu.Unlock()
+
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		ticker := time.NewTicker(p.interval)
+		defer ticker.Stop()
+
+		p.Poll()
+		for {
+			select {
+			case <-ticker.C:
+				p.Poll()
+			case <-p.stopCh:
+				return
+			}
+		}
+	}()
+	log.Printf("👁 Viewer count polling every %s", p.interval)
+}
+
+// Stop ends polling
+func (p *ViewerCountPoller) Stop() {
+	p.mu.Lock()
+	if !p.running {
+		p.mu.Unlock()
+		return
+	}
+	p.running = false
+	p.mu.Unlock()
+
+	close(p.stopCh)
+	p.wg.Wait()
+}
+
+// Poll fetches the viewer count once. On error the last known count is kept.
+func (p *ViewerCountPoller) Poll() {
+	count, live, err := p.fetcher.GetViewerCount()
+
+	p.mu.Lock()
+	if err != nil {
+		if !p.failed {
+			log.Printf("⚠️ Viewer count unavailable: %v", err)
+		}
+		p.failed = true
+		p.mu.Unlock()
+		return
+	}
+	if p.failed {
+		log.Printf("👁 Viewer count available again")
+	}
+	p.failed = false
+
+	changed := count != p.count || live != p.live
+	p.count, p.live = count, live
+	p.mu.Unlock()
+
+	if changed && p.onUpdate != nil {
+		p.onUpdate(count, live)
+	}
+}
+
+// Count returns the last known viewer count and whether the channel is live
+func (p *ViewerCountPoller) Count() (int, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.count, p.live
+}
@@ -0,0 +1,67 @@
+package kick
+
+import (
+	"errors"
+	"testing"
+)
+
+type fakeViewerFetcher struct {
+	count int
+	live  bool
+	err   error
+	calls int
+}
+
+func (f *fakeViewerFetcher) GetViewerCount() (int, bool, error) {
+	f.calls++
+	return f.count, f.live, f.err
+}
+
+// TestParseViewerCount verifies live and offline livestreams responses
+func TestParseViewerCount(t *testing.T) {
+	count, live, err := parseViewerCount([]byte(`{"data":[{"broadcaster_user_id":42,"viewer_count":1243}],"message":"OK"}`))
+	if err != nil || count != 1243 || !live {
+		t.Errorf("Expected 1243 live viewers, got %d live=%v (%v)", count, live, err)
+	}
+
+	count, live, err = parseViewerCount([]byte(`{"data":[],"message":"OK"}`))
+	if err != nil || count != 0 || live {
+		t.Errorf("Expected offline, got %d live=%v (%v)", count, live, err)
+	}
+
+	if _, _, err := parseViewerCount([]byte(`not json`)); err == nil {
+		t.Error("Expected error for invalid JSON")
+	}
+}
+
+// TestViewerCountPoller verifies updates fire only on change and errors keep the last count
+func TestViewerCountPoller(t *testing.T) {
+	fetcher := &fakeViewerFetcher{count: 100, live: true}
+	var updates []int
+	poller := NewViewerCountPoller(fetcher, 0, func(count int, live bool) {
+		updates = append(updates, count)
+	})
+
+	poller.Poll()
+	poller.Poll()
+	if len(updates) != 1 || updates[0] != 100 {
+		t.Fatalf("Expected a single update to 100, got %v", updates)
+	}
+
+	fetcher.err = errors.New("API error 503")
+	fetcher.count = 0
+	poller.Poll()
+	if count, live := poller.Count(); count != 100 || !live {
+		t.Errorf("Expected last count kept on error, got %d live=%v", count, live)
+	}
+
+	fetcher.err = nil
+	fetcher.count, fetcher.live = 0, false
+	poller.Poll()
+	if count, live := poller.Count(); count != 0 || live {
+		t.Errorf("Expected offline after stream ended, got %d live=%v", count, live)
+	}
+	if len(updates) != 2 {
+		t.Errorf("Expected 2 updates, got %v", updates)
+	}
+}
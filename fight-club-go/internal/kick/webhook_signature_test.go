@@ -0,0 +1,122 @@
+package kick
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newSignedWebhook builds a webhook request signed with key (nil = unsigned)
+func newSignedWebhook(t *testing.T, key *rsa.PrivateKey, body []byte) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader(body))
+	req.Header.Set("Kick-Event-Type", "ping")
+	if key == nil {
+		return req
+	}
+
+	messageID, timestamp := "01HTEST", "2025-01-01T00:00:00Z"
+	hash := sha256.Sum256([]byte(messageID + "." + timestamp + "." + string(body)))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, hash[:])
+	if err != nil {
+		t.Fatalf("Failed to sign: %v", err)
+	}
+	req.Header.Set(HeaderEventMessageID, messageID)
+	req.Header.Set(HeaderEventMessageTimestamp, timestamp)
+	req.Header.Set(HeaderEventSignature, base64.StdEncoding.EncodeToString(sig))
+	return req
+}
+
+// newTestSignatureService creates a service trusting the given key
+func newTestSignatureService(t *testing.T, key *rsa.PrivateKey, mode SignatureMode) *Service {
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("Failed to marshal key: %v", err)
+	}
+	s := &Service{client: http.DefaultClient}
+	if err := s.SetWebhookPublicKeyPEM(string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))); err != nil {
+		t.Fatalf("SetWebhookPublicKeyPEM failed: %v", err)
+	}
+	s.SetSignatureMode(mode)
+	return s
+}
+
+// TestWebhookSignatureEnforce verifies valid signatures pass and others get 401
+func TestWebhookSignatureEnforce(t *testing.T) {
+	key, _ := rsa.GenerateKey(rand.Reader, 2048)
+	otherKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	s := newTestSignatureService(t, key, SignatureModeEnforce)
+	body := []byte(`{"message_id":"1"}`)
+
+	tests := []struct {
+		name string
+		req  *http.Request
+		code int
+	}{
+		{"valid", newSignedWebhook(t, key, body), http.StatusOK},
+		{"wrong key", newSignedWebhook(t, otherKey, body), http.StatusUnauthorized},
+		{"unsigned", newSignedWebhook(t, nil, body), http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			s.HandleWebhook(w, tt.req)
+			if w.Code != tt.code {
+				t.Errorf("Expected %d, got %d", tt.code, w.Code)
+			}
+		})
+	}
+
+	// Tampered body must fail even with a valid signature header
+	req := newSignedWebhook(t, key, body)
+	tampered := newSignedWebhook(t, nil, []byte(`{"message_id":"2"}`))
+	tampered.Header = req.Header
+	w := httptest.NewRecorder()
+	s.HandleWebhook(w, tampered)
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Tampered body: expected 401, got %d", w.Code)
+	}
+
+	stats := s.GetWebhookSignatureStats()
+	if stats.Valid != 1 || stats.Rejected != 3 {
+		t.Errorf("Expected 1 valid / 3 rejected, got %+v", stats)
+	}
+}
+
+// TestWebhookSignatureLogOnly verifies log-only mode counts but still accepts
+func TestWebhookSignatureLogOnly(t *testing.T) {
+	key, _ := rsa.GenerateKey(rand.Reader, 2048)
+	s := newTestSignatureService(t, key, SignatureModeLogOnly)
+
+	w := httptest.NewRecorder()
+	s.HandleWebhook(w, newSignedWebhook(t, nil, []byte(`{}`)))
+	if w.Code != http.StatusOK {
+		t.Errorf("Log-only mode should accept unsigned webhooks, got %d", w.Code)
+	}
+
+	stats := s.GetWebhookSignatureStats()
+	if stats.Invalid != 1 || stats.Rejected != 0 {
+		t.Errorf("Expected 1 invalid / 0 rejected, got %+v", stats)
+	}
+}
+
+// TestParseSignatureMode verifies config parsing
+func TestParseSignatureMode(t *testing.T) {
+	if ParseSignatureMode("enforce") != SignatureModeEnforce {
+		t.Error("enforce should parse to SignatureModeEnforce")
+	}
+	if ParseSignatureMode("OFF") != SignatureModeOff {
+		t.Error("OFF should parse to SignatureModeOff")
+	}
+	if ParseSignatureMode("") != SignatureModeLogOnly {
+		t.Error("empty should default to log-only")
+	}
+}
@@ -0,0 +1,374 @@
+// Package notify posts stream events (go-live, records, errors) to a Discord webhook.
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"text/template"
+	"time"
+
+	"fight-club/internal/config"
+
+	"golang.org/x/time/rate"
+)
+
+// Config is an alias for config.NotifyConfig (SSOT)
+type Config = config.NotifyConfig
+
+// Event identifies a notification type (also its template key)
+type Event string
+
+const (
+	EventStreamStart Event = "stream_start"
+	EventStreamStop  Event = "stream_stop"
+	EventRoundWinner Event = "round_winner"
+	EventKillRecord  Event = "kill_record"
+	EventStreamError Event = "stream_error"   // FFmpeg crash / lost RTMP connection
+	EventAuthExpired Event = "auth_expired"   // Kick token refresh failed
+	EventHighlight   Event = "highlight_clip" // Big moment clipped on Kick
+)
+
+// DefaultTemplates are the message templates (text/template) for each event.
+// Every template also receives .URL (Config.StreamURL).
+var DefaultTemplates = map[Event]string{
+	EventStreamStart: "🔴 **Fight Club is LIVE!** Type `!join` in chat to enter the arena{{if .URL}} → {{.URL}}{{end}}",
+	EventStreamStop:  "⚫ Stream ended after {{.Uptime}}. GG!",
+	EventRoundWinner: "🏆 **{{.Winner}}** won the round with {{.Kills}} kills!",
+	EventKillRecord:  "👑 **{{.Username}}** is the new all-time kill leader with {{.Kills}} kills{{if .Previous}} (passing {{.Previous}}){{end}}!",
+	EventStreamError: "⚠️ Stream error: {{.Error}}",
+	EventAuthExpired: "🔑 Kick authentication expired: {{.Error}}. Log in again at /api/kick/auth",
+	EventHighlight:   "🎬 **{{.Title}}** → {{.ClipURL}}",
+}
+
+const (
+	// queueSize bounds pending messages; extra notifications are dropped
+	queueSize = 32
+
+	// flushTimeout bounds how long Stop waits to deliver pending messages
+	flushTimeout = 5 * time.Second
+
+	// maxContentLength is Discord's message content limit
+	maxContentLength = 2000
+)
+
+// webhookPayload is the Discord "execute webhook" body
+type webhookPayload struct {
+	Username        string          `json:"username,omitempty"`
+	Content         string          `json:"content"`
+	AllowedMentions allowedMentions `json:"allowed_mentions"`
+}
+
+// allowedMentions stops viewer names like "@everyone" from pinging the server
+type allowedMentions struct {
+	Parse []string `json:"parse"`
+}
+
+// Notifier renders event templates and posts them to a Discord webhook from a
+// background worker, with a global rate limit and a per-event cooldown.
+// All methods are no-ops when no webhook URL is configured.
+type Notifier struct {
+	cfg       Config
+	templates map[Event]*template.Template
+	enabled   map[Event]bool // nil = all events
+	limiter   *rate.Limiter
+	client    *http.Client
+	post      func(payload []byte) (retryAfter time.Duration, err error)
+
+	mu         sync.Mutex
+	lastSent   map[Event]time.Time
+	suppressed map[Event]int // Notifications skipped by the cooldown since the last post
+
+	queue    chan string
+	running  bool
+	stopCh   chan struct{}
+	deadline chan struct{} // Closed flushTimeout after Stop, abandons pending deliveries
+	wg       sync.WaitGroup
+
+	sent    uint64 // atomic
+	dropped uint64 // atomic
+	failed  uint64 // atomic
+}
+
+// New creates a notifier, parsing the default templates and any overrides from
+// cfg.TemplatesFile. Returns an error for unknown events or invalid templates.
+func New(cfg Config) (*Notifier, error) {
+	if cfg.RatePerMinute <= 0 {
+		cfg.RatePerMinute = config.DefaultNotify().RatePerMinute
+	}
+
+	n := &Notifier{
+		cfg:        cfg,
+		templates:  make(map[Event]*template.Template),
+		limiter:    rate.NewLimiter(rate.Limit(cfg.RatePerMinute/60), 5),
+		lastSent:   make(map[Event]time.Time),
+		suppressed: make(map[Event]int),
+		queue:      make(chan string, queueSize),
+		stopCh:     make(chan struct{}),
+		deadline:   make(chan struct{}),
+	}
+	n.post = n.postWebhook
+	n.client = &http.Client{Timeout: 10 * time.Second}
+
+	sources := make(map[Event]string, len(DefaultTemplates))
+	for ev, text := range DefaultTemplates {
+		sources[ev] = text
+	}
+	if cfg.TemplatesFile != "" {
+		data, err := os.ReadFile(cfg.TemplatesFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read notification templates: %w", err)
+		}
+		var overrides map[Event]string
+		if err := json.Unmarshal(data, &overrides); err != nil {
+			return nil, fmt.Errorf("failed to parse notification templates: %w", err)
+		}
+		for ev, text := range overrides {
+			if _, ok := DefaultTemplates[ev]; !ok {
+				return nil, fmt.Errorf("unknown notification event %q", ev)
+			}
+			sources[ev] = text
+		}
+	}
+	for ev, text := range sources {
+		tmpl, err := template.New(string(ev)).Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid template for %s: %w", ev, err)
+		}
+		n.templates[ev] = tmpl
+	}
+
+	if len(cfg.Events) > 0 {
+		n.enabled = make(map[Event]bool, len(cfg.Events))
+		for _, name := range cfg.Events {
+			ev := Event(name)
+			if _, ok := DefaultTemplates[ev]; !ok {
+				return nil, fmt.Errorf("unknown notification event %q", name)
+			}
+			n.enabled[ev] = true
+		}
+	}
+
+	return n, nil
+}
+
+// Enabled returns whether a webhook is configured
+func (n *Notifier) Enabled() bool {
+	return n != nil && n.cfg.DiscordWebhookURL != ""
+}
+
+// Start begins delivering queued notifications
+func (n *Notifier) Start() {
+	if !n.Enabled() {
+		return
+	}
+
+	n.mu.Lock()
+	if n.running {
+		n.mu.Unlock()
+		return
+	}
+	n.running = true
+	n.mu.Unlock()
+
+	n.wg.Add(1)
+	go n.deliverLoop()
+
+	log.Printf("📣 Discord notifications enabled (%.0f/min, %.0fs cooldown per event)", n.cfg.RatePerMinute, n.cfg.Cooldown)
+}
+
+// Stop delivers pending notifications (bounded by flushTimeout) and stops the worker
+func (n *Notifier) Stop() {
+	if !n.Enabled() {
+		return
+	}
+
+	n.mu.Lock()
+	if !n.running {
+		n.mu.Unlock()
+		return
+	}
+	n.running = false
+	n.mu.Unlock()
+
+	close(n.stopCh)
+	timer := time.AfterFunc(flushTimeout, func() { close(n.deadline) })
+	n.wg.Wait()
+	timer.Stop()
+}
+
+// Notify renders an event's template with data and queues it for delivery.
+// Never blocks: events inside their cooldown are counted and mentioned in the
+// next post, and notifications are dropped if the queue is full.
+func (n *Notifier) Notify(ev Event, data map[string]interface{}) {
+	if !n.Enabled() || (n.enabled != nil && !n.enabled[ev]) {
+		return
+	}
+	tmpl, ok := n.templates[ev]
+	if !ok {
+		return
+	}
+
+	cooldown := time.Duration(n.cfg.Cooldown * float64(time.Second))
+	now := time.Now()
+
+	n.mu.Lock()
+	if last, ok := n.lastSent[ev]; ok && now.Sub(last) < cooldown {
+		n.suppressed[ev]++
+		n.mu.Unlock()
+		return
+	}
+	n.lastSent[ev] = now
+	suppressed := n.suppressed[ev]
+	n.suppressed[ev] = 0
+	n.mu.Unlock()
+
+	vars := map[string]interface{}{"URL": n.cfg.StreamURL}
+	for k, v := range data {
+		vars[k] = v
+	}
+	var content strings.Builder
+	if err := tmpl.Execute(&content, vars); err != nil {
+		log.Printf("⚠️ Failed to render %s notification: %v", ev, err)
+		return
+	}
+	if suppressed > 0 {
+		fmt.Fprintf(&content, "\n_(+%d similar in the last %s)_", suppressed, cooldown.Round(time.Second))
+	}
+
+	msg := content.String()
+	if len(msg) > maxContentLength {
+		msg = msg[:maxContentLength-3] + "..."
+	}
+
+	select {
+	case n.queue <- msg:
+	default:
+		atomic.AddUint64(&n.dropped, 1)
+	}
+}
+
+// RoundWinner announces the winner of a round
+func (n *Notifier) RoundWinner(winner string, kills int) {
+	n.Notify(EventRoundWinner, map[string]interface{}{"Winner": winner, "Kills": kills})
+}
+
+// Stats returns delivery statistics
+func (n *Notifier) Stats() map[string]interface{} {
+	if n == nil {
+		return map[string]interface{}{"enabled": false}
+	}
+	return map[string]interface{}{
+		"enabled": n.Enabled(),
+		"sent":    atomic.LoadUint64(&n.sent),
+		"dropped": atomic.LoadUint64(&n.dropped),
+		"failed":  atomic.LoadUint64(&n.failed),
+		"pending": len(n.queue),
+	}
+}
+
+// deliverLoop posts queued messages, respecting the rate limit
+func (n *Notifier) deliverLoop() {
+	defer n.wg.Done()
+
+	for {
+		select {
+		case <-n.stopCh:
+			n.flush()
+			return
+		case content := <-n.queue:
+			n.deliver(content, n.deadline)
+		}
+	}
+}
+
+// flush delivers whatever is still queued at shutdown (e.g. the stream-ended message)
+func (n *Notifier) flush() {
+	for {
+		select {
+		case content := <-n.queue:
+			n.deliver(content, n.deadline)
+		default:
+			return
+		}
+	}
+}
+
+// deliver waits for the rate limiter and posts one message, retrying once on a 429
+func (n *Notifier) deliver(content string, cancel <-chan struct{}) {
+	payload, err := json.Marshal(webhookPayload{
+		Username:        n.cfg.Username,
+		Content:         content,
+		AllowedMentions: allowedMentions{Parse: []string{}},
+	})
+	if err != nil {
+		return
+	}
+
+	for attempt := 0; attempt < 2; attempt++ {
+		if !n.wait(n.limiter.Reserve().Delay(), cancel) {
+			return
+		}
+
+		retryAfter, err := n.post(payload)
+		if err == nil {
+			atomic.AddUint64(&n.sent, 1)
+			return
+		}
+		if retryAfter <= 0 || !n.wait(retryAfter, cancel) {
+			atomic.AddUint64(&n.failed, 1)
+			log.Printf("⚠️ Discord notification failed: %v", err)
+			return
+		}
+	}
+	atomic.AddUint64(&n.failed, 1)
+}
+
+// wait sleeps for d unless cancelled, returns false if cancelled
+func (n *Notifier) wait(d time.Duration, cancel <-chan struct{}) bool {
+	if d <= 0 {
+		return true
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-cancel:
+		return false
+	}
+}
+
+// postWebhook sends a payload to Discord.
+// On 429 it returns the server-requested delay so the caller can retry.
+func (n *Notifier) postWebhook(payload []byte) (time.Duration, error) {
+	resp, err := n.client.Post(n.cfg.DiscordWebhookURL, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		var body struct {
+			RetryAfter float64 `json:"retry_after"` // Seconds
+		}
+		retryAfter := time.Second
+		if json.NewDecoder(resp.Body).Decode(&body) == nil && body.RetryAfter > 0 {
+			retryAfter = time.Duration(body.RetryAfter * float64(time.Second))
+		} else if secs, err := strconv.ParseFloat(resp.Header.Get("Retry-After"), 64); err == nil && secs > 0 {
+			retryAfter = time.Duration(secs * float64(time.Second))
+		}
+		return retryAfter, fmt.Errorf("rate limited by Discord (retry after %v)", retryAfter)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return 0, fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return 0, nil
+}
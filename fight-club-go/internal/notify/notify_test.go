@@ -0,0 +1,137 @@
+package notify
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// webhookRecorder is a fake Discord webhook collecting posted messages
+type webhookRecorder struct {
+	mu       sync.Mutex
+	payloads []webhookPayload
+	limited  int // Number of 429 responses still to send
+}
+
+func (wr *webhookRecorder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	wr.mu.Lock()
+	defer wr.mu.Unlock()
+
+	if wr.limited > 0 {
+		wr.limited--
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"retry_after": 0.05}`))
+		return
+	}
+
+	var p webhookPayload
+	json.NewDecoder(r.Body).Decode(&p)
+	wr.payloads = append(wr.payloads, p)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (wr *webhookRecorder) contents() []string {
+	wr.mu.Lock()
+	defer wr.mu.Unlock()
+	result := make([]string, len(wr.payloads))
+	for i, p := range wr.payloads {
+		result[i] = p.Content
+	}
+	return result
+}
+
+func newTestNotifier(t *testing.T, wr *webhookRecorder, cfg Config) *Notifier {
+	srv := httptest.NewServer(wr)
+	t.Cleanup(srv.Close)
+
+	cfg.DiscordWebhookURL = srv.URL
+	n, err := New(cfg)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	n.Start()
+	return n
+}
+
+// TestNotifyTemplatesAndDelivery verifies rendering, overrides, 429 retries and flush on Stop
+func TestNotifyTemplatesAndDelivery(t *testing.T) {
+	templates := filepath.Join(t.TempDir(), "templates.json")
+	os.WriteFile(templates, []byte(`{"kill_record": "{{.Username}} now has {{.Kills}}"}`), 0o644)
+
+	wr := &webhookRecorder{limited: 1}
+	n := newTestNotifier(t, wr, Config{
+		Username:      "Fight Club",
+		StreamURL:     "https://kick.com/fightclub",
+		TemplatesFile: templates,
+		RatePerMinute: 600,
+	})
+
+	n.Notify(EventStreamStart, nil)
+	n.Notify(EventKillRecord, map[string]interface{}{"Username": "@everyone", "Kills": 42})
+	n.Stop()
+
+	got := wr.contents()
+	if len(got) != 2 {
+		t.Fatalf("Expected 2 delivered messages, got %v", got)
+	}
+	if !strings.Contains(got[0], "https://kick.com/fightclub") {
+		t.Errorf("Expected stream URL in start message, got %q", got[0])
+	}
+	if got[1] != "@everyone now has 42" {
+		t.Errorf("Expected overridden template, got %q", got[1])
+	}
+	if wr.payloads[0].Username != "Fight Club" || wr.payloads[0].AllowedMentions.Parse == nil {
+		t.Errorf("Expected username and disabled mentions, got %+v", wr.payloads[0])
+	}
+}
+
+// TestNotifyCooldownAndFilter verifies per-event cooldown and the enabled-event list
+func TestNotifyCooldownAndFilter(t *testing.T) {
+	wr := &webhookRecorder{}
+	n := newTestNotifier(t, wr, Config{
+		Events:        []string{"stream_error"},
+		RatePerMinute: 600,
+		Cooldown:      0.2,
+	})
+
+	n.Notify(EventStreamStart, nil) // Not enabled
+	for i := 0; i < 3; i++ {
+		n.Notify(EventStreamError, map[string]interface{}{"Error": "boom"})
+	}
+	time.Sleep(250 * time.Millisecond)
+	n.Notify(EventStreamError, map[string]interface{}{"Error": "again"})
+	n.Stop()
+
+	got := wr.contents()
+	if len(got) != 2 {
+		t.Fatalf("Expected 2 stream errors after cooldown, got %v", got)
+	}
+	if !strings.Contains(got[1], "again") || !strings.Contains(got[1], "+2 similar") {
+		t.Errorf("Expected suppressed count in second message, got %q", got[1])
+	}
+}
+
+// TestNotifyDisabled verifies an unconfigured or nil notifier is a no-op
+func TestNotifyDisabled(t *testing.T) {
+	n, err := New(Config{})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	n.Start()
+	n.Notify(EventStreamStart, nil)
+	n.Stop()
+
+	var nilNotifier *Notifier
+	nilNotifier.Notify(EventStreamStart, nil)
+	nilNotifier.Stop()
+
+	if _, err := New(Config{Events: []string{"unknown"}}); err == nil {
+		t.Error("Expected error for unknown event")
+	}
+}
@@ -0,0 +1,250 @@
+// Package profiling measures where the frame and tick budgets go: named
+// phases ("tick/ai", "render/ui") are timed in place and summarized with
+// process memory and allocation rates for the debug server (/debug/budget).
+package profiling
+
+import (
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxWindow is how long the slowest sample of a phase is remembered
+const maxWindow = time.Second
+
+// Phase accumulates the durations of one named stage. Its lock is private,
+// so recording never waits on readers of the report.
+type Phase struct {
+	name string
+
+	mu          sync.Mutex
+	count       int64
+	last        float64 // ms
+	avg         float64 // ms, exponential moving average
+	max         float64 // Slowest in the current window
+	prevMax     float64 // Slowest in the previous window
+	total       float64 // ms since start
+	windowStart time.Time
+}
+
+// Record adds one duration
+func (p *Phase) Record(d time.Duration) {
+	ms := float64(d) / float64(time.Millisecond)
+	now := time.Now()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.count++
+	p.last = ms
+	p.total += ms
+	if p.count == 1 {
+		p.avg = ms
+		p.windowStart = now
+	} else {
+		p.avg += (ms - p.avg) * 0.05 // ~20 sample smoothing
+	}
+	if now.Sub(p.windowStart) >= maxWindow {
+		p.prevMax, p.max = p.max, 0
+		p.windowStart = now
+	}
+	p.max = max(p.max, ms)
+}
+
+// Since records the time elapsed since start and returns now, so consecutive
+// stages can be chained: t = phaseA.Since(t); ...; t = phaseB.Since(t)
+func (p *Phase) Since(start time.Time) time.Time {
+	now := time.Now()
+	p.Record(now.Sub(start))
+	return now
+}
+
+// Registry holds the phases and per-group budgets of a process
+type Registry struct {
+	mu      sync.Mutex
+	phases  map[string]*Phase
+	budgets map[string]float64 // Group -> ms available per tick/frame
+
+	// Previous memory sample for allocation rates
+	sampledAt  time.Time
+	totalAlloc uint64
+	mallocs    uint64
+	numGC      uint32
+	startedAt  time.Time
+}
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	now := time.Now()
+	return &Registry{
+		phases:    make(map[string]*Phase),
+		budgets:   make(map[string]float64),
+		sampledAt: now,
+		startedAt: now,
+	}
+}
+
+// Default is the process-wide registry used by Track and SetBudget
+var Default = NewRegistry()
+
+// Track returns the phase of the default registry (created on first use)
+func Track(name string) *Phase {
+	return Default.Track(name)
+}
+
+// SetBudget sets the time available per iteration of a group in the default registry
+func SetBudget(group string, ms float64) {
+	Default.SetBudget(group, ms)
+}
+
+// Track returns the named phase, creating it on first use. Names are
+// "group/stage"; phases are looked up once and kept by the caller.
+func (r *Registry) Track(name string) *Phase {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if p, ok := r.phases[name]; ok {
+		return p
+	}
+	p := &Phase{name: name}
+	r.phases[name] = p
+	return p
+}
+
+// SetBudget sets the time available per tick or frame of a group (e.g. "tick" = 1000/tickRate)
+func (r *Registry) SetBudget(group string, ms float64) {
+	r.mu.Lock()
+	r.budgets[group] = ms
+	r.mu.Unlock()
+}
+
+// PhaseStats summarizes one phase
+type PhaseStats struct {
+	Name      string  `json:"name"`
+	Count     int64   `json:"count"`
+	LastMs    float64 `json:"lastMs"`
+	AvgMs     float64 `json:"avgMs"`
+	MaxMs     float64 `json:"maxMs"`     // Slowest over the last one to two seconds
+	TotalMs   float64 `json:"totalMs"`   // Since start
+	SharePct  float64 `json:"sharePct"`  // Of the group's measured time
+	BudgetPct float64 `json:"budgetPct"` // Of the group's budget (0 without one)
+}
+
+// GroupStats summarizes the phases of one subsystem (tick, render, ...)
+type GroupStats struct {
+	Name      string       `json:"name"`
+	BudgetMs  float64      `json:"budgetMs"`  // 0 = no budget set
+	AvgMs     float64      `json:"avgMs"`     // Sum of the phase averages
+	BudgetPct float64      `json:"budgetPct"` // AvgMs of BudgetMs
+	Phases    []PhaseStats `json:"phases"`    // Slowest first
+}
+
+// MemoryStats is the process memory and allocation rate since the previous report
+type MemoryStats struct {
+	HeapAllocMB   float64 `json:"heapAllocMB"`
+	HeapObjects   uint64  `json:"heapObjects"`
+	SysMB         float64 `json:"sysMB"`
+	AllocMBPerSec float64 `json:"allocMBPerSec"`
+	AllocsPerSec  float64 `json:"allocsPerSec"`
+	GCPerMin      float64 `json:"gcPerMin"`
+	LastGCPauseMs float64 `json:"lastGCPauseMs"`
+	Goroutines    int     `json:"goroutines"`
+	WindowSeconds float64 `json:"windowSeconds"` // Rates are averaged over this
+}
+
+// Report is the budget breakdown served on /debug/budget
+type Report struct {
+	UptimeSeconds float64      `json:"uptimeSeconds"`
+	Groups        []GroupStats `json:"groups"`
+	Memory        MemoryStats  `json:"memory"`
+}
+
+// Report returns the current breakdown. Allocation rates cover the time since
+// the previous report (or since start), so polling sets the averaging window.
+func (r *Registry) Report() Report {
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	now := time.Now()
+
+	r.mu.Lock()
+	phases := make([]*Phase, 0, len(r.phases))
+	for _, p := range r.phases {
+		phases = append(phases, p)
+	}
+	budgets := make(map[string]float64, len(r.budgets))
+	for g, b := range r.budgets {
+		budgets[g] = b
+	}
+
+	window := now.Sub(r.sampledAt).Seconds()
+	mem := MemoryStats{
+		HeapAllocMB:   float64(ms.HeapAlloc) / (1 << 20),
+		HeapObjects:   ms.HeapObjects,
+		SysMB:         float64(ms.Sys) / (1 << 20),
+		Goroutines:    runtime.NumGoroutine(),
+		WindowSeconds: window,
+	}
+	if window > 0 {
+		mem.AllocMBPerSec = float64(ms.TotalAlloc-r.totalAlloc) / (1 << 20) / window
+		mem.AllocsPerSec = float64(ms.Mallocs-r.mallocs) / window
+		mem.GCPerMin = float64(ms.NumGC-r.numGC) / window * 60
+	}
+	if ms.NumGC > 0 {
+		mem.LastGCPauseMs = float64(ms.PauseNs[(ms.NumGC+255)%256]) / float64(time.Millisecond)
+	}
+	r.sampledAt, r.totalAlloc, r.mallocs, r.numGC = now, ms.TotalAlloc, ms.Mallocs, ms.NumGC
+	uptime := now.Sub(r.startedAt).Seconds()
+	r.mu.Unlock()
+
+	groups := make(map[string]*GroupStats)
+	for _, p := range phases {
+		p.mu.Lock()
+		stats := PhaseStats{
+			Name:    p.name,
+			Count:   p.count,
+			LastMs:  p.last,
+			AvgMs:   p.avg,
+			MaxMs:   max(p.max, p.prevMax),
+			TotalMs: p.total,
+		}
+		p.mu.Unlock()
+		if stats.Count == 0 {
+			continue // Registered but not run in this process (e.g. tick phases in the streamer)
+		}
+
+		group, _, _ := strings.Cut(p.name, "/")
+		g, ok := groups[group]
+		if !ok {
+			g = &GroupStats{Name: group, BudgetMs: budgets[group]}
+			groups[group] = g
+		}
+		g.AvgMs += stats.AvgMs
+		g.Phases = append(g.Phases, stats)
+	}
+
+	report := Report{UptimeSeconds: uptime, Memory: mem, Groups: make([]GroupStats, 0, len(groups))}
+	for _, g := range groups {
+		for i := range g.Phases {
+			if g.AvgMs > 0 {
+				g.Phases[i].SharePct = g.Phases[i].AvgMs / g.AvgMs * 100
+			}
+			if g.BudgetMs > 0 {
+				g.Phases[i].BudgetPct = g.Phases[i].AvgMs / g.BudgetMs * 100
+			}
+		}
+		if g.BudgetMs > 0 {
+			g.BudgetPct = g.AvgMs / g.BudgetMs * 100
+		}
+		sort.Slice(g.Phases, func(i, j int) bool {
+			if g.Phases[i].AvgMs != g.Phases[j].AvgMs {
+				return g.Phases[i].AvgMs > g.Phases[j].AvgMs
+			}
+			return g.Phases[i].Name < g.Phases[j].Name
+		})
+		report.Groups = append(report.Groups, *g)
+	}
+	sort.Slice(report.Groups, func(i, j int) bool { return report.Groups[i].Name < report.Groups[j].Name })
+	return report
+}
@@ -0,0 +1,79 @@
+package profiling
+
+import (
+	"testing"
+	"time"
+)
+
+// TestReportGroupsPhases verifies phases are grouped by prefix and measured against the budget
+func TestReportGroupsPhases(t *testing.T) {
+	r := NewRegistry()
+	r.SetBudget("tick", 10)
+
+	r.Track("tick/ai").Record(6 * time.Millisecond)
+	r.Track("tick/collision").Record(2 * time.Millisecond)
+	r.Track("render/ui").Record(4 * time.Millisecond)
+
+	report := r.Report()
+	if len(report.Groups) != 2 || report.Groups[0].Name != "render" || report.Groups[1].Name != "tick" {
+		t.Fatalf("Expected render and tick groups, got %+v", report.Groups)
+	}
+
+	tick := report.Groups[1]
+	if tick.AvgMs != 8 || tick.BudgetMs != 10 || tick.BudgetPct != 80 {
+		t.Errorf("Expected 8 of 10ms (80%%), got %.1f of %.1fms (%.0f%%)", tick.AvgMs, tick.BudgetMs, tick.BudgetPct)
+	}
+	if tick.Phases[0].Name != "tick/ai" || tick.Phases[0].SharePct != 75 || tick.Phases[0].BudgetPct != 60 {
+		t.Errorf("Expected tick/ai first with 75%% share and 60%% budget, got %+v", tick.Phases[0])
+	}
+
+	render := report.Groups[0]
+	if render.BudgetMs != 0 || render.Phases[0].BudgetPct != 0 || render.Phases[0].SharePct != 100 {
+		t.Errorf("Expected no budget for render, got %+v", render)
+	}
+}
+
+// TestPhaseStats verifies averages, maximum and chained stage timing
+func TestPhaseStats(t *testing.T) {
+	r := NewRegistry()
+	p := r.Track("tick/ai")
+	if r.Track("tick/ai") != p {
+		t.Fatal("Expected the same phase for the same name")
+	}
+
+	p.Record(2 * time.Millisecond)
+	p.Record(10 * time.Millisecond)
+	stats := r.Report().Groups[0].Phases[0]
+	if stats.Count != 2 || stats.LastMs != 10 || stats.MaxMs != 10 || stats.TotalMs != 12 {
+		t.Errorf("Unexpected stats %+v", stats)
+	}
+	if stats.AvgMs <= 2 || stats.AvgMs >= 10 {
+		t.Errorf("Expected a smoothed average between 2 and 10ms, got %.2f", stats.AvgMs)
+	}
+
+	start := time.Now().Add(-time.Millisecond)
+	if next := r.Track("tick/snapshot").Since(start); next.Before(start) {
+		t.Error("Expected Since to return the end of the stage")
+	}
+}
+
+// TestReportAllocationRate verifies allocation rates cover the time since the last report
+func TestReportAllocationRate(t *testing.T) {
+	r := NewRegistry()
+	r.Report()
+
+	var sink [][]byte
+	for i := 0; i < 100; i++ {
+		sink = append(sink, make([]byte, 64<<10))
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	mem := r.Report().Memory
+	if mem.AllocMBPerSec <= 0 || mem.AllocsPerSec <= 0 || mem.WindowSeconds <= 0 {
+		t.Errorf("Expected a positive allocation rate, got %+v", mem)
+	}
+	if mem.Goroutines == 0 || mem.HeapAllocMB <= 0 {
+		t.Errorf("Expected heap and goroutine counts, got %+v", mem)
+	}
+	_ = sink
+}
@@ -0,0 +1,103 @@
+//go:build !windows
+// +build !windows
+
+package restart
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// Notify relays the graceful restart signal (SIGUSR2) to c
+func Notify(c chan<- os.Signal) {
+	signal.Notify(c, syscall.SIGUSR2)
+}
+
+// fileListener is a listener backed by a socket descriptor
+type fileListener interface {
+	File() (*os.File, error)
+}
+
+// Prepare duplicates every listener so the sockets stay open (and keep
+// queueing connections) while this process shuts down
+func (l *Listeners) Prepare() (*Upgrade, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	u := &Upgrade{}
+	for _, name := range l.names {
+		listener := l.active[name]
+		fl, ok := listener.(fileListener)
+		if !ok {
+			u.Close()
+			return nil, fmt.Errorf("%s listener can't be handed over", name)
+		}
+		// Closing our copy must not remove the socket file the new process uses
+		if ul, ok := listener.(*net.UnixListener); ok {
+			ul.SetUnlinkOnClose(false)
+		}
+		f, err := fl.File()
+		if err != nil {
+			u.Close()
+			return nil, fmt.Errorf("duplicate %s listener: %w", name, err)
+		}
+		u.names = append(u.names, name)
+		u.files = append(u.files, f)
+	}
+	return u, nil
+}
+
+// Start runs the binary on disk (a deploy may have replaced it) with the
+// same arguments and the inherited listeners, and waits until it is serving.
+// The listeners are released either way; on error the new process is killed.
+func (u *Upgrade) Start() error {
+	defer u.Close()
+
+	bin, err := exec.LookPath(os.Args[0])
+	if err != nil {
+		return fmt.Errorf("find binary: %w", err)
+	}
+	readyR, readyW, err := os.Pipe()
+	if err != nil {
+		return fmt.Errorf("ready pipe: %w", err)
+	}
+	defer readyR.Close()
+
+	cmd := exec.Command(bin, os.Args[1:]...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	cmd.ExtraFiles = append(append([]*os.File(nil), u.files...), readyW)
+	cmd.Env = u.environ(3 + len(u.files))
+	err = cmd.Start()
+	readyW.Close() // Only the new process holds the write end now
+	if err != nil {
+		return fmt.Errorf("start %s: %w", bin, err)
+	}
+
+	ready := make(chan error, 1)
+	go func() {
+		buf := make([]byte, 1)
+		if n, _ := readyR.Read(buf); n == 1 {
+			ready <- nil
+			return
+		}
+		ready <- fmt.Errorf("new process exited before it was ready")
+	}()
+
+	select {
+	case err = <-ready:
+	case <-time.After(ReadyTimeout):
+		err = fmt.Errorf("new process not ready after %s", ReadyTimeout)
+	}
+	if err != nil {
+		cmd.Process.Kill()
+		cmd.Wait()
+		return err
+	}
+	cmd.Process.Release()
+	return nil
+}
@@ -0,0 +1,19 @@
+//go:build windows
+// +build windows
+
+package restart
+
+import "os"
+
+// Notify is a no-op: Windows has no SIGUSR2
+func Notify(c chan<- os.Signal) {}
+
+// Prepare is not supported on Windows (sockets can't be inherited as files)
+func (l *Listeners) Prepare() (*Upgrade, error) {
+	return nil, ErrNotSupported
+}
+
+// Start is not supported on Windows
+func (u *Upgrade) Start() error {
+	return ErrNotSupported
+}
@@ -0,0 +1,154 @@
+// Package restart hands a running game server's listeners (HTTP and the IPC
+// socket) to a freshly started copy of the binary, so a deploy doesn't drop
+// connections or wipe the arena. The old process stops accepting, drains and
+// saves its state while the sockets stay open; the new process inherits them
+// as extra file descriptors, restores the state and reports ready, and only
+// then does the old process exit. Connections arriving in between wait in
+// the listen backlog instead of being refused.
+package restart
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Environment passed to the new process
+const (
+	envListeners = "FIGHT_CLUB_LISTENERS" // name=fd,name=fd
+	envReadyFD   = "FIGHT_CLUB_READY_FD"  // Pipe written once the new process is serving
+)
+
+// Handoff timings
+const (
+	DrainTimeout = 5 * time.Second  // In-flight requests get this long before long-lived streams are cut
+	ReadyTimeout = 30 * time.Second // The new process must be serving within this
+)
+
+// ErrNotSupported is returned by Prepare where listeners can't be inherited
+var ErrNotSupported = errors.New("graceful restart is not supported on this platform")
+
+// Listeners tracks the named listeners of this process. In a process started
+// by a graceful restart, Listen returns the inherited sockets instead of
+// opening new ones.
+type Listeners struct {
+	mu        sync.Mutex
+	inherited map[string]*os.File
+	ready     *os.File
+	active    map[string]net.Listener
+	names     []string
+}
+
+// New reads the listeners handed over by the previous process, if any
+func New() *Listeners {
+	l := &Listeners{
+		inherited: make(map[string]*os.File),
+		active:    make(map[string]net.Listener),
+	}
+
+	for _, entry := range strings.Split(os.Getenv(envListeners), ",") {
+		name, fdStr, ok := strings.Cut(entry, "=")
+		fd, err := strconv.Atoi(fdStr)
+		if !ok || err != nil || fd < 3 {
+			continue
+		}
+		l.inherited[name] = os.NewFile(uintptr(fd), name)
+	}
+	if fd, err := strconv.Atoi(os.Getenv(envReadyFD)); err == nil && fd >= 3 {
+		l.ready = os.NewFile(uintptr(fd), "ready")
+	}
+	os.Unsetenv(envListeners)
+	os.Unsetenv(envReadyFD)
+
+	if len(l.inherited) > 0 {
+		log.Printf("🔄 Graceful restart: inherited %d listeners from the previous process", len(l.inherited))
+	}
+	return l
+}
+
+// Listen returns the inherited listener with this name, or creates one
+func (l *Listeners) Listen(name string, create func() (net.Listener, error)) (net.Listener, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var (
+		listener net.Listener
+		err      error
+	)
+	if f, ok := l.inherited[name]; ok {
+		delete(l.inherited, name)
+		listener, err = net.FileListener(f)
+		f.Close() // FileListener dups the descriptor
+		if err != nil {
+			return nil, fmt.Errorf("inherit %s listener: %w", name, err)
+		}
+	} else if listener, err = create(); err != nil {
+		return nil, err
+	}
+
+	if _, ok := l.active[name]; !ok {
+		l.names = append(l.names, name)
+	}
+	l.active[name] = listener
+	return listener, nil
+}
+
+// Ready tells the previous process this one is serving (it exits then).
+// No-op in a process that was started normally.
+func (l *Listeners) Ready() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// Listeners this version no longer uses
+	for name, f := range l.inherited {
+		log.Printf("⚠️ Graceful restart: closing unused inherited listener %q", name)
+		f.Close()
+	}
+	l.inherited = map[string]*os.File{}
+
+	if l.ready != nil {
+		log.Println("🔄 Graceful restart: now serving, previous process is exiting")
+		l.ready.Write([]byte{1})
+		l.ready.Close()
+		l.ready = nil
+	}
+}
+
+// Upgrade holds duplicates of the listeners while the old process shuts down
+type Upgrade struct {
+	names []string
+	files []*os.File
+}
+
+// Close releases the duplicated listeners
+func (u *Upgrade) Close() {
+	for _, f := range u.files {
+		f.Close()
+	}
+	u.files = nil
+}
+
+// environ returns the environment for the new process
+func (u *Upgrade) environ(readyFD int) []string {
+	env := make([]string, 0, len(os.Environ())+2)
+	for _, kv := range os.Environ() {
+		if !strings.HasPrefix(kv, envListeners+"=") && !strings.HasPrefix(kv, envReadyFD+"=") {
+			env = append(env, kv)
+		}
+	}
+
+	// ExtraFiles start at descriptor 3
+	entries := make([]string, len(u.names))
+	for i, name := range u.names {
+		entries[i] = name + "=" + strconv.Itoa(3+i)
+	}
+	return append(env,
+		envListeners+"="+strings.Join(entries, ","),
+		envReadyFD+"="+strconv.Itoa(readyFD))
+}
@@ -0,0 +1,107 @@
+//go:build !windows
+// +build !windows
+
+package restart
+
+import (
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// TestListenCreates verifies a normally started process opens its own listeners
+func TestListenCreates(t *testing.T) {
+	l := New()
+	created := false
+	ln, err := l.Listen("http", func() (net.Listener, error) {
+		created = true
+		return net.Listen("tcp", "127.0.0.1:0")
+	})
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	defer ln.Close()
+	if !created {
+		t.Error("Expected the listener to be created")
+	}
+	l.Ready() // No previous process: no-op
+}
+
+// TestHandoff verifies listeners and the ready signal reach the new process
+func TestHandoff(t *testing.T) {
+	// Old process: a listener prepared for handoff
+	old := New()
+	ln, err := old.Listen("http", func() (net.Listener, error) { return net.Listen("tcp", "127.0.0.1:0") })
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	upgrade, err := old.Prepare()
+	if err != nil {
+		t.Fatalf("Prepare: %v", err)
+	}
+	defer upgrade.Close()
+	addr := ln.Addr().String()
+	ln.Close() // The old process stops accepting; the duplicate keeps the socket open
+
+	readyR, readyW, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer readyR.Close()
+
+	// New process: same environment the upgrade would pass, with descriptors
+	// of its own (dups) instead of ExtraFiles positions
+	listenFD, err := syscall.Dup(int(upgrade.files[0].Fd()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	readyFD, err := syscall.Dup(int(readyW.Fd()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	readyW.Close()
+	for _, kv := range upgrade.environ(readyFD) {
+		if strings.HasPrefix(kv, envListeners+"=") || strings.HasPrefix(kv, envReadyFD+"=") {
+			name, value, _ := strings.Cut(kv, "=")
+			if name == envListeners {
+				value = "http=" + strconv.Itoa(listenFD)
+			}
+			t.Setenv(name, value)
+		}
+	}
+	next := New()
+	created := false
+	inherited, err := next.Listen("http", func() (net.Listener, error) {
+		created = true
+		return nil, nil
+	})
+	if err != nil || created {
+		t.Fatalf("Expected the inherited listener, got created=%v err=%v", created, err)
+	}
+	defer inherited.Close()
+	if inherited.Addr().String() != addr {
+		t.Errorf("Expected %s, got %s", addr, inherited.Addr())
+	}
+
+	// A connection made after the old listener closed is accepted by the new one
+	go func() {
+		if conn, err := net.DialTimeout("tcp", addr, time.Second); err == nil {
+			conn.Close()
+		}
+	}()
+	conn, err := inherited.Accept()
+	if err != nil {
+		t.Fatalf("Accept: %v", err)
+	}
+	conn.Close()
+
+	next.Ready()
+	readyR.SetReadDeadline(time.Now().Add(time.Second))
+	if n, _ := readyR.Read(make([]byte, 1)); n != 1 {
+		t.Error("Expected the ready signal")
+	}
+}
@@ -0,0 +1,57 @@
+package streaming
+
+import (
+	"image/color"
+
+	"fight-club/internal/game"
+
+	"github.com/fogleman/gg"
+)
+
+// Airstrike danger zone colors
+var (
+	airstrikeAccent = color.RGBA{255, 59, 31, 255} // Zone border, closing ring, crosshair
+	airstrikeCore   = color.RGBA{255, 235, 200, 255}
+)
+
+// airstrikeFill returns the danger zone tint, darkening as the impact nears
+func airstrikeFill(a game.AirstrikeSnapshot) color.RGBA {
+	return color.RGBA{255, 40, 20, uint8(30 + a.Progress*80)}
+}
+
+// drawAirstrikes draws incoming airstrike danger zones on the arena floor (under players)
+func (s *StreamManager) drawAirstrikes(dc *gg.Context, strikes []game.AirstrikeSnapshot) {
+	for _, a := range strikes {
+		dc.SetColor(airstrikeFill(a))
+		dc.DrawCircle(a.X, a.Y, a.Radius)
+		dc.Fill()
+
+		// Border, a ring closing in on the impact and a crosshair
+		dc.SetColor(airstrikeAccent)
+		dc.SetLineWidth(4)
+		dc.DrawCircle(a.X, a.Y, a.Radius)
+		dc.Stroke()
+		dc.SetLineWidth(2)
+		dc.DrawCircle(a.X, a.Y, a.Radius*(1-a.Progress))
+		dc.Stroke()
+		dc.DrawLine(a.X-a.Radius/3, a.Y, a.X+a.Radius/3, a.Y)
+		dc.DrawLine(a.X, a.Y-a.Radius/3, a.X, a.Y+a.Radius/3)
+		dc.Stroke()
+		dc.SetColor(airstrikeCore)
+		dc.DrawCircle(a.X, a.Y, 5)
+		dc.Fill()
+	}
+}
+
+// drawAirstrikes draws airstrike danger zones with fast primitives (mirrors StreamManager.drawAirstrikes)
+func (a *AtlasRenderer) drawAirstrikes(strikes []game.AirstrikeSnapshot) {
+	for _, s := range strikes {
+		x, y, r := int(s.X), int(s.Y), int(s.Radius/3)
+		a.fr.DrawFilledCircleBlend(x, y, s.Radius, airstrikeFill(s))
+		a.fr.DrawCircleOutline(x, y, s.Radius, 4, airstrikeAccent)
+		a.fr.DrawCircleOutline(x, y, s.Radius*(1-s.Progress), 2, airstrikeAccent)
+		a.fr.DrawThickLine(x-r, y, x+r, y, 2, airstrikeAccent)
+		a.fr.DrawThickLine(x, y-r, x, y+r, 2, airstrikeAccent)
+		a.fr.DrawFilledCircle(x, y, 5, airstrikeCore)
+	}
+}
@@ -44,6 +44,9 @@ type AsyncFrameWriter struct {
 	maxWriteTimeNs      int64     // atomic - max write time seen
 	currentBitrate      int       // current configured bitrate (for recommendations)
 
+	// A/V sync: advanced on every frame delivered to FFmpeg (optional)
+	avClock *AVClock
+
 	// Connection health tracking
 	consecutiveErrors int32          // atomic - consecutive write failures
 	lastErrorTime     time.Time      // time of last error
@@ -68,6 +71,11 @@ func (w *AsyncFrameWriter) SetBitrate(bitrate int) {
 	w.mu.Unlock()
 }
 
+// SetAVClock sets the clock advanced on every frame written (call before Start)
+func (w *AsyncFrameWriter) SetAVClock(clock *AVClock) {
+	w.avClock = clock
+}
+
 // SetOnConnectionLost sets a callback that will be called when the connection
 // is determined to be lost (after MaxConsecutiveErrors consecutive write failures).
 func (w *AsyncFrameWriter) SetOnConnectionLost(callback func()) {
@@ -181,6 +189,9 @@ func (w *AsyncFrameWriter) Start(fps int) {
 				}
 
 				atomic.AddUint64(&w.framesWritten, 1)
+				if w.avClock != nil {
+					w.avClock.VideoFrameWritten()
+				}
 				w.lastWriteTime = time.Now()
 
 				// Track average write time (exponential moving average)
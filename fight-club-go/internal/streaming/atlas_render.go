@@ -0,0 +1,570 @@
+package streaming
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"strings"
+
+	"fight-club/internal/game"
+
+	"github.com/fogleman/gg"
+	"golang.org/x/image/font"
+)
+
+// Renderer backends selectable via StreamConfig.Renderer
+const (
+	RendererGG    = "gg"    // Full vector rendering with gg.Context every frame (default)
+	RendererAtlas = "atlas" // Pre-rendered sprite atlas blitted into the frame buffer
+)
+
+// maxAtlasLabels caps the text sprite cache (names, money, floating texts).
+// The cache is dropped and rebuilt when it grows past this.
+const maxAtlasLabels = 2048
+
+// spriteSpan is a horizontal run of non-transparent pixels in a sprite.
+// Opaque runs are copied with a single copy() (memmove is vectorized),
+// translucent runs are alpha-blended pixel by pixel.
+type spriteSpan struct {
+	y, x0, x1 int
+	opaque    bool
+}
+
+// sprite is a pre-rendered premultiplied RGBA image with its runs precomputed
+type sprite struct {
+	img   *image.RGBA
+	spans []spriteSpan
+}
+
+// newSprite precomputes the opaque/translucent runs of a rendered image
+func newSprite(img *image.RGBA) *sprite {
+	sp := &sprite{img: img}
+	b := img.Bounds()
+	for y := 0; y < b.Dy(); y++ {
+		row := img.Pix[y*img.Stride:]
+		x := 0
+		for x < b.Dx() {
+			a := row[x*4+3]
+			if a == 0 {
+				x++
+				continue
+			}
+			opaque := a == 255
+			start := x
+			for x < b.Dx() {
+				a = row[x*4+3]
+				if a == 0 || (a == 255) != opaque {
+					break
+				}
+				x++
+			}
+			sp.spans = append(sp.spans, spriteSpan{y: y, x0: start, x1: x, opaque: opaque})
+		}
+	}
+	return sp
+}
+
+// labelKey identifies a cached text sprite
+type labelKey struct {
+	text  string
+	color color.RGBA
+	large bool
+}
+
+// AtlasRenderer composes frames by blitting pre-rendered sprites into the
+// RGBA frame buffer instead of rasterizing vectors with gg every frame.
+// Static art (background, player bodies, text labels, UI panel) is rendered
+// once with gg and cached; only cheap primitives are drawn per frame.
+// Trades some fidelity for speed: no rotation/scale on bodies (spin/flex emotes,
+// ragdoll spin) and no anti-aliasing on lines.
+type AtlasRenderer struct {
+	s      *StreamManager // Fonts, avatars and gg drawing helpers
+	width  int
+	height int
+	fr     *FastRenderer
+
+	backgrounds map[string][]byte  // Time of day backgrounds (see scene_render.go)
+	bodies      map[string]*sprite // Keyed by color or profile picture URL (+ radius when scaled)
+	shadow      *sprite
+	tinyShadow  *sprite // Shadow under the tiny players modifier
+	spawnGlow   *sprite
+	fog         *sprite // Fog modifier haze (full frame)
+	labels      map[labelKey]*sprite
+	ring        *sprite // Duel ring (see duel_render.go)
+	ringRadius  float64
+	badges      map[string]*sprite // Rank badges by tier ID (see rank_render.go)
+	minimap     *sprite            // Minimap background (see minimap_render.go)
+	coin        *sprite            // Dropped coin (see loot_render.go)
+
+	weaponDrops map[string]*sprite // Dropped weapon badges by weapon ID (see loot_render.go)
+	shopSigns   map[string]*sprite // Shop names and stock badges (see shop_render.go)
+
+	bubbles       map[string]*sprite // Chat bubble boxes by text (see bubble_render.go)
+	bubbleMeasure *gg.Context        // Measures bubble text for the layout
+
+	zoomed []byte // Unzoomed arena while auto-framing
+
+	// UI panel is re-rendered only when its contents change
+	ui    *sprite
+	uiKey string
+}
+
+// NewAtlasRenderer pre-renders the static atlas for a stream manager.
+// Returns an error if the atlas can't be built (caller falls back to gg).
+func NewAtlasRenderer(s *StreamManager) (*AtlasRenderer, error) {
+	if !s.fontsLoaded {
+		return nil, errors.New("fonts not loaded (labels need cached font faces)")
+	}
+
+	w, h := s.config.Width, s.config.Height
+	a := &AtlasRenderer{
+		s:      s,
+		width:  w,
+		height: h,
+		bodies: make(map[string]*sprite),
+		labels: make(map[labelKey]*sprite),
+		badges: make(map[string]*sprite),
+
+		backgrounds: make(map[string][]byte),
+	}
+
+	// Backgrounds are deterministic - render once per time of day, memcpy every frame
+	a.fr = NewFastRenderer(w, h, a.sceneBackground(game.SceneDay)) // Retargeted to the frame buffer in Render
+
+	a.shadow = a.renderCircle(30, color.RGBA{0, 0, 0, 128}, false)
+	a.tinyShadow = a.renderCircle(30*game.TinyPlayerScale, color.RGBA{0, 0, 0, 128}, false)
+	a.spawnGlow = a.renderCircle(40, color.RGBA{255, 255, 255, 77}, false)
+
+	dc := gg.NewContext(w, h)
+	s.drawFogOverlay(dc)
+	a.fog = newSprite(dc.Image().(*image.RGBA))
+
+	return a, nil
+}
+
+// renderCircle pre-renders a filled circle sprite, optionally with the white player border
+func (a *AtlasRenderer) renderCircle(radius float64, c color.RGBA, border bool) *sprite {
+	size := int(math.Ceil(radius*2)) + 8
+	dc := gg.NewContext(size, size)
+	center := float64(size) / 2
+
+	dc.SetColor(c)
+	dc.DrawCircle(center, center, radius)
+	dc.Fill()
+	if border {
+		dc.SetColor(color.White)
+		dc.SetLineWidth(4)
+		dc.DrawCircle(center, center, radius)
+		dc.Stroke()
+	}
+	return newSprite(dc.Image().(*image.RGBA))
+}
+
+// body returns the cached body sprite for a player (profile picture or colored circle)
+func (a *AtlasRenderer) body(p *game.PlayerSnapshot, sizeScale float64) *sprite {
+	radius := 30.0 * sizeScale
+	suffix := "" // Scaled bodies are cached separately
+	if sizeScale != 1.0 {
+		suffix = fmt.Sprintf("@%.0f", radius)
+	}
+
+	if p.ProfilePic != "" && a.s.avatarCache != nil {
+		if sp, ok := a.bodies[p.ProfilePic+suffix]; ok {
+			return sp
+		}
+		if avatarImg := a.s.avatarCache.GetOrFetch(p.ProfilePic); avatarImg != nil {
+			size := int(radius*2) + 8
+			dc := gg.NewContext(size, size)
+			center := float64(size) / 2
+
+			// Clip avatar to a circle, then add the border
+			dc.DrawCircle(center, center, radius)
+			dc.Clip()
+			scale := (radius * 2) / float64(avatarImg.Bounds().Dx())
+			dc.Push()
+			dc.Translate(center-radius, center-radius)
+			dc.Scale(scale, scale)
+			dc.DrawImage(avatarImg, 0, 0)
+			dc.Pop()
+			dc.ResetClip()
+
+			dc.SetColor(color.White)
+			dc.SetLineWidth(4)
+			dc.DrawCircle(center, center, radius)
+			dc.Stroke()
+
+			sp := newSprite(dc.Image().(*image.RGBA))
+			a.bodies[p.ProfilePic+suffix] = sp
+			return sp
+		}
+		// Avatar still downloading - use the colored body meanwhile (not cached)
+	}
+
+	key := "color:" + p.Color + suffix
+	if sp, ok := a.bodies[key]; ok {
+		return sp
+	}
+	sp := a.renderCircle(radius, parseHexColor(p.Color), true)
+	a.bodies[key] = sp
+	return sp
+}
+
+// label returns the cached text sprite for a string
+func (a *AtlasRenderer) label(text string, c color.RGBA, large bool) *sprite {
+	key := labelKey{text: text, color: c, large: large}
+	if sp, ok := a.labels[key]; ok {
+		return sp
+	}
+	if len(a.labels) >= maxAtlasLabels {
+		a.labels = make(map[labelKey]*sprite)
+	}
+
+	var face font.Face = a.s.fontSmall
+	if large {
+		face = a.s.fontMedium
+	}
+
+	measure := gg.NewContext(1, 1)
+	measure.SetFontFace(face)
+	tw, th := measure.MeasureString(text)
+
+	dc := gg.NewContext(int(math.Ceil(tw))+4, int(math.Ceil(th*1.5))+4)
+	dc.SetFontFace(face)
+	dc.SetColor(c)
+	dc.DrawStringAnchored(text, float64(dc.Width())/2, float64(dc.Height())/2, 0.5, 0.5)
+
+	sp := newSprite(dc.Image().(*image.RGBA))
+	a.labels[key] = sp
+	return sp
+}
+
+// blit composites a premultiplied sprite centered at (cx, cy) with the given opacity (0-255)
+func (a *AtlasRenderer) blit(buffer []byte, sp *sprite, cx, cy float64, opacity uint8) {
+	if opacity == 0 {
+		return
+	}
+	b := sp.img.Bounds()
+	ox := int(cx) - b.Dx()/2
+	oy := int(cy) - b.Dy()/2
+	stride := a.width * 4
+	op := uint32(opacity)
+
+	for _, span := range sp.spans {
+		y := oy + span.y
+		if y < 0 || y >= a.height {
+			continue
+		}
+		x0 := max(0, ox+span.x0)
+		x1 := min(a.width, ox+span.x1)
+		if x0 >= x1 {
+			continue
+		}
+
+		src := sp.img.Pix[span.y*sp.img.Stride+(x0-ox)*4 : span.y*sp.img.Stride+(x1-ox)*4]
+		dst := buffer[y*stride+x0*4 : y*stride+x1*4]
+
+		// Fast path: opaque run at full opacity is a straight copy
+		if span.opaque && op == 255 {
+			copy(dst, src)
+			continue
+		}
+
+		// Premultiplied "over": dst = src*op + dst*(1 - srcA*op)
+		for i := 0; i < len(src); i += 4 {
+			sa := uint32(src[i+3]) * op / 255
+			inv := 255 - sa
+			dst[i] = uint8((uint32(src[i])*op + uint32(dst[i])*inv) / 255)
+			dst[i+1] = uint8((uint32(src[i+1])*op + uint32(dst[i+1])*inv) / 255)
+			dst[i+2] = uint8((uint32(src[i+2])*op + uint32(dst[i+2])*inv) / 255)
+			dst[i+3] = 255
+		}
+	}
+}
+
+// Render composes a full frame from the snapshot into buffer
+func (a *AtlasRenderer) Render(snap *game.GameSnapshot, buffer []byte) {
+	copy(buffer, a.sceneBackground(snap.Scene.Time))
+	a.fr.SetBuffer(buffer)
+	a.drawPit(snap.PitBand)
+
+	a.drawObjective(snap.Objective)
+	if snap.Duel.Active {
+		a.blit(buffer, a.duelRing(snap.Duel.Radius), snap.Duel.X, snap.Duel.Y, 255)
+	}
+	a.drawChaos(buffer, snap.Chaos, true)
+	a.drawShops(buffer, snap.Shops)
+	a.drawAirstrikes(snap.Airstrikes)
+	a.drawLoot(buffer, snap.Loot)
+	a.drawWeaponDrops(buffer, snap.WeaponDrops)
+
+	sizeScale := game.ModifierPlayerScale(snap.Vote.Modifier)
+	for i := range snap.Players {
+		p := &snap.Players[i]
+		if p.IsRagdoll {
+			a.drawRagdoll(buffer, p, sizeScale)
+		} else if p.IsDead {
+			continue
+		} else if a.s.lod.Full(p) {
+			a.drawPlayer(buffer, p, sizeScale)
+		} else {
+			a.drawPlayerDot(buffer, p, sizeScale)
+		}
+	}
+
+	if len(snap.Particles) > 0 && a.s.workerPool != nil {
+		a.s.workerPool.RenderParticlesSnapshotParallel(snap.Particles, buffer, a.width, a.height)
+	}
+
+	for _, e := range snap.Effects {
+		progress := 1 - float64(e.Timer)/20.0
+		c := parseHexColor(e.Color)
+		c.A = uint8((1 - progress*0.5) * 255)
+		angle := math.Atan2(e.TY-e.Y, e.TX-e.X)
+		a.drawArc(e.X, e.Y, 70, angle-0.8, angle+0.8, 4, c)
+	}
+
+	a.s.trails.Render(buffer, snap.Trails, snap.Players)
+
+	for _, fl := range snap.Flashes {
+		c := parseHexColor(fl.Color)
+		c.A = uint8(fl.Intensity * 200)
+		a.fr.DrawFilledCircleBlend(int(fl.X), int(fl.Y), fl.Radius, c)
+	}
+
+	for _, proj := range snap.Projectiles {
+		a.drawProjectile(proj)
+	}
+
+	for _, t := range snap.Texts {
+		sp := a.label(t.Text, parseHexColor(t.Color), false)
+		a.blit(buffer, sp, t.X, t.Y, uint8(t.Alpha*255))
+	}
+
+	a.drawChatBubbles(buffer, snap.Players)
+
+	a.drawChaos(buffer, snap.Chaos, false)
+	a.drawWeather(snap)
+
+	if snap.Vote.Modifier == game.ModFog {
+		a.blit(buffer, a.fog, float64(a.width)/2, float64(a.height)/2, 255)
+	}
+
+	// Auto-framing zooms the finished arena (sprites are pre-rendered at 1x)
+	if view := a.s.framer.View(); view.zoomed() {
+		if a.zoomed == nil {
+			a.zoomed = make([]byte, len(buffer))
+		}
+		copy(a.zoomed, buffer)
+		zoomFrame(a.zoomed, buffer, a.width, a.height, view)
+	}
+
+	a.drawUI(buffer, snap)
+
+	if a.s.config.Theme.Minimap && !a.s.frameSkip.SkipsOverlays() {
+		a.drawMinimap(buffer, snap)
+	}
+}
+
+// drawPlayer composes an alive player from cached sprites
+func (a *AtlasRenderer) drawPlayer(buffer []byte, p *game.PlayerSnapshot, sizeScale float64) {
+	shadow := a.shadow
+	if sizeScale != 1.0 {
+		shadow = a.tinyShadow
+	}
+	a.blit(buffer, shadow, p.X, p.Y+8, 255)
+
+	y := p.Y
+	emote, hasEmote := game.GetEmote(p.Emote)
+	if hasEmote && emote.Animation == game.EmoteAnimJump {
+		y -= math.Sin(p.EmoteProgress*math.Pi) * 25
+	}
+
+	if p.SpawnProtection {
+		a.blit(buffer, a.spawnGlow, p.X, y, 255)
+	}
+
+	if p.IsAttacking {
+		a.drawWeaponAttack(p, y)
+	}
+
+	a.blit(buffer, a.body(p, sizeScale), p.X, y, 255)
+
+	radius := 30.0 * sizeScale
+	a.drawSkinBorder(p, y, radius)
+
+	// Spectator effect rings
+	if p.IsCheered {
+		a.fr.DrawCircleOutline(int(p.X), int(y), radius+8, 3, color.RGBA{83, 255, 69, 255})
+	}
+	if p.IsCursed {
+		a.fr.DrawCircleOutline(int(p.X), int(y), radius+13, 3, color.RGBA{168, 85, 247, 255})
+	}
+	a.drawTeamOrders(p, y, radius)
+
+	// Health bar
+	hpBarWidth := 80
+	hpPercent := float64(p.HP) / float64(p.MaxHP)
+	barX := int(p.X) - hpBarWidth/2
+	barY := int(y) - 50
+	a.fr.DrawFilledRect(barX, barY, hpBarWidth, 10, color.RGBA{51, 51, 51, 255})
+	fill := color.RGBA{255, 62, 62, 255}
+	if hpPercent > 0.5 {
+		fill = color.RGBA{83, 255, 69, 255}
+	} else if hpPercent > 0.25 {
+		fill = color.RGBA{255, 149, 0, 255}
+	}
+	a.fr.DrawFilledRect(barX, barY, int(float64(hpBarWidth)*hpPercent), 10, fill)
+
+	// Stamina bar under the health bar
+	a.fr.DrawFilledRect(barX, barY+12, hpBarWidth, staminaBarHeight, color.RGBA{51, 51, 51, 255})
+	a.fr.DrawFilledRect(barX, barY+12, int(float64(hpBarWidth)*staminaPercent(p)), staminaBarHeight, staminaBarColor(p))
+
+	// Player card labels
+	nameWidth := a.drawName(buffer, p, p.X, y+50)
+	if rank, ok := game.GetRank(p.Rank); ok {
+		a.blit(buffer, a.rankBadge(rank), p.X-nameWidth/2-rankBadgeRadius-2, y+50, 255)
+	}
+	a.blit(buffer, a.label(fmt.Sprintf("$%d", p.Money), color.RGBA{255, 120, 0, 255}, false), p.X, y+70, 255)
+	if p.Personality != "" {
+		style := game.GetPersonality(p.Personality)
+		a.blit(buffer, a.label(style.Name, parseHexColor(style.Color), false), p.X, y+88, 255)
+	}
+
+	if hasEmote {
+		rise := p.EmoteProgress * 30
+		sp := a.label(emote.Emoji, color.RGBA{20, 25, 35, 255}, true)
+		a.blit(buffer, sp, p.X, y-70-rise, uint8((1-p.EmoteProgress)*255))
+	}
+}
+
+// drawRagdoll draws a faded body with a red X, shrinking into the pit on a ring-out
+func (a *AtlasRenderer) drawRagdoll(buffer []byte, p *game.PlayerSnapshot, sizeScale float64) {
+	scale, opacity := fallScale(p)
+	if opacity <= 0 {
+		return // Fell out of sight
+	}
+	if p.RingOut {
+		scale = fallSpriteScale(scale)
+	}
+	a.blit(buffer, a.body(p, sizeScale*scale), p.X, p.Y, uint8(153*opacity))
+
+	red := color.RGBA{255, 0, 0, 255}
+	x, y := int(p.X), int(p.Y)
+	arm := int(10 * scale)
+	a.fr.DrawThickLine(x-arm, y-arm, x+arm, y+arm, max(int(4*scale), 1), red)
+	a.fr.DrawThickLine(x+arm, y-arm, x-arm, y+arm, max(int(4*scale), 1), red)
+}
+
+// drawWeaponAttack draws the weapon trail with fast primitives (mirrors drawWeaponAttack)
+func (a *AtlasRenderer) drawWeaponAttack(p *game.PlayerSnapshot, y float64) {
+	anim := game.GetWeaponAnimation(p.Weapon)
+	weapon := game.GetWeapon(p.Weapon)
+	c := parseHexColor(swingColor(p, anim))
+
+	switch anim.TrailType {
+	case game.TrailArc:
+		start := p.AttackAngle - anim.TrailWidth/2
+		end := p.AttackAngle + anim.TrailWidth/2
+		for layer := 0; layer < 3; layer++ {
+			radius := weapon.Range * (0.8 + float64(layer)*0.1)
+			a.drawArc(p.X, y, radius, start, end, 4-layer, c)
+		}
+	case game.TrailLine:
+		endX := p.X + math.Cos(p.AttackAngle)*weapon.Range
+		endY := y + math.Sin(p.AttackAngle)*weapon.Range
+		a.fr.DrawThickLine(int(p.X), int(y), int(endX), int(endY), int(anim.TrailWidth/5), c)
+		a.fr.DrawFilledCircle(int(endX), int(endY), 6, c)
+	case game.TrailRadial:
+		c.A = 100
+		a.fr.DrawCircleOutline(int(p.X), int(y), weapon.Range, 3, c)
+		c.A = 180
+		dirX := p.X + math.Cos(p.AttackAngle)*(weapon.Range*0.8)
+		dirY := y + math.Sin(p.AttackAngle)*(weapon.Range*0.8)
+		a.fr.DrawFilledCircleBlend(int(dirX), int(dirY), 10, c)
+	}
+}
+
+// drawArc approximates an arc stroke with short thick line segments
+func (a *AtlasRenderer) drawArc(cx, cy, radius, start, end float64, thickness int, c color.RGBA) {
+	const segments = 12
+	step := (end - start) / segments
+	px, py := cx+math.Cos(start)*radius, cy+math.Sin(start)*radius
+	for i := 1; i <= segments; i++ {
+		angle := start + step*float64(i)
+		nx, ny := cx+math.Cos(angle)*radius, cy+math.Sin(angle)*radius
+		a.fr.DrawThickLine(int(px), int(py), int(nx), int(ny), thickness, c)
+		px, py = nx, ny
+	}
+}
+
+// drawProjectile draws an arrow with fast primitives (mirrors drawProjectilesFromSnapshot)
+func (a *AtlasRenderer) drawProjectile(proj game.ProjectileSnapshot) {
+	c := parseHexColor(proj.Color)
+
+	for i := 0; i < proj.TrailCount; i++ {
+		c.A = uint8(max(30, 100-i*20))
+		a.fr.DrawFilledCircleBlend(int(proj.TrailX[i]), int(proj.TrailY[i]), float64(4-i), c)
+	}
+
+	cos, sin := math.Cos(proj.Rotation), math.Sin(proj.Rotation)
+	at := func(lx, ly float64) (int, int) {
+		return int(proj.X + lx*cos - ly*sin), int(proj.Y + lx*sin + ly*cos)
+	}
+
+	c.A = 100
+	a.fr.DrawFilledCircleBlend(int(proj.X), int(proj.Y), 8, c)
+
+	c.A = 255
+	x0, y0 := at(-18, 0)
+	x1, y1 := at(10, 0)
+	a.fr.DrawThickLine(x0, y0, x1, y1, 4, c)
+	hx, hy := at(7, 0)
+	a.fr.DrawFilledCircle(hx, hy, 4, c)
+	fx, fy := at(-22, -4)
+	a.fr.DrawThickLine(x0, y0, fx, fy, 2, c)
+	fx, fy = at(-22, 4)
+	a.fr.DrawThickLine(x0, y0, fx, fy, 2, c)
+}
+
+// drawUI blits the UI panel, re-rendering it with gg only when its contents change
+func (a *AtlasRenderer) drawUI(buffer []byte, snap *game.GameSnapshot) {
+	var key strings.Builder
+	fmt.Fprintf(&key, "%d", snap.AliveCount)
+	lp := a.s.sessionLeaderboardPage(snap)
+	fmt.Fprintf(&key, "|page:%d/%d", lp.page, lp.pages)
+	for i := lp.start; i < lp.end; i++ {
+		fmt.Fprintf(&key, "|%s:%d", snap.Players[i].Name, snap.Players[i].Kills)
+	}
+	// Vote overlay changes once per second (countdown) or on new ballots
+	v := snap.Vote
+	fmt.Fprintf(&key, "|vote:%t:%s:%v:%v:%d", v.Voting, v.Modifier, v.Options, v.Counts, int(math.Ceil(v.Remaining)))
+	lb := snap.Leaderboard
+	fmt.Fprintf(&key, "|lb:%s:%v:%v", lb.Period, lb.Names[:lb.Count], lb.Kills[:lb.Count])
+	fmt.Fprintf(&key, "|paused:%t|replay:%t|viewers:%d", snap.Paused, snap.Replaying, snap.ViewerCount)
+	if d := a.s.framer.View().duel(snap.Duel); d.Active {
+		fmt.Fprintf(&key, "|duel:%v:%d:%.0f:%.0f", d.Players, int(math.Ceil(d.Remaining)), d.X, d.Y-d.Radius)
+	}
+	if obj := snap.Objective; obj.Active {
+		fmt.Fprintf(&key, "|objective:%s:%d", objectiveBannerText(obj), obj.Owner)
+	}
+	if banner := chaosBannerText(snap.Chaos); banner != "" {
+		fmt.Fprintf(&key, "|chaos:%s", banner)
+	}
+	if t := snap.QuestToast; t.Username != "" {
+		fmt.Fprintf(&key, "|quest:%s:%s:%d", t.Username, t.Quest, t.Reward)
+	}
+	if q := joinQueueText(snap.JoinQueue); q != "" {
+		fmt.Fprintf(&key, "|queue:%s", q)
+	}
+
+	if a.ui == nil || key.String() != a.uiKey {
+		dc := gg.NewContext(a.width, a.height)
+		a.s.drawUIFromSnapshot(dc, snap)
+		a.ui = newSprite(dc.Image().(*image.RGBA))
+		a.uiKey = key.String()
+	}
+
+	a.blit(buffer, a.ui, float64(a.width)/2, float64(a.height)/2, 255)
+}
@@ -0,0 +1,130 @@
+package streaming
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"testing"
+
+	"fight-club/internal/game"
+)
+
+// newAtlasTestManager builds a stream manager with the atlas backend, skipping without fonts
+func newAtlasTestManager(tb testing.TB, width, height int) *StreamManager {
+	sm := NewStreamManager(nil, StreamConfig{Width: width, Height: height, Renderer: RendererAtlas})
+	tb.Cleanup(sm.workerPool.Stop)
+	if sm.atlas == nil {
+		tb.Skip("Atlas renderer unavailable (no fonts installed)")
+	}
+	return sm
+}
+
+func atlasTestSnapshot(players int) *game.GameSnapshot {
+	snap := &game.GameSnapshot{AliveCount: players}
+	for i := 0; i < players; i++ {
+		snap.Players = append(snap.Players, game.PlayerSnapshot{
+			Name:        fmt.Sprintf("Player%d", i),
+			X:           float64(100 + (i*97)%1000),
+			Y:           float64(100 + (i*53)%500),
+			HP:          50 + i%50,
+			MaxHP:       100,
+			Color:       "#4ecdc4",
+			Weapon:      "sword",
+			IsAttacking: i%3 == 0,
+			Personality: "sniper",
+		})
+	}
+	return snap
+}
+
+// TestSpriteSpans verifies opaque and translucent runs are split correctly
+func TestSpriteSpans(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 6, 1))
+	img.Set(1, 0, color.RGBA{255, 0, 0, 255})
+	img.Set(2, 0, color.RGBA{255, 0, 0, 255})
+	img.Set(3, 0, color.RGBA{64, 0, 0, 128})
+
+	sp := newSprite(img)
+	if len(sp.spans) != 2 {
+		t.Fatalf("Expected 2 spans, got %d: %+v", len(sp.spans), sp.spans)
+	}
+	if s := sp.spans[0]; s.x0 != 1 || s.x1 != 3 || !s.opaque {
+		t.Errorf("Unexpected opaque span %+v", s)
+	}
+	if s := sp.spans[1]; s.x0 != 3 || s.x1 != 4 || s.opaque {
+		t.Errorf("Unexpected translucent span %+v", s)
+	}
+}
+
+// TestAtlasBlitClipsAndBlends verifies blits clip at frame edges and blend translucent pixels
+func TestAtlasBlitClipsAndBlends(t *testing.T) {
+	a := &AtlasRenderer{width: 4, height: 4}
+	buffer := make([]byte, 4*4*4)
+	for i := range buffer {
+		buffer[i] = 255 // White frame
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	for i := range img.Pix {
+		img.Pix[i] = 0
+	}
+	img.Set(0, 0, color.RGBA{0, 0, 0, 255})   // Opaque black
+	img.Set(1, 0, color.RGBA{0, 0, 0, 128})   // Half-transparent black
+	img.Set(0, 1, color.RGBA{255, 0, 0, 255}) // Opaque red
+
+	// Centered on the top-left corner: only the bottom-right quadrant of the sprite is visible
+	a.blit(buffer, newSprite(img), 0, 0, 255)
+	a.blit(buffer, newSprite(img), 1, 1, 255)
+
+	if buffer[0] != 0 {
+		t.Errorf("Expected opaque black at (0,0), got %d", buffer[0])
+	}
+	if g := buffer[4]; g < 120 || g > 135 {
+		t.Errorf("Expected ~50%% blend at (1,0), got %d", g)
+	}
+	if r, g := buffer[16], buffer[17]; r != 255 || g != 0 {
+		t.Errorf("Expected red at (0,1), got r=%d g=%d", r, g)
+	}
+}
+
+// TestAtlasRender verifies the atlas backend draws players onto the background
+func TestAtlasRender(t *testing.T) {
+	sm := newAtlasTestManager(t, 1280, 720)
+	buffer := make([]byte, 1280*720*4)
+
+	snap := atlasTestSnapshot(10)
+	sm.atlas.Render(snap, buffer)
+
+	p := snap.Players[5] // Clear of the UI panel
+	idx := (int(p.Y)*1280 + int(p.X)) * 4
+	got := color.RGBA{buffer[idx], buffer[idx+1], buffer[idx+2], buffer[idx+3]}
+	if got != parseHexColor(p.Color) {
+		t.Errorf("Expected player body color at center, got %v", got)
+	}
+}
+
+// BenchmarkRender_GG benchmarks the gg vector renderer at 1080p
+func BenchmarkRender_GG(b *testing.B) {
+	sm := NewStreamManager(nil, StreamConfig{Width: 1920, Height: 1080})
+	defer sm.workerPool.Stop()
+	snap := atlasTestSnapshot(30)
+	buffer := make([]byte, 1920*1080*4)
+	dc := sm.doubleBuffer.contexts[0]
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		sm.renderFrameFromSnapshot(snap, buffer, dc)
+	}
+}
+
+// BenchmarkRender_Atlas benchmarks the sprite atlas renderer at 1080p
+func BenchmarkRender_Atlas(b *testing.B) {
+	sm := newAtlasTestManager(b, 1920, 1080)
+	snap := atlasTestSnapshot(30)
+	buffer := make([]byte, 1920*1080*4)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		sm.atlas.Render(snap, buffer)
+	}
+}
@@ -3,27 +3,29 @@ package streaming
 import (
 	"encoding/binary"
 	"math"
-	"os"
-	"path/filepath"
 	"sync"
 )
 
+// panWidth scales positional panning so events at the arena edge are strongly
+// but not exclusively on one side (1.0 = hard left/right)
+const panWidth = 0.8
+
 // AudioConfig holds audio mixer configuration
 type AudioConfig struct {
 	MusicEnabled bool
 	MusicVolume  float64 // 0.0-1.0, recommended 0.1-0.2 for background
 	MusicPath    string
+	Sounds       SoundConfig // Sound effects pack, directory and cache cap
 }
 
 // AudioMixer handles audio generation and mixing
 type AudioMixer struct {
-	mu            sync.Mutex
-	sampleRate    int
-	channels      int
-	bytesPerFrame int
+	mu         sync.Mutex
+	sampleRate int
+	channels   int
 
-	// Loaded sounds
-	sounds map[string][]int16
+	// Sound effects, decoded on first use from the active pack
+	assets *AudioAssetManager
 
 	// Active sounds being played
 	activeSounds []*activeSound
@@ -40,24 +42,24 @@ type activeSound struct {
 	data     []int16
 	position int
 	volume   float64
+	gainL    float64 // Stereo panning gains (1.0 both = centered)
+	gainR    float64
 }
 
 // NewAudioMixer creates a new audio mixer
 // Pass nil config for defaults (no music)
 func NewAudioMixer(config *AudioConfig) *AudioMixer {
+	var sounds SoundConfig // Zero value = default pack and cache cap
+	if config != nil {
+		sounds = config.Sounds
+	}
 	m := &AudioMixer{
 		sampleRate:   44100,
 		channels:     2,
-		sounds:       make(map[string][]int16),
+		assets:       NewAudioAssetManager(sounds),
 		activeSounds: make([]*activeSound, 0),
 	}
 
-	// 44100 / 30 fps = 1470 samples per frame
-	// 1470 * 2 channels * 2 bytes = 5880 bytes per frame
-	m.bytesPerFrame = (m.sampleRate / 30) * m.channels * 2
-
-	m.loadSounds()
-
 	// Initialize background music if enabled
 	// Music is tied to stream start, not individual matches
 	if config != nil && config.MusicEnabled && config.MusicPath != "" {
@@ -67,43 +69,47 @@ func NewAudioMixer(config *AudioConfig) *AudioMixer {
 	return m
 }
 
-func (m *AudioMixer) loadSounds() {
-	soundsDir := filepath.Join("assets", "sounds")
-	soundNames := []string{"hit", "kill", "spawn", "swing", "ambient"}
-
-	for _, name := range soundNames {
-		path := filepath.Join(soundsDir, name+".wav")
-		data, err := loadWAV(path)
-		if err != nil {
-			// Try parent directory
-			path = filepath.Join("..", "assets", "sounds", name+".wav")
-			data, err = loadWAV(path)
-		}
-		if err == nil {
-			m.sounds[name] = data
-		}
-	}
+// Assets returns the sound effects manager (active pack, cache stats)
+func (m *AudioMixer) Assets() *AudioAssetManager {
+	return m.assets
 }
 
-// QueueSound queues a sound to be played
+// QueueSound queues a sound to be played (centered)
 func (m *AudioMixer) QueueSound(name string) {
-	m.mu.Lock()
-	defer m.mu.Unlock()
+	m.QueueSoundAt(name, "", 0)
+}
 
-	data, ok := m.sounds[name]
+// QueueSoundAt queues a weapon's variant of a sound (falling back to the
+// generic sound) panned by pan: -1 = arena left edge, 0 = center, 1 = right edge
+func (m *AudioMixer) QueueSoundAt(name, weapon string, pan float64) {
+	// Decode (first use) outside the mixer lock so audio generation never waits on disk
+	var data []int16
+	ok := false
+	if weapon != "" {
+		data, ok = m.assets.Sound(weaponSoundKey(weapon, name))
+	}
+	if !ok {
+		data, ok = m.assets.Sound(name)
+	}
 	if !ok {
 		return
 	}
 
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	volume := 1.0
 	if name == "ambient" {
 		volume = 0.3
 	}
 
+	gainL, gainR := panGains(pan)
 	m.activeSounds = append(m.activeSounds, &activeSound{
 		name:   name,
 		data:   data,
 		volume: volume,
+		gainL:  gainL,
+		gainR:  gainR,
 	})
 
 	// Limit concurrent sounds
@@ -112,15 +118,26 @@ func (m *AudioMixer) QueueSound(name string) {
 	}
 }
 
-// GenerateFrame generates one frame of audio (5880 bytes)
+// SampleRate returns the output sample rate (Hz)
+func (m *AudioMixer) SampleRate() int {
+	return m.sampleRate
+}
+
+// GenerateFrame generates one 30 FPS frame of audio (1470 samples, 5880 bytes)
+func (m *AudioMixer) GenerateFrame() []byte {
+	return m.GenerateSamples(m.sampleRate / 30)
+}
+
+// GenerateSamples generates n samples per channel of interleaved s16le stereo.
 // Mixes: background music + ambient + sound effects
 // Applies soft limiting at ±30000 to prevent clipping when mixed
-func (m *AudioMixer) GenerateFrame() []byte {
+func (m *AudioMixer) GenerateSamples(n int) []byte {
+	ambient, _ := m.assets.Sound("ambient")
+
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	samplesPerFrame := m.sampleRate / 30
-	mixBuffer := make([]int32, samplesPerFrame*m.channels)
+	mixBuffer := make([]int32, n*m.channels)
 
 	// Mix background music first (lowest priority, continuous)
 	if m.musicPlayer != nil && m.musicPlayer.IsLoaded() {
@@ -132,7 +149,7 @@ func (m *AudioMixer) GenerateFrame() []byte {
 	}
 
 	// Mix ambient (slightly above music)
-	if ambient, ok := m.sounds["ambient"]; ok && len(ambient) > 0 {
+	if len(ambient) > 0 {
 		for i := 0; i < len(mixBuffer); i++ {
 			idx := (m.ambientPos + i) % len(ambient)
 			mixBuffer[i] += int32(float64(ambient[idx]) * 0.20) // Reduced from 0.25
@@ -153,8 +170,14 @@ func (m *AudioMixer) GenerateFrame() []byte {
 			toRead = remaining
 		}
 
+		// Interleaved stereo: even samples left, odd samples right
+		left, right := s.volume*s.gainL, s.volume*s.gainR
 		for i := 0; i < toRead; i++ {
-			mixBuffer[i] += int32(float64(s.data[s.position+i]) * s.volume)
+			gain := left
+			if i%2 == 1 {
+				gain = right
+			}
+			mixBuffer[i] += int32(float64(s.data[s.position+i]) * gain)
 		}
 
 		s.position += toRead
@@ -166,8 +189,8 @@ func (m *AudioMixer) GenerateFrame() []byte {
 
 	// Convert to bytes with SOFT LIMITING (prevents harsh clipping)
 	// Soft limit at ±30000 leaves headroom, gradual curve for less distortion
-	output := make([]byte, m.bytesPerFrame)
-	for i := 0; i < len(mixBuffer) && i*2+1 < len(output); i++ {
+	output := make([]byte, len(mixBuffer)*2)
+	for i := 0; i < len(mixBuffer); i++ {
 		sample := mixBuffer[i]
 
 		// Soft limiting: gradual compression above ±30000
@@ -190,26 +213,25 @@ func (m *AudioMixer) GenerateFrame() []byte {
 	return output
 }
 
-// loadWAV loads a WAV file and returns the raw PCM samples
-func loadWAV(path string) ([]int16, error) {
-	data, err := os.ReadFile(path)
-	if err != nil {
-		return nil, err
-	}
-
-	// Skip 44-byte WAV header
-	if len(data) < 44 {
-		return nil, err
+// panGains returns left/right gains for a pan position (balance law: the near
+// channel stays at full volume, the far channel fades, center is unchanged)
+func panGains(pan float64) (left, right float64) {
+	pan = math.Max(-1, math.Min(1, pan)) * panWidth
+	left, right = 1, 1
+	if pan > 0 {
+		left = 1 - pan
+	} else {
+		right = 1 + pan
 	}
+	return left, right
+}
 
-	pcmData := data[44:]
-	samples := make([]int16, len(pcmData)/2)
-
-	for i := 0; i < len(samples); i++ {
-		samples[i] = int16(binary.LittleEndian.Uint16(pcmData[i*2:]))
+// ArenaPan maps an arena X coordinate to a pan position for a given arena width
+func ArenaPan(x float64, width int) float64 {
+	if width <= 0 {
+		return 0
 	}
-
-	return samples, nil
+	return x/float64(width)*2 - 1
 }
 
 // GenerateTone generates a simple tone for testing
@@ -0,0 +1,359 @@
+package streaming
+
+import (
+	"container/list"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"fight-club/internal/config"
+	"fight-club/internal/game"
+
+	"github.com/gopxl/beep"
+	"github.com/gopxl/beep/mp3"
+	"github.com/gopxl/beep/vorbis"
+	"github.com/gopxl/beep/wav"
+)
+
+// SoundConfig is an alias for config.SoundConfig (SSOT)
+type SoundConfig = config.SoundConfig
+
+// soundPacksDir holds the named packs inside the sounds directory
+const soundPacksDir = "packs"
+
+// soundExtensions are the formats a sound file may be in, in lookup order
+var soundExtensions = []string{".wav", ".ogg", ".mp3"}
+
+// AudioAssetManager loads the mixer's sound effects on first use from the
+// active sound pack. The sounds directory is the "default" pack; a named pack
+// (<dir>/packs/<name>) overrides any of its sounds with files or manifest
+// variants. Sounds a pack lacks fall back to the default pack, weapon
+// variants to the pack's own generic sound (so a retro pack never plays a
+// default-pack sword). Decoded sounds are cached up to a memory cap, least
+// recently played dropped first.
+type AudioAssetManager struct {
+	mu        sync.Mutex
+	dir       string
+	pack      string
+	manifests map[string]*SoundManifest // Parsed manifest per pack (nil = none)
+
+	cache    map[string]*list.Element // "pack:name" -> *cachedSound
+	lru      *list.List               // Most recently played first
+	bytes    int
+	maxBytes int
+
+	weaponsVersion uint64 // game.WeaponRegistryVersion the cached weapon sounds were loaded for
+
+	hits      uint64
+	misses    uint64
+	evictions uint64
+}
+
+// cachedSound is a decoded sound (nil data = the pack doesn't have it)
+type cachedSound struct {
+	key  string
+	data []int16
+}
+
+// NewAudioAssetManager creates an asset manager for the configured pack. An
+// unknown pack logs a warning and plays the default pack.
+func NewAudioAssetManager(cfg SoundConfig) *AudioAssetManager {
+	if cfg.CacheMB <= 0 {
+		cfg.CacheMB = config.DefaultSounds().CacheMB
+	}
+	am := &AudioAssetManager{
+		dir:       resolveSoundsDir(cfg.Dir),
+		pack:      game.DefaultSoundPack,
+		manifests: make(map[string]*SoundManifest),
+		cache:     make(map[string]*list.Element),
+		lru:       list.New(),
+		maxBytes:  cfg.CacheMB << 20,
+	}
+	if cfg.Pack != "" {
+		if err := am.SetPack(cfg.Pack); err != nil {
+			log.Printf("⚠️ %v, using the default sounds", err)
+		}
+	}
+	return am
+}
+
+// resolveSoundsDir returns the sounds directory: assets/sounds, or
+// ../assets/sounds when run from a subdirectory
+func resolveSoundsDir(dir string) string {
+	if dir != "" {
+		return dir
+	}
+	dir = filepath.Join("assets", "sounds")
+	if _, err := os.Stat(dir); err != nil {
+		dir = filepath.Join("..", "assets", "sounds")
+	}
+	return dir
+}
+
+// ListSoundPacks returns the packs in a sounds directory ("" = default
+// location): "default" followed by <dir>/packs/* in name order
+func ListSoundPacks(dir string) []string {
+	packs := []string{game.DefaultSoundPack}
+	entries, err := os.ReadDir(filepath.Join(resolveSoundsDir(dir), soundPacksDir))
+	if err != nil {
+		return packs
+	}
+	var named []string
+	for _, e := range entries {
+		if e.IsDir() && e.Name() != game.DefaultSoundPack {
+			named = append(named, e.Name())
+		}
+	}
+	sort.Strings(named)
+	return append(packs, named...)
+}
+
+// Packs returns the available sound packs (rescanned, so packs dropped in
+// while streaming can be switched to)
+func (am *AudioAssetManager) Packs() []string {
+	return ListSoundPacks(am.dir)
+}
+
+// Pack returns the active sound pack
+func (am *AudioAssetManager) Pack() string {
+	am.mu.Lock()
+	defer am.mu.Unlock()
+	return am.pack
+}
+
+// SetPack switches the active sound pack. Sounds of the previous pack stay
+// cached until evicted, so switching back is instant.
+func (am *AudioAssetManager) SetPack(pack string) error {
+	found := false
+	for _, p := range am.Packs() {
+		found = found || p == pack
+	}
+	if !found {
+		return fmt.Errorf("unknown sound pack %q", pack)
+	}
+
+	am.mu.Lock()
+	defer am.mu.Unlock()
+	if am.pack != pack {
+		am.pack = pack
+		log.Printf("🔊 Sound pack: %s", pack)
+	}
+	return nil
+}
+
+// Sound returns the decoded sound (44.1kHz s16 interleaved stereo) for a
+// generic ("hit") or weapon ("hammer/hit") sound name in the active pack,
+// decoding it on first use
+func (am *AudioAssetManager) Sound(name string) ([]int16, bool) {
+	am.mu.Lock()
+	defer am.mu.Unlock()
+
+	if v := game.WeaponRegistryVersion(); v != am.weaponsVersion {
+		am.weaponsVersion = v
+		am.dropWeaponSounds()
+	}
+
+	if am.pack != game.DefaultSoundPack {
+		if data := am.lookup(am.pack, name); data != nil {
+			return data, true
+		}
+		if _, _, weapon := splitWeaponSoundKey(name); weapon {
+			return nil, false // The mixer falls back to the pack's generic sound
+		}
+	}
+	data := am.lookup(game.DefaultSoundPack, name)
+	return data, data != nil
+}
+
+// lookup returns a pack's sound from the cache, loading it on a miss. Caller holds am.mu.
+func (am *AudioAssetManager) lookup(pack, name string) []int16 {
+	key := pack + ":" + name
+	if el, ok := am.cache[key]; ok {
+		am.lru.MoveToFront(el)
+		am.hits++
+		return el.Value.(*cachedSound).data
+	}
+	am.misses++
+
+	data := am.load(pack, name)
+	am.cache[key] = am.lru.PushFront(&cachedSound{key: key, data: data})
+	am.bytes += len(data) * 2
+
+	// Evict least recently played sounds past the cap (never the one just loaded)
+	for am.bytes > am.maxBytes && am.lru.Len() > 1 {
+		oldest := am.lru.Back()
+		sound := am.lru.Remove(oldest).(*cachedSound)
+		delete(am.cache, sound.key)
+		am.bytes -= len(sound.data) * 2
+		if sound.data != nil {
+			am.evictions++
+		}
+	}
+	return data
+}
+
+// load decodes a pack's sound: its manifest variant if listed, else
+// <name>.wav/.ogg/.mp3 in the pack directory. Caller holds am.mu.
+func (am *AudioAssetManager) load(pack, name string) []int16 {
+	dir := am.packDir(pack)
+
+	if v, ok := am.manifest(pack).variant(name); ok {
+		// Variant files may also be the default pack's sounds
+		for _, path := range []string{filepath.Join(dir, v.File), filepath.Join(am.dir, v.File)} {
+			if samples, err := decodeSound(path); err == nil && len(samples) > 0 {
+				return applySoundVariant(samples, v)
+			}
+		}
+		log.Printf("⚠️ Sound pack %s: %s: cannot load %s", pack, name, v.File)
+		return nil
+	}
+
+	if weapon, event, ok := splitWeaponSoundKey(name); ok {
+		// Weapon sounds only come from manifests, or the weapons file for the default pack
+		if s, found := game.WeaponSoundFor(weapon, event); found && pack == game.DefaultSoundPack {
+			v := SoundVariant{File: s.File, Volume: s.Volume, Pitch: s.Pitch}
+			if samples, err := decodeSound(filepath.Join(dir, v.File)); err == nil && len(samples) > 0 {
+				return applySoundVariant(samples, v)
+			}
+			log.Printf("⚠️ Weapon %s: %s sound: cannot load %s", weapon, event, s.File)
+		}
+		return nil
+	}
+	for _, ext := range soundExtensions {
+		samples, err := decodeSound(filepath.Join(dir, name+ext))
+		if err == nil {
+			return samples
+		}
+		if !os.IsNotExist(err) {
+			log.Printf("⚠️ Sound pack %s: %s%s: %v", pack, name, ext, err)
+		}
+	}
+	return nil
+}
+
+// dropWeaponSounds forgets cached weapon sounds, so weapons file changes are
+// heard. Caller holds am.mu.
+func (am *AudioAssetManager) dropWeaponSounds() {
+	for key, el := range am.cache {
+		_, name, _ := strings.Cut(key, ":")
+		if _, _, weapon := splitWeaponSoundKey(name); !weapon {
+			continue
+		}
+		sound := am.lru.Remove(el).(*cachedSound)
+		delete(am.cache, key)
+		am.bytes -= len(sound.data) * 2
+	}
+}
+
+// packDir returns a pack's directory
+func (am *AudioAssetManager) packDir(pack string) string {
+	if pack == game.DefaultSoundPack {
+		return am.dir
+	}
+	return filepath.Join(am.dir, soundPacksDir, pack)
+}
+
+// manifest returns a pack's parsed manifest, read once. Caller holds am.mu.
+func (am *AudioAssetManager) manifest(pack string) *SoundManifest {
+	if m, ok := am.manifests[pack]; ok {
+		return m
+	}
+	m, err := readSoundManifest(am.packDir(pack))
+	if err != nil && !os.IsNotExist(err) {
+		log.Printf("⚠️ Sound pack %s: %v", pack, err)
+	}
+	am.manifests[pack] = m
+	return m
+}
+
+// AudioAssetStats is the sound cache state (stream stats)
+type AudioAssetStats struct {
+	Pack        string   `json:"pack"`
+	Packs       []string `json:"packs"`
+	Sounds      int      `json:"sounds"` // Decoded sounds in memory
+	CachedBytes int      `json:"cachedBytes"`
+	MaxBytes    int      `json:"maxBytes"`
+	Hits        uint64   `json:"hits"`
+	Misses      uint64   `json:"misses"` // Sounds decoded (or found missing)
+	Evictions   uint64   `json:"evictions"`
+}
+
+// Stats returns the active pack and cache usage
+func (am *AudioAssetManager) Stats() AudioAssetStats {
+	packs := am.Packs()
+
+	am.mu.Lock()
+	defer am.mu.Unlock()
+	sounds := 0
+	for el := am.lru.Front(); el != nil; el = el.Next() {
+		if el.Value.(*cachedSound).data != nil {
+			sounds++
+		}
+	}
+	return AudioAssetStats{
+		Pack:        am.pack,
+		Packs:       packs,
+		Sounds:      sounds,
+		CachedBytes: am.bytes,
+		MaxBytes:    am.maxBytes,
+		Hits:        am.hits,
+		Misses:      am.misses,
+		Evictions:   am.evictions,
+	}
+}
+
+// decodeAudio decodes a WAV, OGG Vorbis or MP3 stream by file extension
+func decodeAudio(rc io.ReadCloser, path string) (beep.StreamSeekCloser, beep.Format, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".wav":
+		return wav.Decode(rc)
+	case ".ogg":
+		return vorbis.Decode(rc)
+	case ".mp3":
+		return mp3.Decode(rc)
+	default:
+		return nil, beep.Format{}, fmt.Errorf("unsupported audio format %q", filepath.Ext(path))
+	}
+}
+
+// decodeSound fully decodes a sound file to 44.1kHz s16 interleaved stereo
+// (mono files play on both channels, other sample rates are resampled)
+func decodeSound(path string) ([]int16, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	streamer, format, err := decodeAudio(file, path)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	defer streamer.Close()
+
+	const sampleRate = beep.SampleRate(44100) // Must match AudioMixer
+	var s beep.Streamer = streamer
+	if format.SampleRate != sampleRate {
+		s = beep.Resample(4, format.SampleRate, sampleRate, streamer)
+	}
+
+	samples := make([]int16, 0, streamer.Len()*2)
+	buf := make([][2]float64, 4096)
+	for {
+		n, ok := s.Stream(buf)
+		for _, frame := range buf[:n] {
+			samples = append(samples, clampSample(frame[0]*32767), clampSample(frame[1]*32767))
+		}
+		if !ok {
+			break
+		}
+	}
+	if err := streamer.Err(); err != nil {
+		return nil, err
+	}
+	return samples, nil
+}
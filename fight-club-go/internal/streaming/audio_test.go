@@ -0,0 +1,157 @@
+package streaming
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// channelEnergy sums absolute left/right sample values of s16le stereo audio
+func channelEnergy(pcm []byte) (left, right int64) {
+	for i := 0; i+3 < len(pcm); i += 4 {
+		l := int16(binary.LittleEndian.Uint16(pcm[i:]))
+		r := int16(binary.LittleEndian.Uint16(pcm[i+2:]))
+		if l < 0 {
+			l = -l
+		}
+		if r < 0 {
+			r = -r
+		}
+		left += int64(l)
+		right += int64(r)
+	}
+	return left, right
+}
+
+// TestQueueSoundAtPanning verifies events on the left of the arena are louder on the left channel
+func TestQueueSoundAtPanning(t *testing.T) {
+	dir := t.TempDir()
+	writeTestWAV(t, filepath.Join(dir, "kill.wav"), GenerateTone(440, 0.1, 44100))
+	m := NewAudioMixer(&AudioConfig{Sounds: SoundConfig{Dir: dir}})
+
+	m.QueueSoundAt("kill", "", ArenaPan(100, 1280))
+	left, right := channelEnergy(m.GenerateSamples(1000))
+	if left == 0 || right >= left/2 {
+		t.Errorf("Expected left-heavy audio for a kill at x=100, got L=%d R=%d", left, right)
+	}
+
+	m.GenerateSamples(44100) // Finish the first kill
+
+	m.QueueSoundAt("kill", "", ArenaPan(640, 1280))
+	left, right = channelEnergy(m.GenerateSamples(1000))
+	if left != right {
+		t.Errorf("Expected centered audio for a kill mid-arena, got L=%d R=%d", left, right)
+	}
+}
+
+// TestWeaponSoundManifest verifies weapon variants load with pitch and fall back to generic sounds
+func TestWeaponSoundManifest(t *testing.T) {
+	dir := t.TempDir()
+	tone := GenerateTone(440, 0.1, 44100) // 4410 stereo frames
+	writeTestWAV(t, filepath.Join(dir, "hit.wav"), tone)
+	writeTestWAV(t, filepath.Join(dir, "swing.wav"), tone)
+	os.WriteFile(filepath.Join(dir, soundManifestFile), []byte(`{"weapons": {
+		"hammer": {"hit": {"file": "hit.wav", "pitch": 0.5}, "swing": {"file": "missing.wav"}}
+	}}`), 0o644)
+
+	m := NewAudioMixer(&AudioConfig{Sounds: SoundConfig{Dir: dir}})
+	if got, _ := m.Assets().Sound("hammer/hit"); len(got) != len(tone)*2 {
+		t.Errorf("Expected half-pitch hammer hit to be twice as long (%d samples), got %d", len(tone)*2, len(got))
+	}
+	if _, ok := m.Assets().Sound("hammer/swing"); ok {
+		t.Error("Expected missing file to be skipped")
+	}
+
+	// Unlisted events use the generic sound
+	m.QueueSoundAt("swing", "hammer", 0)
+	if len(m.activeSounds) != 1 || len(m.activeSounds[0].data) != len(tone) {
+		t.Error("Expected hammer swing to fall back to the generic swing")
+	}
+}
+
+// TestSoundPacks verifies pack sounds override the default pack, missing ones
+// fall back to it, and weapon variants never leak across packs
+func TestSoundPacks(t *testing.T) {
+	dir := t.TempDir()
+	tone := GenerateTone(440, 0.1, 44100)
+	writeTestWAV(t, filepath.Join(dir, "hit.wav"), tone)
+	writeTestWAV(t, filepath.Join(dir, "kill.wav"), tone)
+	os.WriteFile(filepath.Join(dir, soundManifestFile), []byte(`{"weapons": {"axe": {"hit": {"file": "hit.wav", "pitch": 0.5}}}}`), 0o644)
+	retro := filepath.Join(dir, soundPacksDir, "retro")
+	os.MkdirAll(retro, 0o755)
+	writeTestWAV(t, filepath.Join(retro, "kill.wav"), GenerateTone(880, 0.05, 44100))
+	os.WriteFile(filepath.Join(retro, soundManifestFile), []byte(`{"sounds": {"hit": {"file": "hit.wav", "pitch": 2}}}`), 0o644)
+
+	am := NewAudioAssetManager(SoundConfig{Dir: dir, Pack: "retro"})
+	if packs := am.Packs(); len(packs) != 2 || packs[0] != "default" || packs[1] != "retro" {
+		t.Fatalf("Expected default and retro packs, got %v", packs)
+	}
+	if kill, _ := am.Sound("kill"); len(kill) != len(tone)/2 {
+		t.Errorf("Expected retro's own kill.wav, got %d samples", len(kill))
+	}
+	if hit, _ := am.Sound("hit"); len(hit) != len(tone)/2 {
+		t.Errorf("Expected retro's double-pitch variant of the default hit.wav, got %d samples", len(hit))
+	}
+	if _, ok := am.Sound("axe/hit"); ok {
+		t.Error("Expected the default pack's axe hit not to play in retro")
+	}
+	if _, ok := am.Sound("spawn"); ok {
+		t.Error("Expected a sound no pack has to be missing")
+	}
+
+	if err := am.SetPack("nope"); err == nil || am.Pack() != "retro" {
+		t.Error("Expected an unknown pack to be rejected")
+	}
+	am.SetPack("default")
+	if hit, _ := am.Sound("axe/hit"); len(hit) != len(tone)*2 {
+		t.Errorf("Expected the default pack's axe hit, got %d samples", len(hit))
+	}
+}
+
+// TestSoundCacheCap verifies decoded sounds are evicted least recently played first
+func TestSoundCacheCap(t *testing.T) {
+	dir := t.TempDir()
+	tone := GenerateTone(440, 8, 44100) // ~1.4 MB decoded
+	for _, name := range []string{"a", "b", "c"} {
+		writeTestWAV(t, filepath.Join(dir, name+".wav"), tone)
+	}
+
+	am := NewAudioAssetManager(SoundConfig{Dir: dir, CacheMB: 3})
+	am.Sound("a")
+	am.Sound("b")
+	am.Sound("a") // b is now least recently played
+	am.Sound("c")
+
+	stats := am.Stats()
+	if stats.Sounds != 2 || stats.Evictions != 1 || stats.CachedBytes > stats.MaxBytes {
+		t.Fatalf("Expected 2 sounds cached after 1 eviction within the cap, got %+v", stats)
+	}
+	am.Sound("a")
+	if am.Stats().Misses != 3 {
+		t.Error("Expected a to still be cached")
+	}
+}
+
+// writeTestWAV writes 44.1kHz s16 stereo samples as a PCM WAV file
+func writeTestWAV(t *testing.T, path string, samples []int16) {
+	data := make([]byte, 44+len(samples)*2)
+	copy(data, "RIFF")
+	binary.LittleEndian.PutUint32(data[4:], uint32(36+len(samples)*2))
+	copy(data[8:], "WAVEfmt ")
+	binary.LittleEndian.PutUint32(data[16:], 16)
+	binary.LittleEndian.PutUint16(data[20:], 1) // PCM
+	binary.LittleEndian.PutUint16(data[22:], 2) // Stereo
+	binary.LittleEndian.PutUint32(data[24:], 44100)
+	binary.LittleEndian.PutUint32(data[28:], 44100*4)
+	binary.LittleEndian.PutUint16(data[32:], 4)
+	binary.LittleEndian.PutUint16(data[34:], 16)
+	copy(data[36:], "data")
+	binary.LittleEndian.PutUint32(data[40:], uint32(len(samples)*2))
+	for i, s := range samples {
+		binary.LittleEndian.PutUint16(data[44+i*2:], uint16(s))
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
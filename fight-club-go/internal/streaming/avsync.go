@@ -0,0 +1,103 @@
+package streaming
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// avLeadFrames lets audio run slightly ahead of video so FFmpeg never waits on the audio pipe
+	avLeadFrames = 2
+
+	// avMaxChunkFrames caps how much mixed audio is generated per audio tick (bounded catch-up)
+	avMaxChunkFrames = 4
+
+	// avResyncThreshold is the audio backlog beyond which the gap is filled with
+	// silence instead of mixing (late sound effects would be out of sync anyway)
+	avResyncThreshold = time.Second
+)
+
+// AVClock keeps generated audio locked to the video presentation timestamps (PTS).
+//
+// FFmpeg timestamps raw video by frame count (-r FPS) and raw PCM by sample count,
+// so an audio loop paced by its own wall-clock ticker drifts whenever video frames
+// are late or dropped. Instead the audio loop asks the clock how many samples are
+// due for the frames actually written, computed from the running totals so
+// fractional samples per frame (44100/24 = 1837.5) never accumulate error.
+type AVClock struct {
+	sampleRate int64
+	fps        int64
+
+	videoFrames  int64 // atomic - frames written to FFmpeg (video PTS)
+	audioSamples int64 // atomic - samples written to FFmpeg (audio PTS)
+	resyncs      int64 // atomic - backlogs skipped with silence
+}
+
+// NewAVClock creates an A/V clock for the given audio sample rate and video FPS
+func NewAVClock(sampleRate, fps int) *AVClock {
+	return &AVClock{sampleRate: int64(sampleRate), fps: int64(fps)}
+}
+
+// Reset zeroes both timestamps (new FFmpeg process)
+func (c *AVClock) Reset() {
+	atomic.StoreInt64(&c.videoFrames, 0)
+	atomic.StoreInt64(&c.audioSamples, 0)
+	atomic.StoreInt64(&c.resyncs, 0)
+}
+
+// VideoFrameWritten advances the video PTS by one frame
+func (c *AVClock) VideoFrameWritten() {
+	atomic.AddInt64(&c.videoFrames, 1)
+}
+
+// AudioWritten advances the audio PTS by n samples (per channel)
+func (c *AVClock) AudioWritten(n int) {
+	atomic.AddInt64(&c.audioSamples, int64(n))
+}
+
+// samplesAt returns the audio PTS (in samples) matching a video frame count
+func (c *AVClock) samplesAt(frames int64) int64 {
+	return frames * c.sampleRate / c.fps
+}
+
+// Due returns how many samples the audio loop should write now to match the
+// video PTS plus lead: silence first to skip a large backlog (resync), then
+// mixed audio (bounded per call). Both are 0 while audio is ahead of video.
+func (c *AVClock) Due() (silence, mixed int) {
+	frames := atomic.LoadInt64(&c.videoFrames)
+	written := atomic.LoadInt64(&c.audioSamples)
+
+	due := c.samplesAt(frames+avLeadFrames) - written
+	if due <= 0 {
+		return 0, 0
+	}
+
+	maxChunk := c.samplesAt(avMaxChunkFrames)
+	if threshold := int64(avResyncThreshold.Seconds() * float64(c.sampleRate)); due > threshold {
+		silence = int(due - maxChunk)
+		due = maxChunk
+		atomic.AddInt64(&c.resyncs, 1)
+	}
+	if due > maxChunk {
+		due = maxChunk
+	}
+	return silence, int(due)
+}
+
+// Offset returns audio PTS minus video PTS (positive = audio ahead)
+func (c *AVClock) Offset() time.Duration {
+	frames := atomic.LoadInt64(&c.videoFrames)
+	samples := atomic.LoadInt64(&c.audioSamples)
+	return time.Duration(samples-c.samplesAt(frames)) * time.Second / time.Duration(c.sampleRate)
+}
+
+// Stats returns A/V sync statistics for stream stats
+func (c *AVClock) Stats() map[string]interface{} {
+	return map[string]interface{}{
+		"offsetMs":     float64(c.Offset().Microseconds()) / 1000,
+		"targetMs":     float64(avLeadFrames*1000) / float64(c.fps), // Steady-state offset (audio lead)
+		"videoFrames":  atomic.LoadInt64(&c.videoFrames),
+		"audioSamples": atomic.LoadInt64(&c.audioSamples),
+		"resyncs":      atomic.LoadInt64(&c.resyncs),
+	}
+}
@@ -0,0 +1,77 @@
+package streaming
+
+import (
+	"testing"
+	"time"
+)
+
+// drainAudio writes everything the clock says is due, like audioLoop does
+func drainAudio(c *AVClock) {
+	for {
+		silence, mixed := c.Due()
+		if silence == 0 && mixed == 0 {
+			return
+		}
+		c.AudioWritten(silence + mixed)
+	}
+}
+
+// TestAVClockNoDrift verifies audio follows video frames exactly, even with
+// fractional samples per frame (24 FPS) and frames missing for wall-clock stretches
+func TestAVClockNoDrift(t *testing.T) {
+	c := NewAVClock(44100, 24)
+
+	// One hour of video delivered in irregular bursts (late/dropped ticks)
+	for frame := 0; frame < 24*3600; frame++ {
+		c.VideoFrameWritten()
+		if frame%7 != 3 { // Audio loop misses some ticks
+			drainAudio(c)
+		}
+	}
+	drainAudio(c)
+
+	lead := time.Duration(avLeadFrames) * time.Second / 24
+	if got := c.Offset(); got != lead {
+		t.Errorf("Expected audio exactly %v ahead after an hour, got %v", lead, got)
+	}
+	if got := c.Stats()["resyncs"]; got != int64(0) {
+		t.Errorf("Expected no resyncs for a healthy stream, got %v", got)
+	}
+}
+
+// TestAVClockWaitsAndResyncs verifies audio waits for stalled video and skips large backlogs
+func TestAVClockWaitsAndResyncs(t *testing.T) {
+	c := NewAVClock(44100, 30)
+	drainAudio(c)
+
+	// Video stalled: nothing more is due
+	if silence, mixed := c.Due(); silence != 0 || mixed != 0 {
+		t.Errorf("Expected audio to wait for video, got silence=%d mixed=%d", silence, mixed)
+	}
+
+	// Audio pipe stalled for 3 seconds of video: backlog becomes silence
+	for i := 0; i < 90; i++ {
+		c.VideoFrameWritten()
+	}
+	silence, mixed := c.Due()
+	if silence == 0 || mixed != 44100/30*avMaxChunkFrames {
+		t.Errorf("Expected silence resync plus one bounded chunk, got silence=%d mixed=%d", silence, mixed)
+	}
+	c.AudioWritten(silence + mixed)
+	if got := c.Offset(); got != time.Duration(avLeadFrames)*time.Second/30 {
+		t.Errorf("Expected offset back at lead after resync, got %v", got)
+	}
+}
+
+// TestGenerateSamplesSize verifies the mixer produces exactly the requested samples
+func TestGenerateSamplesSize(t *testing.T) {
+	m := NewAudioMixer(nil)
+	for _, n := range []int{1837, 1838, 44100 / 30} {
+		if got := len(m.GenerateSamples(n)); got != n*4 {
+			t.Errorf("GenerateSamples(%d): expected %d bytes, got %d", n, n*4, got)
+		}
+	}
+	if got := len(m.GenerateFrame()); got != 5880 {
+		t.Errorf("Expected 5880 byte frame, got %d", got)
+	}
+}
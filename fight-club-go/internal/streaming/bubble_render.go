@@ -0,0 +1,263 @@
+package streaming
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"sort"
+
+	"fight-club/internal/game"
+
+	"github.com/fogleman/gg"
+)
+
+// Chat bubble geometry (pixels). The tail points at the gap between the
+// emote area and the health bar (bar top is 50px above the player center).
+const (
+	chatBubbleMaxWidth = 180.0
+	chatBubblePadding  = 6.0
+	chatBubbleRadius   = 8.0
+	chatBubbleTail     = 8.0
+	chatBubbleTipY     = 54.0 // Tail tip, above the player center
+	chatBubbleGap      = 4.0  // Space kept around other bubbles and health bars
+)
+
+var (
+	chatBubbleFill   = color.NRGBA{255, 255, 255, 240}
+	chatBubbleBorder = color.NRGBA{20, 25, 35, 255}
+)
+
+// chatBubble is a laid-out bubble: the box (top-left x, y) and the tail tip
+// on its player
+type chatBubble struct {
+	text       string
+	lines      []string
+	lineHeight float64
+	x, y, w, h float64
+	tipX, tipY float64
+	alpha      float64 // Fades out over the last second of the TTL
+}
+
+// bubbleRect is an axis-aligned area bubbles keep clear of
+type bubbleRect struct {
+	x, y, w, h float64
+}
+
+func (r bubbleRect) overlaps(o bubbleRect) bool {
+	return r.x < o.x+o.w+chatBubbleGap && o.x < r.x+r.w+chatBubbleGap &&
+		r.y < o.y+o.h+chatBubbleGap && o.y < r.y+r.h+chatBubbleGap
+}
+
+// healthBarRect is the health + stamina bar area of a player (see drawPlayerSnapshot)
+func healthBarRect(p *game.PlayerSnapshot) bubbleRect {
+	return bubbleRect{x: p.X - 40, y: p.Y - 50, w: 80, h: 10 + 2 + staminaBarHeight}
+}
+
+// wrapBubbleText word-wraps text to maxWidth with the font set on dc,
+// breaking words that don't fit on a line of their own
+func wrapBubbleText(dc *gg.Context, text string, maxWidth float64) []string {
+	var lines []string
+	for _, line := range dc.WordWrap(text, maxWidth) {
+		for {
+			if w, _ := dc.MeasureString(line); w <= maxWidth {
+				lines = append(lines, line)
+				break
+			}
+			runes := []rune(line)
+			cut := len(runes) - 1
+			for cut > 1 {
+				if w, _ := dc.MeasureString(string(runes[:cut])); w <= maxWidth {
+					break
+				}
+				cut--
+			}
+			lines = append(lines, string(runes[:cut]))
+			line = string(runes[cut:])
+		}
+	}
+	return lines
+}
+
+// layoutChatBubbles places the bubbles of the visible players above their
+// health bars, measured with the font set on dc. Bubbles that would cover
+// another bubble or any health bar are pushed up until they fit; the tail
+// still points at their player.
+func layoutChatBubbles(dc *gg.Context, players []game.PlayerSnapshot, width, height float64) []chatBubble {
+	var bubbles []chatBubble
+	var bars []bubbleRect
+	lineHeight := dc.FontHeight() * 1.3
+	for i := range players {
+		p := &players[i]
+		if p.IsDead || p.IsRagdoll {
+			continue
+		}
+		bars = append(bars, healthBarRect(p))
+		if p.ChatBubble == "" {
+			continue
+		}
+
+		lines := wrapBubbleText(dc, p.ChatBubble, chatBubbleMaxWidth-2*chatBubblePadding)
+		textWidth := 0.0
+		for _, line := range lines {
+			w, _ := dc.MeasureString(line)
+			textWidth = math.Max(textWidth, w)
+		}
+		b := chatBubble{
+			text:       p.ChatBubble,
+			lines:      lines,
+			lineHeight: lineHeight,
+			w:          math.Ceil(textWidth + 2*chatBubblePadding),
+			h:          math.Ceil(float64(len(lines))*lineHeight + 2*chatBubblePadding),
+			tipX:       p.X,
+			tipY:       p.Y - chatBubbleTipY,
+			alpha:      math.Min(p.ChatBubbleTTL, 1),
+		}
+		b.y = b.tipY - chatBubbleTail - b.h
+		bubbles = append(bubbles, b)
+	}
+
+	// Lowest bubbles first: they sit closest to their players, so bubbles
+	// higher up make room rather than the other way around
+	sort.SliceStable(bubbles, func(i, j int) bool { return bubbles[i].tipY > bubbles[j].tipY })
+
+	placed := make([]bubbleRect, 0, len(bubbles))
+	for i := range bubbles {
+		b := &bubbles[i]
+		// Centered over the player, or leaning left or right of the tail;
+		// whichever has to move up the least
+		best := bubbleRect{y: math.Inf(-1)}
+		for _, x := range []float64{b.tipX - b.w/2, b.tipX + 16 - b.w, b.tipX - 16} {
+			box := bubbleRect{x: math.Max(2, math.Min(x, width-b.w-2)), y: b.y, w: b.w, h: b.h}
+			box.y = raiseBubble(box, bars, placed)
+			if box.y > best.y {
+				best = box
+			}
+		}
+		b.x, b.y = best.x, math.Max(0, math.Min(best.y, height-b.h))
+		placed = append(placed, bubbleRect{x: b.x, y: b.y, w: b.w, h: b.h})
+	}
+	return bubbles
+}
+
+// raiseBubble returns the lowest y at or above box.y where box covers none
+// of the obstacles. Boxes only ever move up, so each obstacle is passed at
+// most once.
+func raiseBubble(box bubbleRect, obstacles ...[]bubbleRect) float64 {
+	for moved := true; moved; {
+		moved = false
+		for _, rects := range obstacles {
+			for _, o := range rects {
+				if box.overlaps(o) {
+					box.y = o.y - chatBubbleGap - box.h - 1
+					moved = true
+				}
+			}
+		}
+	}
+	return box.y
+}
+
+// tailBase returns the tail's base center on the bubble's bottom edge
+func (b *chatBubble) tailBase() (x, y float64) {
+	return math.Max(b.x+chatBubbleRadius+6, math.Min(b.tipX, b.x+b.w-chatBubbleRadius-6)), b.y + b.h
+}
+
+// colors returns the fill and border (and text) colors faded by the TTL
+func (b *chatBubble) colors() (fill, border color.NRGBA) {
+	fill, border = chatBubbleFill, chatBubbleBorder
+	fill.A = uint8(float64(fill.A) * b.alpha)
+	border.A = uint8(float64(border.A) * b.alpha)
+	return fill, border
+}
+
+// drawChatBubbleBox draws the rounded box and text of a bubble at (x, y)
+// with the font already set on dc
+func drawChatBubbleBox(dc *gg.Context, b *chatBubble, x, y float64, fill, border color.NRGBA) {
+	dc.DrawRoundedRectangle(x+1, y+1, b.w-2, b.h-2, chatBubbleRadius)
+	dc.SetColor(fill)
+	dc.FillPreserve()
+	dc.SetColor(border)
+	dc.SetLineWidth(2)
+	dc.Stroke()
+
+	for i, line := range b.lines {
+		dc.DrawStringAnchored(line, x+b.w/2, y+chatBubblePadding+(float64(i)+0.5)*b.lineHeight, 0.5, 0.5)
+	}
+}
+
+// drawChatBubbles draws every chat bubble on top of the players
+func (s *StreamManager) drawChatBubbles(dc *gg.Context, players []game.PlayerSnapshot) {
+	if s.fontsLoaded && s.fontSmall != nil {
+		dc.SetFontFace(s.fontSmall)
+	} else if err := s.loadFontFace(dc, 16); err != nil {
+		return
+	}
+
+	bubbles := layoutChatBubbles(dc, players, float64(s.config.Width), float64(s.config.Height))
+	for i := range bubbles {
+		b := &bubbles[i]
+		fill, border := b.colors()
+
+		// Tail first so the box border covers its base
+		baseX, baseY := b.tailBase()
+		dc.MoveTo(baseX-6, baseY-2)
+		dc.LineTo(b.tipX, b.tipY)
+		dc.LineTo(baseX+6, baseY-2)
+		dc.SetColor(fill)
+		dc.FillPreserve()
+		dc.SetColor(border)
+		dc.SetLineWidth(2)
+		dc.Stroke()
+
+		drawChatBubbleBox(dc, b, b.x, b.y, fill, border)
+	}
+}
+
+// drawChatBubbles draws the chat bubbles with cached box sprites and a
+// rasterized tail (mirrors StreamManager.drawChatBubbles)
+func (a *AtlasRenderer) drawChatBubbles(buffer []byte, players []game.PlayerSnapshot) {
+	if a.bubbleMeasure == nil {
+		a.bubbleMeasure = gg.NewContext(1, 1)
+		a.bubbleMeasure.SetFontFace(a.s.fontSmall)
+	}
+	bubbles := layoutChatBubbles(a.bubbleMeasure, players, float64(a.width), float64(a.height))
+	for i := range bubbles {
+		b := &bubbles[i]
+
+		// Tail: one row at a time from the box edge to the tip (FastRenderer
+		// blends straight alpha)
+		nfill, nborder := b.colors()
+		fill, border := color.RGBA(nfill), color.RGBA(nborder)
+		baseX, baseY := b.tailBase()
+		rows := b.tipY - baseY
+		for row := 0.0; row < rows; row++ {
+			t := row / rows
+			cx := baseX + (b.tipX-baseX)*t
+			half := 6 * (1 - t)
+			y := int(baseY + row)
+			a.fr.DrawFilledRectBlend(int(cx-half)-1, y, 2, 1, border)
+			a.fr.DrawFilledRectBlend(int(cx-half)+1, y, int(2*half)-2, 1, fill)
+			a.fr.DrawFilledRectBlend(int(cx+half)-1, y, 2, 1, border)
+		}
+
+		a.blit(buffer, a.bubble(b), b.x+b.w/2, b.y+b.h/2, uint8(b.alpha*255))
+	}
+}
+
+// bubble returns the cached box sprite for a laid-out bubble
+func (a *AtlasRenderer) bubble(b *chatBubble) *sprite {
+	if sp, ok := a.bubbles[b.text]; ok {
+		return sp
+	}
+	if a.bubbles == nil || len(a.bubbles) >= maxAtlasLabels {
+		a.bubbles = make(map[string]*sprite)
+	}
+
+	dc := gg.NewContext(int(b.w), int(b.h))
+	dc.SetFontFace(a.s.fontSmall)
+	drawChatBubbleBox(dc, b, 0, 0, chatBubbleFill, chatBubbleBorder)
+
+	sp := newSprite(dc.Image().(*image.RGBA))
+	a.bubbles[b.text] = sp
+	return sp
+}
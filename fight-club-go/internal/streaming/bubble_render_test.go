@@ -0,0 +1,129 @@
+package streaming
+
+import (
+	"image/color"
+	"strings"
+	"testing"
+
+	"fight-club/internal/game"
+
+	"github.com/fogleman/gg"
+)
+
+// TestWrapBubbleText verifies text wraps by word and long words are broken to fit
+func TestWrapBubbleText(t *testing.T) {
+	dc := gg.NewContext(1, 1)
+	lines := wrapBubbleText(dc, "gg ez "+strings.Repeat("w", 40), 100)
+	if len(lines) < 3 || lines[0] != "gg ez" {
+		t.Fatalf("Expected the words on one line and the long word broken up, got %q", lines)
+	}
+	for _, line := range lines {
+		if w, _ := dc.MeasureString(line); w > 100 {
+			t.Errorf("Line %q is %.0fpx wide, over the 100px limit", line, w)
+		}
+	}
+	if strings.Join(lines[1:], "") != strings.Repeat("w", 40) {
+		t.Errorf("Expected no characters lost, got %q", lines)
+	}
+}
+
+// TestLayoutChatBubblesAvoidsOverlap verifies crowded bubbles cover neither
+// each other nor any health bar, and keep pointing at their players
+func TestLayoutChatBubblesAvoidsOverlap(t *testing.T) {
+	players := []game.PlayerSnapshot{
+		{Name: "a", X: 200, Y: 300, ChatBubble: "first!", ChatBubbleTTL: 5},
+		{Name: "b", X: 230, Y: 305, ChatBubble: "no you are the one who is going down today", ChatBubbleTTL: 0.5},
+		{Name: "c", X: 215, Y: 240}, // Quiet player whose health bar sits right above a and b
+		{Name: "d", X: 215, Y: 200, IsDead: true, ChatBubble: "ghost"},
+		{Name: "e", X: 5, Y: 500, ChatBubble: "edge", ChatBubbleTTL: 5},
+	}
+
+	bubbles := layoutChatBubbles(gg.NewContext(1, 1), players, 800, 600)
+	if len(bubbles) != 3 {
+		t.Fatalf("Expected 3 bubbles (dead players have none), got %d", len(bubbles))
+	}
+
+	var bars []bubbleRect
+	for i := range players {
+		if !players[i].IsDead {
+			bars = append(bars, healthBarRect(&players[i]))
+		}
+	}
+	for i, b := range bubbles {
+		box := bubbleRect{x: b.x, y: b.y, w: b.w, h: b.h}
+		if box.x < 0 || box.x+box.w > 800 || b.w > chatBubbleMaxWidth {
+			t.Errorf("Bubble %q is outside the frame or too wide: %+v", b.text, box)
+		}
+		for _, bar := range bars {
+			if box.overlaps(bar) {
+				t.Errorf("Bubble %q covers a health bar at %+v", b.text, bar)
+			}
+		}
+		for j := i + 1; j < len(bubbles); j++ {
+			other := bubbleRect{x: bubbles[j].x, y: bubbles[j].y, w: bubbles[j].w, h: bubbles[j].h}
+			if box.overlaps(other) {
+				t.Errorf("Bubbles %q and %q overlap", b.text, bubbles[j].text)
+			}
+		}
+
+		switch b.text {
+		case "first!":
+			if b.tipX != 200 || b.tipY != 300-chatBubbleTipY || b.alpha != 1 {
+				t.Errorf("Expected a's tail on a at full opacity, got tip (%.0f, %.0f) alpha %.2f", b.tipX, b.tipY, b.alpha)
+			}
+		case "edge":
+			if b.y != 500-chatBubbleTipY-chatBubbleTail-b.h {
+				t.Errorf("Expected an uncrowded bubble right above its player, got y=%.0f", b.y)
+			}
+		default:
+			if len(b.lines) < 2 || b.alpha != 0.5 {
+				t.Errorf("Expected b's long bubble wrapped and fading, got %d lines alpha %.2f", len(b.lines), b.alpha)
+			}
+		}
+	}
+}
+
+// TestChatBubbleRender verifies both backends draw the bubble and its tail where the layout put them
+func TestChatBubbleRender(t *testing.T) {
+	players := []game.PlayerSnapshot{{Name: "talker", X: 200, Y: 200, HP: 100, MaxHP: 100, ChatBubble: "hello chat", ChatBubbleTTL: 5}}
+
+	check := func(t *testing.T, sm *StreamManager, at func(x, y int) color.RGBA) {
+		dc := gg.NewContext(1, 1)
+		if sm.fontSmall != nil {
+			dc.SetFontFace(sm.fontSmall)
+		}
+		b := layoutChatBubbles(dc, players, 400, 400)[0]
+		if c := at(int(b.x+b.w/2), int(b.y+1)); c.R > 100 {
+			t.Errorf("Expected the dark bubble border on the top edge, got %v", c)
+		}
+		if c := at(int(b.x+b.w/2), int(b.y+b.h+chatBubbleTail/2)); c.R > 90 && c.R < 170 {
+			t.Errorf("Expected the tail under the bubble, got %v", c)
+		}
+	}
+
+	t.Run("gg", func(t *testing.T) {
+		sm := &StreamManager{config: StreamConfig{Width: 400, Height: 400}}
+		sm.loadFonts()
+		dc := gg.NewContext(400, 400)
+		dc.SetColor(color.RGBA{128, 128, 128, 255})
+		dc.Clear()
+		sm.drawChatBubbles(dc, players)
+		check(t, sm, func(x, y int) color.RGBA {
+			return color.RGBAModel.Convert(dc.Image().At(x, y)).(color.RGBA)
+		})
+	})
+
+	t.Run("atlas", func(t *testing.T) {
+		sm := newAtlasTestManager(t, 400, 400)
+		buffer := make([]byte, 400*400*4)
+		for i := range buffer {
+			buffer[i] = 128
+		}
+		sm.atlas.fr.SetBuffer(buffer)
+		sm.atlas.drawChatBubbles(buffer, players)
+		check(t, sm, func(x, y int) color.RGBA {
+			i := (y*400 + x) * 4
+			return color.RGBA{buffer[i], buffer[i+1], buffer[i+2], buffer[i+3]}
+		})
+	})
+}
@@ -0,0 +1,201 @@
+package streaming
+
+import (
+	"math"
+	"runtime"
+	"sync"
+	"time"
+
+	"fight-club/internal/game"
+
+	"github.com/fogleman/gg"
+)
+
+// Auto-framing defaults (ThemeConfig.AutoFrame*)
+const (
+	DefaultAutoFrameMaxPlayers = 3
+	DefaultAutoFrameMaxZoom    = 2.0
+)
+
+// Auto-framing camera motion
+const (
+	autoFrameMargin = 120.0 // World px kept around the fighters (names, weapons, swings)
+	autoFrameEase   = 0.6   // Seconds to close ~63% of the distance to the target framing
+	autoFrameMinDt  = 1.0   // Longer frame gaps (stalls) jump straight to the target
+	autoFrameZoomed = 1.001 // Zoom below this renders untransformed
+)
+
+// cameraView maps world coordinates to the frame: the world point (cx, cy)
+// lands on the frame center, scaled by zoom (>= 1)
+type cameraView struct {
+	zoom         float64
+	cx, cy       float64
+	halfW, halfH float64
+}
+
+// zoomed reports whether the view differs from drawing the world as is
+func (v cameraView) zoomed() bool {
+	return v.zoom >= autoFrameZoomed
+}
+
+// apply converts a world position to frame pixels
+func (v cameraView) apply(x, y float64) (float64, float64) {
+	return (x-v.cx)*v.zoom + v.halfW, (y-v.cy)*v.zoom + v.halfH
+}
+
+// transform applies the view to a gg context (world drawing follows)
+func (v cameraView) transform(dc *gg.Context) {
+	dc.Translate(v.halfW-v.cx*v.zoom, v.halfH-v.cy*v.zoom)
+	dc.Scale(v.zoom, v.zoom)
+}
+
+// duel maps the duel ring into frame pixels, for the banner drawn above it
+func (v cameraView) duel(d game.DuelSnapshot) game.DuelSnapshot {
+	d.X, d.Y = v.apply(d.X, d.Y)
+	d.Radius *= v.zoom
+	return d
+}
+
+// autoFramer is the auto-framing camera: with few fighters alive it eases
+// towards a zoom and offset that fill the frame with their bounding box
+// (snapshot Framing), and back out to the whole arena when more join.
+// Updated and read by the render loop only.
+type autoFramer struct {
+	maxPlayers    int
+	maxZoom       float64
+	width, height float64
+
+	zoom, cx, cy float64
+	last         time.Time
+}
+
+// newAutoFramer creates the camera for a frame size (nil when auto-framing is off)
+func newAutoFramer(theme ThemeConfig, width, height int) *autoFramer {
+	if !theme.AutoFrame {
+		return nil
+	}
+	f := &autoFramer{
+		maxPlayers: theme.AutoFrameMaxPlayers,
+		maxZoom:    theme.AutoFrameMaxZoom,
+		width:      float64(width),
+		height:     float64(height),
+		zoom:       1,
+		cx:         float64(width) / 2,
+		cy:         float64(height) / 2,
+	}
+	if f.maxPlayers <= 0 {
+		f.maxPlayers = DefaultAutoFrameMaxPlayers
+	}
+	if f.maxZoom < 1 {
+		f.maxZoom = DefaultAutoFrameMaxZoom
+	}
+	return f
+}
+
+// target returns the zoom and center framing the fighters (the whole arena
+// when nobody or too many are alive)
+func (f *autoFramer) target(framing game.FramingSnapshot) (zoom, cx, cy float64) {
+	if framing.Count == 0 || framing.Count > f.maxPlayers {
+		return 1, f.width / 2, f.height / 2
+	}
+	w := framing.MaxX - framing.MinX + 2*autoFrameMargin
+	h := framing.MaxY - framing.MinY + 2*autoFrameMargin
+	zoom = math.Max(1, math.Min(f.maxZoom, math.Min(f.width/w, f.height/h)))
+	return zoom, (framing.MinX + framing.MaxX) / 2, (framing.MinY + framing.MaxY) / 2
+}
+
+// Update eases the camera towards the framing of a snapshot
+func (f *autoFramer) Update(framing game.FramingSnapshot, now time.Time) {
+	if f == nil {
+		return
+	}
+	zoom, cx, cy := f.target(framing)
+
+	k := 0.0 // First frame: start from the whole arena
+	if !f.last.IsZero() {
+		dt := now.Sub(f.last).Seconds()
+		k = 1 - math.Exp(-dt/autoFrameEase)
+		if dt >= autoFrameMinDt {
+			k = 1
+		}
+	}
+	f.last = now
+
+	f.zoom += (zoom - f.zoom) * k
+	f.cx += (cx - f.cx) * k
+	f.cy += (cy - f.cy) * k
+}
+
+// View returns the current camera. The center is kept far enough from the
+// arena edges that the zoomed frame never shows past them.
+func (f *autoFramer) View() cameraView {
+	if f == nil {
+		return cameraView{zoom: 1}
+	}
+	halfW, halfH := f.width/2, f.height/2
+	visW, visH := halfW/f.zoom, halfH/f.zoom
+	return cameraView{
+		zoom:  f.zoom,
+		cx:    math.Max(visW, math.Min(f.width-visW, f.cx)),
+		cy:    math.Max(visH, math.Min(f.height-visH, f.cy)),
+		halfW: halfW,
+		halfH: halfH,
+	}
+}
+
+// zoomFrame writes the view of an RGBA world frame into dst with bilinear
+// filtering (atlas renderer: its sprites can't be drawn scaled), splitting
+// rows across CPUs
+func zoomFrame(src, dst []byte, width, height int, v cameraView) {
+	// Source columns are the same for every row
+	cols := make([]zoomTap, width)
+	for x := range cols {
+		cols[x] = newZoomTap(float64(x), v.halfW, v.cx, v.zoom, width)
+	}
+
+	workers := min(runtime.NumCPU(), 8)
+	rowsPer := (height + workers - 1) / workers
+
+	var wg sync.WaitGroup
+	for start := 0; start < height; start += rowsPer {
+		end := min(start+rowsPer, height)
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			zoomRows(src, dst, width, height, v, cols, start, end)
+		}(start, end)
+	}
+	wg.Wait()
+}
+
+// zoomTap is the pair of source pixels an output coordinate blends
+type zoomTap struct {
+	lo, hi int
+	weight uint32 // Weight of hi (0-256)
+}
+
+// newZoomTap maps an output coordinate through the view (center c, frame half size half)
+func newZoomTap(out, half, c, zoom float64, size int) zoomTap {
+	pos := math.Max(0, math.Min(float64(size-1), (out+0.5-half)/zoom+c-0.5))
+	lo := int(pos)
+	return zoomTap{lo: lo, hi: min(lo+1, size-1), weight: uint32((pos - float64(lo)) * 256)}
+}
+
+func zoomRows(src, dst []byte, width, height int, v cameraView, cols []zoomTap, start, end int) {
+	stride := width * 4
+	for y := start; y < end; y++ {
+		row := newZoomTap(float64(y), v.halfH, v.cy, v.zoom, height)
+		top, bottom, wy := row.lo*stride, row.hi*stride, row.weight
+		out := y * stride
+
+		for _, col := range cols {
+			left, right, wx := col.lo*4, col.hi*4, col.weight
+			for c := 0; c < 4; c++ {
+				t := uint32(src[top+left+c])*(256-wx) + uint32(src[top+right+c])*wx
+				b := uint32(src[bottom+left+c])*(256-wx) + uint32(src[bottom+right+c])*wx
+				dst[out+c] = byte((t*(256-wy) + b*wy) >> 16)
+			}
+			out += 4
+		}
+	}
+}
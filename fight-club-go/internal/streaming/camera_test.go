@@ -0,0 +1,79 @@
+package streaming
+
+import (
+	"image/color"
+	"math"
+	"testing"
+	"time"
+
+	"fight-club/internal/game"
+)
+
+// TestAutoFramerTarget verifies the camera zooms on few fighters and stays inside the arena
+func TestAutoFramerTarget(t *testing.T) {
+	if newAutoFramer(ThemeConfig{}, 1280, 720) != nil {
+		t.Fatal("Expected no camera with auto-framing off")
+	}
+	f := newAutoFramer(ThemeConfig{AutoFrame: true}, 1280, 720)
+
+	duel := game.FramingSnapshot{Count: 2, MinX: 100, MinY: 100, MaxX: 300, MaxY: 200}
+	zoom, cx, cy := f.target(duel)
+	if zoom != DefaultAutoFrameMaxZoom || cx != 200 || cy != 150 {
+		t.Errorf("Expected a %.0fx zoom on (200, 150), got %.2fx on (%.0f, %.0f)", DefaultAutoFrameMaxZoom, zoom, cx, cy)
+	}
+	if zoom, _, _ := f.target(game.FramingSnapshot{Count: 3, MinX: 0, MaxX: 1280, MaxY: 720}); zoom != 1 {
+		t.Errorf("Expected no zoom when the fighters span the arena, got %.2fx", zoom)
+	}
+	if zoom, cx, _ := f.target(game.FramingSnapshot{Count: 4, MinX: 100, MaxX: 200}); zoom != 1 || cx != 640 {
+		t.Errorf("Expected the whole arena with more fighters than the limit, got %.2fx on %.0f", zoom, cx)
+	}
+
+	// Eases in from the whole arena, then settles on the target
+	now := time.Now()
+	f.Update(duel, now)
+	if v := f.View(); v.zoomed() {
+		t.Errorf("Expected the first frame to show the whole arena, got %.2fx", v.zoom)
+	}
+	f.Update(duel, now.Add(100*time.Millisecond))
+	if v := f.View(); v.zoom <= 1 || v.zoom >= DefaultAutoFrameMaxZoom {
+		t.Errorf("Expected the camera partway in after 100ms, got %.2fx", v.zoom)
+	}
+	for i := 2; i <= 90; i++ {
+		f.Update(duel, now.Add(time.Duration(i)*100*time.Millisecond))
+	}
+	v := f.View()
+	if math.Abs(v.zoom-DefaultAutoFrameMaxZoom) > 0.01 {
+		t.Errorf("Expected the camera settled at %.0fx, got %.2fx", DefaultAutoFrameMaxZoom, v.zoom)
+	}
+	// The box sits in the corner: the view is clamped to the arena edge
+	if math.Abs(v.cx-320) > 1 || math.Abs(v.cy-180) > 1 {
+		t.Errorf("Expected the center clamped to (320, 180), got (%.0f, %.0f)", v.cx, v.cy)
+	}
+	if x, y := v.apply(0, 0); math.Abs(x) > 1 || math.Abs(y) > 1 {
+		t.Errorf("Expected the arena corner at the frame corner, got (%.0f, %.0f)", x, y)
+	}
+}
+
+// TestZoomFrame verifies the atlas zoom magnifies the viewed region
+func TestZoomFrame(t *testing.T) {
+	const w, h = 64, 36
+	src := make([]byte, w*h*4)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			c := color.RGBA{0, 0, 255, 255} // Blue, red top-left quarter
+			if x < w/2 && y < h/2 {
+				c = color.RGBA{255, 0, 0, 255}
+			}
+			i := (y*w + x) * 4
+			src[i], src[i+1], src[i+2], src[i+3] = c.R, c.G, c.B, c.A
+		}
+	}
+
+	dst := make([]byte, len(src))
+	zoomFrame(src, dst, w, h, cameraView{zoom: 2, cx: w / 4, cy: h / 4, halfW: w / 2, halfH: h / 2})
+	for _, p := range [][2]int{{2, 2}, {w - 3, h - 3}, {w / 2, h / 2}} {
+		if i := (p[1]*w + p[0]) * 4; dst[i] != 255 || dst[i+2] != 0 {
+			t.Errorf("Expected the red quarter to fill the frame at %v, got %v", p, dst[i:i+4])
+		}
+	}
+}
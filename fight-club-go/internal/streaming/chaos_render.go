@@ -0,0 +1,180 @@
+package streaming
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"strings"
+
+	"fight-club/internal/game"
+
+	"github.com/fogleman/gg"
+)
+
+// Chaos event colors
+var (
+	chaosAccent     = color.RGBA{255, 107, 0, 255} // Banner accent, zone border, meteor markers
+	chaosZoneTint   = color.RGBA{255, 60, 0, 60}   // Outside the safe zone
+	chaosMeteorCore = color.RGBA{255, 179, 0, 255}
+)
+
+// meteorDropHeight is how far above its impact point a meteor appears (px)
+const meteorDropHeight = 320.0
+
+// meteorPosition returns where a falling meteor is drawn
+func meteorPosition(m game.MeteorSnapshot) (float64, float64) {
+	return m.X, m.Y - (1-m.Progress)*meteorDropHeight
+}
+
+// drawChaosGround draws meteor impact markers on the arena floor (under players)
+func (s *StreamManager) drawChaosGround(dc *gg.Context, chaos game.ChaosSnapshot) {
+	for i := 0; i < chaos.MeteorCount; i++ {
+		m := chaos.Meteors[i]
+		dc.SetColor(color.RGBA{255, 60, 0, uint8(20 + m.Progress*60)})
+		dc.DrawCircle(m.X, m.Y, m.Radius)
+		dc.Fill()
+
+		// Inner ring closes in on the impact
+		dc.SetColor(chaosAccent)
+		dc.SetLineWidth(3)
+		dc.DrawCircle(m.X, m.Y, m.Radius)
+		dc.Stroke()
+		dc.SetLineWidth(2)
+		dc.DrawCircle(m.X, m.Y, m.Radius*(1-m.Progress))
+		dc.Stroke()
+	}
+}
+
+// drawChaosOverlay draws falling meteors and the shrinking zone (over players)
+func (s *StreamManager) drawChaosOverlay(dc *gg.Context, chaos game.ChaosSnapshot) {
+	for i := 0; i < chaos.MeteorCount; i++ {
+		m := chaos.Meteors[i]
+		x, y := meteorPosition(m)
+		dc.SetColor(color.RGBA{255, 107, 0, 120})
+		dc.SetLineWidth(6)
+		dc.DrawLine(x+18, y-40, x, y)
+		dc.Stroke()
+		dc.SetColor(chaosMeteorCore)
+		dc.DrawCircle(x, y, 12)
+		dc.Fill()
+	}
+
+	if chaos.ZoneRadius > 0 {
+		// Tint everything outside the zone (even-odd: rectangle minus circle)
+		dc.SetFillRuleEvenOdd()
+		dc.SetColor(chaosZoneTint)
+		dc.DrawRectangle(0, 0, float64(s.config.Width), float64(s.config.Height))
+		dc.DrawCircle(chaos.ZoneX, chaos.ZoneY, chaos.ZoneRadius)
+		dc.Fill()
+		dc.SetFillRuleWinding()
+
+		dc.SetColor(chaosAccent)
+		dc.SetLineWidth(4)
+		dc.DrawCircle(chaos.ZoneX, chaos.ZoneY, chaos.ZoneRadius)
+		dc.Stroke()
+	}
+}
+
+// chaosBannerText returns the chaos banner line ("" when no event)
+func chaosBannerText(chaos game.ChaosSnapshot) string {
+	ev, ok := game.GetChaosEvent(chaos.Event)
+	if !ok {
+		return ""
+	}
+	secs := int(math.Ceil(chaos.Remaining))
+	name := strings.ToUpper(ev.Name)
+	switch {
+	case chaos.Warning:
+		return fmt.Sprintf("WARNING: %s IN %d", name, secs)
+	case chaos.Gravity < 0:
+		return fmt.Sprintf("%s - UP  %d:%02d", name, secs/60, secs%60)
+	case chaos.Gravity > 0:
+		return fmt.Sprintf("%s - DOWN  %d:%02d", name, secs/60, secs%60)
+	}
+	return fmt.Sprintf("%s  %d:%02d", name, secs/60, secs%60)
+}
+
+// drawChaosBanner draws the chaos warning/countdown at the bottom center
+func (s *StreamManager) drawChaosBanner(dc *gg.Context, chaos game.ChaosSnapshot) {
+	text := chaosBannerText(chaos)
+	if text == "" {
+		return
+	}
+	if s.fontsLoaded && s.fontMedium != nil {
+		dc.SetFontFace(s.fontMedium)
+	} else {
+		_ = s.loadFontFace(dc, 20)
+	}
+
+	textWidth, _ := dc.MeasureString(text)
+	width := textWidth + 40
+	height := 40.0
+	x := (float64(s.config.Width) - width) / 2
+	y := float64(s.config.Height) - height - 24
+
+	dc.SetColor(color.RGBA{18, 18, 24, 240})
+	dc.DrawRoundedRectangle(x, y, width, height, 6)
+	dc.Fill()
+	dc.SetColor(chaosAccent)
+	dc.DrawRoundedRectangle(x, y, width, 4, 2)
+	dc.Fill()
+	if chaos.Warning {
+		// Full accent outline while the event is incoming
+		dc.SetLineWidth(2)
+		dc.DrawRoundedRectangle(x, y, width, height, 6)
+		dc.Stroke()
+	}
+
+	dc.SetColor(color.RGBA{255, 255, 255, 255})
+	dc.DrawStringAnchored(text, float64(s.config.Width)/2, y+height/2+2, 0.5, 0.35)
+}
+
+// drawChaos draws meteors and the safe zone with fast primitives (mirrors drawChaosGround/drawChaosOverlay)
+func (a *AtlasRenderer) drawChaos(buffer []byte, chaos game.ChaosSnapshot, ground bool) {
+	for i := 0; i < chaos.MeteorCount; i++ {
+		m := chaos.Meteors[i]
+		if ground {
+			a.fr.DrawFilledCircleBlend(int(m.X), int(m.Y), m.Radius, color.RGBA{255, 60, 0, uint8(20 + m.Progress*60)})
+			a.fr.DrawCircleOutline(int(m.X), int(m.Y), m.Radius, 3, chaosAccent)
+			a.fr.DrawCircleOutline(int(m.X), int(m.Y), m.Radius*(1-m.Progress), 2, chaosAccent)
+			continue
+		}
+		x, y := meteorPosition(m)
+		a.fr.DrawThickLine(int(x+18), int(y-40), int(x), int(y), 6, color.RGBA{255, 107, 0, 120})
+		a.fr.DrawFilledCircle(int(x), int(y), 12, chaosMeteorCore)
+	}
+
+	if !ground && chaos.ZoneRadius > 0 {
+		a.tintOutsideCircle(buffer, chaos.ZoneX, chaos.ZoneY, chaos.ZoneRadius, chaosZoneTint)
+		a.fr.DrawCircleOutline(int(chaos.ZoneX), int(chaos.ZoneY), chaos.ZoneRadius, 4, chaosAccent)
+	}
+}
+
+// tintOutsideCircle blends c over every pixel outside the circle, one span per row
+func (a *AtlasRenderer) tintOutsideCircle(buffer []byte, cx, cy, radius float64, c color.RGBA) {
+	op := uint32(c.A)
+	inv := 255 - op
+	r, g, b := uint32(c.R)*op, uint32(c.G)*op, uint32(c.B)*op
+	blend := func(row []byte) {
+		for i := 0; i+3 < len(row); i += 4 {
+			row[i] = uint8((r + uint32(row[i])*inv) / 255)
+			row[i+1] = uint8((g + uint32(row[i+1])*inv) / 255)
+			row[i+2] = uint8((b + uint32(row[i+2])*inv) / 255)
+		}
+	}
+
+	stride := a.width * 4
+	for y := 0; y < a.height; y++ {
+		row := buffer[y*stride : (y+1)*stride]
+		dy := float64(y) - cy
+		if math.Abs(dy) >= radius {
+			blend(row)
+			continue
+		}
+		half := math.Sqrt(radius*radius - dy*dy)
+		left := min(max(int(cx-half), 0), a.width)
+		right := min(max(int(cx+half), 0), a.width)
+		blend(row[:left*4])
+		blend(row[right*4:])
+	}
+}
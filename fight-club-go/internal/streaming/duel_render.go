@@ -0,0 +1,82 @@
+package streaming
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+
+	"fight-club/internal/game"
+
+	"github.com/fogleman/gg"
+)
+
+// Duel ring colors (gold, matching the "DUEL WON!" text)
+var (
+	duelRingFill   = color.RGBA{255, 215, 0, 28}
+	duelRingStroke = color.RGBA{255, 190, 0, 220}
+)
+
+// drawDuelRing draws the highlighted duel ring on the arena floor (under players)
+func (s *StreamManager) drawDuelRing(dc *gg.Context, duel game.DuelSnapshot) {
+	if !duel.Active {
+		return
+	}
+	dc.SetColor(duelRingFill)
+	dc.DrawCircle(duel.X, duel.Y, duel.Radius)
+	dc.Fill()
+
+	dc.SetColor(duelRingStroke)
+	dc.SetLineWidth(5)
+	dc.DrawCircle(duel.X, duel.Y, duel.Radius)
+	dc.Stroke()
+
+	// Thin inner ring for depth
+	dc.SetLineWidth(1.5)
+	dc.DrawCircle(duel.X, duel.Y, duel.Radius-8)
+	dc.Stroke()
+}
+
+// drawDuelBanner draws "A vs B 0:42" above the duel ring
+func (s *StreamManager) drawDuelBanner(dc *gg.Context, duel game.DuelSnapshot) {
+	if !duel.Active {
+		return
+	}
+	if s.fontsLoaded && s.fontSmall != nil {
+		dc.SetFontFace(s.fontSmall)
+	} else {
+		_ = s.loadFontFace(dc, 13)
+	}
+
+	secs := int(math.Ceil(duel.Remaining))
+	text := fmt.Sprintf("DUEL  %s vs %s  %d:%02d", duel.Players[0], duel.Players[1], secs/60, secs%60)
+	textWidth, _ := dc.MeasureString(text)
+	width := textWidth + 24
+	height := 26.0
+	x := duel.X - width/2
+	y := math.Max(4, duel.Y-duel.Radius-height-10)
+
+	dc.SetColor(color.RGBA{18, 18, 24, 235})
+	dc.DrawRoundedRectangle(x, y, width, height, 4)
+	dc.Fill()
+	dc.SetColor(duelRingStroke)
+	dc.DrawRoundedRectangle(x, y, width, 3, 2)
+	dc.Fill()
+
+	dc.SetColor(color.RGBA{255, 255, 255, 255})
+	dc.DrawStringAnchored(text, duel.X, y+height/2+1, 0.5, 0.35)
+}
+
+// duelRing returns the cached ring sprite, re-rendered if the radius changes
+func (a *AtlasRenderer) duelRing(radius float64) *sprite {
+	if a.ring != nil && a.ringRadius == radius {
+		return a.ring
+	}
+	size := int(math.Ceil(radius*2)) + 12
+	center := float64(size) / 2
+	dc := gg.NewContext(size, size)
+	a.s.drawDuelRing(dc, game.DuelSnapshot{Active: true, X: center, Y: center, Radius: radius})
+	a.ring = newSprite(dc.Image().(*image.RGBA))
+	a.ringRadius = radius
+	return a.ring
+}
@@ -284,6 +284,37 @@ func (r *FastRenderer) DrawLine(x0, y0, x1, y1 int, c color.RGBA) {
 	}
 }
 
+// DrawLineBlend draws an alpha-blended line using Bresenham's algorithm
+func (r *FastRenderer) DrawLineBlend(x0, y0, x1, y1 int, c color.RGBA) {
+	dx := absInt(x1 - x0)
+	dy := -absInt(y1 - y0)
+	sx := 1
+	if x0 > x1 {
+		sx = -1
+	}
+	sy := 1
+	if y0 > y1 {
+		sy = -1
+	}
+	err := dx + dy
+
+	for {
+		r.setPixelBlend(x0, y0, c)
+		if x0 == x1 && y0 == y1 {
+			break
+		}
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			x0 += sx
+		}
+		if e2 <= dx {
+			err += dx
+			y0 += sy
+		}
+	}
+}
+
 // DrawThickLine draws a line with thickness
 func (r *FastRenderer) DrawThickLine(x0, y0, x1, y1 int, thickness int, c color.RGBA) {
 	if thickness <= 1 {
@@ -0,0 +1,339 @@
+package streaming
+
+import (
+	"fmt"
+	"os/exec"
+	"sort"
+)
+
+// H.264 encoders supported by the command builder
+const (
+	EncoderX264  = "libx264"
+	EncoderNVENC = "h264_nvenc"
+)
+
+// Named FFmpeg quality profiles (FFMPEG_PROFILE)
+const (
+	ProfileLowLatency720p = "720p30-low-latency"
+	ProfileQuality1080p   = "1080p60-quality"
+	ProfileNVENCP4        = "nvenc-p4"
+	ProfileTestNull       = "test-null-output"
+)
+
+// FFmpegProfile is a named set of output format and encoder settings.
+// Zero Width/Height/FPS/Bitrate keep the StreamConfig values.
+type FFmpegProfile struct {
+	Name    string
+	Width   int
+	Height  int
+	FPS     int
+	Bitrate int // kbps
+
+	Encoder     string // EncoderX264 or EncoderNVENC
+	Preset      string
+	Tune        string // "" = encoder default
+	H264Profile string
+	Level       string // "" = encoder picks
+
+	NullOutput bool // Encode to "-f null -" instead of the stream (tests, benchmarks)
+}
+
+// FFmpegProfiles lists the named profiles selectable with FFMPEG_PROFILE
+var FFmpegProfiles = map[string]FFmpegProfile{
+	ProfileLowLatency720p: {
+		Name: ProfileLowLatency720p, Width: 1280, Height: 720, FPS: 30, Bitrate: 3000,
+		Encoder: EncoderX264, Preset: "ultrafast", Tune: "zerolatency", H264Profile: "main",
+	},
+	ProfileQuality1080p: {
+		Name: ProfileQuality1080p, Width: 1920, Height: 1080, FPS: 60, Bitrate: 6000,
+		Encoder: EncoderX264, Preset: "veryfast", H264Profile: "high", Level: "4.2",
+	},
+	ProfileNVENCP4: {
+		Name:    ProfileNVENCP4,
+		Encoder: EncoderNVENC, Preset: "p4", Tune: "ll", H264Profile: "main", Level: "4.1",
+	},
+	ProfileTestNull: {
+		Name: ProfileTestNull, Width: 640, Height: 360, FPS: 30, Bitrate: 1000,
+		Encoder: EncoderX264, Preset: "ultrafast", Tune: "zerolatency", H264Profile: "main",
+		NullOutput: true,
+	},
+}
+
+// GetFFmpegProfile returns a named profile
+func GetFFmpegProfile(name string) (FFmpegProfile, bool) {
+	p, ok := FFmpegProfiles[name]
+	return p, ok
+}
+
+// FFmpegProfileNames returns the named profiles, sorted
+func FFmpegProfileNames() []string {
+	names := make([]string, 0, len(FFmpegProfiles))
+	for name := range FFmpegProfiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// defaultFFmpegProfile returns the encoder settings used without FFMPEG_PROFILE
+func defaultFFmpegProfile(useNVENC bool) FFmpegProfile {
+	if useNVENC {
+		// Fastest NVENC preset, low-latency tuning (RTX 30/40 series)
+		return FFmpegProfile{Encoder: EncoderNVENC, Preset: "p1", Tune: "ll", H264Profile: "main", Level: "4.1"}
+	}
+	return FFmpegProfile{Encoder: EncoderX264, Preset: "ultrafast", Tune: "zerolatency", H264Profile: "main"}
+}
+
+// Apply overrides the stream format with the profile's size, frame rate and bitrate
+func (p FFmpegProfile) Apply(cfg StreamConfig) StreamConfig {
+	if p.Width > 0 && p.Height > 0 {
+		cfg.Width, cfg.Height = p.Width, p.Height
+	}
+	if p.FPS > 0 {
+		cfg.FPS = p.FPS
+	}
+	if p.Bitrate > 0 {
+		cfg.Bitrate = p.Bitrate
+	}
+	return cfg
+}
+
+// ffmpegAudio is the audio input kind
+type ffmpegAudio int
+
+const (
+	audioSilent ffmpegAudio = iota // Generated silence (lavfi anullsrc)
+	audioPipe                      // s16le stereo on fd 3 (SFX + music mixed in-process)
+	audioFile                      // Looped music file (Windows, no SFX)
+)
+
+// FFmpegCommandBuilder assembles FFmpeg arguments for the live stream:
+// raw RGBA frames on stdin, one audio input, H.264/AAC out to FLV, MPEG-TS
+// over SRT (or tee),
+// plus an optional portrait output fed by its own rawvideo pipe.
+type FFmpegCommandBuilder struct {
+	profile FFmpegProfile
+
+	inputWidth, inputHeight   int
+	outputWidth, outputHeight int
+	fps                       int
+	bitrate                   int
+
+	audio       ffmpegAudio
+	musicPath   string
+	musicVolume float64
+
+	output string // Live URL, the tee muxer output list when tee is set, or a file path when file is set
+	muxer  string // Live output container: flv (RTMP) or mpegts (SRT)
+	tee    bool
+	file   bool
+
+	portrait PortraitConfig // Second video input (pipe:4) and FLV output when enabled
+}
+
+// NewFFmpegCommandBuilder creates a builder for an encoder profile
+func NewFFmpegCommandBuilder(profile FFmpegProfile) *FFmpegCommandBuilder {
+	return &FFmpegCommandBuilder{
+		profile:      profile,
+		inputWidth:   profile.Width,
+		inputHeight:  profile.Height,
+		outputWidth:  profile.Width,
+		outputHeight: profile.Height,
+		fps:          profile.FPS,
+		bitrate:      profile.Bitrate,
+	}
+}
+
+// VideoInput sets the rawvideo frame size and rate written to stdin
+func (b *FFmpegCommandBuilder) VideoInput(width, height, fps int) *FFmpegCommandBuilder {
+	b.inputWidth, b.inputHeight, b.fps = width, height, fps
+	return b
+}
+
+// OutputSize sets the encoded size (frames are upscaled when smaller)
+func (b *FFmpegCommandBuilder) OutputSize(width, height int) *FFmpegCommandBuilder {
+	b.outputWidth, b.outputHeight = width, height
+	return b
+}
+
+// Bitrate sets the video bitrate in kbps
+func (b *FFmpegCommandBuilder) Bitrate(kbps int) *FFmpegCommandBuilder {
+	b.bitrate = kbps
+	return b
+}
+
+// AudioPipe reads s16le stereo audio from fd 3 (Linux/macOS)
+func (b *FFmpegCommandBuilder) AudioPipe() *FFmpegCommandBuilder {
+	b.audio = audioPipe
+	return b
+}
+
+// MusicFile loops a music file as the audio track at the given volume
+func (b *FFmpegCommandBuilder) MusicFile(path string, volume float64) *FFmpegCommandBuilder {
+	b.audio, b.musicPath, b.musicVolume = audioFile, path, volume
+	return b
+}
+
+// SilentAudio generates a silent audio track
+func (b *FFmpegCommandBuilder) SilentAudio() *FFmpegCommandBuilder {
+	b.audio = audioSilent
+	return b
+}
+
+// Output streams FLV to an RTMP(S) URL
+func (b *FFmpegCommandBuilder) Output(url string) *FFmpegCommandBuilder {
+	b.output, b.muxer, b.tee, b.file = url, "flv", false, false
+	return b
+}
+
+// SRTOutput streams MPEG-TS to an SRT URL (see SRTConfig.outputURL)
+func (b *FFmpegCommandBuilder) SRTOutput(url string) *FFmpegCommandBuilder {
+	b.output, b.muxer, b.tee, b.file = url, "mpegts", false, false
+	return b
+}
+
+// TeeOutput writes the same encode to several outputs (see RecordingConfig.teeOutput)
+func (b *FFmpegCommandBuilder) TeeOutput(outputs string) *FFmpegCommandBuilder {
+	b.output, b.tee, b.file = outputs, true, false
+	return b
+}
+
+// FileOutput writes a local file; FFmpeg picks the container from the extension
+func (b *FFmpegCommandBuilder) FileOutput(path string) *FFmpegCommandBuilder {
+	b.output, b.tee, b.file = path, false, true
+	return b
+}
+
+// PortraitOutput adds a portrait stream: rawvideo frames on fd 4, encoded with
+// the same profile and audio, sent as a second FLV output (Linux/macOS)
+func (b *FFmpegCommandBuilder) PortraitOutput(portrait PortraitConfig) *FFmpegCommandBuilder {
+	b.portrait = portrait
+	return b
+}
+
+// Args returns the FFmpeg arguments (without the "ffmpeg" program name)
+func (b *FFmpegCommandBuilder) Args() []string {
+	args := []string{
+		"-y",
+		// Video input (pipe:0 - stdin)
+		"-f", "rawvideo",
+		"-pix_fmt", "rgba",
+		"-s", fmt.Sprintf("%dx%d", b.inputWidth, b.inputHeight),
+		"-r", fmt.Sprintf("%d", b.fps),
+		"-i", "pipe:0",
+	}
+
+	switch b.audio {
+	case audioPipe:
+		args = append(args, "-f", "s16le", "-ar", "44100", "-ac", "2", "-i", "pipe:3")
+	case audioFile:
+		args = append(args, "-stream_loop", "-1", "-i", b.musicPath)
+	default:
+		args = append(args, "-f", "lavfi", "-i", "anullsrc=channel_layout=stereo:sample_rate=44100")
+	}
+
+	// Portrait video input (input 2) - after audio so the main input indexes never change
+	if b.portrait.Enabled() {
+		args = append(args,
+			"-f", "rawvideo",
+			"-pix_fmt", "rgba",
+			"-s", fmt.Sprintf("%dx%d", b.portrait.Width, b.portrait.Height),
+			"-r", fmt.Sprintf("%d", b.fps),
+			"-i", "pipe:4",
+		)
+	}
+
+	// Reduced render resolution: upscale back so the stream size never changes
+	if b.outputWidth > 0 && b.outputHeight > 0 && (b.outputWidth != b.inputWidth || b.outputHeight != b.inputHeight) {
+		args = append(args, "-vf", fmt.Sprintf("scale=%d:%d:flags=bilinear", b.outputWidth, b.outputHeight))
+	}
+
+	args = append(args, b.videoCodecArgs(b.bitrate)...)
+	args = append(args, b.audioCodecArgs()...)
+
+	args = append(args,
+		"-map", "0:v", // Video from stdin (pipe:0)
+		"-map", "1:a", // Audio input
+	)
+	switch {
+	case b.profile.NullOutput:
+		args = append(args, "-f", "null", "-")
+	case b.file:
+		args = append(args, b.output)
+	case b.tee:
+		args = append(args,
+			"-flags", "+global_header", // Required by mkv/mp4 segments; harmless for flv
+			"-f", "tee",
+			b.output,
+		)
+	default:
+		args = append(args, "-f", b.muxer, b.output)
+	}
+
+	// Output options apply per output file, so the portrait output repeats them
+	if b.portrait.Enabled() {
+		args = append(args, b.videoCodecArgs(b.portrait.Bitrate)...)
+		args = append(args, b.audioCodecArgs()...)
+		args = append(args,
+			"-map", "2:v", // Portrait video (pipe:4)
+			"-map", "1:a", // Same audio input
+		)
+		if b.profile.NullOutput {
+			args = append(args, "-f", "null", "-")
+		} else {
+			args = append(args, "-f", "flv", b.portrait.RTMPURL)
+		}
+	}
+	return args
+}
+
+// audioCodecArgs returns the AAC encoder settings
+func (b *FFmpegCommandBuilder) audioCodecArgs() []string {
+	var args []string
+	if b.audio == audioFile {
+		args = append(args, "-af", fmt.Sprintf("volume=%.2f", b.musicVolume))
+	}
+	return append(args, "-c:a", "aac", "-b:a", "128k", "-ar", "44100", "-ac", "2")
+}
+
+// videoCodecArgs returns the H.264 encoder settings (constant bitrate, 2s GOP)
+func (b *FFmpegCommandBuilder) videoCodecArgs(bitrate int) []string {
+	p := b.profile
+	args := []string{"-c:v", p.Encoder, "-preset", p.Preset}
+	if p.Tune != "" {
+		args = append(args, "-tune", p.Tune)
+	}
+	if p.Encoder == EncoderNVENC {
+		args = append(args, "-rc", "cbr") // Constant bitrate for streaming stability
+	}
+	args = append(args,
+		"-b:v", fmt.Sprintf("%dk", bitrate),
+		"-maxrate", fmt.Sprintf("%dk", bitrate),
+		"-bufsize", fmt.Sprintf("%dk", bitrate*2),
+		"-pix_fmt", "yuv420p",
+		"-g", fmt.Sprintf("%d", b.fps*2), // GOP size
+		"-keyint_min", fmt.Sprintf("%d", b.fps),
+	)
+
+	if p.Encoder == EncoderNVENC {
+		args = append(args, "-no-scenecut", "1") // Consistent keyframes
+	} else {
+		args = append(args, "-sc_threshold", "0")
+	}
+	args = append(args, "-profile:v", p.H264Profile)
+	if p.Level != "" {
+		args = append(args, "-level", p.Level)
+	}
+	if p.Encoder == EncoderNVENC {
+		args = append(args,
+			"-spatial-aq", "1", // Spatial adaptive quantization for better quality
+			"-zerolatency", "1", // Minimize encoding latency
+		)
+	}
+	return args
+}
+
+// Command returns the FFmpeg process for the built arguments
+func (b *FFmpegCommandBuilder) Command() *exec.Cmd {
+	return exec.Command("ffmpeg", b.Args()...)
+}
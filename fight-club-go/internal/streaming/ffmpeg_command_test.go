@@ -0,0 +1,179 @@
+package streaming
+
+import (
+	"strings"
+	"testing"
+)
+
+// argValue returns the value following the first occurrence of flag ("" if missing)
+func argValue(args []string, flag string) string {
+	for i := 0; i+1 < len(args); i++ {
+		if args[i] == flag {
+			return args[i+1]
+		}
+	}
+	return ""
+}
+
+// hasArg reports whether flag appears in args
+func hasArg(args []string, flag string) bool {
+	for _, a := range args {
+		if a == flag {
+			return true
+		}
+	}
+	return false
+}
+
+// TestFFmpegProfiles verifies each named profile produces the expected encoder arguments
+func TestFFmpegProfiles(t *testing.T) {
+	tests := []struct {
+		profile string
+		want    map[string]string
+		absent  []string
+	}{
+		{
+			profile: ProfileLowLatency720p,
+			want: map[string]string{
+				"-s": "1280x720", "-r": "30", "-c:v": "libx264", "-preset": "ultrafast", "-tune": "zerolatency",
+				"-b:v": "3000k", "-bufsize": "6000k", "-g": "60", "-keyint_min": "30", "-sc_threshold": "0", "-profile:v": "main",
+			},
+			absent: []string{"-level", "-rc", "-vf"},
+		},
+		{
+			profile: ProfileQuality1080p,
+			want: map[string]string{
+				"-s": "1920x1080", "-r": "60", "-c:v": "libx264", "-preset": "veryfast",
+				"-b:v": "6000k", "-g": "120", "-profile:v": "high", "-level": "4.2",
+			},
+			absent: []string{"-tune"},
+		},
+		{
+			profile: ProfileNVENCP4,
+			want: map[string]string{
+				"-c:v": "h264_nvenc", "-preset": "p4", "-tune": "ll", "-rc": "cbr",
+				"-no-scenecut": "1", "-level": "4.1", "-zerolatency": "1",
+			},
+			absent: []string{"-sc_threshold"},
+		},
+		{
+			profile: ProfileTestNull,
+			want:    map[string]string{"-s": "640x360", "-b:v": "1000k"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.profile, func(t *testing.T) {
+			profile, ok := GetFFmpegProfile(tt.profile)
+			if !ok {
+				t.Fatalf("Profile %s not registered", tt.profile)
+			}
+			cfg := profile.Apply(StreamConfig{Width: 1280, Height: 720, FPS: 30, Bitrate: 4500})
+			args := NewFFmpegCommandBuilder(profile).
+				VideoInput(cfg.Width, cfg.Height, cfg.FPS).
+				OutputSize(cfg.Width, cfg.Height).
+				Bitrate(cfg.Bitrate).
+				AudioPipe().
+				Output("rtmp://live.example/app/key").
+				Args()
+
+			for flag, want := range tt.want {
+				if got := argValue(args, flag); got != want {
+					t.Errorf("%s = %q, want %q", flag, got, want)
+				}
+			}
+			for _, flag := range tt.absent {
+				if hasArg(args, flag) {
+					t.Errorf("Unexpected %s in %v", flag, args)
+				}
+			}
+
+			// Output is always last: the stream, or nowhere for the null profile
+			tail := strings.Join(args[len(args)-3:], " ")
+			if profile.NullOutput {
+				if tail != "-f null -" {
+					t.Errorf("Expected null output, got %q", tail)
+				}
+			} else if tail != "-f flv rtmp://live.example/app/key" {
+				t.Errorf("Expected FLV output, got %q", tail)
+			}
+		})
+	}
+}
+
+// TestFFmpegBuilderInputs verifies audio inputs, upscaling and the tee output
+func TestFFmpegBuilderInputs(t *testing.T) {
+	base := func() *FFmpegCommandBuilder {
+		return NewFFmpegCommandBuilder(defaultFFmpegProfile(false)).
+			VideoInput(640, 360, 24).
+			OutputSize(1280, 720).
+			Bitrate(4000)
+	}
+
+	args := base().AudioPipe().Output("rtmp://x").Args()
+	if argValue(args, "-vf") != "scale=1280:720:flags=bilinear" {
+		t.Errorf("Expected upscale filter, got %v", args)
+	}
+	if !strings.Contains(strings.Join(args, " "), "-f s16le -ar 44100 -ac 2 -i pipe:3") {
+		t.Errorf("Expected piped audio input, got %v", args)
+	}
+
+	args = base().MusicFile("music.ogg", 0.15).Output("rtmp://x").Args()
+	if argValue(args, "-stream_loop") != "-1" || argValue(args, "-af") != "volume=0.15" {
+		t.Errorf("Expected looped music at 15%%, got %v", args)
+	}
+
+	args = base().SilentAudio().TeeOutput("[f=flv]rtmp://x|[f=segment]rec.mkv").Args()
+	if argValue(args, "-i") != "pipe:0" || !hasArg(args, "anullsrc=channel_layout=stereo:sample_rate=44100") {
+		t.Errorf("Expected stdin video and silent audio, got %v", args)
+	}
+	if argValue(args, "-flags") != "+global_header" || args[len(args)-1] != "[f=flv]rtmp://x|[f=segment]rec.mkv" {
+		t.Errorf("Expected tee output, got %v", args)
+	}
+
+	// File output (cmd/rerender): the container follows the extension, no -f
+	args = base().SilentAudio().FileOutput("vod.mp4").Args()
+	if args[len(args)-1] != "vod.mp4" || args[len(args)-2] == "flv" || hasArg(args, "tee") {
+		t.Errorf("Expected plain file output, got %v", args)
+	}
+
+	// Same size in and out: no scale filter
+	args = NewFFmpegCommandBuilder(defaultFFmpegProfile(true)).VideoInput(1280, 720, 30).OutputSize(1280, 720).Bitrate(4000).Output("rtmp://x").Args()
+	if hasArg(args, "-vf") || argValue(args, "-preset") != "p1" {
+		t.Errorf("Expected default NVENC args without scaling, got %v", args)
+	}
+}
+
+// TestFFmpegBuilderPortraitOutput verifies the portrait pipe input and its own encode and FLV output
+func TestFFmpegBuilderPortraitOutput(t *testing.T) {
+	portrait := PortraitConfig{RTMPURL: "rtmp://shorts/key"}.withDefaults()
+	args := NewFFmpegCommandBuilder(defaultFFmpegProfile(false)).
+		VideoInput(1280, 720, 30).
+		OutputSize(1280, 720).
+		Bitrate(4000).
+		AudioPipe().
+		PortraitOutput(portrait).
+		Output("rtmp://x").
+		Args()
+	joined := strings.Join(args, " ")
+
+	if !strings.Contains(joined, "-s 1080x1920 -r 30 -i pipe:4") {
+		t.Errorf("Expected the portrait rawvideo input on pipe:4, got %v", args)
+	}
+	if !strings.Contains(joined, "-b:v 4000k") || !strings.Contains(joined, "-b:v 4500k") {
+		t.Errorf("Expected each output at its own bitrate, got %v", args)
+	}
+	if !strings.Contains(joined, "-map 0:v -map 1:a -f flv rtmp://x") {
+		t.Errorf("Expected the main output unchanged, got %v", args)
+	}
+	if !strings.HasSuffix(joined, "-map 2:v -map 1:a -f flv rtmp://shorts/key") {
+		t.Errorf("Expected the portrait output last, got %v", args)
+	}
+
+	// Without a URL the command is unchanged
+	args = NewFFmpegCommandBuilder(defaultFFmpegProfile(false)).VideoInput(1280, 720, 30).Bitrate(4000).
+		PortraitOutput(PortraitConfig{}).Output("rtmp://x").Args()
+	if strings.Contains(strings.Join(args, " "), "pipe:4") {
+		t.Errorf("Expected no portrait input when disabled, got %v", args)
+	}
+}
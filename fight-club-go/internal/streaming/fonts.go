@@ -0,0 +1,297 @@
+package streaming
+
+import (
+	"embed"
+	"image"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+// Bundled fonts: Go Regular is the default text font on every platform. Any
+// other .ttf dropped into fonts/ (e.g. a monochrome emoji font) is embedded at
+// build time and joins the fallback chain after the system fonts.
+//
+//go:embed fonts/*.ttf
+var bundledFonts embed.FS
+
+// defaultBundledFont is the embedded font every chain falls back to
+const defaultBundledFont = "fonts/Go-Regular.ttf"
+
+// platformFonts lists text and emoji/symbol font candidates for the current OS.
+// Color bitmap emoji fonts (Apple Color Emoji, Noto Color Emoji) can't be
+// rasterized, so only outline fonts are listed.
+func platformFonts() (text, emoji []string) {
+	switch runtime.GOOS {
+	case "windows":
+		dir := filepath.Join(os.Getenv("WINDIR"), "Fonts")
+		if os.Getenv("WINDIR") == "" {
+			dir = `C:\Windows\Fonts`
+		}
+		text = []string{filepath.Join(dir, "segoeui.ttf"), filepath.Join(dir, "arial.ttf")}
+		emoji = []string{filepath.Join(dir, "seguiemj.ttf"), filepath.Join(dir, "seguisym.ttf")}
+	case "darwin":
+		text = []string{"/System/Library/Fonts/Helvetica.ttc", "/Library/Fonts/Arial Unicode.ttf"}
+		emoji = []string{"/System/Library/Fonts/Apple Symbols.ttf"}
+	default:
+		text = []string{
+			"/usr/share/fonts/truetype/dejavu/DejaVuSans.ttf",
+			"/usr/share/fonts/dejavu/DejaVuSans.ttf", // Alpine
+			"/usr/share/fonts/truetype/noto/NotoSans-Regular.ttf",
+			"/usr/share/fonts/noto/NotoSans-Regular.ttf",
+		}
+		emoji = []string{
+			"/usr/share/fonts/truetype/noto/NotoEmoji-Regular.ttf",
+			"/usr/share/fonts/noto/NotoEmoji-Regular.ttf",
+			"/usr/share/fonts/truetype/ancient-scripts/Symbola_hint.ttf",
+			"/usr/share/fonts/TTF/Symbola.ttf",
+			"/usr/share/fonts/truetype/noto/NotoSansSymbols2-Regular.ttf",
+			"/usr/share/fonts/noto/NotoSansSymbols2-Regular.ttf",
+			"/usr/share/fonts/truetype/dejavu/DejaVuSans.ttf", // Misc symbols (☄ ⚔ ★)
+			"/usr/share/fonts/dejavu/DejaVuSans.ttf",
+		}
+	}
+	return text, emoji
+}
+
+// FontRegistry holds the font fallback chain and caches faces per size.
+// Text renders in the first font of the chain; any rune it lacks (emoji,
+// symbols) renders in the first later font that has it.
+type FontRegistry struct {
+	fonts []*sfnt.Font
+	names []string // Source of each font, for logs
+
+	mu    sync.Mutex
+	faces map[float64]font.Face
+}
+
+// NewFontRegistry builds the chain: textPath (or the first platform text font
+// found), the bundled default, emojiPath, platform emoji/symbol fonts and any
+// other bundled fonts. Empty paths use the platform defaults.
+func NewFontRegistry(textPath, emojiPath string) *FontRegistry {
+	r := &FontRegistry{faces: make(map[float64]font.Face)}
+	text, emoji := platformFonts()
+
+	if textPath != "" {
+		text = append([]string{textPath}, text...)
+	}
+	for _, path := range text {
+		if r.addFile(path) {
+			break
+		}
+	}
+	r.addBundled(defaultBundledFont)
+	if emojiPath != "" {
+		emoji = append([]string{emojiPath}, emoji...)
+	}
+	for _, path := range emoji {
+		r.addFile(path)
+	}
+	entries, _ := bundledFonts.ReadDir("fonts")
+	for _, e := range entries {
+		if name := "fonts/" + e.Name(); name != defaultBundledFont {
+			r.addBundled(name)
+		}
+	}
+	return r
+}
+
+// addFile adds a font file (first font of a .ttc collection); false if missing or invalid
+func (r *FontRegistry) addFile(path string) bool {
+	for _, name := range r.names {
+		if name == path {
+			return true
+		}
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	f, err := parseFontData(data)
+	if err != nil {
+		log.Printf("⚠️ Skipping font %s: %v", path, err)
+		return false
+	}
+	r.fonts = append(r.fonts, f)
+	r.names = append(r.names, path)
+	return true
+}
+
+// addBundled adds an embedded font
+func (r *FontRegistry) addBundled(name string) {
+	data, err := bundledFonts.ReadFile(name)
+	if err != nil {
+		return
+	}
+	f, err := parseFontData(data)
+	if err != nil {
+		log.Printf("⚠️ Skipping bundled font %s: %v", name, err)
+		return
+	}
+	r.fonts = append(r.fonts, f)
+	r.names = append(r.names, "embedded:"+name)
+}
+
+// parseFontData parses a .ttf/.otf, or the first font of a .ttc collection
+func parseFontData(data []byte) (*sfnt.Font, error) {
+	if len(data) >= 4 && string(data[:4]) == "ttcf" {
+		c, err := opentype.ParseCollection(data)
+		if err != nil {
+			return nil, err
+		}
+		return c.Font(0)
+	}
+	return opentype.Parse(data)
+}
+
+// Sources returns where each font in the chain was loaded from
+func (r *FontRegistry) Sources() []string {
+	return r.names
+}
+
+// Face returns the cached fallback face at size (points at 72 DPI = px)
+func (r *FontRegistry) Face(size float64) (font.Face, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if face, ok := r.faces[size]; ok {
+		return face, nil
+	}
+	ff := &fallbackFace{fonts: r.fonts, runes: make(map[rune]int)}
+	for _, f := range r.fonts {
+		face, err := opentype.NewFace(f, &opentype.FaceOptions{
+			Size:    size,
+			DPI:     72,
+			Hinting: font.HintingFull,
+		})
+		if err != nil {
+			return nil, err
+		}
+		ff.faces = append(ff.faces, face)
+	}
+	r.faces[size] = ff
+	return ff, nil
+}
+
+var (
+	defaultFontsOnce sync.Once
+	defaultFonts     *FontRegistry
+)
+
+// defaultFontRegistry returns the platform font chain, built on first use
+// (for renderers drawn without a configured StreamManager)
+func defaultFontRegistry() *FontRegistry {
+	defaultFontsOnce.Do(func() {
+		defaultFonts = NewFontRegistry("", "")
+	})
+	return defaultFonts
+}
+
+// isInvisibleRune reports runes that only modify the previous emoji (variation
+// selectors, zero-width joiner, skin tones, keycap); drawn as nothing instead
+// of a missing-glyph box
+func isInvisibleRune(c rune) bool {
+	switch {
+	case c == 0x200D, c == 0x20E3:
+		return true
+	case c >= 0xFE00 && c <= 0xFE0F:
+		return true
+	case c >= 0x1F3FB && c <= 0x1F3FF:
+		return true
+	case c >= 0xE0020 && c <= 0xE007F: // Tag characters (flag sequences)
+		return true
+	}
+	return false
+}
+
+// fallbackFace is a font.Face drawing each rune with the first face of the
+// chain that has a glyph for it. Runes no font has are skipped (no tofu boxes).
+type fallbackFace struct {
+	fonts []*sfnt.Font
+	faces []font.Face
+
+	mu    sync.Mutex
+	buf   sfnt.Buffer
+	runes map[rune]int // Rune -> face index (-1 = no glyph anywhere)
+}
+
+// faceFor returns the face drawing c, or nil when no font has it
+func (f *fallbackFace) faceFor(c rune) font.Face {
+	if isInvisibleRune(c) {
+		return nil
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	idx, ok := f.runes[c]
+	if !ok {
+		idx = -1
+		for i, fnt := range f.fonts {
+			if g, err := fnt.GlyphIndex(&f.buf, c); err == nil && g != 0 {
+				idx = i
+				break
+			}
+		}
+		f.runes[c] = idx
+	}
+	if idx < 0 {
+		return nil
+	}
+	return f.faces[idx]
+}
+
+// Close closes every face in the chain
+func (f *fallbackFace) Close() error {
+	for _, face := range f.faces {
+		face.Close()
+	}
+	return nil
+}
+
+// Glyph implements font.Face
+func (f *fallbackFace) Glyph(dot fixed.Point26_6, r rune) (image.Rectangle, image.Image, image.Point, fixed.Int26_6, bool) {
+	face := f.faceFor(r)
+	if face == nil {
+		return image.Rectangle{}, nil, image.Point{}, 0, false
+	}
+	return face.Glyph(dot, r)
+}
+
+// GlyphBounds implements font.Face
+func (f *fallbackFace) GlyphBounds(r rune) (fixed.Rectangle26_6, fixed.Int26_6, bool) {
+	face := f.faceFor(r)
+	if face == nil {
+		return fixed.Rectangle26_6{}, 0, false
+	}
+	return face.GlyphBounds(r)
+}
+
+// GlyphAdvance implements font.Face
+func (f *fallbackFace) GlyphAdvance(r rune) (fixed.Int26_6, bool) {
+	face := f.faceFor(r)
+	if face == nil {
+		return 0, false
+	}
+	return face.GlyphAdvance(r)
+}
+
+// Kern implements font.Face (only between runes drawn by the same font)
+func (f *fallbackFace) Kern(r0, r1 rune) fixed.Int26_6 {
+	a, b := f.faceFor(r0), f.faceFor(r1)
+	if a == nil || a != b {
+		return 0
+	}
+	return a.Kern(r0, r1)
+}
+
+// Metrics implements font.Face (the primary font's metrics)
+func (f *fallbackFace) Metrics() font.Metrics {
+	return f.faces[0].Metrics()
+}
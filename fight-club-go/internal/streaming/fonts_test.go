@@ -0,0 +1,63 @@
+package streaming
+
+import (
+	"testing"
+
+	"github.com/fogleman/gg"
+	"golang.org/x/image/font"
+)
+
+// bundledOnlyRegistry returns a chain with just the embedded default font,
+// independent of the fonts installed on the test machine
+func bundledOnlyRegistry(t *testing.T) *FontRegistry {
+	t.Helper()
+	r := &FontRegistry{faces: make(map[float64]font.Face)}
+	r.addBundled(defaultBundledFont)
+	if len(r.fonts) != 1 {
+		t.Fatalf("Expected the bundled font to load, got %v", r.Sources())
+	}
+	return r
+}
+
+// TestFontRegistryAlwaysHasBundledFont verifies the platform chain always includes the embedded font
+func TestFontRegistryAlwaysHasBundledFont(t *testing.T) {
+	r := NewFontRegistry("/nonexistent/font.ttf", "/nonexistent/emoji.ttf")
+	found := false
+	for _, src := range r.Sources() {
+		if src == "embedded:"+defaultBundledFont {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("Expected the bundled font in the chain, got %v", r.Sources())
+	}
+
+	a, err := r.Face(16)
+	if err != nil {
+		t.Fatalf("Face failed: %v", err)
+	}
+	if b, _ := r.Face(16); a != b {
+		t.Error("Expected faces cached per size")
+	}
+}
+
+// TestFallbackFaceSkipsMissingGlyphs verifies emoji no font has and emoji
+// modifiers take no space instead of rendering as boxes
+func TestFallbackFaceSkipsMissingGlyphs(t *testing.T) {
+	face, err := bundledOnlyRegistry(t).Face(16)
+	if err != nil {
+		t.Fatalf("Face failed: %v", err)
+	}
+	dc := gg.NewContext(10, 10)
+	dc.SetFontFace(face)
+
+	plain, _ := dc.MeasureString("Fighter")
+	for _, name := range []string{"Fighter🏆", "Fighter❤️", "👍🏽Fighter", "Fi‍ghter"} {
+		if w, _ := dc.MeasureString(name); w != plain {
+			t.Errorf("Expected %q as wide as %q (%.1f), got %.1f", name, "Fighter", plain, w)
+		}
+	}
+
+	// Drawing must not panic on runes without glyphs
+	dc.DrawString("🔥 Fighter 🏆", 0, 8)
+}
@@ -0,0 +1,191 @@
+package streaming
+
+import (
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"fight-club/internal/game"
+)
+
+// Frame skip levels: render work is shed in this order as the renderer
+// falls behind, and restored in reverse once it keeps up again
+const (
+	SkipNone      = iota // Render everything
+	SkipParticles        // Drop particles and floating texts
+	SkipOverlays         // Also drop the minimap (the heaviest per-frame widget)
+	SkipFrames           // Also render every other frame (the previous one is re-sent)
+)
+
+// skipLevelNames are the stats/log names of the frame skip levels
+var skipLevelNames = [...]string{"none", "particles", "overlays", "frames"}
+
+// Frame skip tuning
+const (
+	frameSkipSmoothing = 0.1             // EWMA weight of the newest frame time
+	frameSkipOver      = 0.9             // Average above this share of the budget is falling behind
+	frameSkipUnder     = 0.6             // Average below this share of the budget has headroom
+	frameSkipDownAfter = time.Second     // Sustained lag before shedding the next layer
+	frameSkipUpAfter   = 5 * time.Second // Sustained headroom before restoring a layer
+)
+
+// FrameSkipController is a hysteresis controller shedding render work when
+// frames take longer than the frame budget (1/FPS) or the ring buffer starts
+// dropping frames. The output keeps its frame rate: skipped frames re-send
+// the previous one. Only the render loop calls it, except Stats.
+type FrameSkipController struct {
+	mu     sync.Mutex
+	budget time.Duration
+	avg    time.Duration // Smoothed frame time
+	level  int
+
+	overSince   time.Time
+	underSince  time.Time
+	lastDropped int64     // Ring buffer drop counter at the last observation
+	droppedAt   time.Time // Last observation that saw new drops
+
+	frame   uint64            // Frames seen (decides which ones SkipFrames renders)
+	skipped uint64            // Frames re-sent instead of rendered
+	lite    game.GameSnapshot // Stripped snapshot scratch (no allocation per frame)
+}
+
+// NewFrameSkipController creates a controller for the given output frame rate
+func NewFrameSkipController(fps int) *FrameSkipController {
+	if fps <= 0 {
+		fps = 24
+	}
+	return &FrameSkipController{budget: time.Second / time.Duration(fps)}
+}
+
+// Level returns the current frame skip level (SkipNone for a nil controller)
+func (c *FrameSkipController) Level() int {
+	if c == nil {
+		return SkipNone
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.level
+}
+
+// SkipsOverlays reports whether UI-heavy widgets are currently dropped
+func (c *FrameSkipController) SkipsOverlays() bool {
+	return c.Level() >= SkipOverlays
+}
+
+// ShouldRender reports whether this frame is rendered or the previous one re-sent.
+// Call once per output frame.
+func (c *FrameSkipController) ShouldRender() bool {
+	if c == nil {
+		return true
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.frame++
+	if c.level >= SkipFrames && c.frame%2 == 0 {
+		c.skipped++
+		return false
+	}
+	return true
+}
+
+// Strip returns the snapshot without the layers the current level drops.
+// The result is only valid until the next call.
+func (c *FrameSkipController) Strip(snap *game.GameSnapshot) *game.GameSnapshot {
+	if c.Level() < SkipParticles {
+		return snap
+	}
+	c.lite = *snap
+	c.lite.Particles = nil
+	c.lite.Texts = nil
+	return &c.lite
+}
+
+// Observe records a rendered frame's time and the ring buffer drop counter.
+// Returns the new level and true when it changed.
+func (c *FrameSkipController) Observe(frameTime time.Duration, dropped int64, now time.Time) (int, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.avg == 0 {
+		c.avg = frameTime
+	} else {
+		c.avg += time.Duration(frameSkipSmoothing * float64(frameTime-c.avg))
+	}
+	if dropped > c.lastDropped {
+		c.droppedAt = now
+	}
+	c.lastDropped = dropped
+	dropping := !c.droppedAt.IsZero() && now.Sub(c.droppedAt) < frameSkipDownAfter
+
+	switch {
+	case dropping || c.avg > time.Duration(frameSkipOver*float64(c.budget)):
+		c.underSince = time.Time{}
+		if c.overSince.IsZero() {
+			c.overSince = now
+		}
+		if now.Sub(c.overSince) >= frameSkipDownAfter && c.level < SkipFrames {
+			c.level++
+			c.overSince = time.Time{}
+			return c.level, true
+		}
+
+	case c.avg < time.Duration(frameSkipUnder*float64(c.budget)):
+		c.overSince = time.Time{}
+		if c.underSince.IsZero() {
+			c.underSince = now
+		}
+		if now.Sub(c.underSince) >= frameSkipUpAfter && c.level > SkipNone {
+			c.level--
+			c.underSince = time.Time{}
+			return c.level, true
+		}
+
+	default:
+		// Between the thresholds: no trend either way
+		c.overSince = time.Time{}
+		c.underSince = time.Time{}
+	}
+	return c.level, false
+}
+
+// AverageFrameTime returns the smoothed frame time
+func (c *FrameSkipController) AverageFrameTime() time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.avg
+}
+
+// Budget returns the frame time budget (1/FPS)
+func (c *FrameSkipController) Budget() time.Duration {
+	return c.budget
+}
+
+// Stats returns frame skip state for the stream stats
+func (c *FrameSkipController) Stats() map[string]interface{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return map[string]interface{}{
+		"level":         skipLevelNames[c.level],
+		"avgFrameMs":    float64(c.avg.Microseconds()) / 1000,
+		"budgetMs":      float64(c.budget.Microseconds()) / 1000,
+		"skippedFrames": c.skipped,
+	}
+}
+
+// observeFrameTime feeds a rendered frame's time to the frame skip controller
+// and logs level changes
+func (s *StreamManager) observeFrameTime(frameTime time.Duration, now time.Time) {
+	level, changed := s.frameSkip.Observe(frameTime, atomic.LoadInt64(&s.framesDropped), now)
+	if !changed {
+		return
+	}
+	avg := s.frameSkip.AverageFrameTime().Round(100 * time.Microsecond)
+	budget := s.frameSkip.Budget().Round(100 * time.Microsecond)
+	if level == SkipNone {
+		log.Printf("⏩ Renderer caught up (avg %s of %s budget), rendering every frame in full", avg, budget)
+		return
+	}
+	log.Printf("⏭️ Renderer at avg %s of %s budget, frame skip level: %s", avg, budget, skipLevelNames[level])
+}
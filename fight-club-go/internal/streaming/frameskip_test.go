@@ -0,0 +1,77 @@
+package streaming
+
+import (
+	"testing"
+	"time"
+
+	"fight-club/internal/game"
+)
+
+// TestFrameSkipController verifies layers are shed in order under sustained
+// lag, whole frames are skipped last, and full quality returns with headroom
+func TestFrameSkipController(t *testing.T) {
+	c := NewFrameSkipController(25) // 40ms budget
+	now := time.Unix(0, 0)
+	var dropped, dropRate int64
+	// feed observes one frame every 40ms for d; returns the last level change
+	feed := func(frameTime time.Duration, d time.Duration) (int, bool) {
+		level, changed := c.Level(), false
+		for end := now.Add(d); now.Before(end); now = now.Add(40 * time.Millisecond) {
+			dropped += dropRate
+			if l, ok := c.Observe(frameTime, dropped, now); ok {
+				level, changed = l, true
+			}
+		}
+		return level, changed
+	}
+
+	if _, changed := feed(20*time.Millisecond, 2*time.Second); changed || !c.ShouldRender() {
+		t.Fatal("Expected full quality within budget")
+	}
+
+	if level, changed := feed(50*time.Millisecond, 1500*time.Millisecond); !changed || level != SkipParticles {
+		t.Fatalf("Expected particles shed first, got %s (%v)", skipLevelNames[level], changed)
+	}
+	snap := &game.GameSnapshot{Particles: make([]game.ParticleSnapshot, 3), Players: make([]game.PlayerSnapshot, 2)}
+	if lite := c.Strip(snap); len(lite.Particles) != 0 || len(lite.Players) != 2 || len(snap.Particles) != 3 {
+		t.Errorf("Expected a copy without particles, got %d particles / %d players", len(lite.Particles), len(lite.Players))
+	}
+
+	feed(50*time.Millisecond, 1100*time.Millisecond)
+	if !c.SkipsOverlays() {
+		t.Fatalf("Expected overlays shed next, got %s", skipLevelNames[c.Level()])
+	}
+
+	// Frame drops alone count as falling behind
+	dropRate = 1
+	if level, changed := feed(30*time.Millisecond, time.Second); !changed || level != SkipFrames {
+		t.Fatalf("Expected frame skipping on rising drops, got %s (%v)", skipLevelNames[level], changed)
+	}
+	rendered := 0
+	for i := 0; i < 10; i++ {
+		if c.ShouldRender() {
+			rendered++
+		}
+	}
+	if rendered != 5 {
+		t.Errorf("Expected every other frame rendered, got %d of 10", rendered)
+	}
+
+	// Recovery: one level per 5s of headroom
+	dropRate = 0
+	if level, changed := feed(5*time.Millisecond, 6*time.Second); !changed || level != SkipOverlays {
+		t.Fatalf("Expected recovery to overlays, got %s (%v)", skipLevelNames[level], changed)
+	}
+	feed(5*time.Millisecond, 12*time.Second)
+	if c.Level() != SkipNone || c.Strip(snap) != snap {
+		t.Errorf("Expected full quality after sustained headroom, got %s", skipLevelNames[c.Level()])
+	}
+}
+
+// TestFrameSkipNil verifies a nil controller renders everything
+func TestFrameSkipNil(t *testing.T) {
+	var c *FrameSkipController
+	if !c.ShouldRender() || c.SkipsOverlays() || c.Level() != SkipNone {
+		t.Error("Expected a nil controller to render every frame in full")
+	}
+}
@@ -0,0 +1,113 @@
+package streaming
+
+import (
+	"time"
+
+	"fight-club/internal/game"
+)
+
+// Interpolation limits
+const (
+	// maxInterpolationGap: snapshots further apart than this (stall, reconnect)
+	// are not blended - the renderer shows the latest one as-is
+	maxInterpolationGap = 250 * time.Millisecond
+
+	// maxInterpolationJump: moves longer than this between two snapshots are
+	// teleports (respawn, duel ring) and snap instead of sliding across the arena
+	maxInterpolationJump = 200.0
+)
+
+// snapshotInterpolator blends the last two game snapshots so motion stays
+// smooth when the stream renders more frames than the game ticks.
+// The rendered state trails the latest snapshot by one tick: at the moment
+// a snapshot arrives the previous one is shown, sliding towards the new
+// one over the tick interval. Owned by the render loop (not thread-safe).
+type snapshotInterpolator struct {
+	out         game.GameSnapshot
+	players     []game.PlayerSnapshot
+	projectiles []game.ProjectileSnapshot
+
+	prevPlayers     map[string]int // Player ID -> index in prev.Players
+	prevProjectiles map[string]int // Projectile ID -> index in prev.Projectiles
+}
+
+// newSnapshotInterpolator creates an interpolator with reusable buffers
+func newSnapshotInterpolator() *snapshotInterpolator {
+	return &snapshotInterpolator{
+		prevPlayers:     make(map[string]int),
+		prevProjectiles: make(map[string]int),
+	}
+}
+
+// interpolationAlpha returns how far (0..1) the render time is between prev
+// and curr. curr arrived at currAt; the tick hint (TickNumber, TickRate) gives
+// the simulated interval, falling back to the snapshot timestamps.
+// ok is false when the pair can't be blended.
+func interpolationAlpha(prev, curr *game.GameSnapshot, currAt, now time.Time) (float64, bool) {
+	if prev == nil || curr == nil || prev.Sequence >= curr.Sequence {
+		return 1, false
+	}
+	interval := curr.Timestamp.Sub(prev.Timestamp)
+	if interval > maxInterpolationGap {
+		return 1, false
+	}
+	// Catch-up ticks are published back-to-back: their timestamps are only
+	// microseconds apart, but each still covers a full simulation step
+	if curr.TickRate > 0 && curr.TickNumber > prev.TickNumber {
+		interval = time.Duration(curr.TickNumber-prev.TickNumber) * time.Second / time.Duration(curr.TickRate)
+	}
+	if interval <= 0 || interval > maxInterpolationGap {
+		return 1, false
+	}
+	alpha := float64(now.Sub(currAt)) / float64(interval)
+	return min(max(alpha, 0), 1), true
+}
+
+// Sample returns the state to render at now. The result is curr with player
+// and projectile positions blended from prev; it is only valid until the next call.
+func (ip *snapshotInterpolator) Sample(prev, curr *game.GameSnapshot, currAt, now time.Time) *game.GameSnapshot {
+	alpha, ok := interpolationAlpha(prev, curr, currAt, now)
+	if !ok || alpha >= 1 || curr.Paused {
+		return curr
+	}
+
+	// Shallow copy: everything but players and projectiles is shared read-only
+	ip.out = *curr
+
+	clear(ip.prevPlayers)
+	for i := range prev.Players {
+		ip.prevPlayers[prev.Players[i].ID] = i
+	}
+	ip.players = append(ip.players[:0], curr.Players...)
+	for i := range ip.players {
+		p := &ip.players[i]
+		if j, found := ip.prevPlayers[p.ID]; found {
+			p.X, p.Y = lerpPosition(prev.Players[j].X, prev.Players[j].Y, p.X, p.Y, alpha)
+		}
+	}
+	ip.out.Players = ip.players
+
+	clear(ip.prevProjectiles)
+	for i := range prev.Projectiles {
+		ip.prevProjectiles[prev.Projectiles[i].ID] = i
+	}
+	ip.projectiles = append(ip.projectiles[:0], curr.Projectiles...)
+	for i := range ip.projectiles {
+		proj := &ip.projectiles[i]
+		if j, found := ip.prevProjectiles[proj.ID]; found && proj.ID != "" {
+			proj.X, proj.Y = lerpPosition(prev.Projectiles[j].X, prev.Projectiles[j].Y, proj.X, proj.Y, alpha)
+		}
+	}
+	ip.out.Projectiles = ip.projectiles
+
+	return &ip.out
+}
+
+// lerpPosition blends (x0, y0) towards (x1, y1), snapping on teleports
+func lerpPosition(x0, y0, x1, y1, alpha float64) (float64, float64) {
+	dx, dy := x1-x0, y1-y0
+	if dx*dx+dy*dy > maxInterpolationJump*maxInterpolationJump {
+		return x1, y1
+	}
+	return x0 + dx*alpha, y0 + dy*alpha
+}
@@ -0,0 +1,101 @@
+package streaming
+
+import (
+	"testing"
+	"time"
+
+	"fight-club/internal/game"
+)
+
+// TestSnapshotInterpolatorBlendsPositions verifies players and projectiles
+// slide between snapshots, teleports snap, and stale pairs aren't blended
+func TestSnapshotInterpolatorBlendsPositions(t *testing.T) {
+	t0 := time.Unix(100, 0)
+	interval := 40 * time.Millisecond
+	prev := &game.GameSnapshot{
+		Sequence:  1,
+		Timestamp: t0,
+		Players: []game.PlayerSnapshot{
+			{ID: "a", X: 100, Y: 100},
+			{ID: "b", X: 100, Y: 100},
+		},
+		Projectiles: []game.ProjectileSnapshot{{ID: "p", X: 0, Y: 0}},
+	}
+	curr := &game.GameSnapshot{
+		Sequence:  2,
+		Timestamp: t0.Add(interval),
+		Players: []game.PlayerSnapshot{
+			{ID: "a", X: 110, Y: 90},
+			{ID: "b", X: 900, Y: 100}, // Teleported
+			{ID: "c", X: 50, Y: 50},   // Just joined
+		},
+		Projectiles: []game.ProjectileSnapshot{{ID: "p", X: 20, Y: 0}},
+	}
+
+	ip := newSnapshotInterpolator()
+	arrived := time.Unix(200, 0)
+	out := ip.Sample(prev, curr, arrived, arrived.Add(interval/2))
+
+	if a := out.Players[0]; a.X != 105 || a.Y != 95 {
+		t.Errorf("Expected a halfway at (105, 95), got (%.1f, %.1f)", a.X, a.Y)
+	}
+	if b := out.Players[1]; b.X != 900 {
+		t.Errorf("Expected teleport to snap, got x=%.1f", b.X)
+	}
+	if c := out.Players[2]; c.X != 50 {
+		t.Errorf("Expected new player at its position, got x=%.1f", c.X)
+	}
+	if p := out.Projectiles[0]; p.X != 10 {
+		t.Errorf("Expected projectile halfway, got x=%.1f", p.X)
+	}
+	if curr.Players[0].X != 110 {
+		t.Error("Expected the source snapshot left untouched")
+	}
+
+	// Render time before arrival clamps to prev, after a full interval shows curr
+	if out := ip.Sample(prev, curr, arrived, arrived.Add(-time.Second)); out.Players[0].X != 100 {
+		t.Errorf("Expected alpha clamped to 0, got x=%.1f", out.Players[0].X)
+	}
+	if out := ip.Sample(prev, curr, arrived, arrived.Add(time.Second)); out != curr {
+		t.Error("Expected curr once the interval has passed")
+	}
+
+	// A stalled pair (or a restarted sequence) isn't blended
+	stale := *prev
+	stale.Timestamp = t0.Add(-time.Second)
+	if out := ip.Sample(&stale, curr, arrived, arrived); out != curr {
+		t.Error("Expected no blending across a stall")
+	}
+	restarted := *curr
+	restarted.Sequence = 1
+	if out := ip.Sample(prev, &restarted, arrived, arrived); out != &restarted {
+		t.Error("Expected no blending across a sequence restart")
+	}
+}
+
+// TestInterpolationTickHint verifies the tick hint sets the blend interval,
+// so back-to-back catch-up snapshots still slide over a full simulation step
+func TestInterpolationTickHint(t *testing.T) {
+	t0 := time.Unix(100, 0)
+	prev := &game.GameSnapshot{Sequence: 1, Timestamp: t0, TickNumber: 10, TickRate: 60}
+	curr := &game.GameSnapshot{Sequence: 2, Timestamp: t0.Add(time.Millisecond), TickNumber: 11, TickRate: 60}
+
+	arrived := time.Unix(200, 0)
+	step := time.Second / 60
+	alpha, ok := interpolationAlpha(prev, curr, arrived, arrived.Add(step/2))
+	if !ok || alpha < 0.49 || alpha > 0.51 {
+		t.Errorf("Expected halfway through a 60 TPS step, got alpha %.2f (ok=%v)", alpha, ok)
+	}
+
+	// Two ticks between snapshots (30 FPS stream of a 60 TPS game)
+	curr.TickNumber = 12
+	if alpha, _ := interpolationAlpha(prev, curr, arrived, arrived.Add(step)); alpha < 0.49 || alpha > 0.51 {
+		t.Errorf("Expected halfway through two steps, got alpha %.2f", alpha)
+	}
+
+	// Without the hint the timestamps decide (older servers)
+	curr.TickRate = 0
+	if alpha, _ := interpolationAlpha(prev, curr, arrived, arrived.Add(step)); alpha != 1 {
+		t.Errorf("Expected the timestamp interval without a hint, got alpha %.2f", alpha)
+	}
+}
@@ -0,0 +1,61 @@
+package streaming
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+
+	"fight-club/internal/game"
+
+	"github.com/fogleman/gg"
+)
+
+// joinQueueAccent is the "Up next" card accent (title and edge)
+var joinQueueAccent = color.RGBA{0, 212, 255, 255}
+
+// joinQueueText returns the "Up next" names line ("" = nobody waiting)
+func joinQueueText(q game.JoinQueueSnapshot) string {
+	if q.Total == 0 {
+		return ""
+	}
+	text := strings.Join(q.Names[:q.Count], ", ")
+	if more := q.Total - q.Count; more > 0 {
+		text += fmt.Sprintf("  +%d more", more)
+	}
+	return text
+}
+
+// drawJoinQueue draws the viewers waiting for a slot when the arena is full,
+// starting at x (ending at x when alignRight)
+func (s *StreamManager) drawJoinQueue(dc *gg.Context, q game.JoinQueueSnapshot, x, y float64, alignRight bool) {
+	text := joinQueueText(q)
+	if text == "" {
+		return
+	}
+	if s.fontsLoaded && s.fontSmall != nil {
+		dc.SetFontFace(s.fontSmall)
+	} else {
+		_ = s.loadFontFace(dc, 13)
+	}
+
+	textWidth, _ := dc.MeasureString(text)
+	width := textWidth + 32
+	height := 52.0
+	if alignRight {
+		x -= width
+	}
+
+	dc.SetColor(color.RGBA{0, 0, 0, 25})
+	dc.DrawRoundedRectangle(x+3, y+3, width, height, 6)
+	dc.Fill()
+	dc.SetColor(color.RGBA{18, 18, 24, 245})
+	dc.DrawRoundedRectangle(x, y, width, height, 6)
+	dc.Fill()
+	dc.SetColor(joinQueueAccent)
+	dc.DrawRoundedRectangle(x, y, 4, height, 2)
+	dc.Fill()
+
+	dc.DrawString("UP NEXT - ARENA FULL", x+16, y+21)
+	dc.SetColor(color.RGBA{255, 255, 255, 255})
+	dc.DrawString(text, x+16, y+41)
+}
@@ -0,0 +1,44 @@
+package streaming
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sync/atomic"
+	"time"
+)
+
+// KeyframeCooldown is the minimum time between forced keyframes (each one
+// briefly reconnects the stream)
+const KeyframeCooldown = 10 * time.Second
+
+// ErrNotStreaming is returned by stream actions that need a live stream
+var ErrNotStreaming = errors.New("not streaming")
+
+// ForceKeyframe clears a corrupted picture on viewers' players right away
+// instead of at the next GOP keyframe (up to 2s later, longer for players
+// that lost sync). FFmpeg takes no commands while it reads raw frames from
+// the pipe, so the encoder is restarted like Resize does: the first frame of
+// the new encoder is an IDR frame, and the RTMP connection is back within
+// about a second.
+func (s *StreamManager) ForceKeyframe() error {
+	if !s.IsStreaming() {
+		return ErrNotStreaming
+	}
+	now := time.Now()
+	if last := atomic.LoadInt64(&s.lastKeyframe); last != 0 {
+		if wait := KeyframeCooldown - now.Sub(time.Unix(0, last)); wait > 0 {
+			return fmt.Errorf("a keyframe was just forced, try again in %s", wait.Round(time.Second))
+		}
+	}
+	if !atomic.CompareAndSwapInt32(&s.reconnecting, 0, 1) {
+		return fmt.Errorf("stream is reconnecting, try again")
+	}
+	defer atomic.StoreInt32(&s.reconnecting, 0)
+	atomic.StoreInt64(&s.lastKeyframe, now.UnixNano())
+
+	log.Println("🔑 Forcing a keyframe (restarting the encoder)...")
+	s.stopInternal()
+	time.Sleep(500 * time.Millisecond) // Let the frame loop finish (see Restart)
+	return s.Start()
+}
@@ -0,0 +1,150 @@
+package streaming
+
+import (
+	"fmt"
+	"image/color"
+
+	"fight-club/internal/game"
+
+	"github.com/fogleman/gg"
+)
+
+// Session leaderboard layouts (ThemeConfig.Leaderboard)
+const (
+	LeaderboardTop     = "top"     // Top killers only
+	LeaderboardPages   = "pages"   // Rotate through every alive fighter, one column
+	LeaderboardColumns = "columns" // Rotate through every alive fighter, two condensed columns
+)
+
+// DefaultLeaderboardPageSeconds is how long each leaderboard page stays up
+const DefaultLeaderboardPageSeconds = 5.0
+
+// Session leaderboard size (px). Every layout is leaderboardRows tall, so the
+// persistent leaderboard below it never moves.
+const (
+	leaderboardRows        = 5
+	leaderboardHeaderGap   = 24.0
+	leaderboardRowSpacing  = 26.0
+	leaderboardColumnWidth = 140.0 // Condensed column, names are shortened to fit
+	leaderboardColumnGap   = 12.0
+)
+
+// leaderboardHeight is the session leaderboard height from its header baseline
+const leaderboardHeight = leaderboardHeaderGap + leaderboardRows*leaderboardRowSpacing
+
+// leaderboardPage is the slice of the sorted players a leaderboard frame shows
+type leaderboardPage struct {
+	start, end int // Players shown (start = rank-1 of the first)
+	columns    int
+	page       int // Zero-based
+	pages      int
+}
+
+// layoutLeaderboard picks the players to show. Players are sorted alive first,
+// then by kills. The paged layouts only kick in when more fighters are alive
+// than fit; otherwise (and in "top") the top killers are shown as always.
+func layoutLeaderboard(mode string, players []game.PlayerSnapshot, seconds, pageSeconds float64) leaderboardPage {
+	alive := 0
+	for alive < len(players) && !players[alive].IsDead {
+		alive++
+	}
+	if (mode != LeaderboardPages && mode != LeaderboardColumns) || alive <= leaderboardRows {
+		return leaderboardPage{end: min(leaderboardRows, len(players)), columns: 1, pages: 1}
+	}
+
+	columns := 1
+	if mode == LeaderboardColumns {
+		columns = 2
+	}
+	perPage := leaderboardRows * columns
+	pages := (alive + perPage - 1) / perPage
+	if pageSeconds <= 0 {
+		pageSeconds = DefaultLeaderboardPageSeconds
+	}
+	page := int(seconds/pageSeconds) % pages
+	start := page * perPage
+	return leaderboardPage{start: start, end: min(start+perPage, alive), columns: columns, page: page, pages: pages}
+}
+
+// snapshotSeconds is the game time of a snapshot (paging clock; 0 without a tick rate)
+func snapshotSeconds(snap *game.GameSnapshot) float64 {
+	if snap.TickRate <= 0 {
+		return 0
+	}
+	return float64(snap.TickNumber) / float64(snap.TickRate)
+}
+
+// sessionLeaderboardPage returns the leaderboard page a snapshot shows
+func (s *StreamManager) sessionLeaderboardPage(snap *game.GameSnapshot) leaderboardPage {
+	theme := s.config.Theme
+	return layoutLeaderboard(theme.Leaderboard, snap.Players, snapshotSeconds(snap), theme.LeaderboardPageSeconds)
+}
+
+// drawSessionLeaderboard draws the session leaderboard in the theme's layout
+func (s *StreamManager) drawSessionLeaderboard(dc *gg.Context, snap *game.GameSnapshot, x, y float64) {
+	s.drawLeaderboardPage(dc, snap.Players, s.sessionLeaderboardPage(snap), x, y)
+}
+
+// drawLeaderboardPage draws a page of the leaderboard: header, then ranks
+// filled column by column
+func (s *StreamManager) drawLeaderboardPage(dc *gg.Context, players []game.PlayerSnapshot, lp leaderboardPage, x, y float64) {
+	if lp.end <= lp.start {
+		return
+	}
+
+	if s.fontsLoaded && s.fontSmall != nil {
+		dc.SetFontFace(s.fontSmall)
+	} else {
+		_ = s.loadFontFace(dc, 14)
+	}
+
+	// Header with accent color, plus the page while rotating
+	header := "TOP KILLERS"
+	if lp.pages > 1 {
+		header = fmt.Sprintf("%s · %d/%d", header, lp.page+1, lp.pages)
+	}
+	dc.SetColor(color.RGBA{0, 180, 220, 255}) // Cyan accent
+	dc.DrawString(header, x, y)
+
+	for i := lp.start; i < lp.end; i++ {
+		p := players[i]
+		row := (i - lp.start) % leaderboardRows
+		col := (i - lp.start) / leaderboardRows
+		dc.SetColor(leaderboardRankColor(i))
+
+		// Clean format: "1. Name · kills"
+		text := fmt.Sprintf("%d. %s · %d", i+1, p.Name, p.Kills)
+		if lp.columns > 1 {
+			text = fitLeaderboardEntry(dc, i+1, p.Name, p.Kills, leaderboardColumnWidth)
+		}
+		dc.DrawString(text, x+float64(col)*(leaderboardColumnWidth+leaderboardColumnGap),
+			y+leaderboardHeaderGap+float64(row)*leaderboardRowSpacing)
+	}
+}
+
+// leaderboardRankColor is gold/silver/bronze for the top 3, gray for the rest
+func leaderboardRankColor(index int) color.RGBA {
+	switch index {
+	case 0:
+		return color.RGBA{255, 200, 60, 255} // Gold
+	case 1:
+		return color.RGBA{180, 185, 195, 255} // Silver
+	case 2:
+		return color.RGBA{205, 150, 90, 255} // Bronze
+	default:
+		return color.RGBA{120, 125, 140, 255} // Gray
+	}
+}
+
+// fitLeaderboardEntry formats an entry, shortening the name until it fits width
+func fitLeaderboardEntry(dc *gg.Context, rank int, name string, kills int, width float64) string {
+	text := fmt.Sprintf("%d. %s · %d", rank, name, kills)
+	runes := []rune(name)
+	for n := len(runes) - 1; n > 0; n-- {
+		if w, _ := dc.MeasureString(text); w <= width {
+			break
+		}
+		text = fmt.Sprintf("%d. %s… · %d", rank, string(runes[:n]), kills)
+	}
+	return text
+}
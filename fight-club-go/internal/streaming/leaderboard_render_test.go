@@ -0,0 +1,70 @@
+package streaming
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"fight-club/internal/game"
+
+	"github.com/fogleman/gg"
+)
+
+// leaderboardTestPlayers returns alive fighters (most kills first) followed by dead ones
+func leaderboardTestPlayers(alive, dead int) []game.PlayerSnapshot {
+	var players []game.PlayerSnapshot
+	for i := 0; i < alive; i++ {
+		players = append(players, game.PlayerSnapshot{Name: fmt.Sprintf("alive%d", i), Kills: alive - i})
+	}
+	for i := 0; i < dead; i++ {
+		players = append(players, game.PlayerSnapshot{Name: fmt.Sprintf("dead%d", i), IsDead: true})
+	}
+	return players
+}
+
+// TestLeaderboardLayout verifies paging through alive fighters and the top-killers fallback
+func TestLeaderboardLayout(t *testing.T) {
+	crowd := leaderboardTestPlayers(23, 4)
+
+	if lp := layoutLeaderboard(LeaderboardTop, crowd, 60, 5); lp.start != 0 || lp.end != 5 || lp.pages != 1 {
+		t.Errorf("Expected the top 5 in the top layout, got %+v", lp)
+	}
+	if lp := layoutLeaderboard(LeaderboardPages, leaderboardTestPlayers(4, 3), 60, 5); lp.end != 5 || lp.pages != 1 {
+		t.Errorf("Expected no paging with 5 or fewer alive, got %+v", lp)
+	}
+
+	// 23 alive: 5 pages of 5 rows, the last page short and no dead fighters
+	for _, tc := range []struct {
+		seconds     float64
+		page, start int
+		end         int
+	}{{0, 0, 0, 5}, {4.9, 0, 0, 5}, {5, 1, 5, 10}, {20, 4, 20, 23}, {25, 0, 0, 5}} {
+		lp := layoutLeaderboard(LeaderboardPages, crowd, tc.seconds, 5)
+		if lp.pages != 5 || lp.page != tc.page || lp.start != tc.start || lp.end != tc.end || lp.columns != 1 {
+			t.Errorf("At %.1fs expected page %d [%d, %d), got %+v", tc.seconds, tc.page, tc.start, tc.end, lp)
+		}
+	}
+
+	// Two columns: 10 per page
+	if lp := layoutLeaderboard(LeaderboardColumns, crowd, 12, 5); lp.pages != 3 || lp.start != 20 || lp.end != 23 || lp.columns != 2 {
+		t.Errorf("Expected the third two-column page [20, 23), got %+v", lp)
+	}
+	if lp := layoutLeaderboard(LeaderboardColumns, leaderboardTestPlayers(8, 0), 99, 5); lp.pages != 1 || lp.end != 8 || lp.columns != 2 {
+		t.Errorf("Expected 8 alive on one two-column page, got %+v", lp)
+	}
+	if lp := layoutLeaderboard(LeaderboardPages, crowd, DefaultLeaderboardPageSeconds, 0); lp.page != 1 {
+		t.Errorf("Expected the default page time without one configured, got page %d", lp.page)
+	}
+}
+
+// TestLeaderboardColumnFit verifies long names are shortened to the column width
+func TestLeaderboardColumnFit(t *testing.T) {
+	dc := gg.NewContext(200, 50)
+	if text := fitLeaderboardEntry(dc, 3, "bob", 2, leaderboardColumnWidth); text != "3. bob · 2" {
+		t.Errorf("Expected a short name untouched, got %q", text)
+	}
+	text := fitLeaderboardEntry(dc, 12, strings.Repeat("W", 40), 7, leaderboardColumnWidth)
+	if w, _ := dc.MeasureString(text); w > leaderboardColumnWidth || !strings.Contains(text, "…") {
+		t.Errorf("Expected a shortened name within %.0fpx, got %q (%.0fpx)", leaderboardColumnWidth, text, w)
+	}
+}
@@ -0,0 +1,153 @@
+package streaming
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"fight-club/internal/game"
+
+	"github.com/fogleman/gg"
+)
+
+// Coin pile and dropped weapon tuning
+const (
+	lootCoinRadius   = 8.0
+	lootCoinStack    = 4.0 // Vertical offset between stacked coins (px)
+	lootCoinValue    = 50  // Money per drawn coin
+	lootMaxCoins     = 5   // Coins drawn for the largest piles
+	lootBlinkSeconds = 3.0 // Piles blink this long before despawning
+
+	weaponDropRadius = 15.0
+	weaponDropBob    = 3.0 // Dropped weapons bob up and down this much (px)
+)
+
+// Coin and dropped weapon colors
+var (
+	lootCoinFace  = color.RGBA{255, 196, 0, 255}
+	lootCoinRim   = color.RGBA{184, 122, 0, 255}
+	lootCoinShine = color.RGBA{255, 240, 170, 255}
+
+	weaponDropBack = color.RGBA{30, 33, 45, 230}
+)
+
+// lootCoins returns how many coins to draw for a pile (1..lootMaxCoins, by amount)
+func lootCoins(l game.LootSnapshot) int {
+	return max(1, min(lootMaxCoins, (l.Amount+lootCoinValue-1)/lootCoinValue))
+}
+
+// lootVisible reports whether a pile or weapon with remaining seconds left is
+// drawn this frame (it blinks before despawning)
+func lootVisible(remaining float64) bool {
+	return remaining > lootBlinkSeconds || math.Mod(remaining, 0.5) > 0.25
+}
+
+// weaponDropY returns the bobbing height of a dropped weapon
+func weaponDropY(w game.WeaponDropSnapshot) float64 {
+	return w.Y - weaponDropBob*math.Sin(w.Remaining*math.Pi)
+}
+
+// drawCoin draws a single coin centered on (x, y)
+func drawCoin(dc *gg.Context, x, y float64) {
+	dc.SetColor(lootCoinFace)
+	dc.DrawCircle(x, y, lootCoinRadius)
+	dc.Fill()
+	dc.SetColor(lootCoinRim)
+	dc.SetLineWidth(2)
+	dc.DrawCircle(x, y, lootCoinRadius-1)
+	dc.Stroke()
+	dc.SetColor(lootCoinShine)
+	dc.DrawCircle(x-2.5, y-2.5, 2)
+	dc.Fill()
+}
+
+// drawLoot draws dropped coin piles on the arena floor (under players)
+func (s *StreamManager) drawLoot(dc *gg.Context, loot []game.LootSnapshot) {
+	for _, l := range loot {
+		if !lootVisible(l.Remaining) {
+			continue
+		}
+		for k := 0; k < lootCoins(l); k++ {
+			drawCoin(dc, l.X, l.Y-float64(k)*lootCoinStack)
+		}
+	}
+}
+
+// coinSprite returns the cached coin sprite
+func (a *AtlasRenderer) coinSprite() *sprite {
+	if a.coin == nil {
+		size := int(math.Ceil(lootCoinRadius*2)) + 4
+		dc := gg.NewContext(size, size)
+		drawCoin(dc, float64(size)/2, float64(size)/2)
+		a.coin = newSprite(dc.Image().(*image.RGBA))
+	}
+	return a.coin
+}
+
+// drawLoot draws dropped coin piles with the cached coin sprite (mirrors StreamManager.drawLoot)
+func (a *AtlasRenderer) drawLoot(buffer []byte, loot []game.LootSnapshot) {
+	coin := a.coinSprite()
+	for _, l := range loot {
+		if !lootVisible(l.Remaining) {
+			continue
+		}
+		for k := 0; k < lootCoins(l); k++ {
+			a.blit(buffer, coin, l.X, l.Y-float64(k)*lootCoinStack, 255)
+		}
+	}
+}
+
+// drawWeaponDropBadge draws a dropped weapon (a dark disc ringed in the weapon
+// color with its emoji) centered on (x, y) with the font set on dc
+func drawWeaponDropBadge(dc *gg.Context, weapon game.Weapon, x, y float64) {
+	dc.SetColor(weaponDropBack)
+	dc.DrawCircle(x, y, weaponDropRadius)
+	dc.Fill()
+	dc.SetColor(parseHexColor(weapon.Color))
+	dc.SetLineWidth(3)
+	dc.DrawCircle(x, y, weaponDropRadius-1.5)
+	dc.Stroke()
+	dc.SetColor(color.White)
+	dc.DrawStringAnchored(weapon.Emoji, x, y, 0.5, 0.5)
+}
+
+// drawWeaponDrops draws dropped weapons on the arena floor (under players)
+func (s *StreamManager) drawWeaponDrops(dc *gg.Context, drops []game.WeaponDropSnapshot) {
+	if len(drops) == 0 {
+		return
+	}
+	if s.fontsLoaded && s.fontSmall != nil {
+		dc.SetFontFace(s.fontSmall)
+	}
+	for _, w := range drops {
+		if lootVisible(w.Remaining) {
+			drawWeaponDropBadge(dc, game.GetWeapon(w.Weapon), w.X, weaponDropY(w))
+		}
+	}
+}
+
+// weaponDropSprite returns the cached badge sprite for a weapon
+func (a *AtlasRenderer) weaponDropSprite(id string) *sprite {
+	if sp, ok := a.weaponDrops[id]; ok {
+		return sp
+	}
+	if a.weaponDrops == nil {
+		a.weaponDrops = make(map[string]*sprite)
+	}
+	size := int(math.Ceil(weaponDropRadius*2)) + 4
+	dc := gg.NewContext(size, size)
+	dc.SetFontFace(a.s.fontSmall)
+	drawWeaponDropBadge(dc, game.GetWeapon(id), float64(size)/2, float64(size)/2)
+	sp := newSprite(dc.Image().(*image.RGBA))
+	a.weaponDrops[id] = sp
+	return sp
+}
+
+// drawWeaponDrops draws dropped weapons with cached badge sprites (mirrors StreamManager.drawWeaponDrops)
+func (a *AtlasRenderer) drawWeaponDrops(buffer []byte, drops []game.WeaponDropSnapshot) {
+	for _, w := range drops {
+		if lootVisible(w.Remaining) {
+			a.blit(buffer, a.weaponDropSprite(w.Weapon), w.X, weaponDropY(w), 255)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package streaming
+
+import (
+	"image/color"
+	"testing"
+
+	"fight-club/internal/game"
+
+	"github.com/fogleman/gg"
+)
+
+// TestWeaponDropRender verifies both backends ring a dropped sword in the sword
+// color and hide it on the blink-off phase before it despawns
+func TestWeaponDropRender(t *testing.T) {
+	drop := game.WeaponDropSnapshot{X: 100, Y: 100, Weapon: "sword", Remaining: 10}
+	ringX, ringY := 100+weaponDropRadius-1.5, weaponDropY(drop)
+
+	check := func(t *testing.T, c color.RGBA) {
+		if c.B < 200 || c.R > 80 {
+			t.Errorf("Expected a blue sword ring pixel, got %v", c)
+		}
+	}
+	if lootVisible(2.7) || !lootVisible(2.9) || !lootVisible(10) {
+		t.Error("Expected drops to blink only in their last seconds")
+	}
+
+	t.Run("gg", func(t *testing.T) {
+		sm := &StreamManager{config: StreamConfig{Width: 200, Height: 200}}
+		dc := gg.NewContext(200, 200)
+		sm.drawWeaponDrops(dc, []game.WeaponDropSnapshot{drop})
+		check(t, color.RGBAModel.Convert(dc.Image().At(int(ringX), int(ringY))).(color.RGBA))
+	})
+
+	t.Run("atlas", func(t *testing.T) {
+		sm := newAtlasTestManager(t, 200, 200)
+		buffer := make([]byte, 200*200*4)
+		sm.atlas.fr.SetBuffer(buffer)
+		sm.atlas.drawWeaponDrops(buffer, []game.WeaponDropSnapshot{drop})
+		i := (int(ringY)*200 + int(ringX)) * 4
+		check(t, color.RGBA{buffer[i], buffer[i+1], buffer[i+2], buffer[i+3]})
+	})
+}
@@ -0,0 +1,229 @@
+package streaming
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"fight-club/internal/game"
+
+	"github.com/fogleman/gg"
+)
+
+// ThemeConfig toggles optional on-stream widgets
+type ThemeConfig struct {
+	Minimap bool // Arena minimap in the bottom-right corner
+
+	// Font files overriding the platform defaults ("" = platform font, then the
+	// bundled Go font; see fonts.go)
+	Font      string // Text font
+	EmojiFont string // Outline emoji/symbol font tried before the platform ones
+
+	// Session leaderboard layout once more fighters are alive than it lists
+	// (see leaderboard_render.go)
+	Leaderboard            string  // "top" (default), "pages" or "columns"
+	LeaderboardPageSeconds float64 // Seconds per page (0 = DefaultLeaderboardPageSeconds)
+
+	// Auto-framing camera zooming in on the fighters when few are alive
+	// (see camera.go)
+	AutoFrame           bool
+	AutoFrameMaxPlayers int     // Zoom with this many alive or fewer (0 = DefaultAutoFrameMaxPlayers)
+	AutoFrameMaxZoom    float64 // Closest zoom (0 = DefaultAutoFrameMaxZoom)
+}
+
+// Minimap widget size and placement (px)
+const (
+	minimapWidth         = 200
+	minimapHeight        = 120
+	minimapMarginRight   = 32.0
+	minimapMarginBottom  = 24.0
+	minimapDotRadius     = 3.0
+	minimapLeaderRadius  = 6.0 // Ring around the kill leader's dot
+	minimapMeteorRadius  = 2.0
+	minimapCornerRadius  = 6.0
+	minimapZoneLineWidth = 1.5
+)
+
+// Minimap colors
+var (
+	minimapBackground = color.RGBA{18, 18, 24, 220}
+	minimapBorder     = color.RGBA{0, 212, 255, 160}
+	minimapSoloDot    = color.RGBA{220, 225, 235, 255} // Players without a team
+	minimapLeader     = color.RGBA{255, 196, 0, 255}
+	minimapZoneTint   = color.RGBA{255, 60, 0, 70} // Straight alpha, as FastRenderer blends
+)
+
+// minimapRect maps world coordinates into the corner widget.
+// The world uses frame coordinates, so the widget is the frame downsampled.
+type minimapRect struct {
+	x, y           float64 // Top-left corner
+	scaleX, scaleY float64
+}
+
+// minimapLayout returns the minimap placement for a frame size
+func minimapLayout(frameWidth, frameHeight int) minimapRect {
+	return minimapRect{
+		x:      float64(frameWidth) - minimapWidth - minimapMarginRight,
+		y:      float64(frameHeight) - minimapHeight - minimapMarginBottom,
+		scaleX: minimapWidth / float64(frameWidth),
+		scaleY: minimapHeight / float64(frameHeight),
+	}
+}
+
+// project converts a world position to widget pixels (clamped inside the widget)
+func (m minimapRect) project(wx, wy float64) (float64, float64) {
+	x := math.Max(0, math.Min(minimapWidth, wx*m.scaleX))
+	y := math.Max(0, math.Min(minimapHeight, wy*m.scaleY))
+	return m.x + x, m.y + y
+}
+
+// minimapKillLeader returns the index of the alive player with the most kills (-1 if nobody has a kill)
+func minimapKillLeader(players []game.PlayerSnapshot) int {
+	leader := -1
+	for i := range players {
+		p := &players[i]
+		if p.IsDead || p.Kills == 0 {
+			continue
+		}
+		if leader < 0 || p.Kills > players[leader].Kills {
+			leader = i
+		}
+	}
+	return leader
+}
+
+// minimapDotColor returns a player's dot color: team color, or neutral when solo
+func minimapDotColor(p *game.PlayerSnapshot) color.RGBA {
+	if p.TeamColor == "" {
+		return minimapSoloDot
+	}
+	return parseHexColor(p.TeamColor)
+}
+
+// drawMinimapPanel draws the widget background and border
+func (s *StreamManager) drawMinimapPanel(dc *gg.Context, m minimapRect) {
+	dc.SetColor(color.RGBA{0, 0, 0, 25})
+	dc.DrawRoundedRectangle(m.x+3, m.y+3, minimapWidth, minimapHeight, minimapCornerRadius)
+	dc.Fill()
+	dc.SetColor(minimapBackground)
+	dc.DrawRoundedRectangle(m.x, m.y, minimapWidth, minimapHeight, minimapCornerRadius)
+	dc.Fill()
+	dc.SetColor(minimapBorder)
+	dc.SetLineWidth(1)
+	dc.DrawRoundedRectangle(m.x, m.y, minimapWidth, minimapHeight, minimapCornerRadius)
+	dc.Stroke()
+}
+
+// drawMinimap draws the arena minimap: chaos zones, player dots and the kill leader
+func (s *StreamManager) drawMinimap(dc *gg.Context, snap *game.GameSnapshot) {
+	m := minimapLayout(s.config.Width, s.config.Height)
+	s.drawMinimapPanel(dc, m)
+
+	// Chaos zones, clipped to the widget (the zone starts larger than the arena)
+	chaos := snap.Chaos
+	dc.Push()
+	dc.DrawRoundedRectangle(m.x, m.y, minimapWidth, minimapHeight, minimapCornerRadius)
+	dc.Clip()
+	if chaos.ZoneRadius > 0 {
+		zx, zy := m.project(chaos.ZoneX, chaos.ZoneY)
+		zr := chaos.ZoneRadius * m.scaleX
+
+		dc.SetFillRuleEvenOdd()
+		dc.SetColor(color.NRGBA(minimapZoneTint)) // Straight alpha (gg treats RGBA as premultiplied)
+		dc.DrawRectangle(m.x, m.y, minimapWidth, minimapHeight)
+		dc.DrawCircle(zx, zy, zr)
+		dc.Fill()
+		dc.SetFillRuleWinding()
+
+		dc.SetColor(chaosAccent)
+		dc.SetLineWidth(minimapZoneLineWidth)
+		dc.DrawCircle(zx, zy, zr)
+		dc.Stroke()
+	}
+	for i := 0; i < chaos.MeteorCount; i++ {
+		mx, my := m.project(chaos.Meteors[i].X, chaos.Meteors[i].Y)
+		dc.SetColor(chaosAccent)
+		dc.DrawCircle(mx, my, math.Max(minimapMeteorRadius, chaos.Meteors[i].Radius*m.scaleX))
+		dc.Stroke()
+	}
+	dc.ResetClip()
+	dc.Pop()
+
+	// Player dots, kill leader on top
+	leader := minimapKillLeader(snap.Players)
+	for i := range snap.Players {
+		p := &snap.Players[i]
+		if p.IsDead || i == leader {
+			continue
+		}
+		x, y := m.project(p.X, p.Y)
+		dc.SetColor(minimapDotColor(p))
+		dc.DrawCircle(x, y, minimapDotRadius)
+		dc.Fill()
+	}
+	if leader >= 0 {
+		p := &snap.Players[leader]
+		x, y := m.project(p.X, p.Y)
+		dc.SetColor(minimapLeader)
+		dc.SetLineWidth(2)
+		dc.DrawCircle(x, y, minimapLeaderRadius)
+		dc.Stroke()
+		dc.SetColor(minimapDotColor(p))
+		dc.DrawCircle(x, y, minimapDotRadius+1)
+		dc.Fill()
+	}
+}
+
+// minimapPanel returns the cached widget background sprite
+func (a *AtlasRenderer) minimapPanel() *sprite {
+	if a.minimap == nil {
+		dc := gg.NewContext(a.width, a.height)
+		a.s.drawMinimapPanel(dc, minimapLayout(a.width, a.height))
+		a.minimap = newSprite(dc.Image().(*image.RGBA))
+	}
+	return a.minimap
+}
+
+// drawMinimap draws the arena minimap with the fast renderer
+func (a *AtlasRenderer) drawMinimap(buffer []byte, snap *game.GameSnapshot) {
+	m := minimapLayout(a.width, a.height)
+	a.blit(buffer, a.minimapPanel(), float64(a.width)/2, float64(a.height)/2, 255)
+
+	chaos := snap.Chaos
+	if chaos.ZoneRadius > 0 {
+		zx, zy := m.project(chaos.ZoneX, chaos.ZoneY)
+		zr := chaos.ZoneRadius * m.scaleX
+		// Tint outside the zone and mark its edge, one widget pixel at a time (200x120)
+		for py := int(m.y); py < int(m.y)+minimapHeight; py++ {
+			for px := int(m.x); px < int(m.x)+minimapWidth; px++ {
+				d := math.Hypot(float64(px)-zx, float64(py)-zy)
+				switch {
+				case math.Abs(d-zr) < minimapZoneLineWidth/2+0.5:
+					a.fr.setPixel(px, py, chaosAccent)
+				case d > zr:
+					a.fr.setPixelBlend(px, py, minimapZoneTint)
+				}
+			}
+		}
+	}
+	for i := 0; i < chaos.MeteorCount; i++ {
+		mx, my := m.project(chaos.Meteors[i].X, chaos.Meteors[i].Y)
+		a.fr.DrawCircleOutline(int(mx), int(my), math.Max(minimapMeteorRadius, chaos.Meteors[i].Radius*m.scaleX), 1, chaosAccent)
+	}
+
+	leader := minimapKillLeader(snap.Players)
+	for i := range snap.Players {
+		p := &snap.Players[i]
+		if p.IsDead || i == leader {
+			continue
+		}
+		x, y := m.project(p.X, p.Y)
+		a.fr.DrawFilledCircle(int(x), int(y), minimapDotRadius, minimapDotColor(p))
+	}
+	if leader >= 0 {
+		p := &snap.Players[leader]
+		x, y := m.project(p.X, p.Y)
+		a.fr.DrawCircleOutline(int(x), int(y), minimapLeaderRadius, 2, minimapLeader)
+		a.fr.DrawFilledCircle(int(x), int(y), minimapDotRadius+1, minimapDotColor(p))
+	}
+}
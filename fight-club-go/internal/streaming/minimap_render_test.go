@@ -0,0 +1,86 @@
+package streaming
+
+import (
+	"image/color"
+	"testing"
+
+	"fight-club/internal/game"
+
+	"github.com/fogleman/gg"
+)
+
+// minimapTestSnapshot has a team player, a solo kill leader, a dead player with more kills and a chaos zone
+func minimapTestSnapshot() *game.GameSnapshot {
+	return &game.GameSnapshot{
+		Players: []game.PlayerSnapshot{
+			{Name: "teamed", X: 320, Y: 180, Kills: 1, TeamColor: "#00ff00"},
+			{Name: "leader", X: 960, Y: 540, Kills: 4},
+			{Name: "ghost", X: 640, Y: 360, Kills: 9, IsDead: true},
+		},
+		Chaos: game.ChaosSnapshot{ZoneX: 640, ZoneY: 360, ZoneRadius: 300},
+	}
+}
+
+// TestMinimapLayout verifies the widget sits bottom-right and downsamples the world
+func TestMinimapLayout(t *testing.T) {
+	m := minimapLayout(1280, 720)
+	if m.x != 1280-minimapWidth-minimapMarginRight || m.y != 720-minimapHeight-minimapMarginBottom {
+		t.Errorf("Unexpected minimap corner (%.0f, %.0f)", m.x, m.y)
+	}
+	if x, y := m.project(1280, 720); x != m.x+minimapWidth || y != m.y+minimapHeight {
+		t.Errorf("Expected the world corner at the widget corner, got (%.0f, %.0f)", x, y)
+	}
+	if x, y := m.project(-50, 5000); x != m.x || y != m.y+minimapHeight {
+		t.Errorf("Expected positions clamped inside the widget, got (%.0f, %.0f)", x, y)
+	}
+
+	snap := minimapTestSnapshot()
+	if leader := minimapKillLeader(snap.Players); leader != 1 {
+		t.Errorf("Expected the alive player with most kills as leader, got %d", leader)
+	}
+	if leader := minimapKillLeader(nil); leader != -1 {
+		t.Errorf("Expected no leader without players, got %d", leader)
+	}
+}
+
+// TestMinimapRender verifies team dots, the leader ring and the zone tint on both backends
+func TestMinimapRender(t *testing.T) {
+	snap := minimapTestSnapshot()
+	m := minimapLayout(1280, 720)
+	tx, ty := m.project(320, 180)
+	lx, ly := m.project(960, 540)
+
+	check := func(t *testing.T, pixel func(x, y float64) color.RGBA) {
+		if c := pixel(tx, ty); c.G < 200 || c.R > 60 {
+			t.Errorf("Expected a green team dot, got %v", c)
+		}
+		if c := pixel(lx+minimapLeaderRadius, ly); c.R < 200 || c.G < 150 || c.B > 80 {
+			t.Errorf("Expected the gold leader ring, got %v", c)
+		}
+		// Widget corner is outside the zone: tinted towards red
+		if c := pixel(m.x+4, m.y+minimapHeight/2); c.R <= c.B {
+			t.Errorf("Expected the zone tint outside the safe zone, got %v", c)
+		}
+	}
+
+	t.Run("gg", func(t *testing.T) {
+		sm := &StreamManager{config: StreamConfig{Width: 1280, Height: 720}}
+		dc := gg.NewContext(1280, 720)
+		sm.drawMinimap(dc, snap)
+		check(t, func(x, y float64) color.RGBA {
+			return color.RGBAModel.Convert(dc.Image().At(int(x), int(y))).(color.RGBA)
+		})
+	})
+
+	t.Run("atlas", func(t *testing.T) {
+		sm := newAtlasTestManager(t, 1280, 720)
+		buffer := make([]byte, 1280*720*4)
+		copy(buffer, sm.atlas.sceneBackground(game.SceneDay))
+		sm.atlas.fr.SetBuffer(buffer)
+		sm.atlas.drawMinimap(buffer, snap)
+		check(t, func(x, y float64) color.RGBA {
+			i := (int(y)*1280 + int(x)) * 4
+			return color.RGBA{buffer[i], buffer[i+1], buffer[i+2], buffer[i+3]}
+		})
+	})
+}
@@ -7,10 +7,9 @@ import (
 	"sync"
 
 	"github.com/gopxl/beep"
-	"github.com/gopxl/beep/vorbis"
 )
 
-// MusicPlayer streams OGG Vorbis audio with on-demand decoding.
+// MusicPlayer streams OGG Vorbis (or WAV/MP3) audio with on-demand decoding.
 // Architectural decision: Streaming approach uses ~64KB buffer vs loading
 // entire decoded PCM (~50MB for a 3-minute track) into memory.
 // This is critical for scalability with thousands of concurrent viewers.
@@ -72,8 +71,8 @@ func (mp *MusicPlayer) load() error {
 		return err
 	}
 
-	// Decode OGG Vorbis - this sets up streaming, NOT full decode
-	streamer, format, err := vorbis.Decode(file)
+	// Decode OGG Vorbis (or WAV/MP3) - this sets up streaming, NOT full decode
+	streamer, format, err := decodeAudio(file, mp.filePath)
 	if err != nil {
 		file.Close()
 		return err
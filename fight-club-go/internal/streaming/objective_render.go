@@ -0,0 +1,169 @@
+package streaming
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"fight-club/internal/game"
+
+	"github.com/fogleman/gg"
+)
+
+// objectiveNeutral colors the capture point while nobody holds it
+var objectiveNeutral = color.RGBA{150, 150, 165, 255}
+
+// teamColorRGBA maps game.TeamColors names to stream colors (hex strings pass through)
+func teamColorRGBA(name string) color.RGBA {
+	switch name {
+	case "red":
+		return color.RGBA{231, 76, 60, 255}
+	case "blue":
+		return color.RGBA{52, 152, 219, 255}
+	case "green":
+		return color.RGBA{46, 204, 113, 255}
+	case "yellow":
+		return color.RGBA{241, 196, 15, 255}
+	case "purple":
+		return color.RGBA{155, 89, 182, 255}
+	case "orange":
+		return color.RGBA{230, 126, 34, 255}
+	case "pink":
+		return color.RGBA{255, 105, 180, 255}
+	case "cyan":
+		return color.RGBA{0, 212, 255, 255}
+	case "white":
+		return color.RGBA{200, 200, 210, 255} // Visible on the light arena
+	case "black":
+		return color.RGBA{40, 40, 48, 255}
+	}
+	return parseHexColor(name)
+}
+
+// withAlpha returns c with its alpha replaced
+func withAlpha(c color.RGBA, a uint8) color.RGBA {
+	c.A = a
+	return c
+}
+
+// objectivePointColor returns the capture point color: holder's team color or neutral
+func objectivePointColor(obj game.ObjectiveSnapshot) color.RGBA {
+	if obj.Owner < 0 {
+		return objectiveNeutral
+	}
+	return teamColorRGBA(obj.Teams[obj.Owner].Color)
+}
+
+// drawObjectiveGround draws both team bases and the capture point on the arena floor (under players)
+func (s *StreamManager) drawObjectiveGround(dc *gg.Context, obj game.ObjectiveSnapshot) {
+	if !obj.Active {
+		return
+	}
+	for _, team := range obj.Teams {
+		c := teamColorRGBA(team.Color)
+		dc.SetColor(withAlpha(c, 40))
+		dc.DrawCircle(team.BaseX, team.BaseY, obj.BaseRadius)
+		dc.Fill()
+		dc.SetColor(withAlpha(c, 200))
+		dc.SetLineWidth(4)
+		dc.DrawCircle(team.BaseX, team.BaseY, obj.BaseRadius)
+		dc.Stroke()
+	}
+
+	point := objectivePointColor(obj)
+	dc.SetColor(withAlpha(point, 45))
+	dc.DrawCircle(obj.PointX, obj.PointY, obj.PointRadius)
+	dc.Fill()
+	dc.SetColor(withAlpha(point, 220))
+	dc.SetLineWidth(4)
+	if obj.Contested {
+		dc.SetDash(12, 8)
+	}
+	dc.DrawCircle(obj.PointX, obj.PointY, obj.PointRadius)
+	dc.Stroke()
+	dc.SetDash()
+
+	// Capture progress arc in the capturing team's color, clockwise from the top
+	if obj.Capturing >= 0 && obj.Progress > 0 {
+		dc.SetColor(teamColorRGBA(obj.Teams[obj.Capturing].Color))
+		dc.SetLineWidth(7)
+		dc.NewSubPath()
+		dc.DrawArc(obj.PointX, obj.PointY, obj.PointRadius+8, -math.Pi/2, -math.Pi/2+obj.Progress*2*math.Pi)
+		dc.Stroke()
+	}
+}
+
+// objectiveBannerText returns the score line ("" when no round is running)
+func objectiveBannerText(obj game.ObjectiveSnapshot) string {
+	if !obj.Active {
+		return ""
+	}
+	return fmt.Sprintf("%s %d  -  %d %s   (to %d)", obj.Teams[0].Name, obj.Teams[0].Score, obj.Teams[1].Score, obj.Teams[1].Name, obj.ScoreLimit)
+}
+
+// drawObjectiveBanner draws the team scoreboard at the bottom center, above the chaos banner
+func (s *StreamManager) drawObjectiveBanner(dc *gg.Context, obj game.ObjectiveSnapshot) {
+	text := objectiveBannerText(obj)
+	if text == "" {
+		return
+	}
+	if s.fontsLoaded && s.fontSmall != nil {
+		dc.SetFontFace(s.fontSmall)
+	} else {
+		_ = s.loadFontFace(dc, 13)
+	}
+
+	textWidth, _ := dc.MeasureString(text)
+	width := textWidth + 40
+	height := 30.0
+	x := (float64(s.config.Width) - width) / 2
+	y := float64(s.config.Height) - 24 - 40 - 10 - height // Chaos banner is 40px tall, 24px up
+
+	dc.SetColor(color.RGBA{18, 18, 24, 235})
+	dc.DrawRoundedRectangle(x, y, width, height, 5)
+	dc.Fill()
+
+	// Team color accents on either end, the holder's side in full
+	for i, team := range obj.Teams {
+		c := teamColorRGBA(team.Color)
+		if obj.Owner != i {
+			c = withAlpha(c, 110)
+		}
+		dc.SetColor(c)
+		dc.DrawRoundedRectangle(x+float64(i)*(width-8), y, 8, height, 3)
+		dc.Fill()
+	}
+
+	dc.SetColor(color.RGBA{255, 255, 255, 255})
+	dc.DrawStringAnchored(text, float64(s.config.Width)/2, y+height/2+1, 0.5, 0.35)
+}
+
+// drawObjective draws the bases and capture point with fast primitives (mirrors drawObjectiveGround)
+func (a *AtlasRenderer) drawObjective(obj game.ObjectiveSnapshot) {
+	if !obj.Active {
+		return
+	}
+	for _, team := range obj.Teams {
+		c := teamColorRGBA(team.Color)
+		a.fr.DrawFilledCircleBlend(int(team.BaseX), int(team.BaseY), obj.BaseRadius, withAlpha(c, 40))
+		a.fr.DrawCircleOutline(int(team.BaseX), int(team.BaseY), obj.BaseRadius, 4, c)
+	}
+
+	point := objectivePointColor(obj)
+	a.fr.DrawFilledCircleBlend(int(obj.PointX), int(obj.PointY), obj.PointRadius, withAlpha(point, 45))
+	if obj.Contested {
+		// Dashed outline while both teams stand on the point
+		const dashes = 16
+		for i := 0; i < dashes; i++ {
+			start := float64(i) * 2 * math.Pi / dashes
+			a.drawArc(obj.PointX, obj.PointY, obj.PointRadius, start, start+math.Pi/dashes, 4, point)
+		}
+	} else {
+		a.fr.DrawCircleOutline(int(obj.PointX), int(obj.PointY), obj.PointRadius, 4, point)
+	}
+
+	if obj.Capturing >= 0 && obj.Progress > 0 {
+		c := teamColorRGBA(obj.Teams[obj.Capturing].Color)
+		a.drawArc(obj.PointX, obj.PointY, obj.PointRadius+8, -math.Pi/2, -math.Pi/2+obj.Progress*2*math.Pi, 7, c)
+	}
+}
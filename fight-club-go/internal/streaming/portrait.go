@@ -0,0 +1,160 @@
+package streaming
+
+import (
+	"image"
+	"image/color"
+
+	"fight-club/internal/game"
+
+	"github.com/fogleman/gg"
+)
+
+// Portrait output defaults (9:16 for TikTok/Shorts/Reels live)
+const (
+	DefaultPortraitWidth   = 1080
+	DefaultPortraitHeight  = 1920
+	DefaultPortraitBitrate = 4500 // kbps
+)
+
+// Portrait HUD layout (px)
+const (
+	portraitMargin       = 32.0
+	portraitBandPadding  = 40.0  // Space between the HUD bands and the arena
+	portraitColumnOffset = 300.0 // Second leaderboard column (persistent leaderboard)
+)
+
+// PortraitConfig enables a 9:16 simulcast: the same snapshot drawn with the arena
+// across the middle and the HUD reflowed into bands above and below it.
+// FFmpeg encodes it as a second output of the same process (see PortraitOutput).
+type PortraitConfig struct {
+	RTMPURL string // Full RTMP(S) URL including the stream key ("" disables the portrait output)
+	Width   int    // Default 1080
+	Height  int    // Default 1920
+	Bitrate int    // kbps, default 4500
+}
+
+// Enabled reports whether the portrait output is configured
+func (pc PortraitConfig) Enabled() bool {
+	return pc.RTMPURL != ""
+}
+
+// withDefaults fills in the frame size and bitrate
+func (pc PortraitConfig) withDefaults() PortraitConfig {
+	if pc.Width <= 0 || pc.Height <= 0 {
+		pc.Width, pc.Height = DefaultPortraitWidth, DefaultPortraitHeight
+	}
+	if pc.Bitrate <= 0 {
+		pc.Bitrate = DefaultPortraitBitrate
+	}
+	return pc
+}
+
+// portraitLayout places the landscape arena inside the portrait frame
+type portraitLayout struct {
+	scale      float64 // Arena to portrait scale (fits the frame width)
+	arenaY     float64 // Top of the arena
+	arenaH     float64 // Arena height in portrait pixels
+	topBandY   float64 // Top of the HUD band above the arena
+	bottomBand float64 // Top of the HUD band below the arena
+}
+
+// newPortraitLayout centers the arena vertically at full frame width
+func newPortraitLayout(arenaWidth, arenaHeight, width, height int) portraitLayout {
+	scale := float64(width) / float64(arenaWidth)
+	arenaH := float64(arenaHeight) * scale
+	arenaY := (float64(height) - arenaH) / 2
+	return portraitLayout{
+		scale:      scale,
+		arenaY:     arenaY,
+		arenaH:     arenaH,
+		topBandY:   arenaY - portraitBandPadding - playNowCardHeight,
+		bottomBand: arenaY + arenaH + portraitBandPadding,
+	}
+}
+
+// PortraitRenderer draws the portrait frame with gg from the landscape snapshot
+type PortraitRenderer struct {
+	s      *StreamManager
+	config PortraitConfig
+	layout portraitLayout
+	dc     *gg.Context
+}
+
+// newPortraitRenderer creates the portrait renderer for a stream manager
+func newPortraitRenderer(s *StreamManager, config PortraitConfig) *PortraitRenderer {
+	config = config.withDefaults()
+	return &PortraitRenderer{
+		s:      s,
+		config: config,
+		layout: newPortraitLayout(s.config.Width, s.config.Height, config.Width, config.Height),
+		dc:     gg.NewContext(config.Width, config.Height),
+	}
+}
+
+// FrameSize returns the RGBA frame size in bytes
+func (p *PortraitRenderer) FrameSize() int {
+	return p.config.Width * p.config.Height * 4
+}
+
+// Render draws snap into buffer (RGBA, FrameSize bytes)
+func (p *PortraitRenderer) Render(snap *game.GameSnapshot, buffer []byte) {
+	s, dc, l := p.s, p.dc, p.layout
+	width := float64(p.config.Width)
+
+	dc.SetColor(sceneBackground(snap.Scene.Time).Base()) // Matches the arena background
+	dc.Clear()
+
+	// Arena and its overlays in world coordinates, scaled to the frame width.
+	// Overlays centered on the landscape width stay centered on the portrait frame.
+	dc.Push()
+	dc.Translate(0, l.arenaY)
+	dc.Scale(l.scale, l.scale)
+	dc.DrawRectangle(0, 0, float64(s.config.Width), float64(s.config.Height))
+	dc.Clip()
+	view := s.framer.View()
+	dc.Push()
+	if view.zoomed() {
+		view.transform(dc)
+	}
+	s.drawArenaFromSnapshot(dc, snap, false)
+	dc.Pop()
+	if snap.Replaying {
+		s.drawReplayBanner(dc, portraitMargin)
+	} else {
+		s.drawVoteOverlay(dc, snap.Vote, portraitMargin)
+	}
+	s.drawDuelBanner(dc, view.duel(snap.Duel))
+	s.drawObjectiveBanner(dc, snap.Objective)
+	s.drawChaosBanner(dc, snap.Chaos)
+	if snap.Paused {
+		s.drawPauseOverlay(dc)
+	}
+	dc.ResetClip()
+	dc.Pop()
+
+	// Arena frame
+	dc.SetColor(color.RGBA{0, 212, 255, 255})
+	dc.SetLineWidth(2)
+	dc.DrawLine(0, l.arenaY, width, l.arenaY)
+	dc.DrawLine(0, l.arenaY+l.arenaH, width, l.arenaY+l.arenaH)
+	dc.Stroke()
+
+	// === TOP BAND - PLAY NOW centered, LIVE / viewers above it ===
+	badgeX := width - liveBadgeWidth - portraitMargin
+	badgeY := l.topBandY - liveBadgeHeight - 24
+	s.drawLiveBadge(dc, snap.AliveCount, badgeX, badgeY)
+	if snap.ViewerCount > 0 {
+		s.drawViewerBadge(dc, snap.ViewerCount, badgeX-8, badgeY, liveBadgeHeight)
+	}
+	s.drawPlayNowCard(dc, (width-playNowCardWidth)/2, l.topBandY)
+	s.drawJoinQueue(dc, snap.JoinQueue, portraitMargin, badgeY, false)
+	s.drawQuestToast(dc, snap.QuestToast, badgeX+liveBadgeWidth, l.arenaY+12)
+
+	// === BOTTOM BAND - Session and persistent leaderboards side by side ===
+	leaderboardY := l.bottomBand + 24
+	s.drawSessionLeaderboard(dc, snap, portraitMargin, leaderboardY)
+	s.drawLeaderboardRotator(dc, snap.Leaderboard, portraitMargin+portraitColumnOffset, leaderboardY)
+
+	// The frame is opaque, so premultiplied pixels are already straight RGBA
+	copy(buffer, dc.Image().(*image.RGBA).Pix)
+}
@@ -0,0 +1,60 @@
+package streaming
+
+import (
+	"image/color"
+	"testing"
+
+	"fight-club/internal/game"
+)
+
+// TestPortraitLayout verifies the arena spans the frame width, centered between the HUD bands
+func TestPortraitLayout(t *testing.T) {
+	l := newPortraitLayout(1280, 720, 1080, 1920)
+	if l.scale != 1080.0/1280 || l.arenaH != 607.5 {
+		t.Errorf("Expected the arena scaled to the frame width, got scale %.4f height %.1f", l.scale, l.arenaH)
+	}
+	if l.arenaY+l.arenaH/2 != 960 {
+		t.Errorf("Expected the arena centered vertically, got top %.1f", l.arenaY)
+	}
+	if l.topBandY < 0 || l.topBandY+playNowCardHeight > l.arenaY || l.bottomBand < l.arenaY+l.arenaH {
+		t.Errorf("Expected HUD bands outside the arena: %+v", l)
+	}
+
+	pc := PortraitConfig{RTMPURL: "rtmp://x"}.withDefaults()
+	if pc.Width != 1080 || pc.Height != 1920 || pc.Bitrate != DefaultPortraitBitrate {
+		t.Errorf("Unexpected portrait defaults %+v", pc)
+	}
+	if (PortraitConfig{}).Enabled() {
+		t.Error("Expected the portrait output disabled without a URL")
+	}
+}
+
+// TestPortraitRender verifies players land in the scaled arena and the HUD in the bands
+func TestPortraitRender(t *testing.T) {
+	sm := &StreamManager{config: StreamConfig{Width: 1280, Height: 720}}
+	p := newPortraitRenderer(sm, PortraitConfig{RTMPURL: "rtmp://x"})
+	snap := &game.GameSnapshot{
+		Players: []game.PlayerSnapshot{
+			{Name: "fighter", X: 640, Y: 360, HP: 100, MaxHP: 100, Color: "#ff0000", Kills: 3},
+		},
+		AliveCount: 1,
+	}
+
+	buffer := make([]byte, p.FrameSize())
+	p.Render(snap, buffer)
+	pixel := func(x, y float64) color.RGBA {
+		i := (int(y)*p.config.Width + int(x)) * 4
+		return color.RGBA{buffer[i], buffer[i+1], buffer[i+2], buffer[i+3]}
+	}
+
+	l := p.layout
+	if c := pixel(640*l.scale, l.arenaY+360*l.scale); c == (color.RGBA{250, 250, 255, 255}) {
+		t.Errorf("Expected the player drawn at the scaled arena position, got background %v", c)
+	}
+	if c := pixel(1080/2, l.topBandY+playNowCardHeight/2); c.R > 60 || c.G > 60 {
+		t.Errorf("Expected the dark PLAY NOW card centered above the arena, got %v", c)
+	}
+	if c := pixel(4, 4); c != (color.RGBA{250, 250, 255, 255}) || c.A != 255 {
+		t.Errorf("Expected an opaque background outside the arena, got %v", c)
+	}
+}
@@ -0,0 +1,118 @@
+package streaming
+
+import (
+	"bytes"
+	"image"
+	"image/jpeg"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Preview tuning
+const (
+	previewWidth    = 480                    // Thumbnail width (height keeps the aspect ratio)
+	previewInterval = 250 * time.Millisecond // Min time between captures (4 FPS)
+	previewIdle     = 10 * time.Second       // Stop capturing this long after the last request
+	previewQuality  = 70                     // JPEG quality
+)
+
+// FramePreview keeps a thumbnail of the latest rendered frame for the admin
+// panel (/api/preview.jpg and /api/preview.mjpeg on the streamer debug server).
+// It only works while someone is watching: the render loop copies a
+// downscaled frame at most every previewInterval, and the JPEG is encoded on
+// request, once per captured frame.
+type FramePreview struct {
+	srcW, srcH int
+	requested  atomic.Int64 // Unix nanos of the last JPEG request
+
+	mu       sync.Mutex
+	thumb    *image.RGBA
+	captured time.Time
+	seq      uint64 // Frames captured
+	jpeg     []byte
+	jpegSeq  uint64 // Frame the cached JPEG was encoded from
+}
+
+// NewFramePreview creates a preview for RGBA frames of the given size
+func NewFramePreview(width, height int) *FramePreview {
+	w := min(previewWidth, width)
+	h := max(1, height*w/max(width, 1))
+	return &FramePreview{
+		srcW:  width,
+		srcH:  height,
+		thumb: image.NewRGBA(image.Rect(0, 0, w, h)),
+	}
+}
+
+// Resize switches the preview to frames of a new size (the last thumbnail is dropped)
+func (p *FramePreview) Resize(width, height int) {
+	if p == nil {
+		return
+	}
+	resized := NewFramePreview(width, height)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.srcW, p.srcH = resized.srcW, resized.srcH
+	p.thumb = resized.thumb
+	p.seq, p.jpegSeq, p.jpeg = 0, 0, nil
+}
+
+// Capture downsamples a rendered RGBA frame into the thumbnail when a preview
+// was requested recently. Called from the render loop; never blocks on a
+// request encoding the previous thumbnail (that frame is skipped instead).
+func (p *FramePreview) Capture(frame []byte, now time.Time) {
+	if p == nil || now.UnixNano()-p.requested.Load() > int64(previewIdle) {
+		return
+	}
+	if len(frame) < p.srcW*p.srcH*4 || !p.mu.TryLock() {
+		return
+	}
+	defer p.mu.Unlock()
+	if now.Sub(p.captured) < previewInterval {
+		return
+	}
+
+	// Nearest-neighbour downsample (the thumbnail is small, quality is fine)
+	b := p.thumb.Bounds()
+	for y := 0; y < b.Dy(); y++ {
+		src := (y * p.srcH / b.Dy()) * p.srcW * 4
+		dst := y * p.thumb.Stride
+		for x := 0; x < b.Dx(); x++ {
+			s := src + (x*p.srcW/b.Dx())*4
+			copy(p.thumb.Pix[dst+x*4:dst+x*4+4], frame[s:s+4])
+		}
+	}
+	p.captured = now
+	p.seq++
+}
+
+// JPEG returns the latest thumbnail as JPEG and its frame number (0 = nothing
+// captured yet). Each call keeps capturing alive for previewIdle.
+func (p *FramePreview) JPEG() ([]byte, uint64) {
+	p.requested.Store(time.Now().UnixNano())
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.seq == 0 {
+		return nil, 0
+	}
+	if p.jpegSeq != p.seq {
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, p.thumb, &jpeg.Options{Quality: previewQuality}); err != nil {
+			return nil, 0
+		}
+		p.jpeg, p.jpegSeq = buf.Bytes(), p.seq
+	}
+	return p.jpeg, p.jpegSeq
+}
+
+// PreviewJPEG returns the latest rendered frame as a JPEG thumbnail and its
+// frame number (0 = none yet, e.g. before the first request was seen)
+func (s *StreamManager) PreviewJPEG() ([]byte, uint64) {
+	if s.preview == nil {
+		return nil, 0
+	}
+	return s.preview.JPEG()
+}
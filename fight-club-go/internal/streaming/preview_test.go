@@ -0,0 +1,58 @@
+package streaming
+
+import (
+	"bytes"
+	"image/jpeg"
+	"testing"
+	"time"
+)
+
+// TestFramePreviewCapturesOnDemand verifies frames are only captured while
+// previews are requested, at most every previewInterval
+func TestFramePreviewCapturesOnDemand(t *testing.T) {
+	const w, h = 960, 540
+	frame := make([]byte, w*h*4)
+	for i := 0; i < len(frame); i += 4 {
+		frame[i], frame[i+3] = 255, 255 // Opaque red
+	}
+	p := NewFramePreview(w, h)
+	now := time.Now()
+
+	p.Capture(frame, now)
+	if _, seq := p.JPEG(); seq != 0 {
+		t.Fatal("Expected no capture before the first request")
+	}
+
+	// The request above woke the preview up
+	p.Capture(frame, now)
+	p.Capture(frame, now.Add(previewInterval/2))
+	data, seq := p.JPEG()
+	if seq != 1 {
+		t.Fatalf("Expected one capture within the interval, got %d", seq)
+	}
+	img, err := jpeg.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("Invalid JPEG: %v", err)
+	}
+	if b := img.Bounds(); b.Dx() != previewWidth || b.Dy() != previewWidth*h/w {
+		t.Errorf("Expected a %dpx wide thumbnail, got %dx%d", previewWidth, b.Dx(), b.Dy())
+	}
+	if r, g, _, _ := img.At(10, 10).RGBA(); r>>8 < 200 || g>>8 > 60 {
+		t.Errorf("Expected a red thumbnail, got r=%d g=%d", r>>8, g>>8)
+	}
+
+	// Cached until the next capture
+	if again, _ := p.JPEG(); !bytes.Equal(again, data) {
+		t.Error("Expected the JPEG to be reused for the same frame")
+	}
+	p.Capture(frame, now.Add(previewInterval))
+	if _, seq := p.JPEG(); seq != 2 {
+		t.Errorf("Expected a new capture after the interval, got %d", seq)
+	}
+
+	// Nobody watching: capturing stops
+	p.Capture(frame, time.Now().Add(previewIdle+previewInterval))
+	if p.seq != 2 {
+		t.Errorf("Expected no capture once the preview went idle, got %d", p.seq)
+	}
+}
@@ -0,0 +1,48 @@
+package streaming
+
+import (
+	"fmt"
+	"image/color"
+
+	"fight-club/internal/game"
+
+	"github.com/fogleman/gg"
+)
+
+// questAccent is the quest toast accent (title and edge)
+var questAccent = color.RGBA{255, 196, 0, 255}
+
+// drawQuestToast draws the quest completion toast ending at rightX, below the LIVE badge
+func (s *StreamManager) drawQuestToast(dc *gg.Context, toast game.QuestToastSnapshot, rightX, y float64) {
+	if toast.Username == "" {
+		return
+	}
+	if s.fontsLoaded && s.fontSmall != nil {
+		dc.SetFontFace(s.fontSmall)
+	} else {
+		_ = s.loadFontFace(dc, 13)
+	}
+
+	detail := fmt.Sprintf("%s - %s", toast.Username, toast.Quest)
+	if toast.Reward > 0 {
+		detail += fmt.Sprintf("  +$%d", toast.Reward)
+	}
+	textWidth, _ := dc.MeasureString(detail)
+	width := textWidth + 32
+	height := 52.0
+	x := rightX - width
+
+	dc.SetColor(color.RGBA{0, 0, 0, 25})
+	dc.DrawRoundedRectangle(x+3, y+3, width, height, 6)
+	dc.Fill()
+	dc.SetColor(color.RGBA{18, 18, 24, 245})
+	dc.DrawRoundedRectangle(x, y, width, height, 6)
+	dc.Fill()
+	dc.SetColor(questAccent)
+	dc.DrawRoundedRectangle(x, y, 4, height, 2)
+	dc.Fill()
+
+	dc.DrawString("QUEST COMPLETE", x+16, y+21)
+	dc.SetColor(color.RGBA{255, 255, 255, 255})
+	dc.DrawString(detail, x+16, y+41)
+}
@@ -0,0 +1,49 @@
+package streaming
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"fight-club/internal/game"
+
+	"github.com/fogleman/gg"
+)
+
+// rankBadgeRadius is the radius of the rank badge drawn left of player names
+const rankBadgeRadius = 10.0
+
+// drawRankBadge draws a seasonal rank badge (colored disc with the tier letter) centered at (x, y)
+func (s *StreamManager) drawRankBadge(dc *gg.Context, rank game.RankTier, x, y float64) {
+	dc.SetColor(parseHexColor(rank.Color))
+	dc.DrawCircle(x, y, rankBadgeRadius)
+	dc.Fill()
+
+	dc.SetColor(color.RGBA{20, 25, 35, 255})
+	dc.SetLineWidth(1.5)
+	dc.DrawCircle(x, y, rankBadgeRadius)
+	dc.Stroke()
+
+	if s.fontsLoaded && s.fontSmall != nil {
+		dc.SetFontFace(s.fontSmall)
+	} else {
+		_ = s.loadFontFace(dc, 16)
+	}
+	dc.SetColor(color.RGBA{255, 255, 255, 255})
+	dc.DrawStringAnchored(rank.Badge, x, y, 0.5, 0.35)
+}
+
+// rankBadge returns the cached badge sprite for a rank tier
+func (a *AtlasRenderer) rankBadge(rank game.RankTier) *sprite {
+	if sp, ok := a.badges[rank.ID]; ok {
+		return sp
+	}
+	size := int(math.Ceil(rankBadgeRadius*2)) + 4
+	center := float64(size) / 2
+	dc := gg.NewContext(size, size)
+	a.s.drawRankBadge(dc, rank, center, center)
+
+	sp := newSprite(dc.Image().(*image.RGBA))
+	a.badges[rank.ID] = sp
+	return sp
+}
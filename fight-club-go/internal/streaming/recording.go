@@ -0,0 +1,202 @@
+package streaming
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Recording container formats
+const (
+	RecordingFormatMKV = "mkv" // Default: survives crashes mid-segment
+	RecordingFormatMP4 = "mp4" // Fragmented MP4 (playable while being written)
+)
+
+// recordingPrefix names recorded segments (fightclub-20250101-120000.mkv)
+const recordingPrefix = "fightclub-"
+
+// recordingPruneInterval is how often the retention policy is applied
+const recordingPruneInterval = time.Minute
+
+// RecordingConfig enables a local VOD recording of the full stream.
+// FFmpeg writes it as a second output of the same encode (tee muxer),
+// split into segments so a crash only loses the segment in progress.
+type RecordingConfig struct {
+	Dir            string  // Output directory ("" disables recording)
+	Format         string  // RecordingFormatMKV (default) or RecordingFormatMP4
+	SegmentSeconds int     // Segment length (default 600)
+	RetainHours    float64 // Delete segments older than this (0 = keep forever)
+	MaxSizeMB      int64   // Delete oldest segments above this total (0 = unlimited)
+}
+
+// Enabled reports whether recording is configured
+func (rc RecordingConfig) Enabled() bool {
+	return rc.Dir != ""
+}
+
+// withDefaults fills in the format and segment length
+func (rc RecordingConfig) withDefaults() RecordingConfig {
+	if rc.Format != RecordingFormatMP4 {
+		rc.Format = RecordingFormatMKV
+	}
+	if rc.SegmentSeconds <= 0 {
+		rc.SegmentSeconds = 600
+	}
+	return rc
+}
+
+// teeOutput builds the FFmpeg tee muxer output: the live stream (muxer flv
+// for RTMP, mpegts for SRT) plus segmented recording. Recording failures
+// (disk full, ...) never abort the live output.
+func (rc RecordingConfig) teeOutput(muxer, liveURL string) string {
+	rc = rc.withDefaults()
+
+	opts := []string{
+		"f=segment",
+		fmt.Sprintf("segment_time=%d", rc.SegmentSeconds),
+		"segment_format=" + rc.Format,
+		"reset_timestamps=1",
+		"strftime=1",
+		"onfail=ignore",
+	}
+	if rc.Format == RecordingFormatMP4 {
+		// Fragmented MP4: no moov atom to finalize, so a killed FFmpeg leaves a playable file
+		opts = append(opts, "segment_format_options=movflags=+frag_keyframe+empty_moov")
+	}
+
+	// Tee uses ':' inside [] and '|' between outputs; forward slashes keep Windows paths parseable
+	pattern := filepath.ToSlash(filepath.Join(rc.Dir, recordingPrefix+"%Y%m%d-%H%M%S."+rc.Format))
+
+	return fmt.Sprintf("[f=%s]%s|[%s]%s", muxer, liveURL, strings.Join(opts, ":"), pattern)
+}
+
+// recordingSegment is a recorded file on disk
+type recordingSegment struct {
+	path    string
+	size    int64
+	modTime time.Time
+}
+
+// listRecordings returns recorded segments oldest first
+func (rc RecordingConfig) listRecordings() ([]recordingSegment, error) {
+	entries, err := os.ReadDir(rc.Dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var segments []recordingSegment
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, recordingPrefix) {
+			continue
+		}
+		ext := strings.TrimPrefix(filepath.Ext(name), ".")
+		if ext != RecordingFormatMKV && ext != RecordingFormatMP4 {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		segments = append(segments, recordingSegment{
+			path:    filepath.Join(rc.Dir, name),
+			size:    info.Size(),
+			modTime: info.ModTime(),
+		})
+	}
+
+	sort.Slice(segments, func(i, j int) bool {
+		return segments[i].modTime.Before(segments[j].modTime)
+	})
+	return segments, nil
+}
+
+// pruneRecordings applies the retention policy (age, then total size).
+// The newest segment is never deleted since FFmpeg may still be writing it.
+func (rc RecordingConfig) pruneRecordings(now time.Time) (int, error) {
+	segments, err := rc.listRecordings()
+	if err != nil || len(segments) <= 1 {
+		return 0, err
+	}
+
+	var total int64
+	for _, seg := range segments {
+		total += seg.size
+	}
+
+	removed := 0
+	maxBytes := rc.MaxSizeMB * 1024 * 1024
+	for _, seg := range segments[:len(segments)-1] {
+		expired := rc.RetainHours > 0 && now.Sub(seg.modTime) > time.Duration(rc.RetainHours*float64(time.Hour))
+		overSize := maxBytes > 0 && total > maxBytes
+		if !expired && !overSize {
+			break // Oldest first: nothing newer can be expired either
+		}
+		if err := os.Remove(seg.path); err != nil {
+			return removed, err
+		}
+		total -= seg.size
+		removed++
+	}
+	return removed, nil
+}
+
+// recordingLoop applies the retention policy while streaming
+func (s *StreamManager) recordingLoop(stopChan chan struct{}) {
+	prune := func(now time.Time) {
+		if removed, err := s.config.Recording.pruneRecordings(now); err != nil {
+			log.Printf("⚠️ Recording retention failed: %v", err)
+		} else if removed > 0 {
+			log.Printf("🗑️ Recording retention: deleted %d old segment(s)", removed)
+		}
+	}
+	prune(time.Now()) // Segments left over from previous runs
+
+	ticker := time.NewTicker(recordingPruneInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stopChan:
+			return
+		case now := <-ticker.C:
+			prune(now)
+		}
+	}
+}
+
+// recordingStats reports recording status for GetStats
+func (s *StreamManager) recordingStats() map[string]interface{} {
+	rc := s.config.Recording
+	stats := map[string]interface{}{
+		"enabled": rc.Enabled(),
+	}
+	if !rc.Enabled() {
+		return stats
+	}
+
+	rc = rc.withDefaults()
+	stats["dir"] = rc.Dir
+	stats["format"] = rc.Format
+	stats["active"] = s.recording
+
+	segments, err := rc.listRecordings()
+	if err != nil {
+		stats["error"] = err.Error()
+		return stats
+	}
+	var total int64
+	for _, seg := range segments {
+		total += seg.size
+	}
+	stats["segments"] = len(segments)
+	stats["sizeMB"] = fmt.Sprintf("%.1f", float64(total)/(1024*1024))
+	if len(segments) > 0 {
+		stats["currentSegment"] = filepath.Base(segments[len(segments)-1].path)
+	}
+	return stats
+}
@@ -0,0 +1,77 @@
+package streaming
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestRecordingTeeOutput verifies the live output is kept and the recording can't abort it
+func TestRecordingTeeOutput(t *testing.T) {
+	rc := RecordingConfig{Dir: "recordings"}
+	out := rc.teeOutput("flv", "rtmp://live.example/app/key")
+
+	outputs := strings.Split(out, "|")
+	if len(outputs) != 2 {
+		t.Fatalf("Expected 2 tee outputs, got %q", out)
+	}
+	if outputs[0] != "[f=flv]rtmp://live.example/app/key" {
+		t.Errorf("Unexpected live output %q", outputs[0])
+	}
+	for _, want := range []string{"f=segment", "segment_time=600", "segment_format=mkv", "onfail=ignore", "recordings/fightclub-%Y%m%d-%H%M%S.mkv"} {
+		if !strings.Contains(outputs[1], want) {
+			t.Errorf("Recording output %q missing %q", outputs[1], want)
+		}
+	}
+
+	mp4 := RecordingConfig{Dir: "vod", Format: RecordingFormatMP4, SegmentSeconds: 60}.teeOutput("flv", "rtmp://x")
+	if !strings.Contains(mp4, "empty_moov") || !strings.Contains(mp4, "segment_time=60") {
+		t.Errorf("Expected fragmented MP4 segments, got %q", mp4)
+	}
+}
+
+// TestPruneRecordings verifies age and size retention, keeping the newest segment
+func TestPruneRecordings(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+
+	write := func(name string, size int, age time.Duration) {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, make([]byte, size), 0o644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+		os.Chtimes(path, now.Add(-age), now.Add(-age))
+	}
+	write("fightclub-1.mkv", 1024*1024, 100*time.Hour) // Expired
+	write("fightclub-2.mkv", 1024*1024, 3*time.Hour)   // Over size budget
+	write("fightclub-3.mkv", 1024*1024, 2*time.Hour)
+	write("fightclub-4.mkv", 1024*1024, time.Minute) // Being written
+	write("notes.txt", 10, 200*time.Hour)            // Not a recording
+
+	rc := RecordingConfig{Dir: dir, RetainHours: 72, MaxSizeMB: 2}
+	removed, err := rc.pruneRecordings(now)
+	if err != nil {
+		t.Fatalf("pruneRecordings failed: %v", err)
+	}
+	if removed != 2 {
+		t.Errorf("Expected 2 segments removed, got %d", removed)
+	}
+
+	segments, _ := rc.listRecordings()
+	if len(segments) != 2 || filepath.Base(segments[1].path) != "fightclub-4.mkv" {
+		t.Errorf("Expected segments 3 and 4 to remain, got %+v", segments)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "notes.txt")); err != nil {
+		t.Error("Non-recording files must not be deleted")
+	}
+
+	// The newest segment survives even when it alone exceeds the budget
+	rc.MaxSizeMB = 0
+	rc.RetainHours = 0.0001
+	rc.pruneRecordings(now.Add(time.Hour))
+	if segments, _ := rc.listRecordings(); len(segments) != 1 {
+		t.Errorf("Expected only the newest segment to remain, got %d", len(segments))
+	}
+}
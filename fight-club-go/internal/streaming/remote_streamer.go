@@ -0,0 +1,123 @@
+package streaming
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"fight-club/internal/ipc"
+)
+
+// TelemetrySource provides the latest streamer telemetry (ipc.Publisher)
+type TelemetrySource interface {
+	LatestTelemetry() (msg ipc.TelemetryMessage, receivedAt time.Time, ok bool)
+}
+
+// RemoteSource is the IPC link to the streamer process (ipc.Publisher):
+// telemetry in, stream control out
+type RemoteSource interface {
+	TelemetrySource
+	SendControl(cmd ipc.ControlCommand) error
+}
+
+// RemoteStreamer controls the external streamer process over IPC and
+// reports its stats, received as IPC telemetry, so the game server's
+// /api/stream endpoints and /api/stats act on the real stream. Without
+// recent telemetry it falls back to NoOpStreamer's stats.
+type RemoteStreamer struct {
+	source   RemoteSource
+	fallback *NoOpStreamer
+}
+
+// NewRemoteStreamer creates a streamer backed by the streamer process at source
+func NewRemoteStreamer(source RemoteSource) *RemoteStreamer {
+	return &RemoteStreamer{source: source, fallback: NewNoOpStreamer()}
+}
+
+// Start asks the streamer process to go live and waits for its answer
+func (r *RemoteStreamer) Start() error {
+	if err := r.source.SendControl(ipc.ControlStart); err != nil {
+		if errors.Is(err, ipc.ErrNotConnected) {
+			return fmt.Errorf("streamer process not connected (run cmd/streamer)")
+		}
+		return fmt.Errorf("streamer: %w", err)
+	}
+	return nil
+}
+
+// Stop asks the streamer process to end the broadcast. It stays connected
+// and ready, and ignores auto-stream until the next Start.
+func (r *RemoteStreamer) Stop() {
+	if err := r.source.SendControl(ipc.ControlStop); err != nil {
+		log.Printf("⚠️ Failed to stop the streamer process: %v", err)
+	}
+}
+
+// ForceKeyframe asks the streamer process to send a keyframe now and waits
+// for its answer (see StreamManager.ForceKeyframe)
+func (r *RemoteStreamer) ForceKeyframe() error {
+	if err := r.source.SendControl(ipc.ControlKeyframe); err != nil {
+		if errors.Is(err, ipc.ErrNotConnected) {
+			return fmt.Errorf("streamer process not connected (run cmd/streamer)")
+		}
+		return fmt.Errorf("streamer: %w", err)
+	}
+	return nil
+}
+
+// IsStreaming returns whether the streamer process reported an active stream
+func (r *RemoteStreamer) IsStreaming() bool {
+	msg, _, ok := r.latest()
+	return ok && msg.Streaming
+}
+
+// GetStats returns the streamer process's stats (same keys as StreamManager's)
+func (r *RemoteStreamer) GetStats() map[string]interface{} {
+	msg, age, ok := r.latest()
+	if !ok {
+		stats := r.fallback.GetStats()
+		stats["connected"] = false
+		return stats
+	}
+
+	stats := map[string]interface{}{
+		"streaming":         msg.Streaming,
+		"mode":              "external",
+		"connected":         true,
+		"telemetryAge":      age.Round(time.Millisecond).String(),
+		"framesSent":        msg.FramesSent,
+		"framesDropped":     msg.FramesDropped,
+		"uptime":            (time.Duration(msg.UptimeSeconds) * time.Second).String(),
+		"actualFps":         fmt.Sprintf("%.1f", msg.ActualFPS),
+		"avgFrameMs":        msg.AvgFrameMs,
+		"resolution":        fmt.Sprintf("%dx%d", msg.Width, msg.Height),
+		"fps":               msg.FPS,
+		"bitrate":           msg.Bitrate,
+		"renderer":          msg.Renderer,
+		"reconnecting":      msg.Reconnecting,
+		"reconnectAttempts": msg.ReconnectAttempts,
+		"connectionLost":    msg.ConnectionLost,
+		"snapshotsReceived": msg.SnapshotsReceived,
+	}
+	if msg.EncodeWidth > 0 && (msg.EncodeWidth != msg.Width || msg.EncodeHeight != msg.Height) {
+		stats["encodeResolution"] = fmt.Sprintf("%dx%d", msg.EncodeWidth, msg.EncodeHeight)
+	}
+	if msg.EncoderSpeed > 0 {
+		stats["encoderSpeed"] = msg.EncoderSpeed
+	}
+	if msg.LastError != "" {
+		stats["lastError"] = msg.LastError
+	}
+	return stats
+}
+
+// latest returns the telemetry and its age if it's recent enough to trust
+func (r *RemoteStreamer) latest() (ipc.TelemetryMessage, time.Duration, bool) {
+	msg, receivedAt, ok := r.source.LatestTelemetry()
+	age := time.Since(receivedAt)
+	if !ok || age > ipc.TelemetryStale {
+		return ipc.TelemetryMessage{}, 0, false
+	}
+	return msg, age, true
+}
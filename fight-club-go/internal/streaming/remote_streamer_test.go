@@ -0,0 +1,154 @@
+package streaming
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"fight-club/internal/ipc"
+)
+
+// TestRemoteStreamerTelemetry verifies streamer telemetry crosses the IPC
+// socket into the server's stats, and that the placeholder stats return
+// before any telemetry arrives
+func TestRemoteStreamerTelemetry(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "fight-club.sock")
+	pub := ipc.NewPublisher(socket)
+	if err := pub.Start(); err != nil {
+		t.Fatalf("Publisher start: %v", err)
+	}
+	defer pub.Stop()
+
+	remote := NewRemoteStreamer(pub)
+	if stats := remote.GetStats(); stats["connected"] != false || stats["mode"] != "external" || remote.IsStreaming() {
+		t.Fatalf("Expected placeholder stats without telemetry, got %v", stats)
+	}
+
+	sub := ipc.NewSubscriber(socket)
+	if err := sub.Start(); err != nil {
+		t.Fatalf("Subscriber start: %v", err)
+	}
+	defer sub.Stop()
+
+	sent := ipc.TelemetryMessage{
+		Streaming:    true,
+		FramesSent:   900,
+		ActualFPS:    29.8,
+		EncoderSpeed: 1.02,
+		Width:        1280,
+		Height:       720,
+		EncodeWidth:  960,
+		EncodeHeight: 540,
+		FPS:          30,
+		Renderer:     "gg",
+	}
+	deadline := time.Now().Add(3 * time.Second)
+	for !remote.IsStreaming() {
+		if time.Now().After(deadline) {
+			t.Fatal("Telemetry never reached the publisher")
+		}
+		sub.SendTelemetry(sent)
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	stats := remote.GetStats()
+	checks := map[string]interface{}{
+		"connected":        true,
+		"framesSent":       int64(900),
+		"actualFps":        "29.8",
+		"resolution":       "1280x720",
+		"encodeResolution": "960x540",
+		"encoderSpeed":     1.02,
+		"renderer":         "gg",
+	}
+	for key, want := range checks {
+		if stats[key] != want {
+			t.Errorf("stats[%q] = %v, want %v", key, stats[key], want)
+		}
+	}
+}
+
+// TestRemoteStreamerControl verifies /api/stream/start, /stop and
+// /api/admin/stream/keyframe reach the streamer process over IPC and its
+// errors come back
+func TestRemoteStreamerControl(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "fight-club.sock")
+	pub := ipc.NewPublisher(socket)
+	if err := pub.Start(); err != nil {
+		t.Fatalf("Publisher start: %v", err)
+	}
+	defer pub.Stop()
+
+	remote := NewRemoteStreamer(pub)
+	if err := remote.Start(); err == nil {
+		t.Fatal("Expected Start to fail without a streamer process")
+	}
+
+	commands := make(chan ipc.ControlCommand, 4)
+	var fail atomic.Bool
+	sub := ipc.NewSubscriber(socket)
+	sub.OnControl(func(cmd ipc.ControlCommand) error {
+		commands <- cmd
+		if fail.Load() {
+			return errors.New("invalid stream key")
+		}
+		return nil
+	})
+	if err := sub.Start(); err != nil {
+		t.Fatalf("Subscriber start: %v", err)
+	}
+	defer sub.Stop()
+
+	deadline := time.Now().Add(3 * time.Second)
+	for clients, _, _ := pub.GetStats(); clients == 0; clients, _, _ = pub.GetStats() {
+		if time.Now().After(deadline) {
+			t.Fatal("Streamer never connected")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if err := remote.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	if cmd := <-commands; cmd != ipc.ControlStart {
+		t.Errorf("Expected a start command, got %q", cmd)
+	}
+	remote.Stop()
+	if cmd := <-commands; cmd != ipc.ControlStop {
+		t.Errorf("Expected a stop command, got %q", cmd)
+	}
+	if err := remote.ForceKeyframe(); err != nil {
+		t.Fatalf("ForceKeyframe failed: %v", err)
+	}
+	if cmd := <-commands; cmd != ipc.ControlKeyframe {
+		t.Errorf("Expected a keyframe command, got %q", cmd)
+	}
+
+	fail.Store(true)
+	if err := remote.Start(); err == nil || !strings.Contains(err.Error(), "invalid stream key") {
+		t.Errorf("Expected the streamer's error, got %v", err)
+	}
+}
+
+// TestForceKeyframeNotStreaming verifies a keyframe needs a live stream
+func TestForceKeyframeNotStreaming(t *testing.T) {
+	s := NewStreamManager(nil, StreamConfig{Width: 640, Height: 360, FPS: 24, Bitrate: 2500})
+	if err := s.ForceKeyframe(); !errors.Is(err, ErrNotStreaming) {
+		t.Errorf("Expected ErrNotStreaming, got %v", err)
+	}
+}
+
+// TestStreamManagerTelemetry verifies the sampled telemetry mirrors the config
+func TestStreamManagerTelemetry(t *testing.T) {
+	s := NewStreamManager(nil, StreamConfig{Width: 640, Height: 360, FPS: 24, Bitrate: 2500})
+	msg := s.Telemetry()
+	if msg.Streaming || msg.Width != 640 || msg.Height != 360 || msg.FPS != 24 || msg.Bitrate != 2500 {
+		t.Errorf("Unexpected idle telemetry: %+v", msg)
+	}
+	if msg.Timestamp == 0 {
+		t.Error("Expected a sample timestamp")
+	}
+}
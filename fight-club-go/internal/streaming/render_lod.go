@@ -0,0 +1,160 @@
+package streaming
+
+import (
+	"sync/atomic"
+
+	"fight-club/internal/game"
+
+	"github.com/fogleman/gg"
+)
+
+// Render LOD tuning. Every threshold has a looser exit value so players on
+// the edge don't flicker between a full card and a dot.
+const (
+	lodEnterPlayers = 100   // Alive fighters that switch LOD on
+	lodExitPlayers  = 80    // ... and back off
+	lodTopN         = 12    // Kill leaders always drawn in full
+	lodTopNExit     = 16    // A leader keeps its card until it drops past this rank
+	lodNearRadius   = 220.0 // World px around a leader drawn in full
+	lodNearExit     = 280.0
+	lodDotScale     = 0.6 // Dot radius relative to the body
+)
+
+// renderLOD decides per frame which players get the full player card (avatar,
+// HP and stamina bars, name, money, rank) and which are drawn as a colored
+// dot. With a crowded arena only the top-N fighters by kills and the fighters
+// around them are drawn in full. Updated and read by the render loop only,
+// except Stats.
+type renderLOD struct {
+	active  bool
+	full    map[string]bool // Player IDs drawn in full this frame
+	prev    map[string]bool // ... and last frame (hysteresis)
+	leaders []int           // Scratch: snapshot indexes of the leaders
+
+	// Last frame, for Stats
+	statActive atomic.Bool
+	statFull   atomic.Int32
+	statDots   atomic.Int32
+}
+
+// newRenderLOD creates the LOD controller (nil when disabled)
+func newRenderLOD(enabled bool) *renderLOD {
+	if !enabled {
+		return nil
+	}
+	return &renderLOD{
+		full: make(map[string]bool),
+		prev: make(map[string]bool),
+	}
+}
+
+// Update picks this frame's full-detail players. Players must be sorted
+// alive first, then by kills (as the engine sends them). Call once per
+// rendered frame, before drawing players.
+func (l *renderLOD) Update(players []game.PlayerSnapshot) {
+	if l == nil {
+		return
+	}
+
+	alive := 0
+	for i := range players {
+		if !players[i].IsDead {
+			alive++
+		}
+	}
+	switch {
+	case !l.active && alive > lodEnterPlayers:
+		l.active = true
+	case l.active && alive < lodExitPlayers:
+		l.active = false
+	}
+
+	l.prev, l.full = l.full, l.prev
+	clear(l.full)
+	dots := 0
+	defer func() {
+		l.statActive.Store(l.active)
+		l.statFull.Store(int32(len(l.full)))
+		l.statDots.Store(int32(dots))
+	}()
+	if !l.active {
+		clear(l.prev)
+		return
+	}
+
+	// Leaders: the top ranks, plus former leaders that haven't slipped far
+	l.leaders = l.leaders[:0]
+	for i := range players {
+		p := &players[i]
+		if p.IsDead || i >= lodTopNExit {
+			break
+		}
+		if i < lodTopN || l.prev[p.ID] {
+			l.leaders = append(l.leaders, i)
+		}
+	}
+
+	for i := range players {
+		p := &players[i]
+		if p.IsDead {
+			continue
+		}
+		radius := lodNearRadius
+		if l.prev[p.ID] {
+			radius = lodNearExit
+		}
+		full := false
+		for _, li := range l.leaders {
+			leader := &players[li]
+			dx, dy := p.X-leader.X, p.Y-leader.Y
+			if li == i || dx*dx+dy*dy <= radius*radius {
+				full = true
+				break
+			}
+		}
+		if full {
+			l.full[p.ID] = true
+		} else {
+			dots++
+		}
+	}
+}
+
+// Full reports whether a player gets the full player card this frame
+func (l *renderLOD) Full(p *game.PlayerSnapshot) bool {
+	return l == nil || !l.active || l.full[p.ID]
+}
+
+// Stats returns LOD stats for monitoring
+func (l *renderLOD) Stats() map[string]interface{} {
+	if l == nil {
+		return map[string]interface{}{"enabled": false}
+	}
+	return map[string]interface{}{
+		"enabled": true,
+		"active":  l.statActive.Load(),
+		"full":    l.statFull.Load(),
+		"dots":    l.statDots.Load(),
+	}
+}
+
+// drawPlayerDot draws a low-detail player: a dot in the player's color
+func (s *StreamManager) drawPlayerDot(dc *gg.Context, p game.PlayerSnapshot, sizeScale float64) {
+	dc.SetColor(parseHexColor(p.Color))
+	dc.DrawCircle(p.X, p.Y, 30.0*sizeScale*lodDotScale)
+	dc.Fill()
+}
+
+// drawPlayerDot blits the cached dot sprite for a low-detail player
+func (a *AtlasRenderer) drawPlayerDot(buffer []byte, p *game.PlayerSnapshot, sizeScale float64) {
+	key := "dot:" + p.Color
+	if sizeScale != 1.0 {
+		key += "@tiny"
+	}
+	sp, ok := a.bodies[key]
+	if !ok {
+		sp = a.renderCircle(30*sizeScale*lodDotScale, parseHexColor(p.Color), false)
+		a.bodies[key] = sp
+	}
+	a.blit(buffer, sp, p.X, p.Y, 255)
+}
@@ -0,0 +1,102 @@
+package streaming
+
+import (
+	"fmt"
+	"testing"
+
+	"fight-club/internal/game"
+)
+
+// lodCrowd returns n alive players sorted by kills, spread on a grid far from
+// each other, with player 0 the kill leader
+func lodCrowd(n int) []game.PlayerSnapshot {
+	players := make([]game.PlayerSnapshot, n)
+	for i := range players {
+		players[i] = game.PlayerSnapshot{
+			ID:    fmt.Sprintf("p%d", i),
+			X:     float64(i%20) * 1000,
+			Y:     float64(i/20) * 1000,
+			Kills: n - i,
+		}
+	}
+	return players
+}
+
+// TestRenderLOD verifies leaders and their neighbours keep full detail once
+// the arena is crowded, and that the on/off switch has hysteresis
+func TestRenderLOD(t *testing.T) {
+	lod := newRenderLOD(true)
+	players := lodCrowd(lodEnterPlayers)
+
+	lod.Update(players)
+	if !lod.Full(&players[lodEnterPlayers-1]) {
+		t.Fatal("Expected full detail at the threshold")
+	}
+
+	players = lodCrowd(lodEnterPlayers + 1)
+	players[50].X, players[50].Y = players[0].X+lodNearRadius-10, players[0].Y // Next to the leader
+	lod.Update(players)
+	if !lod.Full(&players[0]) || !lod.Full(&players[lodTopN-1]) || !lod.Full(&players[50]) {
+		t.Error("Expected the leaders and their neighbour in full")
+	}
+	if lod.Full(&players[lodTopN]) || lod.Full(&players[80]) {
+		t.Error("Expected the rest as dots")
+	}
+	if stats := lod.Stats(); stats["active"] != true || stats["full"] != int32(lodTopN+1) {
+		t.Errorf("Unexpected stats %v", stats)
+	}
+
+	// Dropping below the enter threshold keeps LOD on until the exit threshold
+	lod.Update(players[:lodExitPlayers])
+	if lod.Full(&players[70]) {
+		t.Error("Expected LOD to stay on above the exit threshold")
+	}
+	lod.Update(players[:lodExitPlayers-1])
+	if !lod.Full(&players[70]) {
+		t.Error("Expected full detail below the exit threshold")
+	}
+
+	var disabled *renderLOD
+	disabled.Update(players)
+	if !disabled.Full(&players[80]) {
+		t.Error("Expected a disabled LOD to draw everyone in full")
+	}
+}
+
+// TestRenderLODHysteresis verifies players on the edge keep their detail
+// until they clearly leave it
+func TestRenderLODHysteresis(t *testing.T) {
+	lod := newRenderLOD(true)
+	players := lodCrowd(lodEnterPlayers + 20)
+	players[50].X, players[50].Y = players[0].X+lodNearRadius-10, players[0].Y
+	lod.Update(players)
+
+	// Moving just past the enter radius keeps the neighbour in full
+	players[50].X = players[0].X + (lodNearRadius+lodNearExit)/2
+	lod.Update(players)
+	if !lod.Full(&players[50]) {
+		t.Error("Expected the neighbour in full inside the exit radius")
+	}
+	players[50].X = players[0].X + lodNearExit + 10
+	lod.Update(players)
+	if lod.Full(&players[50]) {
+		t.Error("Expected the neighbour as a dot past the exit radius")
+	}
+
+	// A leader slipping a few ranks keeps its card, further loses it
+	leader := players[lodTopN-1]
+	moved := append([]game.PlayerSnapshot{}, players[:lodTopN-1]...)
+	moved = append(moved, players[lodTopN:lodTopNExit]...)
+	moved = append(moved, leader)
+	moved = append(moved, players[lodTopNExit:]...)
+	lod.Update(moved)
+	if !lod.Full(&leader) {
+		t.Error("Expected a leader a few ranks down still in full")
+	}
+	moved = append(append([]game.PlayerSnapshot{}, players[:lodTopN-1]...), players[lodTopN:]...)
+	moved = append(moved, leader)
+	lod.Update(moved)
+	if lod.Full(&leader) {
+		t.Error("Expected a leader far down the ranks as a dot")
+	}
+}
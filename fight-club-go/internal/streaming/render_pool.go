@@ -60,6 +60,7 @@ func (p *RenderWorkerPool) Start() {
 	}
 
 	p.running = true
+	p.wg.Add(p.numWorkers) // Before the workers run, so Stop always waits for them
 	for i := 0; i < p.numWorkers; i++ {
 		go p.worker()
 	}
@@ -81,7 +82,6 @@ func (p *RenderWorkerPool) Stop() {
 
 // worker processes render jobs from the channel
 func (p *RenderWorkerPool) worker() {
-	p.wg.Add(1)
 	defer p.wg.Done()
 
 	for job := range p.jobChan {
@@ -112,8 +112,8 @@ func (p *RenderWorkerPool) processParticleJob(job renderJob) {
 		x := int(particle.X + 0.5)
 		y := int(particle.Y + 0.5)
 
-		// Draw particle as a small filled circle (radius 2-3 pixels)
-		renderer.DrawFilledCircleBlend(x, y, 2, c)
+		// Draw particle as a small filled circle (radius from its style)
+		renderer.DrawFilledCircleBlend(x, y, particleRadius(particle.Size), c)
 	}
 }
 
@@ -196,10 +196,19 @@ func (p *RenderWorkerPool) renderParticlesSequential(particles []*game.Particle,
 
 		x := int(particle.X + 0.5)
 		y := int(particle.Y + 0.5)
-		renderer.DrawFilledCircleBlend(x, y, 2, c)
+		renderer.DrawFilledCircleBlend(x, y, particleRadius(particle.Size), c)
 	}
 }
 
+// particleRadius returns a particle's draw radius (2 for particles without
+// a size, e.g. from a streamer talking to an older server)
+func particleRadius(size float64) float64 {
+	if size <= 0 {
+		return 2
+	}
+	return size
+}
+
 // parseHexColorFast is an optimized hex color parser
 func parseHexColorFast(hex string) color.RGBA {
 	if len(hex) != 7 || hex[0] != '#' {
@@ -288,6 +297,6 @@ func (p *RenderWorkerPool) renderParticlesSnapshotSequential(particles []game.Pa
 
 		x := int(particle.X + 0.5)
 		y := int(particle.Y + 0.5)
-		renderer.DrawFilledCircleBlend(x, y, 2, c)
+		renderer.DrawFilledCircleBlend(x, y, particleRadius(particle.Size), c)
 	}
 }
@@ -0,0 +1,14 @@
+package streaming
+
+import "testing"
+
+// TestRenderWorkerPoolStopRightAfterStart verifies Stop waits for workers that
+// haven't been scheduled yet (run with -race)
+func TestRenderWorkerPoolStopRightAfterStart(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		pool := NewRenderWorkerPool(4)
+		pool.Start()
+		pool.Stop()
+		pool.Stop() // Stopping twice is a no-op
+	}
+}
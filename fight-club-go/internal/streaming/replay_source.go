@@ -0,0 +1,108 @@
+package streaming
+
+import (
+	"errors"
+	"io"
+	"sync/atomic"
+	"time"
+
+	"fight-club/internal/game"
+	"fight-club/internal/ipc"
+)
+
+// maxReplayGap caps the wait between two recorded snapshots, so a stalled
+// server doesn't leave minutes of frozen frames in the re-render
+const maxReplayGap = time.Second
+
+// ReplaySource plays a snapshot recording (see ipc.SnapshotRecorder) back in
+// real time, as if the snapshots were arriving over IPC. The StreamManager
+// renders it like a live stream, so interpolation works the same.
+type ReplaySource struct {
+	pair   atomic.Pointer[snapshotPair]
+	played atomic.Int64 // Recording time reached (time.Duration)
+	stop   chan struct{}
+	sleep  func(time.Duration) // Replaced in tests
+}
+
+// NewReplaySource creates an idle replay source (see Play)
+func NewReplaySource() *ReplaySource {
+	return &ReplaySource{stop: make(chan struct{}), sleep: time.Sleep}
+}
+
+// Play releases snapshots from next at their recorded pace until the
+// recording ends, to is reached (0 = the end) or Stop is called. Snapshots
+// before from are skipped without waiting. next returns io.EOF at the end.
+func (s *ReplaySource) Play(next func() (*ipc.SnapshotMessage, error), from, to time.Duration) error {
+	var start, last time.Time
+	released := false
+	for {
+		select {
+		case <-s.stop:
+			return nil
+		default:
+		}
+
+		msg, err := next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		ts := time.Unix(0, msg.Timestamp)
+		if start.IsZero() {
+			start = ts
+		}
+		at := ts.Sub(start)
+		if at < from {
+			continue
+		}
+		if to > 0 && at > to {
+			return nil
+		}
+
+		if released {
+			s.sleep(min(max(ts.Sub(last), 0), maxReplayGap))
+		}
+		last, released = ts, true
+
+		snap := msg.ToGameSnapshot()
+		nextPair := &snapshotPair{curr: snap, currAt: time.Now()}
+		if old := s.pair.Load(); old != nil {
+			nextPair.prev = old.curr
+		}
+		s.pair.Store(nextPair)
+		s.played.Store(int64(at))
+	}
+}
+
+// Stop ends Play early
+func (s *ReplaySource) Stop() {
+	select {
+	case <-s.stop:
+	default:
+		close(s.stop)
+	}
+}
+
+// Played returns how far into the recording the replay is
+func (s *ReplaySource) Played() time.Duration {
+	return time.Duration(s.played.Load())
+}
+
+// GetSnapshot returns the snapshot being shown
+func (s *ReplaySource) GetSnapshot() *game.GameSnapshot {
+	if p := s.pair.Load(); p != nil {
+		return p.curr
+	}
+	return nil
+}
+
+// GetSnapshotPair returns the last two replayed snapshots for interpolation
+func (s *ReplaySource) GetSnapshotPair() (prev, curr *game.GameSnapshot, currAt time.Time) {
+	if p := s.pair.Load(); p != nil {
+		return p.prev, p.curr, p.currAt
+	}
+	return nil, nil, time.Time{}
+}
@@ -0,0 +1,111 @@
+package streaming
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"fight-club/internal/game"
+	"fight-club/internal/ipc"
+)
+
+var errTestRead = errors.New("disk read error")
+
+// recordTestSnapshots writes n snapshots 50ms apart (20 TPS) to a new recording
+func recordTestSnapshots(t *testing.T, n int) string {
+	t.Helper()
+	rec, err := ipc.NewSnapshotRecorder(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewSnapshotRecorder: %v", err)
+	}
+	if err := rec.RecordConfig(ipc.ConfigMessage{Width: 960, Height: 540, FPS: 30, Bitrate: 3000}); err != nil {
+		t.Fatalf("RecordConfig: %v", err)
+	}
+	start := time.Date(2026, 10, 15, 20, 0, 0, 0, time.UTC)
+	for i := 0; i < n; i++ {
+		msg := &ipc.SnapshotMessage{
+			Sequence:   uint64(i + 1),
+			Timestamp:  start.Add(time.Duration(i) * 50 * time.Millisecond).UnixNano(),
+			TickNumber: uint64(i),
+			TickRate:   20,
+			Players:    []ipc.PlayerData{{ID: "p1", Name: "ana", X: float64(i), Y: 10, HP: 100, MaxHP: 100}},
+		}
+		if err := rec.RecordSnapshot(msg); err != nil {
+			t.Fatalf("RecordSnapshot: %v", err)
+		}
+	}
+	if err := rec.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if rec.Snapshots() != int64(n) {
+		t.Fatalf("Expected %d recorded snapshots, got %d", n, rec.Snapshots())
+	}
+	return rec.Path()
+}
+
+// TestReplaySourcePlaysRecording verifies a recording round-trips and replays at its recorded pace
+func TestReplaySourcePlaysRecording(t *testing.T) {
+	path := recordTestSnapshots(t, 40)
+
+	reader, err := ipc.OpenSnapshotRecording(path)
+	if err != nil {
+		t.Fatalf("OpenSnapshotRecording: %v", err)
+	}
+	defer reader.Close()
+	if cfg := reader.Config(); cfg.Width != 960 || cfg.Height != 540 {
+		t.Fatalf("Expected the recorded arena size up front, got %dx%d", cfg.Width, cfg.Height)
+	}
+
+	source := NewReplaySource()
+	var slept time.Duration
+	var seen []*game.GameSnapshot
+	source.sleep = func(d time.Duration) {
+		slept += d
+		seen = append(seen, source.GetSnapshot())
+	}
+
+	// Skip the first half second, stop after 1.5s: ticks 10..30
+	if err := source.Play(reader.Next, 500*time.Millisecond, 1500*time.Millisecond); err != nil {
+		t.Fatalf("Play: %v", err)
+	}
+	if slept != time.Second {
+		t.Errorf("Expected 1s of pacing between the played snapshots, got %s", slept)
+	}
+	if seen[0].TickNumber != 10 || source.GetSnapshot().TickNumber != 30 {
+		t.Errorf("Expected ticks 10..30, got %d..%d", seen[0].TickNumber, source.GetSnapshot().TickNumber)
+	}
+	if source.Played() != 1500*time.Millisecond {
+		t.Errorf("Expected to stop at 1.5s, got %s", source.Played())
+	}
+
+	prev, curr, _ := source.GetSnapshotPair()
+	if prev == nil || prev.Sequence+1 != curr.Sequence || curr.Players[0].X != 30 {
+		t.Errorf("Expected consecutive snapshots for interpolation, got %v / %v", prev, curr)
+	}
+}
+
+// TestReplaySourceCapsGaps verifies a stalled server doesn't become minutes of frozen frames
+func TestReplaySourceCapsGaps(t *testing.T) {
+	msgs := []*ipc.SnapshotMessage{
+		{Sequence: 1, Timestamp: 0},
+		{Sequence: 2, Timestamp: int64(5 * time.Minute)},
+	}
+	next := func() (*ipc.SnapshotMessage, error) {
+		if len(msgs) == 0 {
+			return nil, errTestRead
+		}
+		m := msgs[0]
+		msgs = msgs[1:]
+		return m, nil
+	}
+
+	source := NewReplaySource()
+	var slept time.Duration
+	source.sleep = func(d time.Duration) { slept += d }
+	if err := source.Play(next, 0, 0); err != errTestRead {
+		t.Fatalf("Expected the reader error, got %v", err)
+	}
+	if slept != maxReplayGap {
+		t.Errorf("Expected the gap capped at %s, slept %s", maxReplayGap, slept)
+	}
+}
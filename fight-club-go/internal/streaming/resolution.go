@@ -0,0 +1,417 @@
+package streaming
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/fogleman/gg"
+)
+
+// renderScales are the resolution steps tried under encoder pressure
+// (1280x720 → 960x540 → 640x360)
+var renderScales = []float64{1.0, 0.75, 0.5}
+
+// maxUpgradeBackoff caps how long a failed upgrade delays the next attempt
+const maxUpgradeBackoff = 30 * time.Minute
+
+// AdaptiveResolutionConfig enables dynamic resolution scaling: when FFmpeg
+// can't keep up (speed < 1.0x) frames are sent at a lower resolution and
+// upscaled back to the output size in FFmpeg, so the stream resolution
+// Kick sees never changes.
+type AdaptiveResolutionConfig struct {
+	Enabled   bool
+	MinScale  float64       // Lowest scale to drop to (default 0.5)
+	DownSpeed float64       // Speed below which the encoder is under pressure (default 0.95)
+	UpSpeed   float64       // Speed at or above which there is headroom (default 0.99)
+	DownAfter time.Duration // Sustained pressure before dropping a step (default 10s)
+	UpAfter   time.Duration // Sustained headroom before raising a step (default 2m)
+	Settle    time.Duration // Readings ignored after a change while FFmpeg restarts (default 15s)
+}
+
+// withDefaults fills in unset thresholds
+func (c AdaptiveResolutionConfig) withDefaults() AdaptiveResolutionConfig {
+	if c.MinScale <= 0 || c.MinScale > 1 {
+		c.MinScale = 0.5
+	}
+	if c.DownSpeed <= 0 {
+		c.DownSpeed = 0.95
+	}
+	if c.UpSpeed < c.DownSpeed {
+		c.UpSpeed = max(0.99, c.DownSpeed)
+	}
+	if c.DownAfter <= 0 {
+		c.DownAfter = 10 * time.Second
+	}
+	if c.UpAfter <= 0 {
+		c.UpAfter = 2 * time.Minute
+	}
+	if c.Settle <= 0 {
+		c.Settle = 15 * time.Second
+	}
+	return c
+}
+
+// ResolutionController is a hysteresis controller choosing the render scale
+// from FFmpeg speed readings. A live rawvideo pipe never reports much above
+// 1.0x, so headroom is "keeping up for UpAfter"; an upgrade that falls
+// behind again doubles the wait before the next try.
+type ResolutionController struct {
+	mu  sync.Mutex
+	cfg AdaptiveResolutionConfig
+
+	scales     []float64
+	level      int // Index into scales (0 = full resolution)
+	lowSince   time.Time
+	okSince    time.Time
+	lastChange time.Time
+	lastWasUp  bool
+	upAfter    time.Duration // Current upgrade wait (grows after failed upgrades)
+}
+
+// NewResolutionController creates a controller starting at full resolution
+func NewResolutionController(cfg AdaptiveResolutionConfig) *ResolutionController {
+	cfg = cfg.withDefaults()
+	c := &ResolutionController{cfg: cfg, upAfter: cfg.UpAfter}
+	for _, scale := range renderScales {
+		if scale >= cfg.MinScale-1e-9 {
+			c.scales = append(c.scales, scale)
+		}
+	}
+	return c
+}
+
+// Scale returns the current render scale (1.0 = full resolution)
+func (c *ResolutionController) Scale() float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.scales[c.level]
+}
+
+// Observe feeds an FFmpeg speed reading. Returns the new scale and true when
+// the render resolution should change.
+func (c *ResolutionController) Observe(speed float64, now time.Time) (float64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// FFmpeg restarts report low speeds while the pipe fills up
+	if !c.lastChange.IsZero() && now.Sub(c.lastChange) < c.cfg.Settle {
+		return c.scales[c.level], false
+	}
+
+	switch {
+	case speed < c.cfg.DownSpeed:
+		c.okSince = time.Time{}
+		if c.lowSince.IsZero() {
+			c.lowSince = now
+		}
+		if now.Sub(c.lowSince) >= c.cfg.DownAfter && c.level < len(c.scales)-1 {
+			if c.lastWasUp {
+				// The last upgrade didn't hold: back off before trying again
+				c.upAfter = min(c.upAfter*2, maxUpgradeBackoff)
+			}
+			c.level++
+			c.lastWasUp = false
+			c.changed(now)
+			return c.scales[c.level], true
+		}
+
+	case speed >= c.cfg.UpSpeed:
+		c.lowSince = time.Time{}
+		if c.okSince.IsZero() {
+			c.okSince = now
+		}
+		if c.lastWasUp && now.Sub(c.lastChange) >= c.cfg.UpAfter {
+			c.upAfter = c.cfg.UpAfter // Upgrade held, reset the backoff
+		}
+		if now.Sub(c.okSince) >= c.upAfter && c.level > 0 {
+			c.level--
+			c.lastWasUp = true
+			c.changed(now)
+			return c.scales[c.level], true
+		}
+
+	default:
+		// Dead band between the thresholds: no trend either way
+		c.lowSince = time.Time{}
+		c.okSince = time.Time{}
+	}
+	return c.scales[c.level], false
+}
+
+// changed resets the trend windows after a resolution change. Caller holds c.mu.
+func (c *ResolutionController) changed(now time.Time) {
+	c.lastChange = now
+	c.lowSince = time.Time{}
+	c.okSince = time.Time{}
+}
+
+// scaledSize returns the render size for a scale, rounded down to even
+// dimensions (required by yuv420p)
+func scaledSize(width, height int, scale float64) (int, int) {
+	if scale >= 1 {
+		return width, height
+	}
+	w := int(float64(width)*scale) &^ 1
+	h := int(float64(height)*scale) &^ 1
+	return max(w, 2), max(h, 2)
+}
+
+// parseFFmpegSpeedField extracts the speed from an FFmpeg progress line
+// ("frame= 240 fps= 24 ... speed=0.987x"). Returns false if there is none.
+func parseFFmpegSpeedField(line string) (float64, bool) {
+	idx := strings.LastIndex(line, "speed=")
+	if idx < 0 {
+		return 0, false
+	}
+	value := strings.TrimSpace(line[idx+len("speed="):])
+	if end := strings.IndexByte(value, 'x'); end >= 0 {
+		value = value[:end]
+	}
+	speed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return 0, false // "speed=N/A" at startup
+	}
+	return speed, true
+}
+
+// ffmpegProgressWriter passes FFmpeg's stderr through and reports the speed
+// of every progress line (FFmpeg separates them with \r)
+type ffmpegProgressWriter struct {
+	out     io.Writer
+	onSpeed func(speed float64)
+	partial []byte
+}
+
+func newFFmpegProgressWriter(out io.Writer, onSpeed func(speed float64)) *ffmpegProgressWriter {
+	return &ffmpegProgressWriter{out: out, onSpeed: onSpeed}
+}
+
+func (w *ffmpegProgressWriter) Write(p []byte) (int, error) {
+	w.partial = append(w.partial, p...)
+	for {
+		end := bytes.IndexAny(w.partial, "\r\n")
+		if end < 0 {
+			break
+		}
+		if speed, ok := parseFFmpegSpeedField(string(w.partial[:end])); ok && w.onSpeed != nil {
+			w.onSpeed(speed)
+		}
+		w.partial = w.partial[end+1:]
+	}
+	if len(w.partial) > 4096 {
+		w.partial = w.partial[:0] // No line breaks at all: not progress output
+	}
+
+	if w.out != nil {
+		return w.out.Write(p)
+	}
+	return len(p), nil
+}
+
+// frameScaler downsamples RGBA frames with bilinear filtering. Source
+// coordinates and weights are precomputed per size.
+type frameScaler struct {
+	srcW, srcH, dstW, dstH int
+	xOff                   []int    // Byte offset of the left source pixel per column
+	xWeight                []uint32 // Right pixel weight per column (0-256)
+	yRow                   []int    // Top source row per output row
+	yWeight                []uint32 // Bottom row weight per output row (0-256)
+}
+
+func newFrameScaler(srcW, srcH, dstW, dstH int) *frameScaler {
+	fs := &frameScaler{srcW: srcW, srcH: srcH, dstW: dstW, dstH: dstH}
+	fs.xOff, fs.xWeight = scalerTaps(srcW, dstW, 4)
+	fs.yRow, fs.yWeight = scalerTaps(srcH, dstH, 1)
+	return fs
+}
+
+// scalerTaps maps each output coordinate to its first source coordinate
+// (times stride) and the weight of the next one
+func scalerTaps(src, dst, stride int) ([]int, []uint32) {
+	offsets := make([]int, dst)
+	weights := make([]uint32, dst)
+	ratio := float64(src) / float64(dst)
+	for i := range offsets {
+		pos := (float64(i)+0.5)*ratio - 0.5
+		pos = max(pos, 0)
+		base := int(pos)
+		if base >= src-1 {
+			base, pos = src-1, float64(src-1)
+		}
+		offsets[i] = base * stride
+		weights[i] = uint32((pos - float64(base)) * 256)
+	}
+	return offsets, weights
+}
+
+// Scale writes the downsampled src frame into dst, splitting rows across CPUs
+func (fs *frameScaler) Scale(src, dst []byte) {
+	workers := min(runtime.NumCPU(), 8)
+	rowsPer := (fs.dstH + workers - 1) / workers
+
+	var wg sync.WaitGroup
+	for start := 0; start < fs.dstH; start += rowsPer {
+		end := min(start+rowsPer, fs.dstH)
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			fs.scaleRows(src, dst, start, end)
+		}(start, end)
+	}
+	wg.Wait()
+}
+
+func (fs *frameScaler) scaleRows(src, dst []byte, start, end int) {
+	srcStride := fs.srcW * 4
+	for y := start; y < end; y++ {
+		top := fs.yRow[y] * srcStride
+		bottom := top
+		if fs.yRow[y] < fs.srcH-1 {
+			bottom += srcStride
+		}
+		wy := fs.yWeight[y]
+		out := y * fs.dstW * 4
+
+		for x := 0; x < fs.dstW; x++ {
+			left := fs.xOff[x]
+			right := left
+			if left < srcStride-4 {
+				right += 4
+			}
+			wx := fs.xWeight[x]
+			for c := 0; c < 4; c++ {
+				t := uint32(src[top+left+c])*(256-wx) + uint32(src[top+right+c])*wx
+				b := uint32(src[bottom+left+c])*(256-wx) + uint32(src[bottom+right+c])*wx
+				dst[out+c] = byte((t*(256-wy) + b*wy) >> 16)
+			}
+			out += 4
+		}
+	}
+}
+
+// onEncoderSpeed tracks FFmpeg's speed and renegotiates the render
+// resolution when the controller asks for a change. Called from FFmpeg's
+// stderr copier, so it must not take s.mu (Stop holds it while waiting for FFmpeg).
+func (s *StreamManager) onEncoderSpeed(speed float64) {
+	atomic.StoreUint64(&s.encoderSpeed, math.Float64bits(speed))
+
+	if s.resolution == nil {
+		return
+	}
+	scale, changed := s.resolution.Observe(speed, time.Now())
+	if !changed {
+		return
+	}
+
+	w, h := scaledSize(s.config.Width, s.config.Height, scale)
+	log.Printf("📐 Encoder at %.2fx, switching render resolution to %dx%d", speed, w, h)
+	go s.renegotiateResolution()
+}
+
+// renegotiateResolution restarts FFmpeg so its rawvideo input matches the new
+// render size (a pipe can't change frame size mid-stream). Skipped while a
+// reconnection is already restarting the stream.
+func (s *StreamManager) renegotiateResolution() {
+	if !atomic.CompareAndSwapInt32(&s.reconnecting, 0, 1) {
+		return
+	}
+	var err error
+	if s.IsStreaming() {
+		err = s.Restart()
+	}
+	atomic.StoreInt32(&s.reconnecting, 0)
+
+	if err != nil {
+		log.Printf("❌ Resolution change failed: %v", err)
+		s.handleConnectionLost()
+	}
+}
+
+// prepareEncodeSize sets the FFmpeg input size for the current render scale
+// and sizes the frame buffers to match. Caller holds s.mu.
+func (s *StreamManager) prepareEncodeSize() {
+	scale := 1.0
+	if s.resolution != nil {
+		scale = s.resolution.Scale()
+	}
+	w, h := scaledSize(s.config.Width, s.config.Height, scale)
+	s.encodeWidth, s.encodeHeight = w, h
+
+	if w == s.config.Width && h == s.config.Height {
+		s.scaler = nil
+		s.scaledFrame = nil
+	} else if s.scaler == nil || s.scaler.dstW != w || s.scaler.dstH != h {
+		s.scaler = newFrameScaler(s.config.Width, s.config.Height, w, h)
+		s.scaledFrame = make([]byte, w*h*4)
+	}
+
+	if frameSize := w * h * 4; s.frameRingBuffer.frameSize != frameSize {
+		s.frameRingBuffer = NewFrameRingBuffer(frameSize)
+	}
+}
+
+// Resize changes the output resolution to a new arena size (the game server
+// switched arena presets, see game.SetArenaPreset). Frame buffers, the
+// renderer, the camera and the portrait layout are rebuilt; a live stream
+// restarts FFmpeg at the new size.
+func (s *StreamManager) Resize(width, height int) error {
+	if width <= 0 || height <= 0 {
+		return fmt.Errorf("invalid resolution %dx%d", width, height)
+	}
+	if !atomic.CompareAndSwapInt32(&s.reconnecting, 0, 1) {
+		return fmt.Errorf("stream is reconnecting, try again")
+	}
+	defer atomic.StoreInt32(&s.reconnecting, 0)
+
+	live := s.IsStreaming()
+	if live {
+		s.stopInternal()
+		time.Sleep(500 * time.Millisecond) // Let the frame loop finish (see Restart)
+	}
+
+	s.mu.Lock()
+	s.config.Width, s.config.Height = width, height
+	s.resizeFrames()
+	s.mu.Unlock()
+	log.Printf("📐 Output resolution changed to %dx%d (arena resized)", width, height)
+
+	if !live {
+		return nil
+	}
+	return s.Start()
+}
+
+// resizeFrames reallocates everything sized by the output resolution.
+// Caller holds s.mu and the frame loop is stopped.
+func (s *StreamManager) resizeFrames() {
+	w, h := s.config.Width, s.config.Height
+	frameSize := w * h * 4
+
+	db := s.doubleBuffer
+	db.mu.Lock()
+	db.buffers = [2][]byte{make([]byte, frameSize), make([]byte, frameSize)}
+	db.contexts = [2]*gg.Context{gg.NewContext(w, h), gg.NewContext(w, h)}
+	db.activeIndex = 0
+	db.mu.Unlock()
+
+	s.fastRenderer = NewFastRenderer(w, h, db.buffers[0])
+	s.frameBuffer = make([]byte, frameSize)
+	s.frameRingBuffer = NewFrameRingBuffer(frameSize)
+	s.scaler, s.scaledFrame = nil, nil
+	s.preview.Resize(w, h)
+	s.framer = newAutoFramer(s.config.Theme, w, h)
+
+	s.atlas = nil
+	s.initRenderer()
+	s.portrait = nil
+	s.initPortrait()
+}
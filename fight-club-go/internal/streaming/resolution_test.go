@@ -0,0 +1,120 @@
+package streaming
+
+import (
+	"testing"
+	"time"
+)
+
+// TestResolutionControllerHysteresis verifies drops need sustained pressure,
+// raises need sustained headroom, and failed upgrades back off
+func TestResolutionControllerHysteresis(t *testing.T) {
+	c := NewResolutionController(AdaptiveResolutionConfig{
+		Enabled:   true,
+		DownAfter: 10 * time.Second,
+		UpAfter:   time.Minute,
+		Settle:    5 * time.Second,
+	})
+	now := time.Unix(0, 0)
+	feed := func(speed float64, d time.Duration) (float64, bool) {
+		var scale float64
+		var changed bool
+		for end := now.Add(d); !now.After(end); now = now.Add(time.Second) {
+			if s, ok := c.Observe(speed, now); ok {
+				scale, changed = s, true
+			}
+		}
+		return scale, changed
+	}
+
+	// A short dip doesn't change anything
+	if _, changed := feed(0.8, 5*time.Second); changed {
+		t.Fatal("Expected no change on a 5s dip")
+	}
+	feed(0.97, time.Second) // Dead band resets the trend
+	if scale, changed := feed(0.8, 10*time.Second); !changed || scale != 0.75 {
+		t.Fatalf("Expected drop to 0.75 after sustained pressure, got %.2f (%v)", scale, changed)
+	}
+	if scale, changed := feed(0.8, 20*time.Second); !changed || scale != 0.5 {
+		t.Fatalf("Expected drop to 0.5, got %.2f (%v)", scale, changed)
+	}
+	if _, changed := feed(0.5, time.Minute); changed {
+		t.Fatal("Expected no drop below MinScale")
+	}
+
+	// Headroom for UpAfter raises one step
+	if scale, changed := feed(1.0, 70*time.Second); !changed || scale != 0.75 {
+		t.Fatalf("Expected raise to 0.75, got %.2f (%v)", scale, changed)
+	}
+
+	// The upgrade fails: back down, and the next raise waits twice as long
+	feed(0.8, 20*time.Second)
+	if c.Scale() != 0.5 {
+		t.Fatalf("Expected back at 0.5, got %.2f", c.Scale())
+	}
+	if _, changed := feed(1.0, 90*time.Second); changed {
+		t.Error("Expected the next upgrade to back off")
+	}
+	if scale, changed := feed(1.0, 40*time.Second); !changed || scale != 0.75 {
+		t.Errorf("Expected raise after the doubled wait, got %.2f (%v)", scale, changed)
+	}
+}
+
+// TestFFmpegProgressWriter verifies speeds are parsed across split writes and \r-separated lines
+func TestFFmpegProgressWriter(t *testing.T) {
+	var speeds []float64
+	w := newFFmpegProgressWriter(nil, func(speed float64) { speeds = append(speeds, speed) })
+
+	w.Write([]byte("frame=  1 fps=0.0 q=0.0 size=0kB time=00:00:00.00 bitrate=N/A speed=N/A    \r"))
+	w.Write([]byte("frame= 240 fps= 24 q=23.0 size=1024kB time=00:00:10.00 bitrate=838.9kbits/s spe"))
+	w.Write([]byte("ed=0.987x    \rframe= 480 fps= 24 speed=1.01x\r"))
+
+	if len(speeds) != 2 || speeds[0] != 0.987 || speeds[1] != 1.01 {
+		t.Errorf("Expected [0.987 1.01], got %v", speeds)
+	}
+}
+
+// TestFrameScaler verifies the downsampled frame size and that flat colors survive filtering
+func TestFrameScaler(t *testing.T) {
+	w, h := scaledSize(1280, 720, 0.75)
+	if w != 960 || h != 540 {
+		t.Fatalf("Expected 960x540, got %dx%d", w, h)
+	}
+	if w, h := scaledSize(854, 480, 0.75); w%2 != 0 || h%2 != 0 {
+		t.Errorf("Expected even dimensions, got %dx%d", w, h)
+	}
+
+	src := make([]byte, 64*32*4)
+	for i := 0; i < len(src); i += 4 {
+		src[i], src[i+1], src[i+2], src[i+3] = 200, 100, 50, 255
+	}
+	dst := make([]byte, 48*24*4)
+	newFrameScaler(64, 32, 48, 24).Scale(src, dst)
+
+	for i := 0; i < len(dst); i += 4 {
+		if dst[i] < 199 || dst[i+1] < 99 || dst[i+2] < 49 || dst[i+3] < 254 {
+			t.Fatalf("Expected flat color preserved, got %v at pixel %d", dst[i:i+4], i/4)
+		}
+	}
+}
+
+// TestStreamManagerResize verifies an idle stream reallocates its frames for a new arena size
+func TestStreamManagerResize(t *testing.T) {
+	sm := NewStreamManager(nil, StreamConfig{Width: 1280, Height: 720})
+	t.Cleanup(sm.workerPool.Stop)
+
+	if err := sm.Resize(800, 600); err != nil {
+		t.Fatalf("Resize failed: %v", err)
+	}
+	if sm.config.Width != 800 || sm.config.Height != 600 {
+		t.Errorf("Expected 800x600, got %dx%d", sm.config.Width, sm.config.Height)
+	}
+	if n := len(sm.doubleBuffer.buffers[1]); n != 800*600*4 || sm.frameRingBuffer.frameSize != n {
+		t.Errorf("Expected 800x600 frame buffers, got %d bytes (ring %d)", n, sm.frameRingBuffer.frameSize)
+	}
+	if w := sm.doubleBuffer.contexts[0].Width(); w != 800 {
+		t.Errorf("Expected an 800px drawing context, got %d", w)
+	}
+	if err := sm.Resize(0, 600); err == nil {
+		t.Error("Expected an invalid size to fail")
+	}
+}
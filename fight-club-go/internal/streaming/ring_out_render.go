@@ -0,0 +1,76 @@
+package streaming
+
+import (
+	"image/color"
+	"math"
+
+	"fight-club/internal/game"
+
+	"github.com/fogleman/gg"
+)
+
+// Ring-out pit colors
+var (
+	pitFill   = color.RGBA{0, 0, 0, 110}
+	pitHazard = color.RGBA{255, 214, 0, 140} // Edge line fighters get knocked over
+)
+
+// fallScale returns the body scale and opacity of a rung-out fighter: they
+// shrink and fade as they fall into the pit
+func fallScale(p *game.PlayerSnapshot) (float64, float64) {
+	if !p.RingOut {
+		return 1, 1
+	}
+	return 1 - p.FallProgress*0.8, 1 - p.FallProgress
+}
+
+// drawPit darkens the ring-out band around the arena and marks its edge
+func (s *StreamManager) drawPit(dc *gg.Context, band float64) {
+	if band <= 0 {
+		return
+	}
+	w, h := float64(s.config.Width), float64(s.config.Height)
+	dc.SetColor(pitFill)
+	dc.DrawRectangle(0, 0, w, band)
+	dc.DrawRectangle(0, h-band, w, band)
+	dc.DrawRectangle(0, band, band, h-band*2)
+	dc.DrawRectangle(w-band, band, band, h-band*2)
+	dc.Fill()
+
+	dc.SetColor(pitHazard)
+	dc.SetLineWidth(3)
+	dc.SetDash(18, 12)
+	dc.DrawRectangle(band, band, w-band*2, h-band*2)
+	dc.Stroke()
+	dc.SetDash()
+}
+
+// drawPit draws the ring-out band with primitives (mirrors StreamManager.drawPit)
+func (a *AtlasRenderer) drawPit(band float64) {
+	if band <= 0 {
+		return
+	}
+	b := int(band)
+	a.fr.DrawFilledRectBlend(0, 0, a.width, b, pitFill)
+	a.fr.DrawFilledRectBlend(0, a.height-b, a.width, b, pitFill)
+	a.fr.DrawFilledRectBlend(0, b, b, a.height-b*2, pitFill)
+	a.fr.DrawFilledRectBlend(a.width-b, b, b, a.height-b*2, pitFill)
+
+	// Dashed hazard line, 18px on / 12px off
+	for x := b; x < a.width-b; x += 30 {
+		end := min(x+18, a.width-b)
+		a.fr.DrawThickLine(x, b, end, b, 3, pitHazard)
+		a.fr.DrawThickLine(x, a.height-b, end, a.height-b, 3, pitHazard)
+	}
+	for y := b; y < a.height-b; y += 30 {
+		end := min(y+18, a.height-b)
+		a.fr.DrawThickLine(b, y, b, end, 3, pitHazard)
+		a.fr.DrawThickLine(a.width-b, y, a.width-b, end, 3, pitHazard)
+	}
+}
+
+// fallSpriteScale quantizes a falling body's scale so the body cache only
+// gains a few sizes per fighter
+func fallSpriteScale(scale float64) float64 {
+	return math.Max(math.Round(scale*10)/10, 0.2)
+}
@@ -0,0 +1,244 @@
+package streaming
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"fight-club/internal/game"
+
+	"github.com/fogleman/gg"
+)
+
+// BackgroundRenderer draws the arena backdrop for one time of day. Draw must
+// be deterministic: the atlas renderer draws each background once and caches it.
+type BackgroundRenderer interface {
+	// Draw paints the full width x height frame
+	Draw(dc *gg.Context, width, height int)
+	// Base is the dominant color (fills the portrait frame around the arena)
+	Base() color.RGBA
+}
+
+// sceneBackgrounds maps a time of day (game.SceneDay...) to its background
+var sceneBackgrounds = map[string]BackgroundRenderer{
+	game.SceneDay: constellationBackground{
+		sky:   color.RGBA{250, 250, 255, 255}, // Soft white
+		line:  color.RGBA{30, 30, 40, 40},
+		stars: [3]color.RGBA{{20, 20, 30, 80}, {40, 40, 50, 60}, {60, 60, 70, 50}},
+	},
+	game.SceneDusk: constellationBackground{
+		sky:   color.RGBA{253, 234, 222, 255}, // Warm peach
+		line:  color.RGBA{90, 40, 60, 45},
+		stars: [3]color.RGBA{{110, 40, 70, 90}, {130, 60, 80, 70}, {150, 80, 90, 60}},
+	},
+	game.SceneNight: constellationBackground{
+		sky:   color.RGBA{218, 224, 242, 255}, // Moonlit blue, light enough for the dark name labels
+		line:  color.RGBA{30, 40, 90, 55},
+		stars: [3]color.RGBA{{20, 30, 90, 110}, {40, 50, 110, 90}, {60, 70, 130, 70}},
+	},
+}
+
+// RegisterBackground adds or replaces the background of a time of day.
+// Call before streaming starts (renderers read the table unlocked).
+func RegisterBackground(timeOfDay string, bg BackgroundRenderer) {
+	sceneBackgrounds[timeOfDay] = bg
+}
+
+// sceneBackgroundName resolves a time of day to a registered background
+// (day for unknown ones, e.g. snapshots from an older server)
+func sceneBackgroundName(timeOfDay string) string {
+	if _, ok := sceneBackgrounds[timeOfDay]; ok {
+		return timeOfDay
+	}
+	return game.SceneDay
+}
+
+// sceneBackground returns the background of a time of day
+func sceneBackground(timeOfDay string) BackgroundRenderer {
+	return sceneBackgrounds[sceneBackgroundName(timeOfDay)]
+}
+
+// constellationBackground is the abstract star network on a flat sky
+type constellationBackground struct {
+	sky   color.RGBA
+	line  color.RGBA
+	stars [3]color.RGBA // Large, medium and small stars
+}
+
+// Base returns the sky color
+func (b constellationBackground) Base() color.RGBA {
+	return b.sky
+}
+
+// Draw paints the sky and the deterministic star network
+func (b constellationBackground) Draw(dc *gg.Context, width, height int) {
+	dc.SetColor(b.sky)
+	dc.DrawRectangle(0, 0, float64(width), float64(height))
+	dc.Fill()
+
+	// Abstract galaxy constellation - connected stars
+	// Generate deterministic star positions
+	type starPos struct {
+		x, y float64
+	}
+	stars := make([]starPos, 40)
+	for i := 0; i < 40; i++ {
+		stars[i] = starPos{
+			x: float64((i*67 + i*i*3) % width),
+			y: float64((i*47 + i*i*2) % height),
+		}
+	}
+
+	// Draw constellation lines connecting nearby stars (abstract network)
+	dc.SetColor(b.line)
+	dc.SetLineWidth(1)
+	for i := 0; i < len(stars); i++ {
+		for j := i + 1; j < len(stars); j++ {
+			dx := stars[i].x - stars[j].x
+			dy := stars[i].y - stars[j].y
+			dist := dx*dx + dy*dy
+			// Connect stars within certain distance (creates network effect)
+			if dist < 40000 && dist > 5000 { // 200px radius, min 70px
+				dc.DrawLine(stars[i].x, stars[i].y, stars[j].x, stars[j].y)
+				dc.Stroke()
+			}
+		}
+	}
+
+	// Draw the stars/nodes themselves
+	for i, star := range stars {
+		// Vary star sizes for depth
+		size := 2.0
+		if i%3 == 0 {
+			size = 3.0
+			dc.SetColor(b.stars[0])
+		} else if i%5 == 0 {
+			size = 1.5
+			dc.SetColor(b.stars[1])
+		} else {
+			dc.SetColor(b.stars[2])
+		}
+		dc.DrawCircle(star.x, star.y, size)
+		dc.Fill()
+	}
+}
+
+// drawSceneBackground draws the background of the snapshot's time of day
+func (s *StreamManager) drawSceneBackground(dc *gg.Context, scene game.SceneSnapshot) {
+	sceneBackground(scene.Time).Draw(dc, s.config.Width, s.config.Height)
+}
+
+// sceneBackground returns the cached background pixels of a time of day
+func (a *AtlasRenderer) sceneBackground(timeOfDay string) []byte {
+	name := sceneBackgroundName(timeOfDay)
+	if pix, ok := a.backgrounds[name]; ok {
+		return pix
+	}
+	dc := gg.NewContext(a.width, a.height)
+	sceneBackgrounds[name].Draw(dc, a.width, a.height)
+	pix := make([]byte, a.width*a.height*4)
+	copy(pix, dc.Image().(*image.RGBA).Pix)
+	a.backgrounds[name] = pix
+	return pix
+}
+
+// Weather overlay tuning
+const (
+	rainDensity  = 6000.0 // Frame px per rain drop at full intensity
+	rainLength   = 18.0
+	rainSlant    = 0.25 // Horizontal drift per px of fall
+	snowDensity  = 5000.0
+	snowSway     = 20.0 // Horizontal sway amplitude (px)
+	weatherClock = 30   // Tick rate assumed when a snapshot has none
+)
+
+// Weather colors (readable on every time-of-day palette)
+var (
+	rainColor = color.RGBA{95, 120, 165, 150}
+	snowColor = color.RGBA{185, 200, 225, 230}
+)
+
+// weatherParticle is one rain drop (top of the streak) or snowflake
+type weatherParticle struct {
+	x, y   float64
+	radius float64 // Snowflake radius
+}
+
+// eachWeatherParticle calls fn for every rain drop or snowflake of a frame.
+// Positions are a pure function of the particle index and the game clock, so
+// both renderers draw the same weather without keeping any state.
+func eachWeatherParticle(snap *game.GameSnapshot, width, height int, fn func(p weatherParticle)) {
+	scene := snap.Scene
+	snow := scene.Weather == game.WeatherSnow
+	if (!snow && scene.Weather != game.WeatherRain) || scene.WeatherIntensity <= 0 {
+		return
+	}
+
+	tickRate := snap.TickRate
+	if tickRate <= 0 {
+		tickRate = weatherClock
+	}
+	t := float64(snap.TickNumber) / float64(tickRate)
+	w, h := float64(width), float64(height)
+
+	density := rainDensity
+	if snow {
+		density = snowDensity
+	}
+	n := int(w * h / density * math.Min(scene.WeatherIntensity, 1))
+
+	for i := 0; i < n; i++ {
+		// Golden-ratio sequences spread the particles evenly
+		fx := math.Mod(float64(i)*0.6180339887, 1)
+		fy := math.Mod(float64(i)*0.4142135624, 1)
+		fv := math.Mod(float64(i)*0.7548776662, 1)
+
+		if snow {
+			speed := 40 + fv*50
+			x := math.Mod(fx*w+snowSway*math.Sin(t*1.3+float64(i))+w, w)
+			y := math.Mod(fy*(h+10)+speed*t, h+10) - 5
+			fn(weatherParticle{x: x, y: y, radius: 1.5 + fv*1.5})
+			continue
+		}
+
+		speed := 700 + fv*300
+		y := math.Mod(fy*(h+rainLength)+speed*t, h+rainLength) - rainLength
+		x := math.Mod(fx*w+y*rainSlant+w, w)
+		fn(weatherParticle{x: x, y: y})
+	}
+}
+
+// drawWeatherOverlay draws rain streaks or snowflakes over the arena
+func (s *StreamManager) drawWeatherOverlay(dc *gg.Context, snap *game.GameSnapshot) {
+	if snap.Scene.Weather == game.WeatherSnow {
+		dc.SetColor(snowColor)
+		eachWeatherParticle(snap, s.config.Width, s.config.Height, func(p weatherParticle) {
+			dc.DrawCircle(p.x, p.y, p.radius)
+			dc.Fill()
+		})
+		return
+	}
+
+	drops := 0
+	eachWeatherParticle(snap, s.config.Width, s.config.Height, func(p weatherParticle) {
+		dc.DrawLine(p.x, p.y, p.x+rainLength*rainSlant, p.y+rainLength)
+		drops++
+	})
+	if drops > 0 {
+		dc.SetColor(rainColor)
+		dc.SetLineWidth(1.5)
+		dc.Stroke()
+	}
+}
+
+// drawWeather draws rain streaks or snowflakes with fast primitives (mirrors drawWeatherOverlay)
+func (a *AtlasRenderer) drawWeather(snap *game.GameSnapshot) {
+	snow := snap.Scene.Weather == game.WeatherSnow
+	eachWeatherParticle(snap, a.width, a.height, func(p weatherParticle) {
+		if snow {
+			a.fr.DrawFilledCircleBlend(int(p.x), int(p.y), p.radius, snowColor)
+			return
+		}
+		a.fr.DrawLineBlend(int(p.x), int(p.y), int(p.x+rainLength*rainSlant), int(p.y+rainLength), rainColor)
+	})
+}
@@ -0,0 +1,119 @@
+package streaming
+
+import (
+	"image/color"
+	"testing"
+
+	"fight-club/internal/game"
+
+	"github.com/fogleman/gg"
+)
+
+// TestSceneBackgrounds verifies each time of day has its own palette and
+// unknown ones fall back to day
+func TestSceneBackgrounds(t *testing.T) {
+	seen := make(map[color.RGBA]string)
+	for _, name := range game.SceneTimes {
+		base := sceneBackground(name).Base()
+		if other, ok := seen[base]; ok {
+			t.Errorf("Expected %s and %s to differ", name, other)
+		}
+		seen[base] = name
+	}
+	if sceneBackground("eclipse").Base() != sceneBackground(game.SceneDay).Base() {
+		t.Error("Expected an unknown time of day to draw the day background")
+	}
+
+	sm := newAtlasTestManager(t, 640, 360)
+	night := sm.atlas.sceneBackground(game.SceneNight)
+	sky := sceneBackground(game.SceneNight).Base()
+	skyPixels := 0
+	for i := 0; i < len(night); i += 4 {
+		if night[i] == sky.R && night[i+1] == sky.G && night[i+2] == sky.B {
+			skyPixels++
+		}
+	}
+	if skyPixels < len(night)/8 {
+		t.Errorf("Expected mostly night sky in the cached background, got %d sky pixels", skyPixels)
+	}
+	if &sm.atlas.sceneBackground(game.SceneNight)[0] != &night[0] {
+		t.Error("Expected the background cached")
+	}
+}
+
+// TestWeatherParticles verifies particles scale with intensity, stay on
+// screen and move with the game clock
+func TestWeatherParticles(t *testing.T) {
+	snap := &game.GameSnapshot{TickRate: 30, TickNumber: 300}
+	count := func() (n int, positions []weatherParticle) {
+		eachWeatherParticle(snap, 1280, 720, func(p weatherParticle) {
+			if p.x < 0 || p.x >= 1280 || p.y < -rainLength || p.y > 720+rainLength {
+				t.Errorf("Particle off screen: %+v", p)
+			}
+			n++
+			positions = append(positions, p)
+		})
+		return n, positions
+	}
+
+	if n, _ := count(); n != 0 {
+		t.Errorf("Expected no particles in clear weather, got %d", n)
+	}
+
+	snap.Scene = game.SceneSnapshot{Weather: game.WeatherRain, WeatherIntensity: 1}
+	full, before := count()
+	area := 1280.0 * 720
+	if want := int(area / rainDensity); full != want {
+		t.Errorf("Expected %d rain drops, got %d", want, full)
+	}
+	snap.Scene.WeatherIntensity = 0.5
+	if half, _ := count(); half != full/2 {
+		t.Errorf("Expected half the drops at half intensity, got %d of %d", half, full)
+	}
+
+	snap.Scene.WeatherIntensity = 1
+	snap.TickNumber++
+	if _, after := count(); after[0] == before[0] {
+		t.Error("Expected the rain to move between ticks")
+	}
+
+	snap.Scene.Weather = game.WeatherSnow
+	_, flakes := count()
+	if len(flakes) == 0 || flakes[0].radius == 0 {
+		t.Error("Expected snowflakes with a radius")
+	}
+}
+
+// TestWeatherRender verifies both renderers draw the weather over the sky
+func TestWeatherRender(t *testing.T) {
+	snap := &game.GameSnapshot{TickRate: 30, Scene: game.SceneSnapshot{Time: game.SceneDusk, Weather: game.WeatherSnow, WeatherIntensity: 1}}
+	var flake weatherParticle
+	eachWeatherParticle(snap, 1280, 720, func(p weatherParticle) {
+		if flake.radius < 2.5 && p.radius >= 2.5 {
+			flake = p
+		}
+	})
+	sky := sceneBackground(game.SceneDusk).Base()
+
+	t.Run("gg", func(t *testing.T) {
+		sm := &StreamManager{config: StreamConfig{Width: 1280, Height: 720}}
+		dc := gg.NewContext(1280, 720)
+		sm.drawSceneBackground(dc, snap.Scene)
+		sm.drawWeatherOverlay(dc, snap)
+		if got := color.RGBAModel.Convert(dc.Image().At(int(flake.x), int(flake.y))).(color.RGBA); got == sky {
+			t.Errorf("Expected a snowflake at (%.0f, %.0f)", flake.x, flake.y)
+		}
+	})
+
+	t.Run("atlas", func(t *testing.T) {
+		sm := newAtlasTestManager(t, 1280, 720)
+		buffer := make([]byte, 1280*720*4)
+		copy(buffer, sm.atlas.sceneBackground(snap.Scene.Time))
+		sm.atlas.fr.SetBuffer(buffer)
+		sm.atlas.drawWeather(snap)
+		i := (int(flake.y)*1280 + int(flake.x)) * 4
+		if got := (color.RGBA{buffer[i], buffer[i+1], buffer[i+2], buffer[i+3]}); got == sky {
+			t.Errorf("Expected a snowflake at (%.0f, %.0f)", flake.x, flake.y)
+		}
+	})
+}
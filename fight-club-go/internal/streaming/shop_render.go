@@ -0,0 +1,108 @@
+package streaming
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"strings"
+
+	"fight-club/internal/game"
+
+	"github.com/fogleman/gg"
+)
+
+// Shop sign tuning: the name above a row of stock badges, centered on the shop
+const (
+	shopBadgeGap   = 6.0
+	shopNameHeight = 20.0
+)
+
+// Weapon shop colors
+var (
+	shopAccent = color.RGBA{255, 196, 0, 255} // Circle border and name
+	shopFill   = color.RGBA{255, 196, 0, 28}
+)
+
+// shopSignText returns the shop name, with how many fighters are on their way
+func shopSignText(sh game.ShopSnapshot) string {
+	text := strings.ToUpper(sh.Name)
+	if sh.Orders > 0 {
+		text += fmt.Sprintf(" (%d)", sh.Orders)
+	}
+	return text
+}
+
+// shopSignSize returns the sign size of a shop
+func shopSignSize(sh game.ShopSnapshot) (float64, float64) {
+	badges := float64(len(sh.Stock))*(weaponDropRadius*2+shopBadgeGap) - shopBadgeGap
+	return max(badges, 120), shopNameHeight + weaponDropRadius*2 + 4
+}
+
+// drawShopSign draws the shop name and its stock badges centered on (x, y)
+// with the font set on dc
+func drawShopSign(dc *gg.Context, sh game.ShopSnapshot, x, y float64) {
+	_, height := shopSignSize(sh)
+	top := y - height/2
+
+	dc.SetColor(shopAccent)
+	dc.DrawStringAnchored(shopSignText(sh), x, top+shopNameHeight/2, 0.5, 0.5)
+
+	step := weaponDropRadius*2 + shopBadgeGap
+	bx := x - float64(len(sh.Stock)-1)*step/2
+	by := top + shopNameHeight + weaponDropRadius + 2
+	for i, id := range sh.Stock {
+		drawWeaponDropBadge(dc, game.GetWeapon(id), bx+float64(i)*step, by)
+	}
+}
+
+// drawShops draws the weapon shop circles with their stock on the arena floor (under players)
+func (s *StreamManager) drawShops(dc *gg.Context, shops []game.ShopSnapshot) {
+	if len(shops) == 0 {
+		return
+	}
+	if s.fontsLoaded && s.fontSmall != nil {
+		dc.SetFontFace(s.fontSmall)
+	}
+	for _, sh := range shops {
+		dc.SetColor(shopFill)
+		dc.DrawCircle(sh.X, sh.Y, sh.Radius)
+		dc.Fill()
+		dc.SetColor(shopAccent)
+		dc.SetLineWidth(3)
+		dc.SetDash(10, 6)
+		dc.DrawCircle(sh.X, sh.Y, sh.Radius)
+		dc.Stroke()
+		dc.SetDash()
+		drawShopSign(dc, sh, sh.X, sh.Y)
+	}
+}
+
+// shopSignSprite returns the cached sign sprite of a shop
+func (a *AtlasRenderer) shopSignSprite(sh game.ShopSnapshot) *sprite {
+	key := fmt.Sprintf("%s|%v|%d", sh.Name, sh.Stock, sh.Orders)
+	if sp, ok := a.shopSigns[key]; ok {
+		return sp
+	}
+	if a.shopSigns == nil || len(a.shopSigns) > 64 {
+		a.shopSigns = make(map[string]*sprite) // Order counts come and go
+	}
+	width, height := shopSignSize(sh)
+	w, h := int(math.Ceil(width))+4, int(math.Ceil(height))+4
+	dc := gg.NewContext(w, h)
+	dc.SetFontFace(a.s.fontSmall)
+	drawShopSign(dc, sh, float64(w)/2, float64(h)/2)
+	sp := newSprite(dc.Image().(*image.RGBA))
+	a.shopSigns[key] = sp
+	return sp
+}
+
+// drawShops draws the weapon shops with primitives and cached signs (mirrors StreamManager.drawShops)
+func (a *AtlasRenderer) drawShops(buffer []byte, shops []game.ShopSnapshot) {
+	for _, sh := range shops {
+		x, y := int(sh.X), int(sh.Y)
+		a.fr.DrawFilledCircleBlend(x, y, sh.Radius, shopFill)
+		a.fr.DrawCircleOutline(x, y, sh.Radius, 3, shopAccent)
+		a.blit(buffer, a.shopSignSprite(sh), sh.X, sh.Y, 255)
+	}
+}
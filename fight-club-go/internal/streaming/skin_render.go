@@ -0,0 +1,197 @@
+package streaming
+
+import (
+	"image/color"
+	"math"
+	"time"
+
+	"fight-club/internal/game"
+
+	"github.com/fogleman/gg"
+)
+
+// Skin rendering tuning
+const (
+	skinRingWidth     = 5.0  // Border ring stroke (px)
+	skinFlameTongues  = 16   // Flame tongues around the body
+	skinFlameLength   = 12.0 // Max tongue reach beyond the ring (px)
+	skinRainbowSpeed  = 0.5  // Hue cycles per second
+	skinRainbowSpread = 0.08 // Hue offset between letters
+	skinRainbowSteps  = 12   // Quantized hues, so atlas letter sprites stay cached
+)
+
+// flameCore is the hot inner color of flame tongues
+var flameCore = color.RGBA{255, 230, 120, 255}
+
+// skinEpoch starts the skin animation clock (flames, rainbow names)
+var skinEpoch = time.Now()
+
+// skinClock returns seconds on the skin animation clock
+func skinClock() float64 {
+	return time.Since(skinEpoch).Seconds()
+}
+
+// swingColor returns a fighter's weapon swing color: the equipped trail skin,
+// otherwise the weapon animation color, otherwise the weapon color
+func swingColor(p *game.PlayerSnapshot, anim game.WeaponAnimationConfig) string {
+	if skin, ok := game.GetSkin(p.Skins.Trail); ok && skin.Color != "" {
+		return skin.Color
+	}
+	if anim.TrailColor != "" {
+		return anim.TrailColor
+	}
+	return game.GetWeapon(p.Weapon).Color
+}
+
+// flameTongue is one flickering flame blob around a body
+type flameTongue struct {
+	dx, dy float64 // Offset from the body center
+	size   float64 // Blob radius
+}
+
+// flameTongues returns the flame blobs around a body of the given radius at time t
+func flameTongues(radius, t float64) [skinFlameTongues]flameTongue {
+	var tongues [skinFlameTongues]flameTongue
+	for i := range tongues {
+		angle := float64(i)*2*math.Pi/skinFlameTongues + t*0.6
+		flicker := 0.5 + 0.5*math.Sin(t*9+float64(i)*2.3)
+		reach := radius + skinFlameLength*flicker*0.5
+		tongues[i] = flameTongue{
+			dx:   math.Cos(angle) * reach,
+			dy:   math.Sin(angle) * reach,
+			size: 3 + 4*flicker,
+		}
+	}
+	return tongues
+}
+
+// rainbowColor returns the color of letter i of a rainbow nameplate at time t
+func rainbowColor(i int, t float64) color.RGBA {
+	hue := math.Mod(t*skinRainbowSpeed+float64(i)*skinRainbowSpread, 1)
+	hue = math.Floor(hue*skinRainbowSteps) / skinRainbowSteps
+
+	// HSV (s=0.85, v=0.95) to RGB
+	const s, v = 0.85, 0.95
+	h := hue * 6
+	f := h - math.Floor(h)
+	p, q, u := v*(1-s), v*(1-s*f), v*(1-s*(1-f))
+	var r, g, b float64
+	switch int(h) % 6 {
+	case 0:
+		r, g, b = v, u, p
+	case 1:
+		r, g, b = q, v, p
+	case 2:
+		r, g, b = p, v, u
+	case 3:
+		r, g, b = p, q, v
+	case 4:
+		r, g, b = u, p, v
+	default:
+		r, g, b = v, p, q
+	}
+	return color.RGBA{uint8(r * 255), uint8(g * 255), uint8(b * 255), 255}
+}
+
+// drawSkinBorder draws the equipped border skin over the body ring
+func (s *StreamManager) drawSkinBorder(dc *gg.Context, p game.PlayerSnapshot, radius float64) {
+	skin, ok := game.GetSkin(p.Skins.Border)
+	if !ok {
+		return
+	}
+	c := parseHexColor(skin.Color)
+
+	if skin.Style == game.SkinStyleFlame {
+		for _, tongue := range flameTongues(radius, skinClock()) {
+			c.A = 200
+			dc.SetColor(c)
+			dc.DrawCircle(p.X+tongue.dx, p.Y+tongue.dy, tongue.size)
+			dc.Fill()
+			dc.SetColor(flameCore)
+			dc.DrawCircle(p.X+tongue.dx*0.97, p.Y+tongue.dy*0.97, tongue.size*0.45)
+			dc.Fill()
+		}
+		c.A = 255
+	}
+
+	dc.SetColor(c)
+	dc.SetLineWidth(skinRingWidth)
+	dc.DrawCircle(p.X, p.Y, radius)
+	dc.Stroke()
+}
+
+// drawNameplate draws the fighter's name centered on (x, y) with the font
+// and default color already set on dc, styled by the nameplate skin
+func drawNameplate(dc *gg.Context, p game.PlayerSnapshot, x, y float64) {
+	skin, ok := game.GetSkin(p.Skins.Nameplate)
+	switch {
+	case !ok:
+		dc.DrawStringAnchored(p.Name, x, y, 0.5, 0.5)
+	case skin.Style == game.SkinStyleRainbow:
+		width, _ := dc.MeasureString(p.Name)
+		left := x - width/2
+		t := skinClock()
+		for i, r := range []rune(p.Name) {
+			letter := string(r)
+			w, _ := dc.MeasureString(letter)
+			dc.SetColor(rainbowColor(i, t))
+			dc.DrawStringAnchored(letter, left, y, 0, 0.5)
+			left += w
+		}
+	default:
+		dc.SetColor(parseHexColor(skin.Color))
+		dc.DrawStringAnchored(p.Name, x, y, 0.5, 0.5)
+	}
+}
+
+// drawSkinBorder draws the equipped border skin with fast primitives (mirrors StreamManager.drawSkinBorder)
+func (a *AtlasRenderer) drawSkinBorder(p *game.PlayerSnapshot, y, radius float64) {
+	skin, ok := game.GetSkin(p.Skins.Border)
+	if !ok {
+		return
+	}
+	c := parseHexColor(skin.Color)
+
+	if skin.Style == game.SkinStyleFlame {
+		glow := c
+		glow.A = 200
+		for _, tongue := range flameTongues(radius, skinClock()) {
+			a.fr.DrawFilledCircleBlend(int(p.X+tongue.dx), int(y+tongue.dy), tongue.size, glow)
+			a.fr.DrawFilledCircle(int(p.X+tongue.dx*0.97), int(y+tongue.dy*0.97), tongue.size*0.45, flameCore)
+		}
+	}
+	a.fr.DrawCircleOutline(int(p.X), int(y), radius, int(skinRingWidth), c)
+}
+
+// drawName blits the fighter's name centered on (x, y) styled by the
+// nameplate skin, and returns its width
+func (a *AtlasRenderer) drawName(buffer []byte, p *game.PlayerSnapshot, x, y float64) float64 {
+	plain := color.RGBA{20, 25, 35, 255}
+	skin, ok := game.GetSkin(p.Skins.Nameplate)
+	if !ok || skin.Style != game.SkinStyleRainbow {
+		if ok {
+			plain = parseHexColor(skin.Color)
+		}
+		name := a.label(p.Name, plain, false)
+		a.blit(buffer, name, x, y, 255)
+		return float64(name.img.Bounds().Dx())
+	}
+
+	// Rainbow: one cached sprite per (letter, hue), laid out left to right.
+	// Label sprites carry 2px of padding on each side, which overlaps here.
+	t := skinClock()
+	letters := make([]*sprite, 0, len(p.Name))
+	width := 0.0
+	for i, r := range []rune(p.Name) {
+		sp := a.label(string(r), rainbowColor(i, t), false)
+		letters = append(letters, sp)
+		width += float64(sp.img.Bounds().Dx() - 4)
+	}
+	left := x - width/2
+	for _, sp := range letters {
+		w := float64(sp.img.Bounds().Dx() - 4)
+		a.blit(buffer, sp, left+w/2, y, 255)
+		left += w
+	}
+	return width + 4
+}
@@ -0,0 +1,68 @@
+package streaming
+
+import (
+	"image/color"
+	"testing"
+
+	"fight-club/internal/game"
+
+	"github.com/fogleman/gg"
+)
+
+// TestSwingColor verifies the trail skin overrides the weapon swing color
+func TestSwingColor(t *testing.T) {
+	p := &game.PlayerSnapshot{Weapon: "sword"}
+	anim := game.GetWeaponAnimation("sword")
+	if got := swingColor(p, anim); got == game.Skins["crimson"].Color {
+		t.Fatalf("Expected the weapon color without a trail skin, got %s", got)
+	}
+
+	p.Skins.Trail = "crimson"
+	if got := swingColor(p, anim); got != game.Skins["crimson"].Color {
+		t.Errorf("Expected the crimson trail color, got %s", got)
+	}
+}
+
+// TestRainbowColor verifies letters get distinct, quantized hues
+func TestRainbowColor(t *testing.T) {
+	if rainbowColor(0, 0) == rainbowColor(3, 0) {
+		t.Error("Expected neighbouring letters to differ in hue")
+	}
+	if rainbowColor(0, 0) != rainbowColor(0, 0.01) {
+		t.Error("Expected hues quantized (sprite cache friendly)")
+	}
+}
+
+// TestSkinBorderRender verifies the gold ring replaces the white border on both backends
+func TestSkinBorderRender(t *testing.T) {
+	p := game.PlayerSnapshot{Name: "goldie", X: 200, Y: 200, HP: 100, MaxHP: 100, Color: "#4ecdc4"}
+	p.Skins.Border = "gold"
+	ringX, ringY := 200+30.0, 200.0
+
+	check := func(t *testing.T, c color.RGBA) {
+		if c.R < 200 || c.G < 180 || c.B > 80 {
+			t.Errorf("Expected a gold ring pixel, got %v", c)
+		}
+	}
+
+	t.Run("gg", func(t *testing.T) {
+		sm := &StreamManager{config: StreamConfig{Width: 400, Height: 400}}
+		dc := gg.NewContext(400, 400)
+		sm.drawSkinBorder(dc, p, 30)
+		check(t, color.RGBAModel.Convert(dc.Image().At(int(ringX), int(ringY))).(color.RGBA))
+	})
+
+	t.Run("atlas", func(t *testing.T) {
+		sm := newAtlasTestManager(t, 400, 400)
+		buffer := make([]byte, 400*400*4)
+		copy(buffer, sm.atlas.sceneBackground(game.SceneDay))
+		sm.atlas.fr.SetBuffer(buffer)
+		sm.atlas.drawPlayer(buffer, &p, 1)
+		i := (int(ringY)*400 + int(ringX)) * 4
+		check(t, color.RGBA{buffer[i], buffer[i+1], buffer[i+2], buffer[i+3]})
+
+		// Flame border and rainbow nameplate draw without panicking
+		p.Skins.Border, p.Skins.Nameplate = "flame", "rainbow"
+		sm.atlas.drawPlayer(buffer, &p, 1)
+	})
+}
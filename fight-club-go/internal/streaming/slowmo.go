@@ -0,0 +1,90 @@
+package streaming
+
+import (
+	"image/color"
+	"sort"
+	"time"
+
+	"fight-club/internal/game"
+
+	"github.com/fogleman/gg"
+)
+
+// Replay banner colors
+var (
+	replayBannerFill = color.RGBA{18, 18, 24, 230}
+	replayBannerDot  = color.RGBA{255, 59, 48, 255}
+)
+
+// replayPlayer plays slow-motion replays (see game.ReplayCue). While one
+// plays, the render loop keeps its pace and writes a frame every tick of the
+// stream clock: only the state it draws comes from the replay frames, so the
+// encoder never waits. Owned by the render loop (not thread-safe).
+type replayPlayer struct {
+	seq    uint64
+	seen   bool
+	frames []*game.GameSnapshot
+	start  time.Time
+	speed  float64
+}
+
+// Frame returns the replay frames around now while a replay plays: prev and
+// curr bracket the replay time at. ok is false when the stream shows live.
+// A new cue in live starts a replay with the frames kept by source.
+func (rp *replayPlayer) Frame(live *game.GameSnapshot, source SnapshotSource, now time.Time) (prev, curr *game.GameSnapshot, at time.Time, ok bool) {
+	cue := live.Replay
+	if !rp.seen {
+		rp.seen, rp.seq = true, cue.Seq // Don't replay a cue from before we connected
+	}
+	if cue.Seq != rp.seq {
+		rp.seq = cue.Seq
+		if history, isHistory := source.(ReplayHistory); isHistory && rp.frames == nil && cue.Speed > 0 {
+			rp.begin(history.ReplayFrames(), cue.Speed, now)
+		}
+	}
+	if rp.frames == nil {
+		return nil, nil, time.Time{}, false
+	}
+
+	first := rp.frames[0].Timestamp
+	at = first.Add(time.Duration(float64(now.Sub(rp.start)) * rp.speed))
+	i := sort.Search(len(rp.frames), func(i int) bool { return rp.frames[i].Timestamp.After(at) })
+	if i == len(rp.frames) {
+		rp.frames = nil // Back to live
+		return nil, nil, time.Time{}, false
+	}
+	return rp.frames[max(i-1, 0)], rp.frames[i], at, true
+}
+
+// begin starts playing frames (oldest first) at speed
+func (rp *replayPlayer) begin(frames []*game.GameSnapshot, speed float64, now time.Time) {
+	if len(frames) < 2 {
+		return // Nothing kept yet
+	}
+	for _, f := range frames {
+		f.Replaying = true // The frames are ours now (see game.ReplayBuffer.Take)
+	}
+	rp.frames, rp.speed, rp.start = frames, speed, now
+}
+
+// drawReplayBanner draws the REPLAY card at the top center of the arena
+func (s *StreamManager) drawReplayBanner(dc *gg.Context, top float64) {
+	w := float64(s.config.Width)
+	cardWidth, cardHeight := 200.0, 44.0
+	cardX := (w - cardWidth) / 2
+
+	dc.SetColor(replayBannerFill)
+	dc.DrawRoundedRectangle(cardX, top, cardWidth, cardHeight, 6)
+	dc.Fill()
+	dc.SetColor(replayBannerDot)
+	dc.DrawCircle(cardX+26, top+cardHeight/2, 7)
+	dc.Fill()
+
+	if s.fontsLoaded && s.fontMedium != nil {
+		dc.SetFontFace(s.fontMedium)
+	} else {
+		_ = s.loadFontFace(dc, 20)
+	}
+	dc.SetColor(color.White)
+	dc.DrawStringAnchored("REPLAY", w/2+12, top+cardHeight/2, 0.5, 0.35)
+}
@@ -0,0 +1,59 @@
+package streaming
+
+import (
+	"testing"
+	"time"
+
+	"fight-club/internal/game"
+)
+
+// replayTestSource is a snapshot source with kept replay frames
+type replayTestSource struct {
+	frames []*game.GameSnapshot
+}
+
+func (s *replayTestSource) GetSnapshot() *game.GameSnapshot { return nil }
+
+func (s *replayTestSource) ReplayFrames() []*game.GameSnapshot {
+	frames := s.frames
+	s.frames = nil
+	return frames
+}
+
+// TestReplayPlayerSlowMotion verifies a new cue plays the kept frames at the
+// cue speed, then returns to live
+func TestReplayPlayerSlowMotion(t *testing.T) {
+	t0 := time.Unix(100, 0)
+	source := &replayTestSource{}
+	for i := 0; i < 4; i++ { // 0.3s of arena at 10 TPS
+		source.frames = append(source.frames, &game.GameSnapshot{TickNumber: uint64(i), Timestamp: t0.Add(time.Duration(i) * 100 * time.Millisecond)})
+	}
+	live := &game.GameSnapshot{Replay: game.ReplayCue{Seq: 4, Speed: 0.5}}
+
+	var rp replayPlayer
+	now := time.Unix(200, 0)
+	if _, _, _, ok := rp.Frame(live, source, now); ok {
+		t.Fatal("Expected the cue seen on connect not to be replayed")
+	}
+
+	live.Replay.Seq++
+	prev, curr, _, ok := rp.Frame(live, source, now)
+	if !ok || prev.TickNumber != 0 || curr.TickNumber != 1 || !prev.Replaying {
+		t.Fatalf("Expected the replay to start at its first frame, got ok=%v", ok)
+	}
+
+	// 0.3s of arena at half speed lasts 0.6s on stream
+	prev, curr, at, ok := rp.Frame(live, source, now.Add(300*time.Millisecond))
+	if !ok || prev.TickNumber != 1 || curr.TickNumber != 2 || !at.Equal(t0.Add(150*time.Millisecond)) {
+		t.Errorf("Expected ticks 1-2 at 150ms into the replay, got ok=%v", ok)
+	}
+	if _, _, _, ok := rp.Frame(live, source, now.Add(700*time.Millisecond)); ok {
+		t.Error("Expected live once the replay is over")
+	}
+
+	// A cue with nothing kept stays live
+	live.Replay.Seq++
+	if _, _, _, ok := rp.Frame(live, source, now.Add(time.Second)); ok {
+		t.Error("Expected live without kept frames")
+	}
+}
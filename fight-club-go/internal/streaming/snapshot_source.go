@@ -4,6 +4,7 @@ import (
 	"fight-club/internal/game"
 	"fight-club/internal/ipc"
 	"sync/atomic"
+	"time"
 )
 
 // SnapshotSource is an interface for getting game snapshots
@@ -12,6 +13,27 @@ type SnapshotSource interface {
 	GetSnapshot() *game.GameSnapshot
 }
 
+// SnapshotHistory is implemented by sources that keep the previous snapshot
+// alive, so the renderer can interpolate between the last two (see interpolation.go).
+// LocalEngineSource doesn't: the engine recycles its snapshots after two ticks.
+type SnapshotHistory interface {
+	// GetSnapshotPair returns the last two snapshots and when curr arrived (prev may be nil)
+	GetSnapshotPair() (prev, curr *game.GameSnapshot, currAt time.Time)
+}
+
+// ReplayHistory is implemented by sources that keep the last seconds of
+// snapshots for slow-motion replays (see game.ReplayBuffer)
+type ReplayHistory interface {
+	// ReplayFrames hands over the kept snapshots, oldest first
+	ReplayFrames() []*game.GameSnapshot
+}
+
+// snapshotPair is an immutable (prev, curr) pair swapped atomically on each arrival
+type snapshotPair struct {
+	prev, curr *game.GameSnapshot
+	currAt     time.Time
+}
+
 // LocalEngineSource wraps a local game.Engine as a SnapshotSource
 type LocalEngineSource struct {
 	engine *game.Engine
@@ -27,6 +49,11 @@ func (s *LocalEngineSource) GetSnapshot() *game.GameSnapshot {
 	return s.engine.GetSnapshot()
 }
 
+// ReplayFrames returns the snapshots the local engine kept for a replay
+func (s *LocalEngineSource) ReplayFrames() []*game.GameSnapshot {
+	return s.engine.ReplayFrames()
+}
+
 // IPCSnapshotSource wraps an IPC subscriber as a SnapshotSource
 type IPCSnapshotSource struct {
 	subscriber *ipc.Subscriber
@@ -34,12 +61,19 @@ type IPCSnapshotSource struct {
 	// Cached conversion to avoid allocations
 	lastSnapshot atomic.Value // *game.GameSnapshot
 	lastSequence uint64
+
+	// Last two snapshots for render interpolation
+	pair atomic.Pointer[snapshotPair]
+
+	// Last seconds of snapshots for replays (the engine's are in the other process)
+	replay *game.ReplayBuffer
 }
 
 // NewIPCSnapshotSource creates a SnapshotSource from an IPC subscriber
 func NewIPCSnapshotSource(subscriber *ipc.Subscriber) *IPCSnapshotSource {
 	source := &IPCSnapshotSource{
 		subscriber: subscriber,
+		replay:     game.NewReplayBuffer(),
 	}
 
 	// Set up callback to convert snapshots as they arrive
@@ -48,6 +82,13 @@ func NewIPCSnapshotSource(subscriber *ipc.Subscriber) *IPCSnapshotSource {
 		snap := msg.ToGameSnapshot()
 		source.lastSnapshot.Store(snap)
 		source.lastSequence = msg.Sequence
+
+		next := &snapshotPair{curr: snap, currAt: time.Now()}
+		if old := source.pair.Load(); old != nil {
+			next.prev = old.curr
+		}
+		source.pair.Store(next)
+		source.replay.Record(snap)
 	})
 
 	return source
@@ -61,6 +102,19 @@ func (s *IPCSnapshotSource) GetSnapshot() *game.GameSnapshot {
 	return nil
 }
 
+// GetSnapshotPair returns the last two snapshots from IPC for interpolation
+func (s *IPCSnapshotSource) GetSnapshotPair() (prev, curr *game.GameSnapshot, currAt time.Time) {
+	if p := s.pair.Load(); p != nil {
+		return p.prev, p.curr, p.currAt
+	}
+	return nil, nil, time.Time{}
+}
+
+// ReplayFrames returns the snapshots kept for a replay
+func (s *IPCSnapshotSource) ReplayFrames() []*game.GameSnapshot {
+	return s.replay.Take()
+}
+
 // GetSequence returns the last received sequence number
 func (s *IPCSnapshotSource) GetSequence() uint64 {
 	return s.lastSequence
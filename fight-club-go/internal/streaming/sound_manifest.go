@@ -0,0 +1,138 @@
+package streaming
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// soundManifestFile lists per-weapon sound sets (and a pack's sound variants),
+// next to the generic sounds
+const soundManifestFile = "manifest.json"
+
+// weaponSoundEvents are the generic sounds a weapon set may override
+var weaponSoundEvents = []string{"swing", "hit", "kill"}
+
+// SoundVariant is one manifest sound: an audio file (WAV, OGG or MP3) played
+// at a pitch and volume, so weapon sets and sound packs can be derived from
+// the generic samples until dedicated recordings are dropped in.
+type SoundVariant struct {
+	File   string  `json:"file"`
+	Volume float64 `json:"volume,omitempty"` // Default 1.0
+	Pitch  float64 `json:"pitch,omitempty"`  // Playback rate, default 1.0 (2.0 = octave up)
+}
+
+// SoundManifest maps weapon IDs to their swing/hit/kill sounds and, in a
+// sound pack, generic sounds to variants.
+//
+//	{"weapons": {"hammer": {"hit": {"file": "hit.wav", "pitch": 0.7, "volume": 1.2}}}}
+//	{"sounds": {"kill": {"file": "kill.wav", "pitch": 1.5}}}
+type SoundManifest struct {
+	Sounds  map[string]SoundVariant            `json:"sounds,omitempty"`
+	Weapons map[string]map[string]SoundVariant `json:"weapons"`
+}
+
+// weaponSoundKey is the mixer sound name for a weapon's event ("hammer/hit")
+func weaponSoundKey(weapon, event string) string {
+	return weapon + "/" + event
+}
+
+// splitWeaponSoundKey splits a weapon sound name; ok is false for generic sounds
+func splitWeaponSoundKey(name string) (weapon, event string, ok bool) {
+	weapon, event, ok = strings.Cut(name, "/")
+	return weapon, event, ok
+}
+
+// readSoundManifest parses dir/manifest.json, logging entries with unknown events
+func readSoundManifest(dir string) (*SoundManifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, soundManifestFile))
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest SoundManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse sound manifest: %w", err)
+	}
+
+	for weapon, set := range manifest.Weapons {
+		for event := range set {
+			if !isWeaponSoundEvent(event) {
+				log.Printf("⚠️ Sound manifest: unknown event %q for %s", event, weapon)
+				delete(set, event)
+			}
+		}
+	}
+	return &manifest, nil
+}
+
+// variant returns the manifest entry for a sound name (generic or weapon)
+func (m *SoundManifest) variant(name string) (SoundVariant, bool) {
+	if m == nil {
+		return SoundVariant{}, false
+	}
+	if weapon, event, ok := splitWeaponSoundKey(name); ok {
+		v, found := m.Weapons[weapon][event]
+		return v, found
+	}
+	v, found := m.Sounds[name]
+	return v, found
+}
+
+// isWeaponSoundEvent returns whether event is a per-weapon sound
+func isWeaponSoundEvent(event string) bool {
+	for _, e := range weaponSoundEvents {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// applySoundVariant resamples interleaved stereo samples by pitch (linear
+// interpolation) and bakes in volume, so playback costs nothing extra.
+func applySoundVariant(samples []int16, v SoundVariant) []int16 {
+	pitch, volume := v.Pitch, v.Volume
+	if pitch <= 0 {
+		pitch = 1
+	}
+	if volume <= 0 {
+		volume = 1
+	}
+	if pitch == 1 && volume == 1 {
+		return samples
+	}
+
+	frames := len(samples) / 2
+	outFrames := int(float64(frames) / pitch)
+	out := make([]int16, outFrames*2)
+	for i := 0; i < outFrames; i++ {
+		pos := float64(i) * pitch
+		idx := int(pos)
+		frac := pos - float64(idx)
+		next := idx + 1
+		if next >= frames {
+			next = frames - 1
+		}
+		for ch := 0; ch < 2; ch++ {
+			a := float64(samples[idx*2+ch])
+			b := float64(samples[next*2+ch])
+			out[i*2+ch] = clampSample((a + (b-a)*frac) * volume)
+		}
+	}
+	return out
+}
+
+// clampSample converts a mixed value to a 16-bit sample without wrapping
+func clampSample(v float64) int16 {
+	if v > 32767 {
+		return 32767
+	}
+	if v < -32768 {
+		return -32768
+	}
+	return int16(v)
+}
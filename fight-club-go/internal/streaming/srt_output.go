@@ -0,0 +1,60 @@
+package streaming
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// SRT output defaults
+const (
+	DefaultSRTLatencyMs = 120 // SRT receive buffer; low enough for local OBS, survives a busy LAN
+)
+
+// SRTConfig sends the rendered feed over SRT instead of RTMP, so OBS (Media
+// Source, "srt://host:port?mode=caller") can composite it with a webcam and
+// alerts. FFmpeg listens and OBS connects, so either can start first.
+type SRTConfig struct {
+	URL        string // srt://host:port ("" keeps the RTMP output); 0.0.0.0 accepts OBS on another machine
+	LatencyMs  int    // Default 120
+	Passphrase string // Optional AES encryption (10-79 characters, same in OBS)
+}
+
+// Enabled reports whether the SRT output is configured
+func (sc SRTConfig) Enabled() bool {
+	return sc.URL != ""
+}
+
+// outputURL returns the FFmpeg SRT URL: listener mode, latency (FFmpeg takes
+// microseconds) and the MPEG-TS packet size SRT expects. Options already in
+// URL win.
+func (sc SRTConfig) outputURL() (string, error) {
+	u, err := url.Parse(sc.URL)
+	if err != nil {
+		return "", fmt.Errorf("invalid SRT URL: %w", err)
+	}
+	if u.Scheme != "srt" || u.Host == "" {
+		return "", fmt.Errorf("invalid SRT URL %q (want srt://host:port)", sc.URL)
+	}
+	if sc.Passphrase != "" && (len(sc.Passphrase) < 10 || len(sc.Passphrase) > 79) {
+		return "", fmt.Errorf("SRT passphrase must be 10-79 characters")
+	}
+
+	latency := sc.LatencyMs
+	if latency <= 0 {
+		latency = DefaultSRTLatencyMs
+	}
+	q := u.Query()
+	setDefault := func(key, value string) {
+		if !q.Has(key) {
+			q.Set(key, value)
+		}
+	}
+	setDefault("mode", "listener")
+	setDefault("latency", fmt.Sprintf("%d", latency*1000))
+	setDefault("pkt_size", "1316") // 7 TS packets per UDP datagram
+	if sc.Passphrase != "" {
+		setDefault("passphrase", sc.Passphrase)
+	}
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
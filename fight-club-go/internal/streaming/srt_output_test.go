@@ -0,0 +1,66 @@
+package streaming
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// TestSRTOutputURL verifies listener mode, latency in microseconds and that
+// options in the configured URL win
+func TestSRTOutputURL(t *testing.T) {
+	out, err := SRTConfig{URL: "srt://127.0.0.1:9000"}.outputURL()
+	if err != nil {
+		t.Fatalf("outputURL failed: %v", err)
+	}
+	u, _ := url.Parse(out)
+	q := u.Query()
+	if u.Host != "127.0.0.1:9000" || q.Get("mode") != "listener" || q.Get("latency") != "120000" || q.Get("pkt_size") != "1316" {
+		t.Errorf("Unexpected SRT URL %q", out)
+	}
+	if q.Has("passphrase") {
+		t.Error("Expected no passphrase by default")
+	}
+
+	out, _ = SRTConfig{URL: "srt://0.0.0.0:9000?mode=caller", LatencyMs: 40, Passphrase: "correct-horse"}.outputURL()
+	q = mustQuery(t, out)
+	if q.Get("mode") != "caller" || q.Get("latency") != "40000" || q.Get("passphrase") != "correct-horse" {
+		t.Errorf("Expected the configured mode, latency and passphrase, got %q", out)
+	}
+
+	for _, bad := range []SRTConfig{
+		{URL: "rtmp://127.0.0.1:9000"},
+		{URL: "srt://"},
+		{URL: "srt://127.0.0.1:9000", Passphrase: "short"},
+	} {
+		if _, err := bad.outputURL(); err == nil {
+			t.Errorf("Expected %+v to be rejected", bad)
+		}
+	}
+}
+
+// TestFFmpegBuilderSRTOutput verifies SRT is muxed as MPEG-TS, alone or teed with recording
+func TestFFmpegBuilderSRTOutput(t *testing.T) {
+	builder := func() *FFmpegCommandBuilder {
+		return NewFFmpegCommandBuilder(defaultFFmpegProfile(false)).VideoInput(1280, 720, 30).Bitrate(4000).AudioPipe()
+	}
+
+	args := builder().SRTOutput("srt://127.0.0.1:9000?mode=listener").Args()
+	if !strings.HasSuffix(strings.Join(args, " "), "-f mpegts srt://127.0.0.1:9000?mode=listener") {
+		t.Errorf("Expected an MPEG-TS SRT output, got %v", args)
+	}
+
+	tee := RecordingConfig{Dir: "recordings"}.teeOutput("mpegts", "srt://127.0.0.1:9000")
+	if !strings.HasPrefix(tee, "[f=mpegts]srt://127.0.0.1:9000|") {
+		t.Errorf("Expected the SRT output first in the tee, got %q", tee)
+	}
+}
+
+func mustQuery(t *testing.T, raw string) url.Values {
+	t.Helper()
+	u, err := url.Parse(raw)
+	if err != nil {
+		t.Fatalf("Bad URL %q: %v", raw, err)
+	}
+	return u.Query()
+}
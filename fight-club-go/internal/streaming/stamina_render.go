@@ -0,0 +1,33 @@
+package streaming
+
+import (
+	"image/color"
+
+	"fight-club/internal/game"
+)
+
+// staminaBarHeight is the height of the stamina bar under the health bar (px)
+const staminaBarHeight = 4
+
+// Stamina bar fills
+var (
+	staminaColor   = color.RGBA{78, 205, 196, 255}  // Resting / regenerating
+	sprintColor    = color.RGBA{255, 214, 10, 255}  // Sprinting (draining)
+	exhaustedColor = color.RGBA{140, 140, 150, 255} // Out of breath
+)
+
+// staminaPercent returns the fighter's stamina as a 0..1 bar fill
+func staminaPercent(p *game.PlayerSnapshot) float64 {
+	return min(max(p.Stamina/game.MaxStamina, 0), 1)
+}
+
+// staminaBarColor returns the stamina bar fill for the fighter's sprint state
+func staminaBarColor(p *game.PlayerSnapshot) color.RGBA {
+	switch {
+	case p.IsExhausted:
+		return exhaustedColor
+	case p.IsSprinting:
+		return sprintColor
+	}
+	return staminaColor
+}
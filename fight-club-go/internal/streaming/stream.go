@@ -1,6 +1,7 @@
 package streaming
 
 import (
+	"errors"
 	"fmt"
 	"image"
 	"image/color"
@@ -9,8 +10,8 @@ import (
 	"math"
 	"os"
 	"os/exec"
-	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -18,10 +19,23 @@ import (
 
 	"fight-club/internal/avatar"
 	"fight-club/internal/game"
+	"fight-club/internal/ipc"
+	"fight-club/internal/profiling"
 
 	"github.com/fogleman/gg"
 	"golang.org/x/image/font"
-	"golang.org/x/image/font/opentype"
+)
+
+// Render stages for the debug server budget breakdown (/debug/budget)
+var (
+	renderPhasePrepare  = profiling.Track("render/prepare")  // Snapshot, sound triggers, interpolation
+	renderPhaseArena    = profiling.Track("render/arena")    // gg: background, players, effects
+	renderPhaseUI       = profiling.Track("render/ui")       // gg: HUD, leaderboards, minimap
+	renderPhaseCopy     = profiling.Track("render/copy")     // gg: context to frame buffer
+	renderPhaseAtlas    = profiling.Track("render/atlas")    // Sprite blitter (whole frame)
+	renderPhaseScale    = profiling.Track("render/scale")    // Downsample for encoder pressure
+	renderPhaseWrite    = profiling.Track("render/write")    // Ring buffer or pipe write
+	renderPhasePortrait = profiling.Track("render/portrait") // 9:16 simulcast frame
 )
 
 // StreamConfig holds streaming configuration
@@ -37,10 +51,63 @@ type StreamConfig struct {
 	MusicEnabled bool
 	MusicVolume  float64 // 0.0-1.0, recommended 0.1-0.2
 	MusicPath    string
+	Sounds       SoundConfig // Sound effects pack (the game server's snapshot pack wins)
 
 	// Hardware encoding configuration
 	UseNVENC   bool // Use NVIDIA NVENC hardware encoder (requires NVIDIA GPU)
 	ForceNVENC bool // Skip NVENC availability check and force usage (use if test fails but you have NVENC)
+
+	// Send the feed to OBS over SRT instead of RTMPURL (see srt_output.go)
+	SRT SRTConfig
+
+	// Frame composition backend: RendererGG (default) or RendererAtlas
+	Renderer string
+
+	// Named FFmpeg encoder profile (see ffmpeg_command.go); "" = pick by NVENC availability
+	Profile string
+
+	// Encode to FFmpeg's null muxer instead of RTMPURL (cmd/benchrender)
+	NullOutput bool
+
+	// Encode to a local file instead of RTMPURL (cmd/rerender), scaled to
+	// OutputWidth x OutputHeight when set (0 = Width x Height)
+	OutputFile                string
+	OutputWidth, OutputHeight int
+
+	// Local VOD recording alongside the live stream (see recording.go)
+	Recording RecordingConfig
+
+	// Drop the render resolution when FFmpeg falls behind (see resolution.go)
+	AdaptiveResolution AdaptiveResolutionConfig
+
+	// Blend the last two snapshots when the source keeps them (see interpolation.go)
+	Interpolation bool
+
+	// Shed particles, overlays, then whole frames when rendering falls behind (see frameskip.go)
+	FrameSkip bool
+
+	// Draw players beyond the leaders and their surroundings as dots in crowded arenas (see render_lod.go)
+	LOD bool
+
+	// Optional on-stream widgets (see minimap_render.go)
+	Theme ThemeConfig
+
+	// 9:16 simulcast as a second FFmpeg output (see portrait.go)
+	Portrait PortraitConfig
+
+	// Avatar cache TTLs and disk directory (zero value = defaults)
+	AvatarCache avatar.Config
+}
+
+// fileOutputFinishTimeout is how long Stop waits for FFmpeg to flush OutputFile
+const fileOutputFinishTimeout = 30 * time.Second
+
+// outputSize returns the encoded video size (the render size unless OutputWidth/Height are set)
+func (c StreamConfig) outputSize() (int, int) {
+	if c.OutputWidth > 0 && c.OutputHeight > 0 {
+		return c.OutputWidth, c.OutputHeight
+	}
+	return c.Width, c.Height
 }
 
 // DoubleBuffer provides non-blocking frame buffering
@@ -61,11 +128,13 @@ type StreamManager struct {
 
 	mu        sync.RWMutex
 	streaming bool
+	recording bool // FFmpeg is also writing the local recording
 	stopChan  chan struct{}
 
 	// Audio
 	audioMixer *AudioMixer
 	audioPipe  io.WriteCloser
+	avClock    *AVClock // Keys audio samples to video frames written (PTS)
 
 	// Stats
 	framesSent int64 // atomic
@@ -76,6 +145,35 @@ type StreamManager struct {
 	doubleBuffer *DoubleBuffer
 	workerPool   *RenderWorkerPool
 	fastRenderer *FastRenderer
+	atlas        *AtlasRenderer // Non-nil when the atlas backend is active
+	trails       *TrailRenderer // Anti-aliased weapon trails (both backends)
+
+	// Dynamic resolution: frames are downsampled before the pipe and
+	// upscaled back to config.Width x config.Height in FFmpeg
+	resolution   *ResolutionController // nil = always full resolution
+	scaler       *frameScaler          // non-nil while sending below output size
+	scaledFrame  []byte
+	encodeWidth  int // FFmpeg rawvideo input size
+	encodeHeight int
+	encoderSpeed uint64 // atomic - math.Float64bits of the last FFmpeg speed
+
+	// Adaptive frame skipping when rendering falls behind (see frameskip.go)
+	frameSkip *FrameSkipController // nil = render every frame in full
+
+	// Auto-framing camera (see camera.go)
+	framer *autoFramer // nil = the whole arena, always
+
+	// Per-player level of detail in crowded arenas (see render_lod.go)
+	lod *renderLOD // nil = every player drawn in full
+
+	// Admin panel thumbnail of the latest rendered frame (see preview.go)
+	preview *FramePreview
+
+	// Render-side snapshot interpolation (nil = draw the latest snapshot as-is)
+	interp *snapshotInterpolator
+
+	// Slow-motion replays (see slowmo.go)
+	replay replayPlayer
 
 	// Legacy buffer for fallback
 	frameBuffer []byte
@@ -84,11 +182,19 @@ type StreamManager struct {
 	frameRingBuffer *FrameRingBuffer
 	asyncWriter     *AsyncFrameWriter
 
+	// Portrait simulcast (nil = disabled): own frame, ring buffer and pipe (fd 4)
+	portrait       *PortraitRenderer
+	portraitFrame  []byte
+	portraitRing   *FrameRingBuffer
+	portraitPipe   io.WriteCloser
+	portraitWriter *AsyncFrameWriter
+
 	// REAL-TIME FIX: Cached fonts (loaded once, not per-frame)
 	fontSmall   font.Face
 	fontMedium  font.Face
 	fontLarge   font.Face
 	fontsLoaded bool
+	fonts       *FontRegistry // Fallback chain with emoji/symbol fonts (see fonts.go)
 
 	// REAL-TIME FIX: Frame timing stats
 	lastFrameTime  time.Time
@@ -99,6 +205,12 @@ type StreamManager struct {
 	// Callback when stream starts
 	onStreamStart func()
 
+	// Callback when the stream fails (connection lost, reconnection given up)
+	onStreamError func(err error)
+
+	// Callback after each rendered frame with its render time (benchmarks)
+	onFrameRendered func(frameTime time.Duration)
+
 	// Avatar cache for profile pictures
 	avatarCache *avatar.Cache
 
@@ -106,10 +218,13 @@ type StreamManager struct {
 	prevAttackingPlayers map[string]bool // Track who was attacking last frame
 	prevAlivePlayers     map[string]bool // Track who was alive last frame
 	prevTotalKills       int             // Track total kills last frame
+	prevPlayerKills      map[string]int  // Track each player's kills last frame (who got the kill)
+	soundPack            string          // Last sound pack the snapshots asked for
 
 	// Auto-reconnection
 	reconnectAttempts int32         // atomic - number of reconnection attempts
 	reconnecting      int32         // atomic - flag to prevent concurrent reconnection
+	lastKeyframe      int64         // atomic - UnixNano of the last forced keyframe (see keyframe.go)
 	maxReconnects     int           // maximum reconnection attempts (0 = unlimited)
 	reconnectBaseDelay time.Duration // base delay for exponential backoff
 }
@@ -163,6 +278,7 @@ func NewStreamManager(engine *game.Engine, config StreamConfig) *StreamManager {
 		MusicEnabled: config.MusicEnabled,
 		MusicVolume:  config.MusicVolume,
 		MusicPath:    config.MusicPath,
+		Sounds:       config.Sounds,
 	}
 
 	sm := &StreamManager{
@@ -175,14 +291,25 @@ func NewStreamManager(engine *game.Engine, config StreamConfig) *StreamManager {
 		fastRenderer:    fastRenderer,
 		frameBuffer:     make([]byte, frameSize),
 		frameRingBuffer:      frameRingBuffer,
-		avatarCache:          avatar.NewCache(200), // Cache up to 200 profile pictures
+		avatarCache:          avatar.NewCacheWithConfig(200, config.AvatarCache), // Cache up to 200 profile pictures
 		prevAttackingPlayers: make(map[string]bool),
 		prevAlivePlayers:     make(map[string]bool),
 		prevTotalKills:       0,
+		prevPlayerKills:      make(map[string]int),
 		// Auto-reconnection settings
 		maxReconnects:      10,                    // Max 10 reconnection attempts
 		reconnectBaseDelay: 2 * time.Second,      // Start with 2 second delay
 	}
+	sm.avClock = NewAVClock(sm.audioMixer.SampleRate(), config.FPS)
+	if config.AdaptiveResolution.Enabled {
+		sm.resolution = NewResolutionController(config.AdaptiveResolution)
+	}
+	if config.FrameSkip {
+		sm.frameSkip = NewFrameSkipController(config.FPS)
+	}
+	sm.preview = NewFramePreview(config.Width, config.Height)
+	sm.framer = newAutoFramer(config.Theme, config.Width, config.Height)
+	sm.lod = newRenderLOD(config.LOD)
 
 	// Initialize snapshot source from engine (local mode)
 	if engine != nil {
@@ -191,6 +318,8 @@ func NewStreamManager(engine *game.Engine, config StreamConfig) *StreamManager {
 
 	// REAL-TIME FIX: Load fonts once at startup (not per-frame)
 	sm.loadFonts()
+	sm.initRenderer()
+	sm.initPortrait()
 
 	return sm
 }
@@ -236,6 +365,7 @@ func NewStreamManagerWithSource(source SnapshotSource, config StreamConfig) *Str
 		MusicEnabled: config.MusicEnabled,
 		MusicVolume:  config.MusicVolume,
 		MusicPath:    config.MusicPath,
+		Sounds:       config.Sounds,
 	}
 
 	sm := &StreamManager{
@@ -249,71 +379,100 @@ func NewStreamManagerWithSource(source SnapshotSource, config StreamConfig) *Str
 		fastRenderer:         fastRenderer,
 		frameBuffer:          make([]byte, frameSize),
 		frameRingBuffer:      frameRingBuffer,
-		avatarCache:          avatar.NewCache(200),
+		avatarCache:          avatar.NewCacheWithConfig(200, config.AvatarCache),
 		prevAttackingPlayers: make(map[string]bool),
 		prevAlivePlayers:     make(map[string]bool),
 		prevTotalKills:       0,
+		prevPlayerKills:      make(map[string]int),
 		maxReconnects:        10,
 		reconnectBaseDelay:   2 * time.Second,
 	}
+	sm.avClock = NewAVClock(sm.audioMixer.SampleRate(), config.FPS)
+	if config.AdaptiveResolution.Enabled {
+		sm.resolution = NewResolutionController(config.AdaptiveResolution)
+	}
+	if config.FrameSkip {
+		sm.frameSkip = NewFrameSkipController(config.FPS)
+	}
+	sm.preview = NewFramePreview(config.Width, config.Height)
+	sm.framer = newAutoFramer(config.Theme, config.Width, config.Height)
+	sm.lod = newRenderLOD(config.LOD)
+	if _, ok := source.(SnapshotHistory); ok && config.Interpolation {
+		sm.interp = newSnapshotInterpolator()
+	}
 
 	sm.loadFonts()
+	sm.initRenderer()
+	sm.initPortrait()
 	return sm
 }
 
-// loadFonts loads fonts once at startup to avoid per-frame file I/O
+// loadFonts builds the font fallback chain and caches the common faces once at
+// startup to avoid per-frame file I/O
 func (s *StreamManager) loadFonts() {
-	fontPath := getFontPath()
-	if fontPath == "" {
-		log.Println("⚠️ No font found, text rendering may be affected")
-		return
-	}
+	s.fonts = NewFontRegistry(s.config.Theme.Font, s.config.Theme.EmojiFont)
 
-	fontData, err := os.ReadFile(fontPath)
-	if err != nil {
-		log.Printf("⚠️ Failed to read font file: %v", err)
-		return
+	var err error
+	for _, f := range []struct {
+		face *font.Face
+		size float64
+	}{{&s.fontSmall, 16}, {&s.fontMedium, 24}, {&s.fontLarge, 48}} {
+		if *f.face, err = s.fonts.Face(f.size); err != nil {
+			log.Printf("⚠️ Failed to create %.0fpx font face: %v", f.size, err)
+			return
+		}
 	}
 
-	parsedFont, err := opentype.Parse(fontData)
-	if err != nil {
-		log.Printf("⚠️ Failed to parse font: %v", err)
-		return
-	}
+	s.fontsLoaded = true
+	log.Printf("✅ Fonts loaded and cached: %s", strings.Join(s.fonts.Sources(), ", "))
+}
 
-	// Create font faces at different sizes
-	s.fontSmall, err = opentype.NewFace(parsedFont, &opentype.FaceOptions{
-		Size:    16,
-		DPI:     72,
-		Hinting: font.HintingFull,
-	})
+// loadFontFace sets a face of the given size from the font chain on dc
+func (s *StreamManager) loadFontFace(dc *gg.Context, size float64) error {
+	fonts := s.fonts
+	if fonts == nil {
+		fonts = defaultFontRegistry()
+	}
+	face, err := fonts.Face(size)
 	if err != nil {
-		log.Printf("⚠️ Failed to create small font face: %v", err)
-		return
+		return err
 	}
+	dc.SetFontFace(face)
+	return nil
+}
 
-	s.fontMedium, err = opentype.NewFace(parsedFont, &opentype.FaceOptions{
-		Size:    24,
-		DPI:     72,
-		Hinting: font.HintingFull,
-	})
-	if err != nil {
-		log.Printf("⚠️ Failed to create medium font face: %v", err)
+// initRenderer sets up the configured frame composition backend.
+// Falls back to the gg renderer if the atlas can't be built.
+func (s *StreamManager) initRenderer() {
+	s.trails = NewTrailRenderer(s.config.Width, s.config.Height)
+
+	switch s.config.Renderer {
+	case "", RendererGG:
+		s.config.Renderer = RendererGG
+	case RendererAtlas:
+		atlas, err := NewAtlasRenderer(s)
+		if err != nil {
+			log.Printf("⚠️ Atlas renderer unavailable, falling back to gg: %v", err)
+			s.config.Renderer = RendererGG
+			return
+		}
+		s.atlas = atlas
+	default:
+		log.Printf("⚠️ Unknown renderer '%s', falling back to gg", s.config.Renderer)
+		s.config.Renderer = RendererGG
 		return
 	}
+	log.Printf("🎨 Renderer: %s", s.config.Renderer)
+}
 
-	s.fontLarge, err = opentype.NewFace(parsedFont, &opentype.FaceOptions{
-		Size:    48,
-		DPI:     72,
-		Hinting: font.HintingFull,
-	})
-	if err != nil {
-		log.Printf("⚠️ Failed to create large font face: %v", err)
+// initPortrait sets up the portrait renderer and its frame buffers when configured
+func (s *StreamManager) initPortrait() {
+	if !s.config.Portrait.Enabled() {
 		return
 	}
-
-	s.fontsLoaded = true
-	log.Printf("✅ Fonts loaded and cached from: %s", fontPath)
+	s.portrait = newPortraitRenderer(s, s.config.Portrait)
+	s.portraitFrame = make([]byte, s.portrait.FrameSize())
+	s.portraitRing = NewFrameRingBuffer(s.portrait.FrameSize())
 }
 
 // OnStreamStart registers a callback to be called when the stream starts
@@ -323,6 +482,32 @@ func (s *StreamManager) OnStreamStart(callback func()) {
 	s.mu.Unlock()
 }
 
+// OnStreamError registers a callback to be called when the stream connection is
+// lost and again if reconnection is abandoned
+func (s *StreamManager) OnStreamError(callback func(err error)) {
+	s.mu.Lock()
+	s.onStreamError = callback
+	s.mu.Unlock()
+}
+
+// OnFrameRendered registers a callback run on the render loop after each
+// fully rendered frame (skipped frames excluded); it must return quickly
+func (s *StreamManager) OnFrameRendered(callback func(frameTime time.Duration)) {
+	s.mu.Lock()
+	s.onFrameRendered = callback
+	s.mu.Unlock()
+}
+
+// notifyStreamError invokes the stream error callback if set
+func (s *StreamManager) notifyStreamError(err error) {
+	s.mu.RLock()
+	callback := s.onStreamError
+	s.mu.RUnlock()
+	if callback != nil {
+		go callback(err)
+	}
+}
+
 // checkNVENCAvailable tests if NVIDIA NVENC hardware encoder is available
 // by running a quick FFmpeg test encode
 func checkNVENCAvailable() bool {
@@ -351,7 +536,7 @@ func checkNVENCAvailable() bool {
 	return err == nil
 }
 
-// Start begins streaming to RTMP
+// Start begins streaming to RTMP (or SRT, see StreamConfig.SRT)
 func (s *StreamManager) Start() error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -366,20 +551,52 @@ func (s *StreamManager) Start() error {
 		rtmpURL = s.config.RTMPURL + "/" + s.config.StreamKey
 	}
 
-	log.Println("🎬 Starting stream to Kick...")
-	log.Println("   Mode: DIRECT RTMP (no proxy/tunnel - minimal latency)")
+	// Live output: RTMP to the platform, or SRT for OBS to pull in locally
+	liveMuxer, liveURL := "flv", rtmpURL
+	if s.config.SRT.Enabled() && s.config.OutputFile == "" {
+		srtURL, err := s.config.SRT.outputURL()
+		if err != nil {
+			return err
+		}
+		liveMuxer, liveURL = "mpegts", srtURL
+	}
+
+	if liveMuxer == "mpegts" {
+		log.Println("🎬 Starting SRT output for OBS...")
+		log.Println("   Mode: SRT listener (add it in OBS as a Media Source with mode=caller)")
+	} else {
+		log.Println("🎬 Starting stream to Kick...")
+		log.Println("   Mode: DIRECT RTMP (no proxy/tunnel - minimal latency)")
+	}
 	log.Printf("   Resolution: %dx%d @ %d fps", s.config.Width, s.config.Height, s.config.FPS)
+	profiling.SetBudget("render", 1000/float64(s.config.FPS))
+	s.prepareEncodeSize()
+	if s.scaler != nil {
+		log.Printf("   📐 Rendering at %dx%d (encoder pressure), upscaled by FFmpeg", s.encodeWidth, s.encodeHeight)
+	}
 	log.Printf("   Bitrate: %dk", s.config.Bitrate)
-	log.Printf("   RTMP URL: %s", s.config.RTMPURL)
-	log.Printf("   Stream Key: %s...", s.config.StreamKey[:min(10, len(s.config.StreamKey))])
+	if liveMuxer == "mpegts" {
+		log.Printf("   SRT URL: %s", s.config.SRT.URL)
+	} else {
+		log.Printf("   RTMP URL: %s", s.config.RTMPURL)
+		log.Printf("   Stream Key: %s...", s.config.StreamKey[:min(10, len(s.config.StreamKey))])
+	}
+
+	// Encoder settings: a named profile (FFMPEG_PROFILE) or the default for the detected encoder
+	profile, named := GetFFmpegProfile(s.config.Profile)
+	wantNVENC := s.config.UseNVENC || s.config.ForceNVENC
+	if named {
+		wantNVENC = profile.Encoder == EncoderNVENC
+		log.Printf("   🎛️ FFmpeg profile: %s", profile.Name)
+	}
 
 	// Determine encoder: NVENC (GPU) vs libx264 (CPU)
 	useNVENC := false
-	if s.config.ForceNVENC {
+	if wantNVENC && s.config.ForceNVENC {
 		// Force NVENC without checking - user knows they have it
 		useNVENC = true
 		log.Println("   🎥 Using NVENC GPU hardware encoding (FORCED - skipping availability check)")
-	} else if s.config.UseNVENC {
+	} else if wantNVENC {
 		if checkNVENCAvailable() {
 			useNVENC = true
 			log.Println("   🎥 Using NVENC GPU hardware encoding (NVIDIA)")
@@ -391,19 +608,22 @@ func (s *StreamManager) Start() error {
 	if !useNVENC {
 		log.Println("   🎥 Using libx264 CPU encoding")
 	}
+	if !named || (wantNVENC && !useNVENC) {
+		profile = defaultFFmpegProfile(useNVENC)
+	}
+	if s.config.NullOutput {
+		profile.NullOutput = true
+		log.Println("   🧪 Output: FFmpeg null muxer (nothing is sent)")
+	}
 
 	// Build FFmpeg arguments - CROSS-PLATFORM
 	// Windows: Uses file-based audio (ExtraFiles not supported)
 	// Linux/macOS: Uses piped audio for full SFX support
-	args := []string{
-		"-y",
-		// Video input (pipe:0 - stdin)
-		"-f", "rawvideo",
-		"-pix_fmt", "rgba",
-		"-s", fmt.Sprintf("%dx%d", s.config.Width, s.config.Height),
-		"-r", fmt.Sprintf("%d", s.config.FPS),
-		"-i", "pipe:0",
-	}
+	// Reduced render resolution is upscaled back so the stream size never changes
+	builder := NewFFmpegCommandBuilder(profile).
+		VideoInput(s.encodeWidth, s.encodeHeight, s.config.FPS).
+		OutputSize(s.config.outputSize()).
+		Bitrate(s.config.Bitrate)
 
 	// Audio input - platform specific
 	useAudioPipe := runtime.GOOS != "windows"
@@ -417,29 +637,18 @@ func (s *StreamManager) Start() error {
 
 	if useAudioPipe {
 		// Linux/macOS: Use piped audio (supports SFX + music mixing)
-		args = append(args,
-			"-f", "s16le",
-			"-ar", "44100",
-			"-ac", "2",
-			"-i", "pipe:3",
-		)
+		builder.AudioPipe()
 		log.Println("   🔊 Sound effects: enabled (piped audio)")
 	} else {
 		// Windows: Use file-based audio (no SFX support yet)
 		if musicFileExists {
 			log.Printf("   🎵 Background music: %s (volume: %.0f%%)", musicPath, s.config.MusicVolume*100)
-			args = append(args,
-				"-stream_loop", "-1",
-				"-i", musicPath,
-			)
+			builder.MusicFile(musicPath, s.config.MusicVolume)
 		} else {
 			if s.config.MusicEnabled && musicPath != "" {
 				log.Printf("   ⚠️ Music file not found: %s, using silent audio", musicPath)
 			}
-			args = append(args,
-				"-f", "lavfi",
-				"-i", "anullsrc=channel_layout=stereo:sample_rate=44100",
-			)
+			builder.SilentAudio()
 		}
 		log.Println("   ⚠️ Sound effects: disabled on Windows (file-based audio)")
 	}
@@ -448,72 +657,44 @@ func (s *StreamManager) Start() error {
 		log.Printf("   🎵 Background music: %s (volume: %.0f%%)", s.config.MusicPath, s.config.MusicVolume*100)
 	}
 
-	// Video encoding - optimized for speed
-	if useNVENC {
-		// NVIDIA NVENC hardware encoding - significantly faster than CPU
-		// Optimized for RTX 30/40 series with low-latency streaming preset
-		args = append(args,
-			"-c:v", "h264_nvenc",
-			"-preset", "p1",        // Fastest NVENC preset (p1-p7, p1=fastest)
-			"-tune", "ll",          // Low-latency tuning
-			"-rc", "cbr",           // Constant bitrate for streaming stability
-			"-b:v", fmt.Sprintf("%dk", s.config.Bitrate),
-			"-maxrate", fmt.Sprintf("%dk", s.config.Bitrate),
-			"-bufsize", fmt.Sprintf("%dk", s.config.Bitrate*2),
-			"-pix_fmt", "yuv420p",
-			"-g", fmt.Sprintf("%d", s.config.FPS*2), // GOP size
-			"-keyint_min", fmt.Sprintf("%d", s.config.FPS),
-			"-no-scenecut", "1",    // Disable scene cut detection for consistent keyframes
-			"-profile:v", "main",
-			"-level", "4.1",        // H.264 level for compatibility
-			"-spatial-aq", "1",     // Spatial adaptive quantization for better quality
-			"-zerolatency", "1",    // Minimize encoding latency
-		)
-	} else {
-		// CPU encoding with libx264 - fallback option
-		args = append(args,
-			"-c:v", "libx264",
-			"-preset", "ultrafast",
-			"-tune", "zerolatency",
-			"-b:v", fmt.Sprintf("%dk", s.config.Bitrate),
-			"-maxrate", fmt.Sprintf("%dk", s.config.Bitrate),
-			"-bufsize", fmt.Sprintf("%dk", s.config.Bitrate*2),
-			"-pix_fmt", "yuv420p",
-			"-g", fmt.Sprintf("%d", s.config.FPS*2),
-			"-keyint_min", fmt.Sprintf("%d", s.config.FPS),
-			"-sc_threshold", "0",
-			"-profile:v", "main",
-		)
-	}
-
-	// Audio encoding
-	if !useAudioPipe && musicFileExists {
-		// Windows with music file: apply volume filter
-		args = append(args,
-			"-af", fmt.Sprintf("volume=%.2f", s.config.MusicVolume),
-			"-c:a", "aac",
-			"-b:a", "128k",
-			"-ar", "44100",
-			"-ac", "2",
-		)
-	} else {
-		args = append(args,
-			"-c:a", "aac",
-			"-b:a", "128k",
-			"-ar", "44100",
-			"-ac", "2",
-		)
-	}
-
-	// Map streams and output
-	args = append(args,
-		"-map", "0:v", // Video from stdin (pipe:0)
-		"-map", "1:a", // Audio from pipe:3
-		"-f", "flv",
-		rtmpURL,
-	)
+	// Local recording: same encode written to segmented files via the tee muxer
+	recording := s.config.Recording.Enabled()
+	if recording {
+		if err := os.MkdirAll(s.config.Recording.Dir, 0o755); err != nil {
+			log.Printf("   ⚠️ Recording disabled: cannot create %s: %v", s.config.Recording.Dir, err)
+			recording = false
+		} else {
+			rc := s.config.Recording.withDefaults()
+			log.Printf("   💾 Recording: %s (%s, %ds segments)", rc.Dir, rc.Format, rc.SegmentSeconds)
+		}
+	}
+
+	switch {
+	case s.config.OutputFile != "":
+		recording = false
+		builder.FileOutput(s.config.OutputFile)
+		w, h := s.config.outputSize()
+		log.Printf("   💾 Output: %s (%dx%d)", s.config.OutputFile, w, h)
+	case recording:
+		builder.TeeOutput(s.config.Recording.teeOutput(liveMuxer, liveURL))
+	case liveMuxer == "mpegts":
+		builder.SRTOutput(liveURL)
+	default:
+		builder.Output(liveURL)
+	}
+
+	// Portrait simulcast: second rawvideo pipe, so Linux/macOS only (like piped audio)
+	portrait := s.portrait != nil
+	if portrait && !useAudioPipe {
+		log.Println("   ⚠️ Portrait output: disabled on Windows (needs a second video pipe)")
+		portrait = false
+	}
+	if portrait {
+		builder.PortraitOutput(s.portrait.config)
+		log.Printf("   📱 Portrait output: %dx%d @ %dk", s.portrait.config.Width, s.portrait.config.Height, s.portrait.config.Bitrate)
+	}
 
-	s.ffmpeg = exec.Command("ffmpeg", args...)
+	s.ffmpeg = builder.Command()
 
 	// Platform-specific process group setup (Linux only)
 	// This allows killing all child processes together on shutdown
@@ -536,8 +717,18 @@ func (s *StreamManager) Start() error {
 		s.ffmpeg.ExtraFiles = []*os.File{audioReader} // fd 3
 	}
 
-	// Capture stderr for debugging
-	s.ffmpeg.Stderr = os.Stderr
+	// Create portrait video pipe (fd 4 via ExtraFiles)
+	if portrait {
+		portraitReader, portraitWriter, err := os.Pipe()
+		if err != nil {
+			return fmt.Errorf("failed to create portrait pipe: %w", err)
+		}
+		s.portraitPipe = portraitWriter
+		s.ffmpeg.ExtraFiles = append(s.ffmpeg.ExtraFiles, portraitReader) // fd 4
+	}
+
+	// Capture stderr for debugging (and encoder speed for adaptive resolution)
+	s.ffmpeg.Stderr = newFFmpegProgressWriter(os.Stderr, s.onEncoderSpeed)
 
 	// Start FFmpeg
 	if err := s.ffmpeg.Start(); err != nil {
@@ -545,8 +736,10 @@ func (s *StreamManager) Start() error {
 	}
 
 	s.streaming = true
+	s.recording = recording
 	s.startTime = time.Now()
 	atomic.StoreInt64(&s.framesSent, 0)
+	s.avClock.Reset()
 	s.stopChan = make(chan struct{})
 	s.errors = nil
 
@@ -557,6 +750,9 @@ func (s *StreamManager) Start() error {
 	// Set bitrate for connection quality recommendations
 	s.asyncWriter.SetBitrate(s.config.Bitrate)
 
+	// Every frame delivered to FFmpeg advances the video PTS the audio loop follows
+	s.asyncWriter.SetAVClock(s.avClock)
+
 	// Set up auto-reconnection callback
 	s.asyncWriter.SetOnConnectionLost(func() {
 		go s.handleConnectionLost()
@@ -564,9 +760,22 @@ func (s *StreamManager) Start() error {
 
 	s.asyncWriter.Start(s.config.FPS)
 
+	// Portrait frames get their own writer; the main writer handles reconnection
+	if portrait {
+		s.portraitRing.Reset()
+		s.portraitWriter = NewAsyncFrameWriter(s.portraitRing, s.portraitPipe)
+		s.portraitWriter.SetBitrate(s.portrait.config.Bitrate)
+		s.portraitWriter.Start(s.config.FPS)
+	}
+
 	// Start frame loop (video)
 	go s.frameLoop()
 
+	// Apply recording retention policy while streaming
+	if recording {
+		go s.recordingLoop(s.stopChan)
+	}
+
 	// Start audio loop (sound effects + music) - Linux/macOS only
 	if useAudioPipe {
 		go s.audioLoop()
@@ -594,12 +803,17 @@ func (s *StreamManager) Stop() {
 	log.Println("🛑 Stopping stream...")
 
 	s.streaming = false
+	s.recording = false
 	close(s.stopChan)
 
-	// Stop async writer first (before closing pipes)
+	// Stop async writers first (before closing pipes)
 	if s.asyncWriter != nil {
 		s.asyncWriter.Stop()
 	}
+	if s.portraitWriter != nil {
+		s.portraitWriter.Stop()
+		s.portraitWriter = nil
+	}
 
 	// Stop worker pool
 	if s.workerPool != nil {
@@ -618,11 +832,14 @@ func (s *StreamManager) Stop() {
 	if s.audioPipe != nil {
 		s.audioPipe.Close()
 	}
+	if s.portraitPipe != nil {
+		s.portraitPipe.Close()
+		s.portraitPipe = nil
+	}
 
 	// Kill FFmpeg process and all its children
 	if s.ffmpeg != nil && s.ffmpeg.Process != nil {
 		pid := s.ffmpeg.Process.Pid
-		log.Printf("🔪 Killing FFmpeg process (PID: %d)...", pid)
 
 		// Wait briefly for graceful exit
 		done := make(chan error, 1)
@@ -630,15 +847,31 @@ func (s *StreamManager) Stop() {
 			done <- s.ffmpeg.Wait()
 		}()
 
+		// File output: the closed pipes end the input, let FFmpeg finish the
+		// file (an MP4 is unplayable without its index) before killing it
+		finished := false
+		if s.config.OutputFile != "" {
+			select {
+			case <-done:
+				finished = true
+				log.Printf("✅ FFmpeg finished %s", s.config.OutputFile)
+			case <-time.After(fileOutputFinishTimeout):
+				log.Println("⚠️ Timed out waiting for FFmpeg to finish the file")
+			}
+		}
+
 		// Try graceful termination first, then force kill
 		// Use platform-specific kill function
-		killFFmpegProcess(s.ffmpeg, pid)
-
-		select {
-		case <-done:
-			log.Println("✅ FFmpeg process terminated")
-		case <-time.After(3 * time.Second):
-			log.Println("⚠️ Timed out waiting for FFmpeg to terminate")
+		if !finished {
+			log.Printf("🔪 Killing FFmpeg process (PID: %d)...", pid)
+			killFFmpegProcess(s.ffmpeg, pid)
+
+			select {
+			case <-done:
+				log.Println("✅ FFmpeg process terminated")
+			case <-time.After(3 * time.Second):
+				log.Println("⚠️ Timed out waiting for FFmpeg to terminate")
+			}
 		}
 	}
 
@@ -660,8 +893,12 @@ func (s *StreamManager) handleConnectionLost() {
 	// Check if we've exceeded max reconnection attempts
 	if s.maxReconnects > 0 && int(attempt) > s.maxReconnects {
 		log.Printf("❌ Max reconnection attempts (%d) exceeded. Manual restart required.", s.maxReconnects)
+		s.notifyStreamError(fmt.Errorf("gave up after %d reconnection attempts, manual restart required", s.maxReconnects))
 		return
 	}
+	if attempt == 1 {
+		s.notifyStreamError(errors.New("FFmpeg connection lost, reconnecting"))
+	}
 
 	// Calculate exponential backoff delay (2s, 4s, 8s, 16s, 32s, capped at 60s)
 	delay := s.reconnectBaseDelay * time.Duration(1<<uint(attempt-1))
@@ -713,6 +950,21 @@ func (s *StreamManager) Restart() error {
 	return s.Start()
 }
 
+// Suspend ends the broadcast but, unlike Stop, keeps the worker pool and
+// music player so Start can resume it later (auto-stream idle shutdown)
+func (s *StreamManager) Suspend() {
+	if !s.IsStreaming() {
+		return
+	}
+	log.Println("💤 Suspending stream (arena idle)...")
+	s.stopInternal()
+
+	s.mu.Lock()
+	s.recording = false
+	s.mu.Unlock()
+	atomic.StoreInt32(&s.reconnectAttempts, 0)
+}
+
 // stopInternal stops the stream internals without setting streaming to false
 // Used during reconnection to preserve the streaming state
 func (s *StreamManager) stopInternal() {
@@ -729,10 +981,14 @@ func (s *StreamManager) stopInternal() {
 		close(s.stopChan)
 	}
 
-	// Stop async writer first (before closing pipes)
+	// Stop async writers first (before closing pipes)
 	if s.asyncWriter != nil {
 		s.asyncWriter.Stop()
 	}
+	if s.portraitWriter != nil {
+		s.portraitWriter.Stop()
+		s.portraitWriter = nil
+	}
 
 	// Close pipes to unblock any reads
 	if s.videoPipe != nil {
@@ -743,6 +999,10 @@ func (s *StreamManager) stopInternal() {
 		s.audioPipe.Close()
 		s.audioPipe = nil
 	}
+	if s.portraitPipe != nil {
+		s.portraitPipe.Close()
+		s.portraitPipe = nil
+	}
 
 	// Kill FFmpeg process and all its children
 	if s.ffmpeg != nil && s.ffmpeg.Process != nil {
@@ -819,11 +1079,30 @@ func (s *StreamManager) GetStats() map[string]interface{} {
 		"resolution":         fmt.Sprintf("%dx%d", s.config.Width, s.config.Height),
 		"fps":                s.config.FPS,
 		"bitrate":            s.config.Bitrate,
+		"renderer":           s.config.Renderer,
 		"errors":             s.errors,
 		"reconnecting":       atomic.LoadInt32(&s.reconnecting) == 1,
 		"reconnectAttempts":  atomic.LoadInt32(&s.reconnectAttempts),
 	}
 
+	stats["recording"] = s.recordingStats()
+	stats["interpolation"] = s.interp != nil
+	if s.resolution != nil {
+		stats["renderScale"] = s.resolution.Scale()
+		stats["encodeResolution"] = fmt.Sprintf("%dx%d", s.encodeWidth, s.encodeHeight)
+	}
+	if speed := math.Float64frombits(atomic.LoadUint64(&s.encoderSpeed)); speed > 0 {
+		stats["encoderSpeed"] = speed
+	}
+	stats["avSync"] = s.avClock.Stats()
+	if s.audioMixer != nil {
+		stats["sounds"] = s.audioMixer.Assets().Stats()
+	}
+	if s.frameSkip != nil {
+		stats["frameSkip"] = s.frameSkip.Stats()
+	}
+	stats["lod"] = s.lod.Stats()
+
 	// Add async writer stats if available
 	if s.asyncWriter != nil {
 		writerStats := s.asyncWriter.GetStats()
@@ -834,6 +1113,47 @@ func (s *StreamManager) GetStats() map[string]interface{} {
 	return stats
 }
 
+// Telemetry samples the stream's health for the game server (see
+// ipc.TelemetryMessage); the streamer process sends it every second
+func (s *StreamManager) Telemetry() ipc.TelemetryMessage {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	msg := ipc.TelemetryMessage{
+		Timestamp:         time.Now().UnixNano(),
+		Streaming:         s.streaming,
+		Reconnecting:      atomic.LoadInt32(&s.reconnecting) == 1,
+		ReconnectAttempts: atomic.LoadInt32(&s.reconnectAttempts),
+		FramesSent:        atomic.LoadInt64(&s.framesSent),
+		FramesDropped:     atomic.LoadInt64(&s.framesDropped),
+		EncoderSpeed:      math.Float64frombits(atomic.LoadUint64(&s.encoderSpeed)),
+		Width:             s.config.Width,
+		Height:            s.config.Height,
+		EncodeWidth:       s.encodeWidth,
+		EncodeHeight:      s.encodeHeight,
+		FPS:               s.config.FPS,
+		Bitrate:           s.config.Bitrate,
+		Renderer:          s.config.Renderer,
+	}
+
+	if s.streaming && !s.startTime.IsZero() {
+		msg.UptimeSeconds = time.Since(s.startTime).Seconds()
+		if msg.UptimeSeconds > 0 {
+			msg.ActualFPS = float64(msg.FramesSent) / msg.UptimeSeconds
+		}
+	}
+	if count := atomic.LoadInt64(&s.frameTimeCount); count > 0 {
+		msg.AvgFrameMs = float64(atomic.LoadInt64(&s.frameTimeAccum)) / float64(count) / 1e6
+	}
+	if s.asyncWriter != nil {
+		msg.ConnectionLost = s.asyncWriter.IsConnectionLost()
+	}
+	if n := len(s.errors); n > 0 {
+		msg.LastError = s.errors[n-1]
+	}
+	return msg
+}
+
 func (s *StreamManager) frameLoop() {
 	ticker := time.NewTicker(time.Second / time.Duration(s.config.FPS))
 	defer ticker.Stop()
@@ -848,12 +1168,16 @@ func (s *StreamManager) frameLoop() {
 	}
 }
 
-// audioLoop generates and writes audio frames to FFmpeg
-// Runs at the same rate as video for A/V synchronization
+// audioLoop generates and writes audio to FFmpeg, keyed to the video frames
+// actually written (PTS) rather than wall clock, so music and SFX can't drift
+// from the picture over long streams. Polls at twice the frame rate so audio
+// follows video within half a frame.
 func (s *StreamManager) audioLoop() {
-	ticker := time.NewTicker(time.Second / time.Duration(s.config.FPS))
+	ticker := time.NewTicker(time.Second / time.Duration(s.config.FPS*2))
 	defer ticker.Stop()
 
+	var silenceBuf []byte
+
 	for {
 		select {
 		case <-s.stopChan:
@@ -868,14 +1192,26 @@ func (s *StreamManager) audioLoop() {
 				continue
 			}
 
-			// Generate one frame of audio (5880 bytes = 1470 samples * 2 channels * 2 bytes)
-			audioFrame := s.audioMixer.GenerateFrame()
+			silence, mixed := s.avClock.Due()
 
-			// Write to FFmpeg audio pipe (non-blocking best effort)
-			_, err := audioPipe.Write(audioFrame)
-			if err != nil {
-				// Audio write failed, likely stream is stopping
-				return
+			// Large backlog (e.g. audio pipe stalled): skip it with silence
+			if silence > 0 {
+				if need := silence * 4; cap(silenceBuf) < need { // 2 channels * 2 bytes
+					silenceBuf = make([]byte, need)
+				}
+				if _, err := audioPipe.Write(silenceBuf[:silence*4]); err != nil {
+					return
+				}
+				s.avClock.AudioWritten(silence)
+			}
+
+			if mixed > 0 {
+				// Write to FFmpeg audio pipe (s16le stereo)
+				if _, err := audioPipe.Write(s.audioMixer.GenerateSamples(mixed)); err != nil {
+					// Audio write failed, likely stream is stopping
+					return
+				}
+				s.avClock.AudioWritten(mixed)
 			}
 		}
 	}
@@ -887,17 +1223,34 @@ func (s *StreamManager) triggerSoundEffects(snap *game.GameSnapshot) {
 		return
 	}
 
+	// The game server picks the sound pack (admin panel)
+	if snap.SoundPack != "" && snap.SoundPack != s.soundPack {
+		s.soundPack = snap.SoundPack
+		if err := s.audioMixer.Assets().SetPack(snap.SoundPack); err != nil {
+			log.Printf("⚠️ %v", err)
+		}
+	}
+
 	// Track current state
 	currentAttacking := make(map[string]bool)
 	currentAlive := make(map[string]bool)
+	currentKills := make(map[string]int, len(snap.Players))
+	var killers []*game.PlayerSnapshot
+
+	// Sounds are panned by where they happen in the arena
+	pan := func(p *game.PlayerSnapshot) float64 {
+		return ArenaPan(p.X, s.config.Width)
+	}
+
+	for i := range snap.Players {
+		p := &snap.Players[i]
 
-	for _, p := range snap.Players {
 		// Track attacking players for swing sound
 		if p.IsAttacking {
 			currentAttacking[p.ID] = true
 			// Play swing sound when player starts attacking
 			if !s.prevAttackingPlayers[p.ID] {
-				s.audioMixer.QueueSound("swing")
+				s.audioMixer.QueueSoundAt("swing", p.Weapon, pan(p))
 			}
 		}
 
@@ -906,32 +1259,61 @@ func (s *StreamManager) triggerSoundEffects(snap *game.GameSnapshot) {
 			currentAlive[p.ID] = true
 			// Player just spawned/respawned
 			if !s.prevAlivePlayers[p.ID] {
-				s.audioMixer.QueueSound("spawn")
+				s.audioMixer.QueueSoundAt("spawn", "", pan(p))
 			}
 		}
+
+		// Players whose kill count went up scored a kill this frame
+		currentKills[p.ID] = p.Kills
+		if prev, ok := s.prevPlayerKills[p.ID]; ok && p.Kills > prev {
+			killers = append(killers, p)
+		}
 	}
 
-	// Check for kills (total kills increased)
+	// Play kill sound for each kill (max 3 to avoid spam) with the killer's weapon
 	if snap.TotalKills > s.prevTotalKills {
 		killsThisFrame := snap.TotalKills - s.prevTotalKills
-		// Play kill sound for each kill (max 3 to avoid spam)
 		for i := 0; i < killsThisFrame && i < 3; i++ {
-			s.audioMixer.QueueSound("kill")
+			if i < len(killers) {
+				s.audioMixer.QueueSoundAt("kill", killers[i].Weapon, pan(killers[i]))
+			} else {
+				s.audioMixer.QueueSound("kill") // Killer not in snapshot (e.g. left)
+			}
 		}
 	}
 
-	// Check for hits - count newly dead players that were alive last frame
+	// Check for hits - players that were alive last frame and died this frame.
+	// The killing blow uses the nearest killer's weapon, at the victim's position.
 	for id := range s.prevAlivePlayers {
-		if !currentAlive[id] {
-			// Player died this frame - hit sound already played via kill
-			s.audioMixer.QueueSound("hit")
+		if currentAlive[id] {
+			continue
+		}
+		var victim *game.PlayerSnapshot
+		for i := range snap.Players {
+			if snap.Players[i].ID == id {
+				victim = &snap.Players[i]
+				break
+			}
 		}
+		if victim == nil {
+			s.audioMixer.QueueSound("hit") // Removed from the arena this frame
+			continue
+		}
+		weapon := ""
+		nearest := math.MaxFloat64
+		for _, k := range killers {
+			if d := math.Hypot(k.X-victim.X, k.Y-victim.Y); d < nearest && k.ID != victim.ID {
+				nearest, weapon = d, k.Weapon
+			}
+		}
+		s.audioMixer.QueueSoundAt("hit", weapon, pan(victim))
 	}
 
 	// Update previous state for next frame
 	s.prevAttackingPlayers = currentAttacking
 	s.prevAlivePlayers = currentAlive
 	s.prevTotalKills = snap.TotalKills
+	s.prevPlayerKills = currentKills
 }
 
 func (s *StreamManager) renderAndSendFrame() {
@@ -944,6 +1326,10 @@ func (s *StreamManager) renderAndSendFrame() {
 	}
 	// Keep lock held for double buffer access if needed, or better, copy what we need.
 	// Actually double buffer has its own mutex.
+	ringBuffer := s.frameRingBuffer
+	scaler, scaledFrame := s.scaler, s.scaledFrame
+	portraitWriter := s.portraitWriter
+	onFrameRendered := s.onFrameRendered
 	s.mu.RUnlock()
 
 	// DOUBLE BUFFERING: Get the back buffer index (opposite of active)
@@ -973,14 +1359,51 @@ func (s *StreamManager) renderAndSendFrame() {
 	// Trigger sound effects based on snapshot changes
 	s.triggerSoundEffects(snapshot)
 
+	// Falling behind: re-send the previous frame instead of rendering this one
+	render := s.frameSkip.ShouldRender()
+
+	// Smooth motion between game ticks (render-side only, sounds use the real state).
+	// A slow-motion replay swaps in older frames, blended the same way.
+	if prev, curr, at, ok := s.replay.Frame(snapshot, s.snapshotSource, frameStart); ok {
+		snapshot = prev
+		if s.interp != nil && render {
+			snapshot = s.interp.Sample(prev, curr, prev.Timestamp, at)
+		}
+	} else if s.interp != nil && render {
+		if prev, curr, currAt := s.snapshotSource.(SnapshotHistory).GetSnapshotPair(); curr != nil {
+			snapshot = s.interp.Sample(prev, curr, currAt, frameStart)
+		}
+	}
+
+	stageStart := renderPhasePrepare.Since(frameStart)
+
 	// Render to back buffer using snapshot (non-blocking)
-	s.renderFrameFromSnapshot(snapshot, backBuffer, backContext)
+	if render {
+		snapshot = s.frameSkip.Strip(snapshot)
+		s.framer.Update(snapshot.Framing, frameStart)
+		s.lod.Update(snapshot.Players)
+		if s.atlas != nil {
+			s.atlas.Render(snapshot, backBuffer)
+			renderPhaseAtlas.Since(stageStart)
+		} else {
+			s.renderFrameFromSnapshot(snapshot, backBuffer, backContext) // Times its own stages
+		}
+	}
+	stageStart = time.Now()
+
+	// Send front buffer to FFmpeg (the one rendered last frame),
+	// downsampled when rendering below output size
+	frame := frontBuffer
+	if scaler != nil {
+		scaler.Scale(frontBuffer, scaledFrame)
+		frame = scaledFrame
+		stageStart = renderPhaseScale.Since(stageStart)
+	}
 
-	// Send front buffer to FFmpeg (the one rendered last frame)
 	// If async writer is available, use ring buffer; otherwise direct write
 	if s.asyncWriter != nil && s.asyncWriter.IsRunning() {
 		// Non-blocking write to ring buffer
-		if !s.frameRingBuffer.TryWrite(frontBuffer) {
+		if !ringBuffer.TryWrite(frame) {
 			atomic.AddInt64(&s.framesDropped, 1)
 		}
 	} else {
@@ -997,7 +1420,7 @@ func (s *StreamManager) renderAndSendFrame() {
 		s.mu.RUnlock()
 
 		if streaming && pipe != nil {
-			_, err := pipe.Write(frontBuffer)
+			_, err := pipe.Write(frame)
 			if err != nil {
 				s.mu.Lock()
 				s.errors = append(s.errors, err.Error())
@@ -1008,10 +1431,24 @@ func (s *StreamManager) renderAndSendFrame() {
 			} else {
 				// Atomic increment preferred for potential race
 				atomic.AddInt64(&s.framesSent, 1)
+				s.avClock.VideoFrameWritten()
 			}
 		}
 	}
 
+	stageStart = renderPhaseWrite.Since(stageStart)
+
+	// Portrait frame from the same snapshot (dropped, not queued, when FFmpeg lags)
+	if portraitWriter != nil && portraitWriter.IsRunning() && render {
+		s.portrait.Render(snapshot, s.portraitFrame)
+		s.portraitRing.TryWrite(s.portraitFrame)
+		renderPhasePortrait.Since(stageStart)
+	}
+
+	if !render {
+		return // Front buffer stays the last rendered frame
+	}
+
 	// Swap buffers: back becomes front for next frame
 	s.doubleBuffer.mu.Lock()
 	s.doubleBuffer.activeIndex = backIndex
@@ -1022,67 +1459,69 @@ func (s *StreamManager) renderAndSendFrame() {
 	atomic.AddInt64(&s.frameTimeAccum, frameTime)
 	atomic.AddInt64(&s.frameTimeCount, 1)
 	s.lastFrameTime = time.Now()
+
+	// Admin panel thumbnail (only while someone is watching it)
+	s.preview.Capture(backBuffer, s.lastFrameTime)
+
+	if s.frameSkip != nil {
+		s.observeFrameTime(time.Duration(frameTime), s.lastFrameTime)
+	}
+	if onFrameRendered != nil {
+		onFrameRendered(time.Duration(frameTime))
+	}
 }
 
 // renderFrameFromSnapshot renders a frame using the lock-free game snapshot
 // This method uses immutable snapshot data and never blocks on game state
 func (s *StreamManager) renderFrameFromSnapshot(snap *game.GameSnapshot, buffer []byte, dc *gg.Context) {
-	// Background with white color
-	dc.SetColor(color.RGBA{250, 250, 255, 255}) // Soft white
-	dc.DrawRectangle(0, 0, float64(s.config.Width), float64(s.config.Height))
-	dc.Fill()
-
-	// Abstract galaxy constellation - connected stars (black on white)
-	// Generate deterministic star positions
-	type starPos struct {
-		x, y float64
-	}
-	stars := make([]starPos, 40)
-	for i := 0; i < 40; i++ {
-		stars[i] = starPos{
-			x: float64((i*67 + i*i*3) % s.config.Width),
-			y: float64((i*47 + i*i*2) % s.config.Height),
-		}
+	stageStart := time.Now()
+	if view := s.framer.View(); view.zoomed() {
+		dc.Push()
+		view.transform(dc)
+		s.drawArenaFromSnapshot(dc, snap, false)
+		dc.Pop()
+	} else {
+		s.drawArenaFromSnapshot(dc, snap, true)
 	}
+	stageStart = renderPhaseArena.Since(stageStart)
 
-	// Draw constellation lines connecting nearby stars (abstract network)
-	dc.SetColor(color.RGBA{30, 30, 40, 40}) // Very subtle dark lines
-	dc.SetLineWidth(1)
-	for i := 0; i < len(stars); i++ {
-		for j := i + 1; j < len(stars); j++ {
-			dx := stars[i].x - stars[j].x
-			dy := stars[i].y - stars[j].y
-			dist := dx*dx + dy*dy
-			// Connect stars within certain distance (creates network effect)
-			if dist < 40000 && dist > 5000 { // 200px radius, min 70px
-				dc.DrawLine(stars[i].x, stars[i].y, stars[j].x, stars[j].y)
-				dc.Stroke()
-			}
-		}
-	}
+	// UI from snapshot (leaderboard already sorted in snapshot)
+	s.drawUIFromSnapshot(dc, snap)
 
-	// Draw the stars/nodes themselves
-	for i, star := range stars {
-		// Vary star sizes for depth
-		size := 2.0
-		if i%3 == 0 {
-			size = 3.0
-			dc.SetColor(color.RGBA{20, 20, 30, 80}) // Darker larger stars
-		} else if i%5 == 0 {
-			size = 1.5
-			dc.SetColor(color.RGBA{40, 40, 50, 60}) // Medium stars
-		} else {
-			dc.SetColor(color.RGBA{60, 60, 70, 50}) // Subtle small stars
-		}
-		dc.DrawCircle(star.x, star.y, size)
-		dc.Fill()
+	// Arena minimap in the bottom-right corner (changes every frame, outside the UI cache)
+	if s.config.Theme.Minimap && !s.frameSkip.SkipsOverlays() {
+		s.drawMinimap(dc, snap)
 	}
+	stageStart = renderPhaseUI.Since(stageStart)
+
+	// Copy gg context to output buffer (fast direct copy)
+	s.imageToBufferFast(dc.Image(), buffer)
+	renderPhaseCopy.Since(stageStart)
+}
+
+// drawArenaFromSnapshot draws the arena in world coordinates: background,
+// world entities and arena-wide overlays (chaos, fog). pooledParticles renders
+// particles with the worker pool directly into the frame pixels, so it must be
+// false when dc is transformed (portrait frame).
+func (s *StreamManager) drawArenaFromSnapshot(dc *gg.Context, snap *game.GameSnapshot, pooledParticles bool) {
+	// Constellation background of the time of day (see scene_render.go)
+	s.drawSceneBackground(dc, snap.Scene)
+	s.drawPit(dc, snap.PitBand)
+
+	// Team bases, capture point, duel ring, meteor and airstrike markers and dropped coins and weapons under the players
+	s.drawObjectiveGround(dc, snap.Objective)
+	s.drawDuelRing(dc, snap.Duel)
+	s.drawChaosGround(dc, snap.Chaos)
+	s.drawShops(dc, snap.Shops)
+	s.drawAirstrikes(dc, snap.Airstrikes)
+	s.drawLoot(dc, snap.Loot)
+	s.drawWeaponDrops(dc, snap.WeaponDrops)
 
 	// Players from snapshot (immutable, no lock needed)
-	s.drawPlayersFromSnapshot(dc, snap.Players)
+	s.drawPlayersFromSnapshot(dc, snap.Players, game.ModifierPlayerScale(snap.Vote.Modifier))
 
 	// PARALLEL RENDER: Particles using worker pool
-	if len(snap.Particles) > 0 && s.workerPool != nil {
+	if len(snap.Particles) > 0 && s.workerPool != nil && pooledParticles {
 		img := dc.Image()
 		if nrgba, ok := img.(*image.NRGBA); ok {
 			s.workerPool.RenderParticlesSnapshotParallel(snap.Particles, nrgba.Pix, s.config.Width, s.config.Height)
@@ -1098,7 +1537,7 @@ func (s *StreamManager) renderFrameFromSnapshot(snap *game.GameSnapshot, buffer
 
 	// NEW: Weapon trails from snapshot
 	if len(snap.Trails) > 0 {
-		s.drawTrailsFromSnapshot(dc, snap.Trails)
+		s.drawTrailsFromSnapshot(dc, snap.Trails, snap.Players)
 	}
 
 	// NEW: Impact flashes from snapshot
@@ -1116,6 +1555,9 @@ func (s *StreamManager) renderFrameFromSnapshot(snap *game.GameSnapshot, buffer
 		s.drawTextsFromSnapshot(dc, snap.Texts)
 	}
 
+	// Chat bubbles on top of everything in the arena
+	s.drawChatBubbles(dc, snap.Players)
+
 	// Apply screen shake by offsetting final copy (if any)
 	// Note: shake is visual only, applied after all drawing
 	shakeX := snap.Shake.OffsetX
@@ -1123,11 +1565,16 @@ func (s *StreamManager) renderFrameFromSnapshot(snap *game.GameSnapshot, buffer
 	_ = shakeX // For now shake is embedded in snapshot but not applied visually
 	_ = shakeY // Could add dc.Translate if we want camera shake effect
 
-	// UI from snapshot (leaderboard already sorted in snapshot)
-	s.drawUIFromSnapshot(dc, snap)
+	// Falling meteors and the shrinking zone
+	s.drawChaosOverlay(dc, snap.Chaos)
 
-	// Copy gg context to output buffer (fast direct copy)
-	s.imageToBufferFast(dc.Image(), buffer)
+	// Rain or snow
+	s.drawWeatherOverlay(dc, snap)
+
+	// Fog arena modifier hides the edges of the arena
+	if snap.Vote.Modifier == game.ModFog {
+		s.drawFogOverlay(dc)
+	}
 }
 
 func (s *StreamManager) renderFrameToBuffer(state game.GameState, buffer []byte, dc *gg.Context) {
@@ -1331,7 +1778,7 @@ func (s *StreamManager) drawPlayer(dc *gg.Context, p *game.Player) {
 
 	// Name - dark color for visibility on white background
 	dc.SetColor(color.RGBA{20, 25, 35, 255}) // Dark charcoal
-	if err := dc.LoadFontFace(getFontPath(), 16); err == nil {
+	if err := s.loadFontFace(dc, 16); err == nil {
 		dc.DrawStringAnchored(p.Name, p.X, p.Y+50, 0.5, 0.5)
 	}
 
@@ -1369,7 +1816,7 @@ func (s *StreamManager) drawParticles(dc *gg.Context, particles []*game.Particle
 		c := parseHexColor(p.Color)
 		c.A = uint8(p.Alpha * 255)
 		dc.SetColor(c)
-		dc.DrawCircle(p.X, p.Y, 2)
+		dc.DrawCircle(p.X, p.Y, particleRadius(p.Size))
 		dc.Fill()
 	}
 }
@@ -1402,26 +1849,23 @@ func (s *StreamManager) drawTexts(dc *gg.Context, texts []*game.FloatingText) {
 }
 
 func (s *StreamManager) drawUI(dc *gg.Context, state game.GameState) {
-	// Load font
-	fontPath := getFontPath()
-
 	// Title
-	if err := dc.LoadFontFace(fontPath, 48); err == nil {
+	if err := s.loadFontFace(dc, 48); err == nil {
 		dc.SetColor(color.RGBA{255, 62, 62, 255})
 		dc.DrawString("THE FIGHT CLUB", 30, 60)
 	}
 
 	// Channel name
-	if err := dc.LoadFontFace(fontPath, 24); err == nil {
+	if err := s.loadFontFace(dc, 24); err == nil {
 		dc.SetColor(color.RGBA{255, 107, 107, 255})
 		dc.DrawString("NoRulesIRL", 30, 100)
 	}
 
 	// Leaderboard
-	s.drawLeaderboard(dc, state.Players, fontPath)
+	s.drawLeaderboard(dc, state.Players)
 }
 
-func (s *StreamManager) drawLeaderboard(dc *gg.Context, players []*game.Player, fontPath string) {
+func (s *StreamManager) drawLeaderboard(dc *gg.Context, players []*game.Player) {
 	if len(players) == 0 {
 		return
 	}
@@ -1450,14 +1894,14 @@ func (s *StreamManager) drawLeaderboard(dc *gg.Context, players []*game.Player,
 	dc.Fill()
 
 	// Header
-	if err := dc.LoadFontFace(fontPath, 20); err == nil {
+	if err := s.loadFontFace(dc, 20); err == nil {
 		dc.SetColor(color.RGBA{83, 255, 69, 255})
 		dc.DrawString("🏆 TOP KILLERS", x, y)
 	}
 	y += 40
 
 	// Players
-	if err := dc.LoadFontFace(fontPath, 18); err == nil {
+	if err := s.loadFontFace(dc, 18); err == nil {
 		for i := 0; i < limit; i++ {
 			p := sorted[i]
 
@@ -1522,30 +1966,6 @@ func parseHexColor(hex string) color.RGBA {
 	return color.RGBA{r, g, b, 255}
 }
 
-func getFontPath() string {
-	// Try common font locations
-	paths := []string{
-		"C:\\Windows\\Fonts\\arial.ttf",
-		"C:\\Windows\\Fonts\\segoeui.ttf",
-		"/usr/share/fonts/truetype/dejavu/DejaVuSans.ttf",
-		"/System/Library/Fonts/Helvetica.ttc",
-	}
-
-	for _, p := range paths {
-		if _, err := os.Stat(p); err == nil {
-			return p
-		}
-	}
-
-	// Try to find any ttf in current directory
-	matches, _ := filepath.Glob("*.ttf")
-	if len(matches) > 0 {
-		return matches[0]
-	}
-
-	return ""
-}
-
 // =============================================================================
 // SNAPSHOT-BASED DRAWING METHODS
 // These methods use immutable GameSnapshot types instead of pointer types
@@ -1553,25 +1973,36 @@ func getFontPath() string {
 // =============================================================================
 
 // drawPlayersFromSnapshot draws players from an immutable snapshot
-func (s *StreamManager) drawPlayersFromSnapshot(dc *gg.Context, players []game.PlayerSnapshot) {
+// sizeScale shrinks bodies under the tiny players arena modifier
+func (s *StreamManager) drawPlayersFromSnapshot(dc *gg.Context, players []game.PlayerSnapshot, sizeScale float64) {
 	for _, p := range players {
 		if p.IsRagdoll {
-			s.drawRagdollPlayerSnapshot(dc, p)
-		} else if !p.IsDead {
-			s.drawPlayerSnapshot(dc, p)
+			s.drawRagdollPlayerSnapshot(dc, p, sizeScale)
+		} else if p.IsDead {
+			continue
+		} else if s.lod.Full(&p) {
+			s.drawPlayerSnapshot(dc, p, sizeScale)
+		} else {
+			s.drawPlayerDot(dc, p, sizeScale)
 		}
 	}
 }
 
 // drawPlayerSnapshot draws a single player from snapshot data
-func (s *StreamManager) drawPlayerSnapshot(dc *gg.Context, p game.PlayerSnapshot) {
-	radius := 30.0
+func (s *StreamManager) drawPlayerSnapshot(dc *gg.Context, p game.PlayerSnapshot, sizeScale float64) {
+	radius := 30.0 * sizeScale
 
 	// Shadow
 	dc.SetColor(color.RGBA{0, 0, 0, 128})
 	dc.DrawCircle(p.X, p.Y+8, radius)
 	dc.Fill()
 
+	// Emote animation: jump lifts the body off its shadow (p is a copy)
+	emote, hasEmote := game.GetEmote(p.Emote)
+	if hasEmote && emote.Animation == game.EmoteAnimJump {
+		p.Y -= math.Sin(p.EmoteProgress*math.Pi) * 25
+	}
+
 	// Spawn protection glow
 	if p.SpawnProtection {
 		dc.SetColor(color.RGBA{255, 255, 255, 77})
@@ -1585,6 +2016,18 @@ func (s *StreamManager) drawPlayerSnapshot(dc *gg.Context, p game.PlayerSnapshot
 		s.drawWeaponAttack(dc, p, anim)
 	}
 
+	// Emote animation: spin/flex transform the body only
+	dc.Push()
+	if hasEmote {
+		switch emote.Animation {
+		case game.EmoteAnimSpin:
+			dc.RotateAbout(p.EmoteProgress*2*math.Pi, p.X, p.Y)
+		case game.EmoteAnimFlex:
+			pulse := 1.0 + 0.15*math.Sin(p.EmoteProgress*3*math.Pi)
+			dc.ScaleAbout(pulse, pulse, p.X, p.Y)
+		}
+	}
+
 	// Try to draw profile picture if available
 	avatarDrawn := false
 	if p.ProfilePic != "" && s.avatarCache != nil {
@@ -1619,6 +2062,23 @@ func (s *StreamManager) drawPlayerSnapshot(dc *gg.Context, p game.PlayerSnapshot
 	dc.SetLineWidth(4)
 	dc.DrawCircle(p.X, p.Y, radius)
 	dc.Stroke()
+	s.drawSkinBorder(dc, p, radius)
+	dc.Pop()
+
+	// Spectator effects: green ring when cheered, purple when cursed
+	if p.IsCheered {
+		dc.SetColor(color.RGBA{83, 255, 69, 200})
+		dc.SetLineWidth(3)
+		dc.DrawCircle(p.X, p.Y, radius+8)
+		dc.Stroke()
+	}
+	if p.IsCursed {
+		dc.SetColor(color.RGBA{168, 85, 247, 200})
+		dc.SetLineWidth(3)
+		dc.DrawCircle(p.X, p.Y, radius+13)
+		dc.Stroke()
+	}
+	s.drawTeamOrders(dc, &p, radius)
 
 	// Health bar
 	hpBarWidth := 80.0
@@ -1641,23 +2101,58 @@ func (s *StreamManager) drawPlayerSnapshot(dc *gg.Context, p game.PlayerSnapshot
 	dc.DrawRectangle(p.X-hpBarWidth/2, p.Y-50, hpBarWidth*hpPercent, hpBarHeight)
 	dc.Fill()
 
+	// Stamina bar under the health bar
+	dc.SetColor(color.RGBA{51, 51, 51, 255})
+	dc.DrawRectangle(p.X-hpBarWidth/2, p.Y-50+hpBarHeight+2, hpBarWidth, staminaBarHeight)
+	dc.Fill()
+	dc.SetColor(staminaBarColor(&p))
+	dc.DrawRectangle(p.X-hpBarWidth/2, p.Y-50+hpBarHeight+2, hpBarWidth*staminaPercent(&p), staminaBarHeight)
+	dc.Fill()
+
 	// Name - use cached font if available (dark color for visibility on white bg)
 	dc.SetColor(color.RGBA{20, 25, 35, 255}) // Dark charcoal for good contrast
 	if s.fontsLoaded && s.fontSmall != nil {
 		dc.SetFontFace(s.fontSmall)
-		dc.DrawStringAnchored(p.Name, p.X, p.Y+50, 0.5, 0.5)
-	} else if err := dc.LoadFontFace(getFontPath(), 16); err == nil {
-		dc.DrawStringAnchored(p.Name, p.X, p.Y+50, 0.5, 0.5)
+		drawNameplate(dc, p, p.X, p.Y+50)
+	} else if err := s.loadFontFace(dc, 16); err == nil {
+		drawNameplate(dc, p, p.X, p.Y+50)
+	}
+
+	// Seasonal rank badge left of the name
+	if rank, ok := game.GetRank(p.Rank); ok {
+		nameWidth, _ := dc.MeasureString(p.Name)
+		s.drawRankBadge(dc, rank, p.X-nameWidth/2-rankBadgeRadius-4, p.Y+50)
 	}
 
 	// Money - orange for better contrast on white background
 	dc.SetColor(color.RGBA{255, 120, 0, 255}) // Vibrant orange
 	dc.DrawStringAnchored(fmt.Sprintf("$%d", p.Money), p.X, p.Y+70, 0.5, 0.5)
+
+	// AI personality label (set via !style)
+	if p.Personality != "" {
+		style := game.GetPersonality(p.Personality)
+		dc.SetColor(parseHexColor(style.Color))
+		dc.DrawStringAnchored(style.Name, p.X, p.Y+88, 0.5, 0.5)
+	}
+
+	// Floating emote emoji - rises above the health bar and fades out
+	if hasEmote {
+		rise := p.EmoteProgress * 30
+		dc.SetColor(color.RGBA{20, 25, 35, uint8((1 - p.EmoteProgress) * 255)})
+		if s.fontsLoaded && s.fontMedium != nil {
+			dc.SetFontFace(s.fontMedium)
+		}
+		dc.DrawStringAnchored(emote.Emoji, p.X, p.Y-70-rise, 0.5, 0.5)
+	}
 }
 
 // drawRagdollPlayerSnapshot draws a ragdoll player from snapshot data
-func (s *StreamManager) drawRagdollPlayerSnapshot(dc *gg.Context, p game.PlayerSnapshot) {
-	radius := 30.0
+func (s *StreamManager) drawRagdollPlayerSnapshot(dc *gg.Context, p game.PlayerSnapshot, sizeScale float64) {
+	scale, opacity := fallScale(&p)
+	if opacity <= 0 {
+		return // Fell out of sight
+	}
+	radius := 30.0 * sizeScale * scale
 
 	dc.Push()
 	dc.RotateAbout(p.RagdollRotation, p.X, p.Y)
@@ -1679,7 +2174,7 @@ func (s *StreamManager) drawRagdollPlayerSnapshot(dc *gg.Context, p game.PlayerS
 			dc.RotateAbout(p.RagdollRotation, p.X, p.Y)
 
 			// Draw semi-transparent dark overlay to fade the image
-			dc.SetColor(color.RGBA{0, 0, 0, 100})
+			dc.SetColor(color.RGBA{0, 0, 0, uint8(255 - 155*opacity)})
 			dc.DrawCircle(p.X, p.Y, radius)
 			dc.Fill()
 			avatarDrawn = true
@@ -1689,18 +2184,19 @@ func (s *StreamManager) drawRagdollPlayerSnapshot(dc *gg.Context, p game.PlayerS
 	// Fallback to faded colored body
 	if !avatarDrawn {
 		c := parseHexColor(p.Color)
-		c.A = 153
+		c.A = uint8(153 * opacity)
 		dc.SetColor(c)
 		dc.DrawCircle(p.X, p.Y, radius)
 		dc.Fill()
 	}
 
 	// X for dead
-	dc.SetColor(color.RGBA{255, 0, 0, 255})
-	dc.SetLineWidth(4)
-	dc.DrawLine(p.X-10, p.Y-10, p.X+10, p.Y+10)
+	dc.SetColor(color.RGBA{255, 0, 0, uint8(255 * opacity)})
+	dc.SetLineWidth(4 * scale)
+	arm := 10 * scale
+	dc.DrawLine(p.X-arm, p.Y-arm, p.X+arm, p.Y+arm)
 	dc.Stroke()
-	dc.DrawLine(p.X+10, p.Y-10, p.X-10, p.Y+10)
+	dc.DrawLine(p.X+arm, p.Y-arm, p.X-arm, p.Y+arm)
 	dc.Stroke()
 
 	dc.Pop()
@@ -1712,7 +2208,7 @@ func (s *StreamManager) drawParticlesFromSnapshot(dc *gg.Context, particles []ga
 		c := parseHexColor(p.Color)
 		c.A = uint8(p.Alpha * 255)
 		dc.SetColor(c)
-		dc.DrawCircle(p.X, p.Y, 2)
+		dc.DrawCircle(p.X, p.Y, particleRadius(p.Size))
 		dc.Fill()
 	}
 }
@@ -1747,36 +2243,11 @@ func (s *StreamManager) drawTextsFromSnapshot(dc *gg.Context, texts []game.TextS
 }
 
 // drawTrailsFromSnapshot draws weapon trails from snapshot data
-func (s *StreamManager) drawTrailsFromSnapshot(dc *gg.Context, trails []game.TrailSnapshot) {
-	for _, tr := range trails {
-		if tr.Count < 2 {
-			continue // Need at least 2 points for a line
-		}
-
-		c := parseHexColor(tr.Color)
-
-		// Draw connected line segments with fading alpha
-		for i := 0; i < tr.Count-1; i++ {
-			p1 := tr.Points[i]
-			p2 := tr.Points[i+1]
-
-			// Alpha fades from oldest to newest
-			alpha := uint8(p2.Alpha * tr.Alpha * 255)
-			c.A = alpha
-
-			dc.SetColor(c)
-			dc.SetLineWidth(3 + float64(i)) // Lines get thicker towards tip
-			dc.MoveTo(p1.X, p1.Y)
-			dc.LineTo(p2.X, p2.Y)
-			dc.Stroke()
-		}
-
-		// Add glow at the tip (newest point)
-		tipPoint := tr.Points[tr.Count-1]
-		c.A = uint8(tr.Alpha * 200)
-		dc.SetColor(c)
-		dc.DrawCircle(tipPoint.X, tipPoint.Y, 5)
-		dc.Fill()
+// Rasterized directly into the context's pixels by the TrailRenderer
+// (smoothed, tapered, anti-aliased with glow, styled per weapon)
+func (s *StreamManager) drawTrailsFromSnapshot(dc *gg.Context, trails []game.TrailSnapshot, players []game.PlayerSnapshot) {
+	if rgba, ok := dc.Image().(*image.RGBA); ok && s.trails != nil {
+		s.trails.Render(rgba.Pix, trails, players)
 	}
 }
 
@@ -1856,10 +2327,68 @@ func (s *StreamManager) drawUIFromSnapshot(dc *gg.Context, snap *game.GameSnapsh
 	marginTop := 24.0
 
 	// === PLAY NOW - DARK FLOATING CARD ===
-	cardX := marginLeft
 	cardY := marginTop
-	cardWidth := 380.0
-	cardHeight := 88.0
+	s.drawPlayNowCard(dc, marginLeft, cardY)
+
+	// === PLAYER COUNT BADGE - Minimal competitive style ===
+	badgeX := float64(s.config.Width) - liveBadgeWidth - marginLeft
+	badgeY := marginTop
+	s.drawLiveBadge(dc, snap.AliveCount, badgeX, badgeY)
+
+	// === VIEWER COUNT - Left of the LIVE badge (hidden while unknown/offline) ===
+	if snap.ViewerCount > 0 {
+		s.drawViewerBadge(dc, snap.ViewerCount, badgeX-8, badgeY, liveBadgeHeight)
+	}
+
+	// === LEADERBOARD - Clean minimal design ===
+	leaderboardX := marginLeft
+	leaderboardY := cardY + playNowCardHeight + 28.0
+	s.drawSessionLeaderboard(dc, snap, leaderboardX, leaderboardY)
+
+	// === PERSISTENT LEADERBOARD - Rotates Today / This Week / All Time ===
+	// Fixed position below a full session leaderboard (header + 5 entries)
+	s.drawLeaderboardRotator(dc, snap.Leaderboard, leaderboardX, leaderboardY+leaderboardHeight+22)
+
+	// === ARENA VOTE / MODIFIER - Top center (REPLAY card during a slow-motion replay) ===
+	if snap.Replaying {
+		s.drawReplayBanner(dc, marginTop)
+	} else {
+		s.drawVoteOverlay(dc, snap.Vote, marginTop)
+	}
+
+	// === DUEL - Names and countdown above the ring (where the camera shows it) ===
+	s.drawDuelBanner(dc, s.framer.View().duel(snap.Duel))
+
+	// === TEAM OBJECTIVE - Scores above the chaos banner ===
+	s.drawObjectiveBanner(dc, snap.Objective)
+
+	// === CHAOS EVENT - Warning and countdown, bottom center ===
+	s.drawChaosBanner(dc, snap.Chaos)
+
+	// === QUEST COMPLETE - Toast below the LIVE badge ===
+	s.drawQuestToast(dc, snap.QuestToast, badgeX+liveBadgeWidth, badgeY+liveBadgeHeight+12)
+
+	// === UP NEXT - Join queue below the quest toast slot ===
+	s.drawJoinQueue(dc, snap.JoinQueue, badgeX+liveBadgeWidth, badgeY+liveBadgeHeight+76, true)
+
+	// === PAUSED - Dims the frozen arena ===
+	if snap.Paused {
+		s.drawPauseOverlay(dc)
+	}
+}
+
+// HUD card sizes, shared by the landscape and portrait layouts
+const (
+	playNowCardWidth  = 380.0
+	playNowCardHeight = 88.0
+	liveBadgeWidth    = 130.0
+	liveBadgeHeight   = 36.0
+)
+
+// drawPlayNowCard draws the dark "PLAY NOW" call-to-action card at (cardX, cardY)
+func (s *StreamManager) drawPlayNowCard(dc *gg.Context, cardX, cardY float64) {
+	cardWidth := playNowCardWidth
+	cardHeight := playNowCardHeight
 	cardRadius := 6.0
 
 	// Shadow layer (soft depth effect)
@@ -1884,7 +2413,7 @@ func (s *StreamManager) drawUIFromSnapshot(dc *gg.Context, snap *game.GameSnapsh
 	if s.fontsLoaded && s.fontLarge != nil {
 		dc.SetFontFace(s.fontLarge)
 	} else {
-		_ = dc.LoadFontFace(getFontPath(), 32)
+		_ = s.loadFontFace(dc, 32)
 	}
 
 	// Cyan glow effect (subtle)
@@ -1900,16 +2429,16 @@ func (s *StreamManager) drawUIFromSnapshot(dc *gg.Context, snap *game.GameSnapsh
 	if s.fontsLoaded && s.fontSmall != nil {
 		dc.SetFontFace(s.fontSmall)
 	} else {
-		_ = dc.LoadFontFace(getFontPath(), 13)
+		_ = s.loadFontFace(dc, 13)
 	}
 	dc.SetColor(color.RGBA{160, 165, 180, 255}) // Soft gray for subtitles
 	dc.DrawString("Type !join in chat to enter the arena", titleX, subtitleY)
+}
 
-	// === PLAYER COUNT BADGE - Minimal competitive style ===
-	badgeHeight := 36.0
-	badgeWidth := 130.0
-	badgeX := float64(s.config.Width) - badgeWidth - marginLeft
-	badgeY := marginTop
+// drawLiveBadge draws the "N LIVE" player count badge at (badgeX, badgeY)
+func (s *StreamManager) drawLiveBadge(dc *gg.Context, alive int, badgeX, badgeY float64) {
+	badgeHeight := liveBadgeHeight
+	badgeWidth := liveBadgeWidth
 
 	// Badge shadow
 	dc.SetColor(color.RGBA{0, 0, 0, 20})
@@ -1932,66 +2461,189 @@ func (s *StreamManager) drawUIFromSnapshot(dc *gg.Context, snap *game.GameSnapsh
 	if s.fontsLoaded && s.fontSmall != nil {
 		dc.SetFontFace(s.fontSmall)
 	}
-	aliveText := fmt.Sprintf("%d LIVE", snap.AliveCount)
+	aliveText := fmt.Sprintf("%d LIVE", alive)
 	dc.SetColor(color.RGBA{255, 255, 255, 255})
 	dc.DrawString(aliveText, dotX+14, badgeY+badgeHeight/2+5)
+}
 
-	// === LEADERBOARD - Clean minimal design ===
-	leaderboardX := marginLeft
-	leaderboardY := cardY + cardHeight + 28.0
-	s.drawLeaderboardFuturistic(dc, snap.Players, leaderboardX, leaderboardY)
+// drawViewerBadge draws an "👁 1,243 watching" badge ending at rightX
+func (s *StreamManager) drawViewerBadge(dc *gg.Context, viewers int, rightX, y, height float64) {
+	text := formatThousands(viewers) + " watching"
+	textWidth, _ := dc.MeasureString(text)
+	width := textWidth + 44
+	x := rightX - width
+
+	dc.SetColor(color.RGBA{0, 0, 0, 20})
+	dc.DrawRoundedRectangle(x+2, y+2, width, height, 4)
+	dc.Fill()
+	dc.SetColor(color.RGBA{18, 18, 24, 240})
+	dc.DrawRoundedRectangle(x, y, width, height, 4)
+	dc.Fill()
+
+	// Eye icon (drawn - the UI fonts have no emoji glyphs)
+	eyeX, eyeY := x+18, y+height/2
+	dc.SetColor(color.RGBA{200, 205, 220, 255})
+	dc.DrawEllipse(eyeX, eyeY, 8, 5)
+	dc.SetLineWidth(1.5)
+	dc.Stroke()
+	dc.DrawCircle(eyeX, eyeY, 2.5)
+	dc.Fill()
+
+	dc.SetColor(color.RGBA{255, 255, 255, 255})
+	dc.DrawString(text, eyeX+14, y+height/2+5)
 }
 
-// drawLeaderboardFuturistic draws a clean, modern leaderboard
-func (s *StreamManager) drawLeaderboardFuturistic(dc *gg.Context, players []game.PlayerSnapshot, startX, startY float64) {
-	if len(players) == 0 {
-		return
+// formatThousands formats n with comma separators (1243 -> "1,243")
+func formatThousands(n int) string {
+	if n < 0 {
+		return "-" + formatThousands(-n)
+	}
+	digits := strconv.Itoa(n)
+	var b strings.Builder
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(d)
+	}
+	return b.String()
+}
+
+// drawPauseOverlay dims the arena and shows a PAUSED card while the broadcaster is away
+func (s *StreamManager) drawPauseOverlay(dc *gg.Context) {
+	w, h := float64(s.config.Width), float64(s.config.Height)
+	dc.SetColor(color.RGBA{0, 0, 0, 110})
+	dc.DrawRectangle(0, 0, w, h)
+	dc.Fill()
+
+	cardWidth := 320.0
+	cardHeight := 110.0
+	cardX := (w - cardWidth) / 2
+	cardY := (h - cardHeight) / 2
+
+	dc.SetColor(color.RGBA{18, 18, 24, 245})
+	dc.DrawRoundedRectangle(cardX, cardY, cardWidth, cardHeight, 6)
+	dc.Fill()
+	dc.SetColor(color.RGBA{0, 212, 255, 255})
+	dc.DrawRoundedRectangle(cardX, cardY, cardWidth, 3, 2)
+	dc.Fill()
+
+	if s.fontsLoaded && s.fontLarge != nil {
+		dc.SetFontFace(s.fontLarge)
+	} else {
+		_ = s.loadFontFace(dc, 32)
+	}
+	dc.SetColor(color.RGBA{255, 255, 255, 255})
+	dc.DrawStringAnchored("PAUSED", w/2, cardY+44, 0.5, 0.35)
+
+	if s.fontsLoaded && s.fontSmall != nil {
+		dc.SetFontFace(s.fontSmall)
+	} else {
+		_ = s.loadFontFace(dc, 13)
 	}
+	dc.SetColor(color.RGBA{160, 165, 180, 255})
+	dc.DrawStringAnchored("The fight resumes shortly", w/2, cardY+80, 0.5, 0.35)
+}
 
-	x := startX
-	y := startY
-	entrySpacing := 26.0
+// drawFogOverlay draws a gray haze that thickens towards the arena edges
+func (s *StreamManager) drawFogOverlay(dc *gg.Context) {
+	w, h := float64(s.config.Width), float64(s.config.Height)
+	grad := gg.NewRadialGradient(w/2, h/2, h*0.2, w/2, h/2, w*0.6)
+	grad.AddColorStop(0, color.RGBA{200, 205, 215, 0})
+	grad.AddColorStop(1, color.RGBA{200, 205, 215, 230})
+	dc.SetFillStyle(grad)
+	dc.DrawRectangle(0, 0, w, h)
+	dc.Fill()
+}
 
-	limit := 5 // Show top 5 for cleaner look
-	if len(players) < limit {
-		limit = len(players)
+// drawVoteOverlay draws the open vote (options and tallies) or the active modifier countdown
+func (s *StreamManager) drawVoteOverlay(dc *gg.Context, vote game.VoteSnapshot, top float64) {
+	var lines []string
+	if vote.Voting {
+		title, option := "ARENA VOTE", "!vote %d  %s  (%d)"
+		if vote.Poll {
+			title, option = "ARENA POLL", "%d  %s  (%d)"
+		}
+		lines = append(lines, fmt.Sprintf("%s - %ds", title, int(math.Ceil(vote.Remaining))))
+		for i, id := range vote.Options {
+			if mod, ok := game.GetModifier(id); ok {
+				lines = append(lines, fmt.Sprintf(option, i+1, mod.Name, vote.Counts[i]))
+			}
+		}
+	} else if mod, ok := game.GetModifier(vote.Modifier); ok {
+		secs := int(math.Ceil(vote.Remaining))
+		lines = append(lines, fmt.Sprintf("%s  %d:%02d", strings.ToUpper(mod.Name), secs/60, secs%60))
+	} else {
+		return
 	}
 
-	// Header - subtle and clean
 	if s.fontsLoaded && s.fontSmall != nil {
 		dc.SetFontFace(s.fontSmall)
 	} else {
-		_ = dc.LoadFontFace(getFontPath(), 14)
+		_ = s.loadFontFace(dc, 13)
 	}
 
-	// Header with accent color
-	dc.SetColor(color.RGBA{0, 180, 220, 255}) // Cyan accent
-	dc.DrawString("TOP KILLERS", x, y)
-	y += 24.0
+	lineHeight := 22.0
+	panelWidth := 260.0
+	panelHeight := lineHeight*float64(len(lines)) + 16
+	panelX := (float64(s.config.Width) - panelWidth) / 2
+
+	// Dark card matching the PLAY NOW card
+	dc.SetColor(color.RGBA{0, 0, 0, 25})
+	dc.DrawRoundedRectangle(panelX+4, top+4, panelWidth, panelHeight, 6)
+	dc.Fill()
+	dc.SetColor(color.RGBA{18, 18, 24, 245})
+	dc.DrawRoundedRectangle(panelX, top, panelWidth, panelHeight, 6)
+	dc.Fill()
 
-	// Player entries
-	for i := 0; i < limit; i++ {
-		p := players[i]
-
-		// Rank colors - gold/silver/bronze for top 3, gray for rest
-		var rankColor color.RGBA
-		switch i {
-		case 0:
-			rankColor = color.RGBA{255, 200, 60, 255}  // Gold
-		case 1:
-			rankColor = color.RGBA{180, 185, 195, 255} // Silver
-		case 2:
-			rankColor = color.RGBA{205, 150, 90, 255}  // Bronze
-		default:
-			rankColor = color.RGBA{120, 125, 140, 255} // Gray
+	// Cyan accent line along the top edge
+	dc.SetColor(color.RGBA{0, 212, 255, 255})
+	dc.DrawRoundedRectangle(panelX, top, panelWidth, 3, 2)
+	dc.Fill()
+
+	for i, line := range lines {
+		dc.SetColor(color.RGBA{160, 165, 180, 255})
+		if i == 0 {
+			dc.SetColor(color.RGBA{0, 212, 255, 255}) // Header in cyan
 		}
+		dc.DrawStringAnchored(line, panelX+panelWidth/2, top+8+lineHeight*(float64(i)+0.5), 0.5, 0.35)
+	}
+}
+
+// drawLeaderboardFuturistic draws a clean, modern leaderboard of the top killers
+func (s *StreamManager) drawLeaderboardFuturistic(dc *gg.Context, players []game.PlayerSnapshot, startX, startY float64) {
+	s.drawLeaderboardPage(dc, players, layoutLeaderboard(LeaderboardTop, players, 0, 0), startX, startY)
+}
 
-		dc.SetColor(rankColor)
+// drawLeaderboardRotator draws the persistent leaderboard view currently in rotation
+func (s *StreamManager) drawLeaderboardRotator(dc *gg.Context, lb game.LeaderboardSnapshot, x, y float64) {
+	if lb.Period == "" {
+		return
+	}
 
-		// Clean format: "1. Name · kills"
-		text := fmt.Sprintf("%d. %s · %d", i+1, p.Name, p.Kills)
-		dc.DrawString(text, x, y)
-		y += entrySpacing
+	if s.fontsLoaded && s.fontSmall != nil {
+		dc.SetFontFace(s.fontSmall)
+	} else {
+		_ = s.loadFontFace(dc, 14)
+	}
+
+	dc.SetColor(color.RGBA{0, 180, 220, 255}) // Cyan accent, matches TOP KILLERS
+	dc.DrawString("BEST "+lb.Period.Title(), x, y)
+	y += 24.0
+
+	if lb.Count == 0 {
+		dc.SetColor(color.RGBA{120, 125, 140, 255})
+		dc.DrawString("No kills yet", x, y)
+		return
+	}
+
+	for i := 0; i < lb.Count; i++ {
+		dc.SetColor(color.RGBA{160, 165, 180, 255})
+		if i == 0 {
+			dc.SetColor(color.RGBA{255, 200, 60, 255}) // Gold for the leader
+		}
+		dc.DrawString(fmt.Sprintf("%d. %s · %d", i+1, lb.Names[i], lb.Kills[i]), x, y)
+		y += 26.0
 	}
 }
 
@@ -2021,10 +2673,7 @@ func (s *StreamManager) drawWeaponAttack(dc *gg.Context, p game.PlayerSnapshot,
 
 // drawArcSwing draws a curved weapon trail (sword, axe, scythe)
 func (s *StreamManager) drawArcSwing(dc *gg.Context, p game.PlayerSnapshot, anim game.WeaponAnimationConfig) {
-	trailColor := anim.TrailColor
-	if trailColor == "" {
-		trailColor = game.GetWeapon(p.Weapon).Color
-	}
+	trailColor := swingColor(&p, anim)
 	c := parseHexColor(trailColor)
 
 	// Multiple arc layers for thickness/motion blur
@@ -2047,10 +2696,7 @@ func (s *StreamManager) drawArcSwing(dc *gg.Context, p game.PlayerSnapshot, anim
 
 // drawThrustLine draws a straight weapon trail (spear, katana)
 func (s *StreamManager) drawThrustLine(dc *gg.Context, p game.PlayerSnapshot, anim game.WeaponAnimationConfig) {
-	trailColor := anim.TrailColor
-	if trailColor == "" {
-		trailColor = game.GetWeapon(p.Weapon).Color
-	}
+	trailColor := swingColor(&p, anim)
 	c := parseHexColor(trailColor)
 	c.A = 200
 	dc.SetColor(c)
@@ -2073,10 +2719,7 @@ func (s *StreamManager) drawThrustLine(dc *gg.Context, p game.PlayerSnapshot, an
 
 // drawRadialBurst draws a 360 burst (fists, hammer)
 func (s *StreamManager) drawRadialBurst(dc *gg.Context, p game.PlayerSnapshot, anim game.WeaponAnimationConfig) {
-	trailColor := anim.TrailColor
-	if trailColor == "" {
-		trailColor = game.GetWeapon(p.Weapon).Color
-	}
+	trailColor := swingColor(&p, anim)
 	c := parseHexColor(trailColor)
 
 	range_ := game.GetWeapon(p.Weapon).Range
@@ -34,3 +34,13 @@ func TestStreamConfig(t *testing.T) {
 		t.Error("Bitrate should be 4500")
 	}
 }
+
+// TestFormatThousands tests the viewer count formatting
+func TestFormatThousands(t *testing.T) {
+	cases := map[int]string{0: "0", 999: "999", 1243: "1,243", 1000000: "1,000,000", -4500: "-4,500"}
+	for n, want := range cases {
+		if got := formatThousands(n); got != want {
+			t.Errorf("formatThousands(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package streaming
+
+import (
+	"image/color"
+	"math"
+
+	"fight-club/internal/game"
+
+	"github.com/fogleman/gg"
+)
+
+// Team order indicators (see game.TeamRally / game.TeamRegroup)
+const (
+	rallyRingGap     = 18.0 // Rally ring offset from the body (outside the spectator rings)
+	rallySpikes      = 8    // Spikes around the rally ring
+	rallySpikeLength = 7.0
+	regroupArrowGap  = 24.0 // Regroup arrow tip offset from the body
+	regroupArrowSize = 9.0
+)
+
+// rallyFallbackColor marks rallied fighters without a team color
+var rallyFallbackColor = color.RGBA{255, 159, 67, 255}
+
+// rallyColor returns the fighter's team color for the rally aura
+func rallyColor(p *game.PlayerSnapshot) color.RGBA {
+	if p.TeamColor == "" {
+		return rallyFallbackColor
+	}
+	return teamColorRGBA(p.TeamColor)
+}
+
+// regroupArrow returns the tip and the two back corners of the arrow pointing
+// from the fighter (at x, y) toward the regroup goal. ok is false when the
+// fighter stands on the goal.
+func regroupArrow(p *game.PlayerSnapshot, x, y, radius float64) (tip, left, right [2]float64, ok bool) {
+	dx, dy := p.RegroupX-x, p.RegroupY-y
+	dist := math.Hypot(dx, dy)
+	if dist < 1 {
+		return tip, left, right, false
+	}
+	dx, dy = dx/dist, dy/dist
+
+	tipDist := radius + regroupArrowGap
+	baseDist := tipDist - regroupArrowSize*1.5
+	tip = [2]float64{x + dx*tipDist, y + dy*tipDist}
+	left = [2]float64{x + dx*baseDist - dy*regroupArrowSize, y + dy*baseDist + dx*regroupArrowSize}
+	right = [2]float64{x + dx*baseDist + dy*regroupArrowSize, y + dy*baseDist - dx*regroupArrowSize}
+	return tip, left, right, true
+}
+
+// drawTeamOrders draws the rally aura and regroup arrow (gg renderer)
+func (s *StreamManager) drawTeamOrders(dc *gg.Context, p *game.PlayerSnapshot, radius float64) {
+	if p.IsRallied {
+		c := rallyColor(p)
+		dc.SetColor(color.NRGBA{c.R, c.G, c.B, 220})
+		dc.SetLineWidth(3)
+		ring := radius + rallyRingGap
+		dc.DrawCircle(p.X, p.Y, ring)
+		dc.Stroke()
+		for i := 0; i < rallySpikes; i++ {
+			angle := float64(i) * 2 * math.Pi / rallySpikes
+			cos, sin := math.Cos(angle), math.Sin(angle)
+			dc.DrawLine(p.X+cos*ring, p.Y+sin*ring, p.X+cos*(ring+rallySpikeLength), p.Y+sin*(ring+rallySpikeLength))
+		}
+		dc.Stroke()
+	}
+
+	if p.IsRegrouping {
+		if tip, left, right, ok := regroupArrow(p, p.X, p.Y, radius); ok {
+			dc.SetColor(color.NRGBA{72, 219, 251, 230})
+			dc.MoveTo(tip[0], tip[1])
+			dc.LineTo(left[0], left[1])
+			dc.LineTo(right[0], right[1])
+			dc.ClosePath()
+			dc.Fill()
+		}
+	}
+}
+
+// drawTeamOrders draws the rally aura and regroup arrow (atlas renderer)
+func (a *AtlasRenderer) drawTeamOrders(p *game.PlayerSnapshot, y, radius float64) {
+	if p.IsRallied {
+		c := rallyColor(p)
+		ring := radius + rallyRingGap
+		a.fr.DrawCircleOutline(int(p.X), int(y), ring, 3, c)
+		for i := 0; i < rallySpikes; i++ {
+			angle := float64(i) * 2 * math.Pi / rallySpikes
+			cos, sin := math.Cos(angle), math.Sin(angle)
+			a.fr.DrawThickLine(int(p.X+cos*ring), int(y+sin*ring),
+				int(p.X+cos*(ring+rallySpikeLength)), int(y+sin*(ring+rallySpikeLength)), 3, c)
+		}
+	}
+
+	if p.IsRegrouping {
+		if tip, left, right, ok := regroupArrow(p, p.X, y, radius); ok {
+			c := color.RGBA{72, 219, 251, 255}
+			a.fr.DrawThickLine(int(left[0]), int(left[1]), int(tip[0]), int(tip[1]), 3, c)
+			a.fr.DrawThickLine(int(right[0]), int(right[1]), int(tip[0]), int(tip[1]), 3, c)
+			a.fr.DrawThickLine(int(left[0]), int(left[1]), int(right[0]), int(right[1]), 2, c)
+		}
+	}
+}
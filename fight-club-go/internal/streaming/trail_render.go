@@ -0,0 +1,297 @@
+package streaming
+
+import (
+	"math"
+
+	"fight-club/internal/game"
+)
+
+// TrailStyle is the per-weapon look of a swing trail
+type TrailStyle struct {
+	Width     float64 // Core width at the tip (px)
+	TailWidth float64 // Core width at the oldest point (taper)
+	Glow      float64 // Glow falloff distance beyond the core (px)
+	GlowAlpha float64 // Peak glow opacity (0-1)
+	HotCore   float64 // Blend of the core towards white (0 = weapon color, 1 = white)
+}
+
+// defaultTrailStyle is used for unknown weapons
+var defaultTrailStyle = TrailStyle{Width: 5, TailWidth: 1, Glow: 6, GlowAlpha: 0.35, HotCore: 0.3}
+
+// trailStyles maps weapon IDs to trail styles (see game.DefaultWeaponAnimations)
+var trailStyles = map[string]TrailStyle{
+	"fists":  {Width: 4, TailWidth: 1, Glow: 4, GlowAlpha: 0.25, HotCore: 0.2},
+	"knife":  {Width: 3, TailWidth: 0.5, Glow: 5, GlowAlpha: 0.4, HotCore: 0.6},
+	"sword":  {Width: 6, TailWidth: 1, Glow: 8, GlowAlpha: 0.4, HotCore: 0.5},
+	"spear":  {Width: 4, TailWidth: 2, Glow: 6, GlowAlpha: 0.3, HotCore: 0.4},
+	"axe":    {Width: 9, TailWidth: 2, Glow: 7, GlowAlpha: 0.35, HotCore: 0.2},
+	"bow":    {Width: 2, TailWidth: 1, Glow: 4, GlowAlpha: 0.3, HotCore: 0.5},
+	"scythe": {Width: 8, TailWidth: 0.5, Glow: 12, GlowAlpha: 0.5, HotCore: 0.4},
+	"katana": {Width: 4, TailWidth: 0.5, Glow: 9, GlowAlpha: 0.5, HotCore: 0.8},
+	"hammer": {Width: 12, TailWidth: 4, Glow: 8, GlowAlpha: 0.3, HotCore: 0.1},
+}
+
+// trailStyleFor returns the trail style for a weapon
+func trailStyleFor(weapon string) TrailStyle {
+	if style, ok := trailStyles[weapon]; ok {
+		return style
+	}
+	return defaultTrailStyle
+}
+
+// trailSubdivisions is the max number of Catmull-Rom steps per trail segment
+const trailSubdivisions = 4
+
+// trailStepLength is the target length (px) of one smoothed step
+const trailStepLength = 8.0
+
+// trailVertex is a point on the smoothed trail polyline
+type trailVertex struct {
+	x, y   float64
+	radius float64 // Half the core width
+	alpha  float64
+}
+
+// TrailRenderer draws weapon trails as smoothed (Catmull-Rom), tapered,
+// anti-aliased polylines with a soft glow, directly into an RGBA frame buffer.
+// Scratch buffers are reused between frames, so a renderer must only be used
+// from one goroutine (the render loop).
+type TrailRenderer struct {
+	width  int
+	height int
+
+	weapons map[string]string // PlayerID -> weapon, rebuilt each frame
+	path    []trailVertex     // Smoothed polyline for the current trail
+
+	// Per-trail coverage over its bounding box. Each pixel keeps the max over
+	// all segments so overlapping joints don't double-blend.
+	core []float32
+	glow []float32
+}
+
+// NewTrailRenderer creates a trail renderer for frames of the given size
+func NewTrailRenderer(width, height int) *TrailRenderer {
+	return &TrailRenderer{
+		width:   width,
+		height:  height,
+		weapons: make(map[string]string),
+		path:    make([]trailVertex, 0, 8*trailSubdivisions),
+	}
+}
+
+// Render draws all trails into buffer (width*height*4 RGBA, opaque destination).
+// Players are used to look up each trail owner's weapon for styling.
+func (r *TrailRenderer) Render(buffer []byte, trails []game.TrailSnapshot, players []game.PlayerSnapshot) {
+	if len(trails) == 0 {
+		return
+	}
+
+	for id := range r.weapons {
+		delete(r.weapons, id)
+	}
+	for i := range players {
+		r.weapons[players[i].ID] = players[i].Weapon
+	}
+
+	for i := range trails {
+		tr := &trails[i]
+		if tr.Count < 2 || tr.Alpha <= 0 {
+			continue
+		}
+		r.renderTrail(buffer, tr, trailStyleFor(r.weapons[tr.PlayerID]))
+	}
+}
+
+// renderTrail rasterizes a single trail
+func (r *TrailRenderer) renderTrail(buffer []byte, tr *game.TrailSnapshot, style TrailStyle) {
+	r.smooth(tr, style)
+
+	// Bounding box of the whole trail including glow, clipped to the frame
+	pad := style.Width/2 + style.Glow + 1
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for _, v := range r.path {
+		minX, maxX = math.Min(minX, v.x), math.Max(maxX, v.x)
+		minY, maxY = math.Min(minY, v.y), math.Max(maxY, v.y)
+	}
+	x0 := clampInt(int(math.Floor(minX-pad)), 0, r.width)
+	y0 := clampInt(int(math.Floor(minY-pad)), 0, r.height)
+	x1 := clampInt(int(math.Ceil(maxX+pad)), 0, r.width)
+	y1 := clampInt(int(math.Ceil(maxY+pad)), 0, r.height)
+	bw, bh := x1-x0, y1-y0
+	if bw <= 0 || bh <= 0 {
+		return
+	}
+
+	// Reset scratch coverage (grows only, no per-frame allocation once warm)
+	n := bw * bh
+	if cap(r.core) < n {
+		r.core = make([]float32, n)
+		r.glow = make([]float32, n)
+	}
+	r.core, r.glow = r.core[:n], r.glow[:n]
+	for i := range r.core {
+		r.core[i] = 0
+		r.glow[i] = 0
+	}
+
+	for i := 0; i < len(r.path)-1; i++ {
+		r.coverSegment(r.path[i], r.path[i+1], style, x0, y0, x1, y1, bw)
+	}
+
+	// Composite: glow in the weapon color, then the (hotter) core on top
+	c := parseHexColorFast(tr.Color)
+	cr, cg, cb := float32(c.R), float32(c.G), float32(c.B)
+	hot := float32(style.HotCore)
+	hr, hg, hb := cr+(255-cr)*hot, cg+(255-cg)*hot, cb+(255-cb)*hot
+
+	stride := r.width * 4
+	for y := 0; y < bh; y++ {
+		row := (y0+y)*stride + x0*4
+		for x := 0; x < bw; x++ {
+			g, k := r.glow[y*bw+x], r.core[y*bw+x]
+			if g == 0 && k == 0 {
+				continue
+			}
+			idx := row + x*4
+			dr, dg, db := float32(buffer[idx]), float32(buffer[idx+1]), float32(buffer[idx+2])
+			if g > 0 {
+				dr += (cr - dr) * g
+				dg += (cg - dg) * g
+				db += (cb - db) * g
+			}
+			if k > 0 {
+				dr += (hr - dr) * k
+				dg += (hg - dg) * k
+				db += (hb - db) * k
+			}
+			buffer[idx] = uint8(dr + 0.5)
+			buffer[idx+1] = uint8(dg + 0.5)
+			buffer[idx+2] = uint8(db + 0.5)
+			buffer[idx+3] = 255
+		}
+	}
+}
+
+// smooth builds the Catmull-Rom polyline through the trail points (oldest first),
+// tapering the width and interpolating alpha from tail to tip
+func (r *TrailRenderer) smooth(tr *game.TrailSnapshot, style TrailStyle) {
+	r.path = r.path[:0]
+	pts := tr.Points[:tr.Count]
+	last := len(pts) - 1
+
+	vertex := func(x, y, t, alpha float64) trailVertex {
+		width := style.TailWidth + (style.Width-style.TailWidth)*t
+		return trailVertex{x: x, y: y, radius: width / 2, alpha: alpha * tr.Alpha}
+	}
+
+	for i := 0; i < last; i++ {
+		p0, p1, p2, p3 := pts[max(i-1, 0)], pts[i], pts[i+1], pts[min(i+2, last)]
+
+		steps := int(math.Ceil(math.Hypot(p2.X-p1.X, p2.Y-p1.Y) / trailStepLength))
+		steps = clampInt(steps, 1, trailSubdivisions)
+		for s := 0; s < steps; s++ {
+			u := float64(s) / float64(steps)
+			x, y := catmullRom(p0.X, p1.X, p2.X, p3.X, u), catmullRom(p0.Y, p1.Y, p2.Y, p3.Y, u)
+			t := (float64(i) + u) / float64(last)
+			r.path = append(r.path, vertex(x, y, t, p1.Alpha+(p2.Alpha-p1.Alpha)*u))
+		}
+	}
+	tip := pts[last]
+	r.path = append(r.path, vertex(tip.X, tip.Y, 1, tip.Alpha))
+}
+
+// coverSegment accumulates (max) core and glow coverage of one tapered segment.
+// Coverage is computed from the pixel center's distance to the segment, which
+// gives a 1px anti-aliased edge and round joints/caps.
+func (r *TrailRenderer) coverSegment(a, b trailVertex, style TrailStyle, bx0, by0, bx1, by1, bw int) {
+	reach := math.Max(a.radius, b.radius) + style.Glow + 0.5 // Farthest pixel center that gets coverage
+	reachSq := reach * reach
+	sy0 := clampInt(int(math.Floor(math.Min(a.y, b.y)-reach)), by0, by1)
+	sy1 := clampInt(int(math.Ceil(math.Max(a.y, b.y)+reach)), by0, by1)
+
+	dx, dy := b.x-a.x, b.y-a.y
+	lenSq := dx*dx + dy*dy
+	invLenSq := 0.0
+	if lenSq > 0 {
+		invLenSq = 1 / lenSq
+	}
+	glow := style.Glow
+	glowAlpha := style.GlowAlpha
+
+	for py := sy0; py < sy1; py++ {
+		cy := float64(py) + 0.5
+
+		// Row span: only the part of the segment within reach of this row, widened by reach
+		lo, hi := math.Min(a.x, b.x), math.Max(a.x, b.x)
+		if dy != 0 {
+			t0 := clampFloat((cy-reach-a.y)/dy, 0, 1)
+			t1 := clampFloat((cy+reach-a.y)/dy, 0, 1)
+			x0, x1 := a.x+dx*t0, a.x+dx*t1
+			lo, hi = math.Min(x0, x1), math.Max(x0, x1)
+		}
+		sx0 := clampInt(int(math.Floor(lo-reach)), bx0, bx1)
+		sx1 := clampInt(int(math.Ceil(hi+reach)), bx0, bx1)
+
+		row := (py - by0) * bw
+		cx := float64(sx0) + 0.5
+		tRow := ((cx-a.x)*dx + (cy-a.y)*dy) * invLenSq // Projection, stepped per pixel
+		tStep := dx * invLenSq
+		for px := sx0; px < sx1; px, cx, tRow = px+1, cx+1, tRow+tStep {
+			t := clampFloat(tRow, 0, 1)
+			ex, ey := cx-(a.x+dx*t), cy-(a.y+dy*t)
+			distSq := ex*ex + ey*ey
+			if distSq > reachSq {
+				continue
+			}
+			dist := math.Sqrt(distSq)
+			radius := a.radius + (b.radius-a.radius)*t
+			alpha := a.alpha + (b.alpha-a.alpha)*t
+			i := row + px - bx0
+
+			if core := (radius + 0.5 - dist) * alpha; core > 0 {
+				if core > alpha {
+					core = alpha
+				}
+				if float32(core) > r.core[i] {
+					r.core[i] = float32(core)
+				}
+			}
+			if glow > 0 && dist < radius+glow {
+				f := 1 - math.Max(dist-radius, 0)/glow
+				if g := float32(f * f * glowAlpha * alpha); g > r.glow[i] {
+					r.glow[i] = g
+				}
+			}
+		}
+	}
+}
+
+// catmullRom evaluates a uniform Catmull-Rom spline between p1 and p2 at u in [0,1]
+func catmullRom(p0, p1, p2, p3, u float64) float64 {
+	u2 := u * u
+	u3 := u2 * u
+	return 0.5 * (2*p1 + (p2-p0)*u + (2*p0-5*p1+4*p2-p3)*u2 + (3*p1-p0-3*p2+p3)*u3)
+}
+
+// clampInt clamps v to [lo, hi]
+func clampInt(v, lo, hi int) int {
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
+
+// clampFloat clamps v to [lo, hi]
+func clampFloat(v, lo, hi float64) float64 {
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
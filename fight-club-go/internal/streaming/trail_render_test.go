@@ -0,0 +1,150 @@
+package streaming
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"fight-club/internal/game"
+)
+
+// trailTestSnapshot builds a swing trail of 8 points along a quarter arc
+func trailTestSnapshot(playerID string, cx, cy float64) game.TrailSnapshot {
+	tr := game.TrailSnapshot{Count: 8, Color: "#ff0000", Alpha: 1, PlayerID: playerID}
+	for i := 0; i < tr.Count; i++ {
+		angle := float64(i) / 7 * math.Pi / 2
+		tr.Points[i] = game.TrailPointSnapshot{
+			X:     cx + 40*math.Cos(angle),
+			Y:     cy + 40*math.Sin(angle),
+			Alpha: 1,
+		}
+	}
+	return tr
+}
+
+// whiteFrame returns an opaque white RGBA buffer
+func whiteFrame(width, height int) []byte {
+	buffer := make([]byte, width*height*4)
+	for i := range buffer {
+		buffer[i] = 255
+	}
+	return buffer
+}
+
+// TestCatmullRomEndpoints verifies the spline passes through its control points
+func TestCatmullRomEndpoints(t *testing.T) {
+	if got := catmullRom(0, 10, 20, 30, 0); got != 10 {
+		t.Errorf("Expected p1 at u=0, got %f", got)
+	}
+	if got := catmullRom(0, 10, 20, 30, 1); got != 20 {
+		t.Errorf("Expected p2 at u=1, got %f", got)
+	}
+	if got := catmullRom(0, 10, 20, 30, 0.5); got != 15 {
+		t.Errorf("Expected collinear points to stay linear, got %f", got)
+	}
+}
+
+// TestTrailRenderAntialiased verifies a solid core, soft edges and untouched surroundings
+func TestTrailRenderAntialiased(t *testing.T) {
+	const w, h = 200, 100
+	r := NewTrailRenderer(w, h)
+	buffer := whiteFrame(w, h)
+
+	// Horizontal trail, tip at x=150
+	tr := game.TrailSnapshot{Count: 4, Color: "#ff0000", Alpha: 1, PlayerID: "p1"}
+	for i := 0; i < tr.Count; i++ {
+		tr.Points[i] = game.TrailPointSnapshot{X: 60 + float64(i)*30, Y: 50, Alpha: 1}
+	}
+	r.Render(buffer, []game.TrailSnapshot{tr}, []game.PlayerSnapshot{{ID: "p1", Weapon: "hammer"}})
+
+	green := func(x, y int) byte { return buffer[(y*w+x)*4+1] }
+
+	if g := green(148, 50); g > 60 {
+		t.Errorf("Expected solid red core near the tip, got green=%d", g)
+	}
+	partial := false
+	for y := 50; y < 70; y++ {
+		if g := green(148, y); g > 60 && g < 250 {
+			partial = true
+		}
+	}
+	if !partial {
+		t.Error("Expected anti-aliased/glow falloff at the trail edge")
+	}
+	if g := green(10, 10); g != 255 {
+		t.Errorf("Pixels far from the trail must be untouched, got green=%d", g)
+	}
+
+	// Trails partially or fully off-screen are clipped
+	off := tr
+	for i := range off.Points {
+		off.Points[i].X -= 150
+	}
+	r.Render(buffer, []game.TrailSnapshot{off}, nil)
+	off.Points[0].X = -1e6
+	r.Render(buffer, []game.TrailSnapshot{off}, nil)
+}
+
+// TestTrailStylePerWeapon verifies weapons get different trail widths
+func TestTrailStylePerWeapon(t *testing.T) {
+	const w, h = 200, 200
+	painted := func(weapon string) int {
+		r := NewTrailRenderer(w, h)
+		buffer := whiteFrame(w, h)
+		r.Render(buffer, []game.TrailSnapshot{trailTestSnapshot("p1", 80, 80)}, []game.PlayerSnapshot{{ID: "p1", Weapon: weapon}})
+		n := 0
+		for i := 0; i < len(buffer); i += 4 {
+			if buffer[i+1] < 250 { // Tinted by the trail or its glow
+				n++
+			}
+		}
+		return n
+	}
+
+	knife, hammer := painted("knife"), painted("hammer")
+	if knife == 0 || hammer <= knife {
+		t.Errorf("Expected hammer trail wider than knife trail, got hammer=%d knife=%d", hammer, knife)
+	}
+	if painted("unknown") == 0 {
+		t.Error("Unknown weapons should use the default style")
+	}
+}
+
+// BenchmarkTrailRender_100Players benchmarks trails in a 100 player fight at 720p.
+// "capped" is what the engine can produce (ResourceLimits.MaxTrails per frame),
+// "uncapped" is every player swinging at once. Reports the share of the
+// 30 FPS frame budget (33.3ms) spent on trails.
+func BenchmarkTrailRender_100Players(b *testing.B) {
+	const w, h = 1280, 720
+
+	players := make([]game.PlayerSnapshot, 100)
+	trails := make([]game.TrailSnapshot, 100)
+	weapons := []string{"fists", "knife", "sword", "spear", "axe", "bow", "scythe", "katana", "hammer"}
+	for i := range players {
+		id := fmt.Sprintf("player_%d", i)
+		players[i] = game.PlayerSnapshot{ID: id, Weapon: weapons[i%len(weapons)]}
+		trails[i] = trailTestSnapshot(id, float64(60+(i*97)%1100), float64(60+(i*53)%580))
+	}
+
+	for _, bc := range []struct {
+		name   string
+		trails int
+	}{
+		{"capped", game.DefaultLimits.MaxTrails},
+		{"uncapped", len(trails)},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			r := NewTrailRenderer(w, h)
+			buffer := whiteFrame(w, h)
+
+			b.ResetTimer()
+			start := time.Now()
+			for i := 0; i < b.N; i++ {
+				r.Render(buffer, trails[:bc.trails], players)
+			}
+			perFrame := time.Since(start) / time.Duration(b.N)
+			b.ReportMetric(float64(perFrame)/float64(time.Second/30)*100, "%budget")
+		})
+	}
+}
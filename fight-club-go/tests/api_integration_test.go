@@ -1,14 +1,23 @@
 package tests
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/json"
+	"errors"
+	"io"
+	"log"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"fight-club/internal/api"
+	"fight-club/internal/chat"
+	"fight-club/internal/config"
 	"fight-club/internal/game"
 )
 
@@ -22,6 +31,9 @@ type MockEngine struct {
 	playerCount int
 	aliveCount  int
 	totalKills  int
+	lastTick    time.Time // Zero = engine not started
+	paused      bool
+	tick        uint64 // Snapshot TickNumber
 }
 
 func NewMockEngine() *MockEngine {
@@ -74,9 +86,24 @@ func (m *MockEngine) GetSnapshot() *game.GameSnapshot {
 		PlayerCount: m.playerCount,
 		AliveCount:  m.aliveCount,
 		TotalKills:  m.totalKills,
+		Timestamp:   m.lastTick,
+		TickNumber:  m.tick,
+		Paused:      m.paused,
 	}
 }
 
+func (m *MockEngine) Pause() bool {
+	changed := !m.paused
+	m.paused = true
+	return changed
+}
+
+func (m *MockEngine) Resume() bool {
+	changed := m.paused
+	m.paused = false
+	return changed
+}
+
 // MockStreamer implements api.StreamerInterface for testing
 type MockStreamer struct {
 	streaming bool
@@ -186,6 +213,17 @@ func TestAPIGetState(t *testing.T) {
 	if len(players) != 2 {
 		t.Errorf("Expected 2 players, got %d", len(players))
 	}
+
+	// Client hints for overlays extrapolating between polls
+	if result["predictionWindow"] != game.PredictionWindow {
+		t.Errorf("Expected predictionWindow %v, got %v", game.PredictionWindow, result["predictionWindow"])
+	}
+	player := players[0].(map[string]interface{})
+	for _, key := range []string{"vx", "vy", "attackPhase", "attackPhaseLeft", "attackCooldown"} {
+		if _, ok := player[key]; !ok {
+			t.Errorf("Expected player field %q", key)
+		}
+	}
 }
 
 // TestAPIPlayerJoin tests the player join endpoint
@@ -331,6 +369,90 @@ func TestAPIStreamControl(t *testing.T) {
 	}
 }
 
+// keyframeStreamer is a MockStreamer that can force keyframes
+type keyframeStreamer struct {
+	*MockStreamer
+	keyframes int
+}
+
+func (k *keyframeStreamer) ForceKeyframe() error {
+	k.keyframes++
+	return nil
+}
+
+// TestAPIStreamKeyframe tests the forced keyframe endpoint
+func TestAPIStreamKeyframe(t *testing.T) {
+	post := func(streamer api.StreamerInterface) int {
+		router := api.NewRouter(api.RouterConfig{
+			Engine:         NewMockEngine(),
+			Streamer:       streamer,
+			DisableLogging: true,
+		})
+		ts := httptest.NewServer(router)
+		defer ts.Close()
+
+		resp, err := http.Post(ts.URL+"/api/admin/stream/keyframe", "application/json", nil)
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	if status := post(NewMockStreamer()); status != http.StatusNotImplemented {
+		t.Errorf("Expected 501 from a streamer without keyframe support, got %d", status)
+	}
+	streamer := &keyframeStreamer{MockStreamer: NewMockStreamer()}
+	if status := post(streamer); status != http.StatusOK || streamer.keyframes != 1 {
+		t.Errorf("Expected one keyframe forced, got status %d and %d keyframes", status, streamer.keyframes)
+	}
+}
+
+// replayEngine is a MockEngine that can cue replays, one at a time
+type replayEngine struct {
+	*MockEngine
+	replays int
+}
+
+func (r *replayEngine) RequestReplay() error {
+	if r.replays > 0 {
+		return errors.New("a replay is playing")
+	}
+	r.replays++
+	return nil
+}
+
+// TestAPIReplay tests the slow-motion replay endpoint
+func TestAPIReplay(t *testing.T) {
+	post := func(engine api.EngineInterface) int {
+		router := api.NewRouter(api.RouterConfig{
+			Engine:         engine,
+			Streamer:       NewMockStreamer(),
+			DisableLogging: true,
+		})
+		ts := httptest.NewServer(router)
+		defer ts.Close()
+
+		resp, err := http.Post(ts.URL+"/api/admin/replay", "application/json", nil)
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	if status := post(NewMockEngine()); status != http.StatusNotImplemented {
+		t.Errorf("Expected 501 from an engine without replays, got %d", status)
+	}
+	engine := &replayEngine{MockEngine: NewMockEngine()}
+	if status := post(engine); status != http.StatusOK || engine.replays != 1 {
+		t.Errorf("Expected one replay cued, got status %d and %d replays", status, engine.replays)
+	}
+	if status := post(engine); status != http.StatusConflict {
+		t.Errorf("Expected 409 while a replay is playing, got %d", status)
+	}
+}
+
 // TestAPIGetWeapons tests the weapons endpoint
 func TestAPIGetWeapons(t *testing.T) {
 	mockEngine := NewMockEngine()
@@ -403,6 +525,92 @@ func TestAPILeaderboard(t *testing.T) {
 	}
 }
 
+// TestAPIPlayerListQuery tests paging, sorting, field selection, team filtering and ETags
+func TestAPIPlayerListQuery(t *testing.T) {
+	mockEngine := NewMockEngine()
+	for i, name := range []string{"alpha", "bravo", "charlie", "delta"} {
+		p := mockEngine.AddPlayer(name, game.PlayerOptions{})
+		p.Kills = i
+		p.Money = 100 - i*10
+		if i%2 == 0 {
+			p.TeamID = "red"
+		}
+	}
+
+	router := api.NewRouter(api.RouterConfig{
+		Engine:         mockEngine,
+		Streamer:       NewMockStreamer(),
+		DisableLogging: true,
+	})
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	get := func(path, etag string) *http.Response {
+		req, _ := http.NewRequest("GET", ts.URL+path, nil)
+		if etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		return resp
+	}
+	names := func(players []interface{}) []string {
+		var out []string
+		for _, p := range players {
+			out = append(out, p.(map[string]interface{})["name"].(string))
+		}
+		return out
+	}
+
+	// Paging and sorting
+	resp := get("/api/state?sort=money&limit=2&offset=1", "")
+	var state map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&state)
+	resp.Body.Close()
+	if got := names(state["players"].([]interface{})); strings.Join(got, ",") != "charlie,bravo" || state["total"] != 4.0 {
+		t.Errorf("Expected charlie,bravo of 4, got %v (total %v)", got, state["total"])
+	}
+
+	// Team filter and field selection
+	resp = get("/api/leaderboard?team=red&fields=name,kills", "")
+	var board []interface{}
+	json.NewDecoder(resp.Body).Decode(&board)
+	resp.Body.Close()
+	if got := names(board); strings.Join(got, ",") != "charlie,alpha" || resp.Header.Get("X-Total-Count") != "2" {
+		t.Errorf("Expected red team charlie,alpha, got %v (total %s)", got, resp.Header.Get("X-Total-Count"))
+	}
+	if len(board[0].(map[string]interface{})) != 2 {
+		t.Errorf("Expected only name and kills, got %v", board[0])
+	}
+
+	// Unchanged data is not re-sent
+	resp = get("/api/leaderboard", "")
+	resp.Body.Close()
+	etag := resp.Header.Get("ETag")
+	if etag == "" {
+		t.Fatal("Expected an ETag")
+	}
+	if resp = get("/api/leaderboard", etag); resp.StatusCode != http.StatusNotModified {
+		t.Errorf("Expected 304 for a matching ETag, got %d", resp.StatusCode)
+	}
+	resp.Body.Close()
+	mockEngine.GetPlayer("alpha").Kills = 10
+	if resp = get("/api/leaderboard", etag); resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected 200 after a change, got %d", resp.StatusCode)
+	}
+	resp.Body.Close()
+
+	for _, bad := range []string{"limit=0", "offset=-1", "sort=rating", "fields=name,password"} {
+		resp = get("/api/state?"+bad, "")
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("Expected 400 for %s, got %d", bad, resp.StatusCode)
+		}
+	}
+}
+
 // ============================================================================
 // Middleware Tests
 // ============================================================================
@@ -516,35 +724,931 @@ func TestAPIRedirects(t *testing.T) {
 	}
 }
 
-// ============================================================================
-// Benchmarks
-// ============================================================================
-
-// BenchmarkAPIGetState benchmarks the state endpoint
-func BenchmarkAPIGetState(b *testing.B) {
+// TestAPIHealthProbes tests /healthz and /readyz status codes
+func TestAPIHealthProbes(t *testing.T) {
 	mockEngine := NewMockEngine()
-	mockStreamer := NewMockStreamer()
+	health := api.NewHealthRegistry()
 
-	// Add some players
-	for i := 0; i < 50; i++ {
-		mockEngine.AddPlayer("Player"+string(rune('A'+i)), game.PlayerOptions{})
+	router := api.NewRouter(api.RouterConfig{
+		Engine:         mockEngine,
+		Streamer:       NewMockStreamer(),
+		DisableLogging: true,
+		Health:         health,
+	})
+
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	getStatus := func(path string) int {
+		resp, err := http.Get(ts.URL + path)
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		defer resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	// Before the first tick: alive (don't restart during boot) but not ready
+	if code := getStatus("/healthz"); code != http.StatusOK {
+		t.Errorf("healthz before start: expected 200, got %d", code)
+	}
+	if code := getStatus("/readyz"); code != http.StatusServiceUnavailable {
+		t.Errorf("readyz before start: expected 503, got %d", code)
+	}
+
+	// Engine ticking: alive and ready
+	mockEngine.lastTick = time.Now()
+	if code := getStatus("/readyz"); code != http.StatusOK {
+		t.Errorf("readyz while ticking: expected 200, got %d", code)
+	}
+
+	// Non-critical failure only degrades readiness
+	health.Register(api.HealthCheck{Name: "kick_auth", Check: func() error {
+		return errors.New("not authenticated")
+	}})
+	if code := getStatus("/readyz"); code != http.StatusOK {
+		t.Errorf("readyz with degraded check: expected 200, got %d", code)
+	}
+
+	// Critical failure fails readiness
+	health.Register(api.HealthCheck{Name: "ipc", Critical: true, Check: func() error {
+		return errors.New("IPC publisher not running")
+	}})
+	if code := getStatus("/readyz"); code != http.StatusServiceUnavailable {
+		t.Errorf("readyz with failed critical check: expected 503, got %d", code)
 	}
 
+	// Hung engine fails liveness
+	mockEngine.lastTick = time.Now().Add(-time.Minute)
+	if code := getStatus("/healthz"); code != http.StatusServiceUnavailable {
+		t.Errorf("healthz with hung engine: expected 503, got %d", code)
+	}
+}
+
+// TestAPIPauseResume tests pausing the simulation via the admin API
+func TestAPIPauseResume(t *testing.T) {
+	mockEngine := NewMockEngine()
+
 	router := api.NewRouter(api.RouterConfig{
 		Engine:         mockEngine,
-		Streamer:       mockStreamer,
+		Streamer:       NewMockStreamer(),
 		DisableLogging: true,
 	})
 
 	ts := httptest.NewServer(router)
 	defer ts.Close()
 
-	b.ResetTimer()
-	for i := 0; i < b.N; i++ {
-		resp, err := http.Get(ts.URL + "/api/state")
+	post := func(path string) map[string]interface{} {
+		resp, err := http.Post(ts.URL+path, "application/json", nil)
 		if err != nil {
-			b.Fatalf("Request failed: %v", err)
+			t.Fatalf("Request failed: %v", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("%s: expected 200, got %d", path, resp.StatusCode)
+		}
+		var result map[string]interface{}
+		json.NewDecoder(resp.Body).Decode(&result)
+		return result
+	}
+
+	if result := post("/api/admin/pause"); result["changed"] != true || !mockEngine.paused {
+		t.Errorf("Expected engine to pause, got %v", result)
+	}
+	if result := post("/api/admin/pause"); result["changed"] != false {
+		t.Errorf("Second pause should be a no-op, got %v", result)
+	}
+
+	resp, err := http.Get(ts.URL + "/api/stats")
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	var stats map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&stats)
+	resp.Body.Close()
+	if stats["paused"] != true {
+		t.Errorf("Expected stats to report paused, got %v", stats["paused"])
+	}
+
+	if result := post("/api/admin/resume"); result["changed"] != true || mockEngine.paused {
+		t.Errorf("Expected engine to resume, got %v", result)
+	}
+}
+
+// TestAPIChatAliases tests chat command alias management and collision detection
+func TestAPIChatAliases(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "aliases.json")
+	os.WriteFile(path, []byte(`{"pelear": "join", "heal": "buy sword"}`), 0o644)
+
+	aliases, err := chat.NewAliasStore(path)
+	if err != nil {
+		t.Fatalf("Failed to load aliases: %v", err)
+	}
+	if list := aliases.List(); len(list) != 1 || list[0].Trigger != "pelear" {
+		t.Errorf("Expected colliding file entry to be skipped, got %v", list)
+	}
+
+	router := api.NewRouter(api.RouterConfig{
+		Engine:         NewMockEngine(),
+		Streamer:       NewMockStreamer(),
+		Aliases:        aliases,
+		DisableLogging: true,
+	})
+
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	do := func(method, path, body string) int {
+		req, _ := http.NewRequest(method, ts.URL+path, bytes.NewBufferString(body))
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
 		}
 		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	if status := do("PUT", "/api/admin/aliases", `{"trigger": "!Espadita", "target": "buy sword"}`); status != http.StatusOK {
+		t.Errorf("Expected 200 creating alias, got %d", status)
+	}
+	if status := do("PUT", "/api/admin/aliases", `{"trigger": "join", "target": "heal"}`); status != http.StatusConflict {
+		t.Errorf("Expected 409 for built-in command, got %d", status)
+	}
+	if status := do("PUT", "/api/admin/aliases", `{"trigger": "espada", "target": "join"}`); status != http.StatusConflict {
+		t.Errorf("Expected 409 for weapon shortcut, got %d", status)
+	}
+	if status := do("PUT", "/api/admin/aliases", `{"trigger": "chain", "target": "pelear"}`); status != http.StatusBadRequest {
+		t.Errorf("Expected 400 for alias-to-alias target, got %d", status)
+	}
+
+	cmd := aliases.Resolve(chat.ChatCommand{Command: "espadita", Args: []string{"now"}, Username: "viewer"})
+	if cmd.Command != "buy" || len(cmd.Args) != 2 || cmd.Args[0] != "sword" {
+		t.Errorf("Expected !espadita to resolve to !buy sword, got !%s %v", cmd.Command, cmd.Args)
+	}
+
+	// Persisted for the next restart
+	restored, _ := chat.NewAliasStore(path)
+	if len(restored.List()) != 2 {
+		t.Errorf("Expected 2 persisted aliases, got %v", restored.List())
+	}
+
+	if status := do("DELETE", "/api/admin/aliases/pelear", ""); status != http.StatusOK {
+		t.Errorf("Expected 200 deleting alias, got %d", status)
+	}
+	if status := do("DELETE", "/api/admin/aliases/pelear", ""); status != http.StatusNotFound {
+		t.Errorf("Expected 404 deleting missing alias, got %d", status)
+	}
+}
+
+// fakeModerator records Kick timeouts issued by the abuse guard
+type fakeModerator struct {
+	timeouts chan int
+}
+
+func (m *fakeModerator) TimeoutUser(userID int64, minutes int, reason string) error {
+	m.timeouts <- minutes
+	return nil
+}
+
+// TestAPIModeration tests the anti-abuse guard and its admin policy endpoints
+func TestAPIModeration(t *testing.T) {
+	policy := config.DefaultModeration()
+	policy.Enabled = true
+	policy.Threshold = 3
+	policy.Exempt = []string{"TrustedMod"}
+	moderator := &fakeModerator{timeouts: make(chan int, 4)}
+	guard := chat.NewAbuseGuard(policy, moderator)
+
+	spammer := chat.ChatCommand{Command: "heal", Username: "spammer", UserID: 42}
+	for i := 0; i < 2; i++ {
+		if guard.RecordViolation(spammer) != nil {
+			t.Fatal("Expected no action below the threshold")
+		}
+	}
+	if a := guard.RecordViolation(spammer); a == nil || !a.DryRun || a.Minutes != 5 {
+		t.Fatalf("Expected a 5 minute dry-run timeout at the threshold, got %+v", a)
+	}
+	if guard.RecordViolation(chat.ChatCommand{Username: "trustedmod", UserID: 7}) != nil ||
+		guard.RecordViolation(chat.ChatCommand{Username: "owner", UserID: 1, IsBroadcaster: true}) != nil {
+		t.Error("Expected exempt users and the broadcaster never to be timed out")
+	}
+
+	router := api.NewRouter(api.RouterConfig{
+		Engine:         NewMockEngine(),
+		Streamer:       NewMockStreamer(),
+		Moderation:     guard,
+		DisableLogging: true,
+	})
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	req, _ := http.NewRequest("PUT", ts.URL+"/api/admin/moderation", bytes.NewBufferString(`{"dryRun": false, "timeoutMinutes": 0}`))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected 400 for invalid timeout, got %d", resp.StatusCode)
+	}
+
+	req, _ = http.NewRequest("PUT", ts.URL+"/api/admin/moderation", bytes.NewBufferString(`{"dryRun": false}`))
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || guard.Policy().DryRun || guard.Policy().Threshold != 3 {
+		t.Fatalf("Expected dry-run disabled with other fields kept, got %d %+v", resp.StatusCode, guard.Policy())
+	}
+
+	// Second offence is a real, doubled timeout
+	for i := 0; i < 3; i++ {
+		guard.RecordViolation(spammer)
+	}
+	select {
+	case minutes := <-moderator.timeouts:
+		if minutes != 10 {
+			t.Errorf("Expected repeat offender timeout of 10 minutes, got %d", minutes)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected the moderator to be called")
+	}
+
+	resp, err = http.Get(ts.URL + "/api/admin/moderation")
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	var body struct {
+		Policy  map[string]interface{}  `json:"policy"`
+		Actions []chat.ModerationAction `json:"actions"`
+	}
+	json.NewDecoder(resp.Body).Decode(&body)
+	if len(body.Actions) != 2 || body.Actions[0].Strikes != 2 || body.Actions[0].DryRun {
+		t.Errorf("Expected 2 actions newest first, got %+v", body.Actions)
+	}
+	if body.Policy["threshold"] != float64(3) {
+		t.Errorf("Expected threshold 3 in policy, got %v", body.Policy)
+	}
+}
+
+// TestAPISoundPack tests switching the stream's sound pack from the admin API
+func TestAPISoundPack(t *testing.T) {
+	packs := game.NewSoundPackSelector(game.DefaultSoundPack)
+	packs.SetAvailable([]string{"default", "meme", "retro"})
+
+	router := api.NewRouter(api.RouterConfig{
+		Engine:         NewMockEngine(),
+		Streamer:       NewMockStreamer(),
+		SoundPacks:     packs,
+		DisableLogging: true,
+	})
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	resp, err := http.Post(ts.URL+"/api/admin/soundpack", "application/json", bytes.NewBufferString(`{"pack": "vaporwave"}`))
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest || packs.Current() != "default" {
+		t.Errorf("Expected 400 for an unknown pack, got %d (pack %s)", resp.StatusCode, packs.Current())
+	}
+
+	resp, err = http.Post(ts.URL+"/api/admin/soundpack", "application/json", bytes.NewBufferString(`{"pack": "retro"}`))
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	var body struct {
+		Pack  string   `json:"pack"`
+		Packs []string `json:"packs"`
+	}
+	json.NewDecoder(resp.Body).Decode(&body)
+	if resp.StatusCode != http.StatusOK || body.Pack != "retro" || len(body.Packs) != 3 || packs.Current() != "retro" {
+		t.Errorf("Expected retro active of 3 packs, got %d %+v", resp.StatusCode, body)
+	}
+}
+
+// TestAPIArenaPreset tests switching the arena preset from the admin API
+func TestAPIArenaPreset(t *testing.T) {
+	engine := game.NewEngine(game.DefaultEngineConfig())
+
+	router := api.NewRouter(api.RouterConfig{
+		Engine:         NewMockEngine(),
+		Streamer:       NewMockStreamer(),
+		Arena:          engine,
+		DisableLogging: true,
+	})
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	resp, err := http.Post(ts.URL+"/api/admin/arena", "application/json", bytes.NewBufferString(`{"preset": "colosseum"}`))
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest || engine.ArenaPreset().Name != "standard" {
+		t.Errorf("Expected 400 for an unknown preset, got %d (arena %s)", resp.StatusCode, engine.ArenaPreset().Name)
+	}
+
+	resp, err = http.Post(ts.URL+"/api/admin/arena", "application/json", bytes.NewBufferString(`{"preset": "large"}`))
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	var body struct {
+		Arena   game.ArenaPreset   `json:"arena"`
+		Presets []game.ArenaPreset `json:"presets"`
+	}
+	json.NewDecoder(resp.Body).Decode(&body)
+	if resp.StatusCode != http.StatusOK || body.Arena.Name != "large" || body.Arena.Width != 1920 || len(body.Presets) != 3 {
+		t.Errorf("Expected the large arena of 3 presets, got %d %+v", resp.StatusCode, body)
+	}
+}
+
+// TestAPIScene tests switching the time of day and weather from the admin API
+func TestAPIScene(t *testing.T) {
+	engine := game.NewEngine(game.DefaultEngineConfig())
+
+	router := api.NewRouter(api.RouterConfig{
+		Engine:         NewMockEngine(),
+		Streamer:       NewMockStreamer(),
+		Scene:          engine,
+		DisableLogging: true,
+	})
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	resp, err := http.Post(ts.URL+"/api/admin/scene", "application/json", bytes.NewBufferString(`{"weather": "hail"}`))
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest || engine.Scene().Weather != game.WeatherClear {
+		t.Errorf("Expected 400 for an unknown weather, got %d (%+v)", resp.StatusCode, engine.Scene())
+	}
+
+	resp, err = http.Post(ts.URL+"/api/admin/scene", "application/json", bytes.NewBufferString(`{"time": "night", "weather": "rain"}`))
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	var body struct {
+		Scene    game.SceneSnapshot `json:"scene"`
+		Times    []string           `json:"times"`
+		Weathers []string           `json:"weathers"`
+	}
+	json.NewDecoder(resp.Body).Decode(&body)
+	if resp.StatusCode != http.StatusOK || body.Scene.Time != "night" || body.Scene.Weather != "rain" || len(body.Times) != 3 {
+		t.Errorf("Expected a rainy night, got %d %+v", resp.StatusCode, body)
+	}
+}
+
+// TestAPIWeaponsReload tests hot reloading the weapons file from the admin API
+func TestAPIWeaponsReload(t *testing.T) {
+	example, err := os.ReadFile("../weapons.example.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "weapons.yaml")
+	registry, err := game.NewWeaponRegistry(path)
+	if err != nil {
+		t.Fatalf("NewWeaponRegistry failed: %v", err)
+	}
+	defer game.InstallWeaponDefinitions(nil)
+
+	router := api.NewRouter(api.RouterConfig{
+		Engine:         NewMockEngine(),
+		Streamer:       NewMockStreamer(),
+		Weapons:        registry,
+		DisableLogging: true,
+	})
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	os.WriteFile(path, example, 0o644)
+	resp, err := http.Post(ts.URL+"/api/admin/weapons/reload", "application/json", nil)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	var status game.WeaponRegistryStatus
+	json.NewDecoder(resp.Body).Decode(&status)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || len(status.Custom) != 2 || !game.IsWeapon("trident") {
+		t.Fatalf("Expected the example weapons loaded, got %d %+v", resp.StatusCode, status)
+	}
+	if id, ok := chat.GetWeaponID("tridente"); !ok || id != "trident" {
+		t.Errorf("Expected !buy to know the trident's alias, got %q", id)
+	}
+
+	os.WriteFile(path, []byte(`weapons: [{id: trident, base: spear, name: Trident, range: 10}]`), 0o644)
+	resp, err = http.Post(ts.URL+"/api/admin/weapons/reload", "application/json", nil)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest || game.GetWeapon("trident").Range == 10 {
+		t.Errorf("Expected 400 and the previous trident kept, got %d", resp.StatusCode)
+	}
+}
+
+// TestAPIPeriodLeaderboard tests the persistent today/week/all-time leaderboard endpoints
+func TestAPIPeriodLeaderboard(t *testing.T) {
+	store := game.NewLeaderboardStore(game.LeaderboardConfig{})
+	now := time.Now()
+	store.RecordKill("old_timer", now.AddDate(0, 0, -30))
+	store.RecordKill("old_timer", now.AddDate(0, 0, -30))
+	store.RecordKill("today_hero", now)
+
+	router := api.NewRouter(api.RouterConfig{
+		Engine:         NewMockEngine(),
+		Streamer:       NewMockStreamer(),
+		Leaderboards:   store,
+		DisableLogging: true,
+	})
+
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	get := func(path string) (int, map[string]interface{}) {
+		resp, err := http.Get(ts.URL + path)
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		defer resp.Body.Close()
+		var result map[string]interface{}
+		json.NewDecoder(resp.Body).Decode(&result)
+		return resp.StatusCode, result
+	}
+
+	top := func(result map[string]interface{}) string {
+		entries, _ := result["entries"].([]interface{})
+		if len(entries) == 0 {
+			return ""
+		}
+		return entries[0].(map[string]interface{})["username"].(string)
+	}
+
+	if status, result := get("/api/leaderboard/today"); status != http.StatusOK || top(result) != "today_hero" {
+		t.Errorf("Expected today_hero on today's board, got %d %v", status, result)
+	}
+	if status, result := get("/api/leaderboard/alltime"); status != http.StatusOK || top(result) != "old_timer" || result["title"] != "ALL TIME" {
+		t.Errorf("Expected old_timer on the all-time board, got %d %v", status, result)
+	}
+	if _, result := get("/api/leaderboard/alltime?limit=1"); len(result["entries"].([]interface{})) != 1 {
+		t.Errorf("Expected limit to apply, got %v", result["entries"])
+	}
+	if status, _ := get("/api/leaderboard/yearly"); status != http.StatusNotFound {
+		t.Errorf("Expected 404 for unknown period, got %d", status)
+	}
+}
+
+// TestAPIAnalytics tests the combat stats and heatmap endpoints
+func TestAPIAnalytics(t *testing.T) {
+	analytics := game.NewCombatAnalytics(game.AnalyticsConfig{CellSize: 40}, 1280, 720, 30)
+	analytics.RecordDamage("victim", "sword", 100, 100, 30, 0)
+	analytics.RecordKill("victim", "sword", "fists", 100, 100, 60)
+
+	router := api.NewRouter(api.RouterConfig{
+		Engine:         NewMockEngine(),
+		Streamer:       NewMockStreamer(),
+		Analytics:      analytics,
+		DisableLogging: true,
+	})
+
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/api/analytics")
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	var stats game.AnalyticsStats
+	json.NewDecoder(resp.Body).Decode(&stats)
+	resp.Body.Close()
+	if stats.Kills != 1 || stats.AvgFightSeconds != 2 || len(stats.LethalZones) != 1 {
+		t.Errorf("Unexpected analytics: %+v", stats)
+	}
+
+	resp, err = http.Get(ts.URL + "/api/analytics/heatmap.png")
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "image/png" {
+		t.Errorf("Expected PNG heatmap, got %d %s", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+}
+
+// TestAPIBalance tests the weapon balance report in JSON and markdown
+func TestAPIBalance(t *testing.T) {
+	balance := game.NewBalanceTelemetry("", 30)
+	balance.RecordHit("spear", "victim", 1, 0)
+	balance.RecordKill("spear", "victim", 30)
+
+	router := api.NewRouter(api.RouterConfig{
+		Engine:         NewMockEngine(),
+		Streamer:       NewMockStreamer(),
+		Balance:        balance,
+		DisableLogging: true,
+	})
+
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/api/balance")
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	var report game.BalanceReport
+	json.NewDecoder(resp.Body).Decode(&report)
+	resp.Body.Close()
+	if report.Kills != 1 || len(report.Weapons) == 0 || report.Weapons[0].Weapon != "spear" || report.Weapons[0].AvgTimeToKill != 1 {
+		t.Errorf("Unexpected balance report: %+v", report)
+	}
+
+	resp, err = http.Get(ts.URL + "/api/balance?format=md")
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/markdown") || !strings.Contains(string(body), "| spear |") {
+		t.Errorf("Expected a markdown report, got %s:\n%s", resp.Header.Get("Content-Type"), body)
+	}
+}
+
+// TestAPIKeys tests API key auth and per-key rate limits on join/heal
+func TestAPIKeys(t *testing.T) {
+	keys, _ := api.NewAPIKeyStore("")
+	key, _, err := keys.Create("discord-bot", 60, 2)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	router := api.NewRouter(api.RouterConfig{
+		Engine:         NewMockEngine(),
+		Streamer:       NewMockStreamer(),
+		DisableLogging: true,
+		APIKeys:        keys,
+		RequireAPIKey:  true,
+	})
+
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	join := func(apiKey string) int {
+		req, _ := http.NewRequest("POST", ts.URL+"/api/player/join", bytes.NewReader([]byte(`{"name": "BotPlayer"}`)))
+		req.Header.Set("Content-Type", "application/json")
+		if apiKey != "" {
+			req.Header.Set(api.APIKeyHeader, apiKey)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	if code := join(""); code != http.StatusUnauthorized {
+		t.Errorf("Missing key: expected 401, got %d", code)
+	}
+	if code := join("fc_invalid"); code != http.StatusUnauthorized {
+		t.Errorf("Invalid key: expected 401, got %d", code)
+	}
+
+	// Burst of 2, then rate limited
+	for i := 0; i < 2; i++ {
+		if code := join(key); code != http.StatusOK {
+			t.Fatalf("Valid key: expected 200, got %d", code)
+		}
+	}
+	if code := join(key); code != http.StatusTooManyRequests {
+		t.Errorf("Expected 429 after burst, got %d", code)
+	}
+
+	list := keys.List()
+	if len(list) != 1 || list[0]["requests"] != uint64(2) || list[0]["rejected"] != uint64(1) {
+		t.Errorf("Unexpected key stats: %v", list)
+	}
+}
+
+// TestAPIKeyManagement tests creating, listing and revoking keys via the admin API
+func TestAPIKeyManagement(t *testing.T) {
+	keys, _ := api.NewAPIKeyStore("")
+	sessions := api.NewSessionManager(0)
+
+	router := api.NewRouter(api.RouterConfig{
+		Engine:          NewMockEngine(),
+		Streamer:        NewMockStreamer(),
+		DisableLogging:  true,
+		SessionManager:  sessions,
+		EnableAdminAuth: true,
+		APIKeys:         keys,
+	})
+
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	sessionID, _ := sessions.CreateSession(1, "admin", 1)
+	rec := httptest.NewRecorder()
+	sessions.SetSessionCookie(rec, sessionID)
+	cookie := rec.Result().Cookies()[0]
+
+	do := func(method, path, body string, authed bool) *http.Response {
+		req, _ := http.NewRequest(method, ts.URL+path, bytes.NewReader([]byte(body)))
+		if authed {
+			req.AddCookie(cookie)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		return resp
+	}
+
+	resp := do("POST", "/api/admin/keys", `{"name": "mobile-app"}`, false)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Create without session: expected 401, got %d", resp.StatusCode)
+	}
+
+	resp = do("POST", "/api/admin/keys", `{"name": "mobile-app", "requestsPerMinute": 30}`, true)
+	var created map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&created)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("Create: expected 201, got %d", resp.StatusCode)
+	}
+	if created["key"] == "" || created["requestsPerMinute"] != 30.0 {
+		t.Errorf("Unexpected create response: %v", created)
+	}
+
+	resp = do("GET", "/api/admin/keys", "", true)
+	var list []map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&list)
+	resp.Body.Close()
+	if len(list) != 1 || list[0]["name"] != "mobile-app" || list[0]["key"] != nil || list[0]["hash"] != nil {
+		t.Errorf("List should return the key without secrets, got %v", list)
+	}
+
+	// Keys are optional when not required
+	resp = do("POST", "/api/player/join", `{"name": "NoKey"}`, false)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Join without key: expected 200, got %d", resp.StatusCode)
+	}
+
+	resp = do("DELETE", "/api/admin/keys/"+created["id"].(string), "", true)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Revoke: expected 200, got %d", resp.StatusCode)
+	}
+	if len(keys.List()) != 0 {
+		t.Error("Expected key to be revoked")
+	}
+
+	req, _ := http.NewRequest("POST", ts.URL+"/api/player/heal", bytes.NewReader([]byte(`{"name": "NoKey"}`)))
+	req.Header.Set("Authorization", "Bearer "+created["key"].(string))
+	resp, _ = http.DefaultClient.Do(req)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Revoked key: expected 401, got %d", resp.StatusCode)
+	}
+}
+
+// TestAPIAdminEvents tests the admin server-sent event stream of live stats and errors
+func TestAPIAdminEvents(t *testing.T) {
+	errorLog := api.NewErrorLog(10)
+	errorLog.Write([]byte("2026/01/02 15:04:05 ❌ FFmpeg exited\n2026/01/02 15:04:05 ✅ all good\n"))
+
+	router := api.NewRouter(api.RouterConfig{
+		Engine:         NewMockEngine(),
+		Streamer:       NewMockStreamer(),
+		Errors:         errorLog,
+		DisableLogging: true,
+	})
+
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/api/admin/events?interval=0.25")
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("Expected text/event-stream, got %q", ct)
+	}
+
+	// Read events until a later error shows up after the first stats event
+	events := make(map[string][]string)
+	scanner := bufio.NewScanner(resp.Body)
+	event := ""
+	deadline := time.AfterFunc(5*time.Second, func() { resp.Body.Close() })
+	defer deadline.Stop()
+	for scanner.Scan() && len(events["error"]) < 2 {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "event: "):
+			event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			events[event] = append(events[event], strings.TrimPrefix(line, "data: "))
+			if event == "stats" && len(events["stats"]) == 1 {
+				log.New(errorLog, "", log.LstdFlags).Print("⚠️ Chat queue full")
+			}
+		}
+	}
+
+	if len(events["stats"]) == 0 {
+		t.Fatal("Expected a stats event")
+	}
+	var stats map[string]interface{}
+	json.Unmarshal([]byte(events["stats"][0]), &stats)
+	if engine, ok := stats["engine"].(map[string]interface{}); !ok || engine["playerCount"] == nil {
+		t.Errorf("Expected engine stats, got %v", stats)
+	}
+
+	if len(events["error"]) != 2 {
+		t.Fatalf("Expected 2 error events, got %v", events["error"])
+	}
+	var first, second api.LogError
+	json.Unmarshal([]byte(events["error"][0]), &first)
+	json.Unmarshal([]byte(events["error"][1]), &second)
+	if first.Message != "❌ FFmpeg exited" || second.Message != "⚠️ Chat queue full" {
+		t.Errorf("Unexpected errors: %+v, %+v", first, second)
+	}
+}
+
+// TestAPISnapshotStream tests the NDJSON snapshot stream for headless clients
+func TestAPISnapshotStream(t *testing.T) {
+	engine := NewMockEngine()
+	engine.tick = 42
+	engine.playerCount = 3
+
+	router := api.NewRouter(api.RouterConfig{
+		Engine:         engine,
+		Streamer:       NewMockStreamer(),
+		DisableLogging: true,
+	})
+
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	// readFrames reads the first n lines of a stream
+	readFrames := func(t *testing.T, query string, n int) []api.StreamFrame {
+		resp, err := http.Get(ts.URL + "/api/snapshots/stream" + query)
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		defer resp.Body.Close()
+		if ct := resp.Header.Get("Content-Type"); ct != "application/x-ndjson" {
+			t.Fatalf("Expected application/x-ndjson, got %q", ct)
+		}
+
+		deadline := time.AfterFunc(5*time.Second, func() { resp.Body.Close() })
+		defer deadline.Stop()
+		var frames []api.StreamFrame
+		scanner := bufio.NewScanner(resp.Body)
+		for len(frames) < n && scanner.Scan() {
+			var f api.StreamFrame
+			if err := json.Unmarshal(scanner.Bytes(), &f); err != nil {
+				t.Fatalf("Invalid NDJSON line %q: %v", scanner.Text(), err)
+			}
+			frames = append(frames, f)
+		}
+		if len(frames) != n {
+			t.Fatalf("Expected %d frames, got %+v", n, frames)
+		}
+		return frames
+	}
+
+	t.Run("current snapshot", func(t *testing.T) {
+		frames := readFrames(t, "?rate=30", 1)
+		if frames[0].Type != "snapshot" || frames[0].Tick != 42 || frames[0].PlayerCount != 3 {
+			t.Errorf("Expected the tick 42 snapshot, got %+v", frames[0])
+		}
+	})
+
+	t.Run("resume after restart", func(t *testing.T) {
+		frames := readFrames(t, "?since=9000", 2)
+		if frames[0].Type != "reset" || frames[0].Tick != 42 {
+			t.Errorf("Expected a reset to tick 42, got %+v", frames[0])
+		}
+		if frames[1].Type != "snapshot" || frames[1].Tick != 42 {
+			t.Errorf("Expected the tick 42 snapshot after the reset, got %+v", frames[1])
+		}
+	})
+
+	t.Run("bad parameters", func(t *testing.T) {
+		for _, query := range []string{"?rate=0", "?rate=fast", "?since=-1"} {
+			resp, err := http.Get(ts.URL + "/api/snapshots/stream" + query)
+			if err != nil {
+				t.Fatalf("Request failed: %v", err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != http.StatusBadRequest {
+				t.Errorf("%s: expected 400, got %d", query, resp.StatusCode)
+			}
+		}
+	})
+}
+
+// ============================================================================
+// Benchmarks
+// ============================================================================
+
+// BenchmarkAPIGetState benchmarks the state endpoint
+func BenchmarkAPIGetState(b *testing.B) {
+	mockEngine := NewMockEngine()
+	mockStreamer := NewMockStreamer()
+
+	// Add some players
+	for i := 0; i < 50; i++ {
+		mockEngine.AddPlayer("Player"+string(rune('A'+i)), game.PlayerOptions{})
+	}
+
+	router := api.NewRouter(api.RouterConfig{
+		Engine:         mockEngine,
+		Streamer:       mockStreamer,
+		DisableLogging: true,
+	})
+
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		resp, err := http.Get(ts.URL + "/api/state")
+		if err != nil {
+			b.Fatalf("Request failed: %v", err)
+		}
+		resp.Body.Close()
+	}
+}
+
+// TestAPIBans tests the ban list admin endpoints
+func TestAPIBans(t *testing.T) {
+	bans, _ := chat.NewBanList(nil)
+	bans.Observe("troll", 42)
+
+	router := api.NewRouter(api.RouterConfig{
+		Engine:         NewMockEngine(),
+		Streamer:       NewMockStreamer(),
+		Bans:           bans,
+		DisableLogging: true,
+	})
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	for body, want := range map[string]int{
+		`{"username": "stranger"}`:                                 http.StatusNotFound,
+		`{"reason": "no target"}`:                                  http.StatusBadRequest,
+		`{"username": "@troll", "reason": "spam"}`:                 http.StatusOK,
+		`{"userId": 7, "durationSeconds": 3600, "reason": "rude"}`: http.StatusOK,
+	} {
+		resp, err := http.Post(ts.URL+"/api/admin/ban", "application/json", bytes.NewBufferString(body))
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != want {
+			t.Errorf("POST %s: expected %d, got %d", body, want, resp.StatusCode)
+		}
+	}
+
+	resp, err := http.Get(ts.URL + "/api/admin/bans")
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	var list struct {
+		Bans []chat.Ban `json:"bans"`
+	}
+	json.NewDecoder(resp.Body).Decode(&list)
+	resp.Body.Close()
+	if len(list.Bans) != 2 || !bans.IsBanned(42, "troll") || !bans.IsBanned(7, "") {
+		t.Fatalf("Expected 2 bans, got %+v", list.Bans)
+	}
+
+	req, _ := http.NewRequest("DELETE", ts.URL+"/api/admin/ban/troll", nil)
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || bans.IsBanned(42, "troll") {
+		t.Errorf("Expected troll unbanned, got %d", resp.StatusCode)
+	}
+	req, _ = http.NewRequest("DELETE", ts.URL+"/api/admin/ban/42", nil)
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected 404 for a user who isn't banned, got %d", resp.StatusCode)
 	}
 }
@@ -0,0 +1,158 @@
+package tests
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"fight-club/internal/chat"
+	"fight-club/internal/game"
+)
+
+// TestBanListPersists verifies bans survive a restart and temp-bans expire
+func TestBanListPersists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bans.json")
+	bans, err := chat.NewBanList(chat.NewFileBanStore(path))
+	if err != nil {
+		t.Fatalf("NewBanList failed: %v", err)
+	}
+
+	if _, err := bans.Ban(0, "stranger", "", "admin", 0); !errors.Is(err, chat.ErrUnknownUser) {
+		t.Errorf("Expected ErrUnknownUser for a name never seen in chat, got %v", err)
+	}
+	bans.Observe("troll", 42)
+	if _, err := bans.Ban(0, "Troll", "spam", "admin", 0); err != nil {
+		t.Fatalf("Ban failed: %v", err)
+	}
+	if _, err := bans.Ban(7, "brief", "", "admin", 20*time.Millisecond); err != nil {
+		t.Fatalf("Ban failed: %v", err)
+	}
+
+	reloaded, err := chat.NewBanList(chat.NewFileBanStore(path))
+	if err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	if !reloaded.IsBanned(42, "renamed") || !reloaded.IsBanned(0, "troll") || !reloaded.IsBanned(7, "brief") {
+		t.Fatal("Expected bans to persist across restarts, by ID and by name")
+	}
+	if list := reloaded.List(); len(list) != 2 || list[1].Reason != "spam" || !list[1].Permanent() {
+		t.Errorf("Unexpected ban list %+v", list)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	if reloaded.IsBanned(7, "brief") || len(reloaded.List()) != 1 {
+		t.Error("Expected the temp-ban to expire")
+	}
+	if ok, err := reloaded.Unban(0, "troll"); !ok || err != nil {
+		t.Errorf("Unban failed: %v, %v", ok, err)
+	}
+	if again, _ := chat.NewBanList(chat.NewFileBanStore(path)); len(again.List()) != 0 {
+		t.Errorf("Expected no bans left on disk, got %+v", again.List())
+	}
+}
+
+// TestSQLBanStore verifies bans round-trip through the SQLite store
+func TestSQLBanStore(t *testing.T) {
+	dsn := filepath.Join(t.TempDir(), "bans.db")
+	store, err := chat.NewBanStore(chat.BanConfig{Backend: "sql", DBDriver: "sqlite", DBDSN: dsn})
+	if err != nil {
+		t.Fatalf("NewBanStore failed: %v", err)
+	}
+	bans, err := chat.NewBanList(store)
+	if err != nil {
+		t.Fatalf("NewBanList failed: %v", err)
+	}
+	if _, err := bans.Ban(42, "troll", "spam", "admin", 0); err != nil {
+		t.Fatalf("Ban failed: %v", err)
+	}
+	if _, err := bans.Ban(7, "brief", "", "mod", time.Hour); err != nil {
+		t.Fatalf("Ban failed: %v", err)
+	}
+	if _, err := bans.Ban(42, "troll", "spam again", "admin", 0); err != nil {
+		t.Fatalf("Re-ban failed: %v", err)
+	}
+	if _, err := bans.Unban(7, ""); err != nil {
+		t.Fatalf("Unban failed: %v", err)
+	}
+	store.(*chat.SQLBanStore).Close()
+
+	reopened, err := chat.OpenSQLBanStore("sqlite", dsn)
+	if err != nil {
+		t.Fatalf("OpenSQLBanStore failed: %v", err)
+	}
+	defer reopened.Close()
+	stored, err := reopened.Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(stored) != 1 {
+		t.Fatalf("Expected 1 stored ban, got %+v", stored)
+	}
+	if b := stored[0]; b.UserID != 42 || b.Username != "troll" || b.Reason != "spam again" || !b.Permanent() {
+		t.Errorf("Unexpected stored ban %+v", b)
+	}
+
+	if err := reopened.Put(chat.Ban{UserID: 9, Username: "temp", BannedBy: "admin", CreatedAt: time.Now(), ExpiresAt: time.Now().Add(time.Hour)}); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	if stored, _ := reopened.Load(); len(stored) != 2 || stored[0].Permanent() == stored[1].Permanent() {
+		t.Errorf("Expected a permanent and a temp ban, got %+v", stored)
+	}
+}
+
+// TestHandlerIgnoresBanned verifies !ban removes the player and blocks their commands
+func TestHandlerIgnoresBanned(t *testing.T) {
+	engine := game.NewEngine(game.DefaultEngineConfig())
+	handler := chat.NewHandler(engine)
+	bans, _ := chat.NewBanList(nil)
+	bans.OnBan(func(b chat.Ban) { engine.RemovePlayer(b.Username) })
+	handler.SetBans(bans)
+	handler.SetRateLimits(chat.RateLimitConfig{MaxPerWindow: 100, WindowDuration: time.Minute})
+
+	handler.ProcessCommand(chat.ChatCommand{Username: "griefer", UserID: 99, Command: "join"})
+	if engine.GetPlayer("griefer") == nil {
+		t.Fatal("Expected griefer to join")
+	}
+
+	// Viewers can't ban; the broadcaster can, with a duration and reason
+	handler.ProcessCommand(chat.ChatCommand{Username: "griefer", UserID: 99, Command: "ban", Args: []string{"@griefer"}})
+	if bans.IsBanned(99, "griefer") {
+		t.Fatal("Expected !ban to be broadcaster only")
+	}
+	handler.ProcessCommand(chat.ChatCommand{Username: "host", UserID: 1, IsBroadcaster: true,
+		Command: "ban", Args: []string{"@griefer", "2h", "team", "killing"}})
+	list := bans.List()
+	if len(list) != 1 || list[0].UserID != 99 || list[0].Reason != "team killing" || list[0].BannedBy != "host" ||
+		time.Until(list[0].ExpiresAt) < time.Hour {
+		t.Fatalf("Unexpected bans %+v", list)
+	}
+	if engine.GetPlayer("griefer") != nil {
+		t.Error("Expected the banned player removed from the arena")
+	}
+
+	handler.ProcessCommand(chat.ChatCommand{Username: "griefer", UserID: 99, Command: "join"})
+	if engine.GetPlayer("griefer") != nil {
+		t.Error("Expected a banned user's !join to be ignored")
+	}
+
+	handler.ProcessCommand(chat.ChatCommand{Username: "host", UserID: 1, IsBroadcaster: true, Command: "unban", Args: []string{"griefer"}})
+	handler.ProcessCommand(chat.ChatCommand{Username: "griefer", UserID: 99, Command: "join"})
+	if engine.GetPlayer("griefer") == nil {
+		t.Error("Expected !unban to let griefer join again")
+	}
+}
+
+// TestParseBanDuration verifies !ban durations
+func TestParseBanDuration(t *testing.T) {
+	for in, want := range map[string]time.Duration{"30m": 30 * time.Minute, "2h": 2 * time.Hour, "7d": 7 * 24 * time.Hour} {
+		if got, err := chat.ParseBanDuration(in); err != nil || got != want {
+			t.Errorf("ParseBanDuration(%q) = %v, %v", in, got, err)
+		}
+	}
+	for _, in := range []string{"spam", "0d", "-1h", "d"} {
+		if _, err := chat.ParseBanDuration(in); err == nil {
+			t.Errorf("Expected %q to be rejected", in)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"fight-club/internal/chat"
+)
+
+// TestDemoTraffic verifies synthetic viewers join first, replay with a seed
+// and only send supported commands
+func TestDemoTraffic(t *testing.T) {
+	cfg := chat.DemoConfig{Viewers: 15, Interval: 0.5, Seed: 42}
+	a := chat.NewDemoTraffic(cfg, nil)
+	b := chat.NewDemoTraffic(cfg, nil)
+
+	joined := make(map[string]bool)
+	for i := 0; i < 500; i++ {
+		cmd := a.Next()
+		if other := b.Next(); other.Username != cmd.Username || other.Command != cmd.Command ||
+			strings.Join(other.Args, " ") != strings.Join(cmd.Args, " ") {
+			t.Fatalf("Command %d differs with the same seed: %+v vs %+v", i, cmd, other)
+		}
+
+		if i < cfg.Viewers {
+			if cmd.Command != "join" || joined[cmd.Username] {
+				t.Fatalf("Expected viewer %d to join first, got %+v", i, cmd)
+			}
+			joined[cmd.Username] = true
+			continue
+		}
+		if !joined[cmd.Username] {
+			t.Fatalf("Command from a viewer who never joined: %+v", cmd)
+		}
+		if chat.GetCommandType(cmd.Command) == chat.CmdUnknown {
+			t.Fatalf("Unsupported demo command: %+v", cmd)
+		}
+		if len(cmd.Args) > 0 && cmd.Args[0] == cmd.Username {
+			t.Fatalf("Viewer targets themselves: %+v", cmd)
+		}
+	}
+	if len(joined) != cfg.Viewers {
+		t.Errorf("Expected %d viewers, got %d", cfg.Viewers, len(joined))
+	}
+}
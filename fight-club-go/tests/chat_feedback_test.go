@@ -0,0 +1,202 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"fight-club/internal/chat"
+	"fight-club/internal/game"
+)
+
+// recordingReplier collects chat feedback
+type recordingReplier struct {
+	replies []string
+}
+
+func (r *recordingReplier) Reply(username, message string) {
+	r.replies = append(r.replies, username+": "+message)
+}
+
+// TestHandlerRepliesOnFailure verifies failed commands tell the viewer why
+func TestHandlerRepliesOnFailure(t *testing.T) {
+	engine := game.NewEngine(game.DefaultEngineConfig())
+	handler := chat.NewHandler(engine)
+	replier := &recordingReplier{}
+	handler.SetReplier(replier)
+
+	handler.ProcessCommand(chat.ChatCommand{Username: "ana", Command: "heal"})
+	handler.ProcessCommand(chat.ChatCommand{Username: "ana", Command: "join"}) // Within the 500ms cooldown
+	want := []string{
+		"ana: type !join to enter the arena first",
+		"ana: slow down, you're sending commands too fast",
+	}
+	if len(replier.replies) != len(want) || replier.replies[0] != want[0] || replier.replies[1] != want[1] {
+		t.Fatalf("Expected %v, got %v", want, replier.replies)
+	}
+
+	handler.ProcessCommand(chat.ChatCommand{Username: "leo", Command: "join"})
+	if len(replier.replies) != 2 {
+		t.Fatalf("Expected no reply for a successful join, got %v", replier.replies)
+	}
+
+	engine.AddPlayer("mia", game.PlayerOptions{})
+	handler.ProcessCommand(chat.ChatCommand{Username: "mia", Command: "buy", Args: []string{"scythe"}})
+	if len(replier.replies) != 3 || !strings.HasPrefix(replier.replies[2], "mia: you need $") ||
+		!strings.HasSuffix(replier.replies[2], "more for scythe") {
+		t.Errorf("Expected a missing money reply, got %v", replier.replies)
+	}
+}
+
+// TestHandlerGift verifies !gift moves money and explains rejected gifts
+func TestHandlerGift(t *testing.T) {
+	engine := game.NewEngine(game.DefaultEngineConfig())
+	handler := chat.NewHandler(engine)
+	replier := &recordingReplier{}
+	handler.SetReplier(replier)
+
+	ana := engine.AddPlayer("ana", game.PlayerOptions{})
+	leo := engine.AddPlayer("leo", game.PlayerOptions{})
+	ana.Money, leo.Money = 100, 0
+
+	handler.ProcessCommand(chat.ChatCommand{Username: "ana", Command: "regalar", Args: []string{"@leo", "$60"}})
+	if ana.Money != 40 || leo.Money != 60 {
+		t.Fatalf("Expected $40/$60 after the gift, got $%d/$%d", ana.Money, leo.Money)
+	}
+
+	handler.ProcessCommand(chat.ChatCommand{Username: "leo", Command: "gift", Args: []string{"ana", "lots"}})
+	handler.ProcessCommand(chat.ChatCommand{Username: "mia", Command: "gift", Args: []string{"ana", "50"}})
+	want := []string{
+		"leo: usage: !gift <player> <amount>",
+		"mia: can't gift ana: not in arena (type !join first)",
+	}
+	if len(replier.replies) != len(want) || replier.replies[0] != want[0] || replier.replies[1] != want[1] {
+		t.Errorf("Expected %v, got %v", want, replier.replies)
+	}
+}
+
+// TestHandlerModCommands verifies !kick, !freeze and !strip need a moderator or broadcaster badge
+func TestHandlerModCommands(t *testing.T) {
+	engine := game.NewEngine(game.DefaultEngineConfig())
+	handler := chat.NewHandler(engine)
+	replier := &recordingReplier{}
+	handler.SetReplier(replier)
+
+	griefer := engine.AddPlayer("griefer", game.PlayerOptions{})
+	griefer.Weapon = "scythe"
+
+	handler.ProcessCommand(chat.ChatCommand{Username: "viewer", Command: "strip", Args: []string{"griefer"}})
+	if griefer.Weapon != "scythe" {
+		t.Fatal("A viewer without a badge shouldn't be able to !strip")
+	}
+
+	handler.ProcessCommand(chat.ChatCommand{Username: "mod1", Command: "desarmar", Args: []string{"@griefer"}, IsModerator: true})
+	handler.ProcessCommand(chat.ChatCommand{Username: "mod2", Command: "freeze", Args: []string{"griefer"}, IsModerator: true})
+	if griefer.Weapon != "fists" || griefer.FreezeTimer != game.FreezeDuration {
+		t.Errorf("Expected the griefer disarmed and frozen, got %s / %.1fs", griefer.Weapon, griefer.FreezeTimer)
+	}
+
+	handler.ProcessCommand(chat.ChatCommand{Username: "owner", Command: "kick", Args: []string{"griefer"}, IsBroadcaster: true})
+	if engine.GetPlayer("griefer") != nil {
+		t.Fatal("Expected the broadcaster to kick the griefer")
+	}
+	if engine.AddPlayer("griefer", game.PlayerOptions{}) != nil {
+		t.Error("Expected a kicked viewer to be barred from rejoining")
+	}
+
+	handler.ProcessCommand(chat.ChatCommand{Username: "mod3", Command: "kick", Args: []string{"griefer"}, IsModerator: true})
+	if want := "mod3: can't kick griefer: 'griefer' is not in the arena"; len(replier.replies) != 1 || replier.replies[0] != want {
+		t.Errorf("Expected %q, got %v", want, replier.replies)
+	}
+}
+
+// TestHandlerCareerCommands verifies !record and !last reply with career stats
+func TestHandlerCareerCommands(t *testing.T) {
+	engine := game.NewEngine(game.DefaultEngineConfig())
+	handler := chat.NewHandler(engine)
+	replier := &recordingReplier{}
+	handler.SetReplier(replier)
+
+	careers := engine.GetCareerStore()
+	careers.RecordKill("ana", 2)
+	careers.RecordKill("ana", 3)
+	careers.RecordDeath("ana", game.LifeSummary{Seconds: 95, Damage: 180, Kills: 3, Weapon: "axe", KilledBy: "leo"})
+	careers.RecordDeath("leo", game.LifeSummary{Seconds: 12, Weapon: "fists"})
+
+	handler.ProcessCommand(chat.ChatCommand{Username: "mia", Command: "record"})
+	handler.ProcessCommand(chat.ChatCommand{Username: "ana", Command: "historial"})
+	handler.ProcessCommand(chat.ChatCommand{Username: "leo", Command: "last"})
+	want := []string{
+		"mia: no record yet, type !join and get fighting",
+		"ana: career 2 kills / 1 deaths (K/D 2.00) | best streak 3",
+		"leo: last life: survived 12s | 0 damage | 0 kills with fists | killed by the arena",
+	}
+	if len(replier.replies) != len(want) {
+		t.Fatalf("Expected %v, got %v", want, replier.replies)
+	}
+	for i := range want {
+		if replier.replies[i] != want[i] {
+			t.Errorf("Expected %q, got %q", want[i], replier.replies[i])
+		}
+	}
+}
+
+// TestHandlerAirstrike verifies !airstrike takes coordinates or a player and reports the price
+func TestHandlerAirstrike(t *testing.T) {
+	engine := game.NewEngine(game.DefaultEngineConfig())
+	handler := chat.NewHandler(engine)
+	replier := &recordingReplier{}
+	handler.SetReplier(replier)
+
+	engine.AddPlayer("ana", game.PlayerOptions{})
+	engine.AddPlayer("leo", game.PlayerOptions{})
+	engine.GetWalletManager().Credit("ana", 10000)
+
+	handler.ProcessCommand(chat.ChatCommand{Username: "ana", Command: "airstrike", Args: []string{"300,", "200"}})
+	handler.ProcessCommand(chat.ChatCommand{Username: "leo", Command: "airstrike", Args: []string{"@ana"}})
+	want := []string{
+		"ana: airstrike incoming for $2000 (next one costs $3000)",
+		"leo: can't call an airstrike: an airstrike costs $3000, you have $0",
+	}
+	if len(replier.replies) != len(want) || replier.replies[0] != want[0] || replier.replies[1] != want[1] {
+		t.Errorf("Expected %v, got %v", want, replier.replies)
+	}
+	if got := engine.GetWalletManager().Balance("ana"); got != 8000 {
+		t.Errorf("Expected $8000 left in the wallet, got $%d", got)
+	}
+}
+
+// TestHandlerJoinQueue verifies !join in a full arena replies with the place in line
+func TestHandlerJoinQueue(t *testing.T) {
+	cfg := game.DefaultEngineConfig()
+	cfg.Limits.MaxTotalPlayers = 1
+	engine := game.NewEngine(cfg)
+	handler := chat.NewHandler(engine)
+	replier := &recordingReplier{}
+	handler.SetReplier(replier)
+
+	handler.ProcessCommand(chat.ChatCommand{Username: "ana", Command: "join"})
+	handler.ProcessCommand(chat.ChatCommand{Username: "leo", Command: "join"})
+	want := "leo: the arena is full, you're #1 in line"
+	if len(replier.replies) != 1 || replier.replies[0] != want {
+		t.Errorf("Expected %q, got %v", want, replier.replies)
+	}
+}
+
+// TestHandlerBuyAtShop verifies !buy sends the fighter to a shop instead of equipping instantly
+func TestHandlerBuyAtShop(t *testing.T) {
+	engine := game.NewEngine(game.DefaultEngineConfig())
+	handler := chat.NewHandler(engine)
+	replier := &recordingReplier{}
+	handler.SetReplier(replier)
+
+	ana := engine.AddPlayer("ana", game.PlayerOptions{})
+	ana.Money = 1000
+	handler.ProcessCommand(chat.ChatCommand{Username: "ana", Command: "buy", Args: []string{"sword"}})
+	if len(replier.replies) != 1 || !strings.HasPrefix(replier.replies[0], "ana: head to the ") ||
+		!strings.HasSuffix(replier.replies[0], "to pick up your sword") {
+		t.Errorf("Expected directions to the shop, got %v", replier.replies)
+	}
+	if ana.Weapon == "sword" || ana.Money != 1000 || ana.ShopOrder != "sword" {
+		t.Errorf("Expected the sword ordered but not bought yet, got %s with $%d", ana.Weapon, ana.Money)
+	}
+}
@@ -0,0 +1,87 @@
+package tests
+
+import (
+	"testing"
+
+	"fight-club/internal/chat"
+	"fight-club/internal/config"
+)
+
+// TestCommandLocales verifies built-in translations follow the enabled
+// locales, accents are optional and config extras map to built-in commands
+func TestCommandLocales(t *testing.T) {
+	defer chat.SetCommandLocales(config.DefaultCommandLocale())
+
+	// Defaults: English, Spanish and Portuguese
+	for word, want := range map[string]chat.CommandType{
+		"join":      chat.CmdJoin,
+		"unirse":    chat.CmdJoin,
+		"curar":     chat.CmdHeal,
+		"comprar":   chat.CmdBuy,
+		"loja":      chat.CmdShop,
+		"missões":   chat.CmdQuests,
+		"missoes":   chat.CmdQuests,
+		"maldición": chat.CmdCurse,
+	} {
+		if got := chat.GetCommandType(word); got != want {
+			t.Errorf("GetCommandType(%q) = %s, want %s", word, got, want)
+		}
+	}
+	if id, ok := chat.GetWeaponID("machado"); !ok || id != "axe" {
+		t.Errorf("Expected !machado to buy an axe, got %q", id)
+	}
+
+	// Spanish only, with extras
+	err := chat.SetCommandLocales(config.CommandLocaleConfig{
+		Locales: []string{"es"},
+		Extras:  map[string]string{"pelear": "join", "sanar": "heal"},
+	})
+	if err != nil {
+		t.Fatalf("SetCommandLocales failed: %v", err)
+	}
+	for word, want := range map[string]chat.CommandType{
+		"unirse": chat.CmdJoin,
+		"pelear": chat.CmdJoin,
+		"sanar":  chat.CmdHeal,
+		"loja":   chat.CmdUnknown,
+		"heal":   chat.CmdHeal,
+	} {
+		if got := chat.GetCommandType(word); got != want {
+			t.Errorf("es only: GetCommandType(%q) = %s, want %s", word, got, want)
+		}
+	}
+
+	// Invalid settings keep the current table
+	bad := []config.CommandLocaleConfig{
+		{Locales: []string{"xx"}},
+		{Extras: map[string]string{"pelear": "fly"}},
+		{Extras: map[string]string{"heal": "join"}},
+	}
+	for _, cfg := range bad {
+		if err := chat.SetCommandLocales(cfg); err == nil {
+			t.Errorf("Expected %+v to be rejected", cfg)
+		}
+	}
+	if chat.GetCommandType("pelear") != chat.CmdJoin {
+		t.Error("Expected a rejected config to keep the previous table")
+	}
+
+	// English only
+	if err := chat.SetCommandLocales(config.CommandLocaleConfig{Locales: config.ParseCommandLocales("none")}); err != nil {
+		t.Fatalf("SetCommandLocales failed: %v", err)
+	}
+	if chat.GetCommandType("unirse") != chat.CmdUnknown || chat.GetCommandType("join") != chat.CmdJoin {
+		t.Error("Expected English only")
+	}
+}
+
+// TestParseCommandExtras verifies the CHAT_COMMAND_ALIASES format
+func TestParseCommandExtras(t *testing.T) {
+	extras, err := config.ParseCommandExtras("pelear=join, !Sanar = !heal,")
+	if err != nil || len(extras) != 2 || extras["pelear"] != "join" || extras["sanar"] != "heal" {
+		t.Errorf("Unexpected extras %v (%v)", extras, err)
+	}
+	if _, err := config.ParseCommandExtras("pelear"); err == nil {
+		t.Error("Expected an entry without = to be rejected")
+	}
+}
@@ -0,0 +1,78 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"fight-club/internal/chat"
+	"fight-club/internal/config"
+)
+
+// TestRateLimiterCommandLimits verifies a command's own limit applies on top of
+// the per-user limit, scales for subscribers and is counted in the stats
+func TestRateLimiterCommandLimits(t *testing.T) {
+	rl := chat.NewRateLimiter(chat.RateLimitConfig{
+		MaxPerWindow:         10,
+		WindowDuration:       time.Minute,
+		Commands:             map[string]chat.CommandLimit{"join": {Max: 1, Window: time.Minute}},
+		SubscriberMultiplier: 2,
+	})
+
+	if ok, _ := rl.AllowCommand("ana", "join", chat.TierViewer); !ok {
+		t.Fatal("Expected the first !join allowed")
+	}
+	ok, limit := rl.AllowCommand("ana", "join", chat.TierViewer)
+	if ok || limit.Max != 1 || limit.Window != time.Minute {
+		t.Fatalf("Expected the second !join stopped by its 1/1m limit, got ok=%t limit=%+v", ok, limit)
+	}
+	if ok, _ := rl.AllowCommand("ana", "heal", chat.TierViewer); !ok {
+		t.Error("Expected other commands unaffected by the !join limit")
+	}
+
+	for i := 0; i < 2; i++ {
+		if ok, _ := rl.AllowCommand("leo", "join", chat.TierSubscriber); !ok {
+			t.Fatalf("Expected subscriber !join %d allowed", i+1)
+		}
+	}
+	if ok, limit := rl.AllowCommand("leo", "join", chat.TierSubscriber); ok || limit.Max != 2 {
+		t.Errorf("Expected subscribers limited to 2 joins, got ok=%t limit=%+v", ok, limit)
+	}
+	for i := 0; i < 20; i++ {
+		if ok, _ := rl.AllowCommand("mia", "join", chat.TierBroadcaster); !ok {
+			t.Fatal("Expected the broadcaster never limited")
+		}
+	}
+
+	stats := rl.Stats()
+	if stats.LimitedByCommand["join"] != 2 || stats.LimitedByTier["viewer"] != 1 ||
+		stats.LimitedByTier["subscriber"] != 1 || stats.Limited != 2 || stats.CommandLimits["join"] != "1/60s" {
+		t.Errorf("Unexpected stats %+v", stats)
+	}
+}
+
+// TestParseCommandLimits verifies the command limit list format
+func TestParseCommandLimits(t *testing.T) {
+	limits, err := config.ParseCommandLimits("join=1/30s, !Emote = 2/5, gift=1/2m")
+	if err != nil {
+		t.Fatalf("ParseCommandLimits failed: %v", err)
+	}
+	want := map[string]config.CommandRateLimit{
+		"join":  {Max: 1, Window: 30},
+		"emote": {Max: 2, Window: 5},
+		"gift":  {Max: 1, Window: 120},
+	}
+	if len(limits) != len(want) {
+		t.Fatalf("Expected %v, got %v", want, limits)
+	}
+	for name, l := range want {
+		if limits[name] != l {
+			t.Errorf("Expected %s=%v, got %v", name, l, limits[name])
+		}
+	}
+
+	for _, bad := range []string{"join", "join=0/30s", "join=1/soon", "join=1/0"} {
+		if _, err := config.ParseCommandLimits(bad); err == nil {
+			t.Errorf("Expected %q rejected", bad)
+		}
+	}
+}
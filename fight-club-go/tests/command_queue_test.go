@@ -0,0 +1,91 @@
+package tests
+
+import (
+	"testing"
+
+	"fight-club/internal/chat"
+)
+
+// drainQueue pops every pending command as "user:command"
+func drainQueue(q *chat.CommandQueue) []string {
+	var order []string
+	for {
+		cmd, ok := q.Next()
+		if !ok {
+			return order
+		}
+		order = append(order, cmd.Username+":"+cmd.Command)
+	}
+}
+
+// TestCommandQueuePriorityFairness verifies !join goes first and viewers are drained round-robin
+func TestCommandQueuePriorityFairness(t *testing.T) {
+	q := chat.NewCommandQueue(nil, chat.QueueConfig{BufferSize: 32, PerUserLimit: 8})
+
+	for _, c := range []chat.ChatCommand{
+		{Username: "spammer", Command: "emote"},
+		{Username: "spammer", Command: "heal"},
+		{Username: "spammer", Command: "heal"},
+		{Username: "alice", Command: "heal"},
+		{Username: "bob", Command: "join"},
+		{Username: "spammer", Command: "sword"},
+		{Username: "alice", Command: "dance"}, // unknown: cosmetic
+	} {
+		if !q.Enqueue(c) {
+			t.Fatalf("Unexpected drop of %+v", c)
+		}
+	}
+
+	want := []string{
+		"bob:join",
+		"spammer:heal", "alice:heal", "spammer:heal", "spammer:sword",
+		"spammer:emote", "alice:dance",
+	}
+	got := drainQueue(q)
+	if len(got) != len(want) {
+		t.Fatalf("Expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Expected %v, got %v", want, got)
+		}
+	}
+	if stats := q.Stats(); stats.Pending != 0 || stats.Users != 0 {
+		t.Errorf("Expected empty queue, got %+v", stats)
+	}
+}
+
+// TestCommandQueueDrops verifies the per-user limit and priority eviction when full
+func TestCommandQueueDrops(t *testing.T) {
+	q := chat.NewCommandQueue(nil, chat.QueueConfig{BufferSize: 4, PerUserLimit: 3})
+
+	for i := 0; i < 4; i++ {
+		q.Enqueue(chat.ChatCommand{Username: "spammer", Command: "emote"})
+	}
+	q.Enqueue(chat.ChatCommand{Username: "alice", Command: "taunt"})
+
+	// Full: a cosmetic command can't displace another, a join can
+	if q.Enqueue(chat.ChatCommand{Username: "carol", Command: "stats"}) {
+		t.Error("Expected cosmetic command dropped when full")
+	}
+	if !q.Enqueue(chat.ChatCommand{Username: "bob", Command: "join"}) {
+		t.Fatal("Expected !join to evict a cosmetic command")
+	}
+
+	stats := q.Stats()
+	if stats.Pending != 4 || stats.PendingByPriority["join"] != 1 || stats.PendingByPriority["cosmetic"] != 3 {
+		t.Errorf("Unexpected depth: %+v", stats)
+	}
+	if stats.Dropped != 3 || stats.Evicted != 1 {
+		t.Errorf("Expected 3 drops (1 user limit, 1 full, 1 evicted), got %d (%d evicted)", stats.Dropped, stats.Evicted)
+	}
+	if stats.DroppedByCommand["emote"] != 2 || stats.DroppedByCommand["stats"] != 1 {
+		t.Errorf("Unexpected drops by command: %v", stats.DroppedByCommand)
+	}
+
+	// The eviction hits the viewer with the most pending commands
+	got := drainQueue(q)
+	if got[0] != "bob:join" || len(got) != 4 || got[2] != "alice:taunt" {
+		t.Errorf("Expected join then the remaining emotes and taunt, got %v", got)
+	}
+}
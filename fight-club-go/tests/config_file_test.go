@@ -0,0 +1,118 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"fight-club/internal/config"
+	"fight-club/internal/game"
+)
+
+// writeConfigFile writes a fight-club.yaml into a temp dir and returns its path
+func writeConfigFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "fight-club.yaml")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// TestConfigFileExampleIsValid verifies the shipped example file passes validation
+func TestConfigFileExampleIsValid(t *testing.T) {
+	if _, err := config.LoadYAML("../fight-club.example.yaml"); err != nil {
+		t.Fatalf("Example config is invalid: %v", err)
+	}
+}
+
+// TestConfigFileValidation verifies unknown keys and bad values are reported with their YAML path
+func TestConfigFileValidation(t *testing.T) {
+	_, err := config.LoadYAML(writeConfigFile(t, "video:\n  fsp: 30\n"))
+	if err == nil || !strings.Contains(err.Error(), "field fsp not found") {
+		t.Errorf("Expected unknown key error, got %v", err)
+	}
+
+	_, err = config.LoadYAML(writeConfigFile(t, `
+video:
+  fps: 0
+chat:
+  signature_mode: strict
+weapons:
+  sword:
+    min_damage: 50
+    max_damage: 10
+`))
+	if err == nil {
+		t.Fatal("Expected validation error")
+	}
+	for _, want := range []string{
+		"video.fps: must be between 1 and 120 (got 0)",
+		`chat.signature_mode: must be one of off, log, enforce (got "strict")`,
+		"weapons.sword: min_damage (50) is greater than max_damage (10)",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected %q in error:\n%v", want, err)
+		}
+	}
+}
+
+// TestConfigFileEnvOverrides verifies the file fills in unset variables and never overrides the environment
+func TestConfigFileEnvOverrides(t *testing.T) {
+	path := writeConfigFile(t, `
+video:
+  width: 1920
+  fps: 60
+limits:
+  max_players: 50
+streaming:
+  music_enabled: false
+`)
+	t.Setenv("FIGHT_CLUB_CONFIG", path)
+	t.Setenv("STREAM_FPS", "30")
+	for _, key := range []string{"STREAM_WIDTH", "LIMIT_MAX_PLAYERS", "MUSIC_ENABLED"} {
+		t.Setenv(key, "")
+		os.Unsetenv(key)
+	}
+
+	if _, err := config.LoadFile(); err != nil {
+		t.Fatalf("LoadFile failed: %v", err)
+	}
+
+	cfg := config.Load()
+	if cfg.Video.Width != 1920 {
+		t.Errorf("Expected width 1920 from file, got %d", cfg.Video.Width)
+	}
+	if cfg.Video.FPS != 30 {
+		t.Errorf("Expected STREAM_FPS=30 to override file, got %d", cfg.Video.FPS)
+	}
+	if cfg.Limits.MaxPlayers != 50 {
+		t.Errorf("Expected max players 50 from file, got %d", cfg.Limits.MaxPlayers)
+	}
+	if cfg.Audio.Enabled {
+		t.Error("Expected music disabled from file")
+	}
+}
+
+// TestApplyWeaponOverrides verifies weapon stats can be tuned and unknown weapons are rejected
+func TestApplyWeaponOverrides(t *testing.T) {
+	original := game.Weapons["knife"]
+	defer func() { game.Weapons["knife"] = original }()
+
+	price, maxDamage := 75, 30
+	err := game.ApplyWeaponOverrides(map[string]config.WeaponOverride{
+		"knife": {Price: &price, MaxDamage: &maxDamage},
+	})
+	if err != nil {
+		t.Fatalf("ApplyWeaponOverrides failed: %v", err)
+	}
+	if w := game.GetWeapon("knife"); w.Price != 75 || w.MaxDamage != 30 || w.MinDamage != original.MinDamage {
+		t.Errorf("Expected knife price 75 and max damage 30, got %+v", w)
+	}
+
+	err = game.ApplyWeaponOverrides(map[string]config.WeaponOverride{"lightsaber": {Price: &price}})
+	if err == nil || !strings.Contains(err.Error(), "unknown weapon") || !strings.Contains(err.Error(), "katana") {
+		t.Errorf("Expected unknown weapon error listing valid weapons, got %v", err)
+	}
+}
@@ -0,0 +1,177 @@
+package tests
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"fight-club/internal/api"
+	"fight-club/internal/config"
+	"fight-club/internal/game"
+	"fight-club/internal/grpcapi"
+	"fight-club/internal/grpcapi/adminpb"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newGRPCTestClient serves the gRPC API over an in-memory connection
+func newGRPCTestClient(t *testing.T, engine grpcapi.EngineInterface, streamer api.StreamerInterface, keys *api.APIKeyStore) *grpc.ClientConn {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	server := grpcapi.NewServer(grpcapi.Config{Engine: engine, Streamer: streamer, APIKeys: keys})
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("Failed to dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// TestGRPCAuth verifies calls need a valid API key and keep its rate limit
+func TestGRPCAuth(t *testing.T) {
+	keys, _ := api.NewAPIKeyStore("")
+	key, _, _ := keys.Create("desktop", 60, 2)
+	engine := game.NewEngine(game.EngineConfig{TickRate: 30, WorldWidth: 1280, WorldHeight: 720, Limits: config.DefaultLimits()})
+	client := adminpb.NewEngineServiceClient(newGRPCTestClient(t, engine, NewMockStreamer(), keys))
+
+	if _, err := client.GetStatus(context.Background(), &adminpb.GetStatusRequest{}); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("Expected Unauthenticated without a key, got %v", err)
+	}
+	bad := metadata.AppendToOutgoingContext(context.Background(), "x-api-key", "fc_nope")
+	if _, err := client.GetStatus(bad, &adminpb.GetStatusRequest{}); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("Expected Unauthenticated with an unknown key, got %v", err)
+	}
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+key)
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetStatus(ctx, &adminpb.GetStatusRequest{}); err != nil {
+			t.Fatalf("Call %d within the burst failed: %v", i+1, err)
+		}
+	}
+	if _, err := client.GetStatus(ctx, &adminpb.GetStatusRequest{}); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("Expected ResourceExhausted past the key's burst, got %v", err)
+	}
+}
+
+// TestGRPCControl verifies engine, player and stream control
+func TestGRPCControl(t *testing.T) {
+	keys, _ := api.NewAPIKeyStore("")
+	key, _, _ := keys.Create("desktop", 6000, 100, api.APIKeyScopeAdmin)
+	engine := game.NewEngine(game.EngineConfig{TickRate: 30, WorldWidth: 1280, WorldHeight: 720, Limits: config.DefaultLimits()})
+	streamer := NewMockStreamer()
+	conn := newGRPCTestClient(t, engine, streamer, keys)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-api-key", key)
+
+	engines := adminpb.NewEngineServiceClient(conn)
+	st, err := engines.Pause(ctx, &adminpb.PauseRequest{})
+	if err != nil || !st.Paused || !st.Changed {
+		t.Fatalf("Expected the engine paused, got %v (%v)", st, err)
+	}
+	if st.Arena.GetName() != "standard" || st.TickStats.GetTickRate() != 30 {
+		t.Errorf("Expected the standard arena at 30 TPS, got %v", st)
+	}
+	if st, _ = engines.Pause(ctx, &adminpb.PauseRequest{}); st.Changed {
+		t.Error("Expected pausing twice to change nothing")
+	}
+	if _, err := engines.SetArena(ctx, &adminpb.SetArenaRequest{Preset: "huge"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for an unknown preset, got %v", err)
+	}
+
+	players := adminpb.NewPlayerServiceClient(conn)
+	p, err := players.JoinPlayer(ctx, &adminpb.JoinPlayerRequest{Username: "grpc_fan"})
+	if err != nil || p.Name != "grpc_fan" || p.Hp != p.MaxHp {
+		t.Fatalf("Expected grpc_fan to join at full HP, got %v (%v)", p, err)
+	}
+	engine.ProduceSnapshot()
+	list, err := players.ListPlayers(ctx, &adminpb.ListPlayersRequest{})
+	if err != nil || len(list.Players) != 1 || list.Players[0].Id != p.Id {
+		t.Fatalf("Expected grpc_fan in the player list, got %v (%v)", list, err)
+	}
+	if _, err := players.GetPlayer(ctx, &adminpb.GetPlayerRequest{Username: "nobody"}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound for an unknown player, got %v", err)
+	}
+	if _, err := players.ModeratePlayer(ctx, &adminpb.ModeratePlayerRequest{Username: "grpc_fan", Action: "ban"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for an unknown action, got %v", err)
+	}
+	if _, err := players.ModeratePlayer(ctx, &adminpb.ModeratePlayerRequest{Username: "grpc_fan", Action: "kick"}); err != nil {
+		t.Fatalf("Kick failed: %v", err)
+	}
+	if engine.GetPlayer("grpc_fan") != nil {
+		t.Error("Expected grpc_fan kicked from the arena")
+	}
+
+	streams := adminpb.NewStreamServiceClient(conn)
+	ss, err := streams.StartStream(ctx, &adminpb.StartStreamRequest{})
+	if err != nil || !ss.Streaming || !ss.Stats.GetFields()["streaming"].GetBoolValue() {
+		t.Fatalf("Expected the stream started with its stats, got %v (%v)", ss, err)
+	}
+	if ss, _ = streams.StopStream(ctx, &adminpb.StopStreamRequest{}); ss.Streaming {
+		t.Error("Expected the stream stopped")
+	}
+}
+
+// TestGRPCAdminScope verifies integration keys can't make admin calls
+func TestGRPCAdminScope(t *testing.T) {
+	keys, _ := api.NewAPIKeyStore("")
+	key, _, _ := keys.Create("discord-bot", 6000, 100)
+	if _, _, err := keys.Create("typo", 60, 2, "root"); err == nil {
+		t.Error("Expected an unknown scope to be rejected")
+	}
+	engine := game.NewEngine(game.EngineConfig{TickRate: 30, WorldWidth: 1280, WorldHeight: 720, Limits: config.DefaultLimits()})
+	streamer := NewMockStreamer()
+	conn := newGRPCTestClient(t, engine, streamer, keys)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-api-key", key)
+
+	engines := adminpb.NewEngineServiceClient(conn)
+	if _, err := engines.GetStatus(ctx, &adminpb.GetStatusRequest{}); err != nil {
+		t.Fatalf("Expected status readable without the admin scope, got %v", err)
+	}
+	if _, err := engines.Pause(ctx, &adminpb.PauseRequest{}); status.Code(err) != codes.PermissionDenied || engine.IsPaused() {
+		t.Errorf("Expected PermissionDenied for Pause, got %v", err)
+	}
+	players := adminpb.NewPlayerServiceClient(conn)
+	if _, err := players.JoinPlayer(ctx, &adminpb.JoinPlayerRequest{Username: "bot_fan"}); err != nil {
+		t.Errorf("Expected join allowed like on REST, got %v", err)
+	}
+	if _, err := players.ModeratePlayer(ctx, &adminpb.ModeratePlayerRequest{Username: "bot_fan", Action: "kick"}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Expected PermissionDenied for ModeratePlayer, got %v", err)
+	}
+	if _, err := adminpb.NewStreamServiceClient(conn).StopStream(ctx, &adminpb.StopStreamRequest{}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Expected PermissionDenied for StopStream, got %v", err)
+	}
+}
+
+// TestGRPCListenAddr verifies plaintext gRPC only listens on loopback
+func TestGRPCListenAddr(t *testing.T) {
+	tests := []struct {
+		addr, want string
+		tls        bool
+	}{
+		{":50051", "127.0.0.1:50051", false},
+		{"localhost:50051", "localhost:50051", false},
+		{"[::1]:50051", "[::1]:50051", false},
+		{"0.0.0.0:50051", "", false},
+		{"10.0.0.5:50051", "", false},
+		{":50051", ":50051", true},
+		{"0.0.0.0:50051", "0.0.0.0:50051", true},
+	}
+	for _, tt := range tests {
+		got, err := grpcapi.ListenAddr(tt.addr, tt.tls)
+		if tt.want == "" && err == nil {
+			t.Errorf("ListenAddr(%q, tls=%v): expected an error, got %q", tt.addr, tt.tls, got)
+		} else if tt.want != "" && got != tt.want {
+			t.Errorf("ListenAddr(%q, tls=%v) = %q (%v), want %q", tt.addr, tt.tls, got, err, tt.want)
+		}
+	}
+}